@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/ledger"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/timestamp"
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// app holds the repositories and services shared by commands
+type app struct {
+	out        *printer
+	baseURL    string
+	localesDir string
+	actor      string
+
+	documents  *database.DocumentRepository
+	signers    *database.ExpectedSignerRepository
+	signatures *database.SignatureRepository
+	reminders  *database.ReminderRepository
+	emailQueue *database.EmailQueueRepository
+	magicLinks services.MagicLinkRepository
+	configs    *database.ConfigRepository
+	ledger     *database.SignatureLedgerRepository
+
+	adminService     *services.AdminService
+	signatureService *services.SignatureService
+}
+
+func newApp(db *sql.DB, tenants providers.TenantProvider, out *printer, baseURL, localesDir, actor string) *app {
+	a := &app{
+		out:        out,
+		baseURL:    baseURL,
+		localesDir: localesDir,
+		actor:      actor,
+		documents:  database.NewDocumentRepository(db, tenants),
+		signers:    database.NewExpectedSignerRepository(db, tenants),
+		signatures: database.NewSignatureRepository(db, tenants),
+		reminders:  database.NewReminderRepository(db, tenants),
+		emailQueue: database.NewEmailQueueRepository(db, tenants),
+		magicLinks: database.NewMagicLinkRepository(db),
+		configs:    database.NewConfigRepository(db, tenants),
+		ledger:     database.NewSignatureLedgerRepository(db, tenants),
+	}
+	a.adminService = services.NewAdminService(a.documents, a.signers)
+	// Chain verification only reads signatures: no signing key is needed
+	a.signatureService = services.NewSignatureService(a.signatures, a.documents, nil)
+	return a
+}
+
+// reminderService builds the reminder service on demand.
+// Reminders are queued in email_queue and sent by the email worker of the running server.
+func (a *app) reminderService() (*services.ReminderAsyncService, error) {
+	if a.baseURL == "" {
+		return nil, fmt.Errorf("ACKIFY_BASE_URL environment variable or -base-url flag is required to send reminders")
+	}
+
+	magicLinkService := services.NewMagicLinkService(services.MagicLinkServiceConfig{
+		Repository: a.magicLinks,
+		BaseURL:    a.baseURL,
+	})
+
+	bundle, err := i18n.NewI18n(a.localesDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: translations not loaded from %s, using default subject: %v\n", a.localesDir, err)
+		return services.NewReminderAsyncService(a.signers, a.reminders, a.emailQueue, magicLinkService, nil, a.baseURL), nil
+	}
+	return services.NewReminderAsyncService(a.signers, a.reminders, a.emailQueue, magicLinkService, bundle, a.baseURL), nil
+}
+
+// configService builds the configuration service with the encryption keys of the server.
+// It is only used on stored sections: no environment seeding happens here.
+func (a *app) configService() (*services.ConfigService, error) {
+	raw := os.Getenv("ACKIFY_OAUTH_COOKIE_SECRET")
+	if raw == "" {
+		return nil, fmt.Errorf("ACKIFY_OAUTH_COOKIE_SECRET environment variable is required to decrypt configuration secrets")
+	}
+
+	configService := services.NewConfigService(a.configs, &config.Config{}, config.DecodeSecret(raw))
+	configService.SetPreviousKeys(config.ParsePreviousSecrets(os.Getenv("ACKIFY_OAUTH_PREVIOUS_COOKIE_SECRETS")))
+	return configService, nil
+}
+
+// exportService builds the export service with the signing key of the server, so that bundles
+// exported here verify like those downloaded from the web UI
+func (a *app) exportService() (*services.ExportService, error) {
+	signer, err := crypto.NewEd25519Signer()
+	if err != nil {
+		return nil, err
+	}
+	if signer.KeySource() == crypto.KeySourceEphemeral {
+		return nil, fmt.Errorf("ACKIFY_ED25519_PRIVATE_KEY or ACKIFY_ED25519_PRIVATE_KEY_FILE environment variable is required to sign export bundles")
+	}
+
+	service := services.NewExportService(a.documents, a.signatures, a.reminders, signer)
+	if tsaURL := os.Getenv("ACKIFY_EXPORT_TSA_URL"); tsaURL != "" {
+		service.SetTimestamper(timestamp.NewClient(tsaURL, nil))
+	}
+	return service, nil
+}
+
+// ledgerService builds the signature ledger service from the same environment as the server.
+// The returned function closes the ledger connection.
+func (a *app) ledgerService() (*services.SignatureLedgerService, func(), error) {
+	cfg := config.LedgerConfig{
+		Type:        strings.ToLower(os.Getenv("ACKIFY_LEDGER_TYPE")),
+		PostgresDSN: os.Getenv("ACKIFY_LEDGER_POSTGRES_DSN"),
+		KafkaURL:    strings.TrimRight(os.Getenv("ACKIFY_LEDGER_KAFKA_URL"), "/"),
+		KafkaTopic:  envOr("ACKIFY_LEDGER_KAFKA_TOPIC", "ackify-signatures"),
+		KafkaToken:  os.Getenv("ACKIFY_LEDGER_KAFKA_TOKEN"),
+	}
+	if cfg.Type == "" {
+		return nil, nil, fmt.Errorf("ACKIFY_LEDGER_TYPE environment variable is required to reconcile the signature ledger")
+	}
+
+	writer, err := ledger.New(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeLedger := func() {}
+	if closer, ok := writer.(io.Closer); ok {
+		closeLedger = func() { _ = closer.Close() }
+	}
+	return services.NewSignatureLedgerService(a.ledger, a.signatures, writer, cfg.Type, 0), closeLedger, nil
+}
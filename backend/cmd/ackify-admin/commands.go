@@ -0,0 +1,529 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type documentRow struct {
+	DocID          string    `json:"docId"`
+	Title          string    `json:"title"`
+	URL            string    `json:"url"`
+	CreatedBy      string    `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+	ExpectedCount  int       `json:"expectedCount"`
+	SignedCount    int       `json:"signedCount"`
+	CompletionRate float64   `json:"completionRate"`
+}
+
+func runDocuments(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("documents", flag.ContinueOnError)
+	search := fs.String("search", "", "Filter by reference, title, URL or description")
+	owner := fs.String("owner", "", "Only documents created by this email")
+	limit := fs.Int("limit", 50, "Maximum number of documents")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var docs []*models.Document
+	var err error
+	switch {
+	case *owner != "" && *search != "":
+		docs, err = a.adminService.SearchDocumentsByCreator(ctx, *owner, *search, *limit, 0)
+	case *owner != "":
+		docs, err = a.adminService.ListDocumentsByCreator(ctx, *owner, *limit, 0)
+	case *search != "":
+		docs, err = a.adminService.SearchDocuments(ctx, *search, *limit, 0)
+	default:
+		docs, err = a.adminService.ListDocuments(ctx, *limit, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list documents: %w", err)
+	}
+
+	result := make([]documentRow, 0, len(docs))
+	rows := make([][]string, 0, len(docs))
+	for _, doc := range docs {
+		row := documentRow{DocID: doc.DocID, Title: doc.Title, URL: doc.URL, CreatedBy: doc.CreatedBy, CreatedAt: doc.CreatedAt}
+		stats, err := a.adminService.GetSignerStats(ctx, doc.DocID)
+		if err != nil {
+			return fmt.Errorf("failed to get stats for %s: %w", doc.DocID, err)
+		}
+		row.ExpectedCount, row.SignedCount, row.CompletionRate = stats.ExpectedCount, stats.SignedCount, stats.CompletionRate
+		result = append(result, row)
+		rows = append(rows, []string{
+			row.DocID, row.Title, row.CreatedBy,
+			fmt.Sprintf("%d/%d", row.SignedCount, row.ExpectedCount),
+			fmt.Sprintf("%.0f%%", row.CompletionRate),
+		})
+	}
+
+	return a.out.table(result, []string{"DOC ID", "TITLE", "CREATED BY", "SIGNED", "COMPLETION"}, rows)
+}
+
+type addSignersResult struct {
+	DocID   string                   `json:"docId"`
+	Added   int                      `json:"added"`
+	Invalid int                      `json:"invalid"`
+	Errors  []services.CSVParseError `json:"errors,omitempty"`
+}
+
+func runAddSigners(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("add-signers", flag.ContinueOnError)
+	maxSigners := fs.Int("max", 500, "Maximum number of signers in the file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: %s", usageAddSigners)
+	}
+	docID, path := fs.Arg(0), fs.Arg(1)
+
+	if err := requireDocument(ctx, a, docID); err != nil {
+		return err
+	}
+
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	parsed, err := services.NewCSVParser(*maxSigners).Parse(reader)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	contacts := make([]models.ContactInfo, 0, len(parsed.Signers))
+	for _, s := range parsed.Signers {
+		contacts = append(contacts, models.ContactInfo{Email: s.Email, Name: s.Name})
+	}
+	if len(contacts) > 0 {
+		if err := a.adminService.AddExpectedSigners(ctx, docID, contacts, a.actor); err != nil {
+			return fmt.Errorf("failed to add signers: %w", err)
+		}
+	}
+
+	if !a.out.json {
+		for _, e := range parsed.Errors {
+			fmt.Fprintf(os.Stderr, "line %d: %s (%s)\n", e.LineNumber, e.Error, e.Content)
+		}
+	}
+	result := addSignersResult{DocID: docID, Added: len(contacts), Invalid: parsed.InvalidCount, Errors: parsed.Errors}
+	return a.out.message(result, "Added %d expected signer(s) to %s (%d invalid line(s))", result.Added, docID, result.Invalid)
+}
+
+func runRemind(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("remind", flag.ContinueOnError)
+	locale := fs.String("locale", "en", "Language of the reminder emails")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		return fmt.Errorf("usage: %s", usageRemind)
+	}
+	docID, emails := fs.Arg(0), fs.Args()[1:]
+
+	doc, err := a.adminService.GetDocument(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return fmt.Errorf("document %s not found", docID)
+	}
+
+	reminderService, err := a.reminderService()
+	if err != nil {
+		return err
+	}
+	result, err := reminderService.SendReminders(ctx, docID, a.actor, emails, doc.URL, *locale)
+	if err != nil {
+		return fmt.Errorf("failed to send reminders: %w", err)
+	}
+
+	if !a.out.json {
+		for _, e := range result.Errors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+	}
+	return a.out.message(result, "Queued %d reminder(s) for %s (%d failed)", result.SuccessfullySent, docID, result.Failed)
+}
+
+func runRemindDigest(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("remind-digest", flag.ContinueOnError)
+	locale := fs.String("locale", "en", "Language of the reminder emails")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	reminderService, err := a.reminderService()
+	if err != nil {
+		return err
+	}
+	result, err := reminderService.SendDigests(ctx, fs.Args(), a.actor, *locale)
+	if err != nil {
+		return fmt.Errorf("failed to send reminder digests: %w", err)
+	}
+
+	if !a.out.json {
+		for _, e := range result.Errors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+	}
+	return a.out.message(result, "Queued %d digest(s) covering %d document(s) (%d failed)", result.DigestsQueued, result.DocumentCount, result.Failed)
+}
+
+func runExport(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	bundlePath := fs.String("bundle", "", "Write a signed export bundle (zip) to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: %s", usageExport)
+	}
+	docID := fs.Arg(0)
+
+	if err := requireDocument(ctx, a, docID); err != nil {
+		return err
+	}
+	if *bundlePath != "" {
+		return exportBundle(ctx, a, docID, *bundlePath)
+	}
+
+	signatures, err := a.signatureService.GetDocumentSignatures(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if signatures == nil {
+		signatures = []*models.Signature{}
+	}
+
+	rows := make([][]string, 0, len(signatures))
+	for _, sig := range signatures {
+		rows = append(rows, []string{
+			strconv.FormatInt(sig.ID, 10), sig.UserEmail, sig.UserName,
+			sig.SignedAtUTC.UTC().Format(time.RFC3339), sig.PayloadHash,
+		})
+	}
+	return a.out.table(signatures, []string{"ID", "EMAIL", "NAME", "SIGNED AT", "PAYLOAD HASH"}, rows)
+}
+
+type exportBundleResult struct {
+	File      string `json:"file"`
+	Files     int    `json:"files"`
+	PublicKey string `json:"publicKey"`
+	Timestamp bool   `json:"timestamp"`
+}
+
+// exportBundle writes the signed bundle of the signatures of a document to path
+func exportBundle(ctx context.Context, a *app, docID, path string) error {
+	exportService, err := a.exportService()
+	if err != nil {
+		return err
+	}
+	bundle, err := exportService.ExportDocumentSignatures(ctx, docID)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file: %w", err)
+	}
+	if err := bundle.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle file: %w", err)
+	}
+
+	result := exportBundleResult{File: path, Files: len(bundle.Files), PublicKey: exportService.PublicKey(), Timestamp: len(bundle.Timestamp) > 0}
+	return a.out.message(result, "Wrote %s (%d files, signed with %s)", result.File, result.Files, result.PublicKey)
+}
+
+type verifyResult struct {
+	Valid        bool   `json:"valid"`
+	TotalRecords int    `json:"totalRecords"`
+	BreakAtID    *int64 `json:"breakAtId,omitempty"`
+	Details      string `json:"details"`
+}
+
+func runVerify(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: %s", usageVerify)
+	}
+
+	integrity, err := a.signatureService.VerifyChainIntegrity(ctx)
+	if err != nil {
+		return err
+	}
+
+	result := verifyResult{Valid: integrity.IsValid, TotalRecords: integrity.TotalRecords, BreakAtID: integrity.BreakAtID, Details: integrity.Details}
+	if err := a.out.message(result, "%s (%d signatures checked)", result.Details, result.TotalRecords); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errChainBroken
+	}
+	return nil
+}
+
+func runVerifyExport(_ context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("verify-export", flag.ContinueOnError)
+	publicKey := fs.String("public-key", "", "Base64 Ed25519 public key the manifest must be signed with (default: key of ACKIFY_ED25519_PRIVATE_KEY)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: %s", usageVerifyExp)
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	trustedKey := *publicKey
+	if trustedKey == "" && (os.Getenv("ACKIFY_ED25519_PRIVATE_KEY") != "" || os.Getenv("ACKIFY_ED25519_PRIVATE_KEY_FILE") != "") {
+		signer, err := crypto.NewEd25519Signer()
+		if err != nil {
+			return err
+		}
+		trustedKey = signer.GetPublicKey()
+	}
+	if trustedKey == "" {
+		fmt.Fprintln(os.Stderr, "Warning: no trusted key given, the bundle is checked against the key it embeds")
+	}
+
+	result, err := crypto.VerifyExportBundle(data, trustedKey)
+	if err != nil {
+		return err
+	}
+
+	if !a.out.json {
+		for _, e := range result.Errors {
+			fmt.Fprintln(os.Stderr, e)
+		}
+	}
+	summary := "Bundle is valid"
+	if !result.Valid {
+		summary = "Bundle is INVALID"
+	}
+	if result.Timestamp != nil {
+		summary += ", time-stamped at " + result.Timestamp.GenTime.Format(time.RFC3339)
+	}
+	if err := a.out.message(result, "%s (%d files, key %s)", summary, len(result.Manifest.Files), result.Manifest.PublicKey); err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errBundleInvalid
+	}
+	return nil
+}
+
+func runRestoreKey(_ context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("restore-key", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "Write the key to this file (mode 0600), for ACKIFY_ED25519_PRIVATE_KEY_FILE, instead of printing it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: %s", usageRestoreKey)
+	}
+	// The passphrase is read from the environment to keep it out of the shell history
+	passphrase := os.Getenv("ACKIFY_KEY_BACKUP_PASSPHRASE")
+	if passphrase == "" {
+		return fmt.Errorf("ACKIFY_KEY_BACKUP_PASSPHRASE environment variable is required to decrypt the backup")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	var backup crypto.KeyBackup
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return crypto.ErrKeyBackupInvalid
+	}
+	privateKey, err := crypto.OpenKeyBackup(&backup, passphrase)
+	if err != nil {
+		return err
+	}
+
+	return writeKey(a, privateKey, backup.PublicKey, *keyFile)
+}
+
+func runGenerateKey(_ context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("generate-key", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "Write the key to this file (mode 0600), for ACKIFY_ED25519_PRIVATE_KEY_FILE, instead of printing it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	return writeKey(a, base64.StdEncoding.EncodeToString(privateKey), base64.StdEncoding.EncodeToString(publicKey), *keyFile)
+}
+
+// writeKey prints a base64 private key, or writes it to keyFile readable by its owner only
+func writeKey(a *app, privateKey, publicKey, keyFile string) error {
+	if keyFile == "" {
+		return a.out.message(map[string]string{"publicKey": publicKey, "privateKey": privateKey}, "%s", privateKey)
+	}
+	if err := os.WriteFile(keyFile, []byte(privateKey+"\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return a.out.message(map[string]string{"publicKey": publicKey, "keyFile": keyFile}, "Key %s written to %s", publicKey, keyFile)
+}
+
+func requireDocument(ctx context.Context, a *app, docID string) error {
+	doc, err := a.adminService.GetDocument(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return fmt.Errorf("document %s not found", docID)
+	}
+	return nil
+}
+
+func runRotateSecrets(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("rotate-secrets", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "Only report which secrets would be re-encrypted")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: %s", usageRotate)
+	}
+
+	configService, err := a.configService()
+	if err != nil {
+		return err
+	}
+
+	var status *models.ConfigSecretsStatus
+	if *dryRun {
+		status, err = configService.CheckSecrets(ctx)
+	} else {
+		status, err = configService.RotateSecrets(ctx, a.actor)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := a.out.message(status, "Secrets: %d current, %d stale, %d rotated, %d undecryptable",
+		len(status.Current), len(status.Stale), len(status.Rotated), len(status.Undecryptable)); err != nil {
+		return err
+	}
+	if len(status.Undecryptable) > 0 {
+		return fmt.Errorf("secrets of %v cannot be decrypted with any configured key", status.Undecryptable)
+	}
+	return nil
+}
+
+func runSanitizeReferers(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("sanitize-referers", flag.ContinueOnError)
+	policy := fs.String("policy", "", "Policy applied to recorded referers: origin, hash or drop")
+	dryRun := fs.Bool("dry-run", false, "Only count the signatures that would change")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *policy == "" {
+		return fmt.Errorf("usage: %s", usageReferers)
+	}
+
+	sanitizer := services.NewRefererSanitizer(a.signatures, a.signatureService)
+	result, err := sanitizer.Sanitize(ctx, models.RefererPolicy(*policy), *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if result.DryRun {
+		return a.out.message(result, "%d of %d referers would change under the %s policy", result.Changed, result.Scanned, result.Policy)
+	}
+	return a.out.message(result, "%d of %d referers sanitized under the %s policy (chain rebuilt: %v)", result.Changed, result.Scanned, result.Policy, result.ChainRebuilt)
+}
+
+type ledgerReconcileResult struct {
+	Lag            *models.LedgerLag            `json:"lag"`
+	Reconciliation *models.LedgerReconciliation `json:"reconciliation"`
+}
+
+func runLedgerReconcile(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("ledger-reconcile", flag.ContinueOnError)
+	after := fs.Int64("after", 0, "Only check the signatures with a higher ID")
+	limit := fs.Int("limit", 0, "Maximum number of signatures checked (0: all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: %s", usageLedger)
+	}
+
+	service, closeLedger, err := a.ledgerService()
+	if err != nil {
+		return err
+	}
+	defer closeLedger()
+
+	lag, err := service.Lag(ctx)
+	if err != nil {
+		return err
+	}
+	reconciliation, err := service.Reconcile(ctx, *after, *limit)
+	if err != nil {
+		return err
+	}
+
+	result := ledgerReconcileResult{Lag: lag, Reconciliation: reconciliation}
+	if a.out.json {
+		if err := a.out.writeJSON(result); err != nil {
+			return err
+		}
+	} else {
+		if len(reconciliation.Discrepancies) > 0 {
+			rows := make([][]string, 0, len(reconciliation.Discrepancies))
+			for _, d := range reconciliation.Discrepancies {
+				rows = append(rows, []string{strconv.FormatInt(d.SignatureID, 10), d.Kind, d.Expected, d.Actual})
+			}
+			if err := a.out.table(result, []string{"SIGNATURE", "KIND", "STREAMED HASH", "ACTUAL HASH"}, rows); err != nil {
+				return err
+			}
+		}
+		ledgerState := "checked"
+		if !reconciliation.LedgerChecked {
+			ledgerState = "not readable, only signatures checked"
+		}
+		_ = a.out.message(result, "%d streamed signatures checked against the %s ledger (%s): %d discrepancies",
+			reconciliation.Checked, lag.Ledger, ledgerState, len(reconciliation.Discrepancies))
+		_ = a.out.message(result, "%d signatures waiting to be streamed, lag %s",
+			lag.PendingCount, time.Duration(lag.LagSeconds*float64(time.Second)).Round(time.Second))
+	}
+
+	if len(reconciliation.Discrepancies) > 0 {
+		return errLedgerMismatch
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Command ackify-admin is a scriptable administration tool for ops teams.
+// It works directly on the Ackify database, inside the instance tenant context,
+// so it does not need a browser session on the web UI.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// exitChainBroken is returned by the verify, verify-export and ledger-reconcile commands when the
+// integrity check fails
+const exitChainBroken = 2
+
+// errChainBroken, errBundleInvalid and errLedgerMismatch signal a failed integrity check without
+// being a runtime error
+var (
+	errChainBroken    = errors.New("signature chain integrity check failed")
+	errBundleInvalid  = errors.New("export bundle integrity check failed")
+	errLedgerMismatch = errors.New("signature ledger reconciliation found discrepancies")
+)
+
+// command is a CLI sub-command running inside a tenant transaction, or without database when offline
+type command struct {
+	usage   string
+	offline bool
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+// Command usages, also reported when arguments are missing
+const (
+	usageDocuments  = "documents [-search q] [-owner email] [-limit n]   List documents with completion stats"
+	usageAddSigners = "add-signers [-max n] <docId> <file.csv|->         Add expected signers from a CSV file (email[,name])"
+	usageRemind     = "remind [-locale fr] <docId> [email...]            Queue reminders for pending signers"
+	usageDigest     = "remind-digest [-locale fr] [email...]             Queue one reminder per signer listing all pending documents"
+	usageExport     = "export [-bundle file.zip] <docId>                 Export the signatures of a document, optionally as a signed bundle"
+	usageVerify     = "verify                                            Verify the signature hash chain"
+	usageVerifyExp  = "verify-export [-public-key key] <file.zip>        Verify a signed export bundle (no database needed)"
+	usageRotate     = "rotate-secrets [-dry-run]                         Re-encrypt configuration secrets with the current key"
+	usageReferers   = "sanitize-referers -policy p [-dry-run]            Apply a referer policy (origin, hash, drop) to recorded signatures"
+	usageLedger     = "ledger-reconcile [-after id] [-limit n]           Reconcile the signatures with the external ledger and report its lag"
+	usageGenKey     = "generate-key [-key-file path]                     Generate an Ed25519 signing key (no database needed)"
+	usageRestoreKey = "restore-key [-key-file path] <backup.json>        Decrypt a signing key backup with ACKIFY_KEY_BACKUP_PASSPHRASE (no database needed)"
+)
+
+var commands = map[string]command{
+	"documents":         {usage: usageDocuments, run: runDocuments},
+	"add-signers":       {usage: usageAddSigners, run: runAddSigners},
+	"remind":            {usage: usageRemind, run: runRemind},
+	"remind-digest":     {usage: usageDigest, run: runRemindDigest},
+	"export":            {usage: usageExport, run: runExport},
+	"verify":            {usage: usageVerify, run: runVerify},
+	"verify-export":     {usage: usageVerifyExp, offline: true, run: runVerifyExport},
+	"rotate-secrets":    {usage: usageRotate, run: runRotateSecrets},
+	"sanitize-referers": {usage: usageReferers, run: runSanitizeReferers},
+	"ledger-reconcile":  {usage: usageLedger, run: runLedgerReconcile},
+	"generate-key":      {usage: usageGenKey, offline: true, run: runGenerateKey},
+	"restore-key":       {usage: usageRestoreKey, offline: true, run: runRestoreKey},
+}
+
+// options are the global flags, given before the command name
+type options struct {
+	dbDSN      string
+	baseURL    string
+	localesDir string
+	output     string
+	actor      string
+}
+
+// invocation is a parsed command line
+type invocation struct {
+	options
+	cmd  command
+	args []string
+}
+
+// Command line errors reported with the usage
+var (
+	errNoCommand      = errors.New("a command is required")
+	errUnknownCommand = errors.New("unknown command")
+)
+
+// parseArgs reads the global flags and resolves the command. Usage and flag errors are written to stderr.
+func parseArgs(args []string, stderr io.Writer) (*invocation, error) {
+	var inv invocation
+	fs := flag.NewFlagSet("ackify-admin", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&inv.dbDSN, "db-dsn", os.Getenv("ACKIFY_DB_DSN"), "Database DSN")
+	fs.StringVar(&inv.baseURL, "base-url", os.Getenv("ACKIFY_BASE_URL"), "Public base URL, used in reminder links")
+	fs.StringVar(&inv.localesDir, "locales-dir", envOr("ACKIFY_LOCALES_DIR", "locales"), "Directory of translation files, used in reminder subjects")
+	fs.StringVar(&inv.output, "output", "table", "Output format: table or json")
+	fs.StringVar(&inv.actor, "actor", "ackify-admin", "Identity recorded as author of changes")
+	fs.Usage = func() { printUsage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return nil, errNoCommand
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		return nil, fmt.Errorf("%w: %s", errUnknownCommand, fs.Arg(0))
+	}
+	if inv.dbDSN == "" && !cmd.offline {
+		return nil, errors.New("ACKIFY_DB_DSN environment variable or -db-dsn flag is required")
+	}
+	inv.cmd, inv.args = cmd, fs.Args()[1:]
+	return &inv, nil
+}
+
+func main() {
+	inv, err := parseArgs(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	out, err := newPrinter(os.Stdout, inv.output)
+	if err != nil {
+		fatal(err)
+	}
+
+	// Keep stdout clean for scripts: only errors are logged
+	logger.SetLevel(slog.LevelError)
+
+	ctx := context.Background()
+	if inv.cmd.offline {
+		exit(inv.cmd.run(ctx, &app{out: out}, inv.args))
+		return
+	}
+
+	db, err := database.InitDB(ctx, database.Config{DSN: inv.dbDSN})
+	if err != nil {
+		fatal(fmt.Errorf("failed to initialize database: %w", err))
+	}
+	defer func() { _ = db.Close() }()
+
+	tenantProvider, err := tenant.NewSingleTenantProviderWithContext(ctx, db)
+	if err != nil {
+		fatal(fmt.Errorf("failed to initialize tenant provider: %w", err))
+	}
+
+	a := newApp(db, tenantProvider, out, inv.baseURL, inv.localesDir, inv.actor)
+	err = tenant.WithTenantContextFromProvider(ctx, db, tenantProvider, func(txCtx context.Context) error {
+		return inv.cmd.run(txCtx, a, inv.args)
+	})
+	exit(err)
+}
+
+// exit terminates with the status of a command error
+func exit(err error) {
+	if errors.Is(err, errChainBroken) || errors.Is(err, errBundleInvalid) || errors.Is(err, errLedgerMismatch) {
+		os.Exit(exitChainBroken)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// commandOrder lists the commands in usage order
+var commandOrder = []string{"documents", "add-signers", "remind", "remind-digest", "export", "verify", "verify-export", "rotate-secrets", "sanitize-referers", "ledger-reconcile", "generate-key", "restore-key"}
+
+func printUsage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: ackify-admin [flags] <command> [args]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, name := range commandOrder {
+		fmt.Fprintf(w, "  %s\n", commands[name].usage)
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	fs.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
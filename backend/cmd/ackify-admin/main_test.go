@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	t.Setenv("ACKIFY_DB_DSN", "")
+	t.Setenv("ACKIFY_BASE_URL", "")
+	t.Setenv("ACKIFY_LOCALES_DIR", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		command string
+		rest    []string
+		opts    options
+	}{
+		{
+			name:    "defaults",
+			args:    []string{"-db-dsn", "postgres://db", "verify"},
+			command: "verify",
+			rest:    []string{},
+			opts:    options{dbDSN: "postgres://db", localesDir: "locales", output: "table", actor: "ackify-admin"},
+		},
+		{
+			name:    "global flags before the command",
+			args:    []string{"-db-dsn=postgres://db", "-output", "json", "-actor", "ops@example.com", "-base-url", "https://sign.example.com", "remind", "-locale", "fr", "doc-1", "alice@example.com"},
+			command: "remind",
+			rest:    []string{"-locale", "fr", "doc-1", "alice@example.com"},
+			opts:    options{dbDSN: "postgres://db", baseURL: "https://sign.example.com", localesDir: "locales", output: "json", actor: "ops@example.com"},
+		},
+		{
+			name:    "offline command without database",
+			args:    []string{"generate-key", "-key-file", "key.txt"},
+			command: "generate-key",
+			rest:    []string{"-key-file", "key.txt"},
+			opts:    options{localesDir: "locales", output: "table", actor: "ackify-admin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			inv, err := parseArgs(tt.args, &stderr)
+			if err != nil {
+				t.Fatalf("parseArgs: %v", err)
+			}
+			if inv.options != tt.opts {
+				t.Errorf("expected options %+v, got %+v", tt.opts, inv.options)
+			}
+			if inv.cmd.usage != commands[tt.command].usage {
+				t.Errorf("expected command %s, got %q", tt.command, inv.cmd.usage)
+			}
+			if strings.Join(inv.args, " ") != strings.Join(tt.rest, " ") {
+				t.Errorf("expected command args %v, got %v", tt.rest, inv.args)
+			}
+			if stderr.Len() != 0 {
+				t.Errorf("expected nothing on stderr, got %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestParseArgs_Environment(t *testing.T) {
+	t.Setenv("ACKIFY_DB_DSN", "postgres://env")
+	t.Setenv("ACKIFY_BASE_URL", "https://env.example.com")
+	t.Setenv("ACKIFY_LOCALES_DIR", "/srv/locales")
+
+	inv, err := parseArgs([]string{"documents"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseArgs: %v", err)
+	}
+	if inv.dbDSN != "postgres://env" || inv.baseURL != "https://env.example.com" || inv.localesDir != "/srv/locales" {
+		t.Errorf("expected options from the environment, got %+v", inv.options)
+	}
+
+	inv, err = parseArgs([]string{"-db-dsn", "postgres://flag", "documents"}, &bytes.Buffer{})
+	if err != nil || inv.dbDSN != "postgres://flag" {
+		t.Errorf("expected the flag to override the environment, got %+v (err %v)", inv, err)
+	}
+}
+
+func TestParseArgs_Errors(t *testing.T) {
+	t.Setenv("ACKIFY_DB_DSN", "")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    error
+		wantErr string
+		usage   bool
+	}{
+		{name: "no command", args: nil, want: errNoCommand, usage: true},
+		{name: "only flags", args: []string{"-db-dsn", "postgres://db"}, want: errNoCommand, usage: true},
+		{name: "unknown command", args: []string{"-db-dsn", "postgres://db", "drop-everything"}, want: errUnknownCommand, wantErr: "drop-everything", usage: true},
+		{name: "missing database URL", args: []string{"documents"}, wantErr: "ACKIFY_DB_DSN environment variable or -db-dsn flag is required"},
+		{name: "missing database URL after flags", args: []string{"-output", "json", "verify"}, wantErr: "-db-dsn flag is required"},
+		{name: "unknown flag", args: []string{"-force", "documents"}, wantErr: "flag provided but not defined", usage: true},
+		{name: "flag without value", args: []string{"-db-dsn"}, wantErr: "flag needs an argument", usage: true},
+		{name: "help", args: []string{"-h"}, want: flag.ErrHelp, usage: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			inv, err := parseArgs(tt.args, &stderr)
+			if err == nil {
+				t.Fatalf("expected an error, got %+v", inv)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if printed := strings.Contains(stderr.String(), "Usage: ackify-admin"); printed != tt.usage {
+				t.Errorf("expected usage printed: %v, got %q", tt.usage, stderr.String())
+			}
+		})
+	}
+}
+
+func TestUsage_ListsEveryCommand(t *testing.T) {
+	if len(commandOrder) != len(commands) {
+		t.Fatalf("usage lists %d commands, %d are registered", len(commandOrder), len(commands))
+	}
+	var stderr bytes.Buffer
+	_, _ = parseArgs([]string{"-h"}, &stderr)
+	for _, name := range commandOrder {
+		cmd, ok := commands[name]
+		if !ok {
+			t.Errorf("usage lists unregistered command %s", name)
+			continue
+		}
+		if !strings.HasPrefix(cmd.usage, name+" ") {
+			t.Errorf("usage of %s does not start with its name: %q", name, cmd.usage)
+		}
+		if !strings.Contains(stderr.String(), cmd.usage) {
+			t.Errorf("usage output misses %s", name)
+		}
+	}
+}
+
+func TestDispatch_OfflineCommands(t *testing.T) {
+	t.Setenv("ACKIFY_DB_DSN", "")
+	t.Setenv("ACKIFY_KEY_BACKUP_PASSPHRASE", "")
+	keyFile := filepath.Join(t.TempDir(), "signing.key")
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+		check   func(t *testing.T, out string)
+	}{
+		{
+			name: "generate-key prints the key",
+			args: []string{"generate-key"},
+			check: func(t *testing.T, out string) {
+				var key map[string]string
+				if err := json.Unmarshal([]byte(out), &key); err != nil || key["privateKey"] == "" || key["publicKey"] == "" {
+					t.Errorf("unexpected key output %q (err %v)", out, err)
+				}
+			},
+		},
+		{
+			name: "generate-key writes the key file",
+			args: []string{"generate-key", "-key-file", keyFile},
+			check: func(t *testing.T, out string) {
+				info, err := os.Stat(keyFile)
+				if err != nil {
+					t.Fatalf("key file not written: %v", err)
+				}
+				if info.Mode().Perm() != 0o600 {
+					t.Errorf("expected key file mode 0600, got %v", info.Mode().Perm())
+				}
+				if strings.Contains(out, "privateKey") {
+					t.Errorf("private key printed: %q", out)
+				}
+			},
+		},
+		{name: "generate-key rejects unknown flags", args: []string{"generate-key", "-size", "4096"}, wantErr: "flag provided but not defined"},
+		{name: "verify-export requires a bundle", args: []string{"verify-export"}, wantErr: "usage: verify-export"},
+		{name: "verify-export reports a missing bundle", args: []string{"verify-export", filepath.Join(t.TempDir(), "missing.zip")}, wantErr: "failed to read bundle"},
+		{name: "restore-key requires a backup", args: []string{"restore-key"}, wantErr: "usage: restore-key"},
+		{name: "restore-key requires a passphrase", args: []string{"restore-key", "backup.json"}, wantErr: "ACKIFY_KEY_BACKUP_PASSPHRASE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, err := parseArgs(append([]string{"-output", "json"}, tt.args...), &bytes.Buffer{})
+			if err != nil {
+				t.Fatalf("parseArgs: %v", err)
+			}
+			if !inv.cmd.offline {
+				t.Fatalf("expected %s to run without database", tt.args[0])
+			}
+
+			var stdout bytes.Buffer
+			out, err := newPrinter(&stdout, inv.output)
+			if err != nil {
+				t.Fatalf("newPrinter: %v", err)
+			}
+			err = inv.cmd.run(context.Background(), &app{out: out}, inv.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("run: %v", err)
+			}
+			tt.check(t, stdout.String())
+		})
+	}
+}
+
+func TestNewPrinter(t *testing.T) {
+	for _, format := range []string{"", "table", "JSON", "json"} {
+		if _, err := newPrinter(&bytes.Buffer{}, format); err != nil {
+			t.Errorf("format %q: %v", format, err)
+		}
+	}
+	if _, err := newPrinter(&bytes.Buffer{}, "yaml"); err == nil {
+		t.Error("expected an unknown output format to be refused")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"embed"
 	"errors"
 	"fmt"
@@ -9,11 +10,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/vault"
 	"github.com/btouchard/ackify-ce/backend/pkg/config"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/web"
@@ -37,6 +40,22 @@ func main() {
 
 	ctx := context.Background()
 
+	// Secrets from Vault are exported to the environment before the configuration is loaded
+	vaultCfg, err := config.LoadVaultConfig()
+	if err != nil {
+		log.Fatalf("Failed to load Vault config: %v", err)
+	}
+	var secrets *vault.Manager
+	if vaultCfg.Enabled() {
+		secrets = vault.NewManager(vault.NewClient(nil, vaultCfg.Addr, vaultCfg.Namespace), vaultCfg)
+		if err := secrets.Bootstrap(ctx); err != nil {
+			log.Fatalf("Failed to read secrets from Vault: %v", err)
+		}
+		if err := secrets.ExportEnv(); err != nil {
+			log.Fatalf("Failed to export Vault secrets: %v", err)
+		}
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
@@ -49,7 +68,11 @@ func main() {
 		"build_date", BuildDate,
 		"telemetry", cfg.Telemetry.Enabled)
 
-	db, err := database.InitDB(ctx, database.Config{DSN: cfg.Database.DSN})
+	dbConfig := database.Config{DSN: cfg.Database.DSN}
+	if secrets != nil && secrets.HasDatabaseCredentials() {
+		dbConfig.DSNProvider = func() (string, error) { return secrets.DatabaseDSN(cfg.Database.DSN) }
+	}
+	db, err := database.InitDB(ctx, dbConfig)
 	if err != nil {
 		log.Fatalf("failed to initialize database: %v", err)
 	}
@@ -61,15 +84,23 @@ func main() {
 
 	// === Build Server ===
 	// All services (I18n, Email, MagicLink, Config, Session) and
-	// default providers (DynamicAuthProvider, SimpleAuthorizer) are created internally.
-	server, err := web.NewServerBuilder(cfg, frontend, Version).
+	// default providers (DynamicAuthProvider, RoleAuthorizer) are created internally.
+	builder := web.NewServerBuilder(cfg, frontend, Version).
 		WithDB(db).
-		WithTenantProvider(tenantProvider).
-		Build(ctx)
+		WithTenantProvider(tenantProvider)
+	if secrets != nil {
+		builder = builder.WithSecretSource(secrets)
+	}
+	server, err := builder.Build(ctx)
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
 
+	if secrets != nil {
+		secrets.Start(ctx)
+		defer secrets.Stop()
+	}
+
 	go func() {
 		log.Printf("Community Edition server starting on %s", server.GetAddr())
 		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -77,13 +108,19 @@ func main() {
 		}
 	}()
 
+	// SIGHUP reloads settings and translations, SIGINT/SIGTERM drain and stop
 	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := <-quit; sig == syscall.SIGHUP; sig = <-quit {
+		log.Println("Reloading configuration...")
+		if err := server.Reload(ctx); err != nil {
+			log.Printf("Reload failed: %v", err)
+		}
+	}
 
 	log.Println("Shutting down Community Edition server...")
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
@@ -96,10 +133,23 @@ func main() {
 // runHealthCheck performs a health check against the local server.
 // Returns 0 on success, 1 on failure.
 func runHealthCheck() int {
+	scheme := "http"
 	addr := os.Getenv("ACKIFY_LISTEN_ADDR")
 	if addr == "" {
 		addr = ":8080"
 	}
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	// With automatic TLS the server only listens on HTTPS, with a certificate for its public
+	// domains rather than localhost
+	if tlsAuto := strings.ToLower(os.Getenv("ACKIFY_TLS_AUTO")); tlsAuto == "true" || tlsAuto == "1" {
+		scheme = "https"
+		addr = os.Getenv("ACKIFY_TLS_ADDR")
+		if addr == "" {
+			addr = ":443"
+		}
+		client.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
 
 	// Build health URL (handle both ":8080" and "0.0.0.0:8080" formats)
 	host := "localhost"
@@ -114,9 +164,8 @@ func runHealthCheck() int {
 		}
 	}
 
-	url := fmt.Sprintf("http://%s%s/api/v1/health", host, port)
+	url := fmt.Sprintf("%s://%s%s/api/v1/health", scheme, host, port)
 
-	client := &http.Client{Timeout: 5 * time.Second}
 	resp, err := client.Get(url)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Health check failed: %v\n", err)
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// Backup archives are gzip-compressed streams of JSON values: a backupHeader
+// followed by one backupRecord per table row.
+const (
+	backupFormat        = "ackify-backup"
+	backupFormatVersion = 1
+)
+
+type backupHeader struct {
+	Format        string    `json:"format"`
+	FormatVersion int       `json:"formatVersion"`
+	SchemaVersion uint      `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Tables        []string  `json:"tables"`
+}
+
+type backupRecord struct {
+	Table string          `json:"table"`
+	Row   json.RawMessage `json:"row"`
+}
+
+// schemaVersion returns the applied migration version, refusing databases
+// without migrations or left dirty by a failed migration.
+func schemaVersion(m *migrate.Migrate) (uint, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, errors.New("no migrations applied, run 'migrate up' first")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("database is dirty at version %d, fix it with 'migrate force' first", version)
+	}
+	return version, nil
+}
+
+// listTables returns the Ackify tables of the public schema, excluding the
+// migration bookkeeping table.
+func listTables(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(`
+		SELECT tablename FROM pg_tables
+		WHERE schemaname = 'public' AND tablename <> 'schema_migrations'
+		ORDER BY tablename
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// runBackup dumps every table to path from a single consistent snapshot.
+func runBackup(db *sql.DB, version uint, path string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY"); err != nil {
+		return fmt.Errorf("failed to start snapshot: %w", err)
+	}
+	// Fail loudly instead of silently dumping nothing when RLS would filter rows
+	if _, err := tx.Exec("SET LOCAL row_security = off"); err != nil {
+		return fmt.Errorf("failed to disable row security: %w", err)
+	}
+
+	tables, err := listTables(tx)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	if err := writeBackup(tx, file, version, tables); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+func writeBackup(tx *sql.Tx, w io.Writer, version uint, tables []string) error {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+
+	header := backupHeader{
+		Format:        backupFormat,
+		FormatVersion: backupFormatVersion,
+		SchemaVersion: version,
+		CreatedAt:     time.Now().UTC(),
+		Tables:        tables,
+	}
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		count, err := dumpTable(tx, enc, table)
+		if err != nil {
+			return fmt.Errorf("failed to dump %s: %w", table, err)
+		}
+		fmt.Printf("  %-28s %d rows\n", table, count)
+	}
+
+	return gz.Close()
+}
+
+func dumpTable(tx *sql.Tx, enc *json.Encoder, table string) (int, error) {
+	rows, err := tx.Query(fmt.Sprintf("SELECT row_to_json(t)::text FROM %s t", quoteIdentifier(table)))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	count := 0
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return count, err
+		}
+		if err := enc.Encode(backupRecord{Table: table, Row: json.RawMessage(row)}); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// readBackupHeader decodes and validates the archive header against the
+// schema version of the target database.
+func readBackupHeader(dec *json.Decoder, version uint) (*backupHeader, error) {
+	var header backupHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	if header.Format != backupFormat {
+		return nil, errors.New("invalid backup archive: not an Ackify backup")
+	}
+	if header.FormatVersion > backupFormatVersion {
+		return nil, fmt.Errorf("backup format version %d is newer than supported version %d", header.FormatVersion, backupFormatVersion)
+	}
+	if header.SchemaVersion != version {
+		return nil, fmt.Errorf("backup schema version %d does not match database version %d, run 'migrate goto %d' first",
+			header.SchemaVersion, version, header.SchemaVersion)
+	}
+	return &header, nil
+}
+
+// runRestore replaces the content of every archived table in a single
+// transaction, so a failed restore leaves the database untouched.
+func runRestore(db *sql.DB, version uint, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("invalid backup archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	dec := json.NewDecoder(gz)
+	header, err := readBackupHeader(dec, version)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Skip triggers and foreign key checks while rows are loaded in arbitrary order
+	if _, err := tx.Exec("SET LOCAL session_replication_role = replica"); err != nil {
+		return fmt.Errorf("failed to disable triggers (superuser required): %w", err)
+	}
+	if _, err := tx.Exec("SET LOCAL row_security = off"); err != nil {
+		return fmt.Errorf("failed to disable row security: %w", err)
+	}
+
+	existing, err := listTables(tx)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, table := range existing {
+		known[table] = true
+	}
+
+	inserts := make(map[string]*sql.Stmt, len(header.Tables))
+	for _, table := range header.Tables {
+		if !known[table] {
+			return fmt.Errorf("table %s from backup does not exist in database", table)
+		}
+		quoted := quoteIdentifier(table)
+		if _, err := tx.Exec("TRUNCATE " + quoted + " CASCADE"); err != nil {
+			return fmt.Errorf("failed to truncate %s: %w", table, err)
+		}
+		stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_record(NULL::%s, $1::json)", quoted, quoted))
+		if err != nil {
+			return fmt.Errorf("failed to prepare restore of %s: %w", table, err)
+		}
+		inserts[table] = stmt
+	}
+
+	counts := make(map[string]int, len(header.Tables))
+	for {
+		var record backupRecord
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("invalid backup archive: %w", err)
+		}
+		stmt, ok := inserts[record.Table]
+		if !ok {
+			return fmt.Errorf("invalid backup archive: unexpected table %s", record.Table)
+		}
+		if _, err := stmt.Exec(string(record.Row)); err != nil {
+			return fmt.Errorf("failed to restore row into %s: %w", record.Table, err)
+		}
+		counts[record.Table]++
+	}
+
+	for _, table := range header.Tables {
+		if err := resetSequences(tx, table); err != nil {
+			return fmt.Errorf("failed to reset sequences of %s: %w", table, err)
+		}
+		fmt.Printf("  %-28s %d rows\n", table, counts[table])
+	}
+
+	return tx.Commit()
+}
+
+// resetSequences moves serial sequences past the restored ids.
+func resetSequences(tx *sql.Tx, table string) error {
+	rows, err := tx.Query(`
+		SELECT a.attname, pg_get_serial_sequence($1, a.attname)
+		FROM pg_attribute a
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		  AND pg_get_serial_sequence($1, a.attname) IS NOT NULL
+	`, quoteIdentifier(table))
+	if err != nil {
+		return err
+	}
+
+	type serial struct{ column, sequence string }
+	var serials []serial
+	for rows.Next() {
+		var s serial
+		if err := rows.Scan(&s.column, &s.sequence); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		serials = append(serials, s)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, s := range serials {
+		query := fmt.Sprintf("SELECT setval($1, COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+			quoteIdentifier(s.column), quoteIdentifier(table))
+		if _, err := tx.Exec(query, s.sequence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
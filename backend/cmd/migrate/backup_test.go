@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackup_RoundTrip(t *testing.T) {
+	recorder := &recordingConnector{results: map[string][]string{
+		`FROM "documents"`:  {`{"doc_id":"policy","title":"Policy"}`, `{"doc_id":"charter","title":"Charter"}`},
+		`FROM "signatures"`: {`{"id":1,"doc_id":"policy","user_email":"alice@example.com"}`},
+	}}
+	db := sql.OpenDB(recorder)
+	defer func() { _ = db.Close() }()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var archive bytes.Buffer
+	if err := writeBackup(tx, &archive, 42, []string{"documents", "signatures"}); err != nil {
+		t.Fatalf("writeBackup: %v", err)
+	}
+
+	gz, err := gzip.NewReader(&archive)
+	if err != nil {
+		t.Fatalf("archive is not gzip: %v", err)
+	}
+	dec := json.NewDecoder(gz)
+	header, err := readBackupHeader(dec, 42)
+	if err != nil {
+		t.Fatalf("readBackupHeader: %v", err)
+	}
+	if header.Format != backupFormat || header.FormatVersion != backupFormatVersion || header.SchemaVersion != 42 ||
+		header.CreatedAt.IsZero() || strings.Join(header.Tables, ",") != "documents,signatures" {
+		t.Fatalf("unexpected header: %+v", header)
+	}
+
+	var records []backupRecord
+	for {
+		var record backupRecord
+		if err := dec.Decode(&record); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("decode record: %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	if records[0].Table != "documents" || string(records[1].Row) != `{"doc_id":"charter","title":"Charter"}` ||
+		records[2].Table != "signatures" {
+		t.Errorf("unexpected records: %+v", records)
+	}
+}
+
+func encodeArchive(t *testing.T, values ...any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadBackupHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  any
+		wantErr string
+	}{
+		{"matching version", backupHeader{Format: backupFormat, FormatVersion: backupFormatVersion, SchemaVersion: 42}, ""},
+		{"older schema", backupHeader{Format: backupFormat, FormatVersion: backupFormatVersion, SchemaVersion: 41}, "run 'migrate goto 41' first"},
+		{"newer schema", backupHeader{Format: backupFormat, FormatVersion: backupFormatVersion, SchemaVersion: 43}, "does not match database version 42"},
+		{"newer format", backupHeader{Format: backupFormat, FormatVersion: backupFormatVersion + 1, SchemaVersion: 42}, "newer than supported"},
+		{"other format", backupHeader{Format: "pg_dump", FormatVersion: 1, SchemaVersion: 42}, "not an Ackify backup"},
+		{"not a header", []int{1, 2}, "invalid backup archive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gz, err := gzip.NewReader(bytes.NewReader(encodeArchive(t, tt.header)))
+			if err != nil {
+				t.Fatalf("gzip: %v", err)
+			}
+			_, err = readBackupHeader(json.NewDecoder(gz), 42)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunRestore_RefusesBeforeTouchingTheDatabase(t *testing.T) {
+	dir := t.TempDir()
+	mismatched := filepath.Join(dir, "old.backup.gz")
+	header := backupHeader{Format: backupFormat, FormatVersion: backupFormatVersion, SchemaVersion: 41, Tables: []string{"documents"}}
+	if err := os.WriteFile(mismatched, encodeArchive(t, header, backupRecord{Table: "documents", Row: json.RawMessage(`{}`)}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	notGzip := filepath.Join(dir, "dump.sql")
+	if err := os.WriteFile(notGzip, []byte("DROP TABLE documents;"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{mismatched, notGzip, filepath.Join(dir, "missing.gz")} {
+		recorder := &recordingConnector{}
+		db := sql.OpenDB(recorder)
+		if err := runRestore(db, 42, path); err == nil {
+			t.Errorf("expected restore of %s to be refused", filepath.Base(path))
+		}
+		if recorder.begins != 0 || len(recorder.execs) != 0 {
+			t.Errorf("restore of %s touched the database: %d begins, %v", filepath.Base(path), recorder.begins, recorder.execs)
+		}
+		_ = db.Close()
+	}
+}
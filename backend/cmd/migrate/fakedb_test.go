@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// recordingConnector is a database/sql driver recording the statements and
+// transactions of a command. A query answers the single-column rows of the
+// results key it contains.
+type recordingConnector struct {
+	execs     []string
+	begins    int
+	commits   int
+	rollbacks int
+	failOn    string
+	results   map[string][]string
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{c}, nil
+}
+func (c *recordingConnector) Driver() driver.Driver { return nil }
+
+type recordingConn struct{ c *recordingConnector }
+
+func (r *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+func (r *recordingConn) Close() error { return nil }
+func (r *recordingConn) Begin() (driver.Tx, error) {
+	r.c.begins++
+	return &recordingTx{r.c}, nil
+}
+
+func (r *recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if r.c.failOn != "" && strings.Contains(query, r.c.failOn) {
+		return nil, errors.New("relation does not exist")
+	}
+	r.c.execs = append(r.c.execs, query)
+	return driver.RowsAffected(0), nil
+}
+
+func (r *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	for key, values := range r.c.results {
+		if strings.Contains(query, key) {
+			return &recordingRows{values: values}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+type recordingRows struct {
+	values []string
+	next   int
+}
+
+func (r *recordingRows) Columns() []string { return []string{"value"} }
+func (r *recordingRows) Close() error      { return nil }
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	dest[0] = r.values[r.next]
+	r.next++
+	return nil
+}
+
+type recordingTx struct{ c *recordingConnector }
+
+func (t *recordingTx) Commit() error   { t.c.commits++; return nil }
+func (t *recordingTx) Rollback() error { t.c.rollbacks++; return nil }
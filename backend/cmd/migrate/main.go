@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/lib/pq"
 )
@@ -52,6 +53,16 @@ func main() {
 
 	switch command {
 	case "up":
+		upFlags := flag.NewFlagSet("up", flag.ExitOnError)
+		dryRun := upFlags.Bool("dry-run", false, "Apply the pending migrations in a rolled-back transaction")
+		_ = upFlags.Parse(args[1:])
+		if *dryRun {
+			if err := runDryRun(db, m, openSource(*migrationsPath)); err != nil {
+				log.Fatal("Dry run failed: ", err)
+			}
+			return
+		}
+
 		// Ensure ackify_app role exists before running migrations (for RLS support)
 		if err := ensureAppRole(db); err != nil {
 			log.Fatal("Failed to ensure ackify_app role:", err)
@@ -62,6 +73,10 @@ func main() {
 			log.Fatal("Migration up failed:", err)
 		}
 		fmt.Println("CE migrations applied successfully")
+	case "plan":
+		if err := runPlan(m, openSource(*migrationsPath)); err != nil {
+			log.Fatal("Plan failed: ", err)
+		}
 	case "down":
 		steps := 1
 		if len(args) > 1 {
@@ -106,6 +121,30 @@ func main() {
 			log.Fatal("Cannot get version:", err)
 		}
 		fmt.Printf("Version: %d, Dirty: %t\n", version, dirty)
+	case "backup":
+		if len(args) < 2 {
+			log.Fatal("backup requires an output file")
+		}
+		version, err := schemaVersion(m)
+		if err != nil {
+			log.Fatal("Cannot backup:", err)
+		}
+		if err := runBackup(db, version, args[1]); err != nil {
+			log.Fatal("Backup failed:", err)
+		}
+		fmt.Printf("Backup of schema version %d written to %s\n", version, args[1])
+	case "restore":
+		if len(args) < 2 {
+			log.Fatal("restore requires a backup file")
+		}
+		version, err := schemaVersion(m)
+		if err != nil {
+			log.Fatal("Cannot restore:", err)
+		}
+		if err := runRestore(db, version, args[1]); err != nil {
+			log.Fatal("Restore failed:", err)
+		}
+		fmt.Printf("Backup %s restored at schema version %d\n", args[1], version)
 	case "drop":
 		err = m.Drop()
 		if err != nil {
@@ -123,10 +162,14 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  up           Apply all CE migrations")
+	fmt.Println("  up --dry-run Apply pending migrations in a rolled-back transaction")
+	fmt.Println("  plan         List pending migrations and warn about destructive statements")
 	fmt.Println("  down [n]     Rollback n CE migrations (default: 1)")
 	fmt.Println("  goto <v>     Migrate to specific version (up or down)")
 	fmt.Println("  force <v>    Force version without running migrations (for existing DBs)")
 	fmt.Println("  version      Show current migration version")
+	fmt.Println("  backup <f>   Write all CE data to a compressed archive")
+	fmt.Println("  restore <f>  Replace all CE data with an archive (same schema version)")
 	fmt.Println("  drop         Drop all migrations (DANGER)")
 	fmt.Println()
 	fmt.Println("Options:")
@@ -137,18 +180,37 @@ func printUsage() {
 	fmt.Println("  ACKIFY_APP_PASSWORD    Password for the ackify_app role (required for RLS)")
 	fmt.Println()
 	fmt.Println("Examples:")
+	fmt.Println("  migrate plan")
+	fmt.Println("  migrate up --dry-run")
 	fmt.Println("  migrate up")
 	fmt.Println("  migrate down 2")
 	fmt.Println("  migrate goto 5")
 	fmt.Println("  migrate force 1        # For existing DB with only signatures table")
 	fmt.Println("  migrate version")
+	fmt.Println("  migrate backup ackify.backup.gz")
+	fmt.Println("  migrate restore ackify.backup.gz")
+}
+
+// sqlExecer is a *sql.DB, or the *sql.Tx of a dry run.
+type sqlExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// openSource opens the migration files, for the commands reading them.
+func openSource(url string) source.Driver {
+	src, err := source.Open(url)
+	if err != nil {
+		log.Fatal("Cannot open migrations:", err)
+	}
+	return src
 }
 
 // ensureAppRole creates or updates the ackify_app role used for RLS.
 // The password is read from ACKIFY_APP_PASSWORD environment variable.
 // If not set, the function logs a warning and continues (for backward compatibility).
 // If set, the role is created (or password updated) before migrations run.
-func ensureAppRole(db *sql.DB) error {
+func ensureAppRole(db sqlExecer) error {
 	password := strings.TrimSpace(os.Getenv("ACKIFY_APP_PASSWORD"))
 	if password == "" {
 		log.Println("WARNING: ACKIFY_APP_PASSWORD not set. ackify_app role will not be created.")
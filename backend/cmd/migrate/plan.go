@@ -0,0 +1,295 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// pendingMigration is an up migration not applied to the database yet.
+type pendingMigration struct {
+	Version    uint
+	Identifier string
+	SQL        string
+}
+
+func (p pendingMigration) name() string {
+	return fmt.Sprintf("%04d_%s", p.Version, p.Identifier)
+}
+
+// statementRule flags a kind of statement found in a migration.
+type statementRule struct {
+	pattern *regexp.Regexp
+	reason  string
+}
+
+// destructiveRules flag statements that lose data. DROP POLICY, DROP TRIGGER
+// and the like are not listed: migrations drop them to recreate them.
+var destructiveRules = []statementRule{
+	{regexp.MustCompile(`(?i)\bDROP\s+(TABLE|SCHEMA|DATABASE|VIEW|MATERIALIZED\s+VIEW|SEQUENCE|TYPE)\b`), "drops an object with its data"},
+	{regexp.MustCompile(`(?i)\bDROP\s+COLUMN\b`), "drops a column with its data"},
+	{regexp.MustCompile(`(?i)\bTRUNCATE\b`), "empties a table"},
+	{regexp.MustCompile(`(?i)\bDELETE\s+FROM\b`), "deletes rows"},
+	{regexp.MustCompile(`(?i)\bALTER\s+COLUMN\s+\S+\s+(SET\s+DATA\s+)?TYPE\b`), "changes a column type, rewriting the table"},
+}
+
+// nonTransactionalRules flag statements PostgreSQL refuses, or cannot fully
+// check, inside a transaction block.
+var nonTransactionalRules = []statementRule{
+	{regexp.MustCompile(`(?i)\bCONCURRENTLY\b`), "runs concurrently"},
+	{regexp.MustCompile(`(?i)\bALTER\s+TYPE\s+\S+\s+ADD\s+VALUE\b`), "adds an enum value"},
+	{regexp.MustCompile(`(?i)\b(VACUUM|ALTER\s+SYSTEM|CREATE\s+DATABASE)\b`), "cannot run in a transaction"},
+}
+
+// versionReader reports the applied migration version; *migrate.Migrate implements it.
+type versionReader interface {
+	Version() (version uint, dirty bool, err error)
+}
+
+// finding is a statement of a migration matched by a rule.
+type finding struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+// pendingMigrations reads the up migrations after the applied version, or all
+// of them when none is applied.
+func pendingMigrations(src source.Driver, current uint, applied bool) ([]pendingMigration, error) {
+	var version uint
+	var err error
+	if applied {
+		version, err = src.Next(current)
+	} else {
+		version, err = src.First()
+	}
+
+	var pending []pendingMigration
+	for err == nil {
+		r, identifier, readErr := src.ReadUp(version)
+		if readErr == nil {
+			body, copyErr := io.ReadAll(r)
+			_ = r.Close()
+			if copyErr != nil {
+				return nil, fmt.Errorf("failed to read migration %d: %w", version, copyErr)
+			}
+			pending = append(pending, pendingMigration{Version: version, Identifier: identifier, SQL: string(body)})
+		} else if !errors.Is(readErr, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read migration %d: %w", version, readErr)
+		}
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return pending, nil
+}
+
+// findStatements returns the statements of a migration matched by the rules,
+// ignoring comments, string literals and function bodies.
+func findStatements(sqlText string, rules []statementRule) []finding {
+	masked := maskSQL(sqlText)
+	lines := strings.Split(sqlText, "\n")
+
+	var findings []finding
+	for _, rule := range rules {
+		for _, loc := range rule.pattern.FindAllStringIndex(masked, -1) {
+			line := strings.Count(masked[:loc[0]], "\n")
+			text := strings.TrimSpace(lines[line])
+			if len(text) > 100 {
+				text = text[:100] + "..."
+			}
+			findings = append(findings, finding{Line: line + 1, Text: text, Reason: rule.reason})
+		}
+	}
+	return findings
+}
+
+// maskSQL blanks out comments, string literals and dollar-quoted bodies,
+// keeping line breaks so that offsets still map to source lines.
+func maskSQL(sqlText string) string {
+	out := []byte(sqlText)
+	blank := func(from, to int) {
+		for i := from; i < to && i < len(out); i++ {
+			if out[i] != '\n' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(sqlText); {
+		switch {
+		case strings.HasPrefix(sqlText[i:], "--"):
+			end := strings.IndexByte(sqlText[i:], '\n')
+			if end < 0 {
+				end = len(sqlText) - i
+			}
+			blank(i, i+end)
+			i += end
+		case strings.HasPrefix(sqlText[i:], "/*"):
+			end := strings.Index(sqlText[i+2:], "*/")
+			if end < 0 {
+				end = len(sqlText) - i - 4
+			}
+			blank(i, i+end+4)
+			i += end + 4
+		case sqlText[i] == '\'':
+			end := i + 1
+			for end < len(sqlText) {
+				if sqlText[end] == '\'' {
+					if end+1 < len(sqlText) && sqlText[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			blank(i, end+1)
+			i = end + 1
+		case sqlText[i] == '$':
+			tag := dollarTag(sqlText[i:])
+			if tag == "" {
+				i++
+				continue
+			}
+			end := strings.Index(sqlText[i+len(tag):], tag)
+			if end < 0 {
+				end = len(sqlText) - i - 2*len(tag)
+			}
+			blank(i, i+end+2*len(tag))
+			i += end + 2*len(tag)
+		default:
+			i++
+		}
+	}
+	return string(out)
+}
+
+// dollarTag returns the $tag$ opening a dollar-quoted string, or "" when s
+// does not start with one.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// runPlan prints the migrations 'up' would apply and warns about the
+// statements needing attention before a production upgrade.
+func runPlan(m versionReader, src source.Driver) error {
+	current, dirty, applied, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+	pending, err := pendingMigrations(src, current, applied)
+	if err != nil {
+		return err
+	}
+
+	if applied {
+		fmt.Printf("Current version: %d\n", current)
+	} else {
+		fmt.Println("Current version: none")
+	}
+	if dirty {
+		fmt.Printf("WARNING: database is dirty at version %d, fix it with 'migrate force' before upgrading\n", current)
+	}
+	if len(pending) == 0 {
+		fmt.Println("No pending migrations")
+		return nil
+	}
+
+	fmt.Printf("Pending migrations (%d):\n", len(pending))
+	warnings := 0
+	for _, p := range pending {
+		fmt.Printf("  %s\n", p.name())
+		for _, f := range findStatements(p.SQL, destructiveRules) {
+			fmt.Printf("    WARNING line %d, %s: %s\n", f.Line, f.Reason, f.Text)
+			warnings++
+		}
+		for _, f := range findStatements(p.SQL, nonTransactionalRules) {
+			fmt.Printf("    NOTE line %d, %s, not checked by 'up --dry-run': %s\n", f.Line, f.Reason, f.Text)
+		}
+	}
+	if warnings > 0 {
+		fmt.Printf("%d destructive statement(s): take a backup ('migrate backup <file>') before running 'migrate up'\n", warnings)
+	}
+	return nil
+}
+
+// runDryRun applies the pending migrations inside a transaction that is rolled
+// back, to check they succeed on this database. It stops at the first
+// migration that cannot run in a transaction.
+func runDryRun(db *sql.DB, m versionReader, src source.Driver) error {
+	current, dirty, applied, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d, fix it with 'migrate force' first", current)
+	}
+	pending, err := pendingMigrations(src, current, applied)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Println("No pending migrations")
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Fail fast rather than queue behind the application on busy tables
+	if _, err := tx.Exec("SET LOCAL lock_timeout = '10s'"); err != nil {
+		return err
+	}
+	if err := ensureAppRole(tx); err != nil {
+		return err
+	}
+
+	for _, p := range pending {
+		if found := findStatements(p.SQL, nonTransactionalRules); len(found) > 0 {
+			fmt.Printf("  %s: not checked, line %d %s\n", p.name(), found[0].Line, found[0].Reason)
+			fmt.Println("Dry run stopped: the following migrations depend on it")
+			return nil
+		}
+		if _, err := tx.Exec(p.SQL); err != nil {
+			return fmt.Errorf("migration %s fails: %w", p.name(), err)
+		}
+		fmt.Printf("  %s: ok\n", p.name())
+	}
+	fmt.Printf("Dry run succeeded: %d migration(s) would apply, nothing was changed\n", len(pending))
+	return nil
+}
+
+// currentVersion returns the applied migration version; applied is false when
+// no migration ran yet.
+func currentVersion(m versionReader) (version uint, dirty, applied bool, err error) {
+	version, dirty, err = m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, false, nil
+	}
+	if err != nil {
+		return 0, false, false, fmt.Errorf("cannot get version: %w", err)
+	}
+	return version, dirty, true, nil
+}
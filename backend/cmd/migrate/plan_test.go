@@ -0,0 +1,219 @@
+package main
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+func TestFindStatements_Destructive(t *testing.T) {
+	tests := []struct {
+		name   string
+		sql    string
+		reason string // empty when nothing should be flagged
+		line   int
+	}{
+		{"drop table", "DROP TABLE signatures;", "drops an object with its data", 1},
+		{"drop table if exists", "DROP TABLE IF EXISTS signatures CASCADE;", "drops an object with its data", 1},
+		{"drop materialized view", "DROP MATERIALIZED VIEW stats;", "drops an object with its data", 1},
+		{"drop column", "ALTER TABLE documents DROP COLUMN legacy;", "drops a column with its data", 1},
+		{"drop column if exists", "ALTER TABLE documents\n  DROP COLUMN IF EXISTS legacy;", "drops a column with its data", 2},
+		{"truncate", "TRUNCATE reminder_logs;", "empties a table", 1},
+		{"delete", "DELETE FROM email_queue WHERE status = 'sent';", "deletes rows", 1},
+		{"column type", "ALTER TABLE documents ALTER COLUMN title TYPE varchar(200);", "changes a column type, rewriting the table", 1},
+		{"column set data type", "ALTER TABLE documents ALTER COLUMN title SET DATA TYPE text;", "changes a column type, rewriting the table", 1},
+		{"lower case", "drop table signatures;", "drops an object with its data", 1},
+		{"mixed case", "Alter Table documents Drop Column legacy;", "drops a column with its data", 1},
+		{"mixed case truncate", "TrUnCaTe reminder_logs;", "empties a table", 1},
+		{"after other statements", "CREATE TABLE a (id int);\n\nDROP TABLE b;", "drops an object with its data", 3},
+		{"drop policy", "DROP POLICY IF EXISTS tenant_isolation ON documents;", "", 0},
+		{"drop trigger", "DROP TRIGGER IF EXISTS set_updated_at ON documents;", "", 0},
+		{"drop index", "DROP INDEX IF EXISTS idx_documents_title;", "", 0},
+		{"identifier containing the keyword", "CREATE TABLE truncated_logs (id int);", "", 0},
+		{"line comment", "-- DROP TABLE signatures;\nSELECT 1;", "", 0},
+		{"trailing line comment", "SELECT 1; -- then TRUNCATE everything", "", 0},
+		{"block comment", "/* Former version:\nDROP TABLE signatures;\n*/\nSELECT 1;", "", 0},
+		{"string literal", "INSERT INTO notes (body) VALUES ('DROP TABLE signatures');", "", 0},
+		{"string with escaped quote", "INSERT INTO notes (body) VALUES ('it''s a DROP COLUMN test');", "", 0},
+		{"statement after escaped quote", "INSERT INTO notes (body) VALUES ('it''s');\nTRUNCATE notes;", "empties a table", 2},
+		{"dollar-quoted body", "CREATE FUNCTION purge() RETURNS void AS $$\nBEGIN\n  DELETE FROM email_queue;\nEND;\n$$ LANGUAGE plpgsql;", "", 0},
+		{"tagged dollar-quoted body", "DO $body$ BEGIN TRUNCATE notes; END $body$;", "", 0},
+		{"statement after function body", "CREATE FUNCTION f() RETURNS void AS $fn$ SELECT 1 $fn$ LANGUAGE sql;\nDROP TABLE old;", "drops an object with its data", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := findStatements(tt.sql, destructiveRules)
+			if tt.reason == "" {
+				if len(findings) != 0 {
+					t.Fatalf("expected no finding, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("expected one finding, got %+v", findings)
+			}
+			if findings[0].Reason != tt.reason || findings[0].Line != tt.line {
+				t.Errorf("expected %q at line %d, got %+v", tt.reason, tt.line, findings[0])
+			}
+		})
+	}
+}
+
+func TestFindStatements_NonTransactional(t *testing.T) {
+	tests := []struct {
+		name   string
+		sql    string
+		reason string
+	}{
+		{"concurrent index", "CREATE INDEX CONCURRENTLY idx_a ON a (b);", "runs concurrently"},
+		{"enum value", "ALTER TYPE reminder_status ADD VALUE 'skipped';", "adds an enum value"},
+		{"vacuum", "vacuum analyze documents;", "cannot run in a transaction"},
+		{"commented out", "-- CREATE INDEX CONCURRENTLY idx_a ON a (b);\nCREATE INDEX idx_a ON a (b);", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := findStatements(tt.sql, nonTransactionalRules)
+			if tt.reason == "" {
+				if len(findings) != 0 {
+					t.Fatalf("expected no finding, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].Reason != tt.reason {
+				t.Fatalf("expected %q, got %+v", tt.reason, findings)
+			}
+		})
+	}
+}
+
+func TestMaskSQL_KeepsOffsets(t *testing.T) {
+	inputs := []string{
+		"SELECT 'a\nb'; -- note\n/* multi\nline */ DROP TABLE x;",
+		"DO $$ BEGIN\n  PERFORM 1;\nEND $$;",
+		"SELECT 'unterminated",
+		"/* unterminated comment",
+		"SELECT $1, $tag$ unterminated",
+	}
+	for _, in := range inputs {
+		masked := maskSQL(in)
+		if len(masked) != len(in) {
+			t.Errorf("masking %q changed its length: %q", in, masked)
+		}
+		if strings.Count(masked, "\n") != strings.Count(in, "\n") {
+			t.Errorf("masking %q changed its lines: %q", in, masked)
+		}
+	}
+}
+
+type fixedVersion struct {
+	version uint
+	dirty   bool
+}
+
+func (f fixedVersion) Version() (uint, bool, error) { return f.version, f.dirty, nil }
+
+func migrationSource(t *testing.T, files map[string]string) source.Driver {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	for name, body := range files {
+		fsys["migrations/"+name] = &fstest.MapFile{Data: []byte(body)}
+	}
+	src, err := iofs.New(fsys, "migrations")
+	if err != nil {
+		t.Fatalf("failed to open migrations: %v", err)
+	}
+	return src
+}
+
+func TestRunDryRun_NeverApplies(t *testing.T) {
+	t.Setenv("ACKIFY_APP_PASSWORD", "")
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		failOn   string
+		wantErr  bool
+		executed []string
+	}{
+		{
+			name: "pending migrations are rolled back",
+			files: map[string]string{
+				"0001_init.up.sql":     "CREATE TABLE a (id int);",
+				"0002_add_b.up.sql":    "CREATE TABLE b (id int);",
+				"0002_add_b.down.sql":  "DROP TABLE b;",
+				"0003_drop_a.up.sql":   "DROP TABLE a;",
+				"0003_drop_a.down.sql": "CREATE TABLE a (id int);",
+			},
+			executed: []string{"CREATE TABLE b (id int);", "DROP TABLE a;"},
+		},
+		{
+			name: "stops before a migration that cannot run in a transaction",
+			files: map[string]string{
+				"0001_init.up.sql":    "CREATE TABLE a (id int);",
+				"0002_add_b.up.sql":   "CREATE TABLE b (id int);",
+				"0003_index.up.sql":   "CREATE INDEX CONCURRENTLY idx_b ON b (id);",
+				"0004_add_c.up.sql":   "CREATE TABLE c (id int);",
+				"0004_add_c.down.sql": "DROP TABLE c;",
+			},
+			executed: []string{"CREATE TABLE b (id int);"},
+		},
+		{
+			name: "failing migration",
+			files: map[string]string{
+				"0001_init.up.sql":  "CREATE TABLE a (id int);",
+				"0002_alter.up.sql": "ALTER TABLE missing ADD COLUMN x int;",
+				"0003_add_c.up.sql": "CREATE TABLE c (id int);",
+			},
+			failOn:  "missing",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &recordingConnector{failOn: tt.failOn}
+			db := sql.OpenDB(recorder)
+			defer func() { _ = db.Close() }()
+
+			err := runDryRun(db, fixedVersion{version: 1}, migrationSource(t, tt.files))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if recorder.begins != 1 || recorder.rollbacks != 1 || recorder.commits != 0 {
+				t.Fatalf("expected one rolled back transaction and no commit, got %d begins, %d rollbacks, %d commits",
+					recorder.begins, recorder.rollbacks, recorder.commits)
+			}
+			var migrations []string
+			for _, q := range recorder.execs {
+				if !strings.HasPrefix(q, "SET LOCAL") {
+					migrations = append(migrations, q)
+				}
+			}
+			if strings.Join(migrations, "|") != strings.Join(tt.executed, "|") {
+				t.Errorf("expected %v to run, got %v", tt.executed, migrations)
+			}
+		})
+	}
+}
+
+func TestRunDryRun_Refusals(t *testing.T) {
+	t.Setenv("ACKIFY_APP_PASSWORD", "")
+	files := map[string]string{"0001_init.up.sql": "CREATE TABLE a (id int);"}
+
+	recorder := &recordingConnector{}
+	db := sql.OpenDB(recorder)
+	defer func() { _ = db.Close() }()
+
+	if err := runDryRun(db, fixedVersion{version: 1, dirty: true}, migrationSource(t, files)); err == nil {
+		t.Fatal("expected a dirty database to be refused")
+	}
+	if err := runDryRun(db, fixedVersion{version: 1}, migrationSource(t, files)); err != nil {
+		t.Fatalf("expected nothing to do, got %v", err)
+	}
+	if recorder.begins != 0 || len(recorder.execs) != 0 {
+		t.Errorf("expected no transaction, got %d begins and %v", recorder.begins, recorder.execs)
+	}
+}
@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// seedActor is recorded as creator of the generated documents and signers
+const seedActor = "seed@ackify.local"
+
+// errForeignDocuments is returned when the tenant holds documents the seed did not create
+var errForeignDocuments = errors.New("tenant already holds documents not created by the seed")
+
+// completionProfile is the share of expected signers who sign a document
+type completionProfile struct {
+	weight   float64
+	min, max float64
+}
+
+// completionProfiles approximates a real instance: a few finished documents,
+// most in progress, and a tail of documents nobody opened yet.
+var completionProfiles = []completionProfile{
+	{weight: 0.20, min: 1, max: 1},      // Complete
+	{weight: 0.45, min: 0.3, max: 0.95}, // In progress
+	{weight: 0.25, min: 0.01, max: 0.3}, // Just started
+	{weight: 0.10, min: 0, max: 0},      // Not started
+}
+
+// unexpectedRate is the probability that a document also gets a signature from outside its signer list
+const unexpectedRate = 0.05
+
+var titleWords = []string{
+	"Security", "Policy", "Code of Conduct", "Remote Work", "Data Protection", "Onboarding",
+	"Expense", "Travel", "IT Charter", "Safety", "Procedure", "Handbook", "Guidelines",
+}
+
+type generatorConfig struct {
+	Documents int
+	Signers   int
+	People    int
+	Span      time.Duration
+	Seed      int64
+	Prefix    string
+	Force     bool // Seed even if the tenant holds other documents
+}
+
+type generatorStats struct {
+	documents  int
+	expected   int
+	signatures int
+	unexpected int
+}
+
+// generator creates documents with their expected signers and signatures
+type generator struct {
+	cfg     generatorConfig
+	db      *sql.DB
+	tenants providers.TenantProvider
+	signer  *crypto.Ed25519Signer
+	rnd     *rand.Rand
+	now     time.Time
+
+	documents  *database.DocumentRepository
+	signers    *database.ExpectedSignerRepository
+	signatures *database.SignatureRepository
+}
+
+func newGenerator(db *sql.DB, tenants providers.TenantProvider, signer *crypto.Ed25519Signer, cfg generatorConfig) *generator {
+	return &generator{
+		cfg:        cfg,
+		db:         db,
+		tenants:    tenants,
+		signer:     signer,
+		rnd:        rand.New(rand.NewSource(cfg.Seed)),
+		now:        time.Now().UTC().Truncate(time.Microsecond),
+		documents:  database.NewDocumentRepository(db, tenants),
+		signers:    database.NewExpectedSignerRepository(db, tenants),
+		signatures: database.NewSignatureRepository(db, tenants),
+	}
+}
+
+// run generates every document, calling progress after each one
+func (g *generator) run(ctx context.Context, progress func(done int)) (*generatorStats, error) {
+	stats := &generatorStats{}
+	if !g.cfg.Force {
+		if err := g.checkEmpty(ctx); err != nil {
+			return stats, err
+		}
+	}
+	for i := 1; i <= g.cfg.Documents; i++ {
+		// Draw the document plan outside the transaction so that skipped documents
+		// do not shift the random sequence of the following ones
+		plan := g.planDocument(i)
+		// One transaction per document keeps transactions short on large volumes
+		err := tenant.WithTenantContextFromProvider(ctx, g.db, g.tenants, func(txCtx context.Context) error {
+			return g.createDocument(txCtx, plan, stats)
+		})
+		if err != nil {
+			return stats, fmt.Errorf("document %s: %w", plan.docID, err)
+		}
+		progress(i)
+	}
+	return stats, nil
+}
+
+// checkEmpty refuses a tenant holding documents the seed did not create, deleted ones included,
+// so that generated data never mixes with real data. Documents of previous runs are skipped later.
+func (g *generator) checkEmpty(ctx context.Context) error {
+	tenantID, err := g.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return err
+	}
+	var count int
+	err = tenant.WithTenantContextFromProvider(ctx, g.db, g.tenants, func(txCtx context.Context) error {
+		return dbctx.GetQuerier(txCtx, g.db).QueryRowContext(txCtx,
+			`SELECT COUNT(*) FROM documents WHERE tenant_id = $1 AND created_by IS DISTINCT FROM $2`,
+			tenantID, seedActor).Scan(&count)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count existing documents: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w (%d found): use -force to seed it anyway", errForeignDocuments, count)
+	}
+	return nil
+}
+
+// documentPlan is the random part of a generated document
+type documentPlan struct {
+	docID      string
+	title      string
+	createdAt  time.Time
+	expected   []models.ContactInfo
+	signing    []int // Indexes in expected of the signers who signed, in signing order
+	unexpected *models.ContactInfo
+	signedAt   []time.Time // One per signature, sorted
+}
+
+func (g *generator) planDocument(n int) *documentPlan {
+	plan := &documentPlan{
+		docID: fmt.Sprintf("%s-%06d", g.cfg.Prefix, n),
+		title: fmt.Sprintf("%s %s #%d", titleWords[g.rnd.Intn(len(titleWords))], titleWords[g.rnd.Intn(len(titleWords))], n),
+		// Older documents are rarer than recent ones
+		createdAt: g.now.Add(-time.Duration(math.Sqrt(g.rnd.Float64()) * float64(g.cfg.Span))),
+	}
+
+	// Pick distinct signers from the shared population, so that users sign many documents
+	for _, p := range g.rnd.Perm(g.cfg.People)[:min(g.cfg.Signers, g.cfg.People)] {
+		plan.expected = append(plan.expected, person(p))
+	}
+
+	ratio := g.pickCompletion()
+	count := int(math.Round(ratio * float64(len(plan.expected))))
+	plan.signing = g.rnd.Perm(len(plan.expected))[:count]
+
+	if g.rnd.Float64() < unexpectedRate {
+		outsider := person(g.cfg.People + g.rnd.Intn(g.cfg.People))
+		plan.unexpected = &outsider
+	}
+
+	// Most people sign within days of the publication, a few weeks later
+	total := len(plan.signing)
+	if plan.unexpected != nil {
+		total++
+	}
+	window := g.now.Sub(plan.createdAt)
+	for i := 0; i < total; i++ {
+		delay := time.Duration(g.rnd.ExpFloat64() * float64(window) / 8)
+		if delay > window {
+			delay = time.Duration(g.rnd.Float64() * float64(window))
+		}
+		plan.signedAt = append(plan.signedAt, plan.createdAt.Add(delay).Truncate(time.Microsecond))
+	}
+	slices.SortFunc(plan.signedAt, time.Time.Compare)
+	return plan
+}
+
+func (g *generator) pickCompletion() float64 {
+	r := g.rnd.Float64()
+	for _, p := range completionProfiles {
+		if r < p.weight {
+			return p.min + g.rnd.Float64()*(p.max-p.min)
+		}
+		r -= p.weight
+	}
+	return 0
+}
+
+func (g *generator) createDocument(ctx context.Context, plan *documentPlan, stats *generatorStats) error {
+	existing, err := g.documents.GetByDocID(ctx, plan.docID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return nil
+	}
+
+	if _, err := g.documents.Create(ctx, plan.docID, models.DocumentInput{
+		Title: plan.title,
+		URL:   fmt.Sprintf("https://docs.example.com/%s.pdf", plan.docID),
+	}, seedActor); err != nil {
+		return err
+	}
+	// Backdate the document so that date-based filters and stats see a realistic spread
+	if _, err := dbctx.GetQuerier(ctx, g.db).ExecContext(ctx,
+		`UPDATE documents SET created_at = $1 WHERE doc_id = $2`, plan.createdAt, plan.docID); err != nil {
+		return fmt.Errorf("failed to backdate document: %w", err)
+	}
+
+	if len(plan.expected) > 0 {
+		if err := g.signers.AddExpected(ctx, plan.docID, plan.expected, seedActor); err != nil {
+			return err
+		}
+	}
+
+	signers := make([]models.ContactInfo, 0, len(plan.signedAt))
+	for _, i := range plan.signing {
+		signers = append(signers, plan.expected[i])
+	}
+	if plan.unexpected != nil {
+		signers = append(signers, *plan.unexpected)
+	}
+
+	var prev *models.Signature
+	for i, contact := range signers {
+		sig, err := g.sign(ctx, plan.docID, contact, plan.signedAt[i], prev)
+		if err != nil {
+			return err
+		}
+		prev = sig
+	}
+
+	stats.documents++
+	stats.expected += len(plan.expected)
+	stats.signatures += len(signers)
+	if plan.unexpected != nil {
+		stats.unexpected++
+	}
+	return nil
+}
+
+// sign records a signature chained to prev, as the signature service does
+func (g *generator) sign(ctx context.Context, docID string, contact models.ContactInfo, signedAt time.Time, prev *models.Signature) (*models.Signature, error) {
+	user := &models.User{Sub: "seed|" + contact.Email, Email: contact.Email, Name: contact.Name}
+	nonce, err := crypto.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	payloadHash, signatureB64, err := g.signer.CreateSignature(ctx, docID, user, signedAt, nonce, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &models.Signature{
+		DocID:       docID,
+		UserSub:     user.Sub,
+		UserEmail:   user.NormalizedEmail(),
+		UserName:    user.Name,
+		SignedAtUTC: signedAt,
+		PayloadHash: payloadHash,
+		Signature:   signatureB64,
+		Nonce:       nonce,
+		AuthMethod:  models.AuthMethodSession,
+	}
+	if prev != nil {
+		hash := prev.ComputeRecordHash()
+		sig.PrevHash = &hash
+	}
+	if err := g.signatures.Create(ctx, sig); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// person returns the n-th member of the generated population
+func person(n int) models.ContactInfo {
+	return models.ContactInfo{
+		Name:  fmt.Sprintf("Seed User %05d", n),
+		Email: fmt.Sprintf("user%05d@seed.example.com", n),
+	}
+}
@@ -0,0 +1,106 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func newTestGenerator(t *testing.T, testDB *database.TestDB, force bool) *generator {
+	t.Helper()
+	signer, err := crypto.NewEd25519Signer()
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	return newGenerator(testDB.DB, testDB.TenantProvider, signer, generatorConfig{
+		Documents: 6,
+		Signers:   4,
+		People:    12,
+		Span:      30 * 24 * time.Hour,
+		Seed:      7,
+		Prefix:    "seed",
+		Force:     force,
+	})
+}
+
+func countRows(t *testing.T, testDB *database.TestDB) [3]int {
+	t.Helper()
+	var counts [3]int
+	for i, table := range []string{"documents", "expected_signers", "signatures"} {
+		if err := testDB.DB.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&counts[i]); err != nil {
+			t.Fatalf("failed to count %s: %v", table, err)
+		}
+	}
+	return counts
+}
+
+func TestGenerator_Idempotent(t *testing.T) {
+	testDB := database.SetupTestDB(t)
+	ctx := context.Background()
+
+	stats, err := newTestGenerator(t, testDB, false).run(ctx, func(int) {})
+	if err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if stats.documents != 6 || stats.expected != 24 {
+		t.Fatalf("unexpected first run stats: %+v", stats)
+	}
+	after := countRows(t, testDB)
+	if after[0] != 6 || after[1] != 24 || after[2] != stats.signatures {
+		t.Fatalf("unexpected rows after the first run: %v (stats %+v)", after, stats)
+	}
+
+	stats, err = newTestGenerator(t, testDB, false).run(ctx, func(int) {})
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if stats.documents != 0 || stats.signatures != 0 {
+		t.Errorf("expected the second run to skip every document, got %+v", stats)
+	}
+	if again := countRows(t, testDB); again != after {
+		t.Errorf("second run changed the data: %v, then %v", after, again)
+	}
+}
+
+func TestGenerator_RefusesForeignDocuments(t *testing.T) {
+	testDB := database.SetupTestDB(t)
+	ctx := context.Background()
+
+	docs := database.NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	if _, err := docs.Create(ctx, "policy-2026", models.DocumentInput{Title: "Security policy"}, "admin@example.com"); err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+
+	_, err := newTestGenerator(t, testDB, false).run(ctx, func(int) {})
+	if !errors.Is(err, errForeignDocuments) {
+		t.Fatalf("expected errForeignDocuments, got %v", err)
+	}
+	if counts := countRows(t, testDB); counts != [3]int{1, 0, 0} {
+		t.Fatalf("refused run wrote data: %v", counts)
+	}
+
+	// A soft-deleted document still counts as real data
+	if err := docs.Delete(ctx, "policy-2026"); err != nil {
+		t.Fatalf("failed to delete document: %v", err)
+	}
+	if _, err := newTestGenerator(t, testDB, false).run(ctx, func(int) {}); !errors.Is(err, errForeignDocuments) {
+		t.Fatalf("expected errForeignDocuments with a deleted document, got %v", err)
+	}
+
+	stats, err := newTestGenerator(t, testDB, true).run(ctx, func(int) {})
+	if err != nil {
+		t.Fatalf("forced run: %v", err)
+	}
+	if stats.documents != 6 {
+		t.Errorf("expected the forced run to seed 6 documents, got %+v", stats)
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlanDocument_Reproducible(t *testing.T) {
+	cfg := generatorConfig{Documents: 20, Signers: 8, People: 40, Span: 30 * 24 * time.Hour, Seed: 42, Prefix: "seed"}
+	first := newGenerator(nil, nil, nil, cfg)
+	second := newGenerator(nil, nil, nil, cfg)
+	second.now = first.now
+
+	for n := 1; n <= cfg.Documents; n++ {
+		a, b := first.planDocument(n), second.planDocument(n)
+		if !reflect.DeepEqual(a, b) {
+			t.Fatalf("document %d differs between runs with the same seed:\n%+v\n%+v", n, a, b)
+		}
+		if len(a.expected) != cfg.Signers || len(a.signedAt) != len(a.signing)+boolToInt(a.unexpected != nil) {
+			t.Fatalf("inconsistent plan for document %d: %+v", n, a)
+		}
+		for _, at := range a.signedAt {
+			if at.Before(a.createdAt) || at.After(first.now) {
+				t.Fatalf("signature of document %d at %s outside [%s, %s]", n, at, a.createdAt, first.now)
+			}
+		}
+	}
+
+	other := newGenerator(nil, nil, nil, generatorConfig{Documents: 20, Signers: 8, People: 40, Span: cfg.Span, Seed: 43, Prefix: "seed"})
+	other.now = first.now
+	if reflect.DeepEqual(newGenerator(nil, nil, nil, cfg).planDocument(1).expected, other.planDocument(1).expected) {
+		t.Error("expected another seed to pick other signers")
+	}
+}
+
+func TestPlanDocument_SmallPopulation(t *testing.T) {
+	g := newGenerator(nil, nil, nil, generatorConfig{Documents: 1, Signers: 10, People: 3, Span: 24 * time.Hour, Seed: 1, Prefix: "load"})
+	plan := g.planDocument(7)
+	if plan.docID != "load-000007" {
+		t.Errorf("unexpected document ID %q", plan.docID)
+	}
+	if len(plan.expected) != 3 {
+		t.Errorf("expected signers capped to the population, got %d", len(plan.expected))
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Command seed fills an Ackify database with generated documents, expected signers
+// and signatures, so that list and stats queries can be measured on realistic volumes.
+// Runs are reproducible: the same -seed produces the same data set.
+//
+// Never run it against a production database.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+func main() {
+	dbDSN := flag.String("db-dsn", os.Getenv("ACKIFY_DB_DSN"), "Database DSN")
+	tenantFlag := flag.String("tenant", "", "Tenant ID to seed (default: the instance tenant)")
+	documents := flag.Int("documents", 100, "Number of documents to generate")
+	signers := flag.Int("signers", 20, "Expected signers per document")
+	people := flag.Int("people", 0, "Size of the signer population shared by all documents (default: 10 x signers)")
+	days := flag.Int("days", 90, "Spread documents and signatures over the last N days")
+	seed := flag.Int64("seed", 1, "Randomness seed; the same seed generates the same data set")
+	prefix := flag.String("prefix", "seed", "Prefix of generated document IDs, to tell seeded data apart")
+	force := flag.Bool("force", false, "Seed even if the tenant holds documents not created by the seed")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if *dbDSN == "" {
+		fatal(errors.New("ACKIFY_DB_DSN environment variable or -db-dsn flag is required"))
+	}
+	if *documents <= 0 || *signers < 0 || *days <= 0 {
+		fatal(errors.New("-documents and -days must be positive, -signers must not be negative"))
+	}
+	if *people <= 0 {
+		*people = max(10*(*signers), 1)
+	}
+
+	// Only errors are logged: progress is printed on stdout
+	logger.SetLevel(slog.LevelError)
+
+	ctx := context.Background()
+	db, err := database.InitDB(ctx, database.Config{DSN: *dbDSN})
+	if err != nil {
+		fatal(fmt.Errorf("failed to initialize database: %w", err))
+	}
+	defer func() { _ = db.Close() }()
+
+	var tenants providers.TenantProvider
+	if *tenantFlag != "" {
+		id, err := uuid.Parse(*tenantFlag)
+		if err != nil {
+			fatal(fmt.Errorf("invalid -tenant: %w", err))
+		}
+		tenants = fixedTenant{id: id}
+	} else {
+		tenants, err = tenant.NewSingleTenantProviderWithContext(ctx, db)
+		if err != nil {
+			fatal(fmt.Errorf("failed to initialize tenant provider: %w", err))
+		}
+	}
+	tenantID, _ := tenants.CurrentTenant(ctx)
+
+	// Uses ACKIFY_ED25519_PRIVATE_KEY when set, so that seeded signatures verify with the server key
+	signer, err := crypto.NewEd25519Signer()
+	if err != nil {
+		fatal(fmt.Errorf("failed to initialize signer: %w", err))
+	}
+
+	g := newGenerator(db, tenants, signer, generatorConfig{
+		Documents: *documents,
+		Signers:   *signers,
+		People:    *people,
+		Span:      time.Duration(*days) * 24 * time.Hour,
+		Seed:      *seed,
+		Prefix:    *prefix,
+		Force:     *force,
+	})
+
+	fmt.Printf("Seeding tenant %s: %d documents x %d signers (seed %d)\n", tenantID, *documents, *signers, *seed)
+	start := time.Now()
+	stats, err := g.run(ctx, func(done int) {
+		if done%100 == 0 || done == *documents {
+			fmt.Printf("  %d/%d documents\n", done, *documents)
+		}
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("Created %d documents, %d expected signers and %d signatures (%d unexpected) in %s\n",
+		stats.documents, stats.expected, stats.signatures, stats.unexpected, time.Since(start).Round(time.Millisecond))
+}
+
+// fixedTenant is a tenant provider for an explicit -tenant flag
+type fixedTenant struct {
+	id uuid.UUID
+}
+
+func (t fixedTenant) CurrentTenant(_ context.Context) (uuid.UUID, error) {
+	return t.id, nil
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: seed [flags]")
+	fmt.Fprintln(os.Stderr, "\nGenerates documents, expected signers and signatures for load testing.")
+	fmt.Fprintln(os.Stderr, "Documents already present with the same ID are skipped.")
+	fmt.Fprintln(os.Stderr, "A tenant holding documents not created by the seed is refused unless -force is given.")
+	fmt.Fprintln(os.Stderr, "\nFlags:")
+	flag.PrintDefaults()
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
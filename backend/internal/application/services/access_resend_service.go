@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// AccessLinkTemplate is the email template carrying a fresh login link sent by an admin
+	AccessLinkTemplate = "access_link"
+
+	// accessResendInterval is the minimum delay between two emails to the same recipient
+	accessResendInterval = 10 * time.Minute
+
+	// accessLinkValidity matches the validity of reminder auth tokens
+	accessLinkValidity = 24 * time.Hour
+)
+
+// ErrAccessResendMethod is returned for an unknown method or codes without external signing
+var ErrAccessResendMethod = errors.New("unsupported access resend method")
+
+// accessResendSignerRepository reads the expected signers of a document with their status
+type accessResendSignerRepository interface {
+	ListWithStatusByDocID(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
+}
+
+// accessResendLog records the emails sent to signers and throttles recipients
+type accessResendLog interface {
+	LogReminder(ctx context.Context, log *models.ReminderLog) error
+	GetLastReminderByEmail(ctx context.Context, docID, email string) (*models.ReminderLog, error)
+}
+
+// accessResendDocuments reads the title of the document shown in the email
+type accessResendDocuments interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// accessCodeSender emails a one-time code of the external signing page
+type accessCodeSender interface {
+	ResendCode(ctx context.Context, docID, emailAddr, ip, locale string) error
+}
+
+// AccessResendService sends fresh magic links, or one-time codes, to signers of a document who
+// say they never got their email. Each recipient is throttled and gets their own outcome.
+type AccessResendService struct {
+	signers    accessResendSignerRepository
+	log        accessResendLog
+	documents  accessResendDocuments
+	queue      emailQueueRepository
+	magicLinks asyncMagicLinkService
+	codes      accessCodeSender
+	i18n       translator
+	locales    recipientLocaleResolver
+	baseURL    string
+	now        func() time.Time
+}
+
+// NewAccessResendService creates an access resend service queuing magic links
+func NewAccessResendService(
+	signers accessResendSignerRepository,
+	log accessResendLog,
+	documents accessResendDocuments,
+	queue emailQueueRepository,
+	magicLinks asyncMagicLinkService,
+	i18nService translator,
+	baseURL string,
+) *AccessResendService {
+	return &AccessResendService{
+		signers:    signers,
+		log:        log,
+		documents:  documents,
+		queue:      queue,
+		magicLinks: magicLinks,
+		i18n:       i18nService,
+		baseURL:    baseURL,
+		now:        time.Now,
+	}
+}
+
+// SetCodeSender enables the code method, for documents open to external signers (optional)
+func (s *AccessResendService) SetCodeSender(codes accessCodeSender) {
+	s.codes = codes
+}
+
+// SetLocaleResolver sends each email in the preferred locale of its recipient (optional)
+func (s *AccessResendService) SetLocaleResolver(locales recipientLocaleResolver) {
+	s.locales = locales
+}
+
+// Resend sends fresh access to the listed signers of a document, or to all pending signers when
+// emails is empty. Signers who already signed or wait for their turn, unknown recipients and
+// recipients emailed less than 10 minutes ago are skipped. ip is recorded with the codes.
+func (s *AccessResendService) Resend(ctx context.Context, docID string, emails []string, method, sentBy, ip, locale string) (*models.AccessResendResult, error) {
+	switch method {
+	case "", models.AccessResendMagicLink:
+		method = models.AccessResendMagicLink
+	case models.AccessResendCode:
+		if s.codes == nil {
+			return nil, ErrAccessResendMethod
+		}
+	default:
+		return nil, ErrAccessResendMethod
+	}
+
+	doc, err := s.documents.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	signers, err := s.signers.ListWithStatusByDocID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expected signers: %w", err)
+	}
+	models.ApplySigningTurns(signers)
+	byEmail := make(map[string]*models.ExpectedSignerWithStatus, len(signers))
+	for _, signer := range signers {
+		byEmail[strings.ToLower(signer.Email)] = signer
+	}
+
+	if len(emails) == 0 {
+		for _, signer := range signers {
+			if !signer.HasSigned && signer.Turn != models.SigningTurnWaiting {
+				emails = append(emails, signer.Email)
+			}
+		}
+	}
+
+	result := &models.AccessResendResult{Method: method, Recipients: []models.AccessResendRecipient{}}
+	seen := make(map[string]bool, len(emails))
+	for _, addr := range emails {
+		addr = strings.ToLower(strings.TrimSpace(addr))
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+
+		recipient := s.resendOne(ctx, doc, byEmail[addr], addr, method, sentBy, ip, locale)
+		switch recipient.Status {
+		case models.AccessResendQueued, models.AccessResendSent:
+			result.Sent++
+		case models.AccessResendFailed:
+			result.Failed++
+		default:
+			result.Skipped++
+		}
+		result.Recipients = append(result.Recipients, recipient)
+	}
+
+	logger.Logger.Info("Access resend completed",
+		"doc_id", docID,
+		"method", method,
+		"sent_by", sentBy,
+		"sent", result.Sent,
+		"skipped", result.Skipped,
+		"failed", result.Failed)
+	return result, nil
+}
+
+// resendOne checks and throttles one recipient, then sends them access
+func (s *AccessResendService) resendOne(ctx context.Context, doc *models.Document, signer *models.ExpectedSignerWithStatus, addr, method, sentBy, ip, locale string) models.AccessResendRecipient {
+	recipient := models.AccessResendRecipient{Email: addr}
+	switch {
+	case signer == nil:
+		recipient.Status = models.AccessResendNotExpected
+		return recipient
+	case signer.HasSigned:
+		recipient.Status = models.AccessResendSigned
+		return recipient
+	case signer.Turn == models.SigningTurnWaiting:
+		recipient.Status = models.AccessResendWaiting
+		return recipient
+	}
+
+	last, err := s.log.GetLastReminderByEmail(ctx, doc.DocID, addr)
+	if err != nil {
+		recipient.Status, recipient.Error = models.AccessResendFailed, "throttle check failed"
+		logger.Logger.Error("Failed to check access resend throttle", "doc_id", doc.DocID, "email", addr, "error", err.Error())
+		return recipient
+	}
+	if last != nil && last.Status != "failed" {
+		if retryAfter := last.SentAt.Add(accessResendInterval); s.now().Before(retryAfter) {
+			recipient.Status, recipient.RetryAfter = models.AccessResendThrottled, &retryAfter
+			return recipient
+		}
+	}
+
+	if s.locales != nil {
+		locale = s.locales.ResolveLocale(ctx, addr, locale)
+	}
+	if locale == "" {
+		locale = "en"
+	}
+
+	template := AccessLinkTemplate
+	if method == models.AccessResendCode {
+		template = "external_signer_code"
+		err = s.codes.ResendCode(ctx, doc.DocID, addr, ip, locale)
+		recipient.Status = models.AccessResendSent
+	} else {
+		err = s.queueLink(ctx, doc, signer, locale, sentBy)
+		recipient.Status = models.AccessResendQueued
+	}
+
+	entry := &models.ReminderLog{
+		DocID:          doc.DocID,
+		RecipientEmail: addr,
+		SentAt:         s.now(),
+		SentBy:         sentBy,
+		TemplateUsed:   template,
+		Status:         recipient.Status,
+	}
+	if err != nil {
+		recipient.Status, recipient.Error = models.AccessResendFailed, err.Error()
+		entry.Status = "failed"
+		entry.ErrorMessage = &recipient.Error
+		logger.Logger.Warn("Failed to resend access", "doc_id", doc.DocID, "email", addr, "method", method, "error", err.Error())
+	}
+	if logErr := s.log.LogReminder(ctx, entry); logErr != nil {
+		logger.Logger.Error("Failed to log access resend", "doc_id", doc.DocID, "email", addr, "error", logErr.Error())
+	}
+	return recipient
+}
+
+// queueLink creates a reminder auth token and queues the email carrying it
+func (s *AccessResendService) queueLink(ctx context.Context, doc *models.Document, signer *models.ExpectedSignerWithStatus, locale, sentBy string) error {
+	token, err := s.magicLinks.CreateReminderAuthToken(ctx, signer.Email, doc.DocID)
+	if err != nil {
+		return fmt.Errorf("failed to create auth token: %w", err)
+	}
+
+	subject := "Your new access link"
+	if s.i18n != nil {
+		subject = s.i18n.T(locale, "email.access_link.subject")
+	}
+	docTitle := doc.Title
+	if docTitle == "" {
+		docTitle = doc.DocID
+	}
+
+	refType := "access_link"
+	_, err = s.queue.Enqueue(ctx, models.EmailQueueInput{
+		ToAddresses: []string{signer.Email},
+		Subject:     subject,
+		Template:    AccessLinkTemplate,
+		Locale:      locale,
+		Data: map[string]interface{}{
+			"DocID":         doc.DocID,
+			"DocTitle":      docTitle,
+			"RecipientName": signer.Name,
+			"MagicLink":     fmt.Sprintf("%s/api/v1/auth/reminder-link/verify?token=%s", s.baseURL, token),
+			"ExpiresIn":     int(accessLinkValidity.Hours()),
+			"BaseURL":       s.baseURL,
+		},
+		Priority:      models.EmailPriorityHigh,
+		ReferenceType: &refType,
+		ReferenceID:   &doc.DocID,
+		CreatedBy:     &sentBy,
+		MaxRetries:    5,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	return nil
+}
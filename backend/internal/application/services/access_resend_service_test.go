@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAccessResendSigners struct {
+	signers []*models.ExpectedSignerWithStatus
+}
+
+func (f *fakeAccessResendSigners) ListWithStatusByDocID(_ context.Context, _ string) ([]*models.ExpectedSignerWithStatus, error) {
+	return f.signers, nil
+}
+
+type fakeAccessResendLog struct {
+	logs []*models.ReminderLog
+}
+
+func (f *fakeAccessResendLog) LogReminder(_ context.Context, log *models.ReminderLog) error {
+	f.logs = append(f.logs, log)
+	return nil
+}
+
+func (f *fakeAccessResendLog) GetLastReminderByEmail(_ context.Context, docID, email string) (*models.ReminderLog, error) {
+	var last *models.ReminderLog
+	for _, log := range f.logs {
+		if log.DocID == docID && log.RecipientEmail == email {
+			last = log
+		}
+	}
+	return last, nil
+}
+
+type fakeAccessResendQueue struct {
+	inputs []models.EmailQueueInput
+}
+
+func (f *fakeAccessResendQueue) Enqueue(_ context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error) {
+	f.inputs = append(f.inputs, input)
+	return &models.EmailQueueItem{ID: int64(len(f.inputs))}, nil
+}
+
+func (f *fakeAccessResendQueue) GetQueueStats(_ context.Context) (*models.EmailQueueStats, error) {
+	return &models.EmailQueueStats{}, nil
+}
+
+type fakeAccessResendTokens struct {
+	err error
+}
+
+func (f *fakeAccessResendTokens) CreateReminderAuthToken(_ context.Context, email, docID string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return "token-" + email, nil
+}
+
+type fakeAccessCodeSender struct {
+	sent []string
+}
+
+func (f *fakeAccessCodeSender) ResendCode(_ context.Context, _, emailAddr, _, _ string) error {
+	f.sent = append(f.sent, emailAddr)
+	return nil
+}
+
+func accessResendSigner(email string, signed bool, order *int) *models.ExpectedSignerWithStatus {
+	return &models.ExpectedSignerWithStatus{
+		ExpectedSigner: models.ExpectedSigner{DocID: "doc-1", Email: email, SignOrder: order},
+		HasSigned:      signed,
+	}
+}
+
+func newTestAccessResendService(signers []*models.ExpectedSignerWithStatus) (*AccessResendService, *fakeAccessResendLog, *fakeAccessResendQueue) {
+	log := &fakeAccessResendLog{}
+	queue := &fakeAccessResendQueue{}
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc-1": {DocID: "doc-1", Title: "Policy"}}}
+	service := NewAccessResendService(&fakeAccessResendSigners{signers: signers}, log, docs, queue,
+		&fakeAccessResendTokens{}, nil, "https://sign.example.com")
+	return service, log, queue
+}
+
+func TestAccessResendService_Resend(t *testing.T) {
+	first, second := 1, 2
+	service, log, queue := newTestAccessResendService([]*models.ExpectedSignerWithStatus{
+		accessResendSigner("alice@example.com", false, nil),
+		accessResendSigner("bob@example.com", true, nil),
+		accessResendSigner("carol@example.com", false, &first),
+		accessResendSigner("dave@example.com", false, &second),
+	})
+	ctx := context.Background()
+
+	result, err := service.Resend(ctx, "doc-1",
+		[]string{"Alice@example.com", "alice@example.com", "bob@example.com", "dave@example.com", "eve@example.com"},
+		"", "admin@example.com", "", "fr")
+	if err != nil {
+		t.Fatalf("resend err: %v", err)
+	}
+	if result.Method != models.AccessResendMagicLink || result.Sent != 1 || result.Skipped != 3 || result.Failed != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	want := []string{models.AccessResendQueued, models.AccessResendSigned, models.AccessResendWaiting, models.AccessResendNotExpected}
+	for i, status := range want {
+		if result.Recipients[i].Status != status {
+			t.Errorf("recipient %d: expected %s, got %+v", i, status, result.Recipients[i])
+		}
+	}
+
+	if len(queue.inputs) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(queue.inputs))
+	}
+	input := queue.inputs[0]
+	link, _ := input.Data["MagicLink"].(string)
+	if input.Template != AccessLinkTemplate || input.Locale != "fr" || !strings.HasSuffix(link, "/reminder-link/verify?token=token-alice@example.com") {
+		t.Errorf("unexpected queued email: %+v", input)
+	}
+	if len(log.logs) != 1 || log.logs[0].TemplateUsed != AccessLinkTemplate || log.logs[0].SentBy != "admin@example.com" {
+		t.Errorf("expected the resend in the reminder history, got %+v", log.logs)
+	}
+
+	// A second resend right away is throttled
+	result, err = service.Resend(ctx, "doc-1", []string{"alice@example.com"}, models.AccessResendMagicLink, "admin@example.com", "", "fr")
+	if err != nil {
+		t.Fatalf("resend err: %v", err)
+	}
+	if got := result.Recipients[0]; got.Status != models.AccessResendThrottled || got.RetryAfter == nil {
+		t.Errorf("expected a throttled recipient, got %+v", got)
+	}
+
+	service.now = func() time.Time { return time.Now().Add(accessResendInterval) }
+	result, _ = service.Resend(ctx, "doc-1", []string{"alice@example.com"}, models.AccessResendMagicLink, "admin@example.com", "", "fr")
+	if result.Sent != 1 {
+		t.Errorf("expected a resend once the interval elapsed, got %+v", result)
+	}
+}
+
+func TestAccessResendService_ResendAllPending(t *testing.T) {
+	service, _, queue := newTestAccessResendService([]*models.ExpectedSignerWithStatus{
+		accessResendSigner("alice@example.com", false, nil),
+		accessResendSigner("bob@example.com", true, nil),
+		accessResendSigner("carol@example.com", false, nil),
+	})
+
+	result, err := service.Resend(context.Background(), "doc-1", nil, "", "admin@example.com", "", "en")
+	if err != nil {
+		t.Fatalf("resend err: %v", err)
+	}
+	if result.Sent != 2 || len(result.Recipients) != 2 || len(queue.inputs) != 2 {
+		t.Errorf("expected the 2 pending signers, got %+v", result)
+	}
+}
+
+func TestAccessResendService_Codes(t *testing.T) {
+	service, log, queue := newTestAccessResendService([]*models.ExpectedSignerWithStatus{
+		accessResendSigner("alice@example.com", false, nil),
+	})
+	ctx := context.Background()
+
+	if _, err := service.Resend(ctx, "doc-1", nil, models.AccessResendCode, "admin@example.com", "", "en"); !errors.Is(err, ErrAccessResendMethod) {
+		t.Fatalf("expected ErrAccessResendMethod without a code sender, got %v", err)
+	}
+
+	codes := &fakeAccessCodeSender{}
+	service.SetCodeSender(codes)
+	result, err := service.Resend(ctx, "doc-1", nil, models.AccessResendCode, "admin@example.com", "10.0.0.1", "en")
+	if err != nil {
+		t.Fatalf("resend err: %v", err)
+	}
+	if result.Recipients[0].Status != models.AccessResendSent || len(codes.sent) != 1 || len(queue.inputs) != 0 {
+		t.Errorf("expected a code sent, got %+v", result)
+	}
+	if log.logs[0].TemplateUsed != "external_signer_code" {
+		t.Errorf("unexpected logged template: %s", log.logs[0].TemplateUsed)
+	}
+}
+
+func TestAccessResendService_Failures(t *testing.T) {
+	service, log, _ := newTestAccessResendService([]*models.ExpectedSignerWithStatus{
+		accessResendSigner("alice@example.com", false, nil),
+	})
+	service.magicLinks = &fakeAccessResendTokens{err: errors.New("db down")}
+	ctx := context.Background()
+
+	result, err := service.Resend(ctx, "doc-1", nil, "", "admin@example.com", "", "en")
+	if err != nil {
+		t.Fatalf("resend err: %v", err)
+	}
+	if result.Failed != 1 || result.Recipients[0].Error == "" || log.logs[0].Status != "failed" {
+		t.Errorf("expected a failed recipient, got %+v", result)
+	}
+
+	// A failed attempt does not throttle the next one
+	service.magicLinks = &fakeAccessResendTokens{}
+	if result, _ = service.Resend(ctx, "doc-1", nil, "", "admin@example.com", "", "en"); result.Sent != 1 {
+		t.Errorf("expected a retry after a failure, got %+v", result)
+	}
+
+	if _, err := service.Resend(ctx, "missing", nil, "", "admin@example.com", "", "en"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+	if _, err := service.Resend(ctx, "doc-1", nil, "sms", "admin@example.com", "", "en"); !errors.Is(err, ErrAccessResendMethod) {
+		t.Errorf("expected ErrAccessResendMethod, got %v", err)
+	}
+}
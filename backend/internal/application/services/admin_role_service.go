@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidRole is returned when a role assignment fails validation
+var ErrInvalidRole = errors.New("invalid role assignment")
+
+// ClaimRoleGrantor is recorded as the grantor of roles derived from an OIDC claim
+const ClaimRoleGrantor = "oidc-claim"
+
+// adminRoleRepository defines delegated admin role storage operations
+type adminRoleRepository interface {
+	Upsert(ctx context.Context, email string, role models.AdminRole, departmentID *int64, grantedBy string) (*models.AdminRoleAssignment, error)
+	GetByEmail(ctx context.Context, email string) (*models.AdminRoleAssignment, error)
+	List(ctx context.Context) ([]*models.AdminRoleAssignment, error)
+	Delete(ctx context.Context, email string) error
+}
+
+// roleDepartmentReader checks the departments of department-admins
+type roleDepartmentReader interface {
+	GetByID(ctx context.Context, id int64) (*models.Department, error)
+}
+
+// AdminRoleService manages delegated admin roles
+type AdminRoleService struct {
+	repo        adminRoleRepository
+	departments roleDepartmentReader
+}
+
+// NewAdminRoleService creates a new admin role service
+func NewAdminRoleService(repo adminRoleRepository) *AdminRoleService {
+	return &AdminRoleService{repo: repo}
+}
+
+// SetDepartments enables the department-admin role
+func (s *AdminRoleService) SetDepartments(departments roleDepartmentReader) {
+	s.departments = departments
+}
+
+// ListRoles returns all delegated role assignments
+func (s *AdminRoleService) ListRoles(ctx context.Context) ([]*models.AdminRoleAssignment, error) {
+	return s.repo.List(ctx)
+}
+
+// AssignRole grants a role to a user, replacing any previous role. departmentID is required
+// for department-admins and rejected for other roles.
+func (s *AdminRoleService) AssignRole(ctx context.Context, email string, role models.AdminRole, departmentID *int64, grantedBy string) (*models.AdminRoleAssignment, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("%w: invalid email", ErrInvalidRole)
+	}
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidRole, role)
+	}
+	if strings.EqualFold(email, grantedBy) {
+		return nil, fmt.Errorf("%w: cannot change your own role", ErrInvalidRole)
+	}
+	if err := s.checkDepartment(ctx, role, departmentID); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Assigning admin role", "email", email, "role", role, "department_id", departmentID, "granted_by", grantedBy)
+	return s.repo.Upsert(ctx, email, role, departmentID, grantedBy)
+}
+
+func (s *AdminRoleService) checkDepartment(ctx context.Context, role models.AdminRole, departmentID *int64) error {
+	if !role.IsDepartmentScoped() {
+		if departmentID != nil {
+			return fmt.Errorf("%w: only department-admins belong to a department", ErrInvalidRole)
+		}
+		return nil
+	}
+	if departmentID == nil {
+		return fmt.Errorf("%w: a department is required for department-admins", ErrInvalidRole)
+	}
+	if s.departments == nil {
+		return fmt.Errorf("%w: departments are not enabled", ErrInvalidRole)
+	}
+	if _, err := s.departments.GetByID(ctx, *departmentID); err != nil {
+		if errors.Is(err, models.ErrDepartmentNotFound) {
+			return fmt.Errorf("%w: unknown department", ErrInvalidRole)
+		}
+		return err
+	}
+	return nil
+}
+
+// RevokeRole removes the delegated role of a user
+func (s *AdminRoleService) RevokeRole(ctx context.Context, email, revokedBy string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if strings.EqualFold(email, revokedBy) {
+		return fmt.Errorf("%w: cannot revoke your own role", ErrInvalidRole)
+	}
+
+	logger.Logger.Info("Revoking admin role", "email", email, "revoked_by", revokedBy)
+	return s.repo.Delete(ctx, email)
+}
+
+// SyncClaimRole aligns the role of a user with the admin claim of their OIDC token.
+// A matching claim grants super-admin; a role previously granted by the claim is revoked
+// once the claim no longer matches. Roles assigned by an admin are left untouched.
+func (s *AdminRoleService) SyncClaimRole(ctx context.Context, email string, isAdmin bool) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil
+	}
+
+	current, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if isAdmin {
+		if current != nil && current.Role == models.AdminRoleSuperAdmin {
+			return nil
+		}
+		logger.Logger.Info("Granting admin role from OIDC claim", "email", email)
+		_, err := s.repo.Upsert(ctx, email, models.AdminRoleSuperAdmin, nil, ClaimRoleGrantor)
+		return err
+	}
+
+	if current == nil || current.GrantedBy != ClaimRoleGrantor {
+		return nil
+	}
+	logger.Logger.Info("Revoking admin role no longer granted by OIDC claim", "email", email)
+	return s.repo.Delete(ctx, email)
+}
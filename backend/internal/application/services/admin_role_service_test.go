@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAdminRoleRepo struct {
+	roles    map[string]models.AdminRole
+	grantors map[string]string
+}
+
+func (f *fakeAdminRoleRepo) Upsert(_ context.Context, email string, role models.AdminRole, _ *int64, grantedBy string) (*models.AdminRoleAssignment, error) {
+	f.roles[email] = role
+	if f.grantors == nil {
+		f.grantors = make(map[string]string)
+	}
+	f.grantors[email] = grantedBy
+	return &models.AdminRoleAssignment{Email: email, Role: role, GrantedBy: grantedBy}, nil
+}
+
+func (f *fakeAdminRoleRepo) GetByEmail(_ context.Context, email string) (*models.AdminRoleAssignment, error) {
+	role, ok := f.roles[email]
+	if !ok {
+		return nil, nil
+	}
+	return &models.AdminRoleAssignment{Email: email, Role: role, GrantedBy: f.grantors[email]}, nil
+}
+
+func (f *fakeAdminRoleRepo) List(_ context.Context) ([]*models.AdminRoleAssignment, error) {
+	out := make([]*models.AdminRoleAssignment, 0, len(f.roles))
+	for email, role := range f.roles {
+		out = append(out, &models.AdminRoleAssignment{Email: email, Role: role})
+	}
+	return out, nil
+}
+
+func (f *fakeAdminRoleRepo) Delete(_ context.Context, email string) error {
+	if _, ok := f.roles[email]; !ok {
+		return models.ErrRoleNotFound
+	}
+	delete(f.roles, email)
+	return nil
+}
+
+func TestAdminRoleService_AssignRole(t *testing.T) {
+	repo := &fakeAdminRoleRepo{roles: make(map[string]models.AdminRole)}
+	svc := NewAdminRoleService(repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		email   string
+		role    models.AdminRole
+		wantErr error
+	}{
+		{"valid assignment", " Viewer@Example.com ", models.AdminRoleViewer, nil},
+		{"invalid email", "not-an-email", models.AdminRoleViewer, ErrInvalidRole},
+		{"unknown role", "user@example.com", "owner", ErrInvalidRole},
+		{"self assignment", "admin@example.com", models.AdminRoleViewer, ErrInvalidRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AssignRole(ctx, tt.email, tt.role, nil, "admin@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if repo.roles["viewer@example.com"] != models.AdminRoleViewer {
+		t.Errorf("expected normalized email to be stored, got %v", repo.roles)
+	}
+}
+
+func TestAdminRoleService_RevokeRole(t *testing.T) {
+	repo := &fakeAdminRoleRepo{roles: map[string]models.AdminRole{"viewer@example.com": models.AdminRoleViewer}}
+	svc := NewAdminRoleService(repo)
+	ctx := context.Background()
+
+	if err := svc.RevokeRole(ctx, "admin@example.com", "Admin@example.com"); !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("expected ErrInvalidRole for self revocation, got %v", err)
+	}
+	if err := svc.RevokeRole(ctx, "VIEWER@example.com", "admin@example.com"); err != nil {
+		t.Fatalf("RevokeRole error: %v", err)
+	}
+	if err := svc.RevokeRole(ctx, "viewer@example.com", "admin@example.com"); !errors.Is(err, models.ErrRoleNotFound) {
+		t.Fatalf("expected ErrRoleNotFound, got %v", err)
+	}
+}
+
+func TestAdminRoleService_SyncClaimRole(t *testing.T) {
+	repo := &fakeAdminRoleRepo{
+		roles:    map[string]models.AdminRole{"manual@example.com": models.AdminRoleViewer},
+		grantors: map[string]string{"manual@example.com": "admin@example.com"},
+	}
+	svc := NewAdminRoleService(repo)
+	ctx := context.Background()
+
+	if err := svc.SyncClaimRole(ctx, "Claim@Example.com", true); err != nil {
+		t.Fatalf("SyncClaimRole error: %v", err)
+	}
+	if repo.roles["claim@example.com"] != models.AdminRoleSuperAdmin || repo.grantors["claim@example.com"] != ClaimRoleGrantor {
+		t.Fatalf("expected claim grant, got role=%v grantor=%q", repo.roles["claim@example.com"], repo.grantors["claim@example.com"])
+	}
+
+	if err := svc.SyncClaimRole(ctx, "claim@example.com", false); err != nil {
+		t.Fatalf("SyncClaimRole error: %v", err)
+	}
+	if _, ok := repo.roles["claim@example.com"]; ok {
+		t.Error("expected claim-granted role to be revoked")
+	}
+
+	// Roles assigned by an admin are not revoked by a missing claim
+	if err := svc.SyncClaimRole(ctx, "manual@example.com", false); err != nil {
+		t.Fatalf("SyncClaimRole error: %v", err)
+	}
+	if repo.roles["manual@example.com"] != models.AdminRoleViewer {
+		t.Errorf("expected manual role to be kept, got %v", repo.roles["manual@example.com"])
+	}
+}
+
+func TestAdminRoleService_AssignDepartmentAdmin(t *testing.T) {
+	repo := &fakeAdminRoleRepo{roles: make(map[string]models.AdminRole)}
+	departments := newFakeDepartmentRepo()
+	sales, _ := departments.Create(context.Background(), models.DepartmentInput{Name: "Sales"})
+	svc := NewAdminRoleService(repo)
+	svc.SetDepartments(departments)
+	ctx := context.Background()
+
+	unknown := int64(42)
+	tests := []struct {
+		name         string
+		role         models.AdminRole
+		departmentID *int64
+		wantErr      error
+	}{
+		{"department-admin", models.AdminRoleDepartmentAdmin, &sales.ID, nil},
+		{"missing department", models.AdminRoleDepartmentAdmin, nil, ErrInvalidRole},
+		{"unknown department", models.AdminRoleDepartmentAdmin, &unknown, ErrInvalidRole},
+		{"department on another role", models.AdminRoleViewer, &sales.ID, ErrInvalidRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AssignRole(ctx, "user@example.com", tt.role, tt.departmentID, "admin@example.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -13,8 +13,13 @@ type adminDocumentRepository interface {
 	List(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
 	Count(ctx context.Context, searchQuery string) (int, error)
+	ListByCreatedBy(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
+	SearchByCreatedBy(ctx context.Context, createdBy, searchQuery string, limit, offset int) ([]*models.Document, error)
+	CountByCreatedBy(ctx context.Context, createdBy, searchQuery string) (int, error)
 	CreateOrUpdate(ctx context.Context, docID string, input models.DocumentInput, createdBy string) (*models.Document, error)
+	Patch(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error)
 	Delete(ctx context.Context, docID string) error
+	GetStatus(ctx context.Context, docID string) (*models.DocumentStatus, error)
 }
 
 // adminSignerRepository defines admin-specific expected signer operations
@@ -57,21 +62,54 @@ func (s *AdminService) CountDocuments(ctx context.Context, searchQuery string) (
 	return s.docRepo.Count(ctx, searchQuery)
 }
 
+// ListDocumentsByCreator restricts the document list to documents owned by createdBy
+func (s *AdminService) ListDocumentsByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error) {
+	return s.docRepo.ListByCreatedBy(ctx, createdBy, limit, offset)
+}
+
+func (s *AdminService) SearchDocumentsByCreator(ctx context.Context, createdBy, query string, limit, offset int) ([]*models.Document, error) {
+	return s.docRepo.SearchByCreatedBy(ctx, createdBy, query, limit, offset)
+}
+
+func (s *AdminService) CountDocumentsByCreator(ctx context.Context, createdBy, searchQuery string) (int, error) {
+	return s.docRepo.CountByCreatedBy(ctx, createdBy, searchQuery)
+}
+
 func (s *AdminService) UpdateDocumentMetadata(ctx context.Context, docID string, input models.DocumentInput, updatedBy string) (*models.Document, error) {
 	return s.docRepo.CreateOrUpdate(ctx, docID, input, updatedBy)
 }
 
+// PatchDocumentMetadata persists only the provided fields, optionally guarded by the expected document version
+func (s *AdminService) PatchDocumentMetadata(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+	return s.docRepo.Patch(ctx, docID, patch, expectedVersion)
+}
+
 func (s *AdminService) DeleteDocument(ctx context.Context, docID string) error {
 	return s.docRepo.Delete(ctx, docID)
 }
 
+// GetDocumentStatus loads the document, its signers, unexpected signatures and stats in one query
+func (s *AdminService) GetDocumentStatus(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+	status, err := s.docRepo.GetStatus(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	models.ApplySigningTurns(status.Signers)
+	return status, nil
+}
+
 // Expected signer operations
 func (s *AdminService) ListExpectedSigners(ctx context.Context, docID string) ([]*models.ExpectedSigner, error) {
 	return s.signerRepo.ListByDocID(ctx, docID)
 }
 
 func (s *AdminService) ListExpectedSignersWithStatus(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error) {
-	return s.signerRepo.ListWithStatusByDocID(ctx, docID)
+	signers, err := s.signerRepo.ListWithStatusByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	models.ApplySigningTurns(signers)
+	return signers, nil
 }
 
 func (s *AdminService) AddExpectedSigners(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error {
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// maxAnnouncementLength bounds the message of a banner
+const maxAnnouncementLength = 1000
+
+// ErrInvalidAnnouncement is returned when announcement input fails validation
+var ErrInvalidAnnouncement = errors.New("invalid announcement")
+
+// announcementRepository defines announcement storage operations
+type announcementRepository interface {
+	Create(ctx context.Context, input models.AnnouncementInput) (*models.Announcement, error)
+	Update(ctx context.Context, id int64, input models.AnnouncementInput) (*models.Announcement, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.Announcement, error)
+	List(ctx context.Context) ([]*models.Announcement, error)
+	ListActive(ctx context.Context, at time.Time) ([]*models.Announcement, error)
+}
+
+// AnnouncementService manages the banners admins publish to the users of their tenant
+type AnnouncementService struct {
+	repo announcementRepository
+	now  func() time.Time
+}
+
+// NewAnnouncementService creates a new announcement service
+func NewAnnouncementService(repo announcementRepository) *AnnouncementService {
+	return &AnnouncementService{repo: repo, now: time.Now}
+}
+
+// CreateAnnouncement validates and stores a new announcement
+func (s *AnnouncementService) CreateAnnouncement(ctx context.Context, input models.AnnouncementInput) (*models.Announcement, error) {
+	if err := validateAnnouncement(&input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Creating announcement", "severity", input.Severity, "audience", input.Audience, "created_by", input.CreatedBy)
+	return s.repo.Create(ctx, input)
+}
+
+// UpdateAnnouncement validates and updates an announcement
+func (s *AnnouncementService) UpdateAnnouncement(ctx context.Context, id int64, input models.AnnouncementInput) (*models.Announcement, error) {
+	if err := validateAnnouncement(&input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Updating announcement", "id", id, "severity", input.Severity, "audience", input.Audience)
+	return s.repo.Update(ctx, id, input)
+}
+
+// DeleteAnnouncement deletes an announcement
+func (s *AnnouncementService) DeleteAnnouncement(ctx context.Context, id int64) error {
+	logger.Logger.Info("Deleting announcement", "id", id)
+	return s.repo.Delete(ctx, id)
+}
+
+// GetAnnouncement retrieves an announcement by ID
+func (s *AnnouncementService) GetAnnouncement(ctx context.Context, id int64) (*models.Announcement, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListAnnouncements retrieves all announcements, including scheduled and expired ones
+func (s *AnnouncementService) ListAnnouncements(ctx context.Context) ([]*models.Announcement, error) {
+	return s.repo.List(ctx)
+}
+
+// ListActive retrieves the announcements currently published to a viewer
+func (s *AnnouncementService) ListActive(ctx context.Context, authenticated, admin bool) ([]*models.Announcement, error) {
+	announcements, err := s.repo.ListActive(ctx, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make([]*models.Announcement, 0, len(announcements))
+	for _, a := range announcements {
+		if a.VisibleTo(authenticated, admin) {
+			visible = append(visible, a)
+		}
+	}
+	return visible, nil
+}
+
+func validateAnnouncement(input *models.AnnouncementInput) error {
+	input.Message = strings.TrimSpace(input.Message)
+	if input.Severity == "" {
+		input.Severity = models.AnnouncementInfo
+	}
+	if input.Audience == "" {
+		input.Audience = models.AudienceAll
+	}
+
+	if input.Message == "" || utf8.RuneCountInString(input.Message) > maxAnnouncementLength {
+		return fmt.Errorf("%w: message is required (max %d characters)", ErrInvalidAnnouncement, maxAnnouncementLength)
+	}
+	if !input.Severity.IsValid() {
+		return fmt.Errorf("%w: unknown severity %s", ErrInvalidAnnouncement, input.Severity)
+	}
+	if !input.Audience.IsValid() {
+		return fmt.Errorf("%w: unknown audience %s", ErrInvalidAnnouncement, input.Audience)
+	}
+	if input.StartsAt != nil && input.EndsAt != nil && !input.EndsAt.After(*input.StartsAt) {
+		return fmt.Errorf("%w: endsAt must be after startsAt", ErrInvalidAnnouncement)
+	}
+	return nil
+}
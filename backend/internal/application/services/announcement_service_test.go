@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAnnouncementRepo struct {
+	announcements map[int64]*models.Announcement
+}
+
+func newFakeAnnouncementRepo() *fakeAnnouncementRepo {
+	return &fakeAnnouncementRepo{announcements: make(map[int64]*models.Announcement)}
+}
+
+func (f *fakeAnnouncementRepo) Create(_ context.Context, input models.AnnouncementInput) (*models.Announcement, error) {
+	a := &models.Announcement{
+		ID:        int64(len(f.announcements) + 1),
+		Severity:  input.Severity,
+		Message:   input.Message,
+		Audience:  input.Audience,
+		StartsAt:  input.StartsAt,
+		EndsAt:    input.EndsAt,
+		CreatedBy: input.CreatedBy,
+	}
+	f.announcements[a.ID] = a
+	return a, nil
+}
+
+func (f *fakeAnnouncementRepo) Update(_ context.Context, id int64, input models.AnnouncementInput) (*models.Announcement, error) {
+	a, ok := f.announcements[id]
+	if !ok {
+		return nil, models.ErrAnnouncementNotFound
+	}
+	a.Severity, a.Message, a.Audience, a.StartsAt, a.EndsAt = input.Severity, input.Message, input.Audience, input.StartsAt, input.EndsAt
+	return a, nil
+}
+
+func (f *fakeAnnouncementRepo) Delete(_ context.Context, id int64) error {
+	if _, ok := f.announcements[id]; !ok {
+		return models.ErrAnnouncementNotFound
+	}
+	delete(f.announcements, id)
+	return nil
+}
+
+func (f *fakeAnnouncementRepo) GetByID(_ context.Context, id int64) (*models.Announcement, error) {
+	a, ok := f.announcements[id]
+	if !ok {
+		return nil, models.ErrAnnouncementNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeAnnouncementRepo) List(_ context.Context) ([]*models.Announcement, error) {
+	list := make([]*models.Announcement, 0, len(f.announcements))
+	for id := int64(1); id <= int64(len(f.announcements)); id++ {
+		if a, ok := f.announcements[id]; ok {
+			list = append(list, a)
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeAnnouncementRepo) ListActive(ctx context.Context, at time.Time) ([]*models.Announcement, error) {
+	all, _ := f.List(ctx)
+	active := make([]*models.Announcement, 0, len(all))
+	for _, a := range all {
+		if a.IsActive(at) {
+			active = append(active, a)
+		}
+	}
+	return active, nil
+}
+
+func TestAnnouncementService_CreateValidation(t *testing.T) {
+	svc := NewAnnouncementService(newFakeAnnouncementRepo())
+	ctx := context.Background()
+	start := time.Date(2026, 3, 6, 8, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+
+	a, err := svc.CreateAnnouncement(ctx, models.AnnouncementInput{Message: "  Signing paused for maintenance Friday  "})
+	if err != nil {
+		t.Fatalf("CreateAnnouncement err: %v", err)
+	}
+	if a.Message != "Signing paused for maintenance Friday" || a.Severity != models.AnnouncementInfo || a.Audience != models.AudienceAll {
+		t.Errorf("expected a trimmed info banner for everyone, got %+v", a)
+	}
+
+	invalid := []models.AnnouncementInput{
+		{Message: "   "},
+		{Message: strings.Repeat("a", maxAnnouncementLength+1)},
+		{Message: "hello", Severity: "urgent"},
+		{Message: "hello", Audience: "signers"},
+		{Message: "hello", StartsAt: &start, EndsAt: &before},
+	}
+	for _, input := range invalid {
+		if _, err := svc.CreateAnnouncement(ctx, input); !errors.Is(err, ErrInvalidAnnouncement) {
+			t.Errorf("expected ErrInvalidAnnouncement for %+v, got %v", input, err)
+		}
+	}
+}
+
+func TestAnnouncementService_ListActive(t *testing.T) {
+	repo := newFakeAnnouncementRepo()
+	svc := NewAnnouncementService(repo)
+	now := time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+	later := now.Add(time.Hour)
+
+	for _, input := range []models.AnnouncementInput{
+		{Message: "everyone", Audience: models.AudienceAll},
+		{Message: "users", Audience: models.AudienceAuthenticated},
+		{Message: "admins", Audience: models.AudienceAdmins},
+		{Message: "scheduled", Audience: models.AudienceAll, StartsAt: &later},
+	} {
+		if _, err := svc.CreateAnnouncement(ctx, input); err != nil {
+			t.Fatalf("CreateAnnouncement err: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		authenticated, admin bool
+		want                 []string
+	}{
+		{"anonymous", false, false, []string{"everyone"}},
+		{"user", true, false, []string{"everyone", "users"}},
+		{"admin", true, true, []string{"everyone", "users", "admins"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, err := svc.ListActive(ctx, tt.authenticated, tt.admin)
+			if err != nil {
+				t.Fatalf("ListActive err: %v", err)
+			}
+			var got []string
+			for _, a := range active {
+				got = append(got, a.Message)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
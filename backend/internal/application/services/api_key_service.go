@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	// ErrInvalidAPIKey is returned when a key is unknown, malformed or revoked
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrInvalidAPIKeyInput is returned when an API key creation fails validation
+	ErrInvalidAPIKeyInput = errors.New("invalid api key input")
+)
+
+const (
+	// apiKeyPrefix makes keys recognizable by humans and secret scanners
+	apiKeyPrefix = "ack_"
+	// apiKeyDisplayLength is the length of the key prefix kept to identify a key
+	apiKeyDisplayLength = 12
+	apiKeyMaxNameLength = 100
+)
+
+// apiKeyScopes are the permissions that can be granted to an API key
+var apiKeyScopes = map[models.Permission]bool{
+	models.PermissionDocumentsRead:  true,
+	models.PermissionDocumentsWrite: true,
+	models.PermissionSignersManage:  true,
+	models.PermissionWebhooksManage: true,
+}
+
+// apiKeyRepository defines API key storage operations
+type apiKeyRepository interface {
+	Create(ctx context.Context, name, keyPrefix, keyHash string, scopes []models.Permission, createdBy string) (*models.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	List(ctx context.Context) ([]*models.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	TouchLastUsed(ctx context.Context, id int64) error
+}
+
+// APIKeyService manages the API keys of external integrations
+type APIKeyService struct {
+	repo apiKeyRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo apiKeyRepository) *APIKeyService {
+	return &APIKeyService{repo: repo}
+}
+
+// CreateKey generates a new API key. The returned secret is shown once and never stored.
+func (s *APIKeyService) CreateKey(ctx context.Context, name string, scopes []models.Permission, createdBy string) (*models.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > apiKeyMaxNameLength {
+		return nil, "", fmt.Errorf("%w: name is required (max %d characters)", ErrInvalidAPIKeyInput, apiKeyMaxNameLength)
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKeyInput)
+	}
+	for _, scope := range scopes {
+		if !apiKeyScopes[scope] {
+			return nil, "", fmt.Errorf("%w: scope %q cannot be granted to an api key", ErrInvalidAPIKeyInput, scope)
+		}
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	key, err := s.repo.Create(ctx, name, secret[:apiKeyDisplayLength], hashSecretToken(secret), scopes, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.Logger.Info("API key created", "id", key.ID, "name", name, "scopes", scopes, "created_by", createdBy)
+	return key, secret, nil
+}
+
+// Authenticate resolves the API key matching a secret value
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*models.APIKey, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHash(ctx, hashSecretToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.IsRevoked() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := s.repo.TouchLastUsed(ctx, key.ID); err != nil {
+		logger.Logger.Warn("Failed to record api key usage", "id", key.ID, "error", err.Error())
+	}
+	return key, nil
+}
+
+// ListKeys returns all API keys, including revoked ones
+func (s *APIKeyService) ListKeys(ctx context.Context) ([]*models.APIKey, error) {
+	return s.repo.List(ctx)
+}
+
+// RevokeKey disables an API key
+func (s *APIKeyService) RevokeKey(ctx context.Context, id int64, revokedBy string) error {
+	logger.Logger.Info("Revoking API key", "id", id, "revoked_by", revokedBy)
+	return s.repo.Revoke(ctx, id)
+}
+
+// hashSecretToken returns the hex SHA-256 stored in place of an API key or a preview link token.
+// Both are long random values, so a fast unsalted hash is sufficient.
+func hashSecretToken(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
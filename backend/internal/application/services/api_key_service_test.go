@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAPIKeyRepo struct {
+	keys   map[string]*models.APIKey // by hash
+	nextID int64
+}
+
+func (f *fakeAPIKeyRepo) Create(_ context.Context, name, keyPrefix, keyHash string, scopes []models.Permission, createdBy string) (*models.APIKey, error) {
+	f.nextID++
+	key := &models.APIKey{ID: f.nextID, Name: name, KeyPrefix: keyPrefix, Scopes: scopes, CreatedBy: createdBy}
+	f.keys[keyHash] = key
+	return key, nil
+}
+
+func (f *fakeAPIKeyRepo) GetByHash(_ context.Context, keyHash string) (*models.APIKey, error) {
+	return f.keys[keyHash], nil
+}
+
+func (f *fakeAPIKeyRepo) List(_ context.Context) ([]*models.APIKey, error) {
+	out := make([]*models.APIKey, 0, len(f.keys))
+	for _, key := range f.keys {
+		out = append(out, key)
+	}
+	return out, nil
+}
+
+func (f *fakeAPIKeyRepo) Revoke(_ context.Context, id int64) error {
+	for _, key := range f.keys {
+		if key.ID == id {
+			now := time.Now()
+			key.RevokedAt = &now
+			return nil
+		}
+	}
+	return models.ErrAPIKeyNotFound
+}
+
+func (f *fakeAPIKeyRepo) TouchLastUsed(_ context.Context, id int64) error {
+	return nil
+}
+
+func TestAPIKeyService_CreateKey_Validation(t *testing.T) {
+	svc := NewAPIKeyService(&fakeAPIKeyRepo{keys: make(map[string]*models.APIKey)})
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		key    string
+		scopes []models.Permission
+	}{
+		{"missing name", "", []models.Permission{models.PermissionDocumentsWrite}},
+		{"no scope", "DMS", nil},
+		{"admin-only scope", "DMS", []models.Permission{models.PermissionRolesManage}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := svc.CreateKey(ctx, tt.key, tt.scopes, "admin@example.com"); !errors.Is(err, ErrInvalidAPIKeyInput) {
+				t.Fatalf("expected ErrInvalidAPIKeyInput, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	repo := &fakeAPIKeyRepo{keys: make(map[string]*models.APIKey)}
+	svc := NewAPIKeyService(repo)
+	ctx := context.Background()
+
+	key, secret, err := svc.CreateKey(ctx, "DMS", []models.Permission{models.PermissionDocumentsWrite}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("CreateKey failed: %v", err)
+	}
+	if !strings.HasPrefix(secret, apiKeyPrefix) || !strings.HasPrefix(secret, key.KeyPrefix) {
+		t.Fatalf("secret %q should start with prefix %q", secret, key.KeyPrefix)
+	}
+	if _, stored := repo.keys[secret]; stored {
+		t.Fatal("secret must not be stored in clear")
+	}
+
+	got, err := svc.Authenticate(ctx, secret)
+	if err != nil || got.ID != key.ID {
+		t.Fatalf("expected key %d, got %v (err %v)", key.ID, got, err)
+	}
+
+	if _, err := svc.Authenticate(ctx, apiKeyPrefix+"unknown"); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("expected ErrInvalidAPIKey for unknown key, got %v", err)
+	}
+
+	if err := svc.RevokeKey(ctx, key.ID, "admin@example.com"); err != nil {
+		t.Fatalf("RevokeKey failed: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, secret); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("expected ErrInvalidAPIKey for revoked key, got %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	ErrBrandingStorageDisabled = errors.New("logo upload requires document storage to be configured")
+	ErrInvalidLogo             = errors.New("logo must be a PNG, JPEG, GIF, WebP or SVG image")
+	ErrLogoTooLarge            = errors.New("logo exceeds the maximum size")
+	ErrLogoNotFound            = errors.New("no logo configured")
+)
+
+// MaxLogoSize is the maximum size of an uploaded branding logo
+const MaxLogoSize = 1 << 20
+
+// logoContentTypes lists the accepted logo formats
+var logoContentTypes = map[string]string{
+	"image/png":     ".png",
+	"image/jpeg":    ".jpg",
+	"image/gif":     ".gif",
+	"image/webp":    ".webp",
+	"image/svg+xml": ".svg",
+}
+
+type brandingConfigStore interface {
+	GetConfig() *models.MutableConfig
+	UpdateBrandingLogo(ctx context.Context, key, contentType, updatedBy string) error
+}
+
+type logoStorage interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Download(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// BrandingService manages the organization branding and its logo
+type BrandingService struct {
+	config  brandingConfigStore
+	storage logoStorage
+	baseURL string
+}
+
+// NewBrandingService creates a new branding service. storage may be nil when
+// document storage is disabled, in which case logos cannot be uploaded.
+func NewBrandingService(config brandingConfigStore, storage logoStorage, baseURL string) *BrandingService {
+	return &BrandingService{
+		config:  config,
+		storage: storage,
+		baseURL: baseURL,
+	}
+}
+
+// GetBranding returns the public branding of the instance
+func (s *BrandingService) GetBranding() *models.Branding {
+	cfg := s.config.GetConfig()
+	return &models.Branding{
+		Organisation: cfg.General.Organisation,
+		LogoURL:      cfg.Branding.LogoURL(s.baseURL),
+		PrimaryColor: cfg.Branding.PrimaryColor,
+		EmailFooter:  cfg.Branding.EmailFooter,
+		SupportEmail: cfg.Branding.SupportEmail,
+		SupportURL:   cfg.Branding.SupportURL,
+	}
+}
+
+// UploadLogo validates and stores a new logo, then removes the previous one
+func (s *BrandingService) UploadLogo(ctx context.Context, data []byte, contentType, updatedBy string) error {
+	if s.storage == nil {
+		return ErrBrandingStorageDisabled
+	}
+	if len(data) > MaxLogoSize {
+		return ErrLogoTooLarge
+	}
+
+	contentType, err := detectLogoType(data, contentType)
+	if err != nil {
+		return err
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to generate logo key: %w", err)
+	}
+	key := "branding/logo-" + hex.EncodeToString(suffix) + logoContentTypes[contentType]
+
+	if err := s.storage.Upload(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return fmt.Errorf("failed to store logo: %w", err)
+	}
+
+	previous := s.config.GetConfig().Branding.LogoKey
+	if err := s.config.UpdateBrandingLogo(ctx, key, contentType, updatedBy); err != nil {
+		s.deleteLogo(ctx, key)
+		return err
+	}
+
+	if previous != "" {
+		s.deleteLogo(ctx, previous)
+	}
+	return nil
+}
+
+// DeleteLogo removes the current logo
+func (s *BrandingService) DeleteLogo(ctx context.Context, updatedBy string) error {
+	previous := s.config.GetConfig().Branding.LogoKey
+	if previous == "" {
+		return ErrLogoNotFound
+	}
+
+	if err := s.config.UpdateBrandingLogo(ctx, "", "", updatedBy); err != nil {
+		return err
+	}
+
+	if s.storage != nil {
+		s.deleteLogo(ctx, previous)
+	}
+	return nil
+}
+
+// OpenLogo returns the current logo content, its size and content type
+func (s *BrandingService) OpenLogo(ctx context.Context) (io.ReadCloser, int64, string, error) {
+	branding := s.config.GetConfig().Branding
+	if !branding.HasLogo() || s.storage == nil {
+		return nil, 0, "", ErrLogoNotFound
+	}
+
+	reader, size, contentType, err := s.storage.Download(ctx, branding.LogoKey)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to read logo: %w", err)
+	}
+	if branding.LogoContentType != "" {
+		contentType = branding.LogoContentType
+	}
+	return reader, size, contentType, nil
+}
+
+func (s *BrandingService) deleteLogo(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		logger.Logger.Warn("Failed to delete branding logo", "key", key, "error", err.Error())
+	}
+}
+
+// detectLogoType checks that the declared content type is an accepted image format
+// matching the actual content, and returns the normalized content type.
+func detectLogoType(data []byte, declared string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return "", ErrInvalidLogo
+	}
+	if _, ok := logoContentTypes[mediaType]; !ok {
+		return "", ErrInvalidLogo
+	}
+
+	if mediaType == "image/svg+xml" {
+		// SVG is sniffed as text: only check it actually is an SVG document
+		if !bytes.Contains(data, []byte("<svg")) {
+			return "", ErrInvalidLogo
+		}
+		return mediaType, nil
+	}
+
+	if http.DetectContentType(data) != mediaType {
+		return "", ErrInvalidLogo
+	}
+	return mediaType, nil
+}
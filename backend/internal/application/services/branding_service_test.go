@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeBrandingConfig struct {
+	cfg models.MutableConfig
+}
+
+func (f *fakeBrandingConfig) GetConfig() *models.MutableConfig {
+	return &f.cfg
+}
+
+func (f *fakeBrandingConfig) UpdateBrandingLogo(_ context.Context, key, contentType, _ string) error {
+	f.cfg.Branding.LogoKey = key
+	f.cfg.Branding.LogoContentType = contentType
+	return nil
+}
+
+type fakeLogoStorage struct {
+	files map[string][]byte
+}
+
+func newFakeLogoStorage() *fakeLogoStorage {
+	return &fakeLogoStorage{files: make(map[string][]byte)}
+}
+
+func (f *fakeLogoStorage) Upload(_ context.Context, key string, reader io.Reader, _ int64, _ string) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	f.files[key] = data
+	return nil
+}
+
+func (f *fakeLogoStorage) Download(_ context.Context, key string) (io.ReadCloser, int64, string, error) {
+	data, ok := f.files[key]
+	if !ok {
+		return nil, 0, "", errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), "application/octet-stream", nil
+}
+
+func (f *fakeLogoStorage) Delete(_ context.Context, key string) error {
+	delete(f.files, key)
+	return nil
+}
+
+// pngLogo is the PNG signature followed by an IHDR chunk header
+var pngLogo = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestBrandingService_UploadLogo(t *testing.T) {
+	ctx := context.Background()
+	cfg := &fakeBrandingConfig{}
+	store := newFakeLogoStorage()
+	svc := NewBrandingService(cfg, store, "https://sign.example.com")
+
+	if err := svc.UploadLogo(ctx, pngLogo, "image/png", "admin@example.com"); err != nil {
+		t.Fatalf("UploadLogo failed: %v", err)
+	}
+	first := cfg.cfg.Branding.LogoKey
+	if !strings.HasPrefix(first, "branding/logo-") || !strings.HasSuffix(first, ".png") {
+		t.Errorf("unexpected logo key %q", first)
+	}
+	if _, ok := store.files[first]; !ok {
+		t.Fatal("expected logo to be stored")
+	}
+
+	svg := []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"></svg>`)
+	if err := svc.UploadLogo(ctx, svg, "image/svg+xml", "admin@example.com"); err != nil {
+		t.Fatalf("UploadLogo svg failed: %v", err)
+	}
+	if _, ok := store.files[first]; ok {
+		t.Error("expected previous logo to be deleted")
+	}
+	if len(store.files) != 1 {
+		t.Errorf("expected a single stored logo, got %d", len(store.files))
+	}
+
+	reader, _, contentType, err := svc.OpenLogo(ctx)
+	if err != nil {
+		t.Fatalf("OpenLogo failed: %v", err)
+	}
+	defer reader.Close()
+	if contentType != "image/svg+xml" {
+		t.Errorf("expected stored content type, got %q", contentType)
+	}
+
+	branding := svc.GetBranding()
+	if !strings.HasPrefix(branding.LogoURL, "https://sign.example.com/api/v1/branding/logo?v=logo-") {
+		t.Errorf("unexpected logo URL %q", branding.LogoURL)
+	}
+}
+
+func TestBrandingService_UploadLogo_Invalid(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		want        error
+	}{
+		{"unsupported type", []byte("%PDF-1.4"), "application/pdf", ErrInvalidLogo},
+		{"content does not match", []byte("GIF89a"), "image/png", ErrInvalidLogo},
+		{"not an svg", []byte("<html></html>"), "image/svg+xml", ErrInvalidLogo},
+		{"too large", append(pngLogo, make([]byte, MaxLogoSize)...), "image/png", ErrLogoTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewBrandingService(&fakeBrandingConfig{}, newFakeLogoStorage(), "")
+			if err := svc.UploadLogo(ctx, tt.data, tt.contentType, "admin@example.com"); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	svc := NewBrandingService(&fakeBrandingConfig{}, nil, "")
+	if err := svc.UploadLogo(ctx, pngLogo, "image/png", "admin@example.com"); !errors.Is(err, ErrBrandingStorageDisabled) {
+		t.Errorf("expected ErrBrandingStorageDisabled, got %v", err)
+	}
+}
+
+func TestBrandingService_DeleteLogo(t *testing.T) {
+	ctx := context.Background()
+	cfg := &fakeBrandingConfig{}
+	store := newFakeLogoStorage()
+	svc := NewBrandingService(cfg, store, "")
+
+	if err := svc.DeleteLogo(ctx, "admin@example.com"); !errors.Is(err, ErrLogoNotFound) {
+		t.Errorf("expected ErrLogoNotFound, got %v", err)
+	}
+
+	if err := svc.UploadLogo(ctx, pngLogo, "image/png", "admin@example.com"); err != nil {
+		t.Fatalf("UploadLogo failed: %v", err)
+	}
+	if err := svc.DeleteLogo(ctx, "admin@example.com"); err != nil {
+		t.Fatalf("DeleteLogo failed: %v", err)
+	}
+	if cfg.cfg.Branding.HasLogo() || len(store.files) != 0 {
+		t.Error("expected logo to be removed")
+	}
+	if svc.GetBranding().LogoURL != "" {
+		t.Error("expected no logo URL")
+	}
+	if _, _, _, err := svc.OpenLogo(ctx); !errors.Is(err, ErrLogoNotFound) {
+		t.Errorf("expected ErrLogoNotFound, got %v", err)
+	}
+}
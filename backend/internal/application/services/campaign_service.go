@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// defaultCampaignLocale is used for notifications sent by scheduled runs
+const defaultCampaignLocale = "en"
+
+// ErrInvalidCampaign is returned when campaign input fails validation
+var ErrInvalidCampaign = errors.New("invalid campaign")
+
+// campaignRepository defines campaign storage operations
+type campaignRepository interface {
+	Create(ctx context.Context, input models.CampaignInput) (*models.Campaign, error)
+	Update(ctx context.Context, id int64, input models.CampaignInput) (*models.Campaign, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.Campaign, error)
+	List(ctx context.Context, limit, offset int) ([]*models.Campaign, error)
+	ListDue(ctx context.Context, now time.Time) ([]*models.Campaign, error)
+	MarkRun(ctx context.Context, id int64, ranAt, nextRunAt time.Time) error
+	CreateRun(ctx context.Context, input models.CampaignRunInput) (*models.CampaignRun, error)
+	ListRuns(ctx context.Context, campaignID int64, limit, offset int) ([]*models.CampaignRun, error)
+}
+
+// campaignDocumentRepository defines document operations needed to clone a campaign document
+type campaignDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	Create(ctx context.Context, docID string, input models.DocumentInput, createdBy string) (*models.Document, error)
+}
+
+// campaignSignerRepository defines expected signer operations needed to reset signer status
+type campaignSignerRepository interface {
+	ListByDocID(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
+	AddExpected(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
+}
+
+// campaignNotifier sends acknowledgement requests to pending signers
+type campaignNotifier interface {
+	SendReminders(ctx context.Context, docID, sentBy string, specificEmails []string, docURL, locale string) (*models.ReminderSendResult, error)
+}
+
+// CampaignService manages recurring acknowledgement campaigns
+type CampaignService struct {
+	campaignRepo campaignRepository
+	docRepo      campaignDocumentRepository
+	signerRepo   campaignSignerRepository
+	notifier     campaignNotifier
+	now          func() time.Time
+}
+
+// NewCampaignService creates a new campaign service
+func NewCampaignService(campaignRepo campaignRepository, docRepo campaignDocumentRepository, signerRepo campaignSignerRepository, notifier campaignNotifier) *CampaignService {
+	return &CampaignService{
+		campaignRepo: campaignRepo,
+		docRepo:      docRepo,
+		signerRepo:   signerRepo,
+		notifier:     notifier,
+		now:          time.Now,
+	}
+}
+
+// CreateCampaign validates and stores a new campaign
+func (s *CampaignService) CreateCampaign(ctx context.Context, input models.CampaignInput) (*models.Campaign, error) {
+	if err := s.validate(ctx, &input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Creating campaign", "title", input.Title, "doc_id", input.DocID, "schedule", input.Schedule)
+	return s.campaignRepo.Create(ctx, input)
+}
+
+// UpdateCampaign validates and updates an existing campaign
+func (s *CampaignService) UpdateCampaign(ctx context.Context, id int64, input models.CampaignInput) (*models.Campaign, error) {
+	if err := s.validate(ctx, &input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Updating campaign", "id", id, "title", input.Title)
+	return s.campaignRepo.Update(ctx, id, input)
+}
+
+// DeleteCampaign deletes a campaign and its history
+func (s *CampaignService) DeleteCampaign(ctx context.Context, id int64) error {
+	logger.Logger.Info("Deleting campaign", "id", id)
+	return s.campaignRepo.Delete(ctx, id)
+}
+
+// GetCampaignByID retrieves a campaign by ID
+func (s *CampaignService) GetCampaignByID(ctx context.Context, id int64) (*models.Campaign, error) {
+	return s.campaignRepo.GetByID(ctx, id)
+}
+
+// ListCampaigns retrieves campaigns with pagination
+func (s *CampaignService) ListCampaigns(ctx context.Context, limit, offset int) ([]*models.Campaign, error) {
+	return s.campaignRepo.List(ctx, limit, offset)
+}
+
+// ListCampaignRuns retrieves the run history of a campaign with completion counts
+func (s *CampaignService) ListCampaignRuns(ctx context.Context, id int64, limit, offset int) ([]*models.CampaignRun, error) {
+	if _, err := s.campaignRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.campaignRepo.ListRuns(ctx, id, limit, offset)
+}
+
+// TriggerCampaign starts a run immediately on behalf of an admin
+func (s *CampaignService) TriggerCampaign(ctx context.Context, id int64, triggeredBy, locale string) (*models.CampaignRun, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, campaign, triggeredBy, locale)
+}
+
+// RunDueCampaigns starts a run for every active campaign whose schedule is due
+// Failures are logged per campaign so one broken campaign does not block the others
+func (s *CampaignService) RunDueCampaigns(ctx context.Context) (int, error) {
+	due, err := s.campaignRepo.ListDue(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due campaigns: %w", err)
+	}
+
+	started := 0
+	for _, campaign := range due {
+		if _, err := s.run(ctx, campaign, "", defaultCampaignLocale); err != nil {
+			logger.Logger.Error("Failed to run campaign", "campaign_id", campaign.ID, "error", err.Error())
+			continue
+		}
+		started++
+	}
+	return started, nil
+}
+
+// run clones the source document, copies its expected signers so that everyone
+// starts unsigned again, notifies them and records the run
+func (s *CampaignService) run(ctx context.Context, campaign *models.Campaign, triggeredBy, locale string) (*models.CampaignRun, error) {
+	source, err := s.docRepo.GetByDocID(ctx, campaign.DocID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source document: %w", err)
+	}
+	if source == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	now := s.now()
+	createdBy := triggeredBy
+	if createdBy == "" {
+		createdBy = campaign.CreatedBy
+	}
+
+	runDocID := generateDocID()
+	input := models.DocumentInput{
+		Title:             fmt.Sprintf("%s (%s)", source.Title, campaignPeriodLabel(campaign.Schedule, now)),
+		URL:               source.URL,
+		Checksum:          source.Checksum,
+		ChecksumAlgorithm: source.ChecksumAlgorithm,
+		Description:       source.Description,
+		ReadMode:          source.ReadMode,
+		AllowDownload:     &source.AllowDownload,
+		RequireFullRead:   &source.RequireFullRead,
+		VerifyChecksum:    &source.VerifyChecksum,
+		StorageKey:        source.StorageKey,
+		StorageProvider:   source.StorageProvider,
+		FileSize:          source.FileSize,
+		MimeType:          source.MimeType,
+		OriginalFilename:  source.OriginalFilename,
+	}
+	if _, err := s.docRepo.Create(ctx, runDocID, input, createdBy); err != nil {
+		return nil, fmt.Errorf("failed to clone document: %w", err)
+	}
+
+	signers, err := s.signerRepo.ListByDocID(ctx, campaign.DocID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expected signers: %w", err)
+	}
+	contacts := make([]models.ContactInfo, 0, len(signers))
+	for _, signer := range signers {
+		contacts = append(contacts, models.ContactInfo{Name: signer.Name, Email: signer.Email, SignOrder: signer.SignOrder})
+	}
+	if err := s.signerRepo.AddExpected(ctx, runDocID, contacts, createdBy); err != nil {
+		return nil, fmt.Errorf("failed to copy expected signers: %w", err)
+	}
+
+	notified := 0
+	if len(contacts) > 0 {
+		if locale == "" {
+			locale = defaultCampaignLocale
+		}
+		result, err := s.notifier.SendReminders(ctx, runDocID, createdBy, nil, source.URL, locale)
+		if err != nil {
+			logger.Logger.Error("Failed to notify campaign signers", "campaign_id", campaign.ID, "doc_id", runDocID, "error", err.Error())
+		} else if result != nil {
+			notified = result.SuccessfullySent
+		}
+	}
+
+	run, err := s.campaignRepo.CreateRun(ctx, models.CampaignRunInput{
+		CampaignID:    campaign.ID,
+		DocID:         runDocID,
+		TriggeredBy:   triggeredBy,
+		ExpectedCount: len(contacts),
+		NotifiedCount: notified,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Scheduled runs move to the next period; manual runs keep the schedule
+	// unless they pre-empt a due run
+	next := campaign.NextRunAt
+	for !next.After(now) {
+		next = campaign.Schedule.Next(next)
+	}
+	if err := s.campaignRepo.MarkRun(ctx, campaign.ID, now, next); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Campaign run started",
+		"campaign_id", campaign.ID,
+		"doc_id", runDocID,
+		"expected", len(contacts),
+		"notified", notified)
+
+	return run, nil
+}
+
+func (s *CampaignService) validate(ctx context.Context, input *models.CampaignInput) error {
+	if input.Title == "" || input.DocID == "" {
+		return fmt.Errorf("%w: title and docId are required", ErrInvalidCampaign)
+	}
+	if !input.Schedule.IsValid() {
+		return fmt.Errorf("%w: schedule must be yearly or quarterly", ErrInvalidCampaign)
+	}
+	if input.NextRunAt.IsZero() {
+		input.NextRunAt = input.Schedule.Next(s.now())
+	}
+	doc, err := s.docRepo.GetByDocID(ctx, input.DocID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return models.ErrDocumentNotFound
+	}
+	return nil
+}
+
+// campaignPeriodLabel names the period a run belongs to, e.g. "2026" or "2026 Q4"
+func campaignPeriodLabel(schedule models.CampaignSchedule, t time.Time) string {
+	if schedule == models.CampaignScheduleQuarterly {
+		return fmt.Sprintf("%d Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	}
+	return fmt.Sprintf("%d", t.Year())
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeCampaignRepo struct {
+	campaigns map[int64]*models.Campaign
+	runs      []models.CampaignRunInput
+	marked    map[int64]time.Time
+}
+
+func newFakeCampaignRepo(campaigns ...*models.Campaign) *fakeCampaignRepo {
+	f := &fakeCampaignRepo{campaigns: make(map[int64]*models.Campaign), marked: make(map[int64]time.Time)}
+	for _, c := range campaigns {
+		f.campaigns[c.ID] = c
+	}
+	return f
+}
+
+func (f *fakeCampaignRepo) Create(_ context.Context, input models.CampaignInput) (*models.Campaign, error) {
+	c := &models.Campaign{ID: int64(len(f.campaigns) + 1), Title: input.Title, DocID: input.DocID, Schedule: input.Schedule, Active: input.Active, NextRunAt: input.NextRunAt}
+	f.campaigns[c.ID] = c
+	return c, nil
+}
+
+func (f *fakeCampaignRepo) Update(_ context.Context, id int64, input models.CampaignInput) (*models.Campaign, error) {
+	c, ok := f.campaigns[id]
+	if !ok {
+		return nil, models.ErrCampaignNotFound
+	}
+	c.Title, c.DocID, c.Schedule, c.Active, c.NextRunAt = input.Title, input.DocID, input.Schedule, input.Active, input.NextRunAt
+	return c, nil
+}
+
+func (f *fakeCampaignRepo) Delete(_ context.Context, id int64) error {
+	delete(f.campaigns, id)
+	return nil
+}
+
+func (f *fakeCampaignRepo) GetByID(_ context.Context, id int64) (*models.Campaign, error) {
+	c, ok := f.campaigns[id]
+	if !ok {
+		return nil, models.ErrCampaignNotFound
+	}
+	return c, nil
+}
+
+func (f *fakeCampaignRepo) List(_ context.Context, _, _ int) ([]*models.Campaign, error) {
+	out := make([]*models.Campaign, 0, len(f.campaigns))
+	for _, c := range f.campaigns {
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (f *fakeCampaignRepo) ListDue(_ context.Context, now time.Time) ([]*models.Campaign, error) {
+	var out []*models.Campaign
+	for _, c := range f.campaigns {
+		if c.Active && !c.NextRunAt.After(now) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeCampaignRepo) MarkRun(_ context.Context, id int64, ranAt, nextRunAt time.Time) error {
+	f.marked[id] = nextRunAt
+	return nil
+}
+
+func (f *fakeCampaignRepo) CreateRun(_ context.Context, input models.CampaignRunInput) (*models.CampaignRun, error) {
+	f.runs = append(f.runs, input)
+	return &models.CampaignRun{ID: int64(len(f.runs)), CampaignID: input.CampaignID, DocID: input.DocID, ExpectedCount: input.ExpectedCount, NotifiedCount: input.NotifiedCount}, nil
+}
+
+func (f *fakeCampaignRepo) ListRuns(_ context.Context, _ int64, _, _ int) ([]*models.CampaignRun, error) {
+	return nil, nil
+}
+
+type fakeCampaignDocRepo struct {
+	docs    map[string]*models.Document
+	created map[string]models.DocumentInput
+}
+
+func (f *fakeCampaignDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+func (f *fakeCampaignDocRepo) Create(_ context.Context, docID string, input models.DocumentInput, _ string) (*models.Document, error) {
+	f.created[docID] = input
+	return &models.Document{DocID: docID, Title: input.Title}, nil
+}
+
+type fakeCampaignSignerRepo struct {
+	signers map[string][]*models.ExpectedSigner
+	added   map[string][]models.ContactInfo
+}
+
+func (f *fakeCampaignSignerRepo) ListByDocID(_ context.Context, docID string) ([]*models.ExpectedSigner, error) {
+	return f.signers[docID], nil
+}
+
+func (f *fakeCampaignSignerRepo) AddExpected(_ context.Context, docID string, contacts []models.ContactInfo, _ string) error {
+	f.added[docID] = contacts
+	return nil
+}
+
+type fakeCampaignNotifier struct{ docIDs []string }
+
+func (f *fakeCampaignNotifier) SendReminders(_ context.Context, docID, _ string, _ []string, _, _ string) (*models.ReminderSendResult, error) {
+	f.docIDs = append(f.docIDs, docID)
+	return &models.ReminderSendResult{TotalAttempted: 2, SuccessfullySent: 2}, nil
+}
+
+func newTestCampaignService(repo *fakeCampaignRepo, now time.Time) (*CampaignService, *fakeCampaignDocRepo, *fakeCampaignSignerRepo, *fakeCampaignNotifier) {
+	docs := &fakeCampaignDocRepo{
+		docs:    map[string]*models.Document{"policy": {DocID: "policy", Title: "Security policy", URL: "https://example.com/policy.pdf", AllowDownload: true}},
+		created: make(map[string]models.DocumentInput),
+	}
+	signers := &fakeCampaignSignerRepo{
+		signers: map[string][]*models.ExpectedSigner{"policy": {
+			{DocID: "policy", Email: "alice@example.com", Name: "Alice"},
+			{DocID: "policy", Email: "bob@example.com", Name: "Bob"},
+		}},
+		added: make(map[string][]models.ContactInfo),
+	}
+	notifier := &fakeCampaignNotifier{}
+	svc := NewCampaignService(repo, docs, signers, notifier)
+	svc.now = func() time.Time { return now }
+	return svc, docs, signers, notifier
+}
+
+func TestCampaignService_CreateCampaign_Validation(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	svc, _, _, _ := newTestCampaignService(newFakeCampaignRepo(), now)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		input   models.CampaignInput
+		wantErr error
+	}{
+		{"missing title", models.CampaignInput{DocID: "policy", Schedule: models.CampaignScheduleYearly}, ErrInvalidCampaign},
+		{"invalid schedule", models.CampaignInput{Title: "T", DocID: "policy", Schedule: "weekly"}, ErrInvalidCampaign},
+		{"unknown document", models.CampaignInput{Title: "T", DocID: "missing", Schedule: models.CampaignScheduleYearly}, models.ErrDocumentNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.CreateCampaign(ctx, tt.input); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	c, err := svc.CreateCampaign(ctx, models.CampaignInput{Title: "Annual", DocID: "policy", Schedule: models.CampaignScheduleQuarterly, Active: true})
+	if err != nil {
+		t.Fatalf("CreateCampaign error: %v", err)
+	}
+	if want := now.AddDate(0, 3, 0); !c.NextRunAt.Equal(want) {
+		t.Errorf("expected default next run %v, got %v", want, c.NextRunAt)
+	}
+}
+
+func TestCampaignService_RunDueCampaigns(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	due := &models.Campaign{ID: 1, Title: "Annual", DocID: "policy", Schedule: models.CampaignScheduleYearly, Active: true, NextRunAt: now.Add(-time.Hour)}
+	later := &models.Campaign{ID: 2, Title: "Later", DocID: "policy", Schedule: models.CampaignScheduleYearly, Active: true, NextRunAt: now.Add(time.Hour)}
+	repo := newFakeCampaignRepo(due, later)
+	svc, docs, signers, notifier := newTestCampaignService(repo, now)
+
+	started, err := svc.RunDueCampaigns(context.Background())
+	if err != nil {
+		t.Fatalf("RunDueCampaigns error: %v", err)
+	}
+	if started != 1 {
+		t.Fatalf("expected 1 started campaign, got %d", started)
+	}
+	if len(repo.runs) != 1 {
+		t.Fatalf("expected 1 recorded run, got %d", len(repo.runs))
+	}
+
+	run := repo.runs[0]
+	if run.DocID == "policy" {
+		t.Fatal("run must use a cloned document")
+	}
+	if run.ExpectedCount != 2 || run.NotifiedCount != 2 {
+		t.Errorf("unexpected counts: %+v", run)
+	}
+	clone, ok := docs.created[run.DocID]
+	if !ok {
+		t.Fatal("expected document to be cloned")
+	}
+	if !strings.HasPrefix(clone.Title, "Security policy (2026") || clone.URL != "https://example.com/policy.pdf" {
+		t.Errorf("unexpected clone input: %+v", clone)
+	}
+	if len(signers.added[run.DocID]) != 2 {
+		t.Errorf("expected signers to be copied, got %d", len(signers.added[run.DocID]))
+	}
+	if len(notifier.docIDs) != 1 || notifier.docIDs[0] != run.DocID {
+		t.Errorf("expected notification for cloned document, got %v", notifier.docIDs)
+	}
+	if next := repo.marked[1]; !next.Equal(due.NextRunAt.AddDate(1, 0, 0)) {
+		t.Errorf("expected next run one year later, got %v", next)
+	}
+	if _, ok := repo.marked[2]; ok {
+		t.Error("campaign not yet due must not run")
+	}
+}
+
+func TestCampaignService_TriggerCampaign_KeepsSchedule(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	nextRun := now.AddDate(0, 2, 0)
+	repo := newFakeCampaignRepo(&models.Campaign{ID: 1, DocID: "policy", Schedule: models.CampaignScheduleQuarterly, Active: true, NextRunAt: nextRun})
+	svc, _, _, _ := newTestCampaignService(repo, now)
+
+	run, err := svc.TriggerCampaign(context.Background(), 1, "admin@example.com", "fr")
+	if err != nil {
+		t.Fatalf("TriggerCampaign error: %v", err)
+	}
+	if run.CampaignID != 1 || repo.runs[0].TriggeredBy != "admin@example.com" {
+		t.Errorf("unexpected run: %+v", repo.runs[0])
+	}
+	if !repo.marked[1].Equal(nextRun) {
+		t.Errorf("manual run must keep the upcoming schedule, got %v", repo.marked[1])
+	}
+
+	if _, err := svc.TriggerCampaign(context.Background(), 42, "admin@example.com", "fr"); !errors.Is(err, models.ErrCampaignNotFound) {
+		t.Errorf("expected ErrCampaignNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/certificate"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type certificateSignatureRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.Signature, error)
+}
+
+type certificateStorage interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Download(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+type certificateConfig interface {
+	GetConfig() *models.MutableConfig
+}
+
+// CertificateService renders the PDF certificate of each signature and keeps it in object storage.
+// The certificate carries a QR code linking to its public verification.
+type CertificateService struct {
+	sigRepo certificateSignatureRepository
+	storage certificateStorage
+	config  certificateConfig
+	baseURL string
+}
+
+// NewCertificateService creates a new certificate service
+func NewCertificateService(sigRepo certificateSignatureRepository, storage certificateStorage, baseURL string) *CertificateService {
+	return &CertificateService{
+		sigRepo: sigRepo,
+		storage: storage,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// SetConfig sets the tenant configuration, whose organization name is printed on certificates
+func (s *CertificateService) SetConfig(config certificateConfig) {
+	s.config = config
+}
+
+// certificateKey returns the storage key of a certificate; signature IDs are unique across tenants
+func certificateKey(signatureID int64) string {
+	return fmt.Sprintf("certificates/%d.pdf", signatureID)
+}
+
+// URL returns the download link of the certificate of a signature
+func (s *CertificateService) URL(signatureID int64) string {
+	return fmt.Sprintf("%s/api/v1/signatures/%d/certificate", s.baseURL, signatureID)
+}
+
+// verifyURL returns the public verification link encoded in the QR code
+func (s *CertificateService) verifyURL(sig *models.Signature) string {
+	return fmt.Sprintf("%s/api/v1/certificates/%d/verify?hash=%s", s.baseURL, sig.ID, url.QueryEscape(sig.ComputeRecordHash()))
+}
+
+// Issue renders the certificate of a signature and stores it, replacing any previous one
+func (s *CertificateService) Issue(ctx context.Context, sig *models.Signature) error {
+	data := certificate.Data{
+		DocID:       sig.DocID,
+		DocTitle:    sig.DocTitle,
+		DocChecksum: sig.DocChecksum,
+		SignerName:  sig.UserName,
+		SignerEmail: sig.UserEmail,
+		SignedAt:    sig.SignedAtUTC,
+		SignatureID: sig.ID,
+		PayloadHash: sig.PayloadHash,
+		RecordHash:  sig.ComputeRecordHash(),
+		Signature:   sig.Signature,
+		VerifyURL:   s.verifyURL(sig),
+	}
+	if data.DocTitle == "" {
+		data.DocTitle = sig.DocID
+	}
+	if s.config != nil {
+		data.Organisation = s.config.GetConfig().General.Organisation
+	}
+
+	pdf, err := certificate.Render(data)
+	if err != nil {
+		return fmt.Errorf("failed to render certificate: %w", err)
+	}
+	if err := s.storage.Upload(ctx, certificateKey(sig.ID), bytes.NewReader(pdf), int64(len(pdf)), "application/pdf"); err != nil {
+		return fmt.Errorf("failed to store certificate: %w", err)
+	}
+	return nil
+}
+
+// Open returns the certificate of a signature owned by email, with its size.
+// Certificates of signatures made before the feature was enabled are issued on first download.
+func (s *CertificateService) Open(ctx context.Context, signatureID int64, email string) (io.ReadCloser, int64, error) {
+	sig, err := s.sigRepo.GetByID(ctx, signatureID)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Other users' signatures are reported as missing so their existence does not leak
+	if sig == nil || !strings.EqualFold(sig.UserEmail, email) {
+		return nil, 0, models.ErrCertificateNotFound
+	}
+
+	key := certificateKey(signatureID)
+	exists, err := s.storage.Exists(ctx, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to check certificate: %w", err)
+	}
+	if !exists {
+		if err := s.Issue(ctx, sig); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	reader, size, _, err := s.storage.Download(ctx, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download certificate: %w", err)
+	}
+	return reader, size, nil
+}
+
+// Verify checks the record hash printed on a certificate against the signature ledger
+func (s *CertificateService) Verify(ctx context.Context, signatureID int64, recordHash string) (*models.CertificateVerification, error) {
+	sig, err := s.sigRepo.GetByID(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+	if sig == nil {
+		return nil, models.ErrCertificateNotFound
+	}
+
+	result := &models.CertificateVerification{SignatureID: signatureID}
+	if recordHash == "" || subtle.ConstantTimeCompare([]byte(sig.ComputeRecordHash()), []byte(recordHash)) != 1 {
+		return result, nil
+	}
+
+	signedAt := sig.SignedAtUTC
+	result.Valid = true
+	result.DocID = sig.DocID
+	result.DocTitle = sig.DocTitle
+	result.SignerName = sig.UserName
+	result.SignedAt = &signedAt
+	result.DocDeleted = sig.DocDeletedAt != nil
+	return result, nil
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeCertificateSignatureRepo struct {
+	signatures map[int64]*models.Signature
+}
+
+func (f *fakeCertificateSignatureRepo) GetByID(_ context.Context, id int64) (*models.Signature, error) {
+	return f.signatures[id], nil
+}
+
+// fakeCertificateStorage adds existence checks to the archive storage fake
+type fakeCertificateStorage struct {
+	*fakeArchiveStorage
+}
+
+func (f fakeCertificateStorage) Exists(_ context.Context, key string) (bool, error) {
+	_, ok := f.objects[key]
+	return ok, nil
+}
+
+func newTestCertificateService() (*CertificateService, *fakeCertificateSignatureRepo, fakeCertificateStorage) {
+	repo := &fakeCertificateSignatureRepo{signatures: map[int64]*models.Signature{
+		7: {
+			ID:          7,
+			DocID:       "policy",
+			DocTitle:    "Security policy",
+			UserSub:     "sub-alice",
+			UserEmail:   "alice@example.com",
+			UserName:    "Alice",
+			SignedAtUTC: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+			PayloadHash: "cGF5bG9hZA==",
+			Signature:   "c2lnbmF0dXJl",
+			Nonce:       "nonce",
+			HashVersion: 2,
+		},
+	}}
+	store := fakeCertificateStorage{&fakeArchiveStorage{objects: make(map[string][]byte)}}
+	svc := NewCertificateService(repo, store, "https://sign.example.com/")
+	svc.SetConfig(&fakeBrandingConfig{cfg: models.MutableConfig{General: models.GeneralConfig{Organisation: "Acme"}}})
+	return svc, repo, store
+}
+
+func TestCertificateService_IssueAndOpen(t *testing.T) {
+	svc, repo, store := newTestCertificateService()
+	ctx := context.Background()
+
+	if err := svc.Issue(ctx, repo.signatures[7]); err != nil {
+		t.Fatalf("Issue err: %v", err)
+	}
+	text := checksum.ExtractText("application/pdf", store.objects["certificates/7.pdf"])
+	for _, want := range []string{"Security policy", "Alice <alice@example.com>", "Issued by Acme", "/api/v1/certificates/7/verify"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the certificate", want)
+		}
+	}
+
+	reader, size, err := svc.Open(ctx, 7, "Alice@Example.com")
+	if err != nil {
+		t.Fatalf("Open err: %v", err)
+	}
+	defer reader.Close()
+	data, _ := io.ReadAll(reader)
+	if int64(len(data)) != size || !strings.HasPrefix(string(data), "%PDF-") {
+		t.Errorf("unexpected certificate content (%d bytes)", size)
+	}
+
+	if _, _, err := svc.Open(ctx, 7, "bob@example.com"); !errors.Is(err, models.ErrCertificateNotFound) {
+		t.Errorf("expected ErrCertificateNotFound for another user, got %v", err)
+	}
+	if _, _, err := svc.Open(ctx, 8, "alice@example.com"); !errors.Is(err, models.ErrCertificateNotFound) {
+		t.Errorf("expected ErrCertificateNotFound for an unknown signature, got %v", err)
+	}
+}
+
+func TestCertificateService_OpenIssuesMissingCertificate(t *testing.T) {
+	svc, _, store := newTestCertificateService()
+
+	reader, _, err := svc.Open(context.Background(), 7, "alice@example.com")
+	if err != nil {
+		t.Fatalf("Open err: %v", err)
+	}
+	reader.Close()
+	if _, ok := store.objects["certificates/7.pdf"]; !ok {
+		t.Error("expected the certificate to be issued on first download")
+	}
+}
+
+func TestCertificateService_Verify(t *testing.T) {
+	svc, repo, _ := newTestCertificateService()
+	ctx := context.Background()
+
+	link, err := url.Parse(svc.verifyURL(repo.signatures[7]))
+	if err != nil {
+		t.Fatalf("invalid verification link: %v", err)
+	}
+	if link.Host != "sign.example.com" || link.Path != "/api/v1/certificates/7/verify" {
+		t.Errorf("unexpected verification link %s", link)
+	}
+
+	result, err := svc.Verify(ctx, 7, link.Query().Get("hash"))
+	if err != nil {
+		t.Fatalf("Verify err: %v", err)
+	}
+	if !result.Valid || result.DocTitle != "Security policy" || result.SignerName != "Alice" || result.SignedAt == nil {
+		t.Errorf("unexpected verification %+v", result)
+	}
+
+	result, err = svc.Verify(ctx, 7, "forged")
+	if err != nil || result.Valid || result.DocTitle != "" {
+		t.Errorf("expected an invalid result without details, got %+v, %v", result, err)
+	}
+
+	// A record altered after issuance no longer matches the certificate
+	repo.signatures[7].UserEmail = "mallory@example.com"
+	if result, _ := svc.Verify(ctx, 7, link.Query().Get("hash")); result.Valid {
+		t.Error("expected a tampered record to fail verification")
+	}
+
+	if _, err := svc.Verify(ctx, 8, "hash"); !errors.Is(err, models.ErrCertificateNotFound) {
+		t.Errorf("expected ErrCertificateNotFound, got %v", err)
+	}
+}
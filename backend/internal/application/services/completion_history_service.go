@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// completionSnapshotRepository stores the daily signing progress of documents
+type completionSnapshotRepository interface {
+	RecordAll(ctx context.Context, date time.Time) (int, error)
+	GetLatestDate(ctx context.Context) (*time.Time, error)
+	ListByDocument(ctx context.Context, docID string, since time.Time) ([]*models.CompletionSnapshot, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// CompletionHistoryService records the signing progress of documents once a day so that
+// admins can chart the completion rate over a campaign
+type CompletionHistoryService struct {
+	repo          completionSnapshotRepository
+	docRepo       completionDocumentRepository
+	retentionDays int
+	now           func() time.Time
+}
+
+// NewCompletionHistoryService creates a completion history service.
+// Snapshots older than retentionDays are purged.
+func NewCompletionHistoryService(repo completionSnapshotRepository, docRepo completionDocumentRepository, retentionDays int) *CompletionHistoryService {
+	return &CompletionHistoryService{
+		repo:          repo,
+		docRepo:       docRepo,
+		retentionDays: retentionDays,
+		now:           time.Now,
+	}
+}
+
+// RetentionDays returns how many days of history are kept
+func (s *CompletionHistoryService) RetentionDays() int {
+	return s.retentionDays
+}
+
+// today returns the current day at UTC midnight
+func (s *CompletionHistoryService) today() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// TakeDailySnapshot records the progress of every document, unless it was already done today,
+// then purges the expired snapshots. It returns the number of snapshots created.
+func (s *CompletionHistoryService) TakeDailySnapshot(ctx context.Context) (int, error) {
+	today := s.today()
+
+	latest, err := s.repo.GetLatestDate(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if latest != nil && !latest.Before(today) {
+		return 0, nil
+	}
+
+	created, err := s.repo.RecordAll(ctx, today)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.retentionDays > 0 {
+		deleted, err := s.repo.DeleteBefore(ctx, today.AddDate(0, 0, -s.retentionDays))
+		if err != nil {
+			return created, fmt.Errorf("failed to purge completion snapshots: %w", err)
+		}
+		if deleted > 0 {
+			logger.Logger.Debug("Purged completion snapshots", "count", deleted)
+		}
+	}
+
+	return created, nil
+}
+
+// GetHistory returns the snapshots of a document over the last days, oldest first
+func (s *CompletionHistoryService) GetHistory(ctx context.Context, docID string, days int) ([]*models.CompletionSnapshot, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	since := s.today().AddDate(0, 0, -(days - 1))
+	return s.repo.ListByDocument(ctx, docID, since)
+}
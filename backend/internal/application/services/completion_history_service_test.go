@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeCompletionSnapshotRepo struct {
+	latest       *time.Time
+	recorded     []time.Time
+	deleteBefore time.Time
+	since        time.Time
+	snapshots    []*models.CompletionSnapshot
+}
+
+func (f *fakeCompletionSnapshotRepo) RecordAll(_ context.Context, date time.Time) (int, error) {
+	f.recorded = append(f.recorded, date)
+	f.latest = &date
+	return 3, nil
+}
+
+func (f *fakeCompletionSnapshotRepo) GetLatestDate(_ context.Context) (*time.Time, error) {
+	return f.latest, nil
+}
+
+func (f *fakeCompletionSnapshotRepo) ListByDocument(_ context.Context, _ string, since time.Time) ([]*models.CompletionSnapshot, error) {
+	f.since = since
+	return f.snapshots, nil
+}
+
+func (f *fakeCompletionSnapshotRepo) DeleteBefore(_ context.Context, before time.Time) (int, error) {
+	f.deleteBefore = before
+	return 0, nil
+}
+
+func TestCompletionHistoryService_TakeDailySnapshot(t *testing.T) {
+	repo := &fakeCompletionSnapshotRepo{}
+	service := NewCompletionHistoryService(repo, &fakeCompletionDocRepo{}, 30)
+	service.now = func() time.Time { return time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC) }
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	created, err := service.TakeDailySnapshot(context.Background())
+	if err != nil || created != 3 {
+		t.Fatalf("expected 3 snapshots, got %d, %v", created, err)
+	}
+	if len(repo.recorded) != 1 || !repo.recorded[0].Equal(today) {
+		t.Fatalf("expected a snapshot for %v, got %v", today, repo.recorded)
+	}
+	if want := today.AddDate(0, 0, -30); !repo.deleteBefore.Equal(want) {
+		t.Errorf("expected purge before %v, got %v", want, repo.deleteBefore)
+	}
+
+	// Later runs the same day do nothing
+	created, err = service.TakeDailySnapshot(context.Background())
+	if err != nil || created != 0 || len(repo.recorded) != 1 {
+		t.Fatalf("expected no new snapshot, got %d, %v, %v", created, err, repo.recorded)
+	}
+
+	service.now = func() time.Time { return time.Date(2025, 3, 11, 0, 5, 0, 0, time.UTC) }
+	if _, err := service.TakeDailySnapshot(context.Background()); err != nil {
+		t.Fatalf("next day err: %v", err)
+	}
+	if len(repo.recorded) != 2 {
+		t.Fatalf("expected a snapshot the next day, got %v", repo.recorded)
+	}
+}
+
+func TestCompletionHistoryService_GetHistory(t *testing.T) {
+	repo := &fakeCompletionSnapshotRepo{snapshots: []*models.CompletionSnapshot{{DocID: "doc-1"}}}
+	docRepo := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc-1": {DocID: "doc-1"}}}
+	service := NewCompletionHistoryService(repo, docRepo, 365)
+	service.now = func() time.Time { return time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC) }
+
+	snapshots, err := service.GetHistory(context.Background(), "doc-1", 7)
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot, got %v, %v", snapshots, err)
+	}
+	if want := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC); !repo.since.Equal(want) {
+		t.Errorf("expected history since %v, got %v", want, repo.since)
+	}
+
+	if _, err := service.GetHistory(context.Background(), "unknown", 7); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
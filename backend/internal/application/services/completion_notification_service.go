@@ -0,0 +1,375 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	completionEmailTemplate = "completion_summary"
+	completionReferenceType = "completion_summary"
+	completionActor         = "completion-notifier"
+
+	// slackPostTimeout bounds the time spent posting to Slack while a signature is being recorded
+	slackPostTimeout = 5 * time.Second
+)
+
+// ErrInvalidCompletionSettings is returned when completion notification settings fail validation
+var ErrInvalidCompletionSettings = errors.New("invalid completion notification settings")
+
+// completionSettingsRepository defines completion notification settings storage
+type completionSettingsRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.CompletionSettings, error)
+	Upsert(ctx context.Context, docID string, input models.CompletionSettingsInput, updatedBy string) (*models.CompletionSettings, error)
+	MarkNotified(ctx context.Context, docID string, trigger models.CompletionTrigger, at time.Time) (bool, error)
+	ListDeadlinesDue(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// completionDocumentRepository loads the document and its creator
+type completionDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// completionSignerRepository provides signing progress for the summary
+type completionSignerRepository interface {
+	GetStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
+	ListWithStatusByDocID(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
+}
+
+// completionEmailQueue queues the summary email
+type completionEmailQueue interface {
+	Enqueue(ctx context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error)
+}
+
+// completionWebhookPublisher publishes threshold and deadline events
+type completionWebhookPublisher interface {
+	Publish(ctx context.Context, eventType string, payload map[string]interface{}) error
+}
+
+// slackPoster posts plain text messages to a Slack incoming webhook
+type slackPoster interface {
+	PostMessage(ctx context.Context, webhookURL, text string) error
+}
+
+// CompletionNotificationService notifies document creators when signing milestones are reached
+type CompletionNotificationService struct {
+	repo       completionSettingsRepository
+	docRepo    completionDocumentRepository
+	signerRepo completionSignerRepository
+	queue      completionEmailQueue
+	publisher  completionWebhookPublisher
+	slack      slackPoster
+	extensions signerDeadlineExtensions
+	i18n       translator
+	baseURL    string
+	locale     string
+	now        func() time.Time
+}
+
+// NewCompletionNotificationService creates a new completion notification service.
+// publisher and slack may be nil to disable the corresponding channels.
+func NewCompletionNotificationService(
+	repo completionSettingsRepository,
+	docRepo completionDocumentRepository,
+	signerRepo completionSignerRepository,
+	queue completionEmailQueue,
+	publisher completionWebhookPublisher,
+	slack slackPoster,
+	i18nService translator,
+	baseURL string,
+	locale string,
+) *CompletionNotificationService {
+	return &CompletionNotificationService{
+		repo:       repo,
+		docRepo:    docRepo,
+		signerRepo: signerRepo,
+		queue:      queue,
+		publisher:  publisher,
+		slack:      slack,
+		i18n:       i18nService,
+		baseURL:    baseURL,
+		locale:     locale,
+		now:        time.Now,
+	}
+}
+
+// SetDeadlineExtensions shows in the summary the signers whose deadline was extended
+func (s *CompletionNotificationService) SetDeadlineExtensions(extensions signerDeadlineExtensions) {
+	s.extensions = extensions
+}
+
+// GetSettings returns the completion settings of a document, or the defaults if none are stored
+func (s *CompletionNotificationService) GetSettings(ctx context.Context, docID string) (*models.CompletionSettings, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	settings, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = &models.CompletionSettings{DocID: docID, NotifyOnComplete: true}
+	}
+	return settings, nil
+}
+
+// UpdateSettings validates and stores the completion settings of a document
+func (s *CompletionNotificationService) UpdateSettings(ctx context.Context, docID string, input models.CompletionSettingsInput, updatedBy string) (*models.CompletionSettings, error) {
+	if input.ThresholdPercent != nil && (*input.ThresholdPercent < 1 || *input.ThresholdPercent > 99) {
+		return nil, fmt.Errorf("%w: thresholdPercent must be between 1 and 99", ErrInvalidCompletionSettings)
+	}
+	input.SlackWebhookURL = strings.TrimSpace(input.SlackWebhookURL)
+	if input.SlackWebhookURL != "" {
+		u, err := url.Parse(input.SlackWebhookURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, fmt.Errorf("%w: slackWebhookUrl must be an https URL", ErrInvalidCompletionSettings)
+		}
+	}
+
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	return s.repo.Upsert(ctx, docID, input, updatedBy)
+}
+
+// OnSignature checks the signing progress of a document after a new signature
+// and notifies the creator when the completion or threshold trigger is reached
+func (s *CompletionNotificationService) OnSignature(ctx context.Context, docID string) error {
+	stats, err := s.signerRepo.GetStats(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get signer stats: %w", err)
+	}
+	if stats.ExpectedCount == 0 {
+		return nil
+	}
+
+	settings, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		settings = &models.CompletionSettings{DocID: docID, NotifyOnComplete: true}
+	}
+
+	var trigger models.CompletionTrigger
+	switch {
+	case stats.PendingCount == 0 && settings.NotifyOnComplete && settings.CompletedNotifiedAt == nil:
+		trigger = models.CompletionTriggerCompleted
+	case settings.ThresholdPercent != nil && stats.CompletionRate >= float64(*settings.ThresholdPercent) && settings.ThresholdNotifiedAt == nil:
+		trigger = models.CompletionTriggerThreshold
+	default:
+		return nil
+	}
+
+	return s.notify(ctx, docID, settings, stats, trigger)
+}
+
+// RunDeadlineChecks notifies creators of documents whose deadline is reached
+func (s *CompletionNotificationService) RunDeadlineChecks(ctx context.Context) error {
+	docIDs, err := s.repo.ListDeadlinesDue(ctx, s.now())
+	if err != nil {
+		return err
+	}
+
+	for _, docID := range docIDs {
+		if err := s.checkDeadline(ctx, docID); err != nil {
+			logger.Logger.Warn("Failed to send deadline notification", "doc_id", docID, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *CompletionNotificationService) checkDeadline(ctx context.Context, docID string) error {
+	settings, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		return nil
+	}
+
+	stats, err := s.signerRepo.GetStats(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get signer stats: %w", err)
+	}
+
+	return s.notify(ctx, docID, settings, stats, models.CompletionTriggerDeadline)
+}
+
+// notify claims the trigger, then sends the summary through every configured channel.
+// Claiming first guarantees a single notification when signatures arrive concurrently.
+func (s *CompletionNotificationService) notify(ctx context.Context, docID string, settings *models.CompletionSettings, stats *models.DocCompletionStats, trigger models.CompletionTrigger) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return nil
+	}
+
+	claimed, err := s.repo.MarkNotified(ctx, docID, trigger, s.now())
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	logger.Logger.Info("Sending completion notification",
+		"doc_id", docID,
+		"trigger", trigger,
+		"signed_count", stats.SignedCount,
+		"expected_count", stats.ExpectedCount)
+
+	subject := s.subject(trigger, doc.GetTitle())
+	adminURL := fmt.Sprintf("%s/admin/docs/%s", s.baseURL, url.PathEscape(docID))
+
+	var errs []error
+	if doc.CreatedBy != "" {
+		if err := s.queueSummary(ctx, doc, settings.Deadline, stats, trigger, subject, adminURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if settings.NotifyWebhook && s.publisher != nil && trigger != models.CompletionTriggerCompleted {
+		// document.completed is already published by the signature flow
+		err := s.publisher.Publish(ctx, "document."+string(trigger)+"_reached", map[string]interface{}{
+			"doc_id":          docID,
+			"expected_count":  stats.ExpectedCount,
+			"signed_count":    stats.SignedCount,
+			"pending_count":   stats.PendingCount,
+			"completion_rate": stats.CompletionRate,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish webhook: %w", err))
+		}
+	}
+
+	if settings.SlackWebhookURL != "" && s.slack != nil {
+		postCtx, cancel := context.WithTimeout(ctx, slackPostTimeout)
+		text := fmt.Sprintf("%s\n%d/%d (%.0f%%) - <%s>", subject, stats.SignedCount, stats.ExpectedCount, stats.CompletionRate, adminURL)
+		if err := s.slack.PostMessage(postCtx, settings.SlackWebhookURL, text); err != nil {
+			errs = append(errs, err)
+		}
+		cancel()
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *CompletionNotificationService) queueSummary(ctx context.Context, doc *models.Document, deadline *time.Time, stats *models.DocCompletionStats, trigger models.CompletionTrigger, subject, adminURL string) error {
+	signers, err := s.signerRepo.ListWithStatusByDocID(ctx, doc.DocID)
+	if err != nil {
+		return fmt.Errorf("failed to get expected signers: %w", err)
+	}
+
+	extensions := s.signerExtensions(ctx, doc.DocID, deadline)
+
+	var signed []*models.ExpectedSignerWithStatus
+	var pending []map[string]interface{}
+	for _, signer := range signers {
+		if signer.HasSigned && signer.SignedAt != nil {
+			signed = append(signed, signer)
+			continue
+		}
+		entry := map[string]interface{}{"Name": signerDisplayName(signer), "Email": signer.Email}
+		if extended := extensions[strings.ToLower(signer.Email)].EffectiveDeadline(deadline); extended != nil && extended.After(*deadline) {
+			entry["ExtendedUntil"] = extended.UTC().Format("2006-01-02 15:04 MST")
+		}
+		pending = append(pending, entry)
+	}
+	sort.SliceStable(signed, func(i, j int) bool { return signed[i].SignedAt.Before(*signed[j].SignedAt) })
+
+	timeline := make([]map[string]interface{}, 0, len(signed))
+	for _, signer := range signed {
+		timeline = append(timeline, map[string]interface{}{
+			"Name":     signerDisplayName(signer),
+			"Email":    signer.Email,
+			"SignedAt": signer.SignedAt.UTC().Format("2006-01-02 15:04 MST"),
+		})
+	}
+
+	data := map[string]interface{}{
+		"DocID":          doc.DocID,
+		"DocTitle":       doc.GetTitle(),
+		"Trigger":        string(trigger),
+		"ExpectedCount":  stats.ExpectedCount,
+		"SignedCount":    stats.SignedCount,
+		"PendingCount":   stats.PendingCount,
+		"CompletionRate": fmt.Sprintf("%.0f", stats.CompletionRate),
+		"Timeline":       timeline,
+		"Pending":        pending,
+		"AdminURL":       adminURL,
+		"ExportURL":      fmt.Sprintf("%s/api/v1/admin/documents/%s/signers", s.baseURL, url.PathEscape(doc.DocID)),
+	}
+
+	refType := completionReferenceType
+	docID := doc.DocID
+	createdBy := completionActor
+	_, err = s.queue.Enqueue(ctx, models.EmailQueueInput{
+		ToAddresses:   []string{doc.CreatedBy},
+		Subject:       subject,
+		Template:      completionEmailTemplate,
+		Locale:        s.locale,
+		Data:          data,
+		Priority:      models.EmailPriorityNormal,
+		ReferenceType: &refType,
+		ReferenceID:   &docID,
+		CreatedBy:     &createdBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue completion email: %w", err)
+	}
+	return nil
+}
+
+// signerExtensions returns the deadline extensions of the signers, or nil when the document has no
+// deadline. A lookup failure only drops the extended dates from the summary.
+func (s *CompletionNotificationService) signerExtensions(ctx context.Context, docID string, deadline *time.Time) map[string]*models.SignerExtensions {
+	if s.extensions == nil || deadline == nil {
+		return nil
+	}
+	extensions, err := s.extensions.SignerExtensions(ctx, docID)
+	if err != nil {
+		logger.Logger.Warn("Failed to load deadline extensions for completion summary", "doc_id", docID, "error", err.Error())
+		return nil
+	}
+	return extensions
+}
+
+func (s *CompletionNotificationService) subject(trigger models.CompletionTrigger, title string) string {
+	subject := "Document reading progress" // Fallback
+	if s.i18n != nil {
+		subject = s.i18n.T(s.locale, "email.completion.subject."+string(trigger))
+	}
+	if title == "" {
+		return subject
+	}
+	return subject + ": " + title
+}
+
+func signerDisplayName(signer *models.ExpectedSignerWithStatus) string {
+	if signer.Name == "" && signer.UserName != nil {
+		return *signer.UserName
+	}
+	return signer.Name
+}
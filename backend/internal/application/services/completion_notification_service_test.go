@@ -0,0 +1,330 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeCompletionRepo struct {
+	settings map[string]*models.CompletionSettings
+	due      []string
+	marked   []models.CompletionTrigger
+}
+
+func (f *fakeCompletionRepo) GetByDocID(_ context.Context, docID string) (*models.CompletionSettings, error) {
+	return f.settings[docID], nil
+}
+
+func (f *fakeCompletionRepo) Upsert(_ context.Context, docID string, input models.CompletionSettingsInput, updatedBy string) (*models.CompletionSettings, error) {
+	s := &models.CompletionSettings{
+		DocID: docID, NotifyOnComplete: input.NotifyOnComplete, ThresholdPercent: input.ThresholdPercent,
+		Deadline: input.Deadline, NotifyWebhook: input.NotifyWebhook, SlackWebhookURL: input.SlackWebhookURL, UpdatedBy: updatedBy,
+	}
+	f.settings[docID] = s
+	return s, nil
+}
+
+func (f *fakeCompletionRepo) MarkNotified(_ context.Context, docID string, trigger models.CompletionTrigger, at time.Time) (bool, error) {
+	s, ok := f.settings[docID]
+	if !ok {
+		s = &models.CompletionSettings{DocID: docID, NotifyOnComplete: true}
+		f.settings[docID] = s
+	}
+	if s.NotifiedAt(trigger) != nil {
+		return false, nil
+	}
+	switch trigger {
+	case models.CompletionTriggerCompleted:
+		s.CompletedNotifiedAt = &at
+	case models.CompletionTriggerThreshold:
+		s.ThresholdNotifiedAt = &at
+	case models.CompletionTriggerDeadline:
+		s.DeadlineNotifiedAt = &at
+	}
+	f.marked = append(f.marked, trigger)
+	return true, nil
+}
+
+func (f *fakeCompletionRepo) ListDeadlinesDue(_ context.Context, _ time.Time) ([]string, error) {
+	return f.due, nil
+}
+
+type fakeCompletionDocRepo struct {
+	docs map[string]*models.Document
+}
+
+func (f *fakeCompletionDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+type fakeCompletionSignerRepo struct {
+	stats   *models.DocCompletionStats
+	signers []*models.ExpectedSignerWithStatus
+}
+
+func (f *fakeCompletionSignerRepo) GetStats(_ context.Context, _ string) (*models.DocCompletionStats, error) {
+	return f.stats, nil
+}
+
+func (f *fakeCompletionSignerRepo) ListWithStatusByDocID(_ context.Context, _ string) ([]*models.ExpectedSignerWithStatus, error) {
+	return f.signers, nil
+}
+
+type fakeCompletionQueue struct {
+	inputs []models.EmailQueueInput
+}
+
+func (f *fakeCompletionQueue) Enqueue(_ context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error) {
+	f.inputs = append(f.inputs, input)
+	return &models.EmailQueueItem{ID: int64(len(f.inputs))}, nil
+}
+
+type fakeCompletionPublisher struct {
+	events []string
+}
+
+func (f *fakeCompletionPublisher) Publish(_ context.Context, eventType string, _ map[string]interface{}) error {
+	f.events = append(f.events, eventType)
+	return nil
+}
+
+type fakeSlackPoster struct {
+	urls  []string
+	texts []string
+	err   error
+}
+
+func (f *fakeSlackPoster) PostMessage(_ context.Context, webhookURL, text string) error {
+	f.urls = append(f.urls, webhookURL)
+	f.texts = append(f.texts, text)
+	return f.err
+}
+
+type fakeTranslator struct{}
+
+func (fakeTranslator) T(_, key string) string { return key }
+
+type completionFixture struct {
+	service   *CompletionNotificationService
+	repo      *fakeCompletionRepo
+	signers   *fakeCompletionSignerRepo
+	queue     *fakeCompletionQueue
+	publisher *fakeCompletionPublisher
+	slack     *fakeSlackPoster
+}
+
+func newCompletionFixture(stats *models.DocCompletionStats) *completionFixture {
+	first := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	second := first.Add(-time.Hour)
+	f := &completionFixture{
+		repo: &fakeCompletionRepo{settings: map[string]*models.CompletionSettings{}},
+		signers: &fakeCompletionSignerRepo{stats: stats, signers: []*models.ExpectedSignerWithStatus{
+			{ExpectedSigner: models.ExpectedSigner{Email: "bob@example.com", Name: "Bob"}, HasSigned: true, SignedAt: &first},
+			{ExpectedSigner: models.ExpectedSigner{Email: "alice@example.com", Name: "Alice"}, HasSigned: true, SignedAt: &second},
+			{ExpectedSigner: models.ExpectedSigner{Email: "carol@example.com"}},
+		}},
+		queue:     &fakeCompletionQueue{},
+		publisher: &fakeCompletionPublisher{},
+		slack:     &fakeSlackPoster{},
+	}
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{
+		"doc1": {DocID: "doc1", Title: "Security policy", CreatedBy: "owner@example.com"},
+	}}
+	f.service = NewCompletionNotificationService(f.repo, docs, f.signers, f.queue, f.publisher, f.slack, fakeTranslator{}, "https://ackify.example.com", "en")
+	return f
+}
+
+func TestCompletionNotification_OnSignature_CompletedByDefault(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 2, SignedCount: 2, CompletionRate: 100})
+
+	if err := f.service.OnSignature(context.Background(), "doc1"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+
+	if len(f.queue.inputs) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(f.queue.inputs))
+	}
+	input := f.queue.inputs[0]
+	if input.ToAddresses[0] != "owner@example.com" {
+		t.Errorf("expected email to creator, got %v", input.ToAddresses)
+	}
+	if input.Template != "completion_summary" {
+		t.Errorf("expected completion_summary template, got %s", input.Template)
+	}
+	if input.Subject != "email.completion.subject.completed: Security policy" {
+		t.Errorf("unexpected subject %q", input.Subject)
+	}
+	timeline := input.Data["Timeline"].([]map[string]interface{})
+	if len(timeline) != 2 || timeline[0]["Email"] != "alice@example.com" {
+		t.Errorf("expected timeline ordered by signing date, got %v", timeline)
+	}
+	if input.Data["ExportURL"] != "https://ackify.example.com/api/v1/admin/documents/doc1/signers" {
+		t.Errorf("unexpected export URL %v", input.Data["ExportURL"])
+	}
+	if len(f.publisher.events) != 0 {
+		t.Errorf("document.completed is published by the signature flow, got %v", f.publisher.events)
+	}
+
+	// A second signature event must not notify again
+	if err := f.service.OnSignature(context.Background(), "doc1"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+	if len(f.queue.inputs) != 1 {
+		t.Errorf("expected completion notified once, got %d emails", len(f.queue.inputs))
+	}
+}
+
+func TestCompletionNotification_OnSignature_Threshold(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 5, SignedCount: 4, PendingCount: 1, CompletionRate: 80})
+	threshold := 80
+	f.repo.settings["doc1"] = &models.CompletionSettings{
+		DocID: "doc1", NotifyOnComplete: true, ThresholdPercent: &threshold,
+		NotifyWebhook: true, SlackWebhookURL: "https://hooks.slack.com/services/T/B/X",
+	}
+
+	if err := f.service.OnSignature(context.Background(), "doc1"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+
+	if len(f.repo.marked) != 1 || f.repo.marked[0] != models.CompletionTriggerThreshold {
+		t.Fatalf("expected threshold trigger, got %v", f.repo.marked)
+	}
+	if len(f.queue.inputs) != 1 || f.queue.inputs[0].Data["Trigger"] != "threshold" {
+		t.Errorf("expected threshold email, got %v", f.queue.inputs)
+	}
+	if len(f.publisher.events) != 1 || f.publisher.events[0] != "document.threshold_reached" {
+		t.Errorf("expected document.threshold_reached webhook, got %v", f.publisher.events)
+	}
+	if len(f.slack.texts) != 1 || !strings.Contains(f.slack.texts[0], "4/5 (80%)") {
+		t.Errorf("expected slack summary, got %v", f.slack.texts)
+	}
+}
+
+func TestCompletionNotification_OnSignature_BelowThreshold(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 5, SignedCount: 3, PendingCount: 2, CompletionRate: 60})
+	threshold := 80
+	f.repo.settings["doc1"] = &models.CompletionSettings{DocID: "doc1", NotifyOnComplete: true, ThresholdPercent: &threshold}
+
+	if err := f.service.OnSignature(context.Background(), "doc1"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+	if len(f.queue.inputs) != 0 || len(f.repo.marked) != 0 {
+		t.Errorf("expected no notification below threshold, got %d emails", len(f.queue.inputs))
+	}
+}
+
+func TestCompletionNotification_OnSignature_CompletionDisabled(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 2, SignedCount: 2, CompletionRate: 100})
+	f.repo.settings["doc1"] = &models.CompletionSettings{DocID: "doc1", NotifyOnComplete: false}
+
+	if err := f.service.OnSignature(context.Background(), "doc1"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+	if len(f.queue.inputs) != 0 {
+		t.Errorf("expected no email when completion notifications are disabled, got %d", len(f.queue.inputs))
+	}
+}
+
+func TestCompletionNotification_RunDeadlineChecks(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 3, SignedCount: 2, PendingCount: 1, CompletionRate: 66.7})
+	deadline := time.Now().Add(-time.Minute)
+	f.repo.settings["doc1"] = &models.CompletionSettings{DocID: "doc1", NotifyOnComplete: true, Deadline: &deadline, NotifyWebhook: true}
+	f.repo.due = []string{"doc1"}
+
+	if err := f.service.RunDeadlineChecks(context.Background()); err != nil {
+		t.Fatalf("RunDeadlineChecks failed: %v", err)
+	}
+
+	if len(f.queue.inputs) != 1 {
+		t.Fatalf("expected 1 deadline email, got %d", len(f.queue.inputs))
+	}
+	pending := f.queue.inputs[0].Data["Pending"].([]map[string]interface{})
+	if len(pending) != 1 || pending[0]["Email"] != "carol@example.com" {
+		t.Errorf("expected pending signer listed, got %v", pending)
+	}
+	if len(f.publisher.events) != 1 || f.publisher.events[0] != "document.deadline_reached" {
+		t.Errorf("expected document.deadline_reached webhook, got %v", f.publisher.events)
+	}
+}
+
+func TestCompletionNotification_DeadlineSummaryShowsExtensions(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 3, SignedCount: 2, PendingCount: 1, CompletionRate: 66.7})
+	deadline := time.Now().Add(-time.Minute)
+	granted := time.Date(2026, 12, 1, 17, 0, 0, 0, time.UTC)
+	f.repo.settings["doc1"] = &models.CompletionSettings{DocID: "doc1", NotifyOnComplete: true, Deadline: &deadline}
+	f.repo.due = []string{"doc1"}
+	f.service.SetDeadlineExtensions(fakeSignerExtensions{
+		"carol@example.com": {Approved: &models.DeadlineExtension{Status: models.ExtensionStatusApproved, GrantedDeadline: &granted}},
+	})
+
+	if err := f.service.RunDeadlineChecks(context.Background()); err != nil {
+		t.Fatalf("RunDeadlineChecks failed: %v", err)
+	}
+
+	pending := f.queue.inputs[0].Data["Pending"].([]map[string]interface{})
+	if len(pending) != 1 || pending[0]["ExtendedUntil"] != "2026-12-01 17:00 UTC" {
+		t.Errorf("expected the extended deadline of the pending signer, got %v", pending)
+	}
+}
+
+func TestCompletionNotification_SlackFailureReported(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{ExpectedCount: 1, SignedCount: 1, CompletionRate: 100})
+	f.repo.settings["doc1"] = &models.CompletionSettings{DocID: "doc1", NotifyOnComplete: true, SlackWebhookURL: "https://hooks.slack.com/x"}
+	f.slack.err = errors.New("slack down")
+
+	err := f.service.OnSignature(context.Background(), "doc1")
+	if err == nil {
+		t.Fatal("expected slack error to be reported")
+	}
+	if len(f.queue.inputs) != 1 {
+		t.Errorf("expected email still queued, got %d", len(f.queue.inputs))
+	}
+}
+
+func TestCompletionNotification_UpdateSettings_Validation(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{})
+	zero, tooHigh, valid := 0, 100, 80
+
+	tests := []struct {
+		name  string
+		docID string
+		input models.CompletionSettingsInput
+		want  error
+	}{
+		{"threshold too low", "doc1", models.CompletionSettingsInput{ThresholdPercent: &zero}, ErrInvalidCompletionSettings},
+		{"threshold too high", "doc1", models.CompletionSettingsInput{ThresholdPercent: &tooHigh}, ErrInvalidCompletionSettings},
+		{"slack url not https", "doc1", models.CompletionSettingsInput{SlackWebhookURL: "http://hooks.slack.com/x"}, ErrInvalidCompletionSettings},
+		{"unknown document", "missing", models.CompletionSettingsInput{ThresholdPercent: &valid}, models.ErrDocumentNotFound},
+		{"valid", "doc1", models.CompletionSettingsInput{NotifyOnComplete: true, ThresholdPercent: &valid, SlackWebhookURL: " https://hooks.slack.com/x "}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := f.service.UpdateSettings(context.Background(), tt.docID, tt.input, "admin@example.com")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected error %v, got %v", tt.want, err)
+			}
+			if tt.want == nil && settings.SlackWebhookURL != "https://hooks.slack.com/x" {
+				t.Errorf("expected trimmed slack URL, got %q", settings.SlackWebhookURL)
+			}
+		})
+	}
+}
+
+func TestCompletionNotification_GetSettings_Defaults(t *testing.T) {
+	f := newCompletionFixture(&models.DocCompletionStats{})
+
+	settings, err := f.service.GetSettings(context.Background(), "doc1")
+	if err != nil {
+		t.Fatalf("GetSettings failed: %v", err)
+	}
+	if !settings.NotifyOnComplete || settings.ThresholdPercent != nil || settings.Deadline != nil {
+		t.Errorf("expected completion-only defaults, got %+v", settings)
+	}
+}
@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/certificate"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	// ErrReportNotReady is returned when downloading a report still being generated or failed
+	ErrReportNotReady = errors.New("compliance report is not ready")
+	// ErrInvalidReportFormat is returned for a download format other than json, csv and pdf
+	ErrInvalidReportFormat = errors.New("format must be json, csv or pdf")
+)
+
+// Report download formats
+const (
+	ReportFormatJSON = "json"
+	ReportFormatCSV  = "csv"
+	ReportFormatPDF  = "pdf"
+)
+
+const (
+	// complianceReportMaxAge is how long a ready report is served again for the same period
+	complianceReportMaxAge = time.Hour
+	// complianceReportStaleAfter is how long a report may stay processing before it is claimed again
+	complianceReportStaleAfter = 15 * time.Minute
+	// complianceReportRetention is how long reports are kept
+	complianceReportRetention = 30 * 24 * time.Hour
+)
+
+// complianceReportRepository stores compliance reports and aggregates the documents they cover
+type complianceReportRepository interface {
+	Create(ctx context.Context, period models.ReportPeriod, requestedBy string) (*models.ComplianceReport, error)
+	GetByID(ctx context.Context, id int64) (*models.ComplianceReport, error)
+	GetLatest(ctx context.Context, period string) (*models.ComplianceReport, error)
+	ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ComplianceReport, error)
+	Complete(ctx context.Context, id int64, data *models.ComplianceReportData) error
+	Fail(ctx context.Context, id int64, message string) error
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+	ListDocumentRows(ctx context.Context, start, end time.Time) ([]models.ComplianceDocumentRow, error)
+	ListOutstandingUsers(ctx context.Context, start, end time.Time) ([]models.ComplianceUserRow, error)
+}
+
+// ComplianceReportService generates cross-document compliance reports in the background.
+// A report reflects the state at the end of its period, or at generation time for the current period.
+type ComplianceReportService struct {
+	repo   complianceReportRepository
+	config certificateConfig
+	now    func() time.Time
+}
+
+func NewComplianceReportService(repo complianceReportRepository) *ComplianceReportService {
+	return &ComplianceReportService{repo: repo, now: time.Now}
+}
+
+// SetConfig sets the tenant configuration, whose organization name is printed on PDF reports
+func (s *ComplianceReportService) SetConfig(config certificateConfig) {
+	s.config = config
+}
+
+// Request returns the report of a period, queuing its generation unless one is in progress or
+// was generated within the last hour. refresh queues a new report even then, unless one is in progress.
+func (s *ComplianceReportService) Request(ctx context.Context, period string, requestedBy string, refresh bool) (*models.ComplianceReport, error) {
+	parsed, err := models.ParseReportPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.repo.GetLatest(ctx, parsed.Name)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil {
+		switch latest.Status {
+		case models.ComplianceReportPending, models.ComplianceReportProcessing:
+			return latest, nil
+		case models.ComplianceReportReady:
+			if !refresh && latest.CompletedAt != nil && s.now().Sub(*latest.CompletedAt) < complianceReportMaxAge {
+				return latest, nil
+			}
+		}
+	}
+
+	report, err := s.repo.Create(ctx, parsed, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Compliance report requested", "report_id", report.ID, "period", report.Period, "requested_by", requestedBy)
+	return report, nil
+}
+
+// Get returns a report, with its data once ready
+func (s *ComplianceReportService) Get(ctx context.Context, id int64) (*models.ComplianceReport, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ProcessNext generates the oldest pending report and reports whether there was one.
+// A report that cannot be generated is marked as failed rather than returning an error.
+func (s *ComplianceReportService) ProcessNext(ctx context.Context) (bool, error) {
+	report, err := s.repo.ClaimNext(ctx, s.now().Add(-complianceReportStaleAfter))
+	if err != nil || report == nil {
+		return false, err
+	}
+
+	data, err := s.generate(ctx, report)
+	if err != nil {
+		logger.Logger.Error("Failed to generate compliance report", "report_id", report.ID, "period", report.Period, "error", err)
+		return true, s.repo.Fail(ctx, report.ID, "report generation failed")
+	}
+	if err := s.repo.Complete(ctx, report.ID, data); err != nil {
+		return true, err
+	}
+
+	logger.Logger.Info("Compliance report generated", "report_id", report.ID, "period", report.Period, "documents", len(data.Documents))
+	return true, nil
+}
+
+// generate aggregates the documents over the period of a report
+func (s *ComplianceReportService) generate(ctx context.Context, report *models.ComplianceReport) (*models.ComplianceReportData, error) {
+	documents, err := s.repo.ListDocumentRows(ctx, report.PeriodStart, report.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.repo.ListOutstandingUsers(ctx, report.PeriodStart, report.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &models.ComplianceReportData{
+		Period:      report.Period,
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		GeneratedAt: s.now().UTC(),
+		Documents:   documents,
+		Users:       users,
+	}
+	for i := range data.Documents {
+		row := &data.Documents[i]
+		row.Outstanding = row.Expected - row.Signed
+		data.Totals.Documents++
+		data.Totals.Expected += row.Expected
+		data.Totals.Signed += row.Signed
+		data.Totals.Late += row.Late
+		data.Totals.Outstanding += row.Outstanding
+	}
+	return data, nil
+}
+
+// PurgeExpired deletes the reports older than the retention and returns how many were deleted
+func (s *ComplianceReportService) PurgeExpired(ctx context.Context) (int, error) {
+	return s.repo.DeleteBefore(ctx, s.now().Add(-complianceReportRetention))
+}
+
+// Render returns a ready report in a download format
+func (s *ComplianceReportService) Render(report *models.ComplianceReport, format string) ([]byte, error) {
+	if report.Status != models.ComplianceReportReady || report.Data == nil {
+		return nil, ErrReportNotReady
+	}
+
+	switch format {
+	case ReportFormatJSON:
+		data, err := json.MarshalIndent(report.Data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode compliance report: %w", err)
+		}
+		return append(data, '\n'), nil
+	case ReportFormatCSV:
+		return encodeComplianceCSV(report.Data)
+	case ReportFormatPDF:
+		organisation := ""
+		if s.config != nil {
+			organisation = s.config.GetConfig().General.Organisation
+		}
+		pdf, err := certificate.RenderReport(complianceReportPDF(report.Data, organisation))
+		if err != nil {
+			return nil, fmt.Errorf("failed to render compliance report: %w", err)
+		}
+		return pdf, nil
+	}
+	return nil, ErrInvalidReportFormat
+}
+
+// encodeComplianceCSV writes the document table, an empty line, then the outstanding signer table
+func encodeComplianceCSV(data *models.ComplianceReportData) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"doc_id", "title", "deadline", "expected", "signed", "signed_in_period", "late", "outstanding", "completion_rate"})
+	for _, row := range data.Documents {
+		_ = w.Write([]string{
+			row.DocID, row.Title, formatReportDeadline(row.Deadline, time.RFC3339),
+			strconv.Itoa(row.Expected), strconv.Itoa(row.Signed), strconv.Itoa(row.SignedInPeriod),
+			strconv.Itoa(row.Late), strconv.Itoa(row.Outstanding), strconv.FormatFloat(row.CompletionRate(), 'f', 1, 64),
+		})
+	}
+	_ = w.Write([]string{""})
+	_ = w.Write([]string{"email", "name", "outstanding", "doc_ids"})
+	for _, row := range data.Users {
+		_ = w.Write([]string{row.Email, row.Name, strconv.Itoa(row.Outstanding), strings.Join(row.DocIDs, " ")})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode compliance report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// complianceReportPDF lays a report out as the document and outstanding signer tables
+func complianceReportPDF(data *models.ComplianceReportData, organisation string) certificate.Report {
+	documents := certificate.Table{
+		Title: "Documents",
+		Columns: []certificate.Column{
+			{Header: "Document", Width: 44}, {Header: "Deadline", Width: 12}, {Header: "Expected", Width: 11},
+			{Header: "Signed", Width: 10}, {Header: "Late", Width: 8}, {Header: "Outstanding", Width: 14}, {Header: "Rate", Width: 8},
+		},
+	}
+	for _, row := range data.Documents {
+		title := row.Title
+		if title == "" {
+			title = row.DocID
+		}
+		documents.Rows = append(documents.Rows, []string{
+			title, formatReportDeadline(row.Deadline, time.DateOnly), strconv.Itoa(row.Expected), strconv.Itoa(row.Signed),
+			strconv.Itoa(row.Late), strconv.Itoa(row.Outstanding), strconv.FormatFloat(row.CompletionRate(), 'f', 0, 64) + "%",
+		})
+	}
+
+	users := certificate.Table{
+		Title:   "Outstanding signers",
+		Columns: []certificate.Column{{Header: "Signer", Width: 40}, {Header: "Outstanding", Width: 14}, {Header: "Documents", Width: 53}},
+	}
+	for _, row := range data.Users {
+		users.Rows = append(users.Rows, []string{row.Email, strconv.Itoa(row.Outstanding), strings.Join(row.DocIDs, ", ")})
+	}
+
+	state := "state at the end of the period"
+	if data.GeneratedAt.Before(data.PeriodEnd) {
+		state = "state on " + data.GeneratedAt.Format(time.DateOnly)
+	}
+	last := data.PeriodEnd.AddDate(0, 0, -1)
+	return certificate.Report{
+		Organisation: organisation,
+		Title:        "Compliance report " + data.Period,
+		Summary: []string{
+			fmt.Sprintf("Period: %s to %s, %s", data.PeriodStart.Format(time.DateOnly), last.Format(time.DateOnly), state),
+			fmt.Sprintf("%d documents, %d of %d expected signatures, %d late, %d outstanding",
+				data.Totals.Documents, data.Totals.Signed, data.Totals.Expected, data.Totals.Late, data.Totals.Outstanding),
+		},
+		GeneratedAt: data.GeneratedAt,
+		Tables:      []certificate.Table{documents, users},
+	}
+}
+
+func formatReportDeadline(deadline *time.Time, layout string) string {
+	if deadline == nil {
+		return ""
+	}
+	return deadline.UTC().Format(layout)
+}
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeComplianceReportRepo struct {
+	reports  []*models.ComplianceReport
+	claimed  *models.ComplianceReport
+	failed   string
+	rowsErr  error
+	docRows  []models.ComplianceDocumentRow
+	userRows []models.ComplianceUserRow
+}
+
+func (f *fakeComplianceReportRepo) Create(_ context.Context, period models.ReportPeriod, requestedBy string) (*models.ComplianceReport, error) {
+	report := &models.ComplianceReport{
+		ID: int64(len(f.reports) + 1), Period: period.Name, PeriodStart: period.Start, PeriodEnd: period.End,
+		Status: models.ComplianceReportPending, RequestedBy: requestedBy,
+	}
+	f.reports = append(f.reports, report)
+	return report, nil
+}
+
+func (f *fakeComplianceReportRepo) GetByID(_ context.Context, id int64) (*models.ComplianceReport, error) {
+	for _, r := range f.reports {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, models.ErrReportNotFound
+}
+
+func (f *fakeComplianceReportRepo) GetLatest(_ context.Context, period string) (*models.ComplianceReport, error) {
+	for i := len(f.reports) - 1; i >= 0; i-- {
+		if r := f.reports[i]; r.Period == period && r.Status != models.ComplianceReportFailed {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeComplianceReportRepo) ClaimNext(_ context.Context, _ time.Time) (*models.ComplianceReport, error) {
+	for _, r := range f.reports {
+		if r.Status == models.ComplianceReportPending {
+			r.Status = models.ComplianceReportProcessing
+			f.claimed = r
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeComplianceReportRepo) Complete(_ context.Context, id int64, data *models.ComplianceReportData) error {
+	r, _ := f.GetByID(context.Background(), id)
+	r.Status, r.Data = models.ComplianceReportReady, data
+	return nil
+}
+
+func (f *fakeComplianceReportRepo) Fail(_ context.Context, id int64, message string) error {
+	r, _ := f.GetByID(context.Background(), id)
+	r.Status, r.Error = models.ComplianceReportFailed, message
+	f.failed = message
+	return nil
+}
+
+func (f *fakeComplianceReportRepo) DeleteBefore(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (f *fakeComplianceReportRepo) ListDocumentRows(_ context.Context, _, _ time.Time) ([]models.ComplianceDocumentRow, error) {
+	return f.docRows, f.rowsErr
+}
+
+func (f *fakeComplianceReportRepo) ListOutstandingUsers(_ context.Context, _, _ time.Time) ([]models.ComplianceUserRow, error) {
+	return f.userRows, nil
+}
+
+func TestComplianceReportService_Request(t *testing.T) {
+	repo := &fakeComplianceReportRepo{}
+	service := NewComplianceReportService(repo)
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if _, err := service.Request(ctx, "2024-Q5", "admin@example.com", false); !errors.Is(err, models.ErrInvalidPeriod) {
+		t.Fatalf("expected ErrInvalidPeriod, got %v", err)
+	}
+
+	report, err := service.Request(ctx, "2024-q4", "admin@example.com", false)
+	if err != nil || report.Period != "2024-Q4" || report.Status != models.ComplianceReportPending {
+		t.Fatalf("unexpected report %+v, %v", report, err)
+	}
+
+	// A report in progress is returned, even on refresh
+	again, err := service.Request(ctx, "2024-Q4", "other@example.com", true)
+	if err != nil || again.ID != report.ID {
+		t.Fatalf("expected the pending report, got %+v, %v", again, err)
+	}
+
+	completed := now.Add(-30 * time.Minute)
+	report.Status, report.CompletedAt = models.ComplianceReportReady, &completed
+	if again, _ := service.Request(ctx, "2024-Q4", "admin@example.com", false); again.ID != report.ID {
+		t.Errorf("expected the recent report to be reused, got %d", again.ID)
+	}
+	if again, _ := service.Request(ctx, "2024-Q4", "admin@example.com", true); again.ID == report.ID {
+		t.Error("expected refresh to queue a new report")
+	}
+
+	// Old reports are generated again
+	old := now.Add(-2 * time.Hour)
+	repo.reports = []*models.ComplianceReport{{ID: 1, Period: "2024-Q3", Status: models.ComplianceReportReady, CompletedAt: &old}}
+	if again, _ := service.Request(ctx, "2024-Q3", "admin@example.com", false); again.ID == 1 {
+		t.Error("expected an old report to be generated again")
+	}
+}
+
+func TestComplianceReportService_ProcessNext(t *testing.T) {
+	deadline := time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)
+	repo := &fakeComplianceReportRepo{
+		docRows: []models.ComplianceDocumentRow{
+			{DocID: "policy", Title: "Security policy", Deadline: &deadline, Expected: 4, Signed: 3, SignedInPeriod: 2, Late: 1},
+			{DocID: "charter", Expected: 2, Signed: 2, SignedInPeriod: 2},
+		},
+		userRows: []models.ComplianceUserRow{{Email: "bob@example.com", Outstanding: 1, DocIDs: []string{"policy"}}},
+	}
+	service := NewComplianceReportService(repo)
+	ctx := context.Background()
+
+	if processed, err := service.ProcessNext(ctx); err != nil || processed {
+		t.Fatalf("expected nothing to process, got %v, %v", processed, err)
+	}
+
+	report, _ := service.Request(ctx, "2024-Q4", "admin@example.com", false)
+	if processed, err := service.ProcessNext(ctx); err != nil || !processed {
+		t.Fatalf("expected a report to be processed, got %v, %v", processed, err)
+	}
+	if report.Status != models.ComplianceReportReady || report.Data == nil {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	totals := report.Data.Totals
+	if totals.Documents != 2 || totals.Expected != 6 || totals.Signed != 5 || totals.Late != 1 || totals.Outstanding != 1 {
+		t.Errorf("unexpected totals %+v", totals)
+	}
+	if report.Data.Documents[0].Outstanding != 1 {
+		t.Errorf("unexpected document row %+v", report.Data.Documents[0])
+	}
+
+	// Aggregation errors fail the report without failing the worker
+	repo.rowsErr = errors.New("connection reset")
+	failed, _ := service.Request(ctx, "2024-Q3", "admin@example.com", false)
+	if processed, err := service.ProcessNext(ctx); err != nil || !processed {
+		t.Fatalf("expected the report to be processed, got %v, %v", processed, err)
+	}
+	if failed.Status != models.ComplianceReportFailed || repo.failed == "" {
+		t.Errorf("expected a failed report, got %+v", failed)
+	}
+}
+
+func TestComplianceReportService_Render(t *testing.T) {
+	repo := &fakeComplianceReportRepo{
+		docRows:  []models.ComplianceDocumentRow{{DocID: "policy", Title: "Security policy", Expected: 2, Signed: 1}},
+		userRows: []models.ComplianceUserRow{{Email: "bob@example.com", Outstanding: 1, DocIDs: []string{"policy"}}},
+	}
+	service := NewComplianceReportService(repo)
+	ctx := context.Background()
+
+	report, _ := service.Request(ctx, "2024-Q4", "admin@example.com", false)
+	if _, err := service.Render(report, ReportFormatCSV); !errors.Is(err, ErrReportNotReady) {
+		t.Fatalf("expected ErrReportNotReady, got %v", err)
+	}
+	if _, err := service.ProcessNext(ctx); err != nil {
+		t.Fatalf("ProcessNext err: %v", err)
+	}
+
+	data, err := service.Render(report, ReportFormatJSON)
+	if err != nil {
+		t.Fatalf("Render json err: %v", err)
+	}
+	var decoded models.ComplianceReportData
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Period != "2024-Q4" || len(decoded.Users) != 1 {
+		t.Errorf("unexpected json report %+v, %v", decoded, err)
+	}
+
+	data, err = service.Render(report, ReportFormatCSV)
+	if err != nil {
+		t.Fatalf("Render csv err: %v", err)
+	}
+	for _, want := range []string{"doc_id,title,deadline", "policy,Security policy,,2,1,0,0,1,50.0", "\n\nemail,name,outstanding,doc_ids\n", "bob@example.com,,1,policy"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in the csv report:\n%s", want, data)
+		}
+	}
+
+	data, err = service.Render(report, ReportFormatPDF)
+	if err != nil || !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Errorf("expected a PDF report, got %v", err)
+	}
+
+	if _, err := service.Render(report, "xlsx"); !errors.Is(err, ErrInvalidReportFormat) {
+		t.Errorf("expected ErrInvalidReportFormat, got %v", err)
+	}
+}
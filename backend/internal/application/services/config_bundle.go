@@ -0,0 +1,209 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	ErrBundleVersion     = errors.New("unsupported configuration bundle version")
+	ErrBundleKeyRequired = errors.New("bundle secrets are encrypted: a key is required")
+	ErrBundleInvalidKey  = errors.New("failed to decrypt bundle secrets: invalid key")
+)
+
+const (
+	bundleKeyIterations = 600000
+	bundleSaltSize      = 16
+)
+
+// ExportBundle returns all configuration sections as a single bundle.
+// Without passphrase secrets are masked; otherwise they are encrypted with a key derived from it.
+func (s *ConfigService) ExportBundle(passphrase string) (*models.ConfigBundle, error) {
+	cfg := s.GetConfig()
+
+	bundle := &models.ConfigBundle{
+		Version:    models.ConfigBundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Secrets:    models.BundleSecretsRedacted,
+		General:    cfg.General,
+		OIDC:       cfg.OIDC,
+		MagicLink:  cfg.MagicLink,
+		SMTP:       cfg.SMTP,
+		Storage:    cfg.Storage,
+		Branding:   cfg.Branding,
+		Reminders:  cfg.Reminders,
+		Security:   cfg.Security,
+	}
+
+	if passphrase == "" {
+		bundle.OIDC.ClientSecret = maskIfSet(cfg.OIDC.ClientSecret)
+		bundle.SMTP.Password = maskIfSet(cfg.SMTP.Password)
+		bundle.Storage.S3SecretKey = maskIfSet(cfg.Storage.S3SecretKey)
+		return bundle, nil
+	}
+
+	secretsJSON, err := json.Marshal(models.ConfigSecrets{
+		OIDCClientSecret: cfg.OIDC.ClientSecret,
+		SMTPPassword:     cfg.SMTP.Password,
+		S3SecretKey:      cfg.Storage.S3SecretKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+
+	salt := make([]byte, bundleSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := deriveBundleKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := crypto.EncryptToken(string(secretsJSON), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secrets: %w", err)
+	}
+
+	bundle.Secrets = models.BundleSecretsEncrypted
+	bundle.OIDC.ClientSecret = ""
+	bundle.SMTP.Password = ""
+	bundle.Storage.S3SecretKey = ""
+	bundle.EncryptedSecrets = encrypted
+	bundle.KeySalt = salt
+	return bundle, nil
+}
+
+// ImportBundle replaces all configuration sections with the content of a bundle.
+// Masked secrets keep their current value; encrypted secrets require the export passphrase.
+// The whole bundle is validated before anything is stored.
+func (s *ConfigService) ImportBundle(ctx context.Context, bundle *models.ConfigBundle, passphrase, updatedBy string) error {
+	if bundle.Version != models.ConfigBundleVersion {
+		return ErrBundleVersion
+	}
+
+	current := s.GetConfig()
+	imported := models.MutableConfig{
+		General:   bundle.General,
+		OIDC:      bundle.OIDC,
+		MagicLink: bundle.MagicLink,
+		SMTP:      bundle.SMTP,
+		Storage:   bundle.Storage,
+		Branding:  bundle.Branding,
+		Reminders: bundle.Reminders,
+		Security:  bundle.Security,
+	}
+
+	// The logo file lives in the storage of the exporting instance: keep the current one
+	imported.Branding.LogoKey = current.Branding.LogoKey
+	imported.Branding.LogoContentType = current.Branding.LogoContentType
+
+	switch bundle.Secrets {
+	case models.BundleSecretsEncrypted:
+		secrets, err := decryptBundleSecrets(bundle, passphrase)
+		if err != nil {
+			return err
+		}
+		imported.OIDC.ClientSecret = secrets.OIDCClientSecret
+		imported.SMTP.Password = secrets.SMTPPassword
+		imported.Storage.S3SecretKey = secrets.S3SecretKey
+	case models.BundleSecretsRedacted, "":
+		if models.IsSecretMasked(imported.OIDC.ClientSecret) {
+			imported.OIDC.ClientSecret = current.OIDC.ClientSecret
+		}
+		if models.IsSecretMasked(imported.SMTP.Password) {
+			imported.SMTP.Password = current.SMTP.Password
+		}
+		if models.IsSecretMasked(imported.Storage.S3SecretKey) {
+			imported.Storage.S3SecretKey = current.Storage.S3SecretKey
+		}
+	default:
+		return fmt.Errorf("unknown secrets mode: %s", bundle.Secrets)
+	}
+
+	sections := []struct {
+		category models.ConfigCategory
+		cfg      any
+		secrets  any
+	}{
+		{models.ConfigCategoryGeneral, imported.General, nil},
+		{models.ConfigCategoryOIDC, imported.OIDC, models.OIDCSecrets{ClientSecret: imported.OIDC.ClientSecret}},
+		{models.ConfigCategoryMagicLink, imported.MagicLink, nil},
+		{models.ConfigCategorySMTP, imported.SMTP, models.SMTPSecrets{Password: imported.SMTP.Password}},
+		{models.ConfigCategoryStorage, imported.Storage, models.StorageSecrets{S3SecretKey: imported.Storage.S3SecretKey}},
+		{models.ConfigCategoryBranding, imported.Branding, nil},
+		{models.ConfigCategoryReminders, imported.Reminders, nil},
+		{models.ConfigCategorySecurity, imported.Security, nil},
+	}
+
+	for _, section := range sections {
+		input, err := json.Marshal(section.cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s config: %w", section.category, err)
+		}
+		if err := s.validateSection(section.category, input); err != nil {
+			return fmt.Errorf("validation failed for %s: %w", section.category, err)
+		}
+	}
+	if err := s.validateCrossCategory(&imported); err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		if err := s.upsertSection(ctx, section.category, section.cfg, section.secrets, updatedBy); err != nil {
+			return fmt.Errorf("failed to save %s config: %w", section.category, err)
+		}
+	}
+
+	return s.reload(ctx)
+}
+
+// decryptBundleSecrets opens the encrypted secrets of a bundle with the export passphrase
+func decryptBundleSecrets(bundle *models.ConfigBundle, passphrase string) (*models.ConfigSecrets, error) {
+	if passphrase == "" {
+		return nil, ErrBundleKeyRequired
+	}
+	var secrets models.ConfigSecrets
+	if len(bundle.EncryptedSecrets) == 0 {
+		return &secrets, nil
+	}
+
+	key, err := deriveBundleKey(passphrase, bundle.KeySalt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := crypto.DecryptToken(bundle.EncryptedSecrets, key)
+	if err != nil {
+		return nil, ErrBundleInvalidKey
+	}
+	if err := json.Unmarshal([]byte(plaintext), &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle secrets: %w", err)
+	}
+	return &secrets, nil
+}
+
+// deriveBundleKey derives an AES-256 key from an export passphrase
+func deriveBundleKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, bundleKeyIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive bundle key: %w", err)
+	}
+	return key, nil
+}
+
+// maskIfSet returns the secret mask if the secret is set, empty string otherwise
+func maskIfSet(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return models.SecretMask
+}
@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// configSecretFields are the JSON names of the secret field of each category
+var configSecretFields = map[models.ConfigCategory]string{
+	models.ConfigCategoryOIDC:    "client_secret",
+	models.ConfigCategorySMTP:    "password",
+	models.ConfigCategoryStorage: "s3_secret_key",
+}
+
+// StageSection validates a section update and stages it until the pending changes are applied.
+// Cross-category rules are checked when applying only, so that sections depending on each
+// other can be staged one by one. Staging a section again replaces the staged one.
+func (s *ConfigService) StageSection(ctx context.Context, category models.ConfigCategory, input json.RawMessage, stagedBy string) (*models.PendingConfig, error) {
+	input, _, err := s.prepareUpdate(category, input)
+	if err != nil {
+		return nil, err
+	}
+
+	configWithoutSecrets, encryptedSecrets, err := s.processSecrets(category, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process secrets: %w", err)
+	}
+
+	if err := s.repo.StagePending(ctx, category, configWithoutSecrets, encryptedSecrets, stagedBy); err != nil {
+		return nil, err
+	}
+
+	return s.PendingChanges(ctx)
+}
+
+// PendingChanges returns the staged sections with the changes and the cross-category
+// errors of the config they would produce
+func (s *ConfigService) PendingChanges(ctx context.Context) (*models.PendingConfig, error) {
+	sections, err := s.repo.ListPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := s.GetConfig()
+	effective, err := s.pendingConfig(current, sections)
+	if err != nil {
+		return nil, err
+	}
+
+	if sections == nil {
+		sections = []*models.PendingConfigSection{}
+	}
+	return &models.PendingConfig{Sections: sections, Preview: *s.preview(current, effective)}, nil
+}
+
+// ApplyPending stores every staged section and clears the staging area. Nothing is stored
+// unless the resulting config passes the cross-category rules, and all sections are stored
+// in the transaction of the context, opened per request by the RLS middleware.
+// It returns the changes made to the effective config.
+func (s *ConfigService) ApplyPending(ctx context.Context, appliedBy string) ([]models.ConfigChange, error) {
+	sections, err := s.repo.ListPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(sections) == 0 {
+		return nil, ErrNoPendingConfig
+	}
+
+	current := s.GetConfig()
+	effective, err := s.pendingConfig(current, sections)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateCrossCategory(effective); err != nil {
+		return nil, err
+	}
+
+	for _, section := range sections {
+		if err := s.repo.Upsert(ctx, section.Category, section.Config, section.SecretsEncrypted, appliedBy); err != nil {
+			return nil, fmt.Errorf("failed to save %s config: %w", section.Category, err)
+		}
+	}
+	if _, err := s.repo.DeletePending(ctx, ""); err != nil {
+		return nil, err
+	}
+
+	if err := s.reload(ctx); err != nil {
+		return nil, err
+	}
+	return diffConfig(current, effective), nil
+}
+
+// DiscardPending discards the staged section of a category, or every staged section when category is empty
+func (s *ConfigService) DiscardPending(ctx context.Context, category models.ConfigCategory) error {
+	if category != "" && !category.IsValid() {
+		return ErrInvalidCategory
+	}
+	_, err := s.repo.DeletePending(ctx, category)
+	return err
+}
+
+// pendingConfig returns a copy of current with the staged sections applied
+func (s *ConfigService) pendingConfig(current *models.MutableConfig, sections []*models.PendingConfigSection) (*models.MutableConfig, error) {
+	effective := *current
+	for _, section := range sections {
+		if err := s.applyPendingSection(&effective, section); err != nil {
+			return nil, fmt.Errorf("failed to apply staged %s config: %w", section.Category, err)
+		}
+	}
+	return &effective, nil
+}
+
+// applyPendingSection applies a staged section, secrets included, to cfg
+func (s *ConfigService) applyPendingSection(cfg *models.MutableConfig, section *models.PendingConfigSection) error {
+	if err := s.applyUpdateToConfig(cfg, section.Category, section.Config); err != nil {
+		return err
+	}
+	if len(section.SecretsEncrypted) == 0 {
+		return nil // The current secrets are kept
+	}
+
+	decrypted, err := s.decryptSecrets(section.SecretsEncrypted)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt secrets: %w", err)
+	}
+	var secrets map[string]string
+	if err := json.Unmarshal(decrypted, &secrets); err != nil {
+		return fmt.Errorf("failed to parse secrets: %w", err)
+	}
+
+	secret, ok := secrets[configSecretFields[section.Category]]
+	if !ok {
+		return nil
+	}
+	switch section.Category {
+	case models.ConfigCategoryOIDC:
+		cfg.OIDC.ClientSecret = secret
+	case models.ConfigCategorySMTP:
+		cfg.SMTP.Password = secret
+	case models.ConfigCategoryStorage:
+		cfg.Storage.S3SecretKey = secret
+	}
+	return nil
+}
+
+// preview reports the changes from current to effective and the cross-category rules effective breaks
+func (s *ConfigService) preview(current, effective *models.MutableConfig) *models.ConfigPreview {
+	preview := &models.ConfigPreview{Valid: true, Errors: []string{}, Changes: diffConfig(current, effective)}
+	for _, err := range s.crossCategoryErrors(effective) {
+		preview.Valid = false
+		preview.Errors = append(preview.Errors, err.Error())
+	}
+	return preview
+}
+
+// diffConfig lists the fields changed from old to updated, by category then field name
+func diffConfig(old, updated *models.MutableConfig) []models.ConfigChange {
+	changes := []models.ConfigChange{}
+	for _, category := range models.AllConfigCategories() {
+		oldFields := flattenSection(configSection(old, category))
+		newFields := flattenSection(configSection(updated, category))
+
+		names := make([]string, 0, len(oldFields)+len(newFields))
+		for name := range oldFields {
+			names = append(names, name)
+		}
+		for name := range newFields {
+			if _, ok := oldFields[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			oldValue, newValue := oldFields[name], newFields[name]
+			if reflect.DeepEqual(oldValue, newValue) {
+				continue
+			}
+			if name == configSecretFields[category] {
+				oldValue, newValue = maskSecretValue(oldValue), maskSecretValue(newValue)
+			}
+			changes = append(changes, models.ConfigChange{Category: category, Field: name, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// configSection returns the section of a category
+func configSection(cfg *models.MutableConfig, category models.ConfigCategory) any {
+	switch category {
+	case models.ConfigCategoryGeneral:
+		return cfg.General
+	case models.ConfigCategoryOIDC:
+		return cfg.OIDC
+	case models.ConfigCategoryMagicLink:
+		return cfg.MagicLink
+	case models.ConfigCategorySMTP:
+		return cfg.SMTP
+	case models.ConfigCategoryStorage:
+		return cfg.Storage
+	case models.ConfigCategoryBranding:
+		return cfg.Branding
+	case models.ConfigCategoryReminders:
+		return cfg.Reminders
+	case models.ConfigCategorySecurity:
+		return cfg.Security
+	}
+	return nil
+}
+
+// flattenSection returns the JSON fields of a section, nested objects flattened to dotted names
+func flattenSection(section any) map[string]any {
+	fields := make(map[string]any)
+	data, err := json.Marshal(section)
+	if err != nil {
+		return fields
+	}
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fields
+	}
+	flattenInto(fields, "", object)
+	return fields
+}
+
+func flattenInto(fields map[string]any, prefix string, object map[string]any) {
+	for name, value := range object {
+		if nested, ok := value.(map[string]any); ok {
+			flattenInto(fields, prefix+name+".", nested)
+			continue
+		}
+		fields[prefix+name] = value
+	}
+}
+
+// maskSecretValue masks a secret of a flattened section
+func maskSecretValue(value any) any {
+	if secret, ok := value.(string); ok {
+		return maskIfSet(secret)
+	}
+	return value
+}
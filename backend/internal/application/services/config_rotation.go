@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// CheckSecrets reports which stored secrets are encrypted with the current key,
+// with a previous key, or with no configured key at all
+func (s *ConfigService) CheckSecrets(ctx context.Context) (*models.ConfigSecretsStatus, error) {
+	status, _, err := s.scanSecrets(ctx)
+	return status, err
+}
+
+// RotateSecrets re-encrypts secrets stored under a previous key with the current key.
+// Undecryptable secrets are left untouched and reported.
+func (s *ConfigService) RotateSecrets(ctx context.Context, updatedBy string) (*models.ConfigSecretsStatus, error) {
+	status, stale, err := s.scanSecrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status.Rotated = make([]models.ConfigCategory, 0, len(stale))
+	for _, entry := range stale {
+		encrypted, err := s.encryptSecrets(entry.plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to re-encrypt %s secrets: %w", entry.config.Category, err)
+		}
+		if err := s.repo.Upsert(ctx, entry.config.Category, entry.config.Config, encrypted, updatedBy); err != nil {
+			return nil, fmt.Errorf("failed to save %s secrets: %w", entry.config.Category, err)
+		}
+		status.Rotated = append(status.Rotated, entry.config.Category)
+		status.Current = append(status.Current, entry.config.Category)
+	}
+	status.Stale = []models.ConfigCategory{}
+
+	if len(status.Rotated) > 0 {
+		logger.Logger.Info("Configuration secrets rotated", "categories", status.Rotated, "by", updatedBy)
+		if err := s.reload(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// staleSecrets is a config section whose secrets were decrypted with a previous key
+type staleSecrets struct {
+	config    *models.TenantConfig
+	plaintext []byte
+}
+
+// scanSecrets tries to decrypt the secrets of every stored section
+func (s *ConfigService) scanSecrets(ctx context.Context) (*models.ConfigSecretsStatus, []staleSecrets, error) {
+	configs, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configs: %w", err)
+	}
+
+	status := &models.ConfigSecretsStatus{
+		Current:       []models.ConfigCategory{},
+		Stale:         []models.ConfigCategory{},
+		Undecryptable: []models.ConfigCategory{},
+	}
+	var stale []staleSecrets
+
+	for _, tc := range configs {
+		if len(tc.SecretsEncrypted) == 0 {
+			continue
+		}
+
+		plaintext, current, err := s.openSecrets(tc.SecretsEncrypted)
+		switch {
+		case err != nil:
+			status.Undecryptable = append(status.Undecryptable, tc.Category)
+		case current:
+			status.Current = append(status.Current, tc.Category)
+		default:
+			status.Stale = append(status.Stale, tc.Category)
+			stale = append(stale, staleSecrets{config: tc, plaintext: plaintext})
+		}
+	}
+
+	return status, stale, nil
+}
+
+// validateSecrets flags stored secrets that need a rotation or cannot be decrypted anymore
+func (s *ConfigService) validateSecrets(ctx context.Context) {
+	status, err := s.CheckSecrets(ctx)
+	if err != nil {
+		logger.Logger.Warn("Failed to check configuration secrets", "error", err)
+		return
+	}
+
+	if len(status.Stale) > 0 {
+		logger.Logger.Warn("Configuration secrets are encrypted with a previous key, rotate them to the current key",
+			"categories", status.Stale)
+	}
+	if len(status.Undecryptable) > 0 {
+		logger.Logger.Error("Configuration secrets cannot be decrypted with any configured key, re-enter them in the settings",
+			"categories", status.Undecryptable)
+	}
+}
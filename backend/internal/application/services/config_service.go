@@ -6,25 +6,29 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	netmail "net/mail"
+	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
 	"github.com/btouchard/ackify-ce/backend/pkg/config"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
-	mail "github.com/go-mail/mail/v2"
+	"github.com/btouchard/ackify-ce/backend/pkg/storage"
 )
 
 var (
@@ -32,8 +36,14 @@ var (
 	ErrMagicLinkNeedsSMTP = errors.New("MagicLink requires SMTP to be configured")
 	ErrOIDCNeedsURLs      = errors.New("custom OIDC provider requires auth, token, and userinfo URLs")
 	ErrInvalidCategory    = errors.New("invalid configuration category")
+	ErrNoPendingConfig    = errors.New("no pending configuration changes")
 )
 
+// maxEmailFooterLength limits the branding footer appended to every email
+const maxEmailFooterLength = 500
+
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
 type configRepository interface {
 	GetByCategory(ctx context.Context, category models.ConfigCategory) (*models.TenantConfig, error)
 	GetAll(ctx context.Context) ([]*models.TenantConfig, error)
@@ -42,16 +52,23 @@ type configRepository interface {
 	MarkSeeded(ctx context.Context) error
 	DeleteAll(ctx context.Context) error
 	GetLatestUpdatedAt(ctx context.Context) (time.Time, error)
+	ListPending(ctx context.Context) ([]*models.PendingConfigSection, error)
+	StagePending(ctx context.Context, category models.ConfigCategory, config json.RawMessage, secrets []byte, stagedBy string) error
+	DeletePending(ctx context.Context, category models.ConfigCategory) (int64, error)
 }
 
 // ConfigService manages application configuration with hot-reload support
 type ConfigService struct {
 	repo          configRepository
 	encryptionKey []byte
+	previousKeys  [][]byte // Former keys, only used to decrypt secrets not yet rotated
 	envConfig     *config.Config
 
 	currentConfig atomic.Value // *models.MutableConfig
 
+	overridesMu sync.RWMutex
+	overrides   models.ConfigSecrets // Secrets from an external store, applied over the stored ones
+
 	subscribersMu sync.RWMutex
 	subscribers   []chan<- models.MutableConfig
 }
@@ -68,6 +85,40 @@ func NewConfigService(repo configRepository, envConfig *config.Config, encryptio
 	return svc
 }
 
+// SetPreviousKeys registers former encryption keys still accepted to decrypt stored secrets
+func (s *ConfigService) SetPreviousKeys(keys [][]byte) {
+	s.previousKeys = keys
+}
+
+// SetSecretOverrides sets secrets read from an external store, such as Vault, which take
+// precedence over those stored in the database. They apply at once and on every reload.
+func (s *ConfigService) SetSecretOverrides(overrides models.ConfigSecrets) {
+	s.overridesMu.Lock()
+	s.overrides = overrides
+	s.overridesMu.Unlock()
+
+	mutable := *s.GetConfig()
+	s.applySecretOverrides(&mutable)
+	s.currentConfig.Store(&mutable)
+	s.notifySubscribers(mutable)
+}
+
+// applySecretOverrides replaces the secrets of cfg with the overrides that are set
+func (s *ConfigService) applySecretOverrides(cfg *models.MutableConfig) {
+	s.overridesMu.RLock()
+	defer s.overridesMu.RUnlock()
+
+	if s.overrides.OIDCClientSecret != "" {
+		cfg.OIDC.ClientSecret = s.overrides.OIDCClientSecret
+	}
+	if s.overrides.SMTPPassword != "" {
+		cfg.SMTP.Password = s.overrides.SMTPPassword
+	}
+	if s.overrides.S3SecretKey != "" {
+		cfg.Storage.S3SecretKey = s.overrides.S3SecretKey
+	}
+}
+
 // Initialize loads config from DB or seeds from ENV on first start
 func (s *ConfigService) Initialize(ctx context.Context) error {
 	seeded, err := s.repo.IsSeeded(ctx)
@@ -82,7 +133,12 @@ func (s *ConfigService) Initialize(ctx context.Context) error {
 		}
 	}
 
-	return s.reload(ctx)
+	if err := s.reload(ctx); err != nil {
+		return err
+	}
+
+	s.validateSecrets(ctx)
+	return nil
 }
 
 // GetConfig returns the current config (lock-free read)
@@ -92,26 +148,13 @@ func (s *ConfigService) GetConfig() *models.MutableConfig {
 
 // UpdateSection updates a specific config section
 func (s *ConfigService) UpdateSection(ctx context.Context, category models.ConfigCategory, input json.RawMessage, updatedBy string) error {
-	if !category.IsValid() {
-		return ErrInvalidCategory
-	}
-
-	// Parse the input to validate structure
-	if err := s.validateSection(category, input); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
-	}
-
-	// Get current config to check cross-category validation
-	currentConfig := s.GetConfig()
-
-	// Apply the update temporarily to check cross-category validation
-	tempConfig := *currentConfig
-	if err := s.applyUpdateToConfig(&tempConfig, category, input); err != nil {
-		return fmt.Errorf("failed to apply update: %w", err)
+	input, tempConfig, err := s.prepareUpdate(category, input)
+	if err != nil {
+		return err
 	}
 
 	// Validate cross-category rules
-	if err := s.validateCrossCategory(&tempConfig); err != nil {
+	if err := s.validateCrossCategory(tempConfig); err != nil {
 		return err
 	}
 
@@ -130,6 +173,74 @@ func (s *ConfigService) UpdateSection(ctx context.Context, category models.Confi
 	return s.reload(ctx)
 }
 
+// PreviewSection validates a section update without storing it. Validation failures are
+// reported in the preview, with the changes the update would make to the effective config.
+func (s *ConfigService) PreviewSection(category models.ConfigCategory, input json.RawMessage) (*models.ConfigPreview, error) {
+	if !category.IsValid() {
+		return nil, ErrInvalidCategory
+	}
+
+	current := s.GetConfig()
+	_, effective, err := s.prepareUpdate(category, input)
+	if err != nil {
+		return &models.ConfigPreview{Errors: []string{err.Error()}, Changes: []models.ConfigChange{}}, nil
+	}
+	return s.preview(current, effective), nil
+}
+
+// prepareUpdate validates a section update and returns the input to store along with
+// the effective configuration once it is applied
+func (s *ConfigService) prepareUpdate(category models.ConfigCategory, input json.RawMessage) (json.RawMessage, *models.MutableConfig, error) {
+	if !category.IsValid() {
+		return nil, nil, ErrInvalidCategory
+	}
+
+	// Parse the input to validate structure
+	if err := s.validateSection(category, input); err != nil {
+		return nil, nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// The logo is only changed through UpdateBrandingLogo
+	if category == models.ConfigCategoryBranding {
+		var err error
+		if input, err = s.keepBrandingLogo(input); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply update: %w", err)
+		}
+	}
+
+	// The mail sender matches the encryption exactly: store it as parsed
+	if category == models.ConfigCategorySMTP {
+		var err error
+		if input, err = normalizeSMTPEncryption(input); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply update: %w", err)
+		}
+	}
+
+	// Apply the update temporarily to check cross-category validation
+	tempConfig := *s.GetConfig()
+	if err := s.applyUpdateToConfig(&tempConfig, category, input); err != nil {
+		return nil, nil, fmt.Errorf("failed to apply update: %w", err)
+	}
+
+	return input, &tempConfig, nil
+}
+
+// UpdateBrandingLogo stores the storage key of a new branding logo, or clears it when key is empty
+func (s *ConfigService) UpdateBrandingLogo(ctx context.Context, key, contentType, updatedBy string) error {
+	branding := s.GetConfig().Branding
+	branding.LogoKey = key
+	branding.LogoContentType = contentType
+	if key == "" {
+		branding.LogoContentType = ""
+	}
+
+	if err := s.upsertSection(ctx, models.ConfigCategoryBranding, branding, nil, updatedBy); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	return s.reload(ctx)
+}
+
 // ResetFromENV resets config to current ENV values
 func (s *ConfigService) ResetFromENV(ctx context.Context, updatedBy string) error {
 	// Delete all existing config
@@ -146,6 +257,11 @@ func (s *ConfigService) ResetFromENV(ctx context.Context, updatedBy string) erro
 	return s.reload(ctx)
 }
 
+// Reload re-reads the configuration from the database, picking up changes made outside this process
+func (s *ConfigService) Reload(ctx context.Context) error {
+	return s.reload(ctx)
+}
+
 // Subscribe registers a channel to receive config updates
 func (s *ConfigService) Subscribe() <-chan models.MutableConfig {
 	ch := make(chan models.MutableConfig, 1)
@@ -168,10 +284,10 @@ func (s *ConfigService) CloseAllSubscribers() {
 
 // --- Test Connection Methods ---
 
-// TestSMTP tests SMTP connection
-func (s *ConfigService) TestSMTP(ctx context.Context, cfg models.SMTPConfig) error {
+// TestSMTP tests SMTP connection and returns the encryption in use, detected in auto mode
+func (s *ConfigService) TestSMTP(ctx context.Context, cfg models.SMTPConfig) (string, error) {
 	if cfg.Host == "" {
-		return errors.New("SMTP host is required")
+		return "", errors.New("SMTP host is required")
 	}
 
 	// Handle masked password - use current config's password
@@ -180,36 +296,40 @@ func (s *ConfigService) TestSMTP(ctx context.Context, cfg models.SMTPConfig) err
 		cfg.Password = current.SMTP.Password
 	}
 
-	d := mail.NewDialer(cfg.Host, cfg.Port, cfg.Username, cfg.Password)
-
-	if cfg.TLS {
-		d.SSL = true
-		d.TLSConfig = &tls.Config{
-			ServerName:         cfg.Host,
-			InsecureSkipVerify: cfg.InsecureSkipVerify,
-		}
-	} else if cfg.StartTLS {
-		d.TLSConfig = &tls.Config{
-			ServerName:         cfg.Host,
-			InsecureSkipVerify: cfg.InsecureSkipVerify,
+	if cfg.Encryption != "" {
+		mode, err := models.ParseSMTPEncryption(cfg.Encryption)
+		if err != nil {
+			return "", err
 		}
-		d.StartTLSPolicy = mail.MandatoryStartTLS
+		cfg.Encryption = mode
 	}
 
 	timeout, err := time.ParseDuration(cfg.Timeout)
 	if err != nil {
 		timeout = 10 * time.Second
 	}
-	d.Timeout = timeout
+
+	d, encryption, err := email.NewDialer(ctx, email.DialSettings{
+		Host:               cfg.Host,
+		Port:               cfg.Port,
+		Username:           cfg.Username,
+		Password:           cfg.Password,
+		Encryption:         cfg.EncryptionMode(),
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		Timeout:            timeout,
+	})
+	if err != nil {
+		return "", err
+	}
 
 	// Try to connect
 	closer, err := d.Dial()
 	if err != nil {
-		return fmt.Errorf("SMTP connection failed: %w", err)
+		return encryption, fmt.Errorf("SMTP connection failed (%s): %w", encryption, err)
 	}
 	defer closer.Close()
 
-	return nil
+	return encryption, nil
 }
 
 // TestS3 tests S3 connection
@@ -364,8 +484,9 @@ func (s *ConfigService) TestOIDC(ctx context.Context, cfg models.OIDCConfig) err
 func (s *ConfigService) seedFromENV(ctx context.Context) error {
 	// General config
 	general := models.GeneralConfig{
-		Organisation:       s.envConfig.App.Organisation,
-		OnlyAdminCanCreate: s.envConfig.App.OnlyAdminCanCreate,
+		Organisation:                s.envConfig.App.Organisation,
+		OnlyAdminCanCreate:          s.envConfig.App.OnlyAdminCanCreate,
+		ApproveUnexpectedSignatures: s.envConfig.App.ApproveUnexpectedSignatures,
 	}
 	if err := s.upsertSection(ctx, models.ConfigCategoryGeneral, general, nil, "system"); err != nil {
 		return fmt.Errorf("failed to seed general config: %w", err)
@@ -404,8 +525,7 @@ func (s *ConfigService) seedFromENV(ctx context.Context) error {
 		Port:               s.envConfig.Mail.Port,
 		Username:           s.envConfig.Mail.Username,
 		Password:           s.envConfig.Mail.Password,
-		TLS:                s.envConfig.Mail.TLS,
-		StartTLS:           s.envConfig.Mail.StartTLS,
+		Encryption:         s.envConfig.Mail.Encryption,
 		InsecureSkipVerify: s.envConfig.Mail.InsecureSkipVerify,
 		Timeout:            s.envConfig.Mail.Timeout,
 		From:               s.envConfig.Mail.From,
@@ -531,6 +651,8 @@ func (s *ConfigService) reload(ctx context.Context) error {
 		mutable.UpdatedAt = updatedAt
 	}
 
+	s.applySecretOverrides(mutable)
+
 	// Atomic swap
 	s.currentConfig.Store(mutable)
 
@@ -604,6 +726,27 @@ func (s *ConfigService) populateCategory(mutable *models.MutableConfig, tc *mode
 			}
 		}
 		mutable.Storage = cfg
+
+	case models.ConfigCategoryBranding:
+		var cfg models.BrandingConfig
+		if err := json.Unmarshal(tc.Config, &cfg); err != nil {
+			return err
+		}
+		mutable.Branding = cfg
+
+	case models.ConfigCategoryReminders:
+		var cfg models.ReminderConfig
+		if err := json.Unmarshal(tc.Config, &cfg); err != nil {
+			return err
+		}
+		mutable.Reminders = cfg
+
+	case models.ConfigCategorySecurity:
+		var cfg models.SecurityConfig
+		if err := json.Unmarshal(tc.Config, &cfg); err != nil {
+			return err
+		}
+		mutable.Security = cfg
 	}
 
 	return nil
@@ -628,7 +771,13 @@ func (s *ConfigService) validateSection(category models.ConfigCategory, input js
 	switch category {
 	case models.ConfigCategoryGeneral:
 		var cfg models.GeneralConfig
-		return json.Unmarshal(input, &cfg)
+		if err := json.Unmarshal(input, &cfg); err != nil {
+			return err
+		}
+		if _, ok := models.NormalizeLocale(cfg.DefaultLocale); cfg.DefaultLocale != "" && !ok {
+			return ErrInvalidLocale
+		}
+		return cfg.SignerEmails.Validate()
 
 	case models.ConfigCategoryOIDC:
 		var cfg models.OIDCConfig
@@ -648,7 +797,15 @@ func (s *ConfigService) validateSection(category models.ConfigCategory, input js
 
 	case models.ConfigCategorySMTP:
 		var cfg models.SMTPConfig
-		return json.Unmarshal(input, &cfg)
+		if err := json.Unmarshal(input, &cfg); err != nil {
+			return err
+		}
+		if cfg.Encryption != "" {
+			if _, err := models.ParseSMTPEncryption(cfg.Encryption); err != nil {
+				return err
+			}
+		}
+		return nil
 
 	case models.ConfigCategoryStorage:
 		var cfg models.StorageConfig
@@ -658,37 +815,146 @@ func (s *ConfigService) validateSection(category models.ConfigCategory, input js
 		if cfg.Type == "s3" && cfg.S3Bucket == "" {
 			return errors.New("S3 bucket is required when storage type is 's3'")
 		}
+		if cfg.MaxSizeMB < 0 {
+			return errors.New("storage max size cannot be negative")
+		}
+		for _, mimeType := range cfg.AllowedMimeTypes {
+			if !storage.AllowedMIMETypes[mimeType] {
+				return fmt.Errorf("MIME type %q is not supported by the storage", mimeType)
+			}
+		}
 		return nil
+
+	case models.ConfigCategoryBranding:
+		var cfg models.BrandingConfig
+		if err := json.Unmarshal(input, &cfg); err != nil {
+			return err
+		}
+		return validateBranding(&cfg)
+
+	case models.ConfigCategoryReminders:
+		var cfg models.ReminderConfig
+		if err := json.Unmarshal(input, &cfg); err != nil {
+			return err
+		}
+		return validateReminders(&cfg)
+
+	case models.ConfigCategorySecurity:
+		var cfg models.SecurityConfig
+		if err := json.Unmarshal(input, &cfg); err != nil {
+			return err
+		}
+		return cfg.Validate()
 	}
 
 	return ErrInvalidCategory
 }
 
-// applyUpdateToConfig applies an update to a MutableConfig for validation
+// validateBranding checks the user-editable branding fields
+func validateBranding(cfg *models.BrandingConfig) error {
+	if cfg.PrimaryColor != "" && !hexColorPattern.MatchString(cfg.PrimaryColor) {
+		return errors.New("primary color must be a hex color such as #4F46E5")
+	}
+	if utf8.RuneCountInString(cfg.EmailFooter) > maxEmailFooterLength {
+		return fmt.Errorf("email footer must not exceed %d characters", maxEmailFooterLength)
+	}
+	if cfg.SupportEmail != "" {
+		addr, err := netmail.ParseAddress(cfg.SupportEmail)
+		if err != nil || addr.Address != cfg.SupportEmail {
+			return errors.New("support email must be a valid email address")
+		}
+	}
+	if cfg.SupportURL != "" {
+		u, err := url.Parse(cfg.SupportURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("support URL must be an absolute http(s) URL")
+		}
+	}
+	return nil
+}
+
+// validateReminders checks that escalation thresholds are positive, that the final notice
+// does not come before the firm reminder and the working hours
+func validateReminders(cfg *models.ReminderConfig) error {
+	if cfg.FirmAfterReminders < 0 || cfg.FirmAfterDays < 0 || cfg.FinalAfterReminders < 0 || cfg.FinalAfterDays < 0 {
+		return errors.New("reminder thresholds must not be negative")
+	}
+	firmReminders, firmDays, finalReminders, finalDays := cfg.Thresholds()
+	if finalReminders < firmReminders || finalDays < firmDays {
+		return errors.New("final notice thresholds must not be lower than firm reminder thresholds")
+	}
+	if cfg.MinIntervalHours < 0 || cfg.MinIntervalHours > models.MaxReminderMinIntervalHours {
+		return fmt.Errorf("min_interval_hours must be between 0 and %d", models.MaxReminderMinIntervalHours)
+	}
+	return cfg.WorkingHours.Validate()
+}
+
+// keepBrandingLogo replaces the logo fields of a branding update with the current ones
+func (s *ConfigService) keepBrandingLogo(input json.RawMessage) (json.RawMessage, error) {
+	var cfg models.BrandingConfig
+	if err := json.Unmarshal(input, &cfg); err != nil {
+		return nil, err
+	}
+	current := s.GetConfig().Branding
+	cfg.LogoKey = current.LogoKey
+	cfg.LogoContentType = current.LogoContentType
+	return json.Marshal(cfg)
+}
+
+// normalizeSMTPEncryption replaces the encryption of an SMTP update with its parsed value, so that
+// "STARTTLS" is stored as "starttls". An empty encryption is kept, the former flags then apply.
+func normalizeSMTPEncryption(input json.RawMessage) (json.RawMessage, error) {
+	var cfg models.SMTPConfig
+	if err := json.Unmarshal(input, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Encryption == "" {
+		return input, nil
+	}
+	mode, err := models.ParseSMTPEncryption(cfg.Encryption)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Encryption = mode
+	return json.Marshal(cfg)
+}
+
+// applyUpdateToConfig applies an update to a MutableConfig for validation.
+// Sections are decoded into new values so that cfg never shares slices with the current config.
 func (s *ConfigService) applyUpdateToConfig(cfg *models.MutableConfig, category models.ConfigCategory, input json.RawMessage) error {
 	switch category {
 	case models.ConfigCategoryGeneral:
-		return json.Unmarshal(input, &cfg.General)
+		var general models.GeneralConfig
+		if err := json.Unmarshal(input, &general); err != nil {
+			return err
+		}
+		cfg.General = general
+		return nil
 	case models.ConfigCategoryOIDC:
 		var oidc models.OIDCConfig
 		if err := json.Unmarshal(input, &oidc); err != nil {
 			return err
 		}
-		// Preserve existing secret if masked
-		if models.IsSecretMasked(oidc.ClientSecret) {
+		// Preserve existing secret if masked or empty, as stored sections do
+		if oidc.ClientSecret == "" || models.IsSecretMasked(oidc.ClientSecret) {
 			oidc.ClientSecret = cfg.OIDC.ClientSecret
 		}
 		cfg.OIDC = oidc
 		return nil
 	case models.ConfigCategoryMagicLink:
-		return json.Unmarshal(input, &cfg.MagicLink)
+		var magicLink models.MagicLinkConfig
+		if err := json.Unmarshal(input, &magicLink); err != nil {
+			return err
+		}
+		cfg.MagicLink = magicLink
+		return nil
 	case models.ConfigCategorySMTP:
 		var smtp models.SMTPConfig
 		if err := json.Unmarshal(input, &smtp); err != nil {
 			return err
 		}
-		// Preserve existing secret if masked
-		if models.IsSecretMasked(smtp.Password) {
+		// Preserve existing secret if masked or empty, as stored sections do
+		if smtp.Password == "" || models.IsSecretMasked(smtp.Password) {
 			smtp.Password = cfg.SMTP.Password
 		}
 		cfg.SMTP = smtp
@@ -698,29 +964,66 @@ func (s *ConfigService) applyUpdateToConfig(cfg *models.MutableConfig, category
 		if err := json.Unmarshal(input, &storage); err != nil {
 			return err
 		}
-		// Preserve existing secret if masked
-		if models.IsSecretMasked(storage.S3SecretKey) {
+		// Preserve existing secret if masked or empty, as stored sections do
+		if storage.S3SecretKey == "" || models.IsSecretMasked(storage.S3SecretKey) {
 			storage.S3SecretKey = cfg.Storage.S3SecretKey
 		}
 		cfg.Storage = storage
 		return nil
+	case models.ConfigCategoryBranding:
+		var branding models.BrandingConfig
+		if err := json.Unmarshal(input, &branding); err != nil {
+			return err
+		}
+		cfg.Branding = branding
+		return nil
+	case models.ConfigCategoryReminders:
+		var reminders models.ReminderConfig
+		if err := json.Unmarshal(input, &reminders); err != nil {
+			return err
+		}
+		cfg.Reminders = reminders
+		return nil
+	case models.ConfigCategorySecurity:
+		var security models.SecurityConfig
+		if err := json.Unmarshal(input, &security); err != nil {
+			return err
+		}
+		cfg.Security = security
+		return nil
 	}
 	return ErrInvalidCategory
 }
 
 // validateCrossCategory validates cross-category rules
 func (s *ConfigService) validateCrossCategory(cfg *models.MutableConfig) error {
+	if errs := s.crossCategoryErrors(cfg); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// crossCategoryErrors returns every cross-category rule the config breaks
+func (s *ConfigService) crossCategoryErrors(cfg *models.MutableConfig) []error {
+	var errs []error
+
 	// At least one auth method must be enabled
 	if !cfg.HasAtLeastOneAuthMethod() {
-		return ErrNoAuthMethod
+		errs = append(errs, ErrNoAuthMethod)
 	}
 
 	// MagicLink requires SMTP
 	if !cfg.MagicLinkRequiresSMTP() {
-		return ErrMagicLinkNeedsSMTP
+		errs = append(errs, ErrMagicLinkNeedsSMTP)
 	}
 
-	return nil
+	// A custom OIDC provider has no discovery: its endpoints must be set
+	if cfg.OIDC.Enabled && cfg.OIDC.Provider == "custom" &&
+		(cfg.OIDC.AuthURL == "" || cfg.OIDC.TokenURL == "" || cfg.OIDC.UserInfoURL == "") {
+		errs = append(errs, ErrOIDCNeedsURLs)
+	}
+
+	return errs
 }
 
 // processSecrets extracts and encrypts secrets from input
@@ -785,6 +1088,21 @@ func (s *ConfigService) processSecrets(category models.ConfigCategory, input jso
 	return configWithoutSecrets, encryptedSecrets, nil
 }
 
+// EncryptSecret encrypts a secret stored outside the configuration sections, such as a directory
+// refresh token, with the settings encryption key
+func (s *ConfigService) EncryptSecret(plaintext string) ([]byte, error) {
+	return s.encryptSecrets([]byte(plaintext))
+}
+
+// DecryptSecret decrypts a secret encrypted by EncryptSecret, with the current key or a previous one
+func (s *ConfigService) DecryptSecret(ciphertext []byte) (string, error) {
+	plaintext, err := s.decryptSecrets(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
 // encryptSecrets encrypts secrets using AES-256-GCM
 func (s *ConfigService) encryptSecrets(plaintext []byte) ([]byte, error) {
 	if len(s.encryptionKey) < 32 {
@@ -809,13 +1127,34 @@ func (s *ConfigService) encryptSecrets(plaintext []byte) ([]byte, error) {
 	return gcm.Seal(nonce, nonce, plaintext, nil), nil
 }
 
-// decryptSecrets decrypts secrets using AES-256-GCM
+// decryptSecrets decrypts secrets using AES-256-GCM, with the current key or a previous one
 func (s *ConfigService) decryptSecrets(ciphertext []byte) ([]byte, error) {
-	if len(s.encryptionKey) < 32 {
+	plaintext, _, err := s.openSecrets(ciphertext)
+	return plaintext, err
+}
+
+// openSecrets decrypts secrets and reports whether they were encrypted with the current key
+func (s *ConfigService) openSecrets(ciphertext []byte) ([]byte, bool, error) {
+	plaintext, err := decryptWithKey(s.encryptionKey, ciphertext)
+	if err == nil {
+		return plaintext, true, nil
+	}
+
+	for _, key := range s.previousKeys {
+		if plaintext, prevErr := decryptWithKey(key, ciphertext); prevErr == nil {
+			return plaintext, false, nil
+		}
+	}
+	return nil, false, err
+}
+
+// decryptWithKey decrypts secrets using AES-256-GCM with the first 32 bytes of key
+func decryptWithKey(key, ciphertext []byte) ([]byte, error) {
+	if len(key) < 32 {
 		return nil, errors.New("encryption key too short")
 	}
 
-	block, err := aes.NewCipher(s.encryptionKey[:32])
+	block, err := aes.NewCipher(key[:32])
 	if err != nil {
 		return nil, err
 	}
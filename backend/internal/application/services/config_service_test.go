@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 // fakeConfigRepository is a mock implementation of configRepository
 type fakeConfigRepository struct {
 	configs          map[models.ConfigCategory]*models.TenantConfig
+	pending          map[models.ConfigCategory]*models.PendingConfigSection
 	seeded           bool
 	shouldFailGet    bool
 	shouldFailGetAll bool
@@ -27,6 +29,7 @@ type fakeConfigRepository struct {
 func newFakeConfigRepository() *fakeConfigRepository {
 	return &fakeConfigRepository{
 		configs: make(map[models.ConfigCategory]*models.TenantConfig),
+		pending: make(map[models.ConfigCategory]*models.PendingConfigSection),
 	}
 }
 
@@ -98,6 +101,40 @@ func (f *fakeConfigRepository) GetLatestUpdatedAt(_ context.Context) (time.Time,
 	return latest, nil
 }
 
+func (f *fakeConfigRepository) ListPending(_ context.Context) ([]*models.PendingConfigSection, error) {
+	var result []*models.PendingConfigSection
+	for _, category := range models.AllConfigCategories() {
+		if section, ok := f.pending[category]; ok {
+			result = append(result, section)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeConfigRepository) StagePending(_ context.Context, category models.ConfigCategory, cfg json.RawMessage, secrets []byte, stagedBy string) error {
+	f.pending[category] = &models.PendingConfigSection{
+		Category:         category,
+		Config:           cfg,
+		SecretsEncrypted: secrets,
+		StagedBy:         stagedBy,
+		StagedAt:         time.Now(),
+	}
+	return nil
+}
+
+func (f *fakeConfigRepository) DeletePending(_ context.Context, category models.ConfigCategory) (int64, error) {
+	if category != "" {
+		if _, ok := f.pending[category]; !ok {
+			return 0, nil
+		}
+		delete(f.pending, category)
+		return 1, nil
+	}
+	deleted := int64(len(f.pending))
+	f.pending = make(map[models.ConfigCategory]*models.PendingConfigSection)
+	return deleted, nil
+}
+
 // createTestConfigService creates a ConfigService with a fake repository for testing
 func createTestConfigService() (*ConfigService, *fakeConfigRepository) {
 	repo := newFakeConfigRepository()
@@ -120,15 +157,14 @@ func createTestConfigService() (*ConfigService, *fakeConfigRepository) {
 			AllowedDomain: "@example.com",
 		},
 		Mail: config.MailConfig{
-			Host:     "smtp.example.com",
-			Port:     587,
-			Username: "test@example.com",
-			Password: "smtp-password",
-			TLS:      false,
-			StartTLS: true,
-			From:     "noreply@example.com",
-			FromName: "Test App",
-			Timeout:  "10s",
+			Host:       "smtp.example.com",
+			Port:       587,
+			Username:   "test@example.com",
+			Password:   "smtp-password",
+			Encryption: models.SMTPEncryptionStartTLS,
+			From:       "noreply@example.com",
+			FromName:   "Test App",
+			Timeout:    "10s",
 		},
 		Storage: config.StorageConfig{
 			Type:      "local",
@@ -249,6 +285,180 @@ func TestConfigService_UpdateSection_General(t *testing.T) {
 	}
 }
 
+func TestConfigService_UpdateSection_BrandingKeepsLogo(t *testing.T) {
+	svc, _ := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	if err := svc.UpdateBrandingLogo(ctx, "branding/logo-1.png", "image/png", "admin@test.com"); err != nil {
+		t.Fatalf("UpdateBrandingLogo failed: %v", err)
+	}
+
+	input := json.RawMessage(`{"primary_color": "#0F766E", "support_email": "help@example.com", "logo_key": "../../etc/passwd"}`)
+	if err := svc.UpdateSection(ctx, models.ConfigCategoryBranding, input, "admin@test.com"); err != nil {
+		t.Fatalf("UpdateSection failed: %v", err)
+	}
+
+	branding := svc.GetConfig().Branding
+	if branding.PrimaryColor != "#0F766E" || branding.SupportEmail != "help@example.com" {
+		t.Errorf("unexpected branding %+v", branding)
+	}
+	if branding.LogoKey != "branding/logo-1.png" || branding.LogoContentType != "image/png" {
+		t.Errorf("expected logo to be preserved, got %q (%q)", branding.LogoKey, branding.LogoContentType)
+	}
+}
+
+func TestConfigService_UpdateSection_NormalizesSMTPEncryption(t *testing.T) {
+	svc, repo := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	for input, want := range map[string]string{" STARTTLS ": models.SMTPEncryptionStartTLS, "Implicit": models.SMTPEncryptionImplicit, "": models.SMTPEncryptionNone} {
+		body, _ := json.Marshal(models.SMTPConfig{Host: "smtp.example.com", Port: 587, From: "noreply@example.com", Encryption: input})
+		if err := svc.UpdateSection(ctx, models.ConfigCategorySMTP, body, "admin@test.com"); err != nil {
+			t.Fatalf("UpdateSection(%q) failed: %v", input, err)
+		}
+		if got := svc.GetConfig().SMTP.EncryptionMode(); got != want {
+			t.Errorf("encryption %q: expected mode %q, got %q", input, want, got)
+		}
+
+		tc, _ := repo.GetByCategory(ctx, models.ConfigCategorySMTP)
+		var saved models.SMTPConfig
+		_ = json.Unmarshal(tc.Config, &saved)
+		if input != "" && saved.Encryption != want {
+			t.Errorf("encryption %q: expected %q to be stored, got %q", input, want, saved.Encryption)
+		}
+	}
+
+	body := json.RawMessage(`{"host": "smtp.example.com", "from": "noreply@example.com", "encryption": "SSL"}`)
+	if err := svc.UpdateSection(ctx, models.ConfigCategorySMTP, body, "admin@test.com"); err == nil {
+		t.Error("expected an unknown encryption to be refused")
+	}
+}
+
+func TestConfigService_ValidateSection_Branding(t *testing.T) {
+	svc, _ := createTestConfigService()
+
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"empty", `{}`, true},
+		{"full", `{"primary_color": "#abc", "email_footer": "Acme", "support_email": "help@example.com", "support_url": "https://help.example.com"}`, true},
+		{"color name", `{"primary_color": "red"}`, false},
+		{"css injection", `{"primary_color": "#fff;background:url(x)"}`, false},
+		{"display name email", `{"support_email": "Help <help@example.com>"}`, false},
+		{"javascript url", `{"support_url": "javascript:alert(1)"}`, false},
+		{"long footer", `{"email_footer": "` + strings.Repeat("a", maxEmailFooterLength+1) + `"}`, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.validateSection(models.ConfigCategoryBranding, json.RawMessage(tc.input))
+			if tc.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestConfigService_ValidateSection_General(t *testing.T) {
+	svc, _ := createTestConfigService()
+
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"no default locale", `{"organisation": "Acme"}`, true},
+		{"default locale", `{"default_locale": "fr"}`, true},
+		{"regional default locale", `{"default_locale": "de-CH"}`, true},
+		{"unsupported default locale", `{"default_locale": "pt"}`, false},
+		{"signer email domains", `{"signer_emails": {"allowed_domains": ["corp.com"], "require_mx": true}}`, true},
+		{"invalid signer email domain", `{"signer_emails": {"blocked_domains": ["@gmail.com"]}}`, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.validateSection(models.ConfigCategoryGeneral, json.RawMessage(tc.input))
+			if tc.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestConfigService_ValidateSection_Reminders(t *testing.T) {
+	svc, _ := createTestConfigService()
+
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"defaults", `{}`, true},
+		{"final before firm", `{"firm_after_days": 20, "final_after_days": 10}`, false},
+		{"working hours", `{"working_hours": {"enabled": true, "timezone": "Europe/Paris", "days": [1, 2, 3, 4, 5], "start": "08:30", "end": "17:30"}}`, true},
+		{"unknown timezone", `{"working_hours": {"enabled": true, "timezone": "Europe/Atlantis"}}`, false},
+		{"overnight hours", `{"working_hours": {"enabled": true, "start": "22:00", "end": "06:00"}}`, false},
+		{"min interval", `{"min_interval_hours": 48}`, true},
+		{"negative min interval", `{"min_interval_hours": -1}`, false},
+		{"min interval too long", `{"min_interval_hours": 721}`, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.validateSection(models.ConfigCategoryReminders, json.RawMessage(tc.input))
+			if tc.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
+func TestConfigService_ValidateSection_Security(t *testing.T) {
+	svc, _ := createTestConfigService()
+
+	tests := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"empty", `{}`, true},
+		{"sources", `{"csp": {"font_src": ["https://fonts.example.com"], "connect_src": ["wss://ws.example.com:8443", "https:"], "img_src": ["https://*.example.com/avatars/"], "script_src": ["'sha256-abc123+/='"]}}`, true},
+		{"directive injection", `{"csp": {"img_src": ["https://cdn.example.com; script-src *"]}}`, false},
+		{"nonce", `{"csp": {"script_src": ["'nonce-abc'"]}}`, false},
+		{"none keyword", `{"csp": {"style_src": ["'none'"]}}`, false},
+		{"embed origins", `{"embed_frame_ancestors": ["'self'", "https://intranet.example.com", "https://*.example.org:8443"]}`, true},
+		{"embed none", `{"embed_frame_ancestors": ["'none'"]}`, true},
+		{"embed none combined", `{"embed_frame_ancestors": ["'none'", "https://intranet.example.com"]}`, false},
+		{"embed origin with path", `{"embed_frame_ancestors": ["https://intranet.example.com/wiki"]}`, false},
+		{"embed wildcard", `{"embed_frame_ancestors": ["*"]}`, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := svc.validateSection(models.ConfigCategorySecurity, json.RawMessage(tc.input))
+			if tc.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
+
 func TestConfigService_UpdateSection_InvalidCategory(t *testing.T) {
 	svc, _ := createTestConfigService()
 	ctx := context.Background()
@@ -433,6 +643,16 @@ func TestConfigService_ValidateSection_Storage(t *testing.T) {
 	if err == nil {
 		t.Error("expected error when S3 type without bucket")
 	}
+
+	// Upload policy: only types supported by the storage
+	valid := json.RawMessage(`{"type": "local", "max_size_mb": 10, "allowed_mime_types": ["application/pdf", "image/png"]}`)
+	if err := svc.validateSection(models.ConfigCategoryStorage, valid); err != nil {
+		t.Errorf("expected valid upload policy, got %v", err)
+	}
+	unsupported := json.RawMessage(`{"type": "local", "allowed_mime_types": ["application/x-msdownload"]}`)
+	if err := svc.validateSection(models.ConfigCategoryStorage, unsupported); err == nil {
+		t.Error("expected error for a MIME type the storage does not support")
+	}
 }
 
 func TestConfigService_UpdateSection_PreserveMaskedSecrets(t *testing.T) {
@@ -544,6 +764,8 @@ func TestConfigCategory_IsValid(t *testing.T) {
 		{models.ConfigCategoryMagicLink, true},
 		{models.ConfigCategorySMTP, true},
 		{models.ConfigCategoryStorage, true},
+		{models.ConfigCategoryBranding, true},
+		{models.ConfigCategorySecurity, true},
 		{"invalid", false},
 		{"", false},
 	}
@@ -657,3 +879,450 @@ func TestIsSecretMasked(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigService_ExportBundle_Redacted(t *testing.T) {
+	svc, _ := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	bundle, err := svc.ExportBundle("")
+	if err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+
+	if bundle.Secrets != models.BundleSecretsRedacted {
+		t.Errorf("expected redacted secrets, got '%s'", bundle.Secrets)
+	}
+	if bundle.OIDC.ClientSecret != models.SecretMask || bundle.SMTP.Password != models.SecretMask {
+		t.Error("expected secrets to be masked")
+	}
+	if bundle.Storage.S3SecretKey != "" {
+		t.Errorf("expected unset secret to stay empty, got '%s'", bundle.Storage.S3SecretKey)
+	}
+	if len(bundle.EncryptedSecrets) != 0 {
+		t.Error("expected no encrypted secrets")
+	}
+	if bundle.General.Organisation != "Test Org" {
+		t.Errorf("expected organisation 'Test Org', got '%s'", bundle.General.Organisation)
+	}
+}
+
+func TestConfigService_ImportBundle_RedactedPreservesSecrets(t *testing.T) {
+	svc, _ := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	bundle, err := svc.ExportBundle("")
+	if err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	bundle.General.Organisation = "Imported Org"
+
+	if err := svc.ImportBundle(ctx, bundle, "", "admin@test.com"); err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+
+	cfg := svc.GetConfig()
+	if cfg.General.Organisation != "Imported Org" {
+		t.Errorf("expected organisation 'Imported Org', got '%s'", cfg.General.Organisation)
+	}
+	if cfg.OIDC.ClientSecret != "test-client-secret" {
+		t.Errorf("expected OIDC secret to be preserved, got '%s'", cfg.OIDC.ClientSecret)
+	}
+	if cfg.SMTP.Password != "smtp-password" {
+		t.Errorf("expected SMTP password to be preserved, got '%s'", cfg.SMTP.Password)
+	}
+}
+
+func TestConfigService_ImportBundle_EncryptedSecrets(t *testing.T) {
+	source, _ := createTestConfigService()
+	ctx := context.Background()
+	_ = source.Initialize(ctx)
+
+	bundle, err := source.ExportBundle("correct horse")
+	if err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	if bundle.Secrets != models.BundleSecretsEncrypted {
+		t.Fatalf("expected encrypted secrets, got '%s'", bundle.Secrets)
+	}
+	if bundle.OIDC.ClientSecret != "" || bundle.SMTP.Password != "" {
+		t.Fatal("expected plaintext secrets to be removed from the bundle")
+	}
+
+	// Round-trip through JSON as a real export would
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		t.Fatalf("failed to marshal bundle: %v", err)
+	}
+	var decoded models.ConfigBundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal bundle: %v", err)
+	}
+
+	// Target instance with different secrets and encryption key
+	target, _ := createTestConfigService()
+	target.envConfig.OAuth.ClientSecret = "other-secret"
+	target.encryptionKey = []byte("a-different-32-byte-encryption-k")
+	_ = target.Initialize(ctx)
+
+	if err := target.ImportBundle(ctx, &decoded, "", "admin@test.com"); !errors.Is(err, ErrBundleKeyRequired) {
+		t.Errorf("expected ErrBundleKeyRequired, got %v", err)
+	}
+	if err := target.ImportBundle(ctx, &decoded, "wrong", "admin@test.com"); !errors.Is(err, ErrBundleInvalidKey) {
+		t.Errorf("expected ErrBundleInvalidKey, got %v", err)
+	}
+
+	if err := target.ImportBundle(ctx, &decoded, "correct horse", "admin@test.com"); err != nil {
+		t.Fatalf("ImportBundle failed: %v", err)
+	}
+
+	cfg := target.GetConfig()
+	if cfg.OIDC.ClientSecret != "test-client-secret" {
+		t.Errorf("expected imported OIDC secret, got '%s'", cfg.OIDC.ClientSecret)
+	}
+	if cfg.SMTP.Password != "smtp-password" {
+		t.Errorf("expected imported SMTP password, got '%s'", cfg.SMTP.Password)
+	}
+}
+
+func TestConfigService_ImportBundle_ValidationError(t *testing.T) {
+	svc, repo := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	bundle, err := svc.ExportBundle("")
+	if err != nil {
+		t.Fatalf("ExportBundle failed: %v", err)
+	}
+	bundle.General.Organisation = "Should Not Be Saved"
+	bundle.OIDC.Enabled = false
+	bundle.MagicLink.Enabled = false
+
+	if err := svc.ImportBundle(ctx, bundle, "", "admin@test.com"); !errors.Is(err, ErrNoAuthMethod) {
+		t.Fatalf("expected ErrNoAuthMethod, got %v", err)
+	}
+
+	var general models.GeneralConfig
+	_ = json.Unmarshal(repo.configs[models.ConfigCategoryGeneral].Config, &general)
+	if general.Organisation != "Test Org" {
+		t.Errorf("expected nothing to be stored on validation error, got organisation '%s'", general.Organisation)
+	}
+}
+
+func TestConfigService_ImportBundle_UnsupportedVersion(t *testing.T) {
+	svc, _ := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	err := svc.ImportBundle(ctx, &models.ConfigBundle{Version: 99}, "", "admin@test.com")
+	if !errors.Is(err, ErrBundleVersion) {
+		t.Errorf("expected ErrBundleVersion, got %v", err)
+	}
+}
+
+func TestConfigService_RotateSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	// Secrets stored under the old key
+	oldSvc, repo := createTestConfigService()
+	_ = oldSvc.Initialize(ctx)
+
+	newKey := []byte("a-brand-new-32-byte-encryption-k")
+	svc := NewConfigService(repo, oldSvc.envConfig, newKey)
+	svc.SetPreviousKeys([][]byte{oldSvc.encryptionKey})
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	// Previous key still decrypts secrets
+	if svc.GetConfig().OIDC.ClientSecret != "test-client-secret" {
+		t.Fatalf("expected secret decrypted with previous key, got '%s'", svc.GetConfig().OIDC.ClientSecret)
+	}
+
+	status, err := svc.CheckSecrets(ctx)
+	if err != nil {
+		t.Fatalf("CheckSecrets failed: %v", err)
+	}
+	if len(status.Stale) == 0 || len(status.Current) != 0 {
+		t.Fatalf("expected only stale secrets before rotation, got %+v", status)
+	}
+
+	status, err = svc.RotateSecrets(ctx, "admin@test.com")
+	if err != nil {
+		t.Fatalf("RotateSecrets failed: %v", err)
+	}
+	if len(status.Rotated) == 0 || len(status.Stale) != 0 || len(status.Undecryptable) != 0 {
+		t.Fatalf("unexpected rotation status: %+v", status)
+	}
+
+	// Without the previous key, rotated secrets are still readable
+	rotated := NewConfigService(repo, oldSvc.envConfig, newKey)
+	if err := rotated.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if rotated.GetConfig().OIDC.ClientSecret != "test-client-secret" {
+		t.Errorf("expected secret readable with new key only, got '%s'", rotated.GetConfig().OIDC.ClientSecret)
+	}
+	if rotated.GetConfig().SMTP.Password != "smtp-password" {
+		t.Errorf("expected SMTP password readable with new key only, got '%s'", rotated.GetConfig().SMTP.Password)
+	}
+}
+
+func TestConfigService_EncryptSecret(t *testing.T) {
+	oldSvc, repo := createTestConfigService()
+	ciphertext, err := oldSvc.EncryptSecret("refresh-token")
+	if err != nil {
+		t.Fatalf("EncryptSecret failed: %v", err)
+	}
+	if strings.Contains(string(ciphertext), "refresh-token") {
+		t.Fatal("expected the secret to be encrypted")
+	}
+
+	// A rotated key still decrypts secrets encrypted with the previous one
+	svc := NewConfigService(repo, oldSvc.envConfig, []byte("a-brand-new-32-byte-encryption-k"))
+	if _, err := svc.DecryptSecret(ciphertext); err == nil {
+		t.Fatal("expected an error without the previous key")
+	}
+	svc.SetPreviousKeys([][]byte{oldSvc.encryptionKey})
+	plaintext, err := svc.DecryptSecret(ciphertext)
+	if err != nil || plaintext != "refresh-token" {
+		t.Fatalf("expected the secret decrypted with the previous key, got %q, %v", plaintext, err)
+	}
+}
+
+func TestConfigService_CheckSecrets_Undecryptable(t *testing.T) {
+	ctx := context.Background()
+
+	oldSvc, repo := createTestConfigService()
+	_ = oldSvc.Initialize(ctx)
+
+	svc := NewConfigService(repo, oldSvc.envConfig, []byte("an-unrelated-32-byte-encryption-"))
+	status, err := svc.RotateSecrets(ctx, "admin@test.com")
+	if err != nil {
+		t.Fatalf("RotateSecrets failed: %v", err)
+	}
+	if len(status.Undecryptable) == 0 {
+		t.Errorf("expected undecryptable secrets, got %+v", status)
+	}
+	if len(status.Rotated) != 0 {
+		t.Errorf("expected nothing rotated, got %v", status.Rotated)
+	}
+}
+
+func TestConfigService_PreviewSection(t *testing.T) {
+	svc, repo := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+	before := len(repo.configs[models.ConfigCategoryGeneral].Config)
+
+	preview, err := svc.PreviewSection(models.ConfigCategoryGeneral, json.RawMessage(`{"organisation": "New Org", "only_admin_can_create": false}`))
+	if err != nil {
+		t.Fatalf("PreviewSection failed: %v", err)
+	}
+	if !preview.Valid || len(preview.Errors) != 0 {
+		t.Errorf("expected a valid preview, got %+v", preview)
+	}
+	if len(preview.Changes) != 1 {
+		t.Fatalf("expected one change, got %+v", preview.Changes)
+	}
+	change := preview.Changes[0]
+	if change.Category != models.ConfigCategoryGeneral || change.Field != "organisation" || change.Old != "Test Org" || change.New != "New Org" {
+		t.Errorf("unexpected change: %+v", change)
+	}
+
+	// Nothing is stored
+	if svc.GetConfig().General.Organisation != "Test Org" || len(repo.configs[models.ConfigCategoryGeneral].Config) != before {
+		t.Error("expected the preview not to change the config")
+	}
+}
+
+func TestConfigService_PreviewSection_MasksSecrets(t *testing.T) {
+	svc, _ := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	input := json.RawMessage(`{"host": "smtp.example.com", "port": 465, "username": "test@example.com", "password": "new-password", "encryption": "starttls", "timeout": "10s", "from": "noreply@example.com", "from_name": "Test App"}`)
+	preview, err := svc.PreviewSection(models.ConfigCategorySMTP, input)
+	if err != nil {
+		t.Fatalf("PreviewSection failed: %v", err)
+	}
+
+	fields := map[string]models.ConfigChange{}
+	for _, change := range preview.Changes {
+		fields[change.Field] = change
+	}
+	if change, ok := fields["port"]; !ok || change.Old != float64(587) || change.New != float64(465) {
+		t.Errorf("expected the port change, got %+v", preview.Changes)
+	}
+	password, ok := fields["password"]
+	if !ok {
+		t.Fatalf("expected the password change, got %+v", preview.Changes)
+	}
+	if password.Old != models.SecretMask || password.New != models.SecretMask {
+		t.Errorf("expected masked secrets, got %+v", password)
+	}
+}
+
+func TestConfigService_PreviewSection_CrossCategoryErrors(t *testing.T) {
+	svc, repo := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	emptySMTP, _ := json.Marshal(models.SMTPConfig{})
+	repo.configs[models.ConfigCategorySMTP] = &models.TenantConfig{Category: models.ConfigCategorySMTP, Config: emptySMTP, UpdatedAt: time.Now()}
+	_ = svc.reload(ctx)
+
+	preview, err := svc.PreviewSection(models.ConfigCategoryMagicLink, json.RawMessage(`{"enabled": true}`))
+	if err != nil {
+		t.Fatalf("PreviewSection failed: %v", err)
+	}
+	if preview.Valid || len(preview.Errors) != 1 || preview.Errors[0] != ErrMagicLinkNeedsSMTP.Error() {
+		t.Errorf("expected the MagicLink SMTP error, got %+v", preview)
+	}
+	if len(preview.Changes) != 1 || preview.Changes[0].Field != "enabled" {
+		t.Errorf("expected the changes to be reported, got %+v", preview.Changes)
+	}
+
+	// Section errors are reported in the preview as well
+	preview, err = svc.PreviewSection(models.ConfigCategoryOIDC, json.RawMessage(`{"enabled": true, "provider": "custom"}`))
+	if err != nil {
+		t.Fatalf("PreviewSection failed: %v", err)
+	}
+	if preview.Valid || len(preview.Errors) != 1 {
+		t.Errorf("expected the OIDC URL error, got %+v", preview)
+	}
+
+	if _, err := svc.PreviewSection("unknown", json.RawMessage(`{}`)); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("expected ErrInvalidCategory, got %v", err)
+	}
+}
+
+func TestConfigService_ApplyPending(t *testing.T) {
+	svc, repo := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	emptySMTP, _ := json.Marshal(models.SMTPConfig{})
+	repo.configs[models.ConfigCategorySMTP] = &models.TenantConfig{Category: models.ConfigCategorySMTP, Config: emptySMTP, UpdatedAt: time.Now()}
+	_ = svc.reload(ctx)
+
+	// Switching from OIDC to MagicLink needs SMTP first: staged, the sections are applied together
+	pending, err := svc.StageSection(ctx, models.ConfigCategoryOIDC, json.RawMessage(`{"enabled": false, "provider": ""}`), "admin@test.com")
+	if err != nil {
+		t.Fatalf("StageSection failed: %v", err)
+	}
+	if pending.Preview.Valid {
+		t.Error("expected the staged changes to be invalid without an auth method")
+	}
+	if _, err := svc.StageSection(ctx, models.ConfigCategoryMagicLink, json.RawMessage(`{"enabled": true}`), "admin@test.com"); err != nil {
+		t.Fatalf("StageSection failed: %v", err)
+	}
+	if _, err := svc.ApplyPending(ctx, "admin@test.com"); !errors.Is(err, ErrMagicLinkNeedsSMTP) {
+		t.Fatalf("expected ErrMagicLinkNeedsSMTP, got %v", err)
+	}
+	if !svc.GetConfig().OIDC.Enabled || len(repo.pending) != 2 {
+		t.Fatal("expected nothing applied when the staged config is invalid")
+	}
+
+	smtp := json.RawMessage(`{"host": "smtp.example.com", "port": 587, "password": "smtp-secret", "from": "noreply@example.com"}`)
+	pending, err = svc.StageSection(ctx, models.ConfigCategorySMTP, smtp, "admin@test.com")
+	if err != nil {
+		t.Fatalf("StageSection failed: %v", err)
+	}
+	if !pending.Preview.Valid || len(pending.Sections) != 3 {
+		t.Fatalf("expected 3 valid staged sections, got %+v", pending)
+	}
+
+	changes, err := svc.ApplyPending(ctx, "admin@test.com")
+	if err != nil {
+		t.Fatalf("ApplyPending failed: %v", err)
+	}
+	if len(changes) == 0 {
+		t.Error("expected the applied changes")
+	}
+	cfg := svc.GetConfig()
+	if cfg.OIDC.Enabled || !cfg.MagicLink.Enabled || cfg.SMTP.Host != "smtp.example.com" || cfg.SMTP.Password != "smtp-secret" {
+		t.Errorf("expected the staged sections to be applied, got %+v", cfg)
+	}
+	if len(repo.pending) != 0 {
+		t.Errorf("expected the staging area to be cleared, got %d sections", len(repo.pending))
+	}
+
+	if _, err := svc.ApplyPending(ctx, "admin@test.com"); !errors.Is(err, ErrNoPendingConfig) {
+		t.Errorf("expected ErrNoPendingConfig, got %v", err)
+	}
+}
+
+func TestConfigService_DiscardPending(t *testing.T) {
+	svc, repo := createTestConfigService()
+	ctx := context.Background()
+	_ = svc.Initialize(ctx)
+
+	if _, err := svc.StageSection(ctx, models.ConfigCategoryGeneral, json.RawMessage(`{"organisation": "New Org"}`), "admin@test.com"); err != nil {
+		t.Fatalf("StageSection failed: %v", err)
+	}
+	if _, err := svc.StageSection(ctx, models.ConfigCategoryBranding, json.RawMessage(`{"primary_color": "#123456"}`), "admin@test.com"); err != nil {
+		t.Fatalf("StageSection failed: %v", err)
+	}
+	if _, err := svc.StageSection(ctx, models.ConfigCategoryBranding, json.RawMessage(`{"primary_color": "blue"}`), "admin@test.com"); err == nil {
+		t.Error("expected staged sections to be validated")
+	}
+
+	if err := svc.DiscardPending(ctx, models.ConfigCategoryBranding); err != nil {
+		t.Fatalf("DiscardPending failed: %v", err)
+	}
+	pending, err := svc.PendingChanges(ctx)
+	if err != nil {
+		t.Fatalf("PendingChanges failed: %v", err)
+	}
+	if len(pending.Sections) != 1 || pending.Sections[0].Category != models.ConfigCategoryGeneral {
+		t.Errorf("expected the general section to stay staged, got %+v", pending.Sections)
+	}
+
+	if err := svc.DiscardPending(ctx, ""); err != nil {
+		t.Fatalf("DiscardPending failed: %v", err)
+	}
+	if len(repo.pending) != 0 {
+		t.Errorf("expected no staged section, got %d", len(repo.pending))
+	}
+	if err := svc.DiscardPending(ctx, "unknown"); !errors.Is(err, ErrInvalidCategory) {
+		t.Errorf("expected ErrInvalidCategory, got %v", err)
+	}
+}
+
+func TestConfigService_SecretOverrides(t *testing.T) {
+	svc, _ := createTestConfigService()
+	ctx := context.Background()
+
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	updates := svc.Subscribe()
+
+	svc.SetSecretOverrides(models.ConfigSecrets{OIDCClientSecret: "vault-client-secret"})
+
+	cfg := svc.GetConfig()
+	if cfg.OIDC.ClientSecret != "vault-client-secret" {
+		t.Errorf("expected the overridden client secret, got %q", cfg.OIDC.ClientSecret)
+	}
+	if cfg.SMTP.Password != "smtp-password" {
+		t.Errorf("expected the stored SMTP password to be kept, got %q", cfg.SMTP.Password)
+	}
+	select {
+	case update := <-updates:
+		if update.OIDC.ClientSecret != "vault-client-secret" {
+			t.Errorf("expected subscribers to receive the overridden secret, got %q", update.OIDC.ClientSecret)
+		}
+	default:
+		t.Error("expected subscribers to be notified")
+	}
+
+	// Overrides survive reloads
+	if err := svc.Reload(ctx); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if got := svc.GetConfig().OIDC.ClientSecret; got != "vault-client-secret" {
+		t.Errorf("expected the override to survive a reload, got %q", got)
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// maxConsentTextLength bounds the consent sentence of one language
+const maxConsentTextLength = 2000
+
+// ErrInvalidConsent is returned when a consent version or document setting fails validation
+var ErrInvalidConsent = errors.New("invalid consent")
+
+// consentRepository defines consent catalog storage operations
+type consentRepository interface {
+	PublishVersion(ctx context.Context, input models.ConsentVersionInput) ([]*models.ConsentText, error)
+	List(ctx context.Context) ([]*models.ConsentText, error)
+	GetVersion(ctx context.Context, version int) ([]*models.ConsentText, error)
+	LatestVersion(ctx context.Context) (int, error)
+	GetDocumentVersion(ctx context.Context, docID string) (*models.DocumentConsent, error)
+	SetDocumentVersion(ctx context.Context, docID string, version int, setBy string) error
+	ClearDocumentVersion(ctx context.Context, docID string) error
+}
+
+// consentDocumentRepository checks that the configured documents exist
+type consentDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// ConsentService manages the versioned consent sentences shown to signers
+type ConsentService struct {
+	repo    consentRepository
+	docRepo consentDocumentRepository
+}
+
+// NewConsentService creates a new consent service
+func NewConsentService(repo consentRepository, docRepo consentDocumentRepository) *ConsentService {
+	return &ConsentService{repo: repo, docRepo: docRepo}
+}
+
+// ListCatalog retrieves every published version, latest first
+func (s *ConsentService) ListCatalog(ctx context.Context) ([]*models.ConsentText, error) {
+	return s.repo.List(ctx)
+}
+
+// PublishVersion validates and publishes the next consent version. Published versions
+// are never modified, so that signatures keep pointing to the text they were shown.
+func (s *ConsentService) PublishVersion(ctx context.Context, input models.ConsentVersionInput) ([]*models.ConsentText, error) {
+	if len(input.Texts) == 0 {
+		return nil, fmt.Errorf("%w: at least one text is required", ErrInvalidConsent)
+	}
+
+	texts := make(map[string]string, len(input.Texts))
+	for tag, text := range input.Texts {
+		locale, ok := models.NormalizeLocale(tag)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported locale %q", ErrInvalidConsent, tag)
+		}
+		if _, dup := texts[locale]; dup {
+			return nil, fmt.Errorf("%w: locale %q is given twice", ErrInvalidConsent, locale)
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, fmt.Errorf("%w: text for %q is empty", ErrInvalidConsent, locale)
+		}
+		if utf8.RuneCountInString(text) > maxConsentTextLength {
+			return nil, fmt.Errorf("%w: text for %q must be at most %d characters", ErrInvalidConsent, locale, maxConsentTextLength)
+		}
+		texts[locale] = text
+	}
+	input.Texts = texts
+
+	published, err := s.repo.PublishVersion(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(published) > 0 {
+		logger.Logger.Info("Consent version published", "version", published[0].Version, "locales", len(published), "created_by", input.CreatedBy)
+	}
+	return published, nil
+}
+
+// GetDocumentConsent reports the consent version a document is signed with
+func (s *ConsentService) GetDocumentConsent(ctx context.Context, docID string) (*models.DocumentConsent, error) {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.documentConsent(ctx, docID)
+}
+
+// SetDocumentVersion pins a document to a published version, or makes it follow the
+// latest version again when version is nil
+func (s *ConsentService) SetDocumentVersion(ctx context.Context, docID string, version *int, setBy string) (*models.DocumentConsent, error) {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+
+	if version == nil {
+		if err := s.repo.ClearDocumentVersion(ctx, docID); err != nil {
+			return nil, err
+		}
+	} else {
+		texts, err := s.repo.GetVersion(ctx, *version)
+		if err != nil {
+			return nil, err
+		}
+		if len(texts) == 0 {
+			return nil, fmt.Errorf("%w: version %d does not exist", ErrInvalidConsent, *version)
+		}
+		if err := s.repo.SetDocumentVersion(ctx, docID, *version, setBy); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Logger.Info("Document consent version updated", "doc_id", docID, "pinned", version != nil, "set_by", setBy)
+	return s.documentConsent(ctx, docID)
+}
+
+// Resolve returns the consent text shown to a signer of a document for a display locale,
+// nil when the tenant has no consent catalog
+func (s *ConsentService) Resolve(ctx context.Context, docID, locale string) (*models.ConsentText, error) {
+	consent, err := s.documentConsent(ctx, docID)
+	if err != nil || consent.Version == 0 {
+		return nil, err
+	}
+	texts, err := s.repo.GetVersion(ctx, consent.Version)
+	if err != nil {
+		return nil, err
+	}
+	return models.SelectConsentText(texts, locale), nil
+}
+
+func (s *ConsentService) documentConsent(ctx context.Context, docID string) (*models.DocumentConsent, error) {
+	pinned, err := s.repo.GetDocumentVersion(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if pinned != nil {
+		return pinned, nil
+	}
+
+	latest, err := s.repo.LatestVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DocumentConsent{DocID: docID, Version: latest}, nil
+}
+
+func (s *ConsentService) checkDocument(ctx context.Context, docID string) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return models.ErrDocumentNotFound
+	}
+	return nil
+}
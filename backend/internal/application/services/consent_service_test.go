@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeConsentRepo struct {
+	texts  []*models.ConsentText
+	pinned map[string]int
+}
+
+func newFakeConsentRepo() *fakeConsentRepo {
+	return &fakeConsentRepo{pinned: make(map[string]int)}
+}
+
+func (f *fakeConsentRepo) PublishVersion(ctx context.Context, input models.ConsentVersionInput) ([]*models.ConsentText, error) {
+	latest, _ := f.LatestVersion(ctx)
+	var published []*models.ConsentText
+	for locale, text := range input.Texts {
+		published = append(published, &models.ConsentText{Version: latest + 1, Locale: locale, Text: text, CreatedBy: input.CreatedBy})
+	}
+	sort.Slice(published, func(i, j int) bool { return published[i].Locale < published[j].Locale })
+	f.texts = append(f.texts, published...)
+	return published, nil
+}
+
+func (f *fakeConsentRepo) List(_ context.Context) ([]*models.ConsentText, error) {
+	return f.texts, nil
+}
+
+func (f *fakeConsentRepo) GetVersion(_ context.Context, version int) ([]*models.ConsentText, error) {
+	var out []*models.ConsentText
+	for _, t := range f.texts {
+		if t.Version == version {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeConsentRepo) LatestVersion(_ context.Context) (int, error) {
+	latest := 0
+	for _, t := range f.texts {
+		latest = max(latest, t.Version)
+	}
+	return latest, nil
+}
+
+func (f *fakeConsentRepo) GetDocumentVersion(_ context.Context, docID string) (*models.DocumentConsent, error) {
+	version, ok := f.pinned[docID]
+	if !ok {
+		return nil, nil
+	}
+	return &models.DocumentConsent{DocID: docID, Version: version, Pinned: true}, nil
+}
+
+func (f *fakeConsentRepo) SetDocumentVersion(_ context.Context, docID string, version int, _ string) error {
+	f.pinned[docID] = version
+	return nil
+}
+
+func (f *fakeConsentRepo) ClearDocumentVersion(_ context.Context, docID string) error {
+	delete(f.pinned, docID)
+	return nil
+}
+
+func newConsentServiceWithDoc(repo *fakeConsentRepo, docID string) *ConsentService {
+	docRepo := newFakeDocumentRepository()
+	docRepo.documents[docID] = &models.Document{DocID: docID}
+	return NewConsentService(repo, docRepo)
+}
+
+func TestConsentService_PublishVersion(t *testing.T) {
+	svc := newConsentServiceWithDoc(newFakeConsentRepo(), "doc1")
+	ctx := context.Background()
+
+	texts, err := svc.PublishVersion(ctx, models.ConsentVersionInput{Texts: map[string]string{"fr-FR": " J'ai lu ce document ", "en": "I have read this document"}})
+	if err != nil {
+		t.Fatalf("PublishVersion err: %v", err)
+	}
+	if len(texts) != 2 || texts[1].Locale != "fr" || texts[1].Text != "J'ai lu ce document" {
+		t.Errorf("unexpected texts %+v", texts)
+	}
+
+	invalid := []map[string]string{
+		nil,
+		{"xx": "Unknown language"},
+		{"en": "  "},
+		{"fr": "Un", "FR": "Deux"},
+		{"en": strings.Repeat("x", maxConsentTextLength+1)},
+	}
+	for _, in := range invalid {
+		if _, err := svc.PublishVersion(ctx, models.ConsentVersionInput{Texts: in}); !errors.Is(err, ErrInvalidConsent) {
+			t.Errorf("expected ErrInvalidConsent for %v, got %v", in, err)
+		}
+	}
+}
+
+func TestConsentService_Resolve(t *testing.T) {
+	repo := newFakeConsentRepo()
+	svc := newConsentServiceWithDoc(repo, "doc1")
+	ctx := context.Background()
+
+	if consent, err := svc.Resolve(ctx, "doc1", "fr"); err != nil || consent != nil {
+		t.Fatalf("expected no consent without catalog, got %+v (err %v)", consent, err)
+	}
+
+	_, _ = svc.PublishVersion(ctx, models.ConsentVersionInput{Texts: map[string]string{"en": "v1", "fr": "v1 fr"}})
+	_, _ = svc.PublishVersion(ctx, models.ConsentVersionInput{Texts: map[string]string{"en": "v2"}})
+
+	consent, err := svc.Resolve(ctx, "doc1", "fr")
+	if err != nil || consent.Version != 2 || consent.Locale != "en" {
+		t.Fatalf("expected English text of the latest version, got %+v (err %v)", consent, err)
+	}
+
+	version := 1
+	if _, err := svc.SetDocumentVersion(ctx, "doc1", &version, "admin@example.com"); err != nil {
+		t.Fatalf("SetDocumentVersion err: %v", err)
+	}
+	consent, _ = svc.Resolve(ctx, "doc1", "fr")
+	if consent.Version != 1 || consent.Text != "v1 fr" {
+		t.Errorf("expected pinned version in French, got %+v", consent)
+	}
+
+	missing := 7
+	if _, err := svc.SetDocumentVersion(ctx, "doc1", &missing, "admin@example.com"); !errors.Is(err, ErrInvalidConsent) {
+		t.Errorf("expected ErrInvalidConsent for an unknown version, got %v", err)
+	}
+	if _, err := svc.SetDocumentVersion(ctx, "unknown", nil, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+
+	dc, err := svc.SetDocumentVersion(ctx, "doc1", nil, "admin@example.com")
+	if err != nil || dc.Pinned || dc.Version != 2 {
+		t.Errorf("expected document to follow the latest version, got %+v (err %v)", dc, err)
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// maxCSPViolations bounds the memory used by reports, the least recent ones are dropped
+	maxCSPViolations = 500
+	// maxCSPFieldLength truncates report fields, which come from unauthenticated clients
+	maxCSPFieldLength = 512
+)
+
+type cspViolationKey struct {
+	directive   string
+	blockedURI  string
+	documentURI string
+}
+
+// CSPReportService collects the Content-Security-Policy violations reported by browsers.
+// Identical reports are counted together and kept in memory: they only help to adjust the
+// policy and are lost on restart.
+type CSPReportService struct {
+	mu         sync.Mutex
+	violations map[cspViolationKey]*models.CSPViolation
+	now        func() time.Time
+}
+
+func NewCSPReportService() *CSPReportService {
+	return &CSPReportService{
+		violations: make(map[cspViolationKey]*models.CSPViolation),
+		now:        time.Now,
+	}
+}
+
+// Record adds a violation report. URLs are stripped of their query string and fragment,
+// which may carry tokens.
+func (s *CSPReportService) Record(report models.CSPViolation) {
+	report.Directive = truncateCSPField(report.Directive)
+	report.BlockedURI = truncateCSPField(stripURLQuery(report.BlockedURI))
+	report.DocumentURI = truncateCSPField(stripURLQuery(report.DocumentURI))
+	report.SourceFile = truncateCSPField(stripURLQuery(report.SourceFile))
+	report.Sample = truncateCSPField(report.Sample)
+	report.Disposition = truncateCSPField(report.Disposition)
+
+	key := cspViolationKey{report.Directive, report.BlockedURI, report.DocumentURI}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.violations[key]; ok {
+		existing.Count++
+		existing.LastSeenAt = now
+		return
+	}
+
+	if len(s.violations) >= maxCSPViolations {
+		s.evictOldest()
+	}
+	report.Count = 1
+	report.FirstSeenAt = now
+	report.LastSeenAt = now
+	s.violations[key] = &report
+
+	logger.Logger.Warn("Content-Security-Policy violation",
+		"directive", report.Directive,
+		"blocked_uri", report.BlockedURI,
+		"document_uri", report.DocumentURI)
+}
+
+// List returns up to limit violations, most recent first
+func (s *CSPReportService) List(limit int) []*models.CSPViolation {
+	s.mu.Lock()
+	violations := make([]*models.CSPViolation, 0, len(s.violations))
+	for _, v := range s.violations {
+		copied := *v
+		violations = append(violations, &copied)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].LastSeenAt.After(violations[j].LastSeenAt)
+	})
+	if limit > 0 && len(violations) > limit {
+		violations = violations[:limit]
+	}
+	return violations
+}
+
+// evictOldest drops the violation seen least recently, must be called with the lock held
+func (s *CSPReportService) evictOldest() {
+	var oldestKey cspViolationKey
+	var oldest *models.CSPViolation
+	for key, v := range s.violations {
+		if oldest == nil || v.LastSeenAt.Before(oldest.LastSeenAt) {
+			oldestKey, oldest = key, v
+		}
+	}
+	delete(s.violations, oldestKey)
+}
+
+func stripURLQuery(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		// Keywords such as "inline" or "eval"
+		return raw
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.User = nil
+	return u.String()
+}
+
+func truncateCSPField(value string) string {
+	if len(value) > maxCSPFieldLength {
+		return value[:maxCSPFieldLength]
+	}
+	return value
+}
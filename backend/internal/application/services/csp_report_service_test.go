@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestCSPReportService_Record(t *testing.T) {
+	service := NewCSPReportService()
+	now := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	report := models.CSPViolation{
+		Directive:   "font-src",
+		BlockedURI:  "https://fonts.example.com/font.woff2?token=secret",
+		DocumentURI: "https://ackify.example.com/admin?doc=1#top",
+	}
+	service.Record(report)
+	service.Record(models.CSPViolation{Directive: "script-src", BlockedURI: "inline", DocumentURI: "https://ackify.example.com/"})
+	now = now.Add(time.Minute)
+	service.Record(report)
+
+	violations := service.List(10)
+	if len(violations) != 2 {
+		t.Fatalf("expected identical reports to be counted together, got %d violations", len(violations))
+	}
+	var font *models.CSPViolation
+	for _, v := range violations {
+		if v.Directive == "font-src" {
+			font = v
+		}
+	}
+	if font == nil || font.Count != 2 || !font.LastSeenAt.After(font.FirstSeenAt) {
+		t.Fatalf("unexpected aggregated violation: %+v", font)
+	}
+	if font.BlockedURI != "https://fonts.example.com/font.woff2" || font.DocumentURI != "https://ackify.example.com/admin" {
+		t.Errorf("expected query strings and fragments removed, got %q and %q", font.BlockedURI, font.DocumentURI)
+	}
+	if violations[0].Directive != "font-src" {
+		t.Errorf("expected most recent violation first, got %s", violations[0].Directive)
+	}
+}
+
+func TestCSPReportService_Bounded(t *testing.T) {
+	service := NewCSPReportService()
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	for i := 0; i < maxCSPViolations+10; i++ {
+		now = now.Add(time.Second)
+		service.Record(models.CSPViolation{Directive: "img-src", BlockedURI: fmt.Sprintf("https://img%d.example.com/", i)})
+	}
+
+	violations := service.List(0)
+	if len(violations) != maxCSPViolations {
+		t.Fatalf("expected %d violations kept, got %d", maxCSPViolations, len(violations))
+	}
+	for _, v := range violations {
+		if v.BlockedURI == "https://img0.example.com/" {
+			t.Error("expected the least recent violation to be dropped")
+		}
+	}
+}
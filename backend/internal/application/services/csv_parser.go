@@ -23,6 +23,7 @@ type CSVParseError struct {
 	LineNumber int    `json:"lineNumber"`
 	Content    string `json:"content"`
 	Error      string `json:"error"`
+	Reason     string `json:"reason,omitempty"` // Set when the signer email policy refused the email
 }
 
 // CSVParseResult contains the complete result of parsing a CSV file
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// Audit actions recorded by the deadline extension service (see web.AuditAction* constants)
+const (
+	auditActionExtensionRequest = "deadline_extension.request"
+	auditActionExtensionApprove = "deadline_extension.approve"
+	auditActionExtensionDeny    = "deadline_extension.deny"
+)
+
+// deadlineExtensionRepository stores the extension requests and the decisions on them
+type deadlineExtensionRepository interface {
+	Create(ctx context.Context, docID, signerEmail, reason string, requestedDeadline time.Time) (*models.DeadlineExtension, error)
+	Get(ctx context.Context, docID string, id int64) (*models.DeadlineExtension, error)
+	ListByDoc(ctx context.Context, docID, signerEmail string) ([]*models.DeadlineExtension, error)
+	Decide(ctx context.Context, docID string, id int64, status models.ExtensionStatus, grantedDeadline *time.Time, decidedBy, note string) (*models.DeadlineExtension, error)
+}
+
+// extensionDocumentRepository looks up the documents extensions are requested for
+type extensionDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// extensionDeadlineRepository reads the document deadline from the completion settings
+type extensionDeadlineRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.CompletionSettings, error)
+}
+
+// extensionSignerRepository lists the expected signers allowed to request an extension
+type extensionSignerRepository interface {
+	ListWithStatusByDocID(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
+}
+
+// DeadlineExtensionService lets expected signers ask for more time than the document deadline and
+// the document owner approve or deny it. The deadline of the document is unchanged: each signer
+// has their own effective deadline, which reminders take into account. Every request and decision
+// is audit-logged.
+type DeadlineExtensionService struct {
+	repo       deadlineExtensionRepository
+	docRepo    extensionDocumentRepository
+	deadlines  extensionDeadlineRepository
+	signers    extensionSignerRepository
+	authorizer documentManagerChecker
+	audit      auditRecorder
+	now        func() time.Time
+}
+
+// NewDeadlineExtensionService creates a new deadline extension service. audit may be nil.
+func NewDeadlineExtensionService(
+	repo deadlineExtensionRepository,
+	docRepo extensionDocumentRepository,
+	deadlines extensionDeadlineRepository,
+	signers extensionSignerRepository,
+	authorizer documentManagerChecker,
+	audit auditRecorder,
+) *DeadlineExtensionService {
+	return &DeadlineExtensionService{
+		repo:       repo,
+		docRepo:    docRepo,
+		deadlines:  deadlines,
+		signers:    signers,
+		authorizer: authorizer,
+		audit:      audit,
+		now:        time.Now,
+	}
+}
+
+// List returns the deadline of a document for user with the extension requests: every request
+// for the document owner and admins, the requests of user otherwise
+func (s *DeadlineExtensionService) List(ctx context.Context, docID string, user *models.User) (*models.DeadlineExtensionView, error) {
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	deadline, err := s.documentDeadline(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &models.DeadlineExtensionView{Deadline: deadline, CanDecide: s.canDecide(ctx, user, doc)}
+	filter := user.NormalizedEmail()
+	if view.CanDecide {
+		filter = ""
+	}
+	if view.Extensions, err = s.repo.ListByDoc(ctx, docID, filter); err != nil {
+		return nil, err
+	}
+	own := view.Extensions
+	if view.CanDecide {
+		own = nil
+		for _, e := range view.Extensions {
+			if strings.EqualFold(e.SignerEmail, user.Email) {
+				own = append(own, e)
+			}
+		}
+	}
+	view.EffectiveDeadline = models.GroupExtensions(own)[user.NormalizedEmail()].EffectiveDeadline(deadline)
+	return view, nil
+}
+
+// Request records the request of an expected signer who has not signed yet for a deadline later
+// than their current one
+func (s *DeadlineExtensionService) Request(ctx context.Context, docID string, user *models.User, deadline time.Time, reason string) (*models.DeadlineExtension, error) {
+	reason, err := models.NormalizeExtensionText(reason, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.document(ctx, docID); err != nil {
+		return nil, err
+	}
+	documentDeadline, err := s.documentDeadline(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if documentDeadline == nil {
+		return nil, models.ErrNoDocumentDeadline
+	}
+	if pending, err := s.isPendingSigner(ctx, docID, user); err != nil {
+		return nil, err
+	} else if !pending {
+		return nil, models.ErrExtensionForbidden
+	}
+
+	email := user.NormalizedEmail()
+	own, err := s.repo.ListByDoc(ctx, docID, email)
+	if err != nil {
+		return nil, err
+	}
+	current := models.GroupExtensions(own)[email].EffectiveDeadline(documentDeadline)
+	if !deadline.After(*current) || !s.withinLimit(deadline) {
+		return nil, models.ErrInvalidExtension
+	}
+
+	extension, err := s.repo.Create(ctx, docID, email, reason, deadline.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Deadline extension requested",
+		"doc_id", docID,
+		"signer_email", email,
+		"requested_deadline", extension.RequestedDeadline)
+	s.record(ctx, auditActionExtensionRequest, extension, email, map[string]any{
+		"requested_deadline": extension.RequestedDeadline.UTC().Format(time.RFC3339),
+		"reason":             reason,
+	})
+	return extension, nil
+}
+
+// Approve grants a pending request, until deadline when set, else until the requested deadline
+func (s *DeadlineExtensionService) Approve(ctx context.Context, docID string, id int64, user *models.User, deadline *time.Time, note string) (*models.DeadlineExtension, error) {
+	note, err := models.NormalizeExtensionText(note, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireDecider(ctx, docID, user); err != nil {
+		return nil, err
+	}
+	extension, err := s.repo.Get(ctx, docID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := extension.RequestedDeadline
+	if deadline != nil {
+		granted = deadline.UTC()
+	}
+	if !s.withinLimit(granted) {
+		return nil, models.ErrInvalidExtension
+	}
+	return s.decide(ctx, docID, id, models.ExtensionStatusApproved, &granted, user, note, auditActionExtensionApprove)
+}
+
+// Deny refuses a pending request; the signer keeps their current deadline
+func (s *DeadlineExtensionService) Deny(ctx context.Context, docID string, id int64, user *models.User, note string) (*models.DeadlineExtension, error) {
+	note, err := models.NormalizeExtensionText(note, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireDecider(ctx, docID, user); err != nil {
+		return nil, err
+	}
+	return s.decide(ctx, docID, id, models.ExtensionStatusDenied, nil, user, note, auditActionExtensionDeny)
+}
+
+// SignerExtensions returns the extension situation of the signers of a document, by lowercased email
+func (s *DeadlineExtensionService) SignerExtensions(ctx context.Context, docID string) (map[string]*models.SignerExtensions, error) {
+	extensions, err := s.repo.ListByDoc(ctx, docID, "")
+	if err != nil {
+		return nil, err
+	}
+	return models.GroupExtensions(extensions), nil
+}
+
+func (s *DeadlineExtensionService) decide(ctx context.Context, docID string, id int64, status models.ExtensionStatus, granted *time.Time, user *models.User, note, action string) (*models.DeadlineExtension, error) {
+	decidedBy := user.NormalizedEmail()
+	extension, err := s.repo.Decide(ctx, docID, id, status, granted, decidedBy, note)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Deadline extension decided",
+		"doc_id", docID,
+		"id", id,
+		"signer_email", extension.SignerEmail,
+		"status", status,
+		"decided_by", decidedBy)
+	details := map[string]any{"signer_email": extension.SignerEmail, "note": note}
+	if granted != nil {
+		details["granted_deadline"] = granted.UTC().Format(time.RFC3339)
+	}
+	s.record(ctx, action, extension, decidedBy, details)
+	return extension, nil
+}
+
+func (s *DeadlineExtensionService) record(ctx context.Context, action string, extension *models.DeadlineExtension, actor string, details map[string]any) {
+	if s.audit == nil {
+		return
+	}
+	details["doc_id"] = extension.DocID
+	s.audit.Record(ctx, action, "deadline_extension", strconv.FormatInt(extension.ID, 10), actor, details)
+}
+
+// withinLimit reports whether an extended deadline is in the future and within MaxExtensionDuration
+func (s *DeadlineExtensionService) withinLimit(deadline time.Time) bool {
+	now := s.now()
+	return deadline.After(now) && !deadline.After(now.Add(models.MaxExtensionDuration))
+}
+
+func (s *DeadlineExtensionService) documentDeadline(ctx context.Context, docID string) (*time.Time, error) {
+	settings, err := s.deadlines.GetByDocID(ctx, docID)
+	if err != nil || settings == nil {
+		return nil, err
+	}
+	return settings.Deadline, nil
+}
+
+func (s *DeadlineExtensionService) isPendingSigner(ctx context.Context, docID string, user *models.User) (bool, error) {
+	signers, err := s.signers.ListWithStatusByDocID(ctx, docID)
+	if err != nil {
+		return false, err
+	}
+	for _, signer := range signers {
+		if strings.EqualFold(signer.Email, user.Email) {
+			return !signer.HasSigned, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *DeadlineExtensionService) document(ctx context.Context, docID string) (*models.Document, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil || doc.DeletedAt != nil {
+		return nil, models.ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+func (s *DeadlineExtensionService) requireDecider(ctx context.Context, docID string, user *models.User) error {
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if !s.canDecide(ctx, user, doc) {
+		return models.ErrExtensionForbidden
+	}
+	return nil
+}
+
+func (s *DeadlineExtensionService) canDecide(ctx context.Context, user *models.User, doc *models.Document) bool {
+	return user != nil && s.authorizer != nil && s.authorizer.CanManageDocument(ctx, user.Email, doc.CreatedBy)
+}
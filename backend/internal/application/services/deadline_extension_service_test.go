@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeExtensionRepo struct {
+	extensions []*models.DeadlineExtension
+}
+
+func (f *fakeExtensionRepo) Create(_ context.Context, docID, signerEmail, reason string, requestedDeadline time.Time) (*models.DeadlineExtension, error) {
+	for _, e := range f.extensions {
+		if e.DocID == docID && e.SignerEmail == signerEmail && e.Status == models.ExtensionStatusPending {
+			return nil, models.ErrExtensionPending
+		}
+	}
+	e := &models.DeadlineExtension{ID: int64(len(f.extensions) + 1), DocID: docID, SignerEmail: signerEmail,
+		Reason: reason, RequestedDeadline: requestedDeadline, Status: models.ExtensionStatusPending}
+	f.extensions = append(f.extensions, e)
+	return e, nil
+}
+
+func (f *fakeExtensionRepo) Get(_ context.Context, docID string, id int64) (*models.DeadlineExtension, error) {
+	for _, e := range f.extensions {
+		if e.DocID == docID && e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, models.ErrExtensionNotFound
+}
+
+func (f *fakeExtensionRepo) ListByDoc(_ context.Context, docID, signerEmail string) ([]*models.DeadlineExtension, error) {
+	var out []*models.DeadlineExtension
+	for _, e := range f.extensions {
+		if e.DocID == docID && (signerEmail == "" || strings.EqualFold(e.SignerEmail, signerEmail)) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeExtensionRepo) Decide(ctx context.Context, docID string, id int64, status models.ExtensionStatus, grantedDeadline *time.Time, decidedBy, note string) (*models.DeadlineExtension, error) {
+	e, err := f.Get(ctx, docID, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != models.ExtensionStatusPending {
+		return nil, models.ErrExtensionDecided
+	}
+	now := time.Now()
+	e.Status, e.GrantedDeadline, e.DecidedBy, e.DecidedAt, e.DecisionNote = status, grantedDeadline, &decidedBy, &now, note
+	return e, nil
+}
+
+type fakeExtensionDeadlines struct {
+	deadlines map[string]time.Time
+}
+
+func (f *fakeExtensionDeadlines) GetByDocID(_ context.Context, docID string) (*models.CompletionSettings, error) {
+	deadline, ok := f.deadlines[docID]
+	if !ok {
+		return nil, nil
+	}
+	return &models.CompletionSettings{DocID: docID, Deadline: &deadline}, nil
+}
+
+type fakeExtensionSigners struct {
+	signers []*models.ExpectedSignerWithStatus
+}
+
+func (f *fakeExtensionSigners) ListWithStatusByDocID(_ context.Context, _ string) ([]*models.ExpectedSignerWithStatus, error) {
+	return f.signers, nil
+}
+
+var extensionDeadline = time.Now().Add(7 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+func newExtensionFixture() (*DeadlineExtensionService, *fakeExtensionRepo, *fakeAuditRecorder) {
+	repo := &fakeExtensionRepo{}
+	docs := &fakeQuestionDocRepo{docs: map[string]*models.Document{
+		questionDocRef: {DocID: questionDocRef, Title: "Policy", CreatedBy: questionOwner.Email},
+		"open":         {DocID: "open", Title: "No deadline", CreatedBy: questionOwner.Email},
+	}}
+	signed := pendingSigner(questionOther.Email)
+	signed.HasSigned = true
+	signers := &fakeExtensionSigners{signers: []*models.ExpectedSignerWithStatus{pendingSigner("Alice@Example.com"), signed}}
+	audit := &fakeAuditRecorder{}
+	svc := NewDeadlineExtensionService(repo, docs, &fakeExtensionDeadlines{deadlines: map[string]time.Time{questionDocRef: extensionDeadline}},
+		signers, &fakeOwnerAuthorizer{admins: map[string]bool{questionAdmin.Email: true}}, audit)
+	return svc, repo, audit
+}
+
+func TestDeadlineExtensionService_RequestValidation(t *testing.T) {
+	svc, repo, audit := newExtensionFixture()
+	ctx := context.Background()
+	later := extensionDeadline.Add(3 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		docID    string
+		user     *models.User
+		deadline time.Time
+		reason   string
+		want     error
+	}{
+		{"missing reason", questionDocRef, questionAsker, later, "  ", models.ErrInvalidExtension},
+		{"unknown document", "missing", questionAsker, later, "Travelling", models.ErrDocumentNotFound},
+		{"no deadline", "open", questionAsker, later, "Travelling", models.ErrNoDocumentDeadline},
+		{"not an expected signer", questionDocRef, questionOwner, later, "Travelling", models.ErrExtensionForbidden},
+		{"already signed", questionDocRef, questionOther, later, "Travelling", models.ErrExtensionForbidden},
+		{"not later than the deadline", questionDocRef, questionAsker, extensionDeadline, "Travelling", models.ErrInvalidExtension},
+		{"beyond the limit", questionDocRef, questionAsker, time.Now().Add(2 * models.MaxExtensionDuration), "Travelling", models.ErrInvalidExtension},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Request(ctx, tt.docID, tt.user, tt.deadline, tt.reason); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+	if len(repo.extensions) != 0 || len(audit.actions) != 0 {
+		t.Fatalf("expected nothing recorded, got %d extensions and %v", len(repo.extensions), audit.actions)
+	}
+
+	e, err := svc.Request(ctx, questionDocRef, questionAsker, later, " Travelling until next week ")
+	if err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	if e.SignerEmail != "alice@example.com" || e.Reason != "Travelling until next week" || e.Status != models.ExtensionStatusPending {
+		t.Fatalf("unexpected extension: %+v", e)
+	}
+	if _, err := svc.Request(ctx, questionDocRef, questionAsker, later.Add(time.Hour), "Still travelling"); !errors.Is(err, models.ErrExtensionPending) {
+		t.Fatalf("expected ErrExtensionPending, got %v", err)
+	}
+	if len(audit.actions) != 1 || audit.actions[0] != auditActionExtensionRequest {
+		t.Fatalf("unexpected audit trail: %v", audit.actions)
+	}
+}
+
+func TestDeadlineExtensionService_Decisions(t *testing.T) {
+	svc, _, audit := newExtensionFixture()
+	ctx := context.Background()
+	requested := extensionDeadline.Add(5 * 24 * time.Hour)
+
+	first, _ := svc.Request(ctx, questionDocRef, questionAsker, requested, "Travelling")
+	if _, err := svc.Approve(ctx, questionDocRef, first.ID, questionAsker, nil, ""); !errors.Is(err, models.ErrExtensionForbidden) {
+		t.Fatalf("expected signer approval to be forbidden, got %v", err)
+	}
+	if _, err := svc.Approve(ctx, questionDocRef, 42, questionOwner, nil, ""); !errors.Is(err, models.ErrExtensionNotFound) {
+		t.Fatalf("expected ErrExtensionNotFound, got %v", err)
+	}
+
+	shorter := extensionDeadline.Add(2 * 24 * time.Hour)
+	approved, err := svc.Approve(ctx, questionDocRef, first.ID, questionOwner, &shorter, "Two days only")
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if approved.Status != models.ExtensionStatusApproved || !approved.GrantedDeadline.Equal(shorter) ||
+		*approved.DecidedBy != questionOwner.Email || approved.DecisionNote != "Two days only" {
+		t.Fatalf("unexpected approval: %+v", approved)
+	}
+	if _, err := svc.Deny(ctx, questionDocRef, first.ID, questionAdmin, ""); !errors.Is(err, models.ErrExtensionDecided) {
+		t.Fatalf("expected ErrExtensionDecided, got %v", err)
+	}
+
+	// A new request must go beyond the extended deadline
+	if _, err := svc.Request(ctx, questionDocRef, questionAsker, shorter, "More time"); !errors.Is(err, models.ErrInvalidExtension) {
+		t.Fatalf("expected ErrInvalidExtension, got %v", err)
+	}
+	second, err := svc.Request(ctx, questionDocRef, questionAsker, requested, "More time")
+	if err != nil {
+		t.Fatalf("second Request: %v", err)
+	}
+	denied, err := svc.Deny(ctx, questionDocRef, second.ID, questionAdmin, "No")
+	if err != nil || denied.Status != models.ExtensionStatusDenied || denied.GrantedDeadline != nil {
+		t.Fatalf("unexpected denial: %+v (err %v)", denied, err)
+	}
+
+	want := []string{auditActionExtensionRequest, auditActionExtensionApprove, auditActionExtensionRequest, auditActionExtensionDeny}
+	if strings.Join(audit.actions, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected audit trail: %v", audit.actions)
+	}
+
+	extensions, err := svc.SignerExtensions(ctx, questionDocRef)
+	if err != nil {
+		t.Fatalf("SignerExtensions: %v", err)
+	}
+	alice := extensions["alice@example.com"]
+	if alice == nil || alice.Pending != nil || alice.Approved == nil || alice.Approved.ID != first.ID {
+		t.Fatalf("unexpected signer extensions: %+v", alice)
+	}
+}
+
+func TestDeadlineExtensionService_List(t *testing.T) {
+	svc, _, _ := newExtensionFixture()
+	ctx := context.Background()
+	requested := extensionDeadline.Add(4 * 24 * time.Hour)
+	e, _ := svc.Request(ctx, questionDocRef, questionAsker, requested, "Travelling")
+	if _, err := svc.Approve(ctx, questionDocRef, e.ID, questionOwner, nil, ""); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+
+	view, err := svc.List(ctx, questionDocRef, questionAsker)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if view.CanDecide || len(view.Extensions) != 1 || !view.Deadline.Equal(extensionDeadline) || !view.EffectiveDeadline.Equal(requested) {
+		t.Fatalf("unexpected signer view: %+v", view)
+	}
+
+	view, err = svc.List(ctx, questionDocRef, questionOther)
+	if err != nil || view.CanDecide || len(view.Extensions) != 0 || !view.EffectiveDeadline.Equal(extensionDeadline) {
+		t.Fatalf("unexpected view for another signer: %+v (err %v)", view, err)
+	}
+
+	view, err = svc.List(ctx, questionDocRef, questionOwner)
+	if err != nil || !view.CanDecide || len(view.Extensions) != 1 {
+		t.Fatalf("unexpected owner view: %+v (err %v)", view, err)
+	}
+
+	view, err = svc.List(ctx, "open", questionAsker)
+	if err != nil || view.Deadline != nil || view.EffectiveDeadline != nil {
+		t.Fatalf("unexpected view without deadline: %+v (err %v)", view, err)
+	}
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidDepartment is returned when a department or its members fail validation
+var ErrInvalidDepartment = errors.New("invalid department")
+
+// maxDepartmentNameLength bounds department names
+const maxDepartmentNameLength = 200
+
+// departmentRepository defines department storage operations
+type departmentRepository interface {
+	Create(ctx context.Context, input models.DepartmentInput) (*models.Department, error)
+	Update(ctx context.Context, id int64, input models.DepartmentInput) (*models.Department, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.Department, error)
+	List(ctx context.Context) ([]*models.Department, error)
+	SubtreeIDs(ctx context.Context, id int64) ([]int64, error)
+	ListMembers(ctx context.Context, departmentIDs []int64) ([]models.DepartmentMember, error)
+	UpsertMembers(ctx context.Context, departmentID int64, members []models.DepartmentMember) error
+	RemoveMembers(ctx context.Context, departmentID int64, emails []string) error
+	SetDocumentDepartment(ctx context.Context, docID string, departmentID *int64) error
+	ListDocuments(ctx context.Context, departmentIDs []int64) ([]*models.DepartmentDocument, error)
+}
+
+// departmentDocumentRepository checks the documents department members are added to
+type departmentDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// departmentSignerRepository adds the members of a department as expected signers
+type departmentSignerRepository interface {
+	AddExpected(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
+}
+
+// DepartmentService manages the department tree, its members and the documents assigned to it.
+// Department-admins are limited to their subtree by the RLS policies of the request transaction.
+type DepartmentService struct {
+	repo       departmentRepository
+	docRepo    departmentDocumentRepository
+	signerRepo departmentSignerRepository
+}
+
+// NewDepartmentService creates a new department service
+func NewDepartmentService(repo departmentRepository, docRepo departmentDocumentRepository, signerRepo departmentSignerRepository) *DepartmentService {
+	return &DepartmentService{repo: repo, docRepo: docRepo, signerRepo: signerRepo}
+}
+
+// ListDepartments returns the departments visible to the current admin
+func (s *DepartmentService) ListDepartments(ctx context.Context) ([]*models.Department, error) {
+	return s.repo.List(ctx)
+}
+
+// GetDepartment returns a department
+func (s *DepartmentService) GetDepartment(ctx context.Context, id int64) (*models.Department, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// CreateDepartment adds a department, at the top level or under a parent
+func (s *DepartmentService) CreateDepartment(ctx context.Context, input models.DepartmentInput) (*models.Department, error) {
+	input, err := s.validate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	department, err := s.repo.Create(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Department created", "department_id", department.ID, "parent_id", department.ParentID, "created_by", input.CreatedBy)
+	return department, nil
+}
+
+// UpdateDepartment renames a department or moves it under another parent
+func (s *DepartmentService) UpdateDepartment(ctx context.Context, id int64, input models.DepartmentInput) (*models.Department, error) {
+	input, err := s.validate(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != nil {
+		subtree, err := s.repo.SubtreeIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(subtree, *input.ParentID) {
+			return nil, fmt.Errorf("%w: a department cannot be moved under itself", ErrInvalidDepartment)
+		}
+	}
+	return s.repo.Update(ctx, id, input)
+}
+
+// DeleteDepartment removes a department. Its documents are no longer assigned to a department;
+// departments with sub-departments or admins cannot be deleted.
+func (s *DepartmentService) DeleteDepartment(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	logger.Logger.Info("Department deleted", "department_id", id)
+	return nil
+}
+
+func (s *DepartmentService) validate(ctx context.Context, input models.DepartmentInput) (models.DepartmentInput, error) {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return input, fmt.Errorf("%w: name is required", ErrInvalidDepartment)
+	}
+	if len(input.Name) > maxDepartmentNameLength {
+		return input, fmt.Errorf("%w: name is too long", ErrInvalidDepartment)
+	}
+	if input.ParentID != nil {
+		if _, err := s.repo.GetByID(ctx, *input.ParentID); err != nil {
+			if errors.Is(err, models.ErrDepartmentNotFound) {
+				return input, fmt.Errorf("%w: unknown parent department", ErrInvalidDepartment)
+			}
+			return input, err
+		}
+	}
+	return input, nil
+}
+
+// ListMembers returns the members of a department, without those of its sub-departments
+func (s *DepartmentService) ListMembers(ctx context.Context, id int64) ([]models.DepartmentMember, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, []int64{id})
+}
+
+// AddMembers adds signers to a department, updating the name of those already present
+func (s *DepartmentService) AddMembers(ctx context.Context, id int64, members []models.DepartmentMember, addedBy string) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	normalized := make([]models.DepartmentMember, 0, len(members))
+	for _, m := range members {
+		email := strings.ToLower(strings.TrimSpace(m.Email))
+		if _, err := mail.ParseAddress(email); err != nil {
+			return fmt.Errorf("%w: invalid email %q", ErrInvalidDepartment, m.Email)
+		}
+		normalized = append(normalized, models.DepartmentMember{Email: email, Name: strings.TrimSpace(m.Name), AddedBy: addedBy})
+	}
+	return s.repo.UpsertMembers(ctx, id, normalized)
+}
+
+// RemoveMember removes a signer from a department
+func (s *DepartmentService) RemoveMember(ctx context.Context, id int64, email string) error {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.RemoveMembers(ctx, id, []string{strings.ToLower(strings.TrimSpace(email))})
+}
+
+// SetDocumentDepartment assigns a document to a department, or unassigns it when departmentID is
+// nil. Department-admins can only move documents between the departments of their subtree.
+func (s *DepartmentService) SetDocumentDepartment(ctx context.Context, docID string, departmentID *int64) error {
+	if departmentID != nil {
+		if _, err := s.repo.GetByID(ctx, *departmentID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.SetDocumentDepartment(ctx, docID, departmentID); err != nil {
+		return err
+	}
+	logger.Logger.Info("Document department changed", "doc_id", docID, "department_id", departmentID)
+	return nil
+}
+
+// ListDocuments returns the documents of a department and of its sub-departments, with their completion
+func (s *DepartmentService) ListDocuments(ctx context.Context, id int64) ([]*models.DepartmentDocument, error) {
+	subtree, err := s.repo.SubtreeIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocuments(ctx, subtree)
+}
+
+// Stats aggregates the completion of the documents of a department and of its sub-departments
+func (s *DepartmentService) Stats(ctx context.Context, id int64) (*models.DepartmentStats, error) {
+	subtree, err := s.repo.SubtreeIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.repo.ListDocuments(ctx, subtree)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.ListMembers(ctx, subtree)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.DepartmentStats{
+		DepartmentID: id,
+		Departments:  len(subtree),
+		Documents:    len(documents),
+		Members:      len(members),
+	}
+	for _, doc := range documents {
+		stats.ExpectedCount += doc.ExpectedCount
+		stats.SignedCount += doc.SignedCount
+	}
+	stats.PendingCount = stats.ExpectedCount - stats.SignedCount
+	if stats.ExpectedCount > 0 {
+		stats.CompletionRate = float64(stats.SignedCount) / float64(stats.ExpectedCount) * 100
+	}
+	return stats, nil
+}
+
+// AddToDocument adds the members of a department and of its sub-departments as expected signers
+// of a document and returns how many were added. Signers already expected are left unchanged.
+func (s *DepartmentService) AddToDocument(ctx context.Context, docID string, id int64, addedBy string) (int, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return 0, err
+	}
+	if doc == nil {
+		return 0, models.ErrDocumentNotFound
+	}
+
+	subtree, err := s.repo.SubtreeIDs(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	members, err := s.repo.ListMembers(ctx, subtree)
+	if err != nil {
+		return 0, err
+	}
+
+	contacts := make([]models.ContactInfo, 0, len(members))
+	for _, m := range members {
+		contacts = append(contacts, models.ContactInfo{Email: m.Email, Name: m.Name})
+	}
+	if err := s.signerRepo.AddExpected(ctx, docID, contacts, addedBy); err != nil {
+		return 0, err
+	}
+
+	logger.Logger.Info("Department members added to document", "department_id", id, "doc_id", docID, "members", len(contacts))
+	return len(contacts), nil
+}
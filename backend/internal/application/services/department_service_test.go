@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeDepartmentRepo struct {
+	departments map[int64]*models.Department
+	members     map[int64][]models.DepartmentMember
+	documents   map[string]*int64
+	nextID      int64
+}
+
+func newFakeDepartmentRepo() *fakeDepartmentRepo {
+	return &fakeDepartmentRepo{
+		departments: make(map[int64]*models.Department),
+		members:     make(map[int64][]models.DepartmentMember),
+		documents:   make(map[string]*int64),
+	}
+}
+
+func (f *fakeDepartmentRepo) Create(_ context.Context, input models.DepartmentInput) (*models.Department, error) {
+	f.nextID++
+	d := &models.Department{ID: f.nextID, Name: input.Name, ParentID: input.ParentID, CreatedBy: input.CreatedBy}
+	f.departments[d.ID] = d
+	return d, nil
+}
+
+func (f *fakeDepartmentRepo) Update(_ context.Context, id int64, input models.DepartmentInput) (*models.Department, error) {
+	d, ok := f.departments[id]
+	if !ok {
+		return nil, models.ErrDepartmentNotFound
+	}
+	d.Name, d.ParentID = input.Name, input.ParentID
+	return d, nil
+}
+
+func (f *fakeDepartmentRepo) Delete(_ context.Context, id int64) error {
+	if _, ok := f.departments[id]; !ok {
+		return models.ErrDepartmentNotFound
+	}
+	delete(f.departments, id)
+	return nil
+}
+
+func (f *fakeDepartmentRepo) GetByID(_ context.Context, id int64) (*models.Department, error) {
+	d, ok := f.departments[id]
+	if !ok {
+		return nil, models.ErrDepartmentNotFound
+	}
+	return d, nil
+}
+
+func (f *fakeDepartmentRepo) List(_ context.Context) ([]*models.Department, error) {
+	out := make([]*models.Department, 0, len(f.departments))
+	for _, d := range f.departments {
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (f *fakeDepartmentRepo) SubtreeIDs(_ context.Context, id int64) ([]int64, error) {
+	if _, ok := f.departments[id]; !ok {
+		return nil, models.ErrDepartmentNotFound
+	}
+	ids := []int64{id}
+	for i := 0; i < len(ids); i++ {
+		for _, d := range f.departments {
+			if d.ParentID != nil && *d.ParentID == ids[i] {
+				ids = append(ids, d.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (f *fakeDepartmentRepo) ListMembers(_ context.Context, departmentIDs []int64) ([]models.DepartmentMember, error) {
+	var out []models.DepartmentMember
+	for _, id := range departmentIDs {
+		out = append(out, f.members[id]...)
+	}
+	return out, nil
+}
+
+func (f *fakeDepartmentRepo) UpsertMembers(_ context.Context, departmentID int64, members []models.DepartmentMember) error {
+	f.members[departmentID] = append(f.members[departmentID], members...)
+	return nil
+}
+
+func (f *fakeDepartmentRepo) RemoveMembers(_ context.Context, departmentID int64, emails []string) error {
+	f.members[departmentID] = slices.DeleteFunc(f.members[departmentID], func(m models.DepartmentMember) bool {
+		return slices.Contains(emails, m.Email)
+	})
+	return nil
+}
+
+func (f *fakeDepartmentRepo) SetDocumentDepartment(_ context.Context, docID string, departmentID *int64) error {
+	f.documents[docID] = departmentID
+	return nil
+}
+
+func (f *fakeDepartmentRepo) ListDocuments(_ context.Context, departmentIDs []int64) ([]*models.DepartmentDocument, error) {
+	var out []*models.DepartmentDocument
+	for docID, id := range f.documents {
+		if id != nil && slices.Contains(departmentIDs, *id) {
+			out = append(out, &models.DepartmentDocument{DocID: docID, DepartmentID: *id, ExpectedCount: 4, SignedCount: 1})
+		}
+	}
+	return out, nil
+}
+
+func newTestDepartmentService() (*DepartmentService, *fakeDepartmentRepo, *fakeCampaignSignerRepo) {
+	repo := newFakeDepartmentRepo()
+	docs := &fakeCampaignDocRepo{docs: map[string]*models.Document{"doc1": {DocID: "doc1"}}}
+	signers := &fakeCampaignSignerRepo{added: make(map[string][]models.ContactInfo)}
+	return NewDepartmentService(repo, docs, signers), repo, signers
+}
+
+func TestDepartmentService_CreateAndMove(t *testing.T) {
+	svc, _, _ := newTestDepartmentService()
+	ctx := context.Background()
+
+	sales, err := svc.CreateDepartment(ctx, models.DepartmentInput{Name: " Sales "})
+	if err != nil || sales.Name != "Sales" {
+		t.Fatalf("unexpected department %+v, %v", sales, err)
+	}
+	emea, err := svc.CreateDepartment(ctx, models.DepartmentInput{Name: "EMEA", ParentID: &sales.ID})
+	if err != nil {
+		t.Fatalf("create sub-department err: %v", err)
+	}
+
+	unknown := int64(42)
+	if _, err := svc.CreateDepartment(ctx, models.DepartmentInput{Name: "APAC", ParentID: &unknown}); !errors.Is(err, ErrInvalidDepartment) {
+		t.Errorf("expected ErrInvalidDepartment for an unknown parent, got %v", err)
+	}
+	if _, err := svc.CreateDepartment(ctx, models.DepartmentInput{Name: "  "}); !errors.Is(err, ErrInvalidDepartment) {
+		t.Errorf("expected ErrInvalidDepartment for an empty name, got %v", err)
+	}
+	if _, err := svc.UpdateDepartment(ctx, sales.ID, models.DepartmentInput{Name: "Sales", ParentID: &emea.ID}); !errors.Is(err, ErrInvalidDepartment) {
+		t.Errorf("expected moving a department under its own child to fail, got %v", err)
+	}
+}
+
+func TestDepartmentService_StatsAndAddToDocument(t *testing.T) {
+	svc, repo, signers := newTestDepartmentService()
+	ctx := context.Background()
+
+	sales, _ := svc.CreateDepartment(ctx, models.DepartmentInput{Name: "Sales"})
+	emea, _ := svc.CreateDepartment(ctx, models.DepartmentInput{Name: "EMEA", ParentID: &sales.ID})
+
+	if err := svc.AddMembers(ctx, sales.ID, []models.DepartmentMember{{Email: " Alice@Example.com "}}, "admin@example.com"); err != nil {
+		t.Fatalf("add members err: %v", err)
+	}
+	if err := svc.AddMembers(ctx, emea.ID, []models.DepartmentMember{{Email: "bob@example.com", Name: "Bob"}}, "admin@example.com"); err != nil {
+		t.Fatalf("add members err: %v", err)
+	}
+	if err := svc.AddMembers(ctx, emea.ID, []models.DepartmentMember{{Email: "not-an-email"}}, "admin@example.com"); !errors.Is(err, ErrInvalidDepartment) {
+		t.Errorf("expected ErrInvalidDepartment for an invalid email, got %v", err)
+	}
+	if repo.members[sales.ID][0].Email != "alice@example.com" {
+		t.Errorf("expected normalized email, got %+v", repo.members[sales.ID])
+	}
+
+	if err := svc.SetDocumentDepartment(ctx, "doc1", &emea.ID); err != nil {
+		t.Fatalf("set document department err: %v", err)
+	}
+	stats, err := svc.Stats(ctx, sales.ID)
+	if err != nil {
+		t.Fatalf("stats err: %v", err)
+	}
+	if stats.Departments != 2 || stats.Documents != 1 || stats.Members != 2 || stats.PendingCount != 3 || stats.CompletionRate != 25 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	added, err := svc.AddToDocument(ctx, "doc1", sales.ID, "admin@example.com")
+	if err != nil || added != 2 || len(signers.added["doc1"]) != 2 {
+		t.Fatalf("expected both subtree members added, got %d, %v", added, err)
+	}
+	if _, err := svc.AddToDocument(ctx, "missing", sales.ID, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// directoryGrantLifetime bounds the time an admin has to consent once the authorization started
+const directoryGrantLifetime = 10 * time.Minute
+
+// directoryCredentialRepository stores the delegated access to directories
+type directoryCredentialRepository interface {
+	Get(ctx context.Context, provider string) (*models.DirectoryCredential, error)
+	List(ctx context.Context) ([]*models.DirectoryCredential, error)
+	StartAuthorization(ctx context.Context, provider, state string, expiresAt time.Time) error
+	ConsumeAuthorization(ctx context.Context, state string) (string, error)
+	SaveGrant(ctx context.Context, provider string, refreshToken []byte, tokenExpiresAt *time.Time, connectedBy string) error
+	RecordRefresh(ctx context.Context, provider string, refreshToken []byte, tokenExpiresAt *time.Time) error
+	RecordFailure(ctx context.Context, provider string, status models.DirectoryCredentialStatus, message string) error
+	Delete(ctx context.Context, provider string) error
+}
+
+// directoryOAuth runs the consent and the token refreshes of directories with delegated access.
+// Refreshes rejected by the provider return models.ErrDirectoryTokenRevoked.
+type directoryOAuth interface {
+	Providers() []string
+	AuthCodeURL(provider, state string) (string, error)
+	Exchange(ctx context.Context, provider, code string) (*models.DirectoryToken, error)
+	Refresh(ctx context.Context, provider, refreshToken string) (*models.DirectoryToken, error)
+}
+
+// secretCipher encrypts the refresh tokens with the settings encryption key
+type secretCipher interface {
+	EncryptSecret(plaintext string) ([]byte, error)
+	DecryptSecret(ciphertext []byte) (string, error)
+}
+
+// DirectoryCredentialService keeps the delegated access to directories: it stores the refresh
+// token an admin grants, exchanges it for access tokens ahead of their expiry, and records the
+// refresh tokens the provider revokes.
+type DirectoryCredentialService struct {
+	repo   directoryCredentialRepository
+	oauth  directoryOAuth
+	cipher secretCipher
+	skew   time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]models.DirectoryToken // Access tokens by provider
+}
+
+// NewDirectoryCredentialService creates a credential service refreshing access tokens skew before
+// they expire
+func NewDirectoryCredentialService(repo directoryCredentialRepository, oauth directoryOAuth, cipher secretCipher, skew time.Duration) *DirectoryCredentialService {
+	return &DirectoryCredentialService{
+		repo:   repo,
+		oauth:  oauth,
+		cipher: cipher,
+		skew:   skew,
+		now:    time.Now,
+		tokens: make(map[string]models.DirectoryToken),
+	}
+}
+
+// Providers returns the directory providers read with delegated access
+func (s *DirectoryCredentialService) Providers() []string {
+	return s.oauth.Providers()
+}
+
+// List returns the state of the delegated access of each provider, disconnected ones included
+func (s *DirectoryCredentialService) List(ctx context.Context) ([]*models.DirectoryCredential, error) {
+	stored, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byProvider := make(map[string]*models.DirectoryCredential, len(stored))
+	for _, c := range stored {
+		byProvider[c.Provider] = c
+	}
+
+	providers := s.oauth.Providers()
+	credentials := make([]*models.DirectoryCredential, 0, len(providers))
+	for _, provider := range providers {
+		c, ok := byProvider[provider]
+		if !ok {
+			c = &models.DirectoryCredential{Provider: provider, Status: models.DirectoryCredentialDisconnected}
+		}
+		credentials = append(credentials, c)
+	}
+	return credentials, nil
+}
+
+// StartAuthorization returns the URL of the consent page of the provider. The grant completes
+// when the provider redirects the admin to the callback with the state of the URL.
+func (s *DirectoryCredentialService) StartAuthorization(ctx context.Context, provider string) (string, error) {
+	if !s.delegated(provider) {
+		return "", models.ErrDirectoryNotDelegated
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate directory authorization state: %w", err)
+	}
+	state := base64.RawURLEncoding.EncodeToString(buf)
+
+	if err := s.repo.StartAuthorization(ctx, provider, state, s.now().Add(directoryGrantLifetime)); err != nil {
+		return "", err
+	}
+	return s.oauth.AuthCodeURL(provider, state)
+}
+
+// CompleteAuthorization exchanges the code of the consent callback and stores the refresh token
+func (s *DirectoryCredentialService) CompleteAuthorization(ctx context.Context, state, code, connectedBy string) (*models.DirectoryCredential, error) {
+	if state == "" || code == "" {
+		return nil, models.ErrInvalidDirectoryGrant
+	}
+	provider, err := s.repo.ConsumeAuthorization(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.oauth.Exchange(ctx, provider, code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidDirectoryGrant, err)
+	}
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("%w: the provider granted no offline access", models.ErrInvalidDirectoryGrant)
+	}
+	encrypted, err := s.cipher.EncryptSecret(token.RefreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt directory refresh token: %w", err)
+	}
+	if err := s.repo.SaveGrant(ctx, provider, encrypted, expiryOrNil(token.Expiry), connectedBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.tokens[provider] = *token
+	s.mu.Unlock()
+
+	logger.Logger.Info("Directory access granted", "provider", provider, "connected_by", connectedBy)
+	return s.repo.Get(ctx, provider)
+}
+
+// Refresh exchanges the refresh token of a provider now and returns the resulting state; a
+// revoked or failing refresh is reported in the state rather than as an error
+func (s *DirectoryCredentialService) Refresh(ctx context.Context, provider string) (*models.DirectoryCredential, error) {
+	if !s.delegated(provider) {
+		return nil, models.ErrDirectoryNotDelegated
+	}
+	s.forget(provider)
+	if _, err := s.AccessToken(ctx, provider); errors.Is(err, models.ErrDirectoryNotConnected) {
+		return nil, err
+	}
+	return s.repo.Get(ctx, provider)
+}
+
+// RefreshAll refreshes the tokens of every connected provider, so that revocations are detected
+// even without groups to sync and refresh tokens expiring when unused stay alive
+func (s *DirectoryCredentialService) RefreshAll(ctx context.Context) {
+	credentials, err := s.repo.List(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to list directory credentials", "error", err.Error())
+		return
+	}
+	for _, c := range credentials {
+		if c.Status != models.DirectoryCredentialActive && c.Status != models.DirectoryCredentialError {
+			continue
+		}
+		if !s.delegated(c.Provider) {
+			continue
+		}
+		s.forget(c.Provider)
+		if _, err := s.AccessToken(ctx, c.Provider); err != nil {
+			logger.Logger.Warn("Directory token refresh failed", "provider", c.Provider, "error", err.Error())
+		}
+	}
+}
+
+// Disconnect deletes the refresh token of a provider; groups synced from it fail until an admin
+// grants access again
+func (s *DirectoryCredentialService) Disconnect(ctx context.Context, provider string) error {
+	if !s.delegated(provider) {
+		return models.ErrDirectoryNotDelegated
+	}
+	s.forget(provider)
+	return s.repo.Delete(ctx, provider)
+}
+
+// AccessToken returns a valid access token of a provider. The cached token is refreshed once it
+// expires within the configured skew.
+func (s *DirectoryCredentialService) AccessToken(ctx context.Context, provider string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token, ok := s.tokens[provider]; ok && s.valid(token) {
+		return token.AccessToken, nil
+	}
+	delete(s.tokens, provider)
+
+	credential, err := s.repo.Get(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case credential.Status == models.DirectoryCredentialRevoked:
+		return "", models.ErrDirectoryTokenRevoked
+	case len(credential.RefreshToken) == 0:
+		return "", models.ErrDirectoryNotConnected
+	}
+
+	refreshToken, err := s.cipher.DecryptSecret(credential.RefreshToken)
+	if err != nil {
+		s.recordFailure(ctx, provider, models.DirectoryCredentialError, "refresh token cannot be decrypted with the settings encryption key")
+		return "", fmt.Errorf("failed to decrypt directory refresh token: %w", err)
+	}
+
+	token, err := s.oauth.Refresh(ctx, provider, refreshToken)
+	if errors.Is(err, models.ErrDirectoryTokenRevoked) {
+		s.recordFailure(ctx, provider, models.DirectoryCredentialRevoked, err.Error())
+		logger.Logger.Warn("Directory access revoked", "provider", provider, "error", err.Error())
+		return "", err
+	}
+	if err != nil {
+		s.recordFailure(ctx, provider, models.DirectoryCredentialError, err.Error())
+		return "", fmt.Errorf("failed to refresh directory token: %w", err)
+	}
+
+	// Providers rotating refresh tokens return a new one; re-encrypting it also moves it to the
+	// current settings key
+	if token.RefreshToken != "" {
+		refreshToken = token.RefreshToken
+	}
+	encrypted, err := s.cipher.EncryptSecret(refreshToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt directory refresh token: %w", err)
+	}
+	if err := s.repo.RecordRefresh(ctx, provider, encrypted, expiryOrNil(token.Expiry)); err != nil {
+		return "", err
+	}
+
+	s.tokens[provider] = *token
+	return token.AccessToken, nil
+}
+
+// InvalidateAccessToken drops the cached access token of a provider after the directory refused
+// it, so that the next request refreshes it
+func (s *DirectoryCredentialService) InvalidateAccessToken(provider string) {
+	s.forget(provider)
+}
+
+// valid reports whether a token stays valid beyond the skew; tokens without expiry are kept
+func (s *DirectoryCredentialService) valid(token models.DirectoryToken) bool {
+	return token.AccessToken != "" && (token.Expiry.IsZero() || s.now().Add(s.skew).Before(token.Expiry))
+}
+
+func (s *DirectoryCredentialService) forget(provider string) {
+	s.mu.Lock()
+	delete(s.tokens, provider)
+	s.mu.Unlock()
+}
+
+func (s *DirectoryCredentialService) delegated(provider string) bool {
+	for _, p := range s.oauth.Providers() {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *DirectoryCredentialService) recordFailure(ctx context.Context, provider string, status models.DirectoryCredentialStatus, message string) {
+	if err := s.repo.RecordFailure(ctx, provider, status, message); err != nil {
+		logger.Logger.Error("Failed to record directory token failure", "provider", provider, "error", err.Error())
+	}
+}
+
+func expiryOrNil(expiry time.Time) *time.Time {
+	if expiry.IsZero() {
+		return nil
+	}
+	return &expiry
+}
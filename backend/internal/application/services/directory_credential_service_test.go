@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeDirectoryCredentialRepo struct {
+	credentials map[string]*models.DirectoryCredential
+	states      map[string]string
+}
+
+func newFakeDirectoryCredentialRepo() *fakeDirectoryCredentialRepo {
+	return &fakeDirectoryCredentialRepo{
+		credentials: make(map[string]*models.DirectoryCredential),
+		states:      make(map[string]string),
+	}
+}
+
+func (f *fakeDirectoryCredentialRepo) Get(_ context.Context, provider string) (*models.DirectoryCredential, error) {
+	c, ok := f.credentials[provider]
+	if !ok {
+		return nil, models.ErrDirectoryNotConnected
+	}
+	copied := *c
+	return &copied, nil
+}
+
+func (f *fakeDirectoryCredentialRepo) List(_ context.Context) ([]*models.DirectoryCredential, error) {
+	out := []*models.DirectoryCredential{}
+	for _, c := range f.credentials {
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (f *fakeDirectoryCredentialRepo) StartAuthorization(_ context.Context, provider, state string, _ time.Time) error {
+	if _, ok := f.credentials[provider]; !ok {
+		f.credentials[provider] = &models.DirectoryCredential{Provider: provider, Status: models.DirectoryCredentialPending}
+	}
+	f.states[state] = provider
+	return nil
+}
+
+func (f *fakeDirectoryCredentialRepo) ConsumeAuthorization(_ context.Context, state string) (string, error) {
+	provider, ok := f.states[state]
+	if !ok {
+		return "", models.ErrInvalidDirectoryGrant
+	}
+	delete(f.states, state)
+	return provider, nil
+}
+
+func (f *fakeDirectoryCredentialRepo) SaveGrant(_ context.Context, provider string, refreshToken []byte, tokenExpiresAt *time.Time, connectedBy string) error {
+	c := f.credentials[provider]
+	c.Status, c.RefreshToken, c.TokenExpiresAt, c.ConnectedBy, c.LastError = models.DirectoryCredentialActive, refreshToken, tokenExpiresAt, connectedBy, ""
+	return nil
+}
+
+func (f *fakeDirectoryCredentialRepo) RecordRefresh(_ context.Context, provider string, refreshToken []byte, tokenExpiresAt *time.Time) error {
+	c := f.credentials[provider]
+	c.Status, c.RefreshToken, c.TokenExpiresAt, c.LastError = models.DirectoryCredentialActive, refreshToken, tokenExpiresAt, ""
+	return nil
+}
+
+func (f *fakeDirectoryCredentialRepo) RecordFailure(_ context.Context, provider string, status models.DirectoryCredentialStatus, message string) error {
+	c := f.credentials[provider]
+	c.Status, c.LastError = status, message
+	if status == models.DirectoryCredentialRevoked {
+		c.RefreshToken = nil
+	}
+	return nil
+}
+
+func (f *fakeDirectoryCredentialRepo) Delete(_ context.Context, provider string) error {
+	if _, ok := f.credentials[provider]; !ok {
+		return models.ErrDirectoryNotConnected
+	}
+	delete(f.credentials, provider)
+	return nil
+}
+
+// fakeDirectoryOAuth issues access tokens valid for an hour and revokes the refresh tokens listed
+type fakeDirectoryOAuth struct {
+	now       func() time.Time
+	refreshes int
+	revoked   map[string]bool
+	rotate    string
+	failWith  error
+}
+
+func (f *fakeDirectoryOAuth) Providers() []string { return []string{models.DirectoryProviderGoogle} }
+
+func (f *fakeDirectoryOAuth) AuthCodeURL(provider, state string) (string, error) {
+	return "https://accounts.example.com/auth?state=" + state, nil
+}
+
+func (f *fakeDirectoryOAuth) Exchange(_ context.Context, _, code string) (*models.DirectoryToken, error) {
+	if code != "good-code" {
+		return nil, errors.New("invalid code")
+	}
+	return &models.DirectoryToken{AccessToken: "access-0", RefreshToken: "refresh-1", Expiry: f.now().Add(time.Hour)}, nil
+}
+
+func (f *fakeDirectoryOAuth) Refresh(_ context.Context, _, refreshToken string) (*models.DirectoryToken, error) {
+	f.refreshes++
+	if f.failWith != nil {
+		return nil, f.failWith
+	}
+	if f.revoked[refreshToken] {
+		return nil, models.ErrDirectoryTokenRevoked
+	}
+	return &models.DirectoryToken{AccessToken: "access-" + refreshToken, RefreshToken: f.rotate, Expiry: f.now().Add(time.Hour)}, nil
+}
+
+// fakeSecretCipher marks the encrypted secrets with a prefix
+type fakeSecretCipher struct{}
+
+func (fakeSecretCipher) EncryptSecret(plaintext string) ([]byte, error) {
+	return []byte("enc:" + plaintext), nil
+}
+
+func (fakeSecretCipher) DecryptSecret(ciphertext []byte) (string, error) {
+	if !strings.HasPrefix(string(ciphertext), "enc:") {
+		return "", errors.New("undecryptable")
+	}
+	return strings.TrimPrefix(string(ciphertext), "enc:"), nil
+}
+
+func newDirectoryCredentialFixture() (*DirectoryCredentialService, *fakeDirectoryCredentialRepo, *fakeDirectoryOAuth, *time.Time) {
+	now := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	repo := newFakeDirectoryCredentialRepo()
+	oauth := &fakeDirectoryOAuth{now: clock, revoked: map[string]bool{}}
+	svc := NewDirectoryCredentialService(repo, oauth, fakeSecretCipher{}, 2*time.Minute)
+	svc.now = clock
+	return svc, repo, oauth, &now
+}
+
+func connectDirectory(t *testing.T, svc *DirectoryCredentialService) {
+	t.Helper()
+	authURL, err := svc.StartAuthorization(context.Background(), models.DirectoryProviderGoogle)
+	if err != nil {
+		t.Fatalf("StartAuthorization: %v", err)
+	}
+	state := authURL[strings.Index(authURL, "state=")+len("state="):]
+	if _, err := svc.CompleteAuthorization(context.Background(), state, "good-code", "admin@example.com"); err != nil {
+		t.Fatalf("CompleteAuthorization: %v", err)
+	}
+}
+
+func TestDirectoryCredentialService_Authorization(t *testing.T) {
+	svc, repo, _, _ := newDirectoryCredentialFixture()
+	ctx := context.Background()
+
+	list, _ := svc.List(ctx)
+	if len(list) != 1 || list[0].Status != models.DirectoryCredentialDisconnected {
+		t.Fatalf("expected a disconnected provider, got %+v", list)
+	}
+	if _, err := svc.StartAuthorization(ctx, models.DirectoryProviderMicrosoft); !errors.Is(err, models.ErrDirectoryNotDelegated) {
+		t.Fatalf("expected ErrDirectoryNotDelegated, got %v", err)
+	}
+
+	authURL, _ := svc.StartAuthorization(ctx, models.DirectoryProviderGoogle)
+	state := authURL[strings.Index(authURL, "state=")+len("state="):]
+	if _, err := svc.CompleteAuthorization(ctx, state, "bad-code", "admin@example.com"); !errors.Is(err, models.ErrInvalidDirectoryGrant) {
+		t.Fatalf("expected ErrInvalidDirectoryGrant for a refused code, got %v", err)
+	}
+	if _, err := svc.CompleteAuthorization(ctx, state, "good-code", "admin@example.com"); !errors.Is(err, models.ErrInvalidDirectoryGrant) {
+		t.Fatalf("expected a used state to be rejected, got %v", err)
+	}
+
+	connectDirectory(t, svc)
+	stored := repo.credentials[models.DirectoryProviderGoogle]
+	if stored.Status != models.DirectoryCredentialActive || string(stored.RefreshToken) != "enc:refresh-1" || stored.ConnectedBy != "admin@example.com" {
+		t.Fatalf("expected an active credential with an encrypted refresh token, got %+v", stored)
+	}
+}
+
+func TestDirectoryCredentialService_AccessTokenSkew(t *testing.T) {
+	svc, _, oauth, now := newDirectoryCredentialFixture()
+	ctx := context.Background()
+	connectDirectory(t, svc)
+
+	// The token received on consent is used until it nears its expiry
+	token, err := svc.AccessToken(ctx, models.DirectoryProviderGoogle)
+	if err != nil || token != "access-0" || oauth.refreshes != 0 {
+		t.Fatalf("expected the consent token, got %q, %v (%d refreshes)", token, err, oauth.refreshes)
+	}
+
+	*now = now.Add(57 * time.Minute)
+	if token, _ := svc.AccessToken(ctx, models.DirectoryProviderGoogle); token != "access-0" {
+		t.Fatalf("expected the token to be kept 3 minutes before expiry, got %q", token)
+	}
+
+	*now = now.Add(90 * time.Second)
+	token, err = svc.AccessToken(ctx, models.DirectoryProviderGoogle)
+	if err != nil || token != "access-refresh-1" || oauth.refreshes != 1 {
+		t.Fatalf("expected a refresh within the skew, got %q, %v (%d refreshes)", token, err, oauth.refreshes)
+	}
+
+	svc.InvalidateAccessToken(models.DirectoryProviderGoogle)
+	if _, err := svc.AccessToken(ctx, models.DirectoryProviderGoogle); err != nil || oauth.refreshes != 2 {
+		t.Fatalf("expected an invalidated token to be refreshed, got %v (%d refreshes)", err, oauth.refreshes)
+	}
+}
+
+func TestDirectoryCredentialService_RotationAndRevocation(t *testing.T) {
+	svc, repo, oauth, _ := newDirectoryCredentialFixture()
+	ctx := context.Background()
+	connectDirectory(t, svc)
+
+	oauth.rotate = "refresh-2"
+	credential, err := svc.Refresh(ctx, models.DirectoryProviderGoogle)
+	if err != nil || credential.Status != models.DirectoryCredentialActive {
+		t.Fatalf("Refresh: %+v, %v", credential, err)
+	}
+	if string(repo.credentials[models.DirectoryProviderGoogle].RefreshToken) != "enc:refresh-2" {
+		t.Fatalf("expected the rotated refresh token to be stored, got %q", repo.credentials[models.DirectoryProviderGoogle].RefreshToken)
+	}
+
+	oauth.failWith = errors.New("connection reset")
+	credential, err = svc.Refresh(ctx, models.DirectoryProviderGoogle)
+	if err != nil || credential.Status != models.DirectoryCredentialError || credential.LastError == "" {
+		t.Fatalf("expected a transient failure in the state, got %+v, %v", credential, err)
+	}
+
+	oauth.failWith = nil
+	oauth.revoked["refresh-2"] = true
+	credential, err = svc.Refresh(ctx, models.DirectoryProviderGoogle)
+	if err != nil || credential.Status != models.DirectoryCredentialRevoked || len(credential.RefreshToken) != 0 {
+		t.Fatalf("expected a revoked credential without refresh token, got %+v, %v", credential, err)
+	}
+	if _, err := svc.AccessToken(ctx, models.DirectoryProviderGoogle); !errors.Is(err, models.ErrDirectoryTokenRevoked) {
+		t.Fatalf("expected ErrDirectoryTokenRevoked, got %v", err)
+	}
+
+	// RefreshAll skips revoked credentials
+	refreshes := oauth.refreshes
+	svc.RefreshAll(ctx)
+	if oauth.refreshes != refreshes {
+		t.Errorf("expected no refresh of a revoked credential")
+	}
+
+	if err := svc.Disconnect(ctx, models.DirectoryProviderGoogle); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+	if _, err := svc.AccessToken(ctx, models.DirectoryProviderGoogle); !errors.Is(err, models.ErrDirectoryNotConnected) {
+		t.Errorf("expected ErrDirectoryNotConnected, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// docIDAttempts bounds the candidates tried before giving up on a unique ID
+	docIDAttempts = 5
+	// maxDocIDSlugLength bounds the slug part of an ID generated from a title
+	maxDocIDSlugLength = 64
+	// ulidCharset is the Crockford base32 alphabet of ULIDs, lowercased
+	ulidCharset = "0123456789abcdefghjkmnpqrstvwxyz"
+)
+
+var errAliasesNotConfigured = errors.New("document aliases not configured")
+
+// documentAliasRepository stores the alternative IDs of documents
+type documentAliasRepository interface {
+	Create(ctx context.Context, alias, docID, createdBy string) (*models.DocumentAlias, error)
+	Resolve(ctx context.Context, alias string) (string, error)
+	ListByDoc(ctx context.Context, docID string) ([]*models.DocumentAlias, error)
+	Delete(ctx context.Context, docID, alias string) error
+}
+
+// SetIDGeneration selects how the IDs of new documents are generated
+func (s *DocumentService) SetIDGeneration(cfg config.DocIDConfig) {
+	s.idConfig = cfg
+}
+
+// SetAliases enables the alternative IDs of documents. Generated IDs then never match an alias,
+// and references matching an alias lead to its document instead of creating a new one.
+func (s *DocumentService) SetAliases(aliases documentAliasRepository) {
+	s.aliases = aliases
+}
+
+// newDocID generates an ID for a new document titled title, unused by documents and aliases
+func (s *DocumentService) newDocID(ctx context.Context, title string) (string, error) {
+	now := time.Now().UTC()
+	prefix := expandDocIDPrefix(s.idConfig.Prefix, now)
+
+	var slug string
+	if s.idConfig.Strategy == config.DocIDStrategySlug {
+		slug = slugify(title)
+	}
+
+	// Slugs first try numbered variants, then random suffixes
+	attempts := docIDAttempts
+	if slug != "" {
+		attempts = 2 * docIDAttempts
+	}
+	for i := 0; i < attempts; i++ {
+		candidate := s.docIDCandidate(prefix, slug, i, now)
+		taken, err := s.docIDTaken(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		logger.Logger.Debug("Generated doc_id already exists, retrying", "doc_id", candidate, "attempt", i+1)
+	}
+	return "", fmt.Errorf("failed to generate a unique doc_id after %d attempts", attempts)
+}
+
+// docIDCandidate returns the ID tried at an attempt of newDocID
+func (s *DocumentService) docIDCandidate(prefix, slug string, attempt int, now time.Time) string {
+	switch {
+	case slug != "" && attempt == 0:
+		return prefix + slug
+	case slug != "" && attempt < docIDAttempts:
+		return prefix + slug + "-" + strconv.Itoa(attempt+1)
+	case slug != "":
+		return prefix + slug + "-" + randomDocIDSuffix()
+	case s.idConfig.Strategy == config.DocIDStrategyULID:
+		return prefix + generateULID(now)
+	default:
+		return prefix + generateDocID()
+	}
+}
+
+// docIDTaken reports whether an ID is used by a document or an alias
+func (s *DocumentService) docIDTaken(ctx context.Context, docID string) (bool, error) {
+	existing, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check doc_id uniqueness: %w", err)
+	}
+	if existing != nil {
+		return true, nil
+	}
+	if s.aliases == nil {
+		return false, nil
+	}
+	target, err := s.aliases.Resolve(ctx, docID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check doc_id uniqueness: %w", err)
+	}
+	return target != "", nil
+}
+
+// ResolveAlias returns the ID of the document an alias leads to, or an empty string when no
+// document has this alias
+func (s *DocumentService) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	if s.aliases == nil {
+		return "", nil
+	}
+	return s.aliases.Resolve(ctx, alias)
+}
+
+// documentByAlias returns the document an alias leads to, or nil when no document has this alias
+func (s *DocumentService) documentByAlias(ctx context.Context, alias string) (*models.Document, error) {
+	docID, err := s.ResolveAlias(ctx, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve document alias: %w", err)
+	}
+	if docID == "" {
+		return nil, nil
+	}
+	logger.Logger.Info("Document found by alias", "doc_id", docID, "alias", alias)
+	return s.repo.GetByDocID(ctx, docID)
+}
+
+// ListAliases returns the aliases of a document
+func (s *DocumentService) ListAliases(ctx context.Context, docID string) ([]*models.DocumentAlias, error) {
+	if s.aliases == nil {
+		return nil, errAliasesNotConfigured
+	}
+	return s.aliases.ListByDoc(ctx, docID)
+}
+
+// AddAlias gives a document an alternative ID; links using it are redirected to the document
+func (s *DocumentService) AddAlias(ctx context.Context, docID, alias, createdBy string) (*models.DocumentAlias, error) {
+	if s.aliases == nil {
+		return nil, errAliasesNotConfigured
+	}
+	alias, err := models.NormalizeDocumentAlias(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	created, err := s.aliases.Create(ctx, alias, docID, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Document alias added", "doc_id", docID, "alias", alias, "by", createdBy)
+	return created, nil
+}
+
+// RemoveAlias removes an alias of a document
+func (s *DocumentService) RemoveAlias(ctx context.Context, docID, alias string) error {
+	if s.aliases == nil {
+		return errAliasesNotConfigured
+	}
+	return s.aliases.Delete(ctx, docID, alias)
+}
+
+// expandDocIDPrefix replaces the date placeholders of a prefix
+func expandDocIDPrefix(prefix string, now time.Time) string {
+	if prefix == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{yyyy}", now.Format("2006"),
+		"{mm}", now.Format("01"),
+		"{dd}", now.Format("02"),
+	).Replace(prefix)
+}
+
+// slugify turns a title into lowercase ASCII words separated by dashes: accents are dropped and
+// other characters separate words. It returns an empty string when no letter or digit is left.
+func slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining accent of the previous letter
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		default:
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxDocIDSlugLength {
+		// Cut at a word boundary
+		cut := slug[:maxDocIDSlugLength]
+		if i := strings.LastIndexByte(cut, '-'); i > 0 && slug[maxDocIDSlugLength] != '-' {
+			cut = cut[:i]
+		}
+		slug = cut
+	}
+	return slug
+}
+
+// generateULID returns a lowercase ULID: 48 bits of milliseconds followed by 80 random bits,
+// so that IDs sort by creation time
+func generateULID(now time.Time) string {
+	var b [16]byte
+	ms := uint64(now.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+	if _, err := rand.Read(b[6:]); err != nil {
+		// crypto/rand never fails on supported platforms; keep the ID usable anyway
+		copy(b[6:], strconv.FormatInt(now.UnixNano(), 36))
+	}
+
+	n := new(big.Int).SetBytes(b[:])
+	mask := big.NewInt(31)
+	out := make([]byte, 26)
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = ulidCharset[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 5)
+	}
+	return string(out)
+}
+
+// randomDocIDSuffix returns 4 random base36 characters telling apart documents with the same slug
+func randomDocIDSuffix() string {
+	id := generateDocID()
+	return id[len(id)-4:]
+}
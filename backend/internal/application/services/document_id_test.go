@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeDocumentAliases struct {
+	aliases map[string]string // alias -> doc ID
+}
+
+func (f *fakeDocumentAliases) Create(_ context.Context, alias, docID, createdBy string) (*models.DocumentAlias, error) {
+	if _, ok := f.aliases[alias]; ok {
+		return nil, models.ErrDocumentAliasTaken
+	}
+	f.aliases[alias] = docID
+	return &models.DocumentAlias{Alias: alias, DocID: docID, CreatedBy: createdBy}, nil
+}
+
+func (f *fakeDocumentAliases) Resolve(_ context.Context, alias string) (string, error) {
+	return f.aliases[alias], nil
+}
+
+func (f *fakeDocumentAliases) ListByDoc(_ context.Context, docID string) ([]*models.DocumentAlias, error) {
+	aliases := []*models.DocumentAlias{}
+	for alias, target := range f.aliases {
+		if target == docID {
+			aliases = append(aliases, &models.DocumentAlias{Alias: alias, DocID: target})
+		}
+	}
+	return aliases, nil
+}
+
+func (f *fakeDocumentAliases) Delete(_ context.Context, docID, alias string) error {
+	if f.aliases[alias] != docID {
+		return models.ErrDocumentAliasNotFound
+	}
+	delete(f.aliases, alias)
+	return nil
+}
+
+// existingDocs returns a repository where the given IDs are taken
+func existingDocs(ids ...string) *mockDocumentRepository {
+	taken := map[string]bool{}
+	for _, id := range ids {
+		taken[id] = true
+	}
+	return &mockDocumentRepository{
+		getByDocIDFunc: func(_ context.Context, docID string) (*models.Document, error) {
+			if taken[docID] {
+				return &models.Document{DocID: docID, Title: "Existing"}, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Security Policy":                 "security-policy",
+		"  Politique de sécurité (2026) ": "politique-de-securite-2026",
+		"Règlement intérieur — Été":       "reglement-interieur-ete",
+		"???":                             "",
+		strings.Repeat("word ", 20):       "word-word-word-word-word-word-word-word-word-word-word-word-word",
+	}
+	for input, want := range tests {
+		if got := slugify(input); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestGenerateULID(t *testing.T) {
+	ulid := regexp.MustCompile(`^[0-9a-hjkmnp-tv-z]{26}$`)
+	earlier := generateULID(time.UnixMilli(1700000000000))
+	later := generateULID(time.UnixMilli(1700000000001))
+
+	if !ulid.MatchString(earlier) || !ulid.MatchString(later) {
+		t.Fatalf("expected lowercase ULIDs, got %q and %q", earlier, later)
+	}
+	if earlier >= later {
+		t.Errorf("expected ULIDs to sort by time, got %q >= %q", earlier, later)
+	}
+	if !strings.HasPrefix(earlier, "01hf") {
+		t.Errorf("expected the timestamp in the first characters, got %q", earlier)
+	}
+}
+
+func TestDocumentService_CreateDocument_IDStrategies(t *testing.T) {
+	ctx := context.Background()
+	year := time.Now().UTC().Format("2006")
+
+	t.Run("slug with prefix", func(t *testing.T) {
+		service := NewDocumentService(existingDocs(), nil, nil)
+		service.SetIDGeneration(config.DocIDConfig{Strategy: config.DocIDStrategySlug, Prefix: "hr-{yyyy}-"})
+
+		doc, err := service.CreateDocument(ctx, CreateDocumentRequest{Reference: "Politique de sécurité"})
+		if err != nil {
+			t.Fatalf("CreateDocument err: %v", err)
+		}
+		if doc.DocID != "hr-"+year+"-politique-de-securite" {
+			t.Errorf("unexpected doc_id %q", doc.DocID)
+		}
+	})
+
+	t.Run("slug taken by a document and an alias", func(t *testing.T) {
+		service := NewDocumentService(existingDocs("security-policy"), nil, nil)
+		service.SetIDGeneration(config.DocIDConfig{Strategy: config.DocIDStrategySlug})
+		service.SetAliases(&fakeDocumentAliases{aliases: map[string]string{"security-policy-2": "other"}})
+
+		doc, err := service.CreateDocument(ctx, CreateDocumentRequest{Reference: "https://example.com/policy.pdf", Title: "Security Policy"})
+		if err != nil {
+			t.Fatalf("CreateDocument err: %v", err)
+		}
+		if doc.DocID != "security-policy-3" {
+			t.Errorf("expected the next free slug, got %q", doc.DocID)
+		}
+	})
+
+	t.Run("title without letters falls back to random IDs", func(t *testing.T) {
+		service := NewDocumentService(existingDocs(), nil, nil)
+		service.SetIDGeneration(config.DocIDConfig{Strategy: config.DocIDStrategySlug})
+
+		doc, err := service.CreateDocument(ctx, CreateDocumentRequest{Reference: "???"})
+		if err != nil {
+			t.Fatalf("CreateDocument err: %v", err)
+		}
+		if len(doc.DocID) < 10 {
+			t.Errorf("expected a random doc_id, got %q", doc.DocID)
+		}
+	})
+
+	t.Run("ulid", func(t *testing.T) {
+		service := NewDocumentService(existingDocs(), nil, nil)
+		service.SetIDGeneration(config.DocIDConfig{Strategy: config.DocIDStrategyULID, Prefix: "doc-"})
+
+		doc, err := service.CreateDocument(ctx, CreateDocumentRequest{Reference: "Handbook"})
+		if err != nil {
+			t.Fatalf("CreateDocument err: %v", err)
+		}
+		if !strings.HasPrefix(doc.DocID, "doc-") || len(doc.DocID) != len("doc-")+26 {
+			t.Errorf("expected a prefixed ULID, got %q", doc.DocID)
+		}
+	})
+
+	t.Run("gives up when every candidate is taken", func(t *testing.T) {
+		repo := &mockDocumentRepository{
+			getByDocIDFunc: func(_ context.Context, docID string) (*models.Document, error) {
+				return &models.Document{DocID: docID}, nil
+			},
+		}
+		service := NewDocumentService(repo, nil, nil)
+
+		if _, err := service.CreateDocument(ctx, CreateDocumentRequest{Reference: "Handbook"}); err == nil {
+			t.Error("expected an error when no unique doc_id can be generated")
+		}
+	})
+}
+
+func TestDocumentService_FindOrCreateDocument_Alias(t *testing.T) {
+	ctx := context.Background()
+	created := false
+	repo := existingDocs("abc123")
+	repo.createFunc = func(_ context.Context, docID string, _ models.DocumentInput, _ string) (*models.Document, error) {
+		created = true
+		return &models.Document{DocID: docID}, nil
+	}
+	service := NewDocumentService(repo, nil, nil)
+	service.SetAliases(&fakeDocumentAliases{aliases: map[string]string{"security-policy": "abc123"}})
+
+	doc, isNew, err := service.FindOrCreateDocument(ctx, "security-policy", "user@example.com")
+	if err != nil {
+		t.Fatalf("FindOrCreateDocument err: %v", err)
+	}
+	if isNew || created || doc == nil || doc.DocID != "abc123" {
+		t.Errorf("expected the aliased document, got %+v (new: %v)", doc, isNew)
+	}
+}
+
+func TestDocumentService_Aliases(t *testing.T) {
+	ctx := context.Background()
+	service := NewDocumentService(existingDocs("abc123"), nil, nil)
+
+	if _, err := service.AddAlias(ctx, "abc123", "security-policy", "admin@example.com"); err == nil {
+		t.Error("expected an error without alias repository")
+	}
+	if docID, err := service.ResolveAlias(ctx, "security-policy"); err != nil || docID != "" {
+		t.Errorf("expected no alias without alias repository, got %q, %v", docID, err)
+	}
+
+	service.SetAliases(&fakeDocumentAliases{aliases: map[string]string{}})
+
+	alias, err := service.AddAlias(ctx, "abc123", " security-policy ", "admin@example.com")
+	if err != nil || alias.Alias != "security-policy" {
+		t.Fatalf("unexpected alias %+v, %v", alias, err)
+	}
+	if _, err := service.AddAlias(ctx, "abc123", "politique/2026", "admin@example.com"); !errors.Is(err, models.ErrInvalidDocumentAlias) {
+		t.Errorf("expected ErrInvalidDocumentAlias, got %v", err)
+	}
+	if _, err := service.AddAlias(ctx, "missing", "other-alias", "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+	if docID, err := service.ResolveAlias(ctx, "security-policy"); err != nil || docID != "abc123" {
+		t.Errorf("expected the alias to resolve to abc123, got %q, %v", docID, err)
+	}
+
+	aliases, err := service.ListAliases(ctx, "abc123")
+	if err != nil || len(aliases) != 1 {
+		t.Errorf("expected 1 alias, got %d, %v", len(aliases), err)
+	}
+	if err := service.RemoveAlias(ctx, "abc123", "security-policy"); err != nil {
+		t.Errorf("RemoveAlias err: %v", err)
+	}
+}
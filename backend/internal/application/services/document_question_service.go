@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// DocumentQuestionTemplate is the email template notifying a new question or reply about a document
+	DocumentQuestionTemplate = "document_question"
+	documentQuestionRefType  = "document_question"
+)
+
+// documentQuestionRepository defines question thread storage operations
+type documentQuestionRepository interface {
+	Create(ctx context.Context, docID, authorEmail, authorName, body string) (*models.DocumentQuestion, error)
+	Get(ctx context.Context, docID string, id int64) (*models.DocumentQuestion, error)
+	ListByDoc(ctx context.Context, docID string) ([]*models.DocumentQuestion, error)
+	AddReply(ctx context.Context, docID string, reply models.DocumentQuestionReply, status models.QuestionStatus) (*models.DocumentQuestionReply, error)
+	SetStatus(ctx context.Context, docID string, id int64, status models.QuestionStatus) error
+	SetHidden(ctx context.Context, docID string, id int64, hidden bool, by string) error
+	SetReplyHidden(ctx context.Context, docID string, questionID, replyID int64, hidden bool, by string) error
+}
+
+// questionDocumentRepository looks up the documents questions are asked about
+type questionDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// documentManagerChecker decides who answers and moderates the questions of a document
+type documentManagerChecker interface {
+	CanManageDocument(ctx context.Context, userEmail, docCreatedBy string) bool
+}
+
+// questionEmailQueue queues the question notifications
+type questionEmailQueue interface {
+	Enqueue(ctx context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error)
+}
+
+// DocumentQuestionService manages the question threads between signers and document owners.
+// Signers ask questions and follow up on them, the document owner and admins reply, resolve,
+// close and hide them.
+type DocumentQuestionService struct {
+	repo       documentQuestionRepository
+	docRepo    questionDocumentRepository
+	authorizer documentManagerChecker
+
+	queue   questionEmailQueue
+	i18n    translator
+	locales recipientLocaleResolver
+	baseURL string
+	locale  string
+}
+
+// NewDocumentQuestionService creates a question service; notifications are disabled until
+// SetNotifications is called
+func NewDocumentQuestionService(repo documentQuestionRepository, docRepo questionDocumentRepository, authorizer documentManagerChecker) *DocumentQuestionService {
+	return &DocumentQuestionService{repo: repo, docRepo: docRepo, authorizer: authorizer}
+}
+
+// SetNotifications enables the emails sent to the owner on new questions and to the asker on replies
+func (s *DocumentQuestionService) SetNotifications(queue questionEmailQueue, i18nService translator, baseURL, locale string) {
+	s.queue = queue
+	s.i18n = i18nService
+	s.baseURL = baseURL
+	s.locale = locale
+}
+
+// SetLocaleResolver sends notifications in the language each recipient chose
+func (s *DocumentQuestionService) SetLocaleResolver(locales recipientLocaleResolver) {
+	s.locales = locales
+}
+
+// List returns the questions of a document. Document managers see every question; other users
+// see the questions not hidden by moderation, their own hidden ones, and no email address but theirs.
+func (s *DocumentQuestionService) List(ctx context.Context, docID string, user *models.User) ([]*models.DocumentQuestion, error) {
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.repo.ListByDoc(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if s.canManage(ctx, user, doc) {
+		return questions, nil
+	}
+
+	visible := make([]*models.DocumentQuestion, 0, len(questions))
+	for _, q := range questions {
+		if q = redactQuestion(q, user); q != nil {
+			visible = append(visible, q)
+		}
+	}
+	return visible, nil
+}
+
+// Ask records a question about a document and notifies its owner
+func (s *DocumentQuestionService) Ask(ctx context.Context, docID string, user *models.User, body string) (*models.DocumentQuestion, error) {
+	body, err := models.NormalizeQuestionBody(body)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.repo.Create(ctx, docID, user.NormalizedEmail(), user.Name, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner := strings.ToLower(doc.CreatedBy); owner != "" && owner != user.NormalizedEmail() {
+		s.notify(ctx, doc, question, owner, "question", user, body)
+	}
+	return question, nil
+}
+
+// Reply adds a message to the thread of a question. A reply of a document manager marks the
+// question answered and notifies the asker; a follow-up of the asker reopens it and notifies
+// the owner.
+func (s *DocumentQuestionService) Reply(ctx context.Context, docID string, questionID int64, user *models.User, body string) (*models.DocumentQuestionReply, error) {
+	body, err := models.NormalizeQuestionBody(body)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	question, err := s.repo.Get(ctx, docID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := s.canManage(ctx, user, doc)
+	if !manager && !isQuestionAuthor(question, user) {
+		return nil, models.ErrQuestionForbidden
+	}
+	if !question.Status.AcceptsReplies() {
+		return nil, models.ErrQuestionClosed
+	}
+
+	status := models.QuestionStatusOpen
+	if manager {
+		status = models.QuestionStatusAnswered
+	}
+	reply, err := s.repo.AddReply(ctx, docID, models.DocumentQuestionReply{
+		QuestionID:  questionID,
+		AuthorEmail: user.NormalizedEmail(),
+		AuthorName:  user.Name,
+		Body:        body,
+		FromOwner:   manager,
+	}, status)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient := strings.ToLower(doc.CreatedBy)
+	if manager {
+		recipient = question.AuthorEmail
+	}
+	if recipient != "" && recipient != user.NormalizedEmail() {
+		s.notify(ctx, doc, question, recipient, "reply", user, body)
+	}
+	return reply, nil
+}
+
+// SetStatus moves a question to status. Document managers may set any status; the asker may
+// only mark a question resolved while it is still open to replies.
+func (s *DocumentQuestionService) SetStatus(ctx context.Context, docID string, questionID int64, user *models.User, status models.QuestionStatus) error {
+	if !status.IsValid() {
+		return models.ErrInvalidQuestionStatus
+	}
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return err
+	}
+	question, err := s.repo.Get(ctx, docID, questionID)
+	if err != nil {
+		return err
+	}
+
+	if !s.canManage(ctx, user, doc) {
+		if !isQuestionAuthor(question, user) || status != models.QuestionStatusResolved {
+			return models.ErrQuestionForbidden
+		}
+		if !question.Status.AcceptsReplies() {
+			return models.ErrQuestionClosed
+		}
+	}
+	return s.repo.SetStatus(ctx, docID, questionID, status)
+}
+
+// SetHidden hides a question from other signers, or shows it again. Reserved to document managers.
+func (s *DocumentQuestionService) SetHidden(ctx context.Context, docID string, questionID int64, user *models.User, hidden bool) error {
+	if err := s.requireManager(ctx, docID, user); err != nil {
+		return err
+	}
+	return s.repo.SetHidden(ctx, docID, questionID, hidden, user.NormalizedEmail())
+}
+
+// SetReplyHidden hides a reply from other signers, or shows it again. Reserved to document managers.
+func (s *DocumentQuestionService) SetReplyHidden(ctx context.Context, docID string, questionID, replyID int64, user *models.User, hidden bool) error {
+	if err := s.requireManager(ctx, docID, user); err != nil {
+		return err
+	}
+	return s.repo.SetReplyHidden(ctx, docID, questionID, replyID, hidden, user.NormalizedEmail())
+}
+
+func (s *DocumentQuestionService) document(ctx context.Context, docID string) (*models.Document, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil || doc.DeletedAt != nil {
+		return nil, models.ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+func (s *DocumentQuestionService) requireManager(ctx context.Context, docID string, user *models.User) error {
+	doc, err := s.document(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if !s.canManage(ctx, user, doc) {
+		return models.ErrQuestionForbidden
+	}
+	return nil
+}
+
+func (s *DocumentQuestionService) canManage(ctx context.Context, user *models.User, doc *models.Document) bool {
+	return user != nil && s.authorizer != nil && s.authorizer.CanManageDocument(ctx, user.Email, doc.CreatedBy)
+}
+
+// notify queues the email telling recipient about a new question or reply. Failures are logged
+// and never fail the message itself, which is already stored.
+func (s *DocumentQuestionService) notify(ctx context.Context, doc *models.Document, question *models.DocumentQuestion, recipient, kind string, author *models.User, body string) {
+	if s.queue == nil {
+		return
+	}
+
+	locale := s.locale
+	if s.locales != nil {
+		locale = s.locales.ResolveLocale(ctx, recipient, locale)
+	}
+	subject := "New question about a document"
+	if kind == "reply" {
+		subject = "New reply to a question"
+	}
+	if s.i18n != nil {
+		subject = s.i18n.T(locale, "email.question.subject."+kind)
+	}
+
+	authorName := author.Name
+	if authorName == "" {
+		authorName = author.NormalizedEmail()
+	}
+	title := doc.Title
+	if title == "" {
+		title = doc.DocID
+	}
+	refID := fmt.Sprintf("%d", question.ID)
+	refType := documentQuestionRefType
+
+	_, err := s.queue.Enqueue(ctx, models.EmailQueueInput{
+		ToAddresses: []string{recipient},
+		Subject:     subject,
+		Template:    DocumentQuestionTemplate,
+		Locale:      locale,
+		Data: map[string]interface{}{
+			"Kind":       kind,
+			"DocTitle":   title,
+			"DocID":      doc.DocID,
+			"AuthorName": authorName,
+			"Body":       body,
+			"Question":   question.Body,
+			"DocURL":     fmt.Sprintf("%s/?doc=%s", s.baseURL, url.QueryEscape(doc.DocID)),
+		},
+		Priority:      models.EmailPriorityNormal,
+		ReferenceType: &refType,
+		ReferenceID:   &refID,
+		CreatedBy:     &author.Email,
+	})
+	if err != nil {
+		logger.Logger.Warn("Failed to queue question notification",
+			"doc_id", doc.DocID,
+			"question_id", question.ID,
+			"error", err.Error())
+	}
+}
+
+func isQuestionAuthor(question *models.DocumentQuestion, user *models.User) bool {
+	return user != nil && question.AuthorEmail != "" && strings.EqualFold(question.AuthorEmail, user.Email)
+}
+
+// redactQuestion returns the view of a question for a user who does not manage the document,
+// or nil when moderation hides it from them
+func redactQuestion(q *models.DocumentQuestion, user *models.User) *models.DocumentQuestion {
+	own := isQuestionAuthor(q, user)
+	if q.HiddenAt != nil && !own {
+		return nil
+	}
+
+	view := *q
+	view.HiddenBy = ""
+	if !own {
+		view.AuthorEmail = ""
+	}
+	view.Replies = make([]*models.DocumentQuestionReply, 0, len(q.Replies))
+	for _, r := range q.Replies {
+		ownReply := user != nil && strings.EqualFold(r.AuthorEmail, user.Email)
+		if r.HiddenAt != nil && !ownReply {
+			continue
+		}
+		reply := *r
+		reply.HiddenBy = ""
+		if !ownReply {
+			reply.AuthorEmail = ""
+		}
+		view.Replies = append(view.Replies, &reply)
+	}
+	return &view
+}
@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeQuestionRepo struct {
+	questions map[int64]*models.DocumentQuestion
+	nextID    int64
+}
+
+func newFakeQuestionRepo() *fakeQuestionRepo {
+	return &fakeQuestionRepo{questions: make(map[int64]*models.DocumentQuestion)}
+}
+
+func (f *fakeQuestionRepo) Create(_ context.Context, docID, authorEmail, authorName, body string) (*models.DocumentQuestion, error) {
+	f.nextID++
+	q := &models.DocumentQuestion{ID: f.nextID, DocID: docID, AuthorEmail: authorEmail, AuthorName: authorName,
+		Body: body, Status: models.QuestionStatusOpen, Replies: []*models.DocumentQuestionReply{}}
+	f.questions[q.ID] = q
+	return q, nil
+}
+
+func (f *fakeQuestionRepo) Get(_ context.Context, docID string, id int64) (*models.DocumentQuestion, error) {
+	q, ok := f.questions[id]
+	if !ok || q.DocID != docID {
+		return nil, models.ErrQuestionNotFound
+	}
+	return q, nil
+}
+
+func (f *fakeQuestionRepo) ListByDoc(_ context.Context, docID string) ([]*models.DocumentQuestion, error) {
+	out := []*models.DocumentQuestion{}
+	for id := f.nextID; id > 0; id-- {
+		if q, ok := f.questions[id]; ok && q.DocID == docID {
+			out = append(out, q)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeQuestionRepo) AddReply(ctx context.Context, docID string, reply models.DocumentQuestionReply, status models.QuestionStatus) (*models.DocumentQuestionReply, error) {
+	q, err := f.Get(ctx, docID, reply.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !q.Status.AcceptsReplies() {
+		return nil, models.ErrQuestionClosed
+	}
+	f.nextID++
+	reply.ID = f.nextID
+	q.Replies = append(q.Replies, &reply)
+	q.Status = status
+	return &reply, nil
+}
+
+func (f *fakeQuestionRepo) SetStatus(ctx context.Context, docID string, id int64, status models.QuestionStatus) error {
+	q, err := f.Get(ctx, docID, id)
+	if err != nil {
+		return err
+	}
+	q.Status = status
+	return nil
+}
+
+func (f *fakeQuestionRepo) SetHidden(ctx context.Context, docID string, id int64, hidden bool, by string) error {
+	q, err := f.Get(ctx, docID, id)
+	if err != nil {
+		return err
+	}
+	q.HiddenAt, q.HiddenBy = nil, ""
+	if hidden {
+		now := time.Now()
+		q.HiddenAt, q.HiddenBy = &now, by
+	}
+	return nil
+}
+
+func (f *fakeQuestionRepo) SetReplyHidden(ctx context.Context, docID string, questionID, replyID int64, hidden bool, by string) error {
+	q, err := f.Get(ctx, docID, questionID)
+	if err != nil {
+		return err
+	}
+	for _, r := range q.Replies {
+		if r.ID == replyID {
+			r.HiddenAt, r.HiddenBy = nil, ""
+			if hidden {
+				now := time.Now()
+				r.HiddenAt, r.HiddenBy = &now, by
+			}
+			return nil
+		}
+	}
+	return models.ErrQuestionNotFound
+}
+
+type fakeQuestionDocRepo struct {
+	docs map[string]*models.Document
+}
+
+func (f *fakeQuestionDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+// fakeOwnerAuthorizer lets admins and the document creator manage a document
+type fakeOwnerAuthorizer struct {
+	admins map[string]bool
+}
+
+func (f *fakeOwnerAuthorizer) CanManageDocument(_ context.Context, userEmail, docCreatedBy string) bool {
+	return f.admins[userEmail] || (docCreatedBy != "" && userEmail == docCreatedBy)
+}
+
+var (
+	questionOwner  = &models.User{Email: "owner@example.com", Name: "Owner"}
+	questionAsker  = &models.User{Email: "alice@example.com", Name: "Alice"}
+	questionOther  = &models.User{Email: "bob@example.com", Name: "Bob"}
+	questionAdmin  = &models.User{Email: "admin@example.com", Name: "Admin"}
+	questionDocRef = "doc-1"
+)
+
+func newQuestionFixture() (*DocumentQuestionService, *fakeQuestionRepo, *fakeCompletionQueue) {
+	repo := newFakeQuestionRepo()
+	docs := &fakeQuestionDocRepo{docs: map[string]*models.Document{
+		questionDocRef: {DocID: questionDocRef, Title: "Policy", CreatedBy: questionOwner.Email},
+	}}
+	queue := &fakeCompletionQueue{}
+	svc := NewDocumentQuestionService(repo, docs, &fakeOwnerAuthorizer{admins: map[string]bool{questionAdmin.Email: true}})
+	svc.SetNotifications(queue, fakeTranslator{}, "https://sign.example.com", "en")
+	return svc, repo, queue
+}
+
+func TestDocumentQuestionService_AskNotifiesOwner(t *testing.T) {
+	svc, _, queue := newQuestionFixture()
+
+	q, err := svc.Ask(context.Background(), questionDocRef, questionAsker, "  What does section 2 mean?  ")
+	if err != nil {
+		t.Fatalf("Ask: %v", err)
+	}
+	if q.Body != "What does section 2 mean?" || q.Status != models.QuestionStatusOpen || q.AuthorEmail != questionAsker.Email {
+		t.Fatalf("unexpected question: %+v", q)
+	}
+	if len(queue.inputs) != 1 {
+		t.Fatalf("expected one notification, got %d", len(queue.inputs))
+	}
+	input := queue.inputs[0]
+	if input.ToAddresses[0] != questionOwner.Email || input.Template != DocumentQuestionTemplate ||
+		input.Subject != "email.question.subject.question" || input.Data["DocURL"] != "https://sign.example.com/?doc=doc-1" {
+		t.Fatalf("unexpected notification: %+v", input)
+	}
+}
+
+func TestDocumentQuestionService_AskValidation(t *testing.T) {
+	svc, _, queue := newQuestionFixture()
+	ctx := context.Background()
+
+	if _, err := svc.Ask(ctx, questionDocRef, questionAsker, "   "); !errors.Is(err, models.ErrInvalidQuestion) {
+		t.Fatalf("expected ErrInvalidQuestion, got %v", err)
+	}
+	if _, err := svc.Ask(ctx, "missing", questionAsker, "Hello?"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Fatalf("expected ErrDocumentNotFound, got %v", err)
+	}
+	if _, err := svc.Ask(ctx, questionDocRef, questionOwner, "Note to self"); err != nil {
+		t.Fatalf("Ask by owner: %v", err)
+	}
+	if len(queue.inputs) != 0 {
+		t.Fatalf("expected no notification, got %d", len(queue.inputs))
+	}
+}
+
+func TestDocumentQuestionService_ReplyThread(t *testing.T) {
+	svc, repo, queue := newQuestionFixture()
+	ctx := context.Background()
+	q, _ := svc.Ask(ctx, questionDocRef, questionAsker, "Question?")
+	queue.inputs = nil
+
+	reply, err := svc.Reply(ctx, questionDocRef, q.ID, questionOwner, "Answer.")
+	if err != nil {
+		t.Fatalf("owner reply: %v", err)
+	}
+	if !reply.FromOwner || repo.questions[q.ID].Status != models.QuestionStatusAnswered {
+		t.Fatalf("expected answered question with owner reply, got %+v / %s", reply, repo.questions[q.ID].Status)
+	}
+	if len(queue.inputs) != 1 || queue.inputs[0].ToAddresses[0] != questionAsker.Email || queue.inputs[0].Data["Question"] != "Question?" {
+		t.Fatalf("expected the asker to be notified, got %+v", queue.inputs)
+	}
+
+	if _, err := svc.Reply(ctx, questionDocRef, q.ID, questionAsker, "Follow-up?"); err != nil {
+		t.Fatalf("asker follow-up: %v", err)
+	}
+	if repo.questions[q.ID].Status != models.QuestionStatusOpen {
+		t.Fatalf("expected follow-up to reopen the question, got %s", repo.questions[q.ID].Status)
+	}
+	if len(queue.inputs) != 2 || queue.inputs[1].ToAddresses[0] != questionOwner.Email {
+		t.Fatalf("expected the owner to be notified, got %+v", queue.inputs)
+	}
+
+	if _, err := svc.Reply(ctx, questionDocRef, q.ID, questionOther, "Me too"); !errors.Is(err, models.ErrQuestionForbidden) {
+		t.Fatalf("expected ErrQuestionForbidden, got %v", err)
+	}
+	if _, err := svc.Reply(ctx, questionDocRef, 99, questionOwner, "Answer"); !errors.Is(err, models.ErrQuestionNotFound) {
+		t.Fatalf("expected ErrQuestionNotFound, got %v", err)
+	}
+}
+
+func TestDocumentQuestionService_SetStatus(t *testing.T) {
+	svc, repo, _ := newQuestionFixture()
+	ctx := context.Background()
+	q, _ := svc.Ask(ctx, questionDocRef, questionAsker, "Question?")
+
+	if err := svc.SetStatus(ctx, questionDocRef, q.ID, questionAsker, models.QuestionStatusClosed); !errors.Is(err, models.ErrQuestionForbidden) {
+		t.Fatalf("expected asker close to be forbidden, got %v", err)
+	}
+	if err := svc.SetStatus(ctx, questionDocRef, q.ID, questionOther, models.QuestionStatusResolved); !errors.Is(err, models.ErrQuestionForbidden) {
+		t.Fatalf("expected other signer to be forbidden, got %v", err)
+	}
+	if err := svc.SetStatus(ctx, questionDocRef, q.ID, questionAsker, "pending"); !errors.Is(err, models.ErrInvalidQuestionStatus) {
+		t.Fatalf("expected ErrInvalidQuestionStatus, got %v", err)
+	}
+	if err := svc.SetStatus(ctx, questionDocRef, q.ID, questionAsker, models.QuestionStatusResolved); err != nil {
+		t.Fatalf("asker resolve: %v", err)
+	}
+	if _, err := svc.Reply(ctx, questionDocRef, q.ID, questionOwner, "Late answer"); !errors.Is(err, models.ErrQuestionClosed) {
+		t.Fatalf("expected ErrQuestionClosed, got %v", err)
+	}
+
+	if err := svc.SetStatus(ctx, questionDocRef, q.ID, questionAdmin, models.QuestionStatusOpen); err != nil {
+		t.Fatalf("admin reopen: %v", err)
+	}
+	if repo.questions[q.ID].Status != models.QuestionStatusOpen {
+		t.Fatalf("expected reopened question, got %s", repo.questions[q.ID].Status)
+	}
+}
+
+func TestDocumentQuestionService_Moderation(t *testing.T) {
+	svc, _, _ := newQuestionFixture()
+	ctx := context.Background()
+	visible, _ := svc.Ask(ctx, questionDocRef, questionAsker, "Visible?")
+	hidden, _ := svc.Ask(ctx, questionDocRef, questionOther, "Spam")
+	reply, _ := svc.Reply(ctx, questionDocRef, visible.ID, questionOwner, "Yes")
+
+	if err := svc.SetHidden(ctx, questionDocRef, hidden.ID, questionAsker, true); !errors.Is(err, models.ErrQuestionForbidden) {
+		t.Fatalf("expected ErrQuestionForbidden, got %v", err)
+	}
+	if err := svc.SetHidden(ctx, questionDocRef, hidden.ID, questionOwner, true); err != nil {
+		t.Fatalf("SetHidden: %v", err)
+	}
+	if err := svc.SetReplyHidden(ctx, questionDocRef, visible.ID, reply.ID, questionAdmin, true); err != nil {
+		t.Fatalf("SetReplyHidden: %v", err)
+	}
+
+	all, _ := svc.List(ctx, questionDocRef, questionOwner)
+	if len(all) != 2 || all[0].AuthorEmail == "" || all[0].HiddenBy != questionOwner.Email {
+		t.Fatalf("expected the owner to see every question with authors, got %+v", all)
+	}
+
+	asker, _ := svc.List(ctx, questionDocRef, questionAsker)
+	if len(asker) != 1 || asker[0].ID != visible.ID || asker[0].AuthorEmail != questionAsker.Email || len(asker[0].Replies) != 0 {
+		t.Fatalf("expected the asker to see their question without the hidden reply, got %+v", asker)
+	}
+
+	author, _ := svc.List(ctx, questionDocRef, questionOther)
+	if len(author) != 2 || author[0].HiddenBy != "" || author[1].AuthorEmail != "" {
+		t.Fatalf("expected the author to see their hidden question and no other email, got %+v", author)
+	}
+}
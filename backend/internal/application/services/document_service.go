@@ -4,6 +4,7 @@ package services
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -22,6 +23,7 @@ type documentRepository interface {
 	FindByReference(ctx context.Context, ref string, refType string) (*models.Document, error)
 	List(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
+	ListKeyset(ctx context.Context, searchQuery string, cursor *models.PageCursor, limit int) ([]*models.Document, error)
 	Count(ctx context.Context, searchQuery string) (int, error)
 	ListByCreatedBy(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
 	SearchByCreatedBy(ctx context.Context, createdBy, searchQuery string, limit, offset int) ([]*models.Document, error)
@@ -33,11 +35,45 @@ type docExpectedSignerRepository interface {
 	GetStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
 }
 
+// documentLinkTracker resolves the Confluence, SharePoint and Google Drive links of new documents
+type documentLinkTracker interface {
+	ResolveLink(ctx context.Context, rawURL string) (*models.DocumentLinkSource, bool)
+	TrackLink(ctx context.Context, source *models.DocumentLinkSource) error
+}
+
+// documentJobQueue enqueues the background retries of checksum computations
+type documentJobQueue interface {
+	Enqueue(ctx context.Context, input models.JobInput) (*models.Job, error)
+}
+
+// documentChecksumPatcher stores the checksum computed by a retry
+type documentChecksumPatcher interface {
+	Patch(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error)
+}
+
+const (
+	// ChecksumJobType is the job retrying the checksum of a remote document
+	ChecksumJobType = "document.checksum"
+	// checksumJobAttempts bounds the runs of a checksum job
+	checksumJobAttempts = 5
+)
+
+// ChecksumJobPayload is the payload of a document.checksum job
+type ChecksumJobPayload struct {
+	DocID string `json:"docId"`
+	URL   string `json:"url"`
+}
+
 // DocumentService handles document metadata operations and unique ID generation
 type DocumentService struct {
 	repo               documentRepository
 	expectedSignerRepo docExpectedSignerRepository
 	checksumConfig     *config.ChecksumConfig
+	links              documentLinkTracker
+	jobs               documentJobQueue
+	patcher            documentChecksumPatcher
+	idConfig           config.DocIDConfig
+	aliases            documentAliasRepository
 }
 
 // NewDocumentService initializes the document service with its repository dependency
@@ -49,6 +85,19 @@ func NewDocumentService(repo documentRepository, expectedSignerRepo docExpectedS
 	}
 }
 
+// SetLinkTracker enables the resolution of Confluence, SharePoint and Google Drive links: the page title is used
+// when no title is given, and the page is watched for changes
+func (s *DocumentService) SetLinkTracker(links documentLinkTracker) {
+	s.links = links
+}
+
+// SetChecksumRetries retries in the background the checksums of remote documents that could
+// not be computed at creation, e.g. when the server hosting the file was unreachable
+func (s *DocumentService) SetChecksumRetries(jobs documentJobQueue, patcher documentChecksumPatcher) {
+	s.jobs = jobs
+	s.patcher = patcher
+}
+
 // CreateDocumentRequest represents the request to create a document
 type CreateDocumentRequest struct {
 	Reference string `json:"reference" validate:"required,min=1"`
@@ -71,28 +120,10 @@ type CreateDocumentRequest struct {
 	OriginalFilename  string `json:"original_filename,omitempty"`
 }
 
-// CreateDocument generates a collision-resistant base36 identifier and persists document metadata
+// CreateDocument generates a unique identifier with the configured strategy and persists document metadata
 func (s *DocumentService) CreateDocument(ctx context.Context, req CreateDocumentRequest) (*models.Document, error) {
 	logger.Logger.Info("Document creation attempt", "reference", req.Reference)
 
-	var docID string
-	maxRetries := 5
-	for i := 0; i < maxRetries; i++ {
-		docID = generateDocID()
-
-		existing, err := s.repo.GetByDocID(ctx, docID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check doc_id uniqueness: %w", err)
-		}
-
-		if existing == nil {
-			break
-		}
-
-		logger.Logger.Debug("Generated doc_id already exists, retrying",
-			"doc_id", docID, "attempt", i+1)
-	}
-
 	var url, title string
 	if strings.HasPrefix(req.Reference, "http://") || strings.HasPrefix(req.Reference, "https://") {
 		url = req.Reference
@@ -111,6 +142,22 @@ func (s *DocumentService) CreateDocument(ctx context.Context, req CreateDocument
 		}
 	}
 
+	var linkSource *models.DocumentLinkSource
+	if url != "" && req.StorageKey == "" && s.links != nil {
+		if source, ok := s.links.ResolveLink(ctx, url); ok {
+			linkSource = source
+			if req.Title == "" && source.Title != "" {
+				title = source.Title
+			}
+		}
+	}
+
+	// Generated once the title is known, slugs derive from it
+	docID, err := s.newDocID(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
 	input := models.DocumentInput{
 		Title:           title,
 		URL:             url,
@@ -141,10 +188,12 @@ func (s *DocumentService) CreateDocument(ctx context.Context, req CreateDocument
 		if checksumResult != nil {
 			input.Checksum = checksumResult.ChecksumHex
 			input.ChecksumAlgorithm = checksumResult.Algorithm
+			input.ContentHash = checksumResult.ContentHashHex
 			logger.Logger.Info("Automatically computed checksum for document",
 				"doc_id", docID,
 				"checksum", checksumResult.ChecksumHex,
-				"algorithm", checksumResult.Algorithm)
+				"algorithm", checksumResult.Algorithm,
+				"has_content_hash", checksumResult.ContentHashHex != "")
 		}
 	}
 
@@ -159,6 +208,17 @@ func (s *DocumentService) CreateDocument(ctx context.Context, req CreateDocument
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
 
+	if input.Checksum == "" && req.StorageKey == "" && strings.HasPrefix(url, "https://") && s.checksumConfig != nil {
+		s.enqueueChecksumRetry(ctx, doc.DocID, url)
+	}
+
+	if linkSource != nil {
+		linkSource.DocID = doc.DocID
+		if err := s.links.TrackLink(ctx, linkSource); err != nil {
+			logger.Logger.Warn("Failed to track document link", "doc_id", doc.DocID, "error", err.Error())
+		}
+	}
+
 	logger.Logger.Info("Document created successfully",
 		"doc_id", docID,
 		"url", url,
@@ -369,7 +429,29 @@ func (s *DocumentService) computeChecksumForURL(ctx context.Context, url string)
 		return nil
 	}
 
-	opts := checksum.ComputeOptions{
+	result, err := checksum.ComputeRemoteChecksum(ctx, url, s.checksumOptions())
+	if err != nil {
+		logger.Logger.Warn("Failed to compute checksum for URL",
+			"url", url,
+			"error", err.Error())
+		return nil
+	}
+
+	return result
+}
+
+// InspectURL downloads a remote document to compute its SHA-256 and SHA-512, its content type and
+// its size, so that the metadata can be prefilled before the document is created
+func (s *DocumentService) InspectURL(ctx context.Context, url string) (*checksum.Inspection, error) {
+	return checksum.Inspect(ctx, url, s.checksumOptions())
+}
+
+// checksumOptions returns the limits applied when fetching a remote document
+func (s *DocumentService) checksumOptions() checksum.ComputeOptions {
+	if s.checksumConfig == nil {
+		return checksum.DefaultOptions()
+	}
+	return checksum.ComputeOptions{
 		MaxBytes:           s.checksumConfig.MaxBytes,
 		TimeoutMs:          s.checksumConfig.TimeoutMs,
 		MaxRedirects:       s.checksumConfig.MaxRedirects,
@@ -377,16 +459,83 @@ func (s *DocumentService) computeChecksumForURL(ctx context.Context, url string)
 		SkipSSRFCheck:      s.checksumConfig.SkipSSRFCheck,
 		InsecureSkipVerify: s.checksumConfig.InsecureSkipVerify,
 	}
+}
 
-	result, err := checksum.ComputeRemoteChecksum(ctx, url, opts)
+// enqueueChecksumRetry queues the computation of a checksum that failed at creation.
+// Errors are logged only: the document is created without checksum either way.
+func (s *DocumentService) enqueueChecksumRetry(ctx context.Context, docID, url string) {
+	if s.jobs == nil || s.patcher == nil {
+		return
+	}
+
+	job, err := s.jobs.Enqueue(ctx, models.JobInput{
+		Type:        ChecksumJobType,
+		Payload:     ChecksumJobPayload{DocID: docID, URL: url},
+		MaxAttempts: checksumJobAttempts,
+		DedupKey:    docID,
+	})
 	if err != nil {
-		logger.Logger.Warn("Failed to compute checksum for URL",
-			"url", url,
-			"error", err.Error())
+		logger.Logger.Warn("Failed to queue checksum retry", "doc_id", docID, "error", err.Error())
+		return
+	}
+	logger.Logger.Info("Checksum retry queued", "doc_id", docID, "job_id", job.ID)
+}
+
+// RunChecksumJob computes the checksum of a document queued by a failed computation at creation.
+// Nothing is done when the document was deleted, its URL changed or a checksum was set meanwhile.
+func (s *DocumentService) RunChecksumJob(ctx context.Context, payload ChecksumJobPayload) error {
+	if s.patcher == nil {
+		return errors.New("checksum retries are not configured")
+	}
+
+	doc, err := s.repo.GetByDocID(ctx, payload.DocID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil || doc.URL != payload.URL || doc.Checksum != "" {
 		return nil
 	}
 
-	return result
+	result := s.computeChecksumForURL(ctx, payload.URL)
+	if result == nil {
+		return errors.New("checksum could not be computed")
+	}
+
+	if _, err := s.patcher.Patch(ctx, payload.DocID, models.DocumentPatch{
+		Checksum:          &result.ChecksumHex,
+		ChecksumAlgorithm: &result.Algorithm,
+	}, nil); errors.Is(err, models.ErrDocumentNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to store checksum: %w", err)
+	}
+
+	logger.Logger.Info("Checksum computed on retry",
+		"doc_id", payload.DocID,
+		"checksum", result.ChecksumHex,
+		"algorithm", result.Algorithm)
+	return nil
+}
+
+// documentInputFrom returns the input keeping every field of the document
+func documentInputFrom(doc *models.Document) models.DocumentInput {
+	allowDownload, requireFullRead, verifyChecksum := doc.AllowDownload, doc.RequireFullRead, doc.VerifyChecksum
+	return models.DocumentInput{
+		Title:             doc.Title,
+		URL:               doc.URL,
+		Checksum:          doc.Checksum,
+		ChecksumAlgorithm: doc.ChecksumAlgorithm,
+		Description:       doc.Description,
+		ReadMode:          doc.ReadMode,
+		AllowDownload:     &allowDownload,
+		RequireFullRead:   &requireFullRead,
+		VerifyChecksum:    &verifyChecksum,
+		StorageKey:        doc.StorageKey,
+		StorageProvider:   doc.StorageProvider,
+		FileSize:          doc.FileSize,
+		MimeType:          doc.MimeType,
+		OriginalFilename:  doc.OriginalFilename,
+	}
 }
 
 type ReferenceType string
@@ -436,12 +585,18 @@ func (s *DocumentService) FindOrCreateDocument(ctx context.Context, ref string,
 		return doc, false, nil
 	}
 
+	// A reference matching an alias leads to its document, never to a new one
+	if refType == ReferenceTypeReference {
+		if doc, err := s.documentByAlias(ctx, ref); err != nil || doc != nil {
+			return doc, false, err
+		}
+	}
+
 	logger.Logger.Info("Document not found, creating new one", "reference", ref, "created_by", createdBy)
 
+	// URL titles are derived by CreateDocument, from the linked page when a resolver handles it
 	var title string
 	switch refType {
-	case ReferenceTypeURL:
-		title = extractTitleFromURL(ref)
 	case ReferenceTypePath:
 		title = extractTitleFromURL(ref)
 	case ReferenceTypeReference:
@@ -506,6 +661,17 @@ func (s *DocumentService) Search(ctx context.Context, query string, limit, offse
 	return s.repo.Search(ctx, query, limit, offset)
 }
 
+// ListPage returns a page of documents, newest first, optionally matching a search query
+func (s *DocumentService) ListPage(ctx context.Context, searchQuery string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Document], error) {
+	docs, err := s.repo.ListKeyset(ctx, searchQuery, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewKeysetPage(docs, cursor, limit, func(doc *models.Document) models.PageCursor {
+		return models.PageCursor{At: doc.CreatedAt, Key: doc.DocID}
+	}), nil
+}
+
 // Count returns the total number of documents matching the search query
 func (s *DocumentService) Count(ctx context.Context, searchQuery string) (int, error) {
 	return s.repo.Count(ctx, searchQuery)
@@ -338,3 +338,105 @@ func TestDocumentService_CreateDocument_PlainReferenceNoChecksum(t *testing.T) {
 		t.Errorf("Expected URL to be empty, got %q", doc.URL)
 	}
 }
+
+type fakeDocumentJobQueue struct {
+	inputs []models.JobInput
+}
+
+func (f *fakeDocumentJobQueue) Enqueue(_ context.Context, input models.JobInput) (*models.Job, error) {
+	f.inputs = append(f.inputs, input)
+	return &models.Job{ID: int64(len(f.inputs)), Type: input.Type}, nil
+}
+
+type fakeChecksumPatcher struct {
+	patches map[string]models.DocumentPatch
+}
+
+func (f *fakeChecksumPatcher) Patch(_ context.Context, docID string, patch models.DocumentPatch, _ *int) (*models.Document, error) {
+	if f.patches == nil {
+		f.patches = map[string]models.DocumentPatch{}
+	}
+	f.patches[docID] = patch
+	return &models.Document{DocID: docID}, nil
+}
+
+// Test a failed checksum computation is retried in the background
+func TestDocumentService_ChecksumRetry(t *testing.T) {
+	available := false
+	content := "Sample PDF content"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+		if r.Method == "GET" {
+			w.Write([]byte(content))
+		}
+	}))
+	defer server.Close()
+
+	docs := map[string]*models.Document{}
+	mockRepo := &mockDocumentRepository{
+		createFunc: func(_ context.Context, docID string, input models.DocumentInput, _ string) (*models.Document, error) {
+			docs[docID] = &models.Document{DocID: docID, URL: input.URL, Checksum: input.Checksum}
+			return docs[docID], nil
+		},
+		getByDocIDFunc: func(_ context.Context, docID string) (*models.Document, error) {
+			return docs[docID], nil
+		},
+	}
+	checksumConfig := &config.ChecksumConfig{
+		MaxBytes:           10 * 1024 * 1024,
+		TimeoutMs:          5000,
+		MaxRedirects:       3,
+		AllowedContentType: []string{"application/pdf"},
+		SkipSSRFCheck:      true,
+		InsecureSkipVerify: true,
+	}
+	queue := &fakeDocumentJobQueue{}
+	patcher := &fakeChecksumPatcher{}
+	service := NewDocumentService(mockRepo, &mockDocExpectedSignerRepo{}, checksumConfig)
+	service.SetChecksumRetries(queue, patcher)
+
+	ctx := context.Background()
+	doc, err := service.CreateDocument(ctx, CreateDocumentRequest{Reference: server.URL, Title: "Test Document"})
+	if err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+	if doc.Checksum != "" {
+		t.Fatalf("expected no checksum while the server is down, got %q", doc.Checksum)
+	}
+	if len(queue.inputs) != 1 {
+		t.Fatalf("expected a checksum retry to be queued, got %d", len(queue.inputs))
+	}
+	input := queue.inputs[0]
+	payload, ok := input.Payload.(ChecksumJobPayload)
+	if input.Type != ChecksumJobType || input.DedupKey != doc.DocID || !ok || payload.URL != server.URL {
+		t.Fatalf("unexpected job: %+v", input)
+	}
+
+	if err := service.RunChecksumJob(ctx, payload); err == nil {
+		t.Error("expected the job to fail while the server is down")
+	}
+
+	available = true
+	if err := service.RunChecksumJob(ctx, payload); err != nil {
+		t.Fatalf("RunChecksumJob failed: %v", err)
+	}
+	patch, ok := patcher.patches[doc.DocID]
+	if !ok || *patch.Checksum != "b3b4e8714358cc79990c5c83391172e01c3e79a1b456d7e0c570cbf59da30e23" {
+		t.Errorf("expected the checksum to be stored, got %+v", patch)
+	}
+
+	// The URL changed since: nothing to do
+	docs[doc.DocID].URL = server.URL + "/other.pdf"
+	delete(patcher.patches, doc.DocID)
+	if err := service.RunChecksumJob(ctx, payload); err != nil {
+		t.Fatalf("RunChecksumJob failed: %v", err)
+	}
+	if _, ok := patcher.patches[doc.DocID]; ok {
+		t.Error("expected no checksum stored for a changed URL")
+	}
+}
@@ -79,6 +79,10 @@ func (m *mockDocRepo) Search(_ context.Context, _ string, _, _ int) ([]*models.D
 	return []*models.Document{}, nil
 }
 
+func (m *mockDocRepo) ListKeyset(_ context.Context, _ string, _ *models.PageCursor, _ int) ([]*models.Document, error) {
+	return []*models.Document{}, nil
+}
+
 func (m *mockDocRepo) Count(_ context.Context, _ string) (int, error) {
 	return len(m.documents), nil
 }
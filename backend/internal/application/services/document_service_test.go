@@ -397,6 +397,10 @@ func (m *mockDocumentRepository) Search(_ context.Context, _ string, _, _ int) (
 	return []*models.Document{}, nil
 }
 
+func (m *mockDocumentRepository) ListKeyset(_ context.Context, _ string, _ *models.PageCursor, _ int) ([]*models.Document, error) {
+	return []*models.Document{}, nil
+}
+
 func (m *mockDocumentRepository) Count(_ context.Context, _ string) (int, error) {
 	return 0, nil
 }
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// emailDeliveryRetention is how long sends stay in the deliverability report
+const emailDeliveryRetention = 30 * 24 * time.Hour
+
+// emailDeliveryRepository stores the outcome of each send and aggregates them
+type emailDeliveryRepository interface {
+	Record(ctx context.Context, delivery *models.EmailDelivery) error
+	ListDomains(ctx context.Context, since time.Time, limit int) ([]*models.EmailDomainDeliverability, error)
+	ListCodes(ctx context.Context, since time.Time) ([]*models.EmailResponseCodeCount, error)
+	ListFailures(ctx context.Context, since time.Time, limit int) ([]*models.EmailDelivery, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EmailDeliverabilityService records the reply code, latency and Message-ID of each send to the
+// SMTP servers and reports the success rate per recipient domain, to diagnose systematic failures
+type EmailDeliverabilityService struct {
+	repo emailDeliveryRepository
+	now  func() time.Time
+}
+
+// NewEmailDeliverabilityService creates a new email deliverability service
+func NewEmailDeliverabilityService(repo emailDeliveryRepository) *EmailDeliverabilityService {
+	return &EmailDeliverabilityService{repo: repo, now: time.Now}
+}
+
+// RecordDelivery stores the outcome of a send. A failure to store it does not fail the send.
+func (s *EmailDeliverabilityService) RecordDelivery(ctx context.Context, delivery *models.EmailDelivery) {
+	logger.Logger.Debug("Email delivery",
+		"message_id", delivery.MessageID,
+		"status", delivery.Status,
+		"smtp_code", delivery.SMTPCode,
+		"latency_ms", delivery.LatencyMs,
+		"domains", delivery.RecipientDomains)
+	if err := s.repo.Record(ctx, delivery); err != nil {
+		logger.Logger.Warn("Failed to record email delivery", "message_id", delivery.MessageID, "error", err.Error())
+	}
+}
+
+// ListDomains returns the success rate of the sends of the last period per recipient domain,
+// the domains with the most failures first
+func (s *EmailDeliverabilityService) ListDomains(ctx context.Context, period time.Duration, limit int) ([]*models.EmailDomainDeliverability, error) {
+	return s.repo.ListDomains(ctx, s.now().Add(-period), limit)
+}
+
+// ListCodes counts the sends of the last period per SMTP reply code
+func (s *EmailDeliverabilityService) ListCodes(ctx context.Context, period time.Duration) ([]*models.EmailResponseCodeCount, error) {
+	return s.repo.ListCodes(ctx, s.now().Add(-period))
+}
+
+// ListFailures returns the failed sends of the last period, newest first
+func (s *EmailDeliverabilityService) ListFailures(ctx context.Context, period time.Duration, limit int) ([]*models.EmailDelivery, error) {
+	return s.repo.ListFailures(ctx, s.now().Add(-period), limit)
+}
+
+// CleanupEvents removes the sends past the retention period
+func (s *EmailDeliverabilityService) CleanupEvents(ctx context.Context) (int64, error) {
+	return s.repo.DeleteBefore(ctx, s.now().Add(-emailDeliveryRetention))
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeEmailDeliveryRepo struct {
+	recorded []*models.EmailDelivery
+	err      error
+	since    time.Time
+	before   time.Time
+}
+
+func (f *fakeEmailDeliveryRepo) Record(_ context.Context, delivery *models.EmailDelivery) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.recorded = append(f.recorded, delivery)
+	return nil
+}
+
+func (f *fakeEmailDeliveryRepo) ListDomains(_ context.Context, since time.Time, _ int) ([]*models.EmailDomainDeliverability, error) {
+	f.since = since
+	return nil, nil
+}
+
+func (f *fakeEmailDeliveryRepo) ListCodes(_ context.Context, since time.Time) ([]*models.EmailResponseCodeCount, error) {
+	f.since = since
+	return nil, nil
+}
+
+func (f *fakeEmailDeliveryRepo) ListFailures(_ context.Context, since time.Time, _ int) ([]*models.EmailDelivery, error) {
+	f.since = since
+	return nil, nil
+}
+
+func (f *fakeEmailDeliveryRepo) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	f.before = before
+	return 0, nil
+}
+
+func TestEmailDeliverabilityService(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	repo := &fakeEmailDeliveryRepo{}
+	svc := NewEmailDeliverabilityService(repo)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	svc.RecordDelivery(ctx, &models.EmailDelivery{MessageID: "1@example.com", Status: models.EmailDeliverySent})
+	repo.err = errors.New("database down")
+	svc.RecordDelivery(ctx, &models.EmailDelivery{MessageID: "2@example.com", Status: models.EmailDeliveryFailed})
+	if len(repo.recorded) != 1 {
+		t.Fatalf("expected one recorded delivery, got %d", len(repo.recorded))
+	}
+
+	if _, err := svc.ListDomains(ctx, 24*time.Hour, 10); err != nil || !repo.since.Equal(now.Add(-24*time.Hour)) {
+		t.Fatalf("unexpected period start %v (err %v)", repo.since, err)
+	}
+	if _, err := svc.CleanupEvents(ctx); err != nil || !repo.before.Equal(now.Add(-emailDeliveryRetention)) {
+		t.Fatalf("unexpected cleanup cutoff %v (err %v)", repo.before, err)
+	}
+}
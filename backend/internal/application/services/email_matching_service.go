@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidEmailAlias is returned when an email alias fails validation
+var ErrInvalidEmailAlias = errors.New("invalid email alias")
+
+// emailMatchingRepository defines email alias and matching rule storage operations
+type emailMatchingRepository interface {
+	ListAliases(ctx context.Context) ([]*models.EmailAlias, error)
+	GetAlias(ctx context.Context, alias string) (*models.EmailAlias, error)
+	HasAliases(ctx context.Context, email string) (bool, error)
+	CreateAlias(ctx context.Context, email, alias, createdBy string) (*models.EmailAlias, error)
+	DeleteAlias(ctx context.Context, id int64) error
+	GetRules(ctx context.Context) (*models.EmailMatchingRules, error)
+	UpsertRules(ctx context.Context, rules models.EmailMatchingRules, updatedBy string) (*models.EmailMatchingRules, error)
+}
+
+// EmailMatchingService manages how signatures are matched to expected signers:
+// per-user alternate addresses and the normalization rules of the tenant
+type EmailMatchingService struct {
+	repo emailMatchingRepository
+}
+
+// NewEmailMatchingService creates a new email matching service
+func NewEmailMatchingService(repo emailMatchingRepository) *EmailMatchingService {
+	return &EmailMatchingService{repo: repo}
+}
+
+// ListAliases returns all email aliases
+func (s *EmailMatchingService) ListAliases(ctx context.Context) ([]*models.EmailAlias, error) {
+	return s.repo.ListAliases(ctx)
+}
+
+// AddAlias records alias as an alternate address of email. Aliases do not chain:
+// an alias cannot itself have aliases, and a primary email cannot be an alias.
+func (s *EmailMatchingService) AddAlias(ctx context.Context, email, alias, createdBy string) (*models.EmailAlias, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	alias = strings.ToLower(strings.TrimSpace(alias))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, fmt.Errorf("%w: invalid email", ErrInvalidEmailAlias)
+	}
+	if _, err := mail.ParseAddress(alias); err != nil {
+		return nil, fmt.Errorf("%w: invalid alias", ErrInvalidEmailAlias)
+	}
+	if email == alias {
+		return nil, fmt.Errorf("%w: alias must differ from the email", ErrInvalidEmailAlias)
+	}
+
+	existing, err := s.repo.GetAlias(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s is an alias of %s", ErrInvalidEmailAlias, email, existing.Email)
+	}
+	hasAliases, err := s.repo.HasAliases(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if hasAliases {
+		return nil, fmt.Errorf("%w: %s already has aliases", ErrInvalidEmailAlias, alias)
+	}
+
+	logger.Logger.Info("Adding email alias", "email", email, "alias", alias, "created_by", createdBy)
+	return s.repo.CreateAlias(ctx, email, alias, createdBy)
+}
+
+// RemoveAlias deletes an email alias
+func (s *EmailMatchingService) RemoveAlias(ctx context.Context, id int64, removedBy string) error {
+	logger.Logger.Info("Removing email alias", "id", id, "removed_by", removedBy)
+	return s.repo.DeleteAlias(ctx, id)
+}
+
+// GetRules returns the matching rules of the tenant, or the defaults when none are configured
+func (s *EmailMatchingService) GetRules(ctx context.Context) (*models.EmailMatchingRules, error) {
+	rules, err := s.repo.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		return models.DefaultEmailMatchingRules(), nil
+	}
+	return rules, nil
+}
+
+// UpdateRules replaces the matching rules of the tenant
+func (s *EmailMatchingService) UpdateRules(ctx context.Context, rules models.EmailMatchingRules, updatedBy string) (*models.EmailMatchingRules, error) {
+	logger.Logger.Info("Updating email matching rules",
+		"ignore_case", rules.IgnoreCase,
+		"strip_plus_tag", rules.StripPlusTag,
+		"ignore_gmail_dots", rules.IgnoreGmailDots,
+		"updated_by", updatedBy)
+	return s.repo.UpsertRules(ctx, rules, updatedBy)
+}
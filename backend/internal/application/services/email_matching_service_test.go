@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeEmailMatchingRepo struct {
+	aliases map[string]*models.EmailAlias
+	rules   *models.EmailMatchingRules
+	nextID  int64
+}
+
+func newFakeEmailMatchingRepo() *fakeEmailMatchingRepo {
+	return &fakeEmailMatchingRepo{aliases: make(map[string]*models.EmailAlias)}
+}
+
+func (f *fakeEmailMatchingRepo) ListAliases(_ context.Context) ([]*models.EmailAlias, error) {
+	out := make([]*models.EmailAlias, 0, len(f.aliases))
+	for _, alias := range f.aliases {
+		out = append(out, alias)
+	}
+	return out, nil
+}
+
+func (f *fakeEmailMatchingRepo) GetAlias(_ context.Context, alias string) (*models.EmailAlias, error) {
+	return f.aliases[alias], nil
+}
+
+func (f *fakeEmailMatchingRepo) HasAliases(_ context.Context, email string) (bool, error) {
+	for _, alias := range f.aliases {
+		if alias.Email == email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeEmailMatchingRepo) CreateAlias(_ context.Context, email, alias, createdBy string) (*models.EmailAlias, error) {
+	if _, ok := f.aliases[alias]; ok {
+		return nil, models.ErrEmailAliasExists
+	}
+	f.nextID++
+	entry := &models.EmailAlias{ID: f.nextID, Email: email, Alias: alias, CreatedBy: createdBy}
+	f.aliases[alias] = entry
+	return entry, nil
+}
+
+func (f *fakeEmailMatchingRepo) DeleteAlias(_ context.Context, id int64) error {
+	for key, alias := range f.aliases {
+		if alias.ID == id {
+			delete(f.aliases, key)
+			return nil
+		}
+	}
+	return models.ErrEmailAliasNotFound
+}
+
+func (f *fakeEmailMatchingRepo) GetRules(_ context.Context) (*models.EmailMatchingRules, error) {
+	return f.rules, nil
+}
+
+func (f *fakeEmailMatchingRepo) UpsertRules(_ context.Context, rules models.EmailMatchingRules, updatedBy string) (*models.EmailMatchingRules, error) {
+	rules.UpdatedBy = updatedBy
+	f.rules = &rules
+	return f.rules, nil
+}
+
+func TestEmailMatchingService_AddAlias(t *testing.T) {
+	repo := newFakeEmailMatchingRepo()
+	svc := NewEmailMatchingService(repo)
+	ctx := context.Background()
+
+	if _, err := svc.AddAlias(ctx, "f.lastname@corp.com", "firstname.lastname@corp.com", "admin@corp.com"); err != nil {
+		t.Fatalf("AddAlias error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		email   string
+		alias   string
+		wantErr error
+	}{
+		{name: "normalized", email: " J.Doe@Corp.com ", alias: "John.Doe@corp.com"},
+		{name: "invalid email", email: "not-an-email", alias: "jane@corp.com", wantErr: ErrInvalidEmailAlias},
+		{name: "invalid alias", email: "jane@corp.com", alias: "", wantErr: ErrInvalidEmailAlias},
+		{name: "alias of itself", email: "jane@corp.com", alias: "JANE@corp.com", wantErr: ErrInvalidEmailAlias},
+		{name: "primary is an alias", email: "firstname.lastname@corp.com", alias: "fl@corp.com", wantErr: ErrInvalidEmailAlias},
+		{name: "alias is a primary", email: "other@corp.com", alias: "f.lastname@corp.com", wantErr: ErrInvalidEmailAlias},
+		{name: "duplicate alias", email: "other@corp.com", alias: "firstname.lastname@corp.com", wantErr: models.ErrEmailAliasExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AddAlias(ctx, tt.email, tt.alias, "admin@corp.com")
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if entry := repo.aliases["john.doe@corp.com"]; entry == nil || entry.Email != "j.doe@corp.com" {
+		t.Errorf("expected normalized alias to be stored, got %+v", entry)
+	}
+}
+
+func TestEmailMatchingService_GetRules(t *testing.T) {
+	repo := newFakeEmailMatchingRepo()
+	svc := NewEmailMatchingService(repo)
+	ctx := context.Background()
+
+	rules, err := svc.GetRules(ctx)
+	if err != nil {
+		t.Fatalf("GetRules error: %v", err)
+	}
+	if !rules.IgnoreCase || rules.StripPlusTag || rules.IgnoreGmailDots {
+		t.Errorf("expected default rules, got %+v", rules)
+	}
+
+	if _, err := svc.UpdateRules(ctx, models.EmailMatchingRules{IgnoreCase: true, StripPlusTag: true}, "admin@corp.com"); err != nil {
+		t.Fatalf("UpdateRules error: %v", err)
+	}
+	rules, err = svc.GetRules(ctx)
+	if err != nil {
+		t.Fatalf("GetRules error: %v", err)
+	}
+	if !rules.StripPlusTag || rules.UpdatedBy != "admin@corp.com" {
+		t.Errorf("expected stored rules, got %+v", rules)
+	}
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// maxSuppressionDetailLength bounds the note stored with a suppressed address
+	maxSuppressionDetailLength = 500
+	// suppressionListLimit bounds a single listing of the suppression list
+	suppressionListLimit = 500
+)
+
+// ErrInvalidSuppression is returned when a suppression entry fails validation
+var ErrInvalidSuppression = errors.New("invalid email suppression")
+
+// emailSuppressionRepository defines suppression list storage operations
+type emailSuppressionRepository interface {
+	Add(ctx context.Context, entry models.EmailSuppression) (*models.EmailSuppression, error)
+	Remove(ctx context.Context, email string) error
+	List(ctx context.Context, search string, limit int) ([]*models.EmailSuppression, error)
+}
+
+// EmailSuppressionService manages the addresses no email is sent to
+type EmailSuppressionService struct {
+	repo emailSuppressionRepository
+}
+
+// NewEmailSuppressionService creates a new email suppression service
+func NewEmailSuppressionService(repo emailSuppressionRepository) *EmailSuppressionService {
+	return &EmailSuppressionService{repo: repo}
+}
+
+// ListSuppressions retrieves the suppressed addresses containing search
+func (s *EmailSuppressionService) ListSuppressions(ctx context.Context, search string) ([]*models.EmailSuppression, error) {
+	return s.repo.List(ctx, search, suppressionListLimit)
+}
+
+// AddSuppression validates and suppresses an address, manually by default
+func (s *EmailSuppressionService) AddSuppression(ctx context.Context, entry models.EmailSuppression) (*models.EmailSuppression, error) {
+	entry.Email = strings.ToLower(strings.TrimSpace(entry.Email))
+	entry.Detail = strings.TrimSpace(entry.Detail)
+	if entry.Reason == "" {
+		entry.Reason = models.SuppressionManual
+	}
+
+	if addr, err := mail.ParseAddress(entry.Email); err != nil || addr.Address != entry.Email {
+		return nil, fmt.Errorf("%w: email address is invalid", ErrInvalidSuppression)
+	}
+	if !entry.Reason.IsValid() {
+		return nil, fmt.Errorf("%w: reason must be hard_bounce, complaint or manual", ErrInvalidSuppression)
+	}
+	if utf8.RuneCountInString(entry.Detail) > maxSuppressionDetailLength {
+		return nil, fmt.Errorf("%w: detail must be at most %d characters", ErrInvalidSuppression, maxSuppressionDetailLength)
+	}
+
+	logger.Logger.Info("Suppressing email address", "reason", entry.Reason, "created_by", entry.CreatedBy)
+	return s.repo.Add(ctx, entry)
+}
+
+// RemoveSuppression lets an address receive email again
+func (s *EmailSuppressionService) RemoveSuppression(ctx context.Context, email string) error {
+	logger.Logger.Info("Lifting email suppression")
+	return s.repo.Remove(ctx, email)
+}
+
+// RecordHardBounce suppresses an address the mail server reported as nonexistent
+func (s *EmailSuppressionService) RecordHardBounce(ctx context.Context, email string, detail string) error {
+	if utf8.RuneCountInString(detail) > maxSuppressionDetailLength {
+		detail = string([]rune(detail)[:maxSuppressionDetailLength])
+	}
+	_, err := s.AddSuppression(ctx, models.EmailSuppression{
+		Email:  email,
+		Reason: models.SuppressionHardBounce,
+		Detail: detail,
+	})
+	return err
+}
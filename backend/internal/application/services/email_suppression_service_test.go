@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeEmailSuppressionRepo struct {
+	entries map[string]*models.EmailSuppression
+}
+
+func newFakeEmailSuppressionRepo() *fakeEmailSuppressionRepo {
+	return &fakeEmailSuppressionRepo{entries: make(map[string]*models.EmailSuppression)}
+}
+
+func (f *fakeEmailSuppressionRepo) Add(_ context.Context, entry models.EmailSuppression) (*models.EmailSuppression, error) {
+	f.entries[entry.Email] = &entry
+	return &entry, nil
+}
+
+func (f *fakeEmailSuppressionRepo) Remove(_ context.Context, email string) error {
+	if _, ok := f.entries[email]; !ok {
+		return models.ErrSuppressionNotFound
+	}
+	delete(f.entries, email)
+	return nil
+}
+
+func (f *fakeEmailSuppressionRepo) List(_ context.Context, search string, _ int) ([]*models.EmailSuppression, error) {
+	var out []*models.EmailSuppression
+	for email, entry := range f.entries {
+		if strings.Contains(email, search) {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+func TestEmailSuppressionService_AddSuppression(t *testing.T) {
+	repo := newFakeEmailSuppressionRepo()
+	svc := NewEmailSuppressionService(repo)
+
+	s, err := svc.AddSuppression(context.Background(), models.EmailSuppression{Email: " Bob@Example.com "})
+	if err != nil {
+		t.Fatalf("AddSuppression err: %v", err)
+	}
+	if s.Email != "bob@example.com" || s.Reason != models.SuppressionManual {
+		t.Errorf("unexpected suppression %+v", s)
+	}
+
+	invalid := []models.EmailSuppression{
+		{Email: "not-an-email"},
+		{Email: "Bob <bob@example.com>"},
+		{Email: "bob@example.com", Reason: "spam"},
+		{Email: "bob@example.com", Detail: strings.Repeat("x", maxSuppressionDetailLength+1)},
+	}
+	for _, entry := range invalid {
+		if _, err := svc.AddSuppression(context.Background(), entry); !errors.Is(err, ErrInvalidSuppression) {
+			t.Errorf("expected ErrInvalidSuppression for %+v, got %v", entry, err)
+		}
+	}
+}
+
+func TestEmailSuppressionService_RecordHardBounce(t *testing.T) {
+	repo := newFakeEmailSuppressionRepo()
+	svc := NewEmailSuppressionService(repo)
+
+	if err := svc.RecordHardBounce(context.Background(), "Gone@example.com", strings.Repeat("5", 600)); err != nil {
+		t.Fatalf("RecordHardBounce err: %v", err)
+	}
+	s := repo.entries["gone@example.com"]
+	if s == nil || s.Reason != models.SuppressionHardBounce || len(s.Detail) != maxSuppressionDetailLength {
+		t.Errorf("unexpected suppression %+v", s)
+	}
+
+	if err := svc.RemoveSuppression(context.Background(), "missing@example.com"); !errors.Is(err, models.ErrSuppressionNotFound) {
+		t.Errorf("expected ErrSuppressionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// outboxMaxAttempts is the number of failed relays after which an event is given up as dead
+	outboxMaxAttempts = 20
+	// outboxMaxBackoff caps the delay between two relays of the same event
+	outboxMaxBackoff = time.Hour
+)
+
+// eventOutboxRepository stores the domain events waiting to be relayed
+type eventOutboxRepository interface {
+	Append(ctx context.Context, eventID, eventType string, payload map[string]interface{}) error
+	ClaimNext(ctx context.Context, now time.Time) (*models.OutboxEvent, error)
+	MarkDelivered(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time, dead bool) error
+	RetryDead(ctx context.Context) (int, error)
+	Lag(ctx context.Context) (*models.OutboxLag, error)
+	PurgeDelivered(ctx context.Context, before time.Time) (int, error)
+}
+
+// outboxSubscriber receives the events relayed from the outbox. It runs in the transaction that
+// marks the event delivered, so its database writes are rolled back when the relay fails.
+type outboxSubscriber interface {
+	HandleEvent(ctx context.Context, event *models.OutboxEvent) error
+}
+
+// EventOutboxService records domain events in the transaction of the change they report and relays
+// them to the webhooks and notifiers, so that no event is lost when the process stops in between.
+// Delivery is at least once: subscribers may receive an event again after a failed relay.
+type EventOutboxService struct {
+	repo        eventOutboxRepository
+	subscribers []outboxSubscriber
+	maxLag      time.Duration
+	now         func() time.Time
+}
+
+// NewEventOutboxService creates an event outbox service. The relay is reported unhealthy once an
+// event waits more than maxLag to be delivered.
+func NewEventOutboxService(repo eventOutboxRepository, maxLag time.Duration) *EventOutboxService {
+	return &EventOutboxService{repo: repo, maxLag: maxLag, now: time.Now}
+}
+
+// Subscribe adds a subscriber receiving every relayed event
+func (s *EventOutboxService) Subscribe(subscriber outboxSubscriber) {
+	s.subscribers = append(s.subscribers, subscriber)
+}
+
+// Publish appends an event to the outbox. It has the signature of the webhook publisher so that
+// producers can record their events through either.
+func (s *EventOutboxService) Publish(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	return s.repo.Append(ctx, newEventID(), eventType, payload)
+}
+
+// RelayNext delivers the next due event to the subscribers and marks it delivered. It returns nil
+// when no event is due. When a subscriber fails, the event is returned with the error: the caller
+// rolls the transaction back and records the failure with RecordFailure in a new one.
+func (s *EventOutboxService) RelayNext(ctx context.Context) (*models.OutboxEvent, error) {
+	event, err := s.repo.ClaimNext(ctx, s.now())
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	for _, subscriber := range s.subscribers {
+		if err := subscriber.HandleEvent(ctx, event); err != nil {
+			return event, fmt.Errorf("failed to relay event %s: %w", event.EventID, err)
+		}
+	}
+	if err := s.repo.MarkDelivered(ctx, event.ID); err != nil {
+		return event, err
+	}
+	return event, nil
+}
+
+// RecordFailure schedules the next relay of an event with an exponential backoff, or gives it up
+// as dead after outboxMaxAttempts
+func (s *EventOutboxService) RecordFailure(ctx context.Context, event *models.OutboxEvent, relayErr error) error {
+	attempts := event.Attempts + 1
+	backoff := outboxMaxBackoff
+	if attempts < 12 {
+		backoff = time.Duration(1<<uint(attempts)) * time.Second
+		if backoff > outboxMaxBackoff {
+			backoff = outboxMaxBackoff
+		}
+	}
+	return s.repo.MarkFailed(ctx, event.ID, relayErr.Error(), s.now().Add(backoff), attempts >= outboxMaxAttempts)
+}
+
+// RetryDead schedules the dead events for an immediate relay and returns how many were
+func (s *EventOutboxService) RetryDead(ctx context.Context) (int, error) {
+	return s.repo.RetryDead(ctx)
+}
+
+// Purge deletes the events delivered more than retention ago and returns how many were
+func (s *EventOutboxService) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	return s.repo.PurgeDelivered(ctx, s.now().Add(-retention))
+}
+
+// Lag reports how far the relay is behind. It is unhealthy when an event waits more than the max
+// lag or when events were given up.
+func (s *EventOutboxService) Lag(ctx context.Context) (*models.OutboxLag, error) {
+	lag, err := s.repo.Lag(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lag.MaxLagSeconds = s.maxLag.Seconds()
+	if lag.OldestPendingAt != nil {
+		lag.LagSeconds = s.now().Sub(*lag.OldestPendingAt).Seconds()
+	}
+	lag.Healthy = lag.DeadCount == 0 && (s.maxLag <= 0 || lag.LagSeconds <= s.maxLag.Seconds())
+	return lag, nil
+}
+
+// signatureCompletionNotifier notifies document creators about signing milestones
+type signatureCompletionNotifier interface {
+	OnSignature(ctx context.Context, docID string) error
+}
+
+// signatureNotificationInbox records new signatures in the document owner's inbox
+type signatureNotificationInbox interface {
+	OnSignature(ctx context.Context, docID, signerEmail, signerName string) error
+}
+
+// SignatureEventNotifier relays the signature.created events of the outbox to the completion
+// notifications and the notification inbox
+type SignatureEventNotifier struct {
+	completion signatureCompletionNotifier
+	inbox      signatureNotificationInbox
+}
+
+// NewSignatureEventNotifier creates a signature event notifier. Either notifier may be nil.
+func NewSignatureEventNotifier(completion signatureCompletionNotifier, inbox signatureNotificationInbox) *SignatureEventNotifier {
+	return &SignatureEventNotifier{completion: completion, inbox: inbox}
+}
+
+// HandleEvent notifies the signature of a signature.created event and ignores the other events
+func (n *SignatureEventNotifier) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	if event.EventType != "signature.created" {
+		return nil
+	}
+	docID, _ := event.Payload["doc_id"].(string)
+	if n.completion != nil {
+		if err := n.completion.OnSignature(ctx, docID); err != nil {
+			return fmt.Errorf("failed to send completion notification: %w", err)
+		}
+	}
+	if n.inbox != nil {
+		email, _ := event.Payload["user_email"].(string)
+		name, _ := event.Payload["user_name"].(string)
+		if err := n.inbox.OnSignature(ctx, docID, email, name); err != nil {
+			return fmt.Errorf("failed to record signature notification: %w", err)
+		}
+	}
+	return nil
+}
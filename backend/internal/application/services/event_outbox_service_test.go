@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeOutboxRepo struct {
+	events    []*models.OutboxEvent
+	delivered []int64
+	failures  []fakeOutboxFailure
+	lag       models.OutboxLag
+}
+
+type fakeOutboxFailure struct {
+	id          int64
+	nextAttempt time.Time
+	dead        bool
+}
+
+func (f *fakeOutboxRepo) Append(_ context.Context, eventID, eventType string, payload map[string]interface{}) error {
+	f.events = append(f.events, &models.OutboxEvent{ID: int64(len(f.events) + 1), EventID: eventID, EventType: eventType, Payload: payload, Status: models.OutboxStatusPending})
+	return nil
+}
+
+func (f *fakeOutboxRepo) ClaimNext(_ context.Context, _ time.Time) (*models.OutboxEvent, error) {
+	for _, e := range f.events {
+		if e.Status == models.OutboxStatusPending {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOutboxRepo) MarkDelivered(_ context.Context, id int64) error {
+	f.delivered = append(f.delivered, id)
+	f.events[id-1].Status = models.OutboxStatusDelivered
+	return nil
+}
+
+func (f *fakeOutboxRepo) MarkFailed(_ context.Context, id int64, _ string, nextAttemptAt time.Time, dead bool) error {
+	f.failures = append(f.failures, fakeOutboxFailure{id: id, nextAttempt: nextAttemptAt, dead: dead})
+	return nil
+}
+
+func (f *fakeOutboxRepo) RetryDead(_ context.Context) (int, error) { return 0, nil }
+
+func (f *fakeOutboxRepo) Lag(_ context.Context) (*models.OutboxLag, error) {
+	lag := f.lag
+	return &lag, nil
+}
+
+func (f *fakeOutboxRepo) PurgeDelivered(_ context.Context, _ time.Time) (int, error) { return 0, nil }
+
+type fakeOutboxSubscriber struct {
+	events []*models.OutboxEvent
+	err    error
+}
+
+func (f *fakeOutboxSubscriber) HandleEvent(_ context.Context, event *models.OutboxEvent) error {
+	f.events = append(f.events, event)
+	return f.err
+}
+
+func TestEventOutboxService_RelayNext(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	subscriber := &fakeOutboxSubscriber{}
+	svc := NewEventOutboxService(repo, time.Minute)
+	svc.Subscribe(subscriber)
+	ctx := context.Background()
+
+	if err := svc.Publish(ctx, "signature.created", map[string]interface{}{"doc_id": "doc-1"}); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if len(repo.events) != 1 || repo.events[0].EventID == "" {
+		t.Fatalf("expected one event with an ID, got %#v", repo.events)
+	}
+
+	event, err := svc.RelayNext(ctx)
+	if err != nil || event == nil {
+		t.Fatalf("RelayNext: %v, %v", event, err)
+	}
+	if len(subscriber.events) != 1 || len(repo.delivered) != 1 {
+		t.Fatalf("expected the event delivered once, got %d handled and %d marked", len(subscriber.events), len(repo.delivered))
+	}
+
+	if event, err := svc.RelayNext(ctx); err != nil || event != nil {
+		t.Fatalf("expected no due event, got %v, %v", event, err)
+	}
+}
+
+func TestEventOutboxService_RelayFailure(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	svc := NewEventOutboxService(repo, time.Minute)
+	svc.Subscribe(&fakeOutboxSubscriber{err: errors.New("boom")})
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_ = svc.Publish(ctx, "signature.created", nil)
+	event, err := svc.RelayNext(ctx)
+	if err == nil || event == nil {
+		t.Fatalf("expected the failed event and an error, got %v, %v", event, err)
+	}
+	if len(repo.delivered) != 0 {
+		t.Fatal("a failed event must not be marked delivered")
+	}
+
+	if err := svc.RecordFailure(ctx, event, err); err != nil {
+		t.Fatalf("RecordFailure error: %v", err)
+	}
+	if f := repo.failures[0]; f.dead || !f.nextAttempt.Equal(now.Add(2*time.Second)) {
+		t.Fatalf("unexpected first failure: %+v", f)
+	}
+
+	event.Attempts = outboxMaxAttempts - 1
+	_ = svc.RecordFailure(ctx, event, err)
+	if f := repo.failures[1]; !f.dead || !f.nextAttempt.Equal(now.Add(outboxMaxBackoff)) {
+		t.Fatalf("expected the event given up, got %+v", f)
+	}
+}
+
+func TestEventOutboxService_Lag(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	oldest := now.Add(-2 * time.Minute)
+	repo := &fakeOutboxRepo{lag: models.OutboxLag{PendingCount: 3, OldestPendingAt: &oldest}}
+	svc := NewEventOutboxService(repo, 5*time.Minute)
+	svc.now = func() time.Time { return now }
+
+	lag, err := svc.Lag(context.Background())
+	if err != nil {
+		t.Fatalf("Lag error: %v", err)
+	}
+	if !lag.Healthy || lag.LagSeconds != 120 || lag.MaxLagSeconds != 300 {
+		t.Fatalf("unexpected lag: %+v", lag)
+	}
+
+	repo.lag.DeadCount = 1
+	if lag, _ := svc.Lag(context.Background()); lag.Healthy {
+		t.Fatal("expected dead events to make the relay unhealthy")
+	}
+}
+
+type fakeSignatureCompletion struct{ docIDs []string }
+
+func (f *fakeSignatureCompletion) OnSignature(_ context.Context, docID string) error {
+	f.docIDs = append(f.docIDs, docID)
+	return nil
+}
+
+func TestSignatureEventNotifier_HandleEvent(t *testing.T) {
+	completion := &fakeSignatureCompletion{}
+	n := NewSignatureEventNotifier(completion, nil)
+	ctx := context.Background()
+
+	_ = n.HandleEvent(ctx, &models.OutboxEvent{EventType: "document.completed", Payload: map[string]interface{}{"doc_id": "doc-1"}})
+	if err := n.HandleEvent(ctx, &models.OutboxEvent{EventType: "signature.created", Payload: map[string]interface{}{"doc_id": "doc-1"}}); err != nil {
+		t.Fatalf("HandleEvent error: %v", err)
+	}
+	if len(completion.docIDs) != 1 || completion.docIDs[0] != "doc-1" {
+		t.Fatalf("expected one notification for doc-1, got %v", completion.docIDs)
+	}
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrTimestampUnavailable wraps the errors of the time-stamping authority
+var ErrTimestampUnavailable = errors.New("time-stamping authority unavailable")
+
+// exportKindSignatures is the manifest kind of document signature exports
+const exportKindSignatures = "signatures"
+
+// exportDocumentRepository reads the exported document
+type exportDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// exportSignatureRepository reads the signatures of a document
+type exportSignatureRepository interface {
+	GetByDoc(ctx context.Context, docID string) ([]*models.Signature, error)
+}
+
+// exportReminderRepository reads the reminder log of a document
+type exportReminderRepository interface {
+	GetReminderHistory(ctx context.Context, docID string) ([]*models.ReminderLog, error)
+}
+
+// manifestSigner signs bundle manifests with the server Ed25519 key
+type manifestSigner interface {
+	Sign(message []byte) string
+	GetPublicKey() string
+}
+
+// manifestTimestamper returns the RFC 3161 time-stamp response of a SHA-256 digest
+type manifestTimestamper interface {
+	Timestamp(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// ExportService builds tamper-evident export bundles and verifies them
+type ExportService struct {
+	docRepo      exportDocumentRepository
+	sigRepo      exportSignatureRepository
+	reminderRepo exportReminderRepository
+	signer       manifestSigner
+	timestamper  manifestTimestamper
+	now          func() time.Time
+}
+
+func NewExportService(docRepo exportDocumentRepository, sigRepo exportSignatureRepository, reminderRepo exportReminderRepository, signer manifestSigner) *ExportService {
+	return &ExportService{
+		docRepo:      docRepo,
+		sigRepo:      sigRepo,
+		reminderRepo: reminderRepo,
+		signer:       signer,
+		now:          time.Now,
+	}
+}
+
+// SetTimestamper enables the RFC 3161 time-stamp of the manifests
+func (s *ExportService) SetTimestamper(timestamper manifestTimestamper) {
+	s.timestamper = timestamper
+}
+
+// PublicKey returns the base64 key bundles are signed with
+func (s *ExportService) PublicKey() string {
+	return s.signer.GetPublicKey()
+}
+
+// ExportDocumentSignatures builds the bundle of the signatures of a document, with its
+// reminder log. When a time-stamping authority is configured and does not answer, the export
+// fails rather than producing a bundle without time-stamp.
+func (s *ExportService) ExportDocumentSignatures(ctx context.Context, docID string) (*crypto.ExportBundle, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	signatures, err := s.sigRepo.GetByDoc(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document signatures: %w", err)
+	}
+	if signatures == nil {
+		signatures = []*models.Signature{}
+	}
+	reminders, err := s.reminderRepo.GetReminderHistory(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminder history: %w", err)
+	}
+	if reminders == nil {
+		reminders = []*models.ReminderLog{}
+	}
+
+	files := make([]crypto.BundleFile, 0, 4)
+	for _, entry := range []struct {
+		name  string
+		value interface{}
+	}{
+		{"document.json", doc},
+		{"signatures.json", signatures},
+		{"reminders.json", reminders},
+	} {
+		data, err := json.MarshalIndent(entry.value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", entry.name, err)
+		}
+		files = append(files, crypto.BundleFile{Name: entry.name, Data: append(data, '\n')})
+	}
+	signaturesCSV, err := encodeSignaturesCSV(signatures)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, crypto.BundleFile{Name: "signatures.csv", Data: signaturesCSV})
+
+	return s.sign(ctx, exportKindSignatures, docID, files)
+}
+
+// SignBundle builds a bundle of files of the given kind about subject, with its signed manifest
+// and, when configured, its time-stamp
+func (s *ExportService) SignBundle(ctx context.Context, kind, subject string, files []crypto.BundleFile) (*crypto.ExportBundle, error) {
+	return s.sign(ctx, kind, subject, files)
+}
+
+// sign adds the signed manifest of files and its time-stamp
+func (s *ExportService) sign(ctx context.Context, kind, subject string, files []crypto.BundleFile) (*crypto.ExportBundle, error) {
+	manifest, err := crypto.NewExportManifest(kind, subject, s.signer.GetPublicKey(), files, s.now()).Encode()
+	if err != nil {
+		return nil, err
+	}
+	bundle := &crypto.ExportBundle{Files: files, Manifest: manifest, Signature: s.signer.Sign(manifest)}
+
+	if s.timestamper != nil {
+		digest := sha256.Sum256(manifest)
+		tsr, err := s.timestamper.Timestamp(ctx, digest[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTimestampUnavailable, err)
+		}
+		bundle.Timestamp = tsr
+	}
+	return bundle, nil
+}
+
+// VerifyBundle checks a bundle against the key of this server
+func (s *ExportService) VerifyBundle(data []byte) (*crypto.BundleVerification, error) {
+	return crypto.VerifyExportBundle(data, s.signer.GetPublicKey())
+}
+
+func encodeSignaturesCSV(signatures []*models.Signature) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"id", "user_email", "user_name", "signed_at", "doc_checksum", "payload_hash", "signature", "nonce", "prev_hash", "auth_method",
+		"locale", "consent_version", "consent_locale", "consent_text"})
+	for _, sig := range signatures {
+		prevHash := ""
+		if sig.PrevHash != nil {
+			prevHash = *sig.PrevHash
+		}
+		consentVersion := ""
+		if sig.ConsentVersion != nil {
+			consentVersion = strconv.Itoa(*sig.ConsentVersion)
+		}
+		_ = w.Write([]string{
+			strconv.FormatInt(sig.ID, 10), sig.UserEmail, sig.UserName, sig.SignedAtUTC.UTC().Format(time.RFC3339Nano),
+			sig.DocChecksum, sig.PayloadHash, sig.Signature, sig.Nonce, prevHash, sig.AuthMethod,
+			sig.Locale, consentVersion, sig.ConsentLocale, sig.ConsentText,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode signatures.csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
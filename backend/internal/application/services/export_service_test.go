@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeExportSignatureRepo struct {
+	signatures []*models.Signature
+}
+
+func (f *fakeExportSignatureRepo) GetByDoc(_ context.Context, _ string) ([]*models.Signature, error) {
+	return f.signatures, nil
+}
+
+type fakeExportReminderRepo struct{}
+
+func (f *fakeExportReminderRepo) GetReminderHistory(_ context.Context, _ string) ([]*models.ReminderLog, error) {
+	return nil, nil
+}
+
+type fakeTimestamper struct {
+	err error
+}
+
+func (f *fakeTimestamper) Timestamp(_ context.Context, _ []byte) ([]byte, error) {
+	return nil, f.err
+}
+
+func newTestExportService(t *testing.T) (*ExportService, *crypto.Ed25519Signer) {
+	t.Helper()
+	signer, err := crypto.NewEd25519Signer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	docs := newMockDocRepo()
+	docs.documents["doc1"] = &models.Document{DocID: "doc1", Title: "Security Policy"}
+	sigs := &fakeExportSignatureRepo{signatures: []*models.Signature{
+		{ID: 1, DocID: "doc1", UserEmail: "alice@example.com", SignedAtUTC: time.Now(), PayloadHash: "hash", Signature: "sig"},
+	}}
+	return NewExportService(docs, sigs, &fakeExportReminderRepo{}, signer), signer
+}
+
+func TestExportService_ExportDocumentSignatures(t *testing.T) {
+	service, _ := newTestExportService(t)
+	ctx := context.Background()
+
+	bundle, err := service.ExportDocumentSignatures(ctx, "doc1")
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	var names []string
+	for _, f := range bundle.Files {
+		names = append(names, f.Name)
+	}
+	if len(names) != 4 || names[1] != "signatures.json" || names[3] != "signatures.csv" {
+		t.Errorf("unexpected files %v", names)
+	}
+
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	result, err := service.VerifyBundle(buf.Bytes())
+	if err != nil || !result.Valid || !result.KeyTrusted {
+		t.Fatalf("expected a valid bundle, got %+v, %v", result, err)
+	}
+	if result.Manifest.Kind != "signatures" || result.Manifest.Subject != "doc1" {
+		t.Errorf("unexpected manifest %+v", result.Manifest)
+	}
+
+	if _, err := service.ExportDocumentSignatures(ctx, "missing"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestExportService_TimestampUnavailable(t *testing.T) {
+	service, _ := newTestExportService(t)
+	service.SetTimestamper(&fakeTimestamper{err: errors.New("status 503")})
+
+	if _, err := service.ExportDocumentSignatures(context.Background(), "doc1"); !errors.Is(err, ErrTimestampUnavailable) {
+		t.Fatalf("expected ErrTimestampUnavailable, got %v", err)
+	}
+}
+
+func TestExportService_VerifyBundleFromOtherServer(t *testing.T) {
+	service, _ := newTestExportService(t)
+	other, _ := newTestExportService(t)
+
+	bundle, err := other.ExportDocumentSignatures(context.Background(), "doc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	result, err := service.VerifyBundle(buf.Bytes())
+	if err != nil || result.Valid || result.KeyTrusted {
+		t.Fatalf("expected the other key rejected, got %+v, %v", result, err)
+	}
+}
+
+func TestEncodeSignaturesCSV_Consent(t *testing.T) {
+	version := 3
+	data, err := encodeSignaturesCSV([]*models.Signature{
+		{ID: 1, UserEmail: "alice@example.com", Locale: "fr", ConsentVersion: &version, ConsentLocale: "fr", ConsentText: "J'ai lu, et compris"},
+		{ID: 2, UserEmail: "bob@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if !strings.HasSuffix(lines[0], ",locale,consent_version,consent_locale,consent_text") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], `,fr,3,fr,"J'ai lu, et compris"`) || !strings.HasSuffix(lines[2], ",,,,") {
+		t.Errorf("unexpected rows %q", lines[1:])
+	}
+}
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	ErrInvalidSignerEmail      = errors.New("invalid email address")
+	ErrInvalidVerificationCode = errors.New("invalid or expired verification code")
+)
+
+const (
+	externalCodeDigits      = 6
+	externalCodeValidity    = 10 * time.Minute
+	externalCodeMaxAttempts = 5
+
+	externalCodeRateLimitWindow   = time.Hour
+	externalCodeRateLimitPerEmail = 5
+	externalCodeRateLimitPerIP    = 20
+)
+
+// externalSignerRepository defines external signing settings and verification code storage
+type externalSignerRepository interface {
+	GetSettings(ctx context.Context, docID string) (*models.ExternalSigning, error)
+	Enable(ctx context.Context, docID, enabledBy string) error
+	Disable(ctx context.Context, docID string) error
+	CreateCode(ctx context.Context, code *models.ExternalSignerCode) error
+	GetLatestCode(ctx context.Context, docID, email string) (*models.ExternalSignerCode, error)
+	IncrementAttempts(ctx context.Context, id int64) error
+	MarkCodeUsed(ctx context.Context, id int64) error
+	CountRecentCodes(ctx context.Context, email string, since time.Time) (int, error)
+	CountRecentCodesByIP(ctx context.Context, ip string, since time.Time) (int, error)
+}
+
+// externalSignerDocuments reads the documents offered to external signers
+type externalSignerDocuments interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// ExternalSignerService lets people outside the identity provider sign documents
+// after proving ownership of their email address with a one-time code
+type ExternalSignerService struct {
+	repo           externalSignerRepository
+	docRepo        externalSignerDocuments
+	emailSender    emailSender
+	i18n           i18nTranslator
+	allowedDomains []string // Empty allows every domain
+	deniedDomains  []string // Wins over allowedDomains
+}
+
+// NewExternalSignerService creates a new external signer service
+func NewExternalSignerService(repo externalSignerRepository, docRepo externalSignerDocuments, sender emailSender, i18n i18nTranslator, allowedDomains, deniedDomains []string) *ExternalSignerService {
+	return &ExternalSignerService{
+		repo:           repo,
+		docRepo:        docRepo,
+		emailSender:    sender,
+		i18n:           i18n,
+		allowedDomains: allowedDomains,
+		deniedDomains:  deniedDomains,
+	}
+}
+
+// GetSettings reports whether a document accepts external signers
+func (s *ExternalSignerService) GetSettings(ctx context.Context, docID string) (*models.ExternalSigning, error) {
+	if _, err := s.getDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSettings(ctx, docID)
+}
+
+// SetEnabled opens or closes a document to external signers
+func (s *ExternalSignerService) SetEnabled(ctx context.Context, docID string, enabled bool, updatedBy string) (*models.ExternalSigning, error) {
+	if _, err := s.getDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+
+	if enabled {
+		if err := s.repo.Enable(ctx, docID, updatedBy); err != nil {
+			return nil, err
+		}
+	} else if err := s.repo.Disable(ctx, docID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetSettings(ctx, docID)
+}
+
+// RequestCode emails a verification code to an external signer. Rate-limited requests
+// succeed silently so that callers cannot probe the limits.
+func (s *ExternalSignerService) RequestCode(ctx context.Context, docID, emailAddr, ip, locale string) error {
+	emailAddr, err := s.checkSigner(emailAddr)
+	if err != nil {
+		return err
+	}
+
+	doc, err := s.openDocument(ctx, docID)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-externalCodeRateLimitWindow)
+	count, err := s.repo.CountRecentCodes(ctx, emailAddr, since)
+	if err != nil {
+		return fmt.Errorf("rate limit check failed: %w", err)
+	}
+	if count >= externalCodeRateLimitPerEmail {
+		logger.Logger.Warn("External signer code rate limit exceeded", "email", emailAddr, "count", count)
+		return nil
+	}
+	countIP, err := s.repo.CountRecentCodesByIP(ctx, ip, since)
+	if err != nil {
+		return fmt.Errorf("rate limit check failed: %w", err)
+	}
+	if countIP >= externalCodeRateLimitPerIP {
+		logger.Logger.Warn("External signer code IP rate limit exceeded", "ip", ip, "count", countIP)
+		return nil
+	}
+
+	return s.sendCode(ctx, doc, emailAddr, ip, locale)
+}
+
+// ResendCode emails a fresh verification code on behalf of an admin. The request rate limits
+// do not apply: the caller throttles each recipient and reports the outcome.
+func (s *ExternalSignerService) ResendCode(ctx context.Context, docID, emailAddr, ip, locale string) error {
+	emailAddr, err := s.checkSigner(emailAddr)
+	if err != nil {
+		return err
+	}
+
+	doc, err := s.openDocument(ctx, docID)
+	if err != nil {
+		return err
+	}
+	return s.sendCode(ctx, doc, emailAddr, ip, locale)
+}
+
+// sendCode stores a new code for the signer and emails it
+func (s *ExternalSignerService) sendCode(ctx context.Context, doc *models.Document, emailAddr, ip, locale string) error {
+	docID := doc.DocID
+	code, err := generateVerificationCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate verification code: %w", err)
+	}
+
+	record := &models.ExternalSignerCode{
+		DocID:       docID,
+		Email:       emailAddr,
+		CodeHash:    hashVerificationCode(code),
+		ExpiresAt:   time.Now().Add(externalCodeValidity),
+		CreatedByIP: ip,
+	}
+	if err := s.repo.CreateCode(ctx, record); err != nil {
+		return err
+	}
+
+	if locale == "" {
+		locale = "en"
+	}
+	subject := "Your verification code"
+	if s.i18n != nil {
+		subject = s.i18n.T(locale, "email.external_signer_code.subject")
+	}
+
+	docTitle := doc.Title
+	if docTitle == "" {
+		docTitle = docID
+	}
+
+	msg := email.Message{
+		To:       []string{emailAddr},
+		Subject:  subject,
+		Template: "external_signer_code",
+		Locale:   locale,
+		Data: map[string]interface{}{
+			"Code":      code,
+			"DocTitle":  docTitle,
+			"ExpiresIn": int(externalCodeValidity.Minutes()),
+		},
+	}
+	if err := s.emailSender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send verification code: %w", err)
+	}
+
+	logger.Logger.Info("External signer verification code sent", "doc_id", docID, "email", emailAddr, "ip", ip)
+	return nil
+}
+
+// VerifyCode checks the last code sent to an external signer and returns the signer identity.
+// The code stays valid until ConsumeCode is called, so that a refused signature can be retried.
+func (s *ExternalSignerService) VerifyCode(ctx context.Context, docID, emailAddr, code string) (*models.User, error) {
+	emailAddr, err := s.checkSigner(emailAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.openDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+
+	record, err := s.repo.GetLatestCode(ctx, docID, emailAddr)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || !record.IsUsable(externalCodeMaxAttempts) {
+		return nil, ErrInvalidVerificationCode
+	}
+
+	hash := hashVerificationCode(strings.TrimSpace(code))
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(record.CodeHash)) != 1 {
+		if err := s.repo.IncrementAttempts(ctx, record.ID); err != nil {
+			return nil, err
+		}
+		logger.Logger.Warn("Invalid external signer verification code", "doc_id", docID, "email", emailAddr)
+		return nil, ErrInvalidVerificationCode
+	}
+
+	return &models.User{
+		Sub:   models.ExternalSignerSubPrefix + emailAddr,
+		Email: emailAddr,
+	}, nil
+}
+
+// ConsumeCode invalidates the code of an external signer once the signature is recorded
+func (s *ExternalSignerService) ConsumeCode(ctx context.Context, docID, emailAddr string) error {
+	emailAddr = strings.ToLower(strings.TrimSpace(emailAddr))
+	record, err := s.repo.GetLatestCode(ctx, docID, emailAddr)
+	if err != nil || record == nil {
+		return err
+	}
+	return s.repo.MarkCodeUsed(ctx, record.ID)
+}
+
+// checkSigner normalizes the email and applies the domain allow and deny lists
+func (s *ExternalSignerService) checkSigner(emailAddr string) (string, error) {
+	emailAddr = strings.ToLower(strings.TrimSpace(emailAddr))
+	if _, err := mail.ParseAddress(emailAddr); err != nil || !strings.Contains(emailAddr, "@") {
+		return "", ErrInvalidSignerEmail
+	}
+
+	domain := emailAddr[strings.LastIndex(emailAddr, "@")+1:]
+	if matchesDomain(domain, s.deniedDomains) {
+		return "", models.ErrDomainNotAllowed
+	}
+	if len(s.allowedDomains) > 0 && !matchesDomain(domain, s.allowedDomains) {
+		return "", models.ErrDomainNotAllowed
+	}
+	return emailAddr, nil
+}
+
+// openDocument returns the document if it accepts external signers. Unknown documents are
+// reported as closed so that document IDs cannot be probed.
+func (s *ExternalSignerService) openDocument(ctx context.Context, docID string) (*models.Document, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrExternalSigningClosed
+	}
+
+	settings, err := s.repo.GetSettings(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return nil, models.ErrExternalSigningClosed
+	}
+	return doc, nil
+}
+
+func (s *ExternalSignerService) getDocument(ctx context.Context, docID string) (*models.Document, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+// matchesDomain reports whether domain is one of domains or a subdomain of one of them
+func matchesDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" {
+			continue
+		}
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateVerificationCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < externalCodeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", externalCodeDigits, n.Int64()), nil
+}
+
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
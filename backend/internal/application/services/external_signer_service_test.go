@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeExternalSignerRepo struct {
+	enabled map[string]bool
+	codes   []*models.ExternalSignerCode
+}
+
+func newFakeExternalSignerRepo() *fakeExternalSignerRepo {
+	return &fakeExternalSignerRepo{enabled: make(map[string]bool)}
+}
+
+func (f *fakeExternalSignerRepo) GetSettings(_ context.Context, docID string) (*models.ExternalSigning, error) {
+	return &models.ExternalSigning{DocID: docID, Enabled: f.enabled[docID]}, nil
+}
+
+func (f *fakeExternalSignerRepo) Enable(_ context.Context, docID, _ string) error {
+	f.enabled[docID] = true
+	return nil
+}
+
+func (f *fakeExternalSignerRepo) Disable(_ context.Context, docID string) error {
+	delete(f.enabled, docID)
+	return nil
+}
+
+func (f *fakeExternalSignerRepo) CreateCode(_ context.Context, code *models.ExternalSignerCode) error {
+	code.ID = int64(len(f.codes) + 1)
+	code.CreatedAt = time.Now()
+	f.codes = append(f.codes, code)
+	return nil
+}
+
+func (f *fakeExternalSignerRepo) GetLatestCode(_ context.Context, docID, email string) (*models.ExternalSignerCode, error) {
+	for i := len(f.codes) - 1; i >= 0; i-- {
+		if f.codes[i].DocID == docID && f.codes[i].Email == email {
+			return f.codes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeExternalSignerRepo) IncrementAttempts(_ context.Context, id int64) error {
+	f.codes[id-1].Attempts++
+	return nil
+}
+
+func (f *fakeExternalSignerRepo) MarkCodeUsed(_ context.Context, id int64) error {
+	now := time.Now()
+	f.codes[id-1].UsedAt = &now
+	return nil
+}
+
+func (f *fakeExternalSignerRepo) CountRecentCodes(_ context.Context, email string, since time.Time) (int, error) {
+	count := 0
+	for _, c := range f.codes {
+		if c.Email == email && !c.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeExternalSignerRepo) CountRecentCodesByIP(_ context.Context, ip string, since time.Time) (int, error) {
+	count := 0
+	for _, c := range f.codes {
+		if c.CreatedByIP == ip && !c.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakeEmailSender struct{ sent []email.Message }
+
+func (f *fakeEmailSender) Send(_ context.Context, msg email.Message) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+type externalSignerFixture struct {
+	service *ExternalSignerService
+	repo    *fakeExternalSignerRepo
+	sender  *fakeEmailSender
+}
+
+func newExternalSignerFixture(allowed, denied []string) *externalSignerFixture {
+	docs := newFakeDocumentRepository()
+	docs.documents["doc1"] = &models.Document{DocID: "doc1", Title: "Security policy"}
+	repo := newFakeExternalSignerRepo()
+	repo.enabled["doc1"] = true
+	sender := &fakeEmailSender{}
+	return &externalSignerFixture{
+		service: NewExternalSignerService(repo, docs, sender, fakeTranslator{}, allowed, denied),
+		repo:    repo,
+		sender:  sender,
+	}
+}
+
+func (f *externalSignerFixture) lastCode(t *testing.T) string {
+	t.Helper()
+	if len(f.sender.sent) == 0 {
+		t.Fatal("no verification email sent")
+	}
+	return f.sender.sent[len(f.sender.sent)-1].Data["Code"].(string)
+}
+
+func TestExternalSignerService_RequestAndVerifyCode(t *testing.T) {
+	f := newExternalSignerFixture(nil, nil)
+	ctx := context.Background()
+
+	if err := f.service.RequestCode(ctx, "doc1", " Alice@Partner.com ", "203.0.113.7", "fr"); err != nil {
+		t.Fatalf("RequestCode failed: %v", err)
+	}
+	msg := f.sender.sent[0]
+	if msg.Template != "external_signer_code" || msg.To[0] != "alice@partner.com" || msg.Locale != "fr" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	code := f.lastCode(t)
+	if len(code) != externalCodeDigits {
+		t.Errorf("code = %q, want %d digits", code, externalCodeDigits)
+	}
+	if f.repo.codes[0].CodeHash == code {
+		t.Error("code must be stored hashed")
+	}
+
+	user, err := f.service.VerifyCode(ctx, "doc1", "alice@partner.com", code)
+	if err != nil {
+		t.Fatalf("VerifyCode failed: %v", err)
+	}
+	if user.Sub != "external:alice@partner.com" || user.Email != "alice@partner.com" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	if err := f.service.ConsumeCode(ctx, "doc1", "alice@partner.com"); err != nil {
+		t.Fatalf("ConsumeCode failed: %v", err)
+	}
+	if _, err := f.service.VerifyCode(ctx, "doc1", "alice@partner.com", code); !errors.Is(err, ErrInvalidVerificationCode) {
+		t.Errorf("reusing a consumed code: err = %v, want ErrInvalidVerificationCode", err)
+	}
+}
+
+func TestExternalSignerService_VerifyCode_LocksAfterMaxAttempts(t *testing.T) {
+	f := newExternalSignerFixture(nil, nil)
+	ctx := context.Background()
+
+	if err := f.service.RequestCode(ctx, "doc1", "bob@partner.com", "203.0.113.7", ""); err != nil {
+		t.Fatalf("RequestCode failed: %v", err)
+	}
+	code := f.lastCode(t)
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+
+	for i := 0; i < externalCodeMaxAttempts; i++ {
+		if _, err := f.service.VerifyCode(ctx, "doc1", "bob@partner.com", wrong); !errors.Is(err, ErrInvalidVerificationCode) {
+			t.Fatalf("attempt %d: err = %v, want ErrInvalidVerificationCode", i, err)
+		}
+	}
+	if _, err := f.service.VerifyCode(ctx, "doc1", "bob@partner.com", code); !errors.Is(err, ErrInvalidVerificationCode) {
+		t.Errorf("correct code after lockout: err = %v, want ErrInvalidVerificationCode", err)
+	}
+}
+
+func TestExternalSignerService_VerifyCode_Expired(t *testing.T) {
+	f := newExternalSignerFixture(nil, nil)
+	ctx := context.Background()
+
+	if err := f.service.RequestCode(ctx, "doc1", "carol@partner.com", "203.0.113.7", ""); err != nil {
+		t.Fatalf("RequestCode failed: %v", err)
+	}
+	f.repo.codes[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+	if _, err := f.service.VerifyCode(ctx, "doc1", "carol@partner.com", f.lastCode(t)); !errors.Is(err, ErrInvalidVerificationCode) {
+		t.Errorf("err = %v, want ErrInvalidVerificationCode", err)
+	}
+}
+
+func TestExternalSignerService_RequestCode_Refused(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		docID   string
+		email   string
+		wantErr error
+	}{
+		{name: "invalid email", docID: "doc1", email: "not-an-email", wantErr: ErrInvalidSignerEmail},
+		{name: "document closed", docID: "doc2", email: "alice@partner.com", wantErr: models.ErrExternalSigningClosed},
+		{name: "unknown document", docID: "missing", email: "alice@partner.com", wantErr: models.ErrExternalSigningClosed},
+		{name: "domain not in allow list", allowed: []string{"partner.com"}, docID: "doc1", email: "eve@other.com", wantErr: models.ErrDomainNotAllowed},
+		{name: "denied domain", denied: []string{"gmail.com"}, docID: "doc1", email: "eve@gmail.com", wantErr: models.ErrDomainNotAllowed},
+		{name: "deny wins over allow", allowed: []string{"partner.com"}, denied: []string{"legal.partner.com"}, docID: "doc1", email: "eve@legal.partner.com", wantErr: models.ErrDomainNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newExternalSignerFixture(tt.allowed, tt.denied)
+			f.service.docRepo.(*fakeDocumentRepository).documents["doc2"] = &models.Document{DocID: "doc2"}
+
+			err := f.service.RequestCode(context.Background(), tt.docID, tt.email, "203.0.113.7", "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(f.sender.sent) != 0 {
+				t.Error("no email should be sent")
+			}
+		})
+	}
+}
+
+func TestExternalSignerService_RequestCode_AllowsSubdomains(t *testing.T) {
+	f := newExternalSignerFixture([]string{"partner.com"}, nil)
+
+	if err := f.service.RequestCode(context.Background(), "doc1", "alice@eu.partner.com", "203.0.113.7", ""); err != nil {
+		t.Fatalf("RequestCode failed: %v", err)
+	}
+	if len(f.sender.sent) != 1 {
+		t.Errorf("sent = %d, want 1", len(f.sender.sent))
+	}
+}
+
+func TestExternalSignerService_RequestCode_RateLimited(t *testing.T) {
+	f := newExternalSignerFixture(nil, nil)
+	ctx := context.Background()
+
+	for i := 0; i < externalCodeRateLimitPerEmail+2; i++ {
+		if err := f.service.RequestCode(ctx, "doc1", "alice@partner.com", "203.0.113.7", ""); err != nil {
+			t.Fatalf("RequestCode %d failed: %v", i, err)
+		}
+	}
+	if len(f.sender.sent) != externalCodeRateLimitPerEmail {
+		t.Errorf("sent = %d, want %d", len(f.sender.sent), externalCodeRateLimitPerEmail)
+	}
+}
+
+func TestExternalSignerService_ResendCode(t *testing.T) {
+	f := newExternalSignerFixture(nil, nil)
+	ctx := context.Background()
+
+	// Admin resends ignore the request rate limits, the caller throttles recipients itself
+	for i := 0; i < externalCodeRateLimitPerIP+1; i++ {
+		if err := f.service.ResendCode(ctx, "doc1", "alice@partner.com", "203.0.113.7", "fr"); err != nil {
+			t.Fatalf("ResendCode %d failed: %v", i, err)
+		}
+	}
+	if len(f.sender.sent) != externalCodeRateLimitPerIP+1 {
+		t.Errorf("sent = %d, want %d", len(f.sender.sent), externalCodeRateLimitPerIP+1)
+	}
+
+	if err := f.service.ResendCode(ctx, "doc2", "alice@partner.com", "203.0.113.7", ""); err == nil {
+		t.Error("ResendCode must refuse a document closed to external signers")
+	}
+}
+
+func TestExternalSignerService_SetEnabled(t *testing.T) {
+	f := newExternalSignerFixture(nil, nil)
+	ctx := context.Background()
+
+	settings, err := f.service.SetEnabled(ctx, "doc1", false, "admin@example.com")
+	if err != nil {
+		t.Fatalf("SetEnabled failed: %v", err)
+	}
+	if settings.Enabled {
+		t.Error("document should be closed to external signers")
+	}
+
+	if _, err := f.service.SetEnabled(ctx, "missing", true, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("err = %v, want ErrDocumentNotFound", err)
+	}
+}
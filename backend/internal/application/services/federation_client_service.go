@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// federationBatchSize bounds the signatures pushed to the hub per push
+const federationBatchSize = 500
+
+// federationPushRepository records the pushes accepted by the hub
+type federationPushRepository interface {
+	GetPushCursor(ctx context.Context) (int64, *time.Time, error)
+	RecordPush(ctx context.Context, lastSignatureID int64, documents, signatures int) error
+	CountPendingPush(ctx context.Context, afterID int64) (int, error)
+}
+
+// federationSignatureRepository reads the signatures to push and counts those of a document
+type federationSignatureRepository interface {
+	ListAfterID(ctx context.Context, afterID int64, before time.Time, limit int) ([]*models.Signature, error)
+	GetByDoc(ctx context.Context, docID string) ([]*models.Signature, error)
+}
+
+// federationDocumentRepository reads the documents summarized in a push
+type federationDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// federationSignerStats reads the completion of the expected signers of a document
+type federationSignerStats interface {
+	GetStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
+}
+
+// federationHub sends a signed push to the hub
+type federationHub interface {
+	Push(ctx context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error)
+}
+
+// federationSigner signs the pushes with the server Ed25519 key, registered at the hub
+type federationSigner interface {
+	Sign(message []byte) string
+	GetPublicKey() string
+}
+
+// FederationClientService pushes the proofs of new signatures and the completion of their
+// documents to the federation hub, so that a central compliance team sees every subsidiary
+type FederationClientService struct {
+	repo       federationPushRepository
+	signatures federationSignatureRepository
+	documents  federationDocumentRepository
+	stats      federationSignerStats
+	hub        federationHub
+	signer     federationSigner
+	hubURL     string
+	instanceID string
+	now        func() time.Time
+
+	mu      sync.Mutex
+	lastErr string
+}
+
+// NewFederationClientService creates a service pushing to the hub at hubURL as instanceID
+func NewFederationClientService(repo federationPushRepository, signatures federationSignatureRepository, documents federationDocumentRepository, stats federationSignerStats, hub federationHub, signer federationSigner, hubURL, instanceID string) *FederationClientService {
+	return &FederationClientService{
+		repo:       repo,
+		signatures: signatures,
+		documents:  documents,
+		stats:      stats,
+		hub:        hub,
+		signer:     signer,
+		hubURL:     hubURL,
+		instanceID: instanceID,
+		now:        time.Now,
+	}
+}
+
+// PushPending pushes the signatures created since the last push with the summaries of their
+// documents and returns how many were pushed. The push is recorded after the hub accepted it,
+// so a failure in between pushes it again on the next run; the hub ignores duplicates.
+func (s *FederationClientService) PushPending(ctx context.Context) (int, error) {
+	cursor, _, err := s.repo.GetPushCursor(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Same settle delay as Merkle roots: a lower ID committed later would be skipped by the cursor
+	signatures, err := s.signatures.ListAfterID(ctx, cursor, s.now().Add(-merkleSettleDelay), federationBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(signatures) == 0 {
+		return 0, nil
+	}
+
+	push := &models.FederationPush{
+		InstanceID: s.instanceID,
+		SentAt:     s.now().UTC(),
+		Signatures: make([]models.FederationSignature, len(signatures)),
+	}
+	seen := map[string]bool{}
+	for i, sig := range signatures {
+		push.Signatures[i] = models.FederationSignature{
+			ID:          sig.ID,
+			DocID:       sig.DocID,
+			UserEmail:   sig.UserEmail,
+			UserName:    sig.UserName,
+			SignedAt:    sig.SignedAtUTC,
+			DocChecksum: sig.DocChecksum,
+			PayloadHash: sig.PayloadHash,
+			Signature:   sig.Signature,
+			Nonce:       sig.Nonce,
+			PrevHash:    sig.PrevHash,
+			RecordHash:  sig.ComputeRecordHash(),
+			HashVersion: sig.HashVersion,
+		}
+		if !seen[sig.DocID] {
+			seen[sig.DocID] = true
+			summary, err := s.summarize(ctx, sig.DocID, push.SentAt)
+			if err != nil {
+				return 0, err
+			}
+			push.Documents = append(push.Documents, *summary)
+		}
+	}
+
+	body, err := json.Marshal(push)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode federation push: %w", err)
+	}
+	if _, err := s.hub.Push(ctx, s.instanceID, body, s.signer.Sign(body)); err != nil {
+		s.setLastError(err.Error())
+		return 0, fmt.Errorf("failed to push to the federation hub: %w", err)
+	}
+	s.setLastError("")
+
+	last := signatures[len(signatures)-1].ID
+	if err := s.repo.RecordPush(ctx, last, len(push.Documents), len(push.Signatures)); err != nil {
+		return 0, err
+	}
+	return len(signatures), nil
+}
+
+// summarize returns the completion of a document at the given time. A deleted document is
+// summarized by its signatures only.
+func (s *FederationClientService) summarize(ctx context.Context, docID string, at time.Time) (*models.FederationDocument, error) {
+	summary := &models.FederationDocument{DocID: docID, UpdatedAt: at}
+
+	doc, err := s.documents.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc != nil {
+		summary.Title, summary.URL, summary.Checksum = doc.Title, doc.URL, doc.Checksum
+	}
+
+	stats, err := s.stats.GetStats(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	summary.ExpectedCount, summary.SignedCount = stats.ExpectedCount, stats.SignedCount
+
+	signatures, err := s.signatures.GetByDoc(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	summary.SignatureCount = len(signatures)
+	return summary, nil
+}
+
+// Status reports the last push and the signatures waiting for the next one
+func (s *FederationClientService) Status(ctx context.Context) (*models.FederationStatus, error) {
+	cursor, pushedAt, err := s.repo.GetPushCursor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.repo.CountPendingPush(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	lastErr := s.lastErr
+	s.mu.Unlock()
+
+	return &models.FederationStatus{
+		HubURL:          s.hubURL,
+		InstanceID:      s.instanceID,
+		PublicKey:       s.signer.GetPublicKey(),
+		LastSignatureID: cursor,
+		LastPushAt:      pushedAt,
+		PendingCount:    pending,
+		LastError:       lastErr,
+	}, nil
+}
+
+func (s *FederationClientService) setLastError(message string) {
+	s.mu.Lock()
+	s.lastErr = message
+	s.mu.Unlock()
+}
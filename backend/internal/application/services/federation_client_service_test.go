@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeFederationPushRepo struct {
+	lastID   int64
+	pushedAt *time.Time
+	pushes   int
+}
+
+func (f *fakeFederationPushRepo) GetPushCursor(_ context.Context) (int64, *time.Time, error) {
+	return f.lastID, f.pushedAt, nil
+}
+
+func (f *fakeFederationPushRepo) RecordPush(_ context.Context, lastSignatureID int64, _, _ int) error {
+	now := time.Now()
+	f.lastID, f.pushedAt = lastSignatureID, &now
+	f.pushes++
+	return nil
+}
+
+func (f *fakeFederationPushRepo) CountPendingPush(_ context.Context, _ int64) (int, error) {
+	return 0, nil
+}
+
+type fakeFederationSignatures struct {
+	fakeMerkleSignatureRepo
+}
+
+func (f *fakeFederationSignatures) GetByDoc(_ context.Context, docID string) ([]*models.Signature, error) {
+	var out []*models.Signature
+	for _, s := range f.signatures {
+		if s.DocID == docID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// addSigned adds n signatures carrying a proof
+func (f *fakeFederationSignatures) addSigned(n int, createdAt time.Time) {
+	f.add(n, createdAt)
+	for _, s := range f.signatures {
+		s.PayloadHash, s.Signature = "cGF5bG9hZA==", "c2lnbmF0dXJl"
+	}
+}
+
+type fakeFederationDocuments struct {
+	docs map[string]*models.Document
+}
+
+func (f *fakeFederationDocuments) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+type fakeFederationStats struct{}
+
+func (fakeFederationStats) GetStats(_ context.Context, docID string) (*models.DocCompletionStats, error) {
+	return &models.DocCompletionStats{DocID: docID, ExpectedCount: 4, SignedCount: 2}, nil
+}
+
+// fakeFederationHub hands the pushes to a hub service, as the hub API does
+type fakeFederationHub struct {
+	hub    *FederationHubService
+	err    error
+	bodies [][]byte
+}
+
+func (f *fakeFederationHub) Push(ctx context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.bodies = append(f.bodies, body)
+	return f.hub.ReceivePush(ctx, instanceID, body, signature)
+}
+
+func newTestFederation(t *testing.T) (*FederationClientService, *fakeFederationPushRepo, *fakeFederationSignatures, *fakeFederationHub, *fakeFederationHubRepo) {
+	t.Helper()
+	signer, err := crypto.NewEd25519Signer()
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+
+	hubRepo := newFakeFederationHubRepo()
+	hub := NewFederationHubService(hubRepo)
+	if _, err := hub.RegisterPeer(context.Background(), "acme-fr", "Acme France", signer.GetPublicKey(), "admin@example.com"); err != nil {
+		t.Fatalf("register peer: %v", err)
+	}
+
+	repo := &fakeFederationPushRepo{}
+	sigs := &fakeFederationSignatures{}
+	docs := &fakeFederationDocuments{docs: map[string]*models.Document{"doc": {DocID: "doc", Title: "Security policy"}}}
+	client := &fakeFederationHub{hub: hub}
+	service := NewFederationClientService(repo, sigs, docs, fakeFederationStats{}, client, signer, "https://hub.example.com", "acme-fr")
+	return service, repo, sigs, client, hubRepo
+}
+
+func TestFederationClientService_PushPending(t *testing.T) {
+	service, repo, sigs, client, hubRepo := newTestFederation(t)
+	ctx := context.Background()
+	sigs.addSigned(3, time.Now().Add(-time.Hour))
+
+	pushed, err := service.PushPending(ctx)
+	if err != nil || pushed != 3 {
+		t.Fatalf("PushPending = %d, %v; want 3", pushed, err)
+	}
+	if repo.lastID != 3 {
+		t.Errorf("expected cursor 3, got %d", repo.lastID)
+	}
+
+	var push models.FederationPush
+	if err := json.Unmarshal(client.bodies[0], &push); err != nil {
+		t.Fatalf("decode push: %v", err)
+	}
+	if len(push.Documents) != 1 || push.Documents[0].Title != "Security policy" || push.Documents[0].SignedCount != 2 || push.Documents[0].SignatureCount != 3 {
+		t.Errorf("unexpected document summaries: %+v", push.Documents)
+	}
+	if push.Signatures[0].RecordHash != sigs.signatures[0].ComputeRecordHash() {
+		t.Error("expected the record hash of the signature in its proof")
+	}
+	if len(hubRepo.signatures) != 3 || hubRepo.documents["acme-fr/doc"] == nil {
+		t.Errorf("expected the hub to store the proofs under the federated ID, got %d signatures, %v", len(hubRepo.signatures), hubRepo.documents)
+	}
+
+	// Nothing new: nothing pushed
+	if pushed, err := service.PushPending(ctx); err != nil || pushed != 0 || len(client.bodies) != 1 {
+		t.Fatalf("expected no push, got %d, %v", pushed, err)
+	}
+}
+
+func TestFederationClientService_SettleDelay(t *testing.T) {
+	service, _, sigs, _, _ := newTestFederation(t)
+	sigs.addSigned(2, time.Now())
+
+	if pushed, err := service.PushPending(context.Background()); err != nil || pushed != 0 {
+		t.Fatalf("expected recent signatures to wait, got %d, %v", pushed, err)
+	}
+}
+
+func TestFederationClientService_HubFailure(t *testing.T) {
+	service, repo, sigs, client, _ := newTestFederation(t)
+	ctx := context.Background()
+	sigs.addSigned(2, time.Now().Add(-time.Hour))
+	client.err = errors.New("hub unavailable")
+
+	if _, err := service.PushPending(ctx); err == nil {
+		t.Fatal("expected an error when the hub is unavailable")
+	}
+	if repo.pushes != 0 {
+		t.Error("a push rejected by the hub must not be recorded")
+	}
+	status, err := service.Status(ctx)
+	if err != nil || status.LastError != "hub unavailable" || status.InstanceID != "acme-fr" || status.PublicKey == "" {
+		t.Fatalf("unexpected status %+v, %v", status, err)
+	}
+
+	// The next run pushes the same signatures again and clears the error
+	client.err = nil
+	if pushed, err := service.PushPending(ctx); err != nil || pushed != 2 {
+		t.Fatalf("PushPending = %d, %v; want 2", pushed, err)
+	}
+	if status, _ := service.Status(ctx); status.LastError != "" {
+		t.Errorf("expected the error to be cleared, got %q", status.LastError)
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// federationMaxClockSkew bounds the age of a push accepted by the hub, limiting the replay of a
+// captured push to this window (replays store nothing new anyway)
+const federationMaxClockSkew = 10 * time.Minute
+
+// federationHubRepository stores the peers and what they pushed
+type federationHubRepository interface {
+	CreatePeer(ctx context.Context, peer *models.FederationPeer) error
+	ListPeers(ctx context.Context) ([]*models.FederationPeer, error)
+	GetPeerByInstanceID(ctx context.Context, instanceID string) (*models.FederationPeer, error)
+	DeletePeer(ctx context.Context, id int64) error
+	StorePush(ctx context.Context, peerID int64, push *models.FederationPush) (*models.FederationPushResult, error)
+	ListDocuments(ctx context.Context, instanceID string) ([]*models.FederatedDocument, error)
+}
+
+// FederationHubService receives the pushes of the peers of a federation: each peer is registered
+// with its instance ID and the public key it signs its pushes with
+type FederationHubService struct {
+	repo federationHubRepository
+	now  func() time.Time
+}
+
+// NewFederationHubService creates a new federation hub service
+func NewFederationHubService(repo federationHubRepository) *FederationHubService {
+	return &FederationHubService{repo: repo, now: time.Now}
+}
+
+// RegisterPeer allows an instance to push, authenticated by its base64 Ed25519 public key
+func (s *FederationHubService) RegisterPeer(ctx context.Context, instanceID, name, publicKey, createdBy string) (*models.FederationPeer, error) {
+	instanceID, publicKey = strings.TrimSpace(instanceID), strings.TrimSpace(publicKey)
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if !models.ValidFederationInstanceID(instanceID) || err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, models.ErrInvalidFederationPeer
+	}
+
+	peer := &models.FederationPeer{
+		InstanceID: instanceID,
+		Name:       strings.TrimSpace(name),
+		PublicKey:  publicKey,
+		CreatedBy:  createdBy,
+	}
+	if err := s.repo.CreatePeer(ctx, peer); err != nil {
+		return nil, err
+	}
+	return peer, nil
+}
+
+// ListPeers returns the registered peers
+func (s *FederationHubService) ListPeers(ctx context.Context) ([]*models.FederationPeer, error) {
+	return s.repo.ListPeers(ctx)
+}
+
+// DeletePeer removes a peer with the documents and signatures it pushed
+func (s *FederationHubService) DeletePeer(ctx context.Context, id int64) error {
+	return s.repo.DeletePeer(ctx, id)
+}
+
+// ListDocuments returns the documents of the peers, or of one peer when instanceID is set
+func (s *FederationHubService) ListDocuments(ctx context.Context, instanceID string) ([]*models.FederatedDocument, error) {
+	return s.repo.ListDocuments(ctx, instanceID)
+}
+
+// ReceivePush stores a push of instanceID after checking its signature against the key of the
+// peer. An unknown instance and a bad signature both return ErrFederationUnauthorized.
+func (s *FederationHubService) ReceivePush(ctx context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error) {
+	peer, err := s.repo.GetPeerByInstanceID(ctx, instanceID)
+	if errors.Is(err, models.ErrFederationPeerNotFound) {
+		return nil, models.ErrFederationUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.VerifyEd25519(peer.PublicKey, body, signature) {
+		return nil, models.ErrFederationUnauthorized
+	}
+
+	var push models.FederationPush
+	if err := json.Unmarshal(body, &push); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidFederationPush, err)
+	}
+	if err := push.Validate(instanceID); err != nil {
+		return nil, err
+	}
+	if skew := s.now().Sub(push.SentAt); skew > federationMaxClockSkew || skew < -federationMaxClockSkew {
+		return nil, fmt.Errorf("%w: sent at %s, outside of the accepted clock skew", models.ErrInvalidFederationPush, push.SentAt.Format(time.RFC3339))
+	}
+
+	return s.repo.StorePush(ctx, peer.ID, &push)
+}
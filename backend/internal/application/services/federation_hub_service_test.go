@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeFederationHubRepo struct {
+	peers      []*models.FederationPeer
+	documents  map[string]*models.FederationDocument
+	signatures map[string]models.FederationSignature
+}
+
+func newFakeFederationHubRepo() *fakeFederationHubRepo {
+	return &fakeFederationHubRepo{
+		documents:  map[string]*models.FederationDocument{},
+		signatures: map[string]models.FederationSignature{},
+	}
+}
+
+func (f *fakeFederationHubRepo) CreatePeer(_ context.Context, peer *models.FederationPeer) error {
+	for _, p := range f.peers {
+		if p.InstanceID == peer.InstanceID {
+			return models.ErrFederationPeerExists
+		}
+	}
+	peer.ID = int64(len(f.peers) + 1)
+	f.peers = append(f.peers, peer)
+	return nil
+}
+
+func (f *fakeFederationHubRepo) ListPeers(_ context.Context) ([]*models.FederationPeer, error) {
+	return f.peers, nil
+}
+
+func (f *fakeFederationHubRepo) GetPeerByInstanceID(_ context.Context, instanceID string) (*models.FederationPeer, error) {
+	for _, p := range f.peers {
+		if p.InstanceID == instanceID {
+			return p, nil
+		}
+	}
+	return nil, models.ErrFederationPeerNotFound
+}
+
+func (f *fakeFederationHubRepo) DeletePeer(_ context.Context, id int64) error {
+	return nil
+}
+
+func (f *fakeFederationHubRepo) StorePush(_ context.Context, peerID int64, push *models.FederationPush) (*models.FederationPushResult, error) {
+	result := &models.FederationPushResult{}
+	for i := range push.Documents {
+		f.documents[models.FederatedDocumentID(push.InstanceID, push.Documents[i].DocID)] = &push.Documents[i]
+		result.Documents++
+	}
+	for _, sig := range push.Signatures {
+		key := fmt.Sprintf("%d/%d", peerID, sig.ID)
+		if _, ok := f.signatures[key]; ok {
+			result.Duplicates++
+			continue
+		}
+		f.signatures[key] = sig
+		result.Signatures++
+	}
+	return result, nil
+}
+
+func (f *fakeFederationHubRepo) ListDocuments(_ context.Context, _ string) ([]*models.FederatedDocument, error) {
+	return nil, nil
+}
+
+func signedFederationPush(t *testing.T, signer *crypto.Ed25519Signer, push *models.FederationPush) ([]byte, string) {
+	t.Helper()
+	body, err := json.Marshal(push)
+	if err != nil {
+		t.Fatalf("encode push: %v", err)
+	}
+	return body, signer.Sign(body)
+}
+
+func TestFederationHubService_RegisterPeer(t *testing.T) {
+	signer, _ := crypto.NewEd25519Signer()
+	service := NewFederationHubService(newFakeFederationHubRepo())
+	ctx := context.Background()
+
+	peer, err := service.RegisterPeer(ctx, " acme-fr ", "Acme France", signer.GetPublicKey(), "admin@example.com")
+	if err != nil || peer.InstanceID != "acme-fr" {
+		t.Fatalf("RegisterPeer = %+v, %v", peer, err)
+	}
+	if _, err := service.RegisterPeer(ctx, "acme-fr", "", signer.GetPublicKey(), ""); !errors.Is(err, models.ErrFederationPeerExists) {
+		t.Errorf("expected ErrFederationPeerExists, got %v", err)
+	}
+	for _, input := range [][2]string{{"Acme/FR", signer.GetPublicKey()}, {"acme-de", "not-a-key"}, {"acme-de", "c2hvcnQ="}} {
+		if _, err := service.RegisterPeer(ctx, input[0], "", input[1], ""); !errors.Is(err, models.ErrInvalidFederationPeer) {
+			t.Errorf("RegisterPeer(%q, %q): expected ErrInvalidFederationPeer, got %v", input[0], input[1], err)
+		}
+	}
+}
+
+func TestFederationHubService_ReceivePush(t *testing.T) {
+	signer, _ := crypto.NewEd25519Signer()
+	other, _ := crypto.NewEd25519Signer()
+	repo := newFakeFederationHubRepo()
+	service := NewFederationHubService(repo)
+	ctx := context.Background()
+	if _, err := service.RegisterPeer(ctx, "acme-fr", "", signer.GetPublicKey(), ""); err != nil {
+		t.Fatalf("register peer: %v", err)
+	}
+
+	push := &models.FederationPush{
+		InstanceID: "acme-fr",
+		SentAt:     time.Now(),
+		Documents:  []models.FederationDocument{{DocID: "policy", ExpectedCount: 1}},
+		Signatures: []models.FederationSignature{{ID: 1, DocID: "policy", RecordHash: "hash", Signature: "sig"}},
+	}
+	body, signature := signedFederationPush(t, signer, push)
+
+	result, err := service.ReceivePush(ctx, "acme-fr", body, signature)
+	if err != nil || result.Signatures != 1 {
+		t.Fatalf("ReceivePush = %+v, %v", result, err)
+	}
+	// A retried push stores nothing twice
+	result, err = service.ReceivePush(ctx, "acme-fr", body, signature)
+	if err != nil || result.Signatures != 0 || result.Duplicates != 1 {
+		t.Fatalf("retried ReceivePush = %+v, %v", result, err)
+	}
+
+	// Unknown peers and other keys are rejected alike
+	if _, err := service.ReceivePush(ctx, "acme-de", body, signature); !errors.Is(err, models.ErrFederationUnauthorized) {
+		t.Errorf("unknown peer: expected ErrFederationUnauthorized, got %v", err)
+	}
+	if _, err := service.ReceivePush(ctx, "acme-fr", body, other.Sign(body)); !errors.Is(err, models.ErrFederationUnauthorized) {
+		t.Errorf("other key: expected ErrFederationUnauthorized, got %v", err)
+	}
+
+	// A peer cannot push for another instance, nor replay an old push
+	push.InstanceID = "acme-de"
+	body, signature = signedFederationPush(t, signer, push)
+	if _, err := service.ReceivePush(ctx, "acme-fr", body, signature); !errors.Is(err, models.ErrInvalidFederationPush) {
+		t.Errorf("other instance: expected ErrInvalidFederationPush, got %v", err)
+	}
+	push.InstanceID, push.SentAt = "acme-fr", time.Now().Add(-time.Hour)
+	body, signature = signedFederationPush(t, signer, push)
+	if _, err := service.ReceivePush(ctx, "acme-fr", body, signature); !errors.Is(err, models.ErrInvalidFederationPush) {
+		t.Errorf("old push: expected ErrInvalidFederationPush, got %v", err)
+	}
+}
@@ -0,0 +1,477 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	// ErrInvalidGitSource is returned when a Git source fails validation
+	ErrInvalidGitSource = errors.New("invalid git source")
+	// ErrGitSync wraps the errors of git itself (unreachable repository, unknown branch, credentials)
+	ErrGitSync = errors.New("git repository unavailable")
+	// ErrInvalidGitWebhook is returned when a push webhook is not signed with the source secret
+	ErrInvalidGitWebhook = errors.New("invalid git webhook signature")
+)
+
+// gitImportMIMETypes are the imported file types by extension
+var gitImportMIMETypes = map[string]string{
+	".md":       "text/markdown",
+	".markdown": "text/markdown",
+	".pdf":      "application/pdf",
+}
+
+var (
+	gitBranchPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+	gitSCPURLPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[A-Za-z0-9._~/-]+$`)
+)
+
+// gitSourceRepository defines the persistence of Git sources and their imported documents
+type gitSourceRepository interface {
+	Create(ctx context.Context, input models.GitSourceInput) (*models.GitSource, error)
+	Update(ctx context.Context, id int64, input models.GitSourceInput) (*models.GitSource, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.GitSource, error)
+	List(ctx context.Context) ([]*models.GitSource, error)
+	ListDue(ctx context.Context, syncedBefore time.Time) ([]*models.GitSource, error)
+	RequestSync(ctx context.Context, id int64, at time.Time) error
+	MarkSynced(ctx context.Context, id int64, commit string, at time.Time, syncErr string) error
+	ListDocuments(ctx context.Context, sourceID int64) ([]*models.GitSourceDocument, error)
+	UpsertDocument(ctx context.Context, doc *models.GitSourceDocument) error
+}
+
+// gitCheckout fetches the files of a repository branch
+type gitCheckout interface {
+	Fetch(ctx context.Context, sourceID int64, repoURL, branch string) (fs.FS, string, error)
+	Remove(sourceID int64) error
+}
+
+// gitImportDocumentService creates the documents of new files
+type gitImportDocumentService interface {
+	CreateDocument(ctx context.Context, req CreateDocumentRequest) (*models.Document, error)
+}
+
+// gitImportDocumentRepository updates the documents of changed files
+type gitImportDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	Update(ctx context.Context, docID string, input models.DocumentInput) (*models.Document, error)
+}
+
+// gitImportStorage keeps the content of the imported files
+type gitImportStorage interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	Type() string
+}
+
+// GitPush is a push webhook received for a source, with the headers carrying its signature
+type GitPush struct {
+	Body      []byte
+	Signature string // X-Hub-Signature-256 (GitHub, "sha256=" prefixed) or X-Gitea-Signature (Gitea, Forgejo)
+	Token     string // X-Gitlab-Token (GitLab)
+}
+
+// GitImportService imports Markdown and PDF files of Git repositories as documents
+type GitImportService struct {
+	repo        gitSourceRepository
+	checkout    gitCheckout
+	documents   gitImportDocumentService
+	docRepo     gitImportDocumentRepository
+	storage     gitImportStorage
+	maxFileSize int64
+}
+
+// NewGitImportService creates a new Git import service. Files larger than maxFileSizeMB are skipped.
+func NewGitImportService(repo gitSourceRepository, checkout gitCheckout, documents gitImportDocumentService, docRepo gitImportDocumentRepository, storage gitImportStorage, maxFileSizeMB int64) *GitImportService {
+	return &GitImportService{
+		repo:        repo,
+		checkout:    checkout,
+		documents:   documents,
+		docRepo:     docRepo,
+		storage:     storage,
+		maxFileSize: maxFileSizeMB * 1024 * 1024,
+	}
+}
+
+// CreateSource validates and creates a Git source
+func (s *GitImportService) CreateSource(ctx context.Context, input models.GitSourceInput) (*models.GitSource, error) {
+	if err := validateGitSource(&input); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, input)
+}
+
+// UpdateSource validates and updates a Git source, an empty webhook secret keeping the current one
+func (s *GitImportService) UpdateSource(ctx context.Context, id int64, input models.GitSourceInput) (*models.GitSource, error) {
+	if err := validateGitSource(&input); err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, id, input)
+}
+
+// DeleteSource deletes a Git source and its clone, the imported documents are kept
+func (s *GitImportService) DeleteSource(ctx context.Context, id int64) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkout.Remove(id); err != nil {
+		logger.Logger.Warn("Failed to remove git clone", "source_id", id, "error", err)
+	}
+	return nil
+}
+
+// GetSource retrieves a Git source
+func (s *GitImportService) GetSource(ctx context.Context, id int64) (*models.GitSource, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListSources lists the Git sources
+func (s *GitImportService) ListSources(ctx context.Context) ([]*models.GitSource, error) {
+	return s.repo.List(ctx)
+}
+
+// ListDocuments lists the documents imported from a source
+func (s *GitImportService) ListDocuments(ctx context.Context, id int64) ([]*models.GitSourceDocument, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocuments(ctx, id)
+}
+
+// ListDueSources lists the sources to sync: requested by a push webhook, or not synced for pollInterval
+func (s *GitImportService) ListDueSources(ctx context.Context, pollInterval time.Duration) ([]*models.GitSource, error) {
+	return s.repo.ListDue(ctx, time.Now().Add(-pollInterval))
+}
+
+// HandlePush checks the signature of a push webhook and requests a sync of the source when the
+// push is on its branch. It reports whether a sync was requested.
+func (s *GitImportService) HandlePush(ctx context.Context, id int64, push GitPush) (bool, error) {
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if !verifyGitWebhook(source.WebhookSecret, push) {
+		return false, ErrInvalidGitWebhook
+	}
+
+	var payload struct {
+		Ref string `json:"ref"`
+	}
+	if err := json.Unmarshal(push.Body, &payload); err != nil || payload.Ref != "refs/heads/"+source.Branch || !source.SyncEnabled {
+		return false, nil
+	}
+
+	if err := s.repo.RequestSync(ctx, id, time.Now()); err != nil {
+		return false, err
+	}
+	logger.Logger.Info("Git source sync requested by push webhook", "source_id", id, "ref", payload.Ref)
+	return true, nil
+}
+
+// SyncSource fetches the branch of a source and imports its matching files: new files become
+// documents, changed files update their document (content, checksum and version). Errors of git
+// itself are recorded on the source and wrapped in ErrGitSync.
+func (s *GitImportService) SyncSource(ctx context.Context, id int64) (*models.GitSyncResult, error) {
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	files, commit, err := s.checkout.Fetch(ctx, source.ID, source.RepoURL, source.Branch)
+	if err != nil {
+		if markErr := s.repo.MarkSynced(ctx, source.ID, "", start, err.Error()); markErr != nil {
+			return nil, markErr
+		}
+		return nil, fmt.Errorf("%w: %v", ErrGitSync, err)
+	}
+
+	result, err := s.importFiles(ctx, source, files, commit)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.MarkSynced(ctx, source.ID, commit, start, ""); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Git source synced",
+		"source_id", source.ID,
+		"commit", commit,
+		"created", len(result.Created),
+		"updated", len(result.Updated),
+		"unchanged", result.Unchanged)
+	return result, nil
+}
+
+// gitFile is a repository file matching a rule of the source
+type gitFile struct {
+	path string
+	rule models.GitPathRule
+	size int64
+}
+
+func (s *GitImportService) importFiles(ctx context.Context, source *models.GitSource, files fs.FS, commit string) (*models.GitSyncResult, error) {
+	result := &models.GitSyncResult{
+		SourceID: source.ID,
+		Commit:   commit,
+		Created:  []models.GitSyncChange{},
+		Updated:  []models.GitSyncChange{},
+		Missing:  []string{},
+		Skipped:  []string{},
+	}
+
+	var matched []gitFile
+	err := fs.WalkDir(files, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		// Symbolic links could point outside the repository
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rule := source.MatchRule(p)
+		if rule == nil {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		matched = append(matched, gitFile{path: p, rule: *rule, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repository files: %w", err)
+	}
+
+	existing, err := s.repo.ListDocuments(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]*models.GitSourceDocument, len(existing))
+	for _, doc := range existing {
+		imported[doc.Path] = doc
+	}
+
+	seen := make(map[string]bool, len(matched))
+	for _, file := range matched {
+		mimeType, ok := gitImportMIMETypes[strings.ToLower(path.Ext(file.path))]
+		if !ok || file.size > s.maxFileSize {
+			result.Skipped = append(result.Skipped, file.path)
+			continue
+		}
+		seen[file.path] = true
+
+		data, err := fs.ReadFile(files, file.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.path, err)
+		}
+		sum := sha256.Sum256(data)
+		checksum := hex.EncodeToString(sum[:])
+
+		previous := imported[file.path]
+		if previous != nil && previous.Checksum == checksum {
+			result.Unchanged++
+			continue
+		}
+
+		change, created, err := s.importFile(ctx, source, file, data, mimeType, checksum, commit, previous)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.Created = append(result.Created, *change)
+		} else {
+			result.Updated = append(result.Updated, *change)
+		}
+	}
+
+	for _, doc := range existing {
+		if !seen[doc.Path] {
+			result.Missing = append(result.Missing, doc.Path)
+		}
+	}
+	return result, nil
+}
+
+// importFile stores the content of a new or changed file and creates or updates its document.
+// It reports whether the document was created.
+func (s *GitImportService) importFile(ctx context.Context, source *models.GitSource, file gitFile, data []byte, mimeType, checksum, commit string, previous *models.GitSourceDocument) (*models.GitSyncChange, bool, error) {
+	key := fmt.Sprintf("git/%d/%s%s", source.ID, checksum[:16], strings.ToLower(path.Ext(file.path)))
+	if err := s.storage.Upload(ctx, key, bytes.NewReader(data), int64(len(data)), mimeType); err != nil {
+		return nil, false, fmt.Errorf("failed to store %s: %w", file.path, err)
+	}
+
+	var current *models.Document
+	if previous != nil {
+		doc, err := s.docRepo.GetByDocID(ctx, previous.DocID)
+		if err != nil {
+			s.discardUpload(ctx, key)
+			return nil, false, err
+		}
+		current = doc
+	}
+
+	record := &models.GitSourceDocument{
+		SourceID:  source.ID,
+		Path:      file.path,
+		Checksum:  checksum,
+		CommitSHA: commit,
+	}
+	change := &models.GitSyncChange{Path: file.path}
+
+	if current == nil {
+		// New file, or its document was deleted since the last sync
+		requireFullRead := file.rule.RequireFullRead
+		doc, err := s.documents.CreateDocument(ctx, CreateDocumentRequest{
+			Reference:         key,
+			Title:             gitDocumentTitle(file.path, data, mimeType),
+			CreatedBy:         source.CreatedBy,
+			RequireFullRead:   &requireFullRead,
+			StorageKey:        key,
+			StorageProvider:   s.storage.Type(),
+			FileSize:          int64(len(data)),
+			MimeType:          mimeType,
+			Checksum:          checksum,
+			ChecksumAlgorithm: "SHA-256",
+			OriginalFilename:  path.Base(file.path),
+		})
+		if err != nil {
+			s.discardUpload(ctx, key)
+			return nil, false, err
+		}
+		record.DocID = doc.DocID
+		change.DocID, change.Version = doc.DocID, doc.Version
+	} else {
+		input := documentInputFrom(current)
+		input.Checksum, input.ChecksumAlgorithm = checksum, "SHA-256"
+		input.StorageKey, input.StorageProvider = key, s.storage.Type()
+		input.FileSize, input.MimeType, input.OriginalFilename = int64(len(data)), mimeType, path.Base(file.path)
+
+		// The previous content stays in the storage, it is what earlier signers acknowledged
+		doc, err := s.docRepo.Update(ctx, current.DocID, input)
+		if err != nil {
+			s.discardUpload(ctx, key)
+			return nil, false, err
+		}
+
+		record.DocID = doc.DocID
+		record.ReacknowledgeSince = previous.ReacknowledgeSince
+		if file.rule.Reacknowledge {
+			now := time.Now()
+			record.ReacknowledgeSince = &now
+		}
+		change.DocID, change.Version, change.Reacknowledge = doc.DocID, doc.Version, file.rule.Reacknowledge
+	}
+
+	if err := s.repo.UpsertDocument(ctx, record); err != nil {
+		return nil, false, err
+	}
+	return change, current == nil, nil
+}
+
+func (s *GitImportService) discardUpload(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		logger.Logger.Warn("Failed to delete imported file from storage", "key", key, "error", err.Error())
+	}
+}
+
+// gitDocumentTitle uses the first heading of a Markdown file, the file name otherwise
+func gitDocumentTitle(filePath string, data []byte, mimeType string) string {
+	if mimeType == "text/markdown" {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(line, "# ") {
+				return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			}
+		}
+	}
+	base := path.Base(filePath)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// verifyGitWebhook checks the GitHub or Gitea HMAC-SHA256 signature of the body, or the GitLab token.
+// Sources without secret do not accept webhooks.
+func verifyGitWebhook(secret string, push GitPush) bool {
+	if secret == "" {
+		return false
+	}
+	if push.Token != "" {
+		return subtle.ConstantTimeCompare([]byte(push.Token), []byte(secret)) == 1
+	}
+	if push.Signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(push.Body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	signature := strings.TrimPrefix(push.Signature, "sha256=")
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
+
+func validateGitSource(input *models.GitSourceInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.RepoURL = strings.TrimSpace(input.RepoURL)
+	input.Branch = strings.TrimSpace(input.Branch)
+	if input.Branch == "" {
+		input.Branch = "main"
+	}
+
+	if input.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidGitSource)
+	}
+	if !isValidGitURL(input.RepoURL) {
+		return fmt.Errorf("%w: repository URL must use https://, ssh:// or user@host:path", ErrInvalidGitSource)
+	}
+	if !gitBranchPattern.MatchString(input.Branch) || strings.HasPrefix(input.Branch, "-") || strings.Contains(input.Branch, "..") {
+		return fmt.Errorf("%w: invalid branch name", ErrInvalidGitSource)
+	}
+	if len(input.Rules) == 0 {
+		return fmt.Errorf("%w: at least one path rule is required", ErrInvalidGitSource)
+	}
+	for i := range input.Rules {
+		input.Rules[i].Pattern = strings.TrimSpace(input.Rules[i].Pattern)
+		if !input.Rules[i].IsValid() {
+			return fmt.Errorf("%w: invalid path pattern %q", ErrInvalidGitSource, input.Rules[i].Pattern)
+		}
+	}
+	return nil
+}
+
+// isValidGitURL accepts the transports allowed to the checkout
+func isValidGitURL(raw string) bool {
+	if gitSCPURLPattern.MatchString(raw) {
+		return true
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || strings.HasPrefix(u.Host, "-") {
+		return false
+	}
+	return u.Scheme == "https" || u.Scheme == "ssh"
+}
@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeGitSourceRepo struct {
+	sources   map[int64]*models.GitSource
+	docs      map[string]*models.GitSourceDocument
+	requested bool
+	syncErr   string
+}
+
+func newFakeGitSourceRepo(source *models.GitSource) *fakeGitSourceRepo {
+	return &fakeGitSourceRepo{
+		sources: map[int64]*models.GitSource{source.ID: source},
+		docs:    make(map[string]*models.GitSourceDocument),
+	}
+}
+
+func (f *fakeGitSourceRepo) Create(_ context.Context, input models.GitSourceInput) (*models.GitSource, error) {
+	s := &models.GitSource{ID: int64(len(f.sources) + 1), Name: input.Name, RepoURL: input.RepoURL, Branch: input.Branch, Rules: input.Rules}
+	f.sources[s.ID] = s
+	return s, nil
+}
+
+func (f *fakeGitSourceRepo) Update(_ context.Context, id int64, input models.GitSourceInput) (*models.GitSource, error) {
+	s, ok := f.sources[id]
+	if !ok {
+		return nil, models.ErrGitSourceNotFound
+	}
+	s.Name, s.Branch, s.Rules = input.Name, input.Branch, input.Rules
+	return s, nil
+}
+
+func (f *fakeGitSourceRepo) Delete(_ context.Context, id int64) error {
+	delete(f.sources, id)
+	return nil
+}
+
+func (f *fakeGitSourceRepo) GetByID(_ context.Context, id int64) (*models.GitSource, error) {
+	s, ok := f.sources[id]
+	if !ok {
+		return nil, models.ErrGitSourceNotFound
+	}
+	return s, nil
+}
+
+func (f *fakeGitSourceRepo) List(_ context.Context) ([]*models.GitSource, error) {
+	var sources []*models.GitSource
+	for _, s := range f.sources {
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+func (f *fakeGitSourceRepo) ListDue(_ context.Context, _ time.Time) ([]*models.GitSource, error) {
+	return f.List(context.Background())
+}
+
+func (f *fakeGitSourceRepo) RequestSync(_ context.Context, _ int64, _ time.Time) error {
+	f.requested = true
+	return nil
+}
+
+func (f *fakeGitSourceRepo) MarkSynced(_ context.Context, id int64, commit string, _ time.Time, syncErr string) error {
+	f.syncErr = syncErr
+	if syncErr == "" {
+		f.sources[id].LastCommit = commit
+	}
+	return nil
+}
+
+func (f *fakeGitSourceRepo) ListDocuments(_ context.Context, _ int64) ([]*models.GitSourceDocument, error) {
+	var docs []*models.GitSourceDocument
+	for _, d := range f.docs {
+		docs = append(docs, d)
+	}
+	return docs, nil
+}
+
+func (f *fakeGitSourceRepo) UpsertDocument(_ context.Context, doc *models.GitSourceDocument) error {
+	f.docs[doc.Path] = doc
+	return nil
+}
+
+type fakeGitCheckout struct {
+	files  fstest.MapFS
+	commit string
+	err    error
+}
+
+func (f *fakeGitCheckout) Fetch(_ context.Context, _ int64, _, _ string) (fs.FS, string, error) {
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	return f.files, f.commit, nil
+}
+
+func (f *fakeGitCheckout) Remove(_ int64) error {
+	return nil
+}
+
+// fakeGitDocuments implements the document service and repository used by the import
+type fakeGitDocuments struct {
+	docs map[string]*models.Document
+}
+
+func (f *fakeGitDocuments) CreateDocument(_ context.Context, req CreateDocumentRequest) (*models.Document, error) {
+	doc := &models.Document{
+		DocID:           fmt.Sprintf("doc%d", len(f.docs)+1),
+		Title:           req.Title,
+		Checksum:        req.Checksum,
+		StorageKey:      req.StorageKey,
+		RequireFullRead: req.RequireFullRead != nil && *req.RequireFullRead,
+		Version:         1,
+	}
+	f.docs[doc.DocID] = doc
+	return doc, nil
+}
+
+func (f *fakeGitDocuments) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+func (f *fakeGitDocuments) Update(_ context.Context, docID string, input models.DocumentInput) (*models.Document, error) {
+	doc := f.docs[docID]
+	doc.Title, doc.Checksum, doc.StorageKey = input.Title, input.Checksum, input.StorageKey
+	doc.Version++
+	return doc, nil
+}
+
+type fakeGitStorage struct {
+	uploads map[string][]byte
+	deleted []string
+}
+
+func (f *fakeGitStorage) Upload(_ context.Context, key string, reader io.Reader, _ int64, _ string) error {
+	f.uploads[key], _ = io.ReadAll(reader)
+	return nil
+}
+
+func (f *fakeGitStorage) Delete(_ context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func (f *fakeGitStorage) Type() string { return "local" }
+
+func newTestGitImport(source *models.GitSource, checkout *fakeGitCheckout) (*GitImportService, *fakeGitSourceRepo, *fakeGitDocuments, *fakeGitStorage) {
+	repo := newFakeGitSourceRepo(source)
+	docs := &fakeGitDocuments{docs: make(map[string]*models.Document)}
+	storage := &fakeGitStorage{uploads: make(map[string][]byte)}
+	return NewGitImportService(repo, checkout, docs, docs, storage, 1), repo, docs, storage
+}
+
+func TestGitImportService_SyncSource(t *testing.T) {
+	source := &models.GitSource{
+		ID:        1,
+		RepoURL:   "https://git.example.com/acme/policies.git",
+		Branch:    "main",
+		CreatedBy: "admin@example.com",
+		Rules: []models.GitPathRule{
+			{Pattern: "policies/security/*.md", Reacknowledge: true, RequireFullRead: true},
+			{Pattern: "policies/**"},
+		},
+	}
+	checkout := &fakeGitCheckout{commit: "c1", files: fstest.MapFS{
+		"policies/security/passwords.md": {Data: []byte("---\n# Password policy\n\nUse a manager.\n")},
+		"policies/hr/leave.pdf":          {Data: []byte("%PDF-1.7 leave")},
+		"policies/hr/notes.txt":          {Data: []byte("not imported")},
+		"policies/hr/link.md":            {Data: []byte("/etc/passwd"), Mode: fs.ModeSymlink},
+		"src/main.go":                    {Data: []byte("package main")},
+	}}
+	service, repo, docs, storage := newTestGitImport(source, checkout)
+	ctx := context.Background()
+
+	result, err := service.SyncSource(ctx, 1)
+	if err != nil {
+		t.Fatalf("first sync err: %v", err)
+	}
+	if len(result.Created) != 2 || len(result.Updated) != 0 || result.Commit != "c1" {
+		t.Fatalf("expected 2 created documents, got %+v", result)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "policies/hr/notes.txt" {
+		t.Errorf("expected the text file skipped, got %v", result.Skipped)
+	}
+	if len(storage.uploads) != 2 || source.LastCommit != "c1" {
+		t.Errorf("expected 2 uploads and the commit recorded, got %d and %q", len(storage.uploads), source.LastCommit)
+	}
+
+	security := docs.docs[repo.docs["policies/security/passwords.md"].DocID]
+	if security.Title != "Password policy" || !security.RequireFullRead {
+		t.Errorf("unexpected security document %+v", security)
+	}
+	if leave := docs.docs[repo.docs["policies/hr/leave.pdf"].DocID]; leave.Title != "leave" {
+		t.Errorf("expected the PDF named after its file, got %q", leave.Title)
+	}
+
+	// The security policy changes and the leave policy is removed
+	oldKey := security.StorageKey
+	checkout.commit = "c2"
+	checkout.files = fstest.MapFS{
+		"policies/security/passwords.md": {Data: []byte("# Password policy\n\nUse a manager and MFA.\n")},
+	}
+	result, err = service.SyncSource(ctx, 1)
+	if err != nil {
+		t.Fatalf("second sync err: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 1 || len(result.Missing) != 1 {
+		t.Fatalf("expected 1 update and 1 missing file, got %+v", result)
+	}
+	updated := result.Updated[0]
+	if updated.Version != 2 || !updated.Reacknowledge || updated.DocID != security.DocID {
+		t.Errorf("unexpected update %+v", updated)
+	}
+	if security.StorageKey == oldKey || len(storage.deleted) != 0 {
+		t.Errorf("expected the content replaced and the old file kept, got key %q, deleted %v", security.StorageKey, storage.deleted)
+	}
+	if record := repo.docs["policies/security/passwords.md"]; record.ReacknowledgeSince == nil || record.CommitSHA != "c2" {
+		t.Errorf("expected re-acknowledgement flagged at c2, got %+v", record)
+	}
+
+	// Nothing changed since
+	result, err = service.SyncSource(ctx, 1)
+	if err != nil || result.Unchanged != 1 || len(result.Updated) != 0 {
+		t.Fatalf("expected the file unchanged, got %+v, %v", result, err)
+	}
+}
+
+func TestGitImportService_SyncSourceGitError(t *testing.T) {
+	source := &models.GitSource{ID: 1, Branch: "main", Rules: []models.GitPathRule{{Pattern: "**"}}}
+	service, repo, _, _ := newTestGitImport(source, &fakeGitCheckout{err: errors.New("git clone: exit status 128: repository not found")})
+
+	_, err := service.SyncSource(context.Background(), 1)
+	if !errors.Is(err, ErrGitSync) {
+		t.Fatalf("expected ErrGitSync, got %v", err)
+	}
+	if repo.syncErr == "" {
+		t.Error("expected the error recorded on the source")
+	}
+}
+
+func TestGitImportService_HandlePush(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/main","after":"c2"}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name      string
+		secret    string
+		disabled  bool
+		push      GitPush
+		requested bool
+		wantErr   error
+	}{
+		{"GitHub signature", "s3cret", false, GitPush{Body: body, Signature: "sha256=" + signature}, true, nil},
+		{"Gitea signature", "s3cret", false, GitPush{Body: body, Signature: signature}, true, nil},
+		{"GitLab token", "s3cret", false, GitPush{Body: body, Token: "s3cret"}, true, nil},
+		{"other branch", "s3cret", false, GitPush{Body: []byte(`{"ref":"refs/heads/dev"}`), Token: "s3cret"}, false, nil},
+		{"wrong signature", "s3cret", false, GitPush{Body: body, Signature: "sha256=00"}, false, ErrInvalidGitWebhook},
+		{"wrong token", "s3cret", false, GitPush{Body: body, Token: "guess"}, false, ErrInvalidGitWebhook},
+		{"sync disabled", "s3cret", true, GitPush{Body: body, Token: "s3cret"}, false, nil},
+		{"source without secret", "", false, GitPush{Body: body, Token: ""}, false, ErrInvalidGitWebhook},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &models.GitSource{ID: 1, Branch: "main", WebhookSecret: tt.secret, SyncEnabled: !tt.disabled}
+			service, repo, _, _ := newTestGitImport(source, &fakeGitCheckout{})
+
+			requested, err := service.HandlePush(context.Background(), 1, tt.push)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if requested != tt.requested || repo.requested != tt.requested {
+				t.Errorf("expected requested %v, got %v (repo %v)", tt.requested, requested, repo.requested)
+			}
+		})
+	}
+}
+
+func TestValidateGitSource(t *testing.T) {
+	rules := []models.GitPathRule{{Pattern: "policies/**/*.md"}}
+	tests := []struct {
+		name  string
+		input models.GitSourceInput
+		valid bool
+	}{
+		{"https", models.GitSourceInput{Name: "Policies", RepoURL: "https://github.com/acme/policies.git", Rules: rules}, true},
+		{"ssh", models.GitSourceInput{Name: "Policies", RepoURL: "ssh://git@gitlab.example.com/acme/policies.git", Rules: rules}, true},
+		{"scp-like", models.GitSourceInput{Name: "Policies", RepoURL: "git@github.com:acme/policies.git", Rules: rules}, true},
+		{"missing name", models.GitSourceInput{RepoURL: "https://github.com/acme/policies.git", Rules: rules}, false},
+		{"file transport", models.GitSourceInput{Name: "Policies", RepoURL: "file:///etc", Rules: rules}, false},
+		{"ext transport", models.GitSourceInput{Name: "Policies", RepoURL: "ext::sh -c touch% /tmp/pwned", Rules: rules}, false},
+		{"option as branch", models.GitSourceInput{Name: "Policies", RepoURL: "https://github.com/acme/policies.git", Branch: "--upload-pack=x", Rules: rules}, false},
+		{"no rules", models.GitSourceInput{Name: "Policies", RepoURL: "https://github.com/acme/policies.git"}, false},
+		{"bad pattern", models.GitSourceInput{Name: "Policies", RepoURL: "https://github.com/acme/policies.git", Rules: []models.GitPathRule{{Pattern: "[.md"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGitSource(&tt.input)
+			if tt.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidGitSource) {
+				t.Errorf("expected ErrInvalidGitSource, got %v", err)
+			}
+			if tt.valid && tt.input.Branch != "main" {
+				t.Errorf("expected the default branch, got %q", tt.input.Branch)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// Audit action recorded when an admin merges two identities (see web.AuditActionIdentityMerge)
+const auditActionIdentityMerge = "identity.merge"
+
+// identityMergeRepository stores the merges and moves the references of merged identities
+type identityMergeRepository interface {
+	ListSubs(ctx context.Context, email string) ([]string, error)
+	GetBySource(ctx context.Context, email string) (*models.IdentityMerge, error)
+	Resolve(ctx context.Context, sub, email string) (*models.IdentityMerge, error)
+	MoveReferences(ctx context.Context, sourceEmail, targetEmail string, sourceSubs []string) (*models.IdentityMergeSummary, error)
+	Retarget(ctx context.Context, fromEmail, targetEmail, targetSub string) (int64, error)
+	Save(ctx context.Context, merge *models.IdentityMerge) error
+	List(ctx context.Context, limit, offset int) ([]*models.IdentityMerge, error)
+}
+
+// identityAliasAdder records the source email of a merge as an alias of the target email, so that
+// the signatures of the source identity count for the target one
+type identityAliasAdder interface {
+	AddAlias(ctx context.Context, email, alias, createdBy string) (*models.EmailAlias, error)
+}
+
+// IdentityMergeService merges duplicate identities of a person, e.g. a Google account and magic
+// links sent to another address. Signatures keep the identity they were signed with; the merge
+// links them to the target identity through an email alias and resolves later logins of the
+// source identity to the target one.
+type IdentityMergeService struct {
+	repo    identityMergeRepository
+	aliases identityAliasAdder
+	audit   auditRecorder
+}
+
+// NewIdentityMergeService creates a new identity merge service
+func NewIdentityMergeService(repo identityMergeRepository, aliases identityAliasAdder, audit auditRecorder) *IdentityMergeService {
+	return &IdentityMergeService{repo: repo, aliases: aliases, audit: audit}
+}
+
+// Merge merges the source identity into the target one. Merging an email into itself merges its
+// subjects into one. The caller runs it within a transaction so a failed step leaves nothing behind.
+func (s *IdentityMergeService) Merge(ctx context.Context, input models.IdentityMergeInput, mergedBy string) (*models.IdentityMerge, error) {
+	source := strings.ToLower(strings.TrimSpace(input.SourceEmail))
+	target := strings.ToLower(strings.TrimSpace(input.TargetEmail))
+	if _, err := mail.ParseAddress(source); err != nil {
+		return nil, fmt.Errorf("%w: invalid source email", models.ErrInvalidIdentityMerge)
+	}
+	if _, err := mail.ParseAddress(target); err != nil {
+		return nil, fmt.Errorf("%w: invalid target email", models.ErrInvalidIdentityMerge)
+	}
+
+	// Merges do not chain: the target must be a live identity
+	targetMerge, err := s.repo.GetBySource(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if targetMerge != nil && targetMerge.TargetEmail != target {
+		return nil, fmt.Errorf("%w: %s is merged into %s", models.ErrIdentityMergeConflict, target, targetMerge.TargetEmail)
+	}
+	previous, err := s.repo.GetBySource(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.TargetEmail != target {
+		return nil, fmt.Errorf("%w: %s is merged into %s", models.ErrIdentityMergeConflict, source, previous.TargetEmail)
+	}
+
+	targetSubs, err := s.repo.ListSubs(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	targetSub := strings.TrimSpace(input.TargetSub)
+	switch {
+	case targetSub != "" && !slices.Contains(targetSubs, targetSub):
+		return nil, fmt.Errorf("%w: %s is not a subject of %s", models.ErrInvalidIdentityMerge, targetSub, target)
+	case targetSub == "" && len(targetSubs) > 0:
+		targetSub = targetSubs[0]
+	}
+
+	sourceSubs := targetSubs
+	if source != target {
+		if sourceSubs, err = s.repo.ListSubs(ctx, source); err != nil {
+			return nil, err
+		}
+	}
+	sourceSubs = slices.DeleteFunc(slices.Clone(sourceSubs), func(sub string) bool { return sub == targetSub })
+	if source == target && len(sourceSubs) == 0 {
+		return nil, fmt.Errorf("%w: %s has a single identity", models.ErrInvalidIdentityMerge, source)
+	}
+
+	aliasCreated := false
+	if source != target && previous == nil {
+		if _, err := s.aliases.AddAlias(ctx, target, source, mergedBy); err != nil {
+			if errors.Is(err, ErrInvalidEmailAlias) || errors.Is(err, models.ErrEmailAliasExists) {
+				return nil, fmt.Errorf("%w: %v", models.ErrIdentityMergeConflict, err)
+			}
+			return nil, err
+		}
+		aliasCreated = true
+	}
+
+	summary, err := s.repo.MoveReferences(ctx, source, target, sourceSubs)
+	if err != nil {
+		return nil, err
+	}
+	summary.AliasCreated = aliasCreated
+	if source != target {
+		if summary.MergesRetargeted, err = s.repo.Retarget(ctx, source, target, targetSub); err != nil {
+			return nil, err
+		}
+	}
+
+	merge := &models.IdentityMerge{
+		SourceEmail: source,
+		SourceSubs:  sourceSubs,
+		TargetEmail: target,
+		TargetSub:   targetSub,
+		Summary:     *summary,
+		MergedBy:    mergedBy,
+	}
+	if err := s.repo.Save(ctx, merge); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Identities merged",
+		"source_email", source,
+		"target_email", target,
+		"source_subs", len(sourceSubs),
+		"merged_by", mergedBy)
+	if s.audit != nil {
+		s.audit.Record(ctx, auditActionIdentityMerge, "user", target, mergedBy, map[string]any{
+			"source_email":      source,
+			"source_subs":       sourceSubs,
+			"target_sub":        targetSub,
+			"alias_created":     summary.AliasCreated,
+			"documents":         summary.Documents,
+			"questions":         summary.Questions,
+			"notifications":     summary.Notifications,
+			"preferences":       summary.Preferences,
+			"roles":             summary.Roles,
+			"roles_dropped":     summary.RolesDropped,
+			"memberships":       summary.Memberships,
+			"sessions_revoked":  summary.SessionsRevoked,
+			"merges_retargeted": summary.MergesRetargeted,
+		})
+	}
+	return merge, nil
+}
+
+// ListMerges returns the merges, newest first
+func (s *IdentityMergeService) ListMerges(ctx context.Context, limit, offset int) ([]*models.IdentityMerge, error) {
+	return s.repo.List(ctx, limit, offset)
+}
+
+// ResolveIdentity returns the identity a login signs in as: the target of the merge matching its
+// subject or email, or the login identity itself. A target that never signed in keeps the login subject.
+func (s *IdentityMergeService) ResolveIdentity(ctx context.Context, sub, email string) (string, string, error) {
+	merge, err := s.repo.Resolve(ctx, sub, email)
+	if err != nil || merge == nil {
+		return sub, email, err
+	}
+	if merge.TargetSub != "" {
+		sub = merge.TargetSub
+	}
+	return sub, merge.TargetEmail, nil
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeIdentityMergeRepo struct {
+	subs   map[string][]string
+	merges map[string]*models.IdentityMerge
+	moved  [][]string
+}
+
+func (f *fakeIdentityMergeRepo) ListSubs(_ context.Context, email string) ([]string, error) {
+	return f.subs[email], nil
+}
+
+func (f *fakeIdentityMergeRepo) GetBySource(_ context.Context, email string) (*models.IdentityMerge, error) {
+	return f.merges[email], nil
+}
+
+func (f *fakeIdentityMergeRepo) Resolve(_ context.Context, sub, email string) (*models.IdentityMerge, error) {
+	if m, ok := f.merges[strings.ToLower(email)]; ok {
+		return m, nil
+	}
+	for _, m := range f.merges {
+		if slices.Contains(m.SourceSubs, sub) {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeIdentityMergeRepo) MoveReferences(_ context.Context, sourceEmail, targetEmail string, sourceSubs []string) (*models.IdentityMergeSummary, error) {
+	f.moved = append(f.moved, append([]string{sourceEmail, targetEmail}, sourceSubs...))
+	return &models.IdentityMergeSummary{Documents: 2, SessionsRevoked: int64(len(sourceSubs))}, nil
+}
+
+func (f *fakeIdentityMergeRepo) Retarget(_ context.Context, fromEmail, targetEmail, targetSub string) (int64, error) {
+	var n int64
+	for _, m := range f.merges {
+		if m.TargetEmail == fromEmail && m.SourceEmail != targetEmail {
+			m.TargetEmail, m.TargetSub = targetEmail, targetSub
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeIdentityMergeRepo) Save(_ context.Context, merge *models.IdentityMerge) error {
+	merge.ID = int64(len(f.merges) + 1)
+	f.merges[merge.SourceEmail] = merge
+	return nil
+}
+
+func (f *fakeIdentityMergeRepo) List(context.Context, int, int) ([]*models.IdentityMerge, error) {
+	var out []*models.IdentityMerge
+	for _, m := range f.merges {
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+type fakeIdentityAliases struct {
+	aliases map[string]string
+	err     error
+}
+
+func (f *fakeIdentityAliases) AddAlias(_ context.Context, email, alias, createdBy string) (*models.EmailAlias, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.aliases[alias] = email
+	return &models.EmailAlias{Email: email, Alias: alias, CreatedBy: createdBy}, nil
+}
+
+func newTestIdentityMergeService() (*IdentityMergeService, *fakeIdentityMergeRepo, *fakeIdentityAliases, *fakeAuditRecorder) {
+	repo := &fakeIdentityMergeRepo{
+		subs: map[string][]string{
+			"alice@example.com":     {"google-alice"},
+			"alice.old@example.com": {"magic-alice-2", "magic-alice-1"},
+			"bob@example.com":       {"google-bob", "magic-bob"},
+		},
+		merges: map[string]*models.IdentityMerge{},
+	}
+	aliases := &fakeIdentityAliases{aliases: map[string]string{}}
+	audit := &fakeAuditRecorder{}
+	return NewIdentityMergeService(repo, aliases, audit), repo, aliases, audit
+}
+
+func TestIdentityMergeService_MergeEmails(t *testing.T) {
+	svc, _, aliases, audit := newTestIdentityMergeService()
+	ctx := context.Background()
+
+	merge, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: " Alice.Old@example.com ", TargetEmail: "alice@example.com"}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("merge err: %v", err)
+	}
+	if merge.TargetSub != "google-alice" || !slices.Equal(merge.SourceSubs, []string{"magic-alice-2", "magic-alice-1"}) {
+		t.Fatalf("unexpected merge: %+v", merge)
+	}
+	if !merge.Summary.AliasCreated || merge.Summary.Documents != 2 || aliases.aliases["alice.old@example.com"] != "alice@example.com" {
+		t.Fatalf("unexpected summary %+v or aliases %v", merge.Summary, aliases.aliases)
+	}
+	if !slices.Equal(audit.actions, []string{auditActionIdentityMerge}) {
+		t.Fatalf("unexpected audit actions: %v", audit.actions)
+	}
+
+	for _, login := range [][2]string{{"magic-alice-1", "alice.old@example.com"}, {"magic-alice-3", "Alice.Old@example.com"}} {
+		sub, email, err := svc.ResolveIdentity(ctx, login[0], login[1])
+		if err != nil || sub != "google-alice" || email != "alice@example.com" {
+			t.Fatalf("login %v resolved to %s %s (err %v)", login, sub, email, err)
+		}
+	}
+	sub, email, err := svc.ResolveIdentity(ctx, "google-bob", "bob@example.com")
+	if err != nil || sub != "google-bob" || email != "bob@example.com" {
+		t.Fatalf("unmerged login resolved to %s %s (err %v)", sub, email, err)
+	}
+
+	// Merging again into the same target is allowed and does not add the alias twice
+	aliases.err = models.ErrEmailAliasExists
+	merge, err = svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "alice.old@example.com", TargetEmail: "alice@example.com"}, "admin@example.com")
+	if err != nil || merge.Summary.AliasCreated {
+		t.Fatalf("unexpected second merge %+v (err %v)", merge, err)
+	}
+}
+
+func TestIdentityMergeService_MergeSubjects(t *testing.T) {
+	svc, repo, _, _ := newTestIdentityMergeService()
+	ctx := context.Background()
+
+	merge, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "bob@example.com", TargetEmail: "bob@example.com", TargetSub: "magic-bob"}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("merge err: %v", err)
+	}
+	if merge.TargetSub != "magic-bob" || !slices.Equal(merge.SourceSubs, []string{"google-bob"}) || merge.Summary.AliasCreated {
+		t.Fatalf("unexpected merge: %+v", merge)
+	}
+	if len(repo.moved) != 1 || !slices.Equal(repo.moved[0], []string{"bob@example.com", "bob@example.com", "google-bob"}) {
+		t.Fatalf("unexpected moves: %v", repo.moved)
+	}
+
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "alice@example.com", TargetEmail: "alice@example.com"}, "admin@example.com"); !errors.Is(err, models.ErrInvalidIdentityMerge) {
+		t.Fatalf("expected ErrInvalidIdentityMerge for a single identity, got %v", err)
+	}
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "bob@example.com", TargetEmail: "bob@example.com", TargetSub: "google-alice"}, "admin@example.com"); !errors.Is(err, models.ErrInvalidIdentityMerge) {
+		t.Fatalf("expected ErrInvalidIdentityMerge for a foreign subject, got %v", err)
+	}
+}
+
+func TestIdentityMergeService_Conflicts(t *testing.T) {
+	svc, repo, aliases, audit := newTestIdentityMergeService()
+	ctx := context.Background()
+
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "not-an-email", TargetEmail: "alice@example.com"}, "admin@example.com"); !errors.Is(err, models.ErrInvalidIdentityMerge) {
+		t.Fatalf("expected ErrInvalidIdentityMerge, got %v", err)
+	}
+
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "alice.old@example.com", TargetEmail: "alice@example.com"}, "admin@example.com"); err != nil {
+		t.Fatalf("merge err: %v", err)
+	}
+	// A merged identity can be neither a target nor merged elsewhere
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "bob@example.com", TargetEmail: "alice.old@example.com"}, "admin@example.com"); !errors.Is(err, models.ErrIdentityMergeConflict) {
+		t.Fatalf("expected ErrIdentityMergeConflict for a merged target, got %v", err)
+	}
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "alice.old@example.com", TargetEmail: "bob@example.com"}, "admin@example.com"); !errors.Is(err, models.ErrIdentityMergeConflict) {
+		t.Fatalf("expected ErrIdentityMergeConflict for a merged source, got %v", err)
+	}
+
+	// Merging the target into another identity retargets the earlier merge
+	merge, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "alice@example.com", TargetEmail: "bob@example.com"}, "admin@example.com")
+	if err != nil || merge.Summary.MergesRetargeted != 1 || repo.merges["alice.old@example.com"].TargetEmail != "bob@example.com" {
+		t.Fatalf("unexpected merge %+v (err %v)", merge, err)
+	}
+
+	aliases.err = ErrInvalidEmailAlias
+	if _, err := svc.Merge(ctx, models.IdentityMergeInput{SourceEmail: "carol@example.com", TargetEmail: "bob@example.com"}, "admin@example.com"); !errors.Is(err, models.ErrIdentityMergeConflict) {
+		t.Fatalf("expected ErrIdentityMergeConflict for an alias conflict, got %v", err)
+	}
+	if len(audit.actions) != 2 {
+		t.Fatalf("expected 2 audited merges, got %v", audit.actions)
+	}
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidIntegrationRequest is returned when an integration request fails validation
+var ErrInvalidIntegrationRequest = errors.New("invalid integration request")
+
+// ExternalRefHeader is sent with the webhook deliveries of an integration document,
+// so the calling system can match events with its own record
+const ExternalRefHeader = "X-Ackify-External-Ref"
+
+// defaultIntegrationEvents are the status events subscribed when none are requested
+var defaultIntegrationEvents = []string{"signature.created", "document.completed"}
+
+// integrationDocumentService defines the document operations used by integrations
+type integrationDocumentService interface {
+	FindByReference(ctx context.Context, ref string, refType string) (*models.Document, error)
+	CreateDocument(ctx context.Context, req CreateDocumentRequest) (*models.Document, error)
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// integrationSignerService defines the expected signer operations used by integrations
+type integrationSignerService interface {
+	AddExpectedSigners(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
+	GetSignerStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
+}
+
+// integrationWebhookService defines the webhook operations used by integrations
+type integrationWebhookService interface {
+	CreateWebhook(ctx context.Context, input models.WebhookInput) (*models.Webhook, error)
+}
+
+// IntegrationSigner is an expected signer given by an external system
+type IntegrationSigner struct {
+	Email     string `json:"email"`
+	Name      string `json:"name,omitempty"`
+	SignOrder *int   `json:"signOrder,omitempty"` // Position in a sequential workflow
+}
+
+// IntegrationWebhookRequest subscribes the calling system to the status events of the document
+type IntegrationWebhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events,omitempty"`
+}
+
+// IntegrationDocumentRequest creates a document with its signers in one call
+type IntegrationDocumentRequest struct {
+	URL         string                     `json:"url"`
+	Title       string                     `json:"title,omitempty"`
+	ExternalRef string                     `json:"externalRef,omitempty"` // ID of the document in the calling system
+	Signers     []IntegrationSigner        `json:"signers,omitempty"`
+	Webhook     *IntegrationWebhookRequest `json:"webhook,omitempty"`
+}
+
+// IntegrationWebhookSubscription describes the webhook created for a document
+type IntegrationWebhookSubscription struct {
+	ID     int64    `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// IntegrationDocumentStatus is the signing progress of a document
+type IntegrationDocumentStatus struct {
+	ExpectedCount  int     `json:"expectedCount"`
+	SignedCount    int     `json:"signedCount"`
+	PendingCount   int     `json:"pendingCount"`
+	CompletionRate float64 `json:"completionRate"`
+	Completed      bool    `json:"completed"`
+}
+
+// IntegrationDocumentResult is returned to the calling system
+type IntegrationDocumentResult struct {
+	DocID       string                          `json:"docId"`
+	Title       string                          `json:"title"`
+	URL         string                          `json:"url"`
+	ExternalRef string                          `json:"externalRef,omitempty"`
+	Created     bool                            `json:"created"`
+	SignURL     string                          `json:"signUrl"`
+	Status      IntegrationDocumentStatus       `json:"status"`
+	Webhook     *IntegrationWebhookSubscription `json:"webhook,omitempty"`
+}
+
+// IntegrationService creates documents on behalf of external systems authenticated by API key
+type IntegrationService struct {
+	documents integrationDocumentService
+	signers   integrationSignerService
+	webhooks  integrationWebhookService
+	baseURL   string
+}
+
+// NewIntegrationService creates a new integration service
+func NewIntegrationService(documents integrationDocumentService, signers integrationSignerService, webhooks integrationWebhookService, baseURL string) *IntegrationService {
+	return &IntegrationService{
+		documents: documents,
+		signers:   signers,
+		webhooks:  webhooks,
+		baseURL:   baseURL,
+	}
+}
+
+// CreateDocument registers a document, adds its expected signers and optionally subscribes
+// a webhook to its status events. A document already registered with the same URL is reused,
+// so retried calls do not create duplicates; the webhook is only created with the document.
+// Documents and signers are owned by the admin who created the API key.
+func (s *IntegrationService) CreateDocument(ctx context.Context, req IntegrationDocumentRequest, key *models.APIKey) (*IntegrationDocumentResult, error) {
+	contacts, err := validateIntegrationRequest(&req)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.documents.FindByReference(ctx, req.URL, string(ReferenceTypeURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up document: %w", err)
+	}
+
+	created := false
+	if doc == nil {
+		doc, err = s.documents.CreateDocument(ctx, CreateDocumentRequest{
+			Reference: req.URL,
+			Title:     req.Title,
+			CreatedBy: key.CreatedBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = true
+	}
+
+	if len(contacts) > 0 {
+		if err := s.signers.AddExpectedSigners(ctx, doc.DocID, contacts, key.CreatedBy); err != nil {
+			return nil, fmt.Errorf("failed to add expected signers: %w", err)
+		}
+	}
+
+	var subscription *IntegrationWebhookSubscription
+	if created && req.Webhook != nil {
+		subscription, err = s.subscribeWebhook(ctx, doc, req, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Logger.Info("Integration document registered",
+		"doc_id", doc.DocID,
+		"created", created,
+		"signers", len(contacts),
+		"api_key_id", key.ID,
+		"external_ref", req.ExternalRef)
+
+	result, err := s.buildResult(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	result.ExternalRef = req.ExternalRef
+	result.Created = created
+	result.Webhook = subscription
+	return result, nil
+}
+
+// GetDocumentStatus returns the signing progress of a document
+func (s *IntegrationService) GetDocumentStatus(ctx context.Context, docID string) (*IntegrationDocumentResult, error) {
+	doc, err := s.documents.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+	return s.buildResult(ctx, doc)
+}
+
+// subscribeWebhook creates a webhook scoped to the document
+func (s *IntegrationService) subscribeWebhook(ctx context.Context, doc *models.Document, req IntegrationDocumentRequest, key *models.APIKey) (*IntegrationWebhookSubscription, error) {
+	events := req.Webhook.Events
+	if len(events) == 0 {
+		events = defaultIntegrationEvents
+	}
+
+	var headers map[string]string
+	if req.ExternalRef != "" {
+		headers = map[string]string{ExternalRefHeader: req.ExternalRef}
+	}
+
+	docID := doc.DocID
+	webhook, err := s.webhooks.CreateWebhook(ctx, models.WebhookInput{
+		Title:       fmt.Sprintf("%s: %s", key.Name, doc.Title),
+		TargetURL:   req.Webhook.URL,
+		Secret:      req.Webhook.Secret,
+		Active:      true,
+		Events:      events,
+		Headers:     headers,
+		Description: fmt.Sprintf("Created by API key %q for document %s", key.Name, doc.DocID),
+		CreatedBy:   key.CreatedBy,
+		DocID:       &docID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &IntegrationWebhookSubscription{ID: webhook.ID, URL: webhook.TargetURL, Events: webhook.Events}, nil
+}
+
+func (s *IntegrationService) buildResult(ctx context.Context, doc *models.Document) (*IntegrationDocumentResult, error) {
+	stats, err := s.signers.GetSignerStats(ctx, doc.DocID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer stats: %w", err)
+	}
+
+	return &IntegrationDocumentResult{
+		DocID:   doc.DocID,
+		Title:   doc.Title,
+		URL:     doc.URL,
+		SignURL: s.baseURL + "/?doc=" + url.QueryEscape(doc.DocID),
+		Status: IntegrationDocumentStatus{
+			ExpectedCount:  stats.ExpectedCount,
+			SignedCount:    stats.SignedCount,
+			PendingCount:   stats.PendingCount,
+			CompletionRate: stats.CompletionRate,
+			Completed:      stats.ExpectedCount > 0 && stats.PendingCount == 0,
+		},
+	}, nil
+}
+
+// validateIntegrationRequest normalizes a request and returns its signers as contacts
+func validateIntegrationRequest(req *IntegrationDocumentRequest) ([]models.ContactInfo, error) {
+	req.URL = strings.TrimSpace(req.URL)
+	if detectReferenceType(req.URL) != ReferenceTypeURL {
+		return nil, fmt.Errorf("%w: url must be an http(s) URL", ErrInvalidIntegrationRequest)
+	}
+
+	contacts := make([]models.ContactInfo, 0, len(req.Signers))
+	for _, signer := range req.Signers {
+		email := strings.ToLower(strings.TrimSpace(signer.Email))
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, fmt.Errorf("%w: invalid signer email %q", ErrInvalidIntegrationRequest, signer.Email)
+		}
+		if signer.SignOrder != nil && *signer.SignOrder < 1 {
+			return nil, fmt.Errorf("%w: signOrder of %q must be a positive number", ErrInvalidIntegrationRequest, signer.Email)
+		}
+		contacts = append(contacts, models.ContactInfo{Email: email, Name: strings.TrimSpace(signer.Name), SignOrder: signer.SignOrder})
+	}
+
+	if req.Webhook != nil {
+		target, err := url.Parse(req.Webhook.URL)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return nil, fmt.Errorf("%w: webhook url must be an http(s) URL", ErrInvalidIntegrationRequest)
+		}
+		if req.Webhook.Secret == "" {
+			return nil, fmt.Errorf("%w: webhook secret is required", ErrInvalidIntegrationRequest)
+		}
+	}
+
+	return contacts, nil
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeIntegrationDocuments struct {
+	docs map[string]*models.Document // by URL
+}
+
+func (f *fakeIntegrationDocuments) FindByReference(_ context.Context, ref string, _ string) (*models.Document, error) {
+	return f.docs[ref], nil
+}
+
+func (f *fakeIntegrationDocuments) CreateDocument(_ context.Context, req CreateDocumentRequest) (*models.Document, error) {
+	doc := &models.Document{DocID: "doc" + string(rune('a'+len(f.docs))), URL: req.Reference, Title: req.Title, CreatedBy: req.CreatedBy}
+	f.docs[req.Reference] = doc
+	return doc, nil
+}
+
+func (f *fakeIntegrationDocuments) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	for _, doc := range f.docs {
+		if doc.DocID == docID {
+			return doc, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeIntegrationSigners struct {
+	signers map[string][]models.ContactInfo
+}
+
+func (f *fakeIntegrationSigners) AddExpectedSigners(_ context.Context, docID string, contacts []models.ContactInfo, _ string) error {
+	f.signers[docID] = append(f.signers[docID], contacts...)
+	return nil
+}
+
+func (f *fakeIntegrationSigners) GetSignerStats(_ context.Context, docID string) (*models.DocCompletionStats, error) {
+	n := len(f.signers[docID])
+	return &models.DocCompletionStats{DocID: docID, ExpectedCount: n, PendingCount: n}, nil
+}
+
+type fakeIntegrationWebhooks struct {
+	created []models.WebhookInput
+}
+
+func (f *fakeIntegrationWebhooks) CreateWebhook(_ context.Context, input models.WebhookInput) (*models.Webhook, error) {
+	f.created = append(f.created, input)
+	return &models.Webhook{ID: int64(len(f.created)), TargetURL: input.TargetURL, Events: input.Events, DocID: input.DocID}, nil
+}
+
+func TestIntegrationService_CreateDocument(t *testing.T) {
+	documents := &fakeIntegrationDocuments{docs: make(map[string]*models.Document)}
+	signers := &fakeIntegrationSigners{signers: make(map[string][]models.ContactInfo)}
+	webhooks := &fakeIntegrationWebhooks{}
+	svc := NewIntegrationService(documents, signers, webhooks, "https://ackify.example.com")
+	key := &models.APIKey{ID: 1, Name: "DMS", CreatedBy: "admin@example.com"}
+	ctx := context.Background()
+
+	req := IntegrationDocumentRequest{
+		URL:         "https://dms.example.com/policies/42.pdf",
+		Title:       "Security policy",
+		ExternalRef: "POL-42",
+		Signers:     []IntegrationSigner{{Email: " Alice@Example.com ", Name: "Alice"}},
+		Webhook:     &IntegrationWebhookRequest{URL: "https://dms.example.com/hooks", Secret: "s3cret"},
+	}
+
+	result, err := svc.CreateDocument(ctx, req, key)
+	if err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+	if !result.Created || result.ExternalRef != "POL-42" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.SignURL != "https://ackify.example.com/?doc="+result.DocID {
+		t.Errorf("unexpected sign URL %q", result.SignURL)
+	}
+	if got := signers.signers[result.DocID]; len(got) != 1 || got[0].Email != "alice@example.com" {
+		t.Errorf("expected normalized signer, got %+v", got)
+	}
+	if len(webhooks.created) != 1 {
+		t.Fatalf("expected one webhook, got %d", len(webhooks.created))
+	}
+	wh := webhooks.created[0]
+	if wh.DocID == nil || *wh.DocID != result.DocID {
+		t.Errorf("webhook should be scoped to %s, got %v", result.DocID, wh.DocID)
+	}
+	if wh.Headers[ExternalRefHeader] != "POL-42" || len(wh.Events) != len(defaultIntegrationEvents) {
+		t.Errorf("unexpected webhook input: %+v", wh)
+	}
+
+	// Retrying the same call reuses the document and does not subscribe twice
+	again, err := svc.CreateDocument(ctx, req, key)
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if again.Created || again.DocID != result.DocID || again.Webhook != nil {
+		t.Errorf("retry should reuse document without webhook, got %+v", again)
+	}
+	if len(webhooks.created) != 1 {
+		t.Errorf("expected no new webhook, got %d", len(webhooks.created))
+	}
+}
+
+func TestIntegrationService_CreateDocument_Validation(t *testing.T) {
+	svc := NewIntegrationService(
+		&fakeIntegrationDocuments{docs: make(map[string]*models.Document)},
+		&fakeIntegrationSigners{signers: make(map[string][]models.ContactInfo)},
+		&fakeIntegrationWebhooks{},
+		"https://ackify.example.com",
+	)
+	key := &models.APIKey{ID: 1, Name: "DMS", CreatedBy: "admin@example.com"}
+
+	tests := []struct {
+		name string
+		req  IntegrationDocumentRequest
+	}{
+		{"missing url", IntegrationDocumentRequest{}},
+		{"not a url", IntegrationDocumentRequest{URL: "policy-42"}},
+		{"invalid signer", IntegrationDocumentRequest{URL: "https://dms.example.com/a.pdf", Signers: []IntegrationSigner{{Email: "nope"}}}},
+		{"webhook without secret", IntegrationDocumentRequest{URL: "https://dms.example.com/a.pdf", Webhook: &IntegrationWebhookRequest{URL: "https://dms.example.com/hooks"}}},
+		{"webhook bad scheme", IntegrationDocumentRequest{URL: "https://dms.example.com/a.pdf", Webhook: &IntegrationWebhookRequest{URL: "ftp://dms.example.com", Secret: "s"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.CreateDocument(context.Background(), tt.req, key); !errors.Is(err, ErrInvalidIntegrationRequest) {
+				t.Fatalf("expected ErrInvalidIntegrationRequest, got %v", err)
+			}
+		})
+	}
+}
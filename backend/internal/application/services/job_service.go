@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	// ErrInvalidJob is returned when a job cannot be enqueued or listed as requested
+	ErrInvalidJob = errors.New("invalid job")
+	// ErrJobStateConflict is returned when a job is retried or cancelled from a status that does not allow it
+	ErrJobStateConflict = errors.New("job status does not allow this action")
+)
+
+const (
+	defaultJobListLimit = 100
+	maxJobListLimit     = 500
+)
+
+// jobRepository stores the background jobs
+type jobRepository interface {
+	Enqueue(ctx context.Context, input models.JobInput) (*models.Job, bool, error)
+	GetByID(ctx context.Context, id int64) (*models.Job, error)
+	List(ctx context.Context, filter models.JobFilter) ([]*models.Job, error)
+	CountByType(ctx context.Context) ([]models.JobCounts, error)
+	SetStatus(ctx context.Context, id int64, from []models.JobStatus, status models.JobStatus) (*models.Job, error)
+}
+
+// JobService enqueues background jobs and lets admins follow, retry and cancel them.
+// The jobs are run by the job runner of each instance.
+type JobService struct {
+	repo jobRepository
+}
+
+func NewJobService(repo jobRepository) *JobService {
+	return &JobService{repo: repo}
+}
+
+// Enqueue adds a job to the queue. With a dedup key, the queued or running job of the same
+// type and key is returned instead of a new one.
+func (s *JobService) Enqueue(ctx context.Context, input models.JobInput) (*models.Job, error) {
+	input.Type = strings.TrimSpace(input.Type)
+	if input.Type == "" {
+		return nil, fmt.Errorf("%w: type is required", ErrInvalidJob)
+	}
+	if input.MaxAttempts < 0 {
+		return nil, fmt.Errorf("%w: maxAttempts must be positive", ErrInvalidJob)
+	}
+
+	job, created, err := s.repo.Enqueue(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		logger.Logger.Debug("Job enqueued", "job_id", job.ID, "type", job.Type, "run_at", job.RunAt)
+	} else {
+		logger.Logger.Debug("Job already queued", "job_id", job.ID, "type", job.Type, "dedup_key", job.DedupKey)
+	}
+	return job, nil
+}
+
+// List returns the jobs matching the filter, newest first
+func (s *JobService) List(ctx context.Context, filter models.JobFilter) ([]*models.Job, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidJob, filter.Status)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultJobListLimit
+	}
+	if filter.Limit > maxJobListLimit {
+		filter.Limit = maxJobListLimit
+	}
+	return s.repo.List(ctx, filter)
+}
+
+// Get returns a job by ID
+func (s *JobService) Get(ctx context.Context, id int64) (*models.Job, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Counts returns the number of jobs of each type by status
+func (s *JobService) Counts(ctx context.Context) ([]models.JobCounts, error) {
+	return s.repo.CountByType(ctx)
+}
+
+// Retry queues a failed or cancelled job again with all its attempts
+func (s *JobService) Retry(ctx context.Context, id int64) (*models.Job, error) {
+	return s.transition(ctx, id, []models.JobStatus{models.JobFailed, models.JobCancelled}, models.JobQueued)
+}
+
+// Cancel prevents a queued job from running. Running jobs cannot be cancelled.
+func (s *JobService) Cancel(ctx context.Context, id int64) (*models.Job, error) {
+	return s.transition(ctx, id, []models.JobStatus{models.JobQueued}, models.JobCancelled)
+}
+
+func (s *JobService) transition(ctx context.Context, id int64, from []models.JobStatus, status models.JobStatus) (*models.Job, error) {
+	job, err := s.repo.SetStatus(ctx, id, from, status)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		logger.Logger.Info("Job status changed by admin", "job_id", id, "status", status)
+		return job, nil
+	}
+
+	// Tell a missing job from one in another status
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, ErrJobStateConflict
+}
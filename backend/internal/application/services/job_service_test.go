@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeJobRepo struct {
+	jobs   []*models.Job
+	filter models.JobFilter
+}
+
+func (f *fakeJobRepo) Enqueue(_ context.Context, input models.JobInput) (*models.Job, bool, error) {
+	for _, j := range f.jobs {
+		if input.DedupKey != "" && j.Type == input.Type && j.DedupKey == input.DedupKey &&
+			(j.Status == models.JobQueued || j.Status == models.JobRunning) {
+			return j, false, nil
+		}
+	}
+	job := &models.Job{ID: int64(len(f.jobs) + 1), Type: input.Type, Status: models.JobQueued, DedupKey: input.DedupKey, MaxAttempts: input.MaxAttempts}
+	f.jobs = append(f.jobs, job)
+	return job, true, nil
+}
+
+func (f *fakeJobRepo) GetByID(_ context.Context, id int64) (*models.Job, error) {
+	for _, j := range f.jobs {
+		if j.ID == id {
+			return j, nil
+		}
+	}
+	return nil, models.ErrJobNotFound
+}
+
+func (f *fakeJobRepo) List(_ context.Context, filter models.JobFilter) ([]*models.Job, error) {
+	f.filter = filter
+	return f.jobs, nil
+}
+
+func (f *fakeJobRepo) CountByType(_ context.Context) ([]models.JobCounts, error) {
+	return nil, nil
+}
+
+func (f *fakeJobRepo) SetStatus(_ context.Context, id int64, from []models.JobStatus, status models.JobStatus) (*models.Job, error) {
+	for _, j := range f.jobs {
+		if j.ID != id {
+			continue
+		}
+		for _, s := range from {
+			if j.Status == s {
+				j.Status = status
+				return j, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func TestJobService_Enqueue(t *testing.T) {
+	repo := &fakeJobRepo{}
+	svc := NewJobService(repo)
+	ctx := context.Background()
+
+	if _, err := svc.Enqueue(ctx, models.JobInput{Type: "  "}); !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("expected ErrInvalidJob without type, got %v", err)
+	}
+
+	first, err := svc.Enqueue(ctx, models.JobInput{Type: "document.checksum", DedupKey: "doc-1"})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	second, err := svc.Enqueue(ctx, models.JobInput{Type: "document.checksum", DedupKey: "doc-1"})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if second.ID != first.ID || len(repo.jobs) != 1 {
+		t.Errorf("expected the queued job to be reused, got %d jobs", len(repo.jobs))
+	}
+}
+
+func TestJobService_List(t *testing.T) {
+	repo := &fakeJobRepo{}
+	svc := NewJobService(repo)
+	ctx := context.Background()
+
+	if _, err := svc.List(ctx, models.JobFilter{Status: "stuck"}); !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("expected ErrInvalidJob for an unknown status, got %v", err)
+	}
+	if _, err := svc.List(ctx, models.JobFilter{Status: models.JobFailed}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if repo.filter.Limit != defaultJobListLimit {
+		t.Errorf("expected the default limit, got %d", repo.filter.Limit)
+	}
+	if _, err := svc.List(ctx, models.JobFilter{Limit: 10000}); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if repo.filter.Limit != maxJobListLimit {
+		t.Errorf("expected the limit to be capped, got %d", repo.filter.Limit)
+	}
+}
+
+func TestJobService_RetryAndCancel(t *testing.T) {
+	repo := &fakeJobRepo{jobs: []*models.Job{
+		{ID: 1, Status: models.JobFailed},
+		{ID: 2, Status: models.JobQueued},
+		{ID: 3, Status: models.JobRunning},
+	}}
+	svc := NewJobService(repo)
+	ctx := context.Background()
+
+	job, err := svc.Retry(ctx, 1)
+	if err != nil || job.Status != models.JobQueued {
+		t.Errorf("expected the failed job to be queued, got %+v (%v)", job, err)
+	}
+	job, err = svc.Cancel(ctx, 2)
+	if err != nil || job.Status != models.JobCancelled {
+		t.Errorf("expected the queued job to be cancelled, got %+v (%v)", job, err)
+	}
+
+	if _, err := svc.Cancel(ctx, 3); !errors.Is(err, ErrJobStateConflict) {
+		t.Errorf("expected a running job not to be cancelled, got %v", err)
+	}
+	if _, err := svc.Retry(ctx, 3); !errors.Is(err, ErrJobStateConflict) {
+		t.Errorf("expected a running job not to be retried, got %v", err)
+	}
+	if _, err := svc.Retry(ctx, 42); !errors.Is(err, models.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
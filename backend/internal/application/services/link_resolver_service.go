@@ -0,0 +1,428 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var (
+	// ErrLinkNotSupported is returned for links no configured resolver handles
+	ErrLinkNotSupported = errors.New("link is not a page or file of a configured Confluence, SharePoint or Google Drive site")
+	// ErrLinkResolve wraps the errors of the Confluence, SharePoint or Google Drive API
+	ErrLinkResolve = errors.New("link metadata unavailable")
+	// ErrInvalidLinkNotification is returned for a change notification whose client state does not match
+	ErrInvalidLinkNotification = errors.New("invalid link change notification")
+)
+
+// linkCheckBatchSize bounds the link sources checked, and the subscriptions renewed, by one worker run
+const linkCheckBatchSize = 100
+
+// linkSourceRepository stores the metadata of the pages documents link to
+type linkSourceRepository interface {
+	Upsert(ctx context.Context, source *models.DocumentLinkSource) error
+	GetByDocID(ctx context.Context, docID string) (*models.DocumentLinkSource, error)
+	ListDue(ctx context.Context, checkedBefore time.Time, limit int) ([]*models.DocumentLinkSource, error)
+	MarkNotified(ctx context.Context, docID string, at time.Time) error
+	Delete(ctx context.Context, docID string) error
+}
+
+// linkResolver reads the metadata of the pages of one host
+type linkResolver interface {
+	Matches(link *url.URL) bool
+	Resolve(ctx context.Context, link *url.URL) (*models.LinkMetadata, error)
+}
+
+// linkWatcher subscribes to the change notifications of the files of one host,
+// implemented by the resolvers of SharePoint and Google Drive
+type linkWatcher interface {
+	Watch(ctx context.Context, link *url.URL, notificationURL, clientState string) (*models.LinkSubscription, error)
+	Unwatch(ctx context.Context, sub *models.LinkSubscription) error
+}
+
+// linkSubscriptionRepository stores the change notification subscriptions of the linked files
+type linkSubscriptionRepository interface {
+	Upsert(ctx context.Context, sub *models.LinkSubscription) error
+	GetByDocID(ctx context.Context, docID string) (*models.LinkSubscription, error)
+	GetBySubscriptionID(ctx context.Context, provider, subscriptionID string) (*models.LinkSubscription, error)
+	ListExpiring(ctx context.Context, before time.Time, limit int) ([]*models.LinkSubscription, error)
+	Delete(ctx context.Context, docID string) error
+}
+
+// linkSignatureReader tells whether a document was signed
+type linkSignatureReader interface {
+	GetLastSignature(ctx context.Context, docID string) (*models.Signature, error)
+}
+
+// linkDocumentRepository reads documents and keeps their title in sync with their page
+type linkDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	Update(ctx context.Context, docID string, input models.DocumentInput) (*models.Document, error)
+}
+
+// linkChangeNotifier alerts the document owner that the linked page changed
+type linkChangeNotifier interface {
+	OnSourceChanged(ctx context.Context, docID string, source *models.DocumentLinkSource) error
+}
+
+// linkWebhookPublisher publishes the document.source_changed event
+type linkWebhookPublisher interface {
+	Publish(ctx context.Context, eventType string, payload map[string]interface{}) error
+}
+
+// LinkResolverService resolves the title, version and content hash of the Confluence pages,
+// SharePoint and Google Drive files documents link to, and watches them for changes
+type LinkResolverService struct {
+	repo          linkSourceRepository
+	docRepo       linkDocumentRepository
+	resolvers     map[string]linkResolver
+	notifier      linkChangeNotifier
+	publisher     linkWebhookPublisher
+	signatures    linkSignatureReader
+	subscriptions linkSubscriptionRepository
+	notifyURL     string // Base of the notification endpoints, one per provider
+	now           func() time.Time
+}
+
+// NewLinkResolverService creates the service; notifier and publisher may be nil to disable the alerts
+func NewLinkResolverService(repo linkSourceRepository, docRepo linkDocumentRepository, notifier linkChangeNotifier, publisher linkWebhookPublisher) *LinkResolverService {
+	return &LinkResolverService{
+		repo:      repo,
+		docRepo:   docRepo,
+		resolvers: make(map[string]linkResolver),
+		notifier:  notifier,
+		publisher: publisher,
+		now:       time.Now,
+	}
+}
+
+// SetResolver registers the resolver of a provider
+func (s *LinkResolverService) SetResolver(provider string, resolver linkResolver) {
+	s.resolvers[provider] = resolver
+}
+
+// SetSignatureReader bumps the version of a signed document when its source changes, so that
+// its signatures are known to cover an earlier content
+func (s *LinkResolverService) SetSignatureReader(signatures linkSignatureReader) {
+	s.signatures = signatures
+}
+
+// EnablePushNotifications subscribes to the change notifications of the hosts supporting them;
+// notifications are posted to notifyURL/{provider}
+func (s *LinkResolverService) EnablePushNotifications(subscriptions linkSubscriptionRepository, notifyURL string) {
+	s.subscriptions = subscriptions
+	s.notifyURL = strings.TrimSuffix(notifyURL, "/")
+}
+
+// Providers returns the configured providers, sorted
+func (s *LinkResolverService) Providers() []string {
+	providers := make([]string, 0, len(s.resolvers))
+	for provider := range s.resolvers {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// resolverFor finds the resolver handling a link
+func (s *LinkResolverService) resolverFor(rawURL string) (string, linkResolver, *url.URL) {
+	link, err := url.Parse(rawURL)
+	if err != nil || link.Host == "" {
+		return "", nil, nil
+	}
+	for _, provider := range s.Providers() {
+		if s.resolvers[provider].Matches(link) {
+			return provider, s.resolvers[provider], link
+		}
+	}
+	return "", nil, nil
+}
+
+// Preview resolves the metadata of a link without tracking it
+func (s *LinkResolverService) Preview(ctx context.Context, rawURL string) (*models.LinkMetadata, error) {
+	_, resolver, link := s.resolverFor(rawURL)
+	if resolver == nil {
+		return nil, ErrLinkNotSupported
+	}
+	meta, err := resolver.Resolve(ctx, link)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLinkResolve, err)
+	}
+	return meta, nil
+}
+
+// ResolveLink resolves a document link when a resolver handles it. A failed resolution is
+// reported in LastError so that the link is still tracked and checked again later.
+func (s *LinkResolverService) ResolveLink(ctx context.Context, rawURL string) (*models.DocumentLinkSource, bool) {
+	provider, resolver, link := s.resolverFor(rawURL)
+	if resolver == nil {
+		return nil, false
+	}
+
+	source := &models.DocumentLinkSource{URL: rawURL, LastCheckedAt: s.now()}
+	meta, err := resolver.Resolve(ctx, link)
+	if err != nil {
+		logger.Logger.Warn("Failed to resolve document link", "provider", provider, "url", rawURL, "error", err.Error())
+		source.Provider = provider
+		source.LastError = err.Error()
+		return source, true
+	}
+	source.LinkMetadata = *meta
+	return source, true
+}
+
+// TrackLink records the link source of a document created with it
+func (s *LinkResolverService) TrackLink(ctx context.Context, source *models.DocumentLinkSource) error {
+	if err := s.repo.Upsert(ctx, source); err != nil {
+		return err
+	}
+	s.ensureWatched(ctx, source)
+	return nil
+}
+
+// GetSource retrieves the tracked link source of a document
+func (s *LinkResolverService) GetSource(ctx context.Context, docID string) (*models.DocumentLinkSource, error) {
+	return s.repo.GetByDocID(ctx, docID)
+}
+
+// ListDueSources lists the link sources notified of a change, then those not checked for the check interval
+func (s *LinkResolverService) ListDueSources(ctx context.Context, checkInterval time.Duration) ([]*models.DocumentLinkSource, error) {
+	return s.repo.ListDue(ctx, s.now().Add(-checkInterval), linkCheckBatchSize)
+}
+
+// Refresh resolves the current link of a document again. When the page changed since the last
+// resolution, the change is recorded, the document title follows the page title unless it was
+// edited, the document version is bumped when it was signed, and the owner is alerted.
+// A failed resolution keeps the last known metadata and is reported in LastError.
+func (s *LinkResolverService) Refresh(ctx context.Context, docID string) (*models.DocumentLinkSource, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	previous, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil && !errors.Is(err, models.ErrLinkSourceNotFound) {
+		return nil, err
+	}
+
+	source, ok := s.ResolveLink(ctx, doc.URL)
+	if !ok {
+		if previous != nil {
+			// The document now links elsewhere
+			if err := s.repo.Delete(ctx, docID); err != nil {
+				return nil, err
+			}
+			s.unwatch(ctx, docID)
+		}
+		return nil, ErrLinkNotSupported
+	}
+	source.DocID = docID
+	if previous != nil && previous.URL != source.URL {
+		// The document now links to another file, watched instead once recorded
+		s.unwatch(ctx, docID)
+	}
+
+	changed := false
+	if previous != nil && previous.URL == source.URL {
+		source.PreviousVersion, source.ChangedAt = previous.PreviousVersion, previous.ChangedAt
+		switch {
+		case source.LastError != "":
+			source.LinkMetadata = previous.LinkMetadata
+		case previous.ContentHash != "" && source.Differs(&previous.LinkMetadata):
+			changed = true
+			now := s.now()
+			source.PreviousVersion, source.ChangedAt = previous.Version, &now
+		}
+	}
+
+	retitle := source.LastError == "" && source.Title != "" && previous != nil && doc.Title == previous.Title && doc.Title != source.Title
+	bump := false
+	if changed && s.signatures != nil {
+		last, err := s.signatures.GetLastSignature(ctx, docID)
+		if err != nil {
+			return nil, err
+		}
+		bump = last != nil
+	}
+
+	// Any update of the document bumps its version
+	if retitle || bump {
+		input := documentInputFrom(doc)
+		if retitle {
+			input.Title = source.Title
+		}
+		updated, err := s.docRepo.Update(ctx, docID, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update document: %w", err)
+		}
+		doc = updated
+	}
+
+	if err := s.repo.Upsert(ctx, source); err != nil {
+		return nil, err
+	}
+	s.ensureWatched(ctx, source)
+
+	if changed {
+		logger.Logger.Info("Document link source changed", "doc_id", docID, "provider", source.Provider,
+			"previous_version", source.PreviousVersion, "version", source.Version, "document_version", doc.Version)
+		s.alert(ctx, docID, source, doc.Version)
+	}
+	return source, nil
+}
+
+// HandleNotification checks the client state of a change notification of provider and marks the
+// source of its document for the next run of the link check worker, as hosts expect a quick answer
+func (s *LinkResolverService) HandleNotification(ctx context.Context, provider, subscriptionID, clientState string) error {
+	if s.subscriptions == nil {
+		return models.ErrSubscriptionNotFound
+	}
+	sub, err := s.subscriptions.GetBySubscriptionID(ctx, provider, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(sub.ClientState), []byte(clientState)) != 1 {
+		return ErrInvalidLinkNotification
+	}
+
+	return s.repo.MarkNotified(ctx, sub.DocID, s.now())
+}
+
+// RenewSubscriptions replaces the subscriptions expiring within renewBefore
+func (s *LinkResolverService) RenewSubscriptions(ctx context.Context, renewBefore time.Duration) error {
+	if s.subscriptions == nil {
+		return nil
+	}
+	subs, err := s.subscriptions.ListExpiring(ctx, s.now().Add(renewBefore), linkCheckBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, sub := range subs {
+		source, err := s.repo.GetByDocID(ctx, sub.DocID)
+		if errors.Is(err, models.ErrLinkSourceNotFound) {
+			s.unwatch(ctx, sub.DocID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.watch(ctx, source, sub); err != nil {
+			logger.Logger.Warn("Failed to renew link subscription", "doc_id", sub.DocID, "provider", sub.Provider, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+// ensureWatched subscribes to the changes of a tracked file not subscribed yet; failures are only
+// logged, the file is still checked periodically
+func (s *LinkResolverService) ensureWatched(ctx context.Context, source *models.DocumentLinkSource) {
+	if s.subscriptions == nil {
+		return
+	}
+	if _, ok := s.resolvers[source.Provider].(linkWatcher); !ok {
+		return
+	}
+	current, err := s.subscriptions.GetByDocID(ctx, source.DocID)
+	if err != nil && !errors.Is(err, models.ErrSubscriptionNotFound) {
+		logger.Logger.Warn("Failed to get link subscription", "doc_id", source.DocID, "error", err.Error())
+		return
+	}
+	if current != nil && current.Provider == source.Provider {
+		return
+	}
+	if err := s.watch(ctx, source, current); err != nil {
+		logger.Logger.Warn("Failed to subscribe to link changes", "doc_id", source.DocID, "provider", source.Provider, "error", err.Error())
+	}
+}
+
+// watch subscribes to the changes of the file of source, replacing the previous subscription
+func (s *LinkResolverService) watch(ctx context.Context, source *models.DocumentLinkSource, previous *models.LinkSubscription) error {
+	provider, resolver, link := s.resolverFor(source.URL)
+	watcher, ok := resolver.(linkWatcher)
+	if !ok {
+		return ErrLinkNotSupported
+	}
+
+	state := make([]byte, 32)
+	if _, err := rand.Read(state); err != nil {
+		return fmt.Errorf("failed to generate client state: %w", err)
+	}
+	sub, err := watcher.Watch(ctx, link, s.notifyURL+"/"+provider, hex.EncodeToString(state))
+	if err != nil {
+		return err
+	}
+	sub.DocID = source.DocID
+	if err := s.subscriptions.Upsert(ctx, sub); err != nil {
+		return err
+	}
+
+	if previous != nil {
+		s.stopSubscription(ctx, previous)
+	}
+	return nil
+}
+
+// unwatch cancels the subscription of a document that no longer links to a watched file
+func (s *LinkResolverService) unwatch(ctx context.Context, docID string) {
+	if s.subscriptions == nil {
+		return
+	}
+	sub, err := s.subscriptions.GetByDocID(ctx, docID)
+	if err != nil {
+		return
+	}
+	if err := s.subscriptions.Delete(ctx, docID); err != nil {
+		logger.Logger.Warn("Failed to delete link subscription", "doc_id", docID, "error", err.Error())
+		return
+	}
+	s.stopSubscription(ctx, sub)
+}
+
+// stopSubscription cancels a subscription at its host; left over, it only expires
+func (s *LinkResolverService) stopSubscription(ctx context.Context, sub *models.LinkSubscription) {
+	watcher, ok := s.resolvers[sub.Provider].(linkWatcher)
+	if !ok {
+		return
+	}
+	if err := watcher.Unwatch(ctx, sub); err != nil {
+		logger.Logger.Warn("Failed to cancel link subscription", "doc_id", sub.DocID, "provider", sub.Provider, "error", err.Error())
+	}
+}
+
+// alert notifies the owner and publishes the change, failures are only logged
+func (s *LinkResolverService) alert(ctx context.Context, docID string, source *models.DocumentLinkSource, docVersion int) {
+	if s.notifier != nil {
+		if err := s.notifier.OnSourceChanged(ctx, docID, source); err != nil {
+			logger.Logger.Warn("Failed to notify link source change", "doc_id", docID, "error", err.Error())
+		}
+	}
+	if s.publisher != nil {
+		err := s.publisher.Publish(ctx, "document.source_changed", map[string]interface{}{
+			"doc_id":           docID,
+			"provider":         source.Provider,
+			"url":              source.URL,
+			"title":            source.Title,
+			"previous_version": source.PreviousVersion,
+			"version":          source.Version,
+			"content_hash":     source.ContentHash,
+			"document_version": docVersion,
+		})
+		if err != nil {
+			logger.Logger.Warn("Failed to publish link source change", "doc_id", docID, "error", err.Error())
+		}
+	}
+}
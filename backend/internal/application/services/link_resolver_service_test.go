@@ -0,0 +1,425 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeLinkSourceRepo struct {
+	sources map[string]*models.DocumentLinkSource
+}
+
+func (f *fakeLinkSourceRepo) Upsert(_ context.Context, source *models.DocumentLinkSource) error {
+	copied := *source
+	f.sources[source.DocID] = &copied
+	return nil
+}
+
+func (f *fakeLinkSourceRepo) GetByDocID(_ context.Context, docID string) (*models.DocumentLinkSource, error) {
+	source, ok := f.sources[docID]
+	if !ok {
+		return nil, models.ErrLinkSourceNotFound
+	}
+	copied := *source
+	return &copied, nil
+}
+
+func (f *fakeLinkSourceRepo) ListDue(_ context.Context, checkedBefore time.Time, _ int) ([]*models.DocumentLinkSource, error) {
+	var due []*models.DocumentLinkSource
+	for _, source := range f.sources {
+		if source.NotifiedAt != nil || source.LastCheckedAt.Before(checkedBefore) {
+			due = append(due, source)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeLinkSourceRepo) MarkNotified(_ context.Context, docID string, at time.Time) error {
+	if source, ok := f.sources[docID]; ok {
+		source.NotifiedAt = &at
+	}
+	return nil
+}
+
+func (f *fakeLinkSourceRepo) Delete(_ context.Context, docID string) error {
+	delete(f.sources, docID)
+	return nil
+}
+
+type fakeLinkResolver struct {
+	host string
+	meta *models.LinkMetadata
+	err  error
+}
+
+func (f *fakeLinkResolver) Matches(link *url.URL) bool {
+	return link.Host == f.host
+}
+
+func (f *fakeLinkResolver) Resolve(_ context.Context, _ *url.URL) (*models.LinkMetadata, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	copied := *f.meta
+	return &copied, nil
+}
+
+type fakeLinkDocRepo struct {
+	docs map[string]*models.Document
+}
+
+func (f *fakeLinkDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+func (f *fakeLinkDocRepo) Update(_ context.Context, docID string, input models.DocumentInput) (*models.Document, error) {
+	doc := *f.docs[docID]
+	doc.Title, doc.URL = input.Title, input.URL
+	doc.Version++
+	f.docs[docID] = &doc
+	return &doc, nil
+}
+
+type fakeLinkNotifier struct {
+	changes []*models.DocumentLinkSource
+}
+
+func (f *fakeLinkNotifier) OnSourceChanged(_ context.Context, _ string, source *models.DocumentLinkSource) error {
+	f.changes = append(f.changes, source)
+	return nil
+}
+
+type fakeLinkPublisher struct {
+	events []string
+}
+
+func (f *fakeLinkPublisher) Publish(_ context.Context, eventType string, _ map[string]interface{}) error {
+	f.events = append(f.events, eventType)
+	return nil
+}
+
+const testPageURL = "https://acme.atlassian.net/wiki/spaces/SEC/pages/42"
+
+func newTestLinkResolver(meta *models.LinkMetadata) (*LinkResolverService, *fakeLinkSourceRepo, *fakeLinkDocRepo, *fakeLinkResolver, *fakeLinkNotifier, *fakeLinkPublisher) {
+	repo := &fakeLinkSourceRepo{sources: map[string]*models.DocumentLinkSource{}}
+	docs := &fakeLinkDocRepo{docs: map[string]*models.Document{
+		"doc1": {DocID: "doc1", Title: "Security Policy", URL: testPageURL, CreatedBy: "owner@example.com"},
+	}}
+	resolver := &fakeLinkResolver{host: "acme.atlassian.net", meta: meta}
+	notifier := &fakeLinkNotifier{}
+	publisher := &fakeLinkPublisher{}
+	service := NewLinkResolverService(repo, docs, notifier, publisher)
+	service.SetResolver(models.LinkProviderConfluence, resolver)
+	return service, repo, docs, resolver, notifier, publisher
+}
+
+func TestLinkResolverService_ResolveLink(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	service, _, _, resolver, _, _ := newTestLinkResolver(meta)
+	ctx := context.Background()
+
+	if _, ok := service.ResolveLink(ctx, "https://example.com/policy.pdf"); ok {
+		t.Error("expected links of other hosts to be ignored")
+	}
+
+	source, ok := service.ResolveLink(ctx, testPageURL)
+	if !ok || source.Title != "Security Policy" || source.Version != "3" || source.LastError != "" {
+		t.Fatalf("unexpected source %+v", source)
+	}
+
+	// A failed resolution is still tracked, to be checked again
+	resolver.err = errors.New("status 401")
+	source, ok = service.ResolveLink(ctx, testPageURL)
+	if !ok || source.Provider != models.LinkProviderConfluence || source.LastError == "" {
+		t.Fatalf("expected the error recorded, got %+v", source)
+	}
+
+	if _, err := service.Preview(ctx, testPageURL); !errors.Is(err, ErrLinkResolve) {
+		t.Errorf("expected ErrLinkResolve, got %v", err)
+	}
+	if _, err := service.Preview(ctx, "https://example.com/policy.pdf"); !errors.Is(err, ErrLinkNotSupported) {
+		t.Errorf("expected ErrLinkNotSupported, got %v", err)
+	}
+}
+
+func TestLinkResolverService_RefreshDetectsChanges(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	service, repo, docs, resolver, notifier, publisher := newTestLinkResolver(meta)
+	ctx := context.Background()
+
+	first, err := service.Refresh(ctx, "doc1")
+	if err != nil || first.ChangedAt != nil {
+		t.Fatalf("expected the first resolution without change, got %+v, %v", first, err)
+	}
+
+	// Same content: nothing to report
+	if _, err := service.Refresh(ctx, "doc1"); err != nil || len(notifier.changes) != 0 {
+		t.Fatalf("expected no alert for an unchanged page, got %v, %v", notifier.changes, err)
+	}
+
+	resolver.meta = &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy v2", Version: "4", ContentHash: "bbb"}
+	changed, err := service.Refresh(ctx, "doc1")
+	if err != nil {
+		t.Fatalf("refresh err: %v", err)
+	}
+	if changed.ChangedAt == nil || changed.PreviousVersion != "3" || changed.Version != "4" {
+		t.Errorf("expected the change recorded, got %+v", changed)
+	}
+	if len(notifier.changes) != 1 || len(publisher.events) != 1 || publisher.events[0] != "document.source_changed" {
+		t.Errorf("expected one alert, got %v and %v", notifier.changes, publisher.events)
+	}
+	if docs.docs["doc1"].Title != "Security Policy v2" {
+		t.Errorf("expected the title to follow the page, got %q", docs.docs["doc1"].Title)
+	}
+
+	// A failure keeps the last known metadata
+	resolver.err = errors.New("status 503")
+	failed, err := service.Refresh(ctx, "doc1")
+	if err != nil || failed.LastError == "" || failed.Version != "4" || failed.ChangedAt == nil {
+		t.Fatalf("expected the metadata kept with the error, got %+v, %v", failed, err)
+	}
+	if stored := repo.sources["doc1"]; stored.ContentHash != "bbb" {
+		t.Errorf("expected the stored hash kept, got %+v", stored)
+	}
+}
+
+func TestLinkResolverService_RefreshKeepsEditedTitle(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	service, _, docs, resolver, _, _ := newTestLinkResolver(meta)
+	ctx := context.Background()
+
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+	docs.docs["doc1"].Title = "Security Policy (2025)"
+	resolver.meta = &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security", Version: "4", ContentHash: "bbb"}
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+	if docs.docs["doc1"].Title != "Security Policy (2025)" {
+		t.Errorf("expected the edited title kept, got %q", docs.docs["doc1"].Title)
+	}
+}
+
+func TestLinkResolverService_RefreshStopsTrackingOtherLinks(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	service, repo, docs, _, _, _ := newTestLinkResolver(meta)
+	ctx := context.Background()
+
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+	docs.docs["doc1"].URL = "https://example.com/policy.pdf"
+	if _, err := service.Refresh(ctx, "doc1"); !errors.Is(err, ErrLinkNotSupported) {
+		t.Fatalf("expected ErrLinkNotSupported, got %v", err)
+	}
+	if _, ok := repo.sources["doc1"]; ok {
+		t.Error("expected the link source deleted")
+	}
+	if _, err := service.Refresh(ctx, "missing"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestDocumentService_CreateDocumentTracksLink(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	links, repo, _, _, _, _ := newTestLinkResolver(meta)
+
+	docRepo := newMockDocRepo()
+	service := NewDocumentService(docRepo, nil, nil)
+	service.SetLinkTracker(links)
+
+	doc, err := service.CreateDocument(context.Background(), CreateDocumentRequest{Reference: testPageURL})
+	if err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+	if doc.Title != "Security Policy" {
+		t.Errorf("expected the page title, got %q", doc.Title)
+	}
+	if source := repo.sources[doc.DocID]; source == nil || source.Version != "3" || !strings.HasPrefix(source.URL, "https://acme") {
+		t.Errorf("expected the link tracked, got %+v", source)
+	}
+
+	other, err := service.CreateDocument(context.Background(), CreateDocumentRequest{Reference: testPageURL, Title: "Given title"})
+	if err != nil || other.Title != "Given title" {
+		t.Errorf("expected the given title kept, got %+v, %v", other, err)
+	}
+}
+
+type fakeLinkSignatures struct {
+	signed map[string]bool
+}
+
+func (f *fakeLinkSignatures) GetLastSignature(_ context.Context, docID string) (*models.Signature, error) {
+	if !f.signed[docID] {
+		return nil, nil
+	}
+	return &models.Signature{DocID: docID}, nil
+}
+
+type fakeLinkSubscriptionRepo struct {
+	subs map[string]*models.LinkSubscription
+}
+
+func (f *fakeLinkSubscriptionRepo) Upsert(_ context.Context, sub *models.LinkSubscription) error {
+	copied := *sub
+	f.subs[sub.DocID] = &copied
+	return nil
+}
+
+func (f *fakeLinkSubscriptionRepo) GetByDocID(_ context.Context, docID string) (*models.LinkSubscription, error) {
+	sub, ok := f.subs[docID]
+	if !ok {
+		return nil, models.ErrSubscriptionNotFound
+	}
+	copied := *sub
+	return &copied, nil
+}
+
+func (f *fakeLinkSubscriptionRepo) GetBySubscriptionID(_ context.Context, provider, subscriptionID string) (*models.LinkSubscription, error) {
+	for _, sub := range f.subs {
+		if sub.Provider == provider && sub.SubscriptionID == subscriptionID {
+			copied := *sub
+			return &copied, nil
+		}
+	}
+	return nil, models.ErrSubscriptionNotFound
+}
+
+func (f *fakeLinkSubscriptionRepo) ListExpiring(_ context.Context, before time.Time, _ int) ([]*models.LinkSubscription, error) {
+	var expiring []*models.LinkSubscription
+	for _, sub := range f.subs {
+		if sub.ExpiresAt.Before(before) {
+			expiring = append(expiring, sub)
+		}
+	}
+	return expiring, nil
+}
+
+func (f *fakeLinkSubscriptionRepo) Delete(_ context.Context, docID string) error {
+	delete(f.subs, docID)
+	return nil
+}
+
+// fakeLinkWatcher is a resolver of a host pushing change notifications
+type fakeLinkWatcher struct {
+	fakeLinkResolver
+	watched   []string
+	unwatched []string
+	ttl       time.Duration
+}
+
+func (f *fakeLinkWatcher) Watch(_ context.Context, _ *url.URL, notificationURL, clientState string) (*models.LinkSubscription, error) {
+	f.watched = append(f.watched, notificationURL)
+	return &models.LinkSubscription{
+		Provider:       models.LinkProviderConfluence,
+		SubscriptionID: fmt.Sprintf("sub-%d", len(f.watched)),
+		ClientState:    clientState,
+		ExpiresAt:      time.Now().Add(f.ttl),
+	}, nil
+}
+
+func (f *fakeLinkWatcher) Unwatch(_ context.Context, sub *models.LinkSubscription) error {
+	f.unwatched = append(f.unwatched, sub.SubscriptionID)
+	return nil
+}
+
+func TestLinkResolverService_RefreshBumpsSignedDocumentVersion(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	service, _, docs, resolver, notifier, _ := newTestLinkResolver(meta)
+	signatures := &fakeLinkSignatures{signed: map[string]bool{}}
+	service.SetSignatureReader(signatures)
+	ctx := context.Background()
+
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Not signed yet: the owner is alerted, the document is left as is
+	resolver.meta = &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "4", ContentHash: "bbb"}
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+	if docs.docs["doc1"].Version != 0 || len(notifier.changes) != 1 {
+		t.Fatalf("expected only an alert for an unsigned document, got version %d", docs.docs["doc1"].Version)
+	}
+
+	signatures.signed["doc1"] = true
+	resolver.meta = &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "5", ContentHash: "ccc"}
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+	if docs.docs["doc1"].Version != 1 || len(notifier.changes) != 2 {
+		t.Errorf("expected the signed document version bumped and alerted, got version %d", docs.docs["doc1"].Version)
+	}
+
+	// Unchanged source: no further bump
+	if _, err := service.Refresh(ctx, "doc1"); err != nil || docs.docs["doc1"].Version != 1 {
+		t.Errorf("expected no bump without change, got version %d, %v", docs.docs["doc1"].Version, err)
+	}
+}
+
+func TestLinkResolverService_PushNotifications(t *testing.T) {
+	meta := &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "3", ContentHash: "aaa"}
+	service, repo, _, _, notifier, _ := newTestLinkResolver(meta)
+	watcher := &fakeLinkWatcher{fakeLinkResolver: fakeLinkResolver{host: "acme.atlassian.net", meta: meta}, ttl: time.Hour}
+	service.SetResolver(models.LinkProviderConfluence, watcher)
+	subs := &fakeLinkSubscriptionRepo{subs: map[string]*models.LinkSubscription{}}
+	service.EnablePushNotifications(subs, "https://ackify.example.com/api/v1/link-notifications/")
+	ctx := context.Background()
+
+	source, ok := service.ResolveLink(ctx, testPageURL)
+	if !ok {
+		t.Fatal("expected the link resolved")
+	}
+	source.DocID = "doc1"
+	if err := service.TrackLink(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	sub := subs.subs["doc1"]
+	if sub == nil || len(watcher.watched) != 1 || watcher.watched[0] != "https://ackify.example.com/api/v1/link-notifications/confluence" {
+		t.Fatalf("expected the tracked file watched, got %+v, %v", sub, watcher.watched)
+	}
+
+	if err := service.HandleNotification(ctx, models.LinkProviderConfluence, sub.SubscriptionID, "forged"); !errors.Is(err, ErrInvalidLinkNotification) {
+		t.Fatalf("expected ErrInvalidLinkNotification, got %v", err)
+	}
+	if err := service.HandleNotification(ctx, models.LinkProviderConfluence, "unknown", sub.ClientState); !errors.Is(err, models.ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+
+	if err := service.HandleNotification(ctx, models.LinkProviderConfluence, sub.SubscriptionID, sub.ClientState); err != nil {
+		t.Fatalf("HandleNotification failed: %v", err)
+	}
+	due, err := service.ListDueSources(ctx, time.Hour)
+	if err != nil || len(due) != 1 || due[0].DocID != "doc1" {
+		t.Fatalf("expected the notified source due, got %v, %v", due, err)
+	}
+
+	watcher.meta = &models.LinkMetadata{Provider: models.LinkProviderConfluence, Title: "Security Policy", Version: "4", ContentHash: "bbb"}
+	if _, err := service.Refresh(ctx, "doc1"); err != nil {
+		t.Fatal(err)
+	}
+	if repo.sources["doc1"].Version != "4" || repo.sources["doc1"].NotifiedAt != nil || len(notifier.changes) != 1 {
+		t.Errorf("expected the notified change recorded, got %+v", repo.sources["doc1"])
+	}
+	if len(watcher.watched) != 1 {
+		t.Errorf("expected the existing subscription kept, got %v", watcher.watched)
+	}
+
+	// Renewal replaces the subscription and cancels the previous one
+	if err := service.RenewSubscriptions(ctx, 2*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if subs.subs["doc1"].SubscriptionID != "sub-2" || len(watcher.unwatched) != 1 || watcher.unwatched[0] != "sub-1" {
+		t.Errorf("expected the subscription renewed, got %+v, unwatched %v", subs.subs["doc1"], watcher.unwatched)
+	}
+}
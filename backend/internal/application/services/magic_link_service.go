@@ -33,6 +33,11 @@ type emailSender interface {
 	Send(ctx context.Context, msg email.Message) error
 }
 
+// rateLimitViolationRecorder records requests rejected by a rate limit
+type rateLimitViolationRecorder interface {
+	RecordViolation(ctx context.Context, action, scope, userEmail, ip string)
+}
+
 // i18nTranslator defines translation operations
 type i18nTranslator interface {
 	T(locale, key string) string
@@ -50,6 +55,8 @@ type MagicLinkService struct {
 	rateLimitPerEmail int           // Nombre max de requêtes par email par fenêtre (défaut: 3)
 	rateLimitPerIP    int           // Nombre max de requêtes par IP par fenêtre (défaut: 10)
 	rateLimitWindow   time.Duration // Fenêtre de rate limit (défaut: 1h)
+	violations        rateLimitViolationRecorder
+	locales           recipientLocaleResolver
 }
 
 // MagicLinkServiceConfig pour le service Magic Link
@@ -64,6 +71,8 @@ type MagicLinkServiceConfig struct {
 	RateLimitPerEmail int           // Défaut: 3
 	RateLimitPerIP    int           // Défaut: 10
 	RateLimitWindow   time.Duration // Défaut: 1 heure
+	// Violations enregistre les dépassements de limite pour les admins (optionnel)
+	Violations rateLimitViolationRecorder
 }
 
 func NewMagicLinkService(cfg MagicLinkServiceConfig) *MagicLinkService {
@@ -98,9 +107,15 @@ func NewMagicLinkService(cfg MagicLinkServiceConfig) *MagicLinkService {
 		rateLimitPerEmail: cfg.RateLimitPerEmail,
 		rateLimitPerIP:    cfg.RateLimitPerIP,
 		rateLimitWindow:   cfg.RateLimitWindow,
+		violations:        cfg.Violations,
 	}
 }
 
+// SetLocaleResolver envoie les liens dans la locale préférée de l'utilisateur, si elle est connue
+func (s *MagicLinkService) SetLocaleResolver(locales recipientLocaleResolver) {
+	s.locales = locales
+}
+
 // RequestMagicLink génère et envoie un Magic Link par email
 func (s *MagicLinkService) RequestMagicLink(
 	ctx context.Context,
@@ -143,6 +158,7 @@ func (s *MagicLinkService) RequestMagicLink(
 	}
 	if count >= s.rateLimitPerEmail {
 		s.logAttempt(ctx, emailAddr, false, "rate_limit_exceeded_email", ip, userAgent)
+		s.recordViolation(ctx, models.RateLimitScopeUser, emailAddr, ip)
 		// Ne pas révéler le rate limiting pour éviter l'énumération
 		logger.Logger.Warn("Magic Link rate limit exceeded", "email", emailAddr, "count", count)
 		// On retourne success pour ne pas révéler qu'on a bloqué
@@ -157,6 +173,7 @@ func (s *MagicLinkService) RequestMagicLink(
 	}
 	if countIP >= s.rateLimitPerIP {
 		s.logAttempt(ctx, emailAddr, false, "rate_limit_exceeded_ip", ip, userAgent)
+		s.recordViolation(ctx, models.RateLimitScopeIP, emailAddr, ip)
 		logger.Logger.Warn("Magic Link IP rate limit exceeded", "ip", ip, "count", countIP)
 		return nil
 	}
@@ -187,7 +204,10 @@ func (s *MagicLinkService) RequestMagicLink(
 	redirectEncoded := url.QueryEscape(redirectTo)
 	magicLink := fmt.Sprintf("%s/api/v1/auth/magic-link/verify?token=%s&redirect=%s", s.baseURL, token, redirectEncoded)
 
-	// Utiliser la locale fournie, défaut "en" si vide
+	// Utiliser la locale préférée de l'utilisateur, sinon celle fournie par le navigateur
+	if s.locales != nil {
+		locale = s.locales.ResolveLocale(ctx, emailAddr, locale)
+	}
 	if locale == "" {
 		locale = "en"
 	}
@@ -236,6 +256,53 @@ func (s *MagicLinkService) CreateReminderAuthToken(
 	ctx context.Context,
 	emailAddr string,
 	docID string,
+) (string, error) {
+	return s.CreateReminderAuthTokenFrom(ctx, emailAddr, docID, time.Now())
+}
+
+// CreateReminderAuthTokenFrom creates a reminder auth token valid 24 hours from the given time,
+// the delivery of a reminder deferred to the working hours of its recipient
+func (s *MagicLinkService) CreateReminderAuthTokenFrom(
+	ctx context.Context,
+	emailAddr string,
+	docID string,
+	from time.Time,
+) (string, error) {
+	token, err := s.createReminderToken(ctx, emailAddr, "/?doc="+docID, &docID, from)
+	if err != nil {
+		return "", err
+	}
+
+	logger.Logger.Info("Reminder auth token created",
+		"email", emailAddr,
+		"doc_id", docID,
+		"expires_in", "24h")
+
+	return token, nil
+}
+
+// CreatePacketReminderAuthToken creates a reminder auth token valid 24 hours that signs the
+// recipient in and opens the page of a document packet
+func (s *MagicLinkService) CreatePacketReminderAuthToken(ctx context.Context, emailAddr string, packetID int64) (string, error) {
+	token, err := s.createReminderToken(ctx, emailAddr, fmt.Sprintf("/packets/%d", packetID), nil, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	logger.Logger.Info("Packet reminder auth token created",
+		"email", emailAddr,
+		"packet_id", packetID,
+		"expires_in", "24h")
+
+	return token, nil
+}
+
+func (s *MagicLinkService) createReminderToken(
+	ctx context.Context,
+	emailAddr string,
+	redirectTo string,
+	docID *string,
+	from time.Time,
 ) (string, error) {
 	// Normaliser l'email
 	emailAddr = strings.ToLower(strings.TrimSpace(emailAddr))
@@ -255,23 +322,18 @@ func (s *MagicLinkService) CreateReminderAuthToken(
 	magicToken := &models.MagicLinkToken{
 		Token:              token,
 		Email:              emailAddr,
-		ExpiresAt:          time.Now().Add(24 * time.Hour), // 24 heures pour reminder
-		RedirectTo:         "/?doc=" + docID,               // Redirection vers la page de signature
-		CreatedByIP:        "127.0.0.1",                    // Localhost = système (reminder)
+		ExpiresAt:          from.Add(24 * time.Hour), // 24 heures pour reminder
+		RedirectTo:         redirectTo,               // Redirection vers la page de signature
+		CreatedByIP:        "127.0.0.1",              // Localhost = système (reminder)
 		CreatedByUserAgent: "reminder-service",
 		Purpose:            "reminder_auth",
-		DocID:              &docID,
+		DocID:              docID,
 	}
 
 	if err := s.repo.CreateToken(ctx, magicToken); err != nil {
 		return "", fmt.Errorf("failed to create reminder auth token: %w", err)
 	}
 
-	logger.Logger.Info("Reminder auth token created",
-		"email", emailAddr,
-		"doc_id", docID,
-		"expires_in", "24h")
-
 	return token, nil
 }
 
@@ -400,6 +462,13 @@ func (s *MagicLinkService) logAttempt(
 	}
 }
 
+// recordViolation signale un dépassement de limite aux admins
+func (s *MagicLinkService) recordViolation(ctx context.Context, scope, email, ip string) {
+	if s.violations != nil {
+		s.violations.RecordViolation(ctx, models.RateLimitActionMagicLink, scope, email, ip)
+	}
+}
+
 // CleanupExpiredTokens supprime les tokens expirés (à appeler périodiquement)
 func (s *MagicLinkService) CleanupExpiredTokens(ctx context.Context) (int64, error) {
 	return s.repo.DeleteExpired(ctx)
@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// maxMerkleLeaves bounds the signatures of one root; a backlog is spread over several runs
+	maxMerkleLeaves = 10000
+
+	// merkleSettleDelay leaves out signatures whose transaction may still be in flight,
+	// since a lower ID committed after a root is computed would never be included
+	merkleSettleDelay = time.Minute
+
+	// merkleAnchorBatch bounds the roots published per run
+	merkleAnchorBatch = 20
+)
+
+// merkleRepository stores Merkle roots and their leaves
+type merkleRepository interface {
+	Create(ctx context.Context, root *models.MerkleRoot, leaves []models.MerkleLeaf) error
+	GetLatest(ctx context.Context) (*models.MerkleRoot, error)
+	GetByID(ctx context.Context, id int64) (*models.MerkleRoot, error)
+	List(ctx context.Context, limit int) ([]*models.MerkleRoot, error)
+	ListUnanchored(ctx context.Context, limit int) ([]*models.MerkleRoot, error)
+	MarkAnchored(ctx context.Context, id int64, ref string) error
+	MarkAnchorFailed(ctx context.Context, id int64, message string) error
+	FindLeaf(ctx context.Context, signatureID int64) (*models.MerkleLeaf, error)
+	ListLeaves(ctx context.Context, rootID int64) ([]models.MerkleLeaf, error)
+}
+
+// merkleSignatureRepository reads the signatures covered by Merkle roots
+type merkleSignatureRepository interface {
+	GetByID(ctx context.Context, id int64) (*models.Signature, error)
+	ListAfterID(ctx context.Context, afterID int64, before time.Time, limit int) ([]*models.Signature, error)
+}
+
+// merkleAnchor publishes a root outside the database and returns where it was published
+type merkleAnchor interface {
+	Publish(ctx context.Context, root *models.MerkleRoot) (string, error)
+}
+
+// MerkleService computes Merkle roots over new signatures, publishes them to an external
+// anchor and serves inclusion proofs, so that tampering can be proven outside the database
+type MerkleService struct {
+	repo       merkleRepository
+	signatures merkleSignatureRepository
+	anchor     merkleAnchor
+	now        func() time.Time
+}
+
+// NewMerkleService creates a Merkle root service
+func NewMerkleService(repo merkleRepository, signatures merkleSignatureRepository) *MerkleService {
+	return &MerkleService{repo: repo, signatures: signatures, now: time.Now}
+}
+
+// SetAnchor sets where roots are published (optional)
+func (s *MerkleService) SetAnchor(anchor merkleAnchor) {
+	s.anchor = anchor
+}
+
+// merkleLeafHash decodes a base64 record hash and hashes it as a leaf
+func merkleLeafHash(recordHash string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(recordHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record hash: %w", err)
+	}
+	return crypto.MerkleLeafHash(raw), nil
+}
+
+// ComputeRoot stores a root over the signatures created since the latest root.
+// It returns nil when there is no new signature.
+func (s *MerkleService) ComputeRoot(ctx context.Context) (*models.MerkleRoot, error) {
+	latest, err := s.repo.GetLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var afterID int64
+	if latest != nil {
+		afterID = latest.LastSignatureID
+	}
+
+	signatures, err := s.signatures.ListAfterID(ctx, afterID, s.now().Add(-merkleSettleDelay), maxMerkleLeaves)
+	if err != nil {
+		return nil, err
+	}
+	if len(signatures) == 0 {
+		return nil, nil
+	}
+
+	leaves := make([]models.MerkleLeaf, len(signatures))
+	hashes := make([][]byte, len(signatures))
+	for i, sig := range signatures {
+		leaves[i] = models.MerkleLeaf{SignatureID: sig.ID, LeafIndex: i, RecordHash: sig.ComputeRecordHash()}
+		if hashes[i], err = merkleLeafHash(leaves[i].RecordHash); err != nil {
+			return nil, err
+		}
+	}
+	rootHash, err := crypto.MerkleRoot(hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	root := &models.MerkleRoot{
+		RootHash:         base64.StdEncoding.EncodeToString(rootHash),
+		FirstSignatureID: signatures[0].ID,
+		LastSignatureID:  signatures[len(signatures)-1].ID,
+		LeafCount:        len(signatures),
+	}
+	if err := s.repo.Create(ctx, root, leaves); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Merkle root computed",
+		"root_id", root.ID,
+		"leaf_count", root.LeafCount,
+		"last_signature_id", root.LastSignatureID)
+	return root, nil
+}
+
+// PublishPending publishes the roots not yet anchored and returns how many succeeded.
+// Failures are recorded on the root and retried on the next run.
+func (s *MerkleService) PublishPending(ctx context.Context) (int, error) {
+	if s.anchor == nil {
+		return 0, nil
+	}
+
+	roots, err := s.repo.ListUnanchored(ctx, merkleAnchorBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, root := range roots {
+		ref, err := s.anchor.Publish(ctx, root)
+		if err != nil {
+			logger.Logger.Warn("Failed to publish merkle root", "root_id", root.ID, "error", err.Error())
+			if markErr := s.repo.MarkAnchorFailed(ctx, root.ID, err.Error()); markErr != nil {
+				return published, markErr
+			}
+			continue
+		}
+		if err := s.repo.MarkAnchored(ctx, root.ID, ref); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// ListRoots returns the most recent roots, newest first
+func (s *MerkleService) ListRoots(ctx context.Context, limit int) ([]*models.MerkleRoot, error) {
+	return s.repo.List(ctx, limit)
+}
+
+// GetProof returns the inclusion path of a signature in its root.
+// It returns nil when the signature is unknown or not included in a root yet.
+func (s *MerkleService) GetProof(ctx context.Context, signatureID int64) (*models.MerkleProof, error) {
+	leaf, err := s.repo.FindLeaf(ctx, signatureID)
+	if err != nil || leaf == nil {
+		return nil, err
+	}
+
+	root, err := s.repo.GetByID(ctx, leaf.RootID)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, nil
+	}
+
+	leaves, err := s.repo.ListLeaves(ctx, root.ID)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([][]byte, len(leaves))
+	for i, l := range leaves {
+		if hashes[i], err = merkleLeafHash(l.RecordHash); err != nil {
+			return nil, err
+		}
+	}
+	steps, err := crypto.MerkleProof(hashes, leaf.LeafIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &models.MerkleProof{
+		SignatureID: signatureID,
+		RecordHash:  leaf.RecordHash,
+		LeafHash:    base64.StdEncoding.EncodeToString(hashes[leaf.LeafIndex]),
+		LeafIndex:   leaf.LeafIndex,
+		Path:        make([]models.MerkleProofStep, len(steps)),
+		Root:        root,
+	}
+	for i, step := range steps {
+		position := "right"
+		if step.Left {
+			position = "left"
+		}
+		proof.Path[i] = models.MerkleProofStep{Hash: base64.StdEncoding.EncodeToString(step.Sibling), Position: position}
+	}
+
+	signature, err := s.signatures.GetByID(ctx, signatureID)
+	if err != nil {
+		return nil, err
+	}
+	if signature != nil {
+		proof.RecordMatches = signature.ComputeRecordHash() == leaf.RecordHash
+	}
+	return proof, nil
+}
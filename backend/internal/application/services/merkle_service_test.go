@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeMerkleRepo struct {
+	roots  []*models.MerkleRoot
+	leaves []models.MerkleLeaf
+}
+
+func (f *fakeMerkleRepo) Create(_ context.Context, root *models.MerkleRoot, leaves []models.MerkleLeaf) error {
+	root.ID = int64(len(f.roots) + 1)
+	root.CreatedAt = time.Now()
+	f.roots = append(f.roots, root)
+	for _, l := range leaves {
+		l.RootID = root.ID
+		f.leaves = append(f.leaves, l)
+	}
+	return nil
+}
+
+func (f *fakeMerkleRepo) GetLatest(_ context.Context) (*models.MerkleRoot, error) {
+	if len(f.roots) == 0 {
+		return nil, nil
+	}
+	return f.roots[len(f.roots)-1], nil
+}
+
+func (f *fakeMerkleRepo) GetByID(_ context.Context, id int64) (*models.MerkleRoot, error) {
+	for _, r := range f.roots {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeMerkleRepo) List(_ context.Context, _ int) ([]*models.MerkleRoot, error) {
+	return f.roots, nil
+}
+
+func (f *fakeMerkleRepo) ListUnanchored(_ context.Context, _ int) ([]*models.MerkleRoot, error) {
+	var out []*models.MerkleRoot
+	for _, r := range f.roots {
+		if r.AnchoredAt == nil {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeMerkleRepo) MarkAnchored(_ context.Context, id int64, ref string) error {
+	root, _ := f.GetByID(context.Background(), id)
+	now := time.Now()
+	root.AnchoredAt, root.AnchorRef, root.AnchorError = &now, ref, ""
+	return nil
+}
+
+func (f *fakeMerkleRepo) MarkAnchorFailed(_ context.Context, id int64, message string) error {
+	root, _ := f.GetByID(context.Background(), id)
+	root.AnchorError = message
+	return nil
+}
+
+func (f *fakeMerkleRepo) FindLeaf(_ context.Context, signatureID int64) (*models.MerkleLeaf, error) {
+	for i := range f.leaves {
+		if f.leaves[i].SignatureID == signatureID {
+			return &f.leaves[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeMerkleRepo) ListLeaves(_ context.Context, rootID int64) ([]models.MerkleLeaf, error) {
+	var out []models.MerkleLeaf
+	for _, l := range f.leaves {
+		if l.RootID == rootID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+type fakeMerkleSignatureRepo struct {
+	signatures []*models.Signature
+}
+
+func (f *fakeMerkleSignatureRepo) GetByID(_ context.Context, id int64) (*models.Signature, error) {
+	for _, s := range f.signatures {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeMerkleSignatureRepo) ListAfterID(_ context.Context, afterID int64, before time.Time, limit int) ([]*models.Signature, error) {
+	var out []*models.Signature
+	for _, s := range f.signatures {
+		if s.ID > afterID && s.CreatedAt.Before(before) && len(out) < limit {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeMerkleSignatureRepo) add(n int, createdAt time.Time) {
+	for i := 0; i < n; i++ {
+		id := int64(len(f.signatures) + 1)
+		f.signatures = append(f.signatures, &models.Signature{
+			ID:          id,
+			DocID:       "doc",
+			UserSub:     fmt.Sprintf("user-%d", id),
+			UserEmail:   fmt.Sprintf("user-%d@example.com", id),
+			SignedAtUTC: createdAt,
+			CreatedAt:   createdAt,
+			Nonce:       fmt.Sprintf("nonce-%d", id),
+			HashVersion: 2,
+		})
+	}
+}
+
+type fakeMerkleAnchor struct {
+	err       error
+	published []int64
+}
+
+func (f *fakeMerkleAnchor) Publish(_ context.Context, root *models.MerkleRoot) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.published = append(f.published, root.ID)
+	return fmt.Sprintf("anchor/%d", root.ID), nil
+}
+
+func TestMerkleService_ComputeRoot(t *testing.T) {
+	repo := &fakeMerkleRepo{}
+	sigs := &fakeMerkleSignatureRepo{}
+	service := NewMerkleService(repo, sigs)
+	ctx := context.Background()
+
+	root, err := service.ComputeRoot(ctx)
+	if err != nil || root != nil {
+		t.Fatalf("expected no root without signatures, got %v, %v", root, err)
+	}
+
+	sigs.add(5, time.Now().Add(-time.Hour))
+	root, err = service.ComputeRoot(ctx)
+	if err != nil {
+		t.Fatalf("compute err: %v", err)
+	}
+	if root.LeafCount != 5 || root.FirstSignatureID != 1 || root.LastSignatureID != 5 {
+		t.Errorf("unexpected root range: %+v", root)
+	}
+
+	// Recent signatures wait for the settle delay, older ones go to a new root
+	sigs.add(2, time.Now().Add(-time.Hour))
+	sigs.add(1, time.Now())
+	root, err = service.ComputeRoot(ctx)
+	if err != nil {
+		t.Fatalf("compute err: %v", err)
+	}
+	if root.FirstSignatureID != 6 || root.LastSignatureID != 7 {
+		t.Errorf("expected a root over signatures 6-7, got %d-%d", root.FirstSignatureID, root.LastSignatureID)
+	}
+}
+
+func TestMerkleService_GetProof(t *testing.T) {
+	repo := &fakeMerkleRepo{}
+	sigs := &fakeMerkleSignatureRepo{}
+	service := NewMerkleService(repo, sigs)
+	ctx := context.Background()
+
+	sigs.add(7, time.Now().Add(-time.Hour))
+	root, err := service.ComputeRoot(ctx)
+	if err != nil {
+		t.Fatalf("compute err: %v", err)
+	}
+	rootHash, _ := base64.StdEncoding.DecodeString(root.RootHash)
+
+	for _, sig := range sigs.signatures {
+		proof, err := service.GetProof(ctx, sig.ID)
+		if err != nil {
+			t.Fatalf("proof err: %v", err)
+		}
+		if !proof.RecordMatches {
+			t.Errorf("signature %d should match its leaf", sig.ID)
+		}
+
+		leaf, _ := base64.StdEncoding.DecodeString(proof.LeafHash)
+		steps := make([]crypto.MerkleStep, len(proof.Path))
+		for i, step := range proof.Path {
+			sibling, _ := base64.StdEncoding.DecodeString(step.Hash)
+			steps[i] = crypto.MerkleStep{Sibling: sibling, Left: step.Position == "left"}
+		}
+		if !crypto.VerifyMerkleProof(leaf, steps, rootHash) {
+			t.Errorf("proof of signature %d does not lead to the root", sig.ID)
+		}
+	}
+
+	t.Run("tampered signature", func(t *testing.T) {
+		sigs.signatures[2].UserEmail = "mallory@example.com"
+		proof, err := service.GetProof(ctx, 3)
+		if err != nil {
+			t.Fatalf("proof err: %v", err)
+		}
+		if proof.RecordMatches {
+			t.Error("expected tampered signature to be reported")
+		}
+	})
+
+	t.Run("not included yet", func(t *testing.T) {
+		proof, err := service.GetProof(ctx, 99)
+		if err != nil || proof != nil {
+			t.Errorf("expected no proof, got %v, %v", proof, err)
+		}
+	})
+}
+
+func TestMerkleService_PublishPending(t *testing.T) {
+	repo := &fakeMerkleRepo{}
+	sigs := &fakeMerkleSignatureRepo{}
+	service := NewMerkleService(repo, sigs)
+	ctx := context.Background()
+
+	sigs.add(3, time.Now().Add(-time.Hour))
+	if _, err := service.ComputeRoot(ctx); err != nil {
+		t.Fatalf("compute err: %v", err)
+	}
+
+	if n, err := service.PublishPending(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing published without anchor, got %d, %v", n, err)
+	}
+
+	anchor := &fakeMerkleAnchor{err: errors.New("endpoint down")}
+	service.SetAnchor(anchor)
+	if n, err := service.PublishPending(ctx); err != nil || n != 0 {
+		t.Fatalf("expected failed publication, got %d, %v", n, err)
+	}
+	if repo.roots[0].AnchorError != "endpoint down" || repo.roots[0].AnchoredAt != nil {
+		t.Errorf("expected anchor error recorded, got %+v", repo.roots[0])
+	}
+
+	anchor.err = nil
+	if n, err := service.PublishPending(ctx); err != nil || n != 1 {
+		t.Fatalf("expected retry to publish, got %d, %v", n, err)
+	}
+	if repo.roots[0].AnchorRef != "anchor/1" || repo.roots[0].AnchoredAt == nil {
+		t.Errorf("expected root anchored, got %+v", repo.roots[0])
+	}
+	if n, _ := service.PublishPending(ctx); n != 0 {
+		t.Errorf("expected anchored roots to be skipped, got %d", n)
+	}
+}
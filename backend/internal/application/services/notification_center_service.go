@@ -0,0 +1,334 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// DeadlineWarningWindow is how long before a document deadline its owner is warned
+	DeadlineWarningWindow = 24 * time.Hour
+
+	// notificationBufferSize bounds the events queued for a slow subscriber before they are dropped
+	notificationBufferSize = 16
+)
+
+// Notification event types pushed to live subscribers
+const (
+	NotificationEventCreated = "notification.created"
+	NotificationEventRead    = "notification.read"
+)
+
+// NotificationEvent is pushed to the recipient's live connections
+type NotificationEvent struct {
+	Type         string               `json:"type"`
+	Notification *models.Notification `json:"notification,omitempty"`
+	// ID of the notification marked as read; 0 when all notifications were marked as read
+	ID int64 `json:"id,omitempty"`
+}
+
+// notificationRepository defines notification inbox storage
+type notificationRepository interface {
+	Create(ctx context.Context, input models.NotificationInput) (*models.Notification, error)
+	ListByRecipient(ctx context.Context, recipient string, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
+	CountByRecipient(ctx context.Context, recipient string, unreadOnly bool) (int, error)
+	MarkRead(ctx context.Context, recipient string, id int64, at time.Time) error
+	MarkAllRead(ctx context.Context, recipient string, at time.Time) (int64, error)
+}
+
+// notificationDocumentRepository resolves the owner of a document
+type notificationDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// notificationDeadlineRepository lists documents whose deadline is approaching
+type notificationDeadlineRepository interface {
+	ListUpcomingDeadlines(ctx context.Context, from, to time.Time) ([]*models.CompletionSettings, error)
+}
+
+// NotificationCenterService stores inbox notifications for document owners and pushes them to live connections.
+// Live delivery is in-process: clients connected to another instance see new entries on their next fetch.
+type NotificationCenterService struct {
+	repo      notificationRepository
+	docRepo   notificationDocumentRepository
+	deadlines notificationDeadlineRepository
+	now       func() time.Time
+
+	mu          sync.RWMutex
+	subscribers map[string]map[chan NotificationEvent]struct{}
+
+	done         chan struct{} // closed on shutdown so live connections end with a reconnect hint
+	shutdownOnce sync.Once
+}
+
+// NewNotificationCenterService creates a new notification center service.
+// deadlines may be nil to disable deadline warnings.
+func NewNotificationCenterService(repo notificationRepository, docRepo notificationDocumentRepository, deadlines notificationDeadlineRepository) *NotificationCenterService {
+	return &NotificationCenterService{
+		repo:        repo,
+		docRepo:     docRepo,
+		deadlines:   deadlines,
+		now:         time.Now,
+		subscribers: make(map[string]map[chan NotificationEvent]struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// List returns the recipient's notifications, newest first, with the total count
+func (s *NotificationCenterService) List(ctx context.Context, recipient string, unreadOnly bool, limit, offset int) ([]*models.Notification, int, error) {
+	notifications, err := s.repo.ListByRecipient(ctx, recipient, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.CountByRecipient(ctx, recipient, unreadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// UnreadCount returns the number of unread notifications of the recipient
+func (s *NotificationCenterService) UnreadCount(ctx context.Context, recipient string) (int, error) {
+	return s.repo.CountByRecipient(ctx, recipient, true)
+}
+
+// MarkRead marks one notification as read and informs the recipient's other connections
+func (s *NotificationCenterService) MarkRead(ctx context.Context, recipient string, id int64) error {
+	if err := s.repo.MarkRead(ctx, recipient, id, s.now()); err != nil {
+		return err
+	}
+	s.publish(recipient, NotificationEvent{Type: NotificationEventRead, ID: id})
+	return nil
+}
+
+// MarkAllRead marks every notification of the recipient as read
+func (s *NotificationCenterService) MarkAllRead(ctx context.Context, recipient string) (int64, error) {
+	count, err := s.repo.MarkAllRead(ctx, recipient, s.now())
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		s.publish(recipient, NotificationEvent{Type: NotificationEventRead})
+	}
+	return count, nil
+}
+
+// Subscribe registers a live connection of the recipient.
+// The returned function must be called to release the subscription.
+func (s *NotificationCenterService) Subscribe(recipient string) (<-chan NotificationEvent, func()) {
+	key := strings.ToLower(recipient)
+	ch := make(chan NotificationEvent, notificationBufferSize)
+
+	s.mu.Lock()
+	if s.subscribers[key] == nil {
+		s.subscribers[key] = make(map[chan NotificationEvent]struct{})
+	}
+	s.subscribers[key][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers[key], ch)
+			if len(s.subscribers[key]) == 0 {
+				delete(s.subscribers, key)
+			}
+			s.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Done is closed when the server shuts down: live connections must then be closed
+// so that clients reconnect to another instance
+func (s *NotificationCenterService) Done() <-chan struct{} {
+	return s.done
+}
+
+// Shutdown signals live connections to close. Safe to call several times.
+func (s *NotificationCenterService) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		s.mu.RLock()
+		count := len(s.subscribers)
+		s.mu.RUnlock()
+		logger.Logger.Info("Closing live notification streams", "recipients", count)
+		close(s.done)
+	})
+}
+
+// OnSignature notifies the document owner that a new signature was recorded
+func (s *NotificationCenterService) OnSignature(ctx context.Context, docID, signerEmail, signerName string) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil || doc.CreatedBy == "" || strings.EqualFold(doc.CreatedBy, signerEmail) {
+		return nil
+	}
+
+	return s.notify(ctx, models.NotificationInput{
+		Recipient: doc.CreatedBy,
+		Type:      models.NotificationTypeSignatureReceived,
+		DocID:     docID,
+		Data: map[string]interface{}{
+			"docTitle":    doc.Title,
+			"signerEmail": signerEmail,
+			"signerName":  signerName,
+		},
+	})
+}
+
+// OnEmailBounce notifies the document owner that a reminder could not be delivered
+func (s *NotificationCenterService) OnEmailBounce(ctx context.Context, docID string, recipients []string, reason string) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil || doc.CreatedBy == "" {
+		return nil
+	}
+
+	return s.notify(ctx, models.NotificationInput{
+		Recipient: doc.CreatedBy,
+		Type:      models.NotificationTypeEmailBounced,
+		DocID:     docID,
+		Data: map[string]interface{}{
+			"docTitle":   doc.Title,
+			"recipients": recipients,
+			"reason":     reason,
+		},
+	})
+}
+
+// OnSourceChanged notifies the document owner that the Confluence page, SharePoint or Google Drive file the document
+// links to changed. Each content is announced once.
+func (s *NotificationCenterService) OnSourceChanged(ctx context.Context, docID string, source *models.DocumentLinkSource) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil || doc.CreatedBy == "" {
+		return nil
+	}
+
+	return s.notify(ctx, models.NotificationInput{
+		Recipient: doc.CreatedBy,
+		Type:      models.NotificationTypeSourceChanged,
+		DocID:     docID,
+		Data: map[string]interface{}{
+			"docTitle":        doc.Title,
+			"provider":        source.Provider,
+			"url":             source.URL,
+			"previousVersion": source.PreviousVersion,
+			"version":         source.Version,
+		},
+		DedupKey: string(models.NotificationTypeSourceChanged) + ":" + docID + ":" + source.ContentHash,
+	})
+}
+
+// OnApprovalRequired asks the document owner to approve or reject a signature held for approval
+func (s *NotificationCenterService) OnApprovalRequired(ctx context.Context, approval *models.SignatureApproval) error {
+	doc, err := s.docRepo.GetByDocID(ctx, approval.DocID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil || doc.CreatedBy == "" {
+		return nil
+	}
+
+	return s.notify(ctx, models.NotificationInput{
+		Recipient: doc.CreatedBy,
+		Type:      models.NotificationTypeApprovalRequired,
+		DocID:     approval.DocID,
+		Data: map[string]interface{}{
+			"docTitle":    doc.Title,
+			"signerEmail": approval.UserEmail,
+			"signerName":  approval.UserName,
+			"approvalId":  approval.ID,
+		},
+		DedupKey: string(models.NotificationTypeApprovalRequired) + ":" + strconv.FormatInt(approval.ID, 10),
+	})
+}
+
+// RunDeadlineWarnings warns document owners whose deadline falls within DeadlineWarningWindow.
+// Each deadline is announced once; moving the deadline announces it again.
+func (s *NotificationCenterService) RunDeadlineWarnings(ctx context.Context) error {
+	if s.deadlines == nil {
+		return nil
+	}
+
+	now := s.now()
+	settings, err := s.deadlines.ListUpcomingDeadlines(ctx, now, now.Add(DeadlineWarningWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, setting := range settings {
+		doc, err := s.docRepo.GetByDocID(ctx, setting.DocID)
+		if err != nil {
+			logger.Logger.Warn("Failed to load document for deadline warning", "doc_id", setting.DocID, "error", err.Error())
+			continue
+		}
+		if doc == nil || doc.CreatedBy == "" {
+			continue
+		}
+
+		deadline := setting.Deadline.UTC().Format(time.RFC3339)
+		err = s.notify(ctx, models.NotificationInput{
+			Recipient: doc.CreatedBy,
+			Type:      models.NotificationTypeDeadlineApproaching,
+			DocID:     setting.DocID,
+			Data: map[string]interface{}{
+				"docTitle": doc.Title,
+				"deadline": deadline,
+			},
+			DedupKey: string(models.NotificationTypeDeadlineApproaching) + ":" + setting.DocID + ":" + deadline,
+		})
+		if err != nil {
+			logger.Logger.Warn("Failed to send deadline warning", "doc_id", setting.DocID, "error", err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *NotificationCenterService) notify(ctx context.Context, input models.NotificationInput) error {
+	notification, err := s.repo.Create(ctx, input)
+	if err != nil {
+		return err
+	}
+	if notification == nil {
+		// Already delivered (dedup key)
+		return nil
+	}
+
+	logger.Logger.Debug("Notification created",
+		"id", notification.ID,
+		"type", notification.Type,
+		"doc_id", notification.DocID)
+
+	s.publish(input.Recipient, NotificationEvent{Type: NotificationEventCreated, Notification: notification})
+	return nil
+}
+
+// publish delivers an event to the recipient's live connections without blocking
+func (s *NotificationCenterService) publish(recipient string, event NotificationEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for ch := range s.subscribers[strings.ToLower(recipient)] {
+		select {
+		case ch <- event:
+		default:
+			logger.Logger.Debug("Dropping notification event for slow subscriber", "type", event.Type)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeNotificationRepo struct {
+	items  []*models.Notification
+	dedups map[string]bool
+}
+
+func newFakeNotificationRepo() *fakeNotificationRepo {
+	return &fakeNotificationRepo{dedups: make(map[string]bool)}
+}
+
+func (f *fakeNotificationRepo) Create(_ context.Context, input models.NotificationInput) (*models.Notification, error) {
+	if input.DedupKey != "" {
+		key := strings.ToLower(input.Recipient) + "|" + input.DedupKey
+		if f.dedups[key] {
+			return nil, nil
+		}
+		f.dedups[key] = true
+	}
+	n := &models.Notification{
+		ID:        int64(len(f.items) + 1),
+		Recipient: strings.ToLower(input.Recipient),
+		Type:      input.Type,
+		DocID:     input.DocID,
+		Data:      input.Data,
+	}
+	f.items = append(f.items, n)
+	return n, nil
+}
+
+func (f *fakeNotificationRepo) ListByRecipient(_ context.Context, recipient string, unreadOnly bool, _, _ int) ([]*models.Notification, error) {
+	var out []*models.Notification
+	for _, n := range f.items {
+		if n.Recipient == strings.ToLower(recipient) && (!unreadOnly || n.ReadAt == nil) {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeNotificationRepo) CountByRecipient(ctx context.Context, recipient string, unreadOnly bool) (int, error) {
+	items, _ := f.ListByRecipient(ctx, recipient, unreadOnly, 0, 0)
+	return len(items), nil
+}
+
+func (f *fakeNotificationRepo) MarkRead(_ context.Context, recipient string, id int64, at time.Time) error {
+	for _, n := range f.items {
+		if n.ID == id && n.Recipient == strings.ToLower(recipient) {
+			n.ReadAt = &at
+			return nil
+		}
+	}
+	return models.ErrNotificationNotFound
+}
+
+func (f *fakeNotificationRepo) MarkAllRead(_ context.Context, recipient string, at time.Time) (int64, error) {
+	var count int64
+	for _, n := range f.items {
+		if n.Recipient == strings.ToLower(recipient) && n.ReadAt == nil {
+			n.ReadAt = &at
+			count++
+		}
+	}
+	return count, nil
+}
+
+type fakeNotificationDocRepo struct{ docs map[string]*models.Document }
+
+func (f *fakeNotificationDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+type fakeDeadlineRepo struct{ settings []*models.CompletionSettings }
+
+func (f *fakeDeadlineRepo) ListUpcomingDeadlines(_ context.Context, from, to time.Time) ([]*models.CompletionSettings, error) {
+	var out []*models.CompletionSettings
+	for _, s := range f.settings {
+		if s.Deadline != nil && s.Deadline.After(from) && !s.Deadline.After(to) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func newTestNotificationCenter(now time.Time) (*NotificationCenterService, *fakeNotificationRepo, *fakeDeadlineRepo) {
+	repo := newFakeNotificationRepo()
+	docs := &fakeNotificationDocRepo{docs: map[string]*models.Document{
+		"doc1":   {DocID: "doc1", Title: "Security Policy", CreatedBy: "Owner@example.com"},
+		"orphan": {DocID: "orphan", Title: "No owner"},
+	}}
+	deadlines := &fakeDeadlineRepo{}
+	service := NewNotificationCenterService(repo, docs, deadlines)
+	service.now = func() time.Time { return now }
+	return service, repo, deadlines
+}
+
+func TestNotificationCenter_OnSignaturePushesToOwner(t *testing.T) {
+	service, repo, _ := newTestNotificationCenter(time.Now())
+	events, cancel := service.Subscribe("owner@example.com")
+	defer cancel()
+
+	if err := service.OnSignature(context.Background(), "doc1", "alice@example.com", "Alice"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+
+	if len(repo.items) != 1 || repo.items[0].Recipient != "owner@example.com" {
+		t.Fatalf("Expected one notification for the owner, got %+v", repo.items)
+	}
+	select {
+	case event := <-events:
+		if event.Type != NotificationEventCreated || event.Notification.Type != models.NotificationTypeSignatureReceived {
+			t.Errorf("Unexpected event %+v", event)
+		}
+		if event.Notification.Data["signerEmail"] != "alice@example.com" {
+			t.Errorf("Expected signer in data, got %v", event.Notification.Data)
+		}
+	default:
+		t.Fatal("Expected a live event")
+	}
+}
+
+func TestNotificationCenter_OnSignatureSkipsOwnSignatureAndOrphans(t *testing.T) {
+	service, repo, _ := newTestNotificationCenter(time.Now())
+	ctx := context.Background()
+
+	if err := service.OnSignature(ctx, "doc1", "owner@example.com", "Owner"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+	if err := service.OnSignature(ctx, "orphan", "alice@example.com", "Alice"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+	if err := service.OnSignature(ctx, "missing", "alice@example.com", "Alice"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+
+	if len(repo.items) != 0 {
+		t.Errorf("Expected no notification, got %d", len(repo.items))
+	}
+}
+
+func TestNotificationCenter_OnEmailBounce(t *testing.T) {
+	service, repo, _ := newTestNotificationCenter(time.Now())
+
+	if err := service.OnEmailBounce(context.Background(), "doc1", []string{"bob@example.com"}, "550 mailbox not found"); err != nil {
+		t.Fatalf("OnEmailBounce failed: %v", err)
+	}
+
+	if len(repo.items) != 1 || repo.items[0].Type != models.NotificationTypeEmailBounced {
+		t.Fatalf("Expected a bounce notification, got %+v", repo.items)
+	}
+	if repo.items[0].Data["reason"] != "550 mailbox not found" {
+		t.Errorf("Expected reason in data, got %v", repo.items[0].Data)
+	}
+}
+
+func TestNotificationCenter_OnSourceChangedOncePerContent(t *testing.T) {
+	service, repo, _ := newTestNotificationCenter(time.Now())
+	ctx := context.Background()
+
+	source := &models.DocumentLinkSource{
+		URL:             "https://acme.atlassian.net/wiki/spaces/SEC/pages/42",
+		LinkMetadata:    models.LinkMetadata{Provider: models.LinkProviderConfluence, Version: "4", ContentHash: "bbb"},
+		PreviousVersion: "3",
+	}
+	for i := 0; i < 2; i++ {
+		if err := service.OnSourceChanged(ctx, "doc1", source); err != nil {
+			t.Fatalf("OnSourceChanged failed: %v", err)
+		}
+	}
+
+	if len(repo.items) != 1 || repo.items[0].Type != models.NotificationTypeSourceChanged {
+		t.Fatalf("Expected a single source change notification, got %+v", repo.items)
+	}
+	if repo.items[0].Data["previousVersion"] != "3" || repo.items[0].Data["version"] != "4" {
+		t.Errorf("Expected versions in data, got %v", repo.items[0].Data)
+	}
+}
+
+func TestNotificationCenter_RunDeadlineWarningsOncePerDeadline(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	service, repo, deadlines := newTestNotificationCenter(now)
+	ctx := context.Background()
+
+	soon := now.Add(6 * time.Hour)
+	later := now.Add(72 * time.Hour)
+	deadlines.settings = []*models.CompletionSettings{
+		{DocID: "doc1", Deadline: &soon},
+		{DocID: "orphan", Deadline: &later},
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := service.RunDeadlineWarnings(ctx); err != nil {
+			t.Fatalf("RunDeadlineWarnings failed: %v", err)
+		}
+	}
+	if len(repo.items) != 1 || repo.items[0].Type != models.NotificationTypeDeadlineApproaching {
+		t.Fatalf("Expected a single deadline warning, got %+v", repo.items)
+	}
+
+	// Moving the deadline announces it again
+	moved := now.Add(12 * time.Hour)
+	deadlines.settings[0].Deadline = &moved
+	if err := service.RunDeadlineWarnings(ctx); err != nil {
+		t.Fatalf("RunDeadlineWarnings failed: %v", err)
+	}
+	if len(repo.items) != 2 {
+		t.Errorf("Expected a new warning after the deadline moved, got %d", len(repo.items))
+	}
+}
+
+func TestNotificationCenter_MarkReadPublishesAndCounts(t *testing.T) {
+	service, _, _ := newTestNotificationCenter(time.Now())
+	ctx := context.Background()
+
+	_ = service.OnSignature(ctx, "doc1", "alice@example.com", "Alice")
+	_ = service.OnSignature(ctx, "doc1", "bob@example.com", "Bob")
+
+	events, cancel := service.Subscribe("OWNER@example.com")
+	defer cancel()
+
+	if count, _ := service.UnreadCount(ctx, "owner@example.com"); count != 2 {
+		t.Fatalf("Expected 2 unread, got %d", count)
+	}
+
+	if err := service.MarkRead(ctx, "owner@example.com", 1); err != nil {
+		t.Fatalf("MarkRead failed: %v", err)
+	}
+	if event := <-events; event.Type != NotificationEventRead || event.ID != 1 {
+		t.Errorf("Unexpected event %+v", event)
+	}
+
+	if err := service.MarkRead(ctx, "someone@example.com", 2); err != models.ErrNotificationNotFound {
+		t.Errorf("Expected ErrNotificationNotFound for another recipient, got %v", err)
+	}
+
+	count, err := service.MarkAllRead(ctx, "owner@example.com")
+	if err != nil || count != 1 {
+		t.Fatalf("Expected 1 notification marked as read, got %d (%v)", count, err)
+	}
+	if count, _ := service.UnreadCount(ctx, "owner@example.com"); count != 0 {
+		t.Errorf("Expected 0 unread, got %d", count)
+	}
+}
+
+func TestNotificationCenter_UnsubscribeStopsDelivery(t *testing.T) {
+	service, _, _ := newTestNotificationCenter(time.Now())
+	events, cancel := service.Subscribe("owner@example.com")
+	cancel()
+	cancel() // idempotent
+
+	if _, ok := <-events; ok {
+		t.Fatal("Expected channel to be closed")
+	}
+	if err := service.OnSignature(context.Background(), "doc1", "alice@example.com", "Alice"); err != nil {
+		t.Fatalf("OnSignature failed: %v", err)
+	}
+}
+
+func TestNotificationCenter_OnApprovalRequiredOncePerApproval(t *testing.T) {
+	service, repo, _ := newTestNotificationCenter(time.Now())
+	ctx := context.Background()
+
+	approval := &models.SignatureApproval{ID: 7, DocID: "doc1", UserEmail: "eve@example.com", UserName: "Eve"}
+	for i := 0; i < 2; i++ {
+		if err := service.OnApprovalRequired(ctx, approval); err != nil {
+			t.Fatalf("OnApprovalRequired failed: %v", err)
+		}
+	}
+
+	if len(repo.items) != 1 || repo.items[0].Type != models.NotificationTypeApprovalRequired {
+		t.Fatalf("Expected a single approval notification, got %+v", repo.items)
+	}
+	if repo.items[0].Data["approvalId"] != int64(7) || repo.items[0].Data["signerEmail"] != "eve@example.com" {
+		t.Errorf("Expected approval in data, got %v", repo.items[0].Data)
+	}
+}
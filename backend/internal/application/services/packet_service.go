@@ -0,0 +1,318 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// PacketReminderTemplate is the email template listing the documents of a packet left to sign
+	PacketReminderTemplate = "packet_reminder"
+	packetReminderRefType  = "document_packet"
+
+	maxPacketTitleLength = 200
+	maxPacketDocuments   = 50
+)
+
+var (
+	// ErrInvalidPacket is returned when packet input fails validation
+	ErrInvalidPacket = errors.New("invalid document packet")
+
+	// ErrPacketRemindersDisabled is returned when packet reminders are requested without email
+	ErrPacketRemindersDisabled = errors.New("packet reminders require email")
+)
+
+// packetRepository defines document packet storage and progress operations
+type packetRepository interface {
+	Create(ctx context.Context, input models.DocumentPacketInput, createdBy string) (*models.DocumentPacket, error)
+	Update(ctx context.Context, id int64, input models.DocumentPacketInput) (*models.DocumentPacket, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.DocumentPacket, error)
+	List(ctx context.Context) ([]*models.DocumentPacket, error)
+	ListSignerDocuments(ctx context.Context, packetID int64, email string) ([]*models.PacketSignerDocument, error)
+	GetStats(ctx context.Context, packetID int64) (*models.PacketStats, error)
+	ListPendingSigners(ctx context.Context, packetID int64) ([]*models.PacketPendingSigner, error)
+}
+
+// packetDocumentRepository checks the documents added to packets
+type packetDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// packetEmailQueue queues the packet reminder emails
+type packetEmailQueue interface {
+	Enqueue(ctx context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error)
+}
+
+// packetReminderTokens creates the links signing reminded signers in on the packet page
+type packetReminderTokens interface {
+	CreatePacketReminderAuthToken(ctx context.Context, email string, packetID int64) (string, error)
+}
+
+// packetReminderLog records packet reminders in the reminder history of each document
+type packetReminderLog interface {
+	LogReminder(ctx context.Context, log *models.ReminderLog) error
+}
+
+// PacketService manages document packets: bundles of documents signed in a single flow,
+// with packet-level progress and reminders
+type PacketService struct {
+	repo    packetRepository
+	docRepo packetDocumentRepository
+
+	queue   packetEmailQueue
+	tokens  packetReminderTokens
+	logs    packetReminderLog
+	i18n    translator
+	locales recipientLocaleResolver
+	baseURL string
+}
+
+// NewPacketService creates a packet service; reminders are disabled until SetReminders is called
+func NewPacketService(repo packetRepository, docRepo packetDocumentRepository) *PacketService {
+	return &PacketService{repo: repo, docRepo: docRepo}
+}
+
+// SetReminders enables packet reminders, queued by email with a link to the packet page
+func (s *PacketService) SetReminders(queue packetEmailQueue, tokens packetReminderTokens, logs packetReminderLog, i18nService translator, baseURL string) {
+	s.queue = queue
+	s.tokens = tokens
+	s.logs = logs
+	s.i18n = i18nService
+	s.baseURL = baseURL
+}
+
+// SetLocaleResolver sends packet reminders in the preferred locale of each recipient
+func (s *PacketService) SetLocaleResolver(locales recipientLocaleResolver) {
+	s.locales = locales
+}
+
+// CreatePacket validates and stores a new packet
+func (s *PacketService) CreatePacket(ctx context.Context, input models.DocumentPacketInput, createdBy string) (*models.DocumentPacket, error) {
+	if err := s.validate(ctx, &input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Creating document packet", "title", input.Title, "documents", len(input.DocIDs))
+	return s.repo.Create(ctx, input, createdBy)
+}
+
+// UpdatePacket validates and updates a packet, replacing its documents
+func (s *PacketService) UpdatePacket(ctx context.Context, id int64, input models.DocumentPacketInput) (*models.DocumentPacket, error) {
+	if err := s.validate(ctx, &input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Updating document packet", "id", id, "documents", len(input.DocIDs))
+	return s.repo.Update(ctx, id, input)
+}
+
+// DeletePacket deletes a packet. Its documents and their signatures are kept.
+func (s *PacketService) DeletePacket(ctx context.Context, id int64) error {
+	logger.Logger.Info("Deleting document packet", "id", id)
+	return s.repo.Delete(ctx, id)
+}
+
+// GetPacket retrieves a packet by ID
+func (s *PacketService) GetPacket(ctx context.Context, id int64) (*models.DocumentPacket, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListPackets retrieves all packets, newest first
+func (s *PacketService) ListPackets(ctx context.Context) ([]*models.DocumentPacket, error) {
+	return s.repo.List(ctx)
+}
+
+// GetStats returns the signing progress of a packet
+func (s *PacketService) GetStats(ctx context.Context, id int64) (*models.PacketStats, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetStats(ctx, id)
+}
+
+// GetSignerView lists the documents of a packet with the signing status of user, and the
+// next document they have to sign
+func (s *PacketService) GetSignerView(ctx context.Context, id int64, user *models.User) (*models.PacketSignerView, error) {
+	packet, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.repo.ListSignerDocuments(ctx, id, user.NormalizedEmail())
+	if err != nil {
+		return nil, err
+	}
+
+	view := &models.PacketSignerView{
+		ID:          packet.ID,
+		Title:       packet.Title,
+		Description: packet.Description,
+		Documents:   documents,
+	}
+	if view.Documents == nil {
+		view.Documents = []*models.PacketSignerDocument{}
+	}
+	for _, doc := range documents {
+		if doc.Signed {
+			view.SignedCount++
+		} else if view.NextDocID == "" {
+			view.NextDocID = doc.DocID
+		}
+	}
+	view.Completed = len(documents) > 0 && view.SignedCount == len(documents)
+	return view, nil
+}
+
+// SendReminders queues one email per signer with documents of the packet left to sign. The
+// email lists those documents and links to the packet page; each document records the reminder
+// in its history.
+func (s *PacketService) SendReminders(ctx context.Context, id int64, sentBy, locale string) (*models.PacketReminderResult, error) {
+	if s.queue == nil || s.tokens == nil {
+		return nil, ErrPacketRemindersDisabled
+	}
+	packet, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := s.repo.ListPendingSigners(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string]string, len(packet.DocIDs))
+	for _, docID := range packet.DocIDs {
+		if doc, err := s.docRepo.GetByDocID(ctx, docID); err == nil && doc != nil {
+			titles[docID] = doc.Title
+		}
+	}
+
+	result := &models.PacketReminderResult{TotalRecipients: len(signers)}
+	for _, signer := range signers {
+		if err := s.queueReminder(ctx, packet, signer, titles, sentBy, locale); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", signer.Email, err))
+			continue
+		}
+		result.RemindersQueued++
+	}
+
+	logger.Logger.Info("Packet reminders queued",
+		"packet_id", id,
+		"recipients", result.TotalRecipients,
+		"queued", result.RemindersQueued,
+		"failed", result.Failed)
+
+	return result, nil
+}
+
+func (s *PacketService) queueReminder(ctx context.Context, packet *models.DocumentPacket, signer *models.PacketPendingSigner, titles map[string]string, sentBy, locale string) error {
+	token, err := s.tokens.CreatePacketReminderAuthToken(ctx, signer.Email, packet.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create auth token: %w", err)
+	}
+
+	documents := make([]map[string]interface{}, 0, len(signer.DocIDs))
+	for _, docID := range signer.DocIDs {
+		documents = append(documents, map[string]interface{}{
+			"DocID": docID,
+			"Title": titles[docID],
+		})
+	}
+
+	if s.locales != nil {
+		locale = s.locales.ResolveLocale(ctx, signer.Email, locale)
+	}
+	subject := "Documents awaiting your reading confirmation" // Fallback
+	if s.i18n != nil {
+		subject = s.i18n.T(locale, "email.packet_reminder.subject")
+	}
+
+	refType := packetReminderRefType
+	refID := fmt.Sprintf("%d", packet.ID)
+	_, queueErr := s.queue.Enqueue(ctx, models.EmailQueueInput{
+		ToAddresses: []string{signer.Email},
+		Subject:     subject + ": " + packet.Title,
+		Template:    PacketReminderTemplate,
+		Locale:      locale,
+		Data: map[string]interface{}{
+			"RecipientName": signer.Name,
+			"PacketTitle":   packet.Title,
+			"Documents":     documents,
+			"PacketURL":     fmt.Sprintf("%s/api/v1/auth/reminder-link/verify?token=%s", s.baseURL, token),
+			"Locale":        locale,
+		},
+		Priority:      models.EmailPriorityHigh,
+		ReferenceType: &refType,
+		ReferenceID:   &refID,
+		CreatedBy:     &sentBy,
+		MaxRetries:    5,
+	})
+
+	status := "queued"
+	var errMsg *string
+	if queueErr != nil {
+		status = "failed"
+		msg := fmt.Sprintf("Failed to queue: %v", queueErr)
+		errMsg = &msg
+	}
+	if s.logs != nil {
+		for _, docID := range signer.DocIDs {
+			log := &models.ReminderLog{
+				DocID:          docID,
+				RecipientEmail: signer.Email,
+				SentAt:         time.Now(),
+				SentBy:         sentBy,
+				TemplateUsed:   PacketReminderTemplate,
+				Status:         status,
+				ErrorMessage:   errMsg,
+			}
+			if err := s.logs.LogReminder(ctx, log); err != nil {
+				logger.Logger.Error("Failed to log packet reminder",
+					"doc_id", docID,
+					"recipient_email", signer.Email,
+					"error", err.Error())
+			}
+		}
+	}
+	if queueErr != nil {
+		return fmt.Errorf("failed to queue email: %w", queueErr)
+	}
+	return nil
+}
+
+func (s *PacketService) validate(ctx context.Context, input *models.DocumentPacketInput) error {
+	input.Title = strings.TrimSpace(input.Title)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Title == "" || len(input.Title) > maxPacketTitleLength {
+		return fmt.Errorf("%w: title is required and must be at most %d characters", ErrInvalidPacket, maxPacketTitleLength)
+	}
+	if len(input.DocIDs) == 0 || len(input.DocIDs) > maxPacketDocuments {
+		return fmt.Errorf("%w: a packet holds 1 to %d documents", ErrInvalidPacket, maxPacketDocuments)
+	}
+
+	seen := make(map[string]bool, len(input.DocIDs))
+	docIDs := make([]string, 0, len(input.DocIDs))
+	for _, docID := range input.DocIDs {
+		docID = strings.TrimSpace(docID)
+		if docID == "" || seen[docID] {
+			return fmt.Errorf("%w: document IDs must be non-empty and unique", ErrInvalidPacket)
+		}
+		seen[docID] = true
+
+		doc, err := s.docRepo.GetByDocID(ctx, docID)
+		if err != nil {
+			return err
+		}
+		if doc == nil {
+			return fmt.Errorf("%w: %s", models.ErrDocumentNotFound, docID)
+		}
+		docIDs = append(docIDs, docID)
+	}
+	input.DocIDs = docIDs
+	return nil
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakePacketRepo struct {
+	packets map[int64]*models.DocumentPacket
+	signed  map[string]bool // docID|email
+	pending []*models.PacketPendingSigner
+	nextID  int64
+}
+
+func newFakePacketRepo() *fakePacketRepo {
+	return &fakePacketRepo{packets: make(map[int64]*models.DocumentPacket), signed: make(map[string]bool)}
+}
+
+func (f *fakePacketRepo) Create(_ context.Context, input models.DocumentPacketInput, createdBy string) (*models.DocumentPacket, error) {
+	f.nextID++
+	p := &models.DocumentPacket{ID: f.nextID, Title: input.Title, Description: input.Description, DocIDs: input.DocIDs, CreatedBy: createdBy}
+	f.packets[p.ID] = p
+	return p, nil
+}
+
+func (f *fakePacketRepo) Update(_ context.Context, id int64, input models.DocumentPacketInput) (*models.DocumentPacket, error) {
+	p, ok := f.packets[id]
+	if !ok {
+		return nil, models.ErrPacketNotFound
+	}
+	p.Title, p.Description, p.DocIDs = input.Title, input.Description, input.DocIDs
+	return p, nil
+}
+
+func (f *fakePacketRepo) Delete(_ context.Context, id int64) error {
+	if _, ok := f.packets[id]; !ok {
+		return models.ErrPacketNotFound
+	}
+	delete(f.packets, id)
+	return nil
+}
+
+func (f *fakePacketRepo) GetByID(_ context.Context, id int64) (*models.DocumentPacket, error) {
+	p, ok := f.packets[id]
+	if !ok {
+		return nil, models.ErrPacketNotFound
+	}
+	return p, nil
+}
+
+func (f *fakePacketRepo) List(_ context.Context) ([]*models.DocumentPacket, error) {
+	var out []*models.DocumentPacket
+	for _, p := range f.packets {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (f *fakePacketRepo) ListSignerDocuments(_ context.Context, packetID int64, email string) ([]*models.PacketSignerDocument, error) {
+	var out []*models.PacketSignerDocument
+	for _, docID := range f.packets[packetID].DocIDs {
+		doc := &models.PacketSignerDocument{DocID: docID, Title: docID}
+		if f.signed[docID+"|"+email] {
+			now := time.Now()
+			doc.Signed, doc.SignedAt = true, &now
+		}
+		out = append(out, doc)
+	}
+	return out, nil
+}
+
+func (f *fakePacketRepo) GetStats(_ context.Context, packetID int64) (*models.PacketStats, error) {
+	return &models.PacketStats{PacketID: packetID}, nil
+}
+
+func (f *fakePacketRepo) ListPendingSigners(_ context.Context, _ int64) ([]*models.PacketPendingSigner, error) {
+	return f.pending, nil
+}
+
+type fakePacketTokens struct {
+	err error
+}
+
+func (f *fakePacketTokens) CreatePacketReminderAuthToken(_ context.Context, email string, _ int64) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return "token-" + email, nil
+}
+
+func newTestPacketService() (*PacketService, *fakePacketRepo) {
+	repo := newFakePacketRepo()
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{
+		"doc-a": {DocID: "doc-a", Title: "Code of conduct"},
+		"doc-b": {DocID: "doc-b", Title: "IT charter"},
+		"doc-c": {DocID: "doc-c", Title: "Privacy policy"},
+	}}
+	return NewPacketService(repo, docs), repo
+}
+
+func TestPacketService_CreatePacket(t *testing.T) {
+	svc, _ := newTestPacketService()
+	ctx := context.Background()
+
+	packet, err := svc.CreatePacket(ctx, models.DocumentPacketInput{Title: " Onboarding ", DocIDs: []string{"doc-b", " doc-a"}}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("CreatePacket failed: %v", err)
+	}
+	if packet.Title != "Onboarding" || len(packet.DocIDs) != 2 || packet.DocIDs[0] != "doc-b" || packet.DocIDs[1] != "doc-a" {
+		t.Errorf("unexpected packet: %+v", packet)
+	}
+
+	invalid := []models.DocumentPacketInput{
+		{Title: "", DocIDs: []string{"doc-a"}},
+		{Title: "Empty"},
+		{Title: "Duplicates", DocIDs: []string{"doc-a", "doc-a"}},
+	}
+	for _, input := range invalid {
+		if _, err := svc.CreatePacket(ctx, input, "admin@example.com"); !errors.Is(err, ErrInvalidPacket) {
+			t.Errorf("expected ErrInvalidPacket for %+v, got %v", input, err)
+		}
+	}
+	if _, err := svc.CreatePacket(ctx, models.DocumentPacketInput{Title: "Missing", DocIDs: []string{"doc-x"}}, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestPacketService_GetSignerView(t *testing.T) {
+	svc, repo := newTestPacketService()
+	ctx := context.Background()
+	packet, _ := svc.CreatePacket(ctx, models.DocumentPacketInput{Title: "Onboarding", DocIDs: []string{"doc-a", "doc-b", "doc-c"}}, "admin@example.com")
+	user := &models.User{Sub: "alice", Email: "Alice@Example.com"}
+
+	repo.signed["doc-a|alice@example.com"] = true
+	repo.signed["doc-c|alice@example.com"] = true
+
+	view, err := svc.GetSignerView(ctx, packet.ID, user)
+	if err != nil {
+		t.Fatalf("GetSignerView failed: %v", err)
+	}
+	if view.SignedCount != 2 || view.NextDocID != "doc-b" || view.Completed {
+		t.Errorf("unexpected view: %+v", view)
+	}
+
+	repo.signed["doc-b|alice@example.com"] = true
+	view, _ = svc.GetSignerView(ctx, packet.ID, user)
+	if !view.Completed || view.NextDocID != "" {
+		t.Errorf("expected the packet to be completed, got %+v", view)
+	}
+
+	if _, err := svc.GetSignerView(ctx, 42, user); !errors.Is(err, models.ErrPacketNotFound) {
+		t.Errorf("expected ErrPacketNotFound, got %v", err)
+	}
+}
+
+func TestPacketService_SendReminders(t *testing.T) {
+	svc, repo := newTestPacketService()
+	ctx := context.Background()
+	packet, _ := svc.CreatePacket(ctx, models.DocumentPacketInput{Title: "Onboarding", DocIDs: []string{"doc-a", "doc-b"}}, "admin@example.com")
+
+	if _, err := svc.SendReminders(ctx, packet.ID, "admin@example.com", "en"); !errors.Is(err, ErrPacketRemindersDisabled) {
+		t.Fatalf("expected ErrPacketRemindersDisabled, got %v", err)
+	}
+
+	queue := &fakeCompletionQueue{}
+	logs := &fakeAccessResendLog{}
+	svc.SetReminders(queue, &fakePacketTokens{}, logs, fakeTranslator{}, "https://sign.example.com")
+	repo.pending = []*models.PacketPendingSigner{
+		{Email: "bob@example.com", Name: "Bob", DocIDs: []string{"doc-a", "doc-b"}},
+		{Email: "carol@example.com", DocIDs: []string{"doc-b"}},
+	}
+
+	result, err := svc.SendReminders(ctx, packet.ID, "admin@example.com", "en")
+	if err != nil {
+		t.Fatalf("SendReminders failed: %v", err)
+	}
+	if result.TotalRecipients != 2 || result.RemindersQueued != 2 || result.Failed != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(queue.inputs) != 2 {
+		t.Fatalf("expected one email per signer, got %d", len(queue.inputs))
+	}
+	input := queue.inputs[0]
+	if input.Template != PacketReminderTemplate || input.ToAddresses[0] != "bob@example.com" || input.Subject != "email.packet_reminder.subject: Onboarding" {
+		t.Errorf("unexpected email: %+v", input)
+	}
+	if input.Data["PacketURL"] != "https://sign.example.com/api/v1/auth/reminder-link/verify?token=token-bob@example.com" {
+		t.Errorf("unexpected packet URL: %v", input.Data["PacketURL"])
+	}
+	documents := input.Data["Documents"].([]map[string]interface{})
+	if len(documents) != 2 || documents[1]["Title"] != "IT charter" {
+		t.Errorf("unexpected documents: %v", documents)
+	}
+	if len(logs.logs) != 3 || logs.logs[0].TemplateUsed != PacketReminderTemplate {
+		t.Errorf("expected one reminder log per pending document, got %d", len(logs.logs))
+	}
+
+	svc.SetReminders(queue, &fakePacketTokens{err: errors.New("invalid email")}, logs, fakeTranslator{}, "https://sign.example.com")
+	result, err = svc.SendReminders(ctx, packet.ID, "admin@example.com", "en")
+	if err != nil {
+		t.Fatalf("SendReminders failed: %v", err)
+	}
+	if result.Failed != 2 || len(result.Errors) != 2 {
+		t.Errorf("expected failures to be reported per signer, got %+v", result)
+	}
+}
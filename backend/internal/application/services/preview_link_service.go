@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidPreviewLink is returned when a preview link creation fails validation
+var ErrInvalidPreviewLink = errors.New("invalid preview link")
+
+const (
+	// DefaultPreviewLinkHours is the lifetime of a preview link when none is given
+	DefaultPreviewLinkHours = 72
+	maxPreviewLinkHours     = 30 * 24
+	maxPreviewLabelLength   = 100
+)
+
+// previewLinkRepository stores the preview links of documents
+type previewLinkRepository interface {
+	Create(ctx context.Context, docID, tokenHash, label string, includeSigners bool, expiresAt time.Time, createdBy string) (*models.PreviewLink, error)
+	ListByDoc(ctx context.Context, docID string) ([]*models.PreviewLink, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.PreviewLink, error)
+	RecordView(ctx context.Context, id int64) error
+	Revoke(ctx context.Context, docID string, id int64) (*models.PreviewLink, error)
+}
+
+// previewDocumentRepository loads the documents and status served by preview links
+type previewDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	GetStatus(ctx context.Context, docID string) (*models.DocumentStatus, error)
+}
+
+// PreviewLinkService manages the expiring read-only links to the status of a document, shared
+// with auditors or external counsel who have no account. Tokens are shown once at creation;
+// only their hash is stored.
+type PreviewLinkService struct {
+	repo    previewLinkRepository
+	docRepo previewDocumentRepository
+	baseURL string
+
+	now func() time.Time
+}
+
+func NewPreviewLinkService(repo previewLinkRepository, docRepo previewDocumentRepository, baseURL string) *PreviewLinkService {
+	return &PreviewLinkService{
+		repo:    repo,
+		docRepo: docRepo,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		now:     time.Now,
+	}
+}
+
+// CreateLink generates a preview link for a document. The returned link carries the URL with
+// its token, which cannot be retrieved afterwards.
+func (s *PreviewLinkService) CreateLink(ctx context.Context, docID string, input models.PreviewLinkInput, createdBy string) (*models.PreviewLink, error) {
+	label := strings.TrimSpace(input.Label)
+	if len(label) > maxPreviewLabelLength {
+		return nil, fmt.Errorf("%w: label is limited to %d characters", ErrInvalidPreviewLink, maxPreviewLabelLength)
+	}
+	hours := input.ExpiresInHours
+	if hours == 0 {
+		hours = DefaultPreviewLinkHours
+	}
+	if hours < 1 || hours > maxPreviewLinkHours {
+		return nil, fmt.Errorf("%w: expiresInHours must be between 1 and %d", ErrInvalidPreviewLink, maxPreviewLinkHours)
+	}
+
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate preview token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	expiresAt := s.now().Add(time.Duration(hours) * time.Hour)
+	link, err := s.repo.Create(ctx, docID, hashSecretToken(token), label, input.IncludeSigners, expiresAt, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	link.URL = s.baseURL + "/preview/" + token
+
+	logger.Logger.Info("Preview link created",
+		"doc_id", docID,
+		"id", link.ID,
+		"include_signers", link.IncludeSigners,
+		"expires_at", link.ExpiresAt,
+		"created_by", createdBy)
+	return link, nil
+}
+
+// ListLinks returns the preview links of a document, including revoked and expired ones
+func (s *PreviewLinkService) ListLinks(ctx context.Context, docID string) ([]*models.PreviewLink, error) {
+	return s.repo.ListByDoc(ctx, docID)
+}
+
+// RevokeLink disables a preview link before it expires
+func (s *PreviewLinkService) RevokeLink(ctx context.Context, docID string, id int64, revokedBy string) (*models.PreviewLink, error) {
+	link, err := s.repo.Revoke(ctx, docID, id)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Preview link revoked", "doc_id", docID, "id", id, "revoked_by", revokedBy)
+	return link, nil
+}
+
+// GetPreview returns the status of the document of a token. Unknown, revoked and expired
+// tokens, and links of deleted documents, all return ErrPreviewLinkNotFound.
+func (s *PreviewLinkService) GetPreview(ctx context.Context, token string) (*models.DocumentPreview, error) {
+	if token == "" {
+		return nil, models.ErrPreviewLinkNotFound
+	}
+	link, err := s.repo.GetByTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if link == nil || !link.IsActive(s.now()) {
+		return nil, models.ErrPreviewLinkNotFound
+	}
+
+	status, err := s.docRepo.GetStatus(ctx, link.DocID)
+	if err != nil {
+		return nil, err
+	}
+	if status.Document == nil {
+		return nil, models.ErrPreviewLinkNotFound
+	}
+
+	if err := s.repo.RecordView(ctx, link.ID); err != nil {
+		logger.Logger.Warn("Failed to record preview link view", "id", link.ID, "error", err.Error())
+	}
+
+	doc := status.Document
+	preview := &models.DocumentPreview{
+		DocID:       doc.DocID,
+		Title:       doc.Title,
+		Description: doc.Description,
+		URL:         doc.URL,
+		CreatedAt:   doc.CreatedAt,
+		Stats:       status.Stats,
+		ExpiresAt:   link.ExpiresAt,
+	}
+	if link.IncludeSigners {
+		preview.Signers = make([]*models.PreviewSigner, 0, len(status.Signers))
+		for _, signer := range status.Signers {
+			item := &models.PreviewSigner{Email: signer.Email, Name: signer.Name, Signed: signer.HasSigned, SignedAt: signer.SignedAt}
+			if item.Name == "" && signer.UserName != nil {
+				item.Name = *signer.UserName
+			}
+			preview.Signers = append(preview.Signers, item)
+		}
+	}
+	return preview, nil
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakePreviewLinkRepo struct {
+	links  []*models.PreviewLink
+	hashes map[string]*models.PreviewLink
+}
+
+func (f *fakePreviewLinkRepo) Create(_ context.Context, docID, tokenHash, label string, includeSigners bool, expiresAt time.Time, createdBy string) (*models.PreviewLink, error) {
+	link := &models.PreviewLink{
+		ID:             int64(len(f.links) + 1),
+		DocID:          docID,
+		Label:          label,
+		IncludeSigners: includeSigners,
+		ExpiresAt:      expiresAt,
+		CreatedBy:      createdBy,
+		CreatedAt:      time.Now(),
+	}
+	f.links = append(f.links, link)
+	if f.hashes == nil {
+		f.hashes = make(map[string]*models.PreviewLink)
+	}
+	f.hashes[tokenHash] = link
+	return link, nil
+}
+
+func (f *fakePreviewLinkRepo) ListByDoc(_ context.Context, docID string) ([]*models.PreviewLink, error) {
+	var out []*models.PreviewLink
+	for _, l := range f.links {
+		if l.DocID == docID {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakePreviewLinkRepo) GetByTokenHash(_ context.Context, tokenHash string) (*models.PreviewLink, error) {
+	return f.hashes[tokenHash], nil
+}
+
+func (f *fakePreviewLinkRepo) RecordView(_ context.Context, id int64) error {
+	for _, l := range f.links {
+		if l.ID == id {
+			l.ViewCount++
+		}
+	}
+	return nil
+}
+
+func (f *fakePreviewLinkRepo) Revoke(_ context.Context, docID string, id int64) (*models.PreviewLink, error) {
+	for _, l := range f.links {
+		if l.ID == id && l.DocID == docID {
+			if l.RevokedAt == nil {
+				now := time.Now()
+				l.RevokedAt = &now
+			}
+			return l, nil
+		}
+	}
+	return nil, models.ErrPreviewLinkNotFound
+}
+
+type fakePreviewDocRepo struct {
+	docs    map[string]*models.Document
+	signers []*models.ExpectedSignerWithStatus
+}
+
+func (f *fakePreviewDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+func (f *fakePreviewDocRepo) GetStatus(_ context.Context, docID string) (*models.DocumentStatus, error) {
+	return &models.DocumentStatus{
+		DocID:    docID,
+		Document: f.docs[docID],
+		Signers:  f.signers,
+		Stats:    &models.DocCompletionStats{DocID: docID, ExpectedCount: 2, SignedCount: 1, PendingCount: 1, CompletionRate: 50},
+	}, nil
+}
+
+func newTestPreviewLinkService() (*PreviewLinkService, *fakePreviewLinkRepo, *fakePreviewDocRepo) {
+	repo := &fakePreviewLinkRepo{}
+	signedAt := time.Now().Add(-time.Hour)
+	docs := &fakePreviewDocRepo{
+		docs: map[string]*models.Document{"policy": {DocID: "policy", Title: "Privacy policy"}},
+		signers: []*models.ExpectedSignerWithStatus{
+			{ExpectedSigner: models.ExpectedSigner{Email: "alice@example.com", Name: "Alice"}, HasSigned: true, SignedAt: &signedAt},
+			{ExpectedSigner: models.ExpectedSigner{Email: "bob@example.com"}},
+		},
+	}
+	return NewPreviewLinkService(repo, docs, "https://sign.example.com/"), repo, docs
+}
+
+func previewToken(t *testing.T, link *models.PreviewLink) string {
+	t.Helper()
+	token := strings.TrimPrefix(link.URL, "https://sign.example.com/preview/")
+	if token == link.URL || token == "" {
+		t.Fatalf("unexpected preview URL %q", link.URL)
+	}
+	return token
+}
+
+func TestPreviewLinkService_CreateLink(t *testing.T) {
+	svc, repo, _ := newTestPreviewLinkService()
+	ctx := context.Background()
+
+	link, err := svc.CreateLink(ctx, "policy", models.PreviewLinkInput{Label: " Auditors "}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("CreateLink failed: %v", err)
+	}
+	if link.Label != "Auditors" || link.IncludeSigners {
+		t.Errorf("unexpected link: %+v", link)
+	}
+	if d := time.Until(link.ExpiresAt); d < 71*time.Hour || d > 72*time.Hour {
+		t.Errorf("expected the default lifetime, expires in %v", d)
+	}
+	token := previewToken(t, link)
+	if _, stored := repo.hashes[token]; stored {
+		t.Error("expected the token not to be stored in clear")
+	}
+
+	invalid := []models.PreviewLinkInput{
+		{ExpiresInHours: -1},
+		{ExpiresInHours: 31 * 24},
+		{Label: strings.Repeat("a", 101)},
+	}
+	for _, input := range invalid {
+		if _, err := svc.CreateLink(ctx, "policy", input, "admin@example.com"); !errors.Is(err, ErrInvalidPreviewLink) {
+			t.Errorf("expected ErrInvalidPreviewLink for %+v, got %v", input, err)
+		}
+	}
+	if _, err := svc.CreateLink(ctx, "unknown", models.PreviewLinkInput{}, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestPreviewLinkService_GetPreview(t *testing.T) {
+	svc, repo, _ := newTestPreviewLinkService()
+	ctx := context.Background()
+
+	link, _ := svc.CreateLink(ctx, "policy", models.PreviewLinkInput{}, "admin@example.com")
+	preview, err := svc.GetPreview(ctx, previewToken(t, link))
+	if err != nil {
+		t.Fatalf("GetPreview failed: %v", err)
+	}
+	if preview.Title != "Privacy policy" || preview.Stats.SignedCount != 1 {
+		t.Errorf("unexpected preview: %+v", preview)
+	}
+	if preview.Signers != nil {
+		t.Error("expected signers to be hidden unless the link includes them")
+	}
+	if repo.links[0].ViewCount != 1 {
+		t.Errorf("expected the view to be recorded, got %d", repo.links[0].ViewCount)
+	}
+
+	withSigners, _ := svc.CreateLink(ctx, "policy", models.PreviewLinkInput{IncludeSigners: true}, "admin@example.com")
+	preview, err = svc.GetPreview(ctx, previewToken(t, withSigners))
+	if err != nil {
+		t.Fatalf("GetPreview failed: %v", err)
+	}
+	if len(preview.Signers) != 2 || preview.Signers[0].Email != "alice@example.com" || !preview.Signers[0].Signed {
+		t.Errorf("unexpected signers: %+v", preview.Signers)
+	}
+}
+
+func TestPreviewLinkService_GetPreview_Inactive(t *testing.T) {
+	svc, _, docs := newTestPreviewLinkService()
+	ctx := context.Background()
+
+	if _, err := svc.GetPreview(ctx, "unknown"); !errors.Is(err, models.ErrPreviewLinkNotFound) {
+		t.Errorf("expected ErrPreviewLinkNotFound for an unknown token, got %v", err)
+	}
+
+	revoked, _ := svc.CreateLink(ctx, "policy", models.PreviewLinkInput{}, "admin@example.com")
+	if _, err := svc.RevokeLink(ctx, "policy", revoked.ID, "admin@example.com"); err != nil {
+		t.Fatalf("RevokeLink failed: %v", err)
+	}
+	if _, err := svc.GetPreview(ctx, previewToken(t, revoked)); !errors.Is(err, models.ErrPreviewLinkNotFound) {
+		t.Errorf("expected ErrPreviewLinkNotFound for a revoked link, got %v", err)
+	}
+
+	expired, _ := svc.CreateLink(ctx, "policy", models.PreviewLinkInput{ExpiresInHours: 1}, "admin@example.com")
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := svc.GetPreview(ctx, previewToken(t, expired)); !errors.Is(err, models.ErrPreviewLinkNotFound) {
+		t.Errorf("expected ErrPreviewLinkNotFound for an expired link, got %v", err)
+	}
+	svc.now = time.Now
+
+	deleted, _ := svc.CreateLink(ctx, "policy", models.PreviewLinkInput{}, "admin@example.com")
+	delete(docs.docs, "policy")
+	if _, err := svc.GetPreview(ctx, previewToken(t, deleted)); !errors.Is(err, models.ErrPreviewLinkNotFound) {
+		t.Errorf("expected ErrPreviewLinkNotFound for a deleted document, got %v", err)
+	}
+}
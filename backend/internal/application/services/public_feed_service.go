@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// publicFeedDocuments lists the documents of the feed, newest first
+type publicFeedDocuments interface {
+	List(ctx context.Context, limit, offset int) ([]*models.Document, error)
+}
+
+// publicFeedStats computes the completion of a document
+type publicFeedStats interface {
+	GetStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
+}
+
+// publicFeedConfig provides the organisation name the feed is titled with
+type publicFeedConfig interface {
+	GetConfig() *models.MutableConfig
+}
+
+// PublicFeedService builds the public feed of the documents of an organisation, so that intranet
+// portals can list the current policies without an account
+type PublicFeedService struct {
+	documents      publicFeedDocuments
+	stats          publicFeedStats
+	config         publicFeedConfig
+	baseURL        string
+	limit          int
+	hideCompletion bool
+}
+
+// NewPublicFeedService creates a feed listing the limit most recent documents
+func NewPublicFeedService(documents publicFeedDocuments, stats publicFeedStats, config publicFeedConfig, baseURL string, limit int) *PublicFeedService {
+	return &PublicFeedService{
+		documents: documents,
+		stats:     stats,
+		config:    config,
+		baseURL:   baseURL,
+		limit:     limit,
+	}
+}
+
+// SetHideCompletion leaves the signature counts out of the feed
+func (s *PublicFeedService) SetHideCompletion(hide bool) {
+	s.hideCompletion = hide
+}
+
+// Feed returns the documents of the feed with their completion, unless hidden
+func (s *PublicFeedService) Feed(ctx context.Context) (*models.PublicFeed, error) {
+	docs, err := s.documents.List(ctx, s.limit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed documents: %w", err)
+	}
+
+	feed := &models.PublicFeed{
+		Title:     s.config.GetConfig().General.Organisation,
+		URL:       s.baseURL + "/",
+		Documents: make([]*models.PublicDocument, 0, len(docs)),
+	}
+	for _, doc := range docs {
+		item := &models.PublicDocument{
+			DocID:             doc.DocID,
+			Title:             doc.Title,
+			Description:       doc.Description,
+			Version:           doc.Version,
+			Checksum:          doc.Checksum,
+			ChecksumAlgorithm: doc.ChecksumAlgorithm,
+			URL:               s.baseURL + "/?doc=" + url.QueryEscape(doc.DocID),
+			CreatedAt:         doc.CreatedAt,
+			UpdatedAt:         doc.UpdatedAt,
+		}
+		if item.Checksum == "" {
+			item.ChecksumAlgorithm = ""
+		}
+		if !s.hideCompletion {
+			item.Completion = s.completion(ctx, doc.DocID)
+		}
+		if doc.UpdatedAt.After(feed.UpdatedAt) {
+			feed.UpdatedAt = doc.UpdatedAt
+		}
+		feed.Documents = append(feed.Documents, item)
+	}
+	return feed, nil
+}
+
+// completion returns the progress of the expected signers, nil for documents without any
+func (s *PublicFeedService) completion(ctx context.Context, docID string) *models.PublicCompletion {
+	stats, err := s.stats.GetStats(ctx, docID)
+	if err != nil {
+		logger.Logger.Warn("Failed to get feed document completion", "doc_id", docID, "error", err.Error())
+		return nil
+	}
+	if stats.ExpectedCount == 0 {
+		return nil
+	}
+	return &models.PublicCompletion{
+		ExpectedCount:  stats.ExpectedCount,
+		SignedCount:    stats.SignedCount,
+		CompletionRate: stats.CompletionRate,
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeFeedDocuments struct {
+	docs  []*models.Document
+	limit int
+}
+
+func (f *fakeFeedDocuments) List(_ context.Context, limit, _ int) ([]*models.Document, error) {
+	f.limit = limit
+	return f.docs, nil
+}
+
+type fakeFeedStats struct {
+	stats map[string]*models.DocCompletionStats
+	calls int
+}
+
+func (f *fakeFeedStats) GetStats(_ context.Context, docID string) (*models.DocCompletionStats, error) {
+	f.calls++
+	if s, ok := f.stats[docID]; ok {
+		return s, nil
+	}
+	return nil, errors.New("stats unavailable")
+}
+
+func newPublicFeedFixture() (*PublicFeedService, *fakeFeedDocuments, *fakeFeedStats) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	docs := &fakeFeedDocuments{docs: []*models.Document{
+		{DocID: "it-charter", Title: "IT charter", Version: 3, Checksum: "abc123", ChecksumAlgorithm: "SHA-256",
+			CreatedAt: created, UpdatedAt: created.Add(48 * time.Hour)},
+		{DocID: "code of conduct", Title: "Code of conduct", Version: 1, ChecksumAlgorithm: "SHA-256",
+			CreatedAt: created, UpdatedAt: created},
+	}}
+	stats := &fakeFeedStats{stats: map[string]*models.DocCompletionStats{
+		"it-charter":      {DocID: "it-charter", ExpectedCount: 4, SignedCount: 3, CompletionRate: 75},
+		"code of conduct": {DocID: "code of conduct"},
+	}}
+	config := &fakeBrandingConfig{cfg: models.MutableConfig{General: models.GeneralConfig{Organisation: "Acme"}}}
+	return NewPublicFeedService(docs, stats, config, "https://sign.acme.com", 50), docs, stats
+}
+
+func TestPublicFeedService_Feed(t *testing.T) {
+	svc, docs, _ := newPublicFeedFixture()
+
+	feed, err := svc.Feed(context.Background())
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	if docs.limit != 50 || feed.Title != "Acme" || len(feed.Documents) != 2 {
+		t.Fatalf("unexpected feed %+v (limit %d)", feed, docs.limit)
+	}
+	if !feed.UpdatedAt.Equal(docs.docs[0].UpdatedAt) {
+		t.Errorf("expected the feed update to be the latest document update, got %v", feed.UpdatedAt)
+	}
+
+	charter := feed.Documents[0]
+	if charter.Version != 3 || charter.Checksum != "abc123" || charter.URL != "https://sign.acme.com/?doc=it-charter" {
+		t.Errorf("unexpected document %+v", charter)
+	}
+	if charter.Completion == nil || charter.Completion.SignedCount != 3 || charter.Completion.CompletionRate != 75 {
+		t.Errorf("expected the completion of the expected signers, got %+v", charter.Completion)
+	}
+
+	conduct := feed.Documents[1]
+	if conduct.URL != "https://sign.acme.com/?doc=code+of+conduct" || conduct.ChecksumAlgorithm != "" || conduct.Completion != nil {
+		t.Errorf("expected an escaped URL, no checksum and no completion without expected signers, got %+v", conduct)
+	}
+}
+
+func TestPublicFeedService_HideCompletion(t *testing.T) {
+	svc, _, stats := newPublicFeedFixture()
+	svc.SetHideCompletion(true)
+
+	feed, err := svc.Feed(context.Background())
+	if err != nil {
+		t.Fatalf("Feed: %v", err)
+	}
+	for _, doc := range feed.Documents {
+		if doc.Completion != nil {
+			t.Errorf("expected no completion for %s, got %+v", doc.DocID, doc.Completion)
+		}
+	}
+	if stats.calls != 0 {
+		t.Errorf("expected no stats query when completion is hidden, got %d", stats.calls)
+	}
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidQuiz is returned when a document quiz fails validation
+var ErrInvalidQuiz = errors.New("invalid quiz")
+
+// quizRepository defines document quiz storage
+type quizRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.DocumentQuiz, error)
+	Upsert(ctx context.Context, docID string, input models.DocumentQuizInput, updatedBy string) (*models.DocumentQuiz, error)
+	Delete(ctx context.Context, docID string) error
+	ListResults(ctx context.Context, docID string) ([]*models.QuizResult, error)
+}
+
+// quizDocumentRepository checks that the quiz document exists
+type quizDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// QuizService manages comprehension quizzes signers must pass before signing
+type QuizService struct {
+	repo    quizRepository
+	docRepo quizDocumentRepository
+}
+
+// NewQuizService creates a new quiz service
+func NewQuizService(repo quizRepository, docRepo quizDocumentRepository) *QuizService {
+	return &QuizService{repo: repo, docRepo: docRepo}
+}
+
+// GetQuiz returns the quiz of a document, including the correct answers
+func (s *QuizService) GetQuiz(ctx context.Context, docID string) (*models.DocumentQuiz, error) {
+	quiz, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz == nil {
+		return nil, models.ErrQuizNotFound
+	}
+	return quiz, nil
+}
+
+// GetSignerQuiz returns the quiz shown to signers, or nil when the document has no quiz
+func (s *QuizService) GetSignerQuiz(ctx context.Context, docID string) (*models.SignerQuiz, error) {
+	quiz, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil || quiz == nil {
+		return nil, err
+	}
+	return quiz.ForSigner(), nil
+}
+
+// UpdateQuiz validates and stores the quiz of a document
+func (s *QuizService) UpdateQuiz(ctx context.Context, docID string, input models.DocumentQuizInput, updatedBy string) (*models.DocumentQuiz, error) {
+	if err := validateQuiz(&input); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	return s.repo.Upsert(ctx, docID, input, updatedBy)
+}
+
+// DeleteQuiz removes the quiz of a document; recorded scores are kept with the signatures
+func (s *QuizService) DeleteQuiz(ctx context.Context, docID string) error {
+	return s.repo.Delete(ctx, docID)
+}
+
+// Grade scores the answers submitted with a signature.
+// It returns nil when the document has no quiz.
+func (s *QuizService) Grade(ctx context.Context, docID string, answers models.QuizAnswers) (*int, error) {
+	quiz, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+	if quiz == nil {
+		return nil, nil
+	}
+	if len(answers) == 0 {
+		return nil, models.ErrQuizAnswersRequired
+	}
+
+	score := quiz.Score(answers)
+	if score < quiz.PassThreshold {
+		return &score, models.ErrQuizFailed
+	}
+	return &score, nil
+}
+
+// GetStats aggregates the quiz results recorded with the signatures of a document.
+// Answers are compared with the current version of the quiz.
+func (s *QuizService) GetStats(ctx context.Context, docID string) (*models.QuizStats, error) {
+	quiz, err := s.GetQuiz(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.repo.ListResults(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.QuizStats{
+		DocID:         docID,
+		PassThreshold: quiz.PassThreshold,
+		ResultCount:   len(results),
+		Questions:     make([]models.QuizQuestionStats, 0, len(quiz.Questions)),
+	}
+
+	total := 0
+	for _, result := range results {
+		total += result.Score
+	}
+	if len(results) > 0 {
+		stats.AverageScore = float64(total) / float64(len(results))
+	}
+
+	for _, question := range quiz.Questions {
+		qs := models.QuizQuestionStats{
+			ID:           question.ID,
+			Text:         question.Text,
+			OptionCounts: make([]int, len(question.Options)),
+		}
+		for _, result := range results {
+			choice, ok := result.Answers[question.ID]
+			if !ok || choice < 0 || choice >= len(question.Options) {
+				continue
+			}
+			qs.OptionCounts[choice]++
+			if choice == question.Answer {
+				qs.CorrectCount++
+			}
+		}
+		if len(results) > 0 {
+			qs.CorrectRate = float64(qs.CorrectCount) * 100 / float64(len(results))
+		}
+		stats.Questions = append(stats.Questions, qs)
+	}
+	return stats, nil
+}
+
+func validateQuiz(input *models.DocumentQuizInput) error {
+	if len(input.Questions) == 0 {
+		return fmt.Errorf("%w: at least one question is required", ErrInvalidQuiz)
+	}
+	if input.PassThreshold < 1 || input.PassThreshold > 100 {
+		return fmt.Errorf("%w: passThreshold must be between 1 and 100", ErrInvalidQuiz)
+	}
+
+	seen := make(map[string]bool, len(input.Questions))
+	for i := range input.Questions {
+		question := &input.Questions[i]
+		question.ID = strings.TrimSpace(question.ID)
+		question.Text = strings.TrimSpace(question.Text)
+		if question.ID == "" {
+			question.ID = fmt.Sprintf("q%d", i+1)
+		}
+		if seen[question.ID] {
+			return fmt.Errorf("%w: duplicate question id %q", ErrInvalidQuiz, question.ID)
+		}
+		seen[question.ID] = true
+		if question.Text == "" {
+			return fmt.Errorf("%w: question %q has no text", ErrInvalidQuiz, question.ID)
+		}
+		if len(question.Options) < 2 {
+			return fmt.Errorf("%w: question %q needs at least two options", ErrInvalidQuiz, question.ID)
+		}
+		if question.Answer < 0 || question.Answer >= len(question.Options) {
+			return fmt.Errorf("%w: question %q has no valid answer", ErrInvalidQuiz, question.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeQuizRepo struct {
+	quizzes map[string]*models.DocumentQuiz
+	results []*models.QuizResult
+}
+
+func newFakeQuizRepo() *fakeQuizRepo {
+	return &fakeQuizRepo{quizzes: make(map[string]*models.DocumentQuiz)}
+}
+
+func (f *fakeQuizRepo) GetByDocID(_ context.Context, docID string) (*models.DocumentQuiz, error) {
+	return f.quizzes[docID], nil
+}
+
+func (f *fakeQuizRepo) Upsert(_ context.Context, docID string, input models.DocumentQuizInput, updatedBy string) (*models.DocumentQuiz, error) {
+	q := &models.DocumentQuiz{DocID: docID, Questions: input.Questions, PassThreshold: input.PassThreshold, UpdatedBy: updatedBy}
+	f.quizzes[docID] = q
+	return q, nil
+}
+
+func (f *fakeQuizRepo) Delete(_ context.Context, docID string) error {
+	if _, ok := f.quizzes[docID]; !ok {
+		return models.ErrQuizNotFound
+	}
+	delete(f.quizzes, docID)
+	return nil
+}
+
+func (f *fakeQuizRepo) ListResults(_ context.Context, _ string) ([]*models.QuizResult, error) {
+	return f.results, nil
+}
+
+func sampleQuizInput() models.DocumentQuizInput {
+	return models.DocumentQuizInput{
+		PassThreshold: 50,
+		Questions: []models.QuizQuestion{
+			{ID: "q1", Text: "Who can access client data?", Options: []string{"Everyone", "Authorized staff"}, Answer: 1},
+			{ID: "q2", Text: "Report incidents within", Options: []string{"24 hours", "1 week", "Never"}, Answer: 0},
+		},
+	}
+}
+
+func TestQuizService_UpdateQuiz_Validation(t *testing.T) {
+	docs := newFakeDocumentRepository()
+	docs.documents["doc1"] = &models.Document{DocID: "doc1"}
+	service := NewQuizService(newFakeQuizRepo(), docs)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		mutate func(*models.DocumentQuizInput)
+	}{
+		{"no questions", func(in *models.DocumentQuizInput) { in.Questions = nil }},
+		{"threshold too low", func(in *models.DocumentQuizInput) { in.PassThreshold = 0 }},
+		{"threshold too high", func(in *models.DocumentQuizInput) { in.PassThreshold = 101 }},
+		{"duplicate id", func(in *models.DocumentQuizInput) { in.Questions[1].ID = "q1" }},
+		{"empty text", func(in *models.DocumentQuizInput) { in.Questions[0].Text = " " }},
+		{"single option", func(in *models.DocumentQuizInput) { in.Questions[0].Options = []string{"Yes"} }},
+		{"answer out of range", func(in *models.DocumentQuizInput) { in.Questions[1].Answer = 3 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := sampleQuizInput()
+			tt.mutate(&input)
+			if _, err := service.UpdateQuiz(ctx, "doc1", input, "admin@example.com"); !errors.Is(err, ErrInvalidQuiz) {
+				t.Fatalf("expected ErrInvalidQuiz, got %v", err)
+			}
+		})
+	}
+
+	if _, err := service.UpdateQuiz(ctx, "missing", sampleQuizInput(), "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Fatalf("expected ErrDocumentNotFound, got %v", err)
+	}
+
+	input := sampleQuizInput()
+	input.Questions[1].ID = ""
+	quiz, err := service.UpdateQuiz(ctx, "doc1", input, "admin@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quiz.Questions[1].ID != "q2" {
+		t.Fatalf("expected generated question id q2, got %q", quiz.Questions[1].ID)
+	}
+}
+
+func TestQuizService_Grade(t *testing.T) {
+	repo := newFakeQuizRepo()
+	input := sampleQuizInput()
+	repo.quizzes["doc1"] = &models.DocumentQuiz{DocID: "doc1", Questions: input.Questions, PassThreshold: input.PassThreshold}
+	service := NewQuizService(repo, newFakeDocumentRepository())
+	ctx := context.Background()
+
+	score, err := service.Grade(ctx, "no-quiz", nil)
+	if err != nil || score != nil {
+		t.Fatalf("documents without quiz should not be graded, got %v, %v", score, err)
+	}
+
+	if _, err := service.Grade(ctx, "doc1", nil); !errors.Is(err, models.ErrQuizAnswersRequired) {
+		t.Fatalf("expected ErrQuizAnswersRequired, got %v", err)
+	}
+
+	score, err = service.Grade(ctx, "doc1", models.QuizAnswers{"q1": 0, "q2": 1})
+	if !errors.Is(err, models.ErrQuizFailed) || score == nil || *score != 0 {
+		t.Fatalf("expected failed quiz with score 0, got %v, %v", score, err)
+	}
+
+	score, err = service.Grade(ctx, "doc1", models.QuizAnswers{"q1": 1, "q2": 2})
+	if err != nil || score == nil || *score != 50 {
+		t.Fatalf("expected passed quiz with score 50, got %v, %v", score, err)
+	}
+}
+
+func TestQuizService_GetStats(t *testing.T) {
+	repo := newFakeQuizRepo()
+	input := sampleQuizInput()
+	repo.quizzes["doc1"] = &models.DocumentQuiz{DocID: "doc1", Questions: input.Questions, PassThreshold: input.PassThreshold}
+	repo.results = []*models.QuizResult{
+		{UserEmail: "alice@example.com", Score: 100, Answers: models.QuizAnswers{"q1": 1, "q2": 0}},
+		{UserEmail: "bob@example.com", Score: 50, Answers: models.QuizAnswers{"q1": 1, "q2": 1}},
+		{UserEmail: "carol@example.com", Score: 50, Answers: models.QuizAnswers{"q1": 0, "q2": 0, "removed": 1}},
+		{UserEmail: "dave@example.com", Score: 50, Answers: models.QuizAnswers{"q1": 1, "q2": 7}},
+	}
+	service := NewQuizService(repo, newFakeDocumentRepository())
+
+	stats, err := service.GetStats(context.Background(), "doc1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.ResultCount != 4 || stats.AverageScore != 62.5 {
+		t.Fatalf("unexpected totals: %+v", stats)
+	}
+	if q := stats.Questions[0]; q.CorrectCount != 3 || q.CorrectRate != 75 || q.OptionCounts[0] != 1 || q.OptionCounts[1] != 3 {
+		t.Fatalf("unexpected q1 stats: %+v", q)
+	}
+	if q := stats.Questions[1]; q.CorrectCount != 2 || q.OptionCounts[1] != 1 || q.OptionCounts[2] != 0 {
+		t.Fatalf("unexpected q2 stats: %+v", q)
+	}
+
+	if _, err := service.GetStats(context.Background(), "no-quiz"); !errors.Is(err, models.ErrQuizNotFound) {
+		t.Fatalf("expected ErrQuizNotFound, got %v", err)
+	}
+}
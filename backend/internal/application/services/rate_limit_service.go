@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// DefaultRateLimitWindow is the sliding window of rules that do not set one
+	DefaultRateLimitWindow = time.Hour
+
+	// rateLimitViolationRetention is how long violations stay listed to admins
+	rateLimitViolationRetention = 30 * 24 * time.Hour
+)
+
+// rateLimitRepository defines sliding window counters and violation storage
+type rateLimitRepository interface {
+	CountRecent(ctx context.Context, action, scope, subject string, since time.Time) (int, error)
+	Record(ctx context.Context, action, userEmail, ip string) error
+	RecordViolation(ctx context.Context, action, scope, userEmail, ip string) error
+	ListViolations(ctx context.Context, since time.Time, limit int) ([]*models.RateLimitViolation, error)
+	ListOffenders(ctx context.Context, since time.Time, limit int) ([]*models.RateLimitOffender, error)
+	DeleteBefore(ctx context.Context, acceptedBefore, blockedBefore time.Time) (int64, error)
+}
+
+// RateLimitRule caps the requests of an action per user and per client IP over a sliding window.
+// A limit of zero disables that scope.
+type RateLimitRule struct {
+	PerUser int
+	PerIP   int
+	Window  time.Duration
+}
+
+// RateLimitService enforces per-user and per-IP limits with counters stored in the database,
+// so that limits hold across instances and restarts, and records violations for admins
+type RateLimitService struct {
+	repo  rateLimitRepository
+	rules map[string]RateLimitRule
+}
+
+// NewRateLimitService creates a rate limit service enforcing the given rules by action
+func NewRateLimitService(repo rateLimitRepository, rules map[string]RateLimitRule) *RateLimitService {
+	for action, rule := range rules {
+		if rule.Window <= 0 {
+			rule.Window = DefaultRateLimitWindow
+			rules[action] = rule
+		}
+	}
+	return &RateLimitService{repo: repo, rules: rules}
+}
+
+// Allow counts a request of userEmail from ip. It returns models.ErrRateLimited and records
+// a violation when the user or the IP already reached its limit for the action.
+func (s *RateLimitService) Allow(ctx context.Context, action, userEmail, ip string) error {
+	rule, ok := s.rules[action]
+	if !ok {
+		return nil
+	}
+	since := time.Now().Add(-rule.Window)
+
+	checks := []struct {
+		scope   string
+		subject string
+		limit   int
+	}{
+		{models.RateLimitScopeUser, userEmail, rule.PerUser},
+		{models.RateLimitScopeIP, ip, rule.PerIP},
+	}
+	for _, check := range checks {
+		if check.limit <= 0 || check.subject == "" {
+			continue
+		}
+		count, err := s.repo.CountRecent(ctx, action, check.scope, check.subject, since)
+		if err != nil {
+			return fmt.Errorf("rate limit check failed: %w", err)
+		}
+		if count >= check.limit {
+			logger.Logger.Warn("Rate limit exceeded",
+				"action", action,
+				"scope", check.scope,
+				"user_email", userEmail,
+				"ip", ip,
+				"count", count)
+			if err := s.repo.RecordViolation(ctx, action, check.scope, userEmail, ip); err != nil {
+				logger.Logger.Error("Failed to record rate limit violation", "action", action, "error", err.Error())
+			}
+			return models.ErrRateLimited
+		}
+	}
+
+	if err := s.repo.Record(ctx, action, userEmail, ip); err != nil {
+		return fmt.Errorf("failed to record rate limit event: %w", err)
+	}
+	return nil
+}
+
+// Quotas returns the limits of the protected actions applying to userEmail and ip, and how many
+// requests are left in their window. Counters are kept per tenant.
+func (s *RateLimitService) Quotas(ctx context.Context, userEmail, ip string) ([]*models.RateLimitQuota, error) {
+	actions := make([]string, 0, len(s.rules))
+	for action := range s.rules {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	quotas := []*models.RateLimitQuota{}
+	for _, action := range actions {
+		rule := s.rules[action]
+		since := time.Now().Add(-rule.Window)
+		checks := []struct {
+			scope   string
+			subject string
+			limit   int
+		}{
+			{models.RateLimitScopeUser, userEmail, rule.PerUser},
+			{models.RateLimitScopeIP, ip, rule.PerIP},
+		}
+		for _, check := range checks {
+			if check.limit <= 0 || check.subject == "" {
+				continue
+			}
+			count, err := s.repo.CountRecent(ctx, action, check.scope, check.subject, since)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count rate limit events: %w", err)
+			}
+			quotas = append(quotas, &models.RateLimitQuota{
+				Name:          action,
+				Scope:         check.scope,
+				Limit:         check.limit,
+				WindowSeconds: int(rule.Window.Seconds()),
+				Remaining:     max(check.limit-count, 0),
+			})
+		}
+	}
+	return quotas, nil
+}
+
+// RecordViolation records a request rejected by a limit enforced elsewhere, such as magic link requests
+func (s *RateLimitService) RecordViolation(ctx context.Context, action, scope, userEmail, ip string) {
+	if err := s.repo.RecordViolation(ctx, action, scope, userEmail, ip); err != nil {
+		logger.Logger.Error("Failed to record rate limit violation", "action", action, "error", err.Error())
+	}
+}
+
+// ListViolations returns the violations of the last period, newest first
+func (s *RateLimitService) ListViolations(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitViolation, error) {
+	return s.repo.ListViolations(ctx, time.Now().Add(-period), limit)
+}
+
+// ListOffenders returns the users and IPs with the most violations over the last period
+func (s *RateLimitService) ListOffenders(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitOffender, error) {
+	return s.repo.ListOffenders(ctx, time.Now().Add(-period), limit)
+}
+
+// CleanupEvents removes counters older than the longest window and expired violations
+func (s *RateLimitService) CleanupEvents(ctx context.Context) (int64, error) {
+	var window time.Duration
+	for _, rule := range s.rules {
+		if rule.Window > window {
+			window = rule.Window
+		}
+	}
+	now := time.Now()
+	return s.repo.DeleteBefore(ctx, now.Add(-window), now.Add(-rateLimitViolationRetention))
+}
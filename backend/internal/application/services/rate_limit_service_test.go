@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeRateLimitEvent struct {
+	action    string
+	userEmail string
+	ip        string
+	scope     string
+	at        time.Time
+}
+
+type fakeRateLimitRepo struct {
+	events []fakeRateLimitEvent
+}
+
+func (f *fakeRateLimitRepo) CountRecent(_ context.Context, action, scope, subject string, since time.Time) (int, error) {
+	count := 0
+	for _, e := range f.events {
+		if e.scope != "" || e.action != action || !e.at.After(since) {
+			continue
+		}
+		if (scope == models.RateLimitScopeUser && e.userEmail == subject) || (scope == models.RateLimitScopeIP && e.ip == subject) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeRateLimitRepo) Record(_ context.Context, action, userEmail, ip string) error {
+	f.events = append(f.events, fakeRateLimitEvent{action: action, userEmail: userEmail, ip: ip, at: time.Now()})
+	return nil
+}
+
+func (f *fakeRateLimitRepo) RecordViolation(_ context.Context, action, scope, userEmail, ip string) error {
+	f.events = append(f.events, fakeRateLimitEvent{action: action, userEmail: userEmail, ip: ip, scope: scope, at: time.Now()})
+	return nil
+}
+
+func (f *fakeRateLimitRepo) ListViolations(_ context.Context, since time.Time, limit int) ([]*models.RateLimitViolation, error) {
+	var out []*models.RateLimitViolation
+	for _, e := range f.events {
+		if e.scope != "" && e.at.After(since) && len(out) < limit {
+			out = append(out, &models.RateLimitViolation{Action: e.action, Scope: e.scope, UserEmail: e.userEmail, IPAddress: e.ip, CreatedAt: e.at})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeRateLimitRepo) ListOffenders(_ context.Context, _ time.Time, _ int) ([]*models.RateLimitOffender, error) {
+	return nil, nil
+}
+
+func (f *fakeRateLimitRepo) DeleteBefore(_ context.Context, acceptedBefore, blockedBefore time.Time) (int64, error) {
+	var kept []fakeRateLimitEvent
+	for _, e := range f.events {
+		if (e.scope == "" && e.at.Before(acceptedBefore)) || (e.scope != "" && e.at.Before(blockedBefore)) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	deleted := int64(len(f.events) - len(kept))
+	f.events = kept
+	return deleted, nil
+}
+
+func (f *fakeRateLimitRepo) violations() []fakeRateLimitEvent {
+	var out []fakeRateLimitEvent
+	for _, e := range f.events {
+		if e.scope != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func TestRateLimitService_Allow_PerUser(t *testing.T) {
+	repo := &fakeRateLimitRepo{}
+	svc := NewRateLimitService(repo, map[string]RateLimitRule{
+		models.RateLimitActionSignature: {PerUser: 2, PerIP: 10},
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := svc.Allow(ctx, models.RateLimitActionSignature, "alice@example.com", "10.0.0.1"); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+	err := svc.Allow(ctx, models.RateLimitActionSignature, "alice@example.com", "10.0.0.2")
+	if !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if err := svc.Allow(ctx, models.RateLimitActionSignature, "bob@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("expected other user to be allowed, got %v", err)
+	}
+
+	violations := repo.violations()
+	if len(violations) != 1 || violations[0].scope != models.RateLimitScopeUser || violations[0].ip != "10.0.0.2" {
+		t.Errorf("expected one user violation, got %+v", violations)
+	}
+}
+
+func TestRateLimitService_Allow_PerIP(t *testing.T) {
+	repo := &fakeRateLimitRepo{}
+	svc := NewRateLimitService(repo, map[string]RateLimitRule{
+		models.RateLimitActionSignature: {PerIP: 2},
+	})
+	ctx := context.Background()
+
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		if err := svc.Allow(ctx, models.RateLimitActionSignature, email, "10.0.0.1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	err := svc.Allow(ctx, models.RateLimitActionSignature, "c@example.com", "10.0.0.1")
+	if !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if violations := repo.violations(); len(violations) != 1 || violations[0].scope != models.RateLimitScopeIP {
+		t.Errorf("expected one IP violation, got %+v", violations)
+	}
+	if err := svc.Allow(ctx, models.RateLimitActionSignature, "c@example.com", ""); err != nil {
+		t.Errorf("expected request without IP to skip the IP limit, got %v", err)
+	}
+}
+
+func TestRateLimitService_Allow_SlidingWindow(t *testing.T) {
+	repo := &fakeRateLimitRepo{}
+	svc := NewRateLimitService(repo, map[string]RateLimitRule{
+		models.RateLimitActionSignature: {PerUser: 1, Window: time.Minute},
+	})
+	ctx := context.Background()
+
+	repo.events = append(repo.events, fakeRateLimitEvent{
+		action:    models.RateLimitActionSignature,
+		userEmail: "alice@example.com",
+		at:        time.Now().Add(-2 * time.Minute),
+	})
+	if err := svc.Allow(ctx, models.RateLimitActionSignature, "alice@example.com", ""); err != nil {
+		t.Fatalf("expected events outside the window to be ignored, got %v", err)
+	}
+	if err := svc.Allow(ctx, models.RateLimitActionSignature, "alice@example.com", ""); !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	deleted, err := svc.CleanupEvents(ctx)
+	if err != nil {
+		t.Fatalf("CleanupEvents error: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected the expired counter to be deleted, got %d", deleted)
+	}
+}
+
+func TestRateLimitService_Allow_UnknownActionIsUnlimited(t *testing.T) {
+	repo := &fakeRateLimitRepo{}
+	svc := NewRateLimitService(repo, map[string]RateLimitRule{})
+
+	if err := svc.Allow(context.Background(), models.RateLimitActionSignature, "alice@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.events) != 0 {
+		t.Errorf("expected no event for an unlimited action, got %d", len(repo.events))
+	}
+}
+
+func TestRateLimitService_Quotas(t *testing.T) {
+	repo := &fakeRateLimitRepo{}
+	svc := NewRateLimitService(repo, map[string]RateLimitRule{
+		models.RateLimitActionSignature: {PerUser: 3, PerIP: 10, Window: time.Minute},
+	})
+	ctx := context.Background()
+
+	if err := svc.Allow(ctx, models.RateLimitActionSignature, "alice@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	quotas, err := svc.Quotas(ctx, "alice@example.com", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(quotas) != 2 {
+		t.Fatalf("expected a user and an IP quota, got %+v", quotas)
+	}
+	if q := quotas[0]; q.Scope != models.RateLimitScopeUser || q.Limit != 3 || q.Remaining != 2 || q.WindowSeconds != 60 {
+		t.Errorf("unexpected user quota %+v", q)
+	}
+	if q := quotas[1]; q.Scope != models.RateLimitScopeIP || q.Remaining != 9 {
+		t.Errorf("unexpected IP quota %+v", q)
+	}
+
+	// Anonymous callers only have the IP quota
+	quotas, _ = svc.Quotas(ctx, "", "10.0.0.2")
+	if len(quotas) != 1 || quotas[0].Scope != models.RateLimitScopeIP || quotas[0].Remaining != 10 {
+		t.Errorf("unexpected anonymous quotas %+v", quotas)
+	}
+}
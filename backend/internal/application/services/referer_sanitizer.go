@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidRefererPolicy is returned for an unknown referer policy
+var ErrInvalidRefererPolicy = errors.New("invalid referer policy")
+
+// refererSanitizeBatch is the number of signatures read per query
+const refererSanitizeBatch = 500
+
+// refererStore reads and rewrites the referers recorded with signatures
+type refererStore interface {
+	ListReferers(ctx context.Context, afterID int64, limit int) ([]models.SignatureReferer, error)
+	UpdateReferer(ctx context.Context, id int64, referer *string) error
+}
+
+// chainRebuilder recomputes the prev_hash pointers of the signature chain
+type chainRebuilder interface {
+	RebuildChain(ctx context.Context) error
+}
+
+// RefererSanitizer applies a referer policy to the signatures recorded before it was set
+type RefererSanitizer struct {
+	store refererStore
+	chain chainRebuilder
+}
+
+// NewRefererSanitizer creates a referer sanitizer
+func NewRefererSanitizer(store refererStore, chain chainRebuilder) *RefererSanitizer {
+	return &RefererSanitizer{store: store, chain: chain}
+}
+
+// Sanitize rewrites the recorded referers under policy. The referer is part of the record hash,
+// so once referers changed the prev_hash pointers of the chain are rebuilt.
+// A dry run only counts the signatures that would change.
+func (s *RefererSanitizer) Sanitize(ctx context.Context, policy models.RefererPolicy, dryRun bool) (*models.RefererSanitizeResult, error) {
+	if policy == "" || policy == models.RefererPolicyKeep || !policy.IsValid() {
+		return nil, fmt.Errorf("%w: must be origin, hash or drop", ErrInvalidRefererPolicy)
+	}
+
+	result := &models.RefererSanitizeResult{Policy: policy, DryRun: dryRun}
+	var afterID int64
+	for {
+		referers, err := s.store.ListReferers(ctx, afterID, refererSanitizeBatch)
+		if err != nil {
+			return nil, err
+		}
+		for _, ref := range referers {
+			afterID = ref.ID
+			result.Scanned++
+
+			sanitized := policy.Apply(&ref.Referer)
+			if sanitized != nil && *sanitized == ref.Referer {
+				continue
+			}
+			result.Changed++
+			if dryRun {
+				continue
+			}
+			if err := s.store.UpdateReferer(ctx, ref.ID, sanitized); err != nil {
+				return nil, err
+			}
+		}
+		if len(referers) < refererSanitizeBatch {
+			break
+		}
+	}
+
+	if result.Changed > 0 && !dryRun {
+		if err := s.chain.RebuildChain(ctx); err != nil {
+			return nil, fmt.Errorf("failed to rebuild signature chain: %w", err)
+		}
+		result.ChainRebuilt = true
+		logger.Logger.Info("Sanitized signature referers", "policy", policy, "changed", result.Changed)
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeRefererStore struct {
+	referers map[int64]*string
+}
+
+func (f *fakeRefererStore) ListReferers(_ context.Context, afterID int64, limit int) ([]models.SignatureReferer, error) {
+	var list []models.SignatureReferer
+	for id := afterID + 1; id <= int64(len(f.referers)) && len(list) < limit; id++ {
+		if ref := f.referers[id]; ref != nil {
+			list = append(list, models.SignatureReferer{ID: id, Referer: *ref})
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeRefererStore) UpdateReferer(_ context.Context, id int64, referer *string) error {
+	f.referers[id] = referer
+	return nil
+}
+
+type fakeChainRebuilder struct{ rebuilt int }
+
+func (f *fakeChainRebuilder) RebuildChain(_ context.Context) error {
+	f.rebuilt++
+	return nil
+}
+
+func newRefererFixture() *fakeRefererStore {
+	internal, origin, service := "https://intranet.corp.local/hr?id=1", "https://docs.example.com", "google-docs"
+	return &fakeRefererStore{referers: map[int64]*string{1: &internal, 2: nil, 3: &origin, 4: &service}}
+}
+
+func TestRefererSanitizer_Origin(t *testing.T) {
+	store, chain := newRefererFixture(), &fakeChainRebuilder{}
+	sanitizer := NewRefererSanitizer(store, chain)
+
+	result, err := sanitizer.Sanitize(context.Background(), models.RefererPolicyOrigin, false)
+	if err != nil {
+		t.Fatalf("Sanitize err: %v", err)
+	}
+	if result.Scanned != 3 || result.Changed != 1 || !result.ChainRebuilt || chain.rebuilt != 1 {
+		t.Errorf("unexpected result %+v (rebuilt %d)", result, chain.rebuilt)
+	}
+	if got := *store.referers[1]; got != "https://intranet.corp.local" {
+		t.Errorf("referer 1 = %q, expected the origin", got)
+	}
+	if got := *store.referers[4]; got != "google-docs" {
+		t.Errorf("service name should be kept, got %q", got)
+	}
+}
+
+func TestRefererSanitizer_DryRunAndDrop(t *testing.T) {
+	store, chain := newRefererFixture(), &fakeChainRebuilder{}
+	sanitizer := NewRefererSanitizer(store, chain)
+
+	result, err := sanitizer.Sanitize(context.Background(), models.RefererPolicyDrop, true)
+	if err != nil || result.Changed != 3 || result.ChainRebuilt {
+		t.Fatalf("unexpected dry run %+v, %v", result, err)
+	}
+	if store.referers[1] == nil || chain.rebuilt != 0 {
+		t.Fatal("dry run should not change anything")
+	}
+
+	if _, err := sanitizer.Sanitize(context.Background(), models.RefererPolicyDrop, false); err != nil {
+		t.Fatalf("Sanitize err: %v", err)
+	}
+	for id, ref := range store.referers {
+		if ref != nil {
+			t.Errorf("referer %d should be dropped, got %q", id, *ref)
+		}
+	}
+
+	// Nothing left to change: the chain is not rebuilt again
+	result, err = sanitizer.Sanitize(context.Background(), models.RefererPolicyDrop, false)
+	if err != nil || result.Changed != 0 || chain.rebuilt != 1 {
+		t.Errorf("unexpected second run %+v, %v (rebuilt %d)", result, err, chain.rebuilt)
+	}
+}
+
+func TestRefererSanitizer_InvalidPolicy(t *testing.T) {
+	sanitizer := NewRefererSanitizer(newRefererFixture(), &fakeChainRebuilder{})
+	for _, policy := range []models.RefererPolicy{"", models.RefererPolicyKeep, "truncate"} {
+		if _, err := sanitizer.Sanitize(context.Background(), policy, false); !errors.Is(err, ErrInvalidRefererPolicy) {
+			t.Errorf("policy %q: expected ErrInvalidRefererPolicy, got %v", policy, err)
+		}
+	}
+}
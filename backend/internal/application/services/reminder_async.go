@@ -4,12 +4,21 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
+const (
+	// ReminderDigestTemplate is the email template grouping every pending document of a recipient
+	ReminderDigestTemplate = "signature_reminder_digest"
+
+	// reminderDigestSender identifies digests sent by the scheduler in the reminder log
+	reminderDigestSender = "system"
+)
+
 // emailQueueRepository defines minimal interface for email queue operations
 type emailQueueRepository interface {
 	Enqueue(ctx context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error)
@@ -19,6 +28,8 @@ type emailQueueRepository interface {
 // asyncExpectedSignerRepository defines expected signer operations for async reminders
 type asyncExpectedSignerRepository interface {
 	ListWithStatusByDocID(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
+	ListPendingByEmail(ctx context.Context, email string) ([]*models.PendingDocument, error)
+	ListPendingRecipients(ctx context.Context, notRemindedSince time.Time) ([]string, error)
 }
 
 // asyncReminderRepository defines reminder logging for async service
@@ -34,11 +45,49 @@ type asyncMagicLinkService interface {
 	CreateReminderAuthToken(ctx context.Context, email string, docID string) (string, error)
 }
 
+// reminderConfigStore provides the tenant reminder escalation settings
+type reminderConfigStore interface {
+	GetConfig() *models.MutableConfig
+}
+
 // translator defines translation operations
 type translator interface {
 	T(locale, key string) string
 }
 
+// recipientLocaleResolver resolves the preferred locale of an email recipient
+type recipientLocaleResolver interface {
+	ResolveLocale(ctx context.Context, email, fallback string) string
+}
+
+// recipientTimezoneResolver resolves the timezone of an email recipient, empty when unknown
+type recipientTimezoneResolver interface {
+	ResolveTimezone(ctx context.Context, email string) string
+}
+
+// deferredReminderTokens creates reminder auth tokens whose validity starts at delivery, for
+// reminders deferred to the working hours of their recipient
+type deferredReminderTokens interface {
+	CreateReminderAuthTokenFrom(ctx context.Context, email, docID string, from time.Time) (string, error)
+}
+
+// lockedReminderHistory reads the last reminder of a recipient while holding a lock on the
+// recipient for the rest of the transaction, so concurrent sends cannot both pass the minimum
+// interval. Without it, the interval uses the last reminder listed with the signers.
+type lockedReminderHistory interface {
+	LockLastReminder(ctx context.Context, docID, email string) (*time.Time, error)
+}
+
+// documentReminderPolicies tightens the reminder settings of a document with the policies of its tags
+type documentReminderPolicies interface {
+	ReminderConfigForDocument(ctx context.Context, docID string, base models.ReminderConfig) models.ReminderConfig
+}
+
+// signerDeadlineExtensions provides the deadline extensions of the signers of a document
+type signerDeadlineExtensions interface {
+	SignerExtensions(ctx context.Context, docID string) (map[string]*models.SignerExtensions, error)
+}
+
 // ReminderAsyncService manages email notifications using asynchronous queue
 type ReminderAsyncService struct {
 	expectedSignerRepo asyncExpectedSignerRepository
@@ -46,6 +95,11 @@ type ReminderAsyncService struct {
 	queueRepo          emailQueueRepository
 	magicLinkService   asyncMagicLinkService
 	i18n               translator
+	configStore        reminderConfigStore
+	locales            recipientLocaleResolver
+	timezones          recipientTimezoneResolver
+	policies           documentReminderPolicies
+	extensions         signerDeadlineExtensions
 	baseURL            string
 	useAsyncQueue      bool // Feature flag to enable/disable async queue
 }
@@ -70,6 +124,104 @@ func NewReminderAsyncService(
 	}
 }
 
+// SetConfigStore sets the source of the reminder escalation settings.
+// Without it, escalation uses the default thresholds.
+func (s *ReminderAsyncService) SetConfigStore(store reminderConfigStore) {
+	s.configStore = store
+}
+
+// SetLocaleResolver sends each reminder in the preferred locale of its recipient, falling back
+// to the tenant default. Without it, reminders use the locale they are sent with.
+func (s *ReminderAsyncService) SetLocaleResolver(locales recipientLocaleResolver) {
+	s.locales = locales
+}
+
+// SetTimezoneResolver sets the source of recipient timezones, used by working hours.
+// Without it, working hours follow the tenant timezone.
+func (s *ReminderAsyncService) SetTimezoneResolver(timezones recipientTimezoneResolver) {
+	s.timezones = timezones
+}
+
+// SetReminderPolicies applies the reminder policies of document tags on top of the tenant
+// settings (optional)
+func (s *ReminderAsyncService) SetReminderPolicies(policies documentReminderPolicies) {
+	s.policies = policies
+}
+
+// SetDeadlineExtensions makes reminders follow the deadline extensions of signers (optional):
+// signers awaiting a decision on a request are not reminded, and the escalation of signers with
+// a running extension starts over from its approval
+func (s *ReminderAsyncService) SetDeadlineExtensions(extensions signerDeadlineExtensions) {
+	s.extensions = extensions
+}
+
+// signerExtensions returns the deadline extensions of the signers of a document. A failure is
+// logged and reminders are sent as without extensions.
+func (s *ReminderAsyncService) signerExtensions(ctx context.Context, docID string) map[string]*models.SignerExtensions {
+	if s.extensions == nil {
+		return nil
+	}
+	extensions, err := s.extensions.SignerExtensions(ctx, docID)
+	if err != nil {
+		logger.Logger.Warn("Failed to load deadline extensions for reminders", "doc_id", docID, "error", err.Error())
+		return nil
+	}
+	return extensions
+}
+
+// signerReminderLevel selects the escalation level of the next reminder of a signer. While an
+// approved extension runs, the level follows the days since its approval only.
+func signerReminderLevel(cfg models.ReminderConfig, signer *models.ExpectedSignerWithStatus, extensions *models.SignerExtensions, now time.Time) models.ReminderLevel {
+	running := extensions.RunningExtension(now)
+	if running == nil || running.DecidedAt == nil {
+		return cfg.LevelFor(signer.ReminderCount, signer.DaysSinceAdded)
+	}
+	return cfg.LevelFor(0, int(now.Sub(*running.DecidedAt).Hours()/24))
+}
+
+// reminderConfig returns the tenant reminder settings, the defaults without a config store
+func (s *ReminderAsyncService) reminderConfig() models.ReminderConfig {
+	if s.configStore == nil {
+		return models.ReminderConfig{}
+	}
+	return s.configStore.GetConfig().Reminders
+}
+
+// deliveryTime returns when a reminder to a recipient is delivered: nil for now, else the start
+// of the next working hours of the recipient when working hours are enabled
+func (s *ReminderAsyncService) deliveryTime(ctx context.Context, email string, cfg models.ReminderConfig) *time.Time {
+	if !cfg.WorkingHours.Enabled {
+		return nil
+	}
+	timezone := ""
+	if s.timezones != nil {
+		timezone = s.timezones.ResolveTimezone(ctx, email)
+	}
+	now := time.Now()
+	next := cfg.WorkingHours.NextWindow(now, timezone)
+	if !next.After(now) {
+		return nil
+	}
+	return &next
+}
+
+// reminderToken creates the auth token of a reminder link, valid from the delivery of a
+// deferred reminder so that it does not expire in the queue
+func (s *ReminderAsyncService) reminderToken(ctx context.Context, email, docID string, sendAt *time.Time) (string, error) {
+	if deferred, ok := s.magicLinkService.(deferredReminderTokens); ok && sendAt != nil {
+		return deferred.CreateReminderAuthTokenFrom(ctx, email, docID, *sendAt)
+	}
+	return s.magicLinkService.CreateReminderAuthToken(ctx, email, docID)
+}
+
+// recipientLocale returns the locale of the emails sent to a recipient
+func (s *ReminderAsyncService) recipientLocale(ctx context.Context, email, locale string) string {
+	if s.locales == nil {
+		return locale
+	}
+	return s.locales.ResolveLocale(ctx, email, "")
+}
+
 // SendRemindersAsync dispatches email notifications to queue for async processing
 func (s *ReminderAsyncService) SendRemindersAsync(
 	ctx context.Context,
@@ -79,12 +231,27 @@ func (s *ReminderAsyncService) SendRemindersAsync(
 	docURL string,
 	locale string,
 ) (*models.ReminderSendResult, error) {
+	return s.SendRemindersWithLevel(ctx, docID, sentBy, specificEmails, docURL, locale, "")
+}
+
+// SendRemindersWithLevel queues reminders using the template of the given escalation level.
+// An empty level selects it per signer from the reminders already sent and the days pending.
+func (s *ReminderAsyncService) SendRemindersWithLevel(
+	ctx context.Context,
+	docID string,
+	sentBy string,
+	specificEmails []string,
+	docURL string,
+	locale string,
+	level models.ReminderLevel,
+) (*models.ReminderSendResult, error) {
 
 	logger.Logger.Info("Starting async reminder queueing process",
 		"doc_id", docID,
 		"sent_by", sentBy,
 		"specific_emails_count", len(specificEmails),
-		"locale", locale)
+		"locale", locale,
+		"level", level)
 
 	allSigners, err := s.expectedSignerRepo.ListWithStatusByDocID(ctx, docID)
 	if err != nil {
@@ -98,10 +265,11 @@ func (s *ReminderAsyncService) SendRemindersAsync(
 		"doc_id", docID,
 		"total_signers", len(allSigners))
 
-	// Filter pending signers
+	// Filter pending signers, skipping ordered signers whose turn has not come yet
+	models.ApplySigningTurns(allSigners)
 	var pendingSigners []*models.ExpectedSignerWithStatus
 	for _, signer := range allSigners {
-		if !signer.HasSigned {
+		if !signer.HasSigned && signer.Turn != models.SigningTurnWaiting {
 			if len(specificEmails) > 0 {
 				if containsEmail(specificEmails, signer.Email) {
 					pendingSigners = append(pendingSigners, signer)
@@ -131,14 +299,41 @@ func (s *ReminderAsyncService) SendRemindersAsync(
 		TotalAttempted: len(pendingSigners),
 	}
 
+	reminderConfig := s.reminderConfig()
+	if s.policies != nil {
+		reminderConfig = s.policies.ReminderConfigForDocument(ctx, docID, reminderConfig)
+	}
+	extensions := s.signerExtensions(ctx, docID)
+
 	// Queue emails asynchronously
 	for _, signer := range pendingSigners {
-		err := s.queueSingleReminder(ctx, docID, signer.Email, signer.Name, sentBy, docURL, locale)
+		signerExtensions := extensions[strings.ToLower(signer.Email)]
+		if signerExtensions != nil && signerExtensions.Pending != nil {
+			result.Skipped = append(result.Skipped, models.ReminderSkip{Email: signer.Email, Reason: models.ReminderSkipExtensionPending})
+			continue
+		}
+		if skip, err := s.checkMinInterval(ctx, docID, signer, reminderConfig); err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", signer.Email, err))
+			continue
+		} else if skip != nil {
+			result.Skipped = append(result.Skipped, *skip)
+			continue
+		}
+		signerLevel := level
+		if signerLevel == "" {
+			signerLevel = signerReminderLevel(reminderConfig, signer, signerExtensions, time.Now())
+		}
+		sendAt := s.deliveryTime(ctx, signer.Email, reminderConfig)
+		err := s.queueSingleReminder(ctx, docID, signer.Email, signer.Name, sentBy, docURL, locale, signerLevel, sendAt)
 		if err != nil {
 			result.Failed++
 			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", signer.Email, err))
 		} else {
 			result.SuccessfullySent++
+			if sendAt != nil {
+				result.Deferred++
+			}
 		}
 	}
 
@@ -146,12 +341,38 @@ func (s *ReminderAsyncService) SendRemindersAsync(
 		"doc_id", docID,
 		"total_attempted", result.TotalAttempted,
 		"successfully_queued", result.SuccessfullySent,
+		"deferred", result.Deferred,
+		"skipped", len(result.Skipped),
 		"failed", result.Failed)
 
 	return result, nil
 }
 
-// queueSingleReminder queues a reminder for a single signer
+// checkMinInterval returns a skip when the signer was reminded of the document within the
+// minimum interval of the settings. Failed reminders do not count.
+func (s *ReminderAsyncService) checkMinInterval(ctx context.Context, docID string, signer *models.ExpectedSignerWithStatus, cfg models.ReminderConfig) (*models.ReminderSkip, error) {
+	if cfg.MinIntervalHours <= 0 {
+		return nil, nil
+	}
+	last := signer.LastReminderSent
+	if history, ok := s.reminderRepo.(lockedReminderHistory); ok {
+		var err error
+		if last, err = history.LockLastReminder(ctx, docID, signer.Email); err != nil {
+			return nil, fmt.Errorf("failed to check last reminder: %w", err)
+		}
+	}
+	next, allowed := cfg.NextReminderAllowed(last, time.Now())
+	if allowed {
+		return nil, nil
+	}
+	logger.Logger.Info("Reminder skipped within the minimum interval",
+		"doc_id", docID,
+		"recipient_email", signer.Email,
+		"next_allowed_at", next)
+	return &models.ReminderSkip{Email: signer.Email, Reason: models.ReminderSkipMinInterval, NextAllowedAt: &next}, nil
+}
+
+// queueSingleReminder queues a reminder for a single signer, delivered at sendAt when set
 func (s *ReminderAsyncService) queueSingleReminder(
 	ctx context.Context,
 	docID string,
@@ -160,16 +381,20 @@ func (s *ReminderAsyncService) queueSingleReminder(
 	sentBy string,
 	docURL string,
 	locale string,
+	level models.ReminderLevel,
+	sendAt *time.Time,
 ) error {
+	locale = s.recipientLocale(ctx, recipientEmail, locale)
 
 	logger.Logger.Debug("Queueing reminder for signer",
 		"doc_id", docID,
 		"recipient_email", recipientEmail,
 		"recipient_name", recipientName,
-		"sent_by", sentBy)
+		"sent_by", sentBy,
+		"level", level)
 
 	// Générer un token d'authentification pour ce lecteur
-	token, err := s.magicLinkService.CreateReminderAuthToken(ctx, recipientEmail, docID)
+	token, err := s.reminderToken(ctx, recipientEmail, docID, sendAt)
 	if err != nil {
 		logger.Logger.Error("Failed to create reminder auth token",
 			"doc_id", docID,
@@ -196,9 +421,10 @@ func (s *ReminderAsyncService) queueSingleReminder(
 	}
 
 	// Get translated subject using i18n
+	template := level.Template()
 	subject := "Document Reading Confirmation Reminder" // Fallback
 	if s.i18n != nil {
-		subject = s.i18n.T(locale, "email.reminder.subject")
+		subject = s.i18n.T(locale, level.SubjectKey())
 	}
 
 	// Create email queue input
@@ -206,10 +432,11 @@ func (s *ReminderAsyncService) queueSingleReminder(
 	input := models.EmailQueueInput{
 		ToAddresses:   []string{recipientEmail},
 		Subject:       subject,
-		Template:      "signature_reminder",
+		Template:      template,
 		Locale:        locale,
 		Data:          data,
 		Priority:      models.EmailPriorityHigh,
+		ScheduledFor:  sendAt,
 		ReferenceType: &refType,
 		ReferenceID:   &docID,
 		CreatedBy:     &sentBy,
@@ -230,7 +457,7 @@ func (s *ReminderAsyncService) queueSingleReminder(
 			RecipientEmail: recipientEmail,
 			SentAt:         time.Now(),
 			SentBy:         sentBy,
-			TemplateUsed:   "signature_reminder",
+			TemplateUsed:   template,
 			Status:         "failed",
 		}
 		errMsg := fmt.Sprintf("Failed to queue: %v", err)
@@ -250,7 +477,8 @@ func (s *ReminderAsyncService) queueSingleReminder(
 	logger.Logger.Info("Reminder email queued successfully",
 		"doc_id", docID,
 		"recipient_email", recipientEmail,
-		"queue_id", item.ID)
+		"queue_id", item.ID,
+		"scheduled_for", sendAt)
 
 	// Log successful queueing
 	log := &models.ReminderLog{
@@ -258,7 +486,7 @@ func (s *ReminderAsyncService) queueSingleReminder(
 		RecipientEmail: recipientEmail,
 		SentAt:         time.Now(),
 		SentBy:         sentBy,
-		TemplateUsed:   "signature_reminder",
+		TemplateUsed:   template,
 		Status:         "queued", // New status for queued emails
 	}
 
@@ -273,6 +501,172 @@ func (s *ReminderAsyncService) queueSingleReminder(
 	return nil
 }
 
+// SendDigests queues one reminder per recipient listing all of their pending documents.
+// When emails is empty, every recipient with a pending document receives a digest.
+func (s *ReminderAsyncService) SendDigests(ctx context.Context, emails []string, sentBy, locale string) (*models.ReminderDigestResult, error) {
+	recipients := emails
+	if len(recipients) == 0 {
+		var err error
+		recipients, err = s.expectedSignerRepo.ListPendingRecipients(ctx, time.Time{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pending recipients: %w", err)
+		}
+	}
+	return s.sendDigests(ctx, recipients, sentBy, locale), nil
+}
+
+// SendScheduledDigests queues digests for recipients who have not been reminded for at least interval
+func (s *ReminderAsyncService) SendScheduledDigests(ctx context.Context, interval time.Duration, locale string) (*models.ReminderDigestResult, error) {
+	recipients, err := s.expectedSignerRepo.ListPendingRecipients(ctx, time.Now().Add(-interval))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending recipients: %w", err)
+	}
+	return s.sendDigests(ctx, recipients, reminderDigestSender, locale), nil
+}
+
+func (s *ReminderAsyncService) sendDigests(ctx context.Context, recipients []string, sentBy, locale string) *models.ReminderDigestResult {
+	result := &models.ReminderDigestResult{TotalRecipients: len(recipients)}
+	reminderConfig := s.reminderConfig()
+
+	for _, email := range recipients {
+		sendAt := s.deliveryTime(ctx, email, reminderConfig)
+		count, err := s.queueDigest(ctx, email, sentBy, locale, sendAt)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", email, err))
+			continue
+		}
+		if count > 0 {
+			result.DigestsQueued++
+			result.DocumentCount += count
+			if sendAt != nil {
+				result.Deferred++
+			}
+		}
+	}
+
+	logger.Logger.Info("Reminder digests queued",
+		"recipients", result.TotalRecipients,
+		"digests_queued", result.DigestsQueued,
+		"documents", result.DocumentCount,
+		"deferred", result.Deferred,
+		"failed", result.Failed)
+
+	return result
+}
+
+// queueDigest queues a single digest email, delivered at sendAt when set, and records one reminder
+// log entry per listed document. It returns the number of documents included; 0 means the
+// recipient had nothing pending.
+func (s *ReminderAsyncService) queueDigest(ctx context.Context, email, sentBy, locale string, sendAt *time.Time) (int, error) {
+	pending, err := s.expectedSignerRepo.ListPendingByEmail(ctx, email)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending documents: %w", err)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	recipientName := ""
+	documents := make([]map[string]interface{}, 0, len(pending))
+	included := make([]*models.PendingDocument, 0, len(pending))
+	waiting := 0
+	for _, doc := range pending {
+		// Documents whose deadline the recipient asked to extend wait for the decision
+		if ext := s.signerExtensions(ctx, doc.DocID)[strings.ToLower(email)]; ext != nil && ext.Pending != nil {
+			waiting++
+			continue
+		}
+		token, err := s.reminderToken(ctx, email, doc.DocID, sendAt)
+		if err != nil {
+			logger.Logger.Warn("Failed to create digest auth token",
+				"doc_id", doc.DocID,
+				"recipient_email", email,
+				"error", err.Error())
+			continue
+		}
+		if recipientName == "" {
+			recipientName = doc.RecipientName
+		}
+		documents = append(documents, map[string]interface{}{
+			"DocID":   doc.DocID,
+			"Title":   doc.Title,
+			"DocURL":  doc.URL,
+			"SignURL": fmt.Sprintf("%s/api/v1/auth/reminder-link/verify?token=%s", s.baseURL, token),
+		})
+		included = append(included, doc)
+	}
+	if len(included) == 0 {
+		if waiting == len(pending) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to create auth tokens")
+	}
+
+	locale = s.recipientLocale(ctx, email, locale)
+
+	subject := "Documents awaiting your reading confirmation" // Fallback
+	if s.i18n != nil {
+		subject = s.i18n.T(locale, "email.reminder_digest.subject")
+	}
+
+	refType := ReminderDigestTemplate
+	input := models.EmailQueueInput{
+		ToAddresses: []string{email},
+		Subject:     subject,
+		Template:    ReminderDigestTemplate,
+		Locale:      locale,
+		Data: map[string]interface{}{
+			"RecipientName": recipientName,
+			"Documents":     documents,
+			"Locale":        locale,
+		},
+		Priority:      models.EmailPriorityHigh,
+		ScheduledFor:  sendAt,
+		ReferenceType: &refType,
+		CreatedBy:     &sentBy,
+		MaxRetries:    5,
+	}
+
+	status := "queued"
+	var errMsg *string
+	_, queueErr := s.queueRepo.Enqueue(ctx, input)
+	if queueErr != nil {
+		status = "failed"
+		msg := fmt.Sprintf("Failed to queue: %v", queueErr)
+		errMsg = &msg
+	}
+
+	// One entry per document keeps per-document reminder history and throttling accurate
+	for _, doc := range included {
+		log := &models.ReminderLog{
+			DocID:          doc.DocID,
+			RecipientEmail: email,
+			SentAt:         time.Now(),
+			SentBy:         sentBy,
+			TemplateUsed:   ReminderDigestTemplate,
+			Status:         status,
+			ErrorMessage:   errMsg,
+		}
+		if err := s.reminderRepo.LogReminder(ctx, log); err != nil {
+			logger.Logger.Error("Failed to log digest reminder",
+				"doc_id", doc.DocID,
+				"recipient_email", email,
+				"error", err.Error())
+		}
+	}
+
+	if queueErr != nil {
+		return 0, fmt.Errorf("failed to queue email: %w", queueErr)
+	}
+
+	logger.Logger.Info("Reminder digest queued",
+		"recipient_email", email,
+		"documents", len(included))
+
+	return len(included), nil
+}
+
 // GetQueueStats returns current email queue statistics
 func (s *ReminderAsyncService) GetQueueStats(ctx context.Context) (*models.EmailQueueStats, error) {
 	return s.queueRepo.GetQueueStats(ctx)
@@ -0,0 +1,279 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAsyncSignerRepo struct {
+	signers []*models.ExpectedSignerWithStatus
+	pending map[string][]*models.PendingDocument
+}
+
+func (f *fakeAsyncSignerRepo) ListWithStatusByDocID(_ context.Context, _ string) ([]*models.ExpectedSignerWithStatus, error) {
+	return f.signers, nil
+}
+
+func (f *fakeAsyncSignerRepo) ListPendingByEmail(_ context.Context, email string) ([]*models.PendingDocument, error) {
+	return f.pending[email], nil
+}
+
+func (f *fakeAsyncSignerRepo) ListPendingRecipients(_ context.Context, _ time.Time) ([]string, error) {
+	var emails []string
+	for email := range f.pending {
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+type fakeAsyncReminderRepo struct {
+	logs []*models.ReminderLog
+}
+
+func (f *fakeAsyncReminderRepo) LogReminder(_ context.Context, log *models.ReminderLog) error {
+	f.logs = append(f.logs, log)
+	return nil
+}
+
+func (f *fakeAsyncReminderRepo) GetReminderHistory(_ context.Context, _ string) ([]*models.ReminderLog, error) {
+	return f.logs, nil
+}
+
+func (f *fakeAsyncReminderRepo) GetReminderStats(_ context.Context, _ string) (*models.ReminderStats, error) {
+	return &models.ReminderStats{}, nil
+}
+
+func (f *fakeAsyncReminderRepo) Count(_ context.Context) (int, error) {
+	return len(f.logs), nil
+}
+
+// fakeDeferredTokens records the start of validity of each reminder token
+type fakeDeferredTokens struct {
+	validFrom map[string]time.Time
+}
+
+func (f *fakeDeferredTokens) CreateReminderAuthToken(ctx context.Context, email, docID string) (string, error) {
+	return f.CreateReminderAuthTokenFrom(ctx, email, docID, time.Now())
+}
+
+func (f *fakeDeferredTokens) CreateReminderAuthTokenFrom(_ context.Context, email, _ string, from time.Time) (string, error) {
+	f.validFrom[email] = from
+	return "token", nil
+}
+
+type fakeReminderConfigStore struct {
+	reminders models.ReminderConfig
+}
+
+func (f *fakeReminderConfigStore) GetConfig() *models.MutableConfig {
+	return &models.MutableConfig{Reminders: f.reminders}
+}
+
+type fakeTimezoneResolver map[string]string
+
+func (f fakeTimezoneResolver) ResolveTimezone(_ context.Context, email string) string {
+	return f[email]
+}
+
+func pendingSigner(email string) *models.ExpectedSignerWithStatus {
+	return &models.ExpectedSignerWithStatus{ExpectedSigner: models.ExpectedSigner{DocID: "doc-1", Email: email}}
+}
+
+func TestReminderAsyncService_WorkingHours(t *testing.T) {
+	signers := &fakeAsyncSignerRepo{
+		signers: []*models.ExpectedSignerWithStatus{pendingSigner("day@example.com"), pendingSigner("night@example.com")},
+		pending: map[string][]*models.PendingDocument{"night@example.com": {{DocID: "doc-1", Title: "Policy"}}},
+	}
+	queue := &fakeAccessResendQueue{}
+	tokens := &fakeDeferredTokens{validFrom: map[string]time.Time{}}
+	service := NewReminderAsyncService(signers, &fakeAsyncReminderRepo{}, queue, tokens, nil, "https://sign.example.com")
+
+	// A single working day, three days from now: today is outside working hours in any timezone
+	now := time.Now().UTC()
+	hours := models.WorkingHours{Enabled: true, Timezone: "UTC", Start: "00:00", End: "24:00", Days: []int{int(now.AddDate(0, 0, 3).Weekday())}}
+	service.SetConfigStore(&fakeReminderConfigStore{reminders: models.ReminderConfig{WorkingHours: hours}})
+	nightZone := "Etc/GMT-12" // UTC+12
+	service.SetTimezoneResolver(fakeTimezoneResolver{"night@example.com": nightZone})
+	ctx := context.Background()
+
+	result, err := service.SendReminders(ctx, "doc-1", "admin@example.com", nil, "", "en")
+	if err != nil {
+		t.Fatalf("send err: %v", err)
+	}
+	if result.SuccessfullySent != 2 || result.Deferred != 2 {
+		t.Fatalf("expected 2 reminders deferred to the next working day, got %+v", result)
+	}
+	for _, input := range queue.inputs {
+		if input.ScheduledFor == nil || !input.ScheduledFor.After(now) {
+			t.Fatalf("expected a delivery in the future, got %+v", input.ScheduledFor)
+		}
+		recipient := input.ToAddresses[0]
+		if !tokens.validFrom[recipient].Equal(*input.ScheduledFor) {
+			t.Errorf("%s: expected the token valid from delivery, got %s", recipient, tokens.validFrom[recipient])
+		}
+		zone := "UTC"
+		if recipient == "night@example.com" {
+			zone = nightZone
+		}
+		loc, _ := time.LoadLocation(zone)
+		if local := input.ScheduledFor.In(loc); local.Hour() != 0 || local.Minute() != 0 {
+			t.Errorf("%s: expected a delivery at midnight of the working day in %s, got %s", recipient, zone, local)
+		}
+	}
+
+	digests, err := service.SendScheduledDigests(ctx, 24*time.Hour, "en")
+	if err != nil || digests.DigestsQueued != 1 || digests.Deferred != 1 {
+		t.Fatalf("expected a deferred digest, got %+v, %v", digests, err)
+	}
+
+	// Disabled working hours deliver immediately
+	queue.inputs = nil
+	service.SetConfigStore(&fakeReminderConfigStore{})
+	result, _ = service.SendReminders(ctx, "doc-1", "admin@example.com", []string{"day@example.com"}, "", "en")
+	if result.Deferred != 0 || queue.inputs[0].ScheduledFor != nil {
+		t.Errorf("expected an immediate delivery, got %+v", result)
+	}
+}
+
+func TestReminderAsyncService_TagPolicies(t *testing.T) {
+	signer := pendingSigner("alice@example.com")
+	signer.ReminderCount = 1
+	queue := &fakeAccessResendQueue{}
+	service := NewReminderAsyncService(&fakeAsyncSignerRepo{signers: []*models.ExpectedSignerWithStatus{signer}},
+		&fakeAsyncReminderRepo{}, queue, &fakeDeferredTokens{validFrom: map[string]time.Time{}}, nil, "https://sign.example.com")
+	ctx := context.Background()
+
+	if _, err := service.SendReminders(ctx, "doc-1", "admin@example.com", nil, "", "en"); err != nil {
+		t.Fatalf("send err: %v", err)
+	}
+
+	tags, repo := newTestTagService()
+	repo.tags["doc-1"] = []string{"security"}
+	repo.policies["security"] = &models.TagPolicy{Tag: "security", FirmAfterReminders: 1}
+	service.SetReminderPolicies(tags)
+	if _, err := service.SendReminders(ctx, "doc-1", "admin@example.com", nil, "", "en"); err != nil {
+		t.Fatalf("send err: %v", err)
+	}
+
+	if len(queue.inputs) != 2 {
+		t.Fatalf("expected 2 queued reminders, got %d", len(queue.inputs))
+	}
+	if got := queue.inputs[0].Template; got != models.ReminderLevelGentle.Template() {
+		t.Errorf("expected a gentle reminder with the tenant settings, got %s", got)
+	}
+	if got := queue.inputs[1].Template; got != models.ReminderLevelFirm.Template() {
+		t.Errorf("expected a firm reminder with the tag policy, got %s", got)
+	}
+}
+
+// fakeLockedReminderRepo returns the last reminder of each recipient from its own history
+type fakeLockedReminderRepo struct {
+	fakeAsyncReminderRepo
+	last   map[string]time.Time
+	locked []string
+}
+
+func (f *fakeLockedReminderRepo) LockLastReminder(_ context.Context, _, email string) (*time.Time, error) {
+	f.locked = append(f.locked, email)
+	if last, ok := f.last[email]; ok {
+		return &last, nil
+	}
+	return nil, nil
+}
+
+func TestReminderAsyncService_MinInterval(t *testing.T) {
+	recent := time.Now().Add(-3 * time.Hour)
+	old := time.Now().Add(-30 * time.Hour)
+	stale := pendingSigner("stale@example.com")
+	stale.LastReminderSent = &recent // Listed from the replica, superseded by the locked read
+	signers := &fakeAsyncSignerRepo{signers: []*models.ExpectedSignerWithStatus{
+		pendingSigner("recent@example.com"), pendingSigner("old@example.com"), stale, pendingSigner("new@example.com"),
+	}}
+	reminders := &fakeLockedReminderRepo{last: map[string]time.Time{"recent@example.com": recent, "old@example.com": old}}
+	queue := &fakeAccessResendQueue{}
+	service := NewReminderAsyncService(signers, reminders, queue, &fakeDeferredTokens{validFrom: map[string]time.Time{}}, nil, "https://sign.example.com")
+	service.SetConfigStore(&fakeReminderConfigStore{reminders: models.ReminderConfig{MinIntervalHours: 24}})
+
+	result, err := service.SendReminders(context.Background(), "doc-1", "admin@example.com", nil, "", "en")
+	if err != nil {
+		t.Fatalf("send err: %v", err)
+	}
+	if result.TotalAttempted != 4 || result.SuccessfullySent != 3 || len(result.Skipped) != 1 || len(queue.inputs) != 3 {
+		t.Fatalf("expected 3 reminders sent and 1 skipped, got %+v", result)
+	}
+	skip := result.Skipped[0]
+	if skip.Email != "recent@example.com" || skip.Reason != models.ReminderSkipMinInterval {
+		t.Errorf("unexpected skip %+v", skip)
+	}
+	if skip.NextAllowedAt == nil || !skip.NextAllowedAt.Equal(recent.Add(24*time.Hour)) {
+		t.Errorf("expected the next reminder allowed 24h after the last one, got %v", skip.NextAllowedAt)
+	}
+	if len(reminders.locked) != 4 {
+		t.Errorf("expected the last reminder of each recipient read under lock, got %v", reminders.locked)
+	}
+
+	// Without a limit, every pending signer is reminded without locking
+	reminders.locked = nil
+	service.SetConfigStore(&fakeReminderConfigStore{})
+	result, _ = service.SendReminders(context.Background(), "doc-1", "admin@example.com", nil, "", "en")
+	if result.SuccessfullySent != 4 || len(result.Skipped) != 0 || len(reminders.locked) != 0 {
+		t.Errorf("expected all reminders sent without a minimum interval, got %+v", result)
+	}
+}
+
+type fakeSignerExtensions map[string]*models.SignerExtensions
+
+func (f fakeSignerExtensions) SignerExtensions(_ context.Context, _ string) (map[string]*models.SignerExtensions, error) {
+	return f, nil
+}
+
+func TestReminderAsyncService_DeadlineExtensions(t *testing.T) {
+	approvedAt := time.Now().Add(-2 * 24 * time.Hour)
+	granted := time.Now().Add(10 * 24 * time.Hour)
+	passed := time.Now().Add(-time.Hour)
+
+	extended := pendingSigner("extended@example.com")
+	extended.ReminderCount, extended.DaysSinceAdded = 5, 40
+	expired := pendingSigner("expired@example.com")
+	expired.ReminderCount, expired.DaysSinceAdded = 5, 40
+	signers := &fakeAsyncSignerRepo{
+		signers: []*models.ExpectedSignerWithStatus{pendingSigner("waiting@example.com"), extended, expired},
+		pending: map[string][]*models.PendingDocument{"waiting@example.com": {{DocID: "doc-1", Title: "Policy"}}},
+	}
+	queue := &fakeAccessResendQueue{}
+	service := NewReminderAsyncService(signers, &fakeAsyncReminderRepo{}, queue, &fakeDeferredTokens{validFrom: map[string]time.Time{}}, nil, "https://sign.example.com")
+	service.SetDeadlineExtensions(fakeSignerExtensions{
+		"waiting@example.com":  {Pending: &models.DeadlineExtension{ID: 1, Status: models.ExtensionStatusPending}},
+		"extended@example.com": {Approved: &models.DeadlineExtension{ID: 2, Status: models.ExtensionStatusApproved, GrantedDeadline: &granted, DecidedAt: &approvedAt}},
+		"expired@example.com":  {Approved: &models.DeadlineExtension{ID: 3, Status: models.ExtensionStatusApproved, GrantedDeadline: &passed, DecidedAt: &approvedAt}},
+	})
+
+	result, err := service.SendReminders(context.Background(), "doc-1", "admin@example.com", nil, "", "en")
+	if err != nil {
+		t.Fatalf("send err: %v", err)
+	}
+	if result.SuccessfullySent != 2 || len(result.Skipped) != 1 || result.Skipped[0].Email != "waiting@example.com" ||
+		result.Skipped[0].Reason != models.ReminderSkipExtensionPending {
+		t.Fatalf("expected the signer awaiting a decision to be skipped, got %+v", result)
+	}
+	templates := map[string]string{}
+	for _, input := range queue.inputs {
+		templates[input.ToAddresses[0]] = input.Template
+	}
+	if got := templates["extended@example.com"]; got != models.ReminderLevelGentle.Template() {
+		t.Errorf("expected escalation to start over from the approval, got %s", got)
+	}
+	if got := templates["expired@example.com"]; got != models.ReminderLevelFinal.Template() {
+		t.Errorf("expected the usual escalation once the extension passed, got %s", got)
+	}
+
+	// Digests leave out the documents awaiting a decision
+	digest, err := service.SendDigests(context.Background(), []string{"waiting@example.com"}, "admin@example.com", "en")
+	if err != nil || digest.DigestsQueued != 0 || digest.Failed != 0 {
+		t.Errorf("expected no digest for a recipient awaiting a decision, got %+v (err %v)", digest, err)
+	}
+}
@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// retentionActor identifies automatic archiving in archive records and audit entries
+	retentionActor = "retention-policy"
+
+	// defaultArchiveAfterDays is reported when a tenant has not configured a policy yet
+	defaultArchiveAfterDays = 365
+	maxArchiveAfterDays     = 3650
+
+	// retentionBatchSize bounds the number of documents archived per run
+	retentionBatchSize = 50
+
+	archiveFormatVersion = 1
+	archiveContentType   = "application/gzip"
+)
+
+// Audit actions recorded by the retention service (see web.AuditAction* constants)
+const (
+	auditActionDocumentArchive  = "document.archive"
+	auditActionDocumentRestore  = "document.restore"
+	auditActionDocumentPurgePII = "document.purge_pii"
+)
+
+var (
+	// ErrInvalidRetentionPolicy is returned when a retention policy fails validation
+	ErrInvalidRetentionPolicy = errors.New("invalid retention policy")
+	// ErrArchiveStorageDisabled is returned when archiving is requested without object storage
+	ErrArchiveStorageDisabled = errors.New("object storage is not configured")
+	// ErrArchiveAlreadyRestored is returned when restoring an archive twice
+	ErrArchiveAlreadyRestored = errors.New("archive already restored")
+)
+
+// retentionRepository defines retention policy and archive storage operations
+type retentionRepository interface {
+	GetPolicy(ctx context.Context) (*models.RetentionPolicy, error)
+	UpsertPolicy(ctx context.Context, input models.RetentionPolicyInput, updatedBy string) (*models.RetentionPolicy, error)
+	ListArchivable(ctx context.Context, now time.Time, archiveAfterDays, limit int) ([]string, error)
+	PurgeDocumentPII(ctx context.Context, docID string) error
+	CreateArchive(ctx context.Context, input models.DocumentArchiveInput) (*models.DocumentArchive, error)
+	GetArchive(ctx context.Context, id int64) (*models.DocumentArchive, error)
+	ListArchives(ctx context.Context, limit, offset int) ([]*models.DocumentArchive, error)
+	CountArchives(ctx context.Context) (int, error)
+	MarkRestored(ctx context.Context, id int64, restoredBy string) error
+}
+
+// retentionDocumentRepository defines document operations needed to archive and restore
+type retentionDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	Delete(ctx context.Context, docID string) error
+	Restore(ctx context.Context, docID string) error
+}
+
+// retentionSignerRepository lists expected signers included in the export
+type retentionSignerRepository interface {
+	ListByDocID(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
+}
+
+// retentionSignatureRepository lists signatures included in the export
+type retentionSignatureRepository interface {
+	GetByDoc(ctx context.Context, docID string) ([]*models.Signature, error)
+}
+
+// archiveStorage stores compressed exports in object storage
+type archiveStorage interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Download(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// auditRecorder records auditable actions performed by services
+type auditRecorder interface {
+	Record(ctx context.Context, action, resource, resourceID, actor string, details map[string]any)
+}
+
+// RetentionService applies retention policies and manages document archives
+type RetentionService struct {
+	repo       retentionRepository
+	docRepo    retentionDocumentRepository
+	signerRepo retentionSignerRepository
+	sigRepo    retentionSignatureRepository
+	storage    archiveStorage
+	audit      auditRecorder
+	now        func() time.Time
+}
+
+// NewRetentionService creates a new retention service.
+// storage may be nil when object storage is disabled: archiving is then unavailable.
+func NewRetentionService(repo retentionRepository, docRepo retentionDocumentRepository, signerRepo retentionSignerRepository, sigRepo retentionSignatureRepository, storage archiveStorage, audit auditRecorder) *RetentionService {
+	return &RetentionService{
+		repo:       repo,
+		docRepo:    docRepo,
+		signerRepo: signerRepo,
+		sigRepo:    sigRepo,
+		storage:    storage,
+		audit:      audit,
+		now:        time.Now,
+	}
+}
+
+// GetPolicy returns the tenant retention policy, or the disabled default if none is stored
+func (s *RetentionService) GetPolicy(ctx context.Context) (*models.RetentionPolicy, error) {
+	policy, err := s.repo.GetPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = &models.RetentionPolicy{ArchiveAfterDays: defaultArchiveAfterDays}
+	}
+	return policy, nil
+}
+
+// UpdatePolicy validates and stores the tenant retention policy
+func (s *RetentionService) UpdatePolicy(ctx context.Context, input models.RetentionPolicyInput, updatedBy string) (*models.RetentionPolicy, error) {
+	if input.ArchiveAfterDays < 1 || input.ArchiveAfterDays > maxArchiveAfterDays {
+		return nil, fmt.Errorf("%w: archiveAfterDays must be between 1 and %d", ErrInvalidRetentionPolicy, maxArchiveAfterDays)
+	}
+	if input.Enabled && s.storage == nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRetentionPolicy, ErrArchiveStorageDisabled)
+	}
+
+	logger.Logger.Info("Updating retention policy",
+		"enabled", input.Enabled,
+		"archive_after_days", input.ArchiveAfterDays,
+		"purge_pii", input.PurgePII,
+		"updated_by", updatedBy)
+	return s.repo.UpsertPolicy(ctx, input, updatedBy)
+}
+
+// ListArchives returns paginated archives, most recent first
+func (s *RetentionService) ListArchives(ctx context.Context, limit, offset int) ([]*models.DocumentArchive, error) {
+	return s.repo.ListArchives(ctx, limit, offset)
+}
+
+// CountArchives returns the total number of archives
+func (s *RetentionService) CountArchives(ctx context.Context) (int, error) {
+	return s.repo.CountArchives(ctx)
+}
+
+// GetArchive returns a single archive
+func (s *RetentionService) GetArchive(ctx context.Context, id int64) (*models.DocumentArchive, error) {
+	return s.repo.GetArchive(ctx, id)
+}
+
+// OpenArchive returns the archive record and a reader on its compressed export.
+// The caller must close the reader.
+func (s *RetentionService) OpenArchive(ctx context.Context, id int64) (*models.DocumentArchive, io.ReadCloser, error) {
+	if s.storage == nil {
+		return nil, nil, ErrArchiveStorageDisabled
+	}
+	archive, err := s.repo.GetArchive(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, _, _, err := s.storage.Download(ctx, archive.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download archive: %w", err)
+	}
+	return archive, reader, nil
+}
+
+// ArchiveDocument exports a document with its signers and signatures to object storage,
+// soft-deletes it and optionally purges signer PII from the database
+func (s *RetentionService) ArchiveDocument(ctx context.Context, docID, archivedBy string, purgePII bool) (*models.DocumentArchive, error) {
+	if s.storage == nil {
+		return nil, ErrArchiveStorageDisabled
+	}
+
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+	signers, err := s.signerRepo.ListByDocID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expected signers: %w", err)
+	}
+	signatures, err := s.sigRepo.GetByDoc(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signatures: %w", err)
+	}
+
+	now := s.now().UTC()
+	payload, err := compressArchive(&models.ArchiveExport{
+		FormatVersion:   archiveFormatVersion,
+		ArchivedAt:      now,
+		Document:        doc,
+		ExpectedSigners: signers,
+		Signatures:      signatures,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("archives/%s/%s.json.gz", docID, now.Format("20060102T150405Z"))
+	if err := s.storage.Upload(ctx, key, bytes.NewReader(payload), int64(len(payload)), archiveContentType); err != nil {
+		return nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	archive, err := s.finalizeArchive(ctx, doc, key, int64(len(payload)), len(signatures), archivedBy, purgePII)
+	if err != nil {
+		if delErr := s.storage.Delete(ctx, key); delErr != nil {
+			logger.Logger.Error("Failed to cleanup archive after failure", "error", delErr.Error(), "key", key)
+		}
+		return nil, err
+	}
+
+	logger.Logger.Info("Document archived",
+		"doc_id", docID,
+		"archive_id", archive.ID,
+		"size_bytes", archive.SizeBytes,
+		"pii_purged", purgePII,
+		"archived_by", archivedBy)
+
+	s.record(ctx, auditActionDocumentArchive, docID, archivedBy, map[string]any{
+		"archive_id":      archive.ID,
+		"signature_count": archive.SignatureCount,
+	})
+	if purgePII {
+		s.record(ctx, auditActionDocumentPurgePII, docID, archivedBy, map[string]any{"archive_id": archive.ID})
+	}
+	return archive, nil
+}
+
+func (s *RetentionService) finalizeArchive(ctx context.Context, doc *models.Document, key string, size int64, signatureCount int, archivedBy string, purgePII bool) (*models.DocumentArchive, error) {
+	if err := s.docRepo.Delete(ctx, doc.DocID); err != nil {
+		return nil, fmt.Errorf("failed to soft-delete document: %w", err)
+	}
+	if purgePII {
+		if err := s.repo.PurgeDocumentPII(ctx, doc.DocID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.CreateArchive(ctx, models.DocumentArchiveInput{
+		DocID:          doc.DocID,
+		Title:          doc.Title,
+		StorageKey:     key,
+		SizeBytes:      size,
+		SignatureCount: signatureCount,
+		PIIPurged:      purgePII,
+		ArchivedBy:     archivedBy,
+	})
+}
+
+// RestoreArchive brings an archived document back. Purged PII is not restored:
+// the original signer data remains available in the archive export only.
+func (s *RetentionService) RestoreArchive(ctx context.Context, id int64, restoredBy string) (*models.DocumentArchive, error) {
+	archive, err := s.repo.GetArchive(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if archive.IsRestored() {
+		return nil, ErrArchiveAlreadyRestored
+	}
+
+	if err := s.docRepo.Restore(ctx, archive.DocID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.MarkRestored(ctx, id, restoredBy); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Document restored from archive", "doc_id", archive.DocID, "archive_id", id, "restored_by", restoredBy)
+	s.record(ctx, auditActionDocumentRestore, archive.DocID, restoredBy, map[string]any{"archive_id": id})
+
+	return s.repo.GetArchive(ctx, id)
+}
+
+// RunRetention archives documents completed for longer than the tenant policy allows, or the
+// retention policies of their tags. It returns the number of archived documents.
+func (s *RetentionService) RunRetention(ctx context.Context) (int, error) {
+	if s.storage == nil {
+		return 0, nil
+	}
+	policy, err := s.repo.GetPolicy(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if policy == nil || !policy.Enabled {
+		return 0, nil
+	}
+
+	docIDs, err := s.repo.ListArchivable(ctx, s.now(), policy.ArchiveAfterDays, retentionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	archived := 0
+	for _, docID := range docIDs {
+		if _, err := s.ArchiveDocument(ctx, docID, retentionActor, policy.PurgePII); err != nil {
+			logger.Logger.Error("Failed to archive document", "doc_id", docID, "error", err.Error())
+			continue
+		}
+		archived++
+	}
+	return archived, nil
+}
+
+func (s *RetentionService) record(ctx context.Context, action, docID, actor string, details map[string]any) {
+	if s.audit == nil {
+		return
+	}
+	s.audit.Record(ctx, action, "document", docID, actor, details)
+}
+
+func compressArchive(export *models.ArchiveExport) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(export); err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeRetentionRepo struct {
+	policy   *models.RetentionPolicy
+	docIDs   []string
+	cutoff   time.Time
+	purged   []string
+	archives map[int64]*models.DocumentArchive
+}
+
+func (f *fakeRetentionRepo) GetPolicy(_ context.Context) (*models.RetentionPolicy, error) {
+	return f.policy, nil
+}
+
+func (f *fakeRetentionRepo) UpsertPolicy(_ context.Context, input models.RetentionPolicyInput, updatedBy string) (*models.RetentionPolicy, error) {
+	f.policy = &models.RetentionPolicy{Enabled: input.Enabled, ArchiveAfterDays: input.ArchiveAfterDays, PurgePII: input.PurgePII, UpdatedBy: updatedBy}
+	return f.policy, nil
+}
+
+func (f *fakeRetentionRepo) ListArchivable(_ context.Context, now time.Time, archiveAfterDays, _ int) ([]string, error) {
+	f.cutoff = now.AddDate(0, 0, -archiveAfterDays)
+	return f.docIDs, nil
+}
+
+func (f *fakeRetentionRepo) PurgeDocumentPII(_ context.Context, docID string) error {
+	f.purged = append(f.purged, docID)
+	return nil
+}
+
+func (f *fakeRetentionRepo) CreateArchive(_ context.Context, input models.DocumentArchiveInput) (*models.DocumentArchive, error) {
+	a := &models.DocumentArchive{
+		ID: int64(len(f.archives) + 1), DocID: input.DocID, Title: input.Title, StorageKey: input.StorageKey,
+		SizeBytes: input.SizeBytes, SignatureCount: input.SignatureCount, PIIPurged: input.PIIPurged, ArchivedBy: input.ArchivedBy,
+	}
+	f.archives[a.ID] = a
+	return a, nil
+}
+
+func (f *fakeRetentionRepo) GetArchive(_ context.Context, id int64) (*models.DocumentArchive, error) {
+	a, ok := f.archives[id]
+	if !ok {
+		return nil, models.ErrArchiveNotFound
+	}
+	return a, nil
+}
+
+func (f *fakeRetentionRepo) ListArchives(_ context.Context, _, _ int) ([]*models.DocumentArchive, error) {
+	return nil, nil
+}
+
+func (f *fakeRetentionRepo) CountArchives(_ context.Context) (int, error) {
+	return len(f.archives), nil
+}
+
+func (f *fakeRetentionRepo) MarkRestored(_ context.Context, id int64, restoredBy string) error {
+	now := time.Now()
+	f.archives[id].RestoredAt = &now
+	f.archives[id].RestoredBy = restoredBy
+	return nil
+}
+
+type fakeRetentionDocRepo struct {
+	docs     map[string]*models.Document
+	deleted  []string
+	restored []string
+}
+
+func (f *fakeRetentionDocRepo) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+func (f *fakeRetentionDocRepo) Delete(_ context.Context, docID string) error {
+	f.deleted = append(f.deleted, docID)
+	return nil
+}
+
+func (f *fakeRetentionDocRepo) Restore(_ context.Context, docID string) error {
+	f.restored = append(f.restored, docID)
+	return nil
+}
+
+type fakeRetentionSignerRepo struct{}
+
+func (fakeRetentionSignerRepo) ListByDocID(_ context.Context, docID string) ([]*models.ExpectedSigner, error) {
+	return []*models.ExpectedSigner{{DocID: docID, Email: "alice@example.com"}}, nil
+}
+
+type fakeRetentionSignatureRepo struct{}
+
+func (fakeRetentionSignatureRepo) GetByDoc(_ context.Context, docID string) ([]*models.Signature, error) {
+	return []*models.Signature{{DocID: docID, UserEmail: "alice@example.com"}}, nil
+}
+
+type fakeArchiveStorage struct {
+	objects map[string][]byte
+	failed  bool
+}
+
+func (f *fakeArchiveStorage) Upload(_ context.Context, key string, reader io.Reader, _ int64, _ string) error {
+	if f.failed {
+		return errors.New("storage unavailable")
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	f.objects[key] = data
+	return nil
+}
+
+func (f *fakeArchiveStorage) Download(_ context.Context, key string) (io.ReadCloser, int64, string, error) {
+	data, ok := f.objects[key]
+	if !ok {
+		return nil, 0, "", errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), archiveContentType, nil
+}
+
+func (f *fakeArchiveStorage) Delete(_ context.Context, key string) error {
+	delete(f.objects, key)
+	return nil
+}
+
+func (f *fakeArchiveStorage) Exists(_ context.Context, key string) (bool, error) {
+	_, ok := f.objects[key]
+	return ok, nil
+}
+
+type fakeAuditRecorder struct{ actions []string }
+
+func (f *fakeAuditRecorder) Record(_ context.Context, action, _, _, _ string, _ map[string]any) {
+	f.actions = append(f.actions, action)
+}
+
+func newTestRetentionService(now time.Time) (*RetentionService, *fakeRetentionRepo, *fakeRetentionDocRepo, *fakeArchiveStorage, *fakeAuditRecorder) {
+	repo := &fakeRetentionRepo{archives: make(map[int64]*models.DocumentArchive)}
+	docs := &fakeRetentionDocRepo{docs: map[string]*models.Document{
+		"policy": {DocID: "policy", Title: "Security policy"},
+	}}
+	store := &fakeArchiveStorage{objects: make(map[string][]byte)}
+	audit := &fakeAuditRecorder{}
+	svc := NewRetentionService(repo, docs, fakeRetentionSignerRepo{}, fakeRetentionSignatureRepo{}, store, audit)
+	svc.now = func() time.Time { return now }
+	return svc, repo, docs, store, audit
+}
+
+func TestRetentionService_UpdatePolicy_Validation(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	svc, _, _, _, _ := newTestRetentionService(now)
+	ctx := context.Background()
+
+	for _, days := range []int{0, -1, maxArchiveAfterDays + 1} {
+		if _, err := svc.UpdatePolicy(ctx, models.RetentionPolicyInput{Enabled: true, ArchiveAfterDays: days}, "admin@example.com"); !errors.Is(err, ErrInvalidRetentionPolicy) {
+			t.Errorf("days=%d: expected ErrInvalidRetentionPolicy, got %v", days, err)
+		}
+	}
+
+	noStorage := NewRetentionService(&fakeRetentionRepo{}, &fakeRetentionDocRepo{}, fakeRetentionSignerRepo{}, fakeRetentionSignatureRepo{}, nil, nil)
+	if _, err := noStorage.UpdatePolicy(ctx, models.RetentionPolicyInput{Enabled: true, ArchiveAfterDays: 30}, "admin@example.com"); !errors.Is(err, ErrArchiveStorageDisabled) {
+		t.Errorf("expected ErrArchiveStorageDisabled, got %v", err)
+	}
+
+	policy, err := svc.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy error: %v", err)
+	}
+	if policy.Enabled || policy.ArchiveAfterDays != defaultArchiveAfterDays {
+		t.Errorf("expected disabled default policy, got %+v", policy)
+	}
+}
+
+func TestRetentionService_RunRetention(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	svc, repo, docs, store, audit := newTestRetentionService(now)
+	repo.policy = &models.RetentionPolicy{Enabled: true, ArchiveAfterDays: 30, PurgePII: true}
+	repo.docIDs = []string{"policy"}
+
+	archived, err := svc.RunRetention(context.Background())
+	if err != nil {
+		t.Fatalf("RunRetention error: %v", err)
+	}
+	if archived != 1 {
+		t.Fatalf("expected 1 archived document, got %d", archived)
+	}
+	if want := now.AddDate(0, 0, -30); !repo.cutoff.Equal(want) {
+		t.Errorf("expected cutoff %v, got %v", want, repo.cutoff)
+	}
+	if len(docs.deleted) != 1 || len(repo.purged) != 1 {
+		t.Errorf("expected document soft-deleted and purged, got deleted=%v purged=%v", docs.deleted, repo.purged)
+	}
+
+	archive := repo.archives[1]
+	if archive.ArchivedBy != retentionActor || !archive.PIIPurged || archive.SignatureCount != 1 {
+		t.Errorf("unexpected archive: %+v", archive)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(store.objects[archive.StorageKey]))
+	if err != nil {
+		t.Fatalf("archive is not gzip: %v", err)
+	}
+	var export models.ArchiveExport
+	if err := json.NewDecoder(zr).Decode(&export); err != nil {
+		t.Fatalf("decode export: %v", err)
+	}
+	if export.Document.DocID != "policy" || len(export.Signatures) != 1 || len(export.ExpectedSigners) != 1 {
+		t.Errorf("unexpected export: %+v", export)
+	}
+
+	if len(audit.actions) != 2 || audit.actions[0] != auditActionDocumentArchive || audit.actions[1] != auditActionDocumentPurgePII {
+		t.Errorf("unexpected audit actions: %v", audit.actions)
+	}
+}
+
+func TestRetentionService_RunRetention_DisabledPolicy(t *testing.T) {
+	svc, repo, docs, _, _ := newTestRetentionService(time.Now())
+	repo.policy = &models.RetentionPolicy{Enabled: false, ArchiveAfterDays: 30}
+	repo.docIDs = []string{"policy"}
+
+	archived, err := svc.RunRetention(context.Background())
+	if err != nil || archived != 0 || len(docs.deleted) != 0 {
+		t.Fatalf("disabled policy must not archive: archived=%d err=%v", archived, err)
+	}
+}
+
+func TestRetentionService_ArchiveDocument_UploadFailure(t *testing.T) {
+	svc, repo, docs, store, _ := newTestRetentionService(time.Now())
+	store.failed = true
+
+	if _, err := svc.ArchiveDocument(context.Background(), "policy", "admin@example.com", false); err == nil {
+		t.Fatal("expected upload error")
+	}
+	if len(docs.deleted) != 0 || len(repo.archives) != 0 {
+		t.Error("document must stay active when the upload fails")
+	}
+
+	if _, err := svc.ArchiveDocument(context.Background(), "missing", "admin@example.com", false); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestRetentionService_RestoreArchive(t *testing.T) {
+	svc, _, docs, _, audit := newTestRetentionService(time.Now())
+	ctx := context.Background()
+
+	archive, err := svc.ArchiveDocument(ctx, "policy", "admin@example.com", false)
+	if err != nil {
+		t.Fatalf("ArchiveDocument error: %v", err)
+	}
+
+	restored, err := svc.RestoreArchive(ctx, archive.ID, "admin@example.com")
+	if err != nil {
+		t.Fatalf("RestoreArchive error: %v", err)
+	}
+	if !restored.IsRestored() || len(docs.restored) != 1 || docs.restored[0] != "policy" {
+		t.Errorf("expected document restored, got %+v / %v", restored, docs.restored)
+	}
+	if audit.actions[len(audit.actions)-1] != auditActionDocumentRestore {
+		t.Errorf("expected restore audit entry, got %v", audit.actions)
+	}
+
+	if _, err := svc.RestoreArchive(ctx, archive.ID, "admin@example.com"); !errors.Is(err, ErrArchiveAlreadyRestored) {
+		t.Errorf("expected ErrArchiveAlreadyRestored, got %v", err)
+	}
+	if _, err := svc.RestoreArchive(ctx, 42, "admin@example.com"); !errors.Is(err, models.ErrArchiveNotFound) {
+		t.Errorf("expected ErrArchiveNotFound, got %v", err)
+	}
+}
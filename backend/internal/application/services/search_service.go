@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidSearchQuery is returned when a search query has no searchable term
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+const (
+	// searchMaxTerms bounds the size of the generated tsquery
+	searchMaxTerms = 8
+	// searchSnippetLength is the maximum number of characters of a highlighted excerpt
+	searchSnippetLength = 160
+	// searchSignersPerResult bounds the number of signer hits fetched per requested result
+	searchSignersPerResult = 5
+)
+
+// searchRepository defines full-text search operations
+type searchRepository interface {
+	SearchDocuments(ctx context.Context, tsquery, tag string, limit int) ([]*models.DocumentSearchHit, error)
+	SearchSigners(ctx context.Context, tsquery, tag string, limit int) ([]*models.SignerSearchHit, error)
+}
+
+// SearchService searches documents and signers for the admin UI
+type SearchService struct {
+	repo searchRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(repo searchRepository) *SearchService {
+	return &SearchService{repo: repo}
+}
+
+// Search returns the documents matching a query, grouped with their matching signers.
+// Every word of the query must match, as a prefix, in the document or in the signer.
+// A non-empty tag restricts the search to the documents carrying it.
+func (s *SearchService) Search(ctx context.Context, query, tag string, limit int) ([]*models.SearchResult, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: no searchable term", ErrInvalidSearchQuery)
+	}
+	tsquery := buildTSQuery(terms)
+	if tag != "" {
+		normalized, err := normalizeTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSearchQuery, err)
+		}
+		tag = normalized
+	}
+
+	docs, err := s.repo.SearchDocuments(ctx, tsquery, tag, limit)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := s.repo.SearchSigners(ctx, tsquery, tag, limit*searchSignersPerResult)
+	if err != nil {
+		return nil, err
+	}
+
+	byDoc := make(map[string]*models.SearchResult)
+	var results []*models.SearchResult
+	for _, doc := range docs {
+		result := &models.SearchResult{
+			DocID:   doc.DocID,
+			Title:   doc.Title,
+			Rank:    doc.Rank,
+			Matches: documentMatches(doc, terms),
+			Signers: []models.SearchSignerMatch{},
+		}
+		byDoc[doc.DocID] = result
+		results = append(results, result)
+	}
+
+	for _, signer := range signers {
+		result, ok := byDoc[signer.DocID]
+		if !ok {
+			result = &models.SearchResult{
+				DocID:   signer.DocID,
+				Title:   signer.DocTitle,
+				Rank:    signer.Rank,
+				Matches: []models.SearchMatch{},
+			}
+			byDoc[signer.DocID] = result
+			results = append(results, result)
+		}
+		if signer.Rank > result.Rank {
+			result.Rank = signer.Rank
+		}
+
+		label := signer.Email
+		if signer.Name != "" {
+			label = signer.Name + " <" + signer.Email + ">"
+		}
+		highlight, _ := highlightTerms(label, terms, 0)
+		result.Signers = append(result.Signers, models.SearchSignerMatch{
+			Email:     signer.Email,
+			Name:      signer.Name,
+			Signed:    signer.Signed,
+			Highlight: highlight,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Rank > results[j].Rank })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// documentMatches returns the highlighted fields of a document that contain a term
+func documentMatches(doc *models.DocumentSearchHit, terms []string) []models.SearchMatch {
+	fields := []struct{ name, value string }{
+		{"title", doc.Title},
+		{"description", doc.Description},
+		{"url", doc.URL},
+		{"filename", doc.OriginalFilename},
+	}
+
+	matches := []models.SearchMatch{}
+	for _, field := range fields {
+		if highlight, ok := highlightTerms(field.value, terms, searchSnippetLength); ok {
+			matches = append(matches, models.SearchMatch{Field: field.name, Highlight: highlight})
+		}
+	}
+	return matches
+}
+
+// searchTerms splits a query into lowercase words, dropping punctuation so that
+// the terms can be embedded in a tsquery without escaping
+func searchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == searchMaxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+// buildTSQuery returns a tsquery requiring every term as a prefix
+func buildTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// highlightTerms HTML-escapes text and wraps the words starting with a term in <mark>.
+// When maxLength > 0, long text is cut to an excerpt around the first match.
+// It reports whether any word matched.
+func highlightTerms(text string, terms []string, maxLength int) (string, bool) {
+	runes := []rune(text)
+
+	type span struct{ start, end int }
+	var marks []span
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+		word := strings.ToLower(string(runes[start:i]))
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				marks = append(marks, span{start, i})
+				break
+			}
+		}
+	}
+	if len(marks) == 0 {
+		return "", false
+	}
+
+	from, to := 0, len(runes)
+	if maxLength > 0 && len(runes) > maxLength {
+		from = max(0, marks[0].start-maxLength/4)
+		to = min(len(runes), from+maxLength)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, m := range marks {
+		if m.start < from {
+			continue
+		}
+		if m.end > to {
+			break
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:m.start])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[m.start:m.end])))
+		b.WriteString("</mark>")
+		pos = m.end
+	}
+	b.WriteString(html.EscapeString(string(runes[pos:to])))
+	if to < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
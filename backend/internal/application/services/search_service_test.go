@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSearchRepo struct {
+	docs    []*models.DocumentSearchHit
+	signers []*models.SignerSearchHit
+	tsquery string
+	tag     string
+}
+
+func (f *fakeSearchRepo) SearchDocuments(_ context.Context, tsquery, tag string, _ int) ([]*models.DocumentSearchHit, error) {
+	f.tsquery, f.tag = tsquery, tag
+	return f.docs, nil
+}
+
+func (f *fakeSearchRepo) SearchSigners(_ context.Context, _, _ string, _ int) ([]*models.SignerSearchHit, error) {
+	return f.signers, nil
+}
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"Security Policy", "security:* & policy:*"},
+		{"alice@example.com", "alice:* & example:* & com:*"},
+		{"politique sécurité sécurité", "politique:* & sécurité:*"},
+		{"a:* | !b", "a:* & b:*"},
+	}
+	for _, tt := range tests {
+		if got := buildTSQuery(searchTerms(tt.query)); got != tt.want {
+			t.Errorf("query %q: expected %q, got %q", tt.query, tt.want, got)
+		}
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	got, ok := highlightTerms("Security <b>policy</b> 2025", []string{"polic"}, 0)
+	if !ok || got != "Security &lt;b&gt;<mark>policy</mark>&lt;/b&gt; 2025" {
+		t.Errorf("unexpected highlight %q", got)
+	}
+
+	if _, ok := highlightTerms("Security policy", []string{"charter"}, 0); ok {
+		t.Error("expected no match")
+	}
+
+	long := strings.Repeat("lorem ipsum ", 40) + "charter " + strings.Repeat("dolor sit ", 40)
+	got, ok = highlightTerms(long, []string{"charter"}, 60)
+	if !ok || !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "<mark>charter</mark>") {
+		t.Errorf("unexpected excerpt %q", got)
+	}
+}
+
+func TestSearchService_Search_GroupsByDocument(t *testing.T) {
+	repo := &fakeSearchRepo{
+		docs: []*models.DocumentSearchHit{
+			{DocID: "doc1", Title: "Alice onboarding", Rank: 0.5},
+		},
+		signers: []*models.SignerSearchHit{
+			{DocID: "doc2", DocTitle: "Security policy", Email: "alice@example.com", Name: "Alice", Rank: 0.9},
+			{DocID: "doc1", DocTitle: "Alice onboarding", Email: "alice@example.com", Name: "Alice", Signed: true, Rank: 0.1},
+		},
+	}
+	svc := NewSearchService(repo)
+
+	results, err := svc.Search(context.Background(), "Alice", "", 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if repo.tsquery != "alice:*" {
+		t.Errorf("unexpected tsquery %q", repo.tsquery)
+	}
+	if len(results) != 2 || results[0].DocID != "doc2" || results[1].DocID != "doc1" {
+		t.Fatalf("expected doc2 then doc1, got %+v", results)
+	}
+	doc1 := results[1]
+	if len(doc1.Matches) != 1 || doc1.Matches[0].Field != "title" || doc1.Matches[0].Highlight != "<mark>Alice</mark> onboarding" {
+		t.Errorf("unexpected matches %+v", doc1.Matches)
+	}
+	if len(doc1.Signers) != 1 || !doc1.Signers[0].Signed ||
+		doc1.Signers[0].Highlight != "<mark>Alice</mark> &lt;<mark>alice</mark>@example.com&gt;" {
+		t.Errorf("unexpected signers %+v", doc1.Signers)
+	}
+
+	if _, err := svc.Search(context.Background(), "  ?! ", "", 10); !errors.Is(err, ErrInvalidSearchQuery) {
+		t.Errorf("expected ErrInvalidSearchQuery, got %v", err)
+	}
+
+	if _, err := svc.Search(context.Background(), "Alice", " Security ", 10); err != nil || repo.tag != "security" {
+		t.Errorf("expected the tag filter normalized, got %q, %v", repo.tag, err)
+	}
+}
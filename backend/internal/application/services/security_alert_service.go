@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// DefaultSecurityAlertWindow is the period over which failed attempts are counted
+	DefaultSecurityAlertWindow = 15 * time.Minute
+
+	securityAlertEmailTemplate = "security_alert"
+	securityAlertReferenceType = "security_event"
+	securityAlertActor         = "security-alerts"
+
+	// securityEventRetention is how long security events stay listed to admins
+	securityEventRetention = 90 * 24 * time.Hour
+	// securityAlertBatch bounds the alerts sent per run
+	securityAlertBatch = 100
+)
+
+// securityEventRepository defines failed attempt counters and security event storage
+type securityEventRepository interface {
+	RecordFailure(ctx context.Context, kind, userEmail, ip string) error
+	CountFailures(ctx context.Context, kind, scope, subject string, since time.Time) (int, error)
+	CreateEvent(ctx context.Context, event *models.SecurityEvent, since time.Time) (bool, error)
+	ListEvents(ctx context.Context, since time.Time, unacknowledgedOnly bool, limit int) ([]*models.SecurityEvent, error)
+	CountFailuresByKind(ctx context.Context, since time.Time) ([]models.AuthFailureCount, error)
+	Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*models.SecurityEvent, error)
+	ListPendingAlerts(ctx context.Context, limit int) ([]*models.SecurityEvent, error)
+	MarkAlerted(ctx context.Context, id int64, at time.Time) error
+	DeleteBefore(ctx context.Context, failuresBefore, eventsBefore time.Time) (int64, error)
+}
+
+// securityAlertEmailQueue queues the alert emails
+type securityAlertEmailQueue interface {
+	Enqueue(ctx context.Context, input models.EmailQueueInput) (*models.EmailQueueItem, error)
+}
+
+// securityAlertPublisher publishes the security.alert webhook event
+type securityAlertPublisher interface {
+	Publish(ctx context.Context, eventType string, payload map[string]interface{}) error
+}
+
+// SecurityAlertService counts failed OAuth callbacks, magic link verifications and CSRF checks
+// per user and per IP, and raises a security event when one of them reaches the threshold
+// within the window. Events are listed to admins and, when channels are configured, alerted
+// by email, Slack or webhook.
+type SecurityAlertService struct {
+	repo      securityEventRepository
+	threshold int
+	window    time.Duration
+
+	queue      securityAlertEmailQueue
+	recipients []string
+	i18n       translator
+	locale     string
+	baseURL    string
+
+	slack    slackPoster
+	slackURL string
+
+	publisher securityAlertPublisher
+
+	now func() time.Time
+}
+
+// NewSecurityAlertService creates a security alert service. A threshold of zero disables the
+// tracking of failed attempts.
+func NewSecurityAlertService(repo securityEventRepository, threshold int, window time.Duration) *SecurityAlertService {
+	if window <= 0 {
+		window = DefaultSecurityAlertWindow
+	}
+	return &SecurityAlertService{
+		repo:      repo,
+		threshold: threshold,
+		window:    window,
+		now:       time.Now,
+	}
+}
+
+// SetEmailAlerts sends the alerts to the given recipients
+func (s *SecurityAlertService) SetEmailAlerts(queue securityAlertEmailQueue, recipients []string, i18nService translator, locale, baseURL string) {
+	s.queue = queue
+	s.recipients = recipients
+	s.i18n = i18nService
+	s.locale = locale
+	s.baseURL = baseURL
+}
+
+// SetSlackAlerts posts the alerts to a Slack incoming webhook
+func (s *SecurityAlertService) SetSlackAlerts(poster slackPoster, webhookURL string) {
+	s.slack = poster
+	s.slackURL = webhookURL
+}
+
+// SetPublisher publishes the alerts as security.alert webhook events
+func (s *SecurityAlertService) SetPublisher(publisher securityAlertPublisher) {
+	s.publisher = publisher
+}
+
+// AlertsEnabled reports whether events are alerted through at least one channel
+func (s *SecurityAlertService) AlertsEnabled() bool {
+	if s.threshold <= 0 {
+		return false
+	}
+	return (s.queue != nil && len(s.recipients) > 0) || (s.slack != nil && s.slackURL != "") || s.publisher != nil
+}
+
+// RecordFailure records a failed attempt of userEmail, when known, from ip, and raises an event
+// for the user or the IP once it reaches the threshold. A single event is raised per window.
+// Errors are logged only: tracking never fails the request.
+func (s *SecurityAlertService) RecordFailure(ctx context.Context, kind, userEmail, ip string) {
+	if s.threshold <= 0 {
+		return
+	}
+	if err := s.repo.RecordFailure(ctx, kind, userEmail, ip); err != nil {
+		logger.Logger.Error("Failed to record auth failure", "kind", kind, "error", err.Error())
+		return
+	}
+
+	since := s.now().Add(-s.window)
+	checks := []struct {
+		scope   string
+		subject string
+	}{
+		{models.RateLimitScopeUser, userEmail},
+		{models.RateLimitScopeIP, ip},
+	}
+	for _, check := range checks {
+		if check.subject == "" {
+			continue
+		}
+		count, err := s.repo.CountFailures(ctx, kind, check.scope, check.subject, since)
+		if err != nil {
+			logger.Logger.Error("Failed to count auth failures", "kind", kind, "error", err.Error())
+			return
+		}
+		if count < s.threshold {
+			continue
+		}
+
+		event := &models.SecurityEvent{Kind: kind, Scope: check.scope, Subject: check.subject, Failures: count}
+		created, err := s.repo.CreateEvent(ctx, event, since)
+		if err != nil {
+			logger.Logger.Error("Failed to create security event", "kind", kind, "error", err.Error())
+			continue
+		}
+		if created {
+			logger.Logger.Warn("Suspicious authentication activity",
+				"kind", kind,
+				"scope", check.scope,
+				"subject", check.subject,
+				"failures", count,
+				"window", s.window)
+		}
+	}
+}
+
+// ListEvents returns the events of the last period, newest first
+func (s *SecurityAlertService) ListEvents(ctx context.Context, period time.Duration, unacknowledgedOnly bool, limit int) ([]*models.SecurityEvent, error) {
+	return s.repo.ListEvents(ctx, s.now().Add(-period), unacknowledgedOnly, limit)
+}
+
+// FailureCounts returns the failed attempts of the last period by kind
+func (s *SecurityAlertService) FailureCounts(ctx context.Context, period time.Duration) ([]models.AuthFailureCount, error) {
+	return s.repo.CountFailuresByKind(ctx, s.now().Add(-period))
+}
+
+// Acknowledge marks an event as reviewed
+func (s *SecurityAlertService) Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*models.SecurityEvent, error) {
+	return s.repo.Acknowledge(ctx, id, acknowledgedBy)
+}
+
+// CleanupEvents removes failed attempts older than the window and expired events
+func (s *SecurityAlertService) CleanupEvents(ctx context.Context) (int64, error) {
+	now := s.now()
+	return s.repo.DeleteBefore(ctx, now.Add(-s.window), now.Add(-securityEventRetention))
+}
+
+// DispatchAlerts sends the alerts of the events raised since the last run through every
+// configured channel. Each event is alerted once: channel failures are logged, not retried.
+func (s *SecurityAlertService) DispatchAlerts(ctx context.Context) error {
+	events, err := s.repo.ListPendingAlerts(ctx, securityAlertBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := s.alert(ctx, event); err != nil {
+			logger.Logger.Warn("Failed to send security alert", "event_id", event.ID, "error", err.Error())
+		}
+		if err := s.repo.MarkAlerted(ctx, event.ID, s.now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SecurityAlertService) alert(ctx context.Context, event *models.SecurityEvent) error {
+	subject := s.subject(event)
+	var errs []error
+
+	if s.queue != nil && len(s.recipients) > 0 {
+		if err := s.queueAlert(ctx, event, subject); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if s.publisher != nil {
+		err := s.publisher.Publish(ctx, "security.alert", map[string]interface{}{
+			"event_id":       event.ID,
+			"kind":           event.Kind,
+			"scope":          event.Scope,
+			"subject":        event.Subject,
+			"failures":       event.Failures,
+			"window_minutes": int(s.window.Minutes()),
+			"created_at":     event.CreatedAt,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to publish webhook: %w", err))
+		}
+	}
+
+	if s.slack != nil && s.slackURL != "" {
+		postCtx, cancel := context.WithTimeout(ctx, slackPostTimeout)
+		text := fmt.Sprintf("%s\n%s %s: %d failed attempts (%s) in %d min",
+			subject, event.Scope, event.Subject, event.Failures, event.Kind, int(s.window.Minutes()))
+		if err := s.slack.PostMessage(postCtx, s.slackURL, text); err != nil {
+			errs = append(errs, err)
+		}
+		cancel()
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *SecurityAlertService) queueAlert(ctx context.Context, event *models.SecurityEvent, subject string) error {
+	data := map[string]interface{}{
+		"Kind":          event.Kind,
+		"Scope":         event.Scope,
+		"Subject":       event.Subject,
+		"Failures":      event.Failures,
+		"WindowMinutes": int(s.window.Minutes()),
+		"CreatedAt":     event.CreatedAt.UTC().Format("2006-01-02 15:04 MST"),
+		"AdminURL":      s.baseURL + "/admin",
+	}
+
+	refType := securityAlertReferenceType
+	refID := fmt.Sprintf("%d", event.ID)
+	createdBy := securityAlertActor
+	_, err := s.queue.Enqueue(ctx, models.EmailQueueInput{
+		ToAddresses:   s.recipients,
+		Subject:       subject,
+		Template:      securityAlertEmailTemplate,
+		Locale:        s.locale,
+		Data:          data,
+		Priority:      models.EmailPriorityHigh,
+		ReferenceType: &refType,
+		ReferenceID:   &refID,
+		CreatedBy:     &createdBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue security alert email: %w", err)
+	}
+	return nil
+}
+
+func (s *SecurityAlertService) subject(event *models.SecurityEvent) string {
+	subject := "Suspicious authentication activity" // Fallback
+	if s.i18n != nil {
+		subject = s.i18n.T(s.locale, "email.security_alert.subject")
+	}
+	return subject + ": " + event.Subject
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAuthFailure struct {
+	kind      string
+	userEmail string
+	ip        string
+	at        time.Time
+}
+
+type fakeSecurityEventRepo struct {
+	failures []fakeAuthFailure
+	events   []*models.SecurityEvent
+}
+
+func (f *fakeSecurityEventRepo) RecordFailure(_ context.Context, kind, userEmail, ip string) error {
+	f.failures = append(f.failures, fakeAuthFailure{kind: kind, userEmail: userEmail, ip: ip, at: time.Now()})
+	return nil
+}
+
+func (f *fakeSecurityEventRepo) CountFailures(_ context.Context, kind, scope, subject string, since time.Time) (int, error) {
+	count := 0
+	for _, e := range f.failures {
+		if e.kind != kind || !e.at.After(since) {
+			continue
+		}
+		if (scope == models.RateLimitScopeUser && e.userEmail == subject) || (scope == models.RateLimitScopeIP && e.ip == subject) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeSecurityEventRepo) CreateEvent(_ context.Context, event *models.SecurityEvent, since time.Time) (bool, error) {
+	for _, e := range f.events {
+		if e.Kind == event.Kind && e.Scope == event.Scope && e.Subject == event.Subject && e.CreatedAt.After(since) {
+			return false, nil
+		}
+	}
+	event.ID = int64(len(f.events) + 1)
+	event.CreatedAt = time.Now()
+	f.events = append(f.events, event)
+	return true, nil
+}
+
+func (f *fakeSecurityEventRepo) ListEvents(_ context.Context, since time.Time, unacknowledgedOnly bool, limit int) ([]*models.SecurityEvent, error) {
+	var out []*models.SecurityEvent
+	for _, e := range f.events {
+		if e.CreatedAt.After(since) && (!unacknowledgedOnly || e.AcknowledgedAt == nil) && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSecurityEventRepo) CountFailuresByKind(_ context.Context, _ time.Time) ([]models.AuthFailureCount, error) {
+	return nil, nil
+}
+
+func (f *fakeSecurityEventRepo) Acknowledge(_ context.Context, id int64, acknowledgedBy string) (*models.SecurityEvent, error) {
+	for _, e := range f.events {
+		if e.ID == id {
+			now := time.Now()
+			e.AcknowledgedAt = &now
+			e.AcknowledgedBy = acknowledgedBy
+			return e, nil
+		}
+	}
+	return nil, models.ErrSecurityEventNotFound
+}
+
+func (f *fakeSecurityEventRepo) ListPendingAlerts(_ context.Context, limit int) ([]*models.SecurityEvent, error) {
+	var out []*models.SecurityEvent
+	for _, e := range f.events {
+		if e.AlertedAt == nil && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSecurityEventRepo) MarkAlerted(_ context.Context, id int64, at time.Time) error {
+	for _, e := range f.events {
+		if e.ID == id {
+			e.AlertedAt = &at
+		}
+	}
+	return nil
+}
+
+func (f *fakeSecurityEventRepo) DeleteBefore(_ context.Context, failuresBefore, eventsBefore time.Time) (int64, error) {
+	var failures []fakeAuthFailure
+	for _, e := range f.failures {
+		if !e.at.Before(failuresBefore) {
+			failures = append(failures, e)
+		}
+	}
+	var events []*models.SecurityEvent
+	for _, e := range f.events {
+		if !e.CreatedAt.Before(eventsBefore) {
+			events = append(events, e)
+		}
+	}
+	deleted := int64(len(f.failures) - len(failures) + len(f.events) - len(events))
+	f.failures, f.events = failures, events
+	return deleted, nil
+}
+
+func TestSecurityAlertService_RecordFailure_RaisesEventAtThreshold(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	svc := NewSecurityAlertService(repo, 3, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		svc.RecordFailure(ctx, models.AuthFailureMagicLink, "", "203.0.113.7")
+	}
+	if len(repo.events) != 0 {
+		t.Fatalf("expected no event below the threshold, got %d", len(repo.events))
+	}
+
+	svc.RecordFailure(ctx, models.AuthFailureMagicLink, "", "203.0.113.7")
+	if len(repo.events) != 1 {
+		t.Fatalf("expected an event at the threshold, got %d", len(repo.events))
+	}
+	event := repo.events[0]
+	if event.Scope != models.RateLimitScopeIP || event.Subject != "203.0.113.7" || event.Failures != 3 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	// Further failures within the window do not raise another event
+	svc.RecordFailure(ctx, models.AuthFailureMagicLink, "", "203.0.113.7")
+	if len(repo.events) != 1 {
+		t.Errorf("expected a single event per window, got %d", len(repo.events))
+	}
+
+	// Failures of another kind are counted separately
+	svc.RecordFailure(ctx, models.AuthFailureCSRF, "", "203.0.113.7")
+	if len(repo.events) != 1 {
+		t.Errorf("expected failures to be counted by kind, got %d events", len(repo.events))
+	}
+}
+
+func TestSecurityAlertService_RecordFailure_ByUser(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	svc := NewSecurityAlertService(repo, 2, time.Minute)
+	ctx := context.Background()
+
+	svc.RecordFailure(ctx, models.AuthFailureCSRF, "alice@example.com", "203.0.113.1")
+	svc.RecordFailure(ctx, models.AuthFailureCSRF, "alice@example.com", "203.0.113.2")
+
+	if len(repo.events) != 1 {
+		t.Fatalf("expected one event for the user, got %d", len(repo.events))
+	}
+	if repo.events[0].Scope != models.RateLimitScopeUser || repo.events[0].Subject != "alice@example.com" {
+		t.Errorf("unexpected event: %+v", repo.events[0])
+	}
+}
+
+func TestSecurityAlertService_Disabled(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	svc := NewSecurityAlertService(repo, 0, time.Minute)
+	svc.SetPublisher(&fakeCompletionPublisher{})
+
+	svc.RecordFailure(context.Background(), models.AuthFailureOAuthCallback, "", "203.0.113.7")
+
+	if len(repo.failures) != 0 {
+		t.Errorf("expected no failure recorded when disabled, got %d", len(repo.failures))
+	}
+	if svc.AlertsEnabled() {
+		t.Error("expected alerts to be disabled with a zero threshold")
+	}
+}
+
+func TestSecurityAlertService_DispatchAlerts(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	queue := &fakeCompletionQueue{}
+	publisher := &fakeCompletionPublisher{}
+	poster := &fakeSlackPoster{}
+
+	svc := NewSecurityAlertService(repo, 1, time.Minute)
+	if svc.AlertsEnabled() {
+		t.Fatal("expected alerts to be disabled without channels")
+	}
+	svc.SetEmailAlerts(queue, []string{"security@example.com"}, fakeTranslator{}, "en", "https://sign.example.com")
+	svc.SetSlackAlerts(poster, "https://hooks.slack.com/services/T/B/X")
+	svc.SetPublisher(publisher)
+	if !svc.AlertsEnabled() {
+		t.Fatal("expected alerts to be enabled")
+	}
+
+	ctx := context.Background()
+	svc.RecordFailure(ctx, models.AuthFailureOAuthCallback, "", "203.0.113.7")
+
+	if err := svc.DispatchAlerts(ctx); err != nil {
+		t.Fatalf("DispatchAlerts failed: %v", err)
+	}
+	if len(queue.inputs) != 1 {
+		t.Fatalf("expected one alert email, got %d", len(queue.inputs))
+	}
+	input := queue.inputs[0]
+	if input.Template != "security_alert" || input.ToAddresses[0] != "security@example.com" {
+		t.Errorf("unexpected email: %+v", input)
+	}
+	if input.Subject != "email.security_alert.subject: 203.0.113.7" {
+		t.Errorf("unexpected subject: %q", input.Subject)
+	}
+	if len(publisher.events) != 1 || publisher.events[0] != "security.alert" {
+		t.Errorf("expected a security.alert webhook, got %v", publisher.events)
+	}
+	if len(poster.texts) != 1 {
+		t.Errorf("expected one Slack message, got %d", len(poster.texts))
+	}
+	if repo.events[0].AlertedAt == nil {
+		t.Error("expected the event to be marked alerted")
+	}
+
+	// Alerts are sent once
+	if err := svc.DispatchAlerts(ctx); err != nil {
+		t.Fatalf("DispatchAlerts failed: %v", err)
+	}
+	if len(queue.inputs) != 1 || len(publisher.events) != 1 || len(poster.texts) != 1 {
+		t.Error("expected no alert to be sent twice")
+	}
+}
+
+func TestSecurityAlertService_DispatchAlerts_ChannelFailure(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	poster := &fakeSlackPoster{err: errors.New("slack down")}
+
+	svc := NewSecurityAlertService(repo, 1, time.Minute)
+	svc.SetSlackAlerts(poster, "https://hooks.slack.com/services/T/B/X")
+
+	ctx := context.Background()
+	svc.RecordFailure(ctx, models.AuthFailureMagicLink, "", "203.0.113.7")
+
+	if err := svc.DispatchAlerts(ctx); err != nil {
+		t.Fatalf("expected channel failures not to fail the run, got %v", err)
+	}
+	if repo.events[0].AlertedAt == nil {
+		t.Error("expected the event to be marked alerted despite the failure")
+	}
+}
+
+func TestSecurityAlertService_Acknowledge(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	svc := NewSecurityAlertService(repo, 1, time.Minute)
+	ctx := context.Background()
+
+	svc.RecordFailure(ctx, models.AuthFailureMagicLink, "", "203.0.113.7")
+
+	event, err := svc.Acknowledge(ctx, repo.events[0].ID, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Acknowledge failed: %v", err)
+	}
+	if event.AcknowledgedBy != "admin@example.com" {
+		t.Errorf("expected acknowledgedBy to be set, got %q", event.AcknowledgedBy)
+	}
+
+	pending, err := svc.ListEvents(ctx, time.Hour, true, 10)
+	if err != nil {
+		t.Fatalf("ListEvents failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected no unacknowledged event, got %d", len(pending))
+	}
+
+	if _, err := svc.Acknowledge(ctx, 42, "admin@example.com"); !errors.Is(err, models.ErrSecurityEventNotFound) {
+		t.Errorf("expected ErrSecurityEventNotFound, got %v", err)
+	}
+}
+
+func TestSecurityAlertService_CleanupEvents(t *testing.T) {
+	repo := &fakeSecurityEventRepo{}
+	svc := NewSecurityAlertService(repo, 1, time.Minute)
+	now := time.Now()
+
+	repo.failures = []fakeAuthFailure{
+		{kind: models.AuthFailureCSRF, ip: "203.0.113.7", at: now.Add(-2 * time.Minute)},
+		{kind: models.AuthFailureCSRF, ip: "203.0.113.7", at: now},
+	}
+	repo.events = []*models.SecurityEvent{
+		{ID: 1, CreatedAt: now.Add(-100 * 24 * time.Hour)},
+		{ID: 2, CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	deleted, err := svc.CleanupEvents(context.Background())
+	if err != nil {
+		t.Fatalf("CleanupEvents failed: %v", err)
+	}
+	if deleted != 2 || len(repo.failures) != 1 || len(repo.events) != 1 || repo.events[0].ID != 2 {
+		t.Errorf("expected stale failures and expired events removed, deleted=%d failures=%d events=%d", deleted, len(repo.failures), len(repo.events))
+	}
+}
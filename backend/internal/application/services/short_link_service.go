@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/certificate"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidQRFormat is returned when a QR code is requested in a format other than svg or png
+var ErrInvalidQRFormat = errors.New("QR code format must be svg or png")
+
+// QR code image formats
+const (
+	QRFormatSVG = "svg"
+	QRFormatPNG = "png"
+)
+
+const (
+	shortLinkCodeCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	shortLinkCodeLen     = 8
+	qrModuleSize         = 8 // Pixels per module, enough to print the code at a few centimeters
+)
+
+// shortLinkRepository stores the short links of documents
+type shortLinkRepository interface {
+	GetOrCreate(ctx context.Context, docID, locale, code, createdBy string) (*models.ShortLink, error)
+	ListByDoc(ctx context.Context, docID string) ([]*models.ShortLink, error)
+	RecordScan(ctx context.Context, code string) (*models.ShortLink, error)
+	Delete(ctx context.Context, docID, code string) error
+}
+
+// ShortLinkService manages the /s/{code} short links to signing pages and their QR codes,
+// printed on notices so that workers reach a document by scanning it
+type ShortLinkService struct {
+	repo    shortLinkRepository
+	docRepo completionDocumentRepository
+	baseURL string
+}
+
+func NewShortLinkService(repo shortLinkRepository, docRepo completionDocumentRepository, baseURL string) *ShortLinkService {
+	return &ShortLinkService{repo: repo, docRepo: docRepo, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// GetOrCreate returns the short link of a document for a locale, creating it on first use.
+// An empty locale leaves the language of the signing page to the browser.
+func (s *ShortLinkService) GetOrCreate(ctx context.Context, docID, locale, createdBy string) (*models.ShortLink, error) {
+	if locale != "" {
+		normalized, ok := models.NormalizeLocale(locale)
+		if !ok {
+			return nil, ErrInvalidLocale
+		}
+		locale = normalized
+	}
+
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	code, err := generateShortLinkCode()
+	if err != nil {
+		return nil, err
+	}
+	link, err := s.repo.GetOrCreate(ctx, docID, locale, code, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	link.URL = s.shortURL(link.Code)
+	return link, nil
+}
+
+// List returns the short links of a document with their scan counts
+func (s *ShortLinkService) List(ctx context.Context, docID string) ([]*models.ShortLink, error) {
+	links, err := s.repo.ListByDoc(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		link.URL = s.shortURL(link.Code)
+	}
+	return links, nil
+}
+
+// Delete removes a short link of a document
+func (s *ShortLinkService) Delete(ctx context.Context, docID, code string) error {
+	return s.repo.Delete(ctx, docID, code)
+}
+
+// Resolve counts a scan of a short link and returns the link with the signing page URL it leads to
+func (s *ShortLinkService) Resolve(ctx context.Context, code string) (*models.ShortLink, string, error) {
+	link, err := s.repo.RecordScan(ctx, code)
+	if err != nil {
+		return nil, "", err
+	}
+	link.URL = s.shortURL(link.Code)
+
+	target := s.baseURL + "/?doc=" + url.QueryEscape(link.DocID)
+	if link.Locale != "" {
+		target += "&lang=" + link.Locale
+	}
+	return link, target, nil
+}
+
+// QRCode returns a QR code image of the short link of a document for a locale, with its content type.
+// The link is created when the document has none for that locale.
+func (s *ShortLinkService) QRCode(ctx context.Context, docID, locale, format, createdBy string) ([]byte, string, error) {
+	if format == "" {
+		format = QRFormatSVG
+	}
+	if format != QRFormatSVG && format != QRFormatPNG {
+		return nil, "", ErrInvalidQRFormat
+	}
+
+	link, err := s.GetOrCreate(ctx, docID, locale, createdBy)
+	if err != nil {
+		return nil, "", err
+	}
+	qr, err := certificate.EncodeQR(link.URL)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode QR code: %w", err)
+	}
+
+	if format == QRFormatPNG {
+		data, err := qr.PNG(qrModuleSize)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, "image/png", nil
+	}
+	return qr.SVG(qrModuleSize), "image/svg+xml", nil
+}
+
+func (s *ShortLinkService) shortURL(code string) string {
+	return s.baseURL + "/s/" + code
+}
+
+// generateShortLinkCode returns a random code, short enough to be typed from a printed notice
+func generateShortLinkCode() (string, error) {
+	code := make([]byte, shortLinkCodeLen)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(shortLinkCodeCharset))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate short link code: %w", err)
+		}
+		code[i] = shortLinkCodeCharset[n.Int64()]
+	}
+	return string(code), nil
+}
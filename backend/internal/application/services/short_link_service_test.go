@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeShortLinkRepo struct {
+	links []*models.ShortLink
+}
+
+func (f *fakeShortLinkRepo) GetOrCreate(_ context.Context, docID, locale, code, createdBy string) (*models.ShortLink, error) {
+	for _, l := range f.links {
+		if l.DocID == docID && l.Locale == locale {
+			return l, nil
+		}
+	}
+	l := &models.ShortLink{ID: int64(len(f.links) + 1), Code: code, DocID: docID, Locale: locale, CreatedBy: createdBy}
+	f.links = append(f.links, l)
+	return l, nil
+}
+
+func (f *fakeShortLinkRepo) ListByDoc(_ context.Context, docID string) ([]*models.ShortLink, error) {
+	var links []*models.ShortLink
+	for _, l := range f.links {
+		if l.DocID == docID {
+			links = append(links, l)
+		}
+	}
+	return links, nil
+}
+
+func (f *fakeShortLinkRepo) RecordScan(_ context.Context, code string) (*models.ShortLink, error) {
+	for _, l := range f.links {
+		if l.Code == code {
+			l.ScanCount++
+			return l, nil
+		}
+	}
+	return nil, models.ErrShortLinkNotFound
+}
+
+func (f *fakeShortLinkRepo) Delete(_ context.Context, docID, code string) error {
+	return nil
+}
+
+func newTestShortLinkService() (*ShortLinkService, *fakeShortLinkRepo) {
+	repo := &fakeShortLinkRepo{}
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc 1": {DocID: "doc 1"}}}
+	return NewShortLinkService(repo, docs, "https://sign.example.com/"), repo
+}
+
+func TestShortLinkService_GetOrCreate(t *testing.T) {
+	service, _ := newTestShortLinkService()
+	ctx := context.Background()
+
+	link, err := service.GetOrCreate(ctx, "doc 1", "FR-fr", "admin@example.com")
+	if err != nil {
+		t.Fatalf("GetOrCreate err: %v", err)
+	}
+	if link.Locale != "fr" || len(link.Code) != shortLinkCodeLen || link.URL != "https://sign.example.com/s/"+link.Code {
+		t.Errorf("unexpected link %+v", link)
+	}
+	again, err := service.GetOrCreate(ctx, "doc 1", "fr", "admin@example.com")
+	if err != nil || again.Code != link.Code {
+		t.Errorf("expected the same link, got %+v, %v", again, err)
+	}
+
+	if _, err := service.GetOrCreate(ctx, "doc 1", "klingon", "admin@example.com"); !errors.Is(err, ErrInvalidLocale) {
+		t.Errorf("expected ErrInvalidLocale, got %v", err)
+	}
+	if _, err := service.GetOrCreate(ctx, "missing", "", "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestShortLinkService_Resolve(t *testing.T) {
+	service, repo := newTestShortLinkService()
+	ctx := context.Background()
+
+	plain, _ := service.GetOrCreate(ctx, "doc 1", "", "admin@example.com")
+	fr, _ := service.GetOrCreate(ctx, "doc 1", "fr", "admin@example.com")
+
+	_, target, err := service.Resolve(ctx, plain.Code)
+	if err != nil || target != "https://sign.example.com/?doc=doc+1" {
+		t.Errorf("unexpected target %q, %v", target, err)
+	}
+	link, target, err := service.Resolve(ctx, fr.Code)
+	if err != nil || target != "https://sign.example.com/?doc=doc+1&lang=fr" || link.Locale != "fr" {
+		t.Errorf("unexpected target %q, %v", target, err)
+	}
+	if repo.links[1].ScanCount != 1 {
+		t.Errorf("expected the scan to be counted, got %d", repo.links[1].ScanCount)
+	}
+
+	if _, _, err := service.Resolve(ctx, "unknown"); !errors.Is(err, models.ErrShortLinkNotFound) {
+		t.Errorf("expected ErrShortLinkNotFound, got %v", err)
+	}
+}
+
+func TestShortLinkService_QRCode(t *testing.T) {
+	service, repo := newTestShortLinkService()
+	ctx := context.Background()
+
+	svg, contentType, err := service.QRCode(ctx, "doc 1", "", "", "admin@example.com")
+	if err != nil || contentType != "image/svg+xml" || !bytes.HasPrefix(svg, []byte("<svg ")) {
+		t.Fatalf("unexpected SVG %q, %v", contentType, err)
+	}
+	png, contentType, err := service.QRCode(ctx, "doc 1", "", QRFormatPNG, "admin@example.com")
+	if err != nil || contentType != "image/png" || !bytes.HasPrefix(png, []byte("\x89PNG")) {
+		t.Fatalf("unexpected PNG %q, %v", contentType, err)
+	}
+	if len(repo.links) != 1 {
+		t.Errorf("expected both images to use the same link, got %d links", len(repo.links))
+	}
+
+	if _, _, err := service.QRCode(ctx, "doc 1", "", "gif", "admin@example.com"); !errors.Is(err, ErrInvalidQRFormat) {
+		t.Errorf("expected ErrInvalidQRFormat, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidSignRedirect is returned when a redirect after signing fails validation
+var ErrInvalidSignRedirect = errors.New("invalid sign redirect")
+
+// signRedirectRepository defines per-document sign redirect storage
+type signRedirectRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.SignRedirect, error)
+	Upsert(ctx context.Context, docID, url, updatedBy string) (*models.SignRedirect, error)
+	Delete(ctx context.Context, docID string) (bool, error)
+}
+
+// signRedirectDocumentRepository checks the document of a redirect exists
+type signRedirectDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// SignRedirectService manages the URLs signers are sent to after signing a document. Redirects
+// must point to the origin of Ackify or to one of the allowed origins.
+type SignRedirectService struct {
+	repo    signRedirectRepository
+	docRepo signRedirectDocumentRepository
+	allowed models.CORSPolicy
+}
+
+// NewSignRedirectService creates a new sign redirect service allowing baseURL and origins
+func NewSignRedirectService(repo signRedirectRepository, docRepo signRedirectDocumentRepository, baseURL string, origins []string) *SignRedirectService {
+	allowed := append([]string{}, origins...)
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		allowed = append(allowed, u.Scheme+"://"+u.Host)
+	}
+	return &SignRedirectService{repo: repo, docRepo: docRepo, allowed: models.CORSPolicy{AllowedOrigins: allowed}}
+}
+
+// GetRedirect returns the redirect of a document, or nil if none is configured
+func (s *SignRedirectService) GetRedirect(ctx context.Context, docID string) (*models.SignRedirect, error) {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByDocID(ctx, docID)
+}
+
+// SetRedirect validates and stores the redirect of a document
+func (s *SignRedirectService) SetRedirect(ctx context.Context, docID, rawURL, updatedBy string) (*models.SignRedirect, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if err := s.validate(rawURL); err != nil {
+		return nil, err
+	}
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.repo.Upsert(ctx, docID, rawURL, updatedBy)
+}
+
+// DeleteRedirect removes the redirect of a document
+func (s *SignRedirectService) DeleteRedirect(ctx context.Context, docID string) error {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return err
+	}
+	_, err := s.repo.Delete(ctx, docID)
+	return err
+}
+
+// ResolveRedirect returns the URL to send the signer of a document to, or "" if none. A redirect
+// whose origin was removed from the allow-list since it was set is ignored.
+func (s *SignRedirectService) ResolveRedirect(ctx context.Context, docID string) (string, error) {
+	redirect, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return "", err
+	}
+	if redirect == nil {
+		return "", nil
+	}
+	if err := s.validate(redirect.URL); err != nil {
+		logger.Logger.Warn("Ignoring sign redirect no longer allowed", "doc_id", docID, "url", redirect.URL)
+		return "", nil
+	}
+	return redirect.URL, nil
+}
+
+// validate checks the URL is an absolute http(s) URL of an allowed origin
+func (s *SignRedirectService) validate(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidSignRedirect)
+	}
+	if !s.allowed.AllowsOrigin(u.Scheme + "://" + u.Host) {
+		return fmt.Errorf("%w: origin %s is not allowed", ErrInvalidSignRedirect, u.Scheme+"://"+u.Host)
+	}
+	return nil
+}
+
+func (s *SignRedirectService) checkDocument(ctx context.Context, docID string) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return models.ErrDocumentNotFound
+	}
+	return nil
+}
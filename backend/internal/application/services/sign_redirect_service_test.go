@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSignRedirectRepo struct {
+	redirects map[string]*models.SignRedirect
+}
+
+func (f *fakeSignRedirectRepo) GetByDocID(_ context.Context, docID string) (*models.SignRedirect, error) {
+	return f.redirects[docID], nil
+}
+
+func (f *fakeSignRedirectRepo) Upsert(_ context.Context, docID, url, updatedBy string) (*models.SignRedirect, error) {
+	redirect := &models.SignRedirect{DocID: docID, URL: url, UpdatedBy: updatedBy}
+	f.redirects[docID] = redirect
+	return redirect, nil
+}
+
+func (f *fakeSignRedirectRepo) Delete(_ context.Context, docID string) (bool, error) {
+	_, ok := f.redirects[docID]
+	delete(f.redirects, docID)
+	return ok, nil
+}
+
+func newTestSignRedirectService() (*SignRedirectService, *fakeSignRedirectRepo) {
+	repo := &fakeSignRedirectRepo{redirects: map[string]*models.SignRedirect{}}
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc1": {DocID: "doc1"}}}
+	return NewSignRedirectService(repo, docs, "https://sign.example.com", []string{"https://intranet.example.com", "https://*.surveys.example.org"}), repo
+}
+
+func TestSignRedirectService_SetRedirect(t *testing.T) {
+	svc, _ := newTestSignRedirectService()
+	ctx := context.Background()
+
+	for _, url := range []string{
+		" https://intranet.example.com/policies?tab=hr ",
+		"https://eu.surveys.example.org/thanks",
+		"https://sign.example.com/my-signatures",
+	} {
+		if _, err := svc.SetRedirect(ctx, "doc1", url, "admin@example.com"); err != nil {
+			t.Errorf("SetRedirect(%q) failed: %v", url, err)
+		}
+	}
+
+	for _, url := range []string{
+		"https://evil.example.net/phishing",
+		"http://intranet.example.com/policies",
+		"https://user@intranet.example.com",
+		"javascript:alert(1)",
+		"/relative/path",
+	} {
+		if _, err := svc.SetRedirect(ctx, "doc1", url, "admin@example.com"); !errors.Is(err, ErrInvalidSignRedirect) {
+			t.Errorf("SetRedirect(%q): expected ErrInvalidSignRedirect, got %v", url, err)
+		}
+	}
+
+	if _, err := svc.SetRedirect(ctx, "missing", "https://intranet.example.com", "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestSignRedirectService_ResolveRedirect(t *testing.T) {
+	svc, repo := newTestSignRedirectService()
+	ctx := context.Background()
+
+	if url, err := svc.ResolveRedirect(ctx, "doc1"); err != nil || url != "" {
+		t.Fatalf("expected no redirect, got %q (err %v)", url, err)
+	}
+
+	_, _ = svc.SetRedirect(ctx, "doc1", "https://intranet.example.com/policies", "admin@example.com")
+	if url, err := svc.ResolveRedirect(ctx, "doc1"); err != nil || url != "https://intranet.example.com/policies" {
+		t.Fatalf("ResolveRedirect = %q, %v", url, err)
+	}
+
+	// A redirect set before its origin left the allow-list is ignored
+	_, _ = repo.Upsert(ctx, "doc1", "https://old-intranet.example.com", "admin@example.com")
+	if url, err := svc.ResolveRedirect(ctx, "doc1"); err != nil || url != "" {
+		t.Fatalf("expected the redirect to be ignored, got %q (err %v)", url, err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
@@ -19,6 +21,8 @@ type repository interface {
 	GetByDocAndUser(ctx context.Context, docID, userSub string) (*models.Signature, error)
 	GetByDoc(ctx context.Context, docID string) ([]*models.Signature, error)
 	GetByUserEmail(ctx context.Context, userEmail string) ([]*models.Signature, error)
+	ListByDocKeyset(ctx context.Context, docID string, cursor *models.PageCursor, limit int) ([]*models.Signature, error)
+	ListByUserEmailKeyset(ctx context.Context, userEmail string, cursor *models.PageCursor, limit int) ([]*models.Signature, error)
 	ExistsByDocAndUser(ctx context.Context, docID, userSub string) (bool, error)
 	CheckUserSignatureStatus(ctx context.Context, docID, userIdentifier string) (bool, error)
 	GetLastSignature(ctx context.Context, docID string) (*models.Signature, error)
@@ -27,6 +31,42 @@ type repository interface {
 	Count(ctx context.Context) (int, error)
 }
 
+// signingOrderChecker reports the signers that must sign before a given one
+type signingOrderChecker interface {
+	CountPendingBefore(ctx context.Context, docID, email string) (int, error)
+}
+
+// quizGrader scores the quiz answers submitted with a signature
+type quizGrader interface {
+	Grade(ctx context.Context, docID string, answers models.QuizAnswers) (*int, error)
+}
+
+// signatureRateLimiter caps signature creations per user and per client IP
+type signatureRateLimiter interface {
+	Allow(ctx context.Context, action, userEmail, ip string) error
+}
+
+// consentResolver returns the consent text displayed to the signers of a document
+type consentResolver interface {
+	Resolve(ctx context.Context, docID, locale string) (*models.ConsentText, error)
+}
+
+// privacyConfig provides the tenant settings applied to the recorded signature data
+type privacyConfig interface {
+	GetConfig() *models.MutableConfig
+}
+
+// signatureValidator evaluates the custom validation hook of a document before signing
+type signatureValidator interface {
+	Validate(ctx context.Context, docID string, user *models.User) error
+}
+
+// signatureApprovals holds the signatures of unexpected users for admin approval
+type signatureApprovals interface {
+	Hold(ctx context.Context, signature *models.Signature) (bool, error)
+	HeldSignatureIDs(ctx context.Context, docID string) (map[int64]bool, error)
+}
+
 type cryptoSigner interface {
 	CreateSignature(ctx context.Context, docID string, user *models.User, timestamp time.Time, nonce string, docChecksum string) (string, string, error)
 }
@@ -37,6 +77,13 @@ type SignatureService struct {
 	docRepo        documentRepository
 	signer         cryptoSigner
 	checksumConfig *config.ChecksumConfig
+	signingOrder   signingOrderChecker
+	quiz           quizGrader
+	rateLimiter    signatureRateLimiter
+	consent        consentResolver
+	privacy        privacyConfig
+	validator      signatureValidator
+	approvals      signatureApprovals
 }
 
 // NewSignatureService initializes the signature service with repository and cryptographic signer dependencies
@@ -53,6 +100,53 @@ func (s *SignatureService) SetChecksumConfig(cfg *config.ChecksumConfig) {
 	s.checksumConfig = cfg
 }
 
+// SetSigningOrder enforces the signing sequence of ordered expected signers
+func (s *SignatureService) SetSigningOrder(checker signingOrderChecker) {
+	s.signingOrder = checker
+}
+
+// SetQuizGrader requires signers to pass the document quiz before signing
+func (s *SignatureService) SetQuizGrader(grader quizGrader) {
+	s.quiz = grader
+}
+
+// SetRateLimiter limits signature creations per user and per client IP
+func (s *SignatureService) SetRateLimiter(limiter signatureRateLimiter) {
+	s.rateLimiter = limiter
+}
+
+// SetConsentResolver records the consent text displayed to the signer with each signature
+func (s *SignatureService) SetConsentResolver(resolver consentResolver) {
+	s.consent = resolver
+}
+
+// SetPrivacyConfig applies the referer policy of the tenant settings to new signatures
+func (s *SignatureService) SetPrivacyConfig(cfg privacyConfig) {
+	s.privacy = cfg
+}
+
+// SetValidationHook lets the per-document validation hooks reject signatures
+func (s *SignatureService) SetValidationHook(validator signatureValidator) {
+	s.validator = validator
+}
+
+// SetApprovals holds the signatures of unexpected users, when the tenant policy requires it, and
+// leaves held signatures out of the document signatures until approved
+func (s *SignatureService) SetApprovals(approvals signatureApprovals) {
+	s.approvals = approvals
+}
+
+// refererPolicy returns the configured referer policy, keep when unset
+func (s *SignatureService) refererPolicy() models.RefererPolicy {
+	if s.privacy == nil {
+		return models.RefererPolicyKeep
+	}
+	if cfg := s.privacy.GetConfig(); cfg != nil && cfg.Security.RefererPolicy != "" {
+		return cfg.Security.RefererPolicy
+	}
+	return models.RefererPolicyKeep
+}
+
 // CreateSignature validates user authorization, generates cryptographic proof, and chains to previous signature
 func (s *SignatureService) CreateSignature(ctx context.Context, request *models.SignatureRequest) error {
 	logger.Logger.Info("Signature creation attempt",
@@ -77,6 +171,16 @@ func (s *SignatureService) CreateSignature(ctx context.Context, request *models.
 		return models.ErrInvalidDocument
 	}
 
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.Allow(ctx, models.RateLimitActionSignature, request.User.NormalizedEmail(), request.IPAddress); err != nil {
+			logger.Logger.Warn("Signature creation failed: rate limited",
+				"doc_id", request.DocID,
+				"user_email", request.User.NormalizedEmail(),
+				"ip", request.IPAddress)
+			return err
+		}
+	}
+
 	exists, err := s.repo.ExistsByDocAndUser(ctx, request.DocID, request.User.Sub)
 	if err != nil {
 		logger.Logger.Error("Signature creation failed: database check error",
@@ -93,7 +197,74 @@ func (s *SignatureService) CreateSignature(ctx context.Context, request *models.
 		return models.ErrSignatureAlreadyExists
 	}
 
-	nonce, err := crypto.GenerateNonce()
+	if s.signingOrder != nil {
+		pending, err := s.signingOrder.CountPendingBefore(ctx, request.DocID, request.User.NormalizedEmail())
+		if err != nil {
+			return fmt.Errorf("failed to check signing order: %w", err)
+		}
+		if pending > 0 {
+			logger.Logger.Warn("Signature creation failed: not the signer's turn",
+				"doc_id", request.DocID,
+				"user_email", request.User.NormalizedEmail(),
+				"pending_before", pending)
+			return models.ErrNotSignerTurn
+		}
+	}
+
+	var quizScore *int
+	if s.quiz != nil {
+		quizScore, err = s.quiz.Grade(ctx, request.DocID, request.QuizAnswers)
+		if errors.Is(err, models.ErrQuizAnswersRequired) || errors.Is(err, models.ErrQuizFailed) {
+			logger.Logger.Warn("Signature creation failed: quiz not passed",
+				"doc_id", request.DocID,
+				"user_email", request.User.NormalizedEmail(),
+				"error", err.Error())
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("failed to grade quiz: %w", err)
+		}
+	}
+
+	locale, ok := models.NormalizeLocale(request.Locale)
+	if !ok {
+		locale = ""
+	}
+
+	var consent *models.ConsentText
+	if s.consent != nil {
+		consent, err = s.consent.Resolve(ctx, request.DocID, locale)
+		if err != nil {
+			return fmt.Errorf("failed to resolve consent text: %w", err)
+		}
+		// The page displayed an older consent text than the one in force
+		if consent != nil && request.ConsentVersion != nil && *request.ConsentVersion != consent.Version {
+			logger.Logger.Warn("Signature creation failed: consent outdated",
+				"doc_id", request.DocID,
+				"user_email", request.User.NormalizedEmail(),
+				"displayed_version", *request.ConsentVersion,
+				"current_version", consent.Version)
+			return models.ErrConsentOutdated
+		}
+	}
+
+	if s.validator != nil {
+		if err := s.validator.Validate(ctx, request.DocID, request.User); err != nil {
+			if errors.Is(err, models.ErrSignatureRejected) || errors.Is(err, models.ErrSignatureHookFailed) {
+				logger.Logger.Warn("Signature creation failed: rejected by validation hook",
+					"doc_id", request.DocID,
+					"user_email", request.User.NormalizedEmail(),
+					"error", err.Error())
+				return err
+			}
+			return fmt.Errorf("failed to validate signature: %w", err)
+		}
+	}
+
+	nonce := request.Nonce
+	if nonce == "" {
+		nonce, err = crypto.GenerateNonce()
+	}
 	if err != nil {
 		logger.Logger.Error("Signature creation failed: nonce generation error",
 			"doc_id", request.DocID,
@@ -171,8 +342,22 @@ func (s *SignatureService) CreateSignature(ctx context.Context, request *models.
 		PayloadHash: payloadHash,
 		Signature:   signatureB64,
 		Nonce:       nonce,
-		Referer:     request.Referer,
+		Referer:     s.refererPolicy().Apply(request.Referer),
 		PrevHash:    prevHashB64,
+		AuthMethod:  request.AuthMethod,
+		Locale:      locale,
+	}
+	if signature.AuthMethod == "" {
+		signature.AuthMethod = models.AuthMethodSession
+	}
+	if quizScore != nil {
+		signature.QuizScore = quizScore
+		signature.QuizAnswers = request.QuizAnswers
+	}
+	if consent != nil {
+		signature.ConsentVersion = &consent.Version
+		signature.ConsentLocale = consent.Locale
+		signature.ConsentText = consent.Text
 	}
 
 	if err := s.repo.Create(ctx, signature); err != nil {
@@ -183,6 +368,19 @@ func (s *SignatureService) CreateSignature(ctx context.Context, request *models.
 		return fmt.Errorf("failed to save signature: %w", err)
 	}
 
+	if s.approvals != nil {
+		held, err := s.approvals.Hold(ctx, signature)
+		if err != nil {
+			return fmt.Errorf("failed to hold signature for approval: %w", err)
+		}
+		if held {
+			logger.Logger.Info("Signature held for approval",
+				"signature_id", signature.ID,
+				"doc_id", request.DocID,
+				"user_email", request.User.NormalizedEmail())
+		}
+	}
+
 	logger.Logger.Info("Signature created successfully",
 		"signature_id", signature.ID,
 		"doc_id", request.DocID,
@@ -192,7 +390,8 @@ func (s *SignatureService) CreateSignature(ctx context.Context, request *models.
 	return nil
 }
 
-// GetSignatureStatus checks if a user has already signed a document and returns signature timestamp if exists
+// GetSignatureStatus checks if a user has already signed a document and returns signature timestamp if exists.
+// For unsigned ordered signers it also reports how many signers must sign first.
 func (s *SignatureService) GetSignatureStatus(ctx context.Context, docID string, user *models.User) (*models.SignatureStatus, error) {
 	if user == nil || !user.IsValid() {
 		return nil, models.ErrInvalidUser
@@ -201,12 +400,20 @@ func (s *SignatureService) GetSignatureStatus(ctx context.Context, docID string,
 	signature, err := s.repo.GetByDocAndUser(ctx, docID, user.Sub)
 	if err != nil {
 		if errors.Is(err, models.ErrSignatureNotFound) {
-			return &models.SignatureStatus{
+			status := &models.SignatureStatus{
 				DocID:     docID,
 				UserEmail: user.Email,
 				IsSigned:  false,
 				SignedAt:  nil,
-			}, nil
+			}
+			if s.signingOrder != nil {
+				pending, err := s.signingOrder.CountPendingBefore(ctx, docID, user.NormalizedEmail())
+				if err != nil {
+					return nil, fmt.Errorf("failed to check signing order: %w", err)
+				}
+				status.PendingBefore = pending
+			}
+			return status, nil
 		}
 		return nil, fmt.Errorf("failed to get signature: %w", err)
 	}
@@ -232,6 +439,17 @@ func (s *SignatureService) GetDocumentSignatures(ctx context.Context, docID stri
 		return nil, fmt.Errorf("failed to get document signatures: %w", err)
 	}
 
+	// Signatures held for approval count once approved
+	if s.approvals != nil {
+		held, err := s.approvals.HeldSignatureIDs(ctx, docID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get held signatures: %w", err)
+		}
+		if len(held) > 0 {
+			signatures = slices.DeleteFunc(signatures, func(sig *models.Signature) bool { return held[sig.ID] })
+		}
+	}
+
 	logger.Logger.Debug("Document signatures retrieved",
 		"doc_id", docID,
 		"count", len(signatures))
@@ -253,6 +471,33 @@ func (s *SignatureService) GetUserSignatures(ctx context.Context, user *models.U
 	return signatures, nil
 }
 
+// ListDocumentSignaturesPage returns a page of the signatures of a document, newest first
+func (s *SignatureService) ListDocumentSignaturesPage(ctx context.Context, docID string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error) {
+	signatures, err := s.repo.ListByDocKeyset(ctx, docID, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document signatures: %w", err)
+	}
+	return models.NewKeysetPage(signatures, cursor, limit, signatureCursor), nil
+}
+
+// ListUserSignaturesPage returns a page of the signatures of a user, newest first
+func (s *SignatureService) ListUserSignaturesPage(ctx context.Context, user *models.User, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error) {
+	if user == nil || !user.IsValid() {
+		return nil, models.ErrInvalidUser
+	}
+
+	signatures, err := s.repo.ListByUserEmailKeyset(ctx, user.Email, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user signatures: %w", err)
+	}
+	return models.NewKeysetPage(signatures, cursor, limit, signatureCursor), nil
+}
+
+// signatureCursor returns the position of a signature in listings
+func signatureCursor(signature *models.Signature) models.PageCursor {
+	return models.PageCursor{At: signature.CreatedAt, Key: strconv.FormatInt(signature.ID, 10)}
+}
+
 // GetSignatureByDocAndUser retrieves a specific signature record for verification or display purposes
 func (s *SignatureService) GetSignatureByDocAndUser(ctx context.Context, docID string, user *models.User) (*models.Signature, error) {
 	if user == nil || !user.IsValid() {
@@ -429,6 +674,17 @@ func (s *SignatureService) verifyDocumentIntegrity(ctx context.Context, doc *mod
 
 	// Compare checksums
 	if result.ChecksumHex != doc.Checksum {
+		// A re-serialized document (new metadata, recompression) keeps the same extracted text
+		if doc.ContentHash != "" && result.ContentHashHex == doc.ContentHash {
+			logger.Logger.Info("Document bytes changed but extracted content is identical",
+				"doc_id", doc.DocID,
+				"url", doc.URL,
+				"stored_checksum", doc.Checksum,
+				"current_checksum", result.ChecksumHex,
+				"content_hash", doc.ContentHash)
+			return nil
+		}
+
 		logger.Logger.Error("Document integrity check FAILED - checksums do not match",
 			"doc_id", doc.DocID,
 			"url", doc.URL,
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// Audit actions recorded by the signature approval service (see web.AuditAction* constants)
+const (
+	auditActionSignatureApprove = "signature.approve"
+	auditActionSignatureReject  = "signature.reject"
+)
+
+// ErrInvalidApprovalStatus is returned when listing held signatures by an unknown status
+var ErrInvalidApprovalStatus = errors.New("approval status must be pending, approved or rejected")
+
+// signatureApprovalRepository stores the held signatures and the decisions on them
+type signatureApprovalRepository interface {
+	HoldIfUnexpected(ctx context.Context, signatureID int64) (*models.SignatureApproval, error)
+	List(ctx context.Context, docID string, status models.ApprovalStatus) ([]*models.SignatureApproval, error)
+	ListHeldSignatureIDs(ctx context.Context, docID string) (map[int64]bool, error)
+	MarkReviewed(ctx context.Context, id int64, status models.ApprovalStatus, reviewedBy string) (*models.SignatureApproval, error)
+}
+
+// approvalPolicyConfig reads the approval policy from the tenant general settings
+type approvalPolicyConfig interface {
+	GetConfig() *models.MutableConfig
+}
+
+// approvalNotifier asks the document owner to review a held signature
+type approvalNotifier interface {
+	OnApprovalRequired(ctx context.Context, approval *models.SignatureApproval) error
+}
+
+// SignatureApprovalService holds the signatures of users missing from the expected signers of a
+// document, when the tenant policy requires it, until an admin approves or rejects them
+type SignatureApprovalService struct {
+	repo     signatureApprovalRepository
+	config   approvalPolicyConfig
+	notifier approvalNotifier
+	audit    auditRecorder
+}
+
+// NewSignatureApprovalService creates a new signature approval service. notifier may be nil.
+func NewSignatureApprovalService(repo signatureApprovalRepository, config approvalPolicyConfig, notifier approvalNotifier, audit auditRecorder) *SignatureApprovalService {
+	return &SignatureApprovalService{repo: repo, config: config, notifier: notifier, audit: audit}
+}
+
+// Hold holds a new signature for approval when the policy is enabled and the signer is not an
+// expected signer of a document that has some. It returns whether the signature was held.
+func (s *SignatureApprovalService) Hold(ctx context.Context, signature *models.Signature) (bool, error) {
+	cfg := s.config.GetConfig()
+	if cfg == nil || !cfg.General.ApproveUnexpectedSignatures {
+		return false, nil
+	}
+
+	approval, err := s.repo.HoldIfUnexpected(ctx, signature.ID)
+	if err != nil || approval == nil {
+		return false, err
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.OnApprovalRequired(ctx, approval); err != nil {
+			logger.Logger.Warn("Failed to request signature approval", "doc_id", approval.DocID, "error", err.Error())
+		}
+	}
+	return true, nil
+}
+
+// HeldSignatureIDs returns the signatures of a document that do not count: pending or rejected.
+// Signatures held before the policy was disabled still wait for a decision.
+func (s *SignatureApprovalService) HeldSignatureIDs(ctx context.Context, docID string) (map[int64]bool, error) {
+	return s.repo.ListHeldSignatureIDs(ctx, docID)
+}
+
+// List returns the held signatures of a document, or of every document when docID is empty,
+// with a status or all of them when status is empty
+func (s *SignatureApprovalService) List(ctx context.Context, docID, status string) ([]*models.SignatureApproval, error) {
+	switch models.ApprovalStatus(status) {
+	case "", models.ApprovalStatusPending, models.ApprovalStatusApproved, models.ApprovalStatusRejected:
+	default:
+		return nil, ErrInvalidApprovalStatus
+	}
+	return s.repo.List(ctx, docID, models.ApprovalStatus(status))
+}
+
+// Approve counts a pending signature in the document signatures
+func (s *SignatureApprovalService) Approve(ctx context.Context, id int64, reviewedBy string) (*models.SignatureApproval, error) {
+	return s.review(ctx, id, models.ApprovalStatusApproved, auditActionSignatureApprove, reviewedBy)
+}
+
+// Reject keeps a pending signature out of the document signatures. The signature itself is kept
+// as a signed record.
+func (s *SignatureApprovalService) Reject(ctx context.Context, id int64, reviewedBy string) (*models.SignatureApproval, error) {
+	return s.review(ctx, id, models.ApprovalStatusRejected, auditActionSignatureReject, reviewedBy)
+}
+
+func (s *SignatureApprovalService) review(ctx context.Context, id int64, status models.ApprovalStatus, action, reviewedBy string) (*models.SignatureApproval, error) {
+	approval, err := s.repo.MarkReviewed(ctx, id, status, reviewedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Signature reviewed",
+		"signature_id", approval.SignatureID,
+		"doc_id", approval.DocID,
+		"status", status,
+		"reviewed_by", reviewedBy)
+	if s.audit != nil {
+		s.audit.Record(ctx, action, "signature", strconv.FormatInt(approval.SignatureID, 10), reviewedBy, map[string]any{
+			"doc_id":     approval.DocID,
+			"user_email": approval.UserEmail,
+		})
+	}
+	return approval, nil
+}
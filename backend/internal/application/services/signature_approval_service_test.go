@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeApprovalRepo struct {
+	expected  map[int64]bool
+	approvals map[int64]*models.SignatureApproval
+}
+
+func (f *fakeApprovalRepo) HoldIfUnexpected(_ context.Context, signatureID int64) (*models.SignatureApproval, error) {
+	if f.expected[signatureID] {
+		return nil, nil
+	}
+	a := &models.SignatureApproval{ID: int64(len(f.approvals) + 1), SignatureID: signatureID, DocID: "doc1", UserEmail: "eve@example.com", Status: models.ApprovalStatusPending}
+	f.approvals[a.ID] = a
+	return a, nil
+}
+
+func (f *fakeApprovalRepo) List(_ context.Context, _ string, status models.ApprovalStatus) ([]*models.SignatureApproval, error) {
+	var out []*models.SignatureApproval
+	for _, a := range f.approvals {
+		if status == "" || a.Status == status {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeApprovalRepo) ListHeldSignatureIDs(context.Context, string) (map[int64]bool, error) {
+	held := map[int64]bool{}
+	for _, a := range f.approvals {
+		if a.Status != models.ApprovalStatusApproved {
+			held[a.SignatureID] = true
+		}
+	}
+	return held, nil
+}
+
+func (f *fakeApprovalRepo) MarkReviewed(_ context.Context, id int64, status models.ApprovalStatus, reviewedBy string) (*models.SignatureApproval, error) {
+	a, ok := f.approvals[id]
+	if !ok {
+		return nil, models.ErrApprovalNotFound
+	}
+	if a.Status != models.ApprovalStatusPending {
+		return nil, models.ErrApprovalReviewed
+	}
+	a.Status, a.ReviewedBy = status, &reviewedBy
+	return a, nil
+}
+
+type fakeApprovalPolicy struct{ enabled bool }
+
+func (f *fakeApprovalPolicy) GetConfig() *models.MutableConfig {
+	return &models.MutableConfig{General: models.GeneralConfig{ApproveUnexpectedSignatures: f.enabled}}
+}
+
+type fakeApprovalNotifier struct{ requested []int64 }
+
+func (f *fakeApprovalNotifier) OnApprovalRequired(_ context.Context, approval *models.SignatureApproval) error {
+	f.requested = append(f.requested, approval.ID)
+	return nil
+}
+
+func newTestSignatureApprovals(enabled bool) (*SignatureApprovalService, *fakeApprovalRepo, *fakeApprovalNotifier, *fakeAuditRecorder) {
+	repo := &fakeApprovalRepo{expected: map[int64]bool{1: true}, approvals: map[int64]*models.SignatureApproval{}}
+	notifier := &fakeApprovalNotifier{}
+	audit := &fakeAuditRecorder{}
+	return NewSignatureApprovalService(repo, &fakeApprovalPolicy{enabled: enabled}, notifier, audit), repo, notifier, audit
+}
+
+func TestSignatureApprovalService_Hold(t *testing.T) {
+	ctx := context.Background()
+
+	svc, repo, notifier, _ := newTestSignatureApprovals(false)
+	if held, err := svc.Hold(ctx, &models.Signature{ID: 2}); err != nil || held || len(repo.approvals) != 0 {
+		t.Fatalf("expected no hold with the policy disabled, got %v (err %v)", held, err)
+	}
+
+	svc, repo, notifier, _ = newTestSignatureApprovals(true)
+	if held, err := svc.Hold(ctx, &models.Signature{ID: 1}); err != nil || held {
+		t.Fatalf("expected an expected signer not to be held, got %v (err %v)", held, err)
+	}
+	held, err := svc.Hold(ctx, &models.Signature{ID: 2})
+	if err != nil || !held {
+		t.Fatalf("expected an unexpected signer to be held, got %v (err %v)", held, err)
+	}
+	if !slices.Equal(notifier.requested, []int64{1}) {
+		t.Fatalf("expected the owner to be asked once, got %v", notifier.requested)
+	}
+
+	ids, err := svc.HeldSignatureIDs(ctx, "doc1")
+	if err != nil || !ids[2] || ids[1] {
+		t.Fatalf("unexpected held signatures %v (err %v)", ids, err)
+	}
+	if len(repo.approvals) != 1 {
+		t.Fatalf("expected one approval, got %d", len(repo.approvals))
+	}
+}
+
+func TestSignatureApprovalService_Review(t *testing.T) {
+	svc, _, _, audit := newTestSignatureApprovals(true)
+	ctx := context.Background()
+
+	for _, id := range []int64{2, 3} {
+		if _, err := svc.Hold(ctx, &models.Signature{ID: id}); err != nil {
+			t.Fatalf("hold err: %v", err)
+		}
+	}
+
+	approval, err := svc.Approve(ctx, 1, "admin@example.com")
+	if err != nil || approval.Status != models.ApprovalStatusApproved {
+		t.Fatalf("unexpected approval %+v (err %v)", approval, err)
+	}
+	if _, err := svc.Reject(ctx, 1, "admin@example.com"); !errors.Is(err, models.ErrApprovalReviewed) {
+		t.Fatalf("expected ErrApprovalReviewed, got %v", err)
+	}
+	if _, err := svc.Reject(ctx, 2, "admin@example.com"); err != nil {
+		t.Fatalf("reject err: %v", err)
+	}
+	if _, err := svc.Approve(ctx, 9, "admin@example.com"); !errors.Is(err, models.ErrApprovalNotFound) {
+		t.Fatalf("expected ErrApprovalNotFound, got %v", err)
+	}
+	if !slices.Equal(audit.actions, []string{auditActionSignatureApprove, auditActionSignatureReject}) {
+		t.Fatalf("unexpected audit actions: %v", audit.actions)
+	}
+
+	ids, _ := svc.HeldSignatureIDs(ctx, "doc1")
+	if ids[2] || !ids[3] {
+		t.Fatalf("expected only the rejected signature to stay held, got %v", ids)
+	}
+	pending, err := svc.List(ctx, "", "rejected")
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("unexpected rejected list %v (err %v)", pending, err)
+	}
+	if _, err := svc.List(ctx, "", "unknown"); !errors.Is(err, ErrInvalidApprovalStatus) {
+		t.Fatalf("expected ErrInvalidApprovalStatus, got %v", err)
+	}
+}
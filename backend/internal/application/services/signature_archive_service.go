@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// SignatureArchiveJobType is the job building the signature archive of all documents
+	SignatureArchiveJobType = "signatures.archive"
+	// SignatureArchiveCleanupJobType is the job deleting a signature archive once expired
+	SignatureArchiveCleanupJobType = "signatures.archive.cleanup"
+
+	// exportKindSignatureArchive is the manifest kind of the signature archives of all documents
+	exportKindSignatureArchive = "signature_archive"
+	// archiveDocumentPageSize is the number of documents listed at once while building an archive
+	archiveDocumentPageSize = 500
+)
+
+// archiveIDPattern matches the random IDs of signature archives
+var archiveIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// SignatureArchiveJobPayload is the payload of a signatures.archive job
+type SignatureArchiveJobPayload struct {
+	RequestedBy string `json:"requestedBy"`
+}
+
+// SignatureArchiveCleanupPayload is the payload of a signatures.archive.cleanup job
+type SignatureArchiveCleanupPayload struct {
+	ArchiveID string `json:"archiveId"`
+}
+
+// archiveDocumentLister lists the documents of the tenant
+type archiveDocumentLister interface {
+	List(ctx context.Context, limit, offset int) ([]*models.Document, error)
+}
+
+// archiveBundleSigner signs the manifest of the archive
+type archiveBundleSigner interface {
+	SignBundle(ctx context.Context, kind, subject string, files []crypto.BundleFile) (*crypto.ExportBundle, error)
+}
+
+// signatureArchiveStorage keeps the archives until they expire
+type signatureArchiveStorage interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Download(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	Delete(ctx context.Context, key string) error
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// archiveLinkSigner issues and checks the expiring download links of the archives
+type archiveLinkSigner interface {
+	SignedArchiveURL(archiveID string, ttl time.Duration) (string, time.Time)
+	VerifyArchive(archiveID, expires, signature string) error
+}
+
+// archiveJobQueue queues the archive builds and cleanups
+type archiveJobQueue interface {
+	Enqueue(ctx context.Context, input models.JobInput) (*models.Job, error)
+}
+
+// SignatureArchiveService builds, in a background job, a signed export bundle holding a CSV of the
+// signatures of every document, kept in object storage behind an expiring download link
+type SignatureArchiveService struct {
+	docs    archiveDocumentLister
+	sigs    exportSignatureRepository
+	signer  archiveBundleSigner
+	storage signatureArchiveStorage
+	links   archiveLinkSigner
+	jobs    archiveJobQueue
+	ttl     time.Duration
+}
+
+// NewSignatureArchiveService creates a signature archive service. Archives and their links expire
+// after ttl.
+func NewSignatureArchiveService(docs archiveDocumentLister, sigs exportSignatureRepository, signer archiveBundleSigner, storage signatureArchiveStorage, links archiveLinkSigner, jobs archiveJobQueue, ttl time.Duration) *SignatureArchiveService {
+	return &SignatureArchiveService{
+		docs:    docs,
+		sigs:    sigs,
+		signer:  signer,
+		storage: storage,
+		links:   links,
+		jobs:    jobs,
+		ttl:     ttl,
+	}
+}
+
+// Request queues the build of an archive. While one is queued or running, it is returned instead.
+func (s *SignatureArchiveService) Request(ctx context.Context, requestedBy string) (*models.Job, error) {
+	return s.jobs.Enqueue(ctx, models.JobInput{
+		Type:        SignatureArchiveJobType,
+		Payload:     SignatureArchiveJobPayload{RequestedBy: requestedBy},
+		MaxAttempts: 2,
+		DedupKey:    "all",
+		CreatedBy:   requestedBy,
+	})
+}
+
+// RunArchiveJob builds the archive: documents.csv lists the documents and documents/<docId>.csv
+// holds the signatures of each. The archive is stored and its deletion queued for its expiry.
+func (s *SignatureArchiveService) RunArchiveJob(ctx context.Context, payload SignatureArchiveJobPayload, progress func(done, total int)) (*models.SignatureArchive, error) {
+	documents, err := s.listDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	progress(0, len(documents))
+
+	var index bytes.Buffer
+	indexCSV := csv.NewWriter(&index)
+	_ = indexCSV.Write([]string{"doc_id", "title", "url", "checksum", "created_by", "created_at", "signatures", "file"})
+
+	files := make([]crypto.BundleFile, 0, len(documents)+1)
+	signatureCount := 0
+	for i, doc := range documents {
+		signatures, err := s.sigs.GetByDoc(ctx, doc.DocID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get signatures of %s: %w", doc.DocID, err)
+		}
+		data, err := encodeSignaturesCSV(signatures)
+		if err != nil {
+			return nil, err
+		}
+		name := "documents/" + doc.DocID + ".csv"
+		files = append(files, crypto.BundleFile{Name: name, Data: data})
+		signatureCount += len(signatures)
+
+		_ = indexCSV.Write([]string{doc.DocID, doc.Title, doc.URL, doc.Checksum, doc.CreatedBy,
+			doc.CreatedAt.UTC().Format(time.RFC3339), strconv.Itoa(len(signatures)), name})
+		progress(i+1, len(documents))
+	}
+	indexCSV.Flush()
+	if err := indexCSV.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode documents.csv: %w", err)
+	}
+	files = append([]crypto.BundleFile{{Name: "documents.csv", Data: index.Bytes()}}, files...)
+
+	bundle, err := s.signer.SignBundle(ctx, exportKindSignatureArchive, "", files)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write signature archive: %w", err)
+	}
+
+	archiveID, err := newArchiveID()
+	if err != nil {
+		return nil, err
+	}
+	key := archiveKey(archiveID)
+	if err := s.storage.Upload(ctx, key, bytes.NewReader(buf.Bytes()), int64(buf.Len()), "application/zip"); err != nil {
+		return nil, fmt.Errorf("failed to store signature archive: %w", err)
+	}
+
+	downloadURL, expiresAt := s.links.SignedArchiveURL(archiveID, s.ttl)
+	_, err = s.jobs.Enqueue(ctx, models.JobInput{
+		Type:      SignatureArchiveCleanupJobType,
+		Payload:   SignatureArchiveCleanupPayload{ArchiveID: archiveID},
+		RunAt:     &expiresAt,
+		CreatedBy: payload.RequestedBy,
+	})
+	if err != nil {
+		// Without its cleanup the archive would be kept forever
+		if delErr := s.storage.Delete(ctx, key); delErr != nil {
+			logger.Logger.Warn("Failed to delete signature archive", "archive_id", archiveID, "error", delErr.Error())
+		}
+		return nil, fmt.Errorf("failed to queue signature archive cleanup: %w", err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	logger.Logger.Info("Signature archive built", "archive_id", archiveID, "documents", len(documents), "signatures", signatureCount, "size", buf.Len())
+	return &models.SignatureArchive{
+		ArchiveID:   archiveID,
+		Documents:   len(documents),
+		Signatures:  signatureCount,
+		Size:        int64(buf.Len()),
+		SHA256:      hex.EncodeToString(sum[:]),
+		DownloadURL: downloadURL,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// RunCleanupJob deletes an expired archive
+func (s *SignatureArchiveService) RunCleanupJob(ctx context.Context, payload SignatureArchiveCleanupPayload) error {
+	if !archiveIDPattern.MatchString(payload.ArchiveID) {
+		return fmt.Errorf("invalid archive ID %q", payload.ArchiveID)
+	}
+	if err := s.storage.Delete(ctx, archiveKey(payload.ArchiveID)); err != nil {
+		return fmt.Errorf("failed to delete signature archive: %w", err)
+	}
+	logger.Logger.Info("Expired signature archive deleted", "archive_id", payload.ArchiveID)
+	return nil
+}
+
+// Open checks the download link of an archive and returns its content and size.
+// ErrSignatureArchiveGone is returned once the archive expired or was deleted.
+func (s *SignatureArchiveService) Open(ctx context.Context, archiveID, expires, signature string) (io.ReadCloser, int64, error) {
+	if !archiveIDPattern.MatchString(archiveID) {
+		return nil, 0, models.ErrSignatureArchiveGone
+	}
+	if err := s.links.VerifyArchive(archiveID, expires, signature); err != nil {
+		return nil, 0, err
+	}
+
+	key := archiveKey(archiveID)
+	exists, err := s.storage.Exists(ctx, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to check signature archive: %w", err)
+	}
+	if !exists {
+		return nil, 0, models.ErrSignatureArchiveGone
+	}
+	reader, size, _, err := s.storage.Download(ctx, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read signature archive: %w", err)
+	}
+	return reader, size, nil
+}
+
+// listDocuments returns every document once, even when documents are created while listing
+func (s *SignatureArchiveService) listDocuments(ctx context.Context) ([]*models.Document, error) {
+	var documents []*models.Document
+	seen := make(map[string]bool)
+	for offset := 0; ; offset += archiveDocumentPageSize {
+		page, err := s.docs.List(ctx, archiveDocumentPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range page {
+			if !seen[doc.DocID] {
+				seen[doc.DocID] = true
+				documents = append(documents, doc)
+			}
+		}
+		if len(page) < archiveDocumentPageSize {
+			return documents, nil
+		}
+	}
+}
+
+func archiveKey(archiveID string) string {
+	return "exports/signatures/" + archiveID + ".zip"
+}
+
+func newArchiveID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate archive ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
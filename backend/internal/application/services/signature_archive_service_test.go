@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/storage"
+)
+
+func TestSignatureArchiveService_RunArchiveJob(t *testing.T) {
+	exports, signer := newTestExportService(t)
+	docs := newMockDocRepo()
+	docs.documents["doc1"] = &models.Document{DocID: "doc1", Title: "Security Policy"}
+	docs.documents["doc2"] = &models.Document{DocID: "doc2", Title: "Code of Conduct"}
+	sigs := &fakeExportSignatureRepo{signatures: []*models.Signature{
+		{ID: 1, UserEmail: "alice@example.com", SignedAtUTC: time.Now(), PayloadHash: "hash", Signature: "sig"},
+	}}
+	store := &fakeArchiveStorage{objects: map[string][]byte{}}
+	jobs := &fakeDocumentJobQueue{}
+	links := storage.NewURLSigner([]byte("secret"), "https://ackify.example.com", time.Hour)
+	service := NewSignatureArchiveService(docs, sigs, exports, store, links, jobs, 72*time.Hour)
+	ctx := context.Background()
+
+	var progress [][2]int
+	archive, err := service.RunArchiveJob(ctx, SignatureArchiveJobPayload{RequestedBy: "admin@example.com"}, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	if err != nil {
+		t.Fatalf("RunArchiveJob error: %v", err)
+	}
+	if archive.Documents != 2 || archive.Signatures != 2 || archive.Size == 0 || archive.SHA256 == "" {
+		t.Fatalf("unexpected archive: %+v", archive)
+	}
+	if len(progress) != 3 || progress[0] != [2]int{0, 2} || progress[2] != [2]int{2, 2} {
+		t.Errorf("unexpected progress: %v", progress)
+	}
+
+	data, ok := store.objects[archiveKey(archive.ArchiveID)]
+	if !ok {
+		t.Fatal("expected the archive in storage")
+	}
+	verification, err := crypto.VerifyExportBundle(data, signer.GetPublicKey())
+	if err != nil || !verification.Valid {
+		t.Fatalf("expected a valid bundle, got %+v (err %v)", verification, err)
+	}
+	names := make([]string, 0, len(verification.Files))
+	for _, f := range verification.Files {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "documents.csv,documents/doc1.csv,documents/doc2.csv" {
+		t.Errorf("unexpected files: %v", names)
+	}
+
+	if len(jobs.inputs) != 1 || jobs.inputs[0].Type != SignatureArchiveCleanupJobType || !jobs.inputs[0].RunAt.Equal(archive.ExpiresAt) {
+		t.Fatalf("expected the cleanup queued at the expiry, got %+v", jobs.inputs)
+	}
+
+	link, err := url.Parse(archive.DownloadURL)
+	if err != nil {
+		t.Fatalf("invalid download URL: %v", err)
+	}
+	reader, size, err := service.Open(ctx, archive.ArchiveID, link.Query().Get("expires"), link.Query().Get("sig"))
+	if err != nil || size != archive.Size {
+		t.Fatalf("Open: size %d, err %v", size, err)
+	}
+	_ = reader.Close()
+
+	if _, _, err := service.Open(ctx, archive.ArchiveID, link.Query().Get("expires"), "forged"); !errors.Is(err, storage.ErrSignedURLInvalid) {
+		t.Errorf("expected a forged link to be refused, got %v", err)
+	}
+
+	if err := service.RunCleanupJob(ctx, SignatureArchiveCleanupPayload{ArchiveID: archive.ArchiveID}); err != nil {
+		t.Fatalf("RunCleanupJob error: %v", err)
+	}
+	if _, _, err := service.Open(ctx, archive.ArchiveID, link.Query().Get("expires"), link.Query().Get("sig")); !errors.Is(err, models.ErrSignatureArchiveGone) {
+		t.Errorf("expected ErrSignatureArchiveGone after the cleanup, got %v", err)
+	}
+}
+
+func TestSignatureArchiveService_StorageFailure(t *testing.T) {
+	exports, _ := newTestExportService(t)
+	docs := newMockDocRepo()
+	store := &fakeArchiveStorage{objects: map[string][]byte{}, failed: true}
+	jobs := &fakeDocumentJobQueue{}
+	links := storage.NewURLSigner([]byte("secret"), "https://ackify.example.com", time.Hour)
+	service := NewSignatureArchiveService(docs, &fakeExportSignatureRepo{}, exports, store, links, jobs, time.Hour)
+
+	if _, err := service.RunArchiveJob(context.Background(), SignatureArchiveJobPayload{}, func(int, int) {}); err == nil {
+		t.Fatal("expected the job to fail when the archive cannot be stored")
+	}
+	if len(jobs.inputs) != 0 {
+		t.Errorf("expected no cleanup queued, got %+v", jobs.inputs)
+	}
+}
+
+func TestSignatureArchiveService_Request(t *testing.T) {
+	jobs := &fakeDocumentJobQueue{}
+	service := NewSignatureArchiveService(newMockDocRepo(), &fakeExportSignatureRepo{}, nil, nil, nil, jobs, time.Hour)
+
+	if _, err := service.Request(context.Background(), "admin@example.com"); err != nil {
+		t.Fatalf("Request error: %v", err)
+	}
+	if len(jobs.inputs) != 1 || jobs.inputs[0].Type != SignatureArchiveJobType || jobs.inputs[0].DedupKey == "" {
+		t.Fatalf("expected a deduplicated archive job, got %+v", jobs.inputs)
+	}
+}
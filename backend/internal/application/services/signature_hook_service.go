@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// DefaultSignatureHookTimeoutMs bounds a hook call when no timeout is configured
+	DefaultSignatureHookTimeoutMs = 3000
+
+	minSignatureHookTimeoutMs = 100
+	maxSignatureHookTimeoutMs = 10000
+)
+
+// ErrInvalidSignatureHook is returned when a signature hook configuration fails validation
+var ErrInvalidSignatureHook = errors.New("invalid signature hook")
+
+// signatureHookRepository defines per-document signature hook storage
+type signatureHookRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.SignatureHook, error)
+	Upsert(ctx context.Context, docID string, input models.SignatureHookInput, updatedBy string) (*models.SignatureHook, error)
+	Delete(ctx context.Context, docID string) (bool, error)
+}
+
+// signatureHookDocumentRepository checks the document of a hook exists
+type signatureHookDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// signatureHookCaller calls the hook of a document and returns its decision
+type signatureHookCaller interface {
+	Evaluate(ctx context.Context, hook *models.SignatureHook, user *models.User) (*models.SignatureHookDecision, error)
+}
+
+// SignatureHookService manages the validation hooks of documents and evaluates them
+// before signatures are created
+type SignatureHookService struct {
+	repo    signatureHookRepository
+	docRepo signatureHookDocumentRepository
+	caller  signatureHookCaller
+}
+
+// NewSignatureHookService creates a new signature hook service
+func NewSignatureHookService(repo signatureHookRepository, docRepo signatureHookDocumentRepository, caller signatureHookCaller) *SignatureHookService {
+	return &SignatureHookService{repo: repo, docRepo: docRepo, caller: caller}
+}
+
+// GetHook returns the validation hook of a document, or nil if none is configured
+func (s *SignatureHookService) GetHook(ctx context.Context, docID string) (*models.SignatureHook, error) {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByDocID(ctx, docID)
+}
+
+// SetHook validates and stores the validation hook of a document
+func (s *SignatureHookService) SetHook(ctx context.Context, docID string, input models.SignatureHookInput, updatedBy string) (*models.SignatureHook, error) {
+	input.URL = strings.TrimSpace(input.URL)
+	u, err := url.Parse(input.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("%w: url must be an http(s) URL", ErrInvalidSignatureHook)
+	}
+	if input.TimeoutMs == 0 {
+		input.TimeoutMs = DefaultSignatureHookTimeoutMs
+	}
+	if input.TimeoutMs < minSignatureHookTimeoutMs || input.TimeoutMs > maxSignatureHookTimeoutMs {
+		return nil, fmt.Errorf("%w: timeoutMs must be between %d and %d", ErrInvalidSignatureHook, minSignatureHookTimeoutMs, maxSignatureHookTimeoutMs)
+	}
+	switch input.FailurePolicy {
+	case "":
+		input.FailurePolicy = models.SignatureHookFailClosed
+	case models.SignatureHookFailOpen, models.SignatureHookFailClosed:
+	default:
+		return nil, fmt.Errorf("%w: failurePolicy must be open or closed", ErrInvalidSignatureHook)
+	}
+
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.repo.Upsert(ctx, docID, input, updatedBy)
+}
+
+// DeleteHook removes the validation hook of a document
+func (s *SignatureHookService) DeleteHook(ctx context.Context, docID string) error {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return err
+	}
+	_, err := s.repo.Delete(ctx, docID)
+	return err
+}
+
+// Validate calls the hook of the document, if any, before user signs it. A refusal returns a
+// SignatureRejectedError. When the hook fails, the signature is allowed under the open policy
+// and refused with ErrSignatureHookFailed under the closed one.
+func (s *SignatureHookService) Validate(ctx context.Context, docID string, user *models.User) error {
+	hook, err := s.repo.GetByDocID(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to get signature hook: %w", err)
+	}
+	if hook == nil {
+		return nil
+	}
+
+	decision, err := s.caller.Evaluate(ctx, hook, user)
+	if err != nil {
+		if hook.FailurePolicy == models.SignatureHookFailOpen {
+			logger.Logger.Warn("Signature hook failed, allowing signature",
+				"doc_id", docID,
+				"user_email", user.NormalizedEmail(),
+				"error", err.Error())
+			return nil
+		}
+		logger.Logger.Warn("Signature hook failed, refusing signature",
+			"doc_id", docID,
+			"user_email", user.NormalizedEmail(),
+			"error", err.Error())
+		return models.ErrSignatureHookFailed
+	}
+	if !decision.Allow {
+		return &models.SignatureRejectedError{Reason: decision.Reason}
+	}
+	return nil
+}
+
+func (s *SignatureHookService) checkDocument(ctx context.Context, docID string) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return models.ErrDocumentNotFound
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSignatureHookRepo struct {
+	hooks map[string]*models.SignatureHook
+}
+
+func newFakeSignatureHookRepo() *fakeSignatureHookRepo {
+	return &fakeSignatureHookRepo{hooks: make(map[string]*models.SignatureHook)}
+}
+
+func (f *fakeSignatureHookRepo) GetByDocID(_ context.Context, docID string) (*models.SignatureHook, error) {
+	return f.hooks[docID], nil
+}
+
+func (f *fakeSignatureHookRepo) Upsert(_ context.Context, docID string, input models.SignatureHookInput, updatedBy string) (*models.SignatureHook, error) {
+	hook := &models.SignatureHook{DocID: docID, URL: input.URL, Secret: input.Secret, TimeoutMs: input.TimeoutMs, FailurePolicy: input.FailurePolicy, UpdatedBy: updatedBy}
+	if existing := f.hooks[docID]; existing != nil && hook.Secret == "" {
+		hook.Secret = existing.Secret
+	}
+	hook.HasSecret = hook.Secret != ""
+	f.hooks[docID] = hook
+	return hook, nil
+}
+
+func (f *fakeSignatureHookRepo) Delete(_ context.Context, docID string) (bool, error) {
+	_, ok := f.hooks[docID]
+	delete(f.hooks, docID)
+	return ok, nil
+}
+
+type fakeSignatureHookCaller struct {
+	decision *models.SignatureHookDecision
+	err      error
+	calls    int
+}
+
+func (f *fakeSignatureHookCaller) Evaluate(_ context.Context, _ *models.SignatureHook, _ *models.User) (*models.SignatureHookDecision, error) {
+	f.calls++
+	return f.decision, f.err
+}
+
+func newTestSignatureHookService(caller *fakeSignatureHookCaller) (*SignatureHookService, *fakeSignatureHookRepo) {
+	repo := newFakeSignatureHookRepo()
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc1": {DocID: "doc1"}}}
+	return NewSignatureHookService(repo, docs, caller), repo
+}
+
+func TestSignatureHookService_SetHook(t *testing.T) {
+	svc, _ := newTestSignatureHookService(&fakeSignatureHookCaller{})
+	ctx := context.Background()
+
+	hook, err := svc.SetHook(ctx, "doc1", models.SignatureHookInput{URL: " https://lms.example.com/check "}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("SetHook failed: %v", err)
+	}
+	if hook.URL != "https://lms.example.com/check" || hook.TimeoutMs != DefaultSignatureHookTimeoutMs || hook.FailurePolicy != models.SignatureHookFailClosed {
+		t.Errorf("expected the defaults to be applied, got %+v", hook)
+	}
+
+	invalid := []models.SignatureHookInput{
+		{URL: "ftp://lms.example.com"},
+		{URL: "https://lms.example.com", TimeoutMs: 50},
+		{URL: "https://lms.example.com", TimeoutMs: 20000},
+		{URL: "https://lms.example.com", FailurePolicy: "maybe"},
+	}
+	for _, input := range invalid {
+		if _, err := svc.SetHook(ctx, "doc1", input, "admin@example.com"); !errors.Is(err, ErrInvalidSignatureHook) {
+			t.Errorf("expected ErrInvalidSignatureHook for %+v, got %v", input, err)
+		}
+	}
+
+	if _, err := svc.SetHook(ctx, "missing", models.SignatureHookInput{URL: "https://lms.example.com"}, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestSignatureHookService_Validate(t *testing.T) {
+	user := &models.User{Sub: "alice", Email: "alice@example.com"}
+	ctx := context.Background()
+
+	caller := &fakeSignatureHookCaller{decision: &models.SignatureHookDecision{Allow: true}}
+	svc, repo := newTestSignatureHookService(caller)
+
+	if err := svc.Validate(ctx, "doc1", user); err != nil || caller.calls != 0 {
+		t.Fatalf("expected documents without hook to be allowed, got %v after %d calls", err, caller.calls)
+	}
+
+	_, _ = repo.Upsert(ctx, "doc1", models.SignatureHookInput{URL: "https://lms.example.com", TimeoutMs: 1000, FailurePolicy: models.SignatureHookFailClosed}, "admin@example.com")
+	if err := svc.Validate(ctx, "doc1", user); err != nil {
+		t.Fatalf("expected the signature to be allowed, got %v", err)
+	}
+
+	caller.decision = &models.SignatureHookDecision{Allow: false, Reason: "Training not completed"}
+	err := svc.Validate(ctx, "doc1", user)
+	var rejected *models.SignatureRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "Training not completed" {
+		t.Fatalf("expected a rejection with its reason, got %v", err)
+	}
+}
+
+func TestSignatureHookService_Validate_FailurePolicy(t *testing.T) {
+	user := &models.User{Sub: "alice", Email: "alice@example.com"}
+	ctx := context.Background()
+
+	caller := &fakeSignatureHookCaller{err: errors.New("timeout")}
+	svc, repo := newTestSignatureHookService(caller)
+
+	_, _ = repo.Upsert(ctx, "doc1", models.SignatureHookInput{URL: "https://lms.example.com", TimeoutMs: 1000, FailurePolicy: models.SignatureHookFailClosed}, "admin@example.com")
+	if err := svc.Validate(ctx, "doc1", user); !errors.Is(err, models.ErrSignatureHookFailed) {
+		t.Errorf("expected ErrSignatureHookFailed when failing closed, got %v", err)
+	}
+
+	_, _ = repo.Upsert(ctx, "doc1", models.SignatureHookInput{URL: "https://lms.example.com", TimeoutMs: 1000, FailurePolicy: models.SignatureHookFailOpen}, "admin@example.com")
+	if err := svc.Validate(ctx, "doc1", user); err != nil {
+		t.Errorf("expected the signature to be allowed when failing open, got %v", err)
+	}
+}
+
+func TestSignatureService_CreateSignature_ValidationHook(t *testing.T) {
+	repo := newFakeRepository()
+	caller := &fakeSignatureHookCaller{decision: &models.SignatureHookDecision{Allow: false, Reason: "Training not completed"}}
+	hooks, hookRepo := newTestSignatureHookService(caller)
+	_, _ = hookRepo.Upsert(context.Background(), "doc1", models.SignatureHookInput{URL: "https://lms.example.com", TimeoutMs: 1000, FailurePolicy: models.SignatureHookFailClosed}, "admin@example.com")
+
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	service.SetValidationHook(hooks)
+	ctx := context.Background()
+	request := &models.SignatureRequest{DocID: "doc1", User: &models.User{Sub: "alice", Email: "alice@example.com"}}
+
+	if err := service.CreateSignature(ctx, request); !errors.Is(err, models.ErrSignatureRejected) {
+		t.Fatalf("expected ErrSignatureRejected, got %v", err)
+	}
+	if len(repo.allSignatures) != 0 {
+		t.Fatalf("expected no signature, got %d", len(repo.allSignatures))
+	}
+
+	caller.decision = &models.SignatureHookDecision{Allow: true}
+	if err := service.CreateSignature(ctx, request); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+	if len(repo.allSignatures) != 1 {
+		t.Errorf("expected the signature to be created, got %d", len(repo.allSignatures))
+	}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// DefaultSignatureIdempotencyTTL is how long idempotency keys are kept when no retention is configured
+const DefaultSignatureIdempotencyTTL = 24 * time.Hour
+
+const maxIdempotencyKeyLen = 255
+
+// signatureIdempotencyRepository stores the idempotency keys of signature requests
+type signatureIdempotencyRepository interface {
+	Reserve(ctx context.Context, key *models.SignatureIdempotencyKey, now time.Time) (bool, error)
+	Get(ctx context.Context, userSub, key string) (*models.SignatureIdempotencyKey, error)
+	Complete(ctx context.Context, userSub, key string, statusCode int, response []byte) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SignatureIdempotencyService lets clients retry a signature request with the same Idempotency-Key
+// and get the original response. Keys are scoped to the user and kept for the retention window.
+type SignatureIdempotencyService struct {
+	repo signatureIdempotencyRepository
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+func NewSignatureIdempotencyService(repo signatureIdempotencyRepository, ttl time.Duration) *SignatureIdempotencyService {
+	if ttl <= 0 {
+		ttl = DefaultSignatureIdempotencyTTL
+	}
+	return &SignatureIdempotencyService{repo: repo, ttl: ttl, now: time.Now}
+}
+
+// Begin reserves the key for a request whose body is given. It returns nil when the request is new,
+// or the stored key whose response must be replayed. The reservation is part of the request
+// transaction: when the request fails, the key can be used again.
+func (s *SignatureIdempotencyService) Begin(ctx context.Context, key string, user *models.User, body []byte) (*models.SignatureIdempotencyKey, error) {
+	if user == nil || !user.IsValid() {
+		return nil, models.ErrInvalidUser
+	}
+	if !validIdempotencyKey(key) {
+		return nil, models.ErrIdempotencyKeyInvalid
+	}
+
+	now := s.now()
+	fingerprint := requestFingerprint(body)
+	reserved, err := s.repo.Reserve(ctx, &models.SignatureIdempotencyKey{
+		Key:         key,
+		UserSub:     user.Sub,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(s.ttl).UTC(),
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	stored, err := s.repo.Get(ctx, user.Sub, key)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case stored == nil:
+		// Removed by the cleanup since the reservation attempt
+		return nil, models.ErrIdempotencyKeyPending
+	case stored.Fingerprint != fingerprint:
+		return nil, models.ErrIdempotencyKeyReused
+	case stored.StatusCode == 0:
+		return nil, models.ErrIdempotencyKeyPending
+	}
+	return stored, nil
+}
+
+// Complete stores the response of the request that reserved the key
+func (s *SignatureIdempotencyService) Complete(ctx context.Context, key string, user *models.User, statusCode int, response []byte) error {
+	return s.repo.Complete(ctx, user.Sub, key, statusCode, response)
+}
+
+// CleanupExpired removes the keys past their retention window
+func (s *SignatureIdempotencyService) CleanupExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, s.now())
+}
+
+func validIdempotencyKey(key string) bool {
+	if key == "" || len(key) > maxIdempotencyKeyLen {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func requestFingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeIdempotencyRepo struct {
+	keys map[string]*models.SignatureIdempotencyKey
+}
+
+func (f *fakeIdempotencyRepo) Reserve(_ context.Context, key *models.SignatureIdempotencyKey, now time.Time) (bool, error) {
+	if stored, ok := f.keys[key.UserSub+"/"+key.Key]; ok && stored.ExpiresAt.After(now) {
+		return false, nil
+	}
+	copied := *key
+	f.keys[key.UserSub+"/"+key.Key] = &copied
+	return true, nil
+}
+
+func (f *fakeIdempotencyRepo) Get(_ context.Context, userSub, key string) (*models.SignatureIdempotencyKey, error) {
+	stored, ok := f.keys[userSub+"/"+key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+func (f *fakeIdempotencyRepo) Complete(_ context.Context, userSub, key string, statusCode int, response []byte) error {
+	stored := f.keys[userSub+"/"+key]
+	stored.StatusCode = statusCode
+	stored.Response = response
+	return nil
+}
+
+func (f *fakeIdempotencyRepo) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for k, stored := range f.keys {
+		if stored.ExpiresAt.Before(before) {
+			delete(f.keys, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestSignatureIdempotencyService_Replay(t *testing.T) {
+	service := NewSignatureIdempotencyService(&fakeIdempotencyRepo{keys: map[string]*models.SignatureIdempotencyKey{}}, 0)
+	ctx := context.Background()
+	body := []byte(`{"docId":"doc1"}`)
+
+	stored, err := service.Begin(ctx, "retry-1", nonceSigner, body)
+	if err != nil || stored != nil {
+		t.Fatalf("expected a new request, got %+v, %v", stored, err)
+	}
+	// A retry before the first request completed
+	if _, err := service.Begin(ctx, "retry-1", nonceSigner, body); !errors.Is(err, models.ErrIdempotencyKeyPending) {
+		t.Errorf("expected ErrIdempotencyKeyPending, got %v", err)
+	}
+
+	if err := service.Complete(ctx, "retry-1", nonceSigner, 201, []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Complete err: %v", err)
+	}
+	stored, err = service.Begin(ctx, "retry-1", nonceSigner, body)
+	if err != nil || stored == nil || stored.StatusCode != 201 || string(stored.Response) != `{"id":1}` {
+		t.Fatalf("expected the stored response, got %+v, %v", stored, err)
+	}
+
+	if _, err := service.Begin(ctx, "retry-1", nonceSigner, []byte(`{"docId":"doc2"}`)); !errors.Is(err, models.ErrIdempotencyKeyReused) {
+		t.Errorf("expected ErrIdempotencyKeyReused, got %v", err)
+	}
+	other := &models.User{Sub: "user-2", Email: "bob@example.com"}
+	if stored, err := service.Begin(ctx, "retry-1", other, body); err != nil || stored != nil {
+		t.Errorf("expected keys to be scoped by user, got %+v, %v", stored, err)
+	}
+}
+
+func TestSignatureIdempotencyService_Retention(t *testing.T) {
+	service := NewSignatureIdempotencyService(&fakeIdempotencyRepo{keys: map[string]*models.SignatureIdempotencyKey{}}, time.Hour)
+	ctx := context.Background()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return start }
+
+	if _, err := service.Begin(ctx, "retry-1", nonceSigner, []byte("{}")); err != nil {
+		t.Fatalf("Begin err: %v", err)
+	}
+	_ = service.Complete(ctx, "retry-1", nonceSigner, 201, []byte("{}"))
+
+	service.now = func() time.Time { return start.Add(2 * time.Hour) }
+	if stored, err := service.Begin(ctx, "retry-1", nonceSigner, []byte("{}")); err != nil || stored != nil {
+		t.Errorf("expected an expired key to start a new request, got %+v, %v", stored, err)
+	}
+	service.now = func() time.Time { return start.Add(4 * time.Hour) }
+	if deleted, err := service.CleanupExpired(ctx); err != nil || deleted != 1 {
+		t.Errorf("expected 1 expired key deleted, got %d, %v", deleted, err)
+	}
+}
+
+func TestSignatureIdempotencyService_InvalidKey(t *testing.T) {
+	service := NewSignatureIdempotencyService(&fakeIdempotencyRepo{keys: map[string]*models.SignatureIdempotencyKey{}}, 0)
+	for _, key := range []string{"", strings.Repeat("k", 256), "tab\tkey", "clé"} {
+		if _, err := service.Begin(context.Background(), key, nonceSigner, nil); !errors.Is(err, models.ErrIdempotencyKeyInvalid) {
+			t.Errorf("key %q: expected ErrIdempotencyKeyInvalid, got %v", key, err)
+		}
+	}
+}
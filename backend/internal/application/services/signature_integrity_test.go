@@ -3,12 +3,15 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
 	"github.com/btouchard/ackify-ce/backend/pkg/config"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
@@ -59,6 +62,14 @@ func (m *mockSignatureRepository) GetByUserEmail(ctx context.Context, userEmail
 	return nil, nil
 }
 
+func (m *mockSignatureRepository) ListByDocKeyset(ctx context.Context, docID string, cursor *models.PageCursor, limit int) ([]*models.Signature, error) {
+	return nil, nil
+}
+
+func (m *mockSignatureRepository) ListByUserEmailKeyset(ctx context.Context, userEmail string, cursor *models.PageCursor, limit int) ([]*models.Signature, error) {
+	return nil, nil
+}
+
 func (m *mockSignatureRepository) CheckUserSignatureStatus(ctx context.Context, docID, userIdentifier string) (bool, error) {
 	return false, nil
 }
@@ -208,6 +219,57 @@ func TestSignatureService_DocumentIntegrity_Modified(t *testing.T) {
 	}
 }
 
+// Test signature creation when the PDF was re-serialized but its text is unchanged
+func TestSignatureService_DocumentIntegrity_SameContentHash(t *testing.T) {
+	pdf := func(title string) string {
+		return "%PDF-1.4\n1 0 obj\n<< /Length 44 >>\nstream\nBT /F1 12 Tf 72 712 Td (Terms of use) Tj ET\nendstream\nendobj\n" +
+			"2 0 obj\n<< /Title (" + title + ") >>\nendobj\n%%EOF\n"
+	}
+	original := pdf("Draft")
+	resaved := pdf("Final")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(resaved)))
+		if r.Method == "GET" {
+			w.Write([]byte(resaved))
+		}
+	}))
+	defer server.Close()
+
+	originalSum := sha256.Sum256([]byte(original))
+	docRepo := &mockDocumentRepository{
+		getByDocIDFunc: func(ctx context.Context, docID string) (*models.Document, error) {
+			return &models.Document{
+				DocID:             "test-doc",
+				URL:               server.URL,
+				Checksum:          hex.EncodeToString(originalSum[:]),
+				ChecksumAlgorithm: "SHA-256",
+				ContentHash:       checksum.ComputeContentHash("application/pdf", []byte(original)),
+			}, nil
+		},
+	}
+
+	service := NewSignatureService(&mockSignatureRepository{}, docRepo, &mockCryptoSigner{})
+	service.SetChecksumConfig(&config.ChecksumConfig{
+		MaxBytes:           10 * 1024 * 1024,
+		TimeoutMs:          5000,
+		MaxRedirects:       3,
+		AllowedContentType: []string{"application/pdf"},
+		SkipSSRFCheck:      true,
+		InsecureSkipVerify: true,
+	})
+
+	request := &models.SignatureRequest{
+		DocID: "test-doc",
+		User:  &models.User{Sub: "test-user", Email: "test@example.com", Name: "Test User"},
+	}
+
+	if err := service.CreateSignature(context.Background(), request); err != nil {
+		t.Fatalf("Expected signature to succeed with identical content hash, got: %v", err)
+	}
+}
+
 // Test signature creation without checksum (document has no URL or checksum)
 func TestSignatureService_NoChecksum_Success(t *testing.T) {
 	// Create mock repositories
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	// ledgerBatchSize bounds the signatures streamed per run and checked per reconciliation page
+	ledgerBatchSize = 500
+)
+
+// ledgerEntryRepository records what was streamed to the external ledger
+type ledgerEntryRepository interface {
+	Record(ctx context.Context, entries []models.LedgerEntry) error
+	GetCursor(ctx context.Context) (int64, *time.Time, error)
+	CountPending(ctx context.Context, afterID int64) (int, *time.Time, error)
+	ListAfterID(ctx context.Context, afterID int64, limit int) ([]models.LedgerEntry, error)
+}
+
+// ledgerWriter appends signature hashes to the external ledger
+type ledgerWriter interface {
+	Append(ctx context.Context, entries []models.LedgerEntry) ([]string, error)
+}
+
+// ledgerReader reads the external ledger back, when it supports it
+type ledgerReader interface {
+	Lookup(ctx context.Context, tenantID uuid.UUID, signatureIDs []int64) (map[int64]string, error)
+}
+
+// SignatureLedgerService streams the record hash of each new signature to an external append-only
+// ledger, reports how far the ledger is behind and reconciles it with the signatures
+type SignatureLedgerService struct {
+	repo       ledgerEntryRepository
+	signatures merkleSignatureRepository
+	ledger     ledgerWriter
+	ledgerType string
+	maxLag     time.Duration
+	now        func() time.Time
+}
+
+// NewSignatureLedgerService creates a signature ledger service writing to ledger, of the given type.
+// The ledger is reported unhealthy once a signature waits more than maxLag to be streamed.
+func NewSignatureLedgerService(repo ledgerEntryRepository, signatures merkleSignatureRepository, ledger ledgerWriter, ledgerType string, maxLag time.Duration) *SignatureLedgerService {
+	return &SignatureLedgerService{
+		repo:       repo,
+		signatures: signatures,
+		ledger:     ledger,
+		ledgerType: ledgerType,
+		maxLag:     maxLag,
+		now:        time.Now,
+	}
+}
+
+// StreamPending appends the signatures created since the last streamed one to the ledger and
+// returns how many were streamed. Entries are recorded after the ledger accepted them, so a failure
+// in between streams them again on the next run: delivery is at least once.
+func (s *SignatureLedgerService) StreamPending(ctx context.Context) (int, error) {
+	cursor, _, err := s.repo.GetCursor(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Same settle delay as Merkle roots: a lower ID committed later would be skipped by the cursor
+	signatures, err := s.signatures.ListAfterID(ctx, cursor, s.now().Add(-merkleSettleDelay), ledgerBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(signatures) == 0 {
+		return 0, nil
+	}
+
+	entries := make([]models.LedgerEntry, len(signatures))
+	for i, sig := range signatures {
+		entries[i] = models.LedgerEntry{
+			TenantID:    sig.TenantID,
+			SignatureID: sig.ID,
+			DocID:       sig.DocID,
+			RecordHash:  sig.ComputeRecordHash(),
+			SignedAt:    sig.SignedAtUTC,
+		}
+	}
+
+	refs, err := s.ledger.Append(ctx, entries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to append to the %s ledger: %w", s.ledgerType, err)
+	}
+	for i := range entries {
+		entries[i].LedgerRef = refs[i]
+	}
+	if err := s.repo.Record(ctx, entries); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// Lag reports the signatures not streamed yet and the age of the oldest one
+func (s *SignatureLedgerService) Lag(ctx context.Context) (*models.LedgerLag, error) {
+	cursor, streamedAt, err := s.repo.GetCursor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, oldest, err := s.repo.CountPending(ctx, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	_, readable := s.ledger.(ledgerReader)
+	lag := &models.LedgerLag{
+		Ledger:              s.ledgerType,
+		LastSignatureID:     cursor,
+		LastStreamedAt:      streamedAt,
+		PendingCount:        pending,
+		OldestPendingAt:     oldest,
+		MaxLagSeconds:       s.maxLag.Seconds(),
+		ReconciliationReady: readable,
+	}
+	if oldest != nil {
+		lag.LagSeconds = s.now().Sub(*oldest).Seconds()
+	}
+	lag.Healthy = s.maxLag <= 0 || lag.LagSeconds <= s.maxLag.Seconds()
+	return lag, nil
+}
+
+// Reconcile checks the streamed entries above afterID against the signatures and, when the
+// ledger can be read back, against the ledger. It stops after limit entries, 0 meaning all.
+func (s *SignatureLedgerService) Reconcile(ctx context.Context, afterID int64, limit int) (*models.LedgerReconciliation, error) {
+	reader, readable := s.ledger.(ledgerReader)
+	result := &models.LedgerReconciliation{LedgerChecked: readable, Discrepancies: []models.LedgerDiscrepancy{}}
+
+	for limit <= 0 || result.Checked < limit {
+		pageSize := ledgerBatchSize
+		if limit > 0 && limit-result.Checked < pageSize {
+			pageSize = limit - result.Checked
+		}
+		entries, err := s.repo.ListAfterID(ctx, afterID, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		for _, entry := range entries {
+			sig, err := s.signatures.GetByID(ctx, entry.SignatureID)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case sig == nil:
+				result.Discrepancies = append(result.Discrepancies, models.LedgerDiscrepancy{
+					SignatureID: entry.SignatureID, Kind: models.LedgerSignatureDeleted, Expected: entry.RecordHash,
+				})
+			case sig.ComputeRecordHash() != entry.RecordHash:
+				result.Discrepancies = append(result.Discrepancies, models.LedgerDiscrepancy{
+					SignatureID: entry.SignatureID, Kind: models.LedgerSignatureModified, Expected: entry.RecordHash, Actual: sig.ComputeRecordHash(),
+				})
+			}
+		}
+
+		if readable {
+			if err := s.reconcileLedger(ctx, reader, entries, result); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Checked += len(entries)
+		afterID = entries[len(entries)-1].SignatureID
+	}
+
+	if len(result.Discrepancies) > 0 {
+		logger.Logger.Warn("Signature ledger reconciliation found discrepancies",
+			"checked", result.Checked,
+			"discrepancies", len(result.Discrepancies))
+	}
+	return result, nil
+}
+
+// reconcileLedger compares a page of streamed entries with the hashes held by the ledger
+func (s *SignatureLedgerService) reconcileLedger(ctx context.Context, reader ledgerReader, entries []models.LedgerEntry, result *models.LedgerReconciliation) error {
+	ids := make([]int64, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.SignatureID
+	}
+	hashes, err := reader.Lookup(ctx, entries[0].TenantID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to read the %s ledger: %w", s.ledgerType, err)
+	}
+
+	for _, entry := range entries {
+		hash, ok := hashes[entry.SignatureID]
+		switch {
+		case !ok:
+			result.Discrepancies = append(result.Discrepancies, models.LedgerDiscrepancy{
+				SignatureID: entry.SignatureID, Kind: models.LedgerEntryMissing, Expected: entry.RecordHash,
+			})
+		case hash != entry.RecordHash:
+			result.Discrepancies = append(result.Discrepancies, models.LedgerDiscrepancy{
+				SignatureID: entry.SignatureID, Kind: models.LedgerEntryMismatch, Expected: entry.RecordHash, Actual: hash,
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeLedgerEntryRepo struct {
+	entries []models.LedgerEntry
+}
+
+func (f *fakeLedgerEntryRepo) Record(_ context.Context, entries []models.LedgerEntry) error {
+	for _, entry := range entries {
+		entry.StreamedAt = time.Now()
+		f.entries = append(f.entries, entry)
+	}
+	return nil
+}
+
+func (f *fakeLedgerEntryRepo) GetCursor(_ context.Context) (int64, *time.Time, error) {
+	if len(f.entries) == 0 {
+		return 0, nil, nil
+	}
+	last := f.entries[len(f.entries)-1]
+	return last.SignatureID, &last.StreamedAt, nil
+}
+
+func (f *fakeLedgerEntryRepo) CountPending(_ context.Context, _ int64) (int, *time.Time, error) {
+	return 0, nil, nil
+}
+
+func (f *fakeLedgerEntryRepo) ListAfterID(_ context.Context, afterID int64, limit int) ([]models.LedgerEntry, error) {
+	var out []models.LedgerEntry
+	for _, entry := range f.entries {
+		if entry.SignatureID > afterID && len(out) < limit {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+type fakeLedgerWriter struct {
+	err      error
+	appended []models.LedgerEntry
+}
+
+func (f *fakeLedgerWriter) Append(_ context.Context, entries []models.LedgerEntry) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	refs := make([]string, len(entries))
+	for i, entry := range entries {
+		f.appended = append(f.appended, entry)
+		refs[i] = fmt.Sprintf("ledger/%d", entry.SignatureID)
+	}
+	return refs, nil
+}
+
+// fakeReadableLedger can be read back for reconciliation
+type fakeReadableLedger struct {
+	fakeLedgerWriter
+}
+
+func (f *fakeReadableLedger) Lookup(_ context.Context, _ uuid.UUID, signatureIDs []int64) (map[int64]string, error) {
+	hashes := map[int64]string{}
+	for _, entry := range f.appended {
+		for _, id := range signatureIDs {
+			if entry.SignatureID == id {
+				hashes[id] = entry.RecordHash
+			}
+		}
+	}
+	return hashes, nil
+}
+
+func TestSignatureLedgerService_StreamPending(t *testing.T) {
+	repo := &fakeLedgerEntryRepo{}
+	sigs := &fakeMerkleSignatureRepo{}
+	ledger := &fakeLedgerWriter{}
+	service := NewSignatureLedgerService(repo, sigs, ledger, models.LedgerKafka, time.Hour)
+	ctx := context.Background()
+
+	sigs.add(3, time.Now().Add(-time.Hour))
+	sigs.add(1, time.Now())
+
+	streamed, err := service.StreamPending(ctx)
+	if err != nil || streamed != 3 {
+		t.Fatalf("expected 3 streamed signatures, got %d, %v", streamed, err)
+	}
+	if len(repo.entries) != 3 || repo.entries[2].LedgerRef != "ledger/3" || repo.entries[0].RecordHash != sigs.signatures[0].ComputeRecordHash() {
+		t.Errorf("unexpected recorded entries: %+v", repo.entries)
+	}
+
+	// Nothing new before the settle delay
+	streamed, err = service.StreamPending(ctx)
+	if err != nil || streamed != 0 {
+		t.Fatalf("expected nothing to stream, got %d, %v", streamed, err)
+	}
+
+	// A ledger failure records nothing, the batch is retried later
+	ledger.err = errors.New("unavailable")
+	sigs.add(1, time.Now().Add(-time.Hour))
+	if _, err := service.StreamPending(ctx); err == nil {
+		t.Fatal("expected the ledger error")
+	}
+	if len(repo.entries) != 3 {
+		t.Errorf("expected no entry recorded on failure, got %d", len(repo.entries))
+	}
+}
+
+func TestSignatureLedgerService_Lag(t *testing.T) {
+	oldest := time.Now().Add(-30 * time.Minute)
+	repo := &fakeLedgerEntryRepo{}
+	service := NewSignatureLedgerService(&pendingLedgerRepo{fakeLedgerEntryRepo: repo, pending: 4, oldest: &oldest},
+		&fakeMerkleSignatureRepo{}, &fakeLedgerWriter{}, models.LedgerKafka, 15*time.Minute)
+
+	lag, err := service.Lag(context.Background())
+	if err != nil {
+		t.Fatalf("lag err: %v", err)
+	}
+	if lag.PendingCount != 4 || lag.LagSeconds < 1799 || lag.Healthy || lag.ReconciliationReady {
+		t.Errorf("expected an unhealthy, unreadable ledger 30 minutes behind, got %+v", lag)
+	}
+}
+
+type pendingLedgerRepo struct {
+	*fakeLedgerEntryRepo
+	pending int
+	oldest  *time.Time
+}
+
+func (p *pendingLedgerRepo) CountPending(_ context.Context, _ int64) (int, *time.Time, error) {
+	return p.pending, p.oldest, nil
+}
+
+func TestSignatureLedgerService_Reconcile(t *testing.T) {
+	repo := &fakeLedgerEntryRepo{}
+	sigs := &fakeMerkleSignatureRepo{}
+	ledger := &fakeReadableLedger{}
+	service := NewSignatureLedgerService(repo, sigs, ledger, models.LedgerPostgres, time.Hour)
+	ctx := context.Background()
+
+	sigs.add(5, time.Now().Add(-time.Hour))
+	if _, err := service.StreamPending(ctx); err != nil {
+		t.Fatalf("stream err: %v", err)
+	}
+
+	result, err := service.Reconcile(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("reconcile err: %v", err)
+	}
+	if result.Checked != 5 || !result.LedgerChecked || len(result.Discrepancies) != 0 {
+		t.Fatalf("expected a clean reconciliation, got %+v", result)
+	}
+
+	// Tamper with a signature, delete another, and lose a ledger entry
+	sigs.signatures[1].UserEmail = "attacker@example.com"
+	sigs.signatures = append(sigs.signatures[:2], sigs.signatures[3:]...)
+	ledger.appended = ledger.appended[:4]
+
+	result, err = service.Reconcile(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("reconcile err: %v", err)
+	}
+	kinds := map[int64]string{}
+	for _, d := range result.Discrepancies {
+		kinds[d.SignatureID] = d.Kind
+	}
+	want := map[int64]string{2: models.LedgerSignatureModified, 3: models.LedgerSignatureDeleted, 5: models.LedgerEntryMissing}
+	if len(kinds) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, result.Discrepancies)
+	}
+	for id, kind := range want {
+		if kinds[id] != kind {
+			t.Errorf("signature %d: expected %s, got %q", id, kind, kinds[id])
+		}
+	}
+
+	result, err = service.Reconcile(ctx, 3, 1)
+	if err != nil || result.Checked != 1 {
+		t.Errorf("expected one entry checked after signature 3, got %+v, %v", result, err)
+	}
+}
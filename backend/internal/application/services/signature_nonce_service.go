@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// DefaultSignatureNonceTTL is how long an issued nonce can be used when no TTL is configured
+const DefaultSignatureNonceTTL = 5 * time.Minute
+
+// signatureNonceRepository stores the nonces issued to signers
+type signatureNonceRepository interface {
+	Create(ctx context.Context, nonce *models.SignatureNonce) error
+	Consume(ctx context.Context, nonce, userSub, docID string, now time.Time) (bool, error)
+	Get(ctx context.Context, nonce string) (*models.SignatureNonce, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SignatureNonceService issues single-use nonces bound to a user and a document, and rejects
+// signature requests replaying one. Nonces are stored in the database so that a nonce consumed
+// on one instance is refused by the others.
+type SignatureNonceService struct {
+	repo     signatureNonceRepository
+	ttl      time.Duration
+	required bool
+	now      func() time.Time
+
+	issued   atomic.Int64
+	consumed atomic.Int64
+	replayed atomic.Int64
+	expired  atomic.Int64
+	invalid  atomic.Int64
+	missing  atomic.Int64
+}
+
+// NewSignatureNonceService creates the service. When required is false, signature requests
+// without nonce are still accepted, but a nonce sent with a request is always checked.
+func NewSignatureNonceService(repo signatureNonceRepository, ttl time.Duration, required bool) *SignatureNonceService {
+	if ttl <= 0 {
+		ttl = DefaultSignatureNonceTTL
+	}
+	return &SignatureNonceService{repo: repo, ttl: ttl, required: required, now: time.Now}
+}
+
+// Issue creates a nonce for the next signature of the user on the document
+func (s *SignatureNonceService) Issue(ctx context.Context, docID string, user *models.User) (*models.SignatureNonce, error) {
+	if user == nil || !user.IsValid() {
+		return nil, models.ErrInvalidUser
+	}
+	if docID == "" {
+		return nil, models.ErrInvalidDocument
+	}
+
+	value, err := crypto.GenerateNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	nonce := &models.SignatureNonce{
+		Nonce:     value,
+		UserSub:   user.Sub,
+		DocID:     docID,
+		ExpiresAt: s.now().Add(s.ttl).UTC(),
+	}
+	if err := s.repo.Create(ctx, nonce); err != nil {
+		return nil, err
+	}
+	s.issued.Add(1)
+	return nonce, nil
+}
+
+// Consume checks the nonce of a signature request and marks it used. The consumption is part of
+// the request transaction: when the signature is not created, the nonce can be used again.
+func (s *SignatureNonceService) Consume(ctx context.Context, nonce, docID string, user *models.User) error {
+	if user == nil || !user.IsValid() {
+		return models.ErrInvalidUser
+	}
+	if nonce == "" {
+		if !s.required {
+			return nil
+		}
+		s.missing.Add(1)
+		logger.Logger.Warn("Signature request rejected: missing nonce", "doc_id", docID, "user_email", user.NormalizedEmail())
+		return models.ErrNonceRequired
+	}
+
+	now := s.now()
+	ok, err := s.repo.Consume(ctx, nonce, user.Sub, docID, now)
+	if err != nil {
+		return err
+	}
+	if ok {
+		s.consumed.Add(1)
+		return nil
+	}
+
+	rejection := s.reject(ctx, nonce, docID, user, now)
+	logger.Logger.Warn("Signature request rejected: invalid nonce",
+		"doc_id", docID,
+		"user_email", user.NormalizedEmail(),
+		"reason", rejection.Error())
+	return rejection
+}
+
+// reject finds out why a nonce could not be consumed and counts the rejection
+func (s *SignatureNonceService) reject(ctx context.Context, nonce, docID string, user *models.User, now time.Time) error {
+	stored, err := s.repo.Get(ctx, nonce)
+	switch {
+	case errors.Is(err, models.ErrNonceNotFound):
+		s.invalid.Add(1)
+		return models.ErrNonceInvalid
+	case err != nil:
+		return err
+	case stored.UserSub != user.Sub || stored.DocID != docID:
+		s.invalid.Add(1)
+		return models.ErrNonceInvalid
+	case stored.UsedAt != nil:
+		s.replayed.Add(1)
+		return models.ErrNonceReplayed
+	case !stored.ExpiresAt.After(now):
+		s.expired.Add(1)
+		return models.ErrNonceExpired
+	default:
+		// Consumed by a concurrent request in the meantime
+		s.replayed.Add(1)
+		return models.ErrNonceReplayed
+	}
+}
+
+// CleanupExpired removes the expired nonces; used nonces are kept until they expire so that
+// replays are reported as such
+func (s *SignatureNonceService) CleanupExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, s.now())
+}
+
+// Stats reports the nonces issued and the rejected signature requests since startup
+func (s *SignatureNonceService) Stats() models.ReplayProtectionStats {
+	return models.ReplayProtectionStats{
+		Required: s.required,
+		TTL:      s.ttl,
+		Issued:   s.issued.Load(),
+		Consumed: s.consumed.Load(),
+		Replayed: s.replayed.Load(),
+		Expired:  s.expired.Load(),
+		Invalid:  s.invalid.Load(),
+		Missing:  s.missing.Load(),
+	}
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeNonceRepo struct {
+	nonces map[string]*models.SignatureNonce
+}
+
+func (f *fakeNonceRepo) Create(_ context.Context, nonce *models.SignatureNonce) error {
+	copied := *nonce
+	f.nonces[nonce.Nonce] = &copied
+	return nil
+}
+
+func (f *fakeNonceRepo) Consume(_ context.Context, nonce, userSub, docID string, now time.Time) (bool, error) {
+	stored, ok := f.nonces[nonce]
+	if !ok || stored.UserSub != userSub || stored.DocID != docID || stored.UsedAt != nil || !stored.ExpiresAt.After(now) {
+		return false, nil
+	}
+	stored.UsedAt = &now
+	return true, nil
+}
+
+func (f *fakeNonceRepo) Get(_ context.Context, nonce string) (*models.SignatureNonce, error) {
+	stored, ok := f.nonces[nonce]
+	if !ok {
+		return nil, models.ErrNonceNotFound
+	}
+	copied := *stored
+	return &copied, nil
+}
+
+func (f *fakeNonceRepo) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	for key, stored := range f.nonces {
+		if stored.ExpiresAt.Before(before) {
+			delete(f.nonces, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+var nonceSigner = &models.User{Sub: "user-1", Email: "alice@example.com"}
+
+func TestSignatureNonceService_SingleUse(t *testing.T) {
+	service := NewSignatureNonceService(&fakeNonceRepo{nonces: map[string]*models.SignatureNonce{}}, 0, true)
+	ctx := context.Background()
+
+	nonce, err := service.Issue(ctx, "doc1", nonceSigner)
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	if nonce.Nonce == "" || nonce.ExpiresAt.Sub(time.Now()) > DefaultSignatureNonceTTL {
+		t.Fatalf("unexpected nonce %+v", nonce)
+	}
+
+	if err := service.Consume(ctx, nonce.Nonce, "doc1", nonceSigner); err != nil {
+		t.Fatalf("expected the nonce accepted, got %v", err)
+	}
+	if err := service.Consume(ctx, nonce.Nonce, "doc1", nonceSigner); !errors.Is(err, models.ErrNonceReplayed) {
+		t.Errorf("expected ErrNonceReplayed, got %v", err)
+	}
+	if err := service.Consume(ctx, "", "doc1", nonceSigner); !errors.Is(err, models.ErrNonceRequired) {
+		t.Errorf("expected ErrNonceRequired, got %v", err)
+	}
+
+	stats := service.Stats()
+	if stats.Issued != 1 || stats.Consumed != 1 || stats.Replayed != 1 || stats.Missing != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestSignatureNonceService_BoundToUserAndDocument(t *testing.T) {
+	service := NewSignatureNonceService(&fakeNonceRepo{nonces: map[string]*models.SignatureNonce{}}, time.Minute, false)
+	ctx := context.Background()
+
+	nonce, err := service.Issue(ctx, "doc1", nonceSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &models.User{Sub: "user-2", Email: "bob@example.com"}
+	if err := service.Consume(ctx, nonce.Nonce, "doc1", other); !errors.Is(err, models.ErrNonceInvalid) {
+		t.Errorf("expected a nonce of another user refused, got %v", err)
+	}
+	if err := service.Consume(ctx, nonce.Nonce, "doc2", nonceSigner); !errors.Is(err, models.ErrNonceInvalid) {
+		t.Errorf("expected a nonce of another document refused, got %v", err)
+	}
+	if err := service.Consume(ctx, "unknown", "doc1", nonceSigner); !errors.Is(err, models.ErrNonceInvalid) {
+		t.Errorf("expected an unknown nonce refused, got %v", err)
+	}
+	// Nonces are optional unless required
+	if err := service.Consume(ctx, "", "doc1", nonceSigner); err != nil {
+		t.Errorf("expected a request without nonce accepted, got %v", err)
+	}
+	if stats := service.Stats(); stats.Invalid != 3 || stats.Missing != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestSignatureNonceService_Expiry(t *testing.T) {
+	repo := &fakeNonceRepo{nonces: map[string]*models.SignatureNonce{}}
+	service := NewSignatureNonceService(repo, time.Minute, true)
+	ctx := context.Background()
+
+	nonce, err := service.Issue(ctx, "doc1", nonceSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	if err := service.Consume(ctx, nonce.Nonce, "doc1", nonceSigner); !errors.Is(err, models.ErrNonceExpired) {
+		t.Errorf("expected ErrNonceExpired, got %v", err)
+	}
+	if deleted, err := service.CleanupExpired(ctx); err != nil || deleted != 1 {
+		t.Errorf("expected the expired nonce deleted, got %d, %v", deleted, err)
+	}
+}
@@ -4,6 +4,8 @@ package services
 import (
 	"context"
 	"errors"
+	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -87,6 +89,58 @@ func (f *fakeRepository) GetByUserEmail(_ context.Context, userEmail string) ([]
 	return result, nil
 }
 
+func (f *fakeRepository) ListByDocKeyset(_ context.Context, docID string, cursor *models.PageCursor, limit int) ([]*models.Signature, error) {
+	if f.shouldFailGet {
+		return nil, errors.New("repository get failed")
+	}
+	return keysetSignatures(f.allSignatures, func(sig *models.Signature) bool { return sig.DocID == docID }, cursor, limit), nil
+}
+
+func (f *fakeRepository) ListByUserEmailKeyset(_ context.Context, userEmail string, cursor *models.PageCursor, limit int) ([]*models.Signature, error) {
+	if f.shouldFailGet {
+		return nil, errors.New("repository get failed")
+	}
+	return keysetSignatures(f.allSignatures, func(sig *models.Signature) bool { return sig.UserEmail == userEmail }, cursor, limit), nil
+}
+
+// keysetSignatures mimics the keyset queries of the repository: newest first, oldest first for backward cursors
+func keysetSignatures(all []*models.Signature, match func(*models.Signature) bool, cursor *models.PageCursor, limit int) []*models.Signature {
+	newer := func(a, b *models.Signature) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	}
+	var result []*models.Signature
+	for _, sig := range all {
+		if !match(sig) {
+			continue
+		}
+		if cursor != nil {
+			position := &models.Signature{ID: mustParseID(cursor.Key), CreatedAt: cursor.At}
+			if sig.ID == position.ID || cursor.Backward != newer(sig, position) {
+				continue
+			}
+		}
+		result = append(result, sig)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if cursor != nil && cursor.Backward {
+			return newer(result[j], result[i])
+		}
+		return newer(result[i], result[j])
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func mustParseID(key string) int64 {
+	id, _ := strconv.ParseInt(key, 10, 64)
+	return id
+}
+
 func (f *fakeRepository) ExistsByDocAndUser(_ context.Context, docID, userSub string) (bool, error) {
 	if f.shouldFailExists {
 		return false, errors.New("repository exists failed")
@@ -214,6 +268,10 @@ func (f *fakeDocumentRepository) Search(_ context.Context, _ string, _, _ int) (
 	return nil, nil
 }
 
+func (f *fakeDocumentRepository) ListKeyset(_ context.Context, _ string, _ *models.PageCursor, _ int) ([]*models.Document, error) {
+	return nil, nil
+}
+
 func (f *fakeDocumentRepository) Count(_ context.Context, _ string) (int, error) {
 	return 0, nil
 }
@@ -453,6 +511,147 @@ func TestSignatureService_CreateSignature(t *testing.T) {
 	}
 }
 
+type fakeSigningOrder struct {
+	pending map[string]int // key: email
+}
+
+func (f *fakeSigningOrder) CountPendingBefore(_ context.Context, _, email string) (int, error) {
+	return f.pending[email], nil
+}
+
+func TestSignatureService_CreateSignature_SigningOrder(t *testing.T) {
+	repo := newFakeRepository()
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	service.SetSigningOrder(&fakeSigningOrder{pending: map[string]int{"hr@example.com": 1}})
+	ctx := context.Background()
+
+	hr := &models.User{Sub: "hr", Email: "HR@example.com"}
+	err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: hr})
+	if !errors.Is(err, models.ErrNotSignerTurn) {
+		t.Fatalf("expected ErrNotSignerTurn, got %v", err)
+	}
+	if len(repo.allSignatures) != 0 {
+		t.Fatal("no signature should be recorded before the signer's turn")
+	}
+
+	manager := &models.User{Sub: "manager", Email: "manager@example.com"}
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: manager}); err != nil {
+		t.Fatalf("expected signature when it is the signer's turn, got %v", err)
+	}
+}
+
+func TestSignatureService_CreateSignature_RateLimited(t *testing.T) {
+	repo := newFakeRepository()
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	service.SetRateLimiter(NewRateLimitService(&fakeRateLimitRepo{}, map[string]RateLimitRule{
+		models.RateLimitActionSignature: {PerUser: 1},
+	}))
+	ctx := context.Background()
+	user := &models.User{Sub: "alice", Email: "alice@example.com"}
+
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: user, IPAddress: "10.0.0.1"}); err != nil {
+		t.Fatalf("expected first signature, got %v", err)
+	}
+	err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc2", User: user, IPAddress: "10.0.0.1"})
+	if !errors.Is(err, models.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if len(repo.allSignatures) != 1 {
+		t.Fatalf("expected rate-limited signature not to be recorded, got %d", len(repo.allSignatures))
+	}
+}
+
+func TestSignatureService_CreateSignature_Quiz(t *testing.T) {
+	repo := newFakeRepository()
+	quizRepo := newFakeQuizRepo()
+	input := sampleQuizInput()
+	quizRepo.quizzes["doc1"] = &models.DocumentQuiz{DocID: "doc1", Questions: input.Questions, PassThreshold: 100}
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	service.SetQuizGrader(NewQuizService(quizRepo, newFakeDocumentRepository()))
+	ctx := context.Background()
+	user := &models.User{Sub: "alice", Email: "alice@example.com"}
+
+	err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: user})
+	if !errors.Is(err, models.ErrQuizAnswersRequired) {
+		t.Fatalf("expected ErrQuizAnswersRequired, got %v", err)
+	}
+	err = service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: user, QuizAnswers: models.QuizAnswers{"q1": 1, "q2": 1}})
+	if !errors.Is(err, models.ErrQuizFailed) {
+		t.Fatalf("expected ErrQuizFailed, got %v", err)
+	}
+	if len(repo.allSignatures) != 0 {
+		t.Fatal("no signature should be recorded when the quiz is not passed")
+	}
+
+	answers := models.QuizAnswers{"q1": 1, "q2": 0}
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: user, QuizAnswers: answers}); err != nil {
+		t.Fatalf("expected signature after passing the quiz, got %v", err)
+	}
+	sig := repo.allSignatures[0]
+	if sig.QuizScore == nil || *sig.QuizScore != 100 || sig.QuizAnswers["q2"] != 0 {
+		t.Fatalf("expected quiz outcome recorded with the signature, got %v %v", sig.QuizScore, sig.QuizAnswers)
+	}
+
+	other := &models.User{Sub: "bob", Email: "bob@example.com"}
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc2", User: other}); err != nil {
+		t.Fatalf("documents without quiz should not require answers, got %v", err)
+	}
+	if repo.allSignatures[1].QuizScore != nil {
+		t.Fatal("no quiz score expected without quiz")
+	}
+}
+
+func TestSignatureService_CreateSignature_AuthMethod(t *testing.T) {
+	repo := newFakeRepository()
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	ctx := context.Background()
+
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: &models.User{Sub: "alice", Email: "alice@example.com"}}); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+	external := &models.User{Sub: "external:bob@partner.com", Email: "bob@partner.com"}
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: external, AuthMethod: models.AuthMethodEmailCode}); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+
+	if got := repo.allSignatures[0].AuthMethod; got != models.AuthMethodSession {
+		t.Errorf("default auth method = %q, want %q", got, models.AuthMethodSession)
+	}
+	if got := repo.allSignatures[1].AuthMethod; got != models.AuthMethodEmailCode {
+		t.Errorf("external auth method = %q, want %q", got, models.AuthMethodEmailCode)
+	}
+}
+
+type fakePrivacyConfig struct{ policy models.RefererPolicy }
+
+func (f *fakePrivacyConfig) GetConfig() *models.MutableConfig {
+	cfg := &models.MutableConfig{}
+	cfg.Security.RefererPolicy = f.policy
+	return cfg
+}
+
+func TestSignatureService_CreateSignature_RefererPolicy(t *testing.T) {
+	repo := newFakeRepository()
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	ctx := context.Background()
+	referer := "https://intranet.corp.local/hr/policies?id=42"
+
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: &models.User{Sub: "alice", Email: "alice@example.com"}, Referer: &referer}); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+	service.SetPrivacyConfig(&fakePrivacyConfig{policy: models.RefererPolicyOrigin})
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: &models.User{Sub: "bob", Email: "bob@example.com"}, Referer: &referer}); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+
+	if got := repo.allSignatures[0].Referer; got == nil || *got != referer {
+		t.Errorf("referer without policy = %v, want it kept", got)
+	}
+	if got := repo.allSignatures[1].Referer; got == nil || *got != "https://intranet.corp.local" {
+		t.Errorf("referer with origin policy = %v, want the origin", got)
+	}
+}
+
 func TestSignatureService_GetSignatureStatus(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -645,6 +844,85 @@ func TestSignatureService_GetUserSignatures(t *testing.T) {
 	})
 }
 
+func TestSignatureService_ListUserSignaturesPage(t *testing.T) {
+	repo := newFakeRepository()
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	user := &models.User{Sub: "user1", Email: "user1@example.com"}
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		repo.allSignatures = append(repo.allSignatures, &models.Signature{
+			ID:        int64(i),
+			DocID:     "doc" + strconv.Itoa(i),
+			UserEmail: user.Email,
+			// Signatures 4 and 5 share their timestamp, the ID breaks the tie
+			CreatedAt: base.Add(time.Duration(min(i, 4)) * time.Minute),
+		})
+	}
+	repo.allSignatures = append(repo.allSignatures, &models.Signature{ID: 6, DocID: "doc1", UserEmail: "user2@example.com", CreatedAt: base})
+
+	ids := func(page *models.KeysetPage[*models.Signature]) []int64 {
+		var result []int64
+		for _, sig := range page.Items {
+			result = append(result, sig.ID)
+		}
+		return result
+	}
+	next := func(raw string) *models.PageCursor {
+		t.Helper()
+		cursor, err := models.ParsePageCursor(raw)
+		if err != nil {
+			t.Fatalf("Invalid cursor %q: %v", raw, err)
+		}
+		return cursor
+	}
+
+	first, err := service.ListUserSignaturesPage(context.Background(), user, nil, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := ids(first); len(got) != 2 || got[0] != 5 || got[1] != 4 {
+		t.Fatalf("Expected signatures [5 4], got %v", got)
+	}
+	if first.NextCursor == "" || first.PrevCursor != "" {
+		t.Errorf("Expected a next cursor only on the first page, got next=%q prev=%q", first.NextCursor, first.PrevCursor)
+	}
+
+	second, err := service.ListUserSignaturesPage(context.Background(), user, next(first.NextCursor), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := ids(second); len(got) != 2 || got[0] != 3 || got[1] != 2 {
+		t.Fatalf("Expected signatures [3 2], got %v", got)
+	}
+
+	last, err := service.ListUserSignaturesPage(context.Background(), user, next(second.NextCursor), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := ids(last); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("Expected signatures [1], got %v", got)
+	}
+	if last.NextCursor != "" || last.PrevCursor == "" {
+		t.Errorf("Expected a previous cursor only on the last page, got next=%q prev=%q", last.NextCursor, last.PrevCursor)
+	}
+
+	back, err := service.ListUserSignaturesPage(context.Background(), user, next(last.PrevCursor), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := ids(back); len(got) != 2 || got[0] != 3 || got[1] != 2 {
+		t.Fatalf("Expected to go back to signatures [3 2], got %v", got)
+	}
+	if back.NextCursor == "" || back.PrevCursor == "" {
+		t.Errorf("Expected both cursors on a middle page, got next=%q prev=%q", back.NextCursor, back.PrevCursor)
+	}
+
+	if _, err := service.ListUserSignaturesPage(context.Background(), nil, nil, 2); !errors.Is(err, models.ErrInvalidUser) {
+		t.Errorf("Error = %v, expected %v", err, models.ErrInvalidUser)
+	}
+}
+
 func TestSignatureService_GetSignatureByDocAndUser(t *testing.T) {
 	repo := newFakeRepository()
 	signer := newFakeCryptoSigner()
@@ -1081,3 +1359,38 @@ func containsAt(s, substr string, start int) bool {
 	}
 	return true
 }
+
+func TestSignatureService_CreateSignature_Consent(t *testing.T) {
+	repo := newFakeRepository()
+	consentRepo := newFakeConsentRepo()
+	consent := newConsentServiceWithDoc(consentRepo, "doc1")
+	service := NewSignatureService(repo, newFakeDocumentRepository(), newFakeCryptoSigner())
+	service.SetConsentResolver(consent)
+	ctx := context.Background()
+
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: &models.User{Sub: "alice", Email: "alice@example.com"}, Locale: "de-DE"}); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+	if sig := repo.allSignatures[0]; sig.Locale != "de" || sig.ConsentVersion != nil || sig.ConsentText != "" {
+		t.Errorf("expected display language only without catalog, got %+v", sig)
+	}
+
+	_, _ = consent.PublishVersion(ctx, models.ConsentVersionInput{Texts: map[string]string{"en": "I have read this document", "fr": "J'ai lu ce document"}})
+	_, _ = consent.PublishVersion(ctx, models.ConsentVersionInput{Texts: map[string]string{"en": "I have read and understood this document", "fr": "J'ai lu et compris ce document"}})
+
+	displayed := 1
+	bob := &models.User{Sub: "bob", Email: "bob@example.com"}
+	err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: bob, Locale: "fr", ConsentVersion: &displayed})
+	if !errors.Is(err, models.ErrConsentOutdated) {
+		t.Fatalf("expected ErrConsentOutdated, got %v", err)
+	}
+
+	displayed = 2
+	if err := service.CreateSignature(ctx, &models.SignatureRequest{DocID: "doc1", User: bob, Locale: "fr", ConsentVersion: &displayed}); err != nil {
+		t.Fatalf("CreateSignature failed: %v", err)
+	}
+	sig := repo.allSignatures[1]
+	if sig.ConsentVersion == nil || *sig.ConsentVersion != 2 || sig.ConsentLocale != "fr" || sig.ConsentText != "J'ai lu et compris ce document" || sig.Locale != "fr" {
+		t.Errorf("expected the French consent of version 2, got %+v", sig)
+	}
+}
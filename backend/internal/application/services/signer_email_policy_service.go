@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// signerEmailPolicyConfig reads the signer email policy from the tenant general settings
+type signerEmailPolicyConfig interface {
+	GetConfig() *models.MutableConfig
+}
+
+// mxResolver looks up the mail servers of a domain
+type mxResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// SignerEmailPolicyService checks the emails added as expected signers against the allowed and
+// blocked domains of the tenant, and optionally that their domain receives mail
+type SignerEmailPolicyService struct {
+	config   signerEmailPolicyConfig
+	resolver mxResolver
+}
+
+// NewSignerEmailPolicyService creates a new signer email policy service
+func NewSignerEmailPolicyService(config signerEmailPolicyConfig) *SignerEmailPolicyService {
+	return &SignerEmailPolicyService{config: config, resolver: net.DefaultResolver}
+}
+
+// CheckEmails returns the emails refused by the policy, with their 1-based position in emails.
+// Each domain is looked up once. A domain whose lookup fails for another reason than a missing
+// domain or record is accepted, so that a DNS outage does not block imports.
+func (s *SignerEmailPolicyService) CheckEmails(ctx context.Context, emails []string) []models.SignerEmailRejection {
+	var policy models.SignerEmailPolicy
+	if cfg := s.config.GetConfig(); cfg != nil {
+		policy = cfg.General.SignerEmails
+	}
+
+	rejections := []models.SignerEmailRejection{}
+	hasMX := map[string]bool{}
+	for i, email := range emails {
+		domain, reason := policy.CheckDomain(email)
+		if reason == "" && policy.RequireMX {
+			found, checked := hasMX[domain]
+			if !checked {
+				found = s.hasMX(ctx, domain)
+				hasMX[domain] = found
+			}
+			if !found {
+				reason = models.SignerEmailNoMX
+			}
+		}
+		if reason != "" {
+			rejections = append(rejections, models.SignerEmailRejection{
+				Row:     i + 1,
+				Email:   email,
+				Reason:  reason,
+				Message: reason.Message(),
+			})
+		}
+	}
+	return rejections
+}
+
+// hasMX returns false only when the domain is known to have no mail server
+func (s *SignerEmailPolicyService) hasMX(ctx context.Context, domain string) bool {
+	records, err := s.resolver.LookupMX(ctx, domain)
+	if err == nil {
+		// A null MX (RFC 7505) declares that the domain accepts no mail
+		return len(records) > 0 && !(len(records) == 1 && records[0].Host == ".")
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	logger.Logger.Warn("Failed to look up MX records, email accepted", "domain", domain, "error", err.Error())
+	return true
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSignerEmailPolicyConfig struct {
+	policy models.SignerEmailPolicy
+}
+
+func (f *fakeSignerEmailPolicyConfig) GetConfig() *models.MutableConfig {
+	return &models.MutableConfig{General: models.GeneralConfig{SignerEmails: f.policy}}
+}
+
+type fakeMXResolver struct {
+	records map[string][]*net.MX
+	err     error
+	lookups int
+}
+
+func (f *fakeMXResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	f.lookups++
+	if f.err != nil {
+		return nil, f.err
+	}
+	records, ok := f.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return records, nil
+}
+
+func TestSignerEmailPolicyService_CheckEmails(t *testing.T) {
+	config := &fakeSignerEmailPolicyConfig{}
+	service := NewSignerEmailPolicyService(config)
+	resolver := &fakeMXResolver{records: map[string][]*net.MX{
+		"corp.com":    {{Host: "mx.corp.com.", Pref: 10}},
+		"nomail.corp": {{Host: ".", Pref: 0}},
+	}}
+	service.resolver = resolver
+	ctx := context.Background()
+
+	emails := []string{"alice@corp.com", "bob@gmial.com", "carol@mail.ru", "nope", "dave@corp.com"}
+	if rejections := service.CheckEmails(ctx, emails); len(rejections) != 1 || rejections[0].Reason != models.SignerEmailInvalid {
+		t.Fatalf("expected only the invalid email refused without policy, got %+v", rejections)
+	}
+	if resolver.lookups != 0 {
+		t.Errorf("expected no MX lookup without policy, got %d", resolver.lookups)
+	}
+
+	config.policy = models.SignerEmailPolicy{BlockedDomains: []string{"mail.ru"}, RequireMX: true}
+	rejections := service.CheckEmails(ctx, emails)
+	want := []struct {
+		row    int
+		reason models.SignerEmailReason
+	}{
+		{2, models.SignerEmailNoMX},
+		{3, models.SignerEmailDomainBlocked},
+		{4, models.SignerEmailInvalid},
+	}
+	if len(rejections) != len(want) {
+		t.Fatalf("expected %d rejections, got %+v", len(want), rejections)
+	}
+	for i, w := range want {
+		if rejections[i].Row != w.row || rejections[i].Reason != w.reason || rejections[i].Message == "" {
+			t.Errorf("rejection %d = %+v, want row %d %s", i, rejections[i], w.row, w.reason)
+		}
+	}
+	if resolver.lookups != 2 {
+		t.Errorf("expected each domain looked up once, got %d lookups", resolver.lookups)
+	}
+
+	if rejections := service.CheckEmails(ctx, []string{"x@nomail.corp"}); len(rejections) != 1 || rejections[0].Reason != models.SignerEmailNoMX {
+		t.Errorf("expected a null MX to be refused, got %+v", rejections)
+	}
+
+	resolver.err = errors.New("i/o timeout")
+	if rejections := service.CheckEmails(ctx, []string{"erin@unknown.org"}); len(rejections) != 0 {
+		t.Errorf("expected a failed lookup to accept the email, got %+v", rejections)
+	}
+}
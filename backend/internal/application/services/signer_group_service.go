@@ -0,0 +1,385 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// maxSignerGroupNameLength bounds the name of a signer group
+const maxSignerGroupNameLength = 200
+
+var (
+	// ErrInvalidSignerGroup is returned when signer group input fails validation
+	ErrInvalidSignerGroup = errors.New("invalid signer group")
+
+	// ErrDirectoryGroup is returned when members of a directory group are edited by hand
+	ErrDirectoryGroup = errors.New("members of a directory group are managed by its sync")
+
+	// ErrDirectorySync is returned when the directory cannot be read; the error is recorded on the group
+	ErrDirectorySync = errors.New("directory sync failed")
+)
+
+// signerGroupRepository defines signer group storage operations
+type signerGroupRepository interface {
+	Create(ctx context.Context, input models.SignerGroupInput) (*models.SignerGroup, error)
+	Update(ctx context.Context, id int64, input models.SignerGroupInput) (*models.SignerGroup, error)
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*models.SignerGroup, error)
+	List(ctx context.Context) ([]*models.SignerGroup, error)
+	ListSyncEnabled(ctx context.Context) ([]*models.SignerGroup, error)
+	MarkSynced(ctx context.Context, id int64, at time.Time, syncErr string) error
+	ListMembers(ctx context.Context, groupID int64) ([]models.SignerGroupMember, error)
+	UpsertMembers(ctx context.Context, groupID int64, members []models.SignerGroupMember) error
+	RemoveMembers(ctx context.Context, groupID int64, emails []string) error
+}
+
+// directoryConnector lists the members of a directory group
+type directoryConnector interface {
+	ListMembers(ctx context.Context, group string) ([]models.DirectoryEntry, error)
+}
+
+// signerGroupDocumentRepository checks the documents signer groups are added to
+type signerGroupDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// signerGroupSignerRepository adds the members of a group as expected signers
+type signerGroupSignerRepository interface {
+	AddExpected(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
+}
+
+// SignerGroupService manages reusable lists of signers and keeps directory groups in sync
+type SignerGroupService struct {
+	repo       signerGroupRepository
+	docRepo    signerGroupDocumentRepository
+	signerRepo signerGroupSignerRepository
+	connectors map[string]directoryConnector
+	now        func() time.Time
+}
+
+// NewSignerGroupService creates a signer group service without directory connectors
+func NewSignerGroupService(repo signerGroupRepository, docRepo signerGroupDocumentRepository, signerRepo signerGroupSignerRepository) *SignerGroupService {
+	return &SignerGroupService{
+		repo:       repo,
+		docRepo:    docRepo,
+		signerRepo: signerRepo,
+		connectors: make(map[string]directoryConnector),
+		now:        time.Now,
+	}
+}
+
+// SetConnector enables groups synced from the given directory provider
+func (s *SignerGroupService) SetConnector(provider string, connector directoryConnector) {
+	s.connectors[provider] = connector
+}
+
+// Providers returns the directory providers groups can be synced from
+func (s *SignerGroupService) Providers() []string {
+	providers := make([]string, 0, len(s.connectors))
+	for provider := range s.connectors {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// CreateGroup validates and stores a new signer group
+func (s *SignerGroupService) CreateGroup(ctx context.Context, input models.SignerGroupInput) (*models.SignerGroup, error) {
+	if err := s.validate(&input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Creating signer group", "name", input.Name, "provider", input.DirectoryProvider)
+	return s.repo.Create(ctx, input)
+}
+
+// UpdateGroup validates and updates a signer group. Turning a manual group into a directory
+// group keeps its members until the first sync.
+func (s *SignerGroupService) UpdateGroup(ctx context.Context, id int64, input models.SignerGroupInput) (*models.SignerGroup, error) {
+	if err := s.validate(&input); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Updating signer group", "id", id, "name", input.Name)
+	return s.repo.Update(ctx, id, input)
+}
+
+// DeleteGroup deletes a signer group. Documents keep the signers already added from it.
+func (s *SignerGroupService) DeleteGroup(ctx context.Context, id int64) error {
+	logger.Logger.Info("Deleting signer group", "id", id)
+	return s.repo.Delete(ctx, id)
+}
+
+// GetGroup retrieves a signer group by ID
+func (s *SignerGroupService) GetGroup(ctx context.Context, id int64) (*models.SignerGroup, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListGroups retrieves all signer groups
+func (s *SignerGroupService) ListGroups(ctx context.Context) ([]*models.SignerGroup, error) {
+	return s.repo.List(ctx)
+}
+
+// ListMembers retrieves the members of a signer group
+func (s *SignerGroupService) ListMembers(ctx context.Context, id int64) ([]models.SignerGroupMember, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListMembers(ctx, id)
+}
+
+// AddMembers adds members to a group maintained by hand
+func (s *SignerGroupService) AddMembers(ctx context.Context, id int64, members []models.SignerGroupMember) error {
+	group, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if group.IsDirectory() {
+		return ErrDirectoryGroup
+	}
+
+	for i := range members {
+		members[i].Email = strings.ToLower(strings.TrimSpace(members[i].Email))
+		members[i].Name = strings.TrimSpace(members[i].Name)
+		if !isValidEmail(members[i].Email) {
+			return fmt.Errorf("%w: invalid email %q", ErrInvalidSignerGroup, members[i].Email)
+		}
+	}
+	return s.repo.UpsertMembers(ctx, id, members)
+}
+
+// RemoveMember removes a member from a group maintained by hand
+func (s *SignerGroupService) RemoveMember(ctx context.Context, id int64, email string) error {
+	group, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if group.IsDirectory() {
+		return ErrDirectoryGroup
+	}
+	return s.repo.RemoveMembers(ctx, id, []string{strings.ToLower(strings.TrimSpace(email))})
+}
+
+// SyncGroup compares a directory group with its members and, unless dryRun is set,
+// applies the difference. The outcome is recorded on the group.
+func (s *SignerGroupService) SyncGroup(ctx context.Context, id int64, dryRun bool) (*models.DirectorySyncResult, error) {
+	group, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !group.IsDirectory() {
+		return nil, fmt.Errorf("%w: group is not synced from a directory", ErrInvalidSignerGroup)
+	}
+	connector, ok := s.connectors[group.DirectoryProvider]
+	if !ok {
+		return nil, fmt.Errorf("%w: directory provider %s is not configured", ErrInvalidSignerGroup, group.DirectoryProvider)
+	}
+
+	result, err := s.diff(ctx, group, connector)
+	if err != nil {
+		if !dryRun {
+			if markErr := s.repo.MarkSynced(ctx, group.ID, s.now(), err.Error()); markErr != nil {
+				logger.Logger.Error("Failed to record signer group sync error", "group_id", group.ID, "error", markErr.Error())
+			}
+		}
+		return nil, err
+	}
+	result.DryRun = dryRun
+	if dryRun {
+		return result, nil
+	}
+
+	upserts := append(append([]models.SignerGroupMember{}, result.Added...), result.Updated...)
+	if err := s.repo.UpsertMembers(ctx, group.ID, upserts); err != nil {
+		return nil, err
+	}
+	removed := make([]string, 0, len(result.Removed))
+	for _, m := range result.Removed {
+		removed = append(removed, m.Email)
+	}
+	if err := s.repo.RemoveMembers(ctx, group.ID, removed); err != nil {
+		return nil, err
+	}
+	if err := s.repo.MarkSynced(ctx, group.ID, s.now(), ""); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Signer group synced", "group_id", group.ID, "added", len(result.Added),
+		"updated", len(result.Updated), "removed", len(result.Removed), "skipped", result.Skipped)
+	return result, nil
+}
+
+// ListScheduledGroups returns the directory groups synced on schedule, least recently synced first
+func (s *SignerGroupService) ListScheduledGroups(ctx context.Context) ([]*models.SignerGroup, error) {
+	return s.repo.ListSyncEnabled(ctx)
+}
+
+// AddToDocument adds the current members of a group as expected signers of a document and
+// returns how many members the group has. Signers already expected are left unchanged.
+func (s *SignerGroupService) AddToDocument(ctx context.Context, docID string, id int64, addedBy string) (int, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return 0, err
+	}
+	if doc == nil {
+		return 0, models.ErrDocumentNotFound
+	}
+
+	members, err := s.ListMembers(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	contacts := make([]models.ContactInfo, 0, len(members))
+	for _, m := range members {
+		contacts = append(contacts, models.ContactInfo{Email: m.Email, Name: m.Name})
+	}
+	if err := s.signerRepo.AddExpected(ctx, docID, contacts, addedBy); err != nil {
+		return 0, err
+	}
+
+	logger.Logger.Info("Signer group added to document", "group_id", id, "doc_id", docID, "members", len(contacts))
+	return len(contacts), nil
+}
+
+// diff compares the directory members, once mapped, with the current members of the group
+func (s *SignerGroupService) diff(ctx context.Context, group *models.SignerGroup, connector directoryConnector) (*models.DirectorySyncResult, error) {
+	entries, err := connector.ListMembers(ctx, group.DirectoryGroup)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDirectorySync, err)
+	}
+	wanted, skipped := mapDirectoryEntries(entries, group.Mapping.WithDefaults(group.DirectoryProvider))
+
+	current, err := s.repo.ListMembers(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	currentByEmail := make(map[string]models.SignerGroupMember, len(current))
+	for _, m := range current {
+		currentByEmail[m.Email] = m
+	}
+
+	result := &models.DirectorySyncResult{
+		GroupID: group.ID,
+		Added:   []models.SignerGroupMember{},
+		Updated: []models.SignerGroupMember{},
+		Removed: []models.SignerGroupMember{},
+		Skipped: skipped,
+	}
+	for _, m := range wanted {
+		existing, ok := currentByEmail[m.Email]
+		switch {
+		case !ok:
+			result.Added = append(result.Added, m)
+		case existing.Name != m.Name:
+			result.Updated = append(result.Updated, m)
+		default:
+			result.Unchanged++
+		}
+		delete(currentByEmail, m.Email)
+	}
+	for _, m := range current {
+		if _, ok := currentByEmail[m.Email]; ok {
+			result.Removed = append(result.Removed, m)
+		}
+	}
+	return result, nil
+}
+
+// mapDirectoryEntries turns directory entries into members, skipping inactive accounts, entries
+// without a valid email and emails outside the allowed domains. Duplicates are merged.
+func mapDirectoryEntries(entries []models.DirectoryEntry, mapping models.DirectoryMapping) ([]models.SignerGroupMember, int) {
+	members := make([]models.SignerGroupMember, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	skipped := 0
+
+	for _, entry := range entries {
+		if !entry.Active && !mapping.IncludeInactive {
+			skipped++
+			continue
+		}
+
+		email := strings.ToLower(strings.TrimSpace(entry.Attributes[mapping.EmailAttribute]))
+		if email != "" && !strings.Contains(email, "@") && mapping.EmailDomain != "" {
+			email += "@" + strings.ToLower(mapping.EmailDomain)
+		}
+		if !isValidEmail(email) {
+			skipped++
+			continue
+		}
+		if len(mapping.AllowedDomains) > 0 {
+			_, domain, _ := strings.Cut(email, "@")
+			if !matchesDomain(domain, mapping.AllowedDomains) {
+				skipped++
+				continue
+			}
+		}
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		var name string
+		if mapping.NameAttribute != "" {
+			name = strings.TrimSpace(entry.Attributes[mapping.NameAttribute])
+		}
+		members = append(members, models.SignerGroupMember{Email: email, Name: name})
+	}
+	return members, skipped
+}
+
+func (s *SignerGroupService) validate(input *models.SignerGroupInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	input.Description = strings.TrimSpace(input.Description)
+	input.DirectoryGroup = strings.TrimSpace(input.DirectoryGroup)
+
+	if input.Name == "" || len(input.Name) > maxSignerGroupNameLength {
+		return fmt.Errorf("%w: name is required (max %d characters)", ErrInvalidSignerGroup, maxSignerGroupNameLength)
+	}
+
+	if input.DirectoryProvider == "" {
+		if input.DirectoryGroup != "" {
+			return fmt.Errorf("%w: directoryGroup requires a directoryProvider", ErrInvalidSignerGroup)
+		}
+		input.Mapping = models.DirectoryMapping{}
+		input.SyncEnabled = false
+		return nil
+	}
+
+	if !models.IsValidDirectoryProvider(input.DirectoryProvider) {
+		return fmt.Errorf("%w: unknown directory provider %s", ErrInvalidSignerGroup, input.DirectoryProvider)
+	}
+	if _, ok := s.connectors[input.DirectoryProvider]; !ok {
+		return fmt.Errorf("%w: directory provider %s is not configured", ErrInvalidSignerGroup, input.DirectoryProvider)
+	}
+	if input.DirectoryGroup == "" {
+		return fmt.Errorf("%w: directoryGroup is required", ErrInvalidSignerGroup)
+	}
+
+	mapping := &input.Mapping
+	mapping.EmailAttribute = strings.TrimSpace(mapping.EmailAttribute)
+	mapping.NameAttribute = strings.TrimSpace(mapping.NameAttribute)
+	mapping.EmailDomain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(mapping.EmailDomain), "@"))
+	if mapping.EmailDomain != "" && !isValidEmail("user@"+mapping.EmailDomain) {
+		return fmt.Errorf("%w: invalid emailDomain %q", ErrInvalidSignerGroup, mapping.EmailDomain)
+	}
+	domains := make([]string, 0, len(mapping.AllowedDomains))
+	for _, d := range mapping.AllowedDomains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" {
+			continue
+		}
+		if !isValidEmail("user@" + d) {
+			return fmt.Errorf("%w: invalid allowed domain %q", ErrInvalidSignerGroup, d)
+		}
+		domains = append(domains, d)
+	}
+	mapping.AllowedDomains = domains
+	return nil
+}
@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSignerGroupRepo struct {
+	groups  map[int64]*models.SignerGroup
+	members map[int64]map[string]models.SignerGroupMember
+	synced  map[int64]string
+}
+
+func newFakeSignerGroupRepo() *fakeSignerGroupRepo {
+	return &fakeSignerGroupRepo{
+		groups:  make(map[int64]*models.SignerGroup),
+		members: make(map[int64]map[string]models.SignerGroupMember),
+		synced:  make(map[int64]string),
+	}
+}
+
+func (f *fakeSignerGroupRepo) Create(_ context.Context, input models.SignerGroupInput) (*models.SignerGroup, error) {
+	g := &models.SignerGroup{
+		ID:                int64(len(f.groups) + 1),
+		Name:              input.Name,
+		DirectoryProvider: input.DirectoryProvider,
+		DirectoryGroup:    input.DirectoryGroup,
+		Mapping:           input.Mapping,
+		SyncEnabled:       input.SyncEnabled,
+	}
+	f.groups[g.ID] = g
+	f.members[g.ID] = make(map[string]models.SignerGroupMember)
+	return g, nil
+}
+
+func (f *fakeSignerGroupRepo) Update(_ context.Context, id int64, input models.SignerGroupInput) (*models.SignerGroup, error) {
+	g, ok := f.groups[id]
+	if !ok {
+		return nil, models.ErrSignerGroupNotFound
+	}
+	g.Name = input.Name
+	return g, nil
+}
+
+func (f *fakeSignerGroupRepo) Delete(_ context.Context, id int64) error {
+	delete(f.groups, id)
+	return nil
+}
+
+func (f *fakeSignerGroupRepo) GetByID(_ context.Context, id int64) (*models.SignerGroup, error) {
+	g, ok := f.groups[id]
+	if !ok {
+		return nil, models.ErrSignerGroupNotFound
+	}
+	return g, nil
+}
+
+func (f *fakeSignerGroupRepo) List(_ context.Context) ([]*models.SignerGroup, error) {
+	var out []*models.SignerGroup
+	for _, g := range f.groups {
+		out = append(out, g)
+	}
+	return out, nil
+}
+
+func (f *fakeSignerGroupRepo) ListSyncEnabled(_ context.Context) ([]*models.SignerGroup, error) {
+	var out []*models.SignerGroup
+	for _, g := range f.groups {
+		if g.SyncEnabled {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSignerGroupRepo) MarkSynced(_ context.Context, id int64, _ time.Time, syncErr string) error {
+	f.synced[id] = syncErr
+	return nil
+}
+
+func (f *fakeSignerGroupRepo) ListMembers(_ context.Context, groupID int64) ([]models.SignerGroupMember, error) {
+	out := make([]models.SignerGroupMember, 0, len(f.members[groupID]))
+	for _, m := range f.members[groupID] {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Email < out[j].Email })
+	return out, nil
+}
+
+func (f *fakeSignerGroupRepo) UpsertMembers(_ context.Context, groupID int64, members []models.SignerGroupMember) error {
+	for _, m := range members {
+		f.members[groupID][m.Email] = m
+	}
+	return nil
+}
+
+func (f *fakeSignerGroupRepo) RemoveMembers(_ context.Context, groupID int64, emails []string) error {
+	for _, email := range emails {
+		delete(f.members[groupID], email)
+	}
+	return nil
+}
+
+type fakeDirectoryConnector struct {
+	entries []models.DirectoryEntry
+	err     error
+}
+
+func (f *fakeDirectoryConnector) ListMembers(_ context.Context, _ string) ([]models.DirectoryEntry, error) {
+	return f.entries, f.err
+}
+
+type fakeGroupSignerRepo struct {
+	added []models.ContactInfo
+}
+
+func (f *fakeGroupSignerRepo) AddExpected(_ context.Context, _ string, contacts []models.ContactInfo, _ string) error {
+	f.added = append(f.added, contacts...)
+	return nil
+}
+
+func directoryUser(email, name string, active bool) models.DirectoryEntry {
+	return models.DirectoryEntry{Attributes: map[string]string{"mail": email, "displayName": name}, Active: active}
+}
+
+func TestSignerGroupService_CreateGroupValidation(t *testing.T) {
+	service := NewSignerGroupService(newFakeSignerGroupRepo(), &fakeCompletionDocRepo{}, &fakeGroupSignerRepo{})
+	service.SetConnector(models.DirectoryProviderMicrosoft, &fakeDirectoryConnector{})
+
+	tests := []struct {
+		name    string
+		input   models.SignerGroupInput
+		wantErr bool
+	}{
+		{"manual group", models.SignerGroupInput{Name: "Board"}, false},
+		{"missing name", models.SignerGroupInput{Name: "  "}, true},
+		{"directory group", models.SignerGroupInput{Name: "Staff", DirectoryProvider: "microsoft", DirectoryGroup: "group-id"}, false},
+		{"unconfigured provider", models.SignerGroupInput{Name: "Staff", DirectoryProvider: "google", DirectoryGroup: "staff@example.com"}, true},
+		{"unknown provider", models.SignerGroupInput{Name: "Staff", DirectoryProvider: "ldap", DirectoryGroup: "cn=staff"}, true},
+		{"missing directory group", models.SignerGroupInput{Name: "Staff", DirectoryProvider: "microsoft"}, true},
+		{"invalid allowed domain", models.SignerGroupInput{Name: "Staff", DirectoryProvider: "microsoft", DirectoryGroup: "group-id",
+			Mapping: models.DirectoryMapping{AllowedDomains: []string{"not a domain"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateGroup(context.Background(), tt.input)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("wantErr=%v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSignerGroup) {
+				t.Errorf("expected ErrInvalidSignerGroup, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSignerGroupService_SyncGroup(t *testing.T) {
+	repo := newFakeSignerGroupRepo()
+	connector := &fakeDirectoryConnector{entries: []models.DirectoryEntry{
+		directoryUser("Alice@Example.com", "Alice Martin", true),
+		directoryUser("bob@example.com", "Bob Durand", true),
+		directoryUser("carol@example.com", "Carol", false),
+		directoryUser("guest@partner.com", "Guest", true),
+		directoryUser("", "No Mail", true),
+	}}
+	service := NewSignerGroupService(repo, &fakeCompletionDocRepo{}, &fakeGroupSignerRepo{})
+	service.SetConnector(models.DirectoryProviderMicrosoft, connector)
+
+	group, err := service.CreateGroup(context.Background(), models.SignerGroupInput{
+		Name: "Staff", DirectoryProvider: "microsoft", DirectoryGroup: "group-id",
+		Mapping: models.DirectoryMapping{AllowedDomains: []string{"example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	_ = repo.UpsertMembers(context.Background(), group.ID, []models.SignerGroupMember{
+		{Email: "bob@example.com", Name: "Bob"},
+		{Email: "leaver@example.com", Name: "Leaver"},
+	})
+
+	preview, err := service.SyncGroup(context.Background(), group.ID, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !preview.DryRun || len(preview.Added) != 1 || len(preview.Updated) != 1 || len(preview.Removed) != 1 || preview.Skipped != 3 {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	if preview.Added[0].Email != "alice@example.com" || preview.Added[0].Name != "Alice Martin" {
+		t.Errorf("unexpected added member %+v", preview.Added[0])
+	}
+	if len(repo.members[group.ID]) != 2 {
+		t.Fatal("dry run must not change the members")
+	}
+
+	if _, err := service.SyncGroup(context.Background(), group.ID, false); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	members, _ := repo.ListMembers(context.Background(), group.ID)
+	if len(members) != 2 || members[0].Email != "alice@example.com" || members[1].Name != "Bob Durand" {
+		t.Errorf("unexpected members after sync %+v", members)
+	}
+	if syncErr, ok := repo.synced[group.ID]; !ok || syncErr != "" {
+		t.Errorf("expected a successful sync to be recorded, got %q", syncErr)
+	}
+
+	connector.err = errors.New("forbidden")
+	if _, err := service.SyncGroup(context.Background(), group.ID, false); !errors.Is(err, ErrDirectorySync) {
+		t.Fatalf("expected ErrDirectorySync, got %v", err)
+	}
+	if repo.synced[group.ID] == "" {
+		t.Error("expected the sync error to be recorded")
+	}
+}
+
+func TestSignerGroupService_EmailDomainMapping(t *testing.T) {
+	entries := []models.DirectoryEntry{
+		{Attributes: map[string]string{"username": "alice", "name": "Alice"}, Active: true},
+		{Attributes: map[string]string{"username": "alice", "name": "Alice again"}, Active: true},
+		{Attributes: map[string]string{"username": "blocked", "name": "Blocked"}, Active: false},
+	}
+	mapping := models.DirectoryMapping{EmailAttribute: "username", EmailDomain: "example.com", IncludeInactive: true}.
+		WithDefaults(models.DirectoryProviderGitLab)
+
+	members, skipped := mapDirectoryEntries(entries, mapping)
+	if len(members) != 2 || skipped != 0 {
+		t.Fatalf("expected 2 members and no skipped entry, got %+v, %d", members, skipped)
+	}
+	if members[0].Email != "alice@example.com" || members[0].Name != "Alice" {
+		t.Errorf("unexpected member %+v", members[0])
+	}
+}
+
+func TestSignerGroupService_ManualMembersAndDocument(t *testing.T) {
+	repo := newFakeSignerGroupRepo()
+	signers := &fakeGroupSignerRepo{}
+	docRepo := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc-1": {DocID: "doc-1"}}}
+	service := NewSignerGroupService(repo, docRepo, signers)
+	service.SetConnector(models.DirectoryProviderGitLab, &fakeDirectoryConnector{})
+	ctx := context.Background()
+
+	board, _ := service.CreateGroup(ctx, models.SignerGroupInput{Name: "Board"})
+	if err := service.AddMembers(ctx, board.ID, []models.SignerGroupMember{{Email: " Ann@Example.com ", Name: "Ann"}}); err != nil {
+		t.Fatalf("AddMembers failed: %v", err)
+	}
+	if err := service.AddMembers(ctx, board.ID, []models.SignerGroupMember{{Email: "not-an-email"}}); !errors.Is(err, ErrInvalidSignerGroup) {
+		t.Errorf("expected ErrInvalidSignerGroup, got %v", err)
+	}
+
+	staff, _ := service.CreateGroup(ctx, models.SignerGroupInput{Name: "Staff", DirectoryProvider: "gitlab", DirectoryGroup: "acme"})
+	if err := service.AddMembers(ctx, staff.ID, []models.SignerGroupMember{{Email: "x@example.com"}}); !errors.Is(err, ErrDirectoryGroup) {
+		t.Errorf("expected ErrDirectoryGroup, got %v", err)
+	}
+
+	count, err := service.AddToDocument(ctx, "doc-1", board.ID, "admin@example.com")
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 signer added, got %d, %v", count, err)
+	}
+	if len(signers.added) != 1 || signers.added[0].Email != "ann@example.com" {
+		t.Errorf("unexpected expected signers %+v", signers.added)
+	}
+	if _, err := service.AddToDocument(ctx, "unknown", board.ID, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// signerTimelineRepository reads the recorded events of a signer on a document
+type signerTimelineRepository interface {
+	ListEvents(ctx context.Context, docID, email string) ([]models.SignerTimelineEvent, error)
+}
+
+// SignerTimelineService gathers everything recorded about one signer on one document,
+// so that support can follow what happened when a signer says they never got the request
+type SignerTimelineService struct {
+	repo    signerTimelineRepository
+	docRepo completionDocumentRepository
+}
+
+func NewSignerTimelineService(repo signerTimelineRepository, docRepo completionDocumentRepository) *SignerTimelineService {
+	return &SignerTimelineService{repo: repo, docRepo: docRepo}
+}
+
+// GetTimeline returns the events of a signer on a document, oldest first
+func (s *SignerTimelineService) GetTimeline(ctx context.Context, docID, email string) (*models.SignerTimeline, error) {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, models.ErrDocumentNotFound
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	events, err := s.repo.ListEvents(ctx, docID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &models.SignerTimeline{DocID: docID, Email: email, Events: events}
+	for _, e := range events {
+		switch e.Type {
+		case models.SignerEventAdded:
+			timeline.Expected = true
+		case models.SignerEventSigned:
+			timeline.Signed = true
+		}
+	}
+	return timeline, nil
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSignerTimelineRepo struct {
+	email  string
+	events []models.SignerTimelineEvent
+}
+
+func (f *fakeSignerTimelineRepo) ListEvents(_ context.Context, _, email string) ([]models.SignerTimelineEvent, error) {
+	f.email = email
+	return f.events, nil
+}
+
+func TestSignerTimelineService_GetTimeline(t *testing.T) {
+	at := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	repo := &fakeSignerTimelineRepo{events: []models.SignerTimelineEvent{
+		{Type: models.SignerEventAdded, At: at, Actor: "admin@example.com"},
+		{Type: models.SignerEventReminderSent, At: at.Add(time.Hour), Actor: "admin@example.com"},
+		{Type: models.SignerEventSigned, At: at.Add(2 * time.Hour)},
+	}}
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc1": {DocID: "doc1"}}}
+	service := NewSignerTimelineService(repo, docs)
+
+	timeline, err := service.GetTimeline(context.Background(), "doc1", " Alice@Example.com ")
+	if err != nil {
+		t.Fatalf("GetTimeline err: %v", err)
+	}
+	if repo.email != "alice@example.com" || timeline.Email != "alice@example.com" {
+		t.Errorf("expected a normalized email, got %q", repo.email)
+	}
+	if !timeline.Expected || !timeline.Signed || len(timeline.Events) != 3 {
+		t.Errorf("unexpected timeline %+v", timeline)
+	}
+
+	if _, err := service.GetTimeline(context.Background(), "missing", "alice@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestSignerTimelineService_GetTimeline_UnknownSigner(t *testing.T) {
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc1": {DocID: "doc1"}}}
+	service := NewSignerTimelineService(&fakeSignerTimelineRepo{events: []models.SignerTimelineEvent{}}, docs)
+
+	timeline, err := service.GetTimeline(context.Background(), "doc1", "nobody@example.com")
+	if err != nil || timeline.Expected || timeline.Signed || len(timeline.Events) != 0 {
+		t.Errorf("expected an empty timeline, got %+v, %v", timeline, err)
+	}
+}
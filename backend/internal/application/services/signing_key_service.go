@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// minKeyBackupPassphraseLength is the minimum length of the passphrase encrypting a key backup
+const minKeyBackupPassphraseLength = 12
+
+var ErrKeyBackupPassphraseTooShort = errors.New("backup passphrase must be at least 12 characters")
+
+// signingKey is the Ed25519 signer key checked and backed up
+type signingKey interface {
+	GetPublicKey() string
+	KeySource() string
+	KeyFile() string
+	SelfTest() error
+	ExportBackup(passphrase string) (*crypto.KeyBackup, error)
+}
+
+// SigningKeyService checks that the signer key is usable and safely stored, at startup and
+// periodically, and exports encrypted backups of it
+type SigningKeyService struct {
+	key signingKey
+	now func() time.Time
+
+	mu     sync.Mutex
+	status *models.SigningKeyStatus
+}
+
+func NewSigningKeyService(key signingKey) *SigningKeyService {
+	return &SigningKeyService{key: key, now: time.Now}
+}
+
+// Check runs the key self-test and the key file permission check, logs the problems found
+// and keeps the result as the current status
+func (s *SigningKeyService) Check() *models.SigningKeyStatus {
+	status := &models.SigningKeyStatus{
+		Healthy:   true,
+		PublicKey: s.key.GetPublicKey(),
+		Source:    s.key.KeySource(),
+		KeyFile:   s.key.KeyFile(),
+		Errors:    []string{},
+		Warnings:  []string{},
+		CheckedAt: s.now().UTC(),
+	}
+
+	if err := s.key.SelfTest(); err != nil {
+		status.Healthy = false
+		status.Errors = append(status.Errors, err.Error())
+		logger.Logger.Error("Signing key self-test failed, signatures cannot be verified", "error", err.Error())
+	}
+	if status.KeyFile != "" {
+		if err := crypto.CheckKeyFilePermissions(status.KeyFile); err != nil {
+			status.Warnings = append(status.Warnings, err.Error())
+			logger.Logger.Warn("Signing key file is not protected", "error", err.Error())
+		}
+	}
+	if status.Source == crypto.KeySourceEphemeral {
+		status.Warnings = append(status.Warnings, "the signing key is ephemeral: signatures will not verify after a restart")
+	}
+
+	s.mu.Lock()
+	s.status = status
+	s.mu.Unlock()
+	return status
+}
+
+// Status returns the result of the last check, running one if the key was never checked
+func (s *SigningKeyService) Status() *models.SigningKeyStatus {
+	s.mu.Lock()
+	status := s.status
+	s.mu.Unlock()
+	if status == nil {
+		return s.Check()
+	}
+	return status
+}
+
+// ExportBackup returns the signer key encrypted with a key derived from passphrase
+func (s *SigningKeyService) ExportBackup(passphrase string) (*crypto.KeyBackup, error) {
+	if len(passphrase) < minKeyBackupPassphraseLength {
+		return nil, ErrKeyBackupPassphraseTooShort
+	}
+	return s.key.ExportBackup(passphrase)
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+)
+
+type fakeSigningKey struct {
+	source      string
+	keyFile     string
+	selfTestErr error
+	passphrase  string
+}
+
+func (f *fakeSigningKey) GetPublicKey() string { return "public-key" }
+func (f *fakeSigningKey) KeySource() string    { return f.source }
+func (f *fakeSigningKey) KeyFile() string      { return f.keyFile }
+func (f *fakeSigningKey) SelfTest() error      { return f.selfTestErr }
+
+func (f *fakeSigningKey) ExportBackup(passphrase string) (*crypto.KeyBackup, error) {
+	f.passphrase = passphrase
+	return &crypto.KeyBackup{PublicKey: "public-key"}, nil
+}
+
+func TestSigningKeyService_Check(t *testing.T) {
+	t.Run("healthy key", func(t *testing.T) {
+		service := NewSigningKeyService(&fakeSigningKey{source: crypto.KeySourceEnv})
+
+		status := service.Check()
+		if !status.Healthy || len(status.Errors) != 0 || len(status.Warnings) != 0 {
+			t.Errorf("expected a healthy key without warnings, got %+v", status)
+		}
+		if status.PublicKey != "public-key" || status.CheckedAt.IsZero() {
+			t.Errorf("unexpected status: %+v", status)
+		}
+	})
+
+	t.Run("self-test failure", func(t *testing.T) {
+		service := NewSigningKeyService(&fakeSigningKey{source: crypto.KeySourceEnv, selfTestErr: crypto.ErrKeyMismatch})
+
+		status := service.Check()
+		if status.Healthy || len(status.Errors) != 1 || status.Errors[0] != crypto.ErrKeyMismatch.Error() {
+			t.Errorf("expected the self-test error, got %+v", status)
+		}
+	})
+
+	t.Run("key file readable by others", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ed25519.key")
+		if err := os.WriteFile(path, []byte("key"), 0o644); err != nil {
+			t.Fatalf("write err: %v", err)
+		}
+		service := NewSigningKeyService(&fakeSigningKey{source: crypto.KeySourceFile, keyFile: path})
+
+		status := service.Check()
+		if !status.Healthy || len(status.Warnings) != 1 {
+			t.Errorf("expected a permission warning, got %+v", status)
+		}
+
+		if err := os.Chmod(path, 0o600); err != nil {
+			t.Fatalf("chmod err: %v", err)
+		}
+		if status := service.Check(); len(status.Warnings) != 0 {
+			t.Errorf("expected no warning once the file is protected, got %+v", status.Warnings)
+		}
+	})
+
+	t.Run("ephemeral key", func(t *testing.T) {
+		service := NewSigningKeyService(&fakeSigningKey{source: crypto.KeySourceEphemeral})
+
+		if status := service.Check(); len(status.Warnings) != 1 {
+			t.Errorf("expected an ephemeral key warning, got %+v", status.Warnings)
+		}
+	})
+}
+
+func TestSigningKeyService_Status(t *testing.T) {
+	key := &fakeSigningKey{source: crypto.KeySourceEnv}
+	service := NewSigningKeyService(key)
+
+	first := service.Status()
+	if first == nil || !first.Healthy {
+		t.Fatalf("expected the first status to run a check, got %+v", first)
+	}
+
+	key.selfTestErr = crypto.ErrKeySelfTest
+	if status := service.Status(); status != first {
+		t.Error("expected the last check to be returned until the next one")
+	}
+	if status := service.Check(); status.Healthy {
+		t.Error("expected the next check to report the failure")
+	}
+}
+
+func TestSigningKeyService_ExportBackup(t *testing.T) {
+	key := &fakeSigningKey{source: crypto.KeySourceEnv}
+	service := NewSigningKeyService(key)
+
+	if _, err := service.ExportBackup("short"); !errors.Is(err, ErrKeyBackupPassphraseTooShort) {
+		t.Errorf("expected ErrKeyBackupPassphraseTooShort, got %v", err)
+	}
+
+	backup, err := service.ExportBackup("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("export err: %v", err)
+	}
+	if backup.PublicKey != "public-key" || key.passphrase != "correct horse battery staple" {
+		t.Errorf("unexpected backup %+v with passphrase %q", backup, key.passphrase)
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// signingOrderSignerRepository lists expected signers with their signing status
+type signingOrderSignerRepository interface {
+	ListWithStatusByDocID(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
+	MatchExpectedEmail(ctx context.Context, docID, email string) (string, error)
+}
+
+// signingOrderDocRepository reads the document signers are asked to confirm
+type signingOrderDocRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// signingTurnNotifier asks signers to confirm a document
+type signingTurnNotifier interface {
+	SendReminders(ctx context.Context, docID, sentBy string, specificEmails []string, docURL, locale string) (*models.ReminderSendResult, error)
+}
+
+// SigningOrderService moves sequential workflows forward by notifying the next signers
+type SigningOrderService struct {
+	signerRepo signingOrderSignerRepository
+	docRepo    signingOrderDocRepository
+	notifier   signingTurnNotifier
+}
+
+// NewSigningOrderService creates a new signing order service
+func NewSigningOrderService(signerRepo signingOrderSignerRepository, docRepo signingOrderDocRepository, notifier signingTurnNotifier) *SigningOrderService {
+	return &SigningOrderService{
+		signerRepo: signerRepo,
+		docRepo:    docRepo,
+		notifier:   notifier,
+	}
+}
+
+// OnSignature notifies the signers of the next position once every signer sharing
+// the position of signerEmail has signed
+func (s *SigningOrderService) OnSignature(ctx context.Context, docID, signerEmail, locale string) error {
+	// The signer may have signed under an alias of the expected address
+	expectedEmail, err := s.signerRepo.MatchExpectedEmail(ctx, docID, signerEmail)
+	if err != nil {
+		return fmt.Errorf("failed to match expected signer: %w", err)
+	}
+	if expectedEmail == "" {
+		return nil
+	}
+
+	signers, err := s.signerRepo.ListWithStatusByDocID(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("failed to list expected signers: %w", err)
+	}
+
+	var signedOrder *int
+	for _, signer := range signers {
+		if strings.EqualFold(signer.Email, expectedEmail) {
+			signedOrder = signer.SignOrder
+			break
+		}
+	}
+	if signedOrder == nil {
+		return nil
+	}
+
+	// Still waiting for signers at the same position, or the sequence is complete
+	current := models.CurrentSignOrder(signers)
+	if current == 0 || current <= *signedOrder {
+		return nil
+	}
+
+	models.ApplySigningTurns(signers)
+	next := make([]string, 0)
+	for _, signer := range signers {
+		if signer.Turn == models.SigningTurnCurrent {
+			next = append(next, signer.Email)
+		}
+	}
+
+	var docURL string
+	if doc, err := s.docRepo.GetByDocID(ctx, docID); err == nil && doc != nil {
+		docURL = doc.URL
+	}
+
+	logger.Logger.Info("Notifying next signers", "doc_id", docID, "sign_order", current, "count", len(next))
+	if _, err := s.notifier.SendReminders(ctx, docID, "system", next, docURL, locale); err != nil {
+		return fmt.Errorf("failed to notify next signers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeSigningOrderSigners struct {
+	signers []*models.ExpectedSignerWithStatus
+	aliases map[string]string
+}
+
+func (f *fakeSigningOrderSigners) ListWithStatusByDocID(_ context.Context, _ string) ([]*models.ExpectedSignerWithStatus, error) {
+	return f.signers, nil
+}
+
+func (f *fakeSigningOrderSigners) MatchExpectedEmail(_ context.Context, _, email string) (string, error) {
+	email = strings.ToLower(email)
+	if primary, ok := f.aliases[email]; ok {
+		email = primary
+	}
+	for _, signer := range f.signers {
+		if strings.EqualFold(signer.Email, email) {
+			return signer.Email, nil
+		}
+	}
+	return "", nil
+}
+
+type fakeTurnNotifier struct {
+	calls  int
+	emails []string
+	docURL string
+}
+
+func (f *fakeTurnNotifier) SendReminders(_ context.Context, _, _ string, emails []string, docURL, _ string) (*models.ReminderSendResult, error) {
+	f.calls++
+	f.emails = emails
+	f.docURL = docURL
+	return &models.ReminderSendResult{TotalAttempted: len(emails), SuccessfullySent: len(emails)}, nil
+}
+
+func sequentialSigner(email string, order int, signed bool) *models.ExpectedSignerWithStatus {
+	s := &models.ExpectedSignerWithStatus{HasSigned: signed}
+	s.Email = email
+	if order > 0 {
+		s.SignOrder = &order
+	}
+	return s
+}
+
+func TestSigningOrderService_OnSignature(t *testing.T) {
+	docRepo := newFakeDocumentRepository()
+	docRepo.documents["doc1"] = &models.Document{DocID: "doc1", URL: "https://example.com/policy.pdf"}
+
+	tests := []struct {
+		name       string
+		signers    []*models.ExpectedSignerWithStatus
+		aliases    map[string]string
+		signer     string
+		wantCalls  int
+		wantEmails []string
+	}{
+		{
+			name: "step complete notifies next position",
+			signers: []*models.ExpectedSignerWithStatus{
+				sequentialSigner("employee@example.com", 1, true),
+				sequentialSigner("manager@example.com", 2, false),
+				sequentialSigner("deputy@example.com", 2, false),
+				sequentialSigner("hr@example.com", 3, false),
+			},
+			signer:     "Employee@example.com",
+			wantCalls:  1,
+			wantEmails: []string{"manager@example.com", "deputy@example.com"},
+		},
+		{
+			name: "signer matched through alias",
+			signers: []*models.ExpectedSignerWithStatus{
+				sequentialSigner("f.lastname@example.com", 1, true),
+				sequentialSigner("manager@example.com", 2, false),
+			},
+			aliases:    map[string]string{"firstname.lastname@example.com": "f.lastname@example.com"},
+			signer:     "firstname.lastname@example.com",
+			wantCalls:  1,
+			wantEmails: []string{"manager@example.com"},
+		},
+		{
+			name: "unexpected signer",
+			signers: []*models.ExpectedSignerWithStatus{
+				sequentialSigner("employee@example.com", 1, true),
+				sequentialSigner("manager@example.com", 2, false),
+			},
+			signer: "visitor@example.com",
+		},
+		{
+			name: "parallel signer still pending",
+			signers: []*models.ExpectedSignerWithStatus{
+				sequentialSigner("manager@example.com", 2, true),
+				sequentialSigner("deputy@example.com", 2, false),
+				sequentialSigner("hr@example.com", 3, false),
+			},
+			signer: "manager@example.com",
+		},
+		{
+			name: "last position signed",
+			signers: []*models.ExpectedSignerWithStatus{
+				sequentialSigner("employee@example.com", 1, true),
+				sequentialSigner("hr@example.com", 2, true),
+			},
+			signer: "hr@example.com",
+		},
+		{
+			name: "unordered signer",
+			signers: []*models.ExpectedSignerWithStatus{
+				sequentialSigner("observer@example.com", 0, true),
+				sequentialSigner("hr@example.com", 1, false),
+			},
+			signer: "observer@example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &fakeTurnNotifier{}
+			svc := NewSigningOrderService(&fakeSigningOrderSigners{signers: tt.signers, aliases: tt.aliases}, docRepo, notifier)
+
+			if err := svc.OnSignature(context.Background(), "doc1", tt.signer, "en"); err != nil {
+				t.Fatalf("OnSignature error: %v", err)
+			}
+			if notifier.calls != tt.wantCalls {
+				t.Fatalf("expected %d notification, got %d", tt.wantCalls, notifier.calls)
+			}
+			if tt.wantCalls == 0 {
+				return
+			}
+			if len(notifier.emails) != len(tt.wantEmails) {
+				t.Fatalf("expected %v, got %v", tt.wantEmails, notifier.emails)
+			}
+			for i, email := range tt.wantEmails {
+				if notifier.emails[i] != email {
+					t.Errorf("expected %v, got %v", tt.wantEmails, notifier.emails)
+				}
+			}
+			if notifier.docURL != "https://example.com/policy.pdf" {
+				t.Errorf("expected document URL, got %q", notifier.docURL)
+			}
+		})
+	}
+}
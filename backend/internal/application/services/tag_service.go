@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidTagPolicy is returned when a tag policy fails validation
+var ErrInvalidTagPolicy = errors.New("invalid tag policy")
+
+// maxReminderThreshold bounds the reminder thresholds of tag policies, in reminders or days
+const maxReminderThreshold = 365
+
+// tagRepository defines document tag and tag policy storage operations
+type tagRepository interface {
+	SetDocumentTags(ctx context.Context, docID string, tags []string, createdBy string) error
+	ListDocumentTags(ctx context.Context, docID string) ([]string, error)
+	ListTags(ctx context.Context, query string, limit int) ([]models.TagCount, error)
+	RenameTag(ctx context.Context, from, to string) (int, error)
+	DeleteTag(ctx context.Context, tag string) (int, error)
+	Stats(ctx context.Context, tag string) (*models.TagStats, error)
+	ListDocuments(ctx context.Context, filter models.DocumentFilter, limit, offset int) ([]*models.Document, error)
+	CountDocuments(ctx context.Context, filter models.DocumentFilter) (int, error)
+	ListPolicies(ctx context.Context) ([]*models.TagPolicy, error)
+	ListDocumentPolicies(ctx context.Context, docID string) ([]*models.TagPolicy, error)
+	UpsertPolicy(ctx context.Context, policy models.TagPolicy, updatedBy string) (*models.TagPolicy, error)
+	DeletePolicy(ctx context.Context, tag string) error
+}
+
+// tagDocumentRepository checks the documents tags are set on
+type tagDocumentRepository interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+}
+
+// TagService manages the free-form tags of documents and the reminder and retention policies
+// inherited by the documents carrying a tag
+type TagService struct {
+	repo    tagRepository
+	docRepo tagDocumentRepository
+}
+
+// NewTagService creates a new tag service
+func NewTagService(repo tagRepository, docRepo tagDocumentRepository) *TagService {
+	return &TagService{repo: repo, docRepo: docRepo}
+}
+
+// normalizeTag normalizes a tag, describing the rule it breaks
+func normalizeTag(tag string) (string, error) {
+	normalized, err := models.NormalizeTag(tag)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q must be 1 to %d characters", models.ErrInvalidTag, tag, models.MaxTagLength)
+	}
+	return normalized, nil
+}
+
+// DocumentTags returns the tags of a document
+func (s *TagService) DocumentTags(ctx context.Context, docID string) ([]string, error) {
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocumentTags(ctx, docID)
+}
+
+// SetDocumentTags replaces the tags of a document and returns them normalized. Duplicates
+// after normalization are kept once.
+func (s *TagService) SetDocumentTags(ctx context.Context, docID string, tags []string, updatedBy string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag, err := normalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > models.MaxDocumentTags {
+		return nil, fmt.Errorf("%w: a document has at most %d tags", models.ErrInvalidTag, models.MaxDocumentTags)
+	}
+
+	if err := s.checkDocument(ctx, docID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetDocumentTags(ctx, docID, normalized, updatedBy); err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Document tags changed", "doc_id", docID, "tags", normalized, "updated_by", updatedBy)
+	return s.repo.ListDocumentTags(ctx, docID)
+}
+
+func (s *TagService) checkDocument(ctx context.Context, docID string) error {
+	doc, err := s.docRepo.GetByDocID(ctx, docID)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return models.ErrDocumentNotFound
+	}
+	return nil
+}
+
+// ListTags returns the tags containing query, for autocompletion, with their document count.
+// An empty query returns the most used tags.
+func (s *TagService) ListTags(ctx context.Context, query string, limit int) ([]models.TagCount, error) {
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	return s.repo.ListTags(ctx, query, limit)
+}
+
+// RenameTag renames a tag on every document, merging it into an existing tag of the new name.
+// It returns the number of documents carrying the renamed tag.
+func (s *TagService) RenameTag(ctx context.Context, from, to string) (int, error) {
+	from, err := normalizeTag(from)
+	if err != nil {
+		return 0, err
+	}
+	to, err = normalizeTag(to)
+	if err != nil {
+		return 0, err
+	}
+	if from == to {
+		return 0, fmt.Errorf("%w: the new name is the same", models.ErrInvalidTag)
+	}
+
+	count, err := s.repo.RenameTag(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	logger.Logger.Info("Tag renamed", "from", from, "to", to, "documents", count)
+	return count, nil
+}
+
+// DeleteTag removes a tag from every document along with its policy and returns the number of
+// documents that carried it
+func (s *TagService) DeleteTag(ctx context.Context, tag string) (int, error) {
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.repo.DeleteTag(ctx, tag)
+	if err != nil {
+		return 0, err
+	}
+	logger.Logger.Info("Tag deleted", "tag", tag, "documents", count)
+	return count, nil
+}
+
+// Stats aggregates the completion of the documents carrying a tag
+func (s *TagService) Stats(ctx context.Context, tag string) (*models.TagStats, error) {
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.Stats(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	stats.PendingCount = stats.ExpectedCount - stats.SignedCount
+	if stats.ExpectedCount > 0 {
+		stats.CompletionRate = float64(stats.SignedCount) / float64(stats.ExpectedCount) * 100
+	}
+	return stats, nil
+}
+
+// ListDocuments returns the documents matching a filter, newest first
+func (s *TagService) ListDocuments(ctx context.Context, filter models.DocumentFilter, limit, offset int) ([]*models.Document, error) {
+	filter, err := normalizeDocumentFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListDocuments(ctx, filter, limit, offset)
+}
+
+// CountDocuments returns the number of documents matching a filter
+func (s *TagService) CountDocuments(ctx context.Context, filter models.DocumentFilter) (int, error) {
+	filter, err := normalizeDocumentFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CountDocuments(ctx, filter)
+}
+
+func normalizeDocumentFilter(filter models.DocumentFilter) (models.DocumentFilter, error) {
+	if filter.Tag != "" {
+		tag, err := normalizeTag(filter.Tag)
+		if err != nil {
+			return filter, err
+		}
+		filter.Tag = tag
+	}
+	return filter, nil
+}
+
+// ListPolicies returns the policies of all tags
+func (s *TagService) ListPolicies(ctx context.Context) ([]*models.TagPolicy, error) {
+	return s.repo.ListPolicies(ctx)
+}
+
+// SetPolicy creates or replaces the policy of a tag. The tag does not need to be used yet, so that
+// documents tagged later inherit the policy.
+func (s *TagService) SetPolicy(ctx context.Context, policy models.TagPolicy, updatedBy string) (*models.TagPolicy, error) {
+	tag, err := normalizeTag(policy.Tag)
+	if err != nil {
+		return nil, err
+	}
+	policy.Tag = tag
+	if err := validateTagPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.repo.UpsertPolicy(ctx, policy, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+	logger.Logger.Info("Tag policy updated", "tag", tag, "updated_by", updatedBy)
+	return saved, nil
+}
+
+func validateTagPolicy(policy models.TagPolicy) error {
+	for _, threshold := range []int{policy.FirmAfterReminders, policy.FirmAfterDays, policy.FinalAfterReminders, policy.FinalAfterDays} {
+		if threshold < 0 || threshold > maxReminderThreshold {
+			return fmt.Errorf("%w: reminder thresholds must be between 0 and %d", ErrInvalidTagPolicy, maxReminderThreshold)
+		}
+	}
+	if (policy.FirmAfterReminders > 0 && policy.FinalAfterReminders > 0 && policy.FinalAfterReminders < policy.FirmAfterReminders) ||
+		(policy.FirmAfterDays > 0 && policy.FinalAfterDays > 0 && policy.FinalAfterDays < policy.FirmAfterDays) {
+		return fmt.Errorf("%w: final notice thresholds must not be lower than firm reminder thresholds", ErrInvalidTagPolicy)
+	}
+	if days := policy.ArchiveAfterDays; days != nil && (*days < 1 || *days > maxArchiveAfterDays) {
+		return fmt.Errorf("%w: archiveAfterDays must be between 1 and %d", ErrInvalidTagPolicy, maxArchiveAfterDays)
+	}
+	if !policy.HasReminders() && policy.ArchiveAfterDays == nil {
+		return fmt.Errorf("%w: set a reminder threshold or archiveAfterDays", ErrInvalidTagPolicy)
+	}
+	return nil
+}
+
+// DeletePolicy removes the policy of a tag; its documents follow the tenant settings again
+func (s *TagService) DeletePolicy(ctx context.Context, tag string) error {
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeletePolicy(ctx, tag); err != nil {
+		return err
+	}
+	logger.Logger.Info("Tag policy deleted", "tag", tag)
+	return nil
+}
+
+// ReminderConfigForDocument returns the reminder settings of a document: the tenant ones
+// tightened by the policies of its tags. The tenant settings are kept when the policies
+// cannot be read.
+func (s *TagService) ReminderConfigForDocument(ctx context.Context, docID string, base models.ReminderConfig) models.ReminderConfig {
+	policies, err := s.repo.ListDocumentPolicies(ctx, docID)
+	if err != nil {
+		logger.Logger.Warn("Failed to read tag policies, using the tenant reminder settings", "doc_id", docID, "error", err.Error())
+		return base
+	}
+	values := make([]models.TagPolicy, 0, len(policies))
+	for _, p := range policies {
+		values = append(values, *p)
+	}
+	return base.WithTagPolicies(values)
+}
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeTagRepo struct {
+	tags     map[string][]string
+	policies map[string]*models.TagPolicy
+	stats    *models.TagStats
+	filter   models.DocumentFilter
+}
+
+func newFakeTagRepo() *fakeTagRepo {
+	return &fakeTagRepo{tags: map[string][]string{}, policies: map[string]*models.TagPolicy{}}
+}
+
+func (f *fakeTagRepo) SetDocumentTags(_ context.Context, docID string, tags []string, _ string) error {
+	f.tags[docID] = append([]string(nil), tags...)
+	return nil
+}
+
+func (f *fakeTagRepo) ListDocumentTags(_ context.Context, docID string) ([]string, error) {
+	tags := append([]string{}, f.tags[docID]...)
+	sort.Strings(tags)
+	return tags, nil
+}
+
+func (f *fakeTagRepo) ListTags(_ context.Context, query string, _ int) ([]models.TagCount, error) {
+	counts := map[string]int{}
+	for _, tags := range f.tags {
+		for _, tag := range tags {
+			if strings.Contains(tag, query) {
+				counts[tag]++
+			}
+		}
+	}
+	var out []models.TagCount
+	for tag, n := range counts {
+		out = append(out, models.TagCount{Tag: tag, Documents: n})
+	}
+	return out, nil
+}
+
+func (f *fakeTagRepo) RenameTag(_ context.Context, from, to string) (int, error) {
+	count := 0
+	for docID, tags := range f.tags {
+		for i, tag := range tags {
+			if tag == from {
+				f.tags[docID][i] = to
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0, models.ErrTagNotFound
+	}
+	return count, nil
+}
+
+func (f *fakeTagRepo) DeleteTag(_ context.Context, _ string) (int, error) {
+	return 0, models.ErrTagNotFound
+}
+
+func (f *fakeTagRepo) Stats(_ context.Context, tag string) (*models.TagStats, error) {
+	stats := *f.stats
+	stats.Tag = tag
+	return &stats, nil
+}
+
+func (f *fakeTagRepo) ListDocuments(_ context.Context, filter models.DocumentFilter, _, _ int) ([]*models.Document, error) {
+	f.filter = filter
+	return nil, nil
+}
+
+func (f *fakeTagRepo) CountDocuments(_ context.Context, filter models.DocumentFilter) (int, error) {
+	f.filter = filter
+	return 0, nil
+}
+
+func (f *fakeTagRepo) ListPolicies(_ context.Context) ([]*models.TagPolicy, error) {
+	var out []*models.TagPolicy
+	for _, p := range f.policies {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (f *fakeTagRepo) ListDocumentPolicies(_ context.Context, docID string) ([]*models.TagPolicy, error) {
+	var out []*models.TagPolicy
+	for _, tag := range f.tags[docID] {
+		if p, ok := f.policies[tag]; ok {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeTagRepo) UpsertPolicy(_ context.Context, policy models.TagPolicy, updatedBy string) (*models.TagPolicy, error) {
+	policy.UpdatedBy = updatedBy
+	f.policies[policy.Tag] = &policy
+	return &policy, nil
+}
+
+func (f *fakeTagRepo) DeletePolicy(_ context.Context, tag string) error {
+	if _, ok := f.policies[tag]; !ok {
+		return models.ErrTagNotFound
+	}
+	delete(f.policies, tag)
+	return nil
+}
+
+func newTestTagService() (*TagService, *fakeTagRepo) {
+	repo := newFakeTagRepo()
+	docs := &fakeCompletionDocRepo{docs: map[string]*models.Document{"doc-1": {DocID: "doc-1", Title: "Policy"}}}
+	return NewTagService(repo, docs), repo
+}
+
+func TestTagService_SetDocumentTags(t *testing.T) {
+	service, _ := newTestTagService()
+	ctx := context.Background()
+
+	tags, err := service.SetDocumentTags(ctx, "doc-1", []string{" Security ", "security", "Legal  Review"}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("set tags err: %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "legal review" || tags[1] != "security" {
+		t.Errorf("expected normalized, deduplicated tags, got %v", tags)
+	}
+
+	if _, err := service.SetDocumentTags(ctx, "doc-1", []string{"  "}, "admin@example.com"); !errors.Is(err, models.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag for a blank tag, got %v", err)
+	}
+	tooMany := make([]string, models.MaxDocumentTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+	if _, err := service.SetDocumentTags(ctx, "doc-1", tooMany, "admin@example.com"); !errors.Is(err, models.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag for too many tags, got %v", err)
+	}
+	if _, err := service.SetDocumentTags(ctx, "missing", []string{"security"}, "admin@example.com"); !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestTagService_RenameAndFilter(t *testing.T) {
+	service, repo := newTestTagService()
+	ctx := context.Background()
+	repo.tags["doc-1"] = []string{"secu"}
+
+	if _, err := service.RenameTag(ctx, "SECU", "secu"); !errors.Is(err, models.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag for a rename to the same tag, got %v", err)
+	}
+	if n, err := service.RenameTag(ctx, "Secu", "Security"); err != nil || n != 1 || repo.tags["doc-1"][0] != "security" {
+		t.Errorf("unexpected rename %d, %v, tags %v", n, err, repo.tags)
+	}
+
+	if _, err := service.ListDocuments(ctx, models.DocumentFilter{Tag: " SECURITY"}, 10, 0); err != nil || repo.filter.Tag != "security" {
+		t.Errorf("expected the tag filter normalized, got %+v, %v", repo.filter, err)
+	}
+
+	repo.stats = &models.TagStats{Documents: 2, ExpectedCount: 4, SignedCount: 3}
+	stats, err := service.Stats(ctx, "security")
+	if err != nil || stats.PendingCount != 1 || stats.CompletionRate != 75 {
+		t.Errorf("unexpected stats %+v, %v", stats, err)
+	}
+}
+
+func TestTagService_Policies(t *testing.T) {
+	service, repo := newTestTagService()
+	ctx := context.Background()
+	days := 90
+
+	invalid := []models.TagPolicy{
+		{Tag: "security"},
+		{Tag: "security", FirmAfterDays: -1},
+		{Tag: "security", FirmAfterDays: 10, FinalAfterDays: 5},
+		{Tag: "security", ArchiveAfterDays: new(int)},
+	}
+	for _, policy := range invalid {
+		if _, err := service.SetPolicy(ctx, policy, "admin@example.com"); !errors.Is(err, ErrInvalidTagPolicy) {
+			t.Errorf("%+v: expected ErrInvalidTagPolicy, got %v", policy, err)
+		}
+	}
+
+	policy, err := service.SetPolicy(ctx, models.TagPolicy{Tag: "Security", FirmAfterReminders: 1, FinalAfterDays: 5, ArchiveAfterDays: &days}, "admin@example.com")
+	if err != nil || policy.Tag != "security" {
+		t.Fatalf("unexpected policy %+v, %v", policy, err)
+	}
+
+	base := models.ReminderConfig{DisableEscalation: true}
+	if cfg := service.ReminderConfigForDocument(ctx, "doc-1", base); !cfg.DisableEscalation {
+		t.Errorf("expected the tenant settings for an untagged document, got %+v", cfg)
+	}
+	repo.tags["doc-1"] = []string{"security"}
+	cfg := service.ReminderConfigForDocument(ctx, "doc-1", base)
+	if cfg.DisableEscalation || cfg.FirmAfterReminders != 1 || cfg.FinalAfterDays != 5 || cfg.FinalAfterReminders != models.DefaultFinalAfterReminders {
+		t.Errorf("expected the tag policy applied, got %+v", cfg)
+	}
+
+	if err := service.DeletePolicy(ctx, "security"); err != nil {
+		t.Fatalf("delete policy err: %v", err)
+	}
+	if err := service.DeletePolicy(ctx, "security"); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("expected ErrTagNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// Audit actions recorded by the upload quarantine service (see web.AuditAction* constants)
+const (
+	auditActionUploadQuarantine = "upload.quarantine"
+	auditActionUploadRelease    = "upload.release"
+	auditActionUploadDiscard    = "upload.discard"
+)
+
+// quarantineKeyPrefix prefixes the storage key of a quarantined file. Releasing the file moves it
+// to the same key without the prefix, where uploaded documents are stored.
+const quarantineKeyPrefix = "quarantine/"
+
+// ErrInvalidQuarantineStatus is returned when listing quarantined uploads by an unknown status
+var ErrInvalidQuarantineStatus = errors.New("quarantine status must be pending, released or discarded")
+
+// uploadQuarantineRepository stores the quarantined uploads and the decisions on them
+type uploadQuarantineRepository interface {
+	Create(ctx context.Context, upload *models.QuarantinedUpload) error
+	List(ctx context.Context, status models.QuarantineStatus) ([]*models.QuarantinedUpload, error)
+	GetByID(ctx context.Context, id int64) (*models.QuarantinedUpload, error)
+	MarkReviewed(ctx context.Context, id int64, status models.QuarantineStatus, reviewedBy, docID string) (*models.QuarantinedUpload, error)
+}
+
+// quarantineDocumentCreator creates the document of a released upload
+type quarantineDocumentCreator interface {
+	CreateDocument(ctx context.Context, req CreateDocumentRequest) (*models.Document, error)
+}
+
+// quarantineStorage holds the quarantined files
+type quarantineStorage interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Download(ctx context.Context, key string) (io.ReadCloser, int64, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// virusScanner returns the name of the virus found in a file, or "" when it is clean
+type virusScanner interface {
+	Scan(ctx context.Context, r io.Reader) (string, error)
+}
+
+// uploadPolicyConfig reads the upload policy from the tenant storage settings
+type uploadPolicyConfig interface {
+	GetConfig() *models.MutableConfig
+}
+
+// UploadQuarantineService holds back the uploads breaking the tenant upload policy or flagged by
+// the virus scanner, until an admin releases them as documents or discards them
+type UploadQuarantineService struct {
+	repo      uploadQuarantineRepository
+	documents quarantineDocumentCreator
+	storage   quarantineStorage
+	config    uploadPolicyConfig
+	scanner   virusScanner
+	audit     auditRecorder
+}
+
+// NewUploadQuarantineService creates a new upload quarantine service
+func NewUploadQuarantineService(repo uploadQuarantineRepository, documents quarantineDocumentCreator, storage quarantineStorage, config uploadPolicyConfig, audit auditRecorder) *UploadQuarantineService {
+	return &UploadQuarantineService{
+		repo:      repo,
+		documents: documents,
+		storage:   storage,
+		config:    config,
+		audit:     audit,
+	}
+}
+
+// SetScanner enables the virus scanning of uploads
+func (s *UploadQuarantineService) SetScanner(scanner virusScanner) {
+	s.scanner = scanner
+}
+
+// Policy returns the tenant size limit in MB and allowed MIME types, 0 and nil when unset
+func (s *UploadQuarantineService) Policy() (int64, []string) {
+	cfg := s.config.GetConfig()
+	if cfg == nil {
+		return 0, nil
+	}
+	return cfg.Storage.MaxSizeMB, cfg.Storage.AllowedMimeTypes
+}
+
+// Inspect checks an upload against the virus scanner and the tenant policy. It returns why the
+// upload must be quarantined with a detail for the reviewer, or "" when it can become a document.
+// r is read to the end when a scanner is set.
+func (s *UploadQuarantineService) Inspect(ctx context.Context, r io.Reader, size int64, mimeType string) (models.QuarantineReason, string) {
+	if s.scanner != nil {
+		virus, err := s.scanner.Scan(ctx, r)
+		if err != nil {
+			logger.Logger.Warn("Upload could not be scanned", "error", err.Error())
+			return models.QuarantineReasonScanFailed, err.Error()
+		}
+		if virus != "" {
+			return models.QuarantineReasonInfected, virus
+		}
+	}
+
+	maxSizeMB, allowedTypes := s.Policy()
+	if maxSizeMB > 0 && size > maxSizeMB*1024*1024 {
+		return models.QuarantineReasonTooLarge, "limit is " + strconv.FormatInt(maxSizeMB, 10) + " MB"
+	}
+	if len(allowedTypes) > 0 && !slices.Contains(allowedTypes, mimeType) {
+		return models.QuarantineReasonTypeNotAllowed, mimeType
+	}
+	return "", ""
+}
+
+// Quarantine stores the file of an upload under the quarantine prefix of upload.StorageKey and
+// records it for review, filling its ID and checksum
+func (s *UploadQuarantineService) Quarantine(ctx context.Context, r io.Reader, upload *models.QuarantinedUpload) error {
+	upload.StorageKey = quarantineKeyPrefix + upload.StorageKey
+
+	hasher := sha256.New()
+	if err := s.storage.Upload(ctx, upload.StorageKey, io.TeeReader(r, hasher), upload.FileSize, upload.MimeType); err != nil {
+		return fmt.Errorf("failed to store quarantined file: %w", err)
+	}
+	upload.Checksum = hex.EncodeToString(hasher.Sum(nil))
+
+	if err := s.repo.Create(ctx, upload); err != nil {
+		s.deleteFile(ctx, upload.StorageKey)
+		return err
+	}
+
+	s.record(ctx, auditActionUploadQuarantine, upload, upload.UploadedBy, nil)
+	logger.Logger.Warn("Upload quarantined",
+		"id", upload.ID,
+		"reason", upload.Reason,
+		"detail", upload.Detail,
+		"filename", upload.OriginalFilename,
+		"user", upload.UploadedBy)
+	return nil
+}
+
+// List returns the quarantined uploads with a status, or all of them when status is empty
+func (s *UploadQuarantineService) List(ctx context.Context, status string) ([]*models.QuarantinedUpload, error) {
+	switch models.QuarantineStatus(status) {
+	case "", models.QuarantineStatusPending, models.QuarantineStatusReleased, models.QuarantineStatusDiscarded:
+	default:
+		return nil, ErrInvalidQuarantineStatus
+	}
+	return s.repo.List(ctx, models.QuarantineStatus(status))
+}
+
+// Release creates the document of a pending upload, as its uploader asked, and moves its file out
+// of quarantine
+func (s *UploadQuarantineService) Release(ctx context.Context, id int64, reviewedBy string) (*models.QuarantinedUpload, error) {
+	upload, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != models.QuarantineStatusPending {
+		return nil, models.ErrQuarantineReviewed
+	}
+
+	key := strings.TrimPrefix(upload.StorageKey, quarantineKeyPrefix)
+	if err := s.copyFile(ctx, upload.StorageKey, key, upload); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.documents.CreateDocument(ctx, CreateDocumentRequest{
+		Reference:         key,
+		Title:             upload.Title,
+		CreatedBy:         upload.UploadedBy,
+		ReadMode:          upload.ReadMode,
+		AllowDownload:     &upload.AllowDownload,
+		RequireFullRead:   &upload.RequireFullRead,
+		VerifyChecksum:    &upload.VerifyChecksum,
+		StorageKey:        key,
+		StorageProvider:   upload.StorageProvider,
+		FileSize:          upload.FileSize,
+		MimeType:          upload.MimeType,
+		Checksum:          upload.Checksum,
+		ChecksumAlgorithm: "SHA-256",
+		OriginalFilename:  upload.OriginalFilename,
+	})
+	if err != nil {
+		s.deleteFile(ctx, key)
+		return nil, fmt.Errorf("failed to create released document: %w", err)
+	}
+
+	released, err := s.repo.MarkReviewed(ctx, id, models.QuarantineStatusReleased, reviewedBy, doc.DocID)
+	if err != nil {
+		return nil, err
+	}
+	s.deleteFile(ctx, upload.StorageKey)
+
+	s.record(ctx, auditActionUploadRelease, released, reviewedBy, map[string]any{"doc_id": doc.DocID})
+	return released, nil
+}
+
+// Discard deletes the file of a pending upload
+func (s *UploadQuarantineService) Discard(ctx context.Context, id int64, reviewedBy string) (*models.QuarantinedUpload, error) {
+	discarded, err := s.repo.MarkReviewed(ctx, id, models.QuarantineStatusDiscarded, reviewedBy, "")
+	if err != nil {
+		return nil, err
+	}
+	s.deleteFile(ctx, discarded.StorageKey)
+
+	s.record(ctx, auditActionUploadDiscard, discarded, reviewedBy, nil)
+	return discarded, nil
+}
+
+func (s *UploadQuarantineService) copyFile(ctx context.Context, from, to string, upload *models.QuarantinedUpload) error {
+	reader, size, _, err := s.storage.Download(ctx, from)
+	if err != nil {
+		return fmt.Errorf("failed to read quarantined file: %w", err)
+	}
+	defer reader.Close()
+
+	if err := s.storage.Upload(ctx, to, reader, size, upload.MimeType); err != nil {
+		return fmt.Errorf("failed to store released file: %w", err)
+	}
+	return nil
+}
+
+func (s *UploadQuarantineService) deleteFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		logger.Logger.Error("Failed to delete quarantined file", "key", key, "error", err.Error())
+	}
+}
+
+func (s *UploadQuarantineService) record(ctx context.Context, action string, upload *models.QuarantinedUpload, actor string, details map[string]any) {
+	if s.audit == nil {
+		return
+	}
+	if details == nil {
+		details = map[string]any{}
+	}
+	details["reason"] = string(upload.Reason)
+	details["filename"] = upload.OriginalFilename
+	details["checksum"] = upload.Checksum
+	if upload.Detail != "" {
+		details["detail"] = upload.Detail
+	}
+	s.audit.Record(ctx, action, "upload", strconv.FormatInt(upload.ID, 10), actor, details)
+}
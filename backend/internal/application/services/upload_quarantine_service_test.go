@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeQuarantineRepo struct {
+	uploads map[int64]*models.QuarantinedUpload
+}
+
+func (f *fakeQuarantineRepo) Create(_ context.Context, upload *models.QuarantinedUpload) error {
+	upload.ID = int64(len(f.uploads) + 1)
+	upload.Status = models.QuarantineStatusPending
+	f.uploads[upload.ID] = upload
+	return nil
+}
+
+func (f *fakeQuarantineRepo) List(_ context.Context, status models.QuarantineStatus) ([]*models.QuarantinedUpload, error) {
+	var out []*models.QuarantinedUpload
+	for _, u := range f.uploads {
+		if status == "" || u.Status == status {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeQuarantineRepo) GetByID(_ context.Context, id int64) (*models.QuarantinedUpload, error) {
+	u, ok := f.uploads[id]
+	if !ok {
+		return nil, models.ErrQuarantineNotFound
+	}
+	return u, nil
+}
+
+func (f *fakeQuarantineRepo) MarkReviewed(ctx context.Context, id int64, status models.QuarantineStatus, reviewedBy, docID string) (*models.QuarantinedUpload, error) {
+	u, err := f.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Status != models.QuarantineStatusPending {
+		return nil, models.ErrQuarantineReviewed
+	}
+	u.Status, u.ReviewedBy = status, &reviewedBy
+	if docID != "" {
+		u.DocID = &docID
+	}
+	return u, nil
+}
+
+type fakeQuarantineDocuments struct {
+	created []CreateDocumentRequest
+}
+
+func (f *fakeQuarantineDocuments) CreateDocument(_ context.Context, req CreateDocumentRequest) (*models.Document, error) {
+	f.created = append(f.created, req)
+	return &models.Document{DocID: "released-doc", StorageKey: req.StorageKey}, nil
+}
+
+type fakeVirusScanner struct {
+	err error
+}
+
+func (f *fakeVirusScanner) Scan(_ context.Context, r io.Reader) (string, error) {
+	data, _ := io.ReadAll(r)
+	if f.err != nil {
+		return "", f.err
+	}
+	if strings.Contains(string(data), "EICAR") {
+		return "Eicar-Test-Signature", nil
+	}
+	return "", nil
+}
+
+type fakeUploadPolicy struct {
+	storage models.StorageConfig
+}
+
+func (f *fakeUploadPolicy) GetConfig() *models.MutableConfig {
+	return &models.MutableConfig{Storage: f.storage}
+}
+
+func newTestUploadQuarantine() (*UploadQuarantineService, *fakeQuarantineRepo, *fakeQuarantineDocuments, *fakeArchiveStorage, *fakeAuditRecorder) {
+	repo := &fakeQuarantineRepo{uploads: map[int64]*models.QuarantinedUpload{}}
+	docs := &fakeQuarantineDocuments{}
+	storage := &fakeArchiveStorage{objects: map[string][]byte{}}
+	policy := &fakeUploadPolicy{storage: models.StorageConfig{MaxSizeMB: 1, AllowedMimeTypes: []string{"application/pdf"}}}
+	audit := &fakeAuditRecorder{}
+	return NewUploadQuarantineService(repo, docs, storage, policy, audit), repo, docs, storage, audit
+}
+
+func TestUploadQuarantineService_Inspect(t *testing.T) {
+	service, _, _, _, _ := newTestUploadQuarantine()
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		content  string
+		size     int64
+		mimeType string
+		reason   models.QuarantineReason
+	}{
+		{"within policy", "%PDF", 4, "application/pdf", ""},
+		{"too large", "%PDF", 2 * 1024 * 1024, "application/pdf", models.QuarantineReasonTooLarge},
+		{"type not allowed", "PNG", 3, "image/png", models.QuarantineReasonTypeNotAllowed},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if reason, _ := service.Inspect(ctx, strings.NewReader(tc.content), tc.size, tc.mimeType); reason != tc.reason {
+				t.Errorf("Inspect = %q, want %q", reason, tc.reason)
+			}
+		})
+	}
+
+	// Without a scanner the content is not read
+	if reason, _ := service.Inspect(ctx, strings.NewReader("EICAR"), 5, "application/pdf"); reason != "" {
+		t.Errorf("expected no scan without a scanner, got %q", reason)
+	}
+
+	service.SetScanner(&fakeVirusScanner{})
+	reason, detail := service.Inspect(ctx, strings.NewReader("EICAR"), 5, "application/pdf")
+	if reason != models.QuarantineReasonInfected || detail != "Eicar-Test-Signature" {
+		t.Errorf("Inspect = %q, %q; want infected", reason, detail)
+	}
+
+	service.SetScanner(&fakeVirusScanner{err: errors.New("clamd unavailable")})
+	if reason, _ := service.Inspect(ctx, strings.NewReader("%PDF"), 4, "application/pdf"); reason != models.QuarantineReasonScanFailed {
+		t.Errorf("Inspect = %q, want scan_failed", reason)
+	}
+}
+
+func quarantineTestUpload(t *testing.T, service *UploadQuarantineService) *models.QuarantinedUpload {
+	t.Helper()
+	upload := &models.QuarantinedUpload{
+		StorageKey:       "2026/10/18/abc.png",
+		StorageProvider:  "local",
+		OriginalFilename: "diagram.png",
+		Title:            "Diagram",
+		MimeType:         "image/png",
+		FileSize:         3,
+		ReadMode:         "integrated",
+		VerifyChecksum:   true,
+		Reason:           models.QuarantineReasonTypeNotAllowed,
+		UploadedBy:       "user@example.com",
+	}
+	if err := service.Quarantine(context.Background(), strings.NewReader("PNG"), upload); err != nil {
+		t.Fatalf("Quarantine: %v", err)
+	}
+	return upload
+}
+
+func TestUploadQuarantineService_Release(t *testing.T) {
+	service, _, docs, storage, audit := newTestUploadQuarantine()
+	ctx := context.Background()
+
+	upload := quarantineTestUpload(t, service)
+	if upload.StorageKey != "quarantine/2026/10/18/abc.png" || upload.Checksum == "" {
+		t.Fatalf("unexpected quarantined upload: %+v", upload)
+	}
+	if _, ok := storage.objects[upload.StorageKey]; !ok {
+		t.Fatal("expected the file to be stored under the quarantine prefix")
+	}
+
+	released, err := service.Release(ctx, upload.ID, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if released.Status != models.QuarantineStatusReleased || released.DocID == nil || *released.DocID != "released-doc" {
+		t.Fatalf("unexpected released upload: %+v", released)
+	}
+	if len(docs.created) != 1 || docs.created[0].CreatedBy != "user@example.com" || docs.created[0].StorageKey != "2026/10/18/abc.png" {
+		t.Fatalf("expected the document of the uploader, got %+v", docs.created)
+	}
+	if _, ok := storage.objects["2026/10/18/abc.png"]; !ok {
+		t.Error("expected the file to move out of quarantine")
+	}
+	if _, ok := storage.objects[upload.StorageKey]; ok {
+		t.Error("expected the quarantined file to be deleted")
+	}
+
+	if _, err := service.Release(ctx, upload.ID, "admin@example.com"); !errors.Is(err, models.ErrQuarantineReviewed) {
+		t.Errorf("expected ErrQuarantineReviewed, got %v", err)
+	}
+	if strings.Join(audit.actions, ",") != "upload.quarantine,upload.release" {
+		t.Errorf("unexpected audit actions %v", audit.actions)
+	}
+}
+
+func TestUploadQuarantineService_Discard(t *testing.T) {
+	service, _, docs, storage, audit := newTestUploadQuarantine()
+	ctx := context.Background()
+
+	upload := quarantineTestUpload(t, service)
+	discarded, err := service.Discard(ctx, upload.ID, "admin@example.com")
+	if err != nil || discarded.Status != models.QuarantineStatusDiscarded {
+		t.Fatalf("Discard = %+v, %v", discarded, err)
+	}
+	if len(storage.objects) != 0 || len(docs.created) != 0 {
+		t.Errorf("expected the file deleted and no document, got %d files and %d documents", len(storage.objects), len(docs.created))
+	}
+	if _, err := service.Discard(ctx, upload.ID, "admin@example.com"); !errors.Is(err, models.ErrQuarantineReviewed) {
+		t.Errorf("expected ErrQuarantineReviewed, got %v", err)
+	}
+	if _, err := service.Release(ctx, 42, "admin@example.com"); !errors.Is(err, models.ErrQuarantineNotFound) {
+		t.Errorf("expected ErrQuarantineNotFound, got %v", err)
+	}
+	if audit.actions[len(audit.actions)-1] != "upload.discard" {
+		t.Errorf("unexpected audit actions %v", audit.actions)
+	}
+
+	if _, err := service.List(ctx, "archived"); !errors.Is(err, ErrInvalidQuarantineStatus) {
+		t.Errorf("expected ErrInvalidQuarantineStatus, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ErrInvalidLocale is returned when a locale is not one of models.SupportedLocales
+var ErrInvalidLocale = errors.New("unsupported locale")
+
+// ErrInvalidTimezone is returned when a timezone is not a known IANA name
+var ErrInvalidTimezone = errors.New("unknown timezone")
+
+// userPreferenceRepository defines user preference storage operations
+type userPreferenceRepository interface {
+	Get(ctx context.Context, email string) (*models.UserPreferences, error)
+	SetLocale(ctx context.Context, email, locale string, overwrite bool) (*models.UserPreferences, error)
+	SetTimezone(ctx context.Context, email, timezone string) (*models.UserPreferences, error)
+}
+
+// localeConfigProvider provides the tenant default locale
+type localeConfigProvider interface {
+	GetConfig() *models.MutableConfig
+}
+
+// UserPreferenceService manages the preferred locale of users and resolves the locale
+// of the emails sent to them
+type UserPreferenceService struct {
+	repo          userPreferenceRepository
+	config        localeConfigProvider
+	defaultLocale string
+}
+
+// NewUserPreferenceService creates a new user preference service. defaultLocale is used when
+// the tenant configuration does not set one.
+func NewUserPreferenceService(repo userPreferenceRepository, config localeConfigProvider, defaultLocale string) *UserPreferenceService {
+	return &UserPreferenceService{repo: repo, config: config, defaultLocale: defaultLocale}
+}
+
+// DefaultLocale returns the locale of the tenant, used for users without a preference
+func (s *UserPreferenceService) DefaultLocale() string {
+	if s.config != nil {
+		if locale, ok := models.NormalizeLocale(s.config.GetConfig().General.DefaultLocale); ok {
+			return locale
+		}
+	}
+	if locale, ok := models.NormalizeLocale(s.defaultLocale); ok {
+		return locale
+	}
+	return "en"
+}
+
+// GetPreferences returns the preferences of a user, with an empty locale when none is stored
+func (s *UserPreferenceService) GetPreferences(ctx context.Context, email string) (*models.UserPreferences, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	prefs, err := s.repo.Get(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if prefs == nil {
+		prefs = &models.UserPreferences{Email: email}
+	}
+	return prefs, nil
+}
+
+// UpdateLocale sets the preferred locale of a user
+func (s *UserPreferenceService) UpdateLocale(ctx context.Context, email, locale string) (*models.UserPreferences, error) {
+	locale, ok := models.NormalizeLocale(locale)
+	if !ok {
+		return nil, ErrInvalidLocale
+	}
+	return s.repo.SetLocale(ctx, strings.ToLower(strings.TrimSpace(email)), locale, true)
+}
+
+// UpdateTimezone sets the timezone of a user, an empty one falling back to the tenant timezone
+func (s *UserPreferenceService) UpdateTimezone(ctx context.Context, email, timezone string) (*models.UserPreferences, error) {
+	timezone = strings.TrimSpace(timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil || strings.EqualFold(timezone, "local") {
+			return nil, ErrInvalidTimezone
+		}
+	}
+	return s.repo.SetTimezone(ctx, strings.ToLower(strings.TrimSpace(email)), timezone)
+}
+
+// DetectLocale returns the preferred locale of a user who just logged in. At first login, the
+// detected browser locale is stored as the preference; later logins keep the stored one.
+func (s *UserPreferenceService) DetectLocale(ctx context.Context, email, detected string) string {
+	locale, ok := models.NormalizeLocale(detected)
+	if !ok {
+		locale = s.DefaultLocale()
+	}
+
+	prefs, err := s.repo.SetLocale(ctx, strings.ToLower(strings.TrimSpace(email)), locale, false)
+	if err != nil {
+		logger.Logger.Warn("Failed to store detected locale", "email", email, "error", err.Error())
+		return locale
+	}
+	return prefs.Locale
+}
+
+// ResolveLocale returns the locale of the emails sent to a user: their preference, else
+// fallback when it is supported, else the tenant default
+func (s *UserPreferenceService) ResolveLocale(ctx context.Context, email, fallback string) string {
+	prefs, err := s.repo.Get(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		logger.Logger.Warn("Failed to get user preferences", "email", email, "error", err.Error())
+	}
+	if prefs != nil {
+		if locale, ok := models.NormalizeLocale(prefs.Locale); ok {
+			return locale
+		}
+	}
+	if locale, ok := models.NormalizeLocale(fallback); ok {
+		return locale
+	}
+	return s.DefaultLocale()
+}
+
+// ResolveTimezone returns the timezone of a user, empty when they have none
+func (s *UserPreferenceService) ResolveTimezone(ctx context.Context, email string) string {
+	prefs, err := s.repo.Get(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		logger.Logger.Warn("Failed to get user preferences", "email", email, "error", err.Error())
+	}
+	if prefs == nil {
+		return ""
+	}
+	return prefs.Timezone
+}
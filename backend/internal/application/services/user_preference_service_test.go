@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeUserPreferenceRepo struct {
+	locales   map[string]string
+	timezones map[string]string
+}
+
+func (f *fakeUserPreferenceRepo) Get(_ context.Context, email string) (*models.UserPreferences, error) {
+	locale, ok := f.locales[email]
+	timezone, hasTimezone := f.timezones[email]
+	if !ok && !hasTimezone {
+		return nil, nil
+	}
+	return &models.UserPreferences{Email: email, Locale: locale, Timezone: timezone}, nil
+}
+
+func (f *fakeUserPreferenceRepo) SetTimezone(_ context.Context, email, timezone string) (*models.UserPreferences, error) {
+	if f.timezones == nil {
+		f.timezones = make(map[string]string)
+	}
+	f.timezones[email] = timezone
+	return &models.UserPreferences{Email: email, Locale: f.locales[email], Timezone: timezone}, nil
+}
+
+func (f *fakeUserPreferenceRepo) SetLocale(_ context.Context, email, locale string, overwrite bool) (*models.UserPreferences, error) {
+	if _, ok := f.locales[email]; !ok || overwrite {
+		f.locales[email] = locale
+	}
+	return &models.UserPreferences{Email: email, Locale: f.locales[email]}, nil
+}
+
+type fakeLocaleConfig struct{ defaultLocale string }
+
+func (f *fakeLocaleConfig) GetConfig() *models.MutableConfig {
+	cfg := &models.MutableConfig{}
+	cfg.General.DefaultLocale = f.defaultLocale
+	return cfg
+}
+
+func TestUserPreferenceService_DetectAndUpdate(t *testing.T) {
+	repo := &fakeUserPreferenceRepo{locales: make(map[string]string)}
+	svc := NewUserPreferenceService(repo, &fakeLocaleConfig{}, "en")
+	ctx := context.Background()
+
+	if got := svc.DetectLocale(ctx, "Alice@Example.com", "fr-FR"); got != "fr" {
+		t.Fatalf("expected fr detected at first login, got %q", got)
+	}
+	if got := svc.DetectLocale(ctx, "alice@example.com", "de"); got != "fr" {
+		t.Errorf("expected stored locale kept at later logins, got %q", got)
+	}
+	if got := svc.DetectLocale(ctx, "bob@example.com", "pt-BR"); got != "en" {
+		t.Errorf("expected default locale for an unsupported browser language, got %q", got)
+	}
+
+	if _, err := svc.UpdateLocale(ctx, "alice@example.com", "klingon"); !errors.Is(err, ErrInvalidLocale) {
+		t.Fatalf("expected ErrInvalidLocale, got %v", err)
+	}
+	prefs, err := svc.UpdateLocale(ctx, "alice@example.com", "ES")
+	if err != nil || prefs.Locale != "es" {
+		t.Fatalf("unexpected preferences %+v, %v", prefs, err)
+	}
+}
+
+func TestUserPreferenceService_ResolveLocale(t *testing.T) {
+	repo := &fakeUserPreferenceRepo{locales: map[string]string{"alice@example.com": "it"}}
+	config := &fakeLocaleConfig{}
+	svc := NewUserPreferenceService(repo, config, "fr")
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		email         string
+		fallback      string
+		tenantDefault string
+		want          string
+	}{
+		{"preference", "Alice@example.com", "de", "es", "it"},
+		{"fallback", "bob@example.com", "de", "es", "de"},
+		{"tenant default", "bob@example.com", "", "es", "es"},
+		{"server default", "bob@example.com", "", "", "fr"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.defaultLocale = tt.tenantDefault
+			if got := svc.ResolveLocale(ctx, tt.email, tt.fallback); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUserPreferenceService_Timezone(t *testing.T) {
+	repo := &fakeUserPreferenceRepo{locales: make(map[string]string)}
+	svc := NewUserPreferenceService(repo, &fakeLocaleConfig{}, "en")
+	ctx := context.Background()
+
+	if got := svc.ResolveTimezone(ctx, "alice@example.com"); got != "" {
+		t.Errorf("expected no timezone, got %q", got)
+	}
+	for _, invalid := range []string{"Europe/Atlantis", "Local"} {
+		if _, err := svc.UpdateTimezone(ctx, "alice@example.com", invalid); !errors.Is(err, ErrInvalidTimezone) {
+			t.Errorf("%s: expected ErrInvalidTimezone, got %v", invalid, err)
+		}
+	}
+
+	prefs, err := svc.UpdateTimezone(ctx, "Alice@Example.com", " America/New_York ")
+	if err != nil || prefs.Timezone != "America/New_York" {
+		t.Fatalf("unexpected preferences %+v, %v", prefs, err)
+	}
+	if got := svc.ResolveTimezone(ctx, "ALICE@example.com"); got != "America/New_York" {
+		t.Errorf("expected the stored timezone, got %q", got)
+	}
+
+	if _, err := svc.UpdateTimezone(ctx, "alice@example.com", ""); err != nil {
+		t.Fatalf("clear timezone: %v", err)
+	}
+	if got := svc.ResolveTimezone(ctx, "alice@example.com"); got != "" {
+		t.Errorf("expected the timezone cleared, got %q", got)
+	}
+}
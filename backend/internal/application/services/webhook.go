@@ -29,9 +29,21 @@ func NewWebhookPublisher(repo webhookRepo, deliveries webhookDeliveryRepo) *Webh
 	return &WebhookPublisher{repo: repo, deliveries: deliveries}
 }
 
-// Publish enqueues deliveries for all webhooks subscribed to the event
+// Publish enqueues deliveries for all webhooks subscribed to the event.
+// Webhooks scoped to a document are skipped for events of other documents.
 func (p *WebhookPublisher) Publish(ctx context.Context, eventType string, payload map[string]interface{}) error {
 	logger.Logger.Debug("Publishing event", "event", eventType)
+	return p.enqueue(ctx, newEventID(), eventType, payload, false)
+}
+
+// HandleEvent enqueues the deliveries of an event relayed from the outbox. Unlike Publish it
+// fails on the first enqueue error so that the relay rolls back and retries the whole event;
+// the outbox event ID is reused so receivers can deduplicate redeliveries.
+func (p *WebhookPublisher) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	return p.enqueue(ctx, event.EventID, event.EventType, event.Payload, true)
+}
+
+func (p *WebhookPublisher) enqueue(ctx context.Context, eventID, eventType string, payload map[string]interface{}, strict bool) error {
 	hooks, err := p.repo.ListActiveByEvent(ctx, eventType)
 	if err != nil {
 		return fmt.Errorf("failed to list webhooks: %w", err)
@@ -40,8 +52,12 @@ func (p *WebhookPublisher) Publish(ctx context.Context, eventType string, payloa
 		return nil
 	}
 
-	eventID := newEventID()
+	docID, _ := payload["doc_id"].(string)
 	for _, h := range hooks {
+		// Document-scoped webhooks only receive events of their document
+		if h.DocID != nil && *h.DocID != docID {
+			continue
+		}
 		input := models.WebhookDeliveryInput{
 			WebhookID:  h.ID,
 			EventType:  eventType,
@@ -51,6 +67,9 @@ func (p *WebhookPublisher) Publish(ctx context.Context, eventType string, payloa
 			MaxRetries: 6,
 		}
 		if _, err := p.deliveries.Enqueue(ctx, input); err != nil {
+			if strict {
+				return fmt.Errorf("failed to enqueue webhook delivery %d: %w", h.ID, err)
+			}
 			logger.Logger.Warn("Failed to enqueue webhook delivery", "webhook_id", h.ID, "error", err.Error())
 		}
 	}
@@ -47,3 +47,40 @@ func TestWebhookPublisher_Publish(t *testing.T) {
 		}
 	}
 }
+
+func TestWebhookPublisher_Publish_DocumentScoped(t *testing.T) {
+	docID := "abc123"
+	otherDocID := "other"
+	hooks := []*models.Webhook{
+		{ID: 1, Active: true, Events: []string{"signature.created"}},
+		{ID: 2, Active: true, Events: []string{"signature.created"}, DocID: &docID},
+		{ID: 3, Active: true, Events: []string{"signature.created"}, DocID: &otherDocID},
+	}
+	drepo := &fakeDeliveryRepo{}
+	p := NewWebhookPublisher(&fakeWebhookRepo{hooks: hooks}, drepo)
+
+	if err := p.Publish(context.Background(), "signature.created", map[string]interface{}{"doc_id": docID}); err != nil {
+		t.Fatalf("Publish error: %v", err)
+	}
+	if len(drepo.inputs) != 2 {
+		t.Fatalf("expected 2 enqueues, got %d", len(drepo.inputs))
+	}
+	for _, in := range drepo.inputs {
+		if in.WebhookID == 3 {
+			t.Error("webhook scoped to another document should not be delivered")
+		}
+	}
+}
+
+func TestWebhookPublisher_HandleEvent_ReusesEventID(t *testing.T) {
+	hooks := []*models.Webhook{{ID: 1, Active: true, Events: []string{"signature.created"}}}
+	drepo := &fakeDeliveryRepo{}
+	p := NewWebhookPublisher(&fakeWebhookRepo{hooks: hooks}, drepo)
+	event := &models.OutboxEvent{EventID: "evt-1", EventType: "signature.created", Payload: map[string]interface{}{"doc_id": "abc123"}}
+	if err := p.HandleEvent(context.Background(), event); err != nil {
+		t.Fatalf("HandleEvent error: %v", err)
+	}
+	if len(drepo.inputs) != 1 || drepo.inputs[0].EventID != "evt-1" {
+		t.Fatalf("expected one delivery with the outbox event ID, got %#v", drepo.inputs)
+	}
+}
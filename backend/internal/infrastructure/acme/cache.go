@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package acme
+
+import (
+	"context"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// certificateStore keeps the account key, certificates and pending challenges of the instances
+// sharing the database. Get returns nil data without error when the name is not stored.
+type certificateStore interface {
+	Get(ctx context.Context, name string) ([]byte, error)
+	Put(ctx context.Context, name string, data []byte) error
+	Delete(ctx context.Context, name string) error
+}
+
+// databaseCache adapts a certificate store to the autocert cache
+type databaseCache struct {
+	store certificateStore
+}
+
+// NewDatabaseCache creates an autocert cache backed by store, so that restarts and other
+// instances reuse the certificates instead of ordering new ones
+func NewDatabaseCache(store certificateStore) autocert.Cache {
+	return databaseCache{store: store}
+}
+
+// Get returns the data of key, or autocert.ErrCacheMiss when it is not stored
+func (c databaseCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, autocert.ErrCacheMiss
+	}
+	return data, nil
+}
+
+// Put stores the data of key
+func (c databaseCache) Put(ctx context.Context, key string, data []byte) error {
+	return c.store.Put(ctx, key, data)
+}
+
+// Delete removes key, such as a challenge response once validated
+func (c databaseCache) Delete(ctx context.Context, key string) error {
+	return c.store.Delete(ctx, key)
+}
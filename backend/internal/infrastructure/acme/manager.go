@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package acme
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// warmUpHello asks for the ECDSA certificate of a domain, which every supported client accepts
+var warmUpHello = tls.ClientHelloInfo{
+	CipherSuites:      []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256},
+	SupportedCurves:   []tls.CurveID{tls.CurveP256},
+	SupportedVersions: []uint16{tls.VersionTLS13, tls.VersionTLS12},
+}
+
+// Manager obtains the certificates of the domains from the ACME server with autocert, renews
+// them before they expire and answers the challenges of the server, on the HTTPS listener
+// (TLS-ALPN-01) and, with the http-01 challenge, on the plain HTTP one.
+type Manager struct {
+	autocert  *autocert.Manager
+	cfg       config.TLSConfig
+	httpsPort string
+}
+
+// NewManager creates a manager of the certificates of cfg.Domains, stored in cache
+func NewManager(cache autocert.Cache, cfg config.TLSConfig) *Manager {
+	m := &Manager{
+		cfg: cfg,
+		autocert: &autocert.Manager{
+			Prompt:      autocert.AcceptTOS,
+			Cache:       cache,
+			HostPolicy:  autocert.HostWhitelist(cfg.Domains...),
+			RenewBefore: cfg.RenewBefore,
+			Email:       cfg.Email,
+			Client:      &acme.Client{DirectoryURL: cfg.DirectoryURL},
+		},
+	}
+	if _, port, err := net.SplitHostPort(cfg.Addr); err == nil && port != "443" {
+		m.httpsPort = ":" + port
+	}
+	return m
+}
+
+// SetHTTPClient sets the client calling the ACME server
+func (m *Manager) SetHTTPClient(client *http.Client) {
+	m.autocert.Client.HTTPClient = client
+}
+
+// TLSConfig returns the configuration of the HTTPS listener, serving the managed certificates
+// and the TLS-ALPN-01 challenge certificates
+func (m *Manager) TLSConfig() *tls.Config {
+	cfg := m.autocert.TLSConfig()
+	cfg.GetCertificate = m.GetCertificate
+	cfg.MinVersion = tls.VersionTLS12
+	return cfg
+}
+
+// GetCertificate returns the certificate of a handshake. Clients not sending a known server
+// name, such as the health check on localhost, get the certificate of the first domain and
+// decide whether to trust it.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	challenge := len(hello.SupportedProtos) == 1 && hello.SupportedProtos[0] == acme.ALPNProto
+	if !challenge && !slices.Contains(m.cfg.Domains, strings.ToLower(strings.TrimSuffix(hello.ServerName, "."))) {
+		known := *hello
+		known.ServerName = m.cfg.Domains[0]
+		hello = &known
+	}
+	return m.autocert.GetCertificate(hello)
+}
+
+// HTTPHandler redirects every request to HTTPS. With the http-01 challenge it also answers the
+// challenges, which autocert uses when the TLS-ALPN-01 validation fails.
+func (m *Manager) HTTPHandler() http.Handler {
+	redirect := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the managed domains are redirected to, whatever the Host header
+		host := m.cfg.Domains[0]
+		if name, _, err := net.SplitHostPort(r.Host); err == nil && slices.Contains(m.cfg.Domains, strings.ToLower(name)) {
+			host = strings.ToLower(name)
+		} else if slices.Contains(m.cfg.Domains, strings.ToLower(r.Host)) {
+			host = strings.ToLower(r.Host)
+		}
+		http.Redirect(w, r, "https://"+host+m.httpsPort+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+	if m.cfg.Challenge != config.TLSChallengeHTTP {
+		return redirect
+	}
+	return m.autocert.HTTPHandler(redirect)
+}
+
+// Start obtains the missing certificates in the background, so that the first visitors do not
+// wait for the orders; autocert then renews them. The listeners must be serving, as the ACME
+// server connects to them to validate the challenges.
+func (m *Manager) Start() {
+	go func() {
+		for _, domain := range m.cfg.Domains {
+			hello := warmUpHello
+			hello.ServerName = domain
+			cert, err := m.autocert.GetCertificate(&hello)
+			if err != nil {
+				// Handshakes of the domain retry the order
+				logger.Logger.Error("Failed to obtain the TLS certificate", "domain", domain, "error", err.Error())
+				continue
+			}
+			logger.Logger.Info("TLS certificate ready", "domain", domain, "expires_at", cert.Leaf.NotAfter)
+		}
+	}()
+}
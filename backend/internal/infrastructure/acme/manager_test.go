@@ -0,0 +1,519 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package acme
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+)
+
+// idPeACMEIdentifier is the extension carrying the key authorization digest in TLS-ALPN-01
+// challenge certificates (RFC 8737)
+var idPeACMEIdentifier = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+
+type fakeChallenge struct {
+	Type   string `json:"type"`
+	URL    string `json:"url"`
+	Token  string `json:"token"`
+	Status string `json:"status"`
+}
+
+type fakeAuthorization struct {
+	Status     string `json:"status"`
+	Identifier struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"identifier"`
+	Challenges []fakeChallenge `json:"challenges"`
+}
+
+type fakeOrder struct {
+	Status         string   `json:"status"`
+	Authorizations []string `json:"authorizations"`
+	Finalize       string   `json:"finalize"`
+	Certificate    string   `json:"certificate,omitempty"`
+}
+
+// fakeCA is an ACME server issuing certificates for the domains whose challenge the manager
+// answers. It checks the JWS signatures and validates the challenges in-process.
+type fakeCA struct {
+	t       *testing.T
+	url     string
+	manager *Manager
+	caKey   *ecdsa.PrivateKey
+	caCert  *x509.Certificate
+
+	mu         sync.Mutex
+	accounts   map[string]*ecdsa.PublicKey
+	authz      map[string]*fakeAuthorization
+	issued     map[string][]byte
+	nonce      int
+	orders     int
+	validated  []string // Challenge types validated, in order
+	refuseALPN bool     // Fail the TLS-ALPN-01 validations, as behind a TLS terminating proxy
+}
+
+func newFakeCA(t *testing.T) *fakeCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(der)
+
+	ca := &fakeCA{
+		t:        t,
+		caKey:    key,
+		caCert:   caCert,
+		accounts: map[string]*ecdsa.PublicKey{},
+		authz:    map[string]*fakeAuthorization{},
+		issued:   map[string][]byte{},
+	}
+	server := httptest.NewServer(ca)
+	t.Cleanup(server.Close)
+	ca.url = server.URL
+	return ca
+}
+
+func (ca *fakeCA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	ca.nonce++
+	w.Header().Set("Replay-Nonce", fmt.Sprintf("nonce-%d", ca.nonce))
+	switch r.URL.Path {
+	case "/directory":
+		_ = json.NewEncoder(w).Encode(map[string]string{"newNonce": ca.url + "/nonce", "newAccount": ca.url + "/account", "newOrder": ca.url + "/order"})
+		return
+	case "/nonce":
+		return
+	}
+
+	payload, account, err := ca.verify(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"type": "urn:ietf:params:acme:error:malformed", "detail": err.Error()})
+		return
+	}
+
+	kind, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch kind {
+	case "account":
+		w.Header().Set("Location", ca.url+"/account/1")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"status": "valid"})
+	case "order":
+		if name == "" {
+			var req struct {
+				Identifiers []struct{ Value string } `json:"identifiers"`
+			}
+			_ = json.Unmarshal(payload, &req)
+			ca.orders++
+			name = req.Identifiers[0].Value
+			authz := &fakeAuthorization{Status: "pending"}
+			authz.Identifier.Type = "dns"
+			authz.Identifier.Value = name
+			authz.Challenges = []fakeChallenge{
+				{Type: "http-01", URL: ca.url + "/challenge/http-01/" + name, Token: "tok-http-" + name, Status: "pending"},
+				{Type: "tls-alpn-01", URL: ca.url + "/challenge/tls-alpn-01/" + name, Token: "tok-alpn-" + name, Status: "pending"},
+			}
+			ca.authz[name] = authz
+			delete(ca.issued, name)
+			w.Header().Set("Location", ca.url+"/order/"+name)
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.Header().Set("Location", ca.url+"/order/"+name)
+		}
+		_ = json.NewEncoder(w).Encode(ca.order(name))
+	case "authz":
+		_ = json.NewEncoder(w).Encode(ca.authz[name])
+	case "challenge":
+		challenge, domain, _ := strings.Cut(name, "/")
+		ca.validate(challenge, domain, account)
+		_ = json.NewEncoder(w).Encode(fakeChallenge{Type: challenge, URL: ca.url + r.URL.Path, Status: "processing"})
+	case "finalize":
+		var req struct{ CSR string }
+		_ = json.Unmarshal(payload, &req)
+		der, _ := base64.RawURLEncoding.DecodeString(req.CSR)
+		csr, err := x509.ParseCertificateRequest(der)
+		if err != nil || len(csr.DNSNames) != 1 || csr.DNSNames[0] != name || ca.authz[name] == nil || ca.authz[name].Status != "valid" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]string{"type": "urn:ietf:params:acme:error:orderNotReady", "detail": name})
+			return
+		}
+		leaf := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(ca.orders + 1)),
+			Subject:      csr.Subject,
+			DNSNames:     csr.DNSNames,
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, leaf, ca.caCert, csr.PublicKey, ca.caKey)
+		if err != nil {
+			ca.t.Errorf("failed to issue certificate: %v", err)
+		}
+		ca.issued[name] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+		w.Header().Set("Location", ca.url+"/order/"+name)
+		_ = json.NewEncoder(w).Encode(ca.order(name))
+	case "cert":
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(ca.issued[name])
+		_, _ = w.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.caCert.Raw}))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// order returns the state of the order of a domain
+func (ca *fakeCA) order(domain string) fakeOrder {
+	order := fakeOrder{Status: "pending", Authorizations: []string{ca.url + "/authz/" + domain}, Finalize: ca.url + "/finalize/" + domain}
+	switch {
+	case ca.issued[domain] != nil:
+		order.Status = "valid"
+		order.Certificate = ca.url + "/cert/" + domain
+	case ca.authz[domain].Status == "valid":
+		order.Status = "ready"
+	case ca.authz[domain].Status == "invalid":
+		order.Status = "invalid"
+	}
+	return order
+}
+
+// verify checks the JWS of a request and returns its payload and account key
+func (ca *fakeCA) verify(r *http.Request) ([]byte, *ecdsa.PublicKey, error) {
+	var jws struct{ Protected, Payload, Signature string }
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return nil, nil, err
+	}
+	header, _ := base64.RawURLEncoding.DecodeString(jws.Protected)
+	var protected struct {
+		Alg, Nonce, URL, Kid string
+		JWK                  map[string]string
+	}
+	if err := json.Unmarshal(header, &protected); err != nil {
+		return nil, nil, err
+	}
+	if protected.Alg != "ES256" || protected.Nonce == "" || protected.URL != ca.url+r.URL.Path {
+		return nil, nil, fmt.Errorf("invalid protected header %s", header)
+	}
+
+	var key *ecdsa.PublicKey
+	if protected.Kid != "" {
+		key = ca.accounts[protected.Kid]
+	} else if protected.JWK != nil && r.URL.Path == "/account" {
+		x, _ := base64.RawURLEncoding.DecodeString(protected.JWK["x"])
+		y, _ := base64.RawURLEncoding.DecodeString(protected.JWK["y"])
+		key = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		ca.accounts[ca.url+"/account/1"] = key
+	}
+	if key == nil {
+		return nil, nil, fmt.Errorf("unknown account")
+	}
+
+	signature, _ := base64.RawURLEncoding.DecodeString(jws.Signature)
+	digest := sha256.Sum256([]byte(jws.Protected + "." + jws.Payload))
+	if len(signature) != 64 || !ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) {
+		return nil, nil, fmt.Errorf("invalid signature")
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+	return payload, key, nil
+}
+
+// validate checks the response of the manager to a challenge, as the CA would by connecting to it
+func (ca *fakeCA) validate(kind, domain string, account *ecdsa.PublicKey) {
+	authz := ca.authz[domain]
+	authz.Status = "invalid"
+	thumbprint, err := acme.JWKThumbprint(account)
+	if err != nil {
+		ca.t.Errorf("thumbprint: %v", err)
+		return
+	}
+	switch kind {
+	case "http-01":
+		token := "tok-http-" + domain
+		rec := httptest.NewRecorder()
+		ca.manager.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://"+domain+"/.well-known/acme-challenge/"+token, nil))
+		if rec.Body.String() == token+"."+thumbprint {
+			authz.Status = "valid"
+		}
+	case "tls-alpn-01":
+		if ca.refuseALPN {
+			break
+		}
+		cert, err := ca.manager.GetCertificate(&tls.ClientHelloInfo{ServerName: domain, SupportedProtos: []string{acme.ALPNProto}})
+		if err != nil {
+			break
+		}
+		leaf, _ := x509.ParseCertificate(cert.Certificate[0])
+		digest := sha256.Sum256([]byte("tok-alpn-" + domain + "." + thumbprint))
+		for _, ext := range leaf.Extensions {
+			var value []byte
+			if ext.Id.Equal(idPeACMEIdentifier) && ext.Critical {
+				if _, err := asn1.Unmarshal(ext.Value, &value); err == nil && bytes.Equal(value, digest[:]) && leaf.VerifyHostname(domain) == nil {
+					authz.Status = "valid"
+				}
+			}
+		}
+	}
+	if authz.Status == "valid" {
+		ca.validated = append(ca.validated, kind)
+	}
+}
+
+// memoryCache stores the cache entries in memory
+type memoryCache struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *memoryCache) Put(_ context.Context, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = data
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func newTestManager(t *testing.T, ca *fakeCA, cache *memoryCache, challenge string) *Manager {
+	t.Helper()
+	m := NewManager(NewDatabaseCache(cache), config.TLSConfig{
+		Auto:         true,
+		Domains:      []string{"ackify.example.com", "sign.example.com"},
+		Email:        "ops@example.com",
+		DirectoryURL: ca.url + "/directory",
+		Challenge:    challenge,
+		Addr:         ":443",
+		HTTPAddr:     ":80",
+		RenewBefore:  30 * 24 * time.Hour,
+	})
+	// The HTTP handler enables the http-01 challenge, as when the server builds its listeners
+	m.HTTPHandler()
+	ca.manager = m
+	return m
+}
+
+// ecdsaHello is the handshake of a client accepting ECDSA certificates
+func ecdsaHello(serverName string) *tls.ClientHelloInfo {
+	hello := warmUpHello
+	hello.ServerName = serverName
+	return &hello
+}
+
+func TestManager_ObtainsCertificate(t *testing.T) {
+	tests := []struct {
+		name       string
+		challenge  string
+		refuseALPN bool
+		want       string
+		wantErr    bool
+	}{
+		{"tls-alpn-01", config.TLSChallengeALPN, false, "tls-alpn-01", false},
+		{"http-01 after a failed tls-alpn-01", config.TLSChallengeHTTP, true, "http-01", false},
+		{"tls-alpn-01 refused without http-01", config.TLSChallengeALPN, true, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newFakeCA(t)
+			ca.refuseALPN = tt.refuseALPN
+			cache := &memoryCache{data: map[string][]byte{}}
+			m := newTestManager(t, ca, cache, tt.challenge)
+
+			cert, err := m.GetCertificate(ecdsaHello("sign.example.com"))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected the order to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetCertificate failed: %v", err)
+			}
+			if cert.Leaf.VerifyHostname("sign.example.com") != nil || len(cert.Certificate) != 2 {
+				t.Errorf("unexpected certificate %v with %d certificates", cert.Leaf.DNSNames, len(cert.Certificate))
+			}
+			if _, ok := cert.PrivateKey.(*ecdsa.PrivateKey); !ok {
+				t.Errorf("expected an ECDSA certificate, got %T", cert.PrivateKey)
+			}
+			if strings.Join(ca.validated, ",") != tt.want {
+				t.Errorf("expected %s validations, got %v", tt.want, ca.validated)
+			}
+			if cache.data["acme_account+key"] == nil || cache.data["sign.example.com"] == nil {
+				t.Errorf("expected the account key and certificate to be stored, got %d entries", len(cache.data))
+			}
+
+			// A restarted manager serves the stored certificate without ordering
+			orders := ca.orders
+			restarted := newTestManager(t, ca, cache, tt.challenge)
+			again, err := restarted.GetCertificate(ecdsaHello("sign.example.com"))
+			if err != nil || ca.orders != orders || !bytes.Equal(again.Certificate[0], cert.Certificate[0]) {
+				t.Fatalf("expected the stored certificate after a restart, got %d orders, %v", ca.orders, err)
+			}
+		})
+	}
+}
+
+func TestManager_HostPolicy(t *testing.T) {
+	ca := newFakeCA(t)
+	cache := &memoryCache{data: map[string][]byte{}}
+	m := newTestManager(t, ca, cache, config.TLSChallengeALPN)
+
+	// Unknown and missing server names get the certificate of the first domain
+	for _, name := range []string{"", "localhost", "evil.example.org"} {
+		cert, err := m.GetCertificate(ecdsaHello(name))
+		if err != nil {
+			t.Fatalf("GetCertificate(%q) failed: %v", name, err)
+		}
+		if cert.Leaf.VerifyHostname("ackify.example.com") != nil {
+			t.Errorf("GetCertificate(%q) served %v", name, cert.Leaf.DNSNames)
+		}
+	}
+	if ca.orders != 1 {
+		t.Errorf("expected a single order for the first domain, got %d", ca.orders)
+	}
+
+	// Challenge handshakes are never redirected to a managed domain
+	if _, err := m.GetCertificate(&tls.ClientHelloInfo{ServerName: "evil.example.org", SupportedProtos: []string{acme.ALPNProto}}); err == nil {
+		t.Error("expected no challenge certificate for an unknown domain")
+	}
+	if err := m.autocert.HostPolicy(context.Background(), "evil.example.org"); err == nil {
+		t.Error("expected the host policy to refuse an unknown domain")
+	}
+}
+
+func TestManager_ReplacesExpiredCertificate(t *testing.T) {
+	ca := newFakeCA(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(7),
+		DNSNames:     []string{"ackify.example.com"},
+		NotBefore:    time.Now().Add(-100 * 24 * time.Hour),
+		NotAfter:     time.Now().Add(-10 * 24 * time.Hour),
+	}, ca.caCert, &key.PublicKey, ca.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	stored := append(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	cache := &memoryCache{data: map[string][]byte{"ackify.example.com": stored}}
+	m := newTestManager(t, ca, cache, config.TLSChallengeALPN)
+
+	cert, err := m.GetCertificate(ecdsaHello("ackify.example.com"))
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if ca.orders != 1 || !cert.Leaf.NotAfter.After(time.Now()) || bytes.Equal(cache.data["ackify.example.com"], stored) {
+		t.Errorf("expected the expired certificate to be replaced, got %d orders, expiring %s", ca.orders, cert.Leaf.NotAfter)
+	}
+}
+
+func TestManager_HTTPHandler(t *testing.T) {
+	tlsConfig := config.TLSConfig{
+		Domains:   []string{"ackify.example.com", "sign.example.com"},
+		Addr:      ":8443",
+		Challenge: config.TLSChallengeALPN,
+	}
+	cache := &memoryCache{data: map[string][]byte{"known+http-01": []byte("known.thumbprint")}}
+
+	tests := []struct {
+		challenge string
+		target    string
+		status    int
+		location  string
+		body      string
+	}{
+		{config.TLSChallengeALPN, "http://sign.example.com/docs?id=1", http.StatusPermanentRedirect, "https://sign.example.com:8443/docs?id=1", ""},
+		{config.TLSChallengeALPN, "http://evil.example.org/login", http.StatusPermanentRedirect, "https://ackify.example.com:8443/login", ""},
+		{config.TLSChallengeALPN, "http://ackify.example.com/.well-known/acme-challenge/known", http.StatusPermanentRedirect, "https://ackify.example.com:8443/.well-known/acme-challenge/known", ""},
+		{config.TLSChallengeHTTP, "http://sign.example.com/docs?id=1", http.StatusPermanentRedirect, "https://sign.example.com:8443/docs?id=1", ""},
+		{config.TLSChallengeHTTP, "http://ackify.example.com/.well-known/acme-challenge/known", http.StatusOK, "", "known.thumbprint"},
+		{config.TLSChallengeHTTP, "http://ackify.example.com/.well-known/acme-challenge/unknown", http.StatusNotFound, "", ""},
+		{config.TLSChallengeHTTP, "http://evil.example.org/.well-known/acme-challenge/known", http.StatusForbidden, "", ""},
+	}
+	for _, tt := range tests {
+		cfg := tlsConfig
+		cfg.Challenge = tt.challenge
+		handler := NewManager(NewDatabaseCache(cache), cfg).HTTPHandler()
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Code != tt.status || rec.Header().Get("Location") != tt.location || (tt.body != "" && rec.Body.String() != tt.body) {
+			t.Errorf("%s %s: got %d %q %q", tt.challenge, tt.target, rec.Code, rec.Header().Get("Location"), rec.Body.String())
+		}
+	}
+}
+
+func TestDatabaseCache(t *testing.T) {
+	store := &memoryCache{data: map[string][]byte{}}
+	cache := NewDatabaseCache(store)
+	ctx := context.Background()
+
+	if _, err := cache.Get(ctx, "ackify.example.com"); !errors.Is(err, autocert.ErrCacheMiss) {
+		t.Fatalf("expected a cache miss, got %v", err)
+	}
+	if err := cache.Put(ctx, "ackify.example.com", []byte("bundle")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if data, err := cache.Get(ctx, "ackify.example.com"); err != nil || string(data) != "bundle" {
+		t.Fatalf("expected the stored bundle, got %q, %v", data, err)
+	}
+	if err := cache.Delete(ctx, "ackify.example.com"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := cache.Get(ctx, "ackify.example.com"); !errors.Is(err, autocert.ErrCacheMiss) {
+		t.Fatalf("expected a cache miss after Delete, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package anchor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/storage"
+)
+
+// Publisher publishes a Merkle root outside the database and returns where it was published
+type Publisher interface {
+	Publish(ctx context.Context, root *models.MerkleRoot) (string, error)
+}
+
+// rootDocument is the published form of a root, enough to check a proof without the database
+type rootDocument struct {
+	TenantID         string    `json:"tenantId"`
+	RootID           int64     `json:"rootId"`
+	RootHash         string    `json:"rootHash"`
+	Algorithm        string    `json:"algorithm"`
+	FirstSignatureID int64     `json:"firstSignatureId"`
+	LastSignatureID  int64     `json:"lastSignatureId"`
+	LeafCount        int       `json:"leafCount"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+func encodeRoot(root *models.MerkleRoot) ([]byte, error) {
+	data, err := json.MarshalIndent(rootDocument{
+		TenantID:         root.TenantID.String(),
+		RootID:           root.ID,
+		RootHash:         root.RootHash,
+		Algorithm:        "sha256-rfc6962",
+		FirstSignatureID: root.FirstSignatureID,
+		LastSignatureID:  root.LastSignatureID,
+		LeafCount:        root.LeafCount,
+		CreatedAt:        root.CreatedAt.UTC(),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merkle root: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// rootPath is the relative path of a published root, one directory per tenant
+func rootPath(root *models.MerkleRoot) string {
+	return fmt.Sprintf("merkle-roots/%s/%08d.json", root.TenantID, root.ID)
+}
+
+// NewPublisher creates the publisher selected by the configuration, or nil when none is set.
+// The storage anchor writes to the document storage provider.
+func NewPublisher(cfg config.MerkleConfig, provider storage.Provider) (Publisher, error) {
+	switch cfg.AnchorType {
+	case "":
+		return nil, nil
+	case models.MerkleAnchorStorage:
+		if provider == nil {
+			return nil, fmt.Errorf("storage anchor requires a storage provider")
+		}
+		return NewStoragePublisher(provider), nil
+	case models.MerkleAnchorGit:
+		return NewGitPublisher(cfg.AnchorGitDir), nil
+	case models.MerkleAnchorHTTPS:
+		return NewHTTPSPublisher(cfg.AnchorURL, cfg.AnchorToken, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown merkle anchor: %s", cfg.AnchorType)
+	}
+}
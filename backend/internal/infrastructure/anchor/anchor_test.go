@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package anchor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func testRoot() *models.MerkleRoot {
+	return &models.MerkleRoot{
+		ID:               7,
+		TenantID:         uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		RootHash:         "q83vEjRWeJq83vEjRWeJq83vEjRWeJq83vEjRWeJq80=",
+		FirstSignatureID: 10,
+		LastSignatureID:  19,
+		LeafCount:        10,
+		CreatedAt:        time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+	}
+}
+
+type fakeUploader struct {
+	key         string
+	data        []byte
+	contentType string
+}
+
+func (f *fakeUploader) Upload(_ context.Context, key string, reader io.Reader, _ int64, contentType string) error {
+	f.key, f.contentType = key, contentType
+	f.data, _ = io.ReadAll(reader)
+	return nil
+}
+
+func (f *fakeUploader) Type() string { return "s3" }
+
+func TestStoragePublisher(t *testing.T) {
+	uploader := &fakeUploader{}
+	ref, err := NewStoragePublisher(uploader).Publish(context.Background(), testRoot())
+	if err != nil {
+		t.Fatalf("publish err: %v", err)
+	}
+
+	wantKey := "merkle-roots/11111111-1111-1111-1111-111111111111/00000007.json"
+	if uploader.key != wantKey || ref != "s3:"+wantKey {
+		t.Errorf("unexpected key %q and ref %q", uploader.key, ref)
+	}
+	var doc rootDocument
+	if err := json.Unmarshal(uploader.data, &doc); err != nil {
+		t.Fatalf("published root is not JSON: %v", err)
+	}
+	if doc.RootHash != testRoot().RootHash || doc.LeafCount != 10 || uploader.contentType != "application/json" {
+		t.Errorf("unexpected published root: %+v", doc)
+	}
+}
+
+func TestHTTPSPublisher(t *testing.T) {
+	var gotAuth, gotKey string
+	var got rootDocument
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotKey = r.Header.Get("Authorization"), r.Header.Get("Idempotency-Key")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 42}`))
+	}))
+	defer srv.Close()
+
+	ref, err := NewHTTPSPublisher(srv.URL, "secret", srv.Client()).Publish(context.Background(), testRoot())
+	if err != nil {
+		t.Fatalf("publish err: %v", err)
+	}
+	if ref != srv.URL+"#42" {
+		t.Errorf("unexpected ref %q", ref)
+	}
+	if gotAuth != "Bearer secret" || gotKey != "11111111-1111-1111-1111-111111111111-7" || got.RootID != 7 {
+		t.Errorf("unexpected request: auth=%q key=%q root=%+v", gotAuth, gotKey, got)
+	}
+
+	t.Run("error status", func(t *testing.T) {
+		failing := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+
+		if _, err := NewHTTPSPublisher(failing.URL, "", failing.Client()).Publish(context.Background(), testRoot()); err == nil {
+			t.Error("expected an error on 503")
+		}
+	})
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestGitPublisher(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	remote := filepath.Join(base, "remote.git")
+	clone := filepath.Join(base, "clone")
+	runGit(t, base, "init", "--bare", "-b", "main", remote)
+	runGit(t, base, "clone", remote, clone)
+	runGit(t, clone, "config", "user.name", "Ackify")
+	runGit(t, clone, "config", "user.email", "ackify@example.com")
+	runGit(t, clone, "commit", "--allow-empty", "-m", "init")
+	runGit(t, clone, "push", "-u", "origin", "main")
+
+	publisher := NewGitPublisher(clone)
+	ref, err := publisher.Publish(context.Background(), testRoot())
+	if err != nil {
+		t.Fatalf("publish err: %v", err)
+	}
+	head := runGit(t, remote, "rev-parse", "main")
+	if ref != "git:"+head {
+		t.Errorf("expected ref of the pushed commit %s, got %q", head, ref)
+	}
+	if _, err := os.Stat(filepath.Join(clone, rootPath(testRoot()))); err != nil {
+		t.Errorf("root file not written: %v", err)
+	}
+
+	// Publishing again (e.g. after a failed push) does not create a new commit
+	again, err := publisher.Publish(context.Background(), testRoot())
+	if err != nil {
+		t.Fatalf("republish err: %v", err)
+	}
+	if again != ref {
+		t.Errorf("expected same commit on republish, got %q", again)
+	}
+}
+
+func TestNewPublisher(t *testing.T) {
+	if p, err := NewPublisher(config.MerkleConfig{}, nil); err != nil || p != nil {
+		t.Errorf("expected no publisher without anchor, got %v, %v", p, err)
+	}
+	if _, err := NewPublisher(config.MerkleConfig{AnchorType: "storage"}, nil); err == nil {
+		t.Error("expected storage anchor to require a provider")
+	}
+	if p, err := NewPublisher(config.MerkleConfig{AnchorType: "https", AnchorURL: "https://anchor.example.com"}, nil); err != nil || p == nil {
+		t.Errorf("expected HTTPS publisher, got %v, %v", p, err)
+	}
+}
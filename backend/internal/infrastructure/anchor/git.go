@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package anchor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// GitPublisher commits each root to a local clone and pushes it to the clone's remote.
+// The clone, its committer identity, its remote and push credentials are prepared by the operator.
+type GitPublisher struct {
+	dir string
+	mu  sync.Mutex // git commands of one clone must not interleave
+}
+
+// NewGitPublisher creates a publisher committing to the clone at dir
+func NewGitPublisher(dir string) *GitPublisher {
+	return &GitPublisher{dir: dir}
+}
+
+func (p *GitPublisher) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = p.dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Publish writes, commits and pushes the root and returns "git:<commit>".
+// A root already committed by a failed push is pushed again without a new commit.
+func (p *GitPublisher) Publish(ctx context.Context, root *models.MerkleRoot) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	data, err := encodeRoot(root)
+	if err != nil {
+		return "", err
+	}
+	path := rootPath(root)
+	full := filepath.Join(p.dir, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0750); err != nil {
+		return "", fmt.Errorf("failed to create merkle root directory: %w", err)
+	}
+	if err := os.WriteFile(full, data, 0640); err != nil {
+		return "", fmt.Errorf("failed to write merkle root: %w", err)
+	}
+
+	if _, err := p.git(ctx, "add", "--", path); err != nil {
+		return "", err
+	}
+	status, err := p.git(ctx, "status", "--porcelain", "--", path)
+	if err != nil {
+		return "", err
+	}
+	if status != "" {
+		message := fmt.Sprintf("Merkle root %d: %s", root.ID, root.RootHash)
+		if _, err := p.git(ctx, "commit", "-m", message, "--", path); err != nil {
+			return "", err
+		}
+	}
+	commit, err := p.git(ctx, "log", "-1", "--format=%H", "--", path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := p.git(ctx, "push"); err != nil {
+		return "", err
+	}
+	return "git:" + commit, nil
+}
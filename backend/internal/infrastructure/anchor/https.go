@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package anchor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const httpsTimeout = 15 * time.Second
+
+// HTTPDoer abstracts http.Client for testing
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPSPublisher POSTs each root as JSON to an endpoint, such as a timestamping or notary service
+type HTTPSPublisher struct {
+	url   string
+	token string
+	http  HTTPDoer
+}
+
+// NewHTTPSPublisher creates a publisher posting to url with an optional bearer token;
+// a nil httpClient uses a client with a 15s timeout
+func NewHTTPSPublisher(url, token string, httpClient HTTPDoer) *HTTPSPublisher {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: httpsTimeout}
+	}
+	return &HTTPSPublisher{url: url, token: token, http: httpClient}
+}
+
+// Publish posts the root. The reference is the "ref" or "id" field of a JSON response,
+// falling back to the endpoint URL.
+func (p *HTTPSPublisher) Publish(ctx context.Context, root *models.MerkleRoot) (string, error) {
+	data, err := encodeRoot(root)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to build anchor request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", fmt.Sprintf("%s-%d", root.TenantID, root.ID))
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to post merkle root: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("anchor endpoint returned status %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		Ref string          `json:"ref"`
+		ID  json.RawMessage `json:"id"`
+	}
+	if json.Unmarshal(body, &reply) == nil {
+		if reply.Ref != "" {
+			return reply.Ref, nil
+		}
+		if len(reply.ID) > 0 && string(reply.ID) != "null" {
+			return p.url + "#" + string(bytes.Trim(reply.ID, `"`)), nil
+		}
+	}
+	return p.url, nil
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package anchor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// uploader is the part of the storage provider used to publish roots
+type uploader interface {
+	Upload(ctx context.Context, key string, reader io.Reader, size int64, contentType string) error
+	Type() string
+}
+
+// StoragePublisher writes each root as a JSON file in the document storage (local or S3)
+type StoragePublisher struct {
+	storage uploader
+}
+
+// NewStoragePublisher creates a publisher writing to the given storage provider
+func NewStoragePublisher(storage uploader) *StoragePublisher {
+	return &StoragePublisher{storage: storage}
+}
+
+// Publish uploads the root and returns "<storage type>:<key>"
+func (p *StoragePublisher) Publish(ctx context.Context, root *models.MerkleRoot) (string, error) {
+	data, err := encodeRoot(root)
+	if err != nil {
+		return "", err
+	}
+	key := rootPath(root)
+	if err := p.storage.Upload(ctx, key, bytes.NewReader(data), int64(len(data)), "application/json"); err != nil {
+		return "", fmt.Errorf("failed to upload merkle root: %w", err)
+	}
+	return p.storage.Type() + ":" + key, nil
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// chunkSize is the size of the INSTREAM chunks sent to clamd, below its default StreamMaxLength
+const chunkSize = 64 * 1024
+
+// ClamdClient scans files with a clamd daemon over its INSTREAM command
+type ClamdClient struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamdClient creates a client for the clamd daemon at address, "tcp://host:port" or
+// "unix:///path/to/clamd.sock"
+func NewClamdClient(address string, timeout time.Duration) (*ClamdClient, error) {
+	network, addr, ok := strings.Cut(address, "://")
+	if !ok || (network != "tcp" && network != "unix") || addr == "" {
+		return nil, fmt.Errorf("invalid clamd address %q, expected tcp://host:port or unix:///path", address)
+	}
+	return &ClamdClient{network: network, address: addr, timeout: timeout}, nil
+}
+
+// Scan streams r to clamd and returns the name of the virus found, or "" when the file is clean.
+// An error means the file could not be scanned, not that it is infected.
+func (c *ClamdClient) Scan(ctx context.Context, r io.Reader) (string, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("failed to send clamd command: %w", err)
+	}
+
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(append(size, buf[:n]...)); err != nil {
+				// clamd closes the stream once StreamMaxLength is reached and says so in its reply
+				break
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read file to scan: %w", readErr)
+		}
+	}
+	_, _ = conn.Write([]byte{0, 0, 0, 0})
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && len(reply) == 0 {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseReply(string(bytes.TrimRight(reply, "\x00\n")))
+}
+
+// parseReply reads a clamd INSTREAM reply: "stream: OK", "stream: <virus> FOUND" or
+// "<message> ERROR"
+func parseReply(reply string) (string, error) {
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return "", nil
+	case strings.HasSuffix(result, " FOUND"):
+		return strings.TrimSuffix(result, " FOUND"), nil
+	case strings.HasSuffix(result, " ERROR"):
+		return "", fmt.Errorf("clamd error: %s", strings.TrimSuffix(result, " ERROR"))
+	default:
+		return "", fmt.Errorf("unexpected clamd reply %q", reply)
+	}
+}
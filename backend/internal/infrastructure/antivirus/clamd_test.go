@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd answers INSTREAM commands: files containing "EICAR" are infected
+func fakeClamd(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				if cmd, err := r.ReadString(0); err != nil || cmd != "zINSTREAM\x00" {
+					_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+				var data bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&data, r, int64(size)); err != nil {
+						return
+					}
+				}
+				reply := "stream: OK"
+				if bytes.Contains(data.Bytes(), []byte("EICAR")) {
+					reply = "stream: Eicar-Test-Signature FOUND"
+				}
+				_, _ = conn.Write([]byte(reply + "\x00"))
+			}(conn)
+		}
+	}()
+	return "tcp://" + ln.Addr().String()
+}
+
+func TestClamdClient_Scan(t *testing.T) {
+	client, err := NewClamdClient(fakeClamd(t), 5*time.Second)
+	if err != nil {
+		t.Fatalf("NewClamdClient: %v", err)
+	}
+	ctx := context.Background()
+
+	virus, err := client.Scan(ctx, strings.NewReader(strings.Repeat("clean content ", 10000)))
+	if err != nil || virus != "" {
+		t.Fatalf("clean file: got %q, %v", virus, err)
+	}
+
+	virus, err = client.Scan(ctx, strings.NewReader("X5O!P%@AP EICAR-STANDARD-ANTIVIRUS-TEST-FILE"))
+	if err != nil || virus != "Eicar-Test-Signature" {
+		t.Fatalf("infected file: got %q, %v", virus, err)
+	}
+}
+
+func TestClamdClient_Unreachable(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	client, _ := NewClamdClient("tcp://"+addr, time.Second)
+	if _, err := client.Scan(context.Background(), strings.NewReader("data")); err == nil {
+		t.Fatal("expected an error when clamd is unreachable")
+	}
+}
+
+func TestNewClamdClient_InvalidAddress(t *testing.T) {
+	for _, addr := range []string{"", "clamav:3310", "http://clamav:3310", "tcp://"} {
+		if _, err := NewClamdClient(addr, time.Second); err == nil {
+			t.Errorf("NewClamdClient(%q): expected an error", addr)
+		}
+	}
+}
+
+func TestParseReply(t *testing.T) {
+	if _, err := parseReply("INSTREAM size limit exceeded. ERROR"); err == nil {
+		t.Error("expected a clamd error")
+	}
+	if _, err := parseReply("garbage"); err == nil {
+		t.Error("expected an unexpected reply error")
+	}
+}
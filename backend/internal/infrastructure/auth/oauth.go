@@ -18,3 +18,15 @@ type SessionRepository interface {
 	DeleteBySessionID(ctx context.Context, sessionID string) error
 	DeleteExpired(ctx context.Context, olderThan time.Duration) (int64, error)
 }
+
+// UserSessionRepository defines the interface for server-side browser session storage
+type UserSessionRepository interface {
+	Create(ctx context.Context, session *models.UserSession) error
+	GetBySessionID(ctx context.Context, sessionID string) (*models.UserSession, error)
+	Touch(ctx context.Context, sessionID, ipAddress string, expiresAt time.Time) error
+	ListActiveByEmail(ctx context.Context, email string) ([]*models.UserSession, error)
+	Revoke(ctx context.Context, id int64, email string) (bool, error)
+	RevokeAllByEmail(ctx context.Context, email, exceptSessionID string) (int64, error)
+	RevokeBySessionID(ctx context.Context, sessionID string) error
+	DeleteExpired(ctx context.Context, olderThan time.Duration) (int64, error)
+}
@@ -4,9 +4,10 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"golang.org/x/oauth2"
@@ -16,13 +17,27 @@ import (
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
+const (
+	// defaultSessionMaxLifetime applies when no absolute lifetime is configured
+	defaultSessionMaxLifetime = 30 * 24 * time.Hour
+
+	// sessionTouchInterval throttles last-seen updates of server-side sessions
+	sessionTouchInterval = time.Minute
+)
+
+var ErrSessionStoreDisabled = errors.New("server-side session store not configured")
+
 // SessionService manages user sessions independently of authentication method
 // This service is always required, regardless of whether OAuth or MagicLink is used
 type SessionService struct {
 	sessionStore  *sessions.CookieStore
 	sessionRepo   SessionRepository
+	userSessions  UserSessionRepository
 	encryptionKey []byte
 	secureCookies bool
+	idleTimeout   time.Duration
+	maxLifetime   time.Duration
+	expiryWarning time.Duration
 }
 
 // SessionServiceConfig holds configuration for the session service
@@ -30,24 +45,40 @@ type SessionServiceConfig struct {
 	CookieSecret  []byte
 	SecureCookies bool
 	SessionRepo   SessionRepository
+	// UserSessions tracks sessions server-side so that they can be listed and revoked.
+	// Without it, the user is stored in the cookie itself.
+	UserSessions UserSessionRepository
+	// IdleTimeout signs out server-side sessions without activity for this long (0 disables it).
+	// Activity renews the session up to MaxLifetime after sign-in, which defaults to 30 days.
+	IdleTimeout time.Duration
+	MaxLifetime time.Duration
+	// ExpiryWarning is how long before expiry the auth check flags the session for the SPA
+	ExpiryWarning time.Duration
 }
 
 // NewSessionService creates a new session service
 func NewSessionService(config SessionServiceConfig) *SessionService {
 	sessionStore := sessions.NewCookieStore(config.CookieSecret)
 
+	maxLifetime := config.MaxLifetime
+	if maxLifetime <= 0 {
+		maxLifetime = defaultSessionMaxLifetime
+	}
+
 	// Configure session options globally on the store
 	sessionStore.Options = &sessions.Options{
 		Path:     "/",
 		HttpOnly: true,
 		Secure:   config.SecureCookies,
 		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400 * 30, // 30 days
+		MaxAge:   int(maxLifetime.Seconds()),
 	}
 
 	logger.Logger.Info("Session store configured",
 		"secure_cookies", config.SecureCookies,
-		"max_age_days", 30)
+		"max_lifetime", maxLifetime.String(),
+		"idle_timeout", config.IdleTimeout.String(),
+		"server_side", config.UserSessions != nil)
 
 	// Use CookieSecret as encryption key (must be 32 bytes for AES-256)
 	encryptionKey := config.CookieSecret
@@ -66,8 +97,12 @@ func NewSessionService(config SessionServiceConfig) *SessionService {
 	return &SessionService{
 		sessionStore:  sessionStore,
 		sessionRepo:   config.SessionRepo,
+		userSessions:  config.UserSessions,
 		encryptionKey: encryptionKey,
 		secureCookies: config.SecureCookies,
+		idleTimeout:   config.IdleTimeout,
+		maxLifetime:   maxLifetime,
+		expiryWarning: config.ExpiryWarning,
 	}
 }
 
@@ -79,6 +114,10 @@ func (s *SessionService) GetUser(r *http.Request) (*models.User, error) {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
+	if s.userSessions != nil {
+		return s.getTrackedUser(r, session)
+	}
+
 	userJSON, ok := session.Values["user"].(string)
 	if !ok || userJSON == "" {
 		logger.Logger.Debug("GetUser: no user in session",
@@ -97,6 +136,26 @@ func (s *SessionService) GetUser(r *http.Request) (*models.User, error) {
 	return &user, nil
 }
 
+// PeekUser returns the user of the request with the expiry of their server-side session, without
+// recording activity: polling the auth check must not keep an idle session alive. The expiry is
+// nil for cookie-only sessions.
+func (s *SessionService) PeekUser(r *http.Request) (*models.User, *models.SessionExpiry, error) {
+	if s.userSessions == nil {
+		user, err := s.GetUser(r)
+		return user, nil, err
+	}
+
+	session, err := s.sessionStore.Get(r, sessionName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	record, err := s.loadTrackedSession(r, session)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record.User(), s.expiryOf(record, time.Now()), nil
+}
+
 // SetUser stores a user in the session (works for both OAuth and MagicLink)
 func (s *SessionService) SetUser(w http.ResponseWriter, r *http.Request, user *models.User) error {
 	// Always create a fresh new session to ensure session ID is generated
@@ -107,18 +166,23 @@ func (s *SessionService) SetUser(w http.ResponseWriter, r *http.Request, user *m
 		return fmt.Errorf("failed to create new session: %w", err)
 	}
 
-	userJSON, err := json.Marshal(user)
-	if err != nil {
-		logger.Logger.Error("SetUser: failed to marshal user", "error", err.Error())
-		return fmt.Errorf("failed to marshal user: %w", err)
-	}
-
 	logger.Logger.Debug("SetUser: saving user to new session",
 		"email", user.Email,
 		"secure_cookies", s.secureCookies,
 		"session_is_new", session.IsNew)
 
-	session.Values["user"] = string(userJSON)
+	if s.userSessions != nil {
+		if err := s.startTrackedSession(r, session, user); err != nil {
+			return err
+		}
+	} else {
+		userJSON, err := json.Marshal(user)
+		if err != nil {
+			logger.Logger.Error("SetUser: failed to marshal user", "error", err.Error())
+			return fmt.Errorf("failed to marshal user: %w", err)
+		}
+		session.Values["user"] = string(userJSON)
+	}
 
 	// Session options are already configured globally on the store
 	// No need to set them again here
@@ -141,6 +205,12 @@ func (s *SessionService) SetUser(w http.ResponseWriter, r *http.Request, user *m
 func (s *SessionService) Logout(w http.ResponseWriter, r *http.Request) {
 	session, _ := s.sessionStore.Get(r, sessionName)
 
+	if sid, ok := session.Values["sid"].(string); ok && sid != "" && s.userSessions != nil {
+		if err := s.userSessions.RevokeBySessionID(r.Context(), sid); err != nil {
+			logger.Logger.Warn("Logout: failed to revoke server-side session", "error", err.Error())
+		}
+	}
+
 	// Clear all session values first (important for cookie-based sessions)
 	for key := range session.Values {
 		delete(session.Values, key)
@@ -155,6 +225,65 @@ func (s *SessionService) Logout(w http.ResponseWriter, r *http.Request) {
 	logger.Logger.Debug("Logout: session cleared")
 }
 
+// CurrentSessionID returns the server-side session ID of the request, or "" if none
+func (s *SessionService) CurrentSessionID(r *http.Request) string {
+	session, err := s.sessionStore.Get(r, sessionName)
+	if err != nil {
+		return ""
+	}
+	sid, _ := session.Values["sid"].(string)
+	return sid
+}
+
+// ListSessions returns the active sessions of a user, flagging currentSessionID
+func (s *SessionService) ListSessions(ctx context.Context, email, currentSessionID string) ([]*models.UserSession, error) {
+	if s.userSessions == nil {
+		return nil, ErrSessionStoreDisabled
+	}
+
+	sessions, err := s.userSessions.ListActiveByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Current = currentSessionID != "" && session.SessionID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession revokes one session of a user. The user is signed out on their next request.
+func (s *SessionService) RevokeSession(ctx context.Context, email string, id int64) error {
+	if s.userSessions == nil {
+		return ErrSessionStoreDisabled
+	}
+
+	revoked, err := s.userSessions.Revoke(ctx, id, email)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return models.ErrSessionNotFound
+	}
+
+	logger.Logger.Info("User session revoked", "email", email, "session", id)
+	return nil
+}
+
+// RevokeSessions revokes every session of a user except exceptSessionID (if not empty)
+func (s *SessionService) RevokeSessions(ctx context.Context, email, exceptSessionID string) (int64, error) {
+	if s.userSessions == nil {
+		return 0, ErrSessionStoreDisabled
+	}
+
+	count, err := s.userSessions.RevokeAllByEmail(ctx, email, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Logger.Info("User sessions revoked", "email", email, "count", count)
+	return count, nil
+}
+
 // GetSession returns the raw session (useful for storing additional data like OAuth state)
 func (s *SessionService) GetSession(r *http.Request) (*sessions.Session, error) {
 	return s.sessionStore.Get(r, sessionName)
@@ -222,30 +351,116 @@ func (s *SessionService) StoreRefreshToken(ctx context.Context, w http.ResponseW
 	return nil
 }
 
-// generateSessionID generates a unique session ID for OAuth sessions
-func generateSessionID() string {
-	nonce, _ := crypto.GenerateNonce()
-	return nonce
+// getTrackedUser resolves the user of a server-side session and records the activity, which
+// slides the idle deadline of the session
+func (s *SessionService) getTrackedUser(r *http.Request, session *sessions.Session) (*models.User, error) {
+	record, err := s.loadTrackedSession(r, session)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if now.Sub(record.LastSeenAt) > sessionTouchInterval {
+		if err := s.userSessions.Touch(r.Context(), record.SessionID, getClientIP(r), s.deadline(record.CreatedAt, now)); err != nil {
+			logger.Logger.Warn("GetUser: failed to record session activity", "error", err.Error())
+		}
+	}
+
+	return record.User(), nil
 }
 
-// getClientIP extracts the client IP address from the request
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header (if behind proxy)
-	forwarded := r.Header.Get("X-Forwarded-For")
-	if forwarded != "" {
-		// Take the first IP in the list
-		parts := strings.Split(forwarded, ",")
-		if len(parts) > 0 {
-			return strings.TrimSpace(parts[0])
+// loadTrackedSession returns the server-side session of the cookie if it is neither revoked
+// nor expired, idle or past its absolute lifetime
+func (s *SessionService) loadTrackedSession(r *http.Request, session *sessions.Session) (*models.UserSession, error) {
+	sid, ok := session.Values["sid"].(string)
+	if !ok || sid == "" {
+		logger.Logger.Debug("GetUser: no session ID in cookie")
+		return nil, models.ErrUnauthorized
+	}
+
+	record, err := s.userSessions.GetBySessionID(r.Context(), sid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if record == nil || !record.IsActive() {
+		logger.Logger.Debug("GetUser: session revoked or expired")
+		return nil, models.ErrUnauthorized
+	}
+	if !time.Now().Before(s.deadline(record.CreatedAt, record.LastSeenAt)) {
+		logger.Logger.Debug("GetUser: session idle or past its lifetime")
+		return nil, models.ErrUnauthorized
+	}
+	return record, nil
+}
+
+// deadline returns when a session started at createdAt and last active at lastSeen expires:
+// after the idle timeout, but never later than the absolute lifetime
+func (s *SessionService) deadline(createdAt, lastSeen time.Time) time.Time {
+	deadline := createdAt.Add(s.maxLifetime)
+	if s.idleTimeout > 0 {
+		if idle := lastSeen.Add(s.idleTimeout); idle.Before(deadline) {
+			return idle
 		}
 	}
+	return deadline
+}
+
+// expiryOf describes the deadlines of a session for the SPA
+func (s *SessionService) expiryOf(record *models.UserSession, now time.Time) *models.SessionExpiry {
+	expiry := &models.SessionExpiry{
+		ExpiresAt:         s.deadline(record.CreatedAt, record.LastSeenAt),
+		AbsoluteExpiresAt: record.CreatedAt.Add(s.maxLifetime),
+		WarnBefore:        int(s.expiryWarning.Seconds()),
+	}
+	if s.idleTimeout > 0 {
+		idle := record.LastSeenAt.Add(s.idleTimeout)
+		expiry.IdleExpiresAt = &idle
+	}
+	remaining := expiry.ExpiresAt.Sub(now)
+	expiry.ExpiresIn = int(remaining.Seconds())
+	expiry.Warning = remaining <= s.expiryWarning
+	return expiry
+}
 
-	// Check X-Real-IP header
-	realIP := r.Header.Get("X-Real-IP")
-	if realIP != "" {
-		return realIP
+// startTrackedSession records a new server-side session and stores its ID in the cookie.
+// A session previously carried by the cookie is revoked.
+func (s *SessionService) startTrackedSession(r *http.Request, session *sessions.Session, user *models.User) error {
+	ctx := r.Context()
+	if previous, ok := session.Values["sid"].(string); ok && previous != "" {
+		if err := s.userSessions.RevokeBySessionID(ctx, previous); err != nil {
+			logger.Logger.Warn("SetUser: failed to revoke previous session", "error", err.Error())
+		}
 	}
 
-	// Fallback to RemoteAddr
+	now := time.Now()
+	record := &models.UserSession{
+		SessionID:   generateSessionID(),
+		UserSub:     user.Sub,
+		UserEmail:   user.Email,
+		UserName:    user.Name,
+		UserPicture: user.Picture,
+		IPAddress:   getClientIP(r),
+		UserAgent:   r.UserAgent(),
+		ExpiresAt:   s.deadline(now, now),
+	}
+	if err := s.userSessions.Create(ctx, record); err != nil {
+		logger.Logger.Error("SetUser: failed to create server-side session", "error", err.Error())
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	delete(session.Values, "user")
+	session.Values["sid"] = record.SessionID
+	return nil
+}
+
+// generateSessionID generates a unique session ID for OAuth and user sessions
+func generateSessionID() string {
+	nonce, _ := crypto.GenerateNonce()
+	return nonce
+}
+
+// getClientIP returns the client address resolved by the RealIP middleware.
+// Forwarding headers are not read here: only trusted proxies may set them.
+func getClientIP(r *http.Request) string {
 	return r.RemoteAddr
 }
@@ -4,6 +4,7 @@ package auth
 import (
 	"context"
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -355,29 +356,21 @@ func TestGetClientIP(t *testing.T) {
 			expectedIP: "192.168.1.100:12345",
 		},
 		{
-			name:       "from X-Real-IP",
+			name:       "X-Real-IP is ignored",
 			remoteAddr: "192.168.1.100:12345",
 			xRealIP:    "203.0.113.45",
-			expectedIP: "203.0.113.45",
-		},
-		{
-			name:          "from X-Forwarded-For single",
-			remoteAddr:    "192.168.1.100:12345",
-			xForwardedFor: "203.0.113.45",
-			expectedIP:    "203.0.113.45",
+			expectedIP: "192.168.1.100:12345",
 		},
 		{
-			name:          "from X-Forwarded-For multiple",
+			name:          "X-Forwarded-For is ignored",
 			remoteAddr:    "192.168.1.100:12345",
-			xForwardedFor: "203.0.113.45, 198.51.100.67, 192.0.2.123",
-			expectedIP:    "203.0.113.45",
+			xForwardedFor: "203.0.113.45, 198.51.100.67",
+			expectedIP:    "192.168.1.100:12345",
 		},
 		{
-			name:          "X-Forwarded-For takes precedence over X-Real-IP",
-			remoteAddr:    "192.168.1.100:12345",
-			xForwardedFor: "203.0.113.45",
-			xRealIP:       "198.51.100.67",
-			expectedIP:    "203.0.113.45",
+			name:       "address resolved by RealIP",
+			remoteAddr: "203.0.113.45",
+			expectedIP: "203.0.113.45",
 		},
 	}
 
@@ -399,3 +392,276 @@ func TestGetClientIP(t *testing.T) {
 		})
 	}
 }
+
+// mockUserSessionRepository implements UserSessionRepository for testing
+type mockUserSessionRepository struct {
+	sessions []*models.UserSession
+}
+
+func (m *mockUserSessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	session.ID = int64(len(m.sessions) + 1)
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = session.CreatedAt
+	m.sessions = append(m.sessions, session)
+	return nil
+}
+
+func (m *mockUserSessionRepository) GetBySessionID(ctx context.Context, sessionID string) (*models.UserSession, error) {
+	for _, s := range m.sessions {
+		if s.SessionID == sessionID {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockUserSessionRepository) Touch(ctx context.Context, sessionID, ipAddress string, expiresAt time.Time) error {
+	for _, s := range m.sessions {
+		if s.SessionID == sessionID {
+			s.LastSeenAt, s.IPAddress, s.ExpiresAt = time.Now(), ipAddress, expiresAt
+		}
+	}
+	return nil
+}
+
+func (m *mockUserSessionRepository) ListActiveByEmail(ctx context.Context, email string) ([]*models.UserSession, error) {
+	var active []*models.UserSession
+	for _, s := range m.sessions {
+		if s.UserEmail == email && s.IsActive() {
+			active = append(active, s)
+		}
+	}
+	return active, nil
+}
+
+func (m *mockUserSessionRepository) Revoke(ctx context.Context, id int64, email string) (bool, error) {
+	for _, s := range m.sessions {
+		if s.ID == id && s.UserEmail == email && s.RevokedAt == nil {
+			now := time.Now()
+			s.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockUserSessionRepository) RevokeAllByEmail(ctx context.Context, email, exceptSessionID string) (int64, error) {
+	var count int64
+	for _, s := range m.sessions {
+		if s.UserEmail == email && s.RevokedAt == nil && s.SessionID != exceptSessionID {
+			now := time.Now()
+			s.RevokedAt = &now
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockUserSessionRepository) RevokeBySessionID(ctx context.Context, sessionID string) error {
+	for _, s := range m.sessions {
+		if s.SessionID == sessionID && s.RevokedAt == nil {
+			now := time.Now()
+			s.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockUserSessionRepository) DeleteExpired(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return 0, nil
+}
+
+// signIn creates a session and returns a request carrying its cookie
+func signIn(t *testing.T, service *SessionService, user *models.User) *http.Request {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	if err := service.SetUser(rec, req, user); err != nil {
+		t.Fatalf("SetUser() failed: %v", err)
+	}
+
+	next := httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range rec.Result().Cookies() {
+		next.AddCookie(cookie)
+	}
+	return next
+}
+
+func TestSessionService_ServerSideSessions(t *testing.T) {
+	repo := &mockUserSessionRepository{}
+	service := NewSessionService(SessionServiceConfig{
+		CookieSecret: []byte("32-byte-secret-for-secure-cookies"),
+		UserSessions: repo,
+	})
+	testUser := &models.User{Sub: "test-user-123", Email: "test@example.com", Name: "Test User"}
+
+	laptop := signIn(t, service, testUser)
+	phone := signIn(t, service, testUser)
+
+	if len(repo.sessions) != 2 {
+		t.Fatalf("sessions = %d, want 2", len(repo.sessions))
+	}
+	if repo.sessions[0].UserAgent != "test-agent" || repo.sessions[0].ExpiresAt.IsZero() {
+		t.Errorf("unexpected session: %+v", repo.sessions[0])
+	}
+
+	user, err := service.GetUser(laptop)
+	if err != nil {
+		t.Fatalf("GetUser() failed: %v", err)
+	}
+	if user.Sub != testUser.Sub || user.Email != testUser.Email || user.Name != testUser.Name {
+		t.Errorf("user = %+v, want %+v", user, testUser)
+	}
+
+	t.Run("ListSessions flags the current session", func(t *testing.T) {
+		sessions, err := service.ListSessions(context.Background(), testUser.Email, service.CurrentSessionID(phone))
+		if err != nil {
+			t.Fatalf("ListSessions() failed: %v", err)
+		}
+		if len(sessions) != 2 || sessions[0].Current || !sessions[1].Current {
+			t.Errorf("unexpected sessions: %+v", sessions)
+		}
+	})
+
+	t.Run("revoked session is signed out", func(t *testing.T) {
+		count, err := service.RevokeSessions(context.Background(), testUser.Email, service.CurrentSessionID(phone))
+		if err != nil {
+			t.Fatalf("RevokeSessions() failed: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("revoked = %d, want 1", count)
+		}
+		if _, err := service.GetUser(laptop); !errors.Is(err, models.ErrUnauthorized) {
+			t.Errorf("GetUser() on revoked session: err = %v, want ErrUnauthorized", err)
+		}
+		if _, err := service.GetUser(phone); err != nil {
+			t.Errorf("GetUser() on kept session failed: %v", err)
+		}
+	})
+
+	t.Run("RevokeSession only matches the user's sessions", func(t *testing.T) {
+		id := repo.sessions[1].ID
+		if err := service.RevokeSession(context.Background(), "other@example.com", id); !errors.Is(err, models.ErrSessionNotFound) {
+			t.Errorf("err = %v, want ErrSessionNotFound", err)
+		}
+		if err := service.RevokeSession(context.Background(), testUser.Email, id); err != nil {
+			t.Fatalf("RevokeSession() failed: %v", err)
+		}
+		if _, err := service.GetUser(phone); !errors.Is(err, models.ErrUnauthorized) {
+			t.Errorf("GetUser() on revoked session: err = %v, want ErrUnauthorized", err)
+		}
+	})
+}
+
+func TestSessionService_ServerSideSessions_Logout(t *testing.T) {
+	repo := &mockUserSessionRepository{}
+	service := NewSessionService(SessionServiceConfig{
+		CookieSecret: []byte("32-byte-secret-for-secure-cookies"),
+		UserSessions: repo,
+	})
+
+	req := signIn(t, service, &models.User{Sub: "test-user-123", Email: "test@example.com"})
+	service.Logout(httptest.NewRecorder(), req)
+
+	if repo.sessions[0].RevokedAt == nil {
+		t.Error("Logout() should revoke the server-side session")
+	}
+	// The old cookie must not be replayable
+	if _, err := service.GetUser(req); !errors.Is(err, models.ErrUnauthorized) {
+		t.Errorf("GetUser() after logout: err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestSessionService_ServerSideSessions_RejectsCookieOnlySession(t *testing.T) {
+	cookieSecret := []byte("32-byte-secret-for-secure-cookies")
+	legacy := NewSessionService(SessionServiceConfig{CookieSecret: cookieSecret})
+	req := signIn(t, legacy, &models.User{Sub: "test-user-123", Email: "test@example.com"})
+
+	service := NewSessionService(SessionServiceConfig{
+		CookieSecret: cookieSecret,
+		UserSessions: &mockUserSessionRepository{},
+	})
+	if _, err := service.GetUser(req); !errors.Is(err, models.ErrUnauthorized) {
+		t.Errorf("GetUser() with a cookie-only session: err = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestSessionService_IdleTimeout(t *testing.T) {
+	repo := &mockUserSessionRepository{}
+	service := NewSessionService(SessionServiceConfig{
+		CookieSecret:  []byte("32-byte-secret-for-secure-cookies"),
+		UserSessions:  repo,
+		IdleTimeout:   15 * time.Minute,
+		ExpiryWarning: 2 * time.Minute,
+	})
+	req := signIn(t, service, &models.User{Sub: "test-user-123", Email: "test@example.com"})
+	record := repo.sessions[0]
+	if until := time.Until(record.ExpiresAt); until > 15*time.Minute || until < 14*time.Minute {
+		t.Fatalf("new session expires in %s, want the idle timeout", until)
+	}
+
+	t.Run("activity slides the idle deadline", func(t *testing.T) {
+		record.LastSeenAt = time.Now().Add(-10 * time.Minute)
+		if _, err := service.GetUser(req); err != nil {
+			t.Fatalf("GetUser() failed: %v", err)
+		}
+		if until := time.Until(record.ExpiresAt); until < 14*time.Minute {
+			t.Errorf("renewed session expires in %s, want the idle timeout", until)
+		}
+	})
+
+	t.Run("PeekUser reports the expiry without recording activity", func(t *testing.T) {
+		lastSeen := time.Now().Add(-14 * time.Minute)
+		record.LastSeenAt = lastSeen
+		user, expiry, err := service.PeekUser(req)
+		if err != nil || user.Email != "test@example.com" {
+			t.Fatalf("PeekUser() = %+v, %v", user, err)
+		}
+		if !record.LastSeenAt.Equal(lastSeen) {
+			t.Error("PeekUser() must not record activity")
+		}
+		if expiry.IdleExpiresAt == nil || !expiry.ExpiresAt.Equal(lastSeen.Add(15*time.Minute)) || !expiry.Warning || expiry.WarnBefore != 120 {
+			t.Errorf("unexpected expiry: %+v", expiry)
+		}
+		if expiry.ExpiresIn > 60 || expiry.ExpiresIn < 55 {
+			t.Errorf("expiresIn = %d, want about 60 seconds", expiry.ExpiresIn)
+		}
+	})
+
+	t.Run("idle session is signed out", func(t *testing.T) {
+		record.LastSeenAt = time.Now().Add(-16 * time.Minute)
+		if _, err := service.GetUser(req); !errors.Is(err, models.ErrUnauthorized) {
+			t.Errorf("GetUser() on idle session: err = %v, want ErrUnauthorized", err)
+		}
+	})
+}
+
+func TestSessionService_MaxLifetime(t *testing.T) {
+	repo := &mockUserSessionRepository{}
+	service := NewSessionService(SessionServiceConfig{
+		CookieSecret: []byte("32-byte-secret-for-secure-cookies"),
+		UserSessions: repo,
+		IdleTimeout:  time.Hour,
+		MaxLifetime:  8 * time.Hour,
+	})
+	req := signIn(t, service, &models.User{Sub: "test-user-123", Email: "test@example.com"})
+	record := repo.sessions[0]
+
+	// Activity renews the session up to the absolute lifetime only
+	record.CreatedAt = time.Now().Add(-7*time.Hour - 30*time.Minute)
+	record.LastSeenAt = time.Now().Add(-5 * time.Minute)
+	if _, err := service.GetUser(req); err != nil {
+		t.Fatalf("GetUser() failed: %v", err)
+	}
+	if !record.ExpiresAt.Equal(record.CreatedAt.Add(8 * time.Hour)) {
+		t.Errorf("expires at %s, want the absolute lifetime %s", record.ExpiresAt, record.CreatedAt.Add(8*time.Hour))
+	}
+
+	// Past the lifetime, the session is signed out even if its stored expiry is later
+	record.CreatedAt = time.Now().Add(-9 * time.Hour)
+	record.ExpiresAt = time.Now().Add(time.Hour)
+	if _, err := service.GetUser(req); !errors.Is(err, models.ErrUnauthorized) {
+		t.Errorf("GetUser() past the lifetime: err = %v, want ErrUnauthorized", err)
+	}
+}
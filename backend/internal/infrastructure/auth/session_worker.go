@@ -10,14 +10,25 @@ import (
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 )
 
+// defaultUserSessionRetention is how long expired or revoked user sessions are kept by default
+const defaultUserSessionRetention = 7 * 24 * time.Hour
+
 // SessionWorker handles background cleanup of expired OAuth sessions
 type SessionWorker struct {
 	sessionRepo     SessionRepository
+	userSessions    UserSessionRepository
 	cleanupInterval time.Duration
 	cleanupAge      time.Duration
+	userRetention   time.Duration
+
+	// runMu keeps forced runs from overlapping scheduled ones
+	runMu   sync.Mutex
+	statsMu sync.Mutex
+	stats   models.SessionCleanupStats
 
 	// RLS support
 	db      *sql.DB
@@ -35,13 +46,16 @@ type SessionWorker struct {
 type SessionWorkerConfig struct {
 	CleanupInterval time.Duration // How often to run cleanup (default: 24 hours)
 	CleanupAge      time.Duration // Age of sessions to delete (default: 37 days = 30 + 7 grace period)
+	// UserSessionRetention is how long user sessions are kept after expiry or revocation (default: 7 days)
+	UserSessionRetention time.Duration
 }
 
 // DefaultSessionWorkerConfig returns default session worker configuration
 func DefaultSessionWorkerConfig() SessionWorkerConfig {
 	return SessionWorkerConfig{
-		CleanupInterval: 24 * time.Hour,            // Run cleanup once per day
-		CleanupAge:      (30 + 7) * 24 * time.Hour, // Delete sessions older than 37 days
+		CleanupInterval:      24 * time.Hour,            // Run cleanup once per day
+		CleanupAge:           (30 + 7) * 24 * time.Hour, // Delete sessions older than 37 days
+		UserSessionRetention: defaultUserSessionRetention,
 	}
 }
 
@@ -54,6 +68,9 @@ func NewSessionWorker(sessionRepo SessionRepository, config SessionWorkerConfig,
 	if config.CleanupAge <= 0 {
 		config.CleanupAge = 37 * 24 * time.Hour
 	}
+	if config.UserSessionRetention <= 0 {
+		config.UserSessionRetention = defaultUserSessionRetention
+	}
 
 	ctx, cancel := context.WithCancel(parentCtx)
 
@@ -61,6 +78,7 @@ func NewSessionWorker(sessionRepo SessionRepository, config SessionWorkerConfig,
 		sessionRepo:     sessionRepo,
 		cleanupInterval: config.CleanupInterval,
 		cleanupAge:      config.CleanupAge,
+		userRetention:   config.UserSessionRetention,
 		db:              db,
 		tenants:         tenants,
 		ctx:             ctx,
@@ -69,6 +87,12 @@ func NewSessionWorker(sessionRepo SessionRepository, config SessionWorkerConfig,
 	}
 }
 
+// SetUserSessionRepository enables cleanup of expired and revoked user sessions.
+// They are kept for the user session retention after expiry or revocation.
+func (w *SessionWorker) SetUserSessionRepository(repo UserSessionRepository) {
+	w.userSessions = repo
+}
+
 // Start begins the cleanup worker
 func (w *SessionWorker) Start() error {
 	w.mu.Lock()
@@ -154,42 +178,109 @@ func (w *SessionWorker) performCleanup() {
 	ctx, cancel := context.WithTimeout(w.ctx, 5*time.Minute)
 	defer cancel()
 
+	// Errors are logged and counted by RunCleanup
+	_, _ = w.RunCleanup(ctx, false)
+}
+
+// RunCleanup purges the expired sessions now and records the run in the stats. Forced runs
+// are those requested by an admin; a run waits for the one in progress, if any.
+func (w *SessionWorker) RunCleanup(ctx context.Context, forced bool) (*models.SessionCleanupRun, error) {
+	w.runMu.Lock()
+	defer w.runMu.Unlock()
+
 	logger.Logger.Debug("Starting OAuth session cleanup",
-		"older_than", w.cleanupAge)
+		"older_than", w.cleanupAge,
+		"forced", forced)
 
-	var deleted int64
+	run := &models.SessionCleanupRun{StartedAt: time.Now(), Forced: forced}
 	var err error
 
 	// Use RLS context if db and tenants are available
 	if w.db != nil && w.tenants != nil {
 		tenantID, tenantErr := w.tenants.CurrentTenant(ctx)
 		if tenantErr != nil {
-			logger.Logger.Error("Failed to get tenant for session cleanup",
-				"error", tenantErr.Error())
-			return
+			err = fmt.Errorf("failed to get tenant: %w", tenantErr)
+		} else {
+			err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+				return w.deleteExpired(txCtx, run)
+			})
 		}
-
-		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
-			var cleanupErr error
-			deleted, cleanupErr = w.sessionRepo.DeleteExpired(txCtx, w.cleanupAge)
-			return cleanupErr
-		})
 	} else {
 		// No RLS - direct repository access (for tests)
-		deleted, err = w.sessionRepo.DeleteExpired(ctx, w.cleanupAge)
+		err = w.deleteExpired(ctx, run)
 	}
+	run.Duration = time.Since(run.StartedAt)
+	if err != nil {
+		// The transaction is rolled back: nothing was purged
+		run.OAuthSessions, run.UserSessions = 0, 0
+		run.Error = err.Error()
+	}
+	w.record(run)
 
 	if err != nil {
 		logger.Logger.Error("Failed to cleanup expired OAuth sessions",
 			"error", err.Error())
-		return
+		return run, err
 	}
 
-	if deleted > 0 {
+	if run.OAuthSessions > 0 || run.UserSessions > 0 {
 		logger.Logger.Info("Cleaned up expired OAuth sessions",
-			"count", deleted,
-			"older_than", w.cleanupAge)
+			"oauth_sessions", run.OAuthSessions,
+			"user_sessions", run.UserSessions,
+			"older_than", w.cleanupAge,
+			"duration", run.Duration)
 	} else {
 		logger.Logger.Debug("No expired OAuth sessions to clean up")
 	}
+	return run, nil
+}
+
+// deleteExpired removes expired OAuth sessions, then expired or revoked user sessions
+func (w *SessionWorker) deleteExpired(ctx context.Context, run *models.SessionCleanupRun) error {
+	deleted, err := w.sessionRepo.DeleteExpired(ctx, w.cleanupAge)
+	if err != nil {
+		return err
+	}
+	run.OAuthSessions = deleted
+	if w.userSessions == nil {
+		return nil
+	}
+
+	userDeleted, err := w.userSessions.DeleteExpired(ctx, w.userRetention)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup user sessions: %w", err)
+	}
+	run.UserSessions = userDeleted
+	return nil
+}
+
+// record adds a run to the stats
+func (w *SessionWorker) record(run *models.SessionCleanupRun) {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	w.stats.Runs++
+	if run.Error != "" {
+		w.stats.Failures++
+	}
+	w.stats.OAuthSessionsTotal += run.OAuthSessions
+	w.stats.UserSessionsTotal += run.UserSessions
+	last := *run
+	w.stats.LastRun = &last
+}
+
+// Stats reports the cleanup settings and the rows purged since startup
+func (w *SessionWorker) Stats() models.SessionCleanupStats {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	stats := w.stats
+	stats.Interval = w.cleanupInterval
+	stats.OAuthRetention = w.cleanupAge
+	stats.UserRetention = w.userRetention
+	if stats.LastRun != nil {
+		last := *stats.LastRun
+		stats.LastRun = &last
+	}
+	return stats
 }
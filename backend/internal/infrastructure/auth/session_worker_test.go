@@ -324,3 +324,62 @@ func TestSessionWorker_ContextCancellation(t *testing.T) {
 		t.Errorf("Stop() failed: %v", err)
 	}
 }
+
+// purgingUserSessionRepo records the retention of the user session cleanup
+type purgingUserSessionRepo struct {
+	mockUserSessionRepository
+	retention time.Duration
+	deleted   int64
+}
+
+func (m *purgingUserSessionRepo) DeleteExpired(ctx context.Context, olderThan time.Duration) (int64, error) {
+	m.retention = olderThan
+	return m.deleted, nil
+}
+
+func TestSessionWorker_RunCleanupStats(t *testing.T) {
+	var fail atomic.Bool
+	repo := &mockSessionRepoForWorker{
+		deleteExpiredFn: func(ctx context.Context, olderThan time.Duration) (int64, error) {
+			if fail.Load() {
+				return 0, errors.New("database error")
+			}
+			return 3, nil
+		},
+	}
+	userSessions := &purgingUserSessionRepo{deleted: 2}
+
+	worker := NewSessionWorker(repo, SessionWorkerConfig{
+		CleanupInterval:      time.Hour,
+		CleanupAge:           48 * time.Hour,
+		UserSessionRetention: 72 * time.Hour,
+	}, context.Background(), nil, &mockTenantProvider{tenantID: uuid.New()})
+	worker.SetUserSessionRepository(userSessions)
+
+	run, err := worker.RunCleanup(context.Background(), true)
+	if err != nil {
+		t.Fatalf("RunCleanup() failed: %v", err)
+	}
+	if run.OAuthSessions != 3 || run.UserSessions != 2 || !run.Forced {
+		t.Errorf("unexpected run %+v", run)
+	}
+	if userSessions.retention != 72*time.Hour {
+		t.Errorf("expected the user session retention, got %s", userSessions.retention)
+	}
+
+	fail.Store(true)
+	if _, err := worker.RunCleanup(context.Background(), false); err == nil {
+		t.Fatal("expected the repository error")
+	}
+
+	stats := worker.Stats()
+	if stats.Runs != 2 || stats.Failures != 1 || stats.OAuthSessionsTotal != 3 || stats.UserSessionsTotal != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats.LastRun == nil || stats.LastRun.Error == "" || stats.LastRun.Forced {
+		t.Errorf("expected the failed scheduled run last, got %+v", stats.LastRun)
+	}
+	if stats.Interval != time.Hour || stats.OAuthRetention != 48*time.Hour {
+		t.Errorf("expected the cleanup settings, got %+v", stats)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const adminRoleColumns = `id, tenant_id, email, role, department_id, COALESCE(granted_by, ''), created_at, updated_at`
+
+// AdminRoleRepository handles database operations for delegated admin roles
+type AdminRoleRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewAdminRoleRepository creates a new admin role repository
+func NewAdminRoleRepository(db *sql.DB, tenants providers.TenantProvider) *AdminRoleRepository {
+	return &AdminRoleRepository{db: db, tenants: tenants}
+}
+
+// Upsert assigns a role to an email, replacing any previous role. departmentID is the
+// department of a department-admin, and must be nil for other roles.
+func (r *AdminRoleRepository) Upsert(ctx context.Context, email string, role models.AdminRole, departmentID *int64, grantedBy string) (*models.AdminRoleAssignment, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO admin_roles (tenant_id, email, role, department_id, granted_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (tenant_id, email) DO UPDATE
+		SET role = EXCLUDED.role, department_id = EXCLUDED.department_id, granted_by = EXCLUDED.granted_by, updated_at = now()
+		RETURNING ` + adminRoleColumns
+
+	assignment, err := scanAdminRole(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, normalizeRoleEmail(email), role, departmentID, grantedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert admin role: %w", err)
+	}
+	return assignment, nil
+}
+
+// GetByEmail returns the role assigned to an email, or nil if none
+// RLS policy automatically filters by tenant_id
+func (r *AdminRoleRepository) GetByEmail(ctx context.Context, email string) (*models.AdminRoleAssignment, error) {
+	query := `SELECT ` + adminRoleColumns + ` FROM admin_roles WHERE email = $1`
+
+	assignment, err := scanAdminRole(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, normalizeRoleEmail(email)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin role: %w", err)
+	}
+	return assignment, nil
+}
+
+// List returns all role assignments ordered by email
+// RLS policy automatically filters by tenant_id
+func (r *AdminRoleRepository) List(ctx context.Context) ([]*models.AdminRoleAssignment, error) {
+	query := `SELECT ` + adminRoleColumns + ` FROM admin_roles ORDER BY email ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list admin roles: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.AdminRoleAssignment
+	for rows.Next() {
+		assignment, err := scanAdminRole(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, assignment)
+	}
+	return out, rows.Err()
+}
+
+// Delete revokes the role assigned to an email
+// RLS policy automatically filters by tenant_id
+func (r *AdminRoleRepository) Delete(ctx context.Context, email string) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM admin_roles WHERE email = $1`, normalizeRoleEmail(email))
+	if err != nil {
+		return fmt.Errorf("failed to delete admin role: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrRoleNotFound
+	}
+	return nil
+}
+
+func normalizeRoleEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+type adminRoleScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAdminRole(row adminRoleScanner) (*models.AdminRoleAssignment, error) {
+	a := &models.AdminRoleAssignment{}
+	if err := row.Scan(&a.ID, &a.TenantID, &a.Email, &a.Role, &a.DepartmentID, &a.GrantedBy, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,58 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestAdminRoleRepository_CRUD(t *testing.T) {
+	tdb := SetupTestDB(t)
+	repo := NewAdminRoleRepository(tdb.DB, tdb.TenantProvider)
+	ctx := context.Background()
+
+	assignment, err := repo.Upsert(ctx, " Viewer@Example.com ", models.AdminRoleViewer, nil, "admin@example.com")
+	if err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	if assignment.Email != "viewer@example.com" || assignment.Role != models.AdminRoleViewer {
+		t.Fatalf("unexpected assignment: %+v", assignment)
+	}
+
+	// Upsert replaces the previous role
+	if _, err := repo.Upsert(ctx, "viewer@example.com", models.AdminRoleDocumentManager, nil, "admin@example.com"); err != nil {
+		t.Fatalf("second upsert err: %v", err)
+	}
+	got, err := repo.GetByEmail(ctx, "VIEWER@example.com")
+	if err != nil {
+		t.Fatalf("get err: %v", err)
+	}
+	if got == nil || got.Role != models.AdminRoleDocumentManager {
+		t.Fatalf("expected document-manager role, got %+v", got)
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected 1 assignment, got %d", len(list))
+	}
+
+	if err := repo.Delete(ctx, "viewer@example.com"); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if err := repo.Delete(ctx, "viewer@example.com"); !errors.Is(err, models.ErrRoleNotFound) {
+		t.Fatalf("expected ErrRoleNotFound, got %v", err)
+	}
+	got, err = repo.GetByEmail(ctx, "viewer@example.com")
+	if err != nil || got != nil {
+		t.Fatalf("expected no assignment, got %+v, err %v", got, err)
+	}
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const announcementColumns = `id, tenant_id, severity, message, audience, starts_at, ends_at, created_by, created_at, updated_at`
+
+// AnnouncementRepository handles database operations for announcement banners
+type AnnouncementRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewAnnouncementRepository creates a new announcement repository
+func NewAnnouncementRepository(db *sql.DB, tenants providers.TenantProvider) *AnnouncementRepository {
+	return &AnnouncementRepository{db: db, tenants: tenants}
+}
+
+func scanAnnouncement(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Announcement, error) {
+	a := &models.Announcement{}
+	var startsAt, endsAt sql.NullTime
+	err := scanner.Scan(&a.ID, &a.TenantID, &a.Severity, &a.Message, &a.Audience, &startsAt, &endsAt,
+		&a.CreatedBy, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startsAt.Valid {
+		a.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		a.EndsAt = &endsAt.Time
+	}
+	return a, nil
+}
+
+// Create inserts a new announcement
+func (r *AnnouncementRepository) Create(ctx context.Context, input models.AnnouncementInput) (*models.Announcement, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO announcements (tenant_id, severity, message, audience, starts_at, ends_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + announcementColumns
+
+	a, err := scanAnnouncement(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, input.Severity, input.Message, input.Audience, input.StartsAt, input.EndsAt, input.CreatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return a, nil
+}
+
+// Update modifies an announcement
+// RLS policy automatically filters by tenant_id
+func (r *AnnouncementRepository) Update(ctx context.Context, id int64, input models.AnnouncementInput) (*models.Announcement, error) {
+	query := `
+		UPDATE announcements
+		SET severity = $1, message = $2, audience = $3, starts_at = $4, ends_at = $5, updated_at = now()
+		WHERE id = $6
+		RETURNING ` + announcementColumns
+
+	a, err := scanAnnouncement(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		input.Severity, input.Message, input.Audience, input.StartsAt, input.EndsAt, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+	return a, nil
+}
+
+// Delete removes an announcement
+// RLS policy automatically filters by tenant_id
+func (r *AnnouncementRepository) Delete(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrAnnouncementNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves an announcement by its ID
+// RLS policy automatically filters by tenant_id
+func (r *AnnouncementRepository) GetByID(ctx context.Context, id int64) (*models.Announcement, error) {
+	query := `SELECT ` + announcementColumns + ` FROM announcements WHERE id = $1`
+
+	a, err := scanAnnouncement(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrAnnouncementNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+	return a, nil
+}
+
+// List retrieves all announcements, past and scheduled ones included, newest first
+// RLS policy automatically filters by tenant_id
+func (r *AnnouncementRepository) List(ctx context.Context) ([]*models.Announcement, error) {
+	return r.list(ctx, `SELECT `+announcementColumns+` FROM announcements ORDER BY created_at DESC, id DESC`)
+}
+
+// ListActive retrieves the announcements within their publication window at the given time,
+// most severe first
+// RLS policy automatically filters by tenant_id
+func (r *AnnouncementRepository) ListActive(ctx context.Context, at time.Time) ([]*models.Announcement, error) {
+	return r.list(ctx, `SELECT `+announcementColumns+` FROM announcements
+		WHERE (starts_at IS NULL OR starts_at <= $1) AND (ends_at IS NULL OR ends_at > $1)
+		ORDER BY CASE severity WHEN 'critical' THEN 0 WHEN 'warning' THEN 1 ELSE 2 END, created_at DESC, id DESC`, at)
+}
+
+func (r *AnnouncementRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.Announcement, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	defer rows.Close()
+
+	var announcements []*models.Announcement
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
@@ -0,0 +1,87 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestAnnouncementRepository_CRUD(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewAnnouncementRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	a, err := repo.Create(ctx, models.AnnouncementInput{
+		Severity:  models.AnnouncementWarning,
+		Message:   "Signing paused for maintenance Friday",
+		Audience:  models.AudienceAll,
+		CreatedBy: "admin@example.com",
+	})
+	if err != nil || a.ID == 0 || a.StartsAt != nil {
+		t.Fatalf("unexpected announcement %+v, %v", a, err)
+	}
+
+	ends := time.Now().Add(time.Hour).UTC()
+	updated, err := repo.Update(ctx, a.ID, models.AnnouncementInput{
+		Severity: models.AnnouncementCritical,
+		Message:  "Signing paused now",
+		Audience: models.AudienceAuthenticated,
+		EndsAt:   &ends,
+	})
+	if err != nil || updated.Severity != models.AnnouncementCritical || updated.EndsAt == nil {
+		t.Fatalf("unexpected update %+v, %v", updated, err)
+	}
+	if got, err := repo.GetByID(ctx, a.ID); err != nil || got.Message != "Signing paused now" {
+		t.Errorf("unexpected announcement %+v, %v", got, err)
+	}
+
+	if err := repo.Delete(ctx, a.ID); err != nil {
+		t.Fatalf("Delete err: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, a.ID); !errors.Is(err, models.ErrAnnouncementNotFound) {
+		t.Errorf("expected ErrAnnouncementNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, a.ID); !errors.Is(err, models.ErrAnnouncementNotFound) {
+		t.Errorf("expected ErrAnnouncementNotFound on second delete, got %v", err)
+	}
+}
+
+func TestAnnouncementRepository_ListActive(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewAnnouncementRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	inputs := []models.AnnouncementInput{
+		{Severity: models.AnnouncementInfo, Message: "current", Audience: models.AudienceAll},
+		{Severity: models.AnnouncementCritical, Message: "ending", Audience: models.AudienceAll, StartsAt: &past, EndsAt: &future},
+		{Severity: models.AnnouncementWarning, Message: "scheduled", Audience: models.AudienceAll, StartsAt: &future},
+		{Severity: models.AnnouncementWarning, Message: "expired", Audience: models.AudienceAll, EndsAt: &past},
+	}
+	for _, input := range inputs {
+		if _, err := repo.Create(ctx, input); err != nil {
+			t.Fatalf("Create err: %v", err)
+		}
+	}
+
+	active, err := repo.ListActive(ctx, now)
+	if err != nil {
+		t.Fatalf("ListActive err: %v", err)
+	}
+	if len(active) != 2 || active[0].Message != "ending" || active[1].Message != "current" {
+		t.Errorf("expected the critical then the info announcement, got %+v", active)
+	}
+
+	all, err := repo.List(ctx)
+	if err != nil || len(all) != 4 {
+		t.Errorf("expected 4 announcements, got %d (err %v)", len(all), err)
+	}
+}
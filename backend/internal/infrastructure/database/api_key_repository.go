@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const apiKeyColumns = `id, tenant_id, name, key_prefix, scopes, created_by, created_at, last_used_at, revoked_at`
+
+// APIKeyRepository handles database operations for integration API keys
+type APIKeyRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *sql.DB, tenants providers.TenantProvider) *APIKeyRepository {
+	return &APIKeyRepository{db: db, tenants: tenants}
+}
+
+// Create stores a new API key from the hash of its secret value
+func (r *APIKeyRepository) Create(ctx context.Context, name, keyPrefix, keyHash string, scopes []models.Permission, createdBy string) (*models.APIKey, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO api_keys (tenant_id, name, key_prefix, key_hash, scopes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + apiKeyColumns
+
+	key, err := scanAPIKey(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, name, keyPrefix, keyHash, pq.Array(permissionsToStrings(scopes)), createdBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return key, nil
+}
+
+// GetByHash returns the API key matching a hashed secret, or nil if none
+// RLS policy automatically filters by tenant_id
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+
+	key, err := scanAPIKey(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, keyHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	return key, nil
+}
+
+// List returns all API keys, newest first
+// RLS policy automatically filters by tenant_id
+func (r *APIKeyRepository) List(ctx context.Context) ([]*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, key)
+	}
+	return out, rows.Err()
+}
+
+// Revoke disables an API key; revoking an already revoked key is a no-op
+// RLS policy automatically filters by tenant_id
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, now()) WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records the last use of an API key
+// RLS policy automatically filters by tenant_id
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id int64) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `UPDATE api_keys SET last_used_at = now() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
+
+type apiKeyScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row apiKeyScanner) (*models.APIKey, error) {
+	k := &models.APIKey{}
+	var scopes []string
+	if err := row.Scan(&k.ID, &k.TenantID, &k.Name, &k.KeyPrefix, pq.Array(&scopes), &k.CreatedBy, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt); err != nil {
+		return nil, err
+	}
+	k.Scopes = make([]models.Permission, 0, len(scopes))
+	for _, s := range scopes {
+		k.Scopes = append(k.Scopes, models.Permission(s))
+	}
+	return k, nil
+}
+
+func permissionsToStrings(permissions []models.Permission) []string {
+	out := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		out = append(out, string(p))
+	}
+	return out
+}
@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const campaignColumns = `id, tenant_id, title, doc_id, schedule, active, next_run_at, last_run_at, created_by, created_at, updated_at`
+
+// CampaignRepository handles database operations for recurring acknowledgement campaigns
+type CampaignRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewCampaignRepository creates a new campaign repository
+func NewCampaignRepository(db *sql.DB, tenants providers.TenantProvider) *CampaignRepository {
+	return &CampaignRepository{db: db, tenants: tenants}
+}
+
+// Create inserts a new campaign
+func (r *CampaignRepository) Create(ctx context.Context, input models.CampaignInput) (*models.Campaign, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO campaigns (tenant_id, title, doc_id, schedule, active, next_run_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + campaignColumns
+
+	c, err := scanCampaign(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID,
+		input.Title,
+		input.DocID,
+		input.Schedule,
+		input.Active,
+		input.NextRunAt,
+		input.CreatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create campaign: %w", err)
+	}
+	return c, nil
+}
+
+// Update modifies an existing campaign
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) Update(ctx context.Context, id int64, input models.CampaignInput) (*models.Campaign, error) {
+	query := `
+		UPDATE campaigns
+		SET title = $1, doc_id = $2, schedule = $3, active = $4, next_run_at = $5, updated_at = now()
+		WHERE id = $6
+		RETURNING ` + campaignColumns
+
+	c, err := scanCampaign(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		input.Title,
+		input.DocID,
+		input.Schedule,
+		input.Active,
+		input.NextRunAt,
+		id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update campaign: %w", err)
+	}
+	return c, nil
+}
+
+// Delete removes a campaign and its run history
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) Delete(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM campaigns WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete campaign: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrCampaignNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a campaign by its ID
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) GetByID(ctx context.Context, id int64) (*models.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE id = $1`
+
+	c, err := scanCampaign(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrCampaignNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+	return c, nil
+}
+
+// List retrieves paginated campaigns, most recent first
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) List(ctx context.Context, limit, offset int) ([]*models.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns ORDER BY id DESC LIMIT $1 OFFSET $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Campaign
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// ListDue returns active campaigns whose next run is at or before now
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) ListDue(ctx context.Context, now time.Time) ([]*models.Campaign, error) {
+	query := `SELECT ` + campaignColumns + ` FROM campaigns WHERE active = TRUE AND next_run_at <= $1 ORDER BY next_run_at ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.Campaign
+	for rows.Next() {
+		c, err := scanCampaign(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// MarkRun records the last run time and schedules the next one
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) MarkRun(ctx context.Context, id int64, ranAt, nextRunAt time.Time) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`UPDATE campaigns SET last_run_at = $1, next_run_at = $2, updated_at = now() WHERE id = $3`,
+		ranAt, nextRunAt, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark campaign run: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrCampaignNotFound
+	}
+	return nil
+}
+
+// CreateRun inserts a run history entry
+func (r *CampaignRepository) CreateRun(ctx context.Context, input models.CampaignRunInput) (*models.CampaignRun, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO campaign_runs (tenant_id, campaign_id, doc_id, triggered_by, expected_count, notified_count)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+		RETURNING id, tenant_id, campaign_id, doc_id, started_at, COALESCE(triggered_by, ''), expected_count, notified_count
+	`
+
+	run := &models.CampaignRun{}
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID,
+		input.CampaignID,
+		input.DocID,
+		input.TriggeredBy,
+		input.ExpectedCount,
+		input.NotifiedCount,
+	).Scan(
+		&run.ID, &run.TenantID, &run.CampaignID, &run.DocID, &run.StartedAt, &run.TriggeredBy, &run.ExpectedCount, &run.NotifiedCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create campaign run: %w", err)
+	}
+	return run, nil
+}
+
+// ListRuns retrieves the run history of a campaign with live completion counts
+// RLS policy automatically filters by tenant_id
+func (r *CampaignRepository) ListRuns(ctx context.Context, campaignID int64, limit, offset int) ([]*models.CampaignRun, error) {
+	query := `
+		SELECT
+			cr.id, cr.tenant_id, cr.campaign_id, cr.doc_id, cr.started_at, COALESCE(cr.triggered_by, ''),
+			cr.expected_count, cr.notified_count,
+			(SELECT COUNT(*) FROM expected_signers es
+				WHERE es.doc_id = cr.doc_id
+				  AND EXISTS (SELECT 1 FROM signatures s
+					WHERE s.tenant_id = es.tenant_id AND s.doc_id = es.doc_id AND s.match_key = es.match_key)) AS signed_count
+		FROM campaign_runs cr
+		WHERE cr.campaign_id = $1
+		ORDER BY cr.started_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, campaignID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaign runs: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.CampaignRun
+	for rows.Next() {
+		run := &models.CampaignRun{}
+		if err := rows.Scan(
+			&run.ID, &run.TenantID, &run.CampaignID, &run.DocID, &run.StartedAt, &run.TriggeredBy,
+			&run.ExpectedCount, &run.NotifiedCount, &run.SignedCount,
+		); err != nil {
+			return nil, err
+		}
+		if run.ExpectedCount > 0 {
+			run.CompletionRate = float64(run.SignedCount) / float64(run.ExpectedCount) * 100
+		}
+		out = append(out, run)
+	}
+	return out, rows.Err()
+}
+
+type campaignScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCampaign(row campaignScanner) (*models.Campaign, error) {
+	c := &models.Campaign{}
+	var createdBy sql.NullString
+	err := row.Scan(
+		&c.ID, &c.TenantID, &c.Title, &c.DocID, &c.Schedule, &c.Active, &c.NextRunAt, &c.LastRunAt, &createdBy, &c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.CreatedBy = createdBy.String
+	return c, nil
+}
@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestCampaignRepository_CRUD_And_Runs(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	if _, err := docRepo.Create(ctx, "policy-doc", models.DocumentInput{Title: "Security policy"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	repo := NewCampaignRepository(tdb.DB, tdb.TenantProvider)
+	past := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	c, err := repo.Create(ctx, models.CampaignInput{
+		Title:     "Annual policy review",
+		DocID:     "policy-doc",
+		Schedule:  models.CampaignScheduleYearly,
+		Active:    true,
+		NextRunAt: past,
+		CreatedBy: "admin@example.com",
+	})
+	if err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+	if c.ID == 0 || c.Schedule != models.CampaignScheduleYearly {
+		t.Fatalf("unexpected campaign: %+v", c)
+	}
+
+	due, err := repo.ListDue(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("list due err: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != c.ID {
+		t.Fatalf("expected campaign to be due, got %d", len(due))
+	}
+
+	next := models.CampaignScheduleYearly.Next(past)
+	if err := repo.MarkRun(ctx, c.ID, past, next); err != nil {
+		t.Fatalf("mark run err: %v", err)
+	}
+	due, err = repo.ListDue(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("list due err: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected no due campaign after run, got %d", len(due))
+	}
+
+	run, err := repo.CreateRun(ctx, models.CampaignRunInput{CampaignID: c.ID, DocID: "policy-doc-run", ExpectedCount: 2, NotifiedCount: 2})
+	if err != nil {
+		t.Fatalf("create run err: %v", err)
+	}
+	if run.ID == 0 || run.TriggeredBy != "" {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+
+	runs, err := repo.ListRuns(ctx, c.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("list runs err: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ExpectedCount != 2 || runs[0].SignedCount != 0 {
+		t.Fatalf("unexpected runs: %+v", runs)
+	}
+
+	updated, err := repo.Update(ctx, c.ID, models.CampaignInput{Title: "Updated", DocID: "policy-doc", Schedule: models.CampaignScheduleQuarterly, Active: false, NextRunAt: next})
+	if err != nil {
+		t.Fatalf("update err: %v", err)
+	}
+	if updated.Title != "Updated" || updated.Active || updated.LastRunAt == nil {
+		t.Fatalf("unexpected updated campaign: %+v", updated)
+	}
+
+	if err := repo.Delete(ctx, c.ID); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, c.ID); !errors.Is(err, models.ErrCampaignNotFound) {
+		t.Fatalf("expected ErrCampaignNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const completionSettingsColumns = `tenant_id, doc_id, notify_on_complete, threshold_percent, deadline, notify_webhook, COALESCE(slack_webhook_url, ''),
+	completed_notified_at, threshold_notified_at, deadline_notified_at, COALESCE(updated_by, ''), updated_at`
+
+// CompletionSettingsRepository handles database operations for per-document completion notification settings
+type CompletionSettingsRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewCompletionSettingsRepository creates a new completion settings repository
+func NewCompletionSettingsRepository(db *sql.DB, tenants providers.TenantProvider) *CompletionSettingsRepository {
+	return &CompletionSettingsRepository{db: db, tenants: tenants}
+}
+
+func scanCompletionSettings(row interface{ Scan(dest ...any) error }) (*models.CompletionSettings, error) {
+	s := &models.CompletionSettings{}
+	var threshold sql.NullInt32
+	var deadline sql.NullTime
+	err := row.Scan(
+		&s.TenantID, &s.DocID, &s.NotifyOnComplete, &threshold, &deadline, &s.NotifyWebhook, &s.SlackWebhookURL,
+		&s.CompletedNotifiedAt, &s.ThresholdNotifiedAt, &s.DeadlineNotifiedAt, &s.UpdatedBy, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if threshold.Valid {
+		v := int(threshold.Int32)
+		s.ThresholdPercent = &v
+	}
+	if deadline.Valid {
+		s.Deadline = &deadline.Time
+	}
+	return s, nil
+}
+
+// GetByDocID returns the completion settings of a document, or nil if none are stored
+// RLS policy automatically filters by tenant_id
+func (r *CompletionSettingsRepository) GetByDocID(ctx context.Context, docID string) (*models.CompletionSettings, error) {
+	query := `SELECT ` + completionSettingsColumns + ` FROM document_completion_settings WHERE doc_id = $1`
+
+	s, err := scanCompletionSettings(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completion settings: %w", err)
+	}
+	return s, nil
+}
+
+// Upsert creates or replaces the completion settings of a document.
+// Changing the threshold or the deadline re-arms the corresponding notification.
+func (r *CompletionSettingsRepository) Upsert(ctx context.Context, docID string, input models.CompletionSettingsInput, updatedBy string) (*models.CompletionSettings, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_completion_settings
+			(tenant_id, doc_id, notify_on_complete, threshold_percent, deadline, notify_webhook, slack_webhook_url, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''))
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET notify_on_complete = EXCLUDED.notify_on_complete,
+			threshold_percent = EXCLUDED.threshold_percent,
+			deadline = EXCLUDED.deadline,
+			notify_webhook = EXCLUDED.notify_webhook,
+			slack_webhook_url = EXCLUDED.slack_webhook_url,
+			updated_by = EXCLUDED.updated_by,
+			threshold_notified_at = CASE
+				WHEN document_completion_settings.threshold_percent IS DISTINCT FROM EXCLUDED.threshold_percent THEN NULL
+				ELSE document_completion_settings.threshold_notified_at END,
+			deadline_notified_at = CASE
+				WHEN document_completion_settings.deadline IS DISTINCT FROM EXCLUDED.deadline THEN NULL
+				ELSE document_completion_settings.deadline_notified_at END,
+			updated_at = now()
+		RETURNING ` + completionSettingsColumns
+
+	s, err := scanCompletionSettings(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, docID, input.NotifyOnComplete, input.ThresholdPercent, input.Deadline,
+		input.NotifyWebhook, input.SlackWebhookURL, updatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert completion settings: %w", err)
+	}
+	return s, nil
+}
+
+// MarkNotified records that the notification for trigger was sent.
+// It returns false when the notification had already been recorded, so concurrent signers notify only once.
+// Documents without stored settings get a default row so the completion notification is not repeated.
+func (r *CompletionSettingsRepository) MarkNotified(ctx context.Context, docID string, trigger models.CompletionTrigger, at time.Time) (bool, error) {
+	var column string
+	switch trigger {
+	case models.CompletionTriggerCompleted:
+		column = "completed_notified_at"
+	case models.CompletionTriggerThreshold:
+		column = "threshold_notified_at"
+	case models.CompletionTriggerDeadline:
+		column = "deadline_notified_at"
+	default:
+		return false, fmt.Errorf("unknown completion trigger: %s", trigger)
+	}
+
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO document_completion_settings (tenant_id, doc_id, %[1]s)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET %[1]s = EXCLUDED.%[1]s
+		WHERE document_completion_settings.%[1]s IS NULL
+	`, column)
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, tenantID, docID, at)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark completion notification: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListDeadlinesDue returns the IDs of active documents whose deadline is reached and not yet notified
+// RLS policy automatically filters by tenant_id
+func (r *CompletionSettingsRepository) ListDeadlinesDue(ctx context.Context, now time.Time) ([]string, error) {
+	query := `
+		SELECT cs.doc_id
+		FROM document_completion_settings cs
+		JOIN documents d ON d.doc_id = cs.doc_id AND d.tenant_id = cs.tenant_id
+		WHERE cs.deadline IS NOT NULL
+		  AND cs.deadline <= $1
+		  AND cs.deadline_notified_at IS NULL
+		  AND d.deleted_at IS NULL
+		ORDER BY cs.deadline ASC
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due deadlines: %w", err)
+	}
+	defer rows.Close()
+
+	var docIDs []string
+	for rows.Next() {
+		var docID string
+		if err := rows.Scan(&docID); err != nil {
+			return nil, err
+		}
+		docIDs = append(docIDs, docID)
+	}
+	return docIDs, rows.Err()
+}
+
+// ListUpcomingDeadlines returns the settings of active documents whose deadline falls within (from, to]
+// RLS policy automatically filters by tenant_id
+func (r *CompletionSettingsRepository) ListUpcomingDeadlines(ctx context.Context, from, to time.Time) ([]*models.CompletionSettings, error) {
+	query := `
+		SELECT cs.tenant_id, cs.doc_id, cs.notify_on_complete, cs.threshold_percent, cs.deadline, cs.notify_webhook,
+			COALESCE(cs.slack_webhook_url, ''), cs.completed_notified_at, cs.threshold_notified_at, cs.deadline_notified_at,
+			COALESCE(cs.updated_by, ''), cs.updated_at
+		FROM document_completion_settings cs
+		JOIN documents d ON d.doc_id = cs.doc_id AND d.tenant_id = cs.tenant_id
+		WHERE cs.deadline > $1
+		  AND cs.deadline <= $2
+		  AND d.deleted_at IS NULL
+		ORDER BY cs.deadline ASC
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list upcoming deadlines: %w", err)
+	}
+	defer rows.Close()
+
+	var settings []*models.CompletionSettings
+	for rows.Next() {
+		s, err := scanCompletionSettings(rows)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, s)
+	}
+	return settings, rows.Err()
+}
@@ -0,0 +1,129 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestCompletionSettingsRepository_Upsert(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewCompletionSettingsRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-settings", models.DocumentInput{Title: "Doc"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	settings, err := repo.GetByDocID(ctx, "doc-settings")
+	if err != nil {
+		t.Fatalf("get settings err: %v", err)
+	}
+	if settings != nil {
+		t.Fatalf("expected no settings, got %+v", settings)
+	}
+
+	threshold := 80
+	deadline := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	settings, err = repo.Upsert(ctx, "doc-settings", models.CompletionSettingsInput{
+		NotifyOnComplete: true, ThresholdPercent: &threshold, Deadline: &deadline,
+		NotifyWebhook: true, SlackWebhookURL: "https://hooks.slack.com/services/x",
+	}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	if settings.ThresholdPercent == nil || *settings.ThresholdPercent != 80 || settings.Deadline == nil || !settings.Deadline.Equal(deadline) {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	if settings.SlackWebhookURL != "https://hooks.slack.com/services/x" || settings.UpdatedBy != "admin@example.com" {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+
+	// Changing the threshold re-arms its notification
+	if _, err := repo.MarkNotified(ctx, "doc-settings", models.CompletionTriggerThreshold, time.Now()); err != nil {
+		t.Fatalf("mark notified err: %v", err)
+	}
+	threshold = 90
+	settings, err = repo.Upsert(ctx, "doc-settings", models.CompletionSettingsInput{NotifyOnComplete: true, ThresholdPercent: &threshold, Deadline: &deadline}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("second upsert err: %v", err)
+	}
+	if settings.ThresholdNotifiedAt != nil {
+		t.Fatalf("expected threshold notification re-armed, got %v", settings.ThresholdNotifiedAt)
+	}
+	if settings.SlackWebhookURL != "" || settings.NotifyWebhook {
+		t.Fatalf("expected channels cleared, got %+v", settings)
+	}
+}
+
+func TestCompletionSettingsRepository_MarkNotified(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewCompletionSettingsRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-notify", models.DocumentInput{Title: "Doc"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	// Documents without settings get a default row on first notification
+	claimed, err := repo.MarkNotified(ctx, "doc-notify", models.CompletionTriggerCompleted, time.Now())
+	if err != nil {
+		t.Fatalf("mark notified err: %v", err)
+	}
+	if !claimed {
+		t.Fatal("expected first notification to be claimed")
+	}
+	claimed, err = repo.MarkNotified(ctx, "doc-notify", models.CompletionTriggerCompleted, time.Now())
+	if err != nil {
+		t.Fatalf("second mark notified err: %v", err)
+	}
+	if claimed {
+		t.Fatal("expected second notification to be rejected")
+	}
+
+	settings, err := repo.GetByDocID(ctx, "doc-notify")
+	if err != nil {
+		t.Fatalf("get settings err: %v", err)
+	}
+	if settings == nil || !settings.NotifyOnComplete || settings.CompletedNotifiedAt == nil {
+		t.Fatalf("expected default settings with completion notified, got %+v", settings)
+	}
+}
+
+func TestCompletionSettingsRepository_ListDeadlinesDue(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewCompletionSettingsRepository(tdb.DB, tdb.TenantProvider)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	for docID, deadline := range map[string]time.Time{"due-doc": past, "notified-doc": past, "future-doc": future} {
+		if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: docID}, "owner@example.com"); err != nil {
+			t.Fatalf("create document err: %v", err)
+		}
+		d := deadline
+		if _, err := repo.Upsert(ctx, docID, models.CompletionSettingsInput{NotifyOnComplete: true, Deadline: &d}, "admin@example.com"); err != nil {
+			t.Fatalf("upsert err: %v", err)
+		}
+	}
+	if _, err := repo.MarkNotified(ctx, "notified-doc", models.CompletionTriggerDeadline, time.Now()); err != nil {
+		t.Fatalf("mark notified err: %v", err)
+	}
+
+	docIDs, err := repo.ListDeadlinesDue(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("list deadlines err: %v", err)
+	}
+	if len(docIDs) != 1 || docIDs[0] != "due-doc" {
+		t.Fatalf("expected only due-doc, got %v", docIDs)
+	}
+}
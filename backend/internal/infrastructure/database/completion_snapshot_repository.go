@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// CompletionSnapshotRepository stores the daily signing progress of documents
+type CompletionSnapshotRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewCompletionSnapshotRepository creates a new completion snapshot repository
+func NewCompletionSnapshotRepository(db *sql.DB, tenants providers.TenantProvider) *CompletionSnapshotRepository {
+	return &CompletionSnapshotRepository{db: db, tenants: tenants}
+}
+
+// RecordAll records the progress of every document having expected signers or signatures
+// for the given day. Documents already recorded that day are left unchanged.
+// It returns the number of snapshots created.
+func (r *CompletionSnapshotRepository) RecordAll(ctx context.Context, date time.Time) (int, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_completion_snapshots (tenant_id, doc_id, snapshot_date, expected_count, signed_count, signature_count)
+		SELECT $1, d.doc_id, $2, COALESCE(e.expected_count, 0), COALESCE(e.signed_count, 0), COALESCE(s.signature_count, 0)
+		FROM documents d
+		LEFT JOIN (
+			SELECT es.doc_id,
+				COUNT(*) AS expected_count,
+				-- A person may have signed under several matching addresses: count them once
+				COUNT(*) FILTER (WHERE EXISTS (
+					SELECT 1 FROM signatures sg
+					WHERE sg.tenant_id = es.tenant_id AND sg.doc_id = es.doc_id AND sg.match_key = es.match_key
+				)) AS signed_count
+			FROM expected_signers es
+			GROUP BY es.doc_id
+		) e ON e.doc_id = d.doc_id
+		LEFT JOIN (
+			-- Signatures held for approval count once approved
+			SELECT sg.doc_id, COUNT(*) AS signature_count FROM signatures sg
+			WHERE NOT EXISTS (
+				SELECT 1 FROM signature_approvals sa WHERE sa.signature_id = sg.id AND sa.status <> 'approved'
+			)
+			GROUP BY sg.doc_id
+		) s ON s.doc_id = d.doc_id
+		WHERE d.deleted_at IS NULL
+		  AND (e.expected_count > 0 OR s.signature_count > 0)
+		ON CONFLICT (tenant_id, doc_id, snapshot_date) DO NOTHING`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, tenantID, date.UTC().Format(time.DateOnly))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record completion snapshots: %w", err)
+	}
+	created, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count completion snapshots: %w", err)
+	}
+	return int(created), nil
+}
+
+// GetLatestDate returns the day of the most recent snapshot, or nil when none was recorded yet
+// RLS policy automatically filters by tenant_id
+func (r *CompletionSnapshotRepository) GetLatestDate(ctx context.Context) (*time.Time, error) {
+	var latest sql.NullTime
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT MAX(snapshot_date) FROM document_completion_snapshots`).Scan(&latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest completion snapshot: %w", err)
+	}
+	if !latest.Valid {
+		return nil, nil
+	}
+	date := latest.Time.UTC()
+	return &date, nil
+}
+
+// ListByDocument returns the snapshots of a document since the given day, oldest first
+// RLS policy automatically filters by tenant_id
+func (r *CompletionSnapshotRepository) ListByDocument(ctx context.Context, docID string, since time.Time) ([]*models.CompletionSnapshot, error) {
+	query := `
+		SELECT id, tenant_id, doc_id, snapshot_date, expected_count, signed_count, signature_count, created_at
+		FROM document_completion_snapshots
+		WHERE doc_id = $1 AND snapshot_date >= $2
+		ORDER BY snapshot_date ASC`
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, docID, since.UTC().Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completion snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*models.CompletionSnapshot
+	for rows.Next() {
+		s := &models.CompletionSnapshot{}
+		if err := rows.Scan(&s.ID, &s.TenantID, &s.DocID, &s.Date, &s.ExpectedCount, &s.SignedCount,
+			&s.SignatureCount, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan completion snapshot: %w", err)
+		}
+		s.Date = s.Date.UTC()
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
+
+// DeleteBefore removes the snapshots older than the given day and returns how many were removed
+// RLS policy automatically filters by tenant_id
+func (r *CompletionSnapshotRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM document_completion_snapshots WHERE snapshot_date < $1`, before.UTC().Format(time.DateOnly))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete completion snapshots: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted completion snapshots: %w", err)
+	}
+	return int(deleted), nil
+}
@@ -0,0 +1,84 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestCompletionSnapshotRepository_RecordAndList(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewCompletionSnapshotRepository(testDB.DB, testDB.TenantProvider)
+	factory := NewSignatureFactory()
+	ctx := context.Background()
+
+	docID := "doc-snapshot-test"
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Snapshot"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	emails := []string{"signed@example.com", "pending@example.com"}
+	if err := expectedRepo.AddExpected(ctx, docID, emailsToContacts(emails), "admin@example.com"); err != nil {
+		t.Fatalf("add expected signers err: %v", err)
+	}
+	for _, email := range []string{"signed@example.com", "extra@example.com"} {
+		if err := sigRepo.Create(ctx, factory.CreateSignatureWithDocAndUser(docID, "user-"+email, email)); err != nil {
+			t.Fatalf("create signature err: %v", err)
+		}
+	}
+
+	latest, err := repo.GetLatestDate(ctx)
+	if err != nil || latest != nil {
+		t.Fatalf("expected no snapshot yet, got %v, %v", latest, err)
+	}
+
+	day := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	created, err := repo.RecordAll(ctx, day)
+	if err != nil || created != 1 {
+		t.Fatalf("expected 1 snapshot, got %d, %v", created, err)
+	}
+	// A second run the same day keeps the first snapshot
+	if created, err := repo.RecordAll(ctx, day); err != nil || created != 0 {
+		t.Fatalf("expected no new snapshot, got %d, %v", created, err)
+	}
+	if _, err := repo.RecordAll(ctx, day.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("record next day err: %v", err)
+	}
+
+	latest, err = repo.GetLatestDate(ctx)
+	if err != nil || latest == nil || !latest.Equal(day.AddDate(0, 0, 1)) {
+		t.Fatalf("expected latest %v, got %v, %v", day.AddDate(0, 0, 1), latest, err)
+	}
+
+	snapshots, err := repo.ListByDocument(ctx, docID, day)
+	if err != nil {
+		t.Fatalf("list snapshots err: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	first := snapshots[0]
+	if !first.Date.Equal(day) || first.ExpectedCount != 2 || first.SignedCount != 1 || first.SignatureCount != 2 {
+		t.Errorf("unexpected snapshot %+v", first)
+	}
+	if first.CompletionRate() != 50 {
+		t.Errorf("expected completion rate 50, got %f", first.CompletionRate())
+	}
+
+	deleted, err := repo.DeleteBefore(ctx, day.AddDate(0, 0, 1))
+	if err != nil || deleted != 1 {
+		t.Fatalf("expected 1 deleted snapshot, got %d, %v", deleted, err)
+	}
+	snapshots, err = repo.ListByDocument(ctx, docID, day)
+	if err != nil || len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot left, got %d, %v", len(snapshots), err)
+	}
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const complianceReportColumns = `id, period, period_start, period_end, status, error, data, requested_by, created_at, started_at, completed_at`
+
+// ComplianceReportRepository handles database operations for compliance reports and the
+// cross-document aggregation they are built from
+type ComplianceReportRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewComplianceReportRepository creates a new compliance report repository
+func NewComplianceReportRepository(db *sql.DB, tenants providers.TenantProvider) *ComplianceReportRepository {
+	return &ComplianceReportRepository{db: db, tenants: tenants}
+}
+
+func scanComplianceReport(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.ComplianceReport, error) {
+	r := &models.ComplianceReport{}
+	var data []byte
+	if err := scanner.Scan(&r.ID, &r.Period, &r.PeriodStart, &r.PeriodEnd, &r.Status, &r.Error, &data,
+		&r.RequestedBy, &r.CreatedAt, &r.StartedAt, &r.CompletedAt); err != nil {
+		return nil, err
+	}
+	if data != nil {
+		r.Data = &models.ComplianceReportData{}
+		if err := json.Unmarshal(data, r.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode compliance report data: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// Create queues the generation of a report over a period
+func (r *ComplianceReportRepository) Create(ctx context.Context, period models.ReportPeriod, requestedBy string) (*models.ComplianceReport, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO compliance_reports (tenant_id, period, period_start, period_end, requested_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + complianceReportColumns
+
+	report, err := scanComplianceReport(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, period.Name, period.Start, period.End, requestedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compliance report: %w", err)
+	}
+	return report, nil
+}
+
+// GetByID retrieves a report with its data
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) GetByID(ctx context.Context, id int64) (*models.ComplianceReport, error) {
+	report, err := scanComplianceReport(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+complianceReportColumns+` FROM compliance_reports WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrReportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compliance report: %w", err)
+	}
+	return report, nil
+}
+
+// GetLatest retrieves the most recent report of a period that did not fail, or nil when there is none
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) GetLatest(ctx context.Context, period string) (*models.ComplianceReport, error) {
+	query := `SELECT ` + complianceReportColumns + ` FROM compliance_reports
+		WHERE period = $1 AND status <> 'failed'
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	report, err := scanComplianceReport(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, period))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest compliance report: %w", err)
+	}
+	return report, nil
+}
+
+// ClaimNext marks the oldest pending report as processing and returns it, or nil when there is none.
+// Reports left processing since before staleBefore, by an instance that stopped, are claimed again.
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (*models.ComplianceReport, error) {
+	query := `
+		UPDATE compliance_reports
+		SET status = 'processing', started_at = now()
+		WHERE id = (
+			SELECT id FROM compliance_reports
+			WHERE status = 'pending' OR (status = 'processing' AND started_at < $1)
+			ORDER BY created_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + complianceReportColumns
+
+	report, err := scanComplianceReport(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, staleBefore))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim compliance report: %w", err)
+	}
+	return report, nil
+}
+
+// Complete stores the data of a report and marks it ready
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) Complete(ctx context.Context, id int64, data *models.ComplianceReportData) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode compliance report data: %w", err)
+	}
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE compliance_reports
+		SET status = 'ready', data = $2, error = '', completed_at = now()
+		WHERE id = $1`, id, encoded)
+	if err != nil {
+		return fmt.Errorf("failed to complete compliance report: %w", err)
+	}
+	return nil
+}
+
+// Fail records why a report could not be generated
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) Fail(ctx context.Context, id int64, message string) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE compliance_reports
+		SET status = 'failed', error = $2, completed_at = now()
+		WHERE id = $1`, id, message)
+	if err != nil {
+		return fmt.Errorf("failed to mark compliance report as failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteBefore deletes the reports requested before a date and returns how many were deleted
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM compliance_reports WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete compliance reports: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted compliance reports: %w", err)
+	}
+	return int(deleted), nil
+}
+
+// ListDocumentRows returns the signing progress at the end of the period of each document that
+// existed during it and had expected signers, ordered by document ID.
+// Outstanding is left to the caller.
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) ListDocumentRows(ctx context.Context, start, end time.Time) ([]models.ComplianceDocumentRow, error) {
+	query := `
+		SELECT d.doc_id, d.title, cs.deadline,
+			COUNT(*) AS expected,
+			COUNT(sg.signed_at) AS signed,
+			COUNT(sg.signed_at) FILTER (WHERE sg.signed_at >= $1) AS signed_in_period,
+			COUNT(sg.signed_at) FILTER (WHERE sg.signed_at > cs.deadline) AS late
+		FROM documents d
+		JOIN expected_signers es ON es.doc_id = d.doc_id AND es.added_at < $2
+		-- A person may have signed under several matching addresses: the first signature counts
+		LEFT JOIN LATERAL (
+			SELECT MIN(s.signed_at) AS signed_at FROM signatures s
+			WHERE s.tenant_id = es.tenant_id AND s.doc_id = es.doc_id AND s.match_key = es.match_key
+			  AND s.signed_at < $2
+		) sg ON true
+		LEFT JOIN document_completion_settings cs ON cs.doc_id = d.doc_id
+		WHERE d.created_at < $2
+		  AND (d.deleted_at IS NULL OR d.deleted_at >= $1)
+		GROUP BY d.doc_id, d.title, cs.deadline
+		ORDER BY d.doc_id`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document compliance: %w", err)
+	}
+	defer rows.Close()
+
+	result := []models.ComplianceDocumentRow{}
+	for rows.Next() {
+		var row models.ComplianceDocumentRow
+		if err := rows.Scan(&row.DocID, &row.Title, &row.Deadline, &row.Expected, &row.Signed, &row.SignedInPeriod, &row.Late); err != nil {
+			return nil, fmt.Errorf("failed to scan document compliance: %w", err)
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate document compliance: %w", err)
+	}
+	return result, nil
+}
+
+// ListOutstandingUsers returns the expected signers who had documents left to sign at the end of
+// the period, with those documents, most outstanding first
+// RLS policy automatically filters by tenant_id
+func (r *ComplianceReportRepository) ListOutstandingUsers(ctx context.Context, start, end time.Time) ([]models.ComplianceUserRow, error) {
+	query := `
+		SELECT lower(es.email), MAX(es.name), array_agg(d.doc_id ORDER BY d.doc_id)
+		FROM expected_signers es
+		JOIN documents d ON d.doc_id = es.doc_id
+		WHERE es.added_at < $2
+		  AND d.created_at < $2
+		  AND (d.deleted_at IS NULL OR d.deleted_at >= $1)
+		  AND NOT EXISTS (
+			SELECT 1 FROM signatures s
+			WHERE s.tenant_id = es.tenant_id AND s.doc_id = es.doc_id AND s.match_key = es.match_key
+			  AND s.signed_at < $2
+		  )
+		GROUP BY lower(es.email)
+		ORDER BY COUNT(*) DESC, lower(es.email)`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query outstanding signers: %w", err)
+	}
+	defer rows.Close()
+
+	result := []models.ComplianceUserRow{}
+	for rows.Next() {
+		var row models.ComplianceUserRow
+		if err := rows.Scan(&row.Email, &row.Name, pq.Array(&row.DocIDs)); err != nil {
+			return nil, fmt.Errorf("failed to scan outstanding signer: %w", err)
+		}
+		row.Outstanding = len(row.DocIDs)
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outstanding signers: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestComplianceReportRepository_Queue(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewComplianceReportRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	period, err := models.ParseReportPeriod("2024-Q4")
+	if err != nil {
+		t.Fatalf("ParseReportPeriod err: %v", err)
+	}
+	report, err := repo.Create(ctx, period, "admin@example.com")
+	if err != nil || report.Status != models.ComplianceReportPending || report.Data != nil {
+		t.Fatalf("unexpected report %+v, %v", report, err)
+	}
+
+	latest, err := repo.GetLatest(ctx, "2024-Q4")
+	if err != nil || latest == nil || latest.ID != report.ID {
+		t.Fatalf("expected the pending report, got %+v, %v", latest, err)
+	}
+
+	claimed, err := repo.ClaimNext(ctx, time.Now().Add(-time.Hour))
+	if err != nil || claimed == nil || claimed.ID != report.ID || claimed.Status != models.ComplianceReportProcessing {
+		t.Fatalf("unexpected claimed report %+v, %v", claimed, err)
+	}
+	// A report being processed is not claimed again until it is stale
+	if claimed, err := repo.ClaimNext(ctx, time.Now().Add(-time.Hour)); err != nil || claimed != nil {
+		t.Fatalf("expected no report to claim, got %+v, %v", claimed, err)
+	}
+	if claimed, err := repo.ClaimNext(ctx, time.Now().Add(time.Minute)); err != nil || claimed == nil {
+		t.Fatalf("expected the stale report to be claimed, got %+v, %v", claimed, err)
+	}
+
+	data := &models.ComplianceReportData{Period: "2024-Q4", Documents: []models.ComplianceDocumentRow{{DocID: "doc1", Expected: 2, Signed: 1}}}
+	if err := repo.Complete(ctx, report.ID, data); err != nil {
+		t.Fatalf("Complete err: %v", err)
+	}
+	ready, err := repo.GetByID(ctx, report.ID)
+	if err != nil || ready.Status != models.ComplianceReportReady || ready.CompletedAt == nil {
+		t.Fatalf("unexpected report %+v, %v", ready, err)
+	}
+	if ready.Data == nil || len(ready.Data.Documents) != 1 || ready.Data.Documents[0].Signed != 1 {
+		t.Errorf("unexpected report data %+v", ready.Data)
+	}
+
+	failed, err := repo.Create(ctx, period, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Create err: %v", err)
+	}
+	if err := repo.Fail(ctx, failed.ID, "boom"); err != nil {
+		t.Fatalf("Fail err: %v", err)
+	}
+	// Failed reports are skipped
+	if latest, err := repo.GetLatest(ctx, "2024-Q4"); err != nil || latest == nil || latest.ID != report.ID {
+		t.Errorf("expected the ready report, got %+v, %v", latest, err)
+	}
+
+	if deleted, err := repo.DeleteBefore(ctx, time.Now().Add(time.Minute)); err != nil || deleted != 2 {
+		t.Fatalf("expected 2 deleted reports, got %d, %v", deleted, err)
+	}
+	if _, err := repo.GetByID(ctx, report.ID); !errors.Is(err, models.ErrReportNotFound) {
+		t.Errorf("expected ErrReportNotFound, got %v", err)
+	}
+}
+
+func TestComplianceReportRepository_Aggregation(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	settingsRepo := NewCompletionSettingsRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewComplianceReportRepository(testDB.DB, testDB.TenantProvider)
+	factory := NewSignatureFactory()
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	docID := "doc-compliance-test"
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Compliance"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	emails := []string{"ontime@example.com", "late@example.com", "pending@example.com"}
+	if err := expectedRepo.AddExpected(ctx, docID, emailsToContacts(emails), "admin@example.com"); err != nil {
+		t.Fatalf("add expected signers err: %v", err)
+	}
+	if _, err := settingsRepo.Upsert(ctx, docID, models.CompletionSettingsInput{NotifyOnComplete: true, Deadline: &now}, "admin@example.com"); err != nil {
+		t.Fatalf("set deadline err: %v", err)
+	}
+	for email, signedAt := range map[string]time.Time{
+		"ontime@example.com": now.Add(-10 * time.Minute),
+		"late@example.com":   now.Add(10 * time.Minute),
+	} {
+		sig := factory.CreateSignatureWithDocAndUser(docID, "user-"+email, email)
+		sig.SignedAtUTC = signedAt
+		if err := sigRepo.Create(ctx, sig); err != nil {
+			t.Fatalf("create signature err: %v", err)
+		}
+	}
+
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+	rows, err := repo.ListDocumentRows(ctx, start, end)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("expected 1 document row, got %+v, %v", rows, err)
+	}
+	row := rows[0]
+	if row.Expected != 3 || row.Signed != 2 || row.SignedInPeriod != 2 || row.Late != 1 || row.Deadline == nil {
+		t.Errorf("unexpected document row %+v", row)
+	}
+
+	// At an earlier end, the late signature had not happened yet
+	rows, err = repo.ListDocumentRows(ctx, start, now)
+	if err != nil || len(rows) != 1 || rows[0].Signed != 1 || rows[0].Late != 0 {
+		t.Errorf("unexpected document rows %+v, %v", rows, err)
+	}
+
+	users, err := repo.ListOutstandingUsers(ctx, start, end)
+	if err != nil || len(users) != 1 || users[0].Email != "pending@example.com" || users[0].Outstanding != 1 {
+		t.Fatalf("unexpected outstanding users %+v, %v", users, err)
+	}
+	if users[0].DocIDs[0] != docID {
+		t.Errorf("unexpected outstanding documents %v", users[0].DocIDs)
+	}
+
+	// Documents created after the period are left out
+	rows, err = repo.ListDocumentRows(ctx, start.Add(-48*time.Hour), start)
+	if err != nil || len(rows) != 0 {
+		t.Errorf("expected no document rows, got %+v, %v", rows, err)
+	}
+}
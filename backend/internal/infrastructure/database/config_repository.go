@@ -201,3 +201,79 @@ func (r *ConfigRepository) GetLatestUpdatedAt(ctx context.Context) (time.Time, e
 	}
 	return updatedAt, nil
 }
+
+// ListPending retrieves the staged configuration sections, by category
+func (r *ConfigRepository) ListPending(ctx context.Context) ([]*models.PendingConfigSection, error) {
+	// RLS policy automatically filters by tenant_id
+	query := `
+		SELECT category, config, secrets_encrypted, staged_by, staged_at
+		FROM tenant_config_pending
+		ORDER BY category
+	`
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending configs: %w", err)
+	}
+	defer rows.Close()
+
+	var sections []*models.PendingConfigSection
+	for rows.Next() {
+		section := &models.PendingConfigSection{}
+		var secretsEncrypted []byte
+		if err := rows.Scan(&section.Category, &section.Config, &secretsEncrypted, &section.StagedBy, &section.StagedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pending config row: %w", err)
+		}
+		section.SecretsEncrypted = secretsEncrypted
+		sections = append(sections, section)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending config rows: %w", err)
+	}
+
+	return sections, nil
+}
+
+// StagePending stages a configuration section, replacing the one already staged for its category
+func (r *ConfigRepository) StagePending(ctx context.Context, category models.ConfigCategory, config json.RawMessage, secrets []byte, stagedBy string) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO tenant_config_pending (tenant_id, category, config, secrets_encrypted, staged_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, category)
+		DO UPDATE SET
+			config = EXCLUDED.config,
+			secrets_encrypted = EXCLUDED.secrets_encrypted,
+			staged_by = EXCLUDED.staged_by,
+			staged_at = NOW()
+	`
+
+	var secretsArg interface{}
+	if len(secrets) > 0 {
+		secretsArg = secrets
+	}
+
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query,
+		tenantID, string(category), config, secretsArg, stagedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to stage config: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePending discards the staged section of a category, or every staged section when category is empty
+func (r *ConfigRepository) DeletePending(ctx context.Context, category models.ConfigCategory) (int64, error) {
+	// RLS policy automatically filters by tenant_id
+	query := `DELETE FROM tenant_config_pending WHERE $1 = '' OR category = $1`
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, string(category))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete pending configs: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -4,20 +4,30 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Config struct {
 	DSN string
+	// DSNProvider, when set, returns the DSN of each new connection, so that rotated
+	// credentials are used as the pool replaces its connections
+	DSNProvider func() (string, error)
 }
 
 func InitDB(ctx context.Context, config Config) (*sql.DB, error) {
-	db, err := sql.Open("postgres", config.DSN)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+	var db *sql.DB
+	if config.DSNProvider != nil {
+		db = sql.OpenDB(&dsnConnector{dsn: config.DSNProvider})
+	} else {
+		var err error
+		db, err = sql.Open("postgres", config.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
 	}
 
 	db.SetMaxOpenConns(25)
@@ -34,3 +44,24 @@ func InitDB(ctx context.Context, config Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// dsnConnector opens each connection with the current DSN
+type dsnConnector struct {
+	dsn func() (string, error)
+}
+
+func (c *dsnConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dsn, err := c.dsn()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database DSN: %w", err)
+	}
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return connector.Connect(ctx)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const consentTextColumns = `version, locale, text, created_by, created_at`
+
+// ConsentRepository handles database operations for the consent catalog and the consent version of documents
+type ConsentRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewConsentRepository creates a new consent repository
+func NewConsentRepository(db *sql.DB, tenants providers.TenantProvider) *ConsentRepository {
+	return &ConsentRepository{db: db, tenants: tenants}
+}
+
+func scanConsentText(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.ConsentText, error) {
+	t := &models.ConsentText{}
+	if err := scanner.Scan(&t.Version, &t.Locale, &t.Text, &t.CreatedBy, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// PublishVersion stores the texts of the next consent version in a single statement
+// RLS policy automatically filters by tenant_id
+func (r *ConsentRepository) PublishVersion(ctx context.Context, input models.ConsentVersionInput) ([]*models.ConsentText, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	locales := make([]string, 0, len(input.Texts))
+	for locale := range input.Texts {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	texts := make([]string, len(locales))
+	for i, locale := range locales {
+		texts[i] = input.Texts[locale]
+	}
+
+	query := `
+		INSERT INTO consent_texts (tenant_id, version, locale, text, created_by)
+		SELECT $1, (SELECT COALESCE(MAX(version), 0) + 1 FROM consent_texts), u.locale, u.text, $4
+		FROM unnest($2::text[], $3::text[]) AS u(locale, text)
+		RETURNING ` + consentTextColumns
+
+	return r.queryTexts(ctx, "failed to publish consent version", query, tenantID, pq.Array(locales), pq.Array(texts), input.CreatedBy)
+}
+
+// List retrieves the whole catalog, latest version first
+// RLS policy automatically filters by tenant_id
+func (r *ConsentRepository) List(ctx context.Context) ([]*models.ConsentText, error) {
+	query := `SELECT ` + consentTextColumns + ` FROM consent_texts ORDER BY version DESC, locale ASC`
+	return r.queryTexts(ctx, "failed to list consent texts", query)
+}
+
+// GetVersion retrieves the texts of a version, empty when the version does not exist
+// RLS policy automatically filters by tenant_id
+func (r *ConsentRepository) GetVersion(ctx context.Context, version int) ([]*models.ConsentText, error) {
+	query := `SELECT ` + consentTextColumns + ` FROM consent_texts WHERE version = $1 ORDER BY locale ASC`
+	return r.queryTexts(ctx, "failed to get consent version", query, version)
+}
+
+// LatestVersion returns the highest published version, 0 when the catalog is empty
+// RLS policy automatically filters by tenant_id
+func (r *ConsentRepository) LatestVersion(ctx context.Context) (int, error) {
+	var version int
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(version), 0) FROM consent_texts`,
+	).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest consent version: %w", err)
+	}
+	return version, nil
+}
+
+func (r *ConsentRepository) queryTexts(ctx context.Context, errMsg, query string, args ...interface{}) ([]*models.ConsentText, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
+	}
+	defer rows.Close()
+
+	var out []*models.ConsentText
+	for rows.Next() {
+		t, err := scanConsentText(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan consent text: %w", err)
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// GetDocumentVersion retrieves the version a document is pinned to, nil when it follows the latest
+// RLS policy automatically filters by tenant_id
+func (r *ConsentRepository) GetDocumentVersion(ctx context.Context, docID string) (*models.DocumentConsent, error) {
+	consent := &models.DocumentConsent{DocID: docID, Pinned: true}
+	var setAt time.Time
+
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT version, set_by, set_at FROM document_consent_versions WHERE doc_id = $1`, docID,
+	).Scan(&consent.Version, &consent.SetBy, &setAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document consent version: %w", err)
+	}
+	consent.SetAt = &setAt
+	return consent, nil
+}
+
+// SetDocumentVersion pins a document to a consent version
+func (r *ConsentRepository) SetDocumentVersion(ctx context.Context, docID string, version int, setBy string) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_consent_versions (tenant_id, doc_id, version, set_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET version = EXCLUDED.version, set_by = EXCLUDED.set_by, set_at = now()
+	`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, tenantID, docID, version, setBy); err != nil {
+		return fmt.Errorf("failed to set document consent version: %w", err)
+	}
+	return nil
+}
+
+// ClearDocumentVersion makes a document follow the latest consent version again
+// RLS policy automatically filters by tenant_id
+func (r *ConsentRepository) ClearDocumentVersion(ctx context.Context, docID string) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_consent_versions WHERE doc_id = $1`, docID)
+	if err != nil {
+		return fmt.Errorf("failed to clear document consent version: %w", err)
+	}
+	return nil
+}
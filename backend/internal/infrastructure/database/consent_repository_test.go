@@ -0,0 +1,81 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestConsentRepository_PublishVersion(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewConsentRepository(tdb.DB, tdb.TenantProvider)
+
+	latest, err := repo.LatestVersion(ctx)
+	if err != nil || latest != 0 {
+		t.Fatalf("expected empty catalog, got %d (err %v)", latest, err)
+	}
+
+	texts, err := repo.PublishVersion(ctx, models.ConsentVersionInput{
+		Texts:     map[string]string{"en": "I have read this document", "fr": "J'ai lu ce document"},
+		CreatedBy: "admin@example.com",
+	})
+	if err != nil || len(texts) != 2 || texts[0].Version != 1 {
+		t.Fatalf("unexpected first version %+v, %v", texts, err)
+	}
+	texts, err = repo.PublishVersion(ctx, models.ConsentVersionInput{Texts: map[string]string{"en": "I have read and understood this document"}})
+	if err != nil || len(texts) != 1 || texts[0].Version != 2 {
+		t.Fatalf("unexpected second version %+v, %v", texts, err)
+	}
+
+	if latest, _ := repo.LatestVersion(ctx); latest != 2 {
+		t.Errorf("expected latest version 2, got %d", latest)
+	}
+	v1, err := repo.GetVersion(ctx, 1)
+	if err != nil || len(v1) != 2 || v1[1].Locale != "fr" {
+		t.Errorf("unexpected version 1 %+v, %v", v1, err)
+	}
+	all, err := repo.List(ctx)
+	if err != nil || len(all) != 3 || all[0].Version != 2 {
+		t.Errorf("unexpected catalog %+v, %v", all, err)
+	}
+}
+
+func TestConsentRepository_DocumentVersion(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewConsentRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-consent", models.DocumentInput{Title: "Doc"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	consent, err := repo.GetDocumentVersion(ctx, "doc-consent")
+	if err != nil || consent != nil {
+		t.Fatalf("expected no pinned version, got %+v (err %v)", consent, err)
+	}
+
+	if err := repo.SetDocumentVersion(ctx, "doc-consent", 1, "admin@example.com"); err != nil {
+		t.Fatalf("SetDocumentVersion err: %v", err)
+	}
+	if err := repo.SetDocumentVersion(ctx, "doc-consent", 3, "admin@example.com"); err != nil {
+		t.Fatalf("SetDocumentVersion err: %v", err)
+	}
+	consent, err = repo.GetDocumentVersion(ctx, "doc-consent")
+	if err != nil || consent == nil || consent.Version != 3 || !consent.Pinned || consent.SetBy != "admin@example.com" {
+		t.Fatalf("unexpected pinned version %+v, %v", consent, err)
+	}
+
+	if err := repo.ClearDocumentVersion(ctx, "doc-consent"); err != nil {
+		t.Fatalf("ClearDocumentVersion err: %v", err)
+	}
+	if consent, _ := repo.GetDocumentVersion(ctx, "doc-consent"); consent != nil {
+		t.Errorf("expected cleared version, got %+v", consent)
+	}
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const deadlineExtensionColumns = `id, doc_id, signer_email, reason, requested_deadline, status, granted_deadline, decided_by, decided_at, decision_note, created_at`
+
+// DeadlineExtensionRepository handles database operations for the deadline extensions requested by signers
+type DeadlineExtensionRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewDeadlineExtensionRepository creates a new deadline extension repository
+func NewDeadlineExtensionRepository(db *sql.DB, tenants providers.TenantProvider) *DeadlineExtensionRepository {
+	return &DeadlineExtensionRepository{db: db, tenants: tenants}
+}
+
+func scanDeadlineExtension(row interface{ Scan(dest ...any) error }) (*models.DeadlineExtension, error) {
+	e := &models.DeadlineExtension{}
+	var grantedDeadline, decidedAt sql.NullTime
+	var decidedBy sql.NullString
+	err := row.Scan(&e.ID, &e.DocID, &e.SignerEmail, &e.Reason, &e.RequestedDeadline, &e.Status,
+		&grantedDeadline, &decidedBy, &decidedAt, &e.DecisionNote, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if grantedDeadline.Valid {
+		e.GrantedDeadline = &grantedDeadline.Time
+	}
+	if decidedBy.Valid {
+		e.DecidedBy = &decidedBy.String
+	}
+	if decidedAt.Valid {
+		e.DecidedAt = &decidedAt.Time
+	}
+	return e, nil
+}
+
+// Create records a pending extension request. It returns ErrExtensionPending when the signer
+// already has a request awaiting a decision for the document.
+func (r *DeadlineExtensionRepository) Create(ctx context.Context, docID, signerEmail, reason string, requestedDeadline time.Time) (*models.DeadlineExtension, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO deadline_extensions (tenant_id, doc_id, signer_email, reason, requested_deadline)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + deadlineExtensionColumns
+
+	e, err := scanDeadlineExtension(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, docID, signerEmail, reason, requestedDeadline,
+	))
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, models.ErrExtensionPending
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deadline extension: %w", err)
+	}
+	return e, nil
+}
+
+// Get returns an extension request of a document
+// RLS policy automatically filters by tenant_id
+func (r *DeadlineExtensionRepository) Get(ctx context.Context, docID string, id int64) (*models.DeadlineExtension, error) {
+	query := `SELECT ` + deadlineExtensionColumns + ` FROM deadline_extensions WHERE doc_id = $1 AND id = $2`
+
+	e, err := scanDeadlineExtension(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrExtensionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline extension: %w", err)
+	}
+	return e, nil
+}
+
+// ListByDoc returns the extension requests of a document, newest first. A non-empty signerEmail
+// only lists the requests of that signer.
+// RLS policy automatically filters by tenant_id
+func (r *DeadlineExtensionRepository) ListByDoc(ctx context.Context, docID, signerEmail string) ([]*models.DeadlineExtension, error) {
+	query := `SELECT ` + deadlineExtensionColumns + ` FROM deadline_extensions
+		WHERE doc_id = $1 AND ($2 = '' OR lower(signer_email) = lower($2))
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID, signerEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deadline extensions: %w", err)
+	}
+	defer rows.Close()
+
+	extensions := []*models.DeadlineExtension{}
+	for rows.Next() {
+		e, err := scanDeadlineExtension(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deadline extension: %w", err)
+		}
+		extensions = append(extensions, e)
+	}
+	return extensions, rows.Err()
+}
+
+// Decide records the decision on a pending request; grantedDeadline is only stored on approval.
+// It returns ErrExtensionDecided when the request was decided first.
+// RLS policy automatically filters by tenant_id
+func (r *DeadlineExtensionRepository) Decide(ctx context.Context, docID string, id int64, status models.ExtensionStatus, grantedDeadline *time.Time, decidedBy, note string) (*models.DeadlineExtension, error) {
+	query := `
+		UPDATE deadline_extensions
+		SET status = $3, granted_deadline = $4, decided_by = $5, decided_at = now(), decision_note = $6
+		WHERE doc_id = $1 AND id = $2 AND status = 'pending'
+		RETURNING ` + deadlineExtensionColumns
+
+	e, err := scanDeadlineExtension(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		docID, id, status, grantedDeadline, decidedBy, note,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, getErr := r.Get(ctx, docID, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, models.ErrExtensionDecided
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide deadline extension: %w", err)
+	}
+	return e, nil
+}
@@ -0,0 +1,62 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestDeadlineExtensionRepository_Decide(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewDeadlineExtensionRepository(tdb.DB, tdb.TenantProvider)
+
+	requested := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	ext, err := repo.Create(ctx, "extension-doc", "signer@example.com", "On leave", requested)
+	if err != nil || ext.Status != models.ExtensionStatusPending || !ext.RequestedDeadline.Equal(requested) {
+		t.Fatalf("unexpected request: %+v (err %v)", ext, err)
+	}
+
+	// One pending request per signer and document
+	if _, err := repo.Create(ctx, "extension-doc", "signer@example.com", "Again", requested); !errors.Is(err, models.ErrExtensionPending) {
+		t.Fatalf("expected ErrExtensionPending, got %v", err)
+	}
+
+	granted := requested.Add(-24 * time.Hour)
+	approved, err := repo.Decide(ctx, "extension-doc", ext.ID, models.ExtensionStatusApproved, &granted, "owner@example.com", "Two days")
+	if err != nil || approved.Status != models.ExtensionStatusApproved || approved.GrantedDeadline == nil ||
+		!approved.GrantedDeadline.Equal(granted) || approved.DecidedBy == nil || approved.DecidedAt == nil || approved.DecisionNote != "Two days" {
+		t.Fatalf("unexpected decision: %+v (err %v)", approved, err)
+	}
+
+	if _, err := repo.Decide(ctx, "extension-doc", ext.ID, models.ExtensionStatusDenied, nil, "owner@example.com", ""); !errors.Is(err, models.ErrExtensionDecided) {
+		t.Fatalf("expected ErrExtensionDecided, got %v", err)
+	}
+	if _, err := repo.Decide(ctx, "other-doc", ext.ID, models.ExtensionStatusDenied, nil, "owner@example.com", ""); !errors.Is(err, models.ErrExtensionNotFound) {
+		t.Fatalf("expected ErrExtensionNotFound, got %v", err)
+	}
+
+	// The decided request no longer blocks a new one
+	if _, err := repo.Create(ctx, "extension-doc", "signer@example.com", "More time", requested.Add(48*time.Hour)); err != nil {
+		t.Fatalf("second request err: %v", err)
+	}
+	if _, err := repo.Create(ctx, "extension-doc", "other@example.com", "Travelling", requested); err != nil {
+		t.Fatalf("other signer request err: %v", err)
+	}
+
+	all, err := repo.ListByDoc(ctx, "extension-doc", "")
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected 3 requests, got %d (err %v)", len(all), err)
+	}
+	own, err := repo.ListByDoc(ctx, "extension-doc", "SIGNER@example.com")
+	if err != nil || len(own) != 2 || own[0].Reason != "More time" {
+		t.Fatalf("unexpected requests of the signer: %+v (err %v)", own, err)
+	}
+}
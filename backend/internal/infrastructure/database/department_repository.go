@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+// maxDepartmentDepth bounds the recursion over the department tree
+const maxDepartmentDepth = 32
+
+const departmentColumns = `d.id, d.tenant_id, d.parent_id, d.name,
+	(SELECT COUNT(*) FROM department_members m WHERE m.department_id = d.id),
+	(SELECT COUNT(*) FROM documents doc WHERE doc.department_id = d.id AND doc.deleted_at IS NULL),
+	d.created_by, d.created_at, d.updated_at`
+
+// DepartmentRepository handles database operations for departments, their members and documents
+type DepartmentRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewDepartmentRepository creates a new department repository
+func NewDepartmentRepository(db *sql.DB, tenants providers.TenantProvider) *DepartmentRepository {
+	return &DepartmentRepository{db: db, tenants: tenants}
+}
+
+func scanDepartment(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Department, error) {
+	d := &models.Department{}
+	err := scanner.Scan(&d.ID, &d.TenantID, &d.ParentID, &d.Name, &d.MemberCount, &d.DocumentCount,
+		&d.CreatedBy, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// departmentWriteError maps the constraint violations of department writes
+func departmentWriteError(err error, action string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505":
+			return models.ErrDepartmentExists
+		case "23503":
+			// Unknown parent on insert and update, children or admins left on delete
+			if action == "delete" {
+				return models.ErrDepartmentInUse
+			}
+			return models.ErrDepartmentNotFound
+		}
+	}
+	return fmt.Errorf("failed to %s department: %w", action, err)
+}
+
+// Create inserts a new department.
+// It returns models.ErrDepartmentExists when the parent already has a department with this name.
+func (r *DepartmentRepository) Create(ctx context.Context, input models.DepartmentInput) (*models.Department, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		WITH d AS (
+			INSERT INTO departments (tenant_id, parent_id, name, created_by)
+			VALUES ($1, $2, $3, $4)
+			RETURNING *
+		)
+		SELECT ` + departmentColumns + ` FROM d`
+
+	d, err := scanDepartment(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, input.ParentID, input.Name, input.CreatedBy,
+	))
+	if err != nil {
+		return nil, departmentWriteError(err, "create")
+	}
+	return d, nil
+}
+
+// Update renames or moves a department
+// RLS policy automatically filters by tenant_id
+func (r *DepartmentRepository) Update(ctx context.Context, id int64, input models.DepartmentInput) (*models.Department, error) {
+	query := `
+		WITH d AS (
+			UPDATE departments SET name = $1, parent_id = $2, updated_at = now()
+			WHERE id = $3
+			RETURNING *
+		)
+		SELECT ` + departmentColumns + ` FROM d`
+
+	d, err := scanDepartment(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, input.Name, input.ParentID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrDepartmentNotFound
+	}
+	if err != nil {
+		return nil, departmentWriteError(err, "update")
+	}
+	return d, nil
+}
+
+// Delete removes a department and its members; its documents are no longer assigned.
+// It returns models.ErrDepartmentInUse while the department has sub-departments or admins.
+// RLS policy automatically filters by tenant_id
+func (r *DepartmentRepository) Delete(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM departments WHERE id = $1`, id)
+	if err != nil {
+		return departmentWriteError(err, "delete")
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a department by its ID
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) GetByID(ctx context.Context, id int64) (*models.Department, error) {
+	query := `SELECT ` + departmentColumns + ` FROM departments d WHERE d.id = $1`
+
+	d, err := scanDepartment(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrDepartmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department: %w", err)
+	}
+	return d, nil
+}
+
+// List retrieves all departments by name
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) List(ctx context.Context) ([]*models.Department, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, `SELECT `+departmentColumns+` FROM departments d ORDER BY lower(d.name) ASC, d.id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list departments: %w", err)
+	}
+	defer rows.Close()
+
+	var departments []*models.Department
+	for rows.Next() {
+		d, err := scanDepartment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan department: %w", err)
+		}
+		departments = append(departments, d)
+	}
+	return departments, rows.Err()
+}
+
+// SubtreeIDs returns the ID of a department followed by the IDs of all its sub-departments,
+// closest first. It returns models.ErrDepartmentNotFound for an unknown department.
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) SubtreeIDs(ctx context.Context, id int64) ([]int64, error) {
+	query := `
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth FROM departments WHERE id = $1
+			UNION ALL
+			SELECT d.id, t.depth + 1 FROM departments d JOIN tree t ON d.parent_id = t.id
+			WHERE t.depth < $2
+		)
+		SELECT id FROM tree ORDER BY depth ASC, id ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, id, maxDepartmentDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get department subtree: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var departmentID int64
+		if err := rows.Scan(&departmentID); err != nil {
+			return nil, fmt.Errorf("failed to scan department subtree: %w", err)
+		}
+		ids = append(ids, departmentID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, models.ErrDepartmentNotFound
+	}
+	return ids, nil
+}
+
+// ListMembers retrieves the members of departments by email. A signer belonging to several
+// of the departments is returned once.
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) ListMembers(ctx context.Context, departmentIDs []int64) ([]models.DepartmentMember, error) {
+	query := `
+		SELECT DISTINCT ON (email) email, name, added_by, added_at
+		FROM department_members WHERE department_id = ANY($1)
+		ORDER BY email ASC, added_at ASC`
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, pq.Array(departmentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list department members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.DepartmentMember
+	for rows.Next() {
+		var m models.DepartmentMember
+		if err := rows.Scan(&m.Email, &m.Name, &m.AddedBy, &m.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan department member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// UpsertMembers adds members to a department, updating the name of those already present
+func (r *DepartmentRepository) UpsertMembers(ctx context.Context, departmentID int64, members []models.DepartmentMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	for start := 0; start < len(members); start += signerGroupMemberBatch {
+		batch := members[start:min(start+signerGroupMemberBatch, len(members))]
+
+		valueStrings := make([]string, 0, len(batch))
+		valueArgs := make([]interface{}, 0, len(batch)*5)
+		for i, m := range batch {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
+			valueArgs = append(valueArgs, departmentID, tenantID, m.Email, m.Name, m.AddedBy)
+		}
+
+		query := fmt.Sprintf(`
+			INSERT INTO department_members (department_id, tenant_id, email, name, added_by)
+			VALUES %s
+			ON CONFLICT (department_id, email) DO UPDATE SET name = EXCLUDED.name
+		`, strings.Join(valueStrings, ","))
+
+		if _, err := q.ExecContext(ctx, query, valueArgs...); err != nil {
+			return fmt.Errorf("failed to add department members: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveMembers removes members from a department by email
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) RemoveMembers(ctx context.Context, departmentID int64, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	query := `DELETE FROM department_members WHERE department_id = $1 AND email = ANY($2)`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, departmentID, pq.Array(emails)); err != nil {
+		return fmt.Errorf("failed to remove department members: %w", err)
+	}
+	return nil
+}
+
+// SetDocumentDepartment assigns a document to a department, or unassigns it when departmentID is nil.
+// Within a department scope, it returns models.ErrOutOfDepartmentScope for departments outside of
+// the scope rather than the RLS violation of the update.
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) SetDocumentDepartment(ctx context.Context, docID string, departmentID *int64) error {
+	if scope := dbctx.DepartmentScope(ctx); scope != nil {
+		if departmentID == nil || !slices.Contains(scope, *departmentID) {
+			return models.ErrOutOfDepartmentScope
+		}
+	}
+
+	query := `UPDATE documents SET department_id = $2, updated_at = now() WHERE doc_id = $1 AND deleted_at IS NULL`
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, docID, departmentID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return models.ErrDepartmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set document department: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrDocumentNotFound
+	}
+	return nil
+}
+
+// ListDocuments retrieves the documents of departments with their completion, newest first
+// RLS policy automatically filters by tenant_id and department scope
+func (r *DepartmentRepository) ListDocuments(ctx context.Context, departmentIDs []int64) ([]*models.DepartmentDocument, error) {
+	query := `
+		SELECT d.doc_id, d.title, d.department_id, dep.name, COUNT(es.id), COUNT(s.id), d.created_at
+		FROM documents d
+		JOIN departments dep ON dep.id = d.department_id
+		LEFT JOIN expected_signers es ON es.tenant_id = d.tenant_id AND es.doc_id = d.doc_id
+		LEFT JOIN LATERAL (
+			-- A person may have signed under several matching addresses: keep the first signature
+			SELECT id FROM signatures
+			WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+			ORDER BY signed_at ASC
+			LIMIT 1
+		) s ON true
+		WHERE d.department_id = ANY($1) AND d.deleted_at IS NULL
+		GROUP BY d.doc_id, d.title, d.department_id, dep.name, d.created_at
+		ORDER BY d.created_at DESC, d.doc_id ASC`
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, pq.Array(departmentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list department documents: %w", err)
+	}
+	defer rows.Close()
+
+	var documents []*models.DepartmentDocument
+	for rows.Next() {
+		doc := &models.DepartmentDocument{}
+		if err := rows.Scan(&doc.DocID, &doc.Title, &doc.DepartmentID, &doc.DepartmentName,
+			&doc.ExpectedCount, &doc.SignedCount, &doc.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan department document: %w", err)
+		}
+		if doc.ExpectedCount > 0 {
+			doc.CompletionRate = float64(doc.SignedCount) / float64(doc.ExpectedCount) * 100
+		}
+		documents = append(documents, doc)
+	}
+	return documents, rows.Err()
+}
@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestDepartmentRepository_TreeMembersAndDocuments(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewDepartmentRepository(testDB.DB, testDB.TenantProvider)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	sales, err := repo.Create(ctx, models.DepartmentInput{Name: "Sales", CreatedBy: "admin@example.com"})
+	if err != nil {
+		t.Fatalf("create department err: %v", err)
+	}
+	emea, err := repo.Create(ctx, models.DepartmentInput{Name: "EMEA", ParentID: &sales.ID})
+	if err != nil {
+		t.Fatalf("create sub-department err: %v", err)
+	}
+	if _, err := repo.Create(ctx, models.DepartmentInput{Name: "emea", ParentID: &sales.ID}); !errors.Is(err, models.ErrDepartmentExists) {
+		t.Fatalf("expected ErrDepartmentExists, got %v", err)
+	}
+
+	subtree, err := repo.SubtreeIDs(ctx, sales.ID)
+	if err != nil || len(subtree) != 2 || subtree[0] != sales.ID {
+		t.Fatalf("unexpected subtree %v, %v", subtree, err)
+	}
+
+	if err := repo.UpsertMembers(ctx, emea.ID, []models.DepartmentMember{{Email: "bob@example.com", Name: "Bob"}}); err != nil {
+		t.Fatalf("upsert members err: %v", err)
+	}
+	members, err := repo.ListMembers(ctx, subtree)
+	if err != nil || len(members) != 1 {
+		t.Fatalf("expected one member in the subtree, got %+v, %v", members, err)
+	}
+
+	if _, err := docRepo.Create(ctx, "dept-doc", models.DocumentInput{Title: "Price list"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if err := repo.SetDocumentDepartment(ctx, "dept-doc", &emea.ID); err != nil {
+		t.Fatalf("set document department err: %v", err)
+	}
+	docs, err := repo.ListDocuments(ctx, subtree)
+	if err != nil || len(docs) != 1 || docs[0].DepartmentName != "EMEA" {
+		t.Fatalf("unexpected department documents %+v, %v", docs, err)
+	}
+
+	if err := repo.Delete(ctx, sales.ID); !errors.Is(err, models.ErrDepartmentInUse) {
+		t.Errorf("expected ErrDepartmentInUse for a department with children, got %v", err)
+	}
+	if err := repo.Delete(ctx, emea.ID); err != nil {
+		t.Fatalf("delete department err: %v", err)
+	}
+	doc, err := docRepo.GetByDocID(ctx, "dept-doc")
+	if err != nil || doc.DepartmentID != nil {
+		t.Errorf("expected the document to be unassigned, got %+v, %v", doc, err)
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const directoryCredentialColumns = `provider, status, connected_by, connected_at, last_refreshed_at, token_expires_at,
+	last_error, revoked_at, updated_at, refresh_token_encrypted`
+
+// DirectoryCredentialRepository handles database operations for the delegated access to directories
+type DirectoryCredentialRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewDirectoryCredentialRepository creates a new directory credential repository
+func NewDirectoryCredentialRepository(db *sql.DB, tenants providers.TenantProvider) *DirectoryCredentialRepository {
+	return &DirectoryCredentialRepository{db: db, tenants: tenants}
+}
+
+func scanDirectoryCredential(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.DirectoryCredential, error) {
+	c := &models.DirectoryCredential{}
+	var updatedAt time.Time
+	if err := scanner.Scan(&c.Provider, &c.Status, &c.ConnectedBy, &c.ConnectedAt, &c.LastRefreshedAt,
+		&c.TokenExpiresAt, &c.LastError, &c.RevokedAt, &updatedAt, &c.RefreshToken); err != nil {
+		return nil, err
+	}
+	c.UpdatedAt = &updatedAt
+	return c, nil
+}
+
+// Get retrieves the credential of a directory provider
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) Get(ctx context.Context, provider string) (*models.DirectoryCredential, error) {
+	c, err := scanDirectoryCredential(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+directoryCredentialColumns+` FROM directory_credentials WHERE provider = $1`, provider))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrDirectoryNotConnected
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get directory credential: %w", err)
+	}
+	return c, nil
+}
+
+// List retrieves the credentials of every directory provider
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) List(ctx context.Context) ([]*models.DirectoryCredential, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT `+directoryCredentialColumns+` FROM directory_credentials ORDER BY provider`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query directory credentials: %w", err)
+	}
+	defer rows.Close()
+
+	credentials := []*models.DirectoryCredential{}
+	for rows.Next() {
+		c, err := scanDirectoryCredential(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan directory credential: %w", err)
+		}
+		credentials = append(credentials, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate directory credentials: %w", err)
+	}
+	return credentials, nil
+}
+
+// StartAuthorization records the state of a consent in progress. A credential already active
+// keeps working until the consent completes.
+func (r *DirectoryCredentialRepository) StartAuthorization(ctx context.Context, provider, state string, expiresAt time.Time) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO directory_credentials (tenant_id, provider, oauth_state, oauth_state_expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, provider) DO UPDATE
+		SET oauth_state = EXCLUDED.oauth_state,
+			oauth_state_expires_at = EXCLUDED.oauth_state_expires_at,
+			updated_at = now()`
+
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, tenantID, provider, state, expiresAt); err != nil {
+		return fmt.Errorf("failed to start directory authorization: %w", err)
+	}
+	return nil
+}
+
+// ConsumeAuthorization clears the state of a consent and returns its provider. It returns
+// ErrInvalidDirectoryGrant for unknown, used or expired states.
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) ConsumeAuthorization(ctx context.Context, state string) (string, error) {
+	query := `
+		UPDATE directory_credentials
+		SET oauth_state = NULL, oauth_state_expires_at = NULL, updated_at = now()
+		WHERE oauth_state = $1 AND oauth_state_expires_at > now()
+		RETURNING provider`
+
+	var provider string
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, state).Scan(&provider)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", models.ErrInvalidDirectoryGrant
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to consume directory authorization: %w", err)
+	}
+	return provider, nil
+}
+
+// SaveGrant stores the refresh token received on consent and marks the credential active
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) SaveGrant(ctx context.Context, provider string, refreshToken []byte, tokenExpiresAt *time.Time, connectedBy string) error {
+	query := `
+		UPDATE directory_credentials
+		SET status = 'active', refresh_token_encrypted = $2, token_expires_at = $3, connected_by = $4,
+			connected_at = now(), last_refreshed_at = now(), last_error = '', revoked_at = NULL, updated_at = now()
+		WHERE provider = $1`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, provider, refreshToken, tokenExpiresAt, connectedBy)
+	if err != nil {
+		return fmt.Errorf("failed to save directory grant: %w", err)
+	}
+	return directoryCredentialRowsAffected(result)
+}
+
+// RecordRefresh stores the refresh token after a successful refresh, re-encrypted with the
+// current key, and marks the credential active
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) RecordRefresh(ctx context.Context, provider string, refreshToken []byte, tokenExpiresAt *time.Time) error {
+	query := `
+		UPDATE directory_credentials
+		SET status = 'active', refresh_token_encrypted = $2, token_expires_at = $3,
+			last_refreshed_at = now(), last_error = '', updated_at = now()
+		WHERE provider = $1`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, provider, refreshToken, tokenExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record directory token refresh: %w", err)
+	}
+	return directoryCredentialRowsAffected(result)
+}
+
+// RecordFailure records a failed refresh. A revoked credential loses its refresh token.
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) RecordFailure(ctx context.Context, provider string, status models.DirectoryCredentialStatus, message string) error {
+	query := `
+		UPDATE directory_credentials
+		SET status = $2, last_error = $3,
+			revoked_at = CASE WHEN $2 = 'revoked' THEN now() ELSE revoked_at END,
+			refresh_token_encrypted = CASE WHEN $2 = 'revoked' THEN NULL ELSE refresh_token_encrypted END,
+			updated_at = now()
+		WHERE provider = $1`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, provider, string(status), message)
+	if err != nil {
+		return fmt.Errorf("failed to record directory token failure: %w", err)
+	}
+	return directoryCredentialRowsAffected(result)
+}
+
+// Delete removes the credential of a directory provider and its refresh token
+// RLS policy automatically filters by tenant_id
+func (r *DirectoryCredentialRepository) Delete(ctx context.Context, provider string) error {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM directory_credentials WHERE provider = $1`, provider)
+	if err != nil {
+		return fmt.Errorf("failed to delete directory credential: %w", err)
+	}
+	return directoryCredentialRowsAffected(result)
+}
+
+func directoryCredentialRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrDirectoryNotConnected
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestDirectoryCredentialRepository(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewDirectoryCredentialRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if _, err := repo.Get(ctx, models.DirectoryProviderGoogle); !errors.Is(err, models.ErrDirectoryNotConnected) {
+		t.Fatalf("expected ErrDirectoryNotConnected, got %v", err)
+	}
+
+	// Expired states are rejected
+	if err := repo.StartAuthorization(ctx, models.DirectoryProviderGoogle, "expired-state", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("StartAuthorization err: %v", err)
+	}
+	if _, err := repo.ConsumeAuthorization(ctx, "expired-state"); !errors.Is(err, models.ErrInvalidDirectoryGrant) {
+		t.Fatalf("expected ErrInvalidDirectoryGrant for an expired state, got %v", err)
+	}
+
+	if err := repo.StartAuthorization(ctx, models.DirectoryProviderGoogle, "valid-state", time.Now().Add(10*time.Minute)); err != nil {
+		t.Fatalf("StartAuthorization err: %v", err)
+	}
+	pending, err := repo.Get(ctx, models.DirectoryProviderGoogle)
+	if err != nil || pending.Status != models.DirectoryCredentialPending {
+		t.Fatalf("expected a pending credential, got %+v, %v", pending, err)
+	}
+
+	provider, err := repo.ConsumeAuthorization(ctx, "valid-state")
+	if err != nil || provider != models.DirectoryProviderGoogle {
+		t.Fatalf("ConsumeAuthorization: %q, %v", provider, err)
+	}
+	if _, err := repo.ConsumeAuthorization(ctx, "valid-state"); !errors.Is(err, models.ErrInvalidDirectoryGrant) {
+		t.Fatalf("expected a used state to be rejected, got %v", err)
+	}
+
+	expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	if err := repo.SaveGrant(ctx, models.DirectoryProviderGoogle, []byte("sealed-1"), &expiry, "admin@example.com"); err != nil {
+		t.Fatalf("SaveGrant err: %v", err)
+	}
+	active, err := repo.Get(ctx, models.DirectoryProviderGoogle)
+	if err != nil || active.Status != models.DirectoryCredentialActive || string(active.RefreshToken) != "sealed-1" ||
+		active.ConnectedBy != "admin@example.com" || active.ConnectedAt == nil {
+		t.Fatalf("expected an active credential, got %+v, %v", active, err)
+	}
+
+	if err := repo.RecordFailure(ctx, models.DirectoryProviderGoogle, models.DirectoryCredentialError, "timeout"); err != nil {
+		t.Fatalf("RecordFailure err: %v", err)
+	}
+	if err := repo.RecordRefresh(ctx, models.DirectoryProviderGoogle, []byte("sealed-2"), nil); err != nil {
+		t.Fatalf("RecordRefresh err: %v", err)
+	}
+	refreshed, _ := repo.Get(ctx, models.DirectoryProviderGoogle)
+	if refreshed.Status != models.DirectoryCredentialActive || refreshed.LastError != "" || string(refreshed.RefreshToken) != "sealed-2" {
+		t.Fatalf("expected the refresh to clear the error, got %+v", refreshed)
+	}
+
+	// Revocation drops the refresh token
+	if err := repo.RecordFailure(ctx, models.DirectoryProviderGoogle, models.DirectoryCredentialRevoked, "invalid_grant"); err != nil {
+		t.Fatalf("RecordFailure err: %v", err)
+	}
+	revoked, _ := repo.Get(ctx, models.DirectoryProviderGoogle)
+	if revoked.Status != models.DirectoryCredentialRevoked || revoked.RevokedAt == nil || len(revoked.RefreshToken) != 0 {
+		t.Fatalf("expected a revoked credential without refresh token, got %+v", revoked)
+	}
+
+	list, err := repo.List(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("expected 1 credential, got %+v, %v", list, err)
+	}
+
+	if err := repo.Delete(ctx, models.DirectoryProviderGoogle); err != nil {
+		t.Fatalf("Delete err: %v", err)
+	}
+	if err := repo.Delete(ctx, models.DirectoryProviderGoogle); !errors.Is(err, models.ErrDirectoryNotConnected) {
+		t.Errorf("expected ErrDirectoryNotConnected, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const documentAliasColumns = `alias, doc_id, created_by, created_at`
+
+// DocumentAliasRepository handles database operations for the alternative IDs of documents
+type DocumentAliasRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewDocumentAliasRepository creates a new document alias repository
+func NewDocumentAliasRepository(db *sql.DB, tenants providers.TenantProvider) *DocumentAliasRepository {
+	return &DocumentAliasRepository{db: db, tenants: tenants}
+}
+
+func scanDocumentAlias(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.DocumentAlias, error) {
+	a := &models.DocumentAlias{}
+	if err := scanner.Scan(&a.Alias, &a.DocID, &a.CreatedBy, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Create adds an alias to a document. It returns ErrDocumentAliasTaken when the alias is already
+// used by another alias or is the ID of a document, deleted ones included.
+func (r *DocumentAliasRepository) Create(ctx context.Context, alias, docID, createdBy string) (*models.DocumentAlias, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_aliases (alias, tenant_id, doc_id, created_by)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM documents WHERE doc_id = $1)
+		RETURNING ` + documentAliasColumns
+
+	a, err := scanDocumentAlias(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, alias, tenantID, docID, createdBy))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrDocumentAliasTaken
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, models.ErrDocumentAliasTaken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document alias: %w", err)
+	}
+	return a, nil
+}
+
+// Resolve returns the ID of the document an alias leads to, or an empty string when the alias
+// does not exist or its document is deleted
+// RLS policy automatically filters by tenant_id
+func (r *DocumentAliasRepository) Resolve(ctx context.Context, alias string) (string, error) {
+	query := `
+		SELECT a.doc_id
+		FROM document_aliases a
+		JOIN documents d ON d.doc_id = a.doc_id
+		WHERE a.alias = $1 AND d.deleted_at IS NULL`
+
+	var docID string
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, alias).Scan(&docID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve document alias: %w", err)
+	}
+	return docID, nil
+}
+
+// ListByDoc retrieves the aliases of a document, oldest first
+// RLS policy automatically filters by tenant_id
+func (r *DocumentAliasRepository) ListByDoc(ctx context.Context, docID string) ([]*models.DocumentAlias, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT `+documentAliasColumns+` FROM document_aliases WHERE doc_id = $1 ORDER BY created_at ASC, alias ASC`, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []*models.DocumentAlias{}
+	for rows.Next() {
+		a, err := scanDocumentAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document alias: %w", err)
+		}
+		aliases = append(aliases, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate document aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// Delete removes an alias of a document; links using it stop working
+// RLS policy automatically filters by tenant_id
+func (r *DocumentAliasRepository) Delete(ctx context.Context, docID, alias string) error {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM document_aliases WHERE doc_id = $1 AND alias = $2`, docID, alias)
+	if err != nil {
+		return fmt.Errorf("failed to delete document alias: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrDocumentAliasNotFound
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestDocumentAliasRepository(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewDocumentAliasRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	for _, docID := range []string{"doc-alias-test", "doc-alias-other"} {
+		if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Security policy"}, "admin@example.com"); err != nil {
+			t.Fatalf("create document err: %v", err)
+		}
+	}
+
+	alias, err := repo.Create(ctx, "security-policy", "doc-alias-test", "admin@example.com")
+	if err != nil || alias.DocID != "doc-alias-test" || alias.CreatedBy != "admin@example.com" {
+		t.Fatalf("unexpected alias %+v, %v", alias, err)
+	}
+	if _, err := repo.Create(ctx, "security-policy-v1", "doc-alias-test", "admin@example.com"); err != nil {
+		t.Fatalf("create second alias err: %v", err)
+	}
+
+	// An alias is unique and never matches a document ID
+	if _, err := repo.Create(ctx, "security-policy", "doc-alias-other", "admin@example.com"); !errors.Is(err, models.ErrDocumentAliasTaken) {
+		t.Errorf("expected ErrDocumentAliasTaken for a used alias, got %v", err)
+	}
+	if _, err := repo.Create(ctx, "doc-alias-other", "doc-alias-test", "admin@example.com"); !errors.Is(err, models.ErrDocumentAliasTaken) {
+		t.Errorf("expected ErrDocumentAliasTaken for a document ID, got %v", err)
+	}
+
+	docID, err := repo.Resolve(ctx, "security-policy")
+	if err != nil || docID != "doc-alias-test" {
+		t.Errorf("expected the alias to resolve to doc-alias-test, got %q, %v", docID, err)
+	}
+	if docID, err := repo.Resolve(ctx, "unknown"); err != nil || docID != "" {
+		t.Errorf("expected no document for an unknown alias, got %q, %v", docID, err)
+	}
+
+	aliases, err := repo.ListByDoc(ctx, "doc-alias-test")
+	if err != nil || len(aliases) != 2 {
+		t.Fatalf("expected 2 aliases, got %d, %v", len(aliases), err)
+	}
+
+	if err := repo.Delete(ctx, "doc-alias-test", "security-policy-v1"); err != nil {
+		t.Fatalf("Delete err: %v", err)
+	}
+	if err := repo.Delete(ctx, "doc-alias-test", "security-policy-v1"); !errors.Is(err, models.ErrDocumentAliasNotFound) {
+		t.Errorf("expected ErrDocumentAliasNotFound, got %v", err)
+	}
+
+	// The aliases of a deleted document no longer resolve
+	if err := docRepo.Delete(ctx, "doc-alias-test"); err != nil {
+		t.Fatalf("delete document err: %v", err)
+	}
+	if docID, err := repo.Resolve(ctx, "security-policy"); err != nil || docID != "" {
+		t.Errorf("expected no document once deleted, got %q, %v", docID, err)
+	}
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const (
+	documentQuestionColumns = `id, doc_id, author_email, author_name, body, status, hidden_at, hidden_by, created_at, updated_at`
+	questionReplyColumns    = `id, question_id, author_email, author_name, body, from_owner, hidden_at, hidden_by, created_at`
+)
+
+// DocumentQuestionRepository handles database operations for the questions signers ask about
+// documents and their replies
+type DocumentQuestionRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewDocumentQuestionRepository creates a new document question repository
+func NewDocumentQuestionRepository(db *sql.DB, tenants providers.TenantProvider) *DocumentQuestionRepository {
+	return &DocumentQuestionRepository{db: db, tenants: tenants}
+}
+
+func scanDocumentQuestion(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.DocumentQuestion, error) {
+	q := &models.DocumentQuestion{Replies: []*models.DocumentQuestionReply{}}
+	if err := scanner.Scan(&q.ID, &q.DocID, &q.AuthorEmail, &q.AuthorName, &q.Body, &q.Status,
+		&q.HiddenAt, &q.HiddenBy, &q.CreatedAt, &q.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func scanQuestionReply(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.DocumentQuestionReply, error) {
+	r := &models.DocumentQuestionReply{}
+	if err := scanner.Scan(&r.ID, &r.QuestionID, &r.AuthorEmail, &r.AuthorName, &r.Body, &r.FromOwner,
+		&r.HiddenAt, &r.HiddenBy, &r.CreatedAt); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Create records an open question about a document
+func (r *DocumentQuestionRepository) Create(ctx context.Context, docID, authorEmail, authorName, body string) (*models.DocumentQuestion, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_questions (tenant_id, doc_id, author_email, author_name, body)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + documentQuestionColumns
+
+	q, err := scanDocumentQuestion(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, docID, authorEmail, authorName, body,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document question: %w", err)
+	}
+	return q, nil
+}
+
+// Get retrieves a question of a document with its replies
+// RLS policy automatically filters by tenant_id
+func (r *DocumentQuestionRepository) Get(ctx context.Context, docID string, id int64) (*models.DocumentQuestion, error) {
+	q, err := scanDocumentQuestion(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+documentQuestionColumns+` FROM document_questions WHERE doc_id = $1 AND id = $2`, docID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrQuestionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document question: %w", err)
+	}
+
+	if err := r.attachReplies(ctx, []*models.DocumentQuestion{q}); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// ListByDoc retrieves the questions of a document with their replies, newest question first
+// RLS policy automatically filters by tenant_id
+func (r *DocumentQuestionRepository) ListByDoc(ctx context.Context, docID string) ([]*models.DocumentQuestion, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT `+documentQuestionColumns+` FROM document_questions WHERE doc_id = $1 ORDER BY created_at DESC, id DESC`, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []*models.DocumentQuestion{}
+	for rows.Next() {
+		q, err := scanDocumentQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document question: %w", err)
+		}
+		questions = append(questions, q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate document questions: %w", err)
+	}
+
+	if err := r.attachReplies(ctx, questions); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// attachReplies loads the replies of questions, oldest first
+func (r *DocumentQuestionRepository) attachReplies(ctx context.Context, questions []*models.DocumentQuestion) error {
+	if len(questions) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*models.DocumentQuestion, len(questions))
+	ids := make([]int64, 0, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+		ids = append(ids, q.ID)
+	}
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT `+questionReplyColumns+` FROM document_question_replies WHERE question_id = ANY($1) ORDER BY created_at ASC, id ASC`,
+		pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query question replies: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		reply, err := scanQuestionReply(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan question reply: %w", err)
+		}
+		q := byID[reply.QuestionID]
+		q.Replies = append(q.Replies, reply)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate question replies: %w", err)
+	}
+	return nil
+}
+
+// AddReply adds a reply to a question of a document and moves the question to status. It returns
+// ErrQuestionClosed when the question was resolved or closed in the meantime.
+func (r *DocumentQuestionRepository) AddReply(ctx context.Context, docID string, reply models.DocumentQuestionReply, status models.QuestionStatus) (*models.DocumentQuestionReply, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		WITH q AS (
+			UPDATE document_questions
+			SET status = $3, updated_at = now()
+			WHERE doc_id = $1 AND id = $2 AND status IN ('open', 'answered')
+			RETURNING id
+		)
+		INSERT INTO document_question_replies (tenant_id, question_id, author_email, author_name, body, from_owner)
+		SELECT $4, q.id, $5, $6, $7, $8 FROM q
+		RETURNING ` + questionReplyColumns
+
+	created, err := scanQuestionReply(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		docID, reply.QuestionID, status, tenantID, reply.AuthorEmail, reply.AuthorName, reply.Body, reply.FromOwner,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrQuestionClosed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to add question reply: %w", err)
+	}
+	return created, nil
+}
+
+// SetStatus changes the status of a question of a document
+// RLS policy automatically filters by tenant_id
+func (r *DocumentQuestionRepository) SetStatus(ctx context.Context, docID string, id int64, status models.QuestionStatus) error {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`UPDATE document_questions SET status = $3, updated_at = now() WHERE doc_id = $1 AND id = $2`, docID, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update question status: %w", err)
+	}
+	return questionRowsAffected(result)
+}
+
+// SetHidden hides a question of a document from other signers, or shows it again
+// RLS policy automatically filters by tenant_id
+func (r *DocumentQuestionRepository) SetHidden(ctx context.Context, docID string, id int64, hidden bool, by string) error {
+	query := `
+		UPDATE document_questions
+		SET hidden_at = CASE WHEN $3 THEN COALESCE(hidden_at, now()) END,
+			hidden_by = CASE WHEN $3 THEN $4 ELSE '' END
+		WHERE doc_id = $1 AND id = $2`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, docID, id, hidden, by)
+	if err != nil {
+		return fmt.Errorf("failed to moderate document question: %w", err)
+	}
+	return questionRowsAffected(result)
+}
+
+// SetReplyHidden hides a reply to a question of a document from other signers, or shows it again
+// RLS policy automatically filters by tenant_id
+func (r *DocumentQuestionRepository) SetReplyHidden(ctx context.Context, docID string, questionID, replyID int64, hidden bool, by string) error {
+	query := `
+		UPDATE document_question_replies rp
+		SET hidden_at = CASE WHEN $4 THEN COALESCE(rp.hidden_at, now()) END,
+			hidden_by = CASE WHEN $4 THEN $5 ELSE '' END
+		FROM document_questions q
+		WHERE q.id = rp.question_id AND q.doc_id = $1 AND rp.question_id = $2 AND rp.id = $3`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, docID, questionID, replyID, hidden, by)
+	if err != nil {
+		return fmt.Errorf("failed to moderate question reply: %w", err)
+	}
+	return questionRowsAffected(result)
+}
+
+func questionRowsAffected(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrQuestionNotFound
+	}
+	return nil
+}
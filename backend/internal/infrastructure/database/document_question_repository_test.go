@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestDocumentQuestionRepository(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewDocumentQuestionRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if _, err := docRepo.Create(ctx, "doc-question-test", models.DocumentInput{Title: "Security policy"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	first, err := repo.Create(ctx, "doc-question-test", "alice@example.com", "Alice", "What does section 2 mean?")
+	if err != nil || first.Status != models.QuestionStatusOpen || first.HiddenAt != nil {
+		t.Fatalf("unexpected question %+v, %v", first, err)
+	}
+	second, err := repo.Create(ctx, "doc-question-test", "bob@example.com", "Bob", "Is it mandatory?")
+	if err != nil {
+		t.Fatalf("create second question err: %v", err)
+	}
+
+	reply, err := repo.AddReply(ctx, "doc-question-test", models.DocumentQuestionReply{
+		QuestionID: first.ID, AuthorEmail: "owner@example.com", AuthorName: "Owner", Body: "It covers data retention.", FromOwner: true,
+	}, models.QuestionStatusAnswered)
+	if err != nil || !reply.FromOwner || reply.QuestionID != first.ID {
+		t.Fatalf("unexpected reply %+v, %v", reply, err)
+	}
+
+	got, err := repo.Get(ctx, "doc-question-test", first.ID)
+	if err != nil || got.Status != models.QuestionStatusAnswered || len(got.Replies) != 1 {
+		t.Fatalf("expected an answered question with one reply, got %+v, %v", got, err)
+	}
+	if _, err := repo.Get(ctx, "other-doc", first.ID); !errors.Is(err, models.ErrQuestionNotFound) {
+		t.Errorf("expected ErrQuestionNotFound for another document, got %v", err)
+	}
+
+	questions, err := repo.ListByDoc(ctx, "doc-question-test")
+	if err != nil || len(questions) != 2 || questions[0].ID != second.ID || len(questions[1].Replies) != 1 {
+		t.Fatalf("expected 2 questions newest first with replies, got %+v, %v", questions, err)
+	}
+
+	// Resolved questions no longer accept replies
+	if err := repo.SetStatus(ctx, "doc-question-test", first.ID, models.QuestionStatusResolved); err != nil {
+		t.Fatalf("SetStatus err: %v", err)
+	}
+	if _, err := repo.AddReply(ctx, "doc-question-test", models.DocumentQuestionReply{
+		QuestionID: first.ID, AuthorEmail: "alice@example.com", Body: "Thanks",
+	}, models.QuestionStatusOpen); !errors.Is(err, models.ErrQuestionClosed) {
+		t.Errorf("expected ErrQuestionClosed, got %v", err)
+	}
+
+	if err := repo.SetHidden(ctx, "doc-question-test", second.ID, true, "owner@example.com"); err != nil {
+		t.Fatalf("SetHidden err: %v", err)
+	}
+	if err := repo.SetReplyHidden(ctx, "doc-question-test", first.ID, reply.ID, true, "owner@example.com"); err != nil {
+		t.Fatalf("SetReplyHidden err: %v", err)
+	}
+	hidden, err := repo.Get(ctx, "doc-question-test", second.ID)
+	if err != nil || hidden.HiddenAt == nil || hidden.HiddenBy != "owner@example.com" {
+		t.Fatalf("expected a hidden question, got %+v, %v", hidden, err)
+	}
+	got, _ = repo.Get(ctx, "doc-question-test", first.ID)
+	if got.Replies[0].HiddenAt == nil {
+		t.Errorf("expected a hidden reply, got %+v", got.Replies[0])
+	}
+
+	if err := repo.SetHidden(ctx, "doc-question-test", second.ID, false, "owner@example.com"); err != nil {
+		t.Fatalf("unhide err: %v", err)
+	}
+	shown, _ := repo.Get(ctx, "doc-question-test", second.ID)
+	if shown.HiddenAt != nil || shown.HiddenBy != "" {
+		t.Errorf("expected a visible question, got %+v", shown)
+	}
+
+	if err := repo.SetStatus(ctx, "doc-question-test", 999999, models.QuestionStatusClosed); !errors.Is(err, models.ErrQuestionNotFound) {
+		t.Errorf("expected ErrQuestionNotFound, got %v", err)
+	}
+	if err := repo.SetReplyHidden(ctx, "doc-question-test", second.ID, reply.ID, true, "owner@example.com"); !errors.Is(err, models.ErrQuestionNotFound) {
+		t.Errorf("expected ErrQuestionNotFound for a reply of another question, got %v", err)
+	}
+}
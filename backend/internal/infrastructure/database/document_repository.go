@@ -23,7 +23,8 @@ func NewDocumentRepository(db *sql.DB, tenants providers.TenantProvider) *Docume
 	return &DocumentRepository{db: db, tenants: tenants}
 }
 
-// Create persists a new document with metadata including optional checksum validation data
+// Create persists a new document with metadata including optional checksum validation data.
+// Within a department scope, the document belongs to the department of the department-admin.
 func (r *DocumentRepository) Create(ctx context.Context, docID string, input models.DocumentInput, createdBy string) (*models.Document, error) {
 	tenantID, err := r.tenants.CurrentTenant(ctx)
 	if err != nil {
@@ -31,9 +32,9 @@ func (r *DocumentRepository) Create(ctx context.Context, docID string, input mod
 	}
 
 	query := `
-		INSERT INTO documents (tenant_id, doc_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_by, storage_key, storage_provider, file_size, mime_type, original_filename)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
-		RETURNING doc_id, tenant_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_at, updated_at, created_by, deleted_at, storage_key, storage_provider, file_size, mime_type, original_filename
+		INSERT INTO documents (tenant_id, doc_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_by, storage_key, storage_provider, file_size, mime_type, original_filename, content_hash, department_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+		RETURNING doc_id, tenant_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_at, updated_at, created_by, deleted_at, storage_key, storage_provider, file_size, mime_type, original_filename, version, content_hash, department_id, landing_message
 	`
 
 	// Use NULL for empty checksum fields to avoid constraint violation
@@ -86,8 +87,14 @@ func (r *DocumentRepository) Create(ctx context.Context, docID string, input mod
 		originalFilename = sql.NullString{String: input.OriginalFilename, Valid: true}
 	}
 
+	// Content hash is only known for URL documents with extractable text
+	var contentHash sql.NullString
+	if input.ContentHash != "" {
+		contentHash = sql.NullString{String: input.ContentHash, Valid: true}
+	}
+
 	doc := &models.Document{}
-	var scanStorageKey, scanStorageProvider, scanMimeType, scanOriginalFilename sql.NullString
+	var scanStorageKey, scanStorageProvider, scanMimeType, scanOriginalFilename, scanContentHash sql.NullString
 	var scanFileSize sql.NullInt64
 
 	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(
@@ -110,6 +117,8 @@ func (r *DocumentRepository) Create(ctx context.Context, docID string, input mod
 		fileSize,
 		mimeType,
 		originalFilename,
+		contentHash,
+		dbctx.HomeDepartment(ctx),
 	).Scan(
 		&doc.DocID,
 		&doc.TenantID,
@@ -131,6 +140,10 @@ func (r *DocumentRepository) Create(ctx context.Context, docID string, input mod
 		&scanFileSize,
 		&scanMimeType,
 		&scanOriginalFilename,
+		&doc.Version,
+		&scanContentHash,
+		&doc.DepartmentID,
+		&doc.LandingMessage,
 	)
 
 	if err != nil {
@@ -144,17 +157,18 @@ func (r *DocumentRepository) Create(ctx context.Context, docID string, input mod
 	doc.FileSize = scanFileSize.Int64
 	doc.MimeType = scanMimeType.String
 	doc.OriginalFilename = scanOriginalFilename.String
+	doc.ContentHash = scanContentHash.String
 
 	return doc, nil
 }
 
 // documentColumns is the standard column list for document queries
-const documentColumns = `doc_id, tenant_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_at, updated_at, created_by, deleted_at, storage_key, storage_provider, file_size, mime_type, original_filename`
+const documentColumns = `doc_id, tenant_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_at, updated_at, created_by, deleted_at, storage_key, storage_provider, file_size, mime_type, original_filename, version, content_hash, department_id, landing_message`
 
 // scanDocument scans a row into a Document model with nullable storage fields
 func scanDocument(row interface{ Scan(dest ...any) error }) (*models.Document, error) {
 	doc := &models.Document{}
-	var storageKey, storageProvider, mimeType, originalFilename sql.NullString
+	var storageKey, storageProvider, mimeType, originalFilename, contentHash sql.NullString
 	var fileSize sql.NullInt64
 
 	err := row.Scan(
@@ -178,6 +192,10 @@ func scanDocument(row interface{ Scan(dest ...any) error }) (*models.Document, e
 		&fileSize,
 		&mimeType,
 		&originalFilename,
+		&doc.Version,
+		&contentHash,
+		&doc.DepartmentID,
+		&doc.LandingMessage,
 	)
 	if err != nil {
 		return nil, err
@@ -189,6 +207,7 @@ func scanDocument(row interface{ Scan(dest ...any) error }) (*models.Document, e
 	doc.FileSize = fileSize.Int64
 	doc.MimeType = mimeType.String
 	doc.OriginalFilename = originalFilename.String
+	doc.ContentHash = contentHash.String
 
 	return doc, nil
 }
@@ -252,7 +271,8 @@ func (r *DocumentRepository) FindByReference(ctx context.Context, ref string, re
 func (r *DocumentRepository) Update(ctx context.Context, docID string, input models.DocumentInput) (*models.Document, error) {
 	query := `
 		UPDATE documents
-		SET title = $2, url = $3, checksum = $4, checksum_algorithm = $5, description = $6, read_mode = $7, allow_download = $8, require_full_read = $9, verify_checksum = $10, storage_key = $11, storage_provider = $12, file_size = $13, mime_type = $14, original_filename = $15
+		SET title = $2, url = $3, checksum = $4, checksum_algorithm = $5, description = $6, read_mode = $7, allow_download = $8, require_full_read = $9, verify_checksum = $10, storage_key = $11, storage_provider = $12, file_size = $13, mime_type = $14, original_filename = $15,
+			content_hash = CASE WHEN $16::text IS NULL AND checksum = $4 THEN content_hash ELSE $16 END
 		WHERE doc_id = $1 AND deleted_at IS NULL
 		RETURNING ` + documentColumns
 
@@ -299,10 +319,16 @@ func (r *DocumentRepository) Update(ctx context.Context, docID string, input mod
 		originalFilename = sql.NullString{String: input.OriginalFilename, Valid: true}
 	}
 
+	// Content hash is only known for URL documents with extractable text
+	var contentHash sql.NullString
+	if input.ContentHash != "" {
+		contentHash = sql.NullString{String: input.ContentHash, Valid: true}
+	}
+
 	row := dbctx.GetQuerier(ctx, r.db).QueryRowContext(
 		ctx, query, docID, input.Title, input.URL, checksum, checksumAlgorithm,
 		input.Description, readMode, allowDownload, requireFullRead, verifyChecksum,
-		storageKey, storageProvider, fileSize, mimeType, originalFilename,
+		storageKey, storageProvider, fileSize, mimeType, originalFilename, contentHash,
 	)
 	doc, err := scanDocument(row)
 
@@ -326,8 +352,8 @@ func (r *DocumentRepository) CreateOrUpdate(ctx context.Context, docID string, i
 	}
 
 	query := `
-		INSERT INTO documents (tenant_id, doc_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_by, storage_key, storage_provider, file_size, mime_type, original_filename)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		INSERT INTO documents (tenant_id, doc_id, title, url, checksum, checksum_algorithm, description, read_mode, allow_download, require_full_read, verify_checksum, created_by, storage_key, storage_provider, file_size, mime_type, original_filename, content_hash, department_id, landing_message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, COALESCE($20::jsonb, '{}'::jsonb))
 		ON CONFLICT (doc_id) DO UPDATE SET
 			title = EXCLUDED.title,
 			url = EXCLUDED.url,
@@ -343,6 +369,8 @@ func (r *DocumentRepository) CreateOrUpdate(ctx context.Context, docID string, i
 			file_size = EXCLUDED.file_size,
 			mime_type = EXCLUDED.mime_type,
 			original_filename = EXCLUDED.original_filename,
+			content_hash = CASE WHEN EXCLUDED.content_hash IS NULL AND documents.checksum = EXCLUDED.checksum THEN documents.content_hash ELSE EXCLUDED.content_hash END,
+			landing_message = CASE WHEN $20::jsonb IS NULL THEN documents.landing_message ELSE EXCLUDED.landing_message END,
 			deleted_at = NULL
 		RETURNING ` + documentColumns
 
@@ -389,10 +417,17 @@ func (r *DocumentRepository) CreateOrUpdate(ctx context.Context, docID string, i
 		originalFilename = sql.NullString{String: input.OriginalFilename, Valid: true}
 	}
 
+	// Content hash is only known for URL documents with extractable text
+	var contentHash sql.NullString
+	if input.ContentHash != "" {
+		contentHash = sql.NullString{String: input.ContentHash, Valid: true}
+	}
+
 	row := dbctx.GetQuerier(ctx, r.db).QueryRowContext(
 		ctx, query, tenantID, docID, input.Title, input.URL, checksum, checksumAlgorithm,
 		input.Description, readMode, allowDownload, requireFullRead, verifyChecksum, createdBy,
-		storageKey, storageProvider, fileSize, mimeType, originalFilename,
+		storageKey, storageProvider, fileSize, mimeType, originalFilename, contentHash, dbctx.HomeDepartment(ctx),
+		input.LandingMessage,
 	)
 	doc, err := scanDocument(row)
 
@@ -404,6 +439,76 @@ func (r *DocumentRepository) CreateOrUpdate(ctx context.Context, docID string, i
 	return doc, nil
 }
 
+// Patch applies a partial metadata update, persisting only the fields set in the patch.
+// When expectedVersion is non-nil the update only succeeds if the stored version matches,
+// returning models.ErrVersionConflict otherwise.
+// RLS policy automatically filters by tenant_id
+func (r *DocumentRepository) Patch(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+	query := `
+		UPDATE documents SET
+			title = COALESCE($2, title),
+			url = COALESCE($3, url),
+			checksum = COALESCE($4, checksum),
+			content_hash = CASE WHEN $4::text IS NULL OR $4 = checksum THEN content_hash ELSE NULL END,
+			checksum_algorithm = COALESCE($5, checksum_algorithm),
+			description = COALESCE($6, description),
+			read_mode = COALESCE($7, read_mode),
+			allow_download = COALESCE($8, allow_download),
+			require_full_read = COALESCE($9, require_full_read),
+			verify_checksum = COALESCE($10, verify_checksum),
+			landing_message = COALESCE($12::jsonb, landing_message)
+		WHERE doc_id = $1 AND deleted_at IS NULL AND ($11::int IS NULL OR version = $11)
+		RETURNING ` + documentColumns
+
+	var version sql.NullInt64
+	if expectedVersion != nil {
+		version = sql.NullInt64{Int64: int64(*expectedVersion), Valid: true}
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	row := q.QueryRowContext(
+		ctx, query, docID,
+		nullableString(patch.Title), nullableString(patch.URL), nullableString(patch.Checksum),
+		nullableString(patch.ChecksumAlgorithm), nullableString(patch.Description), nullableString(patch.ReadMode),
+		nullableBool(patch.AllowDownload), nullableBool(patch.RequireFullRead), nullableBool(patch.VerifyChecksum),
+		version, patch.LandingMessage,
+	)
+	doc, err := scanDocument(row)
+
+	if err == sql.ErrNoRows {
+		// Distinguish a missing document from a stale version
+		var exists bool
+		if err := q.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM documents WHERE doc_id = $1 AND deleted_at IS NULL)`, docID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check document existence: %w", err)
+		}
+		if !exists {
+			return nil, models.ErrDocumentNotFound
+		}
+		return nil, models.ErrVersionConflict
+	}
+
+	if err != nil {
+		logger.Logger.Error("Failed to patch document", "error", err.Error(), "doc_id", docID)
+		return nil, fmt.Errorf("failed to patch document: %w", err)
+	}
+
+	return doc, nil
+}
+
+func nullableString(v *string) sql.NullString {
+	if v == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *v, Valid: true}
+}
+
+func nullableBool(v *bool) sql.NullBool {
+	if v == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *v, Valid: true}
+}
+
 // Delete soft-deletes document by setting deleted_at timestamp, preserving metadata and signature history
 // RLS policy automatically filters by tenant_id
 func (r *DocumentRepository) Delete(ctx context.Context, docID string) error {
@@ -427,12 +532,39 @@ func (r *DocumentRepository) Delete(ctx context.Context, docID string) error {
 	return nil
 }
 
+// Restore clears deleted_at on a soft-deleted document and on its signatures
+// RLS policy automatically filters by tenant_id
+func (r *DocumentRepository) Restore(ctx context.Context, docID string) error {
+	q := dbctx.GetQuerier(ctx, r.db)
+
+	result, err := q.ExecContext(ctx, `UPDATE documents SET deleted_at = NULL WHERE doc_id = $1 AND deleted_at IS NOT NULL`, docID)
+	if err != nil {
+		logger.Logger.Error("Failed to restore document", "error", err.Error(), "doc_id", docID)
+		return fmt.Errorf("failed to restore document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return models.ErrDocumentNotFound
+	}
+
+	if _, err := q.ExecContext(ctx, `UPDATE signatures SET doc_deleted_at = NULL WHERE doc_id = $1`, docID); err != nil {
+		return fmt.Errorf("failed to restore document signatures: %w", err)
+	}
+
+	return nil
+}
+
 // scanDocumentRows scans multiple rows into Document models
 func scanDocumentRows(rows *sql.Rows) ([]*models.Document, error) {
 	documents := []*models.Document{}
 	for rows.Next() {
 		doc := &models.Document{}
-		var storageKey, storageProvider, mimeType, originalFilename sql.NullString
+		var storageKey, storageProvider, mimeType, originalFilename, contentHash sql.NullString
 		var fileSize sql.NullInt64
 
 		err := rows.Scan(
@@ -441,6 +573,7 @@ func scanDocumentRows(rows *sql.Rows) ([]*models.Document, error) {
 			&doc.AllowDownload, &doc.RequireFullRead, &doc.VerifyChecksum,
 			&doc.CreatedAt, &doc.UpdatedAt, &doc.CreatedBy, &doc.DeletedAt,
 			&storageKey, &storageProvider, &fileSize, &mimeType, &originalFilename,
+			&doc.Version, &contentHash, &doc.DepartmentID,
 		)
 		if err != nil {
 			return nil, err
@@ -451,6 +584,7 @@ func scanDocumentRows(rows *sql.Rows) ([]*models.Document, error) {
 		doc.FileSize = fileSize.Int64
 		doc.MimeType = mimeType.String
 		doc.OriginalFilename = originalFilename.String
+		doc.ContentHash = contentHash.String
 		documents = append(documents, doc)
 	}
 	return documents, rows.Err()
@@ -461,7 +595,7 @@ func scanDocumentRows(rows *sql.Rows) ([]*models.Document, error) {
 func (r *DocumentRepository) List(ctx context.Context, limit, offset int) ([]*models.Document, error) {
 	query := `SELECT ` + documentColumns + ` FROM documents WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
-	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, limit, offset)
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		logger.Logger.Error("Failed to list documents", "error", err.Error())
 		return nil, fmt.Errorf("failed to list documents: %w", err)
@@ -484,7 +618,7 @@ func (r *DocumentRepository) Search(ctx context.Context, query string, limit, of
 	searchQuery := `SELECT ` + documentColumns + ` FROM documents WHERE deleted_at IS NULL AND (doc_id ILIKE $1 OR title ILIKE $1 OR url ILIKE $1 OR description ILIKE $1) ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 
 	searchPattern := "%" + query + "%"
-	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, searchQuery, searchPattern, limit, offset)
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, searchQuery, searchPattern, limit, offset)
 	if err != nil {
 		logger.Logger.Error("Failed to search documents", "error", err.Error(), "query", query)
 		return nil, fmt.Errorf("failed to search documents: %w", err)
@@ -501,6 +635,42 @@ func (r *DocumentRepository) Search(ctx context.Context, query string, limit, of
 	return documents, nil
 }
 
+// ListKeyset retrieves up to limit documents past cursor, newest first or oldest first for
+// backward cursors, optionally matching a search query (excluding soft-deleted)
+// RLS policy automatically filters by tenant_id
+func (r *DocumentRepository) ListKeyset(ctx context.Context, searchQuery string, cursor *models.PageCursor, limit int) ([]*models.Document, error) {
+	var key interface{}
+	if cursor != nil {
+		key = cursor.Key
+	}
+	condition, order, args := keysetClause("created_at", "doc_id", cursor, key, 3)
+	query := `SELECT ` + documentColumns + ` FROM documents
+		WHERE deleted_at IS NULL
+		AND ($2 = '' OR doc_id ILIKE $2 OR title ILIKE $2 OR url ILIKE $2 OR description ILIKE $2)
+		AND ` + condition + `
+		ORDER BY ` + order + `
+		LIMIT $1`
+
+	searchPattern := ""
+	if searchQuery != "" {
+		searchPattern = "%" + searchQuery + "%"
+	}
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, append([]interface{}{limit, searchPattern}, args...)...)
+	if err != nil {
+		logger.Logger.Error("Failed to list documents", "error", err.Error())
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents, err := scanDocumentRows(rows)
+	if err != nil {
+		logger.Logger.Error("Failed to scan document rows", "error", err.Error())
+		return nil, fmt.Errorf("failed to scan documents: %w", err)
+	}
+
+	return documents, nil
+}
+
 // Count returns the total number of documents matching the optional search query (excluding soft-deleted)
 func (r *DocumentRepository) Count(ctx context.Context, searchQuery string) (int, error) {
 	var query string
@@ -532,7 +702,7 @@ func (r *DocumentRepository) Count(ctx context.Context, searchQuery string) (int
 	}
 
 	var count int
-	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
+	err := dbctx.GetReadQuerier(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		logger.Logger.Error("Failed to count documents", "error", err.Error(), "search", searchQuery)
 		return 0, fmt.Errorf("failed to count documents: %w", err)
@@ -547,7 +717,7 @@ func (r *DocumentRepository) Count(ctx context.Context, searchQuery string) (int
 func (r *DocumentRepository) ListByCreatedBy(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error) {
 	query := `SELECT ` + documentColumns + ` FROM documents WHERE deleted_at IS NULL AND created_by = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
 
-	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, createdBy, limit, offset)
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, createdBy, limit, offset)
 	if err != nil {
 		logger.Logger.Error("Failed to list documents by creator", "error", err.Error(), "created_by", createdBy)
 		return nil, fmt.Errorf("failed to list documents: %w", err)
@@ -569,7 +739,7 @@ func (r *DocumentRepository) SearchByCreatedBy(ctx context.Context, createdBy, s
 	query := `SELECT ` + documentColumns + ` FROM documents WHERE deleted_at IS NULL AND created_by = $1 AND (doc_id ILIKE $2 OR title ILIKE $2 OR url ILIKE $2 OR description ILIKE $2) ORDER BY created_at DESC LIMIT $3 OFFSET $4`
 
 	searchPattern := "%" + searchQuery + "%"
-	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, createdBy, searchPattern, limit, offset)
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, createdBy, searchPattern, limit, offset)
 	if err != nil {
 		logger.Logger.Error("Failed to search documents by creator", "error", err.Error(), "created_by", createdBy, "query", searchQuery)
 		return nil, fmt.Errorf("failed to search documents: %w", err)
@@ -615,7 +785,7 @@ func (r *DocumentRepository) CountByCreatedBy(ctx context.Context, createdBy, se
 	}
 
 	var count int
-	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
+	err := dbctx.GetReadQuerier(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		logger.Logger.Error("Failed to count documents by creator", "error", err.Error(), "created_by", createdBy, "search", searchQuery)
 		return 0, fmt.Errorf("failed to count documents: %w", err)
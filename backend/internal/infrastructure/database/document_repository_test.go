@@ -5,8 +5,10 @@ package database
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
@@ -187,6 +189,52 @@ func TestDocumentRepository_Update(t *testing.T) {
 	}
 }
 
+func TestDocumentRepository_UpdateKeepsDepartment(t *testing.T) {
+	testDB := SetupTestDB(t)
+
+	ctx := context.Background()
+	repo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	departments := NewDepartmentRepository(testDB.DB, testDB.TenantProvider)
+
+	sales, err := departments.Create(ctx, models.DepartmentInput{Name: "Sales"})
+	if err != nil {
+		t.Fatalf("Failed to create department: %v", err)
+	}
+	emea, err := departments.Create(ctx, models.DepartmentInput{Name: "EMEA", ParentID: &sales.ID})
+	if err != nil {
+		t.Fatalf("Failed to create sub-department: %v", err)
+	}
+
+	created, err := repo.Create(dbctx.WithDepartmentScope(ctx, []int64{emea.ID}), "update-dept-doc", models.DocumentInput{Title: "Price list"}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Failed to create document: %v", err)
+	}
+	if created.DepartmentID == nil || *created.DepartmentID != emea.ID {
+		t.Fatalf("Expected document in department %d, got %v", emea.ID, created.DepartmentID)
+	}
+
+	// Updating from the parent department scope does not move the document
+	updated, err := repo.Update(dbctx.WithDepartmentScope(ctx, []int64{sales.ID, emea.ID}), "update-dept-doc", models.DocumentInput{Title: "Price list 2026"})
+	if err != nil {
+		t.Fatalf("Failed to update document: %v", err)
+	}
+	if updated.Title != "Price list 2026" {
+		t.Errorf("Expected Title Price list 2026, got %s", updated.Title)
+	}
+	if updated.DepartmentID == nil || *updated.DepartmentID != emea.ID {
+		t.Errorf("Expected department %d to be kept, got %v", emea.ID, updated.DepartmentID)
+	}
+
+	// Updates outside any department scope keep it too
+	updated, err = repo.Update(ctx, "update-dept-doc", models.DocumentInput{Title: "Price list 2027"})
+	if err != nil {
+		t.Fatalf("Failed to update document without scope: %v", err)
+	}
+	if updated.DepartmentID == nil || *updated.DepartmentID != emea.ID {
+		t.Errorf("Expected department %d to be kept, got %v", emea.ID, updated.DepartmentID)
+	}
+}
+
 func TestDocumentRepository_CreateOrUpdate(t *testing.T) {
 	testDB := SetupTestDB(t)
 
@@ -344,6 +392,56 @@ func TestDocumentRepository_List(t *testing.T) {
 	}
 }
 
+func TestDocumentRepository_ListKeyset(t *testing.T) {
+	testDB := SetupTestDB(t)
+
+	ctx := context.Background()
+	repo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+
+	for i := 1; i <= 5; i++ {
+		input := models.DocumentInput{Title: "Keyset " + string(rune('A'+i-1))}
+		if _, err := repo.Create(ctx, "keyset-doc-00"+string(rune('0'+i)), input, "admin@example.com"); err != nil {
+			t.Fatalf("Failed to create document %d: %v", i, err)
+		}
+	}
+
+	all, err := repo.ListKeyset(ctx, "", nil, 10)
+	if err != nil {
+		t.Fatalf("Failed to list documents: %v", err)
+	}
+	if len(all) != 5 {
+		t.Fatalf("Expected 5 documents, got %d", len(all))
+	}
+
+	// Forward from the second document returns the following ones
+	cursor := &models.PageCursor{At: all[1].CreatedAt, Key: all[1].DocID}
+	next, err := repo.ListKeyset(ctx, "", cursor, 2)
+	if err != nil {
+		t.Fatalf("Failed to list next documents: %v", err)
+	}
+	if len(next) != 2 || next[0].DocID != all[2].DocID || next[1].DocID != all[3].DocID {
+		t.Errorf("Expected documents %s and %s after the cursor, got %v", all[2].DocID, all[3].DocID, next)
+	}
+
+	// Backward from the fourth document returns the previous ones, oldest first
+	cursor = &models.PageCursor{At: all[3].CreatedAt, Key: all[3].DocID, Backward: true}
+	prev, err := repo.ListKeyset(ctx, "", cursor, 2)
+	if err != nil {
+		t.Fatalf("Failed to list previous documents: %v", err)
+	}
+	if len(prev) != 2 || prev[0].DocID != all[2].DocID || prev[1].DocID != all[1].DocID {
+		t.Errorf("Expected documents %s and %s before the cursor, got %v", all[2].DocID, all[1].DocID, prev)
+	}
+
+	filtered, err := repo.ListKeyset(ctx, "Keyset C", nil, 10)
+	if err != nil {
+		t.Fatalf("Failed to search documents: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].DocID != "keyset-doc-003" {
+		t.Errorf("Expected keyset-doc-003 only, got %v", filtered)
+	}
+}
+
 func TestDocumentRepository_FindByReference_Integration(t *testing.T) {
 	testDB := SetupTestDB(t)
 
@@ -391,3 +489,99 @@ func TestDocumentRepository_FindByReference_Integration(t *testing.T) {
 		// This is expected - not found
 	}
 }
+
+func TestDocumentRepository_Patch(t *testing.T) {
+	testDB := SetupTestDB(t)
+
+	ctx := context.Background()
+	repo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+
+	allowDownload := false
+	created, err := repo.Create(ctx, "patch-doc-001", models.DocumentInput{
+		Title:         "Original",
+		Description:   "Keep me",
+		AllowDownload: &allowDownload,
+	}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Failed to create document: %v", err)
+	}
+
+	if created.Version != 1 {
+		t.Fatalf("Expected initial version 1, got %d", created.Version)
+	}
+
+	// Only the title is provided: other fields must be preserved
+	title := "Patched"
+	patched, err := repo.Patch(ctx, "patch-doc-001", models.DocumentPatch{Title: &title}, &created.Version)
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+
+	if patched.Title != "Patched" {
+		t.Errorf("Expected title Patched, got %s", patched.Title)
+	}
+	if patched.Description != "Keep me" {
+		t.Errorf("Expected description to be preserved, got %s", patched.Description)
+	}
+	if patched.AllowDownload {
+		t.Error("Expected allow_download to be preserved as false")
+	}
+	if patched.Version != 2 {
+		t.Errorf("Expected version 2, got %d", patched.Version)
+	}
+
+	// Reusing the stale version must be rejected
+	description := "Stale"
+	_, err = repo.Patch(ctx, "patch-doc-001", models.DocumentPatch{Description: &description}, &created.Version)
+	if !errors.Is(err, models.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	// Missing documents are reported as not found
+	_, err = repo.Patch(ctx, "patch-doc-missing", models.DocumentPatch{Description: &description}, nil)
+	if !errors.Is(err, models.ErrDocumentNotFound) {
+		t.Errorf("Expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestDocumentRepository_LandingMessage(t *testing.T) {
+	testDB := SetupTestDB(t)
+
+	ctx := context.Background()
+	repo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+
+	created, err := repo.Create(ctx, "landing-doc-001", models.DocumentInput{Title: "Policy"}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Failed to create document: %v", err)
+	}
+	if len(created.LandingMessage) != 0 {
+		t.Errorf("Expected no landing message, got %v", created.LandingMessage)
+	}
+
+	message := models.LandingMessage{"en": "Read **all** of it", "fr": "Lisez **tout**"}
+	patched, err := repo.Patch(ctx, "landing-doc-001", models.DocumentPatch{LandingMessage: message}, nil)
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	if patched.LandingMessage["fr"] != "Lisez **tout**" {
+		t.Errorf("Expected the landing message to be stored, got %v", patched.LandingMessage)
+	}
+
+	// Updates without a landing message keep it
+	updated, err := repo.CreateOrUpdate(ctx, "landing-doc-001", models.DocumentInput{Title: "Policy v2"}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("CreateOrUpdate failed: %v", err)
+	}
+	if len(updated.LandingMessage) != 2 {
+		t.Errorf("Expected the landing message to be preserved, got %v", updated.LandingMessage)
+	}
+
+	// An empty message clears it
+	cleared, err := repo.Patch(ctx, "landing-doc-001", models.DocumentPatch{LandingMessage: models.LandingMessage{}}, nil)
+	if err != nil {
+		t.Fatalf("Patch failed: %v", err)
+	}
+	if len(cleared.LandingMessage) != 0 {
+		t.Errorf("Expected the landing message to be cleared, got %v", cleared.LandingMessage)
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// documentStatusQuery loads a document, its expected signers with their status, the
+// signatures of unexpected users with their approval and the reminder totals in a single round-trip.
+// Lists are aggregated as JSON; the signer columns match ListWithStatusByDocID.
+const documentStatusQuery = `
+	WITH signer_status AS (
+		SELECT
+			es.id,
+			es.tenant_id,
+			es.doc_id,
+			es.email,
+			es.name,
+			es.added_at,
+			es.added_by,
+			es.notes,
+			es.sign_order,
+			s.id IS NOT NULL as has_signed,
+			s.signed_at,
+			s.user_name,
+			MAX(rl.sent_at) as last_reminder_sent,
+			-- Queued reminders count too: they drive the escalation level of the next one
+			COUNT(CASE WHEN rl.status <> 'failed' THEN 1 END) as reminder_count,
+			EXTRACT(DAY FROM (NOW() - es.added_at))::int as days_since_added,
+			EXTRACT(DAY FROM (NOW() - MAX(rl.sent_at)))::int as days_since_last_reminder
+		FROM expected_signers es
+		LEFT JOIN LATERAL (
+			-- A person may have signed under several matching addresses: keep the first signature
+			SELECT id, signed_at, user_name FROM signatures
+			WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+			ORDER BY signed_at ASC
+			LIMIT 1
+		) s ON true
+		LEFT JOIN reminder_logs rl ON es.tenant_id = rl.tenant_id AND es.doc_id = rl.doc_id AND es.email = rl.recipient_email
+		WHERE es.doc_id = $1
+		GROUP BY es.id, es.tenant_id, es.doc_id, es.email, es.name, es.added_at, es.added_by, es.notes, es.sign_order, s.id, s.signed_at, s.user_name
+	),
+	unexpected AS (
+		SELECT s.user_email, COALESCE(s.user_name, '') as user_name, s.signed_at, s.created_at,
+			COALESCE(sa.id, 0) as approval_id, COALESCE(sa.status, '') as approval_status
+		FROM signatures s
+		LEFT JOIN signature_approvals sa ON sa.tenant_id = s.tenant_id AND sa.signature_id = s.id
+		WHERE s.doc_id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM expected_signers es
+			WHERE es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id AND es.match_key = s.match_key
+		  )
+	),
+	reminders AS (
+		SELECT COUNT(*) as total_sent, MAX(sent_at) as last_sent_at
+		FROM reminder_logs
+		WHERE doc_id = $1 AND status = 'sent'
+	)
+	SELECT
+		(SELECT row_to_json(d) FROM (
+			SELECT ` + documentColumns + ` FROM documents WHERE doc_id = $1 AND deleted_at IS NULL
+		) d),
+		(SELECT COALESCE(json_agg(ss ORDER BY ss.has_signed DESC, ss.sign_order ASC NULLS LAST, ss.added_at ASC), '[]')
+		 FROM signer_status ss),
+		(SELECT COALESCE(json_agg(json_build_object(
+			'user_email', u.user_email, 'user_name', u.user_name, 'signed_at', u.signed_at,
+			'approval_id', u.approval_id, 'approval_status', u.approval_status
+		 ) ORDER BY u.created_at DESC), '[]')
+		 FROM unexpected u),
+		r.total_sent,
+		r.last_sent_at
+	FROM reminders r
+`
+
+// GetStatus retrieves everything the admin status page needs about a document in one query.
+// Document is nil when the document has no metadata; signers and signatures are still returned.
+// RLS policy automatically filters by tenant_id
+func (r *DocumentRepository) GetStatus(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+	var documentJSON, signersJSON, unexpectedJSON []byte
+	var lastSent sql.NullTime
+	reminderStats := &models.ReminderStats{}
+
+	err := dbctx.GetReadQuerier(ctx, r.db).QueryRowContext(ctx, documentStatusQuery, docID).Scan(
+		&documentJSON,
+		&signersJSON,
+		&unexpectedJSON,
+		&reminderStats.TotalSent,
+		&lastSent,
+	)
+	if err != nil {
+		logger.Logger.Error("Failed to get document status", "error", err.Error(), "doc_id", docID)
+		return nil, fmt.Errorf("failed to get document status: %w", err)
+	}
+
+	status := &models.DocumentStatus{
+		DocID:                docID,
+		Signers:              []*models.ExpectedSignerWithStatus{},
+		UnexpectedSignatures: []*models.UnexpectedSignature{},
+		ReminderStats:        reminderStats,
+	}
+
+	if documentJSON != nil {
+		if err := json.Unmarshal(documentJSON, &status.Document); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+	}
+	if err := json.Unmarshal(signersJSON, &status.Signers); err != nil {
+		return nil, fmt.Errorf("failed to decode expected signers: %w", err)
+	}
+	if err := json.Unmarshal(unexpectedJSON, &status.UnexpectedSignatures); err != nil {
+		return nil, fmt.Errorf("failed to decode unexpected signatures: %w", err)
+	}
+	if lastSent.Valid {
+		reminderStats.LastSentAt = &lastSent.Time
+	}
+
+	stats := &models.DocCompletionStats{DocID: docID, ExpectedCount: len(status.Signers)}
+	for _, signer := range status.Signers {
+		if signer.HasSigned {
+			stats.SignedCount++
+		}
+	}
+	stats.PendingCount = stats.ExpectedCount - stats.SignedCount
+	if stats.ExpectedCount > 0 {
+		stats.CompletionRate = float64(stats.SignedCount) / float64(stats.ExpectedCount) * 100
+	}
+	status.Stats = stats
+	reminderStats.PendingCount = stats.PendingCount
+
+	return status, nil
+}
@@ -0,0 +1,166 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// seedDocumentStatus creates a document with the given number of expected signers, half of them signed,
+// one signature from an unexpected user and a sent reminder for every pending signer
+func seedDocumentStatus(tb testing.TB, tdb *TestDB, docID string, signers int) {
+	tb.Helper()
+	ctx := context.Background()
+
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	signerRepo := NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider)
+	sigRepo := NewSignatureRepository(tdb.DB, tdb.TenantProvider)
+	reminderRepo := NewReminderRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Status " + docID, URL: "https://example.com/" + docID}, "admin@example.com"); err != nil {
+		tb.Fatalf("create document err: %v", err)
+	}
+
+	contacts := make([]models.ContactInfo, signers)
+	for i := range contacts {
+		contacts[i] = models.ContactInfo{Name: fmt.Sprintf("Signer %d", i), Email: fmt.Sprintf("signer%d@example.com", i)}
+	}
+	if err := signerRepo.AddExpected(ctx, docID, contacts, "admin@example.com"); err != nil {
+		tb.Fatalf("add expected err: %v", err)
+	}
+
+	factory := &SignatureFactory{}
+	sign := func(email string) {
+		sig := factory.CreateSignatureWithDocAndUser(docID, "sub-"+email, email)
+		sig.Nonce = "nonce-" + docID + "-" + email
+		if err := sigRepo.Create(ctx, sig); err != nil {
+			tb.Fatalf("create signature err: %v", err)
+		}
+	}
+	for i, contact := range contacts {
+		if i%2 == 0 {
+			sign(contact.Email)
+			continue
+		}
+		if err := reminderRepo.LogReminder(ctx, &models.ReminderLog{
+			DocID: docID, RecipientEmail: contact.Email, SentBy: "admin@example.com",
+			TemplateUsed: "signature_reminder", Status: "sent",
+		}); err != nil {
+			tb.Fatalf("log reminder err: %v", err)
+		}
+	}
+	sign("outsider@example.com")
+}
+
+func TestDocumentRepository_GetStatus(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	seedDocumentStatus(t, tdb, "status-doc", 4)
+
+	repo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	status, err := repo.GetStatus(ctx, "status-doc")
+	if err != nil {
+		t.Fatalf("get status err: %v", err)
+	}
+
+	if status.Document == nil || status.Document.Title != "Status status-doc" {
+		t.Fatalf("expected document metadata, got %+v", status.Document)
+	}
+	if len(status.Signers) != 4 {
+		t.Fatalf("expected 4 signers, got %d", len(status.Signers))
+	}
+	if !status.Signers[0].HasSigned || status.Signers[3].HasSigned {
+		t.Errorf("expected signed signers first, got %+v", status.Signers)
+	}
+	if len(status.UnexpectedSignatures) != 1 || status.UnexpectedSignatures[0].UserEmail != "outsider@example.com" {
+		t.Errorf("expected one unexpected signature, got %+v", status.UnexpectedSignatures)
+	}
+	if status.Stats.ExpectedCount != 4 || status.Stats.SignedCount != 2 || status.Stats.CompletionRate != 50 {
+		t.Errorf("unexpected stats: %+v", status.Stats)
+	}
+	if status.ReminderStats.TotalSent != 2 || status.ReminderStats.PendingCount != 2 || status.ReminderStats.LastSentAt == nil {
+		t.Errorf("unexpected reminder stats: %+v", status.ReminderStats)
+	}
+
+	// Results must match the separate repository calls the status page used before
+	signers, err := NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider).ListWithStatusByDocID(ctx, "status-doc")
+	if err != nil {
+		t.Fatalf("list signers err: %v", err)
+	}
+	for i, signer := range signers {
+		got := status.Signers[i]
+		if got.Email != signer.Email || got.HasSigned != signer.HasSigned || got.ReminderCount != signer.ReminderCount {
+			t.Errorf("signer %d mismatch: got %+v, want %+v", i, got, signer)
+		}
+		if !got.AddedAt.Equal(signer.AddedAt) {
+			t.Errorf("signer %d added_at mismatch: got %v, want %v", i, got.AddedAt, signer.AddedAt)
+		}
+	}
+}
+
+func TestDocumentRepository_GetStatus_UnknownDocument(t *testing.T) {
+	tdb := SetupTestDB(t)
+
+	repo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	status, err := repo.GetStatus(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("get status err: %v", err)
+	}
+	if status.Document != nil || len(status.Signers) != 0 || len(status.UnexpectedSignatures) != 0 {
+		t.Fatalf("expected empty status, got %+v", status)
+	}
+	if status.Stats.CompletionRate != 0 || status.ReminderStats.LastSentAt != nil {
+		t.Fatalf("expected zero stats, got %+v / %+v", status.Stats, status.ReminderStats)
+	}
+}
+
+// BenchmarkDocumentStatus compares the aggregated status query with the five
+// repository calls the admin status page issued before
+func BenchmarkDocumentStatus(b *testing.B) {
+	tdb := SetupTestDB(b)
+	ctx := context.Background()
+
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	signerRepo := NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider)
+	sigRepo := NewSignatureRepository(tdb.DB, tdb.TenantProvider)
+	reminderRepo := NewReminderRepository(tdb.DB, tdb.TenantProvider)
+
+	for _, signers := range []int{10, 100, 500} {
+		docID := fmt.Sprintf("bench-%d", signers)
+		seedDocumentStatus(b, tdb, docID, signers)
+
+		b.Run(fmt.Sprintf("aggregated/%d", signers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := docRepo.GetStatus(ctx, docID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("separate/%d", signers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := docRepo.GetByDocID(ctx, docID); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := signerRepo.ListWithStatusByDocID(ctx, docID); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := sigRepo.GetByDoc(ctx, docID); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := signerRepo.GetStats(ctx, docID); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := reminderRepo.GetReminderStats(ctx, docID); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
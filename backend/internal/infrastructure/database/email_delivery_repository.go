@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+// EmailDeliveryRepository stores the outcome of each send to the SMTP servers and aggregates them
+// for the deliverability report
+type EmailDeliveryRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewEmailDeliveryRepository creates a new email delivery repository
+func NewEmailDeliveryRepository(db *sql.DB, tenants providers.TenantProvider) *EmailDeliveryRepository {
+	return &EmailDeliveryRepository{db: db, tenants: tenants}
+}
+
+// Record stores a send.
+// It is written in its own transaction, since the queue update of a failed send may be rolled back.
+func (r *EmailDeliveryRepository) Record(ctx context.Context, delivery *models.EmailDelivery) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_deliveries (tenant_id, message_id, template, recipient_domains, status, smtp_code, error, server, latency_ms)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at`
+
+	err = tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+		return dbctx.GetQuerier(txCtx, r.db).QueryRowContext(txCtx, query,
+			tenantID, delivery.MessageID, delivery.Template, pq.Array(delivery.RecipientDomains), delivery.Status,
+			delivery.SMTPCode, delivery.Error, delivery.Server, delivery.LatencyMs,
+		).Scan(&delivery.ID, &delivery.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record email delivery: %w", err)
+	}
+	delivery.TenantID = tenantID
+	return nil
+}
+
+// ListDomains aggregates the sends since a given time per recipient domain, most failures first
+// RLS policy automatically filters by tenant_id
+func (r *EmailDeliveryRepository) ListDomains(ctx context.Context, since time.Time, limit int) ([]*models.EmailDomainDeliverability, error) {
+	query := `
+		WITH per_domain AS (
+			SELECT unnest(recipient_domains) AS domain, status, smtp_code, latency_ms, created_at
+			FROM email_deliveries
+			WHERE created_at > $1
+		)
+		SELECT domain,
+			COUNT(*) FILTER (WHERE status = 'sent') AS sent,
+			COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+			COALESCE(AVG(latency_ms), 0)::BIGINT AS avg_latency_ms,
+			(ARRAY_AGG(smtp_code ORDER BY created_at DESC) FILTER (WHERE status = 'failed'))[1] AS last_failure_code,
+			MAX(created_at) FILTER (WHERE status = 'failed') AS last_failure_at
+		FROM per_domain
+		GROUP BY domain
+		ORDER BY failed DESC, sent + failed DESC, domain
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate email deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.EmailDomainDeliverability
+	for rows.Next() {
+		d := &models.EmailDomainDeliverability{}
+		var lastCode sql.NullInt64
+		var lastAt sql.NullTime
+		if err := rows.Scan(&d.Domain, &d.Sent, &d.Failed, &d.AvgLatencyMs, &lastCode, &lastAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email domain deliverability: %w", err)
+		}
+		if total := d.Sent + d.Failed; total > 0 {
+			d.SuccessRate = float64(d.Sent) / float64(total)
+		}
+		d.LastFailureCode = int(lastCode.Int64)
+		if lastAt.Valid {
+			d.LastFailureAt = &lastAt.Time
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// ListCodes counts the sends since a given time per SMTP reply code, most frequent first
+// RLS policy automatically filters by tenant_id
+func (r *EmailDeliveryRepository) ListCodes(ctx context.Context, since time.Time) ([]*models.EmailResponseCodeCount, error) {
+	query := `
+		SELECT status, smtp_code, COUNT(*) AS count
+		FROM email_deliveries
+		WHERE created_at > $1
+		GROUP BY status, smtp_code
+		ORDER BY count DESC, smtp_code`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count email reply codes: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.EmailResponseCodeCount
+	for rows.Next() {
+		c := &models.EmailResponseCodeCount{}
+		if err := rows.Scan(&c.Status, &c.Code, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan email reply code: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// ListFailures returns the most recent failed sends since a given time, newest first
+// RLS policy automatically filters by tenant_id
+func (r *EmailDeliveryRepository) ListFailures(ctx context.Context, since time.Time, limit int) ([]*models.EmailDelivery, error) {
+	query := `
+		SELECT id, tenant_id, message_id, template, recipient_domains, status, smtp_code, error, server, latency_ms, created_at
+		FROM email_deliveries
+		WHERE status = 'failed' AND created_at > $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed email deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.EmailDelivery
+	for rows.Next() {
+		d := &models.EmailDelivery{}
+		if err := rows.Scan(&d.ID, &d.TenantID, &d.MessageID, &d.Template, pq.Array(&d.RecipientDomains), &d.Status,
+			&d.SMTPCode, &d.Error, &d.Server, &d.LatencyMs, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email delivery: %w", err)
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// DeleteBefore removes the sends older than a given time
+// RLS policy automatically filters by tenant_id
+func (r *EmailDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM email_deliveries WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete email deliveries: %w", err)
+	}
+	return result.RowsAffected()
+}
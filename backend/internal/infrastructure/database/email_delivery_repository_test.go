@@ -0,0 +1,73 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestEmailDeliveryRepository_Report(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewEmailDeliveryRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	deliveries := []*models.EmailDelivery{
+		{MessageID: "1@ackify.example", RecipientDomains: []string{"gmail.com"}, Status: models.EmailDeliverySent, SMTPCode: 250, LatencyMs: 100},
+		{MessageID: "2@ackify.example", RecipientDomains: []string{"gmail.com", "corp.example"}, Status: models.EmailDeliverySent, SMTPCode: 250, LatencyMs: 300},
+		{MessageID: "3@ackify.example", RecipientDomains: []string{"corp.example"}, Status: models.EmailDeliveryFailed, SMTPCode: 550, Error: "550 relay denied", LatencyMs: 50},
+	}
+	for _, d := range deliveries {
+		if err := repo.Record(ctx, d); err != nil {
+			t.Fatalf("record err: %v", err)
+		}
+		if d.ID == 0 {
+			t.Fatal("expected the delivery ID to be set")
+		}
+	}
+
+	domains, err := repo.ListDomains(ctx, since, 10)
+	if err != nil {
+		t.Fatalf("list domains err: %v", err)
+	}
+	if len(domains) != 2 || domains[0].Domain != "corp.example" {
+		t.Fatalf("expected the failing domain first, got %+v", domains)
+	}
+	corp, gmail := domains[0], domains[1]
+	if corp.Sent != 1 || corp.Failed != 1 || corp.SuccessRate != 0.5 || corp.LastFailureCode != 550 || corp.LastFailureAt == nil {
+		t.Errorf("unexpected corp.example stats: %+v", corp)
+	}
+	if gmail.Sent != 2 || gmail.Failed != 0 || gmail.SuccessRate != 1 || gmail.AvgLatencyMs != 200 || gmail.LastFailureAt != nil {
+		t.Errorf("unexpected gmail.com stats: %+v", gmail)
+	}
+
+	codes, err := repo.ListCodes(ctx, since)
+	if err != nil {
+		t.Fatalf("list codes err: %v", err)
+	}
+	if len(codes) != 2 || codes[0].Code != 250 || codes[0].Count != 2 || codes[0].Status != models.EmailDeliverySent {
+		t.Errorf("unexpected codes: %+v", codes)
+	}
+
+	failures, err := repo.ListFailures(ctx, since, 10)
+	if err != nil {
+		t.Fatalf("list failures err: %v", err)
+	}
+	if len(failures) != 1 || failures[0].MessageID != "3@ackify.example" || failures[0].RecipientDomains[0] != "corp.example" {
+		t.Errorf("unexpected failures: %+v", failures)
+	}
+
+	deleted, err := repo.DeleteBefore(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("expected 3 deleted deliveries, got %d", deleted)
+	}
+}
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const emailAliasColumns = `id, tenant_id, email, alias, created_by, created_at`
+
+// EmailMatchingRepository handles database operations for email aliases and matching rules.
+// Expected signers and signatures store a match key computed by the email_match_key() SQL
+// function; every change here recomputes the keys it affects.
+type EmailMatchingRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewEmailMatchingRepository creates a new email matching repository
+func NewEmailMatchingRepository(db *sql.DB, tenants providers.TenantProvider) *EmailMatchingRepository {
+	return &EmailMatchingRepository{db: db, tenants: tenants}
+}
+
+// ListAliases returns all aliases ordered by primary email
+// RLS policy automatically filters by tenant_id
+func (r *EmailMatchingRepository) ListAliases(ctx context.Context) ([]*models.EmailAlias, error) {
+	query := `SELECT ` + emailAliasColumns + ` FROM email_aliases ORDER BY email ASC, alias ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.EmailAlias
+	for rows.Next() {
+		alias, err := scanEmailAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email alias: %w", err)
+		}
+		out = append(out, alias)
+	}
+	return out, rows.Err()
+}
+
+// GetAlias returns the alias entry of an address, or nil if the address is not an alias
+// RLS policy automatically filters by tenant_id
+func (r *EmailMatchingRepository) GetAlias(ctx context.Context, alias string) (*models.EmailAlias, error) {
+	query := `SELECT ` + emailAliasColumns + ` FROM email_aliases WHERE alias = $1`
+
+	entry, err := scanEmailAlias(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, normalizeAliasEmail(alias)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email alias: %w", err)
+	}
+	return entry, nil
+}
+
+// HasAliases reports whether an address is the primary email of at least one alias
+// RLS policy automatically filters by tenant_id
+func (r *EmailMatchingRepository) HasAliases(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT EXISTS(SELECT 1 FROM email_aliases WHERE email = $1)`, normalizeAliasEmail(email),
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check email aliases: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateAlias records alias as an alternate address of email and rematches the rows using alias.
+// It returns models.ErrEmailAliasExists when the alias is already recorded.
+func (r *EmailMatchingRepository) CreateAlias(ctx context.Context, email, alias, createdBy string) (*models.EmailAlias, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_aliases (tenant_id, email, alias, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tenant_id, alias) DO NOTHING
+		RETURNING ` + emailAliasColumns
+
+	entry, err := scanEmailAlias(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, normalizeAliasEmail(email), normalizeAliasEmail(alias), createdBy,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrEmailAliasExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email alias: %w", err)
+	}
+
+	if err := r.rematch(ctx, entry.Alias); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteAlias removes an alias and rematches the rows using it
+// RLS policy automatically filters by tenant_id
+func (r *EmailMatchingRepository) DeleteAlias(ctx context.Context, id int64) error {
+	var alias string
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`DELETE FROM email_aliases WHERE id = $1 RETURNING alias`, id,
+	).Scan(&alias)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ErrEmailAliasNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete email alias: %w", err)
+	}
+
+	return r.rematch(ctx, alias)
+}
+
+// GetRules returns the matching rules of the tenant, or nil if none are stored
+// RLS policy automatically filters by tenant_id
+func (r *EmailMatchingRepository) GetRules(ctx context.Context) (*models.EmailMatchingRules, error) {
+	query := `SELECT ignore_case, strip_plus_tag, ignore_gmail_dots, updated_by, updated_at FROM email_matching_rules`
+
+	rules, err := scanEmailMatchingRules(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email matching rules: %w", err)
+	}
+	return rules, nil
+}
+
+// UpsertRules stores the matching rules of the tenant and rematches every expected signer and signature
+func (r *EmailMatchingRepository) UpsertRules(ctx context.Context, rules models.EmailMatchingRules, updatedBy string) (*models.EmailMatchingRules, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_matching_rules (tenant_id, ignore_case, strip_plus_tag, ignore_gmail_dots, updated_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET ignore_case = EXCLUDED.ignore_case,
+			strip_plus_tag = EXCLUDED.strip_plus_tag,
+			ignore_gmail_dots = EXCLUDED.ignore_gmail_dots,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING ignore_case, strip_plus_tag, ignore_gmail_dots, updated_by, updated_at`
+
+	stored, err := scanEmailMatchingRules(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, rules.IgnoreCase, rules.StripPlusTag, rules.IgnoreGmailDots, updatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert email matching rules: %w", err)
+	}
+
+	if err := r.rematch(ctx, ""); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// rematch recomputes the match keys of the rows using address, or of every row when address is empty.
+// Only changed rows are written, so the signer status notifications stay limited to affected documents.
+// RLS policy automatically filters by tenant_id
+func (r *EmailMatchingRepository) rematch(ctx context.Context, address string) error {
+	queries := []string{
+		`UPDATE expected_signers SET match_key = email_match_key(tenant_id, email)
+		WHERE ($1 = '' OR lower(btrim(email)) = $1)
+		  AND match_key IS DISTINCT FROM email_match_key(tenant_id, email)`,
+		`UPDATE signatures SET match_key = email_match_key(tenant_id, user_email)
+		WHERE ($1 = '' OR lower(btrim(user_email)) = $1)
+		  AND match_key IS DISTINCT FROM email_match_key(tenant_id, user_email)`,
+	}
+	for _, query := range queries {
+		if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, address); err != nil {
+			return fmt.Errorf("failed to rematch email addresses: %w", err)
+		}
+	}
+	return nil
+}
+
+func normalizeAliasEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func scanEmailAlias(row interface{ Scan(dest ...any) error }) (*models.EmailAlias, error) {
+	a := &models.EmailAlias{}
+	if err := row.Scan(&a.ID, &a.TenantID, &a.Email, &a.Alias, &a.CreatedBy, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func scanEmailMatchingRules(row interface{ Scan(dest ...any) error }) (*models.EmailMatchingRules, error) {
+	rules := &models.EmailMatchingRules{}
+	var updatedAt sql.NullTime
+	if err := row.Scan(&rules.IgnoreCase, &rules.StripPlusTag, &rules.IgnoreGmailDots, &rules.UpdatedBy, &updatedAt); err != nil {
+		return nil, err
+	}
+	if updatedAt.Valid {
+		rules.UpdatedAt = &updatedAt.Time
+	}
+	return rules, nil
+}
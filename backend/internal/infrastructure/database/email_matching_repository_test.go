@@ -0,0 +1,162 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func signAs(t *testing.T, tdb *TestDB, docID, email string) {
+	t.Helper()
+	sig := (&SignatureFactory{}).CreateSignatureWithDocAndUser(docID, "sub-"+email, email)
+	sig.Nonce = "nonce-" + docID + "-" + email
+	if err := NewSignatureRepository(tdb.DB, tdb.TenantProvider).Create(context.Background(), sig); err != nil {
+		t.Fatalf("create signature err: %v", err)
+	}
+}
+
+func TestEmailMatchingRepository_Aliases(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewEmailMatchingRepository(testDB.DB, testDB.TenantProvider)
+	signerRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if err := signerRepo.AddExpected(ctx, "doc-alias", []models.ContactInfo{{Email: "f.lastname@corp.com"}}, "admin@corp.com"); err != nil {
+		t.Fatalf("add expected err: %v", err)
+	}
+	signAs(t, testDB, "doc-alias", "firstname.lastname@corp.com")
+
+	stats, err := signerRepo.GetStats(ctx, "doc-alias")
+	if err != nil {
+		t.Fatalf("get stats err: %v", err)
+	}
+	if stats.SignedCount != 0 {
+		t.Fatalf("expected no match before alias, got %d", stats.SignedCount)
+	}
+
+	alias, err := repo.CreateAlias(ctx, "F.Lastname@corp.com", "Firstname.Lastname@corp.com", "admin@corp.com")
+	if err != nil {
+		t.Fatalf("create alias err: %v", err)
+	}
+	if alias.Email != "f.lastname@corp.com" || alias.Alias != "firstname.lastname@corp.com" {
+		t.Errorf("expected lowercase addresses, got %+v", alias)
+	}
+	if _, err := repo.CreateAlias(ctx, "other@corp.com", "firstname.lastname@corp.com", "admin@corp.com"); !errors.Is(err, models.ErrEmailAliasExists) {
+		t.Errorf("expected ErrEmailAliasExists, got %v", err)
+	}
+
+	stats, err = signerRepo.GetStats(ctx, "doc-alias")
+	if err != nil {
+		t.Fatalf("get stats err: %v", err)
+	}
+	if stats.SignedCount != 1 {
+		t.Errorf("expected signature matched through alias, got %d", stats.SignedCount)
+	}
+
+	signers, err := signerRepo.ListWithStatusByDocID(ctx, "doc-alias")
+	if err != nil {
+		t.Fatalf("list with status err: %v", err)
+	}
+	if len(signers) != 1 || !signers[0].HasSigned {
+		t.Errorf("expected expected signer marked as signed, got %+v", signers)
+	}
+
+	expected, err := signerRepo.IsExpected(ctx, "doc-alias", "firstname.lastname@corp.com")
+	if err != nil || !expected {
+		t.Errorf("expected alias to be expected, got %v (%v)", expected, err)
+	}
+	matched, err := signerRepo.MatchExpectedEmail(ctx, "doc-alias", "Firstname.Lastname@corp.com")
+	if err != nil || matched != "f.lastname@corp.com" {
+		t.Errorf("expected match on primary email, got %q (%v)", matched, err)
+	}
+
+	status, err := NewDocumentRepository(testDB.DB, testDB.TenantProvider).GetStatus(ctx, "doc-alias")
+	if err != nil {
+		t.Fatalf("get status err: %v", err)
+	}
+	if status.Stats.SignedCount != 1 || len(status.UnexpectedSignatures) != 0 {
+		t.Errorf("expected aliased signature in completion stats, got %+v, %d unexpected", status.Stats, len(status.UnexpectedSignatures))
+	}
+
+	if err := repo.DeleteAlias(ctx, alias.ID); err != nil {
+		t.Fatalf("delete alias err: %v", err)
+	}
+	if err := repo.DeleteAlias(ctx, alias.ID); !errors.Is(err, models.ErrEmailAliasNotFound) {
+		t.Errorf("expected ErrEmailAliasNotFound, got %v", err)
+	}
+	stats, err = signerRepo.GetStats(ctx, "doc-alias")
+	if err != nil {
+		t.Fatalf("get stats err: %v", err)
+	}
+	if stats.SignedCount != 0 {
+		t.Errorf("expected no match after alias removal, got %d", stats.SignedCount)
+	}
+}
+
+func TestEmailMatchingRepository_Rules(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewEmailMatchingRepository(testDB.DB, testDB.TenantProvider)
+	signerRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	rules, err := repo.GetRules(ctx)
+	if err != nil {
+		t.Fatalf("get rules err: %v", err)
+	}
+	if rules != nil {
+		t.Fatalf("expected no stored rules, got %+v", rules)
+	}
+
+	contacts := []models.ContactInfo{
+		{Email: "Jane@Corp.com"},
+		{Email: "john@corp.com"},
+		{Email: "john.doe@gmail.com"},
+	}
+	if err := signerRepo.AddExpected(ctx, "doc-rules", contacts, "admin@corp.com"); err != nil {
+		t.Fatalf("add expected err: %v", err)
+	}
+	signAs(t, testDB, "doc-rules", "jane@corp.com")
+	signAs(t, testDB, "doc-rules", "john+hr@corp.com")
+	signAs(t, testDB, "doc-rules", "JohnDoe@googlemail.com")
+
+	stats, err := signerRepo.GetStats(ctx, "doc-rules")
+	if err != nil {
+		t.Fatalf("get stats err: %v", err)
+	}
+	if stats.SignedCount != 1 {
+		t.Fatalf("expected only the case-insensitive match by default, got %d", stats.SignedCount)
+	}
+
+	stored, err := repo.UpsertRules(ctx, models.EmailMatchingRules{IgnoreCase: true, StripPlusTag: true, IgnoreGmailDots: true}, "admin@corp.com")
+	if err != nil {
+		t.Fatalf("upsert rules err: %v", err)
+	}
+	if !stored.StripPlusTag || !stored.IgnoreGmailDots || stored.UpdatedBy != "admin@corp.com" {
+		t.Errorf("unexpected stored rules: %+v", stored)
+	}
+
+	stats, err = signerRepo.GetStats(ctx, "doc-rules")
+	if err != nil {
+		t.Fatalf("get stats err: %v", err)
+	}
+	if stats.SignedCount != 3 {
+		t.Errorf("expected all signatures matched with normalization, got %d", stats.SignedCount)
+	}
+
+	if _, err := repo.UpsertRules(ctx, models.EmailMatchingRules{}, "admin@corp.com"); err != nil {
+		t.Fatalf("upsert rules err: %v", err)
+	}
+	stats, err = signerRepo.GetStats(ctx, "doc-rules")
+	if err != nil {
+		t.Fatalf("get stats err: %v", err)
+	}
+	if stats.SignedCount != 0 {
+		t.Errorf("expected exact matching once case is significant, got %d", stats.SignedCount)
+	}
+}
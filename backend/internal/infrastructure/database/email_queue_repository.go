@@ -468,6 +468,26 @@ func (r *EmailQueueRepository) GetQueueStats(ctx context.Context) (*models.Email
 	return stats, nil
 }
 
+// ReleaseToPending puts emails claimed for processing but not sent back in the queue,
+// so that they are picked up after a restart or by another instance
+func (r *EmailQueueRepository) ReleaseToPending(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		UPDATE email_queue
+		SET status = 'pending'
+		WHERE id = ANY($1) AND status = 'processing'
+	`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to release emails: %w", err)
+	}
+	return result.RowsAffected()
+}
+
 // CancelEmail cancels a pending email
 func (r *EmailQueueRepository) CancelEmail(ctx context.Context, id int64) error {
 	query := `
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const emailSuppressionColumns = `email, reason, detail, created_by, created_at, updated_at`
+
+// EmailSuppressionRepository handles database operations for the addresses no email is sent to
+type EmailSuppressionRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewEmailSuppressionRepository creates a new email suppression repository
+func NewEmailSuppressionRepository(db *sql.DB, tenants providers.TenantProvider) *EmailSuppressionRepository {
+	return &EmailSuppressionRepository{db: db, tenants: tenants}
+}
+
+func scanEmailSuppression(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.EmailSuppression, error) {
+	s := &models.EmailSuppression{}
+	if err := scanner.Scan(&s.Email, &s.Reason, &s.Detail, &s.CreatedBy, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add suppresses an address. An address already suppressed takes the new reason and detail.
+func (r *EmailSuppressionRepository) Add(ctx context.Context, entry models.EmailSuppression) (*models.EmailSuppression, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO email_suppressions (tenant_id, email, reason, detail, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, email) DO UPDATE
+		SET reason = EXCLUDED.reason, detail = EXCLUDED.detail, created_by = EXCLUDED.created_by, updated_at = now()
+		RETURNING ` + emailSuppressionColumns
+
+	s, err := scanEmailSuppression(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, strings.ToLower(strings.TrimSpace(entry.Email)), entry.Reason, entry.Detail, entry.CreatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add email suppression: %w", err)
+	}
+	return s, nil
+}
+
+// Remove lifts the suppression of an address
+// RLS policy automatically filters by tenant_id
+func (r *EmailSuppressionRepository) Remove(ctx context.Context, email string) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM email_suppressions WHERE email = $1`,
+		strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return fmt.Errorf("failed to remove email suppression: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrSuppressionNotFound
+	}
+	return nil
+}
+
+// List retrieves the suppressed addresses containing search, most recent first
+// RLS policy automatically filters by tenant_id
+func (r *EmailSuppressionRepository) List(ctx context.Context, search string, limit int) ([]*models.EmailSuppression, error) {
+	query := `SELECT ` + emailSuppressionColumns + ` FROM email_suppressions
+		WHERE $1 = '' OR email LIKE '%' || $1 || '%'
+		ORDER BY updated_at DESC, email ASC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, strings.ToLower(strings.TrimSpace(search)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.EmailSuppression
+	for rows.Next() {
+		s, err := scanEmailSuppression(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// ListSuppressed returns the addresses among emails that are suppressed, lowercased
+// RLS policy automatically filters by tenant_id
+func (r *EmailSuppressionRepository) ListSuppressed(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	normalized := make([]string, len(emails))
+	for i, email := range emails {
+		normalized[i] = strings.ToLower(strings.TrimSpace(email))
+	}
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT email FROM email_suppressions WHERE email = ANY($1)`, pq.Array(normalized))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	var suppressed []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		suppressed = append(suppressed, email)
+	}
+	return suppressed, rows.Err()
+}
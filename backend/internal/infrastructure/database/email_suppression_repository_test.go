@@ -0,0 +1,50 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestEmailSuppressionRepository_AddAndRemove(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewEmailSuppressionRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	s, err := repo.Add(ctx, models.EmailSuppression{Email: " Gone@Example.com ", Reason: models.SuppressionHardBounce, Detail: "550 user unknown"})
+	if err != nil || s.Email != "gone@example.com" {
+		t.Fatalf("unexpected suppression %+v, %v", s, err)
+	}
+
+	// Adding an address again replaces its reason
+	s, err = repo.Add(ctx, models.EmailSuppression{Email: "gone@example.com", Reason: models.SuppressionManual, CreatedBy: "admin@example.com"})
+	if err != nil || s.Reason != models.SuppressionManual || s.Detail != "" {
+		t.Fatalf("unexpected suppression %+v, %v", s, err)
+	}
+	if _, err := repo.Add(ctx, models.EmailSuppression{Email: "spam@example.com", Reason: models.SuppressionComplaint}); err != nil {
+		t.Fatalf("Add err: %v", err)
+	}
+
+	suppressed, err := repo.ListSuppressed(ctx, []string{"GONE@example.com", "alice@example.com", "spam@example.com"})
+	if err != nil || len(suppressed) != 2 {
+		t.Fatalf("expected 2 suppressed addresses, got %v (err %v)", suppressed, err)
+	}
+
+	list, err := repo.List(ctx, "gone", 10)
+	if err != nil || len(list) != 1 || list[0].Email != "gone@example.com" {
+		t.Errorf("unexpected search result %+v, %v", list, err)
+	}
+
+	if err := repo.Remove(ctx, "Gone@example.com"); err != nil {
+		t.Fatalf("Remove err: %v", err)
+	}
+	if err := repo.Remove(ctx, "gone@example.com"); !errors.Is(err, models.ErrSuppressionNotFound) {
+		t.Errorf("expected ErrSuppressionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const eventOutboxColumns = `id, tenant_id, event_id, event_type, payload, status, attempts, last_error, next_attempt_at, created_at, delivered_at`
+
+// EventOutboxRepository handles database operations for the domain events waiting to be relayed
+type EventOutboxRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewEventOutboxRepository creates a new event outbox repository
+func NewEventOutboxRepository(db *sql.DB, tenants providers.TenantProvider) *EventOutboxRepository {
+	return &EventOutboxRepository{db: db, tenants: tenants}
+}
+
+func scanOutboxEvent(row interface{ Scan(dest ...any) error }) (*models.OutboxEvent, error) {
+	e := &models.OutboxEvent{}
+	var payload []byte
+	var lastError sql.NullString
+	var deliveredAt sql.NullTime
+	err := row.Scan(&e.ID, &e.TenantID, &e.EventID, &e.EventType, &payload, &e.Status, &e.Attempts, &lastError,
+		&e.NextAttemptAt, &e.CreatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(payload, &e.Payload); err != nil {
+		return nil, fmt.Errorf("failed to decode event payload: %w", err)
+	}
+	if lastError.Valid {
+		e.LastError = &lastError.String
+	}
+	if deliveredAt.Valid {
+		e.DeliveredAt = &deliveredAt.Time
+	}
+	return e, nil
+}
+
+// Append writes an event to the outbox. Called within the transaction of the state change, the
+// event is committed or rolled back with it.
+func (r *EventOutboxRepository) Append(ctx context.Context, eventID, eventType string, payload map[string]interface{}) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO event_outbox (tenant_id, event_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)`,
+		tenantID, eventID, eventType, payloadJSON)
+	if err != nil {
+		return fmt.Errorf("failed to append outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimNext locks the oldest pending event due at now, or returns nil when none is due. Events
+// locked by another relay are skipped; the lock is held until the transaction ends.
+// RLS policy automatically filters by tenant_id
+func (r *EventOutboxRepository) ClaimNext(ctx context.Context, now time.Time) (*models.OutboxEvent, error) {
+	query := `SELECT ` + eventOutboxColumns + ` FROM event_outbox
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`
+
+	e, err := scanOutboxEvent(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, now))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox event: %w", err)
+	}
+	return e, nil
+}
+
+// MarkDelivered records the delivery of an event
+// RLS policy automatically filters by tenant_id
+func (r *EventOutboxRepository) MarkDelivered(ctx context.Context, id int64) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE event_outbox
+		SET status = 'delivered', attempts = attempts + 1, last_error = NULL, delivered_at = now()
+		WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt, scheduling the next one or giving up when dead is set
+// RLS policy automatically filters by tenant_id
+func (r *EventOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time, dead bool) error {
+	status := models.OutboxStatusPending
+	if dead {
+		status = models.OutboxStatusDead
+	}
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE event_outbox
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE id = $1 AND status = 'pending'`,
+		id, status, lastError, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("failed to record outbox event failure: %w", err)
+	}
+	return nil
+}
+
+// RetryDead sets the dead events back to pending, due now, and returns how many were
+// RLS policy automatically filters by tenant_id
+func (r *EventOutboxRepository) RetryDead(ctx context.Context) (int, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE event_outbox
+		SET status = 'pending', attempts = 0, next_attempt_at = now()
+		WHERE status = 'dead'`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retry dead outbox events: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+// Lag counts the pending and dead events with the creation of the oldest pending one and the
+// last delivery
+// RLS policy automatically filters by tenant_id
+func (r *EventOutboxRepository) Lag(ctx context.Context) (*models.OutboxLag, error) {
+	lag := &models.OutboxLag{}
+	var oldest, lastDelivered sql.NullTime
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'dead'),
+			MIN(created_at) FILTER (WHERE status = 'pending'),
+			MAX(delivered_at)
+		FROM event_outbox`,
+	).Scan(&lag.PendingCount, &lag.DeadCount, &oldest, &lastDelivered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure outbox lag: %w", err)
+	}
+	if oldest.Valid {
+		lag.OldestPendingAt = &oldest.Time
+	}
+	if lastDelivered.Valid {
+		lag.LastDeliveredAt = &lastDelivered.Time
+	}
+	return lag, nil
+}
+
+// PurgeDelivered deletes the events delivered before the given time and returns how many were
+// RLS policy automatically filters by tenant_id
+func (r *EventOutboxRepository) PurgeDelivered(ctx context.Context, before time.Time) (int, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM event_outbox WHERE status = 'delivered' AND delivered_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge delivered outbox events: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
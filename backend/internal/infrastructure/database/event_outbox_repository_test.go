@@ -0,0 +1,71 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestEventOutboxRepository_Relay(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewEventOutboxRepository(tdb.DB, tdb.TenantProvider)
+	now := time.Now()
+
+	if err := repo.Append(ctx, "evt-1", "signature.created", map[string]interface{}{"doc_id": "doc-1"}); err != nil {
+		t.Fatalf("append err: %v", err)
+	}
+	if err := repo.Append(ctx, "evt-2", "document.completed", map[string]interface{}{"doc_id": "doc-1"}); err != nil {
+		t.Fatalf("append err: %v", err)
+	}
+
+	first, err := repo.ClaimNext(ctx, now.Add(time.Second))
+	if err != nil || first == nil || first.EventID != "evt-1" || first.Payload["doc_id"] != "doc-1" {
+		t.Fatalf("unexpected first event: %+v (err %v)", first, err)
+	}
+	if err := repo.MarkDelivered(ctx, first.ID); err != nil {
+		t.Fatalf("mark delivered err: %v", err)
+	}
+
+	second, err := repo.ClaimNext(ctx, now.Add(time.Second))
+	if err != nil || second == nil || second.EventID != "evt-2" {
+		t.Fatalf("unexpected second event: %+v (err %v)", second, err)
+	}
+	if err := repo.MarkFailed(ctx, second.ID, "hub unreachable", now.Add(time.Hour), false); err != nil {
+		t.Fatalf("mark failed err: %v", err)
+	}
+
+	// The failed event is not due before its next attempt
+	if e, err := repo.ClaimNext(ctx, now.Add(time.Second)); err != nil || e != nil {
+		t.Fatalf("expected no due event, got %+v (err %v)", e, err)
+	}
+
+	if err := repo.MarkFailed(ctx, second.ID, "hub unreachable", now, true); err != nil {
+		t.Fatalf("mark dead err: %v", err)
+	}
+	lag, err := repo.Lag(ctx)
+	if err != nil {
+		t.Fatalf("lag err: %v", err)
+	}
+	if lag.PendingCount != 0 || lag.DeadCount != 1 || lag.OldestPendingAt != nil || lag.LastDeliveredAt == nil {
+		t.Fatalf("unexpected lag: %+v", lag)
+	}
+
+	if n, err := repo.RetryDead(ctx); err != nil || n != 1 {
+		t.Fatalf("expected one retried event, got %d (err %v)", n, err)
+	}
+	retried, err := repo.ClaimNext(ctx, time.Now().Add(time.Second))
+	if err != nil || retried == nil || retried.Attempts != 0 || retried.Status != models.OutboxStatusPending {
+		t.Fatalf("unexpected retried event: %+v (err %v)", retried, err)
+	}
+
+	if n, err := repo.PurgeDelivered(ctx, time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Fatalf("expected one purged event, got %d (err %v)", n, err)
+	}
+}
@@ -4,8 +4,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
@@ -37,15 +39,15 @@ func (r *ExpectedSignerRepository) AddExpected(ctx context.Context, docID string
 
 	// Build batch INSERT with ON CONFLICT DO NOTHING
 	valueStrings := make([]string, 0, len(contacts))
-	valueArgs := make([]interface{}, 0, len(contacts)*5)
+	valueArgs := make([]interface{}, 0, len(contacts)*6)
 
 	for i, contact := range contacts {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
-		valueArgs = append(valueArgs, tenantID, docID, contact.Email, contact.Name, addedBy)
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", i*6+1, i*6+2, i*6+3, i*6+4, i*6+5, i*6+6))
+		valueArgs = append(valueArgs, tenantID, docID, contact.Email, contact.Name, addedBy, contact.SignOrder)
 	}
 
 	query := fmt.Sprintf(`
-		INSERT INTO expected_signers (tenant_id, doc_id, email, name, added_by)
+		INSERT INTO expected_signers (tenant_id, doc_id, email, name, added_by, sign_order)
 		VALUES %s
 		ON CONFLICT (doc_id, email) DO NOTHING
 	`, strings.Join(valueStrings, ","))
@@ -58,14 +60,14 @@ func (r *ExpectedSignerRepository) AddExpected(ctx context.Context, docID string
 	return nil
 }
 
-// ListByDocID retrieves all expected signers for a document, ordered chronologically by when they were added
+// ListByDocID retrieves all expected signers for a document, ordered by signing position then by when they were added
 // RLS policy automatically filters by tenant_id
 func (r *ExpectedSignerRepository) ListByDocID(ctx context.Context, docID string) ([]*models.ExpectedSigner, error) {
 	query := `
-		SELECT id, tenant_id, doc_id, email, name, added_at, added_by, notes
+		SELECT id, tenant_id, doc_id, email, name, added_at, added_by, notes, sign_order
 		FROM expected_signers
 		WHERE doc_id = $1
-		ORDER BY added_at ASC
+		ORDER BY sign_order ASC NULLS LAST, added_at ASC
 	`
 
 	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID)
@@ -91,6 +93,7 @@ func (r *ExpectedSignerRepository) ListByDocID(ctx context.Context, docID string
 			&signer.AddedAt,
 			&signer.AddedBy,
 			&signer.Notes,
+			&signer.SignOrder,
 		)
 		if err != nil {
 			continue
@@ -114,22 +117,30 @@ func (r *ExpectedSignerRepository) ListWithStatusByDocID(ctx context.Context, do
 			es.added_at,
 			es.added_by,
 			es.notes,
+			es.sign_order,
 			CASE WHEN s.id IS NOT NULL THEN true ELSE false END as has_signed,
 			s.signed_at,
 			s.user_name,
 			MAX(rl.sent_at) as last_reminder_sent,
-			COUNT(CASE WHEN rl.status = 'sent' THEN 1 END) as reminder_count,
+			-- Queued reminders count too: they drive the escalation level of the next one
+			COUNT(CASE WHEN rl.status <> 'failed' THEN 1 END) as reminder_count,
 			EXTRACT(DAY FROM (NOW() - es.added_at))::int as days_since_added,
 			EXTRACT(DAY FROM (NOW() - MAX(rl.sent_at)))::int as days_since_last_reminder
 		FROM expected_signers es
-		LEFT JOIN signatures s ON es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id AND es.email = s.user_email
+		LEFT JOIN LATERAL (
+			-- A person may have signed under several matching addresses: keep the first signature
+			SELECT id, signed_at, user_name FROM signatures
+			WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+			ORDER BY signed_at ASC
+			LIMIT 1
+		) s ON true
 		LEFT JOIN reminder_logs rl ON es.tenant_id = rl.tenant_id AND es.doc_id = rl.doc_id AND es.email = rl.recipient_email
 		WHERE es.doc_id = $1
-		GROUP BY es.id, es.tenant_id, es.doc_id, es.email, es.name, es.added_at, es.added_by, es.notes, s.id, s.signed_at, s.user_name
-		ORDER BY has_signed DESC, es.added_at ASC
+		GROUP BY es.id, es.tenant_id, es.doc_id, es.email, es.name, es.added_at, es.added_by, es.notes, es.sign_order, s.id, s.signed_at, s.user_name
+		ORDER BY has_signed DESC, es.sign_order ASC NULLS LAST, es.added_at ASC
 	`
 
-	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID)
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, docID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query expected signers with status: %w", err)
 	}
@@ -155,6 +166,7 @@ func (r *ExpectedSignerRepository) ListWithStatusByDocID(ctx context.Context, do
 			&signer.AddedAt,
 			&signer.AddedBy,
 			&signer.Notes,
+			&signer.SignOrder,
 			&signer.HasSigned,
 			&signer.SignedAt,
 			&signer.UserName,
@@ -182,6 +194,98 @@ func (r *ExpectedSignerRepository) ListWithStatusByDocID(ctx context.Context, do
 	return signers, nil
 }
 
+// ListPendingByEmail returns the active documents a recipient is expected on and has not confirmed yet,
+// leaving out documents where signers ordered before the recipient are still pending
+// RLS policy automatically filters by tenant_id
+func (r *ExpectedSignerRepository) ListPendingByEmail(ctx context.Context, email string) ([]*models.PendingDocument, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		SELECT es.doc_id, COALESCE(d.title, ''), COALESCE(d.url, ''), es.name, es.added_at
+		FROM expected_signers es
+		LEFT JOIN documents d ON d.tenant_id = es.tenant_id AND d.doc_id = es.doc_id
+		LEFT JOIN signatures s ON s.tenant_id = es.tenant_id AND s.doc_id = es.doc_id AND s.match_key = es.match_key
+		WHERE es.match_key = email_match_key($2, $1)
+		  AND s.id IS NULL
+		  AND d.deleted_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM expected_signers prev
+			LEFT JOIN signatures ps ON ps.tenant_id = prev.tenant_id AND ps.doc_id = prev.doc_id AND ps.match_key = prev.match_key
+			WHERE prev.tenant_id = es.tenant_id
+			  AND prev.doc_id = es.doc_id
+			  AND prev.sign_order < es.sign_order
+			  AND ps.id IS NULL
+		  )
+		ORDER BY es.added_at ASC, es.doc_id ASC
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, strings.TrimSpace(email), tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending documents: %w", err)
+	}
+	defer rows.Close()
+
+	var documents []*models.PendingDocument
+	for rows.Next() {
+		doc := &models.PendingDocument{}
+		if err := rows.Scan(&doc.DocID, &doc.Title, &doc.URL, &doc.RecipientName, &doc.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pending document: %w", err)
+		}
+		documents = append(documents, doc)
+	}
+	return documents, rows.Err()
+}
+
+// ListPendingRecipients returns the recipients with at least one pending document whose turn has come
+// and who have not been reminded since the given time
+// RLS policy automatically filters by tenant_id
+func (r *ExpectedSignerRepository) ListPendingRecipients(ctx context.Context, notRemindedSince time.Time) ([]string, error) {
+	query := `
+		SELECT es.email
+		FROM expected_signers es
+		LEFT JOIN documents d ON d.tenant_id = es.tenant_id AND d.doc_id = es.doc_id
+		LEFT JOIN signatures s ON s.tenant_id = es.tenant_id AND s.doc_id = es.doc_id AND s.match_key = es.match_key
+		WHERE s.id IS NULL
+		  AND d.deleted_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM expected_signers prev
+			LEFT JOIN signatures ps ON ps.tenant_id = prev.tenant_id AND ps.doc_id = prev.doc_id AND ps.match_key = prev.match_key
+			WHERE prev.tenant_id = es.tenant_id
+			  AND prev.doc_id = es.doc_id
+			  AND prev.sign_order < es.sign_order
+			  AND ps.id IS NULL
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM reminder_logs rl
+			WHERE rl.tenant_id = es.tenant_id
+			  AND rl.recipient_email = es.email
+			  AND rl.status <> 'failed'
+			  AND rl.sent_at > $1
+		  )
+		GROUP BY es.email
+		ORDER BY es.email ASC
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, notRemindedSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan pending recipient: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	return emails, rows.Err()
+}
+
 // Remove deletes a specific expected signer by document ID and email address
 // RLS policy automatically filters by tenant_id
 func (r *ExpectedSignerRepository) Remove(ctx context.Context, docID, email string) error {
@@ -223,18 +327,24 @@ func (r *ExpectedSignerRepository) RemoveAllForDoc(ctx context.Context, docID st
 	return nil
 }
 
-// IsExpected efficiently verifies if an email address is in the expected signer list for a document
+// IsExpected efficiently verifies if an email address is in the expected signer list for a document.
+// Addresses are compared through email aliases and the tenant's matching rules.
 // RLS policy automatically filters by tenant_id
 func (r *ExpectedSignerRepository) IsExpected(ctx context.Context, docID, email string) (bool, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM expected_signers
-			WHERE doc_id = $1 AND email = $2
+			WHERE doc_id = $1 AND match_key = email_match_key($3, $2)
 		)
 	`
 
 	var exists bool
-	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, email).Scan(&exists)
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, email, tenantID).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check if email is expected: %w", err)
 	}
@@ -242,6 +352,60 @@ func (r *ExpectedSignerRepository) IsExpected(ctx context.Context, docID, email
 	return exists, nil
 }
 
+// MatchExpectedEmail returns the address under which email is expected on a document,
+// resolving email aliases and the tenant's matching rules. It returns "" when email is not expected.
+// RLS policy automatically filters by tenant_id
+func (r *ExpectedSignerRepository) MatchExpectedEmail(ctx context.Context, docID, email string) (string, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		SELECT email FROM expected_signers
+		WHERE doc_id = $1 AND match_key = email_match_key($3, $2)
+		ORDER BY added_at ASC
+		LIMIT 1
+	`
+
+	var expected string
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, email, tenantID).Scan(&expected)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to match expected signer: %w", err)
+	}
+	return expected, nil
+}
+
+// CountPendingBefore counts the signers ordered before the given one who have not signed yet.
+// It returns 0 when the signer is not expected or has no position in the signing sequence.
+// RLS policy automatically filters by tenant_id
+func (r *ExpectedSignerRepository) CountPendingBefore(ctx context.Context, docID, email string) (int, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM expected_signers me
+		JOIN expected_signers prev ON prev.tenant_id = me.tenant_id AND prev.doc_id = me.doc_id
+		LEFT JOIN signatures s ON prev.tenant_id = s.tenant_id AND prev.doc_id = s.doc_id AND prev.match_key = s.match_key
+		WHERE me.doc_id = $1
+		  AND me.match_key = email_match_key($3, $2)
+		  AND prev.sign_order < me.sign_order
+		  AND s.id IS NULL
+	`
+
+	var count int
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, email, tenantID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count pending previous signers: %w", err)
+	}
+	return count, nil
+}
+
 // GetStats calculates signature completion metrics including percentage progress for a document
 // RLS policy automatically filters by tenant_id
 func (r *ExpectedSignerRepository) GetStats(ctx context.Context, docID string) (*models.DocCompletionStats, error) {
@@ -250,7 +414,13 @@ func (r *ExpectedSignerRepository) GetStats(ctx context.Context, docID string) (
 			COUNT(*) as expected_count,
 			COUNT(s.id) as signed_count
 		FROM expected_signers es
-		LEFT JOIN signatures s ON es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id AND es.email = s.user_email
+		LEFT JOIN LATERAL (
+			-- A person may have signed under several matching addresses: keep the first signature
+			SELECT id FROM signatures
+			WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+			ORDER BY signed_at ASC
+			LIMIT 1
+		) s ON true
 		WHERE es.doc_id = $1
 	`
 
@@ -258,7 +428,7 @@ func (r *ExpectedSignerRepository) GetStats(ctx context.Context, docID string) (
 		DocID: docID,
 	}
 
-	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID).Scan(&stats.ExpectedCount, &stats.SignedCount)
+	err := dbctx.GetReadQuerier(ctx, r.db).QueryRowContext(ctx, query, docID).Scan(&stats.ExpectedCount, &stats.SignedCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
@@ -157,6 +157,52 @@ func TestExpectedSignerRepository_ListWithStatusByDocID(t *testing.T) {
 	}
 }
 
+func TestExpectedSignerRepository_CountPendingBefore(t *testing.T) {
+	testDB := SetupTestDB(t)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	factory := NewSignatureFactory()
+	ctx := context.Background()
+
+	clearExpectedSignersTable(t, testDB)
+	testDB.ClearTable(t)
+
+	docID := "doc-order-test"
+	first, second := 1, 2
+	contacts := []models.ContactInfo{
+		{Email: "employee@example.com", SignOrder: &first},
+		{Email: "manager@example.com", SignOrder: &second},
+		{Email: "observer@example.com"},
+	}
+	if err := expectedRepo.AddExpected(ctx, docID, contacts, "admin@example.com"); err != nil {
+		t.Fatalf("failed to add expected signers: %v", err)
+	}
+
+	counts := map[string]int{"employee@example.com": 0, "manager@example.com": 1, "observer@example.com": 0}
+	for email, want := range counts {
+		got, err := expectedRepo.CountPendingBefore(ctx, docID, email)
+		if err != nil {
+			t.Fatalf("CountPendingBefore failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("%s: expected %d pending before, got %d", email, want, got)
+		}
+	}
+
+	sig := factory.CreateSignatureWithDocAndUser(docID, "user-employee", "employee@example.com")
+	if err := sigRepo.Create(ctx, sig); err != nil {
+		t.Fatalf("failed to create signature: %v", err)
+	}
+
+	got, err := expectedRepo.CountPendingBefore(ctx, docID, "manager@example.com")
+	if err != nil {
+		t.Fatalf("CountPendingBefore failed: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("expected manager's turn after employee signed, got %d pending", got)
+	}
+}
+
 func TestExpectedSignerRepository_GetStats(t *testing.T) {
 	testDB := SetupTestDB(t)
 	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
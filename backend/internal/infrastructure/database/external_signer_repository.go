@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const externalSignerCodeColumns = `id, tenant_id, doc_id, email, code_hash, attempts, expires_at, used_at, COALESCE(created_by_ip, ''), created_at`
+
+// ExternalSignerRepository handles database operations for external signers and their verification codes
+type ExternalSignerRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewExternalSignerRepository creates a new external signer repository
+func NewExternalSignerRepository(db *sql.DB, tenants providers.TenantProvider) *ExternalSignerRepository {
+	return &ExternalSignerRepository{db: db, tenants: tenants}
+}
+
+// GetSettings reports whether a document accepts external signers
+// RLS policy automatically filters by tenant_id
+func (r *ExternalSignerRepository) GetSettings(ctx context.Context, docID string) (*models.ExternalSigning, error) {
+	settings := &models.ExternalSigning{DocID: docID}
+	var enabledBy sql.NullString
+	var enabledAt time.Time
+
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT enabled_by, enabled_at FROM document_external_signing WHERE doc_id = $1`, docID,
+	).Scan(&enabledBy, &enabledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external signing settings: %w", err)
+	}
+
+	settings.Enabled = true
+	settings.EnabledBy = enabledBy.String
+	settings.EnabledAt = &enabledAt
+	return settings, nil
+}
+
+// Enable opens a document to external signers
+func (r *ExternalSignerRepository) Enable(ctx context.Context, docID, enabledBy string) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_external_signing (tenant_id, doc_id, enabled_by)
+		VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT (tenant_id, doc_id) DO NOTHING
+	`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, tenantID, docID, enabledBy); err != nil {
+		return fmt.Errorf("failed to enable external signing: %w", err)
+	}
+	return nil
+}
+
+// Disable closes a document to external signers. Signatures already recorded are kept.
+// RLS policy automatically filters by tenant_id
+func (r *ExternalSignerRepository) Disable(ctx context.Context, docID string) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_external_signing WHERE doc_id = $1`, docID)
+	if err != nil {
+		return fmt.Errorf("failed to disable external signing: %w", err)
+	}
+	return nil
+}
+
+// CreateCode stores a new verification code
+func (r *ExternalSignerRepository) CreateCode(ctx context.Context, code *models.ExternalSignerCode) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO external_signer_codes (tenant_id, doc_id, email, code_hash, expires_at, created_by_ip)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		RETURNING id, created_at
+	`
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, code.DocID, code.Email, code.CodeHash, code.ExpiresAt, code.CreatedByIP,
+	).Scan(&code.ID, &code.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create verification code: %w", err)
+	}
+	code.TenantID = tenantID
+	return nil
+}
+
+// GetLatestCode returns the most recent verification code sent to an email for a document, or nil if none
+// RLS policy automatically filters by tenant_id
+func (r *ExternalSignerRepository) GetLatestCode(ctx context.Context, docID, email string) (*models.ExternalSignerCode, error) {
+	query := `SELECT ` + externalSignerCodeColumns + `
+		FROM external_signer_codes
+		WHERE doc_id = $1 AND email = $2
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	code := &models.ExternalSignerCode{}
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, email).Scan(
+		&code.ID, &code.TenantID, &code.DocID, &code.Email, &code.CodeHash,
+		&code.Attempts, &code.ExpiresAt, &code.UsedAt, &code.CreatedByIP, &code.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get verification code: %w", err)
+	}
+	return code, nil
+}
+
+// IncrementAttempts records a failed verification of a code.
+// It is written in its own transaction, since the refused request is rolled back.
+func (r *ExternalSignerRepository) IncrementAttempts(ctx context.Context, id int64) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	err = tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+		_, err := dbctx.GetQuerier(txCtx, r.db).ExecContext(txCtx,
+			`UPDATE external_signer_codes SET attempts = attempts + 1 WHERE id = $1`, id)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record verification attempt: %w", err)
+	}
+	return nil
+}
+
+// MarkCodeUsed prevents a verification code from being redeemed again
+// RLS policy automatically filters by tenant_id
+func (r *ExternalSignerRepository) MarkCodeUsed(ctx context.Context, id int64) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`UPDATE external_signer_codes SET used_at = now() WHERE id = $1 AND used_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark verification code used: %w", err)
+	}
+	return nil
+}
+
+// CountRecentCodes counts the codes sent to an email since a given time
+// RLS policy automatically filters by tenant_id
+func (r *ExternalSignerRepository) CountRecentCodes(ctx context.Context, email string, since time.Time) (int, error) {
+	var count int
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM external_signer_codes WHERE email = $1 AND created_at >= $2`, email, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count verification codes: %w", err)
+	}
+	return count, nil
+}
+
+// CountRecentCodesByIP counts the codes requested from an IP address since a given time
+// RLS policy automatically filters by tenant_id
+func (r *ExternalSignerRepository) CountRecentCodesByIP(ctx context.Context, ip string, since time.Time) (int, error) {
+	var count int
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM external_signer_codes WHERE created_by_ip = $1 AND created_at >= $2`, ip, since,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count verification codes: %w", err)
+	}
+	return count, nil
+}
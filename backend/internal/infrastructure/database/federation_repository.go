@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+// FederationRepository stores both sides of the federation: the pushes of this instance to its
+// hub, and the peers, documents and signature proofs received as a hub
+type FederationRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewFederationRepository creates a new federation repository
+func NewFederationRepository(db *sql.DB, tenants providers.TenantProvider) *FederationRepository {
+	return &FederationRepository{db: db, tenants: tenants}
+}
+
+// GetPushCursor returns the last signature pushed to the hub and when, zero values when nothing
+// was pushed yet
+// RLS policy automatically filters by tenant_id
+func (r *FederationRepository) GetPushCursor(ctx context.Context) (int64, *time.Time, error) {
+	query := `SELECT COALESCE(MAX(last_signature_id), 0), MAX(pushed_at) FROM federation_pushes`
+
+	var lastID int64
+	var pushedAt sql.NullTime
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query).Scan(&lastID, &pushedAt); err != nil {
+		return 0, nil, fmt.Errorf("failed to get federation push cursor: %w", err)
+	}
+	if !pushedAt.Valid {
+		return lastID, nil, nil
+	}
+	return lastID, &pushedAt.Time, nil
+}
+
+// RecordPush records a push accepted by the hub
+func (r *FederationRepository) RecordPush(ctx context.Context, lastSignatureID int64, documents, signatures int) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO federation_pushes (tenant_id, last_signature_id, documents, signatures)
+		VALUES ($1, $2, $3, $4)`,
+		tenantID, lastSignatureID, documents, signatures)
+	if err != nil {
+		return fmt.Errorf("failed to record federation push: %w", err)
+	}
+	return nil
+}
+
+// CountPendingPush counts the signatures above the push cursor
+// RLS policy automatically filters by tenant_id
+func (r *FederationRepository) CountPendingPush(ctx context.Context, afterID int64) (int, error) {
+	var count int
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM signatures WHERE id > $1`, afterID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count signatures pending for the federation hub: %w", err)
+	}
+	return count, nil
+}
+
+// CreatePeer registers a peer allowed to push to this hub
+func (r *FederationRepository) CreatePeer(ctx context.Context, peer *models.FederationPeer) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO federation_peers (tenant_id, instance_id, name, public_key, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		tenantID, peer.InstanceID, peer.Name, peer.PublicKey, peer.CreatedBy,
+	).Scan(&peer.ID, &peer.CreatedAt)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return models.ErrFederationPeerExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create federation peer: %w", err)
+	}
+	peer.TenantID = tenantID
+	return nil
+}
+
+const federationPeerColumns = `p.id, p.tenant_id, p.instance_id, p.name, p.public_key, p.created_by, p.created_at, p.last_push_at,
+	(SELECT COUNT(*) FROM federation_documents d WHERE d.peer_id = p.id),
+	(SELECT COUNT(*) FROM federation_signatures s WHERE s.peer_id = p.id)`
+
+func scanFederationPeer(row interface{ Scan(...interface{}) error }) (*models.FederationPeer, error) {
+	peer := &models.FederationPeer{}
+	var lastPushAt sql.NullTime
+	err := row.Scan(&peer.ID, &peer.TenantID, &peer.InstanceID, &peer.Name, &peer.PublicKey, &peer.CreatedBy,
+		&peer.CreatedAt, &lastPushAt, &peer.Documents, &peer.Signatures)
+	if err != nil {
+		return nil, err
+	}
+	if lastPushAt.Valid {
+		peer.LastPushAt = &lastPushAt.Time
+	}
+	return peer, nil
+}
+
+// ListPeers returns the registered peers by instance ID, with the documents and signatures received
+// RLS policy automatically filters by tenant_id
+func (r *FederationRepository) ListPeers(ctx context.Context) ([]*models.FederationPeer, error) {
+	query := `SELECT ` + federationPeerColumns + ` FROM federation_peers p ORDER BY p.instance_id`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list federation peers: %w", err)
+	}
+	defer rows.Close()
+
+	peers := []*models.FederationPeer{}
+	for rows.Next() {
+		peer, err := scanFederationPeer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan federation peer: %w", err)
+		}
+		peers = append(peers, peer)
+	}
+	return peers, rows.Err()
+}
+
+// GetPeerByInstanceID returns the peer registered with an instance ID
+// RLS policy automatically filters by tenant_id
+func (r *FederationRepository) GetPeerByInstanceID(ctx context.Context, instanceID string) (*models.FederationPeer, error) {
+	query := `SELECT ` + federationPeerColumns + ` FROM federation_peers p WHERE p.instance_id = $1`
+
+	peer, err := scanFederationPeer(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, instanceID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrFederationPeerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get federation peer: %w", err)
+	}
+	return peer, nil
+}
+
+// DeletePeer removes a peer with the documents and signatures it pushed
+// RLS policy automatically filters by tenant_id
+func (r *FederationRepository) DeletePeer(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM federation_peers WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete federation peer: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrFederationPeerNotFound
+	}
+	return nil
+}
+
+// StorePush stores a push of a peer. Document summaries replace the previous ones unless older;
+// signatures already received are kept as first received and counted as duplicates.
+func (r *FederationRepository) StorePush(ctx context.Context, peerID int64, push *models.FederationPush) (*models.FederationPushResult, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	result := &models.FederationPushResult{}
+	for _, doc := range push.Documents {
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO federation_documents (tenant_id, peer_id, doc_id, title, url, checksum,
+				expected_count, signed_count, signature_count, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (peer_id, doc_id) DO UPDATE SET
+				title = EXCLUDED.title, url = EXCLUDED.url, checksum = EXCLUDED.checksum,
+				expected_count = EXCLUDED.expected_count, signed_count = EXCLUDED.signed_count,
+				signature_count = EXCLUDED.signature_count, updated_at = EXCLUDED.updated_at, received_at = now()
+			WHERE federation_documents.updated_at <= EXCLUDED.updated_at`,
+			tenantID, peerID, doc.DocID, doc.Title, doc.URL, doc.Checksum,
+			doc.ExpectedCount, doc.SignedCount, doc.SignatureCount, doc.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store federated document: %w", err)
+		}
+		result.Documents++
+	}
+
+	for _, sig := range push.Signatures {
+		res, err := q.ExecContext(ctx, `
+			INSERT INTO federation_signatures (tenant_id, peer_id, signature_id, doc_id, user_email, user_name,
+				signed_at, doc_checksum, payload_hash, signature, nonce, prev_hash, record_hash, hash_version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			ON CONFLICT (peer_id, signature_id) DO NOTHING`,
+			tenantID, peerID, sig.ID, sig.DocID, sig.UserEmail, sig.UserName,
+			sig.SignedAt, sig.DocChecksum, sig.PayloadHash, sig.Signature, sig.Nonce, sig.PrevHash, sig.RecordHash, sig.HashVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store federated signature: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			result.Duplicates++
+		} else {
+			result.Signatures++
+		}
+	}
+
+	if _, err := q.ExecContext(ctx, `UPDATE federation_peers SET last_push_at = now() WHERE id = $1`, peerID); err != nil {
+		return nil, fmt.Errorf("failed to update federation peer: %w", err)
+	}
+	return result, nil
+}
+
+// ListDocuments returns the documents received from the peers, or from one peer when instanceID
+// is set, by peer and document ID
+// RLS policy automatically filters by tenant_id
+func (r *FederationRepository) ListDocuments(ctx context.Context, instanceID string) ([]*models.FederatedDocument, error) {
+	query := `
+		SELECT p.instance_id, p.name, d.doc_id, d.title, d.url, d.checksum,
+			d.expected_count, d.signed_count, d.signature_count, d.updated_at, d.received_at
+		FROM federation_documents d
+		JOIN federation_peers p ON p.id = d.peer_id
+		WHERE $1 = '' OR p.instance_id = $1
+		ORDER BY p.instance_id, d.doc_id`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list federated documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*models.FederatedDocument{}
+	for rows.Next() {
+		doc := &models.FederatedDocument{}
+		err := rows.Scan(&doc.InstanceID, &doc.PeerName, &doc.DocID, &doc.Title, &doc.URL, &doc.Checksum,
+			&doc.ExpectedCount, &doc.SignedCount, &doc.SignatureCount, &doc.UpdatedAt, &doc.ReceivedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan federated document: %w", err)
+		}
+		doc.FederatedID = models.FederatedDocumentID(doc.InstanceID, doc.DocID)
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
@@ -0,0 +1,118 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestFederationRepository_PushCursor(t *testing.T) {
+	testDB := SetupTestDB(t)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewFederationRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	sig := NewSignatureFactory().CreateSignatureWithUser("user-1", "user-1@example.com")
+	if err := sigRepo.Create(ctx, sig); err != nil {
+		t.Fatalf("create signature err: %v", err)
+	}
+
+	lastID, pushedAt, err := repo.GetPushCursor(ctx)
+	if err != nil || lastID != 0 || pushedAt != nil {
+		t.Fatalf("expected empty cursor, got %d, %v, %v", lastID, pushedAt, err)
+	}
+	if pending, err := repo.CountPendingPush(ctx, 0); err != nil || pending != 1 {
+		t.Fatalf("expected 1 pending signature, got %d, %v", pending, err)
+	}
+
+	if err := repo.RecordPush(ctx, sig.ID, 1, 1); err != nil {
+		t.Fatalf("record push err: %v", err)
+	}
+	lastID, pushedAt, err = repo.GetPushCursor(ctx)
+	if err != nil || lastID != sig.ID || pushedAt == nil {
+		t.Fatalf("expected cursor %d, got %d, %v, %v", sig.ID, lastID, pushedAt, err)
+	}
+	if pending, err := repo.CountPendingPush(ctx, lastID); err != nil || pending != 0 {
+		t.Fatalf("expected no pending signature, got %d, %v", pending, err)
+	}
+}
+
+func TestFederationRepository_Hub(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewFederationRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	fr := &models.FederationPeer{InstanceID: "acme-fr", Name: "Acme France", PublicKey: "a2V5LWZy", CreatedBy: "admin@example.com"}
+	de := &models.FederationPeer{InstanceID: "acme-de", Name: "Acme Deutschland", PublicKey: "a2V5LWRl"}
+	for _, peer := range []*models.FederationPeer{fr, de} {
+		if err := repo.CreatePeer(ctx, peer); err != nil {
+			t.Fatalf("create peer err: %v", err)
+		}
+	}
+	if err := repo.CreatePeer(ctx, &models.FederationPeer{InstanceID: "acme-fr", PublicKey: "b3RoZXI="}); !errors.Is(err, models.ErrFederationPeerExists) {
+		t.Fatalf("expected ErrFederationPeerExists, got %v", err)
+	}
+
+	updatedAt := time.Now().UTC().Truncate(time.Second)
+	push := &models.FederationPush{
+		InstanceID: "acme-fr",
+		Documents:  []models.FederationDocument{{DocID: "policy", Title: "Policy", ExpectedCount: 2, SignedCount: 1, SignatureCount: 1, UpdatedAt: updatedAt}},
+		Signatures: []models.FederationSignature{{ID: 7, DocID: "policy", UserEmail: "alice@example.com", SignedAt: updatedAt,
+			PayloadHash: "cGF5bG9hZA==", Signature: "c2ln", Nonce: "nonce", RecordHash: "cmVjb3Jk", HashVersion: 2}},
+	}
+	result, err := repo.StorePush(ctx, fr.ID, push)
+	if err != nil || result.Documents != 1 || result.Signatures != 1 || result.Duplicates != 0 {
+		t.Fatalf("unexpected push result %+v, %v", result, err)
+	}
+	// The same document ID pushed by another peer is another document
+	push.InstanceID = "acme-de"
+	push.Signatures = nil
+	if _, err := repo.StorePush(ctx, de.ID, push); err != nil {
+		t.Fatalf("store push err: %v", err)
+	}
+	// A retried push stores nothing twice; an older summary does not replace a newer one
+	push.InstanceID = "acme-fr"
+	push.Documents[0].SignedCount = 0
+	push.Documents[0].UpdatedAt = updatedAt.Add(-time.Hour)
+	push.Signatures = []models.FederationSignature{{ID: 7, DocID: "policy", UserEmail: "alice@example.com", SignedAt: updatedAt,
+		PayloadHash: "cGF5bG9hZA==", Signature: "c2ln", Nonce: "nonce", RecordHash: "cmVjb3Jk", HashVersion: 2}}
+	result, err = repo.StorePush(ctx, fr.ID, push)
+	if err != nil || result.Signatures != 0 || result.Duplicates != 1 {
+		t.Fatalf("unexpected retried push result %+v, %v", result, err)
+	}
+
+	docs, err := repo.ListDocuments(ctx, "")
+	if err != nil || len(docs) != 2 {
+		t.Fatalf("expected 2 federated documents, got %d, %v", len(docs), err)
+	}
+	if docs[0].FederatedID != "acme-de/policy" || docs[1].FederatedID != "acme-fr/policy" || docs[1].SignedCount != 1 {
+		t.Errorf("unexpected documents: %+v, %+v", docs[0], docs[1])
+	}
+	if docs, err := repo.ListDocuments(ctx, "acme-fr"); err != nil || len(docs) != 1 {
+		t.Fatalf("expected 1 document of acme-fr, got %d, %v", len(docs), err)
+	}
+
+	peer, err := repo.GetPeerByInstanceID(ctx, "acme-fr")
+	if err != nil || peer.Documents != 1 || peer.Signatures != 1 || peer.LastPushAt == nil {
+		t.Fatalf("unexpected peer %+v, %v", peer, err)
+	}
+	if err := repo.DeletePeer(ctx, fr.ID); err != nil {
+		t.Fatalf("delete peer err: %v", err)
+	}
+	if _, err := repo.GetPeerByInstanceID(ctx, "acme-fr"); !errors.Is(err, models.ErrFederationPeerNotFound) {
+		t.Fatalf("expected ErrFederationPeerNotFound, got %v", err)
+	}
+	if err := repo.DeletePeer(ctx, fr.ID); !errors.Is(err, models.ErrFederationPeerNotFound) {
+		t.Fatalf("expected ErrFederationPeerNotFound on second delete, got %v", err)
+	}
+	if docs, _ := repo.ListDocuments(ctx, ""); len(docs) != 1 {
+		t.Errorf("expected the documents of the deleted peer to be removed, got %d", len(docs))
+	}
+}
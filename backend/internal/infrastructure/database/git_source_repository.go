@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const gitSourceColumns = `s.id, s.tenant_id, s.name, s.repo_url, s.branch, s.rules, s.webhook_secret, s.sync_enabled,
+	s.sync_requested_at, s.last_commit, s.last_synced_at, COALESCE(s.last_sync_error, ''), s.created_by,
+	s.created_at, s.updated_at`
+
+// GitSourceRepository handles database operations for Git sources and the documents imported from them
+type GitSourceRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewGitSourceRepository creates a new Git source repository
+func NewGitSourceRepository(db *sql.DB, tenants providers.TenantProvider) *GitSourceRepository {
+	return &GitSourceRepository{db: db, tenants: tenants}
+}
+
+func scanGitSource(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.GitSource, error) {
+	s := &models.GitSource{}
+	var rules []byte
+	var syncRequestedAt, lastSyncedAt sql.NullTime
+	err := scanner.Scan(&s.ID, &s.TenantID, &s.Name, &s.RepoURL, &s.Branch, &rules, &s.WebhookSecret,
+		&s.SyncEnabled, &syncRequestedAt, &s.LastCommit, &lastSyncedAt, &s.LastSyncError, &s.CreatedBy,
+		&s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rules, &s.Rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal git path rules: %w", err)
+	}
+	if syncRequestedAt.Valid {
+		s.SyncRequestedAt = &syncRequestedAt.Time
+	}
+	if lastSyncedAt.Valid {
+		s.LastSyncedAt = &lastSyncedAt.Time
+	}
+	return s, nil
+}
+
+// Create inserts a new Git source.
+// It returns models.ErrGitSourceExists when the name is already used.
+func (r *GitSourceRepository) Create(ctx context.Context, input models.GitSourceInput) (*models.GitSource, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	rules, err := json.Marshal(input.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal git path rules: %w", err)
+	}
+
+	query := `
+		WITH s AS (
+			INSERT INTO git_sources (tenant_id, name, repo_url, branch, rules, webhook_secret, sync_enabled, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (tenant_id, name) DO NOTHING
+			RETURNING *
+		)
+		SELECT ` + gitSourceColumns + ` FROM s`
+
+	s, err := scanGitSource(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, input.Name, input.RepoURL, input.Branch, rules, input.WebhookSecret, input.SyncEnabled, input.CreatedBy,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrGitSourceExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git source: %w", err)
+	}
+	return s, nil
+}
+
+// Update modifies a Git source, an empty webhook secret keeping the current one
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) Update(ctx context.Context, id int64, input models.GitSourceInput) (*models.GitSource, error) {
+	rules, err := json.Marshal(input.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal git path rules: %w", err)
+	}
+
+	query := `
+		WITH s AS (
+			UPDATE git_sources
+			SET name = $1, repo_url = $2, branch = $3, rules = $4,
+				webhook_secret = COALESCE(NULLIF($5, ''), webhook_secret), sync_enabled = $6, updated_at = now()
+			WHERE id = $7
+			RETURNING *
+		)
+		SELECT ` + gitSourceColumns + ` FROM s`
+
+	s, err := scanGitSource(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		input.Name, input.RepoURL, input.Branch, rules, input.WebhookSecret, input.SyncEnabled, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrGitSourceNotFound
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, models.ErrGitSourceExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update git source: %w", err)
+	}
+	return s, nil
+}
+
+// Delete removes a Git source. The imported documents and their signatures are kept.
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) Delete(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM git_sources WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete git source: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrGitSourceNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a Git source by its ID
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) GetByID(ctx context.Context, id int64) (*models.GitSource, error) {
+	query := `SELECT ` + gitSourceColumns + ` FROM git_sources s WHERE s.id = $1`
+
+	s, err := scanGitSource(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrGitSourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git source: %w", err)
+	}
+	return s, nil
+}
+
+// List retrieves all Git sources by name
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) List(ctx context.Context) ([]*models.GitSource, error) {
+	return r.list(ctx, `SELECT `+gitSourceColumns+` FROM git_sources s ORDER BY s.name ASC`)
+}
+
+// ListDue retrieves the sources to sync: requested by a webhook, never synced or synced before
+// the given time. Requested sources come first.
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) ListDue(ctx context.Context, syncedBefore time.Time) ([]*models.GitSource, error) {
+	return r.list(ctx, `SELECT `+gitSourceColumns+` FROM git_sources s
+		WHERE s.sync_enabled = TRUE
+			AND (s.sync_requested_at IS NOT NULL OR s.last_synced_at IS NULL OR s.last_synced_at < $1)
+		ORDER BY s.sync_requested_at ASC NULLS LAST, s.last_synced_at ASC NULLS FIRST`, syncedBefore)
+}
+
+func (r *GitSourceRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.GitSource, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*models.GitSource
+	for rows.Next() {
+		s, err := scanGitSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan git source: %w", err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
+
+// RequestSync marks a source to be synced by the next worker run
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) RequestSync(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE git_sources SET sync_requested_at = COALESCE(sync_requested_at, $2) WHERE id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, at); err != nil {
+		return fmt.Errorf("failed to request git source sync: %w", err)
+	}
+	return nil
+}
+
+// MarkSynced records the outcome of a sync started at the given time. The commit is only
+// recorded on success, and webhook requests received during the sync are kept for the next run.
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) MarkSynced(ctx context.Context, id int64, commit string, at time.Time, syncErr string) error {
+	query := `
+		UPDATE git_sources
+		SET last_synced_at = $3, last_sync_error = NULLIF($4, ''),
+			last_commit = CASE WHEN $4 = '' THEN $2 ELSE last_commit END,
+			sync_requested_at = CASE WHEN sync_requested_at <= $3 THEN NULL ELSE sync_requested_at END
+		WHERE id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, commit, at, syncErr); err != nil {
+		return fmt.Errorf("failed to mark git source synced: %w", err)
+	}
+	return nil
+}
+
+// ListDocuments retrieves the documents imported from a source by path, with the number of
+// signatures made on a previous content of their file
+// RLS policy automatically filters by tenant_id
+func (r *GitSourceRepository) ListDocuments(ctx context.Context, sourceID int64) ([]*models.GitSourceDocument, error) {
+	query := `
+		SELECT gd.source_id, gd.path, gd.doc_id, gd.checksum, gd.commit_sha, gd.reacknowledge_since,
+			(SELECT COUNT(*) FROM signatures sig
+				WHERE sig.doc_id = gd.doc_id AND sig.doc_checksum IS NOT NULL AND sig.doc_checksum <> gd.checksum),
+			gd.updated_at
+		FROM git_source_documents gd
+		WHERE gd.source_id = $1
+		ORDER BY gd.path ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git source documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*models.GitSourceDocument
+	for rows.Next() {
+		d := &models.GitSourceDocument{}
+		var reacknowledgeSince sql.NullTime
+		if err := rows.Scan(&d.SourceID, &d.Path, &d.DocID, &d.Checksum, &d.CommitSHA, &reacknowledgeSince,
+			&d.OutdatedSignatures, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan git source document: %w", err)
+		}
+		if reacknowledgeSince.Valid {
+			d.ReacknowledgeSince = &reacknowledgeSince.Time
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
+
+// UpsertDocument records the document imported from a file of the source
+func (r *GitSourceRepository) UpsertDocument(ctx context.Context, doc *models.GitSourceDocument) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO git_source_documents (source_id, tenant_id, path, doc_id, checksum, commit_sha, reacknowledge_since)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (source_id, path) DO UPDATE
+		SET doc_id = EXCLUDED.doc_id, checksum = EXCLUDED.checksum, commit_sha = EXCLUDED.commit_sha,
+			reacknowledge_since = EXCLUDED.reacknowledge_since, updated_at = now()`
+
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query,
+		doc.SourceID, tenantID, doc.Path, doc.DocID, doc.Checksum, doc.CommitSHA, doc.ReacknowledgeSince,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record git source document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestGitSourceRepository_SourcesAndDocuments(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewGitSourceRepository(testDB.DB, testDB.TenantProvider)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	source, err := repo.Create(ctx, models.GitSourceInput{
+		Name:          "Policies",
+		RepoURL:       "https://git.example.com/acme/policies.git",
+		Branch:        "main",
+		Rules:         []models.GitPathRule{{Pattern: "policies/**/*.md", Reacknowledge: true}},
+		WebhookSecret: "s3cret",
+		SyncEnabled:   true,
+		CreatedBy:     "admin@example.com",
+	})
+	if err != nil {
+		t.Fatalf("create source err: %v", err)
+	}
+	if len(source.Rules) != 1 || !source.Rules[0].Reacknowledge || source.WebhookSecret != "s3cret" {
+		t.Errorf("unexpected source %+v", source)
+	}
+
+	if _, err := repo.Create(ctx, models.GitSourceInput{Name: "Policies", RepoURL: "https://git.example.com/other.git"}); !errors.Is(err, models.ErrGitSourceExists) {
+		t.Fatalf("expected ErrGitSourceExists, got %v", err)
+	}
+
+	updated, err := repo.Update(ctx, source.ID, models.GitSourceInput{
+		Name: "Policies", RepoURL: source.RepoURL, Branch: "release", Rules: source.Rules, SyncEnabled: true,
+	})
+	if err != nil || updated.Branch != "release" || updated.WebhookSecret != "s3cret" {
+		t.Fatalf("expected the branch updated and the secret kept, got %+v, %v", updated, err)
+	}
+
+	due, err := repo.ListDue(ctx, time.Now().Add(-time.Hour))
+	if err != nil || len(due) != 1 {
+		t.Fatalf("expected the never synced source to be due, got %v, %v", due, err)
+	}
+
+	start := time.Now()
+	if err := repo.MarkSynced(ctx, source.ID, "abc123", start, ""); err != nil {
+		t.Fatalf("mark synced err: %v", err)
+	}
+	if due, _ := repo.ListDue(ctx, start.Add(-time.Hour)); len(due) != 0 {
+		t.Fatalf("expected no due source after sync, got %v", due)
+	}
+
+	if err := repo.RequestSync(ctx, source.ID, time.Now()); err != nil {
+		t.Fatalf("request sync err: %v", err)
+	}
+	if due, _ := repo.ListDue(ctx, start.Add(-time.Hour)); len(due) != 1 {
+		t.Fatalf("expected the requested source to be due, got %v", due)
+	}
+
+	if err := repo.MarkSynced(ctx, source.ID, "def456", start, "git fetch failed"); err != nil {
+		t.Fatalf("mark failed sync err: %v", err)
+	}
+	got, err := repo.GetByID(ctx, source.ID)
+	if err != nil || got.LastCommit != "abc123" || got.LastSyncError == "" || got.SyncRequestedAt == nil {
+		t.Fatalf("expected the commit kept and the later request pending, got %+v, %v", got, err)
+	}
+
+	if _, err := docRepo.Create(ctx, "git-doc-1", models.DocumentInput{Title: "Security", Checksum: "aaa"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	now := time.Now()
+	if err := repo.UpsertDocument(ctx, &models.GitSourceDocument{
+		SourceID: source.ID, Path: "policies/security.md", DocID: "git-doc-1", Checksum: "aaa", CommitSHA: "abc123",
+	}); err != nil {
+		t.Fatalf("upsert document err: %v", err)
+	}
+	if err := repo.UpsertDocument(ctx, &models.GitSourceDocument{
+		SourceID: source.ID, Path: "policies/security.md", DocID: "git-doc-1", Checksum: "bbb", CommitSHA: "def456",
+		ReacknowledgeSince: &now,
+	}); err != nil {
+		t.Fatalf("update document err: %v", err)
+	}
+
+	docs, err := repo.ListDocuments(ctx, source.ID)
+	if err != nil || len(docs) != 1 || docs[0].Checksum != "bbb" || docs[0].ReacknowledgeSince == nil {
+		t.Fatalf("unexpected documents %+v, %v", docs, err)
+	}
+
+	if err := repo.Delete(ctx, source.ID); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, source.ID); !errors.Is(err, models.ErrGitSourceNotFound) {
+		t.Errorf("expected ErrGitSourceNotFound, got %v", err)
+	}
+	if doc, err := docRepo.GetByDocID(ctx, "git-doc-1"); err != nil || doc == nil {
+		t.Errorf("expected the imported document to be kept, got %v, %v", doc, err)
+	}
+}
@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const identityMergeColumns = `id, tenant_id, source_email, source_subs, target_email, target_sub, summary, merged_by, merged_at`
+
+// IdentityMergeRepository handles database operations for merged user identities
+type IdentityMergeRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewIdentityMergeRepository creates a new identity merge repository
+func NewIdentityMergeRepository(db *sql.DB, tenants providers.TenantProvider) *IdentityMergeRepository {
+	return &IdentityMergeRepository{db: db, tenants: tenants}
+}
+
+func scanIdentityMerge(row interface{ Scan(dest ...any) error }) (*models.IdentityMerge, error) {
+	m := &models.IdentityMerge{}
+	var summary []byte
+	err := row.Scan(&m.ID, &m.TenantID, &m.SourceEmail, pq.Array(&m.SourceSubs), &m.TargetEmail, &m.TargetSub,
+		&summary, &m.MergedBy, &m.MergedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(summary, &m.Summary); err != nil {
+		return nil, fmt.Errorf("failed to decode merge summary: %w", err)
+	}
+	if m.SourceSubs == nil {
+		m.SourceSubs = []string{}
+	}
+	return m, nil
+}
+
+// ListSubs returns the subjects an email signed in or signed with, most recently used first
+// RLS policy automatically filters by tenant_id
+func (r *IdentityMergeRepository) ListSubs(ctx context.Context, email string) ([]string, error) {
+	query := `
+		SELECT user_sub FROM (
+			SELECT user_sub, max(created_at) AS used_at FROM signatures WHERE lower(user_email) = lower($1) GROUP BY user_sub
+			UNION ALL
+			SELECT user_sub, max(last_seen_at) AS used_at FROM user_sessions WHERE lower(user_email) = lower($1) GROUP BY user_sub
+		) used
+		GROUP BY user_sub
+		ORDER BY max(used_at) DESC, user_sub`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity subjects: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []string{}
+	for rows.Next() {
+		var sub string
+		if err := rows.Scan(&sub); err != nil {
+			return nil, fmt.Errorf("failed to scan identity subject: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// GetBySource returns the merge of a source email, or nil when it was never merged
+// RLS policy automatically filters by tenant_id
+func (r *IdentityMergeRepository) GetBySource(ctx context.Context, email string) (*models.IdentityMerge, error) {
+	query := `SELECT ` + identityMergeColumns + ` FROM identity_merges WHERE source_email = lower($1)`
+
+	m, err := scanIdentityMerge(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity merge: %w", err)
+	}
+	return m, nil
+}
+
+// Resolve returns the latest merge whose source matches a login subject or email, or nil
+// RLS policy automatically filters by tenant_id
+func (r *IdentityMergeRepository) Resolve(ctx context.Context, sub, email string) (*models.IdentityMerge, error) {
+	query := `SELECT ` + identityMergeColumns + ` FROM identity_merges
+		WHERE source_email = lower($2) OR ($1 <> '' AND $1 = ANY(source_subs))
+		ORDER BY merged_at DESC, id DESC
+		LIMIT 1`
+
+	m, err := scanIdentityMerge(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, sub, email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve identity: %w", err)
+	}
+	return m, nil
+}
+
+// MoveReferences moves the references owned by the source email to the target email and revokes
+// the sessions of the source subjects. Rows the target already holds (preferences, admin role,
+// memberships) win over the source ones, which are dropped. Signatures, expected signers and
+// reminder logs are left untouched: they match the target through the email alias.
+// Call it within a transaction.
+// RLS policy automatically filters by tenant_id
+func (r *IdentityMergeRepository) MoveReferences(ctx context.Context, sourceEmail, targetEmail string, sourceSubs []string) (*models.IdentityMergeSummary, error) {
+	q := dbctx.GetQuerier(ctx, r.db)
+	summary := &models.IdentityMergeSummary{}
+
+	exec := func(what string, counter *int64, query string, args ...any) error {
+		res, err := q.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to move %s: %w", what, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to move %s: %w", what, err)
+		}
+		if counter != nil {
+			*counter += n
+		}
+		return nil
+	}
+
+	if sourceEmail != targetEmail {
+		steps := []struct {
+			what    string
+			counter *int64
+			query   string
+		}{
+			{"documents", &summary.Documents,
+				`UPDATE documents SET created_by = $2 WHERE lower(created_by) = $1`},
+			{"questions", &summary.Questions,
+				`UPDATE document_questions SET author_email = $2 WHERE lower(author_email) = $1`},
+			{"question replies", &summary.Questions,
+				`UPDATE document_question_replies SET author_email = $2 WHERE lower(author_email) = $1`},
+			{"notifications", &summary.Notifications,
+				`UPDATE admin_notifications n SET recipient_email = $2
+				WHERE lower(n.recipient_email) = $1 AND (n.dedup_key IS NULL OR NOT EXISTS (
+					SELECT 1 FROM admin_notifications o WHERE o.recipient_email = $2 AND o.dedup_key = n.dedup_key))`},
+			{"notifications", nil,
+				`DELETE FROM admin_notifications WHERE lower(recipient_email) = $1 AND recipient_email <> $2`},
+			{"preferences", &summary.Preferences,
+				`UPDATE user_preferences SET email = $2
+				WHERE email = $1 AND NOT EXISTS (SELECT 1 FROM user_preferences WHERE email = $2)`},
+			{"preferences", nil,
+				`DELETE FROM user_preferences WHERE email = $1 AND email <> $2`},
+			{"admin role", &summary.Roles,
+				`UPDATE admin_roles SET email = $2
+				WHERE email = $1 AND NOT EXISTS (SELECT 1 FROM admin_roles WHERE email = $2)`},
+			{"admin role", &summary.RolesDropped,
+				`DELETE FROM admin_roles WHERE email = $1 AND email <> $2`},
+			{"group memberships", &summary.Memberships,
+				`UPDATE signer_group_members m SET email = $2
+				WHERE lower(m.email) = $1 AND NOT EXISTS (
+					SELECT 1 FROM signer_group_members o WHERE o.group_id = m.group_id AND lower(o.email) = $2)`},
+			{"group memberships", nil,
+				`DELETE FROM signer_group_members WHERE lower(email) = $1 AND email <> $2`},
+			{"department memberships", &summary.Memberships,
+				`UPDATE department_members m SET email = $2
+				WHERE lower(m.email) = $1 AND NOT EXISTS (
+					SELECT 1 FROM department_members o WHERE o.department_id = m.department_id AND lower(o.email) = $2)`},
+			{"department memberships", nil,
+				`DELETE FROM department_members WHERE lower(email) = $1 AND email <> $2`},
+		}
+		for _, step := range steps {
+			if err := exec(step.what, step.counter, step.query, sourceEmail, targetEmail); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Revoked sessions sign the source identity out; its next login resolves to the target
+	if err := exec("sessions", &summary.SessionsRevoked, `
+		UPDATE user_sessions SET revoked_at = now()
+		WHERE revoked_at IS NULL AND (user_sub = ANY($1) OR ($2 <> $3 AND lower(user_email) = $2))`,
+		pq.Array(sourceSubs), sourceEmail, targetEmail); err != nil {
+		return nil, err
+	}
+	if err := exec("oauth sessions", nil, `DELETE FROM oauth_sessions WHERE user_sub = ANY($1)`, pq.Array(sourceSubs)); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// Retarget points the earlier merges into an email to a new target identity, so that merge
+// chains resolve in one step. It returns the number of merges retargeted.
+// RLS policy automatically filters by tenant_id
+func (r *IdentityMergeRepository) Retarget(ctx context.Context, fromEmail, targetEmail, targetSub string) (int64, error) {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE identity_merges SET target_email = $2, target_sub = $3
+		WHERE target_email = $1 AND source_email <> $2`,
+		fromEmail, targetEmail, targetSub)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retarget identity merges: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// Save records a merge, replacing an earlier merge of the same source email
+func (r *IdentityMergeRepository) Save(ctx context.Context, m *models.IdentityMerge) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	summary, err := json.Marshal(m.Summary)
+	if err != nil {
+		return fmt.Errorf("failed to encode merge summary: %w", err)
+	}
+
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO identity_merges (tenant_id, source_email, source_subs, target_email, target_sub, summary, merged_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, source_email) DO UPDATE
+		SET source_subs = EXCLUDED.source_subs, target_email = EXCLUDED.target_email, target_sub = EXCLUDED.target_sub,
+			summary = EXCLUDED.summary, merged_by = EXCLUDED.merged_by, merged_at = now()
+		RETURNING id, merged_at`,
+		tenantID, m.SourceEmail, pq.Array(m.SourceSubs), m.TargetEmail, m.TargetSub, summary, m.MergedBy,
+	).Scan(&m.ID, &m.MergedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save identity merge: %w", err)
+	}
+	m.TenantID = tenantID
+	return nil
+}
+
+// List returns the merges, newest first
+// RLS policy automatically filters by tenant_id
+func (r *IdentityMergeRepository) List(ctx context.Context, limit, offset int) ([]*models.IdentityMerge, error) {
+	query := `SELECT ` + identityMergeColumns + ` FROM identity_merges
+		ORDER BY merged_at DESC, id DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity merges: %w", err)
+	}
+	defer rows.Close()
+
+	merges := []*models.IdentityMerge{}
+	for rows.Next() {
+		m, err := scanIdentityMerge(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity merge: %w", err)
+		}
+		merges = append(merges, m)
+	}
+	return merges, rows.Err()
+}
@@ -0,0 +1,92 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestIdentityMergeRepository_Merge(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewIdentityMergeRepository(tdb.DB, tdb.TenantProvider)
+	sessions := NewUserSessionRepository(tdb.DB, tdb.TenantProvider)
+	roles := NewAdminRoleRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := tdb.DB.Exec(`INSERT INTO documents (doc_id, title, created_by) VALUES ('doc1', 'Policy', 'Alice.Old@example.com')`); err != nil {
+		t.Fatalf("insert document err: %v", err)
+	}
+	for _, s := range []*models.UserSession{
+		{SessionID: "s1", UserSub: "magic-alice", UserEmail: "alice.old@example.com", ExpiresAt: time.Now().Add(time.Hour)},
+		{SessionID: "s2", UserSub: "google-alice", UserEmail: "alice@example.com", ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		if err := sessions.Create(ctx, s); err != nil {
+			t.Fatalf("create session err: %v", err)
+		}
+	}
+	for _, email := range []string{"alice.old@example.com", "alice@example.com"} {
+		if _, err := roles.Upsert(ctx, email, models.AdminRoleViewer, nil, "admin@example.com"); err != nil {
+			t.Fatalf("upsert role err: %v", err)
+		}
+	}
+
+	subs, err := repo.ListSubs(ctx, "Alice.Old@example.com")
+	if err != nil || len(subs) != 1 || subs[0] != "magic-alice" {
+		t.Fatalf("unexpected subjects: %v (err %v)", subs, err)
+	}
+
+	summary, err := repo.MoveReferences(ctx, "alice.old@example.com", "alice@example.com", subs)
+	if err != nil {
+		t.Fatalf("move references err: %v", err)
+	}
+	if summary.Documents != 1 || summary.Roles != 0 || summary.RolesDropped != 1 || summary.SessionsRevoked != 1 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	active, err := sessions.ListActiveByEmail(ctx, "alice@example.com")
+	if err != nil || len(active) != 1 {
+		t.Fatalf("the target sessions must stay active: %+v (err %v)", active, err)
+	}
+
+	merge := &models.IdentityMerge{
+		SourceEmail: "alice.old@example.com",
+		SourceSubs:  subs,
+		TargetEmail: "alice@example.com",
+		TargetSub:   "google-alice",
+		Summary:     *summary,
+		MergedBy:    "admin@example.com",
+	}
+	if err := repo.Save(ctx, merge); err != nil {
+		t.Fatalf("save err: %v", err)
+	}
+
+	for _, login := range [][2]string{{"magic-alice", "someone@example.com"}, {"", "ALICE.OLD@example.com"}} {
+		got, err := repo.Resolve(ctx, login[0], login[1])
+		if err != nil || got == nil || got.TargetSub != "google-alice" || got.Summary.Documents != 1 {
+			t.Fatalf("unexpected resolution of %v: %+v (err %v)", login, got, err)
+		}
+	}
+	if got, err := repo.Resolve(ctx, "", "bob@example.com"); err != nil || got != nil {
+		t.Fatalf("expected no resolution, got %+v (err %v)", got, err)
+	}
+
+	// Merging the target further retargets the earlier merge
+	n, err := repo.Retarget(ctx, "alice@example.com", "alice.new@example.com", "")
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 merge retargeted, got %d (err %v)", n, err)
+	}
+	got, err := repo.GetBySource(ctx, "alice.old@example.com")
+	if err != nil || got == nil || got.TargetEmail != "alice.new@example.com" || got.TargetSub != "" {
+		t.Fatalf("unexpected retargeted merge: %+v (err %v)", got, err)
+	}
+
+	merges, err := repo.List(ctx, 10, 0)
+	if err != nil || len(merges) != 1 {
+		t.Fatalf("unexpected merges: %+v (err %v)", merges, err)
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobCoordinationRepository handles the leases electing the instance that runs scheduled jobs,
+// and the bookkeeping of their runs. Both tables are shared by all tenants.
+type JobCoordinationRepository struct {
+	db *sql.DB
+}
+
+// NewJobCoordinationRepository creates a new job coordination repository
+func NewJobCoordinationRepository(db *sql.DB) *JobCoordinationRepository {
+	return &JobCoordinationRepository{db: db}
+}
+
+// AcquireLease takes or renews the lease name for holder. It reports false while another
+// holder owns a lease that has not expired yet.
+func (r *JobCoordinationRepository) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	query := `
+		INSERT INTO job_leases (name, holder, expires_at)
+		VALUES ($1, $2, now() + make_interval(secs => $3))
+		ON CONFLICT (name) DO UPDATE
+		SET holder = EXCLUDED.holder,
+			acquired_at = CASE WHEN job_leases.holder = EXCLUDED.holder THEN job_leases.acquired_at ELSE now() END,
+			renewed_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE job_leases.holder = EXCLUDED.holder OR job_leases.expires_at < now()
+		RETURNING holder
+	`
+
+	var owner string
+	err := r.db.QueryRowContext(ctx, query, name, holder, ttl.Seconds()).Scan(&owner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lease: %w", err)
+	}
+	return true, nil
+}
+
+// ReleaseLease gives the lease up so another instance takes over without waiting for its expiry
+func (r *JobCoordinationRepository) ReleaseLease(ctx context.Context, name, holder string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM job_leases WHERE name = $1 AND holder = $2`, name, holder); err != nil {
+		return fmt.Errorf("failed to release job lease: %w", err)
+	}
+	return nil
+}
+
+// StartRun records the start of a run of job by holder and returns its ID
+func (r *JobCoordinationRepository) StartRun(ctx context.Context, job, holder string) (int64, error) {
+	var id int64
+	err := r.db.QueryRowContext(ctx,
+		`INSERT INTO job_runs (job, holder) VALUES ($1, $2) RETURNING id`,
+		job, holder,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record job run: %w", err)
+	}
+	return id, nil
+}
+
+// FinishRun records the end of a run with its final status (succeeded or failed)
+func (r *JobCoordinationRepository) FinishRun(ctx context.Context, id int64, status string) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE job_runs SET status = $2, finished_at = now() WHERE id = $1`,
+		id, status,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish job run: %w", err)
+	}
+	return nil
+}
+
+// PurgeRuns deletes the runs started before the given time
+func (r *JobCoordinationRepository) PurgeRuns(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM job_runs WHERE started_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge job runs: %w", err)
+	}
+	return result.RowsAffected()
+}
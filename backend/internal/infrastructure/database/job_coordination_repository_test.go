@@ -0,0 +1,77 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestJobCoordinationRepository_Lease(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobCoordinationRepository(testDB.DB)
+	ctx := context.Background()
+
+	if ok, err := repo.AcquireLease(ctx, "test-scheduler", "instance-a", time.Minute); err != nil || !ok {
+		t.Fatalf("instance-a should acquire the free lease, got %v, %v", ok, err)
+	}
+	if ok, err := repo.AcquireLease(ctx, "test-scheduler", "instance-a", time.Minute); err != nil || !ok {
+		t.Fatalf("instance-a should renew its lease, got %v, %v", ok, err)
+	}
+	if ok, err := repo.AcquireLease(ctx, "test-scheduler", "instance-b", time.Minute); err != nil || ok {
+		t.Fatalf("instance-b should not take a live lease, got %v, %v", ok, err)
+	}
+
+	// An expired lease is taken over
+	if _, err := testDB.DB.ExecContext(ctx, `UPDATE job_leases SET expires_at = now() - interval '1 second' WHERE name = 'test-scheduler'`); err != nil {
+		t.Fatalf("failed to expire lease: %v", err)
+	}
+	if ok, err := repo.AcquireLease(ctx, "test-scheduler", "instance-b", time.Minute); err != nil || !ok {
+		t.Fatalf("instance-b should take the expired lease over, got %v, %v", ok, err)
+	}
+
+	// Only the holder releases the lease
+	if err := repo.ReleaseLease(ctx, "test-scheduler", "instance-a"); err != nil {
+		t.Fatalf("ReleaseLease err: %v", err)
+	}
+	if ok, _ := repo.AcquireLease(ctx, "test-scheduler", "instance-a", time.Minute); ok {
+		t.Fatal("instance-a should not have released the lease of instance-b")
+	}
+	if err := repo.ReleaseLease(ctx, "test-scheduler", "instance-b"); err != nil {
+		t.Fatalf("ReleaseLease err: %v", err)
+	}
+	if ok, err := repo.AcquireLease(ctx, "test-scheduler", "instance-a", time.Minute); err != nil || !ok {
+		t.Fatalf("instance-a should acquire the released lease, got %v, %v", ok, err)
+	}
+}
+
+func TestJobCoordinationRepository_Runs(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobCoordinationRepository(testDB.DB)
+	ctx := context.Background()
+
+	id, err := repo.StartRun(ctx, "retention", "instance-a")
+	if err != nil || id == 0 {
+		t.Fatalf("StartRun = %d, %v", id, err)
+	}
+	if err := repo.FinishRun(ctx, id, "succeeded"); err != nil {
+		t.Fatalf("FinishRun err: %v", err)
+	}
+
+	var status string
+	var finished bool
+	if err := testDB.DB.QueryRowContext(ctx, `SELECT status, finished_at IS NOT NULL FROM job_runs WHERE id = $1`, id).Scan(&status, &finished); err != nil {
+		t.Fatalf("failed to read run: %v", err)
+	}
+	if status != "succeeded" || !finished {
+		t.Errorf("run = %s (finished %v), expected a finished succeeded run", status, finished)
+	}
+
+	purged, err := repo.PurgeRuns(ctx, time.Now().Add(time.Minute))
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeRuns = %d, %v, expected 1", purged, err)
+	}
+}
@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const jobColumns = `id, type, payload, status, priority, attempts, max_attempts, run_at, COALESCE(locked_by, ''),
+	locked_at, COALESCE(last_error, ''), COALESCE(dedup_key, ''), created_by, created_at, started_at, finished_at,
+	progress_done, progress_total, result`
+
+// JobRepository handles database operations for background jobs
+type JobRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(db *sql.DB, tenants providers.TenantProvider) *JobRepository {
+	return &JobRepository{db: db, tenants: tenants}
+}
+
+func scanJob(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Job, error) {
+	j := &models.Job{}
+	var payload, result []byte
+	var progress models.JobProgress
+	if err := scanner.Scan(&j.ID, &j.Type, &payload, &j.Status, &j.Priority, &j.Attempts, &j.MaxAttempts, &j.RunAt,
+		&j.LockedBy, &j.LockedAt, &j.LastError, &j.DedupKey, &j.CreatedBy, &j.CreatedAt, &j.StartedAt, &j.FinishedAt,
+		&progress.Done, &progress.Total, &result); err != nil {
+		return nil, err
+	}
+	j.Payload = payload
+	if progress.Total > 0 {
+		j.Progress = &progress
+	}
+	if len(result) > 0 {
+		j.Result = result
+	}
+	return j, nil
+}
+
+func scanJobs(rows *sql.Rows) ([]*models.Job, error) {
+	defer rows.Close()
+
+	jobs := []*models.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// Enqueue stores a new job. With a dedup key, the queued or running job of the same type and
+// key is returned instead, and created is false.
+func (r *JobRepository) Enqueue(ctx context.Context, input models.JobInput) (*models.Job, bool, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	payload, err := json.Marshal(input.Payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+	maxAttempts := input.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = models.DefaultJobMaxAttempts
+	}
+	var runAt interface{}
+	if input.RunAt != nil {
+		runAt = *input.RunAt
+	}
+	var dedupKey interface{}
+	if input.DedupKey != "" {
+		dedupKey = input.DedupKey
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	query := `
+		INSERT INTO jobs (tenant_id, type, payload, priority, max_attempts, run_at, dedup_key, created_by)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6::timestamptz, now()), $7, $8)
+		ON CONFLICT (tenant_id, type, dedup_key) WHERE dedup_key IS NOT NULL AND status IN ('queued', 'running')
+		DO NOTHING
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(q.QueryRowContext(ctx, query,
+		tenantID, input.Type, payload, input.Priority, maxAttempts, runAt, dedupKey, input.CreatedBy,
+	))
+	if err == nil {
+		return job, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	// RLS policy automatically filters by tenant_id
+	job, err = scanJob(q.QueryRowContext(ctx,
+		`SELECT `+jobColumns+` FROM jobs WHERE type = $1 AND dedup_key = $2 AND status IN ('queued', 'running')`,
+		input.Type, input.DedupKey))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get deduplicated job: %w", err)
+	}
+	return job, false, nil
+}
+
+// Claim marks up to limit queued jobs of a type due by now as running by holder, highest
+// priority first, and returns them. Jobs locked by another instance are skipped.
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) Claim(ctx context.Context, jobType, holder string, limit int) ([]*models.Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running',
+		    attempts = attempts + 1,
+		    locked_by = $2,
+		    locked_at = now(),
+		    started_at = now(),
+		    progress_done = 0,
+		    progress_total = 0
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE type = $1
+			  AND status = 'queued'
+			  AND run_at <= now()
+			ORDER BY priority DESC, run_at ASC, id ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, jobType, holder, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim jobs: %w", err)
+	}
+	return scanJobs(rows)
+}
+
+// Complete marks a running job as succeeded with its JSON result, nil when it has none
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) Complete(ctx context.Context, id int64, result []byte) error {
+	var resultJSON interface{}
+	if len(result) > 0 {
+		resultJSON = result
+	}
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE jobs
+		SET status = 'succeeded', finished_at = now(), locked_by = NULL, locked_at = NULL, last_error = NULL, result = $2
+		WHERE id = $1 AND status = 'running'`, id, resultJSON)
+	if err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// SetProgress records how many items of a running job are done
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) SetProgress(ctx context.Context, id int64, done, total int) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE jobs SET progress_done = $2, progress_total = $3
+		WHERE id = $1 AND status = 'running'`, id, done, total)
+	if err != nil {
+		return fmt.Errorf("failed to record job progress: %w", err)
+	}
+	return nil
+}
+
+// Fail records the error of a run. With a retry time the job is queued again for then,
+// otherwise it is failed for good.
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) Fail(ctx context.Context, id int64, errMsg string, retryAt *time.Time) error {
+	var err error
+	q := dbctx.GetQuerier(ctx, r.db)
+	if retryAt != nil {
+		_, err = q.ExecContext(ctx, `
+			UPDATE jobs
+			SET status = 'queued', run_at = $3, last_error = $2, locked_by = NULL, locked_at = NULL
+			WHERE id = $1 AND status = 'running'`, id, errMsg, *retryAt)
+	} else {
+		_, err = q.ExecContext(ctx, `
+			UPDATE jobs
+			SET status = 'failed', finished_at = now(), last_error = $2, locked_by = NULL, locked_at = NULL
+			WHERE id = $1 AND status = 'running'`, id, errMsg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record job failure: %w", err)
+	}
+	return nil
+}
+
+// RecoverStale requeues the jobs locked before lockedBefore, left running by a lost instance.
+// Jobs without attempts left are failed.
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) RecoverStale(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN 'failed' ELSE 'queued' END,
+		    finished_at = CASE WHEN attempts >= max_attempts THEN now() ELSE NULL END,
+		    run_at = now(),
+		    last_error = 'worker lost while running the job',
+		    locked_by = NULL,
+		    locked_at = NULL
+		WHERE status = 'running' AND locked_at < $1`, lockedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recover stale jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetByID returns a job by ID
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) GetByID(ctx context.Context, id int64) (*models.Job, error) {
+	job, err := scanJob(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job, nil
+}
+
+// List returns the jobs matching the filter, newest first
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) List(ctx context.Context, filter models.JobFilter) ([]*models.Job, error) {
+	query := `
+		SELECT ` + jobColumns + ` FROM jobs
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR type = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, string(filter.Status), filter.Type, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	return scanJobs(rows)
+}
+
+// CountByType counts the jobs of each type by status
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) CountByType(ctx context.Context) ([]models.JobCounts, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, `
+		SELECT type,
+		       COUNT(*) FILTER (WHERE status = 'queued'),
+		       COUNT(*) FILTER (WHERE status = 'running'),
+		       COUNT(*) FILTER (WHERE status = 'succeeded'),
+		       COUNT(*) FILTER (WHERE status = 'failed'),
+		       COUNT(*) FILTER (WHERE status = 'cancelled')
+		FROM jobs
+		GROUP BY type
+		ORDER BY type`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []models.JobCounts
+	for rows.Next() {
+		var c models.JobCounts
+		if err := rows.Scan(&c.Type, &c.Queued, &c.Running, &c.Succeeded, &c.Failed, &c.Cancelled); err != nil {
+			return nil, fmt.Errorf("failed to scan job counts: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// SetStatus moves a job from one of the from statuses to status: a retry queues it again with
+// fresh attempts, a cancellation finishes it. Returns false when the job is in another status.
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) SetStatus(ctx context.Context, id int64, from []models.JobStatus, status models.JobStatus) (*models.Job, error) {
+	fromStatuses := make([]string, len(from))
+	for i, s := range from {
+		fromStatuses[i] = string(s)
+	}
+
+	query := `
+		UPDATE jobs
+		SET status = $3,
+		    attempts = CASE WHEN $3 = 'queued' THEN 0 ELSE attempts END,
+		    run_at = CASE WHEN $3 = 'queued' THEN now() ELSE run_at END,
+		    finished_at = CASE WHEN $3 = 'queued' THEN NULL ELSE now() END
+		WHERE id = $1 AND status = ANY($2)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id, pq.Array(fromStatuses), string(status)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update job status: %w", err)
+	}
+	return job, nil
+}
+
+// DeleteFinishedBefore removes the jobs succeeded or cancelled before doneBefore and the jobs
+// failed before failedBefore
+// RLS policy automatically filters by tenant_id
+func (r *JobRepository) DeleteFinishedBefore(ctx context.Context, doneBefore, failedBefore time.Time) (int64, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM jobs
+		WHERE (status IN ('succeeded', 'cancelled') AND finished_at < $1)
+		   OR (status = 'failed' AND finished_at < $2)`, doneBefore, failedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
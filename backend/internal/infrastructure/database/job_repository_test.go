@@ -0,0 +1,206 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestJobRepository_EnqueueAndClaim(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	low, created, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", Payload: map[string]string{"n": "low"}})
+	if err != nil || !created {
+		t.Fatalf("enqueue err: %v (created=%v)", err, created)
+	}
+	high, _, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", Payload: map[string]string{"n": "high"}, Priority: 10})
+	if err != nil {
+		t.Fatalf("enqueue err: %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	if _, _, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", RunAt: &later}); err != nil {
+		t.Fatalf("enqueue err: %v", err)
+	}
+	if low.Status != models.JobQueued || low.MaxAttempts != models.DefaultJobMaxAttempts {
+		t.Errorf("unexpected job: %+v", low)
+	}
+
+	claimed, err := repo.Claim(ctx, "test.job", "instance-1", 1)
+	if err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != high.ID {
+		t.Fatalf("expected the high priority job to be claimed first, got %+v", claimed)
+	}
+	if claimed[0].Status != models.JobRunning || claimed[0].Attempts != 1 || claimed[0].LockedBy != "instance-1" {
+		t.Errorf("unexpected claimed job: %+v", claimed[0])
+	}
+
+	claimed, err = repo.Claim(ctx, "test.job", "instance-1", 10)
+	if err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != low.ID {
+		t.Errorf("expected the scheduled job not to be claimed, got %d jobs", len(claimed))
+	}
+}
+
+func TestJobRepository_Dedup(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	first, created, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", DedupKey: "doc-1"})
+	if err != nil || !created {
+		t.Fatalf("enqueue err: %v (created=%v)", err, created)
+	}
+	second, created, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", DedupKey: "doc-1"})
+	if err != nil {
+		t.Fatalf("enqueue duplicate err: %v", err)
+	}
+	if created || second.ID != first.ID {
+		t.Errorf("expected the queued job to be returned, got %+v", second)
+	}
+
+	if _, err := repo.Claim(ctx, "test.job", "instance-1", 1); err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+	if err := repo.Complete(ctx, first.ID, nil); err != nil {
+		t.Fatalf("complete err: %v", err)
+	}
+	if _, created, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", DedupKey: "doc-1"}); err != nil || !created {
+		t.Errorf("expected a new job once the previous one finished, err=%v created=%v", err, created)
+	}
+}
+
+func TestJobRepository_ProgressAndResult(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	job, _, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job"})
+	if err != nil {
+		t.Fatalf("enqueue err: %v", err)
+	}
+	if _, err := repo.Claim(ctx, "test.job", "instance-1", 1); err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+	if err := repo.SetProgress(ctx, job.ID, 3, 10); err != nil {
+		t.Fatalf("set progress err: %v", err)
+	}
+	job, err = repo.GetByID(ctx, job.ID)
+	if err != nil || job.Progress == nil || job.Progress.Done != 3 || job.Progress.Total != 10 || job.Result != nil {
+		t.Fatalf("unexpected running job: %+v (err %v)", job, err)
+	}
+
+	if err := repo.Complete(ctx, job.ID, []byte(`{"documents":10}`)); err != nil {
+		t.Fatalf("complete err: %v", err)
+	}
+	job, err = repo.GetByID(ctx, job.ID)
+	if err != nil || job.Status != models.JobSucceeded || string(job.Result) != `{"documents": 10}` {
+		t.Fatalf("unexpected succeeded job: %+v (err %v)", job, err)
+	}
+}
+
+func TestJobRepository_FailAndRetry(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	job, _, err := repo.Enqueue(ctx, models.JobInput{Type: "test.job", MaxAttempts: 2})
+	if err != nil {
+		t.Fatalf("enqueue err: %v", err)
+	}
+	if _, err := repo.Claim(ctx, "test.job", "instance-1", 1); err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+
+	retryAt := time.Now().Add(time.Minute)
+	if err := repo.Fail(ctx, job.ID, "boom", &retryAt); err != nil {
+		t.Fatalf("fail err: %v", err)
+	}
+	job, err = repo.GetByID(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("get err: %v", err)
+	}
+	if job.Status != models.JobQueued || job.LastError != "boom" || job.LockedBy != "" {
+		t.Errorf("expected the job to be queued for a retry, got %+v", job)
+	}
+
+	if _, err := repo.SetStatus(ctx, job.ID, []models.JobStatus{models.JobQueued}, models.JobCancelled); err != nil {
+		t.Fatalf("cancel err: %v", err)
+	}
+	job, err = repo.SetStatus(ctx, job.ID, []models.JobStatus{models.JobQueued}, models.JobCancelled)
+	if err != nil || job != nil {
+		t.Errorf("expected a cancelled job not to be cancelled again, got %+v (%v)", job, err)
+	}
+
+	if _, err := repo.GetByID(ctx, 999999); !errors.Is(err, models.ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+}
+
+func TestJobRepository_RecoverStale(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewJobRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	retried, _, _ := repo.Enqueue(ctx, models.JobInput{Type: "test.job", MaxAttempts: 2})
+	exhausted, _, _ := repo.Enqueue(ctx, models.JobInput{Type: "test.once", MaxAttempts: 1})
+	if _, err := repo.Claim(ctx, "test.job", "instance-1", 1); err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+	if _, err := repo.Claim(ctx, "test.once", "instance-1", 1); err != nil {
+		t.Fatalf("claim err: %v", err)
+	}
+
+	recovered, err := repo.RecoverStale(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("recover err: %v", err)
+	}
+	if recovered != 2 {
+		t.Errorf("expected 2 stale jobs, got %d", recovered)
+	}
+
+	job, _ := repo.GetByID(ctx, retried.ID)
+	if job.Status != models.JobQueued {
+		t.Errorf("expected the job to be queued again, got %s", job.Status)
+	}
+	job, _ = repo.GetByID(ctx, exhausted.ID)
+	if job.Status != models.JobFailed {
+		t.Errorf("expected the job without attempts left to fail, got %s", job.Status)
+	}
+
+	counts, err := repo.CountByType(ctx)
+	if err != nil {
+		t.Fatalf("count err: %v", err)
+	}
+	if len(counts) != 2 || counts[0].Type != "test.job" || counts[0].Queued != 1 || counts[1].Failed != 1 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	failed, err := repo.List(ctx, models.JobFilter{Status: models.JobFailed, Limit: 10})
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	if len(failed) != 1 || failed[0].ID != exhausted.ID {
+		t.Errorf("expected the failed job to be listed, got %+v", failed)
+	}
+
+	deleted, err := repo.DeleteFinishedBefore(ctx, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected the failed job to be deleted, got %d", deleted)
+	}
+}
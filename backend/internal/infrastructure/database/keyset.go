@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// keysetClause returns the condition selecting the rows past cursor and the ORDER BY clause
+// traversing them, for a list ordered newest first by timeColumn then keyColumn.
+// Backward cursors walk the list in reverse, oldest first. Placeholders start at $next.
+func keysetClause(timeColumn, keyColumn string, cursor *models.PageCursor, key interface{}, next int) (string, string, []interface{}) {
+	if cursor == nil {
+		return "TRUE", fmt.Sprintf("%s DESC, %s DESC", timeColumn, keyColumn), nil
+	}
+	if cursor.Backward {
+		return fmt.Sprintf("(%s, %s) > ($%d, $%d)", timeColumn, keyColumn, next, next+1),
+			fmt.Sprintf("%s ASC, %s ASC", timeColumn, keyColumn),
+			[]interface{}{cursor.At, key}
+	}
+	return fmt.Sprintf("(%s, %s) < ($%d, $%d)", timeColumn, keyColumn, next, next+1),
+		fmt.Sprintf("%s DESC, %s DESC", timeColumn, keyColumn),
+		[]interface{}{cursor.At, key}
+}
+
+// cursorID returns the numeric key of a cursor over rows identified by a BIGSERIAL
+func cursorID(cursor *models.PageCursor) (int64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(cursor.Key, 10, 64)
+	if err != nil {
+		return 0, models.ErrInvalidCursor
+	}
+	return id, nil
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const linkSourceColumns = `tenant_id, doc_id, provider, url, title, version, content_hash, modified_at,
+	previous_version, changed_at, last_checked_at, notified_at, COALESCE(last_error, ''), created_at, updated_at`
+
+// LinkSourceRepository handles database operations for the Confluence pages, SharePoint and Google Drive files documents link to
+type LinkSourceRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewLinkSourceRepository creates a new link source repository
+func NewLinkSourceRepository(db *sql.DB, tenants providers.TenantProvider) *LinkSourceRepository {
+	return &LinkSourceRepository{db: db, tenants: tenants}
+}
+
+func scanLinkSource(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.DocumentLinkSource, error) {
+	s := &models.DocumentLinkSource{}
+	var modifiedAt, changedAt, notifiedAt sql.NullTime
+	err := scanner.Scan(&s.TenantID, &s.DocID, &s.Provider, &s.URL, &s.Title, &s.Version, &s.ContentHash,
+		&modifiedAt, &s.PreviousVersion, &changedAt, &s.LastCheckedAt, &notifiedAt, &s.LastError, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if modifiedAt.Valid {
+		s.ModifiedAt = &modifiedAt.Time
+	}
+	if changedAt.Valid {
+		s.ChangedAt = &changedAt.Time
+	}
+	if notifiedAt.Valid {
+		s.NotifiedAt = &notifiedAt.Time
+	}
+	return s, nil
+}
+
+// Upsert records the metadata of the page a document links to, which clears a pending notification
+func (r *LinkSourceRepository) Upsert(ctx context.Context, source *models.DocumentLinkSource) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_link_sources (tenant_id, doc_id, provider, url, title, version, content_hash, modified_at,
+			previous_version, changed_at, last_checked_at, last_error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''))
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET provider = EXCLUDED.provider, url = EXCLUDED.url, title = EXCLUDED.title, version = EXCLUDED.version,
+			content_hash = EXCLUDED.content_hash, modified_at = EXCLUDED.modified_at,
+			previous_version = EXCLUDED.previous_version, changed_at = EXCLUDED.changed_at,
+			last_checked_at = EXCLUDED.last_checked_at, last_error = EXCLUDED.last_error, notified_at = NULL,
+			updated_at = now()`
+
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query,
+		tenantID, source.DocID, source.Provider, source.URL, source.Title, source.Version, source.ContentHash,
+		source.ModifiedAt, source.PreviousVersion, source.ChangedAt, source.LastCheckedAt, source.LastError,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record document link source: %w", err)
+	}
+	return nil
+}
+
+// GetByDocID retrieves the link source of a document
+// RLS policy automatically filters by tenant_id
+func (r *LinkSourceRepository) GetByDocID(ctx context.Context, docID string) (*models.DocumentLinkSource, error) {
+	query := `SELECT ` + linkSourceColumns + ` FROM document_link_sources WHERE doc_id = $1`
+
+	s, err := scanLinkSource(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrLinkSourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document link source: %w", err)
+	}
+	return s, nil
+}
+
+// ListDue retrieves the link sources notified of a change, then those last checked before
+// the given time, oldest first
+// RLS policy automatically filters by tenant_id
+func (r *LinkSourceRepository) ListDue(ctx context.Context, checkedBefore time.Time, limit int) ([]*models.DocumentLinkSource, error) {
+	query := `SELECT ` + linkSourceColumns + ` FROM document_link_sources
+		WHERE last_checked_at < $1 OR notified_at IS NOT NULL
+		ORDER BY notified_at ASC NULLS LAST, last_checked_at ASC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document link sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*models.DocumentLinkSource
+	for rows.Next() {
+		s, err := scanLinkSource(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document link source: %w", err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
+
+// MarkNotified marks the link source of a document to be checked at the next worker run
+// RLS policy automatically filters by tenant_id
+func (r *LinkSourceRepository) MarkNotified(ctx context.Context, docID string, at time.Time) error {
+	query := `UPDATE document_link_sources SET notified_at = COALESCE(notified_at, $2) WHERE doc_id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, docID, at); err != nil {
+		return fmt.Errorf("failed to mark document link source notified: %w", err)
+	}
+	return nil
+}
+
+// Delete stops tracking the link source of a document
+// RLS policy automatically filters by tenant_id
+func (r *LinkSourceRepository) Delete(ctx context.Context, docID string) error {
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_link_sources WHERE doc_id = $1`, docID); err != nil {
+		return fmt.Errorf("failed to delete document link source: %w", err)
+	}
+	return nil
+}
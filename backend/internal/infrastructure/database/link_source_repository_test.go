@@ -0,0 +1,107 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestLinkSourceRepository_UpsertAndListDue(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewLinkSourceRepository(testDB.DB, testDB.TenantProvider)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if _, err := docRepo.Create(ctx, "link-doc-1", models.DocumentInput{Title: "Security", URL: "https://acme.atlassian.net/wiki/spaces/SEC/pages/42"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	if _, err := repo.GetByDocID(ctx, "link-doc-1"); !errors.Is(err, models.ErrLinkSourceNotFound) {
+		t.Fatalf("expected ErrLinkSourceNotFound, got %v", err)
+	}
+
+	checked := time.Now().Add(-2 * time.Hour)
+	source := &models.DocumentLinkSource{
+		DocID: "link-doc-1",
+		URL:   "https://acme.atlassian.net/wiki/spaces/SEC/pages/42",
+		LinkMetadata: models.LinkMetadata{
+			Provider: models.LinkProviderConfluence, Title: "Security", Version: "3", ContentHash: "aaa",
+		},
+		LastCheckedAt: checked,
+	}
+	if err := repo.Upsert(ctx, source); err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+
+	due, err := repo.ListDue(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil || len(due) != 1 || due[0].Version != "3" {
+		t.Fatalf("expected the source to be due, got %v, %v", due, err)
+	}
+
+	changed := time.Now()
+	source.PreviousVersion, source.Version, source.ContentHash = "3", "4", "bbb"
+	source.ChangedAt, source.LastCheckedAt = &changed, changed
+	if err := repo.Upsert(ctx, source); err != nil {
+		t.Fatalf("second upsert err: %v", err)
+	}
+	got, err := repo.GetByDocID(ctx, "link-doc-1")
+	if err != nil || got.Version != "4" || got.PreviousVersion != "3" || got.ChangedAt == nil || got.LastError != "" {
+		t.Fatalf("unexpected source %+v, %v", got, err)
+	}
+	if due, _ := repo.ListDue(ctx, time.Now().Add(-time.Hour), 10); len(due) != 0 {
+		t.Fatalf("expected no due source after a check, got %v", due)
+	}
+
+	if err := repo.Delete(ctx, "link-doc-1"); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, err := repo.GetByDocID(ctx, "link-doc-1"); !errors.Is(err, models.ErrLinkSourceNotFound) {
+		t.Fatalf("expected the source deleted, got %v", err)
+	}
+}
+
+func TestLinkSourceRepository_MarkNotified(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewLinkSourceRepository(testDB.DB, testDB.TenantProvider)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if _, err := docRepo.Create(ctx, "link-doc-2", models.DocumentInput{Title: "Policy", URL: "https://docs.google.com/document/d/1AbCdEfGhIjKlMnOp/edit"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	source := &models.DocumentLinkSource{
+		DocID:         "link-doc-2",
+		URL:           "https://docs.google.com/document/d/1AbCdEfGhIjKlMnOp/edit",
+		LinkMetadata:  models.LinkMetadata{Provider: models.LinkProviderGoogleDrive, Title: "Policy", Version: "1", ContentHash: "aaa"},
+		LastCheckedAt: time.Now(),
+	}
+	if err := repo.Upsert(ctx, source); err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	if due, _ := repo.ListDue(ctx, time.Now().Add(-time.Hour), 10); len(due) != 0 {
+		t.Fatalf("expected no due source, got %v", due)
+	}
+
+	if err := repo.MarkNotified(ctx, "link-doc-2", time.Now()); err != nil {
+		t.Fatalf("mark notified err: %v", err)
+	}
+	due, err := repo.ListDue(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil || len(due) != 1 || due[0].NotifiedAt == nil {
+		t.Fatalf("expected the notified source due, got %v, %v", due, err)
+	}
+
+	// Recording the check clears the notification
+	if err := repo.Upsert(ctx, source); err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	if due, _ := repo.ListDue(ctx, time.Now().Add(-time.Hour), 10); len(due) != 0 {
+		t.Fatalf("expected the notification cleared, got %v", due)
+	}
+}
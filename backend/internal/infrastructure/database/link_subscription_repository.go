@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const linkSubscriptionColumns = `tenant_id, doc_id, provider, subscription_id, resource_id, client_state, expires_at, created_at, updated_at`
+
+// LinkSubscriptionRepository handles database operations for the change notification subscriptions
+// of the SharePoint and Google Drive files documents link to
+type LinkSubscriptionRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewLinkSubscriptionRepository creates a new link subscription repository
+func NewLinkSubscriptionRepository(db *sql.DB, tenants providers.TenantProvider) *LinkSubscriptionRepository {
+	return &LinkSubscriptionRepository{db: db, tenants: tenants}
+}
+
+func scanLinkSubscription(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.LinkSubscription, error) {
+	s := &models.LinkSubscription{}
+	err := scanner.Scan(&s.TenantID, &s.DocID, &s.Provider, &s.SubscriptionID, &s.ResourceID, &s.ClientState,
+		&s.ExpiresAt, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Upsert records the subscription of a document, replacing the previous one
+func (r *LinkSubscriptionRepository) Upsert(ctx context.Context, sub *models.LinkSubscription) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_link_subscriptions (tenant_id, doc_id, provider, subscription_id, resource_id, client_state, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET provider = EXCLUDED.provider, subscription_id = EXCLUDED.subscription_id, resource_id = EXCLUDED.resource_id,
+			client_state = EXCLUDED.client_state, expires_at = EXCLUDED.expires_at, updated_at = now()`
+
+	_, err = dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query,
+		tenantID, sub.DocID, sub.Provider, sub.SubscriptionID, sub.ResourceID, sub.ClientState, sub.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record document link subscription: %w", err)
+	}
+	return nil
+}
+
+// GetByDocID retrieves the subscription of a document
+// RLS policy automatically filters by tenant_id
+func (r *LinkSubscriptionRepository) GetByDocID(ctx context.Context, docID string) (*models.LinkSubscription, error) {
+	query := `SELECT ` + linkSubscriptionColumns + ` FROM document_link_subscriptions WHERE doc_id = $1`
+
+	s, err := scanLinkSubscription(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document link subscription: %w", err)
+	}
+	return s, nil
+}
+
+// GetBySubscriptionID retrieves the subscription a notification of the provider refers to
+// RLS policy automatically filters by tenant_id
+func (r *LinkSubscriptionRepository) GetBySubscriptionID(ctx context.Context, provider, subscriptionID string) (*models.LinkSubscription, error) {
+	query := `SELECT ` + linkSubscriptionColumns + ` FROM document_link_subscriptions WHERE provider = $1 AND subscription_id = $2`
+
+	s, err := scanLinkSubscription(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, provider, subscriptionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document link subscription: %w", err)
+	}
+	return s, nil
+}
+
+// ListExpiring retrieves the subscriptions expiring before the given time, soonest first
+// RLS policy automatically filters by tenant_id
+func (r *LinkSubscriptionRepository) ListExpiring(ctx context.Context, before time.Time, limit int) ([]*models.LinkSubscription, error) {
+	query := `SELECT ` + linkSubscriptionColumns + ` FROM document_link_subscriptions
+		WHERE expires_at < $1
+		ORDER BY expires_at ASC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document link subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []*models.LinkSubscription
+	for rows.Next() {
+		s, err := scanLinkSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document link subscription: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// Delete removes the subscription of a document
+// RLS policy automatically filters by tenant_id
+func (r *LinkSubscriptionRepository) Delete(ctx context.Context, docID string) error {
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_link_subscriptions WHERE doc_id = $1`, docID); err != nil {
+		return fmt.Errorf("failed to delete document link subscription: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestLinkSubscriptionRepository_Lifecycle(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewLinkSubscriptionRepository(testDB.DB, testDB.TenantProvider)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if _, err := docRepo.Create(ctx, "sub-doc-1", models.DocumentInput{Title: "Policy", URL: "https://acme.sharepoint.com/sites/hr/Policy.docx"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if _, err := repo.GetByDocID(ctx, "sub-doc-1"); !errors.Is(err, models.ErrSubscriptionNotFound) {
+		t.Fatalf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+
+	sub := &models.LinkSubscription{
+		DocID: "sub-doc-1", Provider: models.LinkProviderSharePoint, SubscriptionID: "sub-1",
+		ClientState: "state-1", ExpiresAt: time.Now().Add(time.Hour),
+	}
+	if err := repo.Upsert(ctx, sub); err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+
+	got, err := repo.GetBySubscriptionID(ctx, models.LinkProviderSharePoint, "sub-1")
+	if err != nil || got.DocID != "sub-doc-1" || got.ClientState != "state-1" {
+		t.Fatalf("unexpected subscription %+v, %v", got, err)
+	}
+	if _, err := repo.GetBySubscriptionID(ctx, models.LinkProviderGoogleDrive, "sub-1"); !errors.Is(err, models.ErrSubscriptionNotFound) {
+		t.Fatalf("expected the subscription scoped to its provider, got %v", err)
+	}
+
+	expiring, err := repo.ListExpiring(ctx, time.Now().Add(2*time.Hour), 10)
+	if err != nil || len(expiring) != 1 {
+		t.Fatalf("expected the subscription to expire, got %v, %v", expiring, err)
+	}
+
+	sub.SubscriptionID, sub.ExpiresAt = "sub-2", time.Now().Add(72*time.Hour)
+	if err := repo.Upsert(ctx, sub); err != nil {
+		t.Fatalf("renew err: %v", err)
+	}
+	if expiring, _ := repo.ListExpiring(ctx, time.Now().Add(2*time.Hour), 10); len(expiring) != 0 {
+		t.Fatalf("expected no expiring subscription after renewal, got %v", expiring)
+	}
+	if _, err := repo.GetBySubscriptionID(ctx, models.LinkProviderSharePoint, "sub-1"); !errors.Is(err, models.ErrSubscriptionNotFound) {
+		t.Fatalf("expected the previous subscription replaced, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, "sub-doc-1"); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, err := repo.GetByDocID(ctx, "sub-doc-1"); !errors.Is(err, models.ErrSubscriptionNotFound) {
+		t.Fatalf("expected the subscription deleted, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// MerkleRepository stores Merkle roots over batches of signatures and their leaves
+type MerkleRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewMerkleRepository creates a new Merkle root repository
+func NewMerkleRepository(db *sql.DB, tenants providers.TenantProvider) *MerkleRepository {
+	return &MerkleRepository{db: db, tenants: tenants}
+}
+
+const merkleRootColumns = `id, tenant_id, root_hash, first_signature_id, last_signature_id, leaf_count,
+	created_at, anchored_at, COALESCE(anchor_ref, ''), COALESCE(anchor_error, '')`
+
+func scanMerkleRoot(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.MerkleRoot, error) {
+	root := &models.MerkleRoot{}
+	var anchoredAt sql.NullTime
+	err := scanner.Scan(&root.ID, &root.TenantID, &root.RootHash, &root.FirstSignatureID, &root.LastSignatureID,
+		&root.LeafCount, &root.CreatedAt, &anchoredAt, &root.AnchorRef, &root.AnchorError)
+	if err != nil {
+		return nil, err
+	}
+	if anchoredAt.Valid {
+		root.AnchoredAt = &anchoredAt.Time
+	}
+	return root, nil
+}
+
+// Create stores a root and its leaves, filling the root ID and creation time
+func (r *MerkleRepository) Create(ctx context.Context, root *models.MerkleRoot, leaves []models.MerkleLeaf) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	err = q.QueryRowContext(ctx, `
+		INSERT INTO merkle_roots (tenant_id, root_hash, first_signature_id, last_signature_id, leaf_count)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		tenantID, root.RootHash, root.FirstSignatureID, root.LastSignatureID, root.LeafCount,
+	).Scan(&root.ID, &root.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create merkle root: %w", err)
+	}
+	root.TenantID = tenantID
+
+	for _, leaf := range leaves {
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO merkle_leaves (root_id, tenant_id, signature_id, leaf_index, record_hash)
+			VALUES ($1, $2, $3, $4, $5)`,
+			root.ID, tenantID, leaf.SignatureID, leaf.LeafIndex, leaf.RecordHash)
+		if err != nil {
+			return fmt.Errorf("failed to create merkle leaf: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetLatest returns the most recent root, or nil when none was computed yet
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) GetLatest(ctx context.Context) (*models.MerkleRoot, error) {
+	query := `SELECT ` + merkleRootColumns + ` FROM merkle_roots ORDER BY last_signature_id DESC LIMIT 1`
+
+	root, err := scanMerkleRoot(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest merkle root: %w", err)
+	}
+	return root, nil
+}
+
+// GetByID returns a root, or nil when not found
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) GetByID(ctx context.Context, id int64) (*models.MerkleRoot, error) {
+	query := `SELECT ` + merkleRootColumns + ` FROM merkle_roots WHERE id = $1`
+
+	root, err := scanMerkleRoot(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get merkle root: %w", err)
+	}
+	return root, nil
+}
+
+// List returns the most recent roots, newest first
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) List(ctx context.Context, limit int) ([]*models.MerkleRoot, error) {
+	query := `SELECT ` + merkleRootColumns + ` FROM merkle_roots ORDER BY last_signature_id DESC LIMIT $1`
+	return r.list(ctx, query, limit)
+}
+
+// ListUnanchored returns the roots not yet published to the anchor, oldest first
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) ListUnanchored(ctx context.Context, limit int) ([]*models.MerkleRoot, error) {
+	query := `SELECT ` + merkleRootColumns + ` FROM merkle_roots WHERE anchored_at IS NULL ORDER BY id ASC LIMIT $1`
+	return r.list(ctx, query, limit)
+}
+
+func (r *MerkleRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.MerkleRoot, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merkle roots: %w", err)
+	}
+	defer rows.Close()
+
+	var roots []*models.MerkleRoot
+	for rows.Next() {
+		root, err := scanMerkleRoot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan merkle root: %w", err)
+		}
+		roots = append(roots, root)
+	}
+	return roots, rows.Err()
+}
+
+// MarkAnchored records where a root was published
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) MarkAnchored(ctx context.Context, id int64, ref string) error {
+	query := `UPDATE merkle_roots SET anchored_at = now(), anchor_ref = $2, anchor_error = NULL WHERE id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, ref); err != nil {
+		return fmt.Errorf("failed to mark merkle root anchored: %w", err)
+	}
+	return nil
+}
+
+// MarkAnchorFailed records the last publication error of a root, which is retried later
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) MarkAnchorFailed(ctx context.Context, id int64, message string) error {
+	query := `UPDATE merkle_roots SET anchor_error = $2 WHERE id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, message); err != nil {
+		return fmt.Errorf("failed to record merkle anchor error: %w", err)
+	}
+	return nil
+}
+
+// FindLeaf returns the leaf of a signature, or nil when the signature is not included in a root yet
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) FindLeaf(ctx context.Context, signatureID int64) (*models.MerkleLeaf, error) {
+	query := `SELECT root_id, signature_id, leaf_index, record_hash FROM merkle_leaves WHERE signature_id = $1`
+
+	leaf := &models.MerkleLeaf{}
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, signatureID).
+		Scan(&leaf.RootID, &leaf.SignatureID, &leaf.LeafIndex, &leaf.RecordHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find merkle leaf: %w", err)
+	}
+	return leaf, nil
+}
+
+// ListLeaves returns the leaves of a root, by index
+// RLS policy automatically filters by tenant_id
+func (r *MerkleRepository) ListLeaves(ctx context.Context, rootID int64) ([]models.MerkleLeaf, error) {
+	query := `SELECT root_id, signature_id, leaf_index, record_hash FROM merkle_leaves WHERE root_id = $1 ORDER BY leaf_index ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, rootID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merkle leaves: %w", err)
+	}
+	defer rows.Close()
+
+	var leaves []models.MerkleLeaf
+	for rows.Next() {
+		var leaf models.MerkleLeaf
+		if err := rows.Scan(&leaf.RootID, &leaf.SignatureID, &leaf.LeafIndex, &leaf.RecordHash); err != nil {
+			return nil, fmt.Errorf("failed to scan merkle leaf: %w", err)
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves, rows.Err()
+}
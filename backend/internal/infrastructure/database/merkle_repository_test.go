@@ -0,0 +1,94 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestMerkleRepository_RootsAndLeaves(t *testing.T) {
+	testDB := SetupTestDB(t)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewMerkleRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	factory := NewSignatureFactory()
+
+	for i := 0; i < 3; i++ {
+		sig := factory.CreateSignatureWithUser(fmt.Sprintf("user-%d", i), fmt.Sprintf("user-%d@example.com", i))
+		if err := sigRepo.Create(ctx, sig); err != nil {
+			t.Fatalf("create signature err: %v", err)
+		}
+	}
+
+	latest, err := repo.GetLatest(ctx)
+	if err != nil || latest != nil {
+		t.Fatalf("expected no root yet, got %v, %v", latest, err)
+	}
+
+	signatures, err := sigRepo.ListAfterID(ctx, 0, time.Now().Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("list signatures err: %v", err)
+	}
+	if len(signatures) != 3 {
+		t.Fatalf("expected 3 signatures, got %d", len(signatures))
+	}
+
+	leaves := make([]models.MerkleLeaf, len(signatures))
+	for i, sig := range signatures {
+		leaves[i] = models.MerkleLeaf{SignatureID: sig.ID, LeafIndex: i, RecordHash: sig.ComputeRecordHash()}
+	}
+	root := &models.MerkleRoot{
+		RootHash:         "cm9vdA==",
+		FirstSignatureID: signatures[0].ID,
+		LastSignatureID:  signatures[2].ID,
+		LeafCount:        3,
+	}
+	if err := repo.Create(ctx, root, leaves); err != nil {
+		t.Fatalf("create root err: %v", err)
+	}
+
+	latest, err = repo.GetLatest(ctx)
+	if err != nil || latest == nil || latest.ID != root.ID {
+		t.Fatalf("expected latest root %d, got %v, %v", root.ID, latest, err)
+	}
+
+	after, err := sigRepo.ListAfterID(ctx, root.LastSignatureID, time.Now().Add(time.Minute), 10)
+	if err != nil || len(after) != 0 {
+		t.Errorf("expected no signature after the root, got %d, %v", len(after), err)
+	}
+
+	leaf, err := repo.FindLeaf(ctx, signatures[1].ID)
+	if err != nil || leaf == nil || leaf.RootID != root.ID || leaf.LeafIndex != 1 {
+		t.Fatalf("unexpected leaf %+v, %v", leaf, err)
+	}
+	stored, err := repo.ListLeaves(ctx, root.ID)
+	if err != nil || len(stored) != 3 || stored[2].RecordHash != leaves[2].RecordHash {
+		t.Errorf("unexpected leaves %+v, %v", stored, err)
+	}
+
+	unanchored, err := repo.ListUnanchored(ctx, 10)
+	if err != nil || len(unanchored) != 1 {
+		t.Fatalf("expected 1 unanchored root, got %d, %v", len(unanchored), err)
+	}
+	if err := repo.MarkAnchorFailed(ctx, root.ID, "endpoint down"); err != nil {
+		t.Fatalf("mark failed err: %v", err)
+	}
+	if err := repo.MarkAnchored(ctx, root.ID, "s3:merkle-roots/1.json"); err != nil {
+		t.Fatalf("mark anchored err: %v", err)
+	}
+	got, err := repo.GetByID(ctx, root.ID)
+	if err != nil || got.AnchoredAt == nil || got.AnchorRef != "s3:merkle-roots/1.json" || got.AnchorError != "" {
+		t.Errorf("unexpected anchored root %+v, %v", got, err)
+	}
+	unanchored, _ = repo.ListUnanchored(ctx, 10)
+	if len(unanchored) != 0 {
+		t.Errorf("expected no unanchored root, got %d", len(unanchored))
+	}
+}
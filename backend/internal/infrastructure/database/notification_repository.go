@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const notificationColumns = `id, tenant_id, recipient_email, type, COALESCE(doc_id, ''), data, read_at, created_at`
+
+// NotificationRepository handles database operations for user notification inboxes
+type NotificationRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *sql.DB, tenants providers.TenantProvider) *NotificationRepository {
+	return &NotificationRepository{db: db, tenants: tenants}
+}
+
+func scanNotification(row interface{ Scan(dest ...any) error }) (*models.Notification, error) {
+	n := &models.Notification{}
+	var data []byte
+	if err := row.Scan(&n.ID, &n.TenantID, &n.Recipient, &n.Type, &n.DocID, &data, &n.ReadAt, &n.CreatedAt); err != nil {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &n.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode notification data: %w", err)
+		}
+	}
+	return n, nil
+}
+
+// Create stores a notification in the recipient's inbox.
+// It returns nil when a notification with the same dedup key already exists.
+func (r *NotificationRepository) Create(ctx context.Context, input models.NotificationInput) (*models.Notification, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	data := []byte("{}")
+	if input.Data != nil {
+		data, err = json.Marshal(input.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal notification data: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO admin_notifications (tenant_id, recipient_email, type, doc_id, data, dedup_key)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, ''))
+		ON CONFLICT (tenant_id, recipient_email, dedup_key) WHERE dedup_key IS NOT NULL DO NOTHING
+		RETURNING ` + notificationColumns
+
+	n, err := scanNotification(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, strings.ToLower(input.Recipient), input.Type, input.DocID, data, input.DedupKey,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+	return n, nil
+}
+
+// ListByRecipient returns the recipient's notifications, newest first
+// RLS policy automatically filters by tenant_id
+func (r *NotificationRepository) ListByRecipient(ctx context.Context, recipient string, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM admin_notifications
+		WHERE recipient_email = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, strings.ToLower(recipient), unreadOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*models.Notification{}
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// CountByRecipient returns the number of notifications of the recipient
+// RLS policy automatically filters by tenant_id
+func (r *NotificationRepository) CountByRecipient(ctx context.Context, recipient string, unreadOnly bool) (int, error) {
+	query := `SELECT COUNT(*) FROM admin_notifications WHERE recipient_email = $1 AND ($2 = FALSE OR read_at IS NULL)`
+
+	var count int
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, strings.ToLower(recipient), unreadOnly).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks one of the recipient's notifications as read
+// RLS policy automatically filters by tenant_id
+func (r *NotificationRepository) MarkRead(ctx context.Context, recipient string, id int64, at time.Time) error {
+	query := `
+		UPDATE admin_notifications SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND recipient_email = $2
+	`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, strings.ToLower(recipient), at)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification of the recipient as read and returns how many changed
+// RLS policy automatically filters by tenant_id
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, recipient string, at time.Time) (int64, error) {
+	query := `UPDATE admin_notifications SET read_at = $2 WHERE recipient_email = $1 AND read_at IS NULL`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, strings.ToLower(recipient), at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return result.RowsAffected()
+}
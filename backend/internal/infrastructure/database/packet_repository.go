@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const packetColumns = `p.id, p.tenant_id, p.title, p.description,
+	COALESCE((SELECT array_agg(i.doc_id ORDER BY i.position) FROM document_packet_items i WHERE i.packet_id = p.id), '{}'),
+	p.created_by, p.created_at, p.updated_at`
+
+// PacketRepository handles database operations for document packets and their documents
+type PacketRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewPacketRepository creates a new packet repository
+func NewPacketRepository(db *sql.DB, tenants providers.TenantProvider) *PacketRepository {
+	return &PacketRepository{db: db, tenants: tenants}
+}
+
+func scanPacket(row interface{ Scan(dest ...any) error }) (*models.DocumentPacket, error) {
+	p := &models.DocumentPacket{}
+	err := row.Scan(&p.ID, &p.TenantID, &p.Title, &p.Description, pq.Array(&p.DocIDs), &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Create inserts a packet and its documents, in the given order
+func (r *PacketRepository) Create(ctx context.Context, input models.DocumentPacketInput, createdBy string) (*models.DocumentPacket, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	var id int64
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO document_packets (tenant_id, title, description, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`,
+		tenantID, input.Title, input.Description, createdBy,
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create packet: %w", err)
+	}
+
+	if err := r.insertItems(ctx, tenantID, id, input.DocIDs); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// Update modifies a packet and replaces its documents
+// RLS policy automatically filters by tenant_id
+func (r *PacketRepository) Update(ctx context.Context, id int64, input models.DocumentPacketInput) (*models.DocumentPacket, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	res, err := q.ExecContext(ctx, `
+		UPDATE document_packets SET title = $1, description = $2, updated_at = now()
+		WHERE id = $3`,
+		input.Title, input.Description, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update packet: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.ErrPacketNotFound
+	}
+
+	if _, err := q.ExecContext(ctx, `DELETE FROM document_packet_items WHERE packet_id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to clear packet documents: %w", err)
+	}
+	if err := r.insertItems(ctx, tenantID, id, input.DocIDs); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+func (r *PacketRepository) insertItems(ctx context.Context, tenantID uuid.UUID, packetID int64, docIDs []string) error {
+	query := `
+		INSERT INTO document_packet_items (tenant_id, packet_id, doc_id, position)
+		SELECT $1, $2, t.doc_id, t.position
+		FROM unnest($3::text[]) WITH ORDINALITY AS t(doc_id, position)`
+
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, tenantID, packetID, pq.Array(docIDs)); err != nil {
+		return fmt.Errorf("failed to add packet documents: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a packet. Its documents and their signatures are kept.
+// RLS policy automatically filters by tenant_id
+func (r *PacketRepository) Delete(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_packets WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete packet: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return models.ErrPacketNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a packet by its ID
+// RLS policy automatically filters by tenant_id
+func (r *PacketRepository) GetByID(ctx context.Context, id int64) (*models.DocumentPacket, error) {
+	query := `SELECT ` + packetColumns + ` FROM document_packets p WHERE p.id = $1`
+
+	p, err := scanPacket(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrPacketNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get packet: %w", err)
+	}
+	return p, nil
+}
+
+// List retrieves all packets, newest first
+// RLS policy automatically filters by tenant_id
+func (r *PacketRepository) List(ctx context.Context) ([]*models.DocumentPacket, error) {
+	query := `SELECT ` + packetColumns + ` FROM document_packets p ORDER BY p.created_at DESC, p.id DESC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packets: %w", err)
+	}
+	defer rows.Close()
+
+	var packets []*models.DocumentPacket
+	for rows.Next() {
+		p, err := scanPacket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan packet: %w", err)
+		}
+		packets = append(packets, p)
+	}
+	return packets, rows.Err()
+}
+
+// ListSignerDocuments returns the documents of a packet in order, with the signature of email if any
+func (r *PacketRepository) ListSignerDocuments(ctx context.Context, packetID int64, email string) ([]*models.PacketSignerDocument, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		SELECT i.doc_id, COALESCE(d.title, ''), COALESCE(d.url, ''), s.signed_at
+		FROM document_packet_items i
+		JOIN documents d ON d.tenant_id = i.tenant_id AND d.doc_id = i.doc_id
+		LEFT JOIN LATERAL (
+			SELECT signed_at FROM signatures
+			WHERE tenant_id = i.tenant_id AND doc_id = i.doc_id AND match_key = email_match_key($3, $1)
+			ORDER BY signed_at ASC
+			LIMIT 1
+		) s ON true
+		WHERE i.tenant_id = $1 AND i.packet_id = $2 AND d.deleted_at IS NULL
+		ORDER BY i.position ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, tenantID, packetID, strings.TrimSpace(email))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packet documents: %w", err)
+	}
+	defer rows.Close()
+
+	var documents []*models.PacketSignerDocument
+	for rows.Next() {
+		doc := &models.PacketSignerDocument{}
+		var signedAt sql.NullTime
+		if err := rows.Scan(&doc.DocID, &doc.Title, &doc.URL, &signedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan packet document: %w", err)
+		}
+		if signedAt.Valid {
+			doc.Signed = true
+			doc.SignedAt = &signedAt.Time
+		}
+		documents = append(documents, doc)
+	}
+	return documents, rows.Err()
+}
+
+// GetStats returns the signing progress of a packet, per signer and per document
+// RLS policy automatically filters by tenant_id
+func (r *PacketRepository) GetStats(ctx context.Context, packetID int64) (*models.PacketStats, error) {
+	q := dbctx.GetReadQuerier(ctx, r.db)
+	stats := &models.PacketStats{PacketID: packetID}
+
+	signerQuery := `
+		WITH progress AS (
+			SELECT es.match_key, COUNT(*) AS expected, COUNT(s.id) AS signed
+			FROM document_packet_items i
+			JOIN documents d ON d.tenant_id = i.tenant_id AND d.doc_id = i.doc_id AND d.deleted_at IS NULL
+			JOIN expected_signers es ON es.tenant_id = i.tenant_id AND es.doc_id = i.doc_id
+			LEFT JOIN LATERAL (
+				SELECT id FROM signatures
+				WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+				LIMIT 1
+			) s ON true
+			WHERE i.packet_id = $1
+			GROUP BY es.match_key
+		)
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE signed = expected),
+			COUNT(*) FILTER (WHERE signed > 0 AND signed < expected),
+			COUNT(*) FILTER (WHERE signed = 0)
+		FROM progress`
+
+	err := q.QueryRowContext(ctx, signerQuery, packetID).Scan(
+		&stats.SignerCount, &stats.CompletedCount, &stats.InProgressCount, &stats.NotStartedCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get packet signer stats: %w", err)
+	}
+	if stats.SignerCount > 0 {
+		stats.CompletionRate = float64(stats.CompletedCount) / float64(stats.SignerCount) * 100
+	}
+
+	documentQuery := `
+		SELECT i.doc_id, COALESCE(d.title, ''), COUNT(es.match_key), COUNT(s.id)
+		FROM document_packet_items i
+		JOIN documents d ON d.tenant_id = i.tenant_id AND d.doc_id = i.doc_id AND d.deleted_at IS NULL
+		LEFT JOIN expected_signers es ON es.tenant_id = i.tenant_id AND es.doc_id = i.doc_id
+		LEFT JOIN LATERAL (
+			SELECT id FROM signatures
+			WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+			LIMIT 1
+		) s ON true
+		WHERE i.packet_id = $1
+		GROUP BY i.doc_id, d.title, i.position
+		ORDER BY i.position ASC`
+
+	rows, err := q.QueryContext(ctx, documentQuery, packetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get packet document stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats.Documents = []*models.PacketDocumentStats{}
+	for rows.Next() {
+		doc := &models.PacketDocumentStats{}
+		if err := rows.Scan(&doc.DocID, &doc.Title, &doc.ExpectedCount, &doc.SignedCount); err != nil {
+			return nil, fmt.Errorf("failed to scan packet document stats: %w", err)
+		}
+		if doc.ExpectedCount > 0 {
+			doc.CompletionRate = float64(doc.SignedCount) / float64(doc.ExpectedCount) * 100
+		}
+		stats.Documents = append(stats.Documents, doc)
+	}
+	return stats, rows.Err()
+}
+
+// ListPendingSigners returns the expected signers with documents of the packet left to sign,
+// with those documents in packet order
+// RLS policy automatically filters by tenant_id
+func (r *PacketRepository) ListPendingSigners(ctx context.Context, packetID int64) ([]*models.PacketPendingSigner, error) {
+	query := `
+		SELECT es.email, MAX(es.name), array_agg(es.doc_id ORDER BY i.position)
+		FROM document_packet_items i
+		JOIN documents d ON d.tenant_id = i.tenant_id AND d.doc_id = i.doc_id AND d.deleted_at IS NULL
+		JOIN expected_signers es ON es.tenant_id = i.tenant_id AND es.doc_id = i.doc_id
+		WHERE i.packet_id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM signatures s
+			WHERE s.tenant_id = es.tenant_id AND s.doc_id = es.doc_id AND s.match_key = es.match_key
+		  )
+		GROUP BY es.email
+		ORDER BY es.email ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, packetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packet pending signers: %w", err)
+	}
+	defer rows.Close()
+
+	var signers []*models.PacketPendingSigner
+	for rows.Next() {
+		signer := &models.PacketPendingSigner{}
+		if err := rows.Scan(&signer.Email, &signer.Name, pq.Array(&signer.DocIDs)); err != nil {
+			return nil, fmt.Errorf("failed to scan packet pending signer: %w", err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers, rows.Err()
+}
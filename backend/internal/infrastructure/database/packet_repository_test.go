@@ -0,0 +1,121 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestPacketRepository_CRUD(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewPacketRepository(tdb.DB, tdb.TenantProvider)
+
+	for _, docID := range []string{"doc-a", "doc-b", "doc-c"} {
+		if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: docID}, "owner@example.com"); err != nil {
+			t.Fatalf("create document err: %v", err)
+		}
+	}
+
+	packet, err := repo.Create(ctx, models.DocumentPacketInput{Title: "Onboarding", DocIDs: []string{"doc-b", "doc-a"}}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+	if packet.ID == 0 || len(packet.DocIDs) != 2 || packet.DocIDs[0] != "doc-b" || packet.CreatedBy != "admin@example.com" {
+		t.Fatalf("unexpected packet: %+v", packet)
+	}
+
+	packet, err = repo.Update(ctx, packet.ID, models.DocumentPacketInput{Title: "Onboarding 2026", DocIDs: []string{"doc-a", "doc-b", "doc-c"}})
+	if err != nil {
+		t.Fatalf("update err: %v", err)
+	}
+	if packet.Title != "Onboarding 2026" || len(packet.DocIDs) != 3 || packet.DocIDs[0] != "doc-a" {
+		t.Fatalf("unexpected updated packet: %+v", packet)
+	}
+
+	packets, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	if len(packets) != 1 {
+		t.Fatalf("expected 1 packet, got %d", len(packets))
+	}
+
+	if err := repo.Delete(ctx, packet.ID); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, packet.ID); !errors.Is(err, models.ErrPacketNotFound) {
+		t.Fatalf("expected ErrPacketNotFound, got %v", err)
+	}
+	if doc, _ := docRepo.GetByDocID(ctx, "doc-a"); doc == nil {
+		t.Error("expected the documents to be kept")
+	}
+}
+
+func TestPacketRepository_Progress(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider)
+	sigRepo := NewSignatureRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewPacketRepository(tdb.DB, tdb.TenantProvider)
+	factory := NewSignatureFactory()
+
+	for _, docID := range []string{"doc-a", "doc-b"} {
+		if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: docID}, "owner@example.com"); err != nil {
+			t.Fatalf("create document err: %v", err)
+		}
+		if err := expectedRepo.AddExpected(ctx, docID, emailsToContacts([]string{"alice@example.com", "bob@example.com"}), "admin@example.com"); err != nil {
+			t.Fatalf("add expected err: %v", err)
+		}
+	}
+	packet, err := repo.Create(ctx, models.DocumentPacketInput{Title: "Onboarding", DocIDs: []string{"doc-a", "doc-b"}}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+
+	// Alice signed both documents, Bob the first one
+	for _, sig := range []*models.Signature{
+		factory.CreateSignatureWithDocAndUser("doc-a", "alice", "alice@example.com"),
+		factory.CreateSignatureWithDocAndUser("doc-b", "alice", "alice@example.com"),
+		factory.CreateSignatureWithDocAndUser("doc-a", "bob", "bob@example.com"),
+	} {
+		if err := sigRepo.Create(ctx, sig); err != nil {
+			t.Fatalf("create signature err: %v", err)
+		}
+	}
+
+	stats, err := repo.GetStats(ctx, packet.ID)
+	if err != nil {
+		t.Fatalf("stats err: %v", err)
+	}
+	if stats.SignerCount != 2 || stats.CompletedCount != 1 || stats.InProgressCount != 1 || stats.NotStartedCount != 0 {
+		t.Errorf("unexpected signer stats: %+v", stats)
+	}
+	if len(stats.Documents) != 2 || stats.Documents[0].SignedCount != 2 || stats.Documents[1].SignedCount != 1 {
+		t.Errorf("unexpected document stats: %+v", stats.Documents)
+	}
+
+	docs, err := repo.ListSignerDocuments(ctx, packet.ID, "bob@example.com")
+	if err != nil {
+		t.Fatalf("list signer documents err: %v", err)
+	}
+	if len(docs) != 2 || !docs[0].Signed || docs[1].Signed {
+		t.Errorf("unexpected signer documents: %+v", docs)
+	}
+
+	pending, err := repo.ListPendingSigners(ctx, packet.ID)
+	if err != nil {
+		t.Fatalf("list pending err: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Email != "bob@example.com" || len(pending[0].DocIDs) != 1 || pending[0].DocIDs[0] != "doc-b" {
+		t.Errorf("unexpected pending signers: %+v", pending)
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const previewLinkColumns = `id, doc_id, label, include_signers, expires_at, revoked_at, view_count, last_viewed_at, created_by, created_at`
+
+// PreviewLinkRepository handles database operations for document preview links
+type PreviewLinkRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewPreviewLinkRepository creates a new preview link repository
+func NewPreviewLinkRepository(db *sql.DB, tenants providers.TenantProvider) *PreviewLinkRepository {
+	return &PreviewLinkRepository{db: db, tenants: tenants}
+}
+
+func scanPreviewLink(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.PreviewLink, error) {
+	l := &models.PreviewLink{}
+	if err := scanner.Scan(&l.ID, &l.DocID, &l.Label, &l.IncludeSigners, &l.ExpiresAt, &l.RevokedAt,
+		&l.ViewCount, &l.LastViewedAt, &l.CreatedBy, &l.CreatedAt); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Create stores a new preview link from the hash of its token
+func (r *PreviewLinkRepository) Create(ctx context.Context, docID, tokenHash, label string, includeSigners bool, expiresAt time.Time, createdBy string) (*models.PreviewLink, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_preview_links (tenant_id, doc_id, token_hash, label, include_signers, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + previewLinkColumns
+
+	link, err := scanPreviewLink(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, docID, tokenHash, label, includeSigners, expiresAt, createdBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preview link: %w", err)
+	}
+	return link, nil
+}
+
+// ListByDoc retrieves the links of a document, newest first, including revoked and expired ones
+// RLS policy automatically filters by tenant_id
+func (r *PreviewLinkRepository) ListByDoc(ctx context.Context, docID string) ([]*models.PreviewLink, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT `+previewLinkColumns+` FROM document_preview_links WHERE doc_id = $1 ORDER BY created_at DESC, id DESC`, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query preview links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*models.PreviewLink{}
+	for rows.Next() {
+		link, err := scanPreviewLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan preview link: %w", err)
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate preview links: %w", err)
+	}
+	return links, nil
+}
+
+// GetByTokenHash returns the link matching a hashed token, or nil if none
+// RLS policy automatically filters by tenant_id
+func (r *PreviewLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PreviewLink, error) {
+	link, err := scanPreviewLink(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+previewLinkColumns+` FROM document_preview_links WHERE token_hash = $1`, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preview link: %w", err)
+	}
+	return link, nil
+}
+
+// RecordView counts an opening of a link
+// RLS policy automatically filters by tenant_id
+func (r *PreviewLinkRepository) RecordView(ctx context.Context, id int64) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`UPDATE document_preview_links SET view_count = view_count + 1, last_viewed_at = now() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to record preview link view: %w", err)
+	}
+	return nil
+}
+
+// Revoke disables a link of a document; revoking an already revoked link is a no-op
+// RLS policy automatically filters by tenant_id
+func (r *PreviewLinkRepository) Revoke(ctx context.Context, docID string, id int64) (*models.PreviewLink, error) {
+	query := `
+		UPDATE document_preview_links
+		SET revoked_at = COALESCE(revoked_at, now())
+		WHERE doc_id = $1 AND id = $2
+		RETURNING ` + previewLinkColumns
+
+	link, err := scanPreviewLink(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrPreviewLinkNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke preview link: %w", err)
+	}
+	return link, nil
+}
@@ -0,0 +1,72 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestPreviewLinkRepository(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewPreviewLinkRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	docID := "doc-preview-link-test"
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Privacy policy"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	expiresAt := time.Now().Add(72 * time.Hour).Truncate(time.Second)
+	link, err := repo.Create(ctx, docID, "hash-1", "External counsel", true, expiresAt, "admin@example.com")
+	if err != nil {
+		t.Fatalf("Create err: %v", err)
+	}
+	if link.ID == 0 || !link.IncludeSigners || !link.ExpiresAt.Equal(expiresAt) || link.ViewCount != 0 {
+		t.Fatalf("unexpected link %+v", link)
+	}
+	if _, err := repo.Create(ctx, docID, "hash-2", "", false, expiresAt, "admin@example.com"); err != nil {
+		t.Fatalf("Create err: %v", err)
+	}
+
+	found, err := repo.GetByTokenHash(ctx, "hash-1")
+	if err != nil || found == nil || found.ID != link.ID {
+		t.Fatalf("expected the link, got %+v, %v", found, err)
+	}
+	missing, err := repo.GetByTokenHash(ctx, "unknown")
+	if err != nil || missing != nil {
+		t.Fatalf("expected no link, got %+v, %v", missing, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := repo.RecordView(ctx, link.ID); err != nil {
+			t.Fatalf("RecordView err: %v", err)
+		}
+	}
+	links, err := repo.ListByDoc(ctx, docID)
+	if err != nil || len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d, %v", len(links), err)
+	}
+	if links[1].ID != link.ID || links[1].ViewCount != 2 || links[1].LastViewedAt == nil {
+		t.Errorf("unexpected links %+v %+v", links[0], links[1])
+	}
+
+	revoked, err := repo.Revoke(ctx, docID, link.ID)
+	if err != nil || revoked.RevokedAt == nil {
+		t.Fatalf("expected the link to be revoked, got %+v, %v", revoked, err)
+	}
+	again, err := repo.Revoke(ctx, docID, link.ID)
+	if err != nil || !again.RevokedAt.Equal(*revoked.RevokedAt) {
+		t.Errorf("expected revoking twice to keep the first date, got %+v, %v", again, err)
+	}
+	if _, err := repo.Revoke(ctx, "other-doc", link.ID); !errors.Is(err, models.ErrPreviewLinkNotFound) {
+		t.Errorf("expected ErrPreviewLinkNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const quizColumns = `tenant_id, doc_id, questions, pass_threshold, COALESCE(updated_by, ''), created_at, updated_at`
+
+// QuizRepository handles database operations for document comprehension quizzes
+type QuizRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewQuizRepository creates a new quiz repository
+func NewQuizRepository(db *sql.DB, tenants providers.TenantProvider) *QuizRepository {
+	return &QuizRepository{db: db, tenants: tenants}
+}
+
+func scanQuiz(row interface{ Scan(dest ...any) error }) (*models.DocumentQuiz, error) {
+	q := &models.DocumentQuiz{}
+	var questions []byte
+	err := row.Scan(&q.TenantID, &q.DocID, &questions, &q.PassThreshold, &q.UpdatedBy, &q.CreatedAt, &q.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(questions, &q.Questions); err != nil {
+		return nil, fmt.Errorf("failed to decode quiz questions: %w", err)
+	}
+	return q, nil
+}
+
+// GetByDocID returns the quiz of a document, or nil if it has none
+// RLS policy automatically filters by tenant_id
+func (r *QuizRepository) GetByDocID(ctx context.Context, docID string) (*models.DocumentQuiz, error) {
+	query := `SELECT ` + quizColumns + ` FROM document_quizzes WHERE doc_id = $1`
+
+	q, err := scanQuiz(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+	return q, nil
+}
+
+// Upsert creates or replaces the quiz of a document
+func (r *QuizRepository) Upsert(ctx context.Context, docID string, input models.DocumentQuizInput, updatedBy string) (*models.DocumentQuiz, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	questions, err := json.Marshal(input.Questions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quiz questions: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_quizzes (tenant_id, doc_id, questions, pass_threshold, updated_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET questions = EXCLUDED.questions,
+			pass_threshold = EXCLUDED.pass_threshold,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING ` + quizColumns
+
+	q, err := scanQuiz(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, docID, questions, input.PassThreshold, updatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert quiz: %w", err)
+	}
+	return q, nil
+}
+
+// Delete removes the quiz of a document. Scores already recorded with signatures are kept.
+// RLS policy automatically filters by tenant_id
+func (r *QuizRepository) Delete(ctx context.Context, docID string) error {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_quizzes WHERE doc_id = $1`, docID)
+	if err != nil {
+		return fmt.Errorf("failed to delete quiz: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrQuizNotFound
+	}
+	return nil
+}
+
+// ListResults returns the quiz outcomes recorded with the signatures of a document, oldest first
+// RLS policy automatically filters by tenant_id
+func (r *QuizRepository) ListResults(ctx context.Context, docID string) ([]*models.QuizResult, error) {
+	query := `
+		SELECT user_email, quiz_score, quiz_answers, signed_at
+		FROM signatures
+		WHERE doc_id = $1 AND quiz_score IS NOT NULL
+		ORDER BY signed_at ASC
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quiz results: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.QuizResult
+	for rows.Next() {
+		result := &models.QuizResult{}
+		var answers []byte
+		if err := rows.Scan(&result.UserEmail, &result.Score, &answers, &result.SignedAt); err != nil {
+			return nil, err
+		}
+		if len(answers) > 0 {
+			if err := json.Unmarshal(answers, &result.Answers); err != nil {
+				return nil, fmt.Errorf("failed to decode quiz answers: %w", err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// RateLimitRepository stores rate-limited requests: accepted ones are counted over a sliding
+// window, blocked ones are the violations listed to admins
+type RateLimitRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewRateLimitRepository creates a new rate limit repository
+func NewRateLimitRepository(db *sql.DB, tenants providers.TenantProvider) *RateLimitRepository {
+	return &RateLimitRepository{db: db, tenants: tenants}
+}
+
+// CountRecent counts the accepted requests of a user or IP for an action since a given time
+// RLS policy automatically filters by tenant_id
+func (r *RateLimitRepository) CountRecent(ctx context.Context, action, scope, subject string, since time.Time) (int, error) {
+	column := "user_email"
+	if scope == models.RateLimitScopeIP {
+		column = "ip_address"
+	}
+	query := `SELECT COUNT(*) FROM rate_limit_events
+		WHERE action = $1 AND ` + column + ` = $2 AND created_at > $3 AND NOT blocked`
+
+	var count int
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, action, subject, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count rate limit events: %w", err)
+	}
+	return count, nil
+}
+
+// Record stores an accepted request.
+// Events are written in their own transaction: they must count even when the request fails.
+func (r *RateLimitRepository) Record(ctx context.Context, action, userEmail, ip string) error {
+	return r.insert(ctx, action, userEmail, ip, "")
+}
+
+// RecordViolation stores a request rejected by the limit of the given scope.
+// It is written in its own transaction, since the rejected request is rolled back.
+func (r *RateLimitRepository) RecordViolation(ctx context.Context, action, scope, userEmail, ip string) error {
+	return r.insert(ctx, action, userEmail, ip, scope)
+}
+
+func (r *RateLimitRepository) insert(ctx context.Context, action, userEmail, ip, scope string) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO rate_limit_events (tenant_id, action, user_email, ip_address, blocked, scope)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5 <> '', NULLIF($5, ''))`
+
+	err = tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+		_, err := dbctx.GetQuerier(txCtx, r.db).ExecContext(txCtx, query, tenantID, action, userEmail, ip, scope)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record rate limit event: %w", err)
+	}
+	return nil
+}
+
+// ListViolations returns the most recent violations since a given time, newest first
+// RLS policy automatically filters by tenant_id
+func (r *RateLimitRepository) ListViolations(ctx context.Context, since time.Time, limit int) ([]*models.RateLimitViolation, error) {
+	query := `
+		SELECT id, tenant_id, action, scope, COALESCE(user_email, ''), COALESCE(ip_address, ''), created_at
+		FROM rate_limit_events
+		WHERE blocked AND created_at > $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rate limit violations: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.RateLimitViolation
+	for rows.Next() {
+		v := &models.RateLimitViolation{}
+		if err := rows.Scan(&v.ID, &v.TenantID, &v.Action, &v.Scope, &v.UserEmail, &v.IPAddress, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rate limit violation: %w", err)
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// ListOffenders groups the violations since a given time by user or IP, most violations first
+// RLS policy automatically filters by tenant_id
+func (r *RateLimitRepository) ListOffenders(ctx context.Context, since time.Time, limit int) ([]*models.RateLimitOffender, error) {
+	query := `
+		SELECT scope,
+			CASE WHEN scope = 'ip' THEN ip_address ELSE user_email END AS subject,
+			COUNT(*) AS violations,
+			MAX(created_at) AS last_seen_at
+		FROM rate_limit_events
+		WHERE blocked AND created_at > $1
+		GROUP BY scope, subject
+		ORDER BY violations DESC, last_seen_at DESC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rate limit offenders: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.RateLimitOffender
+	for rows.Next() {
+		o := &models.RateLimitOffender{}
+		var subject sql.NullString
+		if err := rows.Scan(&o.Scope, &subject, &o.Violations, &o.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rate limit offender: %w", err)
+		}
+		o.Subject = subject.String
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// DeleteBefore removes accepted requests older than acceptedBefore and violations older than blockedBefore
+// RLS policy automatically filters by tenant_id
+func (r *RateLimitRepository) DeleteBefore(ctx context.Context, acceptedBefore, blockedBefore time.Time) (int64, error) {
+	query := `DELETE FROM rate_limit_events
+		WHERE (NOT blocked AND created_at < $1) OR (blocked AND created_at < $2)`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, acceptedBefore, blockedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rate limit events: %w", err)
+	}
+	return result.RowsAffected()
+}
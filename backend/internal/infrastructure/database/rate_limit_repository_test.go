@@ -0,0 +1,99 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestRateLimitRepository_Counters(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewRateLimitRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	for _, email := range []string{"alice@example.com", "alice@example.com", "bob@example.com"} {
+		if err := repo.Record(ctx, models.RateLimitActionSignature, email, "10.0.0.1"); err != nil {
+			t.Fatalf("record err: %v", err)
+		}
+	}
+	if err := repo.RecordViolation(ctx, models.RateLimitActionSignature, models.RateLimitScopeUser, "alice@example.com", "10.0.0.1"); err != nil {
+		t.Fatalf("record violation err: %v", err)
+	}
+
+	count, err := repo.CountRecent(ctx, models.RateLimitActionSignature, models.RateLimitScopeUser, "alice@example.com", since)
+	if err != nil {
+		t.Fatalf("count err: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected violations excluded from user count, got %d", count)
+	}
+	count, err = repo.CountRecent(ctx, models.RateLimitActionSignature, models.RateLimitScopeIP, "10.0.0.1", since)
+	if err != nil {
+		t.Fatalf("count err: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 requests from IP, got %d", count)
+	}
+	count, err = repo.CountRecent(ctx, models.RateLimitActionMagicLink, models.RateLimitScopeIP, "10.0.0.1", since)
+	if err != nil {
+		t.Fatalf("count err: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected counters to be per action, got %d", count)
+	}
+}
+
+func TestRateLimitRepository_Violations(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewRateLimitRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	violations := []struct{ action, scope, email, ip string }{
+		{models.RateLimitActionSignature, models.RateLimitScopeIP, "a@example.com", "10.0.0.9"},
+		{models.RateLimitActionSignature, models.RateLimitScopeIP, "b@example.com", "10.0.0.9"},
+		{models.RateLimitActionMagicLink, models.RateLimitScopeUser, "c@example.com", ""},
+	}
+	for _, v := range violations {
+		if err := repo.RecordViolation(ctx, v.action, v.scope, v.email, v.ip); err != nil {
+			t.Fatalf("record violation err: %v", err)
+		}
+	}
+	if err := repo.Record(ctx, models.RateLimitActionSignature, "d@example.com", "10.0.0.9"); err != nil {
+		t.Fatalf("record err: %v", err)
+	}
+
+	listed, err := repo.ListViolations(ctx, since, 10)
+	if err != nil {
+		t.Fatalf("list violations err: %v", err)
+	}
+	if len(listed) != 3 {
+		t.Fatalf("expected 3 violations, got %d", len(listed))
+	}
+	if listed[0].Action != models.RateLimitActionMagicLink || listed[0].IPAddress != "" {
+		t.Errorf("expected newest violation first, got %+v", listed[0])
+	}
+
+	offenders, err := repo.ListOffenders(ctx, since, 10)
+	if err != nil {
+		t.Fatalf("list offenders err: %v", err)
+	}
+	if len(offenders) != 2 || offenders[0].Subject != "10.0.0.9" || offenders[0].Violations != 2 {
+		t.Errorf("expected IP with most violations first, got %+v", offenders)
+	}
+
+	deleted, err := repo.DeleteBefore(ctx, time.Now().Add(time.Minute), time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected only the accepted request deleted, got %d", deleted)
+	}
+}
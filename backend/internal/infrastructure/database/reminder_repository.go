@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
@@ -135,6 +136,41 @@ func (r *ReminderRepository) GetLastReminderByEmail(ctx context.Context, docID,
 	return log, nil
 }
 
+// LockLastReminder returns when a recipient was last reminded of a document, failed reminders
+// excepted, after taking a transaction lock on the recipient: concurrent sends to the same
+// recipient wait for the first one to commit its reminder log. Outside a transaction the
+// lock is released at once.
+// RLS policy automatically filters by tenant_id
+func (r *ReminderRepository) LockLastReminder(ctx context.Context, docID, email string) (*time.Time, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	if _, err := q.ExecContext(ctx,
+		`SELECT pg_advisory_xact_lock(hashtextextended('reminder:' || $1 || ':' || $2 || ':' || $3, 0))`,
+		tenantID.String(), docID, email,
+	); err != nil {
+		return nil, fmt.Errorf("failed to lock recipient reminders: %w", err)
+	}
+
+	query := `
+		SELECT MAX(sent_at)
+		FROM reminder_logs
+		WHERE doc_id = $1 AND recipient_email = $2 AND status <> 'failed'
+	`
+
+	var last sql.NullTime
+	if err := q.QueryRowContext(ctx, query, docID, email).Scan(&last); err != nil {
+		return nil, fmt.Errorf("failed to get last reminder: %w", err)
+	}
+	if !last.Valid {
+		return nil, nil
+	}
+	return &last.Time, nil
+}
+
 // GetReminderCount tallies successfully delivered reminders to a recipient for rate limiting
 // RLS policy automatically filters by tenant_id
 func (r *ReminderRepository) GetReminderCount(ctx context.Context, docID, email string) (int, error) {
@@ -179,7 +215,7 @@ func (r *ReminderRepository) GetReminderStats(ctx context.Context, docID string)
 	pendingQuery := `
 		SELECT COUNT(*)
 		FROM expected_signers es
-		LEFT JOIN signatures s ON es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id AND es.email = s.user_email
+		LEFT JOIN signatures s ON es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id AND es.match_key = s.match_key
 		WHERE es.doc_id = $1 AND s.id IS NULL
 	`
 
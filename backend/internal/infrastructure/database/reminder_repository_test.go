@@ -5,6 +5,7 @@ package database
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
@@ -90,3 +91,40 @@ func TestReminderRepository_Basic_Integration(t *testing.T) {
 		t.Errorf("Expected 1 reminder in history, got %d", len(history))
 	}
 }
+
+func TestReminderRepository_LockLastReminder(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewReminderRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	docID := "doc-reminder-interval"
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Interval"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if err := expectedRepo.AddExpected(ctx, docID, emailsToContacts([]string{"alice@example.com"}), "admin@example.com"); err != nil {
+		t.Fatalf("add expected signers err: %v", err)
+	}
+
+	last, err := repo.LockLastReminder(ctx, docID, "alice@example.com")
+	if err != nil || last != nil {
+		t.Fatalf("expected no reminder yet, got %v, %v", last, err)
+	}
+
+	queued := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	for _, log := range []*models.ReminderLog{
+		{DocID: docID, RecipientEmail: "alice@example.com", SentAt: queued, SentBy: "admin@example.com", TemplateUsed: "signature_reminder", Status: "queued"},
+		{DocID: docID, RecipientEmail: "alice@example.com", SentAt: time.Now(), SentBy: "admin@example.com", TemplateUsed: "signature_reminder", Status: "failed"},
+	} {
+		if err := repo.LogReminder(ctx, log); err != nil {
+			t.Fatalf("LogReminder err: %v", err)
+		}
+	}
+
+	// Failed reminders do not count
+	last, err = repo.LockLastReminder(ctx, docID, "alice@example.com")
+	if err != nil || last == nil || !last.Equal(queued) {
+		t.Fatalf("expected the last queued reminder at %s, got %v, %v", queued, last, err)
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// replicaLagQuery measures how far the replica is behind the primary. A replica that replayed
+// everything it received is in sync, even when the primary has been idle since its last transaction.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// replicaCheckTimeout bounds a health check, so a hung replica is reported unhealthy
+const replicaCheckTimeout = 5 * time.Second
+
+// Replica is an optional read replica serving heavy reads. It is used while it answers health
+// checks and its replication lag stays under the maximum; otherwise reads fall back to the primary.
+type Replica struct {
+	db     *sql.DB
+	maxLag time.Duration
+
+	healthy   atomic.Bool
+	lag       atomic.Int64 // Nanoseconds
+	checkedAt atomic.Int64 // Unix nanoseconds
+	lastError atomic.Value // string
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewReplica wraps a connection to a read replica. It is unhealthy until the first check.
+func NewReplica(db *sql.DB, maxLag time.Duration) *Replica {
+	return &Replica{db: db, maxLag: maxLag, done: make(chan struct{})}
+}
+
+// OpenReplica connects to the read replica and checks it once. A replica that is down or lagging
+// at startup is not an error: reads go to the primary until a later check succeeds.
+func OpenReplica(ctx context.Context, dsn string, maxLag time.Duration) (*Replica, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open read replica: %w", err)
+	}
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(5 * time.Minute)
+	db.SetConnMaxIdleTime(2 * time.Minute)
+
+	replica := NewReplica(db, maxLag)
+	replica.Check(ctx)
+	if !replica.healthy.Load() {
+		logger.Logger.Warn("Read replica unavailable at startup, reads use the primary", "error", replica.Status().Error)
+	}
+	return replica, nil
+}
+
+// DB returns the replica connection while it is healthy, nil when reads must go to the primary
+func (r *Replica) DB() *sql.DB {
+	if r == nil || !r.healthy.Load() {
+		return nil
+	}
+	return r.db
+}
+
+// Check measures the replication lag and updates the health of the replica
+func (r *Replica) Check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	var seconds float64
+	err := r.db.QueryRowContext(ctx, replicaLagQuery).Scan(&seconds)
+	lag := time.Duration(seconds * float64(time.Second))
+
+	r.checkedAt.Store(time.Now().UnixNano())
+	r.lag.Store(int64(lag))
+
+	healthy := err == nil && lag <= r.maxLag
+	switch {
+	case err != nil:
+		r.lastError.Store(err.Error())
+	case !healthy:
+		r.lastError.Store(fmt.Sprintf("replication lag %s exceeds %s", lag.Round(time.Millisecond), r.maxLag))
+	default:
+		r.lastError.Store("")
+	}
+
+	if was := r.healthy.Swap(healthy); was != healthy {
+		if healthy {
+			logger.Logger.Info("Read replica in use", "lag", lag.String())
+		} else {
+			logger.Logger.Warn("Read replica unavailable, reads fall back to the primary", "error", r.lastError.Load())
+		}
+	}
+}
+
+// Start checks the replica at every interval until the context is done or the replica is closed
+func (r *Replica) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.done:
+			return
+		case <-ticker.C:
+			r.Check(ctx)
+		}
+	}
+}
+
+// Status returns the outcome of the last health check
+func (r *Replica) Status() models.ReplicaStatus {
+	status := models.ReplicaStatus{
+		Healthy:    r.healthy.Load(),
+		LagSeconds: time.Duration(r.lag.Load()).Seconds(),
+		MaxLag:     r.maxLag.Seconds(),
+	}
+	if checkedAt := r.checkedAt.Load(); checkedAt > 0 {
+		t := time.Unix(0, checkedAt).UTC()
+		status.CheckedAt = &t
+	}
+	if msg, _ := r.lastError.Load().(string); msg != "" {
+		status.Error = msg
+	}
+	return status
+}
+
+// Close stops the health checks and closes the replica connection
+func (r *Replica) Close() error {
+	r.closeOnce.Do(func() { close(r.done) })
+	r.healthy.Store(false)
+	return r.db.Close()
+}
@@ -0,0 +1,60 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+)
+
+func TestReplica_Check(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+
+	replica := NewReplica(tdb.DB, time.Second)
+	if replica.DB() != nil {
+		t.Fatal("expected the replica to be unused before its first check")
+	}
+
+	// A primary answers the lag query as an in-sync replica
+	replica.Check(ctx)
+	status := replica.Status()
+	if !status.Healthy || status.LagSeconds != 0 || status.CheckedAt == nil || status.Error != "" {
+		t.Fatalf("expected a healthy replica, got %+v", status)
+	}
+	if replica.DB() == nil {
+		t.Fatal("expected the healthy replica to serve reads")
+	}
+
+	lagging := NewReplica(tdb.DB, -time.Second)
+	lagging.Check(ctx)
+	if lagging.DB() != nil || lagging.Status().Error == "" {
+		t.Errorf("expected a replica over its max lag to be unused, got %+v", lagging.Status())
+	}
+}
+
+func TestReplica_ReadsAreNotCached(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	seedDocumentStatus(t, tdb, "replica-doc", 2)
+
+	tx, err := tdb.DB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx err: %v", err)
+	}
+	defer tx.Rollback()
+
+	cache := NewSignerStatusCache(NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider), time.Minute)
+	signers, err := cache.ListWithStatusByDocID(dbctx.WithReadTx(ctx, tx), "replica-doc")
+	if err != nil || len(signers) != 2 {
+		t.Fatalf("expected 2 signers, got %d (err %v)", len(signers), err)
+	}
+	if entries := cache.Stats().Entries; entries != 0 {
+		t.Errorf("expected replica reads to stay out of the cache, got %d entries", entries)
+	}
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const documentArchiveColumns = `id, tenant_id, doc_id, title, storage_key, size_bytes, signature_count, pii_purged, archived_by, archived_at, COALESCE(restored_by, ''), restored_at`
+
+// RetentionRepository handles database operations for retention policies and document archives
+type RetentionRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *sql.DB, tenants providers.TenantProvider) *RetentionRepository {
+	return &RetentionRepository{db: db, tenants: tenants}
+}
+
+// GetPolicy returns the retention policy of the current tenant, or nil if none is configured
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) GetPolicy(ctx context.Context) (*models.RetentionPolicy, error) {
+	query := `SELECT tenant_id, enabled, archive_after_days, purge_pii, COALESCE(updated_by, ''), updated_at FROM retention_policies`
+
+	p := &models.RetentionPolicy{}
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query).Scan(
+		&p.TenantID, &p.Enabled, &p.ArchiveAfterDays, &p.PurgePII, &p.UpdatedBy, &p.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retention policy: %w", err)
+	}
+	return p, nil
+}
+
+// UpsertPolicy creates or replaces the retention policy of the current tenant
+func (r *RetentionRepository) UpsertPolicy(ctx context.Context, input models.RetentionPolicyInput, updatedBy string) (*models.RetentionPolicy, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO retention_policies (tenant_id, enabled, archive_after_days, purge_pii, updated_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, archive_after_days = EXCLUDED.archive_after_days,
+			purge_pii = EXCLUDED.purge_pii, updated_by = EXCLUDED.updated_by, updated_at = now()
+		RETURNING tenant_id, enabled, archive_after_days, purge_pii, COALESCE(updated_by, ''), updated_at
+	`
+
+	p := &models.RetentionPolicy{}
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, input.Enabled, input.ArchiveAfterDays, input.PurgePII, updatedBy,
+	).Scan(&p.TenantID, &p.Enabled, &p.ArchiveAfterDays, &p.PurgePII, &p.UpdatedBy, &p.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert retention policy: %w", err)
+	}
+	return p, nil
+}
+
+// ListArchivable returns active documents whose expected signers have all signed, the last
+// signature being at least archiveAfterDays before now. Documents carrying tags with a retention
+// policy are kept for the longest delay of those policies instead.
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) ListArchivable(ctx context.Context, now time.Time, archiveAfterDays, limit int) ([]string, error) {
+	query := `
+		SELECT d.doc_id
+		FROM documents d
+		WHERE d.deleted_at IS NULL
+		  AND EXISTS (SELECT 1 FROM expected_signers es WHERE es.doc_id = d.doc_id)
+		  AND NOT EXISTS (
+			SELECT 1 FROM expected_signers es
+			WHERE es.doc_id = d.doc_id
+			  AND NOT EXISTS (SELECT 1 FROM signatures s WHERE s.doc_id = es.doc_id AND s.match_key = es.match_key)
+		  )
+		  AND (SELECT MAX(s.signed_at) FROM signatures s WHERE s.doc_id = d.doc_id) <= $1::timestamptz - make_interval(days => COALESCE(
+			(SELECT MAX(tp.archive_after_days) FROM document_tags t
+			 JOIN tag_policies tp ON tp.tenant_id = t.tenant_id AND tp.tag = t.tag
+			 WHERE t.doc_id = d.doc_id),
+			$2::int))
+		ORDER BY d.created_at ASC
+		LIMIT $3
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, now, archiveAfterDays, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archivable documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docIDs []string
+	for rows.Next() {
+		var docID string
+		if err := rows.Scan(&docID); err != nil {
+			return nil, err
+		}
+		docIDs = append(docIDs, docID)
+	}
+	return docIDs, rows.Err()
+}
+
+// PurgeDocumentPII removes signer personal data kept for a document.
+// Expected signers (and their reminder logs, by cascade) are deleted and signatures are pseudonymised.
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) PurgeDocumentPII(ctx context.Context, docID string) error {
+	q := dbctx.GetQuerier(ctx, r.db)
+
+	if _, err := q.ExecContext(ctx, `DELETE FROM expected_signers WHERE doc_id = $1`, docID); err != nil {
+		return fmt.Errorf("failed to purge expected signers: %w", err)
+	}
+
+	_, err := q.ExecContext(ctx, `
+		UPDATE signatures
+		SET user_email = 'redacted-' || id, user_sub = 'redacted-' || id, user_name = NULL, referer = NULL
+		WHERE doc_id = $1
+	`, docID)
+	if err != nil {
+		return fmt.Errorf("failed to purge signatures: %w", err)
+	}
+	return nil
+}
+
+// CreateArchive records an archive stored in object storage
+func (r *RetentionRepository) CreateArchive(ctx context.Context, input models.DocumentArchiveInput) (*models.DocumentArchive, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_archives (tenant_id, doc_id, title, storage_key, size_bytes, signature_count, pii_purged, archived_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + documentArchiveColumns
+
+	a, err := scanDocumentArchive(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID,
+		input.DocID,
+		input.Title,
+		input.StorageKey,
+		input.SizeBytes,
+		input.SignatureCount,
+		input.PIIPurged,
+		input.ArchivedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document archive: %w", err)
+	}
+	return a, nil
+}
+
+// GetArchive retrieves an archive by its ID
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) GetArchive(ctx context.Context, id int64) (*models.DocumentArchive, error) {
+	query := `SELECT ` + documentArchiveColumns + ` FROM document_archives WHERE id = $1`
+
+	a, err := scanDocumentArchive(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrArchiveNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document archive: %w", err)
+	}
+	return a, nil
+}
+
+// ListArchives retrieves paginated archives, most recent first
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) ListArchives(ctx context.Context, limit, offset int) ([]*models.DocumentArchive, error) {
+	query := `SELECT ` + documentArchiveColumns + ` FROM document_archives ORDER BY archived_at DESC, id DESC LIMIT $1 OFFSET $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document archives: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.DocumentArchive
+	for rows.Next() {
+		a, err := scanDocumentArchive(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// CountArchives returns the total number of archives
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) CountArchives(ctx context.Context) (int, error) {
+	var count int
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM document_archives`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count document archives: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRestored records that the archived document has been restored
+// RLS policy automatically filters by tenant_id
+func (r *RetentionRepository) MarkRestored(ctx context.Context, id int64, restoredBy string) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`UPDATE document_archives SET restored_by = $1, restored_at = now() WHERE id = $2 AND restored_at IS NULL`,
+		restoredBy, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark archive restored: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrArchiveNotFound
+	}
+	return nil
+}
+
+type documentArchiveScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDocumentArchive(row documentArchiveScanner) (*models.DocumentArchive, error) {
+	a := &models.DocumentArchive{}
+	err := row.Scan(
+		&a.ID, &a.TenantID, &a.DocID, &a.Title, &a.StorageKey, &a.SizeBytes, &a.SignatureCount, &a.PIIPurged,
+		&a.ArchivedBy, &a.ArchivedAt, &a.RestoredBy, &a.RestoredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
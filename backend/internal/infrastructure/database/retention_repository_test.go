@@ -0,0 +1,162 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestRetentionRepository_Policy(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewRetentionRepository(tdb.DB, tdb.TenantProvider)
+
+	policy, err := repo.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("get policy err: %v", err)
+	}
+	if policy != nil {
+		t.Fatalf("expected no policy, got %+v", policy)
+	}
+
+	if _, err := repo.UpsertPolicy(ctx, models.RetentionPolicyInput{Enabled: true, ArchiveAfterDays: 30}, "admin@example.com"); err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	policy, err = repo.UpsertPolicy(ctx, models.RetentionPolicyInput{Enabled: true, ArchiveAfterDays: 90, PurgePII: true}, "other@example.com")
+	if err != nil {
+		t.Fatalf("second upsert err: %v", err)
+	}
+	if policy.ArchiveAfterDays != 90 || !policy.PurgePII || policy.UpdatedBy != "other@example.com" {
+		t.Fatalf("unexpected policy: %+v", policy)
+	}
+}
+
+func TestRetentionRepository_ArchiveLifecycle(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	signerRepo := NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider)
+	sigRepo := NewSignatureRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewRetentionRepository(tdb.DB, tdb.TenantProvider)
+	factory := NewSignatureFactory()
+
+	for _, docID := range []string{"complete-doc", "pending-doc"} {
+		if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: docID}, "admin@example.com"); err != nil {
+			t.Fatalf("create document err: %v", err)
+		}
+		if err := signerRepo.AddExpected(ctx, docID, emailsToContacts([]string{"user1@example.com", "user2@example.com"}), "admin@example.com"); err != nil {
+			t.Fatalf("add expected err: %v", err)
+		}
+	}
+	for _, sig := range []*models.Signature{
+		factory.CreateSignatureWithDocAndUser("complete-doc", "sub1", "user1@example.com"),
+		factory.CreateSignatureWithDocAndUser("complete-doc", "sub2", "user2@example.com"),
+		factory.CreateSignatureWithDocAndUser("pending-doc", "sub1", "user1@example.com"),
+	} {
+		if err := sigRepo.Create(ctx, sig); err != nil {
+			t.Fatalf("create signature err: %v", err)
+		}
+	}
+
+	docIDs, err := repo.ListArchivable(ctx, time.Now().Add(time.Hour), 0, 10)
+	if err != nil {
+		t.Fatalf("list archivable err: %v", err)
+	}
+	if len(docIDs) != 1 || docIDs[0] != "complete-doc" {
+		t.Fatalf("expected only complete-doc to be archivable, got %v", docIDs)
+	}
+	docIDs, err = repo.ListArchivable(ctx, time.Now().Add(-time.Hour), 0, 10)
+	if err != nil {
+		t.Fatalf("list archivable err: %v", err)
+	}
+	if len(docIDs) != 0 {
+		t.Fatalf("recently completed document must not be archivable, got %v", docIDs)
+	}
+
+	// A tag policy keeps the document longer than the tenant policy
+	tagRepo := NewTagRepository(tdb.DB, tdb.TenantProvider)
+	if err := tagRepo.SetDocumentTags(ctx, "complete-doc", []string{"legal"}, "admin@example.com"); err != nil {
+		t.Fatalf("set tags err: %v", err)
+	}
+	keepDays := 30
+	if _, err := tagRepo.UpsertPolicy(ctx, models.TagPolicy{Tag: "legal", ArchiveAfterDays: &keepDays}, "admin@example.com"); err != nil {
+		t.Fatalf("upsert tag policy err: %v", err)
+	}
+	if docIDs, err = repo.ListArchivable(ctx, time.Now().Add(time.Hour), 0, 10); err != nil || len(docIDs) != 0 {
+		t.Fatalf("expected the tag policy to keep complete-doc, got %v, %v", docIDs, err)
+	}
+	if docIDs, err = repo.ListArchivable(ctx, time.Now().AddDate(0, 0, keepDays+1), 0, 10); err != nil || len(docIDs) != 1 {
+		t.Fatalf("expected complete-doc archivable after the tag policy delay, got %v, %v", docIDs, err)
+	}
+
+	if err := docRepo.Delete(ctx, "complete-doc"); err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if err := repo.PurgeDocumentPII(ctx, "complete-doc"); err != nil {
+		t.Fatalf("purge err: %v", err)
+	}
+	sigs, err := sigRepo.GetByDoc(ctx, "complete-doc")
+	if err != nil {
+		t.Fatalf("get signatures err: %v", err)
+	}
+	for _, sig := range sigs {
+		if sig.UserEmail == "user1@example.com" || sig.UserEmail == "user2@example.com" {
+			t.Fatalf("signature email not purged: %+v", sig)
+		}
+	}
+	signers, err := signerRepo.ListByDocID(ctx, "complete-doc")
+	if err != nil {
+		t.Fatalf("list signers err: %v", err)
+	}
+	if len(signers) != 0 {
+		t.Fatalf("expected signers to be purged, got %d", len(signers))
+	}
+
+	archive, err := repo.CreateArchive(ctx, models.DocumentArchiveInput{
+		DocID:          "complete-doc",
+		Title:          "complete-doc",
+		StorageKey:     "archives/complete-doc/x.json.gz",
+		SizeBytes:      128,
+		SignatureCount: 2,
+		PIIPurged:      true,
+		ArchivedBy:     "retention-policy",
+	})
+	if err != nil {
+		t.Fatalf("create archive err: %v", err)
+	}
+	list, err := repo.ListArchives(ctx, 10, 0)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("list archives: %v, %d", err, len(list))
+	}
+	if count, err := repo.CountArchives(ctx); err != nil || count != 1 {
+		t.Fatalf("count archives: %v, %d", err, count)
+	}
+
+	if err := docRepo.Restore(ctx, "complete-doc"); err != nil {
+		t.Fatalf("restore err: %v", err)
+	}
+	if doc, err := docRepo.GetByDocID(ctx, "complete-doc"); err != nil || doc == nil {
+		t.Fatalf("expected restored document, got %v, %v", doc, err)
+	}
+	if err := repo.MarkRestored(ctx, archive.ID, "admin@example.com"); err != nil {
+		t.Fatalf("mark restored err: %v", err)
+	}
+	if err := repo.MarkRestored(ctx, archive.ID, "admin@example.com"); !errors.Is(err, models.ErrArchiveNotFound) {
+		t.Fatalf("expected ErrArchiveNotFound on second restore, got %v", err)
+	}
+	got, err := repo.GetArchive(ctx, archive.ID)
+	if err != nil {
+		t.Fatalf("get archive err: %v", err)
+	}
+	if !got.IsRestored() || got.RestoredBy != "admin@example.com" {
+		t.Fatalf("unexpected archive: %+v", got)
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// Search vectors, identical to the GIN index expressions of migration 0028 so that the indexes are used
+const (
+	documentSearchVector = `(setweight(to_tsvector('simple', d.title), 'A') ||
+		setweight(to_tsvector('simple', d.description), 'B') ||
+		setweight(to_tsvector('simple', d.doc_id || ' ' || translate(d.url, '/:.-_?=&#', '         ') || ' ' || coalesce(d.original_filename, '')), 'C'))`
+	expectedSignerSearchVector = `to_tsvector('simple', es.name || ' ' || translate(es.email, '@.-_+', '     '))`
+	signatureSearchVector      = `to_tsvector('simple', coalesce(s.user_name, '') || ' ' || translate(s.user_email, '@.-_+', '     '))`
+)
+
+// searchTagFilter restricts a search to the documents carrying the tag bound to $2, if any
+func searchTagFilter(docIDColumn string) string {
+	return `($2 = '' OR EXISTS (SELECT 1 FROM document_tags t WHERE t.doc_id = ` + docIDColumn + ` AND t.tag = $2))`
+}
+
+// SearchRepository runs full-text searches across documents and signers
+type SearchRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(db *sql.DB, tenants providers.TenantProvider) *SearchRepository {
+	return &SearchRepository{db: db, tenants: tenants}
+}
+
+// SearchDocuments returns the documents matching a tsquery, best matches first, restricted to
+// the documents carrying tag unless it is empty
+// RLS policy automatically filters by tenant_id
+func (r *SearchRepository) SearchDocuments(ctx context.Context, tsquery, tag string, limit int) ([]*models.DocumentSearchHit, error) {
+	query := `
+		SELECT d.doc_id, d.title, d.description, d.url, COALESCE(d.original_filename, ''),
+			ts_rank(` + documentSearchVector + `, q) AS rank
+		FROM documents d, to_tsquery('simple', $1) q
+		WHERE d.deleted_at IS NULL AND ` + documentSearchVector + ` @@ q
+			AND ` + searchTagFilter("d.doc_id") + `
+		ORDER BY rank DESC, d.created_at DESC
+		LIMIT $3`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, tsquery, tag, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.DocumentSearchHit
+	for rows.Next() {
+		hit := &models.DocumentSearchHit{}
+		if err := rows.Scan(&hit.DocID, &hit.Title, &hit.Description, &hit.URL, &hit.OriginalFilename, &hit.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan document search hit: %w", err)
+		}
+		out = append(out, hit)
+	}
+	return out, rows.Err()
+}
+
+// SearchSigners returns the expected signers and signers matching a tsquery, best matches first,
+// restricted to the documents carrying tag unless it is empty.
+// Signers who are also expected are only returned once.
+// RLS policy automatically filters by tenant_id
+func (r *SearchRepository) SearchSigners(ctx context.Context, tsquery, tag string, limit int) ([]*models.SignerSearchHit, error) {
+	query := `
+		SELECT es.doc_id, COALESCE(d.title, ''), es.email, es.name,
+			EXISTS (SELECT 1 FROM signatures sig WHERE sig.doc_id = es.doc_id AND sig.match_key = es.match_key) AS signed,
+			ts_rank(` + expectedSignerSearchVector + `, q) AS rank
+		FROM expected_signers es
+		CROSS JOIN to_tsquery('simple', $1) q
+		LEFT JOIN documents d ON d.doc_id = es.doc_id
+		WHERE d.deleted_at IS NULL AND ` + expectedSignerSearchVector + ` @@ q
+			AND ` + searchTagFilter("es.doc_id") + `
+		UNION ALL
+		SELECT s.doc_id, COALESCE(d.title, ''), s.user_email, COALESCE(s.user_name, ''),
+			TRUE AS signed,
+			ts_rank(` + signatureSearchVector + `, q) AS rank
+		FROM signatures s
+		CROSS JOIN to_tsquery('simple', $1) q
+		LEFT JOIN documents d ON d.doc_id = s.doc_id
+		WHERE d.deleted_at IS NULL AND ` + signatureSearchVector + ` @@ q
+			AND NOT EXISTS (SELECT 1 FROM expected_signers ex WHERE ex.doc_id = s.doc_id AND ex.match_key = s.match_key)
+			AND ` + searchTagFilter("s.doc_id") + `
+		ORDER BY rank DESC
+		LIMIT $3`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, tsquery, tag, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search signers: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.SignerSearchHit
+	for rows.Next() {
+		hit := &models.SignerSearchHit{}
+		if err := rows.Scan(&hit.DocID, &hit.DocTitle, &hit.Email, &hit.Name, &hit.Signed, &hit.Rank); err != nil {
+			return nil, fmt.Errorf("failed to scan signer search hit: %w", err)
+		}
+		out = append(out, hit)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSearchRepository_SearchDocuments(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewSearchRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-search-title", models.DocumentInput{Title: "Zephyrine charter", URL: "https://intranet.example.com/charter.pdf"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if _, err := docRepo.Create(ctx, "doc-search-desc", models.DocumentInput{Title: "Other", Description: "Read the zephyrine rules"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if _, err := docRepo.Create(ctx, "doc-search-deleted", models.DocumentInput{Title: "Zephyrine archive"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if err := docRepo.Delete(ctx, "doc-search-deleted"); err != nil {
+		t.Fatalf("delete document err: %v", err)
+	}
+
+	hits, err := repo.SearchDocuments(ctx, "zephyr:*", "", 10)
+	if err != nil {
+		t.Fatalf("search err: %v", err)
+	}
+	if len(hits) != 2 || hits[0].DocID != "doc-search-title" || hits[1].DocID != "doc-search-desc" {
+		t.Fatalf("expected title match ranked before description match, got %+v", hits)
+	}
+
+	// URL parts are searchable on their own
+	hits, err = repo.SearchDocuments(ctx, "intranet:* & charter:*", "", 10)
+	if err != nil {
+		t.Fatalf("search err: %v", err)
+	}
+	if len(hits) != 1 || hits[0].DocID != "doc-search-title" {
+		t.Fatalf("expected URL match, got %+v", hits)
+	}
+}
+
+func TestSearchRepository_SearchSigners(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider)
+	sigRepo := NewSignatureRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewSearchRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-search-signers", models.DocumentInput{Title: "Policy"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	contacts := []models.ContactInfo{{Name: "Quillon Ravel", Email: "quillon@example.com"}}
+	if err := expectedRepo.AddExpected(ctx, "doc-search-signers", contacts, "owner@example.com"); err != nil {
+		t.Fatalf("add expected err: %v", err)
+	}
+
+	factory := NewSignatureFactory()
+	expected := factory.CreateSignatureWithDocAndUser("doc-search-signers", "sub-quillon", "quillon@example.com")
+	if err := sigRepo.Create(ctx, expected); err != nil {
+		t.Fatalf("create signature err: %v", err)
+	}
+	walkIn := factory.CreateSignatureWithDocAndUser("doc-search-signers", "sub-walkin", "quillon.walkin@example.com")
+	walkIn.Nonce = "nonce-walkin"
+	if err := sigRepo.Create(ctx, walkIn); err != nil {
+		t.Fatalf("create signature err: %v", err)
+	}
+
+	hits, err := repo.SearchSigners(ctx, "quillon:*", "", 10)
+	if err != nil {
+		t.Fatalf("search err: %v", err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("expected expected signer and walk-in signer once each, got %+v", hits)
+	}
+	for _, hit := range hits {
+		if !hit.Signed || hit.DocTitle != "Policy" {
+			t.Errorf("unexpected hit %+v", hit)
+		}
+	}
+}
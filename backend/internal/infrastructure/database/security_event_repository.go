@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const securityEventColumns = `id, tenant_id, kind, scope, subject, failures, created_at, alerted_at, acknowledged_at, COALESCE(acknowledged_by, '')`
+
+// SecurityEventRepository stores failed authentication attempts and the security events raised
+// when a user or IP exceeds the alert threshold
+type SecurityEventRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSecurityEventRepository creates a new security event repository
+func NewSecurityEventRepository(db *sql.DB, tenants providers.TenantProvider) *SecurityEventRepository {
+	return &SecurityEventRepository{db: db, tenants: tenants}
+}
+
+// RecordFailure stores a failed attempt.
+// It is written in its own transaction, since the failed request is usually rolled back.
+func (r *SecurityEventRepository) RecordFailure(ctx context.Context, kind, userEmail, ip string) error {
+	query := `
+		INSERT INTO auth_failures (tenant_id, kind, user_email, ip_address)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))`
+
+	err := r.inOwnTx(ctx, func(txCtx context.Context, tenantID string) error {
+		_, err := dbctx.GetQuerier(txCtx, r.db).ExecContext(txCtx, query, tenantID, kind, userEmail, ip)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record auth failure: %w", err)
+	}
+	return nil
+}
+
+// CountFailures counts the failed attempts of a kind by a user or IP since a given time
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) CountFailures(ctx context.Context, kind, scope, subject string, since time.Time) (int, error) {
+	column := "user_email"
+	if scope == models.RateLimitScopeIP {
+		column = "ip_address"
+	}
+	query := `SELECT COUNT(*) FROM auth_failures
+		WHERE kind = $1 AND ` + column + ` = $2 AND created_at > $3`
+
+	var count int
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, kind, subject, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count auth failures: %w", err)
+	}
+	return count, nil
+}
+
+// CreateEvent raises a security event unless one was already raised for the same kind and
+// subject since a given time. It reports whether the event was created; it is written in its
+// own transaction, like the failure that triggered it.
+func (r *SecurityEventRepository) CreateEvent(ctx context.Context, event *models.SecurityEvent, since time.Time) (bool, error) {
+	query := `
+		INSERT INTO security_events (tenant_id, kind, scope, subject, failures)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM security_events
+			WHERE kind = $2 AND scope = $3 AND subject = $4 AND created_at > $6
+		)
+		RETURNING ` + securityEventColumns
+
+	created := false
+	err := r.inOwnTx(ctx, func(txCtx context.Context, tenantID string) error {
+		err := scanSecurityEvent(dbctx.GetQuerier(txCtx, r.db).QueryRowContext(txCtx, query,
+			tenantID, event.Kind, event.Scope, event.Subject, event.Failures, since), event)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		created = err == nil
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to create security event: %w", err)
+	}
+	return created, nil
+}
+
+// ListEvents returns the events raised since a given time, newest first
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) ListEvents(ctx context.Context, since time.Time, unacknowledgedOnly bool, limit int) ([]*models.SecurityEvent, error) {
+	query := `SELECT ` + securityEventColumns + `
+		FROM security_events
+		WHERE created_at > $1 AND (NOT $2 OR acknowledged_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3`
+
+	return r.queryEvents(ctx, query, since, unacknowledgedOnly, limit)
+}
+
+// ListPendingAlerts returns the events whose alert was not sent yet, oldest first
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) ListPendingAlerts(ctx context.Context, limit int) ([]*models.SecurityEvent, error) {
+	query := `SELECT ` + securityEventColumns + `
+		FROM security_events
+		WHERE alerted_at IS NULL
+		ORDER BY created_at, id
+		LIMIT $1`
+
+	return r.queryEvents(ctx, query, limit)
+}
+
+// MarkAlerted records that the alert of an event was sent
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) MarkAlerted(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE security_events SET alerted_at = $2 WHERE id = $1`
+
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, at); err != nil {
+		return fmt.Errorf("failed to mark security event alerted: %w", err)
+	}
+	return nil
+}
+
+// Acknowledge marks an event as reviewed by an admin
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*models.SecurityEvent, error) {
+	query := `
+		UPDATE security_events
+		SET acknowledged_at = COALESCE(acknowledged_at, now()),
+			acknowledged_by = COALESCE(acknowledged_by, $2)
+		WHERE id = $1
+		RETURNING ` + securityEventColumns
+
+	event := &models.SecurityEvent{}
+	err := scanSecurityEvent(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id, acknowledgedBy), event)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSecurityEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge security event: %w", err)
+	}
+	return event, nil
+}
+
+// CountFailuresByKind counts the failed attempts since a given time, and the distinct IPs
+// they came from, by kind
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) CountFailuresByKind(ctx context.Context, since time.Time) ([]models.AuthFailureCount, error) {
+	query := `
+		SELECT kind, COUNT(*), COUNT(DISTINCT ip_address)
+		FROM auth_failures
+		WHERE created_at > $1
+		GROUP BY kind
+		ORDER BY kind`
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count auth failures: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.AuthFailureCount
+	for rows.Next() {
+		var c models.AuthFailureCount
+		if err := rows.Scan(&c.Kind, &c.Failures, &c.IPs); err != nil {
+			return nil, fmt.Errorf("failed to scan auth failure count: %w", err)
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// DeleteBefore removes failures older than failuresBefore and events older than eventsBefore
+// RLS policy automatically filters by tenant_id
+func (r *SecurityEventRepository) DeleteBefore(ctx context.Context, failuresBefore, eventsBefore time.Time) (int64, error) {
+	q := dbctx.GetQuerier(ctx, r.db)
+
+	failures, err := q.ExecContext(ctx, `DELETE FROM auth_failures WHERE created_at < $1`, failuresBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete auth failures: %w", err)
+	}
+	events, err := q.ExecContext(ctx, `DELETE FROM security_events WHERE created_at < $1`, eventsBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete security events: %w", err)
+	}
+
+	deletedFailures, _ := failures.RowsAffected()
+	deletedEvents, _ := events.RowsAffected()
+	return deletedFailures + deletedEvents, nil
+}
+
+// inOwnTx runs fn in a transaction of its own for the current tenant
+func (r *SecurityEventRepository) inOwnTx(ctx context.Context, fn func(txCtx context.Context, tenantID string) error) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+		return fn(txCtx, tenantID.String())
+	})
+}
+
+func (r *SecurityEventRepository) queryEvents(ctx context.Context, query string, args ...interface{}) ([]*models.SecurityEvent, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*models.SecurityEvent
+	for rows.Next() {
+		event := &models.SecurityEvent{}
+		if err := scanSecurityEvent(rows, event); err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+		out = append(out, event)
+	}
+	return out, rows.Err()
+}
+
+func scanSecurityEvent(row interface{ Scan(...interface{}) error }, event *models.SecurityEvent) error {
+	var alertedAt, acknowledgedAt sql.NullTime
+	err := row.Scan(&event.ID, &event.TenantID, &event.Kind, &event.Scope, &event.Subject, &event.Failures,
+		&event.CreatedAt, &alertedAt, &acknowledgedAt, &event.AcknowledgedBy)
+	if err != nil {
+		return err
+	}
+	if alertedAt.Valid {
+		event.AlertedAt = &alertedAt.Time
+	}
+	if acknowledgedAt.Valid {
+		event.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	return nil
+}
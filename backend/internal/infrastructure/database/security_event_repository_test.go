@@ -0,0 +1,151 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSecurityEventRepository_Failures(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSecurityEventRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	failures := []struct{ kind, email, ip string }{
+		{models.AuthFailureCSRF, "alice@example.com", "10.0.0.1"},
+		{models.AuthFailureCSRF, "alice@example.com", "10.0.0.2"},
+		{models.AuthFailureMagicLink, "", "10.0.0.1"},
+	}
+	for _, f := range failures {
+		if err := repo.RecordFailure(ctx, f.kind, f.email, f.ip); err != nil {
+			t.Fatalf("record err: %v", err)
+		}
+	}
+
+	count, err := repo.CountFailures(ctx, models.AuthFailureCSRF, models.RateLimitScopeUser, "alice@example.com", since)
+	if err != nil {
+		t.Fatalf("count err: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 CSRF failures for alice, got %d", count)
+	}
+	count, err = repo.CountFailures(ctx, models.AuthFailureCSRF, models.RateLimitScopeIP, "10.0.0.1", since)
+	if err != nil {
+		t.Fatalf("count err: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected failures to be counted per kind, got %d", count)
+	}
+
+	counts, err := repo.CountFailuresByKind(ctx, since)
+	if err != nil {
+		t.Fatalf("count by kind err: %v", err)
+	}
+	if len(counts) != 2 || counts[0].Kind != models.AuthFailureCSRF || counts[0].Failures != 2 || counts[0].IPs != 2 {
+		t.Errorf("unexpected counts by kind: %+v", counts)
+	}
+}
+
+func TestSecurityEventRepository_Events(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSecurityEventRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	since := time.Now().Add(-time.Hour)
+
+	event := &models.SecurityEvent{Kind: models.AuthFailureMagicLink, Scope: models.RateLimitScopeIP, Subject: "10.0.0.1", Failures: 10}
+	created, err := repo.CreateEvent(ctx, event, since)
+	if err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+	if !created || event.ID == 0 || event.CreatedAt.IsZero() {
+		t.Fatalf("expected the event to be created, got %+v", event)
+	}
+
+	duplicate := &models.SecurityEvent{Kind: models.AuthFailureMagicLink, Scope: models.RateLimitScopeIP, Subject: "10.0.0.1", Failures: 11}
+	created, err = repo.CreateEvent(ctx, duplicate, since)
+	if err != nil {
+		t.Fatalf("create duplicate err: %v", err)
+	}
+	if created {
+		t.Error("expected a single event per subject within the window")
+	}
+
+	pending, err := repo.ListPendingAlerts(ctx, 10)
+	if err != nil {
+		t.Fatalf("list pending err: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != event.ID {
+		t.Fatalf("expected the event pending alert, got %+v", pending)
+	}
+	if err := repo.MarkAlerted(ctx, event.ID, time.Now()); err != nil {
+		t.Fatalf("mark alerted err: %v", err)
+	}
+	pending, err = repo.ListPendingAlerts(ctx, 10)
+	if err != nil {
+		t.Fatalf("list pending err: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected no pending alert, got %d", len(pending))
+	}
+
+	acknowledged, err := repo.Acknowledge(ctx, event.ID, "admin@example.com")
+	if err != nil {
+		t.Fatalf("acknowledge err: %v", err)
+	}
+	if acknowledged.AcknowledgedAt == nil || acknowledged.AcknowledgedBy != "admin@example.com" || acknowledged.AlertedAt == nil {
+		t.Errorf("unexpected acknowledged event: %+v", acknowledged)
+	}
+	if _, err := repo.Acknowledge(ctx, event.ID+1000, "admin@example.com"); !errors.Is(err, models.ErrSecurityEventNotFound) {
+		t.Errorf("expected ErrSecurityEventNotFound, got %v", err)
+	}
+
+	all, err := repo.ListEvents(ctx, since, false, 10)
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	unacknowledged, err := repo.ListEvents(ctx, since, true, 10)
+	if err != nil {
+		t.Fatalf("list unacknowledged err: %v", err)
+	}
+	if len(all) != 1 || len(unacknowledged) != 0 {
+		t.Errorf("expected 1 event and none unacknowledged, got %d/%d", len(all), len(unacknowledged))
+	}
+}
+
+func TestSecurityEventRepository_DeleteBefore(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSecurityEventRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if err := repo.RecordFailure(ctx, models.AuthFailureOAuthCallback, "", "10.0.0.1"); err != nil {
+		t.Fatalf("record err: %v", err)
+	}
+	event := &models.SecurityEvent{Kind: models.AuthFailureOAuthCallback, Scope: models.RateLimitScopeIP, Subject: "10.0.0.1", Failures: 1}
+	if _, err := repo.CreateEvent(ctx, event, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+
+	// Failures expire before events
+	deleted, err := repo.DeleteBefore(ctx, time.Now().Add(time.Minute), time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("delete err: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected the failure only to be deleted, got %d", deleted)
+	}
+	events, err := repo.ListEvents(ctx, time.Now().Add(-time.Hour), false, 10)
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected the event to be kept, got %d", len(events))
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const shortLinkColumns = `id, code, doc_id, locale, scan_count, last_scanned_at, created_by, created_at`
+
+// ShortLinkRepository handles database operations for document short links
+type ShortLinkRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewShortLinkRepository creates a new short link repository
+func NewShortLinkRepository(db *sql.DB, tenants providers.TenantProvider) *ShortLinkRepository {
+	return &ShortLinkRepository{db: db, tenants: tenants}
+}
+
+func scanShortLink(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.ShortLink, error) {
+	l := &models.ShortLink{}
+	if err := scanner.Scan(&l.ID, &l.Code, &l.DocID, &l.Locale, &l.ScanCount, &l.LastScannedAt,
+		&l.CreatedBy, &l.CreatedAt); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetOrCreate returns the link of a document for a locale, creating it with the given code
+// when the document has none yet. An existing link keeps its code, so printed notices stay valid.
+func (r *ShortLinkRepository) GetOrCreate(ctx context.Context, docID, locale, code, createdBy string) (*models.ShortLink, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_short_links (tenant_id, code, doc_id, locale, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, doc_id, locale) DO UPDATE SET locale = EXCLUDED.locale
+		RETURNING ` + shortLinkColumns
+
+	link, err := scanShortLink(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, code, docID, locale, createdBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create short link: %w", err)
+	}
+	return link, nil
+}
+
+// ListByDoc retrieves the links of a document, the one without locale first
+// RLS policy automatically filters by tenant_id
+func (r *ShortLinkRepository) ListByDoc(ctx context.Context, docID string) ([]*models.ShortLink, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx,
+		`SELECT `+shortLinkColumns+` FROM document_short_links WHERE doc_id = $1 ORDER BY locale ASC`, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query short links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*models.ShortLink{}
+	for rows.Next() {
+		link, err := scanShortLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan short link: %w", err)
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate short links: %w", err)
+	}
+	return links, nil
+}
+
+// RecordScan counts a visit of a link and returns the link
+// RLS policy automatically filters by tenant_id
+func (r *ShortLinkRepository) RecordScan(ctx context.Context, code string) (*models.ShortLink, error) {
+	query := `
+		UPDATE document_short_links
+		SET scan_count = scan_count + 1, last_scanned_at = now()
+		WHERE code = $1
+		RETURNING ` + shortLinkColumns
+
+	link, err := scanShortLink(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, code))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrShortLinkNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record short link scan: %w", err)
+	}
+	return link, nil
+}
+
+// Delete removes a link of a document; printed notices using it stop working
+// RLS policy automatically filters by tenant_id
+func (r *ShortLinkRepository) Delete(ctx context.Context, docID, code string) error {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM document_short_links WHERE doc_id = $1 AND code = $2`, docID, code)
+	if err != nil {
+		return fmt.Errorf("failed to delete short link: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return models.ErrShortLinkNotFound
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestShortLinkRepository(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewShortLinkRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	docID := "doc-short-link-test"
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Safety notice"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	link, err := repo.GetOrCreate(ctx, docID, "", "Abcd1234", "admin@example.com")
+	if err != nil || link.Code != "Abcd1234" || link.ScanCount != 0 {
+		t.Fatalf("unexpected link %+v, %v", link, err)
+	}
+	// The existing link of a locale keeps its code
+	again, err := repo.GetOrCreate(ctx, docID, "", "Zyxw9876", "other@example.com")
+	if err != nil || again.ID != link.ID || again.Code != "Abcd1234" {
+		t.Fatalf("expected the existing link, got %+v, %v", again, err)
+	}
+	fr, err := repo.GetOrCreate(ctx, docID, "fr", "Frfr1234", "admin@example.com")
+	if err != nil || fr.ID == link.ID || fr.Locale != "fr" {
+		t.Fatalf("unexpected fr link %+v, %v", fr, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := repo.RecordScan(ctx, "Frfr1234"); err != nil {
+			t.Fatalf("RecordScan err: %v", err)
+		}
+	}
+	links, err := repo.ListByDoc(ctx, docID)
+	if err != nil || len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d, %v", len(links), err)
+	}
+	if links[0].Locale != "" || links[1].ScanCount != 2 || links[1].LastScannedAt == nil {
+		t.Errorf("unexpected links %+v %+v", links[0], links[1])
+	}
+
+	if _, err := repo.RecordScan(ctx, "unknown"); !errors.Is(err, models.ErrShortLinkNotFound) {
+		t.Errorf("expected ErrShortLinkNotFound, got %v", err)
+	}
+	if err := repo.Delete(ctx, docID, "Frfr1234"); err != nil {
+		t.Fatalf("Delete err: %v", err)
+	}
+	if err := repo.Delete(ctx, docID, "Frfr1234"); !errors.Is(err, models.ErrShortLinkNotFound) {
+		t.Errorf("expected ErrShortLinkNotFound, got %v", err)
+	}
+}
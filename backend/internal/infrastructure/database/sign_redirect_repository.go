@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const signRedirectColumns = `tenant_id, doc_id, url, COALESCE(updated_by, ''), created_at, updated_at`
+
+// SignRedirectRepository handles database operations for the per-document redirects after signing
+type SignRedirectRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignRedirectRepository creates a new sign redirect repository
+func NewSignRedirectRepository(db *sql.DB, tenants providers.TenantProvider) *SignRedirectRepository {
+	return &SignRedirectRepository{db: db, tenants: tenants}
+}
+
+func scanSignRedirect(row interface{ Scan(dest ...any) error }) (*models.SignRedirect, error) {
+	s := &models.SignRedirect{}
+	if err := row.Scan(&s.TenantID, &s.DocID, &s.URL, &s.UpdatedBy, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetByDocID returns the redirect of a document, or nil if none is configured
+// RLS policy automatically filters by tenant_id
+func (r *SignRedirectRepository) GetByDocID(ctx context.Context, docID string) (*models.SignRedirect, error) {
+	query := `SELECT ` + signRedirectColumns + ` FROM document_sign_redirects WHERE doc_id = $1`
+
+	s, err := scanSignRedirect(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sign redirect: %w", err)
+	}
+	return s, nil
+}
+
+// Upsert creates or replaces the redirect of a document
+func (r *SignRedirectRepository) Upsert(ctx context.Context, docID, url, updatedBy string) (*models.SignRedirect, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO document_sign_redirects (tenant_id, doc_id, url, updated_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET url = EXCLUDED.url,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING ` + signRedirectColumns
+
+	s, err := scanSignRedirect(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, tenantID, docID, url, updatedBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert sign redirect: %w", err)
+	}
+	return s, nil
+}
+
+// Delete removes the redirect of a document
+// RLS policy automatically filters by tenant_id
+func (r *SignRedirectRepository) Delete(ctx context.Context, docID string) (bool, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM document_sign_redirects WHERE doc_id = $1`, docID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete sign redirect: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,47 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignRedirectRepository_Upsert(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewSignRedirectRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-redirect", models.DocumentInput{Title: "Doc"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	redirect, err := repo.GetByDocID(ctx, "doc-redirect")
+	if err != nil || redirect != nil {
+		t.Fatalf("expected no redirect, got %+v (err %v)", redirect, err)
+	}
+
+	if _, err := repo.Upsert(ctx, "doc-redirect", "https://intranet.example.com/policies", "admin@example.com"); err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	redirect, err = repo.Upsert(ctx, "doc-redirect", "https://survey.example.com/thanks", "")
+	if err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	if redirect.URL != "https://survey.example.com/thanks" || redirect.UpdatedBy != "" {
+		t.Fatalf("unexpected redirect: %+v", redirect)
+	}
+
+	deleted, err := repo.Delete(ctx, "doc-redirect")
+	if err != nil || !deleted {
+		t.Fatalf("expected the redirect to be deleted, got %v (err %v)", deleted, err)
+	}
+	if redirect, _ := repo.GetByDocID(ctx, "doc-redirect"); redirect != nil {
+		t.Fatalf("expected no redirect after delete, got %+v", redirect)
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const signatureApprovalColumns = `id, tenant_id, signature_id, doc_id, user_email, user_name, status, reviewed_by, reviewed_at, created_at`
+
+// SignatureApprovalRepository handles database operations for the signatures held for admin approval
+type SignatureApprovalRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignatureApprovalRepository creates a new signature approval repository
+func NewSignatureApprovalRepository(db *sql.DB, tenants providers.TenantProvider) *SignatureApprovalRepository {
+	return &SignatureApprovalRepository{db: db, tenants: tenants}
+}
+
+func scanSignatureApproval(row interface{ Scan(dest ...any) error }) (*models.SignatureApproval, error) {
+	a := &models.SignatureApproval{}
+	var reviewedBy sql.NullString
+	var reviewedAt sql.NullTime
+	err := row.Scan(&a.ID, &a.TenantID, &a.SignatureID, &a.DocID, &a.UserEmail, &a.UserName, &a.Status,
+		&reviewedBy, &reviewedAt, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if reviewedBy.Valid {
+		a.ReviewedBy = &reviewedBy.String
+	}
+	if reviewedAt.Valid {
+		a.ReviewedAt = &reviewedAt.Time
+	}
+	return a, nil
+}
+
+// HoldIfUnexpected holds a signature for approval when its document has expected signers and the
+// signer is not one of them. It returns nil when the signature was expected.
+// RLS policy automatically filters by tenant_id
+func (r *SignatureApprovalRepository) HoldIfUnexpected(ctx context.Context, signatureID int64) (*models.SignatureApproval, error) {
+	query := `
+		INSERT INTO signature_approvals (tenant_id, signature_id, doc_id, user_email, user_name)
+		SELECT s.tenant_id, s.id, s.doc_id, s.user_email, COALESCE(s.user_name, '')
+		FROM signatures s
+		WHERE s.id = $1
+		  AND EXISTS (
+			SELECT 1 FROM expected_signers es WHERE es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM expected_signers es
+			WHERE es.tenant_id = s.tenant_id AND es.doc_id = s.doc_id AND es.match_key = s.match_key
+		  )
+		ON CONFLICT (signature_id) DO NOTHING
+		RETURNING ` + signatureApprovalColumns
+
+	a, err := scanSignatureApproval(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, signatureID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold signature for approval: %w", err)
+	}
+	return a, nil
+}
+
+// List returns the held signatures with a status, or all of them when status is empty, newest first.
+// An empty docID lists the signatures of every document.
+// RLS policy automatically filters by tenant_id
+func (r *SignatureApprovalRepository) List(ctx context.Context, docID string, status models.ApprovalStatus) ([]*models.SignatureApproval, error) {
+	query := `SELECT ` + signatureApprovalColumns + ` FROM signature_approvals
+		WHERE ($1 = '' OR doc_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signature approvals: %w", err)
+	}
+	defer rows.Close()
+
+	approvals := []*models.SignatureApproval{}
+	for rows.Next() {
+		a, err := scanSignatureApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signature approval: %w", err)
+		}
+		approvals = append(approvals, a)
+	}
+	return approvals, rows.Err()
+}
+
+// ListHeldSignatureIDs returns the IDs of the signatures of a document that are pending or rejected
+// RLS policy automatically filters by tenant_id
+func (r *SignatureApprovalRepository) ListHeldSignatureIDs(ctx context.Context, docID string) (map[int64]bool, error) {
+	query := `SELECT signature_id FROM signature_approvals WHERE doc_id = $1 AND status <> 'approved'`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list held signatures: %w", err)
+	}
+	defer rows.Close()
+
+	held := map[int64]bool{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan held signature: %w", err)
+		}
+		held[id] = true
+	}
+	return held, rows.Err()
+}
+
+// GetByID returns a held signature
+// RLS policy automatically filters by tenant_id
+func (r *SignatureApprovalRepository) GetByID(ctx context.Context, id int64) (*models.SignatureApproval, error) {
+	query := `SELECT ` + signatureApprovalColumns + ` FROM signature_approvals WHERE id = $1`
+
+	a, err := scanSignatureApproval(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrApprovalNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signature approval: %w", err)
+	}
+	return a, nil
+}
+
+// MarkReviewed records the decision on a pending signature.
+// It returns ErrApprovalReviewed when another admin decided first.
+// RLS policy automatically filters by tenant_id
+func (r *SignatureApprovalRepository) MarkReviewed(ctx context.Context, id int64, status models.ApprovalStatus, reviewedBy string) (*models.SignatureApproval, error) {
+	query := `
+		UPDATE signature_approvals
+		SET status = $2, reviewed_by = $3, reviewed_at = now()
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + signatureApprovalColumns
+
+	a, err := scanSignatureApproval(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id, status, reviewedBy))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, models.ErrApprovalReviewed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to review signature approval: %w", err)
+	}
+	return a, nil
+}
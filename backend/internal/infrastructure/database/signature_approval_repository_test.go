@@ -0,0 +1,73 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignatureApprovalRepository_Review(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	seedDocumentStatus(t, tdb, "approval-doc", 2)
+	repo := NewSignatureApprovalRepository(tdb.DB, tdb.TenantProvider)
+
+	signatures, err := NewSignatureRepository(tdb.DB, tdb.TenantProvider).GetByDoc(ctx, "approval-doc")
+	if err != nil {
+		t.Fatalf("get signatures err: %v", err)
+	}
+	var expectedID, unexpectedID int64
+	for _, sig := range signatures {
+		if sig.UserEmail == "outsider@example.com" {
+			unexpectedID = sig.ID
+		} else {
+			expectedID = sig.ID
+		}
+	}
+
+	// Expected signers are never held
+	if held, err := repo.HoldIfUnexpected(ctx, expectedID); err != nil || held != nil {
+		t.Fatalf("expected no hold, got %+v (err %v)", held, err)
+	}
+	held, err := repo.HoldIfUnexpected(ctx, unexpectedID)
+	if err != nil || held == nil || held.Status != models.ApprovalStatusPending || held.UserEmail != "outsider@example.com" {
+		t.Fatalf("unexpected hold: %+v (err %v)", held, err)
+	}
+
+	ids, err := repo.ListHeldSignatureIDs(ctx, "approval-doc")
+	if err != nil || len(ids) != 1 || !ids[unexpectedID] {
+		t.Fatalf("unexpected held IDs: %v (err %v)", ids, err)
+	}
+	status, err := NewDocumentRepository(tdb.DB, tdb.TenantProvider).GetStatus(ctx, "approval-doc")
+	if err != nil || len(status.UnexpectedSignatures) != 1 || status.UnexpectedSignatures[0].ApprovalID != held.ID ||
+		status.UnexpectedSignatures[0].ApprovalStatus != models.ApprovalStatusPending {
+		t.Fatalf("unexpected status: %+v (err %v)", status, err)
+	}
+
+	approved, err := repo.MarkReviewed(ctx, held.ID, models.ApprovalStatusApproved, "admin@example.com")
+	if err != nil || approved.ReviewedBy == nil || approved.ReviewedAt == nil {
+		t.Fatalf("unexpected review: %+v (err %v)", approved, err)
+	}
+	if ids, _ := repo.ListHeldSignatureIDs(ctx, "approval-doc"); len(ids) != 0 {
+		t.Fatalf("approved signatures must count, got held %v", ids)
+	}
+
+	// A decision is final
+	if _, err := repo.MarkReviewed(ctx, held.ID, models.ApprovalStatusRejected, "admin@example.com"); !errors.Is(err, models.ErrApprovalReviewed) {
+		t.Fatalf("expected ErrApprovalReviewed, got %v", err)
+	}
+	if _, err := repo.MarkReviewed(ctx, held.ID+1, models.ApprovalStatusRejected, "admin@example.com"); !errors.Is(err, models.ErrApprovalNotFound) {
+		t.Fatalf("expected ErrApprovalNotFound, got %v", err)
+	}
+
+	approvals, err := repo.List(ctx, "", models.ApprovalStatusApproved)
+	if err != nil || len(approvals) != 1 {
+		t.Fatalf("unexpected approvals: %+v (err %v)", approvals, err)
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const signatureHookColumns = `tenant_id, doc_id, url, COALESCE(secret, ''), timeout_ms, failure_policy, COALESCE(updated_by, ''), created_at, updated_at`
+
+// SignatureHookRepository handles database operations for per-document signature validation hooks
+type SignatureHookRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignatureHookRepository creates a new signature hook repository
+func NewSignatureHookRepository(db *sql.DB, tenants providers.TenantProvider) *SignatureHookRepository {
+	return &SignatureHookRepository{db: db, tenants: tenants}
+}
+
+func scanSignatureHook(row interface{ Scan(dest ...any) error }) (*models.SignatureHook, error) {
+	h := &models.SignatureHook{}
+	err := row.Scan(
+		&h.TenantID, &h.DocID, &h.URL, &h.Secret, &h.TimeoutMs, &h.FailurePolicy, &h.UpdatedBy, &h.CreatedAt, &h.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	h.HasSecret = h.Secret != ""
+	return h, nil
+}
+
+// GetByDocID returns the validation hook of a document, or nil if none is configured
+// RLS policy automatically filters by tenant_id
+func (r *SignatureHookRepository) GetByDocID(ctx context.Context, docID string) (*models.SignatureHook, error) {
+	query := `SELECT ` + signatureHookColumns + ` FROM signature_hooks WHERE doc_id = $1`
+
+	h, err := scanSignatureHook(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signature hook: %w", err)
+	}
+	return h, nil
+}
+
+// Upsert creates or replaces the validation hook of a document. An empty secret keeps the stored one.
+func (r *SignatureHookRepository) Upsert(ctx context.Context, docID string, input models.SignatureHookInput, updatedBy string) (*models.SignatureHook, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO signature_hooks (tenant_id, doc_id, url, secret, timeout_ms, failure_policy, updated_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''))
+		ON CONFLICT (tenant_id, doc_id) DO UPDATE
+		SET url = EXCLUDED.url,
+			secret = COALESCE(EXCLUDED.secret, signature_hooks.secret),
+			timeout_ms = EXCLUDED.timeout_ms,
+			failure_policy = EXCLUDED.failure_policy,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING ` + signatureHookColumns
+
+	h, err := scanSignatureHook(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, docID, input.URL, input.Secret, input.TimeoutMs, input.FailurePolicy, updatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert signature hook: %w", err)
+	}
+	return h, nil
+}
+
+// Delete removes the validation hook of a document
+// RLS policy automatically filters by tenant_id
+func (r *SignatureHookRepository) Delete(ctx context.Context, docID string) (bool, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM signature_hooks WHERE doc_id = $1`, docID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete signature hook: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
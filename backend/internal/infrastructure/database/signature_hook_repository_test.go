@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignatureHookRepository_Upsert(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	docRepo := NewDocumentRepository(tdb.DB, tdb.TenantProvider)
+	repo := NewSignatureHookRepository(tdb.DB, tdb.TenantProvider)
+
+	if _, err := docRepo.Create(ctx, "doc-hook", models.DocumentInput{Title: "Doc"}, "owner@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+
+	hook, err := repo.GetByDocID(ctx, "doc-hook")
+	if err != nil {
+		t.Fatalf("get hook err: %v", err)
+	}
+	if hook != nil {
+		t.Fatalf("expected no hook, got %+v", hook)
+	}
+
+	hook, err = repo.Upsert(ctx, "doc-hook", models.SignatureHookInput{
+		URL: "https://lms.example.com/check", Secret: "s3cret", TimeoutMs: 2000, FailurePolicy: models.SignatureHookFailOpen,
+	}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("upsert err: %v", err)
+	}
+	if hook.URL != "https://lms.example.com/check" || hook.Secret != "s3cret" || !hook.HasSecret || hook.TimeoutMs != 2000 || hook.FailurePolicy != models.SignatureHookFailOpen {
+		t.Fatalf("unexpected hook: %+v", hook)
+	}
+
+	// An empty secret keeps the stored one
+	hook, err = repo.Upsert(ctx, "doc-hook", models.SignatureHookInput{
+		URL: "https://lms.example.com/v2/check", TimeoutMs: 3000, FailurePolicy: models.SignatureHookFailClosed,
+	}, "admin@example.com")
+	if err != nil {
+		t.Fatalf("second upsert err: %v", err)
+	}
+	if hook.URL != "https://lms.example.com/v2/check" || hook.Secret != "s3cret" || hook.FailurePolicy != models.SignatureHookFailClosed {
+		t.Fatalf("unexpected hook after update: %+v", hook)
+	}
+
+	deleted, err := repo.Delete(ctx, "doc-hook")
+	if err != nil || !deleted {
+		t.Fatalf("expected the hook to be deleted, got %v, %v", deleted, err)
+	}
+	deleted, err = repo.Delete(ctx, "doc-hook")
+	if err != nil || deleted {
+		t.Fatalf("expected nothing to delete, got %v, %v", deleted, err)
+	}
+}
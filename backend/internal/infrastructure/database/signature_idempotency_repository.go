@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// SignatureIdempotencyRepository handles database operations for the idempotency keys of signature requests
+type SignatureIdempotencyRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignatureIdempotencyRepository creates a new signature idempotency key repository
+func NewSignatureIdempotencyRepository(db *sql.DB, tenants providers.TenantProvider) *SignatureIdempotencyRepository {
+	return &SignatureIdempotencyRepository{db: db, tenants: tenants}
+}
+
+// Reserve stores a key for a request in progress, replacing the same key once expired. It reports
+// false when the key is already stored; a concurrent request with the same key waits for the
+// transaction of the first one, then sees its key.
+func (r *SignatureIdempotencyRepository) Reserve(ctx context.Context, key *models.SignatureIdempotencyKey, now time.Time) (bool, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO signature_idempotency_keys (tenant_id, user_sub, idempotency_key, fingerprint, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, user_sub, idempotency_key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = 0, response = NULL,
+			expires_at = EXCLUDED.expires_at, created_at = now()
+		WHERE signature_idempotency_keys.expires_at <= $6
+		RETURNING created_at`
+
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, key.UserSub, key.Key, key.Fingerprint, key.ExpiresAt, now,
+	).Scan(&key.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return true, nil
+}
+
+// Get retrieves the key of a user, or nil when there is none
+// RLS policy automatically filters by tenant_id
+func (r *SignatureIdempotencyRepository) Get(ctx context.Context, userSub, key string) (*models.SignatureIdempotencyKey, error) {
+	query := `
+		SELECT idempotency_key, user_sub, fingerprint, status_code, response, expires_at, created_at
+		FROM signature_idempotency_keys
+		WHERE user_sub = $1 AND idempotency_key = $2`
+
+	k := &models.SignatureIdempotencyKey{}
+	var response []byte
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, userSub, key).Scan(
+		&k.Key, &k.UserSub, &k.Fingerprint, &k.StatusCode, &response, &k.ExpiresAt, &k.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	k.Response = response
+	return k, nil
+}
+
+// Complete stores the response of the request of a key
+// RLS policy automatically filters by tenant_id
+func (r *SignatureIdempotencyRepository) Complete(ctx context.Context, userSub, key string, statusCode int, response []byte) error {
+	_, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `
+		UPDATE signature_idempotency_keys SET status_code = $3, response = $4
+		WHERE user_sub = $1 AND idempotency_key = $2`, userSub, key, statusCode, response)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes the keys expired before the given time
+// RLS policy automatically filters by tenant_id
+func (r *SignatureIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM signature_idempotency_keys WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,58 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignatureIdempotencyRepository_ReserveOnce(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSignatureIdempotencyRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	now := time.Now()
+
+	key := &models.SignatureIdempotencyKey{Key: "key-1", UserSub: "user-1", Fingerprint: "fp-1", ExpiresAt: now.Add(time.Hour)}
+	if ok, err := repo.Reserve(ctx, key, now); err != nil || !ok {
+		t.Fatalf("expected the key reserved, got %v, %v", ok, err)
+	}
+	again := &models.SignatureIdempotencyKey{Key: "key-1", UserSub: "user-1", Fingerprint: "fp-2", ExpiresAt: now.Add(time.Hour)}
+	if ok, err := repo.Reserve(ctx, again, now); err != nil || ok {
+		t.Fatalf("expected the key already reserved, got %v, %v", ok, err)
+	}
+	other := &models.SignatureIdempotencyKey{Key: "key-1", UserSub: "user-2", Fingerprint: "fp-1", ExpiresAt: now.Add(time.Hour)}
+	if ok, err := repo.Reserve(ctx, other, now); err != nil || !ok {
+		t.Fatalf("expected keys to be scoped by user, got %v, %v", ok, err)
+	}
+
+	if err := repo.Complete(ctx, "user-1", "key-1", 201, []byte(`{"id":1}`)); err != nil {
+		t.Fatalf("Complete err: %v", err)
+	}
+	got, err := repo.Get(ctx, "user-1", "key-1")
+	if err != nil || got == nil || got.Fingerprint != "fp-1" || got.StatusCode != 201 || string(got.Response) != `{"id": 1}` {
+		t.Fatalf("unexpected key %+v, %v", got, err)
+	}
+	if missing, err := repo.Get(ctx, "user-1", "unknown"); err != nil || missing != nil {
+		t.Fatalf("expected no key, got %+v, %v", missing, err)
+	}
+
+	// An expired key is reserved again for the new request
+	later := now.Add(2 * time.Hour)
+	if ok, err := repo.Reserve(ctx, &models.SignatureIdempotencyKey{Key: "key-1", UserSub: "user-1", Fingerprint: "fp-3", ExpiresAt: later.Add(time.Hour)}, later); err != nil || !ok {
+		t.Fatalf("expected the expired key reserved again, got %v, %v", ok, err)
+	}
+	if got, _ := repo.Get(ctx, "user-1", "key-1"); got.Fingerprint != "fp-3" || got.StatusCode != 0 || got.Response != nil {
+		t.Errorf("expected a fresh key, got %+v", got)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, later)
+	if err != nil || deleted != 1 {
+		t.Errorf("expected the expired key of user-2 deleted, got %d, %v", deleted, err)
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// SignatureLedgerRepository records the signature hashes streamed to the external ledger
+type SignatureLedgerRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignatureLedgerRepository creates a new signature ledger repository
+func NewSignatureLedgerRepository(db *sql.DB, tenants providers.TenantProvider) *SignatureLedgerRepository {
+	return &SignatureLedgerRepository{db: db, tenants: tenants}
+}
+
+// Record stores the entries written to the ledger. An entry already recorded is kept as is,
+// since a batch may be streamed again after a failure.
+func (r *SignatureLedgerRepository) Record(ctx context.Context, entries []models.LedgerEntry) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	for _, entry := range entries {
+		_, err := q.ExecContext(ctx, `
+			INSERT INTO signature_ledger_entries (tenant_id, signature_id, record_hash, ledger_ref)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, signature_id) DO NOTHING`,
+			tenantID, entry.SignatureID, entry.RecordHash, entry.LedgerRef)
+		if err != nil {
+			return fmt.Errorf("failed to record ledger entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetCursor returns the last signature streamed and when, zero values when none was streamed yet
+// RLS policy automatically filters by tenant_id
+func (r *SignatureLedgerRepository) GetCursor(ctx context.Context) (int64, *time.Time, error) {
+	query := `SELECT COALESCE(MAX(signature_id), 0), MAX(streamed_at) FROM signature_ledger_entries`
+
+	var lastID int64
+	var streamedAt sql.NullTime
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query).Scan(&lastID, &streamedAt); err != nil {
+		return 0, nil, fmt.Errorf("failed to get ledger cursor: %w", err)
+	}
+	if !streamedAt.Valid {
+		return lastID, nil, nil
+	}
+	return lastID, &streamedAt.Time, nil
+}
+
+// CountPending counts the signatures above the cursor and returns the creation time of the oldest
+// RLS policy automatically filters by tenant_id
+func (r *SignatureLedgerRepository) CountPending(ctx context.Context, afterID int64) (int, *time.Time, error) {
+	query := `SELECT COUNT(*), MIN(created_at) FROM signatures WHERE id > $1`
+
+	var count int
+	var oldest sql.NullTime
+	if err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, afterID).Scan(&count, &oldest); err != nil {
+		return 0, nil, fmt.Errorf("failed to count signatures pending for the ledger: %w", err)
+	}
+	if !oldest.Valid {
+		return count, nil, nil
+	}
+	return count, &oldest.Time, nil
+}
+
+// ListAfterID returns, by signature ID, the entries recorded above afterID
+// RLS policy automatically filters by tenant_id
+func (r *SignatureLedgerRepository) ListAfterID(ctx context.Context, afterID int64, limit int) ([]models.LedgerEntry, error) {
+	query := `
+		SELECT tenant_id, signature_id, record_hash, ledger_ref, streamed_at
+		FROM signature_ledger_entries
+		WHERE signature_id > $1
+		ORDER BY signature_id ASC
+		LIMIT $2`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.LedgerEntry
+	for rows.Next() {
+		var entry models.LedgerEntry
+		if err := rows.Scan(&entry.TenantID, &entry.SignatureID, &entry.RecordHash, &entry.LedgerRef, &entry.StreamedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
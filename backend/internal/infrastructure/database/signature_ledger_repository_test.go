@@ -0,0 +1,69 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignatureLedgerRepository_CursorAndEntries(t *testing.T) {
+	testDB := SetupTestDB(t)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewSignatureLedgerRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	factory := NewSignatureFactory()
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		sig := factory.CreateSignatureWithUser(fmt.Sprintf("user-%d", i), fmt.Sprintf("user-%d@example.com", i))
+		if err := sigRepo.Create(ctx, sig); err != nil {
+			t.Fatalf("create signature err: %v", err)
+		}
+		ids = append(ids, sig.ID)
+	}
+
+	lastID, streamedAt, err := repo.GetCursor(ctx)
+	if err != nil || lastID != 0 || streamedAt != nil {
+		t.Fatalf("expected empty cursor, got %d, %v, %v", lastID, streamedAt, err)
+	}
+	pending, oldest, err := repo.CountPending(ctx, 0)
+	if err != nil || pending != 3 || oldest == nil {
+		t.Fatalf("expected 3 pending signatures, got %d, %v, %v", pending, oldest, err)
+	}
+
+	entries := []models.LedgerEntry{
+		{SignatureID: ids[0], RecordHash: "aGFzaC0x", LedgerRef: "postgres:1"},
+		{SignatureID: ids[1], RecordHash: "aGFzaC0y", LedgerRef: "postgres:2"},
+	}
+	if err := repo.Record(ctx, entries); err != nil {
+		t.Fatalf("record err: %v", err)
+	}
+	// Streaming a batch again keeps the first entries
+	entries[0].RecordHash = "b3RoZXI="
+	if err := repo.Record(ctx, entries); err != nil {
+		t.Fatalf("record again err: %v", err)
+	}
+
+	lastID, streamedAt, err = repo.GetCursor(ctx)
+	if err != nil || lastID != ids[1] || streamedAt == nil {
+		t.Fatalf("expected cursor %d, got %d, %v, %v", ids[1], lastID, streamedAt, err)
+	}
+	pending, _, err = repo.CountPending(ctx, lastID)
+	if err != nil || pending != 1 {
+		t.Fatalf("expected 1 pending signature, got %d, %v", pending, err)
+	}
+
+	listed, err := repo.ListAfterID(ctx, 0, 10)
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	if len(listed) != 2 || listed[0].SignatureID != ids[0] || listed[0].RecordHash != "aGFzaC0x" || listed[1].LedgerRef != "postgres:2" {
+		t.Errorf("unexpected entries: %+v", listed)
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// SignatureNonceRepository handles database operations for the single-use nonces of signature requests
+type SignatureNonceRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignatureNonceRepository creates a new signature nonce repository
+func NewSignatureNonceRepository(db *sql.DB, tenants providers.TenantProvider) *SignatureNonceRepository {
+	return &SignatureNonceRepository{db: db, tenants: tenants}
+}
+
+// Create stores a nonce issued to a signer
+func (r *SignatureNonceRepository) Create(ctx context.Context, nonce *models.SignatureNonce) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO signature_nonces (tenant_id, nonce, user_sub, doc_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`
+
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, nonce.Nonce, nonce.UserSub, nonce.DocID, nonce.ExpiresAt,
+	).Scan(&nonce.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create signature nonce: %w", err)
+	}
+	nonce.TenantID = tenantID
+	return nil
+}
+
+// Consume marks an unused, unexpired nonce of the user and document as used. It reports false
+// when no such nonce exists; the row lock makes concurrent requests with the same nonce wait
+// for the first one, so that only one of them consumes it.
+// RLS policy automatically filters by tenant_id
+func (r *SignatureNonceRepository) Consume(ctx context.Context, nonce, userSub, docID string, now time.Time) (bool, error) {
+	query := `
+		UPDATE signature_nonces SET used_at = $4
+		WHERE nonce = $1 AND user_sub = $2 AND doc_id = $3 AND used_at IS NULL AND expires_at > $4`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, nonce, userSub, docID, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to consume signature nonce: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to consume signature nonce: %w", err)
+	}
+	return affected == 1, nil
+}
+
+// Get retrieves a nonce, to tell why it could not be consumed
+// RLS policy automatically filters by tenant_id
+func (r *SignatureNonceRepository) Get(ctx context.Context, nonce string) (*models.SignatureNonce, error) {
+	query := `
+		SELECT tenant_id, nonce, user_sub, doc_id, expires_at, used_at, created_at
+		FROM signature_nonces
+		WHERE nonce = $1`
+
+	n := &models.SignatureNonce{}
+	var usedAt sql.NullTime
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, nonce).Scan(
+		&n.TenantID, &n.Nonce, &n.UserSub, &n.DocID, &n.ExpiresAt, &usedAt, &n.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrNonceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signature nonce: %w", err)
+	}
+	if usedAt.Valid {
+		n.UsedAt = &usedAt.Time
+	}
+	return n, nil
+}
+
+// DeleteExpired removes the nonces expired before the given time, used or not
+// RLS policy automatically filters by tenant_id
+func (r *SignatureNonceRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM signature_nonces WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired signature nonces: %w", err)
+	}
+	return result.RowsAffected()
+}
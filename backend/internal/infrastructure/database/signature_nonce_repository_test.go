@@ -0,0 +1,71 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignatureNonceRepository_ConsumeOnce(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSignatureNonceRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	now := time.Now()
+
+	nonce := &models.SignatureNonce{Nonce: "nonce-1", UserSub: "user-1", DocID: "doc-1", ExpiresAt: now.Add(5 * time.Minute)}
+	if err := repo.Create(ctx, nonce); err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+
+	if ok, err := repo.Consume(ctx, "nonce-1", "user-2", "doc-1", now); err != nil || ok {
+		t.Fatalf("expected a nonce of another user to be refused, got %v, %v", ok, err)
+	}
+	if ok, err := repo.Consume(ctx, "nonce-1", "user-1", "doc-1", now); err != nil || !ok {
+		t.Fatalf("expected the nonce consumed, got %v, %v", ok, err)
+	}
+	if ok, err := repo.Consume(ctx, "nonce-1", "user-1", "doc-1", now); err != nil || ok {
+		t.Fatalf("expected a replay to be refused, got %v, %v", ok, err)
+	}
+
+	got, err := repo.Get(ctx, "nonce-1")
+	if err != nil || got.UsedAt == nil || got.UserSub != "user-1" {
+		t.Fatalf("unexpected nonce %+v, %v", got, err)
+	}
+	if _, err := repo.Get(ctx, "missing"); !errors.Is(err, models.ErrNonceNotFound) {
+		t.Fatalf("expected ErrNonceNotFound, got %v", err)
+	}
+}
+
+func TestSignatureNonceRepository_Expiry(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSignatureNonceRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+	now := time.Now()
+
+	expired := &models.SignatureNonce{Nonce: "expired", UserSub: "user-1", DocID: "doc-1", ExpiresAt: now.Add(-time.Minute)}
+	valid := &models.SignatureNonce{Nonce: "valid", UserSub: "user-1", DocID: "doc-1", ExpiresAt: now.Add(time.Minute)}
+	for _, n := range []*models.SignatureNonce{expired, valid} {
+		if err := repo.Create(ctx, n); err != nil {
+			t.Fatalf("create err: %v", err)
+		}
+	}
+
+	if ok, err := repo.Consume(ctx, "expired", "user-1", "doc-1", now); err != nil || ok {
+		t.Fatalf("expected an expired nonce to be refused, got %v, %v", ok, err)
+	}
+
+	deleted, err := repo.DeleteExpired(ctx, now)
+	if err != nil || deleted != 1 {
+		t.Fatalf("expected one expired nonce deleted, got %d, %v", deleted, err)
+	}
+	if _, err := repo.Get(ctx, "valid"); err != nil {
+		t.Fatalf("expected the valid nonce kept, got %v", err)
+	}
+}
@@ -4,8 +4,10 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
@@ -30,6 +32,12 @@ func scanSignature(scanner interface {
 	var docChecksum sql.NullString
 	var hashVersion sql.NullInt64
 	var docDeletedAt sql.NullTime
+	var quizScore sql.NullInt64
+	var quizAnswers []byte
+	var locale sql.NullString
+	var consentVersion sql.NullInt64
+	var consentLocale sql.NullString
+	var consentText sql.NullString
 	var docTitle sql.NullString
 	var docURL sql.NullString
 	err := scanner.Scan(
@@ -49,6 +57,13 @@ func scanSignature(scanner interface {
 		&signature.PrevHash,
 		&hashVersion,
 		&docDeletedAt,
+		&quizScore,
+		&quizAnswers,
+		&signature.AuthMethod,
+		&locale,
+		&consentVersion,
+		&consentLocale,
+		&consentText,
 		&docTitle,
 		&docURL,
 	)
@@ -73,6 +88,22 @@ func scanSignature(scanner interface {
 	if docDeletedAt.Valid {
 		signature.DocDeletedAt = &docDeletedAt.Time
 	}
+	if quizScore.Valid {
+		score := int(quizScore.Int64)
+		signature.QuizScore = &score
+	}
+	if len(quizAnswers) > 0 {
+		if err := json.Unmarshal(quizAnswers, &signature.QuizAnswers); err != nil {
+			return fmt.Errorf("failed to decode quiz answers: %w", err)
+		}
+	}
+	signature.Locale = locale.String
+	if consentVersion.Valid {
+		version := int(consentVersion.Int64)
+		signature.ConsentVersion = &version
+		signature.ConsentLocale = consentLocale.String
+		signature.ConsentText = consentText.String
+	}
 	if docTitle.Valid {
 		signature.DocTitle = docTitle.String
 	}
@@ -90,8 +121,9 @@ func (r *SignatureRepository) Create(ctx context.Context, signature *models.Sign
 	}
 
 	query := `
-		INSERT INTO signatures (tenant_id, doc_id, user_sub, user_email, user_name, signed_at, doc_checksum, payload_hash, signature, nonce, referer, prev_hash)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO signatures (tenant_id, doc_id, user_sub, user_email, user_name, signed_at, doc_checksum, payload_hash, signature, nonce, referer, prev_hash, quiz_score, quiz_answers, auth_method,
+		                        locale, consent_version, consent_locale, consent_text)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''), $17, NULLIF($18, ''), NULLIF($19, ''))
 		RETURNING id, created_at
 	`
 
@@ -105,6 +137,19 @@ func (r *SignatureRepository) Create(ctx context.Context, signature *models.Sign
 		docChecksum = sql.NullString{String: signature.DocChecksum, Valid: true}
 	}
 
+	var quizAnswers []byte
+	if signature.QuizAnswers != nil {
+		quizAnswers, err = json.Marshal(signature.QuizAnswers)
+		if err != nil {
+			return fmt.Errorf("failed to encode quiz answers: %w", err)
+		}
+	}
+
+	authMethod := signature.AuthMethod
+	if authMethod == "" {
+		authMethod = models.AuthMethodSession
+	}
+
 	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(
 		ctx, query,
 		tenantID,
@@ -119,6 +164,13 @@ func (r *SignatureRepository) Create(ctx context.Context, signature *models.Sign
 		signature.Nonce,
 		signature.Referer,
 		signature.PrevHash,
+		signature.QuizScore,
+		quizAnswers,
+		authMethod,
+		signature.Locale,
+		signature.ConsentVersion,
+		signature.ConsentLocale,
+		signature.ConsentText,
 	).Scan(&signature.ID, &signature.CreatedAt)
 
 	if err != nil {
@@ -126,6 +178,7 @@ func (r *SignatureRepository) Create(ctx context.Context, signature *models.Sign
 	}
 
 	signature.TenantID = tenantID
+	signature.AuthMethod = authMethod
 	return nil
 }
 
@@ -135,7 +188,8 @@ func (r *SignatureRepository) GetByDocAndUser(ctx context.Context, docID, userSu
 	query := `
 		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
 		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
-		       s.hash_version, s.doc_deleted_at, d.title, d.url
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
 		FROM signatures s
 		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
 		WHERE s.doc_id = $1 AND s.user_sub = $2
@@ -160,14 +214,15 @@ func (r *SignatureRepository) GetByDoc(ctx context.Context, docID string) ([]*mo
 	query := `
 		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
 		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
-		       s.hash_version, s.doc_deleted_at, d.title, d.url
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
 		FROM signatures s
 		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
 		WHERE s.doc_id = $1
 		ORDER BY s.created_at DESC
 	`
 
-	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, docID)
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, docID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query signatures: %w", err)
 	}
@@ -193,7 +248,8 @@ func (r *SignatureRepository) GetByUserEmail(ctx context.Context, userEmail stri
 	query := `
 		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
 		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
-		       s.hash_version, s.doc_deleted_at, d.title, d.url
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
 		FROM signatures s
 		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
 		WHERE LOWER(s.user_email) = LOWER($1)
@@ -220,6 +276,86 @@ func (r *SignatureRepository) GetByUserEmail(ctx context.Context, userEmail stri
 	return signatures, nil
 }
 
+// ListByDocKeyset retrieves up to limit signatures of a document past cursor, newest first,
+// or oldest first for backward cursors
+// RLS policy automatically filters by tenant_id
+func (r *SignatureRepository) ListByDocKeyset(ctx context.Context, docID string, cursor *models.PageCursor, limit int) ([]*models.Signature, error) {
+	id, err := cursorID(cursor)
+	if err != nil {
+		return nil, err
+	}
+	condition, order, args := keysetClause("s.created_at", "s.id", cursor, id, 3)
+	query := `
+		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
+		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
+		FROM signatures s
+		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
+		WHERE s.doc_id = $1 AND ` + condition + `
+		ORDER BY ` + order + `
+		LIMIT $2
+	`
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, append([]interface{}{docID, limit}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signatures: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	return scanSignatureRows(rows)
+}
+
+// ListByUserEmailKeyset retrieves up to limit signatures of a user past cursor, newest first,
+// or oldest first for backward cursors
+// RLS policy automatically filters by tenant_id
+func (r *SignatureRepository) ListByUserEmailKeyset(ctx context.Context, userEmail string, cursor *models.PageCursor, limit int) ([]*models.Signature, error) {
+	id, err := cursorID(cursor)
+	if err != nil {
+		return nil, err
+	}
+	condition, order, args := keysetClause("s.created_at", "s.id", cursor, id, 3)
+	query := `
+		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
+		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
+		FROM signatures s
+		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
+		WHERE LOWER(s.user_email) = LOWER($1) AND ` + condition + `
+		ORDER BY ` + order + `
+		LIMIT $2
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, append([]interface{}{userEmail, limit}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user signatures: %w", err)
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+
+	return scanSignatureRows(rows)
+}
+
+// scanSignatureRows scans the rows of a signature listing, skipping the rows failing to scan
+func scanSignatureRows(rows *sql.Rows) ([]*models.Signature, error) {
+	var signatures []*models.Signature
+	for rows.Next() {
+		signature := &models.Signature{}
+		if err := scanSignature(rows, signature); err != nil {
+			continue
+		}
+		signatures = append(signatures, signature)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating signature rows: %w", err)
+	}
+	return signatures, nil
+}
+
 // ExistsByDocAndUser efficiently checks if a signature already exists without retrieving full record data
 // RLS policy automatically filters by tenant_id
 func (r *SignatureRepository) ExistsByDocAndUser(ctx context.Context, docID, userSub string) (bool, error) {
@@ -259,7 +395,8 @@ func (r *SignatureRepository) GetLastSignature(ctx context.Context, docID string
 	query := `
 		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
 		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
-		       s.hash_version, s.doc_deleted_at, d.title, d.url
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
 		FROM signatures s
 		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
 		WHERE s.doc_id = $1
@@ -286,7 +423,8 @@ func (r *SignatureRepository) GetAllSignaturesOrdered(ctx context.Context) ([]*m
 	query := `
 		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
 		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
-		       s.hash_version, s.doc_deleted_at, d.title, d.url
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
 		FROM signatures s
 		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
 		ORDER BY s.id ASC`
@@ -311,6 +449,62 @@ func (r *SignatureRepository) GetAllSignaturesOrdered(ctx context.Context) ([]*m
 	return signatures, nil
 }
 
+// GetByID retrieves a signature by its ID, returns nil when not found
+// RLS policy automatically filters by tenant_id
+func (r *SignatureRepository) GetByID(ctx context.Context, id int64) (*models.Signature, error) {
+	query := `
+		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
+		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
+		FROM signatures s
+		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
+		WHERE s.id = $1`
+
+	signature := &models.Signature{}
+	err := scanSignature(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id), signature)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get signature: %w", err)
+	}
+
+	return signature, nil
+}
+
+// ListAfterID retrieves, by ID, the signatures with an ID above afterID created before a given time
+// RLS policy automatically filters by tenant_id
+func (r *SignatureRepository) ListAfterID(ctx context.Context, afterID int64, before time.Time, limit int) ([]*models.Signature, error) {
+	query := `
+		SELECT s.id, s.tenant_id, s.doc_id, s.user_sub, s.user_email, s.user_name, s.signed_at, s.doc_checksum,
+		       s.payload_hash, s.signature, s.nonce, s.created_at, s.referer, s.prev_hash,
+		       s.hash_version, s.doc_deleted_at, s.quiz_score, s.quiz_answers, s.auth_method,
+		       s.locale, s.consent_version, s.consent_locale, s.consent_text, d.title, d.url
+		FROM signatures s
+		LEFT JOIN documents d ON s.doc_id = d.doc_id AND s.tenant_id = d.tenant_id
+		WHERE s.id > $1 AND s.created_at < $2
+		ORDER BY s.id ASC
+		LIMIT $3`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, afterID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signatures: %w", err)
+	}
+	defer rows.Close()
+
+	var signatures []*models.Signature
+	for rows.Next() {
+		signature := &models.Signature{}
+		if err := scanSignature(rows, signature); err != nil {
+			return nil, fmt.Errorf("failed to scan signature: %w", err)
+		}
+		signatures = append(signatures, signature)
+	}
+
+	return signatures, rows.Err()
+}
+
 // UpdatePrevHash modifies the previous hash pointer for chain reconstruction operations
 // RLS policy automatically filters by tenant_id
 func (r *SignatureRepository) UpdatePrevHash(ctx context.Context, id int64, prevHash *string) error {
@@ -321,6 +515,42 @@ func (r *SignatureRepository) UpdatePrevHash(ctx context.Context, id int64, prev
 	return nil
 }
 
+// ListReferers returns, by ascending ID, up to limit signatures with a referer after the given ID
+// RLS policy automatically filters by tenant_id
+func (r *SignatureRepository) ListReferers(ctx context.Context, afterID int64, limit int) ([]models.SignatureReferer, error) {
+	query := `
+		SELECT id, referer FROM signatures
+		WHERE referer IS NOT NULL AND id > $1
+		ORDER BY id ASC
+		LIMIT $2
+	`
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referers: %w", err)
+	}
+	defer rows.Close()
+
+	var referers []models.SignatureReferer
+	for rows.Next() {
+		var ref models.SignatureReferer
+		if err := rows.Scan(&ref.ID, &ref.Referer); err != nil {
+			return nil, fmt.Errorf("failed to scan referer: %w", err)
+		}
+		referers = append(referers, ref)
+	}
+	return referers, rows.Err()
+}
+
+// UpdateReferer replaces the referer recorded with a signature
+// RLS policy automatically filters by tenant_id
+func (r *SignatureRepository) UpdateReferer(ctx context.Context, id int64, referer *string) error {
+	query := `UPDATE signatures SET referer = $2 WHERE id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, referer); err != nil {
+		return fmt.Errorf("failed to update referer: %w", err)
+	}
+	return nil
+}
+
 // Count returns the total number of unique signers (distinct email addresses)
 func (r *SignatureRepository) Count(ctx context.Context) (int, error) {
 	query := `SELECT COUNT(DISTINCT user_email) FROM signatures`
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+// signerGroupMemberBatch bounds the rows of one INSERT, directory groups may hold thousands of members
+const signerGroupMemberBatch = 500
+
+const signerGroupColumns = `g.id, g.tenant_id, g.name, g.description, COALESCE(g.directory_provider, ''),
+	COALESCE(g.directory_group, ''), g.mapping, g.sync_enabled,
+	(SELECT COUNT(*) FROM signer_group_members m WHERE m.group_id = g.id),
+	g.last_synced_at, COALESCE(g.last_sync_error, ''), g.created_by, g.created_at, g.updated_at`
+
+// SignerGroupRepository handles database operations for signer groups and their members
+type SignerGroupRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewSignerGroupRepository creates a new signer group repository
+func NewSignerGroupRepository(db *sql.DB, tenants providers.TenantProvider) *SignerGroupRepository {
+	return &SignerGroupRepository{db: db, tenants: tenants}
+}
+
+func scanSignerGroup(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.SignerGroup, error) {
+	g := &models.SignerGroup{}
+	var mapping []byte
+	var lastSyncedAt sql.NullTime
+	err := scanner.Scan(&g.ID, &g.TenantID, &g.Name, &g.Description, &g.DirectoryProvider, &g.DirectoryGroup,
+		&mapping, &g.SyncEnabled, &g.MemberCount, &lastSyncedAt, &g.LastSyncError, &g.CreatedBy,
+		&g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(mapping, &g.Mapping); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal directory mapping: %w", err)
+	}
+	if lastSyncedAt.Valid {
+		g.LastSyncedAt = &lastSyncedAt.Time
+	}
+	return g, nil
+}
+
+// Create inserts a new signer group.
+// It returns models.ErrSignerGroupExists when the name is already used.
+func (r *SignerGroupRepository) Create(ctx context.Context, input models.SignerGroupInput) (*models.SignerGroup, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	mapping, err := json.Marshal(input.Mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory mapping: %w", err)
+	}
+
+	query := `
+		WITH g AS (
+			INSERT INTO signer_groups (tenant_id, name, description, directory_provider, directory_group, mapping, sync_enabled, created_by)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8)
+			ON CONFLICT (tenant_id, name) DO NOTHING
+			RETURNING *
+		)
+		SELECT ` + signerGroupColumns + ` FROM g`
+
+	g, err := scanSignerGroup(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, input.Name, input.Description, input.DirectoryProvider, input.DirectoryGroup,
+		mapping, input.SyncEnabled, input.CreatedBy,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSignerGroupExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer group: %w", err)
+	}
+	return g, nil
+}
+
+// Update modifies a signer group, its members are kept
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) Update(ctx context.Context, id int64, input models.SignerGroupInput) (*models.SignerGroup, error) {
+	mapping, err := json.Marshal(input.Mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal directory mapping: %w", err)
+	}
+
+	query := `
+		WITH g AS (
+			UPDATE signer_groups
+			SET name = $1, description = $2, directory_provider = NULLIF($3, ''), directory_group = NULLIF($4, ''),
+				mapping = $5, sync_enabled = $6, updated_at = now()
+			WHERE id = $7
+			RETURNING *
+		)
+		SELECT ` + signerGroupColumns + ` FROM g`
+
+	g, err := scanSignerGroup(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		input.Name, input.Description, input.DirectoryProvider, input.DirectoryGroup, mapping, input.SyncEnabled, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSignerGroupNotFound
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return nil, models.ErrSignerGroupExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update signer group: %w", err)
+	}
+	return g, nil
+}
+
+// Delete removes a signer group and its members. Documents keep the signers already added.
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) Delete(ctx context.Context, id int64) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM signer_groups WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete signer group: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return models.ErrSignerGroupNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a signer group by its ID
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) GetByID(ctx context.Context, id int64) (*models.SignerGroup, error) {
+	query := `SELECT ` + signerGroupColumns + ` FROM signer_groups g WHERE g.id = $1`
+
+	g, err := scanSignerGroup(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrSignerGroupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signer group: %w", err)
+	}
+	return g, nil
+}
+
+// List retrieves all signer groups by name
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) List(ctx context.Context) ([]*models.SignerGroup, error) {
+	return r.list(ctx, `SELECT `+signerGroupColumns+` FROM signer_groups g ORDER BY g.name ASC`)
+}
+
+// ListSyncEnabled retrieves the directory groups synced on schedule, least recently synced first
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) ListSyncEnabled(ctx context.Context) ([]*models.SignerGroup, error) {
+	return r.list(ctx, `SELECT `+signerGroupColumns+` FROM signer_groups g
+		WHERE g.sync_enabled = TRUE AND g.directory_provider IS NOT NULL
+		ORDER BY g.last_synced_at ASC NULLS FIRST`)
+}
+
+func (r *SignerGroupRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.SignerGroup, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signer groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*models.SignerGroup
+	for rows.Next() {
+		g, err := scanSignerGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan signer group: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// MarkSynced records the outcome of a directory sync, an empty message clearing the last error
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) MarkSynced(ctx context.Context, id int64, at time.Time, syncErr string) error {
+	query := `UPDATE signer_groups SET last_synced_at = $2, last_sync_error = NULLIF($3, '') WHERE id = $1`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, at, syncErr); err != nil {
+		return fmt.Errorf("failed to mark signer group synced: %w", err)
+	}
+	return nil
+}
+
+// ListMembers retrieves the members of a group by email
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) ListMembers(ctx context.Context, groupID int64) ([]models.SignerGroupMember, error) {
+	query := `SELECT email, name, added_at FROM signer_group_members WHERE group_id = $1 ORDER BY email ASC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signer group members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []models.SignerGroupMember
+	for rows.Next() {
+		var m models.SignerGroupMember
+		if err := rows.Scan(&m.Email, &m.Name, &m.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signer group member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+// UpsertMembers adds members to a group, updating the name of those already present
+func (r *SignerGroupRepository) UpsertMembers(ctx context.Context, groupID int64, members []models.SignerGroupMember) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	for start := 0; start < len(members); start += signerGroupMemberBatch {
+		batch := members[start:min(start+signerGroupMemberBatch, len(members))]
+
+		valueStrings := make([]string, 0, len(batch))
+		valueArgs := make([]interface{}, 0, len(batch)*4)
+		for i, m := range batch {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d)", i*4+1, i*4+2, i*4+3, i*4+4))
+			valueArgs = append(valueArgs, groupID, tenantID, m.Email, m.Name)
+		}
+
+		query := fmt.Sprintf(`
+			INSERT INTO signer_group_members (group_id, tenant_id, email, name)
+			VALUES %s
+			ON CONFLICT (group_id, email) DO UPDATE SET name = EXCLUDED.name
+		`, strings.Join(valueStrings, ","))
+
+		if _, err := q.ExecContext(ctx, query, valueArgs...); err != nil {
+			return fmt.Errorf("failed to add signer group members: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveMembers removes members from a group by email
+// RLS policy automatically filters by tenant_id
+func (r *SignerGroupRepository) RemoveMembers(ctx context.Context, groupID int64, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	query := `DELETE FROM signer_group_members WHERE group_id = $1 AND email = ANY($2)`
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, groupID, pq.Array(emails)); err != nil {
+		return fmt.Errorf("failed to remove signer group members: %w", err)
+	}
+	return nil
+}
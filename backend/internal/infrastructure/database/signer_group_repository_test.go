@@ -0,0 +1,86 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignerGroupRepository_GroupsAndMembers(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewSignerGroupRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	group, err := repo.Create(ctx, models.SignerGroupInput{
+		Name:              "All employees",
+		DirectoryProvider: models.DirectoryProviderMicrosoft,
+		DirectoryGroup:    "group-id",
+		Mapping:           models.DirectoryMapping{AllowedDomains: []string{"example.com"}},
+		SyncEnabled:       true,
+		CreatedBy:         "admin@example.com",
+	})
+	if err != nil {
+		t.Fatalf("create group err: %v", err)
+	}
+	if len(group.Mapping.AllowedDomains) != 1 || group.MemberCount != 0 {
+		t.Errorf("unexpected group %+v", group)
+	}
+
+	if _, err := repo.Create(ctx, models.SignerGroupInput{Name: "All employees"}); !errors.Is(err, models.ErrSignerGroupExists) {
+		t.Fatalf("expected ErrSignerGroupExists, got %v", err)
+	}
+	manual, err := repo.Create(ctx, models.SignerGroupInput{Name: "Board"})
+	if err != nil {
+		t.Fatalf("create manual group err: %v", err)
+	}
+
+	members := []models.SignerGroupMember{
+		{Email: "alice@example.com", Name: "Alice"},
+		{Email: "bob@example.com", Name: "Bob"},
+	}
+	if err := repo.UpsertMembers(ctx, group.ID, members); err != nil {
+		t.Fatalf("upsert members err: %v", err)
+	}
+	if err := repo.UpsertMembers(ctx, group.ID, []models.SignerGroupMember{{Email: "bob@example.com", Name: "Bob Durand"}}); err != nil {
+		t.Fatalf("update member err: %v", err)
+	}
+	if err := repo.RemoveMembers(ctx, group.ID, []string{"alice@example.com"}); err != nil {
+		t.Fatalf("remove members err: %v", err)
+	}
+
+	listed, err := repo.ListMembers(ctx, group.ID)
+	if err != nil || len(listed) != 1 || listed[0].Name != "Bob Durand" {
+		t.Fatalf("expected Bob Durand only, got %+v, %v", listed, err)
+	}
+
+	if err := repo.MarkSynced(ctx, group.ID, time.Now(), "directory returned status 403"); err != nil {
+		t.Fatalf("mark synced err: %v", err)
+	}
+	got, err := repo.GetByID(ctx, group.ID)
+	if err != nil || got.MemberCount != 1 || got.LastSyncedAt == nil || got.LastSyncError == "" {
+		t.Fatalf("unexpected group after sync %+v, %v", got, err)
+	}
+
+	scheduled, err := repo.ListSyncEnabled(ctx)
+	if err != nil || len(scheduled) != 1 || scheduled[0].ID != group.ID {
+		t.Fatalf("expected only the directory group to be scheduled, got %v, %v", scheduled, err)
+	}
+
+	if _, err := repo.Update(ctx, manual.ID, models.SignerGroupInput{Name: "All employees"}); !errors.Is(err, models.ErrSignerGroupExists) {
+		t.Errorf("expected ErrSignerGroupExists on rename, got %v", err)
+	}
+
+	if err := repo.Delete(ctx, group.ID); err != nil {
+		t.Fatalf("delete group err: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, group.ID); !errors.Is(err, models.ErrSignerGroupNotFound) {
+		t.Errorf("expected ErrSignerGroupNotFound, got %v", err)
+	}
+}
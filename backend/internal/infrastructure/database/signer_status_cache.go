@@ -0,0 +1,319 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// signerStatusChannel is notified with '<tenant_id>:<doc_id>' by the triggers of migration 0034
+const signerStatusChannel = "ackify_signer_status"
+
+// maxSignerStatusCacheEntries bounds the memory used by a SignerStatusCache
+const maxSignerStatusCacheEntries = 1000
+
+// signerStatusPingInterval detects dead listener connections that the server did not close
+const signerStatusPingInterval = 90 * time.Second
+
+type signerStatusKey struct {
+	tenantID uuid.UUID
+	docID    string
+}
+
+type signerStatusEntry struct {
+	signers   []*models.ExpectedSignerWithStatus
+	expiresAt time.Time
+}
+
+// SignerStatusCache keeps the expected signers with status of recently viewed documents in memory,
+// so that admin pages polling a document do not recompute the signer list on every request.
+// It wraps an ExpectedSignerRepository: writes go through it and drop the affected entry.
+// Once started, a Postgres LISTEN connection drops entries changed by any instance or process;
+// while that connection is down, reads go to the database.
+type SignerStatusCache struct {
+	*ExpectedSignerRepository
+
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[signerStatusKey]signerStatusEntry
+	// onChange is told about the documents notified by any instance or process
+	onChange func(docID string)
+	// generation changes on every invalidation, so that a read started before
+	// an invalidation does not store the data it loaded
+	generation uint64
+
+	hits          atomic.Int64
+	misses        atomic.Int64
+	bypasses      atomic.Int64
+	invalidations atomic.Int64
+
+	listener  *pq.Listener
+	listening atomic.Bool
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewSignerStatusCache creates a signer status cache; a zero ttl disables caching
+func NewSignerStatusCache(repo *ExpectedSignerRepository, ttl time.Duration) *SignerStatusCache {
+	return &SignerStatusCache{
+		ExpectedSignerRepository: repo,
+		ttl:                      ttl,
+		now:                      time.Now,
+		entries:                  make(map[signerStatusKey]signerStatusEntry),
+	}
+}
+
+// ListWithStatusByDocID returns the cached signer status of a document, loading it on a miss.
+// Reads bypass the cache when the context was marked with dbctx.WithoutCache, and within a
+// department scope, since cached entries were not filtered by the department RLS policies.
+// Misses read from the read replica are not stored.
+func (c *SignerStatusCache) ListWithStatusByDocID(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error) {
+	if c.ttl <= 0 {
+		return c.ExpectedSignerRepository.ListWithStatusByDocID(ctx, docID)
+	}
+	if dbctx.CacheBypassed(ctx) || dbctx.DepartmentScope(ctx) != nil || (c.listener != nil && !c.listening.Load()) {
+		c.bypasses.Add(1)
+		return c.ExpectedSignerRepository.ListWithStatusByDocID(ctx, docID)
+	}
+
+	tenantID, err := c.tenants.CurrentTenant(ctx)
+	if err != nil {
+		c.bypasses.Add(1)
+		return c.ExpectedSignerRepository.ListWithStatusByDocID(ctx, docID)
+	}
+	key := signerStatusKey{tenantID: tenantID, docID: docID}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && c.now().Before(entry.expiresAt) {
+		c.mu.Unlock()
+		c.hits.Add(1)
+		return cloneSignerStatus(entry.signers), nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	c.misses.Add(1)
+	signers, err := c.ExpectedSignerRepository.ListWithStatusByDocID(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replica reads may lag behind invalidations, so they are served but not cached
+	if dbctx.ReadsFromReplica(ctx) {
+		return signers, nil
+	}
+	c.mu.Lock()
+	if c.generation == generation {
+		c.store(key, cloneSignerStatus(signers))
+	}
+	c.mu.Unlock()
+
+	return signers, nil
+}
+
+// AddExpected adds expected signers and drops the cached status of the document
+func (c *SignerStatusCache) AddExpected(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error {
+	defer c.invalidateContext(ctx, docID)
+	return c.ExpectedSignerRepository.AddExpected(ctx, docID, contacts, addedBy)
+}
+
+// Remove removes an expected signer and drops the cached status of the document
+func (c *SignerStatusCache) Remove(ctx context.Context, docID, email string) error {
+	defer c.invalidateContext(ctx, docID)
+	return c.ExpectedSignerRepository.Remove(ctx, docID, email)
+}
+
+// Invalidate drops the cached status of a document
+func (c *SignerStatusCache) Invalidate(tenantID uuid.UUID, docID string) {
+	c.mu.Lock()
+	delete(c.entries, signerStatusKey{tenantID: tenantID, docID: docID})
+	c.generation++
+	c.mu.Unlock()
+	c.invalidations.Add(1)
+}
+
+// Flush drops every cached entry
+func (c *SignerStatusCache) Flush() {
+	c.mu.Lock()
+	c.entries = make(map[signerStatusKey]signerStatusEntry)
+	c.generation++
+	c.mu.Unlock()
+	c.invalidations.Add(1)
+}
+
+// Stats reports the cache activity since startup
+func (c *SignerStatusCache) Stats() models.CacheStats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	stats := models.CacheStats{
+		Name:          "signer_status",
+		Enabled:       c.ttl > 0,
+		Listening:     c.listening.Load(),
+		Entries:       entries,
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Bypasses:      c.bypasses.Load(),
+		Invalidations: c.invalidations.Load(),
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups) * 100
+	}
+	return stats
+}
+
+// SetChangeHook calls fn with the documents whose signers or signatures changed, as notified
+// to every instance
+func (c *SignerStatusCache) SetChangeHook(fn func(docID string)) {
+	c.mu.Lock()
+	c.onChange = fn
+	c.mu.Unlock()
+}
+
+// Start listens for signer status notifications on a dedicated connection to dsn
+func (c *SignerStatusCache) Start(dsn string) error {
+	if c.ttl <= 0 {
+		return nil
+	}
+
+	c.listener = pq.NewListener(dsn, 2*time.Second, time.Minute, c.handleListenerEvent)
+	if err := c.listener.Listen(signerStatusChannel); err != nil {
+		_ = c.listener.Close()
+		c.listener = nil
+		return err
+	}
+
+	c.stopChan = make(chan struct{})
+	c.wg.Add(1)
+	go c.run()
+
+	logger.Logger.Info("Signer status cache started", "ttl", c.ttl)
+	return nil
+}
+
+// Stop closes the notification listener
+func (c *SignerStatusCache) Stop() error {
+	if c.listener == nil {
+		return nil
+	}
+	close(c.stopChan)
+	c.wg.Wait()
+	c.listening.Store(false)
+	return c.listener.Close()
+}
+
+func (c *SignerStatusCache) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(signerStatusPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopChan:
+			return
+		case n := <-c.listener.Notify:
+			// A nil notification follows a reconnection: changes may have been missed
+			if n == nil {
+				c.Flush()
+				continue
+			}
+			c.handleNotification(n.Extra)
+		case <-ticker.C:
+			if err := c.listener.Ping(); err != nil {
+				logger.Logger.Warn("Signer status listener ping failed", "error", err.Error())
+			}
+		}
+	}
+}
+
+func (c *SignerStatusCache) handleListenerEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+		// Entries may have changed while no notification could be received
+		c.Flush()
+		c.listening.Store(true)
+	case pq.ListenerEventDisconnected:
+		c.listening.Store(false)
+		logger.Logger.Warn("Signer status listener disconnected, cache bypassed until reconnection", "error", err)
+	case pq.ListenerEventConnectionAttemptFailed:
+		logger.Logger.Warn("Signer status listener connection failed", "error", err)
+	}
+}
+
+func (c *SignerStatusCache) handleNotification(payload string) {
+	tenant, docID, ok := strings.Cut(payload, ":")
+	if !ok {
+		logger.Logger.Warn("Ignoring malformed signer status notification", "payload", payload)
+		return
+	}
+	tenantID, err := uuid.Parse(tenant)
+	if err != nil {
+		logger.Logger.Warn("Ignoring malformed signer status notification", "payload", payload)
+		return
+	}
+	c.Invalidate(tenantID, docID)
+
+	c.mu.Lock()
+	onChange := c.onChange
+	c.mu.Unlock()
+	if onChange != nil {
+		onChange(docID)
+	}
+}
+
+func (c *SignerStatusCache) invalidateContext(ctx context.Context, docID string) {
+	tenantID, err := c.tenants.CurrentTenant(ctx)
+	if err != nil {
+		c.Flush()
+		return
+	}
+	c.Invalidate(tenantID, docID)
+}
+
+// store saves an entry; the caller holds c.mu
+func (c *SignerStatusCache) store(key signerStatusKey, signers []*models.ExpectedSignerWithStatus) {
+	now := c.now()
+	if len(c.entries) >= maxSignerStatusCacheEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		// Still full: drop an arbitrary entry rather than grow without bound
+		for k := range c.entries {
+			if len(c.entries) < maxSignerStatusCacheEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = signerStatusEntry{signers: signers, expiresAt: now.Add(c.ttl)}
+}
+
+// cloneSignerStatus copies the signers so that callers may modify them (e.g. to set signing turns)
+func cloneSignerStatus(signers []*models.ExpectedSignerWithStatus) []*models.ExpectedSignerWithStatus {
+	if signers == nil {
+		return nil
+	}
+	clones := make([]*models.ExpectedSignerWithStatus, len(signers))
+	for i, signer := range signers {
+		clone := *signer
+		clones[i] = &clone
+	}
+	return clones
+}
@@ -0,0 +1,117 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignerStatusCache_HitsAndLocalInvalidation(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	seedDocumentStatus(t, tdb, "cached-doc", 2)
+
+	cache := NewSignerStatusCache(NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider), time.Minute)
+
+	first, err := cache.ListWithStatusByDocID(ctx, "cached-doc")
+	if err != nil || len(first) != 2 {
+		t.Fatalf("expected 2 signers, got %d (err %v)", len(first), err)
+	}
+	// Callers may modify the returned signers without altering the cache
+	first[0].Turn = models.SigningTurnWaiting
+
+	second, err := cache.ListWithStatusByDocID(ctx, "cached-doc")
+	if err != nil || len(second) != 2 {
+		t.Fatalf("expected 2 cached signers, got %d (err %v)", len(second), err)
+	}
+	if second[0].Turn == models.SigningTurnWaiting {
+		t.Error("cached entry was modified through a returned signer")
+	}
+
+	if _, err := cache.ListWithStatusByDocID(dbctx.WithoutCache(ctx), "cached-doc"); err != nil {
+		t.Fatalf("bypass err: %v", err)
+	}
+
+	if err := cache.AddExpected(ctx, "cached-doc", []models.ContactInfo{{Email: "late@example.com"}}, "admin@example.com"); err != nil {
+		t.Fatalf("add expected err: %v", err)
+	}
+	third, err := cache.ListWithStatusByDocID(ctx, "cached-doc")
+	if err != nil || len(third) != 3 {
+		t.Fatalf("expected 3 signers after local invalidation, got %d (err %v)", len(third), err)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Bypasses != 1 || stats.Invalidations != 1 || stats.Entries != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestSignerStatusCache_NotifyInvalidation(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	seedDocumentStatus(t, tdb, "notified-doc", 2)
+
+	cache := NewSignerStatusCache(NewExpectedSignerRepository(tdb.DB, tdb.TenantProvider), time.Minute)
+	changed := make(chan string, 1)
+	cache.SetChangeHook(func(docID string) {
+		select {
+		case changed <- docID:
+		default:
+		}
+	})
+	if err := cache.Start(tdb.DSN); err != nil {
+		t.Fatalf("start err: %v", err)
+	}
+	defer func() { _ = cache.Stop() }()
+
+	waitFor(t, func() bool { return cache.Stats().Listening })
+
+	signers, err := cache.ListWithStatusByDocID(ctx, "notified-doc")
+	if err != nil || len(signers) != 2 || signers[1].HasSigned {
+		t.Fatalf("unexpected signers: %+v (err %v)", signers, err)
+	}
+
+	// A signature recorded outside the cache must drop the entry through NOTIFY
+	sig := (&SignatureFactory{}).CreateSignatureWithDocAndUser("notified-doc", "sub-signer1", signers[1].Email)
+	sig.Nonce = "nonce-notified"
+	if err := NewSignatureRepository(tdb.DB, tdb.TenantProvider).Create(ctx, sig); err != nil {
+		t.Fatalf("create signature err: %v", err)
+	}
+	waitFor(t, func() bool { return cache.Stats().Entries == 0 })
+	select {
+	case docID := <-changed:
+		if docID != "notified-doc" {
+			t.Errorf("expected the change hook called for notified-doc, got %q", docID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("expected the change hook to be called")
+	}
+
+	signers, err = cache.ListWithStatusByDocID(ctx, "notified-doc")
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	for _, signer := range signers {
+		if !signer.HasSigned {
+			t.Errorf("expected every signer to have signed, got %+v", signer)
+		}
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// signerTimelineQuery gathers the events of one signer on one document from every table recording them.
+// The ord column keeps events sharing a timestamp in the order they happen in a signing flow.
+const signerTimelineQuery = `
+	SELECT type, at, actor, detail FROM (
+		SELECT 'added' AS type, added_at AS at, added_by AS actor, COALESCE(notes, '') AS detail, 1 AS ord
+		FROM expected_signers
+		WHERE doc_id = $1 AND lower(email) = $2
+		UNION ALL
+		SELECT 'reminder_' || status, sent_at, sent_by, COALESCE(NULLIF(error_message, ''), template_used), 2
+		FROM reminder_logs
+		WHERE doc_id = $1 AND lower(recipient_email) = $2
+		UNION ALL
+		SELECT 'link_opened', used_at, '', purpose, 3
+		FROM magic_link_tokens
+		WHERE doc_id = $1 AND lower(email) = $2 AND used_at IS NOT NULL
+		UNION ALL
+		SELECT 'code_sent', created_at, '', '', 3
+		FROM external_signer_codes
+		WHERE doc_id = $1 AND lower(email) = $2
+		UNION ALL
+		SELECT 'code_verified', used_at, '', '', 4
+		FROM external_signer_codes
+		WHERE doc_id = $1 AND lower(email) = $2 AND used_at IS NOT NULL
+		UNION ALL
+		SELECT 'checksum_verified', verified_at, '', CASE WHEN is_valid THEN 'valid' ELSE 'invalid' END, 5
+		FROM checksum_verifications
+		WHERE doc_id = $1 AND lower(verified_by) = $2
+		UNION ALL
+		SELECT 'signed', signed_at, '', auth_method, 6
+		FROM signatures
+		WHERE doc_id = $1 AND lower(user_email) = $2
+		UNION ALL
+		SELECT 'suppressed', created_at, created_by, reason || CASE WHEN detail <> '' THEN ': ' || detail ELSE '' END, 7
+		FROM email_suppressions
+		WHERE email = $2
+	) events
+	ORDER BY at ASC, ord ASC`
+
+// SignerTimelineRepository reads the events of a signer on a document for support investigations
+type SignerTimelineRepository struct {
+	db *sql.DB
+}
+
+// NewSignerTimelineRepository creates a new signer timeline repository
+func NewSignerTimelineRepository(db *sql.DB) *SignerTimelineRepository {
+	return &SignerTimelineRepository{db: db}
+}
+
+// ListEvents retrieves the events of a signer on a document, oldest first. The email must be lowercase.
+// RLS policy automatically filters by tenant_id
+func (r *SignerTimelineRepository) ListEvents(ctx context.Context, docID, email string) ([]models.SignerTimelineEvent, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, signerTimelineQuery, docID, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signer timeline: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.SignerTimelineEvent{}
+	for rows.Next() {
+		var e models.SignerTimelineEvent
+		if err := rows.Scan(&e.Type, &e.At, &e.Actor, &e.Detail); err != nil {
+			return nil, fmt.Errorf("failed to scan signer timeline event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate signer timeline: %w", err)
+	}
+	return events, nil
+}
@@ -0,0 +1,77 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestSignerTimelineRepository_ListEvents(t *testing.T) {
+	testDB := SetupTestDB(t)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	sigRepo := NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	expectedRepo := NewExpectedSignerRepository(testDB.DB, testDB.TenantProvider)
+	reminderRepo := NewReminderRepository(testDB.DB, testDB.TenantProvider)
+	suppressionRepo := NewEmailSuppressionRepository(testDB.DB, testDB.TenantProvider)
+	repo := NewSignerTimelineRepository(testDB.DB)
+	factory := NewSignatureFactory()
+	ctx := context.Background()
+
+	docID := "doc-timeline-test"
+	if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Timeline"}, "admin@example.com"); err != nil {
+		t.Fatalf("create document err: %v", err)
+	}
+	if err := expectedRepo.AddExpected(ctx, docID, emailsToContacts([]string{"Alice@example.com", "bob@example.com"}), "admin@example.com"); err != nil {
+		t.Fatalf("add expected signers err: %v", err)
+	}
+	for _, email := range []string{"Alice@example.com", "bob@example.com"} {
+		if err := reminderRepo.LogReminder(ctx, &models.ReminderLog{
+			DocID: docID, RecipientEmail: email, SentAt: time.Now().Add(time.Minute),
+			SentBy: "admin@example.com", TemplateUsed: "signature_reminder", Status: "sent",
+		}); err != nil {
+			t.Fatalf("log reminder err: %v", err)
+		}
+	}
+	sig := factory.CreateSignatureWithDocAndUser(docID, "user-alice", "alice@example.com")
+	sig.SignedAtUTC = time.Now().Add(2 * time.Minute)
+	if err := sigRepo.Create(ctx, sig); err != nil {
+		t.Fatalf("create signature err: %v", err)
+	}
+	if _, err := suppressionRepo.Add(ctx, models.EmailSuppression{Email: "bob@example.com", Reason: models.SuppressionHardBounce}); err != nil {
+		t.Fatalf("add suppression err: %v", err)
+	}
+
+	events, err := repo.ListEvents(ctx, docID, "alice@example.com")
+	if err != nil {
+		t.Fatalf("ListEvents err: %v", err)
+	}
+	want := []models.SignerEventType{models.SignerEventAdded, models.SignerEventReminderSent, models.SignerEventSigned}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, e := range events {
+		if e.Type != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], e.Type)
+		}
+	}
+	if events[1].Actor != "admin@example.com" || events[1].Detail != "signature_reminder" {
+		t.Errorf("unexpected reminder event %+v", events[1])
+	}
+
+	// Suppressions are per address, not per document
+	events, err = repo.ListEvents(ctx, docID, "bob@example.com")
+	if err != nil || len(events) != 3 || events[2].Type != models.SignerEventSuppressed {
+		t.Errorf("unexpected events %+v, %v", events, err)
+	}
+
+	events, err = repo.ListEvents(ctx, docID, "nobody@example.com")
+	if err != nil || len(events) != 0 {
+		t.Errorf("expected no events, got %+v, %v", events, err)
+	}
+}
@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
+)
+
+const tagPolicyColumns = `tag, firm_after_reminders, firm_after_days, final_after_reminders, final_after_days, archive_after_days, updated_by, updated_at`
+
+// TagRepository handles database operations for document tags and tag policies
+type TagRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewTagRepository creates a new tag repository
+func NewTagRepository(db *sql.DB, tenants providers.TenantProvider) *TagRepository {
+	return &TagRepository{db: db, tenants: tenants}
+}
+
+func scanTagPolicy(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.TagPolicy, error) {
+	p := &models.TagPolicy{}
+	var archiveAfterDays sql.NullInt64
+	err := scanner.Scan(&p.Tag, &p.FirmAfterReminders, &p.FirmAfterDays, &p.FinalAfterReminders, &p.FinalAfterDays,
+		&archiveAfterDays, &p.UpdatedBy, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if archiveAfterDays.Valid {
+		days := int(archiveAfterDays.Int64)
+		p.ArchiveAfterDays = &days
+	}
+	return p, nil
+}
+
+// SetDocumentTags replaces the tags of a document
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) SetDocumentTags(ctx context.Context, docID string, tags []string, createdBy string) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	q := dbctx.GetQuerier(ctx, r.db)
+	if _, err := q.ExecContext(ctx, `DELETE FROM document_tags WHERE doc_id = $1 AND NOT (tag = ANY($2))`, docID, pq.Array(tags)); err != nil {
+		return fmt.Errorf("failed to remove document tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO document_tags (tenant_id, doc_id, tag, created_by)
+		SELECT $1, $2, unnest($3::text[]), $4
+		ON CONFLICT (tenant_id, doc_id, tag) DO NOTHING`
+	if _, err := q.ExecContext(ctx, query, tenantID, docID, pq.Array(tags), createdBy); err != nil {
+		return fmt.Errorf("failed to add document tags: %w", err)
+	}
+	return nil
+}
+
+// ListDocumentTags retrieves the tags of a document by name
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) ListDocumentTags(ctx context.Context, docID string) ([]string, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, `SELECT tag FROM document_tags WHERE doc_id = $1 ORDER BY tag ASC`, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan document tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// ListTags retrieves the tags of active documents containing query, those starting with it
+// first, with the number of documents carrying them
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) ListTags(ctx context.Context, query string, limit int) ([]models.TagCount, error) {
+	sqlQuery := `
+		SELECT t.tag, COUNT(*)
+		FROM document_tags t
+		JOIN documents d ON d.doc_id = t.doc_id AND d.deleted_at IS NULL
+		WHERE strpos(t.tag, $1) > 0
+		GROUP BY t.tag
+		ORDER BY strpos(t.tag, $1) = 1 DESC, COUNT(*) DESC, t.tag ASC
+		LIMIT $2`
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, sqlQuery, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []models.TagCount
+	for rows.Next() {
+		var tag models.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Documents); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// RenameTag renames a tag on every document, merging it into the new tag when documents already
+// carry both, and moves its policy unless the new tag already has one. It returns the number of
+// documents carrying the renamed tag, or models.ErrTagNotFound when no document or policy uses it.
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) RenameTag(ctx context.Context, from, to string) (int, error) {
+	q := dbctx.GetQuerier(ctx, r.db)
+
+	query := `
+		WITH moved AS (
+			DELETE FROM document_tags WHERE tag = $1
+			RETURNING tenant_id, doc_id, created_by, created_at
+		), inserted AS (
+			INSERT INTO document_tags (tenant_id, doc_id, tag, created_by, created_at)
+			SELECT tenant_id, doc_id, $2, created_by, created_at FROM moved
+			ON CONFLICT (tenant_id, doc_id, tag) DO NOTHING
+		)
+		SELECT COUNT(*) FROM moved`
+
+	var count int
+	if err := q.QueryRowContext(ctx, query, from, to).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	res, err := q.ExecContext(ctx, `
+		UPDATE tag_policies SET tag = $2, updated_at = now()
+		WHERE tag = $1 AND NOT EXISTS (SELECT 1 FROM tag_policies WHERE tag = $2)`, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename tag policy: %w", err)
+	}
+	renamed, _ := res.RowsAffected()
+	res, err = q.ExecContext(ctx, `DELETE FROM tag_policies WHERE tag = $1`, from)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tag policy: %w", err)
+	}
+	dropped, _ := res.RowsAffected()
+
+	if count == 0 && renamed+dropped == 0 {
+		return 0, models.ErrTagNotFound
+	}
+	return count, nil
+}
+
+// DeleteTag removes a tag from every document along with its policy. It returns the number of
+// documents that carried it, or models.ErrTagNotFound when no document or policy uses it.
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) DeleteTag(ctx context.Context, tag string) (int, error) {
+	q := dbctx.GetQuerier(ctx, r.db)
+
+	res, err := q.ExecContext(ctx, `DELETE FROM document_tags WHERE tag = $1`, tag)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tag: %w", err)
+	}
+	count, _ := res.RowsAffected()
+
+	res, err = q.ExecContext(ctx, `DELETE FROM tag_policies WHERE tag = $1`, tag)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete tag policy: %w", err)
+	}
+	if n, _ := res.RowsAffected(); count == 0 && n == 0 {
+		return 0, models.ErrTagNotFound
+	}
+	return int(count), nil
+}
+
+// Stats aggregates the completion of the active documents carrying a tag
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) Stats(ctx context.Context, tag string) (*models.TagStats, error) {
+	query := `
+		SELECT COUNT(DISTINCT d.doc_id), COUNT(es.id), COUNT(s.id)
+		FROM documents d
+		JOIN document_tags t ON t.doc_id = d.doc_id AND t.tag = $1
+		LEFT JOIN expected_signers es ON es.tenant_id = d.tenant_id AND es.doc_id = d.doc_id
+		LEFT JOIN LATERAL (
+			-- A person may have signed under several matching addresses: keep the first signature
+			SELECT id FROM signatures
+			WHERE tenant_id = es.tenant_id AND doc_id = es.doc_id AND match_key = es.match_key
+			ORDER BY signed_at ASC
+			LIMIT 1
+		) s ON true
+		WHERE d.deleted_at IS NULL`
+
+	stats := &models.TagStats{Tag: tag}
+	err := dbctx.GetReadQuerier(ctx, r.db).QueryRowContext(ctx, query, tag).Scan(
+		&stats.Documents, &stats.ExpectedCount, &stats.SignedCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag stats: %w", err)
+	}
+	return stats, nil
+}
+
+// documentFilterWhere returns the conditions of a document filter on the documents table and
+// their arguments, numbered from the first placeholder
+func documentFilterWhere(filter models.DocumentFilter) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	var args []interface{}
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM document_tags t WHERE t.doc_id = documents.doc_id AND t.tag = $%d)", len(args)))
+	}
+	if filter.Owner != "" {
+		args = append(args, filter.Owner)
+		conditions = append(conditions, fmt.Sprintf("created_by = $%d", len(args)))
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		n := len(args)
+		conditions = append(conditions, fmt.Sprintf("(doc_id ILIKE $%d OR title ILIKE $%d OR url ILIKE $%d OR description ILIKE $%d)", n, n, n, n))
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// ListDocuments retrieves paginated active documents matching a filter, newest first
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) ListDocuments(ctx context.Context, filter models.DocumentFilter, limit, offset int) ([]*models.Document, error) {
+	where, args := documentFilterWhere(filter)
+	query := fmt.Sprintf(`SELECT `+documentColumns+` FROM documents WHERE %s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`,
+		where, len(args)+1, len(args)+2)
+
+	rows, err := dbctx.GetReadQuerier(ctx, r.db).QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents, err := scanDocumentRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan documents: %w", err)
+	}
+	return documents, nil
+}
+
+// CountDocuments returns the number of active documents matching a filter
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) CountDocuments(ctx context.Context, filter models.DocumentFilter) (int, error) {
+	where, args := documentFilterWhere(filter)
+
+	var count int
+	if err := dbctx.GetReadQuerier(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM documents WHERE `+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	return count, nil
+}
+
+// ListPolicies retrieves all tag policies by tag
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) ListPolicies(ctx context.Context) ([]*models.TagPolicy, error) {
+	return r.listPolicies(ctx, `SELECT `+tagPolicyColumns+` FROM tag_policies ORDER BY tag ASC`)
+}
+
+// ListDocumentPolicies retrieves the policies of the tags of a document
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) ListDocumentPolicies(ctx context.Context, docID string) ([]*models.TagPolicy, error) {
+	query := `
+		SELECT ` + tagPolicyColumns + ` FROM tag_policies
+		WHERE tag IN (SELECT tag FROM document_tags WHERE doc_id = $1)
+		ORDER BY tag ASC`
+	return r.listPolicies(ctx, query, docID)
+}
+
+func (r *TagRepository) listPolicies(ctx context.Context, query string, args ...interface{}) ([]*models.TagPolicy, error) {
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tag policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*models.TagPolicy
+	for rows.Next() {
+		p, err := scanTagPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag policy: %w", err)
+		}
+		policies = append(policies, p)
+	}
+	return policies, rows.Err()
+}
+
+// UpsertPolicy creates or replaces the policy of a tag
+func (r *TagRepository) UpsertPolicy(ctx context.Context, policy models.TagPolicy, updatedBy string) (*models.TagPolicy, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO tag_policies (tenant_id, tag, firm_after_reminders, firm_after_days, final_after_reminders, final_after_days, archive_after_days, updated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tenant_id, tag) DO UPDATE
+		SET firm_after_reminders = EXCLUDED.firm_after_reminders, firm_after_days = EXCLUDED.firm_after_days,
+			final_after_reminders = EXCLUDED.final_after_reminders, final_after_days = EXCLUDED.final_after_days,
+			archive_after_days = EXCLUDED.archive_after_days, updated_by = EXCLUDED.updated_by, updated_at = now()
+		RETURNING ` + tagPolicyColumns
+
+	p, err := scanTagPolicy(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID, policy.Tag, policy.FirmAfterReminders, policy.FirmAfterDays,
+		policy.FinalAfterReminders, policy.FinalAfterDays, policy.ArchiveAfterDays, updatedBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert tag policy: %w", err)
+	}
+	return p, nil
+}
+
+// DeletePolicy removes the policy of a tag, returning models.ErrTagNotFound when it has none
+// RLS policy automatically filters by tenant_id
+func (r *TagRepository) DeletePolicy(ctx context.Context, tag string) error {
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, `DELETE FROM tag_policies WHERE tag = $1`, tag)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag policy: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return models.ErrTagNotFound
+	}
+	return nil
+}
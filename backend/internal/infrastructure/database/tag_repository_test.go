@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestTagRepository_TagsAndPolicies(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewTagRepository(testDB.DB, testDB.TenantProvider)
+	docRepo := NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	for _, docID := range []string{"tag-doc-1", "tag-doc-2"} {
+		if _, err := docRepo.Create(ctx, docID, models.DocumentInput{Title: "Security " + docID}, "admin@example.com"); err != nil {
+			t.Fatalf("create document err: %v", err)
+		}
+	}
+	if err := repo.SetDocumentTags(ctx, "tag-doc-1", []string{"security", "legal"}, "admin@example.com"); err != nil {
+		t.Fatalf("set tags err: %v", err)
+	}
+	if err := repo.SetDocumentTags(ctx, "tag-doc-2", []string{"security"}, "admin@example.com"); err != nil {
+		t.Fatalf("set tags err: %v", err)
+	}
+
+	// Replacing the tags drops the missing ones
+	if err := repo.SetDocumentTags(ctx, "tag-doc-1", []string{"security", "gdpr"}, "admin@example.com"); err != nil {
+		t.Fatalf("replace tags err: %v", err)
+	}
+	tags, err := repo.ListDocumentTags(ctx, "tag-doc-1")
+	if err != nil || len(tags) != 2 || tags[0] != "gdpr" || tags[1] != "security" {
+		t.Fatalf("unexpected document tags %v, %v", tags, err)
+	}
+
+	counts, err := repo.ListTags(ctx, "s", 10)
+	if err != nil || len(counts) != 1 || counts[0].Tag != "security" || counts[0].Documents != 2 {
+		t.Fatalf("unexpected tag autocomplete %+v, %v", counts, err)
+	}
+
+	filter := models.DocumentFilter{Tag: "gdpr", Search: "security"}
+	docs, err := repo.ListDocuments(ctx, filter, 10, 0)
+	if err != nil || len(docs) != 1 || docs[0].DocID != "tag-doc-1" {
+		t.Fatalf("unexpected filtered documents %+v, %v", docs, err)
+	}
+	if count, err := repo.CountDocuments(ctx, models.DocumentFilter{Tag: "security", Owner: "admin@example.com"}); err != nil || count != 2 {
+		t.Fatalf("expected 2 documents tagged security, got %d, %v", count, err)
+	}
+
+	stats, err := repo.Stats(ctx, "security")
+	if err != nil || stats.Documents != 2 {
+		t.Fatalf("unexpected tag stats %+v, %v", stats, err)
+	}
+
+	days := 90
+	if _, err := repo.UpsertPolicy(ctx, models.TagPolicy{Tag: "gdpr", FirmAfterDays: 2, ArchiveAfterDays: &days}, "admin@example.com"); err != nil {
+		t.Fatalf("upsert policy err: %v", err)
+	}
+	policies, err := repo.ListDocumentPolicies(ctx, "tag-doc-1")
+	if err != nil || len(policies) != 1 || policies[0].FirmAfterDays != 2 || *policies[0].ArchiveAfterDays != 90 {
+		t.Fatalf("unexpected document policies %+v, %v", policies, err)
+	}
+
+	// Renaming merges the tag and moves its policy
+	if n, err := repo.RenameTag(ctx, "gdpr", "security"); err != nil || n != 1 {
+		t.Fatalf("rename err: %d, %v", n, err)
+	}
+	if tags, _ := repo.ListDocumentTags(ctx, "tag-doc-1"); len(tags) != 1 || tags[0] != "security" {
+		t.Errorf("expected the renamed tag merged, got %v", tags)
+	}
+	if policies, _ := repo.ListPolicies(ctx); len(policies) != 1 || policies[0].Tag != "security" {
+		t.Errorf("expected the policy moved to the new tag, got %+v", policies)
+	}
+
+	if n, err := repo.DeleteTag(ctx, "security"); err != nil || n != 2 {
+		t.Fatalf("delete err: %d, %v", n, err)
+	}
+	if _, err := repo.DeleteTag(ctx, "security"); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("expected ErrTagNotFound, got %v", err)
+	}
+	if err := repo.DeletePolicy(ctx, "security"); !errors.Is(err, models.ErrTagNotFound) {
+		t.Errorf("expected the policy deleted with the tag, got %v", err)
+	}
+}
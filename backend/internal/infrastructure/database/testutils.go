@@ -31,7 +31,7 @@ type TestDB struct {
 	TenantProvider providers.TenantProvider
 }
 
-func SetupTestDB(t *testing.T) *TestDB {
+func SetupTestDB(t testing.TB) *TestDB {
 	t.Helper()
 
 	if os.Getenv("INTEGRATION_TESTS") == "" {
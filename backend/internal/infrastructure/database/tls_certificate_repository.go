@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// TLSCertificateRepository stores the ACME account key and certificates of the automatic TLS.
+// They belong to the instance, so the table is shared by all tenants.
+type TLSCertificateRepository struct {
+	db *sql.DB
+}
+
+// NewTLSCertificateRepository creates a new TLS certificate repository
+func NewTLSCertificateRepository(db *sql.DB) *TLSCertificateRepository {
+	return &TLSCertificateRepository{db: db}
+}
+
+// Get returns the data stored under name, or nil when there is none
+func (r *TLSCertificateRepository) Get(ctx context.Context, name string) ([]byte, error) {
+	var data []byte
+	err := r.db.QueryRowContext(ctx, `SELECT data FROM tls_certificates WHERE name = $1`, name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TLS certificate: %w", err)
+	}
+	return data, nil
+}
+
+// Put stores data under name, replacing the previous data
+func (r *TLSCertificateRepository) Put(ctx context.Context, name string, data []byte) error {
+	query := `
+		INSERT INTO tls_certificates (name, data)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE
+		SET data = EXCLUDED.data, updated_at = now()
+	`
+	if _, err := r.db.ExecContext(ctx, query, name, data); err != nil {
+		return fmt.Errorf("failed to store TLS certificate: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the data stored under name, if any
+func (r *TLSCertificateRepository) Delete(ctx context.Context, name string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tls_certificates WHERE name = $1`, name); err != nil {
+		return fmt.Errorf("failed to delete TLS certificate: %w", err)
+	}
+	return nil
+}
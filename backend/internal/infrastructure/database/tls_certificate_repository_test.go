@@ -0,0 +1,41 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTLSCertificateRepository_GetPutDelete(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewTLSCertificateRepository(testDB.DB)
+	ctx := context.Background()
+	t.Cleanup(func() {
+		_, _ = testDB.DB.ExecContext(context.Background(), `DELETE FROM tls_certificates WHERE name = 'test.example.com'`)
+	})
+
+	if data, err := repo.Get(ctx, "test.example.com"); err != nil || data != nil {
+		t.Fatalf("expected no data for a missing certificate, got %q, %v", data, err)
+	}
+	if err := repo.Put(ctx, "test.example.com", []byte("first")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := repo.Put(ctx, "test.example.com", []byte("renewed")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if data, err := repo.Get(ctx, "test.example.com"); err != nil || string(data) != "renewed" {
+		t.Fatalf("expected the renewed certificate, got %q, %v", data, err)
+	}
+	if err := repo.Delete(ctx, "test.example.com"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if data, err := repo.Get(ctx, "test.example.com"); err != nil || data != nil {
+		t.Fatalf("expected no data after Delete, got %q, %v", data, err)
+	}
+	if err := repo.Delete(ctx, "test.example.com"); err != nil {
+		t.Fatalf("Delete of a missing certificate failed: %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const uploadQuarantineColumns = `id, tenant_id, storage_key, storage_provider, original_filename, title, mime_type, file_size,
+	checksum, read_mode, allow_download, require_full_read, verify_checksum, reason, detail, uploaded_by, status,
+	reviewed_by, reviewed_at, doc_id, created_at`
+
+// UploadQuarantineRepository handles database operations for the uploads held for admin review
+type UploadQuarantineRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewUploadQuarantineRepository creates a new upload quarantine repository
+func NewUploadQuarantineRepository(db *sql.DB, tenants providers.TenantProvider) *UploadQuarantineRepository {
+	return &UploadQuarantineRepository{db: db, tenants: tenants}
+}
+
+func scanQuarantinedUpload(row interface{ Scan(dest ...any) error }) (*models.QuarantinedUpload, error) {
+	q := &models.QuarantinedUpload{}
+	var reviewedBy, docID sql.NullString
+	var reviewedAt sql.NullTime
+	err := row.Scan(&q.ID, &q.TenantID, &q.StorageKey, &q.StorageProvider, &q.OriginalFilename, &q.Title, &q.MimeType,
+		&q.FileSize, &q.Checksum, &q.ReadMode, &q.AllowDownload, &q.RequireFullRead, &q.VerifyChecksum, &q.Reason,
+		&q.Detail, &q.UploadedBy, &q.Status, &reviewedBy, &reviewedAt, &docID, &q.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if reviewedBy.Valid {
+		q.ReviewedBy = &reviewedBy.String
+	}
+	if reviewedAt.Valid {
+		q.ReviewedAt = &reviewedAt.Time
+	}
+	if docID.Valid {
+		q.DocID = &docID.String
+	}
+	return q, nil
+}
+
+// Create records a quarantined upload, pending review
+func (r *UploadQuarantineRepository) Create(ctx context.Context, q *models.QuarantinedUpload) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO upload_quarantine (tenant_id, storage_key, storage_provider, original_filename, title, mime_type,
+			file_size, checksum, read_mode, allow_download, require_full_read, verify_checksum, reason, detail, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, status, created_at`,
+		tenantID, q.StorageKey, q.StorageProvider, q.OriginalFilename, q.Title, q.MimeType, q.FileSize, q.Checksum,
+		q.ReadMode, q.AllowDownload, q.RequireFullRead, q.VerifyChecksum, q.Reason, q.Detail, q.UploadedBy,
+	).Scan(&q.ID, &q.Status, &q.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create quarantined upload: %w", err)
+	}
+	q.TenantID = tenantID
+	return nil
+}
+
+// List returns the quarantined uploads with a status, or all of them when status is empty, newest first
+// RLS policy automatically filters by tenant_id
+func (r *UploadQuarantineRepository) List(ctx context.Context, status models.QuarantineStatus) ([]*models.QuarantinedUpload, error) {
+	query := `SELECT ` + uploadQuarantineColumns + ` FROM upload_quarantine
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined uploads: %w", err)
+	}
+	defer rows.Close()
+
+	uploads := []*models.QuarantinedUpload{}
+	for rows.Next() {
+		q, err := scanQuarantinedUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan quarantined upload: %w", err)
+		}
+		uploads = append(uploads, q)
+	}
+	return uploads, rows.Err()
+}
+
+// GetByID returns a quarantined upload
+// RLS policy automatically filters by tenant_id
+func (r *UploadQuarantineRepository) GetByID(ctx context.Context, id int64) (*models.QuarantinedUpload, error) {
+	query := `SELECT ` + uploadQuarantineColumns + ` FROM upload_quarantine WHERE id = $1`
+
+	q, err := scanQuarantinedUpload(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.ErrQuarantineNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quarantined upload: %w", err)
+	}
+	return q, nil
+}
+
+// MarkReviewed records the decision on a pending upload, with the document created when released.
+// It returns ErrQuarantineReviewed when another admin decided first.
+// RLS policy automatically filters by tenant_id
+func (r *UploadQuarantineRepository) MarkReviewed(ctx context.Context, id int64, status models.QuarantineStatus, reviewedBy, docID string) (*models.QuarantinedUpload, error) {
+	query := `
+		UPDATE upload_quarantine
+		SET status = $2, reviewed_by = $3, reviewed_at = now(), doc_id = NULLIF($4, '')
+		WHERE id = $1 AND status = 'pending'
+		RETURNING ` + uploadQuarantineColumns
+
+	q, err := scanQuarantinedUpload(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id, status, reviewedBy, docID))
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, getErr := r.GetByID(ctx, id); getErr != nil {
+			return nil, getErr
+		}
+		return nil, models.ErrQuarantineReviewed
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to review quarantined upload: %w", err)
+	}
+	return q, nil
+}
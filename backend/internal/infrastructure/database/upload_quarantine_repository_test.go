@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestUploadQuarantineRepository_Review(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewUploadQuarantineRepository(tdb.DB, tdb.TenantProvider)
+
+	upload := &models.QuarantinedUpload{
+		StorageKey:       "quarantine/2026/10/abc.pdf",
+		StorageProvider:  "local",
+		OriginalFilename: "policy.pdf",
+		MimeType:         "application/pdf",
+		FileSize:         1024,
+		Checksum:         "deadbeef",
+		ReadMode:         "integrated",
+		VerifyChecksum:   true,
+		Reason:           models.QuarantineReasonInfected,
+		Detail:           "Eicar-Test-Signature",
+		UploadedBy:       "user@example.com",
+	}
+	if err := repo.Create(ctx, upload); err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+	if upload.ID == 0 || upload.Status != models.QuarantineStatusPending {
+		t.Fatalf("unexpected created upload: %+v", upload)
+	}
+
+	pending, err := repo.List(ctx, models.QuarantineStatusPending)
+	if err != nil || len(pending) != 1 || pending[0].Detail != "Eicar-Test-Signature" {
+		t.Fatalf("unexpected pending uploads: %+v (err %v)", pending, err)
+	}
+
+	discarded, err := repo.MarkReviewed(ctx, upload.ID, models.QuarantineStatusDiscarded, "admin@example.com", "")
+	if err != nil {
+		t.Fatalf("mark reviewed err: %v", err)
+	}
+	if discarded.Status != models.QuarantineStatusDiscarded || discarded.ReviewedBy == nil || discarded.ReviewedAt == nil || discarded.DocID != nil {
+		t.Fatalf("unexpected reviewed upload: %+v", discarded)
+	}
+
+	// A decision is final
+	if _, err := repo.MarkReviewed(ctx, upload.ID, models.QuarantineStatusReleased, "admin@example.com", "doc"); !errors.Is(err, models.ErrQuarantineReviewed) {
+		t.Fatalf("expected ErrQuarantineReviewed, got %v", err)
+	}
+	if _, err := repo.MarkReviewed(ctx, upload.ID+1, models.QuarantineStatusReleased, "admin@example.com", ""); !errors.Is(err, models.ErrQuarantineNotFound) {
+		t.Fatalf("expected ErrQuarantineNotFound, got %v", err)
+	}
+
+	if pending, _ := repo.List(ctx, models.QuarantineStatusPending); len(pending) != 0 {
+		t.Fatalf("expected no pending upload, got %d", len(pending))
+	}
+	if all, _ := repo.List(ctx, ""); len(all) != 1 {
+		t.Fatalf("expected one upload in total, got %d", len(all))
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// UserPreferenceRepository handles database operations for user preferences
+type UserPreferenceRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewUserPreferenceRepository creates a new user preference repository
+func NewUserPreferenceRepository(db *sql.DB, tenants providers.TenantProvider) *UserPreferenceRepository {
+	return &UserPreferenceRepository{db: db, tenants: tenants}
+}
+
+// Get returns the preferences of a user, or nil when none are stored
+// RLS policy automatically filters by tenant_id
+func (r *UserPreferenceRepository) Get(ctx context.Context, email string) (*models.UserPreferences, error) {
+	query := `SELECT email, locale, timezone, updated_at FROM user_preferences WHERE email = $1`
+
+	p := &models.UserPreferences{}
+	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, email).Scan(&p.Email, &p.Locale, &p.Timezone, &p.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user preferences: %w", err)
+	}
+	return p, nil
+}
+
+// SetLocale stores the preferred locale of a user. When overwrite is false, a locale already
+// stored is kept and returned instead; an empty one, stored with a timezone, is replaced.
+func (r *UserPreferenceRepository) SetLocale(ctx context.Context, email, locale string, overwrite bool) (*models.UserPreferences, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	// The no-op update lets RETURNING report the stored row when it is kept
+	query := `
+		INSERT INTO user_preferences (tenant_id, email, locale)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, email) DO UPDATE SET
+			locale = CASE WHEN $4 OR user_preferences.locale = '' THEN EXCLUDED.locale ELSE user_preferences.locale END,
+			updated_at = CASE WHEN $4 OR user_preferences.locale = '' THEN now() ELSE user_preferences.updated_at END
+		RETURNING email, locale, timezone, updated_at`
+
+	p := &models.UserPreferences{}
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, tenantID, email, locale, overwrite).Scan(&p.Email, &p.Locale, &p.Timezone, &p.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user locale: %w", err)
+	}
+	return p, nil
+}
+
+// SetTimezone stores the timezone of a user, an empty one clearing it. A user without stored
+// preferences keeps an empty locale, detected at their next login.
+func (r *UserPreferenceRepository) SetTimezone(ctx context.Context, email, timezone string) (*models.UserPreferences, error) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_preferences (tenant_id, email, locale, timezone)
+		VALUES ($1, $2, '', $3)
+		ON CONFLICT (tenant_id, email) DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			updated_at = now()
+		RETURNING email, locale, timezone, updated_at`
+
+	p := &models.UserPreferences{}
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, tenantID, email, timezone).Scan(&p.Email, &p.Locale, &p.Timezone, &p.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user timezone: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,63 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUserPreferenceRepository_SetLocale(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewUserPreferenceRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	if p, err := repo.Get(ctx, "alice@example.com"); err != nil || p != nil {
+		t.Fatalf("expected no preferences, got %+v, %v", p, err)
+	}
+
+	p, err := repo.SetLocale(ctx, "alice@example.com", "fr", false)
+	if err != nil || p.Locale != "fr" {
+		t.Fatalf("expected detected locale stored, got %+v, %v", p, err)
+	}
+
+	// A later detection keeps the stored locale
+	p, err = repo.SetLocale(ctx, "alice@example.com", "de", false)
+	if err != nil || p.Locale != "fr" {
+		t.Fatalf("expected stored locale kept, got %+v, %v", p, err)
+	}
+
+	p, err = repo.SetLocale(ctx, "alice@example.com", "es", true)
+	if err != nil || p.Locale != "es" {
+		t.Fatalf("expected locale overwritten, got %+v, %v", p, err)
+	}
+	if got, err := repo.Get(ctx, "alice@example.com"); err != nil || got.Locale != "es" {
+		t.Errorf("unexpected preferences %+v, %v", got, err)
+	}
+}
+
+func TestUserPreferenceRepository_SetTimezone(t *testing.T) {
+	testDB := SetupTestDB(t)
+	repo := NewUserPreferenceRepository(testDB.DB, testDB.TenantProvider)
+	ctx := context.Background()
+
+	p, err := repo.SetTimezone(ctx, "bob@example.com", "Asia/Tokyo")
+	if err != nil || p.Timezone != "Asia/Tokyo" || p.Locale != "" {
+		t.Fatalf("expected timezone stored without locale, got %+v, %v", p, err)
+	}
+
+	// The locale detected at first login replaces the empty one and keeps the timezone
+	p, err = repo.SetLocale(ctx, "bob@example.com", "de", false)
+	if err != nil || p.Locale != "de" || p.Timezone != "Asia/Tokyo" {
+		t.Fatalf("expected detected locale stored, got %+v, %v", p, err)
+	}
+
+	if _, err := repo.SetTimezone(ctx, "bob@example.com", ""); err != nil {
+		t.Fatalf("clear timezone: %v", err)
+	}
+	if got, err := repo.Get(ctx, "bob@example.com"); err != nil || got.Timezone != "" || got.Locale != "de" {
+		t.Errorf("unexpected preferences %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const userSessionColumns = `id, tenant_id, session_id, user_sub, user_email, user_name, user_picture,
+	COALESCE(ip_address, ''), COALESCE(user_agent, ''), created_at, last_seen_at, expires_at, revoked_at`
+
+// UserSessionRepository handles database operations for server-side browser sessions
+type UserSessionRepository struct {
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+// NewUserSessionRepository creates a new user session repository
+func NewUserSessionRepository(db *sql.DB, tenants providers.TenantProvider) *UserSessionRepository {
+	return &UserSessionRepository{db: db, tenants: tenants}
+}
+
+// Create inserts a new user session
+func (r *UserSessionRepository) Create(ctx context.Context, session *models.UserSession) error {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get tenant: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_sessions (
+			tenant_id, session_id, user_sub, user_email, user_name, user_picture,
+			ip_address, user_agent, expires_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, last_seen_at
+	`
+
+	err = dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query,
+		tenantID,
+		session.SessionID,
+		session.UserSub,
+		session.UserEmail,
+		session.UserName,
+		session.UserPicture,
+		session.IPAddress,
+		session.UserAgent,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt, &session.LastSeenAt)
+	if err != nil {
+		return fmt.Errorf("failed to create user session: %w", err)
+	}
+
+	session.TenantID = tenantID
+	return nil
+}
+
+// GetBySessionID returns a session by its cookie identifier, or nil if unknown
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) GetBySessionID(ctx context.Context, sessionID string) (*models.UserSession, error) {
+	query := `SELECT ` + userSessionColumns + ` FROM user_sessions WHERE session_id = $1`
+
+	session, err := scanUserSession(dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, sessionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user session: %w", err)
+	}
+	return session, nil
+}
+
+// Touch records activity on a session and moves its expiry to expiresAt (sliding renewal)
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) Touch(ctx context.Context, sessionID, ipAddress string, expiresAt time.Time) error {
+	query := `UPDATE user_sessions SET last_seen_at = now(), ip_address = $2, expires_at = $3 WHERE session_id = $1`
+
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, sessionID, ipAddress, expiresAt); err != nil {
+		return fmt.Errorf("failed to touch user session: %w", err)
+	}
+	return nil
+}
+
+// ListActiveByEmail returns the non-revoked, non-expired sessions of a user, most recent activity first
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) ListActiveByEmail(ctx context.Context, email string) ([]*models.UserSession, error) {
+	query := `
+		SELECT ` + userSessionColumns + `
+		FROM user_sessions
+		WHERE lower(user_email) = lower($1) AND revoked_at IS NULL AND expires_at > now()
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := dbctx.GetQuerier(ctx, r.db).QueryContext(ctx, query, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*models.UserSession{}
+	for rows.Next() {
+		session, err := scanUserSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// Revoke revokes one active session of a user. It reports whether a session was revoked.
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) Revoke(ctx context.Context, id int64, email string) (bool, error) {
+	query := `
+		UPDATE user_sessions SET revoked_at = now()
+		WHERE id = $1 AND lower(user_email) = lower($2) AND revoked_at IS NULL
+	`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, id, email)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke user session: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RevokeAllByEmail revokes every active session of a user except exceptSessionID (if not empty)
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) RevokeAllByEmail(ctx context.Context, email, exceptSessionID string) (int64, error) {
+	query := `
+		UPDATE user_sessions SET revoked_at = now()
+		WHERE lower(user_email) = lower($1) AND revoked_at IS NULL AND session_id <> $2
+	`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, email, exceptSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// RevokeBySessionID revokes a session by its cookie identifier
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) RevokeBySessionID(ctx context.Context, sessionID string) error {
+	query := `UPDATE user_sessions SET revoked_at = now() WHERE session_id = $1 AND revoked_at IS NULL`
+
+	if _, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke user session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes sessions that expired or were revoked more than olderThan ago
+// RLS policy automatically filters by tenant_id
+func (r *UserSessionRepository) DeleteExpired(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `DELETE FROM user_sessions WHERE expires_at < $1 OR revoked_at < $1`
+
+	result, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, query, time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired user sessions: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if deleted > 0 {
+		logger.Logger.Info("Deleted expired user sessions", "count", deleted)
+	}
+	return deleted, nil
+}
+
+func scanUserSession(row interface{ Scan(dest ...any) error }) (*models.UserSession, error) {
+	session := &models.UserSession{}
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&session.ID,
+		&session.TenantID,
+		&session.SessionID,
+		&session.UserSub,
+		&session.UserEmail,
+		&session.UserName,
+		&session.UserPicture,
+		&session.IPAddress,
+		&session.UserAgent,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	return session, nil
+}
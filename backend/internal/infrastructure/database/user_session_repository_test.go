@@ -0,0 +1,88 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func TestUserSessionRepository_Lifecycle(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewUserSessionRepository(tdb.DB, tdb.TenantProvider)
+
+	for _, sid := range []string{"sid-laptop", "sid-phone"} {
+		session := &models.UserSession{
+			SessionID: sid, UserSub: "sub-1", UserEmail: "alice@example.com", UserName: "Alice",
+			IPAddress: "203.0.113.7", UserAgent: "agent-" + sid, ExpiresAt: time.Now().Add(time.Hour),
+		}
+		if err := repo.Create(ctx, session); err != nil {
+			t.Fatalf("create err: %v", err)
+		}
+	}
+
+	sessions, err := repo.ListActiveByEmail(ctx, "Alice@Example.com")
+	if err != nil {
+		t.Fatalf("list err: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+
+	missing, err := repo.GetBySessionID(ctx, "unknown")
+	if err != nil || missing != nil {
+		t.Fatalf("expected no session, got %+v (err %v)", missing, err)
+	}
+
+	revoked, err := repo.Revoke(ctx, sessions[0].ID, "bob@example.com")
+	if err != nil || revoked {
+		t.Fatalf("expected no revocation for another user, got %v (err %v)", revoked, err)
+	}
+
+	count, err := repo.RevokeAllByEmail(ctx, "alice@example.com", "sid-phone")
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 revoked session, got %d (err %v)", count, err)
+	}
+
+	laptop, err := repo.GetBySessionID(ctx, "sid-laptop")
+	if err != nil || laptop == nil || laptop.IsActive() {
+		t.Fatalf("expected revoked laptop session, got %+v (err %v)", laptop, err)
+	}
+	phone, err := repo.GetBySessionID(ctx, "sid-phone")
+	if err != nil || phone == nil || !phone.IsActive() {
+		t.Fatalf("expected active phone session, got %+v (err %v)", phone, err)
+	}
+}
+
+func TestUserSessionRepository_TouchRenewsExpiry(t *testing.T) {
+	tdb := SetupTestDB(t)
+	ctx := context.Background()
+	repo := NewUserSessionRepository(tdb.DB, tdb.TenantProvider)
+
+	session := &models.UserSession{
+		SessionID: "sid-idle", UserSub: "sub-1", UserEmail: "alice@example.com",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	if err := repo.Create(ctx, session); err != nil {
+		t.Fatalf("create err: %v", err)
+	}
+
+	renewed := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := repo.Touch(ctx, "sid-idle", "198.51.100.4", renewed); err != nil {
+		t.Fatalf("touch err: %v", err)
+	}
+
+	got, err := repo.GetBySessionID(ctx, "sid-idle")
+	if err != nil || got == nil {
+		t.Fatalf("expected session, got %+v (err %v)", got, err)
+	}
+	if !got.ExpiresAt.Equal(renewed) || got.IPAddress != "198.51.100.4" {
+		t.Errorf("expected renewed expiry %s from the new IP, got %s from %s", renewed, got.ExpiresAt, got.IPAddress)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/lib/pq"
 )
 
 // Joined view of a delivery with webhook send data
@@ -241,6 +242,19 @@ func (r *WebhookDeliveryRepository) ListByWebhook(ctx context.Context, webhookID
 	return out, nil
 }
 
+// ReleaseToPending puts deliveries claimed for processing but not sent back in the queue
+func (r *WebhookDeliveryRepository) ReleaseToPending(ctx context.Context, ids []int64) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	q := `UPDATE webhook_deliveries SET status = 'pending' WHERE id = ANY($1) AND status = 'processing'`
+	res, err := dbctx.GetQuerier(ctx, r.db).ExecContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to release webhook deliveries: %w", err)
+	}
+	return res.RowsAffected()
+}
+
 func (r *WebhookDeliveryRepository) CleanupOld(ctx context.Context, olderThan time.Duration) (int64, error) {
 	q := `DELETE FROM webhook_deliveries WHERE status IN ('delivered','failed','cancelled') AND processed_at < $1`
 	cutoff := time.Now().Add(-olderThan)
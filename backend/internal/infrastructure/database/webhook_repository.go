@@ -36,9 +36,9 @@ func (r *WebhookRepository) Create(ctx context.Context, input models.WebhookInpu
 	}
 
 	query := `
-        INSERT INTO webhooks (tenant_id, title, target_url, secret, active, events, headers, description, created_by)
-        VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
-        RETURNING id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count
+        INSERT INTO webhooks (tenant_id, title, target_url, secret, active, events, headers, description, created_by, doc_id)
+        VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+        RETURNING id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count, doc_id
     `
 	wh := &models.Webhook{}
 	var headersOut models.NullRawMessage
@@ -52,9 +52,10 @@ func (r *WebhookRepository) Create(ctx context.Context, input models.WebhookInpu
 		headersIn,
 		input.Description,
 		input.CreatedBy,
+		input.DocID,
 	).Scan(
 		&wh.ID, &wh.TenantID, &wh.Title, &wh.TargetURL, &wh.Secret, &wh.Active, pq.Array(&wh.Events), &headersOut, &wh.Description, &wh.CreatedBy,
-		&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount,
+		&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount, &wh.DocID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create webhook: %w", err)
@@ -79,7 +80,7 @@ func (r *WebhookRepository) Update(ctx context.Context, id int64, input models.W
         UPDATE webhooks
         SET title=$1, target_url=$2, secret=COALESCE(NULLIF($3,''), secret), active=$4, events=$5, headers=$6, description=$7, updated_at=now()
         WHERE id=$8
-        RETURNING id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count
+        RETURNING id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count, doc_id
     `
 	wh := &models.Webhook{}
 	var headersOut models.NullRawMessage
@@ -94,7 +95,7 @@ func (r *WebhookRepository) Update(ctx context.Context, id int64, input models.W
 		id,
 	).Scan(
 		&wh.ID, &wh.TenantID, &wh.Title, &wh.TargetURL, &wh.Secret, &wh.Active, pq.Array(&wh.Events), &headersOut, &wh.Description, &wh.CreatedBy,
-		&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount,
+		&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount, &wh.DocID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update webhook: %w", err)
@@ -133,7 +134,7 @@ func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
 // RLS policy automatically filters by tenant_id
 func (r *WebhookRepository) GetByID(ctx context.Context, id int64) (*models.Webhook, error) {
 	query := `
-        SELECT id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count
+        SELECT id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count, doc_id
         FROM webhooks
         WHERE id=$1
     `
@@ -142,7 +143,7 @@ func (r *WebhookRepository) GetByID(ctx context.Context, id int64) (*models.Webh
 	var headersJSON models.NullRawMessage
 	err := dbctx.GetQuerier(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&wh.ID, &wh.TenantID, &wh.Title, &wh.TargetURL, &wh.Secret, &wh.Active, pq.Array(&events), &headersJSON, &wh.Description, &wh.CreatedBy,
-		&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount,
+		&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount, &wh.DocID,
 	)
 	if err != nil {
 		return nil, err
@@ -158,7 +159,7 @@ func (r *WebhookRepository) GetByID(ctx context.Context, id int64) (*models.Webh
 // RLS policy automatically filters by tenant_id
 func (r *WebhookRepository) List(ctx context.Context, limit, offset int) ([]*models.Webhook, error) {
 	query := `
-        SELECT id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count
+        SELECT id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count, doc_id
         FROM webhooks
         ORDER BY id DESC
         LIMIT $1 OFFSET $2
@@ -176,7 +177,7 @@ func (r *WebhookRepository) List(ctx context.Context, limit, offset int) ([]*mod
 		var headersJSON models.NullRawMessage
 		if err := rows.Scan(
 			&wh.ID, &wh.TenantID, &wh.Title, &wh.TargetURL, &wh.Secret, &wh.Active, pq.Array(&events), &headersJSON, &wh.Description, &wh.CreatedBy,
-			&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount,
+			&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount, &wh.DocID,
 		); err != nil {
 			return nil, err
 		}
@@ -193,7 +194,7 @@ func (r *WebhookRepository) List(ctx context.Context, limit, offset int) ([]*mod
 // RLS policy automatically filters by tenant_id
 func (r *WebhookRepository) ListActiveByEvent(ctx context.Context, event string) ([]*models.Webhook, error) {
 	query := `
-        SELECT id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count
+        SELECT id, tenant_id, title, target_url, secret, active, events, headers, description, created_by, created_at, updated_at, last_delivered_at, failure_count, doc_id
         FROM webhooks
         WHERE active = TRUE AND $1 = ANY(events)
     `
@@ -210,7 +211,7 @@ func (r *WebhookRepository) ListActiveByEvent(ctx context.Context, event string)
 		var headersJSON models.NullRawMessage
 		if err := rows.Scan(
 			&wh.ID, &wh.TenantID, &wh.Title, &wh.TargetURL, &wh.Secret, &wh.Active, pq.Array(&events), &headersJSON, &wh.Description, &wh.CreatedBy,
-			&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount,
+			&wh.CreatedAt, &wh.UpdatedAt, &wh.LastDeliveredAt, &wh.FailureCount, &wh.DocID,
 		); err != nil {
 			return nil, err
 		}
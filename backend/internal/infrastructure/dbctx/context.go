@@ -58,3 +58,72 @@ func GetQuerier(ctx context.Context, db *sql.DB) Querier {
 	}
 	return db
 }
+
+// readTxKey is the context key for the read-only transaction on the read replica.
+type readTxKey struct{}
+
+// WithReadTx returns a new context whose heavy reads run in the given read-only
+// transaction on the read replica. Writes keep using the request transaction.
+func WithReadTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, readTxKey{}, tx)
+}
+
+// ReadsFromReplica reports whether heavy reads made with this context go to the read replica.
+// Their results may lag behind the primary and must not be cached.
+func ReadsFromReplica(ctx context.Context) bool {
+	tx, _ := ctx.Value(readTxKey{}).(*sql.Tx)
+	return tx != nil
+}
+
+// GetReadQuerier returns the Querier for heavy reads: lists, statuses, statistics and exports.
+// It returns the read replica transaction when the request was routed to the replica,
+// and the same Querier as GetQuerier otherwise.
+func GetReadQuerier(ctx context.Context, db *sql.DB) Querier {
+	if tx, ok := ctx.Value(readTxKey{}).(*sql.Tx); ok && tx != nil {
+		return tx
+	}
+	return GetQuerier(ctx, db)
+}
+
+// noCacheKey is the context key marking reads that must skip caches.
+type noCacheKey struct{}
+
+// WithoutCache returns a new context whose reads bypass repository caches
+// and go to the database. Used to debug suspected stale data.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// CacheBypassed reports whether reads made with this context must bypass caches.
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
+}
+
+// departmentScopeKey is the context key for the departments a department-admin may access.
+type departmentScopeKey struct{}
+
+// WithDepartmentScope returns a new context limited to the given departments: the
+// department of a department-admin first, then its sub-departments. The RLS policies
+// enforce the scope once app.department_ids is set on the transaction.
+func WithDepartmentScope(ctx context.Context, departmentIDs []int64) context.Context {
+	return context.WithValue(ctx, departmentScopeKey{}, departmentIDs)
+}
+
+// DepartmentScope returns the departments the context is limited to, or nil when
+// the context is not limited to departments.
+func DepartmentScope(ctx context.Context) []int64 {
+	ids, _ := ctx.Value(departmentScopeKey{}).([]int64)
+	return ids
+}
+
+// HomeDepartment returns the department new documents are assigned to within a
+// department scope, or nil when the context is not limited to departments.
+func HomeDepartment(ctx context.Context) *int64 {
+	ids := DepartmentScope(ctx)
+	if len(ids) == 0 {
+		return nil
+	}
+	home := ids[0]
+	return &home
+}
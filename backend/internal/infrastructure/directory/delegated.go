@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package directory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"golang.org/x/oauth2"
+)
+
+// CallbackPath is where providers redirect the admin after consenting to delegated access
+const CallbackPath = "/api/v1/admin/integrations/directory/callback"
+
+// TokenSource provides the access tokens of directories read with delegated access
+type TokenSource interface {
+	AccessToken(ctx context.Context, provider string) (string, error)
+	InvalidateAccessToken(provider string)
+}
+
+// DelegatedOAuth runs the authorization code flow of the directories read with delegated access,
+// asking for offline access, and refreshes their access tokens
+type DelegatedOAuth struct {
+	configs map[string]*oauth2.Config
+	client  *http.Client // Used for token requests
+}
+
+// NewDelegatedOAuth creates the OAuth clients of the providers configured for delegated access;
+// baseURL is the public URL the callback is served under
+func NewDelegatedOAuth(cfg config.DirectoryConfig, baseURL string) *DelegatedOAuth {
+	redirectURL := strings.TrimSuffix(baseURL, "/") + CallbackPath
+	configs := make(map[string]*oauth2.Config)
+
+	if cfg.GoogleClientID != "" {
+		configs[models.DirectoryProviderGoogle] = &oauth2.Config{
+			ClientID:     cfg.GoogleClientID,
+			ClientSecret: cfg.GoogleClientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"https://www.googleapis.com/auth/admin.directory.group.member.readonly"},
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+				TokenURL: "https://oauth2.googleapis.com/token",
+			},
+		}
+	}
+
+	if cfg.MicrosoftTenantID != "" && cfg.MicrosoftDelegated {
+		base := fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0", cfg.MicrosoftTenantID)
+		configs[models.DirectoryProviderMicrosoft] = &oauth2.Config{
+			ClientID:     cfg.MicrosoftClientID,
+			ClientSecret: cfg.MicrosoftClientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       []string{"offline_access", "https://graph.microsoft.com/GroupMember.Read.All"},
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  base + "/authorize",
+				TokenURL: base + "/token",
+			},
+		}
+	}
+
+	return &DelegatedOAuth{configs: configs, client: &http.Client{Timeout: defaultTimeout}}
+}
+
+// Providers returns the providers configured for delegated access
+func (o *DelegatedOAuth) Providers() []string {
+	providers := make([]string, 0, len(o.configs))
+	for provider := range o.configs {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// AuthCodeURL returns the consent page of a provider, asking for a refresh token
+func (o *DelegatedOAuth) AuthCodeURL(provider, state string) (string, error) {
+	cfg, ok := o.configs[provider]
+	if !ok {
+		return "", models.ErrDirectoryNotDelegated
+	}
+	// Google only returns a refresh token for offline access on an explicit consent
+	return cfg.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce), nil
+}
+
+// Exchange trades the code of the consent callback for a token set
+func (o *DelegatedOAuth) Exchange(ctx context.Context, provider, code string) (*models.DirectoryToken, error) {
+	cfg, ok := o.configs[provider]
+	if !ok {
+		return nil, models.ErrDirectoryNotDelegated
+	}
+	token, err := cfg.Exchange(o.context(ctx), code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange directory authorization code: %w", err)
+	}
+	return directoryToken(token), nil
+}
+
+// Refresh trades a refresh token for a new access token. It returns ErrDirectoryTokenRevoked when
+// the provider rejects the refresh token: the admin revoked the consent, the token expired
+// unused, or the account lost its rights.
+func (o *DelegatedOAuth) Refresh(ctx context.Context, provider, refreshToken string) (*models.DirectoryToken, error) {
+	cfg, ok := o.configs[provider]
+	if !ok {
+		return nil, models.ErrDirectoryNotDelegated
+	}
+	token, err := cfg.TokenSource(o.context(ctx), &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant" {
+			return nil, fmt.Errorf("%w: %s", models.ErrDirectoryTokenRevoked, truncate(retrieveErr.ErrorDescription, 200))
+		}
+		return nil, fmt.Errorf("failed to refresh directory token: %w", err)
+	}
+
+	result := directoryToken(token)
+	if result.RefreshToken == refreshToken {
+		result.RefreshToken = ""
+	}
+	return result, nil
+}
+
+// context makes token requests use the client with a timeout
+func (o *DelegatedOAuth) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, o.client)
+}
+
+func directoryToken(token *oauth2.Token) *models.DirectoryToken {
+	return &models.DirectoryToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}
+}
+
+// newDelegatedClient creates a client authenticating the requests of a provider with the access
+// tokens of the token source
+func newDelegatedClient(provider string, tokens TokenSource) *http.Client {
+	return &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: &delegatedTransport{provider: provider, tokens: tokens, base: http.DefaultTransport},
+	}
+}
+
+// delegatedTransport adds the access token of the provider to requests. The token is looked up
+// with the context of the request, which carries the tenant of the stored credential.
+type delegatedTransport struct {
+	provider string
+	tokens   TokenSource
+	base     http.RoundTripper
+}
+
+func (t *delegatedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.tokens.AccessToken(req.Context(), t.provider)
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+	resp, err := t.base.RoundTrip(authorized)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		// The token was revoked before its expiry: the next request refreshes it
+		t.tokens.InvalidateAccessToken(t.provider)
+	}
+	return resp, err
+}
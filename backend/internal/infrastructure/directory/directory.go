@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package directory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+
+	// maxMembers bounds the members read from one directory group
+	maxMembers = 50000
+
+	// maxResponseSize bounds one page of members
+	maxResponseSize = 10 << 20
+)
+
+// Connector lists the members of a directory group, including those of nested groups
+type Connector interface {
+	ListMembers(ctx context.Context, group string) ([]models.DirectoryEntry, error)
+}
+
+// NewConnectors creates the connectors whose credentials are configured, by provider. Providers
+// configured for delegated access authenticate with the access tokens of tokens, and are skipped
+// when tokens is nil.
+func NewConnectors(cfg config.DirectoryConfig, tokens TokenSource) (map[string]Connector, error) {
+	connectors := make(map[string]Connector)
+
+	if cfg.GoogleCredentialsFile != "" {
+		client, err := newGoogleClient(cfg.GoogleCredentialsFile, cfg.GoogleAdminEmail)
+		if err != nil {
+			return nil, err
+		}
+		connectors[models.DirectoryProviderGoogle] = NewGoogleConnector(client, "")
+	} else if cfg.GoogleClientID != "" && tokens != nil {
+		connectors[models.DirectoryProviderGoogle] = NewGoogleConnector(newDelegatedClient(models.DirectoryProviderGoogle, tokens), "")
+	}
+
+	if cfg.MicrosoftTenantID != "" {
+		if !cfg.MicrosoftDelegated {
+			connectors[models.DirectoryProviderMicrosoft] = NewMicrosoftConnector(newMicrosoftClient(cfg), "")
+		} else if tokens != nil {
+			connectors[models.DirectoryProviderMicrosoft] = NewMicrosoftConnector(newDelegatedClient(models.DirectoryProviderMicrosoft, tokens), "")
+		}
+	}
+
+	if cfg.GitLabToken != "" {
+		connectors[models.DirectoryProviderGitLab] = NewGitLabConnector(&http.Client{Timeout: defaultTimeout}, cfg.GitLabURL, cfg.GitLabToken)
+	}
+
+	return connectors, nil
+}
+
+// serviceAccountKey holds the fields of a Google service account key file used by the JWT flow
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// newGoogleClient authenticates as a service account with domain-wide delegation,
+// impersonating an admin allowed to read groups
+func newGoogleClient(credentialsFile, adminEmail string) (*http.Client, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read google credentials: %w", err)
+	}
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse google credentials: %w", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("google credentials are not a service account key")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	cfg := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Subject:      adminEmail,
+		Scopes:       []string{"https://www.googleapis.com/auth/admin.directory.group.member.readonly"},
+		TokenURL:     key.TokenURI,
+	}
+	return oauthClient(cfg.Client), nil
+}
+
+// newMicrosoftClient authenticates as an application granted the GroupMember.Read.All permission
+func newMicrosoftClient(cfg config.DirectoryConfig) *http.Client {
+	ccfg := &clientcredentials.Config{
+		ClientID:     cfg.MicrosoftClientID,
+		ClientSecret: cfg.MicrosoftClientSecret,
+		TokenURL:     fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", cfg.MicrosoftTenantID),
+		Scopes:       []string{"https://graph.microsoft.com/.default"},
+	}
+	return oauthClient(ccfg.Client)
+}
+
+// oauthClient creates an authenticated client whose requests and token fetches time out
+func oauthClient(newClient func(ctx context.Context) *http.Client) *http.Client {
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: defaultTimeout})
+	client := newClient(ctx)
+	client.Timeout = defaultTimeout
+	return client
+}
+
+// getJSON fetches a page of a directory API and decodes it into out
+func getJSON(ctx context.Context, client *http.Client, url string, header http.Header, out interface{}) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build directory request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("directory request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("directory returned status %d: %s", resp.StatusCode, truncate(string(body), 200))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return nil, fmt.Errorf("failed to decode directory response: %w", err)
+	}
+	return resp.Header, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// checkSize stops reading a group that exceeds maxMembers
+func checkSize(entries []models.DirectoryEntry) error {
+	if len(entries) > maxMembers {
+		return fmt.Errorf("directory group has more than %d members", maxMembers)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package directory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"golang.org/x/oauth2"
+)
+
+func TestGoogleConnector_ListMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/directory/v1/groups/staff@example.com/members" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("includeDerivedMembership") != "true" {
+			t.Error("expected nested members to be included")
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"members":[{"id":"1","email":"alice@example.com","type":"USER","status":"ACTIVE"},
+				{"id":"2","email":"team@example.com","type":"GROUP"}],"nextPageToken":"next"}`)
+			return
+		}
+		fmt.Fprint(w, `{"members":[{"id":"3","email":"bob@example.com","type":"USER","status":"SUSPENDED"}]}`)
+	}))
+	defer server.Close()
+
+	entries, err := NewGoogleConnector(server.Client(), server.URL).ListMembers(context.Background(), "staff@example.com")
+	if err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(entries))
+	}
+	if entries[0].Attributes["email"] != "alice@example.com" || !entries[0].Active {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Active {
+		t.Error("suspended user should be inactive")
+	}
+}
+
+func TestMicrosoftConnector_ListMembers(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/groups/group-id/transitiveMembers/microsoft.graph.user":
+			fmt.Fprintf(w, `{"value":[{"id":"1","displayName":"Alice","mail":"alice@example.com","accountEnabled":true}],
+				"@odata.nextLink":"%s/next"}`, server.URL)
+		case "/next":
+			fmt.Fprint(w, `{"value":[{"id":"2","displayName":"Bob","userPrincipalName":"bob@example.com","accountEnabled":false}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	entries, err := NewMicrosoftConnector(server.Client(), server.URL).ListMembers(context.Background(), "group-id")
+	if err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(entries))
+	}
+	if entries[0].Attributes["displayName"] != "Alice" || entries[0].Attributes["mail"] != "alice@example.com" {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Active || entries[1].Attributes["userPrincipalName"] != "bob@example.com" {
+		t.Errorf("unexpected second entry %+v", entries[1])
+	}
+}
+
+func TestGitLabConnector_ListMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Private-Token") != "glpat-test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.EscapedPath() != "/api/v4/groups/acme%2Fstaff/members/all" {
+			t.Errorf("unexpected path %s", r.URL.EscapedPath())
+		}
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":1,"username":"alice","name":"Alice","state":"active"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":2,"username":"bob","name":"Bob","state":"blocked","email":"bob@example.com"}]`)
+	}))
+	defer server.Close()
+
+	entries, err := NewGitLabConnector(server.Client(), server.URL, "glpat-test").ListMembers(context.Background(), "acme/staff")
+	if err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(entries))
+	}
+	if entries[0].Attributes["username"] != "alice" || !entries[0].Active {
+		t.Errorf("unexpected first entry %+v", entries[0])
+	}
+	if entries[1].Active || entries[1].Attributes["email"] != "bob@example.com" {
+		t.Errorf("unexpected second entry %+v", entries[1])
+	}
+
+	if _, err := NewGitLabConnector(server.Client(), server.URL, "wrong").ListMembers(context.Background(), "acme/staff"); err == nil {
+		t.Error("expected an error when the directory refuses the token")
+	}
+}
+
+func TestNewConnectors(t *testing.T) {
+	connectors, err := NewConnectors(config.DirectoryConfig{}, nil)
+	if err != nil || len(connectors) != 0 {
+		t.Fatalf("expected no connector without credentials, got %v, %v", connectors, err)
+	}
+
+	connectors, err = NewConnectors(config.DirectoryConfig{
+		MicrosoftTenantID: "contoso", MicrosoftClientID: "id", MicrosoftClientSecret: "secret",
+		GitLabToken: "glpat-test",
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewConnectors failed: %v", err)
+	}
+	if connectors[models.DirectoryProviderMicrosoft] == nil || connectors[models.DirectoryProviderGitLab] == nil {
+		t.Errorf("expected Microsoft and GitLab connectors, got %v", connectors)
+	}
+
+	if _, err := NewConnectors(config.DirectoryConfig{GoogleCredentialsFile: "/nonexistent.json"}, nil); err == nil {
+		t.Error("expected an error for a missing Google credentials file")
+	}
+}
+
+type fakeTokenSource struct {
+	token       string
+	invalidated []string
+}
+
+func (f *fakeTokenSource) AccessToken(_ context.Context, _ string) (string, error) {
+	return f.token, nil
+}
+
+func (f *fakeTokenSource) InvalidateAccessToken(provider string) {
+	f.invalidated = append(f.invalidated, provider)
+}
+
+func TestNewConnectors_DelegatedAccess(t *testing.T) {
+	cfg := config.DirectoryConfig{
+		GoogleClientID: "google-id", GoogleClientSecret: "google-secret",
+		MicrosoftTenantID: "contoso", MicrosoftClientID: "id", MicrosoftClientSecret: "secret", MicrosoftDelegated: true,
+	}
+
+	connectors, err := NewConnectors(cfg, nil)
+	if err != nil || len(connectors) != 0 {
+		t.Fatalf("expected no delegated connector without a token source, got %v, %v", connectors, err)
+	}
+
+	connectors, err = NewConnectors(cfg, &fakeTokenSource{})
+	if err != nil {
+		t.Fatalf("NewConnectors failed: %v", err)
+	}
+	if connectors[models.DirectoryProviderGoogle] == nil || connectors[models.DirectoryProviderMicrosoft] == nil {
+		t.Errorf("expected delegated Google and Microsoft connectors, got %v", connectors)
+	}
+}
+
+func TestDelegatedOAuth_AuthCodeURL(t *testing.T) {
+	oauth := NewDelegatedOAuth(config.DirectoryConfig{
+		GoogleClientID: "google-id", GoogleClientSecret: "google-secret",
+		MicrosoftTenantID: "contoso", MicrosoftClientID: "id", MicrosoftClientSecret: "secret",
+	}, "https://sign.example.com/")
+
+	if providers := oauth.Providers(); len(providers) != 1 || providers[0] != models.DirectoryProviderGoogle {
+		t.Fatalf("expected only Google with delegated access, got %v", providers)
+	}
+
+	authURL, err := oauth.AuthCodeURL(models.DirectoryProviderGoogle, "state-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL failed: %v", err)
+	}
+	parsed, _ := url.Parse(authURL)
+	query := parsed.Query()
+	if query.Get("access_type") != "offline" || query.Get("prompt") != "consent" || query.Get("state") != "state-1" {
+		t.Errorf("expected an offline consent URL, got %s", authURL)
+	}
+	if query.Get("redirect_uri") != "https://sign.example.com"+CallbackPath {
+		t.Errorf("unexpected redirect URI %q", query.Get("redirect_uri"))
+	}
+
+	if _, err := oauth.AuthCodeURL(models.DirectoryProviderMicrosoft, "state-1"); !errors.Is(err, models.ErrDirectoryNotDelegated) {
+		t.Errorf("expected ErrDirectoryNotDelegated, got %v", err)
+	}
+}
+
+func TestDelegatedOAuth_Refresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("refresh_token") {
+		case "valid":
+			fmt.Fprint(w, `{"access_token":"access-1","token_type":"Bearer","expires_in":3600}`)
+		case "rotating":
+			fmt.Fprint(w, `{"access_token":"access-2","token_type":"Bearer","expires_in":3600,"refresh_token":"rotated"}`)
+		case "revoked":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"Token has been expired or revoked."}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	oauth := &DelegatedOAuth{
+		configs: map[string]*oauth2.Config{models.DirectoryProviderGoogle: {
+			ClientID: "id", ClientSecret: "secret", Endpoint: oauth2.Endpoint{TokenURL: server.URL},
+		}},
+		client: server.Client(),
+	}
+	ctx := context.Background()
+
+	token, err := oauth.Refresh(ctx, models.DirectoryProviderGoogle, "valid")
+	if err != nil || token.AccessToken != "access-1" || token.RefreshToken != "" || token.Expiry.IsZero() {
+		t.Fatalf("unexpected token %+v, %v", token, err)
+	}
+	token, err = oauth.Refresh(ctx, models.DirectoryProviderGoogle, "rotating")
+	if err != nil || token.RefreshToken != "rotated" {
+		t.Fatalf("expected a rotated refresh token, got %+v, %v", token, err)
+	}
+	if _, err := oauth.Refresh(ctx, models.DirectoryProviderGoogle, "revoked"); !errors.Is(err, models.ErrDirectoryTokenRevoked) {
+		t.Errorf("expected ErrDirectoryTokenRevoked, got %v", err)
+	}
+	if _, err := oauth.Refresh(ctx, models.DirectoryProviderGoogle, "broken"); err == nil || errors.Is(err, models.ErrDirectoryTokenRevoked) {
+		t.Errorf("expected a transient error, got %v", err)
+	}
+}
+
+func TestDelegatedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"members":[]}`)
+	}))
+	defer server.Close()
+
+	tokens := &fakeTokenSource{token: "good"}
+	connector := NewGoogleConnector(newDelegatedClient(models.DirectoryProviderGoogle, tokens), server.URL)
+	if _, err := connector.ListMembers(context.Background(), "staff@example.com"); err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+
+	tokens.token = "stale"
+	if _, err := connector.ListMembers(context.Background(), "staff@example.com"); err == nil {
+		t.Fatal("expected an error for a refused token")
+	}
+	if len(tokens.invalidated) != 1 || tokens.invalidated[0] != models.DirectoryProviderGoogle {
+		t.Errorf("expected the refused token to be invalidated, got %v", tokens.invalidated)
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package directory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// GitLabConnector lists the members of a GitLab group with an access token allowed to read it
+type GitLabConnector struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewGitLabConnector creates a connector for the GitLab instance at baseURL (https://gitlab.com when empty)
+func NewGitLabConnector(client *http.Client, baseURL, token string) *GitLabConnector {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+	return &GitLabConnector{client: client, baseURL: strings.TrimSuffix(baseURL, "/"), token: token}
+}
+
+type gitlabMember struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Email       string `json:"email"`        // Only returned to administrators and for enterprise users
+	PublicEmail string `json:"public_email"` // Only returned to administrators
+}
+
+// ListMembers returns the members of a group given by its full path, including inherited ones.
+// Attributes: id, username, name, email, public_email.
+func (c *GitLabConnector) ListMembers(ctx context.Context, group string) ([]models.DirectoryEntry, error) {
+	header := http.Header{"Private-Token": {c.token}}
+
+	var entries []models.DirectoryEntry
+	page := "1"
+	for page != "" {
+		endpoint := fmt.Sprintf("%s/api/v4/groups/%s/members/all?per_page=100&page=%s", c.baseURL, url.PathEscape(group), url.QueryEscape(page))
+
+		var members []gitlabMember
+		respHeader, err := getJSON(ctx, c.client, endpoint, header, &members)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			entries = append(entries, models.DirectoryEntry{
+				Attributes: map[string]string{
+					"id":           fmt.Sprintf("%d", m.ID),
+					"username":     m.Username,
+					"name":         m.Name,
+					"email":        m.Email,
+					"public_email": m.PublicEmail,
+				},
+				Active: m.State == "" || m.State == "active",
+			})
+		}
+		if err := checkSize(entries); err != nil {
+			return nil, err
+		}
+		page = respHeader.Get("X-Next-Page")
+	}
+	return entries, nil
+}
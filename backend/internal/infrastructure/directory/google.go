@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package directory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const googleBaseURL = "https://admin.googleapis.com"
+
+// GoogleConnector lists the members of a Google Workspace group through the Admin SDK Directory API
+type GoogleConnector struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewGoogleConnector creates a connector using an authenticated client; an empty baseURL uses the Google API
+func NewGoogleConnector(client *http.Client, baseURL string) *GoogleConnector {
+	if baseURL == "" {
+		baseURL = googleBaseURL
+	}
+	return &GoogleConnector{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type googleMembersPage struct {
+	Members []struct {
+		ID     string `json:"id"`
+		Email  string `json:"email"`
+		Role   string `json:"role"`
+		Type   string `json:"type"`
+		Status string `json:"status"`
+	} `json:"members"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// ListMembers returns the users of a group given by its email, including those of nested groups.
+// Attributes: id, email, role.
+func (c *GoogleConnector) ListMembers(ctx context.Context, group string) ([]models.DirectoryEntry, error) {
+	var entries []models.DirectoryEntry
+	pageToken := ""
+	for {
+		query := url.Values{"includeDerivedMembership": {"true"}, "maxResults": {"200"}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		endpoint := fmt.Sprintf("%s/admin/directory/v1/groups/%s/members?%s", c.baseURL, url.PathEscape(group), query.Encode())
+
+		var page googleMembersPage
+		if _, err := getJSON(ctx, c.client, endpoint, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, m := range page.Members {
+			if m.Type != "USER" {
+				continue
+			}
+			entries = append(entries, models.DirectoryEntry{
+				Attributes: map[string]string{"id": m.ID, "email": m.Email, "role": m.Role},
+				Active:     m.Status != "SUSPENDED",
+			})
+		}
+		if err := checkSize(entries); err != nil {
+			return nil, err
+		}
+
+		if page.NextPageToken == "" {
+			return entries, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
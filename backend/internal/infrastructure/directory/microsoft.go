@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package directory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const microsoftBaseURL = "https://graph.microsoft.com/v1.0"
+
+// MicrosoftConnector lists the members of a Microsoft Entra ID group through Microsoft Graph
+type MicrosoftConnector struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewMicrosoftConnector creates a connector using an authenticated client; an empty baseURL uses Microsoft Graph
+func NewMicrosoftConnector(client *http.Client, baseURL string) *MicrosoftConnector {
+	if baseURL == "" {
+		baseURL = microsoftBaseURL
+	}
+	return &MicrosoftConnector{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+type microsoftMembersPage struct {
+	Value []struct {
+		ID                string `json:"id"`
+		DisplayName       string `json:"displayName"`
+		Mail              string `json:"mail"`
+		UserPrincipalName string `json:"userPrincipalName"`
+		AccountEnabled    *bool  `json:"accountEnabled"`
+	} `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+// ListMembers returns the users of a group given by its object ID, including those of nested groups.
+// Attributes: id, displayName, mail, userPrincipalName.
+func (c *MicrosoftConnector) ListMembers(ctx context.Context, group string) ([]models.DirectoryEntry, error) {
+	query := url.Values{"$select": {"id,displayName,mail,userPrincipalName,accountEnabled"}, "$top": {"999"}}
+	endpoint := fmt.Sprintf("%s/groups/%s/transitiveMembers/microsoft.graph.user?%s", c.baseURL, url.PathEscape(group), query.Encode())
+
+	var entries []models.DirectoryEntry
+	for endpoint != "" {
+		var page microsoftMembersPage
+		if _, err := getJSON(ctx, c.client, endpoint, nil, &page); err != nil {
+			return nil, err
+		}
+		for _, u := range page.Value {
+			entries = append(entries, models.DirectoryEntry{
+				Attributes: map[string]string{
+					"id":                u.ID,
+					"displayName":       u.DisplayName,
+					"mail":              u.Mail,
+					"userPrincipalName": u.UserPrincipalName,
+				},
+				Active: u.AccountEnabled == nil || *u.AccountEnabled,
+			})
+		}
+		if err := checkSize(entries); err != nil {
+			return nil, err
+		}
+		endpoint = page.NextLink
+	}
+	return entries, nil
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	mail "github.com/go-mail/mail/v2"
+)
+
+// DialSettings are the connection settings of an SMTP server
+type DialSettings struct {
+	Host               string
+	Port               int
+	Username           string
+	Password           string
+	Encryption         string // models.SMTPEncryption*, empty meaning auto
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+// NewDialer returns a dialer for the settings and the encryption it uses.
+// In auto mode the server is probed first to find out how it expects to be secured.
+func NewDialer(ctx context.Context, s DialSettings) (*mail.Dialer, string, error) {
+	mode := s.Encryption
+	if mode == "" || mode == models.SMTPEncryptionAuto {
+		probed, err := ProbeEncryption(ctx, s.Host, s.Port, s.Timeout)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to detect SMTP encryption: %w", err)
+		}
+		mode = probed
+	}
+
+	d := mail.NewDialer(s.Host, s.Port, s.Username, s.Password)
+	d.Timeout = s.Timeout
+	tlsConfig := &tls.Config{
+		ServerName:         s.Host,
+		InsecureSkipVerify: s.InsecureSkipVerify,
+	}
+
+	switch mode {
+	case models.SMTPEncryptionImplicit:
+		d.SSL = true
+		d.TLSConfig = tlsConfig
+	case models.SMTPEncryptionStartTLS:
+		d.SSL = false
+		d.TLSConfig = tlsConfig
+		d.StartTLSPolicy = mail.MandatoryStartTLS
+	case models.SMTPEncryptionNone:
+		d.SSL = false
+		d.StartTLSPolicy = mail.NoStartTLS
+	default:
+		return nil, "", fmt.Errorf("unknown SMTP encryption %q", mode)
+	}
+	return d, mode, nil
+}
+
+// ProbeEncryption detects how an SMTP server expects to be secured: a server completing a TLS
+// handshake right away uses implicit TLS, otherwise its greeting must offer STARTTLS.
+// Plain text is never picked, it has to be chosen explicitly.
+func ProbeEncryption(ctx context.Context, host string, port int, timeout time.Duration) (string, error) {
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	// Only the protocol is detected here, the certificate is verified when sending
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	handshakeErr := tlsConn.HandshakeContext(ctx)
+	_ = tlsConn.Close()
+	if handshakeErr == nil {
+		return models.SMTPEncryptionImplicit, nil
+	}
+
+	conn, err = dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return "", fmt.Errorf("%s answers neither TLS nor SMTP: %w", address, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		_ = client.Quit()
+		return models.SMTPEncryptionStartTLS, nil
+	}
+	_ = client.Quit()
+	return "", fmt.Errorf("%s offers neither implicit TLS nor STARTTLS, set the encryption to none to send in plain text", address)
+}
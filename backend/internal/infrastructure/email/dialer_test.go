@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	mail "github.com/go-mail/mail/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startPlainSMTP starts a minimal plain text SMTP server, offering STARTTLS or not
+func startPlainSMTP(t *testing.T, offerStartTLS bool) (string, int) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				_, _ = conn.Write([]byte("220 test ESMTP\r\n"))
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch command := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(command, "EHLO"):
+						if offerStartTLS {
+							_, _ = conn.Write([]byte("250-test\r\n250 STARTTLS\r\n"))
+						} else {
+							_, _ = conn.Write([]byte("250 test\r\n"))
+						}
+					case command == "QUIT":
+						_, _ = conn.Write([]byte("221 bye\r\n"))
+						return
+					default:
+						_, _ = conn.Write([]byte("500 unknown\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return "127.0.0.1", addr.Port
+}
+
+// startImplicitTLS starts a server completing TLS handshakes from the first byte
+func startImplicitTLS(t *testing.T) (string, int) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	return u.Hostname(), port
+}
+
+func TestProbeEncryption(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	host, port := startImplicitTLS(t)
+	mode, err := ProbeEncryption(ctx, host, port, 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, models.SMTPEncryptionImplicit, mode)
+
+	host, port = startPlainSMTP(t, true)
+	mode, err = ProbeEncryption(ctx, host, port, 2*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, models.SMTPEncryptionStartTLS, mode)
+
+	host, port = startPlainSMTP(t, false)
+	_, err = ProbeEncryption(ctx, host, port, 2*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "neither implicit TLS nor STARTTLS")
+}
+
+func TestNewDialer(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	d, mode, err := NewDialer(ctx, DialSettings{Host: "smtp.example.com", Port: 465, Encryption: models.SMTPEncryptionImplicit})
+	require.NoError(t, err)
+	assert.Equal(t, models.SMTPEncryptionImplicit, mode)
+	assert.True(t, d.SSL)
+
+	d, mode, err = NewDialer(ctx, DialSettings{Host: "smtp.example.com", Port: 465, Encryption: models.SMTPEncryptionStartTLS})
+	require.NoError(t, err)
+	assert.Equal(t, models.SMTPEncryptionStartTLS, mode)
+	assert.False(t, d.SSL, "the port must not imply implicit TLS")
+	assert.Equal(t, mail.MandatoryStartTLS, d.StartTLSPolicy)
+
+	d, _, err = NewDialer(ctx, DialSettings{Host: "localhost", Port: 25, Encryption: models.SMTPEncryptionNone})
+	require.NoError(t, err)
+	assert.False(t, d.SSL)
+	assert.EqualValues(t, mail.NoStartTLS, d.StartTLSPolicy)
+
+	host, port := startPlainSMTP(t, true)
+	d, mode, err = NewDialer(ctx, DialSettings{Host: host, Port: port, Encryption: models.SMTPEncryptionAuto, Timeout: 2 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, models.SMTPEncryptionStartTLS, mode)
+	assert.Equal(t, mail.MandatoryStartTLS, d.StartTLSPolicy)
+
+	_, _, err = NewDialer(ctx, DialSettings{Host: "localhost", Port: 25, Encryption: "ssl"})
+	assert.Error(t, err)
+}
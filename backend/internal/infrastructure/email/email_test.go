@@ -9,6 +9,7 @@ import (
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
 	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -167,6 +168,47 @@ func TestRenderer_Render_FrenchLocale(t *testing.T) {
 	assert.Contains(t, textBody, "Bonjour le monde")
 }
 
+func TestRenderer_Render_Branding(t *testing.T) {
+	t.Parallel()
+
+	renderer, tmpDir := createTestRenderer(t)
+
+	// Render with the real base templates to check branding placement
+	for _, name := range []string{"base.html.tmpl", "base.txt.tmpl"} {
+		content, err := os.ReadFile(filepath.Join("..", "..", "..", "templates", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), content, 0644))
+	}
+
+	htmlBody, textBody, err := renderer.Render("test", "en", map[string]any{"message": "Hello"})
+	require.NoError(t, err)
+	assert.Contains(t, htmlBody, defaultPrimaryColor)
+	assert.NotContains(t, htmlBody, "<img")
+
+	renderer.SetBranding(func() *models.Branding {
+		return &models.Branding{
+			Organisation: "Branded Org",
+			LogoURL:      testBaseURL + "/api/v1/branding/logo?v=abc",
+			PrimaryColor: "#0F766E",
+			EmailFooter:  "Acme Corp, 1 Main Street",
+			SupportEmail: "help@example.com",
+		}
+	})
+
+	htmlBody, textBody, err = renderer.Render("test", "en", map[string]any{"message": "Hello"})
+	require.NoError(t, err)
+	assert.Contains(t, htmlBody, "Branded Org")
+	assert.Contains(t, htmlBody, `<img src="https://example.com/api/v1/branding/logo?v=abc"`)
+	assert.Contains(t, htmlBody, "color: #0F766E")
+	assert.NotContains(t, htmlBody, defaultPrimaryColor)
+	assert.Contains(t, htmlBody, "Acme Corp, 1 Main Street")
+	assert.Contains(t, htmlBody, "mailto:help@example.com")
+
+	assert.Contains(t, textBody, "Branded Org")
+	assert.Contains(t, textBody, "Acme Corp, 1 Main Street")
+	assert.Contains(t, textBody, "Support: help@example.com")
+}
+
 func TestRenderer_Render_TemplateNotFound(t *testing.T) {
 	t.Parallel()
 
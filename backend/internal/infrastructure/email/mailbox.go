@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// mailboxCapacity is the number of messages a Mailbox keeps, the oldest are dropped first
+const mailboxCapacity = 100
+
+// MailboxMessage is a message caught by a Mailbox, rendered as it would have been sent
+type MailboxMessage struct {
+	ID       string            `json:"id"`
+	To       []string          `json:"to"`
+	Cc       []string          `json:"cc,omitempty"`
+	Bcc      []string          `json:"bcc,omitempty"`
+	Subject  string            `json:"subject"`
+	Template string            `json:"template"`
+	Locale   string            `json:"locale"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	HTML     string            `json:"html"`
+	Text     string            `json:"text"`
+	SentAt   time.Time         `json:"sent_at"`
+}
+
+// Mailbox is a development sender: messages are rendered, logged to the console and kept
+// in memory instead of being delivered
+type Mailbox struct {
+	renderer *Renderer
+
+	mu       sync.RWMutex
+	messages []MailboxMessage
+	nextID   int
+}
+
+// NewMailbox creates an empty development mailbox
+func NewMailbox(renderer *Renderer) *Mailbox {
+	return &Mailbox{renderer: renderer}
+}
+
+func (m *Mailbox) Send(ctx context.Context, msg Message) error {
+	if len(msg.To) == 0 {
+		return fmt.Errorf("no recipients specified")
+	}
+
+	htmlBody, textBody, err := m.renderer.Render(msg.Template, msg.Locale, msg.Data)
+	if err != nil {
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
+
+	m.mu.Lock()
+	m.nextID++
+	caught := MailboxMessage{
+		ID:       strconv.Itoa(m.nextID),
+		To:       msg.To,
+		Cc:       msg.Cc,
+		Bcc:      msg.Bcc,
+		Subject:  msg.Subject,
+		Template: msg.Template,
+		Locale:   msg.Locale,
+		Headers:  msg.Headers,
+		HTML:     htmlBody,
+		Text:     textBody,
+		SentAt:   time.Now(),
+	}
+	m.messages = append(m.messages, caught)
+	if len(m.messages) > mailboxCapacity {
+		m.messages = m.messages[len(m.messages)-mailboxCapacity:]
+	}
+	m.mu.Unlock()
+
+	logger.Logger.Info("Email caught by the dev mailbox",
+		"id", caught.ID,
+		"to", msg.To,
+		"subject", msg.Subject,
+		"template", msg.Template)
+	fmt.Printf("\n----- dev mailbox #%s -----\nTo: %v\nSubject: %s\n\n%s\n---------------------------\n",
+		caught.ID, msg.To, msg.Subject, textBody)
+
+	return nil
+}
+
+// Messages returns the caught messages, most recent first
+func (m *Mailbox) Messages() []MailboxMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	messages := make([]MailboxMessage, len(m.messages))
+	for i, msg := range m.messages {
+		messages[len(m.messages)-1-i] = msg
+	}
+	return messages
+}
+
+// Message returns a caught message by ID
+func (m *Mailbox) Message(id string) (MailboxMessage, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, msg := range m.messages {
+		if msg.ID == id {
+			return msg, true
+		}
+	}
+	return MailboxMessage{}, false
+}
+
+// Clear empties the mailbox
+func (m *Mailbox) Clear() {
+	m.mu.Lock()
+	m.messages = nil
+	m.mu.Unlock()
+}
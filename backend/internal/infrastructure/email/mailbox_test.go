@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMailboxMessage(to string) Message {
+	return Message{
+		To:       []string{to},
+		Subject:  "Test",
+		Template: "test",
+		Locale:   "en",
+		Data: map[string]any{
+			"message": "Hello mailbox",
+		},
+	}
+}
+
+func TestMailbox_Send_KeepsRenderedMessage(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	mailbox := NewMailbox(renderer)
+
+	require.NoError(t, mailbox.Send(context.Background(), testMailboxMessage("alice@example.com")))
+
+	messages := mailbox.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "1", messages[0].ID)
+	assert.Equal(t, []string{"alice@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].HTML, "Hello mailbox")
+	assert.Contains(t, messages[0].Text, "Hello mailbox")
+
+	msg, ok := mailbox.Message("1")
+	require.True(t, ok)
+	assert.Equal(t, "Test", msg.Subject)
+
+	_, ok = mailbox.Message("2")
+	assert.False(t, ok)
+}
+
+func TestMailbox_Send_Errors(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	mailbox := NewMailbox(renderer)
+
+	err := mailbox.Send(context.Background(), Message{Template: "test"})
+	assert.Error(t, err)
+
+	msg := testMailboxMessage("alice@example.com")
+	msg.Template = "missing"
+	err = mailbox.Send(context.Background(), msg)
+	assert.Error(t, err)
+
+	assert.Empty(t, mailbox.Messages())
+}
+
+func TestMailbox_Messages_MostRecentFirstAndBounded(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	mailbox := NewMailbox(renderer)
+
+	for i := 0; i < mailboxCapacity+5; i++ {
+		require.NoError(t, mailbox.Send(context.Background(), testMailboxMessage(fmt.Sprintf("user%d@example.com", i))))
+	}
+
+	messages := mailbox.Messages()
+	require.Len(t, messages, mailboxCapacity)
+	assert.Equal(t, fmt.Sprintf("%d", mailboxCapacity+5), messages[0].ID)
+	assert.Equal(t, "6", messages[len(messages)-1].ID)
+
+	mailbox.Clear()
+	assert.Empty(t, mailbox.Messages())
+}
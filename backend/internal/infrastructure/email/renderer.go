@@ -11,8 +11,12 @@ import (
 	txtTemplate "text/template"
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
+// defaultPrimaryColor is the accent color of emails without branding
+const defaultPrimaryColor = "#4F46E5"
+
 type Renderer struct {
 	templateDir   string
 	baseURL       string
@@ -21,6 +25,7 @@ type Renderer struct {
 	fromMail      string
 	defaultLocale string
 	i18n          *i18n.I18n
+	branding      func() *models.Branding
 }
 
 type TemplateData struct {
@@ -28,6 +33,11 @@ type TemplateData struct {
 	BaseURL      string
 	FromName     string
 	FromMail     string
+	LogoURL      string
+	PrimaryColor string
+	EmailFooter  string
+	SupportEmail string
+	SupportURL   string
 	Data         map[string]any
 	T            func(key string, args ...map[string]any) string
 }
@@ -44,6 +54,11 @@ func NewRenderer(templateDir, baseURL, organisation, fromName, fromMail, default
 	}
 }
 
+// SetBranding sets the provider of the organization branding applied to every email
+func (r *Renderer) SetBranding(branding func() *models.Branding) {
+	r.branding = branding
+}
+
 func (r *Renderer) Render(templateName, locale string, data map[string]any) (htmlBody, textBody string, err error) {
 	if locale == "" {
 		locale = r.defaultLocale
@@ -69,9 +84,13 @@ func (r *Renderer) Render(templateName, locale string, data map[string]any) (htm
 		BaseURL:      r.baseURL,
 		FromName:     r.fromName,
 		FromMail:     r.fromMail,
+		PrimaryColor: defaultPrimaryColor,
 		Data:         data,
 		T:            tFunc,
 	}
+	if r.branding != nil {
+		applyBranding(&templateData, r.branding())
+	}
 
 	htmlBody, err = r.renderHTML(templateName, locale, templateData)
 	if err != nil {
@@ -165,3 +184,20 @@ func (r *Renderer) renderText(templateName, locale string, data TemplateData) (s
 
 	return buf.String(), nil
 }
+
+// applyBranding copies the configured branding into the template data
+func applyBranding(data *TemplateData, branding *models.Branding) {
+	if branding == nil {
+		return
+	}
+	if branding.Organisation != "" {
+		data.Organisation = branding.Organisation
+	}
+	if branding.PrimaryColor != "" {
+		data.PrimaryColor = branding.PrimaryColor
+	}
+	data.LogoURL = branding.LogoURL
+	data.EmailFooter = branding.EmailFooter
+	data.SupportEmail = branding.SupportEmail
+	data.SupportURL = branding.SupportURL
+}
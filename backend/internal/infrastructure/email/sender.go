@@ -3,11 +3,19 @@ package email
 
 import (
 	"context"
-	"crypto/tls"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/rand"
+	"net/textproto"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	mail "github.com/go-mail/mail/v2"
 
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
@@ -28,18 +36,91 @@ type Message struct {
 	Headers  map[string]string
 }
 
+// SMTPSender sends mail through the main server and fails over to the backup servers when a
+// server cannot be reached or rejects the credentials. A failing server is skipped until its
+// cooldown elapses; when all of them are cooling down, they are all tried anyway.
 type SMTPSender struct {
-	config   config.MailConfig
-	renderer *Renderer
+	config     config.MailConfig
+	renderer   *Renderer
+	servers    []*smtpServer
+	cooldown   time.Duration
+	deliveries DeliveryRecorder
+
+	// mu guards the health and detected encryption of the servers
+	mu   sync.Mutex
+	rand *rand.Rand
+	now  func() time.Time
+}
+
+// smtpServer is a server mail is sent through, with its health
+type smtpServer struct {
+	settings DialSettings
+	priority int
+	weight   int
+
+	// encryption is the mode detected on the first send in auto mode, probed again after a failure
+	encryption string
+
+	unhealthyUntil time.Time
+	failures       int
+	lastError      string
+	lastErrorAt    *time.Time
+	lastSuccessAt  *time.Time
 }
 
 func NewSMTPSender(cfg config.MailConfig, renderer *Renderer) *SMTPSender {
+	weight := cfg.Weight
+	if weight <= 0 {
+		weight = 1
+	}
+	servers := []*smtpServer{{
+		settings: DialSettings{
+			Host:               cfg.Host,
+			Port:               cfg.Port,
+			Username:           cfg.Username,
+			Password:           cfg.Password,
+			Encryption:         cfg.Encryption,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		},
+		weight: weight,
+	}}
+	for _, backup := range cfg.Servers {
+		servers = append(servers, &smtpServer{
+			settings: DialSettings{
+				Host:               backup.Host,
+				Port:               backup.Port,
+				Username:           backup.Username,
+				Password:           backup.Password,
+				Encryption:         backup.Encryption,
+				InsecureSkipVerify: cfg.InsecureSkipVerify,
+			},
+			priority: backup.Priority,
+			weight:   backup.Weight,
+		})
+	}
+
+	cooldown := cfg.FailoverCooldown
+	if cooldown <= 0 {
+		cooldown = time.Minute
+	}
 	return &SMTPSender{
 		config:   cfg,
 		renderer: renderer,
+		servers:  servers,
+		cooldown: cooldown,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:      time.Now,
 	}
 }
 
+// DeliveryRecorder keeps the outcome of each send for the deliverability report
+type DeliveryRecorder interface {
+	RecordDelivery(ctx context.Context, delivery *models.EmailDelivery)
+}
+
+// SetDeliveryRecorder records the reply code, latency and Message-ID of each send
+func (s *SMTPSender) SetDeliveryRecorder(r DeliveryRecorder) { s.deliveries = r }
+
 func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
 	if s.config.Host == "" {
 		logger.Logger.Info("SMTP not configured, email not sent", "template", msg.Template)
@@ -78,6 +159,11 @@ func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
 	}
 	m.SetHeader("Subject", subject)
 
+	messageID := newMessageID(from)
+	if custom := strings.Trim(msg.Headers["Message-ID"], "<>"); custom != "" {
+		messageID = custom
+	}
+	m.SetHeader("Message-ID", "<"+messageID+">")
 	for key, value := range msg.Headers {
 		m.SetHeader(key, value)
 	}
@@ -90,33 +176,220 @@ func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
 		timeout = 10 * time.Second
 	}
 
-	d := mail.NewDialer(s.config.Host, s.config.Port, s.config.Username, s.config.Password)
+	delivery := &models.EmailDelivery{
+		MessageID:        messageID,
+		Template:         msg.Template,
+		RecipientDomains: models.EmailDomains(msg.To, msg.Cc, msg.Bcc),
+	}
+	start := s.now()
+
+	var lastErr error
+	for _, server := range s.candidates() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		delivery.Server = server.settings.Host
+
+		conn, encryption, err := s.connect(ctx, server, timeout)
+		if err != nil {
+			// The server cannot be used: try the next one
+			s.markFailed(server, err)
+			lastErr = err
+			continue
+		}
+
+		logger.Logger.Info("Sending email", "to", msg.To, "template", msg.Template, "locale", msg.Locale,
+			"server", server.settings.Host, "encryption", encryption, "message_id", messageID)
+
+		err = mail.Send(conn, m)
+		_ = conn.Close()
+		if err != nil {
+			// The server answered: the message itself was refused, another server would refuse it too
+			s.recordDelivery(ctx, delivery, start, err)
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+
+		s.markSucceeded(server)
+		s.recordDelivery(ctx, delivery, start, nil)
+		logger.Logger.Info("Email sent successfully", "to", msg.To, "server", server.settings.Host, "message_id", messageID)
+		return nil
+	}
+	s.recordDelivery(ctx, delivery, start, lastErr)
+	return fmt.Errorf("failed to send email: %w", lastErr)
+}
+
+// recordDelivery hands the outcome of a send to the delivery recorder, if any
+func (s *SMTPSender) recordDelivery(ctx context.Context, delivery *models.EmailDelivery, start time.Time, err error) {
+	if s.deliveries == nil {
+		return
+	}
+	delivery.LatencyMs = s.now().Sub(start).Milliseconds()
+	if err != nil {
+		delivery.Status = models.EmailDeliveryFailed
+		delivery.SMTPCode = smtpReplyCode(err)
+		delivery.Error = err.Error()
+	} else {
+		// net/smtp does not expose the final reply, which is 250 when the message is accepted
+		delivery.Status = models.EmailDeliverySent
+		delivery.SMTPCode = 250
+	}
+	s.deliveries.RecordDelivery(ctx, delivery)
+}
+
+// smtpReplyCode returns the SMTP reply code of a send error, 0 when no server answered
+func smtpReplyCode(err error) int {
+	// mail.SendError does not unwrap its cause
+	var sendErr *mail.SendError
+	if errors.As(err, &sendErr) {
+		err = sendErr.Cause
+	}
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code
+	}
+	return 0
+}
+
+// newMessageID returns a random Message-ID, without angle brackets, in the domain of the sender
+func newMessageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	b := make([]byte, 16)
+	_, _ = cryptorand.Read(b)
+	return hex.EncodeToString(b) + "@" + domain
+}
+
+// candidates returns the servers in the order they are tried: the healthy ones by ascending
+// priority, spread by weight within a priority, then the ones cooling down if none is healthy
+func (s *SMTPSender) candidates() []*smtpServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var healthy, cooling []*smtpServer
+	for _, server := range s.servers {
+		if now.Before(server.unhealthyUntil) {
+			cooling = append(cooling, server)
+		} else {
+			healthy = append(healthy, server)
+		}
+	}
+	if len(healthy) == 0 {
+		healthy = cooling
+	}
 
-	// Configure TLS: either SSL (port 465) or STARTTLS (port 587), not both
-	if s.config.TLS {
-		// Implicit TLS/SSL (typically port 465)
-		d.SSL = true
-		d.TLSConfig = &tls.Config{
-			ServerName:         s.config.Host,
-			InsecureSkipVerify: s.config.InsecureSkipVerify,
+	// Weighted shuffle: each server is drawn with a probability proportional to its weight
+	ordered := make([]*smtpServer, 0, len(healthy))
+	for len(healthy) > 0 {
+		total := 0
+		for _, server := range healthy {
+			total += server.weight
 		}
-	} else if s.config.StartTLS {
-		// Explicit TLS via STARTTLS (typically port 587)
-		d.TLSConfig = &tls.Config{
-			ServerName:         s.config.Host,
-			InsecureSkipVerify: s.config.InsecureSkipVerify,
+		pick := s.rand.Intn(total)
+		for i, server := range healthy {
+			if pick < server.weight {
+				ordered = append(ordered, server)
+				healthy = append(healthy[:i], healthy[i+1:]...)
+				break
+			}
+			pick -= server.weight
 		}
-		d.StartTLSPolicy = mail.MandatoryStartTLS
 	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].priority < ordered[j].priority })
+	return ordered
+}
 
-	d.Timeout = timeout
+// connect dials and authenticates to a server, probing its encryption in auto mode
+func (s *SMTPSender) connect(ctx context.Context, server *smtpServer, timeout time.Duration) (mail.SendCloser, string, error) {
+	s.mu.Lock()
+	encryption := server.encryption
+	s.mu.Unlock()
+	if encryption == "" {
+		encryption = server.settings.Encryption
+	}
 
-	logger.Logger.Info("Sending email", "to", msg.To, "template", msg.Template, "locale", msg.Locale)
+	settings := server.settings
+	settings.Encryption = encryption
+	settings.Timeout = timeout
+	d, mode, err := NewDialer(ctx, settings)
+	if err != nil {
+		return nil, "", err
+	}
 
-	if err := d.DialAndSend(m); err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+	if mode != encryption {
+		logger.Logger.Info("SMTP encryption detected", "host", server.settings.Host, "port", server.settings.Port, "encryption", mode)
+		s.mu.Lock()
+		server.encryption = mode
+		s.mu.Unlock()
 	}
 
-	logger.Logger.Info("Email sent successfully", "to", msg.To)
-	return nil
+	conn, err := d.Dial()
+	if err != nil {
+		return nil, "", err
+	}
+	return conn, mode, nil
+}
+
+func (s *SMTPSender) markFailed(server *smtpServer, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	server.encryption = ""
+	server.unhealthyUntil = now.Add(s.cooldown)
+	server.failures++
+	server.lastError = err.Error()
+	server.lastErrorAt = &now
+
+	logger.Logger.Warn("SMTP server unavailable, failing over",
+		"server", server.settings.Host,
+		"port", server.settings.Port,
+		"failures", server.failures,
+		"cooldown", s.cooldown,
+		"error", err.Error())
+}
+
+func (s *SMTPSender) markSucceeded(server *smtpServer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if server.failures > 0 {
+		logger.Logger.Info("SMTP server available again", "server", server.settings.Host, "port", server.settings.Port)
+	}
+	server.unhealthyUntil = time.Time{}
+	server.failures = 0
+	server.lastSuccessAt = &now
+}
+
+// Health reports the state of each server, in configuration order
+func (s *SMTPSender) Health() []models.SMTPServerHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	health := make([]models.SMTPServerHealth, len(s.servers))
+	for i, server := range s.servers {
+		h := models.SMTPServerHealth{
+			Host:                server.settings.Host,
+			Port:                server.settings.Port,
+			Priority:            server.priority,
+			Weight:              server.weight,
+			Encryption:          server.settings.Encryption,
+			DetectedEncryption:  server.encryption,
+			Healthy:             !now.Before(server.unhealthyUntil),
+			ConsecutiveFailures: server.failures,
+			LastError:           server.lastError,
+			LastErrorAt:         server.lastErrorAt,
+			LastSuccessAt:       server.lastSuccessAt,
+		}
+		if !h.Healthy {
+			until := server.unhealthyUntil
+			h.UnhealthyUntil = &until
+		}
+		health[i] = h
+	}
+	return health
 }
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startAcceptingSMTP starts a plain text SMTP server accepting every message, or rejecting
+// every recipient, and returns the number of messages it received
+func startAcceptingSMTP(t *testing.T, rejectRecipients bool) (int, *atomic.Int32) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	received := &atomic.Int32{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				_, _ = conn.Write([]byte("220 test ESMTP\r\n"))
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch command := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(command, "EHLO"):
+						_, _ = conn.Write([]byte("250 test\r\n"))
+					case strings.HasPrefix(command, "RCPT") && rejectRecipients:
+						_, _ = conn.Write([]byte("550 mailbox unavailable\r\n"))
+					case command == "DATA":
+						_, _ = conn.Write([]byte("354 go ahead\r\n"))
+						for {
+							body, err := reader.ReadString('\n')
+							if err != nil {
+								return
+							}
+							if body == ".\r\n" {
+								break
+							}
+						}
+						received.Add(1)
+						_, _ = conn.Write([]byte("250 queued\r\n"))
+					case command == "QUIT":
+						_, _ = conn.Write([]byte("221 bye\r\n"))
+						return
+					default:
+						_, _ = conn.Write([]byte("250 ok\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+// closedPort returns a local port nothing listens on
+func closedPort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return port
+}
+
+func testMessage() Message {
+	return Message{
+		To:       []string{"recipient@example.com"},
+		Subject:  "Test",
+		Template: "test",
+		Locale:   "en",
+		Data:     map[string]any{"message": "test"},
+	}
+}
+
+func TestSMTPSender_Send_FailsOver(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	backupPort, received := startAcceptingSMTP(t, false)
+
+	cfg := config.MailConfig{
+		Host:       "127.0.0.1",
+		Port:       closedPort(t),
+		Encryption: models.SMTPEncryptionNone,
+		Timeout:    "2s",
+		From:       testFromEmail,
+		Servers: []config.MailServer{
+			{Host: "127.0.0.1", Port: backupPort, Encryption: models.SMTPEncryptionNone, Priority: 1, Weight: 1},
+		},
+		FailoverCooldown: time.Minute,
+	}
+	sender := NewSMTPSender(cfg, renderer)
+
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+	assert.Equal(t, int32(1), received.Load())
+
+	health := sender.Health()
+	require.Len(t, health, 2)
+	assert.False(t, health[0].Healthy, "the main server should cool down")
+	assert.NotNil(t, health[0].UnhealthyUntil)
+	assert.Equal(t, 1, health[0].ConsecutiveFailures)
+	assert.NotEmpty(t, health[0].LastError)
+	assert.True(t, health[1].Healthy)
+	assert.NotNil(t, health[1].LastSuccessAt)
+
+	// While it cools down, the main server is not tried again
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+	assert.Equal(t, 1, sender.Health()[0].ConsecutiveFailures)
+
+	// Once the cooldown elapsed, it is tried first again
+	sender.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	require.NoError(t, sender.Send(context.Background(), testMessage()))
+	assert.Equal(t, 2, sender.Health()[0].ConsecutiveFailures)
+	assert.Equal(t, int32(3), received.Load())
+}
+
+func TestSMTPSender_Send_AllServersDown(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	cfg := config.MailConfig{
+		Host:       "127.0.0.1",
+		Port:       closedPort(t),
+		Encryption: models.SMTPEncryptionNone,
+		Timeout:    "2s",
+		From:       testFromEmail,
+		Servers: []config.MailServer{
+			{Host: "127.0.0.1", Port: closedPort(t), Encryption: models.SMTPEncryptionNone, Priority: 1, Weight: 1},
+		},
+	}
+	sender := NewSMTPSender(cfg, renderer)
+
+	err := sender.Send(context.Background(), testMessage())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send email")
+
+	// Servers cooling down are still tried when none is healthy
+	require.Error(t, sender.Send(context.Background(), testMessage()))
+	for _, server := range sender.Health() {
+		assert.Equal(t, 2, server.ConsecutiveFailures)
+	}
+}
+
+func TestSMTPSender_Send_RejectedMessageDoesNotFailOver(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	mainPort, _ := startAcceptingSMTP(t, true)
+	backupPort, received := startAcceptingSMTP(t, false)
+
+	cfg := config.MailConfig{
+		Host:       "127.0.0.1",
+		Port:       mainPort,
+		Encryption: models.SMTPEncryptionNone,
+		Timeout:    "2s",
+		From:       testFromEmail,
+		Servers: []config.MailServer{
+			{Host: "127.0.0.1", Port: backupPort, Encryption: models.SMTPEncryptionNone, Priority: 1, Weight: 1},
+		},
+	}
+	sender := NewSMTPSender(cfg, renderer)
+
+	err := sender.Send(context.Background(), testMessage())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "550")
+	assert.Equal(t, int32(0), received.Load(), "a rejected message should not be sent through the backup")
+	assert.True(t, sender.Health()[0].Healthy)
+}
+
+func TestSMTPSender_Candidates_ByPriorityAndWeight(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	cfg := config.MailConfig{
+		Host:   "main.example.com",
+		Weight: 1,
+		Servers: []config.MailServer{
+			{Host: "heavy.example.com", Priority: 0, Weight: 1000},
+			{Host: "backup.example.com", Priority: 1, Weight: 1},
+		},
+	}
+	sender := NewSMTPSender(cfg, renderer)
+
+	heavyFirst := 0
+	for i := 0; i < 100; i++ {
+		candidates := sender.candidates()
+		require.Len(t, candidates, 3)
+		assert.Equal(t, "backup.example.com", candidates[2].settings.Host, "backups come after priority 0")
+		if candidates[0].settings.Host == "heavy.example.com" {
+			heavyFirst++
+		}
+	}
+	assert.Greater(t, heavyFirst, 90)
+}
+
+type recordedDeliveries struct{ deliveries []*models.EmailDelivery }
+
+func (r *recordedDeliveries) RecordDelivery(_ context.Context, delivery *models.EmailDelivery) {
+	r.deliveries = append(r.deliveries, delivery)
+}
+
+func TestSMTPSender_Send_RecordsDeliveries(t *testing.T) {
+	t.Parallel()
+
+	renderer, _ := createTestRenderer(t)
+	acceptingPort, _ := startAcceptingSMTP(t, false)
+	rejectingPort, _ := startAcceptingSMTP(t, true)
+
+	send := func(port int) (*models.EmailDelivery, error) {
+		cfg := config.MailConfig{
+			Host:       "127.0.0.1",
+			Port:       port,
+			Encryption: models.SMTPEncryptionNone,
+			Timeout:    "2s",
+			From:       testFromEmail,
+		}
+		sender := NewSMTPSender(cfg, renderer)
+		recorder := &recordedDeliveries{}
+		sender.SetDeliveryRecorder(recorder)
+
+		msg := testMessage()
+		msg.Cc = []string{"someone@Corp.Example"}
+		err := sender.Send(context.Background(), msg)
+		require.Len(t, recorder.deliveries, 1)
+		return recorder.deliveries[0], err
+	}
+
+	delivery, err := send(acceptingPort)
+	require.NoError(t, err)
+	assert.Equal(t, models.EmailDeliverySent, delivery.Status)
+	assert.Equal(t, 250, delivery.SMTPCode)
+	assert.Equal(t, []string{"example.com", "corp.example"}, delivery.RecipientDomains)
+	assert.True(t, strings.HasSuffix(delivery.MessageID, testFromEmail[strings.LastIndex(testFromEmail, "@"):]))
+	assert.Equal(t, "127.0.0.1", delivery.Server)
+
+	delivery, err = send(rejectingPort)
+	require.Error(t, err)
+	assert.Equal(t, models.EmailDeliveryFailed, delivery.Status)
+	assert.Equal(t, 550, delivery.SMTPCode)
+	assert.Contains(t, delivery.Error, "mailbox unavailable")
+
+	delivery, err = send(closedPort(t))
+	require.Error(t, err)
+	assert.Equal(t, models.EmailDeliveryFailed, delivery.Status)
+	assert.Equal(t, 0, delivery.SMTPCode, "no server answered")
+}
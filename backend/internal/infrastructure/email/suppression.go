@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// SuppressionList reports which addresses must not receive email
+type SuppressionList interface {
+	ListSuppressed(ctx context.Context, emails []string) ([]string, error)
+}
+
+// SuppressingSender drops suppressed recipients before handing a message to the next sender
+type SuppressingSender struct {
+	next Sender
+	list SuppressionList
+}
+
+// NewSuppressingSender wraps next so that suppressed addresses are never mailed
+func NewSuppressingSender(next Sender, list SuppressionList) *SuppressingSender {
+	return &SuppressingSender{next: next, list: list}
+}
+
+func (s *SuppressingSender) Send(ctx context.Context, msg Message) error {
+	all := make([]string, 0, len(msg.To)+len(msg.Cc)+len(msg.Bcc))
+	all = append(append(append(all, msg.To...), msg.Cc...), msg.Bcc...)
+
+	suppressed, err := s.list.ListSuppressed(ctx, all)
+	if err != nil {
+		// Failing open: a broken lookup must not stop every email
+		logger.Logger.Warn("Failed to check email suppression list", "template", msg.Template, "error", err.Error())
+		return s.next.Send(ctx, msg)
+	}
+	if len(suppressed) == 0 {
+		return s.next.Send(ctx, msg)
+	}
+
+	blocked := make(map[string]bool, len(suppressed))
+	for _, email := range suppressed {
+		blocked[strings.ToLower(email)] = true
+	}
+
+	msg.To = withoutSuppressed(msg.To, blocked)
+	msg.Cc = withoutSuppressed(msg.Cc, blocked)
+	msg.Bcc = withoutSuppressed(msg.Bcc, blocked)
+
+	logger.Logger.Info("Suppressed recipients removed from email", "template", msg.Template, "count", len(suppressed))
+
+	if len(msg.To) == 0 {
+		return fmt.Errorf("%w", models.ErrEmailSuppressed)
+	}
+	return s.next.Send(ctx, msg)
+}
+
+func withoutSuppressed(addresses []string, blocked map[string]bool) []string {
+	var kept []string
+	for _, address := range addresses {
+		if !blocked[strings.ToLower(strings.TrimSpace(address))] {
+			kept = append(kept, address)
+		}
+	}
+	return kept
+}
+
+// isHardBounce reports whether a permanent SMTP error means the mailbox itself does not exist
+func isHardBounce(err error) bool {
+	errStr := strings.ToLower(err.Error())
+	return strings.Contains(errStr, "550") ||
+		strings.Contains(errStr, "551") ||
+		strings.Contains(errStr, "553") ||
+		strings.Contains(errStr, "user unknown") ||
+		strings.Contains(errStr, "no such user") ||
+		strings.Contains(errStr, "invalid recipient")
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSender struct {
+	sent []Message
+}
+
+func (s *recordingSender) Send(_ context.Context, msg Message) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+type fakeSuppressionList struct {
+	suppressed []string
+	err        error
+}
+
+func (l *fakeSuppressionList) ListSuppressed(_ context.Context, _ []string) ([]string, error) {
+	return l.suppressed, l.err
+}
+
+func TestSuppressingSender_DropsSuppressedRecipients(t *testing.T) {
+	next := &recordingSender{}
+	sender := NewSuppressingSender(next, &fakeSuppressionList{suppressed: []string{"gone@example.com"}})
+
+	err := sender.Send(context.Background(), Message{
+		To:  []string{"alice@example.com", "Gone@Example.com"},
+		Bcc: []string{"gone@example.com"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, next.sent, 1)
+	assert.Equal(t, []string{"alice@example.com"}, next.sent[0].To)
+	assert.Empty(t, next.sent[0].Bcc)
+}
+
+func TestSuppressingSender_AllRecipientsSuppressed(t *testing.T) {
+	next := &recordingSender{}
+	sender := NewSuppressingSender(next, &fakeSuppressionList{suppressed: []string{"gone@example.com"}})
+
+	err := sender.Send(context.Background(), Message{To: []string{"gone@example.com"}})
+
+	assert.ErrorIs(t, err, models.ErrEmailSuppressed)
+	assert.Empty(t, next.sent)
+}
+
+func TestSuppressingSender_LookupFailureSendsAnyway(t *testing.T) {
+	next := &recordingSender{}
+	sender := NewSuppressingSender(next, &fakeSuppressionList{err: errors.New("db down")})
+
+	err := sender.Send(context.Background(), Message{To: []string{"alice@example.com"}})
+
+	require.NoError(t, err)
+	assert.Len(t, next.sent, 1)
+}
+
+func TestWorker_CategorizeError_Suppressed(t *testing.T) {
+	w := &Worker{}
+	assert.Equal(t, ErrorTypePermanent, w.categorizeError(models.ErrEmailSuppressed))
+}
+
+func TestIsHardBounce(t *testing.T) {
+	assert.True(t, isHardBounce(errors.New("550 5.1.1 user unknown")))
+	assert.False(t, isHardBounce(errors.New("552 mailbox full")))
+}
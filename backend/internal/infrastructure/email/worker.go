@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -14,6 +15,7 @@ import (
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/google/uuid"
 )
 
 // EmailErrorType represents the category of an email sending error
@@ -41,6 +43,7 @@ type QueueRepository interface {
 	MarkAsFailed(ctx context.Context, id int64, err error, shouldRetry bool) error
 	MarkAsFailedWithDelay(ctx context.Context, id int64, err error, shouldRetry bool, retryDelay time.Duration) error
 	GetRetryableEmails(ctx context.Context, limit int) ([]*models.EmailQueueItem, error)
+	ReleaseToPending(ctx context.Context, ids []int64) (int64, error)
 	CleanupOldEmails(ctx context.Context, olderThan time.Duration) (int64, error)
 }
 
@@ -50,6 +53,8 @@ type Worker struct {
 	sender    Sender
 	renderer  *Renderer
 	publisher EventPublisher
+	bounces   BounceNotifier
+	bounceLog BounceRecorder
 
 	// RLS support
 	db      *sql.DB
@@ -137,6 +142,22 @@ type EventPublisher interface {
 // SetPublisher injects an optional event publisher (e.g., webhooks)
 func (w *Worker) SetPublisher(p EventPublisher) { w.publisher = p }
 
+// BounceNotifier is informed when a reminder is permanently rejected by the mail server
+type BounceNotifier interface {
+	OnEmailBounce(ctx context.Context, docID string, recipients []string, reason string) error
+}
+
+// SetBounceNotifier injects an optional notifier for undeliverable reminders
+func (w *Worker) SetBounceNotifier(n BounceNotifier) { w.bounces = n }
+
+// BounceRecorder adds hard-bouncing addresses to the suppression list
+type BounceRecorder interface {
+	RecordHardBounce(ctx context.Context, email string, detail string) error
+}
+
+// SetBounceRecorder injects an optional recorder for addresses that no longer exist
+func (w *Worker) SetBounceRecorder(r BounceRecorder) { w.bounceLog = r }
+
 // Start begins processing emails from the queue
 func (w *Worker) Start() error {
 	w.mu.Lock()
@@ -175,8 +196,8 @@ func (w *Worker) Stop() error {
 
 	logger.Logger.Info("Stopping email worker...")
 
-	// Signal shutdown
-	w.cancel()
+	// Signal shutdown: the current batch finishes sending the emails already started,
+	// the others are released to the queue
 	close(w.stopChan)
 
 	// Wait for goroutines to finish with timeout
@@ -192,6 +213,7 @@ func (w *Worker) Stop() error {
 	case <-time.After(30 * time.Second):
 		logger.Logger.Warn("Email worker stop timeout, some operations may not have completed")
 	}
+	w.cancel()
 
 	w.mu.Lock()
 	w.started = false
@@ -264,7 +286,12 @@ func (w *Worker) processBatch() {
 	sem := make(chan struct{}, w.maxConcurrent)
 	var wg sync.WaitGroup
 
-	for _, email := range emails {
+	for i, email := range emails {
+		if w.stopping() {
+			w.release(ctx, tenantID, emails[i:])
+			break
+		}
+
 		wg.Add(1)
 		sem <- struct{}{} // Acquire semaphore
 
@@ -288,6 +315,36 @@ func (w *Worker) processBatch() {
 	wg.Wait()
 }
 
+// stopping reports whether Stop was called
+func (w *Worker) stopping() bool {
+	select {
+	case <-w.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// release puts emails of an interrupted batch back in the queue
+func (w *Worker) release(ctx context.Context, tenantID uuid.UUID, items []*models.EmailQueueItem) {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	var released int64
+	err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var err error
+		released, err = w.queueRepo.ReleaseToPending(txCtx, ids)
+		return err
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to release emails to the queue", "count", len(ids), "error", err.Error())
+		return
+	}
+	logger.Logger.Info("Released unsent emails to the queue", "count", released)
+}
+
 // processEmail processes a single email
 func (w *Worker) processEmail(ctx context.Context, item *models.EmailQueueItem) {
 	logger.Logger.Debug("Processing email",
@@ -360,6 +417,27 @@ func (w *Worker) processEmail(ctx context.Context, item *models.EmailQueueItem)
 				"error", markErr.Error())
 		}
 
+		// A mailbox that does not exist is never mailed again. The SMTP error does not
+		// say which recipient failed, so only single-recipient emails are recorded.
+		if errorType == ErrorTypePermanent && w.bounceLog != nil && !errors.Is(err, models.ErrEmailSuppressed) &&
+			len(item.ToAddresses)+len(item.CcAddresses)+len(item.BccAddresses) == 1 && isHardBounce(err) {
+			if recordErr := w.bounceLog.RecordHardBounce(ctx, item.ToAddresses[0], err.Error()); recordErr != nil {
+				logger.Logger.Warn("Failed to record hard bounce",
+					"id", item.ID,
+					"error", recordErr.Error())
+			}
+		}
+
+		// Tell the document owner that the signer's address rejects mail
+		if errorType == ErrorTypePermanent && w.bounces != nil &&
+			item.ReferenceType != nil && item.ReferenceID != nil && *item.ReferenceType == "signature_reminder" {
+			if notifyErr := w.bounces.OnEmailBounce(ctx, *item.ReferenceID, item.ToAddresses, err.Error()); notifyErr != nil {
+				logger.Logger.Warn("Failed to notify email bounce",
+					"id", item.ID,
+					"error", notifyErr.Error())
+			}
+		}
+
 		// Publish reminder.failed event
 		if w.publisher != nil {
 			payload := map[string]interface{}{
@@ -454,6 +532,11 @@ func (w *Worker) categorizeError(err error) EmailErrorType {
 		return ErrorTypeRetryable
 	}
 
+	// Suppressed recipients stay suppressed until an admin lifts them
+	if errors.Is(err, models.ErrEmailSuppressed) {
+		return ErrorTypePermanent
+	}
+
 	errStr := strings.ToLower(err.Error())
 
 	// Permanent errors - SMTP 5xx codes (permanent failure)
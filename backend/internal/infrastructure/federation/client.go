@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// Headers authenticating a push: the instance ID of the peer and the Ed25519 signature of the
+// body with its signing key
+const (
+	InstanceHeader  = "X-Ackify-Instance"
+	SignatureHeader = "X-Ackify-Signature"
+)
+
+// PushPath is the path of the push endpoint of the hub
+const PushPath = "/api/v1/federation/push"
+
+const pushTimeout = 30 * time.Second
+
+// HTTPDoer abstracts http.Client for testing
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client pushes signed batches of signature proofs to the hub of a federation
+type Client struct {
+	hubURL string
+	http   HTTPDoer
+}
+
+// NewClient creates a client of the hub at hubURL; a nil httpClient uses a client with a 30s timeout
+func NewClient(hubURL string, httpClient HTTPDoer) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: pushTimeout}
+	}
+	return &Client{hubURL: hubURL, http: httpClient}
+}
+
+// Push sends a push body signed by instanceID and returns what the hub stored
+func (c *Client) Push(ctx context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.hubURL+PushPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build federation push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(InstanceHeader, instanceID)
+	req.Header.Set(SignatureHeader, signature)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the federation hub: %w", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var reply struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &reply) == nil && reply.Error.Message != "" {
+			return nil, fmt.Errorf("federation hub returned status %d: %s", resp.StatusCode, reply.Error.Message)
+		}
+		return nil, fmt.Errorf("federation hub returned status %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		Data models.FederationPushResult `json:"data"`
+	}
+	if err := json.Unmarshal(data, &reply); err != nil {
+		return nil, fmt.Errorf("invalid federation hub response: %w", err)
+	}
+	return &reply.Data, nil
+}
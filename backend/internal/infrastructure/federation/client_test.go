@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package federation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_Push(t *testing.T) {
+	var gotPath, gotInstance, gotSignature, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotInstance, gotSignature = r.URL.Path, r.Header.Get(InstanceHeader), r.Header.Get(SignatureHeader)
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		_, _ = w.Write([]byte(`{"data":{"documents":1,"signatures":2,"duplicates":1}}`))
+	}))
+	defer srv.Close()
+
+	result, err := NewClient(srv.URL, nil).Push(context.Background(), "acme-fr", []byte(`{"instanceId":"acme-fr"}`), "c2ln")
+	if err != nil {
+		t.Fatalf("push err: %v", err)
+	}
+	if gotPath != PushPath || gotInstance != "acme-fr" || gotSignature != "c2ln" || gotBody != `{"instanceId":"acme-fr"}` {
+		t.Errorf("unexpected request: path %q, instance %q, signature %q, body %q", gotPath, gotInstance, gotSignature, gotBody)
+	}
+	if result.Documents != 1 || result.Signatures != 2 || result.Duplicates != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestClient_PushRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"code":"UNAUTHORIZED","message":"federation push is not signed by a registered peer"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, nil).Push(context.Background(), "acme-fr", []byte(`{}`), "c2ln")
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "registered peer") {
+		t.Errorf("expected the hub error, got %v", err)
+	}
+}
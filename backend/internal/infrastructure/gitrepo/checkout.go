@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package gitrepo
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// defaultProtocols are the transports git may use, "ext" and "file" would let a repository
+// URL run commands or read the server files
+const defaultProtocols = "https:ssh"
+
+// Checkout keeps a shallow clone of the branch of each Git source under a working directory.
+// Credentials come from the repository URL or from the SSH configuration of the server.
+type Checkout struct {
+	dir       string
+	protocols string
+	mu        sync.Mutex // git commands of one clone must not interleave
+}
+
+// NewCheckout creates a checkout keeping its clones under dir
+func NewCheckout(dir string) *Checkout {
+	return &Checkout{dir: dir, protocols: defaultProtocols}
+}
+
+func (c *Checkout) git(ctx context.Context, dir, repoURL string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ALLOW_PROTOCOL="+c.protocols)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		output := strings.TrimSpace(string(out))
+		if u, parseErr := url.Parse(repoURL); parseErr == nil && u.User != nil {
+			output = strings.ReplaceAll(output, repoURL, u.Redacted())
+		}
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, output)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Fetch updates the clone of a source to the head of the branch and returns its files
+// and the commit they were read at
+func (c *Checkout) Fetch(ctx context.Context, sourceID int64, repoURL, branch string) (fs.FS, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clone := filepath.Join(c.dir, fmt.Sprintf("source-%d", sourceID))
+	if _, err := os.Stat(filepath.Join(clone, ".git")); err != nil {
+		if err := os.MkdirAll(c.dir, 0750); err != nil {
+			return nil, "", fmt.Errorf("failed to create git working directory: %w", err)
+		}
+		// A clone interrupted earlier would make git refuse the directory
+		if err := os.RemoveAll(clone); err != nil {
+			return nil, "", fmt.Errorf("failed to clean git clone: %w", err)
+		}
+		if _, err := c.git(ctx, c.dir, repoURL, "clone", "--depth", "1", "--single-branch", "--branch", branch, "--", repoURL, clone); err != nil {
+			return nil, "", err
+		}
+	} else {
+		if _, err := c.git(ctx, clone, repoURL, "remote", "set-url", "origin", repoURL); err != nil {
+			return nil, "", err
+		}
+		if _, err := c.git(ctx, clone, repoURL, "fetch", "--depth", "1", "origin", "--", branch); err != nil {
+			return nil, "", err
+		}
+		if _, err := c.git(ctx, clone, repoURL, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return nil, "", err
+		}
+	}
+
+	commit, err := c.git(ctx, clone, repoURL, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, "", err
+	}
+	return os.DirFS(clone), commit, nil
+}
+
+// Remove deletes the clone of a source
+func (c *Checkout) Remove(sourceID int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return os.RemoveAll(filepath.Join(c.dir, fmt.Sprintf("source-%d", sourceID)))
+}
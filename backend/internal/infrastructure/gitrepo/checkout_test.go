@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package gitrepo
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func commitFile(t *testing.T, work, name, content string) string {
+	t.Helper()
+	full := filepath.Join(work, name)
+	if err := os.MkdirAll(filepath.Dir(full), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0640); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, work, "add", "--", name)
+	runGit(t, work, "commit", "-m", "update "+name)
+	runGit(t, work, "push", "origin", "main")
+	return runGit(t, work, "rev-parse", "HEAD")
+}
+
+func TestCheckout_Fetch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	remote := filepath.Join(base, "remote.git")
+	work := filepath.Join(base, "work")
+	runGit(t, base, "init", "--bare", "-b", "main", remote)
+	runGit(t, base, "clone", remote, work)
+	runGit(t, work, "config", "user.name", "Ackify")
+	runGit(t, work, "config", "user.email", "ackify@example.com")
+	runGit(t, work, "checkout", "-b", "main")
+	first := commitFile(t, work, "policies/security.md", "# Security\n")
+
+	checkout := NewCheckout(filepath.Join(base, "clones"))
+	checkout.protocols = "file" // the test remote is local
+
+	files, commit, err := checkout.Fetch(context.Background(), 7, remote, "main")
+	if err != nil {
+		t.Fatalf("fetch err: %v", err)
+	}
+	if commit != first {
+		t.Errorf("expected commit %s, got %s", first, commit)
+	}
+	if data, err := fs.ReadFile(files, "policies/security.md"); err != nil || string(data) != "# Security\n" {
+		t.Errorf("unexpected file %q, %v", data, err)
+	}
+
+	// A later fetch follows the branch
+	second := commitFile(t, work, "policies/security.md", "# Security v2\n")
+	files, commit, err = checkout.Fetch(context.Background(), 7, remote, "main")
+	if err != nil {
+		t.Fatalf("refetch err: %v", err)
+	}
+	if commit != second {
+		t.Errorf("expected commit %s, got %s", second, commit)
+	}
+	if data, _ := fs.ReadFile(files, "policies/security.md"); string(data) != "# Security v2\n" {
+		t.Errorf("expected the updated file, got %q", data)
+	}
+
+	if err := checkout.Remove(7); err != nil {
+		t.Fatalf("remove err: %v", err)
+	}
+}
+
+func TestCheckout_RejectsOtherProtocols(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	remote := filepath.Join(base, "remote.git")
+	runGit(t, base, "init", "--bare", "-b", "main", remote)
+
+	// The default transports exclude local repositories
+	if _, _, err := NewCheckout(filepath.Join(base, "clones")).Fetch(context.Background(), 1, "file://"+remote, "main"); err == nil {
+		t.Error("expected the file transport to be refused")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"golang.org/x/text/language"
 )
@@ -29,43 +30,59 @@ var (
 )
 
 type I18n struct {
+	localesDir string
+
+	mu           sync.RWMutex
 	translations map[string]map[string]string // lang -> key -> value
 }
 
 func NewI18n(localesDir string) (*I18n, error) {
-	i18n := &I18n{
-		translations: make(map[string]map[string]string),
+	i18n := &I18n{localesDir: localesDir}
+	if err := i18n.Reload(); err != nil {
+		return nil, err
 	}
+	return i18n, nil
+}
+
+// Reload reads the translation files again. On error the current translations are kept.
+func (i *I18n) Reload() error {
+	translations := make(map[string]map[string]string)
 
 	// Load all supported language translations
 	languages := []string{"en", "fr", "it", "de", "es"}
 	for _, lang := range languages {
-		filePath := filepath.Join(localesDir, lang+".json")
-		if err := i18n.loadTranslations(filePath, lang); err != nil {
-			return nil, fmt.Errorf("failed to load %s translations: %w", lang, err)
+		filePath := filepath.Join(i.localesDir, lang+".json")
+		values, err := loadTranslations(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to load %s translations: %w", lang, err)
 		}
+		translations[lang] = values
 	}
 
-	return i18n, nil
+	i.mu.Lock()
+	i.translations = translations
+	i.mu.Unlock()
+	return nil
 }
 
-func (i *I18n) loadTranslations(filePath, lang string) error {
+func loadTranslations(filePath string) (map[string]string, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	translations := make(map[string]string)
 	if err := json.Unmarshal(data, &translations); err != nil {
-		return err
+		return nil, err
 	}
-
-	i.translations[lang] = translations
-	return nil
+	return translations, nil
 }
 
 // T translates a key for a given language
 func (i *I18n) T(lang, key string) string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	if translations, ok := i.translations[lang]; ok {
 		if value, ok := translations[key]; ok {
 			return value
@@ -111,6 +128,15 @@ func GetLangFromRequest(r *http.Request) string {
 	return DefaultLang
 }
 
+// GetLangFromQueryOrRequest extracts language from the lang query parameter, used by pages
+// embedded in other sites, then from cookie or Accept-Language header
+func GetLangFromQueryOrRequest(r *http.Request) string {
+	if lang := normalizeLang(r.URL.Query().Get("lang")); isSupported(lang) {
+		return lang
+	}
+	return GetLangFromRequest(r)
+}
+
 // SetLangCookie sets the language preference cookie
 func SetLangCookie(w http.ResponseWriter, lang string, secureCookies bool) {
 	lang = normalizeLang(lang)
@@ -155,6 +181,9 @@ func isSupported(lang string) bool {
 
 // GetTranslations returns all translations for a given language
 func (i *I18n) GetTranslations(lang string) map[string]string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	if translations, ok := i.translations[lang]; ok {
 		return translations
 	}
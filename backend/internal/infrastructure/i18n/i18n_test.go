@@ -409,3 +409,19 @@ func getKeys(translations map[string]string) map[string]struct{} {
 	}
 	return keys
 }
+
+func TestGetLangFromQueryOrRequest(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/status.txt?doc=policy&lang=de-CH", nil)
+	req.AddCookie(&http.Cookie{Name: LangCookieName, Value: "fr"})
+	if got := GetLangFromQueryOrRequest(req); got != "de" {
+		t.Errorf("expected the query language, got %s", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/status.txt?doc=policy&lang=xx", nil)
+	req.AddCookie(&http.Cookie{Name: LangCookieName, Value: "fr"})
+	if got := GetLangFromQueryOrRequest(req); got != "fr" {
+		t.Errorf("expected the cookie language for an unsupported query language, got %s", got)
+	}
+}
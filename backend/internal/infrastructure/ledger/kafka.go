@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	kafkaTimeout     = 15 * time.Second
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+)
+
+// HTTPDoer abstracts http.Client for testing
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// KafkaREST produces one record per signature to a Kafka topic through a Kafka REST proxy (v2 API).
+// The record key is "<tenant>:<signature ID>", so consumers can drop records streamed twice.
+// A topic cannot be searched by key, so this ledger is not read back for reconciliation.
+type KafkaREST struct {
+	url   string
+	topic string
+	token string
+	http  HTTPDoer
+}
+
+// NewKafkaREST creates a ledger producing to topic through the proxy at baseURL, with an optional
+// bearer token; a nil httpClient uses a client with a 15s timeout
+func NewKafkaREST(baseURL, topic, token string, httpClient HTTPDoer) *KafkaREST {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: kafkaTimeout}
+	}
+	return &KafkaREST{url: baseURL, topic: topic, token: token, http: httpClient}
+}
+
+type kafkaRecord struct {
+	Key   string             `json:"key"`
+	Value models.LedgerEntry `json:"value"`
+}
+
+type kafkaOffset struct {
+	Partition int     `json:"partition"`
+	Offset    int64   `json:"offset"`
+	ErrorCode *int    `json:"error_code"`
+	Error     *string `json:"error"`
+}
+
+// Append produces the entries in one request. The reference is "kafka:<topic>/<partition>/<offset>".
+func (k *KafkaREST) Append(ctx context.Context, entries []models.LedgerEntry) ([]string, error) {
+	records := make([]kafkaRecord, len(entries))
+	for i, entry := range entries {
+		records[i] = kafkaRecord{Key: entryKey(entry), Value: entry}
+	}
+	body, err := json.Marshal(map[string]any{"records": records})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ledger records: %w", err)
+	}
+
+	endpoint := k.url + "/topics/" + url.PathEscape(k.topic)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build ledger request: %w", err)
+	}
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json, application/json")
+	if k.token != "" {
+		req.Header.Set("Authorization", "Bearer "+k.token)
+	}
+
+	resp, err := k.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to produce ledger records: %w", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("kafka REST proxy returned status %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		Offsets []kafkaOffset `json:"offsets"`
+	}
+	if err := json.Unmarshal(data, &reply); err != nil {
+		return nil, fmt.Errorf("invalid kafka REST proxy response: %w", err)
+	}
+	if len(reply.Offsets) != len(entries) {
+		return nil, fmt.Errorf("kafka REST proxy acknowledged %d records out of %d", len(reply.Offsets), len(entries))
+	}
+
+	refs := make([]string, len(entries))
+	for i, offset := range reply.Offsets {
+		if offset.ErrorCode != nil || offset.Error != nil {
+			message := "unknown error"
+			if offset.Error != nil {
+				message = *offset.Error
+			}
+			return nil, fmt.Errorf("kafka rejected the record of signature %d: %s", entries[i].SignatureID, message)
+		}
+		refs[i] = fmt.Sprintf("kafka:%s/%d/%d", k.topic, offset.Partition, offset.Offset)
+	}
+	return refs, nil
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package ledger streams signature record hashes to an external append-only ledger,
+// so that evidence is also kept by a system the Ackify operators cannot rewrite.
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// Writer appends entries to a ledger and returns where each one was written, in order.
+// Appending an entry already in the ledger with the same hash must succeed, since a batch
+// is streamed again when recording it locally failed.
+type Writer interface {
+	Append(ctx context.Context, entries []models.LedgerEntry) ([]string, error)
+}
+
+// Reader is implemented by ledgers that can be read back for reconciliation
+type Reader interface {
+	// Lookup returns the record hashes held by the ledger for the signatures, by signature ID
+	Lookup(ctx context.Context, tenantID uuid.UUID, signatureIDs []int64) (map[int64]string, error)
+}
+
+// New creates the ledger selected by the configuration, or nil when none is set
+func New(cfg config.LedgerConfig) (Writer, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case models.LedgerPostgres:
+		return OpenPostgres(cfg.PostgresDSN)
+	case models.LedgerKafka:
+		return NewKafkaREST(cfg.KafkaURL, cfg.KafkaTopic, cfg.KafkaToken, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown ledger: %s", cfg.Type)
+	}
+}
+
+// entryKey identifies an entry across tenants, used as message key and idempotency key
+func entryKey(entry models.LedgerEntry) string {
+	return fmt.Sprintf("%s:%d", entry.TenantID, entry.SignatureID)
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package ledger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func testEntries() []models.LedgerEntry {
+	tenantID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	signedAt := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	return []models.LedgerEntry{
+		{TenantID: tenantID, SignatureID: 7, DocID: "doc-1", RecordHash: "aGFzaC03", SignedAt: signedAt},
+		{TenantID: tenantID, SignatureID: 8, DocID: "doc-1", RecordHash: "aGFzaC04", SignedAt: signedAt},
+	}
+}
+
+func TestKafkaREST_Append(t *testing.T) {
+	var gotPath, gotType, gotAuth string
+	var got struct {
+		Records []struct {
+			Key   string             `json:"key"`
+			Value models.LedgerEntry `json:"value"`
+		} `json:"records"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotType, gotAuth = r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":41,"error_code":null,"error":null},{"partition":1,"offset":12}]}`))
+	}))
+	defer srv.Close()
+
+	refs, err := NewKafkaREST(srv.URL, "ackify-signatures", "secret", nil).Append(context.Background(), testEntries())
+	if err != nil {
+		t.Fatalf("append err: %v", err)
+	}
+
+	if gotPath != "/topics/ackify-signatures" || gotType != kafkaContentType || gotAuth != "Bearer secret" {
+		t.Errorf("unexpected request: path %q, content type %q, auth %q", gotPath, gotType, gotAuth)
+	}
+	if len(got.Records) != 2 || got.Records[0].Key != "11111111-1111-1111-1111-111111111111:7" || got.Records[1].Value.RecordHash != "aGFzaC04" {
+		t.Errorf("unexpected records: %+v", got.Records)
+	}
+	if len(refs) != 2 || refs[0] != "kafka:ackify-signatures/0/41" || refs[1] != "kafka:ackify-signatures/1/12" {
+		t.Errorf("unexpected refs: %v", refs)
+	}
+}
+
+func TestKafkaREST_AppendErrors(t *testing.T) {
+	replies := map[string]string{
+		"rejected":     `{"offsets":[{"partition":0,"offset":1},{"error_code":40101,"error":"not authorized"}]}`,
+		"acknowledged": `{"offsets":[{"partition":0,"offset":1}]}`,
+	}
+	for name, reply := range replies {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(reply))
+		}))
+		_, err := NewKafkaREST(srv.URL, "topic", "", nil).Append(context.Background(), testEntries())
+		srv.Close()
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("expected a %q error, got %v", name, err)
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	if _, err := NewKafkaREST(srv.URL, "topic", "", nil).Append(context.Background(), testEntries()); err == nil {
+		t.Error("expected an error when the proxy is unavailable")
+	}
+}
+
+func TestNew(t *testing.T) {
+	writer, err := New(config.LedgerConfig{})
+	if err != nil || writer != nil {
+		t.Fatalf("expected no ledger, got %v, %v", writer, err)
+	}
+
+	writer, err = New(config.LedgerConfig{Type: models.LedgerKafka, KafkaURL: "https://kafka-rest.example.com", KafkaTopic: "topic"})
+	if err != nil {
+		t.Fatalf("kafka err: %v", err)
+	}
+	if _, ok := writer.(Reader); ok {
+		t.Error("kafka ledger must not be readable for reconciliation")
+	}
+
+	writer, err = New(config.LedgerConfig{Type: models.LedgerPostgres, PostgresDSN: "postgres://ledger@localhost/ledger"})
+	if err != nil {
+		t.Fatalf("postgres err: %v", err)
+	}
+	if _, ok := writer.(Reader); !ok {
+		t.Error("postgres ledger must be readable for reconciliation")
+	}
+	_ = writer.(*Postgres).Close()
+
+	if _, err := New(config.LedgerConfig{Type: "immudb"}); err == nil {
+		t.Error("expected an error for an unknown ledger")
+	}
+}
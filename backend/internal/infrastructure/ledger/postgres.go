@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// PostgresSchema creates the ledger table in the separate database. It is run by the owner of that
+// database, who only grants SELECT and INSERT on it to the role Ackify connects with.
+const PostgresSchema = `
+CREATE TABLE IF NOT EXISTS ackify_signature_ledger (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    signature_id BIGINT NOT NULL,
+    doc_id TEXT NOT NULL,
+    record_hash TEXT NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    appended_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, signature_id)
+);
+
+CREATE OR REPLACE FUNCTION ackify_signature_ledger_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ackify_signature_ledger is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tr_ackify_signature_ledger_append_only ON ackify_signature_ledger;
+CREATE TRIGGER tr_ackify_signature_ledger_append_only
+    BEFORE UPDATE OR DELETE ON ackify_signature_ledger
+    FOR EACH ROW EXECUTE FUNCTION ackify_signature_ledger_append_only();
+`
+
+// Postgres appends entries to a table of a separate PostgreSQL database
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres wraps a connection to the ledger database
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// OpenPostgres connects to the ledger database. The connection is checked lazily, so that a ledger
+// down at startup only delays streaming.
+func OpenPostgres(dsn string) (*Postgres, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ledger database: %w", err)
+	}
+	db.SetMaxOpenConns(2)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(5 * time.Minute)
+	return NewPostgres(db), nil
+}
+
+// Close closes the connection to the ledger database
+func (p *Postgres) Close() error {
+	return p.db.Close()
+}
+
+// Append inserts the entries in one transaction. The reference is the ledger row ID.
+func (p *Postgres) Append(ctx context.Context, entries []models.LedgerEntry) ([]string, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin ledger transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	refs := make([]string, len(entries))
+	for i, entry := range entries {
+		id, err := appendPostgresEntry(ctx, tx, entry)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = "postgres:" + strconv.FormatInt(id, 10)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit ledger entries: %w", err)
+	}
+	return refs, nil
+}
+
+// appendPostgresEntry inserts an entry, or returns the existing row when the same hash is already there
+func appendPostgresEntry(ctx context.Context, tx *sql.Tx, entry models.LedgerEntry) (int64, error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO ackify_signature_ledger (tenant_id, signature_id, doc_id, record_hash, signed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, signature_id) DO NOTHING
+		RETURNING id`,
+		entry.TenantID, entry.SignatureID, entry.DocID, entry.RecordHash, entry.SignedAt,
+	).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to append ledger entry: %w", err)
+	}
+
+	var existing string
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, record_hash FROM ackify_signature_ledger WHERE tenant_id = $1 AND signature_id = $2`,
+		entry.TenantID, entry.SignatureID,
+	).Scan(&id, &existing)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read existing ledger entry: %w", err)
+	}
+	if existing != entry.RecordHash {
+		return 0, fmt.Errorf("ledger already holds another hash for signature %d", entry.SignatureID)
+	}
+	return id, nil
+}
+
+// Lookup returns the record hashes held by the ledger for the signatures
+func (p *Postgres) Lookup(ctx context.Context, tenantID uuid.UUID, signatureIDs []int64) (map[int64]string, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT signature_id, record_hash FROM ackify_signature_ledger
+		WHERE tenant_id = $1 AND signature_id = ANY($2)`,
+		tenantID, pq.Array(signatureIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[int64]string, len(signatureIDs))
+	for rows.Next() {
+		var id int64
+		var hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		hashes[id] = hash
+	}
+	return hashes, rows.Err()
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linkresolver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// confluencePagePath finds the page ID of /spaces/KEY/pages/123/Title links
+var confluencePagePath = regexp.MustCompile(`/pages/(\d+)(?:/|$)`)
+
+// ConfluenceResolver reads pages of a Confluence Cloud or Data Center site through its REST API
+type ConfluenceResolver struct {
+	client *http.Client
+	base   *url.URL
+	user   string
+	token  string
+}
+
+// NewConfluenceResolver creates a resolver for the site at baseURL (https://acme.atlassian.net/wiki for Cloud).
+// With a user, the token is an API token sent with basic auth; without, a personal access token.
+func NewConfluenceResolver(client *http.Client, baseURL, user, token string) (*ConfluenceResolver, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid confluence URL: %s", baseURL)
+	}
+	return &ConfluenceResolver{client: client, base: base, user: user, token: token}, nil
+}
+
+// Matches reports whether the link is a page of the configured site
+func (c *ConfluenceResolver) Matches(link *url.URL) bool {
+	if !strings.EqualFold(link.Host, c.base.Host) || !strings.HasPrefix(link.Path, c.base.Path+"/") {
+		return false
+	}
+	return c.pageID(link) != ""
+}
+
+// pageID extracts the page ID of a page link or a viewpage.action?pageId= link
+func (c *ConfluenceResolver) pageID(link *url.URL) string {
+	if id := link.Query().Get("pageId"); id != "" {
+		if _, err := strconv.ParseInt(id, 10, 64); err == nil {
+			return id
+		}
+		return ""
+	}
+	if m := confluencePagePath.FindStringSubmatch(link.Path); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+type confluenceContent struct {
+	Title   string `json:"title"`
+	Version struct {
+		Number int    `json:"number"`
+		When   string `json:"when"`
+	} `json:"version"`
+	Body struct {
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+}
+
+// Resolve returns the title and version number of the page; the content hash is the SHA-256
+// of its storage format body
+func (c *ConfluenceResolver) Resolve(ctx context.Context, link *url.URL) (*models.LinkMetadata, error) {
+	id := c.pageID(link)
+	if id == "" {
+		return nil, fmt.Errorf("not a confluence page link")
+	}
+
+	endpoint := fmt.Sprintf("%s/rest/api/content/%s?expand=version,body.storage", c.base.String(), url.PathEscape(id))
+	header := http.Header{}
+	if c.user != "" {
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.user+":"+c.token)))
+	} else {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	var content confluenceContent
+	if err := getJSON(ctx, c.client, endpoint, header, &content); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(content.Body.Storage.Value))
+	meta := &models.LinkMetadata{
+		Provider:    models.LinkProviderConfluence,
+		Title:       content.Title,
+		Version:     strconv.Itoa(content.Version.Number),
+		ContentHash: hex.EncodeToString(sum[:]),
+	}
+	if when, err := time.Parse(time.RFC3339, content.Version.When); err == nil {
+		meta.ModifiedAt = &when
+	}
+	return meta, nil
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linkresolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	driveBaseURL = "https://www.googleapis.com/drive/v3"
+
+	// driveChannelTTL is the lifetime requested for a channel, Drive caps file channels at one day;
+	// channels are renewed before they expire
+	driveChannelTTL = 24 * time.Hour
+
+	// googleNativeMimePrefix marks the native Google Docs, Sheets and Slides files, which have no
+	// binary content and so no checksum nor head revision
+	googleNativeMimePrefix = "application/vnd.google-apps."
+)
+
+// driveFilePath finds the file ID of /document/d/ID/edit, /spreadsheets/d/ID, /presentation/d/ID
+// and /file/d/ID/view links
+var driveFilePath = regexp.MustCompile(`^/(?:document|spreadsheets|presentation|file)/d/([A-Za-z0-9_-]{10,})(?:/|$)`)
+
+// GoogleDriveResolver reads Google Docs, Sheets, Slides and Drive files through the Drive API
+type GoogleDriveResolver struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewGoogleDriveResolver creates a resolver using an authenticated client; an empty baseURL uses the Drive API
+func NewGoogleDriveResolver(client *http.Client, baseURL string) *GoogleDriveResolver {
+	if baseURL == "" {
+		baseURL = driveBaseURL
+	}
+	return &GoogleDriveResolver{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// newDriveClient authenticates as a service account; the files are shared with its email address
+func newDriveClient(credentialsFile string) (*http.Client, error) {
+	data, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read google drive credentials: %w", err)
+	}
+	var key struct {
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse google drive credentials: %w", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("google drive credentials are not a service account key")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	jcfg := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{"https://www.googleapis.com/auth/drive.readonly"},
+		TokenURL:     key.TokenURI,
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: defaultTimeout})
+	client := jcfg.Client(ctx)
+	client.Timeout = defaultTimeout
+	return client, nil
+}
+
+// Matches reports whether the link is a Google Docs, Sheets, Slides or Drive file
+func (c *GoogleDriveResolver) Matches(link *url.URL) bool {
+	return c.fileID(link) != ""
+}
+
+// fileID extracts the file ID of a docs.google.com or drive.google.com link
+func (c *GoogleDriveResolver) fileID(link *url.URL) string {
+	if link.Scheme != "https" {
+		return ""
+	}
+	switch strings.ToLower(link.Hostname()) {
+	case "docs.google.com":
+		if m := driveFilePath.FindStringSubmatch(link.Path); m != nil && !strings.HasPrefix(link.Path, "/file/") {
+			return m[1]
+		}
+	case "drive.google.com":
+		if m := driveFilePath.FindStringSubmatch(link.Path); m != nil && strings.HasPrefix(link.Path, "/file/") {
+			return m[1]
+		}
+		if link.Path == "/open" {
+			return link.Query().Get("id")
+		}
+	}
+	return ""
+}
+
+type driveFile struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	MimeType       string `json:"mimeType"`
+	MD5Checksum    string `json:"md5Checksum"`
+	HeadRevisionID string `json:"headRevisionId"`
+	ModifiedTime   string `json:"modifiedTime"`
+}
+
+// Resolve returns the file name, its head revision and checksum. Native Google files have
+// neither: their modification time stands for both, as the file version also counts
+// changes of sharing and other metadata.
+func (c *GoogleDriveResolver) Resolve(ctx context.Context, link *url.URL) (*models.LinkMetadata, error) {
+	id := c.fileID(link)
+	if id == "" {
+		return nil, fmt.Errorf("not a google drive file link: %s", link)
+	}
+
+	query := url.Values{
+		"fields":            {"id,name,mimeType,md5Checksum,headRevisionId,modifiedTime"},
+		"supportsAllDrives": {"true"},
+	}
+	endpoint := fmt.Sprintf("%s/files/%s?%s", c.baseURL, url.PathEscape(id), query.Encode())
+
+	var file driveFile
+	if err := getJSON(ctx, c.client, endpoint, nil, &file); err != nil {
+		return nil, err
+	}
+
+	meta := &models.LinkMetadata{
+		Provider:    models.LinkProviderGoogleDrive,
+		Title:       file.Name,
+		Version:     file.HeadRevisionID,
+		ContentHash: file.MD5Checksum,
+	}
+	if !strings.HasPrefix(file.MimeType, googleNativeMimePrefix) {
+		meta.Title = strings.TrimSuffix(file.Name, path.Ext(file.Name))
+	}
+	if modified, err := time.Parse(time.RFC3339, file.ModifiedTime); err == nil {
+		meta.ModifiedAt = &modified
+		if meta.Version == "" {
+			meta.Version = modified.UTC().Format(time.RFC3339)
+		}
+		if meta.ContentHash == "" {
+			meta.ContentHash = "modified:" + meta.Version
+		}
+	}
+	return meta, nil
+}
+
+type driveChannel struct {
+	ID         string `json:"id"`
+	ResourceID string `json:"resourceId,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Address    string `json:"address,omitempty"`
+	Token      string `json:"token,omitempty"`
+	Expiration string `json:"expiration,omitempty"` // Unix time in milliseconds
+}
+
+// Watch opens a notification channel on the file
+func (c *GoogleDriveResolver) Watch(ctx context.Context, link *url.URL, notificationURL, clientState string) (*models.LinkSubscription, error) {
+	id := c.fileID(link)
+	if id == "" {
+		return nil, fmt.Errorf("not a google drive file link: %s", link)
+	}
+
+	request := driveChannel{
+		ID:         uuid.NewString(),
+		Type:       "web_hook",
+		Address:    notificationURL,
+		Token:      clientState,
+		Expiration: strconv.FormatInt(time.Now().Add(driveChannelTTL).UnixMilli(), 10),
+	}
+	endpoint := fmt.Sprintf("%s/files/%s/watch?supportsAllDrives=true", c.baseURL, url.PathEscape(id))
+	var created driveChannel
+	if err := sendJSON(ctx, c.client, http.MethodPost, endpoint, nil, request, &created); err != nil {
+		return nil, err
+	}
+
+	expiration, err := strconv.ParseInt(created.Expiration, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel expiration %q: %w", created.Expiration, err)
+	}
+	return &models.LinkSubscription{
+		Provider:       models.LinkProviderGoogleDrive,
+		SubscriptionID: created.ID,
+		ResourceID:     created.ResourceID,
+		ClientState:    clientState,
+		ExpiresAt:      time.UnixMilli(expiration),
+	}, nil
+}
+
+// Unwatch stops the notification channel
+func (c *GoogleDriveResolver) Unwatch(ctx context.Context, sub *models.LinkSubscription) error {
+	request := driveChannel{ID: sub.SubscriptionID, ResourceID: sub.ResourceID}
+	return sendJSON(ctx, c.client, http.MethodPost, c.baseURL+"/channels/stop", nil, request, nil)
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linkresolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+
+	// maxResponseSize bounds one API response, Confluence returns the page body
+	maxResponseSize = 10 << 20
+)
+
+// Resolver reads the metadata of the pages of one host through its API.
+// Requests only go to the API of the host, never to the link itself.
+type Resolver interface {
+	// Matches reports whether the link is a page of the host
+	Matches(link *url.URL) bool
+	// Resolve returns the title, latest version and content hash of the page
+	Resolve(ctx context.Context, link *url.URL) (*models.LinkMetadata, error)
+}
+
+// Watcher subscribes to the change notifications a host pushes for a file, implemented by the
+// resolvers of the hosts supporting them. Notifications are posted to notificationURL and carry
+// clientState back.
+type Watcher interface {
+	Watch(ctx context.Context, link *url.URL, notificationURL, clientState string) (*models.LinkSubscription, error)
+	Unwatch(ctx context.Context, sub *models.LinkSubscription) error
+}
+
+// NewResolvers creates the resolvers whose credentials are configured, by provider
+func NewResolvers(cfg config.LinkResolverConfig) (map[string]Resolver, error) {
+	resolvers := make(map[string]Resolver)
+
+	if cfg.ConfluenceURL != "" {
+		resolver, err := NewConfluenceResolver(&http.Client{Timeout: defaultTimeout}, cfg.ConfluenceURL, cfg.ConfluenceUser, cfg.ConfluenceToken)
+		if err != nil {
+			return nil, err
+		}
+		resolvers[models.LinkProviderConfluence] = resolver
+	}
+
+	if cfg.SharePointTenantID != "" {
+		resolvers[models.LinkProviderSharePoint] = NewSharePointResolver(newGraphClient(cfg), "")
+	}
+
+	if cfg.GoogleDriveCredentialsFile != "" {
+		client, err := newDriveClient(cfg.GoogleDriveCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		resolvers[models.LinkProviderGoogleDrive] = NewGoogleDriveResolver(client, "")
+	}
+
+	return resolvers, nil
+}
+
+// newGraphClient authenticates as an application granted the Sites.Read.All permission
+func newGraphClient(cfg config.LinkResolverConfig) *http.Client {
+	ccfg := &clientcredentials.Config{
+		ClientID:     cfg.SharePointClientID,
+		ClientSecret: cfg.SharePointClientSecret,
+		TokenURL:     fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", cfg.SharePointTenantID),
+		Scopes:       []string{"https://graph.microsoft.com/.default"},
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: defaultTimeout})
+	client := ccfg.Client(ctx)
+	client.Timeout = defaultTimeout
+	return client
+}
+
+// getJSON fetches an API resource and decodes it into out
+func getJSON(ctx context.Context, client *http.Client, endpoint string, header http.Header, out interface{}) error {
+	return sendJSON(ctx, client, http.MethodGet, endpoint, header, nil, out)
+}
+
+// sendJSON sends in, when not nil, as the JSON body of the request and decodes the response
+// into out, when not nil
+func sendJSON(ctx context.Context, client *http.Client, method, endpoint string, header http.Header, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode link request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to build link request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("link request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read link response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("link host returned status %d: %s", resp.StatusCode, truncate(string(data), 200))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode link response: %w", err)
+	}
+	return nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
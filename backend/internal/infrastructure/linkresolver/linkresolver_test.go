@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linkresolver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+func mustParse(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestConfluenceResolver_Matches(t *testing.T) {
+	resolver, err := NewConfluenceResolver(http.DefaultClient, "https://acme.atlassian.net/wiki", "", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		link    string
+		matches bool
+	}{
+		{"https://acme.atlassian.net/wiki/spaces/SEC/pages/42/Security+Policy", true},
+		{"https://acme.atlassian.net/wiki/spaces/SEC/pages/42", true},
+		{"https://acme.atlassian.net/wiki/pages/viewpage.action?pageId=42", true},
+		{"https://acme.atlassian.net/wiki/spaces/SEC/overview", false},
+		{"https://acme.atlassian.net/wiki/pages/viewpage.action?pageId=abc", false},
+		{"https://other.atlassian.net/wiki/spaces/SEC/pages/42", false},
+		{"https://acme.atlassian.net/jira/pages/42", false},
+	}
+	for _, tt := range tests {
+		if got := resolver.Matches(mustParse(t, tt.link)); got != tt.matches {
+			t.Errorf("Matches(%s) = %v, expected %v", tt.link, got, tt.matches)
+		}
+	}
+}
+
+func TestConfluenceResolver_Resolve(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/content/42" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("expand") != "version,body.storage" {
+			t.Errorf("unexpected expand %s", r.URL.Query().Get("expand"))
+		}
+		authorization = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"id":"42","title":"Security Policy","version":{"number":7,"when":"2025-03-01T10:00:00.000Z"},
+			"body":{"storage":{"value":"<p>Policy</p>"}}}`)
+	}))
+	defer server.Close()
+
+	resolver, err := NewConfluenceResolver(server.Client(), server.URL+"/wiki", "admin@acme.com", "api-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	link := mustParse(t, server.URL+"/wiki/spaces/SEC/pages/42/Security+Policy")
+	meta, err := resolver.Resolve(context.Background(), link)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if meta.Provider != models.LinkProviderConfluence || meta.Title != "Security Policy" || meta.Version != "7" {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+	sum := sha256.Sum256([]byte("<p>Policy</p>"))
+	if meta.ContentHash != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected the SHA-256 of the page body, got %q", meta.ContentHash)
+	}
+	if meta.ModifiedAt == nil {
+		t.Error("expected the version date")
+	}
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin@acme.com:api-token"))
+	if authorization != expected {
+		t.Errorf("expected basic auth, got %q", authorization)
+	}
+}
+
+func TestConfluenceResolver_PersonalAccessToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"title":"Page","version":{"number":1}}`)
+	}))
+	defer server.Close()
+
+	resolver, _ := NewConfluenceResolver(server.Client(), server.URL, "", "pat")
+	if _, err := resolver.Resolve(context.Background(), mustParse(t, server.URL+"/pages/viewpage.action?pageId=9")); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+}
+
+func TestSharePointResolver_Resolve(t *testing.T) {
+	link := "https://acme.sharepoint.com/sites/policies/SitePages/Security.aspx"
+	shareID := "u!" + base64.RawURLEncoding.EncodeToString([]byte(link))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/shares/" + shareID + "/driveItem":
+			fmt.Fprint(w, `{"id":"item-1","name":"Security.aspx","cTag":"ctag-3","lastModifiedDateTime":"2025-03-01T10:00:00Z",
+				"file":{"hashes":{"quickXorHash":"qxh"}},"parentReference":{"driveId":"drive-1"},
+				"listItem":{"fields":{"Title":"Security Policy"}}}`)
+		case "/drives/drive-1/items/item-1/versions":
+			fmt.Fprint(w, `{"value":[{"id":"3.0"},{"id":"2.0"},{"id":"1.0"}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewSharePointResolver(server.Client(), server.URL)
+	u := mustParse(t, link)
+	if !resolver.Matches(u) || resolver.Matches(mustParse(t, "https://sharepoint.example.com/doc")) {
+		t.Fatal("expected only SharePoint Online links to match")
+	}
+
+	meta, err := resolver.Resolve(context.Background(), u)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if meta.Provider != models.LinkProviderSharePoint || meta.Title != "Security Policy" || meta.Version != "3.0" || meta.ContentHash != "qxh" {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+}
+
+func TestSharePointResolver_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":"accessDenied"}}`)
+	}))
+	defer server.Close()
+
+	_, err := NewSharePointResolver(server.Client(), server.URL).Resolve(context.Background(), mustParse(t, "https://acme.sharepoint.com/doc.docx"))
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("expected the status in the error, got %v", err)
+	}
+}
+
+func TestNewResolvers(t *testing.T) {
+	resolvers, err := NewResolvers(config.LinkResolverConfig{})
+	if err != nil || len(resolvers) != 0 {
+		t.Fatalf("expected no resolver without credentials, got %v, %v", resolvers, err)
+	}
+
+	resolvers, err = NewResolvers(config.LinkResolverConfig{
+		ConfluenceURL: "https://acme.atlassian.net/wiki", ConfluenceToken: "token",
+		SharePointTenantID: "tenant", SharePointClientID: "id", SharePointClientSecret: "secret",
+	})
+	if err != nil || len(resolvers) != 2 {
+		t.Fatalf("expected both resolvers, got %v, %v", resolvers, err)
+	}
+
+	if _, err := NewResolvers(config.LinkResolverConfig{GoogleDriveCredentialsFile: "/nonexistent.json"}); err == nil {
+		t.Error("expected an error for missing google drive credentials")
+	}
+}
+
+func TestSharePointResolver_Watch(t *testing.T) {
+	link := "https://acme.sharepoint.com/sites/hr/Shared%20Documents/Policy.docx"
+	var subscription graphSubscription
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/shares/"):
+			fmt.Fprint(w, `{"id":"item-1","name":"Policy.docx","parentReference":{"driveId":"drive-1"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/subscriptions":
+			if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id":"sub-1","expirationDateTime":"2030-01-04T10:00:00Z"}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/subscriptions/sub-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewSharePointResolver(server.Client(), server.URL)
+	sub, err := resolver.Watch(context.Background(), mustParse(t, link), "https://ackify.example.com/api/v1/link-notifications/sharepoint", "state")
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if subscription.Resource != "/drives/drive-1/root" || subscription.ClientState != "state" || subscription.ChangeType != "updated" {
+		t.Errorf("unexpected subscription request %+v", subscription)
+	}
+	if sub.SubscriptionID != "sub-1" || sub.Provider != models.LinkProviderSharePoint || sub.ExpiresAt.Year() != 2030 {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+	if err := resolver.Unwatch(context.Background(), sub); err != nil {
+		t.Errorf("Unwatch failed: %v", err)
+	}
+}
+
+func TestGoogleDriveResolver_Matches(t *testing.T) {
+	resolver := NewGoogleDriveResolver(http.DefaultClient, "")
+
+	tests := []struct {
+		link string
+		id   string
+	}{
+		{"https://docs.google.com/document/d/1AbCdEfGhIjKlMnOp/edit", "1AbCdEfGhIjKlMnOp"},
+		{"https://docs.google.com/spreadsheets/d/1AbCdEfGhIjKlMnOp", "1AbCdEfGhIjKlMnOp"},
+		{"https://docs.google.com/presentation/d/1AbCdEfGhIjKlMnOp/edit#slide=id.p", "1AbCdEfGhIjKlMnOp"},
+		{"https://drive.google.com/file/d/1AbCdEfGhIjKlMnOp/view?usp=sharing", "1AbCdEfGhIjKlMnOp"},
+		{"https://drive.google.com/open?id=1AbCdEfGhIjKlMnOp", "1AbCdEfGhIjKlMnOp"},
+		{"https://docs.google.com/forms/d/1AbCdEfGhIjKlMnOp/viewform", ""},
+		{"https://drive.google.com/drive/folders/1AbCdEfGhIjKlMnOp", ""},
+		{"http://docs.google.com/document/d/1AbCdEfGhIjKlMnOp/edit", ""},
+		{"https://example.com/document/d/1AbCdEfGhIjKlMnOp/edit", ""},
+	}
+	for _, tt := range tests {
+		if got := resolver.fileID(mustParse(t, tt.link)); got != tt.id {
+			t.Errorf("fileID(%s) = %q, expected %q", tt.link, got, tt.id)
+		}
+		if resolver.Matches(mustParse(t, tt.link)) != (tt.id != "") {
+			t.Errorf("Matches(%s) disagrees with fileID", tt.link)
+		}
+	}
+}
+
+func TestGoogleDriveResolver_Resolve(t *testing.T) {
+	files := map[string]string{
+		"/files/1NativeDocument0": `{"id":"1NativeDocument0","name":"Security Policy","mimeType":"application/vnd.google-apps.document","modifiedTime":"2025-03-01T10:00:00.000Z"}`,
+		"/files/1BinaryFile00000": `{"id":"1BinaryFile00000","name":"Policy.pdf","mimeType":"application/pdf","md5Checksum":"md5","headRevisionId":"rev-7","modifiedTime":"2025-03-02T10:00:00Z"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok || r.URL.Query().Get("supportsAllDrives") != "true" {
+			t.Errorf("unexpected request %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	resolver := NewGoogleDriveResolver(server.Client(), server.URL)
+
+	native, err := resolver.Resolve(context.Background(), mustParse(t, "https://docs.google.com/document/d/1NativeDocument0/edit"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if native.Provider != models.LinkProviderGoogleDrive || native.Title != "Security Policy" ||
+		native.Version != "2025-03-01T10:00:00Z" || native.ContentHash != "modified:2025-03-01T10:00:00Z" {
+		t.Errorf("unexpected native file metadata %+v", native)
+	}
+
+	binary, err := resolver.Resolve(context.Background(), mustParse(t, "https://drive.google.com/file/d/1BinaryFile00000/view"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if binary.Title != "Policy" || binary.Version != "rev-7" || binary.ContentHash != "md5" {
+		t.Errorf("unexpected binary file metadata %+v", binary)
+	}
+}
+
+func TestGoogleDriveResolver_Watch(t *testing.T) {
+	var channel driveChannel
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/1NativeDocument0/watch":
+			if err := json.NewDecoder(r.Body).Decode(&channel); err != nil {
+				t.Fatal(err)
+			}
+			fmt.Fprintf(w, `{"id":%q,"resourceId":"res-1","expiration":"1893456000000"}`, channel.ID)
+		case "/channels/stop":
+			var stop driveChannel
+			if err := json.NewDecoder(r.Body).Decode(&stop); err != nil || stop.ResourceID != "res-1" {
+				t.Errorf("unexpected stop request %+v, %v", stop, err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewGoogleDriveResolver(server.Client(), server.URL)
+	sub, err := resolver.Watch(context.Background(), mustParse(t, "https://docs.google.com/document/d/1NativeDocument0/edit"),
+		"https://ackify.example.com/api/v1/link-notifications/google-drive", "state")
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	if channel.Type != "web_hook" || channel.Token != "state" || channel.ID == "" {
+		t.Errorf("unexpected channel request %+v", channel)
+	}
+	if sub.SubscriptionID != channel.ID || sub.ResourceID != "res-1" || sub.ExpiresAt.Year() != 2030 {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+	if err := resolver.Unwatch(context.Background(), sub); err != nil {
+		t.Errorf("Unwatch failed: %v", err)
+	}
+}
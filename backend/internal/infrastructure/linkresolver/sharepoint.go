@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linkresolver
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	graphBaseURL = "https://graph.microsoft.com/v1.0"
+
+	// graphSubscriptionTTL is the lifetime requested for a subscription, below the Graph limit
+	// for drive items (about 29 days); subscriptions are renewed before they expire
+	graphSubscriptionTTL = 72 * time.Hour
+)
+
+// SharePointResolver reads SharePoint pages and files through the shares endpoint of Microsoft Graph
+type SharePointResolver struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewSharePointResolver creates a resolver using an authenticated client; an empty baseURL uses Microsoft Graph
+func NewSharePointResolver(client *http.Client, baseURL string) *SharePointResolver {
+	if baseURL == "" {
+		baseURL = graphBaseURL
+	}
+	return &SharePointResolver{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Matches reports whether the link is on a SharePoint Online site
+func (c *SharePointResolver) Matches(link *url.URL) bool {
+	host := strings.ToLower(link.Hostname())
+	return link.Scheme == "https" && strings.HasSuffix(host, ".sharepoint.com")
+}
+
+type sharePointItem struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	CTag                 string `json:"cTag"`
+	LastModifiedDateTime string `json:"lastModifiedDateTime"`
+	File                 *struct {
+		Hashes struct {
+			SHA256Hash   string `json:"sha256Hash"`
+			QuickXorHash string `json:"quickXorHash"`
+		} `json:"hashes"`
+	} `json:"file"`
+	ParentReference struct {
+		DriveID string `json:"driveId"`
+	} `json:"parentReference"`
+	ListItem *struct {
+		Fields map[string]interface{} `json:"fields"`
+	} `json:"listItem"`
+}
+
+type sharePointVersions struct {
+	Value []struct {
+		ID string `json:"id"`
+	} `json:"value"`
+}
+
+// Resolve returns the page title (or file name), the latest version label and the content hash
+// reported by SharePoint: the file hash when available, else the content tag
+func (c *SharePointResolver) Resolve(ctx context.Context, link *url.URL) (*models.LinkMetadata, error) {
+	item, err := c.item(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	// Versions are listed newest first
+	var versions sharePointVersions
+	endpoint := fmt.Sprintf("%s/drives/%s/items/%s/versions", c.baseURL, url.PathEscape(item.ParentReference.DriveID), url.PathEscape(item.ID))
+	if err := getJSON(ctx, c.client, endpoint, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	meta := &models.LinkMetadata{
+		Provider:    models.LinkProviderSharePoint,
+		Title:       strings.TrimSuffix(item.Name, path.Ext(item.Name)),
+		ContentHash: item.CTag,
+	}
+	if item.ListItem != nil {
+		if title, ok := item.ListItem.Fields["Title"].(string); ok && title != "" {
+			meta.Title = title
+		}
+	}
+	if len(versions.Value) > 0 {
+		meta.Version = versions.Value[0].ID
+	}
+	if item.File != nil {
+		if item.File.Hashes.SHA256Hash != "" {
+			meta.ContentHash = item.File.Hashes.SHA256Hash
+		} else if item.File.Hashes.QuickXorHash != "" {
+			meta.ContentHash = item.File.Hashes.QuickXorHash
+		}
+	}
+	if modified, err := time.Parse(time.RFC3339, item.LastModifiedDateTime); err == nil {
+		meta.ModifiedAt = &modified
+	}
+	return meta, nil
+}
+
+// item reads the drive item a link shares
+func (c *SharePointResolver) item(ctx context.Context, link *url.URL) (*sharePointItem, error) {
+	// Sharing URLs are addressed as u!<unpadded base64url of the URL>
+	shareID := "u!" + base64.RawURLEncoding.EncodeToString([]byte(link.String()))
+	query := url.Values{"$expand": {"listItem($expand=fields($select=Title))"}}
+	endpoint := fmt.Sprintf("%s/shares/%s/driveItem?%s", c.baseURL, shareID, query.Encode())
+
+	var item sharePointItem
+	if err := getJSON(ctx, c.client, endpoint, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+type graphSubscription struct {
+	ID                 string `json:"id,omitempty"`
+	ChangeType         string `json:"changeType"`
+	NotificationURL    string `json:"notificationUrl"`
+	Resource           string `json:"resource"`
+	ExpirationDateTime string `json:"expirationDateTime"`
+	ClientState        string `json:"clientState"`
+}
+
+// Watch subscribes to the changes of the drive holding the file: Graph only accepts subscriptions
+// on the root of a drive, a notification means that some file of the drive changed.
+// The application needs the Files.ReadWrite.All permission to subscribe.
+func (c *SharePointResolver) Watch(ctx context.Context, link *url.URL, notificationURL, clientState string) (*models.LinkSubscription, error) {
+	item, err := c.item(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+
+	request := graphSubscription{
+		ChangeType:         "updated",
+		NotificationURL:    notificationURL,
+		Resource:           fmt.Sprintf("/drives/%s/root", item.ParentReference.DriveID),
+		ExpirationDateTime: time.Now().Add(graphSubscriptionTTL).UTC().Format(time.RFC3339),
+		ClientState:        clientState,
+	}
+	var created graphSubscription
+	if err := sendJSON(ctx, c.client, http.MethodPost, c.baseURL+"/subscriptions", nil, request, &created); err != nil {
+		return nil, err
+	}
+
+	expires, err := time.Parse(time.RFC3339, created.ExpirationDateTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription expiration %q: %w", created.ExpirationDateTime, err)
+	}
+	return &models.LinkSubscription{
+		Provider:       models.LinkProviderSharePoint,
+		SubscriptionID: created.ID,
+		ClientState:    clientState,
+		ExpiresAt:      expires,
+	}, nil
+}
+
+// Unwatch deletes the subscription
+func (c *SharePointResolver) Unwatch(ctx context.Context, sub *models.LinkSubscription) error {
+	endpoint := fmt.Sprintf("%s/subscriptions/%s", c.baseURL, url.PathEscape(sub.SubscriptionID))
+	return sendJSON(ctx, c.client, http.MethodDelete, endpoint, nil, nil, nil)
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signaturehook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/webhook"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// EventValidate is the event sent to hooks before a signature is created
+	EventValidate = "signature.validate"
+
+	maxResponseSize = 64 * 1024
+)
+
+// HTTPDoer abstracts http.Client for testing
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client calls the validation hooks of documents
+type Client struct {
+	http HTTPDoer
+	now  func() time.Time
+}
+
+// NewClient creates a hook client; a nil httpClient uses http.DefaultClient.
+// Each call is bounded by the timeout of its hook.
+func NewClient(httpClient HTTPDoer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{http: httpClient, now: time.Now}
+}
+
+type validateRequest struct {
+	Event     string    `json:"event"`
+	DocID     string    `json:"doc_id"`
+	UserEmail string    `json:"user_email"`
+	UserName  string    `json:"user_name,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Evaluate asks the hook whether user may sign the document. Requests are signed like webhooks
+// when the hook has a secret. Any answer other than a 2xx with a JSON decision is an error.
+func (c *Client) Evaluate(ctx context.Context, hook *models.SignatureHook, user *models.User) (*models.SignatureHookDecision, error) {
+	now := c.now()
+	body, err := json.Marshal(validateRequest{
+		Event:     EventValidate,
+		DocID:     hook.DocID,
+		UserEmail: user.NormalizedEmail(),
+		UserName:  user.Name,
+		Timestamp: now.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hook request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(hook.TimeoutMs)*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build hook request: %w", err)
+	}
+	eventID := uuid.NewString()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Ackify-SignatureHook/1.0")
+	req.Header.Set("X-Ackify-Event", EventValidate)
+	req.Header.Set("X-Ackify-Event-Id", eventID)
+	req.Header.Set("X-Ackify-Timestamp", strconv.FormatInt(now.Unix(), 10))
+	if hook.Secret != "" {
+		req.Header.Set("X-Ackify-Signature", "sha256="+webhook.ComputeSignature(hook.Secret, now.Unix(), eventID, EventValidate, body))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call signature hook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+		return nil, fmt.Errorf("signature hook returned status %d", resp.StatusCode)
+	}
+
+	var decision models.SignatureHookDecision
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&decision); err != nil {
+		return nil, fmt.Errorf("failed to decode hook response: %w", err)
+	}
+	return &decision, nil
+}
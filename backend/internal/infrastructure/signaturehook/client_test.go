@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signaturehook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/webhook"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+var testUser = &models.User{Sub: "sub-1", Email: "Alice@Example.com", Name: "Alice"}
+
+func TestClient_Evaluate(t *testing.T) {
+	var got validateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		ts, _ := strconv.ParseInt(r.Header.Get("X-Ackify-Timestamp"), 10, 64)
+		want := "sha256=" + webhook.ComputeSignature("s3cret", ts, r.Header.Get("X-Ackify-Event-Id"), EventValidate, body)
+		if r.Header.Get("X-Ackify-Signature") != want {
+			t.Errorf("unexpected signature %q", r.Header.Get("X-Ackify-Signature"))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"allow": false, "reason": "Training not completed"})
+	}))
+	defer server.Close()
+
+	hook := &models.SignatureHook{DocID: "doc-1", URL: server.URL, Secret: "s3cret", TimeoutMs: 1000}
+	decision, err := NewClient(server.Client()).Evaluate(context.Background(), hook, testUser)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if decision.Allow || decision.Reason != "Training not completed" {
+		t.Errorf("unexpected decision %+v", decision)
+	}
+	if got.Event != EventValidate || got.DocID != "doc-1" || got.UserEmail != "alice@example.com" {
+		t.Errorf("unexpected payload %+v", got)
+	}
+}
+
+func TestClient_Evaluate_Unsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Ackify-Signature") != "" {
+			t.Error("expected no signature without secret")
+		}
+		_, _ = w.Write([]byte(`{"allow": true}`))
+	}))
+	defer server.Close()
+
+	hook := &models.SignatureHook{DocID: "doc-1", URL: server.URL, TimeoutMs: 1000}
+	decision, err := NewClient(server.Client()).Evaluate(context.Background(), hook, testUser)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !decision.Allow {
+		t.Error("expected the signature to be allowed")
+	}
+}
+
+func TestClient_Evaluate_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"error status", func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}},
+		{"invalid body", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}},
+		{"timeout", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			hook := &models.SignatureHook{DocID: "doc-1", URL: server.URL, TimeoutMs: 100}
+			if _, err := NewClient(server.Client()).Evaluate(context.Background(), hook, testUser); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
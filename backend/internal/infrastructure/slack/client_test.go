@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_PostMessage(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected JSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := NewClient(server.Client()).PostMessage(context.Background(), server.URL, "All readers have confirmed"); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	if got["text"] != "All readers have confirmed" {
+		t.Errorf("unexpected payload %v", got)
+	}
+}
+
+func TestClient_PostMessage_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if err := NewClient(server.Client()).PostMessage(context.Background(), server.URL, "hello"); err == nil {
+		t.Fatal("expected error on non-2xx status")
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package timestamp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+)
+
+const (
+	tsaTimeout = 15 * time.Second
+
+	// maxResponseSize bounds the response read, tokens embed the TSA certificate chain
+	maxResponseSize = 1 << 20
+)
+
+// HTTPDoer abstracts http.Client for testing
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client requests RFC 3161 time-stamps from a time-stamping authority over HTTP
+type Client struct {
+	url  string
+	http HTTPDoer
+}
+
+// NewClient creates a client for the TSA at url; a nil httpClient uses a client with a 15s timeout
+func NewClient(url string, httpClient HTTPDoer) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: tsaTimeout}
+	}
+	return &Client{url: url, http: httpClient}
+}
+
+// Timestamp returns the DER time-stamp response for a SHA-256 digest, once checked to cover it
+func (c *Client) Timestamp(ctx context.Context, digest []byte) ([]byte, error) {
+	query, err := crypto.NewTimestampRequest(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build time-stamp request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/timestamp-query")
+	req.Header.Set("Accept", "application/timestamp-reply")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach time-stamping authority: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("time-stamping authority returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read time-stamp response: %w", err)
+	}
+
+	if _, err := crypto.ParseTimestampResponse(body, digest); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package timestamp
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+)
+
+func TestClient_Timestamp(t *testing.T) {
+	var contentType string
+	var query []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		query, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/timestamp-reply")
+		_, _ = w.Write([]byte("not a time-stamp response"))
+	}))
+	defer srv.Close()
+
+	digest := sha256.Sum256([]byte("manifest"))
+	_, err := NewClient(srv.URL, srv.Client()).Timestamp(context.Background(), digest[:])
+	if !errors.Is(err, crypto.ErrTimestampInvalid) {
+		t.Fatalf("expected ErrTimestampInvalid, got %v", err)
+	}
+	if contentType != "application/timestamp-query" || len(query) == 0 {
+		t.Errorf("expected a DER time-stamp query, got %q (%d bytes)", contentType, len(query))
+	}
+}
+
+func TestClient_TimestampErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	digest := sha256.Sum256([]byte("manifest"))
+	_, err := NewClient(srv.URL, srv.Client()).Timestamp(context.Background(), digest[:])
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected the status in the error, got %v", err)
+	}
+}
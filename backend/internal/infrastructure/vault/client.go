@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrLeaseNotRenewable is returned when Vault refuses to extend a lease, e.g. past its max TTL
+var ErrLeaseNotRenewable = errors.New("vault lease cannot be renewed")
+
+// Secret is the response of Vault to reads, logins and renewals
+type Secret struct {
+	LeaseID       string                 `json:"lease_id"`
+	LeaseDuration int                    `json:"lease_duration"`
+	Renewable     bool                   `json:"renewable"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *SecretAuth            `json:"auth"`
+}
+
+// TTL returns the lease duration of the secret
+func (s *Secret) TTL() time.Duration {
+	return time.Duration(s.LeaseDuration) * time.Second
+}
+
+// SecretAuth is the token issued by a login or extended by a renewal
+type SecretAuth struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// TTL returns the time to live of the token
+func (a *SecretAuth) TTL() time.Duration {
+	return time.Duration(a.LeaseDuration) * time.Second
+}
+
+// Client calls the Vault HTTP API with the token it holds. It only covers what Ackify needs:
+// AppRole login, token renewal, KV v2 reads, dynamic secrets and lease renewal.
+type Client struct {
+	http      *http.Client
+	addr      string
+	namespace string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// NewClient creates a Vault client for the server at addr, in the namespace when set
+func NewClient(httpClient *http.Client, addr, namespace string) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Client{http: httpClient, addr: strings.TrimSuffix(addr, "/"), namespace: namespace}
+}
+
+// SetToken sets the token sent with the requests
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+}
+
+// LoginAppRole logs in with an AppRole and keeps the issued token
+func (c *Client) LoginAppRole(ctx context.Context, mount, roleID, secretID string) (*SecretAuth, error) {
+	body := map[string]string{"role_id": roleID, "secret_id": secretID}
+	secret, err := c.do(ctx, http.MethodPost, "auth/"+mount+"/login", body, false)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, errors.New("vault login returned no token")
+	}
+	c.SetToken(secret.Auth.ClientToken)
+	return secret.Auth, nil
+}
+
+// LookupSelf returns the remaining time to live of the token and whether it is renewable.
+// Root tokens have no TTL.
+func (c *Client) LookupSelf(ctx context.Context) (time.Duration, bool, error) {
+	secret, err := c.do(ctx, http.MethodGet, "auth/token/lookup-self", nil, true)
+	if err != nil {
+		return 0, false, err
+	}
+	ttl, _ := secret.Data["ttl"].(float64)
+	renewable, _ := secret.Data["renewable"].(bool)
+	return time.Duration(ttl) * time.Second, renewable, nil
+}
+
+// RenewSelf extends the token by its default increment
+func (c *Client) RenewSelf(ctx context.Context) (*SecretAuth, error) {
+	secret, err := c.do(ctx, http.MethodPost, "auth/token/renew-self", map[string]string{}, true)
+	if err != nil {
+		return nil, err
+	}
+	if secret.Auth == nil {
+		return nil, errors.New("vault token renewal returned no token")
+	}
+	return secret.Auth, nil
+}
+
+// ReadKV reads the latest version of a KV v2 secret. Values other than strings are skipped.
+func (c *Client) ReadKV(ctx context.Context, mount, path string) (map[string]string, error) {
+	secret, err := c.do(ctx, http.MethodGet, mount+"/data/"+path, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := secret.Data["data"].(map[string]interface{})
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		if s, ok := value.(string); ok {
+			values[key] = s
+		}
+	}
+	return values, nil
+}
+
+// Read reads a secret, such as the dynamic credentials of a database role
+func (c *Client) Read(ctx context.Context, path string) (*Secret, error) {
+	return c.do(ctx, http.MethodGet, path, nil, true)
+}
+
+// RenewLease extends a lease by increment. Vault may grant less, down to nothing past the max
+// TTL of the lease: ErrLeaseNotRenewable is returned when the lease is not extended at all.
+func (c *Client) RenewLease(ctx context.Context, leaseID string, increment time.Duration) (*Secret, error) {
+	body := map[string]interface{}{"lease_id": leaseID, "increment": int(increment.Seconds())}
+	secret, err := c.do(ctx, http.MethodPut, "sys/leases/renew", body, true)
+	if err != nil {
+		return nil, err
+	}
+	if secret.LeaseDuration <= 0 {
+		return nil, ErrLeaseNotRenewable
+	}
+	return secret, nil
+}
+
+// do sends a request to the API; Vault errors are returned with their messages
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, authenticated bool) (*Secret, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.addr+"/v1/"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	if authenticated {
+		c.mu.RLock()
+		req.Header.Set("X-Vault-Token", c.token)
+		c.mu.RUnlock()
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(raw, &vaultErr)
+		return nil, fmt.Errorf("vault %s %s: status %d: %s", method, path, resp.StatusCode, strings.Join(vaultErr.Errors, "; "))
+	}
+
+	secret := &Secret{}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, secret); err != nil {
+			return nil, fmt.Errorf("failed to decode vault response: %w", err)
+		}
+	}
+	return secret, nil
+}
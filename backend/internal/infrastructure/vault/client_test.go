@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_ReadKV(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/ackify/prod" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Vault-Token") != "s.token" || r.Header.Get("X-Vault-Namespace") != "team" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"ACKIFY_MAIL_PASSWORD":"smtp","port":25},"metadata":{"version":3}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL+"/", "team")
+	client.SetToken("s.token")
+	values, err := client.ReadKV(context.Background(), "secret", "ackify/prod")
+	if err != nil {
+		t.Fatalf("ReadKV failed: %v", err)
+	}
+	if len(values) != 1 || values["ACKIFY_MAIL_PASSWORD"] != "smtp" {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/sys/leases/renew" {
+			_, _ = w.Write([]byte(`{"lease_id":"database/creds/ackify/abc","lease_duration":0}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), server.URL, "")
+	_, err := client.Read(context.Background(), "database/creds/ackify")
+	if err == nil || !strings.Contains(err.Error(), "status 403: permission denied") {
+		t.Errorf("expected the Vault error, got %v", err)
+	}
+
+	_, err = client.RenewLease(context.Background(), "database/creds/ackify/abc", time.Hour)
+	if !errors.Is(err, ErrLeaseNotRenewable) {
+		t.Errorf("expected ErrLeaseNotRenewable, got %v", err)
+	}
+}
@@ -0,0 +1,351 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// DatabasePasswordKey is the KV key of the database password, set in the DSN rather than exported
+const DatabasePasswordKey = "ACKIFY_DB_PASSWORD"
+
+// exportedSecrets are the ACKIFY_* secrets of the KV secret exported to the environment.
+// Other keys are ignored, so the secret cannot change settings that are not secrets.
+var exportedSecrets = map[string]bool{
+	"ACKIFY_OAUTH_CLIENT_SECRET":           true,
+	"ACKIFY_OAUTH_COOKIE_SECRET":           true,
+	"ACKIFY_OAUTH_PREVIOUS_COOKIE_SECRETS": true,
+	"ACKIFY_MAIL_PASSWORD":                 true,
+	"ACKIFY_STORAGE_S3_SECRET_KEY":         true,
+}
+
+// checkInterval is how often leases are checked; they are renewed once two thirds of their
+// TTL have elapsed, and database credentials are replaced when less than two checks remain
+const checkInterval = 30 * time.Second
+
+// lease tracks the time to live of a token or of dynamic credentials
+type lease struct {
+	id        string
+	ttl       time.Duration
+	renewable bool
+	obtained  time.Time
+}
+
+func (l lease) due(now time.Time) bool {
+	return l.ttl > 0 && now.Sub(l.obtained) >= l.ttl*2/3
+}
+
+// Manager reads the secrets of Ackify from Vault and keeps them valid: it renews its token
+// and the lease of the database credentials, replaces the credentials when the lease reaches
+// its max TTL, and reads the KV secret again to pass rotated secrets to its listeners.
+type Manager struct {
+	client *Client
+	cfg    config.VaultConfig
+
+	mu          sync.RWMutex
+	secrets     map[string]string
+	dbUser      string
+	dbPassword  string
+	token       lease
+	dbLease     lease
+	dbIncrement time.Duration
+	refreshedAt time.Time
+	listeners   []func(map[string]string)
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewManager creates a manager reading the secrets configured in cfg
+func NewManager(client *Client, cfg config.VaultConfig) *Manager {
+	return &Manager{client: client, cfg: cfg, secrets: map[string]string{}}
+}
+
+// Bootstrap logs in and reads the secrets. It fails when any of them cannot be read, so that
+// the server never starts with missing secrets.
+func (m *Manager) Bootstrap(ctx context.Context) error {
+	now := time.Now()
+	if err := m.login(ctx, now); err != nil {
+		return err
+	}
+	if m.cfg.KVPath != "" {
+		if _, err := m.refreshKV(ctx, now); err != nil {
+			return err
+		}
+	}
+	if m.cfg.DatabaseCredsPath != "" {
+		if err := m.readDatabaseCredentials(ctx, now); err != nil {
+			return err
+		}
+	}
+	logger.Logger.Info("Secrets loaded from Vault",
+		"kv_path", m.cfg.KVPath,
+		"database_creds_path", m.cfg.DatabaseCredsPath)
+	return nil
+}
+
+// ExportEnv sets the ACKIFY_* secrets of the KV secret in the environment, read by
+// config.Load. Vault values override those of the environment.
+func (m *Manager) ExportEnv() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for key, value := range m.secrets {
+		if !exportedSecrets[key] {
+			continue
+		}
+		if os.Getenv(key) != "" {
+			logger.Logger.Info("Vault secret overrides the environment", "variable", key)
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to export %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Secrets returns the ACKIFY_* secrets of the KV secret
+func (m *Manager) Secrets() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	secrets := make(map[string]string, len(m.secrets))
+	for key, value := range m.secrets {
+		secrets[key] = value
+	}
+	return secrets
+}
+
+// OnChange registers a function called with the secrets when the KV secret changes
+func (m *Manager) OnChange(fn func(secrets map[string]string)) {
+	m.mu.Lock()
+	m.listeners = append(m.listeners, fn)
+	m.mu.Unlock()
+}
+
+// HasDatabaseCredentials reports whether the database credentials come from Vault
+func (m *Manager) HasDatabaseCredentials() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.dbPassword != ""
+}
+
+// DatabaseDSN returns dsn with the current database credentials: the dynamic ones, or the
+// password of the KV secret. New connections call it, so that they use rotated credentials.
+func (m *Manager) DatabaseDSN(dsn string) (string, error) {
+	m.mu.RLock()
+	user, password := m.dbUser, m.dbPassword
+	m.mu.RUnlock()
+
+	if password == "" {
+		return dsn, nil
+	}
+	return WithCredentials(dsn, user, password)
+}
+
+// WithCredentials sets the user, when not empty, and the password of a PostgreSQL DSN given
+// as a URL or as key=value pairs
+func WithCredentials(dsn, user, password string) (string, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("invalid database DSN: %w", err)
+		}
+		if user == "" && u.User != nil {
+			user = u.User.Username()
+		}
+		u.User = url.UserPassword(user, password)
+		return u.String(), nil
+	}
+
+	// Later pairs override earlier ones
+	quote := func(value string) string {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+	}
+	if user != "" {
+		dsn += " user=" + quote(user)
+	}
+	return dsn + " password=" + quote(password), nil
+}
+
+// Start renews the leases and refreshes the secrets until Stop is called
+func (m *Manager) Start(ctx context.Context) {
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.stop:
+				return
+			case now := <-ticker.C:
+				m.renew(ctx, now)
+			}
+		}
+	}()
+}
+
+// Stop stops the renewals. Dynamic credentials are left to expire with their lease.
+func (m *Manager) Stop() {
+	if m.stop == nil {
+		return
+	}
+	close(m.stop)
+	<-m.done
+	m.stop = nil
+}
+
+// renew renews what is due: the token, the database lease and the KV secret
+func (m *Manager) renew(ctx context.Context, now time.Time) {
+	if err := m.renewToken(ctx, now); err != nil {
+		logger.Logger.Error("Failed to renew the Vault token", "error", err.Error())
+	}
+	if err := m.renewDatabaseLease(ctx, now); err != nil {
+		logger.Logger.Error("Failed to renew the Vault database credentials", "error", err.Error())
+	}
+	if m.cfg.KVPath == "" || now.Sub(m.refreshedAt) < m.cfg.RefreshInterval {
+		return
+	}
+	changed, err := m.refreshKV(ctx, now)
+	if err != nil {
+		logger.Logger.Error("Failed to refresh the Vault secrets", "error", err.Error())
+		return
+	}
+	if changed {
+		logger.Logger.Info("Vault secrets changed, applying them", "kv_path", m.cfg.KVPath)
+		m.mu.RLock()
+		listeners := append([]func(map[string]string){}, m.listeners...)
+		m.mu.RUnlock()
+		secrets := m.Secrets()
+		for _, fn := range listeners {
+			fn(secrets)
+		}
+	}
+}
+
+// login authenticates with the AppRole, or checks the configured token
+func (m *Manager) login(ctx context.Context, now time.Time) error {
+	if m.cfg.RoleID != "" {
+		auth, err := m.client.LoginAppRole(ctx, m.cfg.AppRoleMount, m.cfg.RoleID, m.cfg.SecretID)
+		if err != nil {
+			return fmt.Errorf("failed to log in to vault: %w", err)
+		}
+		m.token = lease{ttl: auth.TTL(), renewable: auth.Renewable, obtained: now}
+		return nil
+	}
+
+	m.client.SetToken(m.cfg.Token)
+	ttl, renewable, err := m.client.LookupSelf(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to look up vault token: %w", err)
+	}
+	m.token = lease{ttl: ttl, renewable: renewable, obtained: now}
+	return nil
+}
+
+// renewToken renews the token once due. An AppRole logs in again when its token cannot be
+// renewed; a configured token that expires has to be replaced by the operator.
+func (m *Manager) renewToken(ctx context.Context, now time.Time) error {
+	if !m.token.due(now) {
+		return nil
+	}
+	if m.token.renewable {
+		auth, err := m.client.RenewSelf(ctx)
+		if err == nil {
+			m.token = lease{ttl: auth.TTL(), renewable: auth.Renewable, obtained: now}
+			return nil
+		}
+		if m.cfg.RoleID == "" {
+			return err
+		}
+		logger.Logger.Warn("Vault token renewal failed, logging in again", "error", err.Error())
+	} else if m.cfg.RoleID == "" {
+		return errors.New("vault token is not renewable and expires soon")
+	}
+	return m.login(ctx, now)
+}
+
+// renewDatabaseLease extends the lease of the database credentials once due, and reads new
+// credentials when Vault no longer extends it
+func (m *Manager) renewDatabaseLease(ctx context.Context, now time.Time) error {
+	if m.dbLease.id == "" || !m.dbLease.due(now) {
+		return nil
+	}
+	if m.dbLease.renewable {
+		secret, err := m.client.RenewLease(ctx, m.dbLease.id, m.dbIncrement)
+		if err == nil && secret.TTL() >= 2*checkInterval {
+			m.dbLease.ttl, m.dbLease.obtained = secret.TTL(), now
+			return nil
+		}
+		if err != nil && !errors.Is(err, ErrLeaseNotRenewable) {
+			logger.Logger.Warn("Vault database lease renewal failed, reading new credentials", "error", err.Error())
+		}
+	}
+	return m.readDatabaseCredentials(ctx, now)
+}
+
+// readDatabaseCredentials reads new dynamic database credentials
+func (m *Manager) readDatabaseCredentials(ctx context.Context, now time.Time) error {
+	secret, err := m.client.Read(ctx, m.cfg.DatabaseCredsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database credentials: %w", err)
+	}
+	user, _ := secret.Data["username"].(string)
+	password, _ := secret.Data["password"].(string)
+	if user == "" || password == "" {
+		return errors.New("vault returned no database credentials")
+	}
+
+	m.mu.Lock()
+	m.dbUser, m.dbPassword = user, password
+	m.mu.Unlock()
+	m.dbLease = lease{id: secret.LeaseID, ttl: secret.TTL(), renewable: secret.Renewable, obtained: now}
+	m.dbIncrement = secret.TTL()
+
+	logger.Logger.Info("Database credentials read from Vault", "user", user, "ttl", secret.TTL())
+	return nil
+}
+
+// refreshKV reads the KV secret and reports whether the secrets changed
+func (m *Manager) refreshKV(ctx context.Context, now time.Time) (bool, error) {
+	values, err := m.client.ReadKV(ctx, m.cfg.KVMount, m.cfg.KVPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read secret %s/%s: %w", m.cfg.KVMount, m.cfg.KVPath, err)
+	}
+
+	secrets := make(map[string]string)
+	for key, value := range values {
+		if exportedSecrets[key] || key == DatabasePasswordKey {
+			secrets[key] = value
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshedAt = now
+	changed := len(secrets) != len(m.secrets)
+	for key, value := range secrets {
+		if m.secrets[key] != value {
+			changed = true
+		}
+	}
+	m.secrets = secrets
+	if m.cfg.DatabaseCredsPath == "" {
+		m.dbPassword = secrets[DatabasePasswordKey]
+	}
+	return changed, nil
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+)
+
+// fakeVault serves an AppRole login, a KV v2 secret and dynamic database credentials
+type fakeVault struct {
+	mu           sync.Mutex
+	kv           map[string]string
+	credsRead    int
+	leaseRenewed int
+	renewTTL     int
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var response interface{}
+	switch r.URL.Path {
+	case "/v1/auth/approle/login":
+		response = map[string]interface{}{"auth": map[string]interface{}{"client_token": "s.approle", "lease_duration": 3600, "renewable": true}}
+	case "/v1/secret/data/ackify":
+		response = map[string]interface{}{"data": map[string]interface{}{"data": f.kv}}
+	case "/v1/database/creds/ackify":
+		f.credsRead++
+		response = map[string]interface{}{
+			"lease_id": "database/creds/ackify/lease", "lease_duration": 3600, "renewable": true,
+			"data": map[string]interface{}{"username": "v-ackify-" + string(rune('0'+f.credsRead)), "password": "pw"},
+		}
+	case "/v1/sys/leases/renew":
+		f.leaseRenewed++
+		response = map[string]interface{}{"lease_id": "database/creds/ackify/lease", "lease_duration": f.renewTTL, "renewable": true}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.URL.Path != "/v1/auth/approle/login" && r.Header.Get("X-Vault-Token") != "s.approle" {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func newTestManager(t *testing.T, fake *fakeVault) *Manager {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	manager := NewManager(NewClient(server.Client(), server.URL, ""), config.VaultConfig{
+		Addr:              server.URL,
+		RoleID:            "role",
+		SecretID:          "secret",
+		AppRoleMount:      "approle",
+		KVMount:           "secret",
+		KVPath:            "ackify",
+		DatabaseCredsPath: "database/creds/ackify",
+		RefreshInterval:   5 * time.Minute,
+	})
+	if err := manager.Bootstrap(context.Background()); err != nil {
+		t.Fatalf("Bootstrap failed: %v", err)
+	}
+	return manager
+}
+
+func TestManager_Bootstrap(t *testing.T) {
+	fake := &fakeVault{kv: map[string]string{
+		"ACKIFY_OAUTH_CLIENT_SECRET": "vault-client-secret",
+		"ACKIFY_BASE_URL":            "https://evil.example.com",
+	}}
+	manager := newTestManager(t, fake)
+
+	if secrets := manager.Secrets(); len(secrets) != 1 || secrets["ACKIFY_OAUTH_CLIENT_SECRET"] != "vault-client-secret" {
+		t.Errorf("expected only the allowed secrets, got %v", secrets)
+	}
+
+	t.Setenv("ACKIFY_OAUTH_CLIENT_SECRET", "env-client-secret")
+	t.Setenv("ACKIFY_BASE_URL", "https://ackify.example.com")
+	if err := manager.ExportEnv(); err != nil {
+		t.Fatalf("ExportEnv failed: %v", err)
+	}
+	if got := os.Getenv("ACKIFY_OAUTH_CLIENT_SECRET"); got != "vault-client-secret" {
+		t.Errorf("expected Vault to override the environment, got %q", got)
+	}
+	if got := os.Getenv("ACKIFY_BASE_URL"); got != "https://ackify.example.com" {
+		t.Errorf("expected other variables to be kept, got %q", got)
+	}
+
+	dsn, err := manager.DatabaseDSN("postgres://ackify:old@db:5432/ackify?sslmode=disable")
+	if err != nil {
+		t.Fatalf("DatabaseDSN failed: %v", err)
+	}
+	if dsn != "postgres://v-ackify-1:pw@db:5432/ackify?sslmode=disable" {
+		t.Errorf("unexpected DSN %q", dsn)
+	}
+}
+
+func TestWithCredentials(t *testing.T) {
+	dsn, err := WithCredentials("host=db dbname=ackify user=ackify", "", "it's")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dsn != `host=db dbname=ackify user=ackify password='it\'s'` {
+		t.Errorf("unexpected DSN %q", dsn)
+	}
+
+	dsn, err = WithCredentials("postgresql://ackify@db/ackify", "", "p@ss")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dsn != "postgresql://ackify:p%40ss@db/ackify" {
+		t.Errorf("unexpected DSN %q", dsn)
+	}
+}
+
+func TestManager_Renew(t *testing.T) {
+	fake := &fakeVault{kv: map[string]string{"ACKIFY_MAIL_PASSWORD": "smtp-1"}, renewTTL: 3600}
+	manager := newTestManager(t, fake)
+
+	var changes []map[string]string
+	manager.OnChange(func(secrets map[string]string) { changes = append(changes, secrets) })
+
+	start := manager.dbLease.obtained
+
+	// Nothing is due yet
+	manager.renew(context.Background(), start.Add(time.Minute))
+	if fake.leaseRenewed != 0 || len(changes) != 0 {
+		t.Fatalf("expected nothing to be renewed, got %d renewals and %d changes", fake.leaseRenewed, len(changes))
+	}
+
+	// The lease is renewed at two thirds of its TTL; the KV secret is read again and changed
+	fake.kv["ACKIFY_MAIL_PASSWORD"] = "smtp-2"
+	manager.renew(context.Background(), start.Add(41*time.Minute))
+	if fake.leaseRenewed != 1 || fake.credsRead != 1 {
+		t.Errorf("expected the lease to be renewed, got %d renewals and %d reads", fake.leaseRenewed, fake.credsRead)
+	}
+	if len(changes) != 1 || changes[0]["ACKIFY_MAIL_PASSWORD"] != "smtp-2" {
+		t.Errorf("expected listeners to receive the rotated secret, got %v", changes)
+	}
+
+	// Past its max TTL the lease is not extended: new credentials are read
+	fake.renewTTL = 0
+	manager.renew(context.Background(), start.Add(82*time.Minute))
+	if fake.credsRead != 2 {
+		t.Errorf("expected new credentials, got %d reads", fake.credsRead)
+	}
+	dsn, _ := manager.DatabaseDSN("postgres://ackify@db/ackify")
+	if dsn != "postgres://v-ackify-2:pw@db/ackify" {
+		t.Errorf("expected the new credentials in the DSN, got %q", dsn)
+	}
+}
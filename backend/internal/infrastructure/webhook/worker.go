@@ -27,6 +27,7 @@ type DeliveryRepository interface {
 	GetRetryable(ctx context.Context, limit int) ([]*database.WebhookDeliveryItem, error)
 	MarkDelivered(ctx context.Context, id int64, responseStatus int, responseHeaders map[string]string, responseBody string) error
 	MarkFailed(ctx context.Context, id int64, err error, shouldRetry bool) error
+	ReleaseToPending(ctx context.Context, ids []int64) (int64, error)
 	CleanupOld(ctx context.Context, olderThan time.Duration) (int64, error)
 }
 
@@ -112,7 +113,7 @@ func (w *Worker) Stop() error {
 		return nil
 	}
 	w.mu.Unlock()
-	w.cancel()
+	// In-flight deliveries complete, the rest of the batch is released to the queue
 	close(w.stopChan)
 	done := make(chan struct{})
 	go func() { w.wg.Wait(); close(done) }()
@@ -121,6 +122,7 @@ func (w *Worker) Stop() error {
 	case <-time.After(30 * time.Second):
 		logger.Logger.Warn("Webhook worker stop timeout")
 	}
+	w.cancel()
 	w.mu.Lock()
 	w.started = false
 	w.mu.Unlock()
@@ -236,7 +238,11 @@ func (w *Worker) processBatch() {
 
 	sem := make(chan struct{}, w.cfg.MaxConcurrent)
 	var wg sync.WaitGroup
-	for _, it := range items {
+	for i, it := range items {
+		if w.stopping() {
+			w.release(ctx, items[i:])
+			break
+		}
 		wg.Add(1)
 		sem <- struct{}{}
 		go func(item *database.WebhookDeliveryItem) {
@@ -263,6 +269,42 @@ func (w *Worker) processBatch() {
 	wg.Wait()
 }
 
+// stopping reports whether Stop was called
+func (w *Worker) stopping() bool {
+	select {
+	case <-w.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// release puts deliveries of an interrupted batch back in the queue
+func (w *Worker) release(ctx context.Context, items []*database.WebhookDeliveryItem) {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	var released int64
+	var err error
+	if w.db != nil && w.tenants != nil {
+		tenantID, _ := w.tenants.CurrentTenant(ctx)
+		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			var releaseErr error
+			released, releaseErr = w.repo.ReleaseToPending(txCtx, ids)
+			return releaseErr
+		})
+	} else {
+		released, err = w.repo.ReleaseToPending(ctx, ids)
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to release webhook deliveries", "count", len(ids), "error", err.Error())
+		return
+	}
+	logger.Logger.Info("Released unsent webhook deliveries", "count", released)
+}
+
 func (w *Worker) processOne(ctx context.Context, item *database.WebhookDeliveryItem) {
 	// Build request
 	reqBody := strings.NewReader(string(item.Payload))
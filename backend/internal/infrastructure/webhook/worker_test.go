@@ -50,6 +50,7 @@ func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) { return f.resp
 type fakeDelRepo struct {
 	delivered int
 	failed    int
+	released  []int64
 }
 
 func (f *fakeDelRepo) GetNextToProcess(ctx context.Context, limit int) ([]*database.WebhookDeliveryItem, error) {
@@ -66,6 +67,10 @@ func (f *fakeDelRepo) MarkFailed(ctx context.Context, id int64, err error, shoul
 	f.failed++
 	return nil
 }
+func (f *fakeDelRepo) ReleaseToPending(ctx context.Context, ids []int64) (int64, error) {
+	f.released = append(f.released, ids...)
+	return int64(len(ids)), nil
+}
 func (f *fakeDelRepo) CleanupOld(ctx context.Context, olderThan time.Duration) (int64, error) {
 	return 0, nil
 }
@@ -80,3 +85,15 @@ func TestWorker_ProcessBatch_Success(t *testing.T) {
 		t.Fatalf("expected delivered=1, got %d", repo.delivered)
 	}
 }
+
+func TestWorker_ProcessBatch_ReleasesWhenStopping(t *testing.T) {
+	repo := &fakeDelRepo{}
+	doer := &fakeDoer{resp: &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{}}}
+	tenants := &mockTenantProviderWebhook{tenantID: uuid.New()}
+	w := NewWorker(repo, doer, DefaultWorkerConfig(), context.Background(), nil, tenants)
+	close(w.stopChan)
+	w.processBatch()
+	if repo.delivered != 0 || len(repo.released) != 1 || repo.released[0] != 1 {
+		t.Fatalf("expected delivery released without sending, got delivered=%d released=%v", repo.delivered, repo.released)
+	}
+}
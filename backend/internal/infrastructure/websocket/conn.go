@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// acceptGUID is the fixed GUID of the opening handshake (RFC 6455, section 1.3)
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxControlPayload is the largest payload allowed in a control frame
+const maxControlPayload = 125
+
+// maxClientMessage bounds the size of messages read from clients, which only send control frames
+const maxClientMessage = 4096
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// Close status codes (RFC 6455, section 7.4)
+const (
+	// StatusNormalClosure ends the connection because its purpose is fulfilled
+	StatusNormalClosure uint16 = 1000
+	// StatusServiceRestart asks the client to reconnect, as the server is restarting
+	StatusServiceRestart uint16 = 1012
+)
+
+var (
+	// ErrNotWebSocket is returned when the request is not a WebSocket opening handshake
+	ErrNotWebSocket = errors.New("not a websocket handshake")
+	// ErrOriginNotAllowed is returned when the Origin header does not match the allowed origin
+	ErrOriginNotAllowed = errors.New("websocket origin not allowed")
+	// ErrClosed is returned when writing to a closed connection
+	ErrClosed = errors.New("websocket connection closed")
+)
+
+// Conn is a server-side WebSocket connection limited to what push channels need:
+// sending text messages and answering the client's control frames.
+type Conn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+
+	writeMu sync.Mutex
+	closed  bool
+}
+
+// Upgrade validates the opening handshake and takes over the HTTP connection.
+// When allowedOrigin is set, browsers must connect from that origin (protection against
+// cross-site WebSocket hijacking). Errors returned before the hijack leave the response
+// untouched so the caller can write an HTTP error.
+func Upgrade(w http.ResponseWriter, r *http.Request, allowedOrigin string) (*Conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContainsToken(r.Header, "Connection", "upgrade") ||
+		!headerContainsToken(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" {
+		return nil, ErrNotWebSocket
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return nil, ErrNotWebSocket
+	}
+
+	if origin := r.Header.Get("Origin"); origin != "" && allowedOrigin != "" && !sameOrigin(origin, allowedOrigin) {
+		return nil, ErrOriginNotAllowed
+	}
+
+	netConn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+
+	// Deadlines set by the HTTP server no longer apply to the long-lived connection
+	_ = netConn.SetDeadline(time.Time{})
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + AcceptKey(key) + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+	if err := rw.Flush(); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+
+	return &Conn{conn: netConn, rw: rw}, nil
+}
+
+// AcceptKey computes the Sec-WebSocket-Accept value for a client key
+func AcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// WriteJSON sends v as a JSON text message
+func (c *Conn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	return c.writeFrame(opText, data)
+}
+
+// Ping sends a ping control frame; browsers answer automatically
+func (c *Conn) Ping() error {
+	return c.writeFrame(opPing, nil)
+}
+
+// Close sends a normal closure frame and closes the underlying connection
+func (c *Conn) Close() error {
+	return c.CloseWithStatus(StatusNormalClosure, "")
+}
+
+// CloseWithStatus sends a close frame with a status code and reason, then closes the
+// underlying connection. Closing an already closed connection is a no-op.
+func (c *Conn) CloseWithStatus(code uint16, reason string) error {
+	if len(reason) > maxControlPayload-2 {
+		reason = reason[:maxControlPayload-2]
+	}
+	_ = c.writeFrame(opClose, append(binary.BigEndian.AppendUint16(nil, code), reason...))
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.conn.Close()
+}
+
+// ReadLoop consumes client frames until the connection is closed.
+// Pings are answered and data messages are discarded: the channel is server-push only.
+func (c *Conn) ReadLoop() error {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return err
+		}
+		switch opcode {
+		case opClose:
+			return nil
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	header := []byte{0x80 | opcode, 0}
+	switch n := len(payload); {
+	case n <= 125:
+		header[1] = byte(n)
+	case n <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// readFrame reads one complete message, reassembling fragmented frames
+func (c *Conn) readFrame() (byte, []byte, error) {
+	var message []byte
+	var messageOpcode byte
+
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+			return 0, nil, err
+		}
+		fin := head[0]&0x80 != 0
+		opcode := head[0] & 0x0F
+		masked := head[1]&0x80 != 0
+		length := uint64(head[1] & 0x7F)
+
+		if !masked {
+			return 0, nil, errors.New("client frames must be masked")
+		}
+
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+				return 0, nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+				return 0, nil, err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+
+		isControl := opcode&0x8 != 0
+		if isControl && (length > maxControlPayload || !fin) {
+			return 0, nil, errors.New("invalid control frame")
+		}
+		if uint64(len(message))+length > maxClientMessage {
+			return 0, nil, errors.New("client message too large")
+		}
+
+		var mask [4]byte
+		if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+			return 0, nil, err
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(c.rw, payload); err != nil {
+			return 0, nil, err
+		}
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+
+		// Control frames may be interleaved with the fragments of a message
+		if isControl {
+			return opcode, payload, nil
+		}
+
+		switch opcode {
+		case opText, opBinary:
+			messageOpcode = opcode
+			message = payload
+		case opContinuation:
+			message = append(message, payload...)
+		default:
+			return 0, nil, fmt.Errorf("unsupported opcode %d", opcode)
+		}
+
+		if fin {
+			return messageOpcode, message, nil
+		}
+	}
+}
+
+func headerContainsToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sameOrigin(origin, allowed string) bool {
+	o, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	a, err := url.Parse(allowed)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(o.Scheme, a.Scheme) && strings.EqualFold(o.Host, a.Host)
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package websocket
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKey = "dGhlIHNhbXBsZSBub25jZQ=="
+
+func TestAcceptKey(t *testing.T) {
+	// Example from RFC 6455, section 1.3
+	if got := AcceptKey(testKey); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("Unexpected accept key %s", got)
+	}
+}
+
+func TestUpgrade_RejectsInvalidHandshake(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr error
+	}{
+		{"plain request", map[string]string{}, ErrNotWebSocket},
+		{"missing key", map[string]string{"Connection": "Upgrade", "Upgrade": "websocket", "Sec-WebSocket-Version": "13"}, ErrNotWebSocket},
+		{"foreign origin", map[string]string{
+			"Connection": "keep-alive, Upgrade", "Upgrade": "websocket", "Sec-WebSocket-Version": "13",
+			"Sec-WebSocket-Key": testKey, "Origin": "https://evil.example",
+		}, ErrOriginNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			_, err := Upgrade(httptest.NewRecorder(), req, "https://ackify.example")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConn_PushAndControlFrames(t *testing.T) {
+	readDone := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, "https://ackify.example")
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		if err := conn.WriteJSON(map[string]string{"type": "hello"}); err != nil {
+			t.Errorf("WriteJSON failed: %v", err)
+		}
+		readDone <- conn.ReadLoop()
+		conn.Close()
+	}))
+	defer server.Close()
+
+	client, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer client.Close()
+	_ = client.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake := "GET /ws HTTP/1.1\r\nHost: ackify.example\r\nOrigin: https://ackify.example\r\n" +
+		"Connection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Key: " + testKey + "\r\n\r\n"
+	if _, err := client.Write([]byte(handshake)); err != nil {
+		t.Fatalf("Handshake write failed: %v", err)
+	}
+
+	reader := bufio.NewReader(client)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("Failed to read handshake response: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Expected 101, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != AcceptKey(testKey) {
+		t.Errorf("Unexpected accept header %s", resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+
+	opcode, payload := readServerFrame(t, reader)
+	if opcode != opText || string(payload) != `{"type":"hello"}` {
+		t.Errorf("Unexpected message %d %q", opcode, payload)
+	}
+
+	writeClientFrame(t, client, opPing, []byte("hi"))
+	opcode, payload = readServerFrame(t, reader)
+	if opcode != opPong || string(payload) != "hi" {
+		t.Errorf("Expected pong echoing the ping payload, got %d %q", opcode, payload)
+	}
+
+	writeClientFrame(t, client, opClose, nil)
+	select {
+	case err := <-readDone:
+		if err != nil {
+			t.Errorf("Expected clean close, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ReadLoop did not return after close frame")
+	}
+}
+
+func readServerFrame(t *testing.T, r io.Reader) (byte, []byte) {
+	t.Helper()
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		t.Fatalf("Failed to read frame: %v", err)
+	}
+	if head[1]&0x80 != 0 {
+		t.Fatal("Server frames must not be masked")
+	}
+	payload := make([]byte, head[1]&0x7F)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatalf("Failed to read payload: %v", err)
+	}
+	return head[0] & 0x0F, payload
+}
+
+func writeClientFrame(t *testing.T, w io.Writer, opcode byte, payload []byte) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := w.Write(frame); err != nil {
+		t.Fatalf("Failed to write frame: %v", err)
+	}
+}
+
+func TestConn_CloseWithStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Upgrade(w, r, "")
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		if err := conn.CloseWithStatus(StatusServiceRestart, "restarting"); err != nil {
+			t.Errorf("CloseWithStatus failed: %v", err)
+		}
+		if err := conn.Close(); err != nil {
+			t.Errorf("Second close should be a no-op, got %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer client.Close()
+	_ = client.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake := "GET /ws HTTP/1.1\r\nHost: ackify.example\r\n" +
+		"Connection: Upgrade\r\nUpgrade: websocket\r\nSec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Key: " + testKey + "\r\n\r\n"
+	if _, err := client.Write([]byte(handshake)); err != nil {
+		t.Fatalf("Handshake write failed: %v", err)
+	}
+
+	reader := bufio.NewReader(client)
+	if _, err := http.ReadResponse(reader, nil); err != nil {
+		t.Fatalf("Failed to read handshake response: %v", err)
+	}
+
+	opcode, payload := readServerFrame(t, reader)
+	if opcode != opClose || len(payload) < 2 {
+		t.Fatalf("Expected close frame, got %d %q", opcode, payload)
+	}
+	if code := uint16(payload[0])<<8 | uint16(payload[1]); code != StatusServiceRestart || string(payload[2:]) != "restarting" {
+		t.Errorf("Unexpected close status %d %q", code, payload[2:])
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// CampaignSchedulerWorker starts due recurring acknowledgement campaigns
+type CampaignSchedulerWorker struct {
+	service  *services.CampaignService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewCampaignSchedulerWorker(service *services.CampaignService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *CampaignSchedulerWorker {
+	if interval == 0 {
+		interval = 15 * time.Minute // Default: every 15 minutes
+	}
+
+	return &CampaignSchedulerWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *CampaignSchedulerWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *CampaignSchedulerWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Campaign scheduler worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "campaigns", w.runDue)
+		case <-w.stopChan:
+			logger.Logger.Info("Campaign scheduler worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Campaign scheduler worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *CampaignSchedulerWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Campaign scheduler worker stop timeout")
+	}
+}
+
+func (w *CampaignSchedulerWorker) runDue(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for campaign scheduler", "error", err)
+		return
+	}
+
+	var started int
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var runErr error
+		started, runErr = w.service.RunDueCampaigns(txCtx)
+		return runErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to run due campaigns", "error", err)
+		return
+	}
+
+	if started > 0 {
+		logger.Logger.Info("Started due campaigns", "count", started)
+	}
+}
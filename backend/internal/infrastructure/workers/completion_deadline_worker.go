@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// CompletionDeadlineWorker notifies document creators when a document deadline is reached
+type CompletionDeadlineWorker struct {
+	service  *services.CompletionNotificationService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewCompletionDeadlineWorker(service *services.CompletionNotificationService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *CompletionDeadlineWorker {
+	if interval == 0 {
+		interval = 15 * time.Minute // Default: every 15 minutes
+	}
+
+	return &CompletionDeadlineWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *CompletionDeadlineWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *CompletionDeadlineWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Completion deadline worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "completion_deadlines", w.check)
+		case <-w.stopChan:
+			logger.Logger.Info("Completion deadline worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Completion deadline worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *CompletionDeadlineWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Completion deadline worker stop timeout")
+	}
+}
+
+func (w *CompletionDeadlineWorker) check(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for completion deadline worker", "error", err)
+		return
+	}
+
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		return w.service.RunDeadlineChecks(txCtx)
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to check document deadlines", "error", err)
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// CompletionSnapshotWorker records the signing progress of documents once a day.
+// It checks every interval, so a restart or downtime only delays the day's snapshot.
+type CompletionSnapshotWorker struct {
+	service  *services.CompletionHistoryService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewCompletionSnapshotWorker(service *services.CompletionHistoryService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *CompletionSnapshotWorker {
+	if interval == 0 {
+		interval = 1 * time.Hour // Default: every hour
+	}
+
+	return &CompletionSnapshotWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *CompletionSnapshotWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *CompletionSnapshotWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Completion snapshot worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "completion_snapshots", w.snapshot)
+		case <-w.stopChan:
+			logger.Logger.Info("Completion snapshot worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Completion snapshot worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *CompletionSnapshotWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Completion snapshot worker stop timeout")
+	}
+}
+
+func (w *CompletionSnapshotWorker) snapshot(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for completion snapshot worker", "error", err)
+		return
+	}
+
+	var created int
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var runErr error
+		created, runErr = w.service.TakeDailySnapshot(txCtx)
+		return runErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to record completion snapshots", "error", err)
+		return
+	}
+
+	if created > 0 {
+		logger.Logger.Info("Recorded completion snapshots", "count", created)
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// complianceReportBatch bounds the reports generated per tick, so that Stop is not held up
+const complianceReportBatch = 5
+
+// ComplianceReportWorker generates the requested compliance reports and purges the old ones.
+// Reports are claimed with SKIP LOCKED, so the worker runs on every instance, like the email worker.
+type ComplianceReportWorker struct {
+	service   *services.ComplianceReportService
+	interval  time.Duration
+	lastPurge time.Time
+	stopChan  chan struct{}
+	done      chan struct{} // closed when Start returns
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewComplianceReportWorker(service *services.ComplianceReportService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *ComplianceReportWorker {
+	if interval == 0 {
+		interval = 10 * time.Second // Default: reports ready within seconds
+	}
+
+	return &ComplianceReportWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+func (w *ComplianceReportWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Compliance report worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-w.stopChan:
+			logger.Logger.Info("Compliance report worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Compliance report worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the report in progress to finish
+func (w *ComplianceReportWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Compliance report worker stop timeout")
+	}
+}
+
+// process generates each pending report in its own transaction, then purges old reports once a day
+func (w *ComplianceReportWorker) process(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for compliance report worker", "error", err)
+		return
+	}
+
+	for i := 0; i < complianceReportBatch; i++ {
+		var processed bool
+		err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			var runErr error
+			processed, runErr = w.service.ProcessNext(txCtx)
+			return runErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to process compliance report", "error", err)
+			return
+		}
+		if !processed {
+			break
+		}
+	}
+
+	if time.Since(w.lastPurge) < 24*time.Hour {
+		return
+	}
+	w.lastPurge = time.Now()
+	var deleted int
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var purgeErr error
+		deleted, purgeErr = w.service.PurgeExpired(txCtx)
+		return purgeErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to purge compliance reports", "error", err)
+		return
+	}
+	if deleted > 0 {
+		logger.Logger.Info("Purged compliance reports", "count", deleted)
+	}
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+const (
+	// schedulerLease is the lease all scheduled jobs run under
+	schedulerLease = "scheduler"
+
+	// jobRunRetention is how long the runs of scheduled jobs are kept
+	jobRunRetention = 30 * 24 * time.Hour
+)
+
+// leaseStore persists the scheduler lease and the bookkeeping of job runs
+type leaseStore interface {
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+	StartRun(ctx context.Context, job, holder string) (int64, error)
+	FinishRun(ctx context.Context, id int64, status string) error
+	PurgeRuns(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Coordinator elects, among the instances sharing the database, the one running the
+// scheduled jobs. The leader renews its lease every third of the TTL; when it stops or
+// dies, another instance takes the lease over once it expires.
+// A nil Coordinator runs every job, as a single instance does.
+type Coordinator struct {
+	store     leaseStore
+	holder    string
+	ttl       time.Duration
+	leader    atomic.Bool
+	lastPurge time.Time
+	stopChan  chan struct{}
+	done      chan struct{} // closed when Start returns
+}
+
+func NewCoordinator(store leaseStore, holder string, ttl time.Duration) *Coordinator {
+	if ttl == 0 {
+		ttl = 30 * time.Second // Default: takeover within 30 seconds
+	}
+
+	return &Coordinator{
+		store:    store,
+		holder:   holder,
+		ttl:      ttl,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (c *Coordinator) Start(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.ttl / 3)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Job coordinator started", "instance", c.holder, "lease_ttl", c.ttl)
+	c.heartbeat(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			c.heartbeat(ctx)
+		case <-c.stopChan:
+			logger.Logger.Info("Job coordinator stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Job coordinator context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the coordinator and gives the lease up so another instance takes over immediately.
+// Call it once the workers are stopped, so no job still runs when the lease is released.
+func (c *Coordinator) Stop() {
+	close(c.stopChan)
+	select {
+	case <-c.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Job coordinator stop timeout")
+	}
+
+	if !c.leader.Swap(false) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := c.store.ReleaseLease(ctx, schedulerLease, c.holder); err != nil {
+		logger.Logger.Warn("Failed to release scheduler lease", "error", err)
+	}
+}
+
+// IsLeader reports whether this instance currently runs the scheduled jobs
+func (c *Coordinator) IsLeader() bool {
+	return c == nil || c.leader.Load()
+}
+
+// Run executes job when this instance is the leader and records the run
+func (c *Coordinator) Run(ctx context.Context, job string, fn func(ctx context.Context)) {
+	if c == nil {
+		fn(ctx)
+		return
+	}
+	if !c.IsLeader() {
+		logger.Logger.Debug("Skipping scheduled job, another instance is the leader", "job", job)
+		return
+	}
+
+	runID, err := c.store.StartRun(ctx, job, c.holder)
+	if err != nil {
+		logger.Logger.Warn("Failed to record job run", "job", job, "error", err)
+	}
+
+	status := "failed"
+	defer func() {
+		if runID == 0 {
+			return
+		}
+		if err := c.store.FinishRun(context.WithoutCancel(ctx), runID, status); err != nil {
+			logger.Logger.Warn("Failed to finish job run", "job", job, "error", err)
+		}
+	}()
+
+	fn(ctx)
+	status = "succeeded"
+}
+
+func (c *Coordinator) heartbeat(ctx context.Context) {
+	acquired, err := c.store.AcquireLease(ctx, schedulerLease, c.holder, c.ttl)
+	if err != nil {
+		// Without a renewed lease another instance may take over: stop running jobs
+		logger.Logger.Error("Failed to renew scheduler lease", "error", err)
+		acquired = false
+	}
+
+	if was := c.leader.Swap(acquired); was != acquired {
+		if acquired {
+			logger.Logger.Info("This instance is now running scheduled jobs", "instance", c.holder)
+		} else {
+			logger.Logger.Info("This instance no longer runs scheduled jobs", "instance", c.holder)
+		}
+	}
+
+	if acquired && time.Since(c.lastPurge) > 24*time.Hour {
+		c.lastPurge = time.Now()
+		if purged, err := c.store.PurgeRuns(ctx, time.Now().Add(-jobRunRetention)); err != nil {
+			logger.Logger.Warn("Failed to purge job runs", "error", err)
+		} else if purged > 0 {
+			logger.Logger.Info("Purged job runs", "count", purged)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// DirectorySyncWorker periodically refreshes signer groups from their directory group
+type DirectorySyncWorker struct {
+	service  *services.SignerGroupService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator                         // nil: runs on every instance
+	credentials *services.DirectoryCredentialService // nil: no directory read with delegated access
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewDirectorySyncWorker(service *services.SignerGroupService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *DirectorySyncWorker {
+	if interval == 0 {
+		interval = 6 * time.Hour // Default: every 6 hours
+	}
+
+	return &DirectorySyncWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCredentials refreshes the delegated directory tokens on each run, before the groups sync
+func (w *DirectorySyncWorker) SetCredentials(credentials *services.DirectoryCredentialService) {
+	w.credentials = credentials
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *DirectorySyncWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *DirectorySyncWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Directory sync worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "directory_sync", w.sync)
+		case <-w.stopChan:
+			logger.Logger.Info("Directory sync worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Directory sync worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *DirectorySyncWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Directory sync worker stop timeout")
+	}
+}
+
+// sync refreshes each group in its own transaction, so that one failing group does not
+// roll back the others
+func (w *DirectorySyncWorker) sync(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for directory sync worker", "error", err)
+		return
+	}
+
+	if w.credentials != nil {
+		// Refresh failures are recorded on the credential, so the transaction always commits
+		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			w.credentials.RefreshAll(txCtx)
+			return nil
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to refresh directory credentials", "error", err)
+		}
+	}
+
+	var groups []*models.SignerGroup
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var listErr error
+		groups, listErr = w.service.ListScheduledGroups(txCtx)
+		return listErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to list signer groups to sync", "error", err)
+		return
+	}
+
+	synced := 0
+	for _, group := range groups {
+		err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			_, syncErr := w.service.SyncGroup(txCtx, group.ID, false)
+			if errors.Is(syncErr, services.ErrDirectorySync) {
+				// Commit the error recorded on the group
+				logger.Logger.Warn("Directory unavailable for signer group", "group_id", group.ID, "error", syncErr)
+				return nil
+			}
+			return syncErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to sync signer group", "group_id", group.ID, "error", err)
+			continue
+		}
+		synced++
+	}
+
+	if synced > 0 {
+		logger.Logger.Info("Synced signer groups from directories", "count", synced)
+	}
+}
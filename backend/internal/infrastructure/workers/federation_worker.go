@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// maxFederationPushesPerRun bounds the pushes per run when catching up on a backlog
+const maxFederationPushesPerRun = 20
+
+// FederationWorker periodically pushes the proofs of new signatures to the federation hub
+type FederationWorker struct {
+	service  *services.FederationClientService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewFederationWorker(service *services.FederationClientService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *FederationWorker {
+	if interval == 0 {
+		interval = 15 * time.Minute // Default: every 15 minutes
+	}
+
+	return &FederationWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *FederationWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *FederationWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Federation worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "federation_push", w.run)
+		case <-w.stopChan:
+			logger.Logger.Info("Federation worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Federation worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *FederationWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Federation worker stop timeout")
+	}
+}
+
+// run pushes each batch in its own transaction, so that pushes accepted by the hub are recorded
+// even when a later one fails
+func (w *FederationWorker) run(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for federation worker", "error", err)
+		return
+	}
+
+	total := 0
+	for i := 0; i < maxFederationPushesPerRun; i++ {
+		var pushed int
+		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			var runErr error
+			pushed, runErr = w.service.PushPending(txCtx)
+			return runErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to push signatures to the federation hub", "error", err)
+			break
+		}
+		if pushed == 0 {
+			break
+		}
+		total += pushed
+	}
+	if total > 0 {
+		logger.Logger.Info("Pushed signatures to the federation hub", "count", total)
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// gitImportCheckInterval is how often the worker looks for sources to sync,
+// so that push webhooks are handled within a minute
+const gitImportCheckInterval = time.Minute
+
+// GitImportWorker syncs the Git sources requested by a push webhook or not synced for the poll interval
+type GitImportWorker struct {
+	service      *services.GitImportService
+	pollInterval time.Duration
+	stopChan     chan struct{}
+	done         chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewGitImportWorker(service *services.GitImportService, pollInterval time.Duration, db *sql.DB, tenants providers.TenantProvider) *GitImportWorker {
+	if pollInterval == 0 {
+		pollInterval = 15 * time.Minute // Default: every 15 minutes
+	}
+
+	return &GitImportWorker{
+		service:      service,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+		done:         make(chan struct{}),
+		db:           db,
+		tenants:      tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *GitImportWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *GitImportWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(gitImportCheckInterval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Git import worker started", "poll_interval", w.pollInterval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "git_import", w.sync)
+		case <-w.stopChan:
+			logger.Logger.Info("Git import worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Git import worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *GitImportWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Git import worker stop timeout")
+	}
+}
+
+// sync imports each due source in its own transaction, so that one failing source does not
+// roll back the others
+func (w *GitImportWorker) sync(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for git import worker", "error", err)
+		return
+	}
+
+	var sources []*models.GitSource
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var listErr error
+		sources, listErr = w.service.ListDueSources(txCtx, w.pollInterval)
+		return listErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to list git sources to sync", "error", err)
+		return
+	}
+
+	for _, source := range sources {
+		err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			_, syncErr := w.service.SyncSource(txCtx, source.ID)
+			if errors.Is(syncErr, services.ErrGitSync) {
+				// Commit the error recorded on the source
+				logger.Logger.Warn("Git repository unavailable for source", "source_id", source.ID, "error", syncErr)
+				return nil
+			}
+			return syncErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to sync git source", "source_id", source.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/google/uuid"
+)
+
+const (
+	// jobStaleMargin is added to the longest job timeout before a running job is considered lost
+	jobStaleMargin = 5 * time.Minute
+
+	// jobRetention is how long succeeded and cancelled jobs are kept; failed jobs are kept longer
+	// for admins to review them
+	jobRetention       = 7 * 24 * time.Hour
+	failedJobRetention = 30 * 24 * time.Hour
+)
+
+// jobStore claims the queued jobs and records the outcome of their runs
+type jobStore interface {
+	Claim(ctx context.Context, jobType, holder string, limit int) ([]*models.Job, error)
+	Complete(ctx context.Context, id int64, result []byte) error
+	SetProgress(ctx context.Context, id int64, done, total int) error
+	Fail(ctx context.Context, id int64, errMsg string, retryAt *time.Time) error
+	RecoverStale(ctx context.Context, lockedBefore time.Time) (int64, error)
+	DeleteFinishedBefore(ctx context.Context, doneBefore, failedBefore time.Time) (int64, error)
+}
+
+// JobHandler runs a job. An error fails the run: the job is retried after a backoff until
+// its attempts are exhausted.
+type JobHandler func(ctx context.Context, job *models.Job) error
+
+// TypedJob decodes the payload of the job into T before calling fn.
+// A payload that does not decode fails the job without retry.
+func TypedJob[T any](fn func(ctx context.Context, payload T) error) JobHandler {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid payload: %w", err))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// ReportingJob decodes the payload of the job into T before calling fn, which reports its progress
+// through the given function and returns the result recorded with the succeeded job
+func ReportingJob[T any, R any](fn func(ctx context.Context, payload T, progress func(done, total int)) (R, error)) JobHandler {
+	return func(ctx context.Context, job *models.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return PermanentJobError(fmt.Errorf("invalid payload: %w", err))
+		}
+
+		report, _ := ctx.Value(jobReportKey{}).(*jobReport)
+		progress := func(done, total int) {}
+		if report != nil {
+			progress = report.progress
+		}
+		result, err := fn(ctx, payload, progress)
+		if err != nil {
+			return err
+		}
+		if report != nil {
+			data, err := json.Marshal(result)
+			if err != nil {
+				return PermanentJobError(fmt.Errorf("invalid result: %w", err))
+			}
+			report.result = data
+		}
+		return nil
+	}
+}
+
+// jobReportKey is the context key of the jobReport of a run
+type jobReportKey struct{}
+
+// jobReport collects the progress and the result of a run
+type jobReport struct {
+	progress func(done, total int)
+	result   []byte
+}
+
+// jobProgressInterval spaces the progress writes of a run, except the last one
+const jobProgressInterval = time.Second
+
+type permanentJobError struct{ err error }
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// PermanentJobError fails the job without retrying it
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+// JobOptions configures how the jobs of a type run on each instance
+type JobOptions struct {
+	Concurrency int           // Jobs of the type run at once; default 1
+	Timeout     time.Duration // Per run; default 5 minutes
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each attempt; default 30 seconds
+	MaxDelay    time.Duration // Longest delay between retries; default 1 hour
+}
+
+// RetryDelay returns the delay before running a job again after its attempt-th run failed
+func (o JobOptions) RetryDelay(attempt int) time.Duration {
+	delay := o.BaseDelay
+	for i := 1; i < attempt && delay < o.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > o.MaxDelay {
+		delay = o.MaxDelay
+	}
+	return delay
+}
+
+type registeredJob struct {
+	handler JobHandler
+	opts    JobOptions
+	running int
+}
+
+// JobRunner runs the jobs of the shared queue. Every instance runs one: jobs are claimed with
+// SKIP LOCKED, so each runs on a single instance, up to the concurrency of its type.
+// The runner also requeues the jobs of lost instances and purges finished jobs.
+type JobRunner struct {
+	store    jobStore
+	holder   string
+	interval time.Duration
+	handlers map[string]*registeredJob
+	jobs     sync.WaitGroup // runs in progress
+	mu       sync.Mutex     // guards the running counters
+
+	ctx       context.Context // cancelled to interrupt runs that outlast Stop
+	cancel    context.CancelFunc
+	stopChan  chan struct{}
+	done      chan struct{} // closed when Start returns
+	lastPurge time.Time
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewJobRunner(store jobStore, holder string, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *JobRunner {
+	if interval == 0 {
+		interval = 2 * time.Second // Default: poll every 2 seconds
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &JobRunner{
+		store:    store,
+		holder:   holder,
+		interval: interval,
+		handlers: make(map[string]*registeredJob),
+		ctx:      ctx,
+		cancel:   cancel,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// Register runs the jobs of a type with handler. Call it before Start.
+func (r *JobRunner) Register(jobType string, handler JobHandler, opts JobOptions) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = 30 * time.Second
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = time.Hour
+	}
+	r.handlers[jobType] = &registeredJob{handler: handler, opts: opts}
+}
+
+func (r *JobRunner) Start(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Job runner started", "instance", r.holder, "interval", r.interval, "types", len(r.handlers))
+	r.maintain(ctx)
+
+	lastMaintenance := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+			r.poll(ctx)
+			if time.Since(lastMaintenance) >= time.Minute {
+				lastMaintenance = time.Now()
+				r.maintain(ctx)
+			}
+		case <-r.stopChan:
+			logger.Logger.Info("Job runner stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Job runner context cancelled")
+			return
+		}
+	}
+}
+
+// Stop stops claiming jobs and waits for the runs in progress. Runs still going after the
+// timeout are cancelled and retried later.
+func (r *JobRunner) Stop() {
+	close(r.stopChan)
+	select {
+	case <-r.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Job runner stop timeout")
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		r.jobs.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Job runner stop timeout, cancelling the runs in progress")
+		r.cancel()
+		<-finished
+	}
+	r.cancel()
+}
+
+// poll claims the due jobs of each type, up to its free slots, and runs them
+func (r *JobRunner) poll(ctx context.Context) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for job runner", "error", err)
+		return
+	}
+
+	for jobType, registered := range r.handlers {
+		r.mu.Lock()
+		free := registered.opts.Concurrency - registered.running
+		r.mu.Unlock()
+		if free <= 0 {
+			continue
+		}
+
+		var jobs []*models.Job
+		err := tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+			var claimErr error
+			jobs, claimErr = r.store.Claim(txCtx, jobType, r.holder, free)
+			return claimErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to claim jobs", "type", jobType, "error", err)
+			continue
+		}
+
+		for _, job := range jobs {
+			r.mu.Lock()
+			registered.running++
+			r.mu.Unlock()
+
+			r.jobs.Add(1)
+			go func(job *models.Job) {
+				defer r.jobs.Done()
+				defer func() {
+					r.mu.Lock()
+					registered.running--
+					r.mu.Unlock()
+				}()
+				r.run(tenantID, registered, job)
+			}(job)
+		}
+	}
+}
+
+// run executes a job and its completion in one tenant transaction, so the changes of a failed
+// run are rolled back. The failure is recorded in a transaction of its own.
+func (r *JobRunner) run(tenantID uuid.UUID, registered *registeredJob, job *models.Job) {
+	ctx, cancel := context.WithTimeout(r.ctx, registered.opts.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	report := &jobReport{progress: r.progressReporter(ctx, tenantID, job)}
+	err := tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+		if err := execute(context.WithValue(txCtx, jobReportKey{}, report), registered.handler, job); err != nil {
+			return err
+		}
+		return r.store.Complete(txCtx, job.ID, report.result)
+	})
+	if err == nil {
+		logger.Logger.Debug("Job succeeded", "job_id", job.ID, "type", job.Type, "duration", time.Since(started))
+		return
+	}
+
+	var retryAt *time.Time
+	var permanent *permanentJobError
+	if job.Attempts < job.MaxAttempts && !errors.As(err, &permanent) {
+		at := time.Now().Add(registered.opts.RetryDelay(job.Attempts))
+		retryAt = &at
+	}
+	logger.Logger.Warn("Job failed",
+		"job_id", job.ID,
+		"type", job.Type,
+		"attempt", job.Attempts,
+		"max_attempts", job.MaxAttempts,
+		"retry", retryAt != nil,
+		"error", err.Error())
+
+	// The run may have been cancelled by Stop: record the failure regardless
+	failCtx, failCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer failCancel()
+	err = tenant.WithTenantContext(failCtx, r.db, tenantID, func(txCtx context.Context) error {
+		return r.store.Fail(txCtx, job.ID, err.Error(), retryAt)
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to record job failure", "job_id", job.ID, "error", err)
+	}
+}
+
+// progressReporter returns the function recording the progress of a run. Progress is written in
+// transactions of its own, so the jobs API shows it while the run transaction is still open.
+func (r *JobRunner) progressReporter(ctx context.Context, tenantID uuid.UUID, job *models.Job) func(done, total int) {
+	var last time.Time
+	return func(done, total int) {
+		if done < total && time.Since(last) < jobProgressInterval {
+			return
+		}
+		last = time.Now()
+		err := tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+			return r.store.SetProgress(txCtx, job.ID, done, total)
+		})
+		if err != nil {
+			logger.Logger.Warn("Failed to record job progress", "job_id", job.ID, "error", err)
+		}
+	}
+}
+
+// execute calls the handler, turning a panic into a failed run
+func execute(ctx context.Context, handler JobHandler, job *models.Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// maintain requeues the jobs left running by lost instances and purges old finished jobs once a day
+func (r *JobRunner) maintain(ctx context.Context) {
+	tenantID, err := r.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for job runner", "error", err)
+		return
+	}
+
+	var longest time.Duration
+	for _, registered := range r.handlers {
+		if registered.opts.Timeout > longest {
+			longest = registered.opts.Timeout
+		}
+	}
+
+	err = tenant.WithTenantContext(ctx, r.db, tenantID, func(txCtx context.Context) error {
+		recovered, err := r.store.RecoverStale(txCtx, time.Now().Add(-longest-jobStaleMargin))
+		if err != nil {
+			return err
+		}
+		if recovered > 0 {
+			logger.Logger.Warn("Recovered jobs left running by a lost instance", "count", recovered)
+		}
+
+		if time.Since(r.lastPurge) < 24*time.Hour {
+			return nil
+		}
+		r.lastPurge = time.Now()
+		purged, err := r.store.DeleteFinishedBefore(txCtx, time.Now().Add(-jobRetention), time.Now().Add(-failedJobRetention))
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			logger.Logger.Info("Purged finished jobs", "count", purged)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to maintain job queue", "error", err)
+	}
+}
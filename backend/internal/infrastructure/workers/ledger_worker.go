@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// maxLedgerBatchesPerRun bounds the batches streamed per run when catching up on a backlog
+const maxLedgerBatchesPerRun = 20
+
+// LedgerWorker periodically streams new signature hashes to the external ledger and warns when
+// the ledger falls behind
+type LedgerWorker struct {
+	service  *services.SignatureLedgerService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewLedgerWorker(service *services.SignatureLedgerService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *LedgerWorker {
+	if interval == 0 {
+		interval = 1 * time.Minute // Default: every minute
+	}
+
+	return &LedgerWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *LedgerWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *LedgerWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Ledger worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "signature_ledger", w.run)
+		case <-w.stopChan:
+			logger.Logger.Info("Ledger worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Ledger worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *LedgerWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Ledger worker stop timeout")
+	}
+}
+
+// run streams each batch in its own transaction, so that entries accepted by the ledger are
+// recorded even when a later batch fails
+func (w *LedgerWorker) run(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for ledger worker", "error", err)
+		return
+	}
+
+	total := 0
+	for i := 0; i < maxLedgerBatchesPerRun; i++ {
+		var streamed int
+		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			var runErr error
+			streamed, runErr = w.service.StreamPending(txCtx)
+			return runErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to stream signatures to the ledger", "error", err)
+			break
+		}
+		if streamed == 0 {
+			break
+		}
+		total += streamed
+	}
+	if total > 0 {
+		logger.Logger.Info("Streamed signatures to the ledger", "count", total)
+	}
+
+	var lag *models.LedgerLag
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var runErr error
+		lag, runErr = w.service.Lag(txCtx)
+		return runErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to measure ledger lag", "error", err)
+		return
+	}
+	if !lag.Healthy {
+		logger.Logger.Warn("Signature ledger is lagging",
+			"ledger", lag.Ledger,
+			"pending", lag.PendingCount,
+			"lag_seconds", lag.LagSeconds,
+			"max_lag_seconds", lag.MaxLagSeconds)
+	}
+}
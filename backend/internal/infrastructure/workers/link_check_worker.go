@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// linkCheckTickInterval is how often the worker looks for link sources to check; each run
+// checks a bounded batch, so large backlogs are spread over several runs
+const linkCheckTickInterval = 5 * time.Minute
+
+// linkSubscriptionRenewBefore is how long before their expiry change notification subscriptions
+// are renewed, several runs of the worker so that a failed renewal is retried
+const linkSubscriptionRenewBefore = time.Hour
+
+// LinkCheckWorker checks the Confluence pages, SharePoint and Google Drive files documents link to
+// for changes, and renews the change notification subscriptions of the files
+type LinkCheckWorker struct {
+	service       *services.LinkResolverService
+	checkInterval time.Duration
+	stopChan      chan struct{}
+	done          chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewLinkCheckWorker(service *services.LinkResolverService, checkInterval time.Duration, db *sql.DB, tenants providers.TenantProvider) *LinkCheckWorker {
+	if checkInterval == 0 {
+		checkInterval = time.Hour // Default: every hour
+	}
+
+	return &LinkCheckWorker{
+		service:       service,
+		checkInterval: checkInterval,
+		stopChan:      make(chan struct{}),
+		done:          make(chan struct{}),
+		db:            db,
+		tenants:       tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *LinkCheckWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *LinkCheckWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(linkCheckTickInterval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Link check worker started", "check_interval", w.checkInterval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "link_checks", w.check)
+		case <-w.stopChan:
+			logger.Logger.Info("Link check worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Link check worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *LinkCheckWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Link check worker stop timeout")
+	}
+}
+
+// check refreshes each due link source in its own transaction, so that one failing document
+// does not roll back the others
+func (w *LinkCheckWorker) check(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for link check worker", "error", err)
+		return
+	}
+
+	var sources []*models.DocumentLinkSource
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var listErr error
+		sources, listErr = w.service.ListDueSources(txCtx, w.checkInterval)
+		return listErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to list link sources to check", "error", err)
+		return
+	}
+
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		return w.service.RenewSubscriptions(txCtx, linkSubscriptionRenewBefore)
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to renew link subscriptions", "error", err)
+	}
+
+	for _, source := range sources {
+		err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			_, refreshErr := w.service.Refresh(txCtx, source.DocID)
+			if errors.Is(refreshErr, services.ErrLinkNotSupported) {
+				// The document no longer links to a tracked page, its source was removed
+				return nil
+			}
+			return refreshErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to check document link source", "doc_id", source.DocID, "error", err)
+		}
+	}
+}
@@ -10,13 +10,47 @@ import (
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/google/uuid"
 )
 
+// rateLimitCleaner removes expired rate limit counters and violations
+type rateLimitCleaner interface {
+	CleanupEvents(ctx context.Context) (int64, error)
+}
+
+// nonceCleaner removes expired signature nonces
+type nonceCleaner interface {
+	CleanupExpired(ctx context.Context) (int64, error)
+}
+
+// idempotencyKeyCleaner removes signature idempotency keys past their retention window
+type idempotencyKeyCleaner interface {
+	CleanupExpired(ctx context.Context) (int64, error)
+}
+
+// securityEventCleaner removes stale failed attempts and expired security events
+type securityEventCleaner interface {
+	CleanupEvents(ctx context.Context) (int64, error)
+}
+
+// emailDeliveryCleaner removes the sends past the deliverability report retention
+type emailDeliveryCleaner interface {
+	CleanupEvents(ctx context.Context) (int64, error)
+}
+
 // MagicLinkCleanupWorker nettoie périodiquement les tokens expirés
 type MagicLinkCleanupWorker struct {
-	service  *services.MagicLinkService
-	interval time.Duration
-	stopChan chan struct{}
+	service     *services.MagicLinkService
+	rateLimiter rateLimitCleaner
+	nonces      nonceCleaner
+	idempotency idempotencyKeyCleaner
+	security    securityEventCleaner
+	deliveries  emailDeliveryCleaner
+	interval    time.Duration
+	stopChan    chan struct{}
+	done        chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
 
 	// RLS support
 	db      *sql.DB
@@ -32,12 +66,45 @@ func NewMagicLinkCleanupWorker(service *services.MagicLinkService, interval time
 		service:  service,
 		interval: interval,
 		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
 		db:       db,
 		tenants:  tenants,
 	}
 }
 
+// SetRateLimitCleaner also removes expired rate limit events on each run
+func (w *MagicLinkCleanupWorker) SetRateLimitCleaner(cleaner rateLimitCleaner) {
+	w.rateLimiter = cleaner
+}
+
+// SetNonceCleaner also removes expired signature nonces on each run
+func (w *MagicLinkCleanupWorker) SetNonceCleaner(cleaner nonceCleaner) {
+	w.nonces = cleaner
+}
+
+// SetIdempotencyKeyCleaner also removes expired signature idempotency keys on each run
+func (w *MagicLinkCleanupWorker) SetIdempotencyKeyCleaner(cleaner idempotencyKeyCleaner) {
+	w.idempotency = cleaner
+}
+
+// SetSecurityEventCleaner also removes stale failed attempts and expired security events on each run
+func (w *MagicLinkCleanupWorker) SetSecurityEventCleaner(cleaner securityEventCleaner) {
+	w.security = cleaner
+}
+
+// SetEmailDeliveryCleaner also removes the email deliveries past their retention on each run
+func (w *MagicLinkCleanupWorker) SetEmailDeliveryCleaner(cleaner emailDeliveryCleaner) {
+	w.deliveries = cleaner
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *MagicLinkCleanupWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
 func (w *MagicLinkCleanupWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
 
@@ -46,7 +113,7 @@ func (w *MagicLinkCleanupWorker) Start(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			w.cleanup(ctx)
+			w.coordinator.Run(ctx, "magic_link_cleanup", w.cleanup)
 		case <-w.stopChan:
 			logger.Logger.Info("Magic Link cleanup worker stopped")
 			return
@@ -57,8 +124,14 @@ func (w *MagicLinkCleanupWorker) Start(ctx context.Context) {
 	}
 }
 
+// Stop signals the worker and waits for the run in progress to finish
 func (w *MagicLinkCleanupWorker) Stop() {
 	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Magic link cleanup worker stop timeout")
+	}
 }
 
 func (w *MagicLinkCleanupWorker) cleanup(ctx context.Context) {
@@ -83,4 +156,105 @@ func (w *MagicLinkCleanupWorker) cleanup(ctx context.Context) {
 	if deleted > 0 {
 		logger.Logger.Info("Cleaned up expired magic link tokens", "count", deleted)
 	}
+
+	if w.rateLimiter != nil {
+		w.cleanupRateLimits(ctx, tenantID)
+	}
+	if w.nonces != nil {
+		w.cleanupNonces(ctx, tenantID)
+	}
+	if w.idempotency != nil {
+		w.cleanupIdempotencyKeys(ctx, tenantID)
+	}
+	if w.security != nil {
+		w.cleanupSecurityEvents(ctx, tenantID)
+	}
+	if w.deliveries != nil {
+		w.cleanupEmailDeliveries(ctx, tenantID)
+	}
+}
+
+func (w *MagicLinkCleanupWorker) cleanupRateLimits(ctx context.Context, tenantID uuid.UUID) {
+	var deleted int64
+	err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var cleanupErr error
+		deleted, cleanupErr = w.rateLimiter.CleanupEvents(txCtx)
+		return cleanupErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to cleanup rate limit events", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		logger.Logger.Info("Cleaned up expired rate limit events", "count", deleted)
+	}
+}
+
+func (w *MagicLinkCleanupWorker) cleanupNonces(ctx context.Context, tenantID uuid.UUID) {
+	var deleted int64
+	err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var cleanupErr error
+		deleted, cleanupErr = w.nonces.CleanupExpired(txCtx)
+		return cleanupErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to cleanup expired signature nonces", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		logger.Logger.Info("Cleaned up expired signature nonces", "count", deleted)
+	}
+}
+
+func (w *MagicLinkCleanupWorker) cleanupIdempotencyKeys(ctx context.Context, tenantID uuid.UUID) {
+	var deleted int64
+	err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var cleanupErr error
+		deleted, cleanupErr = w.idempotency.CleanupExpired(txCtx)
+		return cleanupErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to cleanup expired signature idempotency keys", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		logger.Logger.Info("Cleaned up expired signature idempotency keys", "count", deleted)
+	}
+}
+
+func (w *MagicLinkCleanupWorker) cleanupSecurityEvents(ctx context.Context, tenantID uuid.UUID) {
+	var deleted int64
+	err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var cleanupErr error
+		deleted, cleanupErr = w.security.CleanupEvents(txCtx)
+		return cleanupErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to cleanup security events", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		logger.Logger.Info("Cleaned up stale security events", "count", deleted)
+	}
+}
+
+func (w *MagicLinkCleanupWorker) cleanupEmailDeliveries(ctx context.Context, tenantID uuid.UUID) {
+	var deleted int64
+	err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var cleanupErr error
+		deleted, cleanupErr = w.deliveries.CleanupEvents(txCtx)
+		return cleanupErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to cleanup email deliveries", "error", err)
+		return
+	}
+
+	if deleted > 0 {
+		logger.Logger.Info("Cleaned up old email deliveries", "count", deleted)
+	}
 }
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// maxMerkleRootsPerRun bounds the roots computed per run when catching up on a backlog
+const maxMerkleRootsPerRun = 10
+
+// MerkleWorker periodically computes Merkle roots over new signatures and publishes them
+type MerkleWorker struct {
+	service  *services.MerkleService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewMerkleWorker(service *services.MerkleService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *MerkleWorker {
+	if interval == 0 {
+		interval = 1 * time.Hour // Default: every hour
+	}
+
+	return &MerkleWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *MerkleWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *MerkleWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Merkle worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "merkle_roots", w.run)
+		case <-w.stopChan:
+			logger.Logger.Info("Merkle worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Merkle worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *MerkleWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Merkle worker stop timeout")
+	}
+}
+
+// run commits each root in its own transaction before publishing, so that a published
+// root always exists in the database
+func (w *MerkleWorker) run(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for merkle worker", "error", err)
+		return
+	}
+
+	for i := 0; i < maxMerkleRootsPerRun; i++ {
+		var root *models.MerkleRoot
+		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			var runErr error
+			root, runErr = w.service.ComputeRoot(txCtx)
+			return runErr
+		})
+		if err != nil {
+			logger.Logger.Error("Failed to compute merkle root", "error", err)
+			break
+		}
+		if root == nil {
+			break
+		}
+	}
+
+	var published int
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var runErr error
+		published, runErr = w.service.PublishPending(txCtx)
+		return runErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to publish merkle roots", "error", err)
+		return
+	}
+
+	if published > 0 {
+		logger.Logger.Info("Published merkle roots", "count", published)
+	}
+}
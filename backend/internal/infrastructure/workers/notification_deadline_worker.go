@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// NotificationDeadlineWorker warns document owners in their notification inbox before a deadline
+type NotificationDeadlineWorker struct {
+	service  *services.NotificationCenterService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewNotificationDeadlineWorker(service *services.NotificationCenterService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *NotificationDeadlineWorker {
+	if interval == 0 {
+		interval = time.Hour // Default: every hour
+	}
+
+	return &NotificationDeadlineWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *NotificationDeadlineWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *NotificationDeadlineWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Notification deadline worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "notification_deadlines", w.check)
+		case <-w.stopChan:
+			logger.Logger.Info("Notification deadline worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Notification deadline worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *NotificationDeadlineWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Notification deadline worker stop timeout")
+	}
+}
+
+func (w *NotificationDeadlineWorker) check(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for notification deadline worker", "error", err)
+		return
+	}
+
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		return w.service.RunDeadlineWarnings(txCtx)
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to send deadline warnings", "error", err)
+	}
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+const (
+	// maxOutboxEventsPerRun bounds the events relayed per run when catching up on a backlog
+	maxOutboxEventsPerRun = 200
+	// outboxRetention is how long delivered events are kept before being purged
+	outboxRetention = 7 * 24 * time.Hour
+	// outboxCheckInterval spaces the purges and lag checks, which need not run on every relay
+	outboxCheckInterval = time.Minute
+)
+
+// OutboxRelayWorker periodically relays the events of the transactional outbox to the webhooks and
+// notifiers, purges the delivered ones and warns when the relay falls behind
+type OutboxRelayWorker struct {
+	service  *services.EventOutboxService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+	lastCheck   time.Time
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewOutboxRelayWorker(service *services.EventOutboxService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *OutboxRelayWorker {
+	if interval == 0 {
+		interval = 2 * time.Second // Default: every 2 seconds
+	}
+
+	return &OutboxRelayWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *OutboxRelayWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *OutboxRelayWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Outbox relay worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "event_outbox", w.run)
+		case <-w.stopChan:
+			logger.Logger.Info("Outbox relay worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Outbox relay worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *OutboxRelayWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Outbox relay worker stop timeout")
+	}
+}
+
+// run relays each event in its own transaction, so that a failing event is rolled back alone and
+// its failure recorded in a new transaction
+func (w *OutboxRelayWorker) run(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for outbox relay worker", "error", err)
+		return
+	}
+
+	relayed := 0
+	for i := 0; i < maxOutboxEventsPerRun; i++ {
+		var event *models.OutboxEvent
+		err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+			var runErr error
+			event, runErr = w.service.RelayNext(txCtx)
+			return runErr
+		})
+		if err != nil {
+			if event == nil {
+				logger.Logger.Error("Failed to relay outbox events", "error", err)
+				break
+			}
+			logger.Logger.Warn("Failed to relay outbox event",
+				"event_id", event.EventID,
+				"event_type", event.EventType,
+				"attempts", event.Attempts+1,
+				"error", err.Error())
+			relayErr := err
+			if err := tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+				return w.service.RecordFailure(txCtx, event, relayErr)
+			}); err != nil {
+				logger.Logger.Error("Failed to record outbox relay failure", "event_id", event.EventID, "error", err)
+				break
+			}
+			continue
+		}
+		if event == nil {
+			break
+		}
+		relayed++
+	}
+	if relayed > 0 {
+		logger.Logger.Debug("Relayed outbox events", "count", relayed)
+	}
+
+	if time.Since(w.lastCheck) < outboxCheckInterval {
+		return
+	}
+	w.lastCheck = time.Now()
+
+	var lag *models.OutboxLag
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		if _, err := w.service.Purge(txCtx, outboxRetention); err != nil {
+			return err
+		}
+		var runErr error
+		lag, runErr = w.service.Lag(txCtx)
+		return runErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to measure outbox lag", "error", err)
+		return
+	}
+	if !lag.Healthy {
+		logger.Logger.Warn("Event outbox relay is lagging",
+			"pending", lag.PendingCount,
+			"dead", lag.DeadCount,
+			"lag_seconds", lag.LagSeconds,
+			"max_lag_seconds", lag.MaxLagSeconds)
+	}
+}
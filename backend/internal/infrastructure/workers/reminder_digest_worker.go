@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// ReminderDigestWorker periodically sends one reminder per signer listing all of their pending documents
+type ReminderDigestWorker struct {
+	service        *services.ReminderAsyncService
+	digestInterval time.Duration // Minimum delay between two reminders to the same signer
+	locale         string
+	interval       time.Duration
+	stopChan       chan struct{}
+	done           chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewReminderDigestWorker(service *services.ReminderAsyncService, digestInterval time.Duration, locale string, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *ReminderDigestWorker {
+	if interval == 0 {
+		interval = 1 * time.Hour // Default: every hour
+	}
+
+	return &ReminderDigestWorker{
+		service:        service,
+		digestInterval: digestInterval,
+		locale:         locale,
+		interval:       interval,
+		stopChan:       make(chan struct{}),
+		done:           make(chan struct{}),
+		db:             db,
+		tenants:        tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *ReminderDigestWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *ReminderDigestWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Reminder digest worker started", "interval", w.interval, "digest_interval", w.digestInterval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "reminder_digests", w.send)
+		case <-w.stopChan:
+			logger.Logger.Info("Reminder digest worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Reminder digest worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *ReminderDigestWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Reminder digest worker stop timeout")
+	}
+}
+
+func (w *ReminderDigestWorker) send(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for reminder digest worker", "error", err)
+		return
+	}
+
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		_, err := w.service.SendScheduledDigests(txCtx, w.digestInterval, w.locale)
+		return err
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to send reminder digests", "error", err)
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// RetentionWorker archives completed documents according to the tenant retention policy
+type RetentionWorker struct {
+	service  *services.RetentionService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewRetentionWorker(service *services.RetentionService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *RetentionWorker {
+	if interval == 0 {
+		interval = 1 * time.Hour // Default: every hour
+	}
+
+	return &RetentionWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *RetentionWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *RetentionWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Retention worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "retention", w.apply)
+		case <-w.stopChan:
+			logger.Logger.Info("Retention worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Retention worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *RetentionWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Retention worker stop timeout")
+	}
+}
+
+func (w *RetentionWorker) apply(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for retention worker", "error", err)
+		return
+	}
+
+	var archived int
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		var runErr error
+		archived, runErr = w.service.RunRetention(txCtx)
+		return runErr
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to apply retention policy", "error", err)
+		return
+	}
+
+	if archived > 0 {
+		logger.Logger.Info("Archived completed documents", "count", archived)
+	}
+}
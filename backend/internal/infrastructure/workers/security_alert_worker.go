@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// SecurityAlertWorker sends the alerts of the security events raised by failed authentications
+type SecurityAlertWorker struct {
+	service  *services.SecurityAlertService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+
+	coordinator *Coordinator // nil: runs on every instance
+
+	// RLS support
+	db      *sql.DB
+	tenants providers.TenantProvider
+}
+
+func NewSecurityAlertWorker(service *services.SecurityAlertService, interval time.Duration, db *sql.DB, tenants providers.TenantProvider) *SecurityAlertWorker {
+	if interval == 0 {
+		interval = time.Minute // Default: every minute
+	}
+
+	return &SecurityAlertWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+		db:       db,
+		tenants:  tenants,
+	}
+}
+
+// SetCoordinator restricts the runs to the instance elected by the coordinator
+func (w *SecurityAlertWorker) SetCoordinator(coordinator *Coordinator) {
+	w.coordinator = coordinator
+}
+
+func (w *SecurityAlertWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Security alert worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.coordinator.Run(ctx, "security_alerts", w.dispatch)
+		case <-w.stopChan:
+			logger.Logger.Info("Security alert worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Security alert worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the run in progress to finish
+func (w *SecurityAlertWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Security alert worker stop timeout")
+	}
+}
+
+func (w *SecurityAlertWorker) dispatch(ctx context.Context) {
+	// Get tenant ID for RLS context
+	tenantID, err := w.tenants.CurrentTenant(ctx)
+	if err != nil {
+		logger.Logger.Error("Failed to get tenant for security alert worker", "error", err)
+		return
+	}
+
+	err = tenant.WithTenantContext(ctx, w.db, tenantID, func(txCtx context.Context) error {
+		return w.service.DispatchAlerts(txCtx)
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to send security alerts", "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// SigningKeyWorker periodically checks the signer key. Each instance holds its own copy of the
+// key, so the check runs on every instance.
+type SigningKeyWorker struct {
+	service  *services.SigningKeyService
+	interval time.Duration
+	stopChan chan struct{}
+	done     chan struct{} // closed when Start returns
+}
+
+func NewSigningKeyWorker(service *services.SigningKeyService, interval time.Duration) *SigningKeyWorker {
+	if interval == 0 {
+		interval = time.Hour // Default: every hour
+	}
+
+	return &SigningKeyWorker{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+func (w *SigningKeyWorker) Start(ctx context.Context) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	logger.Logger.Info("Signing key worker started", "interval", w.interval)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.service.Check()
+		case <-w.stopChan:
+			logger.Logger.Info("Signing key worker stopped")
+			return
+		case <-ctx.Done():
+			logger.Logger.Info("Signing key worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop signals the worker and waits for the check in progress to finish
+func (w *SigningKeyWorker) Stop() {
+	close(w.stopChan)
+	select {
+	case <-w.done:
+	case <-time.After(30 * time.Second):
+		logger.Logger.Warn("Signing key worker stop timeout")
+	}
+}
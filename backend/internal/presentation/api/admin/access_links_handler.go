@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxAccessResendRecipients bounds the recipients listed in one access resend
+const maxAccessResendRecipients = 500
+
+// accessResendService sends fresh magic links or codes to signers who lost access
+type accessResendService interface {
+	Resend(ctx context.Context, docID string, emails []string, method, sentBy, ip, locale string) (*models.AccessResendResult, error)
+}
+
+// AccessLinksHandler resends access to the signers of a document
+type AccessLinksHandler struct {
+	service accessResendService
+}
+
+func NewAccessLinksHandler(service accessResendService) *AccessLinksHandler {
+	return &AccessLinksHandler{service: service}
+}
+
+// ResendAccessRequest lists the signers to send access to, all pending signers when empty
+type ResendAccessRequest struct {
+	Emails []string `json:"emails,omitempty"`
+	Method string   `json:"method,omitempty"` // magic_link (default) or code
+}
+
+// HandleResendAccess handles POST /api/v1/admin/documents/{docId}/access-links
+func (h *AccessLinksHandler) HandleResendAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	var req ResendAccessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if len(req.Emails) > maxAccessResendRecipients {
+		shared.WriteValidationError(w, "Too many recipients", map[string]string{"emails": "at most " + strconv.Itoa(maxAccessResendRecipients) + " addresses"})
+		return
+	}
+
+	result, err := h.service.Resend(ctx, docID, req.Emails, req.Method, user.Email, shared.RemoteIP(r), i18n.GetLangFromRequest(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAccessResendMethod):
+			shared.WriteValidationError(w, "Unsupported method", map[string]string{"method": "must be magic_link, or code when external signing is enabled"})
+		case errors.Is(err, models.ErrDocumentNotFound):
+			shared.WriteNotFound(w, "Document")
+		default:
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
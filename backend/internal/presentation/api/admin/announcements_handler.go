@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// announcementService defines announcement management operations
+type announcementService interface {
+	CreateAnnouncement(ctx context.Context, input models.AnnouncementInput) (*models.Announcement, error)
+	UpdateAnnouncement(ctx context.Context, id int64, input models.AnnouncementInput) (*models.Announcement, error)
+	DeleteAnnouncement(ctx context.Context, id int64) error
+	GetAnnouncement(ctx context.Context, id int64) (*models.Announcement, error)
+	ListAnnouncements(ctx context.Context) ([]*models.Announcement, error)
+}
+
+// AnnouncementsHandler groups operations on the banners published to users
+type AnnouncementsHandler struct {
+	service announcementService
+}
+
+func NewAnnouncementsHandler(service announcementService) *AnnouncementsHandler {
+	return &AnnouncementsHandler{service: service}
+}
+
+type AnnouncementRequest struct {
+	Severity models.AnnouncementSeverity `json:"severity"`
+	Message  string                      `json:"message"`
+	Audience models.AnnouncementAudience `json:"audience"`
+	StartsAt *time.Time                  `json:"startsAt"`
+	EndsAt   *time.Time                  `json:"endsAt"`
+}
+
+func (req AnnouncementRequest) toInput() models.AnnouncementInput {
+	return models.AnnouncementInput{
+		Severity: req.Severity,
+		Message:  req.Message,
+		Audience: req.Audience,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	}
+}
+
+// HandleListAnnouncements handles GET /api/v1/admin/announcements
+func (h *AnnouncementsHandler) HandleListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	announcements, err := h.service.ListAnnouncements(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if announcements == nil {
+		announcements = []*models.Announcement{}
+	}
+	shared.WriteJSON(w, http.StatusOK, announcements)
+}
+
+// HandleCreateAnnouncement handles POST /api/v1/admin/announcements
+func (h *AnnouncementsHandler) HandleCreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req AnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	input := req.toInput()
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		input.CreatedBy = user.Email
+	}
+	announcement, err := h.service.CreateAnnouncement(ctx, input)
+	if err != nil {
+		writeAnnouncementError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, announcement)
+}
+
+// HandleGetAnnouncement handles GET /api/v1/admin/announcements/{id}
+func (h *AnnouncementsHandler) HandleGetAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAnnouncementID(w, r)
+	if !ok {
+		return
+	}
+	announcement, err := h.service.GetAnnouncement(r.Context(), id)
+	if err != nil {
+		writeAnnouncementError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, announcement)
+}
+
+// HandleUpdateAnnouncement handles PUT /api/v1/admin/announcements/{id}
+func (h *AnnouncementsHandler) HandleUpdateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAnnouncementID(w, r)
+	if !ok {
+		return
+	}
+	var req AnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	announcement, err := h.service.UpdateAnnouncement(r.Context(), id, req.toInput())
+	if err != nil {
+		writeAnnouncementError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, announcement)
+}
+
+// HandleDeleteAnnouncement handles DELETE /api/v1/admin/announcements/{id}
+func (h *AnnouncementsHandler) HandleDeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseAnnouncementID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteAnnouncement(r.Context(), id); err != nil {
+		writeAnnouncementError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Announcement deleted"})
+}
+
+func parseAnnouncementID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid announcement ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeAnnouncementError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidAnnouncement):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrAnnouncementNotFound):
+		shared.WriteNotFound(w, "Announcement")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
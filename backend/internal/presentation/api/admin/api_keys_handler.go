@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// apiKeyService defines API key management operations
+type apiKeyService interface {
+	CreateKey(ctx context.Context, name string, scopes []models.Permission, createdBy string) (*models.APIKey, string, error)
+	ListKeys(ctx context.Context) ([]*models.APIKey, error)
+	RevokeKey(ctx context.Context, id int64, revokedBy string) error
+}
+
+// APIKeysHandler groups operations on integration API keys
+type APIKeysHandler struct {
+	service apiKeyService
+}
+
+func NewAPIKeysHandler(service apiKeyService) *APIKeysHandler {
+	return &APIKeysHandler{service: service}
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string              `json:"name"`
+	Scopes []models.Permission `json:"scopes"`
+}
+
+// CreateAPIKeyResponse carries the key secret, which is only returned once
+type CreateAPIKeyResponse struct {
+	*models.APIKey
+	Key string `json:"key"`
+}
+
+// HandleListAPIKeys handles GET /api/v1/admin/api-keys
+func (h *APIKeysHandler) HandleListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.ListKeys(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if keys == nil {
+		keys = []*models.APIKey{}
+	}
+	shared.WriteJSON(w, http.StatusOK, keys)
+}
+
+// HandleCreateAPIKey handles POST /api/v1/admin/api-keys
+func (h *APIKeysHandler) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	key, secret, err := h.service.CreateKey(ctx, req.Name, req.Scopes, user.Email)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKeyInput) {
+			shared.WriteValidationError(w, err.Error(), nil)
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Key: secret})
+}
+
+// HandleRevokeAPIKey handles DELETE /api/v1/admin/api-keys/{id}
+func (h *APIKeysHandler) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid id", nil)
+		return
+	}
+
+	if err := h.service.RevokeKey(ctx, id, user.Email); err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			shared.WriteNotFound(w, "API key")
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "API key revoked"})
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+)
+
+// brandingService defines branding logo management operations
+type brandingService interface {
+	UploadLogo(ctx context.Context, data []byte, contentType, updatedBy string) error
+	DeleteLogo(ctx context.Context, updatedBy string) error
+}
+
+// BrandingHandler manages the branding logo
+type BrandingHandler struct {
+	service brandingService
+}
+
+func NewBrandingHandler(service brandingService) *BrandingHandler {
+	return &BrandingHandler{service: service}
+}
+
+// HandleUploadLogo handles PUT /api/v1/admin/settings/branding/logo (multipart field "file")
+func (h *BrandingHandler) HandleUploadLogo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	// Leave room for the multipart envelope around the file
+	r.Body = http.MaxBytesReader(w, r.Body, services.MaxLogoSize+64<<10)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "A logo file is required in the 'file' field", nil)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, services.MaxLogoSize+1))
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Failed to read logo file", nil)
+		return
+	}
+
+	if err := h.service.UploadLogo(ctx, data, header.Header.Get("Content-Type"), user.Email); err != nil {
+		writeBrandingError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Logo updated"})
+}
+
+// HandleDeleteLogo handles DELETE /api/v1/admin/settings/branding/logo
+func (h *BrandingHandler) HandleDeleteLogo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	if err := h.service.DeleteLogo(ctx, user.Email); err != nil {
+		writeBrandingError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Logo deleted"})
+}
+
+func writeBrandingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidLogo):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, services.ErrLogoTooLarge):
+		shared.WriteError(w, http.StatusRequestEntityTooLarge, shared.ErrCodeBadRequest, err.Error(), nil)
+	case errors.Is(err, services.ErrBrandingStorageDisabled):
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, err.Error(), nil)
+	case errors.Is(err, services.ErrLogoNotFound):
+		shared.WriteNotFound(w, "Logo")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// cacheStatsProvider reports the activity of an in-memory cache
+type cacheStatsProvider interface {
+	Stats() models.CacheStats
+}
+
+// CacheHandler exposes server-side cache metrics to admins
+type CacheHandler struct {
+	caches []cacheStatsProvider
+}
+
+func NewCacheHandler(caches ...cacheStatsProvider) *CacheHandler {
+	return &CacheHandler{caches: caches}
+}
+
+// CacheStatsResponse represents the metrics of one cache
+type CacheStatsResponse struct {
+	Name          string  `json:"name"`
+	Enabled       bool    `json:"enabled"`
+	Listening     bool    `json:"listening"`
+	Entries       int     `json:"entries"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	Bypasses      int64   `json:"bypasses"`
+	Invalidations int64   `json:"invalidations"`
+	HitRate       float64 `json:"hitRate"`
+}
+
+// HandleGetCacheStats handles GET /api/v1/admin/cache
+func (h *CacheHandler) HandleGetCacheStats(w http.ResponseWriter, r *http.Request) {
+	response := make([]*CacheStatsResponse, 0, len(h.caches))
+	for _, cache := range h.caches {
+		stats := cache.Stats()
+		response = append(response, &CacheStatsResponse{
+			Name:          stats.Name,
+			Enabled:       stats.Enabled,
+			Listening:     stats.Listening,
+			Entries:       stats.Entries,
+			Hits:          stats.Hits,
+			Misses:        stats.Misses,
+			Bypasses:      stats.Bypasses,
+			Invalidations: stats.Invalidations,
+			HitRate:       stats.HitRate,
+		})
+	}
+
+	shared.WriteJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// campaignService defines recurring campaign management operations
+type campaignService interface {
+	CreateCampaign(ctx context.Context, input models.CampaignInput) (*models.Campaign, error)
+	UpdateCampaign(ctx context.Context, id int64, input models.CampaignInput) (*models.Campaign, error)
+	DeleteCampaign(ctx context.Context, id int64) error
+	GetCampaignByID(ctx context.Context, id int64) (*models.Campaign, error)
+	ListCampaigns(ctx context.Context, limit, offset int) ([]*models.Campaign, error)
+	ListCampaignRuns(ctx context.Context, id int64, limit, offset int) ([]*models.CampaignRun, error)
+	TriggerCampaign(ctx context.Context, id int64, triggeredBy, locale string) (*models.CampaignRun, error)
+}
+
+// CampaignsHandler groups operations on recurring acknowledgement campaigns
+type CampaignsHandler struct {
+	service campaignService
+}
+
+func NewCampaignsHandler(service campaignService) *CampaignsHandler {
+	return &CampaignsHandler{service: service}
+}
+
+type CampaignRequest struct {
+	Title     string                  `json:"title"`
+	DocID     string                  `json:"docId"`
+	Schedule  models.CampaignSchedule `json:"schedule"`
+	Active    *bool                   `json:"active,omitempty"`
+	NextRunAt *time.Time              `json:"nextRunAt,omitempty"`
+}
+
+func (req CampaignRequest) toInput() models.CampaignInput {
+	input := models.CampaignInput{Title: req.Title, DocID: req.DocID, Schedule: req.Schedule, Active: true}
+	if req.Active != nil {
+		input.Active = *req.Active
+	}
+	if req.NextRunAt != nil {
+		input.NextRunAt = *req.NextRunAt
+	}
+	return input
+}
+
+func (h *CampaignsHandler) HandleListCampaigns(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pagination := shared.ParsePaginationParams(r, 20, 100)
+	list, err := h.service.ListCampaigns(ctx, pagination.PageSize, pagination.Offset)
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	meta := map[string]interface{}{"total": len(list), "limit": pagination.PageSize, "offset": pagination.Offset}
+	shared.WriteJSONWithMeta(w, http.StatusOK, list, meta)
+}
+
+func (h *CampaignsHandler) HandleCreateCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req CampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	input := req.toInput()
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		input.CreatedBy = user.Email
+	}
+	campaign, err := h.service.CreateCampaign(ctx, input)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, campaign)
+}
+
+func (h *CampaignsHandler) HandleGetCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+	campaign, err := h.service.GetCampaignByID(r.Context(), id)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, campaign)
+}
+
+func (h *CampaignsHandler) HandleUpdateCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+	var req CampaignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	campaign, err := h.service.UpdateCampaign(r.Context(), id, req.toInput())
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, campaign)
+}
+
+func (h *CampaignsHandler) HandleDeleteCampaign(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteCampaign(r.Context(), id); err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Campaign deleted"})
+}
+
+func (h *CampaignsHandler) HandleListCampaignRuns(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+	pagination := shared.ParsePaginationParams(r, 20, 100)
+	runs, err := h.service.ListCampaignRuns(r.Context(), id, pagination.PageSize, pagination.Offset)
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, runs)
+}
+
+func (h *CampaignsHandler) HandleTriggerCampaign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := parseCampaignID(w, r)
+	if !ok {
+		return
+	}
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	run, err := h.service.TriggerCampaign(ctx, id, user.Email, i18n.GetLangFromRequest(r))
+	if err != nil {
+		writeCampaignError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, run)
+}
+
+func parseCampaignID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid campaign ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeCampaignError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCampaign):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrCampaignNotFound):
+		shared.WriteNotFound(w, "Campaign")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// completionService defines per-document completion notification settings operations
+type completionService interface {
+	GetSettings(ctx context.Context, docID string) (*models.CompletionSettings, error)
+	UpdateSettings(ctx context.Context, docID string, input models.CompletionSettingsInput, updatedBy string) (*models.CompletionSettings, error)
+}
+
+// CompletionHandler exposes completion notification settings of documents
+type CompletionHandler struct {
+	service completionService
+}
+
+func NewCompletionHandler(service completionService) *CompletionHandler {
+	return &CompletionHandler{service: service}
+}
+
+// HandleGetSettings handles GET /api/v1/admin/documents/{docId}/notifications
+func (h *CompletionHandler) HandleGetSettings(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), docID)
+	if err != nil {
+		writeCompletionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, settings)
+}
+
+// HandleUpdateSettings handles PUT /api/v1/admin/documents/{docId}/notifications
+func (h *CompletionHandler) HandleUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	var input models.CompletionSettingsInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(ctx, docID, input, user.Email)
+	if err != nil {
+		writeCompletionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, settings)
+}
+
+func writeCompletionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidCompletionSettings):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
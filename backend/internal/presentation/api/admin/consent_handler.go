@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// consentService defines consent catalog management operations
+type consentService interface {
+	ListCatalog(ctx context.Context) ([]*models.ConsentText, error)
+	PublishVersion(ctx context.Context, input models.ConsentVersionInput) ([]*models.ConsentText, error)
+	GetDocumentConsent(ctx context.Context, docID string) (*models.DocumentConsent, error)
+	SetDocumentVersion(ctx context.Context, docID string, version *int, setBy string) (*models.DocumentConsent, error)
+}
+
+// ConsentHandler groups operations on the consent sentences shown to signers
+type ConsentHandler struct {
+	service consentService
+}
+
+func NewConsentHandler(service consentService) *ConsentHandler {
+	return &ConsentHandler{service: service}
+}
+
+// PublishConsentRequest is the body of POST /api/v1/admin/consent, with one text per locale
+type PublishConsentRequest struct {
+	Texts map[string]string `json:"texts"`
+}
+
+// UpdateDocumentConsentRequest is the body of PUT /api/v1/admin/documents/{docId}/consent.
+// A null version makes the document follow the latest version.
+type UpdateDocumentConsentRequest struct {
+	Version *int `json:"version"`
+}
+
+// HandleListCatalog handles GET /api/v1/admin/consent
+func (h *ConsentHandler) HandleListCatalog(w http.ResponseWriter, r *http.Request) {
+	texts, err := h.service.ListCatalog(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if texts == nil {
+		texts = []*models.ConsentText{}
+	}
+	shared.WriteJSON(w, http.StatusOK, texts)
+}
+
+// HandlePublishVersion handles POST /api/v1/admin/consent
+func (h *ConsentHandler) HandlePublishVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req PublishConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	input := models.ConsentVersionInput{Texts: req.Texts}
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		input.CreatedBy = user.Email
+	}
+	texts, err := h.service.PublishVersion(ctx, input)
+	if err != nil {
+		writeConsentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, texts)
+}
+
+// HandleGetDocumentConsent handles GET /api/v1/admin/documents/{docId}/consent
+func (h *ConsentHandler) HandleGetDocumentConsent(w http.ResponseWriter, r *http.Request) {
+	consent, err := h.service.GetDocumentConsent(r.Context(), chi.URLParam(r, "docId"))
+	if err != nil {
+		writeConsentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, consent)
+}
+
+// HandleUpdateDocumentConsent handles PUT /api/v1/admin/documents/{docId}/consent
+func (h *ConsentHandler) HandleUpdateDocumentConsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req UpdateDocumentConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	var setBy string
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		setBy = user.Email
+	}
+	consent, err := h.service.SetDocumentVersion(ctx, chi.URLParam(r, "docId"), req.Version, setBy)
+	if err != nil {
+		writeConsentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, consent)
+}
+
+func writeConsentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidConsent):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
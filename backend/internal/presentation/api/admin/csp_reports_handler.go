@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	defaultCSPViolations = 100
+	maxCSPViolations     = 500
+)
+
+// cspReportService lists the Content-Security-Policy violations reported by browsers
+type cspReportService interface {
+	List(limit int) []*models.CSPViolation
+}
+
+// CSPReportsHandler exposes Content-Security-Policy violations to admins
+type CSPReportsHandler struct {
+	service cspReportService
+}
+
+func NewCSPReportsHandler(service cspReportService) *CSPReportsHandler {
+	return &CSPReportsHandler{service: service}
+}
+
+// HandleListViolations handles GET /api/v1/admin/csp-reports
+func (h *CSPReportsHandler) HandleListViolations(w http.ResponseWriter, r *http.Request) {
+	limit := defaultCSPViolations
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxCSPViolations {
+			shared.WriteValidationError(w, "limit must be between 1 and 500", nil)
+			return
+		}
+		limit = parsed
+	}
+
+	shared.WriteJSON(w, http.StatusOK, h.service.List(limit))
+}
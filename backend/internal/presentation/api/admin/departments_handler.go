@@ -0,0 +1,335 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// departmentService defines department, member and document assignment operations
+type departmentService interface {
+	ListDepartments(ctx context.Context) ([]*models.Department, error)
+	GetDepartment(ctx context.Context, id int64) (*models.Department, error)
+	CreateDepartment(ctx context.Context, input models.DepartmentInput) (*models.Department, error)
+	UpdateDepartment(ctx context.Context, id int64, input models.DepartmentInput) (*models.Department, error)
+	DeleteDepartment(ctx context.Context, id int64) error
+	ListMembers(ctx context.Context, id int64) ([]models.DepartmentMember, error)
+	AddMembers(ctx context.Context, id int64, members []models.DepartmentMember, addedBy string) error
+	RemoveMember(ctx context.Context, id int64, email string) error
+	SetDocumentDepartment(ctx context.Context, docID string, departmentID *int64) error
+	ListDocuments(ctx context.Context, id int64) ([]*models.DepartmentDocument, error)
+	Stats(ctx context.Context, id int64) (*models.DepartmentStats, error)
+	AddToDocument(ctx context.Context, docID string, id int64, addedBy string) (int, error)
+}
+
+// DepartmentsHandler groups operations on departments, their members and documents
+type DepartmentsHandler struct {
+	service departmentService
+}
+
+func NewDepartmentsHandler(service departmentService) *DepartmentsHandler {
+	return &DepartmentsHandler{service: service}
+}
+
+type DepartmentRequest struct {
+	Name     string `json:"name"`
+	ParentID *int64 `json:"parentId"`
+}
+
+type AddDepartmentMembersRequest struct {
+	Members []models.DepartmentMember `json:"members"`
+}
+
+type DocumentDepartmentRequest struct {
+	DepartmentID *int64 `json:"departmentId"`
+}
+
+// HandleListDepartments handles GET /api/v1/admin/departments
+func (h *DepartmentsHandler) HandleListDepartments(w http.ResponseWriter, r *http.Request) {
+	departments, err := h.service.ListDepartments(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if departments == nil {
+		departments = []*models.Department{}
+	}
+	shared.WriteJSON(w, http.StatusOK, departments)
+}
+
+// HandleCreateDepartment handles POST /api/v1/admin/departments
+func (h *DepartmentsHandler) HandleCreateDepartment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	input := models.DepartmentInput{Name: req.Name, ParentID: req.ParentID}
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		input.CreatedBy = user.Email
+	}
+	department, err := h.service.CreateDepartment(ctx, input)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, department)
+}
+
+// HandleGetDepartment handles GET /api/v1/admin/departments/{id}
+func (h *DepartmentsHandler) HandleGetDepartment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	department, err := h.service.GetDepartment(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, department)
+}
+
+// HandleUpdateDepartment handles PUT /api/v1/admin/departments/{id}
+func (h *DepartmentsHandler) HandleUpdateDepartment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	var req DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	department, err := h.service.UpdateDepartment(r.Context(), id, models.DepartmentInput{Name: req.Name, ParentID: req.ParentID})
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, department)
+}
+
+// HandleDeleteDepartment handles DELETE /api/v1/admin/departments/{id}
+func (h *DepartmentsHandler) HandleDeleteDepartment(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteDepartment(r.Context(), id); err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Department deleted"})
+}
+
+// HandleListMembers handles GET /api/v1/admin/departments/{id}/members
+func (h *DepartmentsHandler) HandleListMembers(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	members, err := h.service.ListMembers(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	if members == nil {
+		members = []models.DepartmentMember{}
+	}
+	shared.WriteJSON(w, http.StatusOK, members)
+}
+
+// HandleAddMembers handles POST /api/v1/admin/departments/{id}/members
+func (h *DepartmentsHandler) HandleAddMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	var req AddDepartmentMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Members) == 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "At least one member is required", nil)
+		return
+	}
+	if err := h.service.AddMembers(ctx, id, req.Members, user.Email); err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, map[string]int{"added": len(req.Members)})
+}
+
+// HandleRemoveMember handles DELETE /api/v1/admin/departments/{id}/members/{email}
+func (h *DepartmentsHandler) HandleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	email, err := url.PathUnescape(chi.URLParam(r, "email"))
+	if err != nil || email == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Email is required", nil)
+		return
+	}
+	if err := h.service.RemoveMember(r.Context(), id, email); err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Member removed"})
+}
+
+// HandleListDocuments handles GET /api/v1/admin/departments/{id}/documents, including the
+// documents of the sub-departments
+func (h *DepartmentsHandler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	documents, err := h.service.ListDocuments(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	if documents == nil {
+		documents = []*models.DepartmentDocument{}
+	}
+	shared.WriteJSON(w, http.StatusOK, documents)
+}
+
+// HandleExportDocuments handles GET /api/v1/admin/departments/{id}/documents/export, the CSV
+// of the documents of the department and of its sub-departments with their completion
+func (h *DepartmentsHandler) HandleExportDocuments(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	documents, err := h.service.ListDocuments(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	_ = cw.Write([]string{"doc_id", "title", "department_id", "department", "expected", "signed", "completion_rate", "created_at"})
+	for _, doc := range documents {
+		_ = cw.Write([]string{
+			doc.DocID, doc.Title, strconv.FormatInt(doc.DepartmentID, 10), doc.DepartmentName,
+			strconv.Itoa(doc.ExpectedCount), strconv.Itoa(doc.SignedCount),
+			strconv.FormatFloat(doc.CompletionRate, 'f', 1, 64), doc.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="department-%d-documents.csv"`, id))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// HandleGetStats handles GET /api/v1/admin/departments/{id}/stats
+func (h *DepartmentsHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	stats, err := h.service.Stats(r.Context(), id)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, stats)
+}
+
+// HandleSetDocumentDepartment handles PUT /api/v1/admin/documents/{docId}/department,
+// a null departmentId unassigning the document
+func (h *DepartmentsHandler) HandleSetDocumentDepartment(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+	var req DocumentDepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if err := h.service.SetDocumentDepartment(r.Context(), docID, req.DepartmentID); err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "departmentId": req.DepartmentID})
+}
+
+// HandleAddToDocument handles POST /api/v1/admin/documents/{docId}/signers/departments/{id}
+func (h *DepartmentsHandler) HandleAddToDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+	id, ok := parseDepartmentID(w, r)
+	if !ok {
+		return
+	}
+	count, err := h.service.AddToDocument(ctx, docID, id, user.Email)
+	if err != nil {
+		writeDepartmentError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "departmentId": id, "memberCount": count})
+}
+
+func parseDepartmentID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid department ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeDepartmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidDepartment):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDepartmentExists), errors.Is(err, models.ErrDepartmentInUse):
+		shared.WriteConflict(w, err.Error())
+	case errors.Is(err, models.ErrOutOfDepartmentScope):
+		shared.WriteForbidden(w, err.Error())
+	case errors.Is(err, models.ErrDepartmentNotFound):
+		shared.WriteNotFound(w, "Department")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// directoryCredentialService manages the delegated access to the directories signer groups are synced from
+type directoryCredentialService interface {
+	List(ctx context.Context) ([]*models.DirectoryCredential, error)
+	StartAuthorization(ctx context.Context, provider string) (string, error)
+	CompleteAuthorization(ctx context.Context, state, code, connectedBy string) (*models.DirectoryCredential, error)
+	Refresh(ctx context.Context, provider string) (*models.DirectoryCredential, error)
+	Disconnect(ctx context.Context, provider string) error
+}
+
+// DirectoryCredentialsHandler lets admins grant, check and revoke the delegated access to directories
+type DirectoryCredentialsHandler struct {
+	service directoryCredentialService
+	baseURL string
+}
+
+func NewDirectoryCredentialsHandler(service directoryCredentialService, baseURL string) *DirectoryCredentialsHandler {
+	return &DirectoryCredentialsHandler{service: service, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// HandleList handles GET /api/v1/admin/integrations/directory
+func (h *DirectoryCredentialsHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	credentials, err := h.service.List(r.Context())
+	if err != nil {
+		writeDirectoryCredentialError(w, err, "")
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, credentials)
+}
+
+// HandleConnect handles POST /api/v1/admin/integrations/directory/{provider}/connect.
+// Returns the consent page of the provider the admin opens to grant offline access.
+func (h *DirectoryCredentialsHandler) HandleConnect(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	authURL, err := h.service.StartAuthorization(r.Context(), provider)
+	if err != nil {
+		writeDirectoryCredentialError(w, err, provider)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"authorizationUrl": authURL})
+}
+
+// HandleCallback handles GET /api/v1/admin/integrations/directory/callback, where the provider
+// redirects the admin after the consent. The admin is sent back to the settings page with the
+// outcome in the directory and result query parameters.
+func (h *DirectoryCredentialsHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	result := url.Values{"result": {"connected"}}
+
+	if providerErr := query.Get("error"); providerErr != "" {
+		result.Set("result", "error")
+		result.Set("reason", providerErr)
+	} else {
+		connectedBy := ""
+		if user, _ := shared.GetUserFromContext(r.Context()); user != nil {
+			connectedBy = user.Email
+		}
+		credential, err := h.service.CompleteAuthorization(r.Context(), query.Get("state"), query.Get("code"), connectedBy)
+		if err != nil {
+			logger.Logger.Warn("Directory authorization failed", "error", err.Error())
+			result.Set("result", "error")
+			result.Set("reason", "invalid_grant")
+		} else {
+			result.Set("directory", credential.Provider)
+		}
+	}
+
+	http.Redirect(w, r, h.baseURL+"/admin/settings?"+result.Encode(), http.StatusFound)
+}
+
+// HandleRefresh handles POST /api/v1/admin/integrations/directory/{provider}/refresh.
+// Refreshes the access token now; a revoked or failing access is reported in the returned state.
+func (h *DirectoryCredentialsHandler) HandleRefresh(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	credential, err := h.service.Refresh(r.Context(), provider)
+	if err != nil {
+		writeDirectoryCredentialError(w, err, provider)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, credential)
+}
+
+// HandleDisconnect handles DELETE /api/v1/admin/integrations/directory/{provider}
+func (h *DirectoryCredentialsHandler) HandleDisconnect(w http.ResponseWriter, r *http.Request) {
+	provider := chi.URLParam(r, "provider")
+	if err := h.service.Disconnect(r.Context(), provider); err != nil {
+		writeDirectoryCredentialError(w, err, provider)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeDirectoryCredentialError(w http.ResponseWriter, err error, provider string) {
+	switch {
+	case errors.Is(err, models.ErrDirectoryNotDelegated):
+		shared.WriteNotFound(w, "Directory provider")
+	case errors.Is(err, models.ErrDirectoryNotConnected):
+		shared.WriteNotFound(w, "Directory credential")
+	default:
+		logger.Logger.Error("Failed to manage directory credential", "provider", provider, "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
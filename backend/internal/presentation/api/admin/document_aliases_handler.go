@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// documentAliasService manages the alternative IDs of documents
+type documentAliasService interface {
+	ListAliases(ctx context.Context, docID string) ([]*models.DocumentAlias, error)
+	AddAlias(ctx context.Context, docID, alias, createdBy string) (*models.DocumentAlias, error)
+	RemoveAlias(ctx context.Context, docID, alias string) error
+}
+
+// DocumentAliasesHandler exposes the aliases of documents, whose links redirect to the document
+type DocumentAliasesHandler struct {
+	service documentAliasService
+}
+
+func NewDocumentAliasesHandler(service documentAliasService) *DocumentAliasesHandler {
+	return &DocumentAliasesHandler{service: service}
+}
+
+// DocumentAliasRequest is the body of an alias creation
+type DocumentAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// HandleListAliases handles GET /api/v1/admin/documents/{docId}/aliases
+func (h *DocumentAliasesHandler) HandleListAliases(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	aliases, err := h.service.ListAliases(r.Context(), docID)
+	if err != nil {
+		logger.Logger.Error("Failed to list document aliases", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, aliases)
+}
+
+// HandleAddAlias handles POST /api/v1/admin/documents/{docId}/aliases
+func (h *DocumentAliasesHandler) HandleAddAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req DocumentAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	alias, err := h.service.AddAlias(ctx, chi.URLParam(r, "docId"), req.Alias, currentUserEmail(ctx))
+	if err != nil {
+		writeDocumentAliasError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, alias)
+}
+
+// HandleRemoveAlias handles DELETE /api/v1/admin/documents/{docId}/aliases/{alias}
+func (h *DocumentAliasesHandler) HandleRemoveAlias(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.RemoveAlias(r.Context(), chi.URLParam(r, "docId"), chi.URLParam(r, "alias")); err != nil {
+		writeDocumentAliasError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Document alias removed"})
+}
+
+func writeDocumentAliasError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	case errors.Is(err, models.ErrDocumentAliasNotFound):
+		shared.WriteNotFound(w, "Document alias")
+	case errors.Is(err, models.ErrInvalidDocumentAlias):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDocumentAliasTaken):
+		shared.WriteConflict(w, err.Error())
+	default:
+		logger.Logger.Error("Failed to manage document alias", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// checksumInspector downloads a remote document to compute its checksums
+type checksumInspector interface {
+	InspectURL(ctx context.Context, url string) (*checksum.Inspection, error)
+}
+
+// DocumentChecksumHandler computes the checksum of a document URL server-side, so that the
+// document form can be prefilled without the browser downloading the file
+type DocumentChecksumHandler struct {
+	inspector checksumInspector
+}
+
+func NewDocumentChecksumHandler(inspector checksumInspector) *DocumentChecksumHandler {
+	return &DocumentChecksumHandler{inspector: inspector}
+}
+
+// DocumentChecksumRequest is the body of a checksum computation
+type DocumentChecksumRequest struct {
+	URL string `json:"url"`
+}
+
+// HandleComputeChecksum handles POST /api/v1/admin/documents/checksum
+func (h *DocumentChecksumHandler) HandleComputeChecksum(w http.ResponseWriter, r *http.Request) {
+	var req DocumentChecksumRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if strings.TrimSpace(req.URL) == "" {
+		shared.WriteValidationError(w, "url is required", nil)
+		return
+	}
+
+	inspection, err := h.inspector.InspectURL(r.Context(), req.URL)
+	if err != nil {
+		switch {
+		case errors.Is(err, checksum.ErrURLNotAllowed):
+			shared.WriteValidationError(w, err.Error(), nil)
+		case errors.Is(err, checksum.ErrContentTypeNotAllowed), errors.Is(err, checksum.ErrTooLarge):
+			shared.WriteError(w, http.StatusUnprocessableEntity, shared.ErrCodeBadRequest, err.Error(), nil)
+		case errors.Is(err, checksum.ErrFetchFailed):
+			logger.Logger.Info("Failed to fetch document for checksum", "url", req.URL, "error", err.Error())
+			shared.WriteError(w, http.StatusBadGateway, shared.ErrCodeServiceUnavailable, "Failed to fetch the document", nil)
+		default:
+			logger.Logger.Error("Failed to compute document checksum", "url", req.URL, "error", err.Error())
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, inspection)
+}
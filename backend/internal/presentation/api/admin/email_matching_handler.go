@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// emailMatchingService defines email alias and matching rule management operations
+type emailMatchingService interface {
+	ListAliases(ctx context.Context) ([]*models.EmailAlias, error)
+	AddAlias(ctx context.Context, email, alias, createdBy string) (*models.EmailAlias, error)
+	RemoveAlias(ctx context.Context, id int64, removedBy string) error
+	GetRules(ctx context.Context) (*models.EmailMatchingRules, error)
+	UpdateRules(ctx context.Context, rules models.EmailMatchingRules, updatedBy string) (*models.EmailMatchingRules, error)
+}
+
+// EmailMatchingHandler groups operations on email aliases and matching rules
+type EmailMatchingHandler struct {
+	service emailMatchingService
+}
+
+func NewEmailMatchingHandler(service emailMatchingService) *EmailMatchingHandler {
+	return &EmailMatchingHandler{service: service}
+}
+
+type CreateEmailAliasRequest struct {
+	Email string `json:"email"`
+	Alias string `json:"alias"`
+}
+
+type UpdateEmailMatchingRulesRequest struct {
+	IgnoreCase      bool `json:"ignoreCase"`
+	StripPlusTag    bool `json:"stripPlusTag"`
+	IgnoreGmailDots bool `json:"ignoreGmailDots"`
+}
+
+// HandleListEmailAliases handles GET /api/v1/admin/email-aliases
+func (h *EmailMatchingHandler) HandleListEmailAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := h.service.ListAliases(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to list email aliases", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if aliases == nil {
+		aliases = []*models.EmailAlias{}
+	}
+	shared.WriteJSON(w, http.StatusOK, aliases)
+}
+
+// HandleCreateEmailAlias handles POST /api/v1/admin/email-aliases
+func (h *EmailMatchingHandler) HandleCreateEmailAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	var req CreateEmailAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	alias, err := h.service.AddAlias(ctx, req.Email, req.Alias, user.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidEmailAlias):
+			shared.WriteValidationError(w, err.Error(), nil)
+		case errors.Is(err, models.ErrEmailAliasExists):
+			shared.WriteConflict(w, "Alias already exists")
+		default:
+			logger.Logger.Error("Failed to create email alias", "error", err.Error())
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, alias)
+}
+
+// HandleDeleteEmailAlias handles DELETE /api/v1/admin/email-aliases/{id}
+func (h *EmailMatchingHandler) HandleDeleteEmailAlias(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid id", nil)
+		return
+	}
+
+	if err := h.service.RemoveAlias(ctx, id, user.Email); err != nil {
+		if errors.Is(err, models.ErrEmailAliasNotFound) {
+			shared.WriteNotFound(w, "Email alias")
+			return
+		}
+		logger.Logger.Error("Failed to delete email alias", "id", id, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Email alias deleted"})
+}
+
+// HandleGetEmailMatchingRules handles GET /api/v1/admin/email-matching
+func (h *EmailMatchingHandler) HandleGetEmailMatchingRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.GetRules(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to get email matching rules", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, rules)
+}
+
+// HandleUpdateEmailMatchingRules handles PUT /api/v1/admin/email-matching
+func (h *EmailMatchingHandler) HandleUpdateEmailMatchingRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	var req UpdateEmailMatchingRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	rules, err := h.service.UpdateRules(ctx, models.EmailMatchingRules{
+		IgnoreCase:      req.IgnoreCase,
+		StripPlusTag:    req.StripPlusTag,
+		IgnoreGmailDots: req.IgnoreGmailDots,
+	}, user.Email)
+	if err != nil {
+		logger.Logger.Error("Failed to update email matching rules", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, rules)
+}
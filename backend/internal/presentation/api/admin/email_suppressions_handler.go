@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// emailSuppressionService defines suppression list management operations
+type emailSuppressionService interface {
+	ListSuppressions(ctx context.Context, search string) ([]*models.EmailSuppression, error)
+	AddSuppression(ctx context.Context, entry models.EmailSuppression) (*models.EmailSuppression, error)
+	RemoveSuppression(ctx context.Context, email string) error
+}
+
+// EmailSuppressionsHandler groups operations on the addresses no email is sent to
+type EmailSuppressionsHandler struct {
+	service emailSuppressionService
+}
+
+func NewEmailSuppressionsHandler(service emailSuppressionService) *EmailSuppressionsHandler {
+	return &EmailSuppressionsHandler{service: service}
+}
+
+type EmailSuppressionRequest struct {
+	Email  string                   `json:"email"`
+	Reason models.SuppressionReason `json:"reason"`
+	Detail string                   `json:"detail"`
+}
+
+// HandleListSuppressions handles GET /api/v1/admin/email-suppressions
+func (h *EmailSuppressionsHandler) HandleListSuppressions(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.service.ListSuppressions(r.Context(), r.URL.Query().Get("search"))
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if entries == nil {
+		entries = []*models.EmailSuppression{}
+	}
+	shared.WriteJSON(w, http.StatusOK, entries)
+}
+
+// HandleAddSuppression handles POST /api/v1/admin/email-suppressions
+func (h *EmailSuppressionsHandler) HandleAddSuppression(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req EmailSuppressionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	entry := models.EmailSuppression{Email: req.Email, Reason: req.Reason, Detail: req.Detail}
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		entry.CreatedBy = user.Email
+	}
+	suppression, err := h.service.AddSuppression(ctx, entry)
+	if err != nil {
+		writeSuppressionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, suppression)
+}
+
+// HandleRemoveSuppression handles DELETE /api/v1/admin/email-suppressions/{email}
+func (h *EmailSuppressionsHandler) HandleRemoveSuppression(w http.ResponseWriter, r *http.Request) {
+	email, err := url.PathUnescape(chi.URLParam(r, "email"))
+	if err != nil || email == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid email address", nil)
+		return
+	}
+	if err := h.service.RemoveSuppression(r.Context(), email); err != nil {
+		writeSuppressionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Email suppression removed"})
+}
+
+func writeSuppressionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSuppression):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrSuppressionNotFound):
+		shared.WriteNotFound(w, "Email suppression")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
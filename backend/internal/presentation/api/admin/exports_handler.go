@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxExportBundleSize bounds the bundles uploaded for verification
+const maxExportBundleSize = 64 << 20
+
+// exportService builds and verifies signed export bundles
+type exportService interface {
+	ExportDocumentSignatures(ctx context.Context, docID string) (*crypto.ExportBundle, error)
+	VerifyBundle(data []byte) (*crypto.BundleVerification, error)
+}
+
+// ExportsHandler serves the tamper-evident export bundles
+type ExportsHandler struct {
+	service exportService
+}
+
+func NewExportsHandler(service exportService) *ExportsHandler {
+	return &ExportsHandler{service: service}
+}
+
+// HandleExportSignatures handles GET /api/v1/admin/documents/{docId}/signatures/export
+func (h *ExportsHandler) HandleExportSignatures(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	bundle, err := h.service.ExportDocumentSignatures(r.Context(), docID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrDocumentNotFound):
+			shared.WriteNotFound(w, "Document")
+		case errors.Is(err, services.ErrTimestampUnavailable):
+			shared.WriteError(w, http.StatusBadGateway, shared.ErrCodeServiceUnavailable, err.Error(), nil)
+		default:
+			logger.Logger.Error("Failed to export document signatures", "doc_id", docID, "error", err.Error())
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+
+	// Build the archive first, so that a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf); err != nil {
+		logger.Logger.Error("Failed to write export bundle", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-signatures.zip"`, docID))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// HandleVerifyBundle handles POST /api/v1/admin/exports/verify, with the bundle in the 'file'
+// field of a multipart form. Bundles signed with another key are reported as invalid.
+func (h *ExportsHandler) HandleVerifyBundle(w http.ResponseWriter, r *http.Request) {
+	// Leave room for the multipart envelope around the file
+	r.Body = http.MaxBytesReader(w, r.Body, maxExportBundleSize+64<<10)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "An export bundle is required in the 'file' field", nil)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxExportBundleSize+1))
+	if err != nil || len(data) > maxExportBundleSize {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Failed to read export bundle", nil)
+		return
+	}
+
+	result, err := h.service.VerifyBundle(data)
+	if err != nil {
+		if errors.Is(err, crypto.ErrBundleInvalid) {
+			shared.WriteValidationError(w, err.Error(), nil)
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
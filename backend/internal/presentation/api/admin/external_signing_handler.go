@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// externalSigningService defines the per-document external signer toggle
+type externalSigningService interface {
+	GetSettings(ctx context.Context, docID string) (*models.ExternalSigning, error)
+	SetEnabled(ctx context.Context, docID string, enabled bool, updatedBy string) (*models.ExternalSigning, error)
+}
+
+// ExternalSigningHandler opens documents to signers outside the identity provider
+type ExternalSigningHandler struct {
+	service externalSigningService
+}
+
+func NewExternalSigningHandler(service externalSigningService) *ExternalSigningHandler {
+	return &ExternalSigningHandler{service: service}
+}
+
+// UpdateExternalSigningRequest is the body of PUT /api/v1/admin/documents/{docId}/external-signing
+type UpdateExternalSigningRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// HandleGetExternalSigning handles GET /api/v1/admin/documents/{docId}/external-signing
+func (h *ExternalSigningHandler) HandleGetExternalSigning(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	settings, err := h.service.GetSettings(r.Context(), docID)
+	if err != nil {
+		writeExternalSigningError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, settings)
+}
+
+// HandleUpdateExternalSigning handles PUT /api/v1/admin/documents/{docId}/external-signing
+func (h *ExternalSigningHandler) HandleUpdateExternalSigning(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	var req UpdateExternalSigningRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	settings, err := h.service.SetEnabled(ctx, docID, req.Enabled, user.Email)
+	if err != nil {
+		writeExternalSigningError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, settings)
+}
+
+func writeExternalSigningError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// federationHub manages the peers pushing to this hub and what they pushed
+type federationHub interface {
+	RegisterPeer(ctx context.Context, instanceID, name, publicKey, createdBy string) (*models.FederationPeer, error)
+	ListPeers(ctx context.Context) ([]*models.FederationPeer, error)
+	DeletePeer(ctx context.Context, id int64) error
+	ListDocuments(ctx context.Context, instanceID string) ([]*models.FederatedDocument, error)
+}
+
+// federationClient pushes the signatures of this instance to its hub
+type federationClient interface {
+	PushPending(ctx context.Context) (int, error)
+	Status(ctx context.Context) (*models.FederationStatus, error)
+}
+
+// FederationHandler exposes the federation to admins: the peers and documents of a hub, and the
+// pushes of a peer. Either side may be nil when this instance does not play it.
+type FederationHandler struct {
+	hub    federationHub
+	client federationClient
+}
+
+func NewFederationHandler(hub federationHub, client federationClient) *FederationHandler {
+	return &FederationHandler{hub: hub, client: client}
+}
+
+// FederationPeerRequest registers a peer at the hub
+type FederationPeerRequest struct {
+	InstanceID string `json:"instanceId"`
+	Name       string `json:"name"`
+	PublicKey  string `json:"publicKey"`
+}
+
+// HandleListPeers handles GET /api/v1/admin/federation/peers
+func (h *FederationHandler) HandleListPeers(w http.ResponseWriter, r *http.Request) {
+	peers, err := h.hub.ListPeers(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to list federation peers", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, peers)
+}
+
+// HandleCreatePeer handles POST /api/v1/admin/federation/peers
+func (h *FederationHandler) HandleCreatePeer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req FederationPeerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	createdBy := ""
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		createdBy = user.Email
+	}
+
+	peer, err := h.hub.RegisterPeer(ctx, req.InstanceID, req.Name, req.PublicKey, createdBy)
+	if err != nil {
+		writeFederationError(w, err)
+		return
+	}
+	logger.Logger.Info("Federation peer registered", "instance_id", peer.InstanceID, "admin", createdBy)
+	shared.WriteJSON(w, http.StatusCreated, peer)
+}
+
+// HandleDeletePeer handles DELETE /api/v1/admin/federation/peers/{id}
+func (h *FederationHandler) HandleDeletePeer(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid federation peer ID", nil)
+		return
+	}
+	if err := h.hub.DeletePeer(r.Context(), id); err != nil {
+		writeFederationError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Federation peer deleted"})
+}
+
+// HandleListDocuments handles GET /api/v1/admin/federation/documents?instance=<instance ID>
+func (h *FederationHandler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
+	docs, err := h.hub.ListDocuments(r.Context(), r.URL.Query().Get("instance"))
+	if err != nil {
+		logger.Logger.Error("Failed to list federated documents", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, docs)
+}
+
+// HandleGetStatus handles GET /api/v1/admin/federation/status
+func (h *FederationHandler) HandleGetStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.client.Status(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to get federation status", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, status)
+}
+
+// HandlePush handles POST /api/v1/admin/federation/push, pushing one batch without waiting for
+// the worker
+func (h *FederationHandler) HandlePush(w http.ResponseWriter, r *http.Request) {
+	pushed, err := h.client.PushPending(r.Context())
+	if err != nil {
+		logger.Logger.Warn("Forced federation push failed", "error", err.Error())
+		shared.WriteError(w, http.StatusBadGateway, shared.ErrCodeServiceUnavailable, err.Error(), nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]int{"pushed": pushed})
+}
+
+func writeFederationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidFederationPeer):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrFederationPeerExists):
+		shared.WriteConflict(w, err.Error())
+	case errors.Is(err, models.ErrFederationPeerNotFound):
+		shared.WriteNotFound(w, "Federation peer")
+	default:
+		logger.Logger.Error("Federation request failed", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
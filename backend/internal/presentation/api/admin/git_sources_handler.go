@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// gitImportService defines Git source management and import operations
+type gitImportService interface {
+	CreateSource(ctx context.Context, input models.GitSourceInput) (*models.GitSource, error)
+	UpdateSource(ctx context.Context, id int64, input models.GitSourceInput) (*models.GitSource, error)
+	DeleteSource(ctx context.Context, id int64) error
+	GetSource(ctx context.Context, id int64) (*models.GitSource, error)
+	ListSources(ctx context.Context) ([]*models.GitSource, error)
+	ListDocuments(ctx context.Context, id int64) ([]*models.GitSourceDocument, error)
+	SyncSource(ctx context.Context, id int64) (*models.GitSyncResult, error)
+}
+
+// GitSourcesHandler groups operations on the Git repositories documents are imported from
+type GitSourcesHandler struct {
+	service gitImportService
+}
+
+func NewGitSourcesHandler(service gitImportService) *GitSourcesHandler {
+	return &GitSourcesHandler{service: service}
+}
+
+// GitSourceRequest is the body of create and update requests.
+// An empty webhookSecret keeps the current secret on update.
+type GitSourceRequest struct {
+	Name          string               `json:"name"`
+	RepoURL       string               `json:"repoUrl"`
+	Branch        string               `json:"branch"`
+	Rules         []models.GitPathRule `json:"rules"`
+	WebhookSecret string               `json:"webhookSecret"`
+	SyncEnabled   bool                 `json:"syncEnabled"`
+}
+
+func (req GitSourceRequest) toInput() models.GitSourceInput {
+	return models.GitSourceInput{
+		Name:          req.Name,
+		RepoURL:       req.RepoURL,
+		Branch:        req.Branch,
+		Rules:         req.Rules,
+		WebhookSecret: req.WebhookSecret,
+		SyncEnabled:   req.SyncEnabled,
+	}
+}
+
+// redactGitSource hides the credentials of the repository URL from API responses
+func redactGitSource(source *models.GitSource) *models.GitSource {
+	redacted := *source
+	redacted.RepoURL = source.RedactedRepoURL()
+	return &redacted
+}
+
+// HandleListSources handles GET /api/v1/admin/git-sources
+func (h *GitSourcesHandler) HandleListSources(w http.ResponseWriter, r *http.Request) {
+	sources, err := h.service.ListSources(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	result := make([]*models.GitSource, 0, len(sources))
+	for _, source := range sources {
+		result = append(result, redactGitSource(source))
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
+
+// HandleCreateSource handles POST /api/v1/admin/git-sources
+func (h *GitSourcesHandler) HandleCreateSource(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req GitSourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	input := req.toInput()
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		input.CreatedBy = user.Email
+	}
+	source, err := h.service.CreateSource(ctx, input)
+	if err != nil {
+		writeGitSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, redactGitSource(source))
+}
+
+// HandleGetSource handles GET /api/v1/admin/git-sources/{id}
+func (h *GitSourcesHandler) HandleGetSource(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseGitSourceID(w, r)
+	if !ok {
+		return
+	}
+	source, err := h.service.GetSource(r.Context(), id)
+	if err != nil {
+		writeGitSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, redactGitSource(source))
+}
+
+// HandleUpdateSource handles PUT /api/v1/admin/git-sources/{id}
+func (h *GitSourcesHandler) HandleUpdateSource(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseGitSourceID(w, r)
+	if !ok {
+		return
+	}
+	var req GitSourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	source, err := h.service.UpdateSource(r.Context(), id, req.toInput())
+	if err != nil {
+		writeGitSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, redactGitSource(source))
+}
+
+// HandleDeleteSource handles DELETE /api/v1/admin/git-sources/{id}
+func (h *GitSourcesHandler) HandleDeleteSource(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseGitSourceID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteSource(r.Context(), id); err != nil {
+		writeGitSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Git source deleted"})
+}
+
+// HandleListDocuments handles GET /api/v1/admin/git-sources/{id}/documents
+func (h *GitSourcesHandler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseGitSourceID(w, r)
+	if !ok {
+		return
+	}
+	docs, err := h.service.ListDocuments(r.Context(), id)
+	if err != nil {
+		writeGitSourceError(w, err)
+		return
+	}
+	if docs == nil {
+		docs = []*models.GitSourceDocument{}
+	}
+	shared.WriteJSON(w, http.StatusOK, docs)
+}
+
+// HandleSyncSource handles POST /api/v1/admin/git-sources/{id}/sync
+func (h *GitSourcesHandler) HandleSyncSource(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseGitSourceID(w, r)
+	if !ok {
+		return
+	}
+	result, err := h.service.SyncSource(r.Context(), id)
+	if err != nil {
+		writeGitSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
+
+func parseGitSourceID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid git source ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeGitSourceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidGitSource):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrGitSourceExists):
+		shared.WriteConflict(w, err.Error())
+	case errors.Is(err, services.ErrGitSync):
+		shared.WriteError(w, http.StatusBadGateway, shared.ErrCodeServiceUnavailable, services.ErrGitSync.Error(), nil)
+	case errors.Is(err, models.ErrGitSourceNotFound):
+		shared.WriteNotFound(w, "Git source")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
@@ -4,10 +4,12 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/btouchard/ackify-ce/backend/internal/application/services"
@@ -24,8 +26,13 @@ type adminService interface {
 	ListDocuments(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	SearchDocuments(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
 	CountDocuments(ctx context.Context, searchQuery string) (int, error)
+	ListDocumentsByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
+	SearchDocumentsByCreator(ctx context.Context, createdBy, query string, limit, offset int) ([]*models.Document, error)
+	CountDocumentsByCreator(ctx context.Context, createdBy, searchQuery string) (int, error)
 	UpdateDocumentMetadata(ctx context.Context, docID string, input models.DocumentInput, updatedBy string) (*models.Document, error)
+	PatchDocumentMetadata(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error)
 	DeleteDocument(ctx context.Context, docID string) error
+	GetDocumentStatus(ctx context.Context, docID string) (*models.DocumentStatus, error)
 	ListExpectedSigners(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
 	ListExpectedSignersWithStatus(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
 	AddExpectedSigners(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
@@ -35,36 +42,79 @@ type adminService interface {
 
 // reminderService defines the interface for reminder operations
 type reminderService interface {
-	SendReminders(ctx context.Context, docID, sentBy string, specificEmails []string, docURL string, locale string) (*models.ReminderSendResult, error)
+	SendRemindersWithLevel(ctx context.Context, docID, sentBy string, specificEmails []string, docURL string, locale string, level models.ReminderLevel) (*models.ReminderSendResult, error)
 	GetReminderHistory(ctx context.Context, docID string) ([]*models.ReminderLog, error)
-	GetReminderStats(ctx context.Context, docID string) (*models.ReminderStats, error)
+	SendDigests(ctx context.Context, emails []string, sentBy, locale string) (*models.ReminderDigestResult, error)
 }
 
-// signatureService defines the interface for signature operations
-type signatureService interface {
-	GetDocumentSignatures(ctx context.Context, docID string) ([]*models.Signature, error)
+// Handler handles admin API requests
+// contentURLSigner issues expiring links to the content of stored documents
+type contentURLSigner interface {
+	SignedURL(docID string) string
+}
+
+// documentFilter lists the documents carrying a tag, optionally owned by a user or matching a search
+type documentFilter interface {
+	ListDocuments(ctx context.Context, filter models.DocumentFilter, limit, offset int) ([]*models.Document, error)
+	CountDocuments(ctx context.Context, filter models.DocumentFilter) (int, error)
+}
+
+// signerEmailPolicy refuses the expected signer emails outside the domains allowed by the tenant
+type signerEmailPolicy interface {
+	CheckEmails(ctx context.Context, emails []string) []models.SignerEmailRejection
 }
 
-// Handler handles admin API requests
 type Handler struct {
 	adminService     adminService
 	reminderService  reminderService
-	signatureService signatureService
 	baseURL          string
 	importMaxSigners int
+	urlSigner        contentURLSigner
+	documentFilter   documentFilter
+	emailPolicy      signerEmailPolicy
 }
 
 // NewHandler creates a new admin handler
-func NewHandler(adminService adminService, reminderService reminderService, signatureService signatureService, baseURL string, importMaxSigners int) *Handler {
+func NewHandler(adminService adminService, reminderService reminderService, baseURL string, importMaxSigners int) *Handler {
 	return &Handler{
 		adminService:     adminService,
 		reminderService:  reminderService,
-		signatureService: signatureService,
 		baseURL:          baseURL,
 		importMaxSigners: importMaxSigners,
 	}
 }
 
+// SetURLSigner enables signed content links for stored documents in share links and reminders
+func (h *Handler) SetURLSigner(signer contentURLSigner) {
+	h.urlSigner = signer
+}
+
+// SetDocumentFilter enables the tag filter of the document list
+func (h *Handler) SetDocumentFilter(filter documentFilter) {
+	h.documentFilter = filter
+}
+
+// SetSignerEmailPolicy enforces the tenant signer email policy when adding and importing signers
+func (h *Handler) SetSignerEmailPolicy(policy signerEmailPolicy) {
+	h.emailPolicy = policy
+}
+
+// checkSignerEmails returns the emails refused by the signer email policy, if any
+func (h *Handler) checkSignerEmails(ctx context.Context, emails []string) []models.SignerEmailRejection {
+	if h.emailPolicy == nil {
+		return nil
+	}
+	return h.emailPolicy.CheckEmails(ctx, emails)
+}
+
+// signedContentURL returns an expiring link to the stored file of doc, if any
+func (h *Handler) signedContentURL(doc *models.Document) string {
+	if h.urlSigner == nil || doc == nil || !doc.IsStored() {
+		return ""
+	}
+	return h.urlSigner.SignedURL(doc.DocID)
+}
+
 // DocumentResponse represents a document in API responses
 type DocumentResponse struct {
 	DocID             string `json:"docId"`
@@ -72,6 +122,7 @@ type DocumentResponse struct {
 	URL               string `json:"url"`
 	Checksum          string `json:"checksum,omitempty"`
 	ChecksumAlgorithm string `json:"checksumAlgorithm,omitempty"`
+	ContentHash       string `json:"contentHash,omitempty"`
 	Description       string `json:"description"`
 	ReadMode          string `json:"readMode"`
 	AllowDownload     bool   `json:"allowDownload"`
@@ -84,6 +135,10 @@ type DocumentResponse struct {
 	StorageProvider   string `json:"storageProvider,omitempty"`
 	FileSize          int64  `json:"fileSize,omitempty"`
 	MimeType          string `json:"mimeType,omitempty"`
+	Version           int    `json:"version"`
+	DepartmentID      *int64 `json:"departmentId,omitempty"`
+
+	LandingMessage map[string]string `json:"landingMessage,omitempty"`
 }
 
 // ExpectedSignerResponse represents an expected signer in API responses
@@ -102,6 +157,8 @@ type ExpectedSignerResponse struct {
 	ReminderCount         int     `json:"reminderCount"`
 	DaysSinceAdded        int     `json:"daysSinceAdded"`
 	DaysSinceLastReminder *int    `json:"daysSinceLastReminder,omitempty"`
+	SignOrder             *int    `json:"signOrder,omitempty"`
+	Turn                  string  `json:"turn,omitempty"` // signed, current or waiting in a sequential workflow
 }
 
 // DocumentStatsResponse represents document statistics
@@ -118,6 +175,9 @@ type UnexpectedSignatureResponse struct {
 	UserEmail   string  `json:"userEmail"`
 	UserName    *string `json:"userName,omitempty"`
 	SignedAtUTC string  `json:"signedAtUTC"`
+	// Set when the signature is held for approval: pending, approved or rejected
+	ApprovalID     int64  `json:"approvalId,omitempty"`
+	ApprovalStatus string `json:"approvalStatus,omitempty"`
 }
 
 // HandleListDocuments handles GET /api/v1/admin/documents
@@ -128,23 +188,59 @@ func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 	pagination := shared.ParsePaginationParams(r, 100, 200)
 	searchQuery := r.URL.Query().Get("search")
 
+	// Optional sparse fieldset: only the columns the client renders, docId always included
+	fields, unknown := shared.ParseFields(r, DocumentResponse{}, "docId")
+	if unknown != nil {
+		shared.WriteValidationError(w, shared.FieldsError(unknown), unknown)
+		return
+	}
+
+	// Optional ownership filter: an email, or "me" for the current user
+	owner := strings.TrimSpace(r.URL.Query().Get("owner"))
+	if strings.EqualFold(owner, "me") {
+		user, ok := shared.GetUserFromContext(ctx)
+		if !ok {
+			shared.WriteUnauthorized(w, "")
+			return
+		}
+		owner = user.Email
+	}
+
+	// Optional tag filter, combined with the ownership and search filters
+	tag := strings.TrimSpace(r.URL.Query().Get("tag"))
+	if h.documentFilter == nil {
+		tag = ""
+	}
+	filter := models.DocumentFilter{Tag: tag, Owner: owner, Search: searchQuery}
+
 	// Fetch documents with or without search
 	var documents []*models.Document
 	var err error
 
-	if searchQuery != "" {
+	switch {
+	case tag != "":
+		documents, err = h.documentFilter.ListDocuments(ctx, filter, pagination.PageSize, pagination.Offset)
+	case owner != "" && searchQuery != "":
+		documents, err = h.adminService.SearchDocumentsByCreator(ctx, owner, searchQuery, pagination.PageSize, pagination.Offset)
+	case owner != "":
+		documents, err = h.adminService.ListDocumentsByCreator(ctx, owner, pagination.PageSize, pagination.Offset)
+	case searchQuery != "":
 		documents, err = h.adminService.SearchDocuments(ctx, searchQuery, pagination.PageSize, pagination.Offset)
 		logger.Logger.Debug("Admin document search",
 			"query", searchQuery,
 			"limit", pagination.PageSize,
 			"offset", pagination.Offset)
-	} else {
+	default:
 		documents, err = h.adminService.ListDocuments(ctx, pagination.PageSize, pagination.Offset)
 		logger.Logger.Debug("Admin document list",
 			"limit", pagination.PageSize,
 			"offset", pagination.Offset)
 	}
 
+	if errors.Is(err, models.ErrInvalidTag) {
+		shared.WriteValidationError(w, err.Error(), map[string]string{"tag": "invalid"})
+		return
+	}
 	if err != nil {
 		logger.Logger.Error("Failed to fetch documents", "error", err.Error(), "search", searchQuery)
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to list documents", nil)
@@ -152,7 +248,15 @@ func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get total count of documents (with or without search filter)
-	totalCount, err := h.adminService.CountDocuments(ctx, searchQuery)
+	var totalCount int
+	switch {
+	case tag != "":
+		totalCount, err = h.documentFilter.CountDocuments(ctx, filter)
+	case owner != "":
+		totalCount, err = h.adminService.CountDocumentsByCreator(ctx, owner, searchQuery)
+	default:
+		totalCount, err = h.adminService.CountDocuments(ctx, searchQuery)
+	}
 	if err != nil {
 		logger.Logger.Warn("Failed to count documents, using result count",
 			"error", err.Error(),
@@ -164,6 +268,12 @@ func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 	for _, doc := range documents {
 		response = append(response, toDocumentResponse(doc))
 	}
+	data, err := shared.Project(response, fields)
+	if err != nil {
+		logger.Logger.Error("Failed to project documents", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
 
 	meta := map[string]interface{}{
 		"total":  totalCount,     // Total matching documents in DB
@@ -176,8 +286,14 @@ func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 	if searchQuery != "" {
 		meta["search"] = searchQuery
 	}
+	if owner != "" {
+		meta["owner"] = owner
+	}
+	if tag != "" {
+		meta["tag"] = tag
+	}
 
-	shared.WriteJSONWithMeta(w, http.StatusOK, response, meta)
+	shared.WriteJSONWithMeta(w, http.StatusOK, data, meta)
 }
 
 // HandleGetDocument handles GET /api/v1/admin/documents/{docId}
@@ -196,6 +312,7 @@ func (h *Handler) HandleGetDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	shared.SetVersionETag(w, document.Version)
 	shared.WriteJSON(w, http.StatusOK, toDocumentResponse(document))
 }
 
@@ -209,6 +326,13 @@ func (h *Handler) HandleGetDocumentWithSigners(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Optional sparse fieldset of the signers, email always included
+	fields, unknown := shared.ParseFields(r, ExpectedSignerResponse{}, "email")
+	if unknown != nil {
+		shared.WriteValidationError(w, shared.FieldsError(unknown), unknown)
+		return
+	}
+
 	// Get document
 	document, err := h.adminService.GetDocument(ctx, docID)
 	if err != nil {
@@ -234,10 +358,16 @@ func (h *Handler) HandleGetDocumentWithSigners(w http.ResponseWriter, r *http.Re
 	for _, signer := range signers {
 		signersResponse = append(signersResponse, toExpectedSignerResponse(signer))
 	}
+	signersData, err := shared.Project(signersResponse, fields)
+	if err != nil {
+		logger.Logger.Error("Failed to project signers", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
 
 	response := map[string]interface{}{
 		"document": toDocumentResponse(document),
-		"signers":  signersResponse,
+		"signers":  signersData,
 		"stats":    toStatsResponse(stats),
 	}
 
@@ -246,9 +376,10 @@ func (h *Handler) HandleGetDocumentWithSigners(w http.ResponseWriter, r *http.Re
 
 // AddExpectedSignerRequest represents the request body for adding an expected signer
 type AddExpectedSignerRequest struct {
-	Email string  `json:"email"`
-	Name  string  `json:"name"`
-	Notes *string `json:"notes,omitempty"`
+	Email     string  `json:"email"`
+	Name      string  `json:"name"`
+	Notes     *string `json:"notes,omitempty"`
+	SignOrder *int    `json:"signOrder,omitempty"` // Position in a sequential workflow
 }
 
 // HandleAddExpectedSigner handles POST /api/v1/admin/documents/{docId}/signers
@@ -280,9 +411,20 @@ func (h *Handler) HandleAddExpectedSigner(w http.ResponseWriter, r *http.Request
 		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Email is required", nil)
 		return
 	}
+	if req.SignOrder != nil && *req.SignOrder < 1 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Sign order must be a positive number", nil)
+		return
+	}
+	if rejections := h.checkSignerEmails(ctx, []string{req.Email}); len(rejections) > 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeValidation, rejections[0].Message, map[string]interface{}{
+			"email":  req.Email,
+			"reason": rejections[0].Reason,
+		})
+		return
+	}
 
 	// Add expected signer
-	contacts := []models.ContactInfo{{Email: req.Email, Name: req.Name}}
+	contacts := []models.ContactInfo{{Email: req.Email, Name: req.Name, SignOrder: req.SignOrder}}
 	err := h.adminService.AddExpectedSigners(ctx, docID, contacts, user.Email)
 	if err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to add expected signer", nil)
@@ -335,6 +477,7 @@ func toDocumentResponse(doc *models.Document) *DocumentResponse {
 		URL:               doc.URL,
 		Checksum:          doc.Checksum,
 		ChecksumAlgorithm: doc.ChecksumAlgorithm,
+		ContentHash:       doc.ContentHash,
 		Description:       doc.Description,
 		ReadMode:          doc.ReadMode,
 		AllowDownload:     doc.AllowDownload,
@@ -347,6 +490,9 @@ func toDocumentResponse(doc *models.Document) *DocumentResponse {
 		StorageProvider:   doc.StorageProvider,
 		FileSize:          doc.FileSize,
 		MimeType:          doc.MimeType,
+		Version:           doc.Version,
+		DepartmentID:      doc.DepartmentID,
+		LandingMessage:    doc.LandingMessage,
 	}
 }
 
@@ -364,6 +510,8 @@ func toExpectedSignerResponse(signer *models.ExpectedSignerWithStatus) *Expected
 		ReminderCount:         signer.ReminderCount,
 		DaysSinceAdded:        signer.DaysSinceAdded,
 		DaysSinceLastReminder: signer.DaysSinceLastReminder,
+		SignOrder:             signer.SignOrder,
+		Turn:                  string(signer.Turn),
 	}
 
 	if signer.SignedAt != nil {
@@ -391,7 +539,8 @@ func toStatsResponse(stats *models.DocCompletionStats) *DocumentStatsResponse {
 
 // SendRemindersRequest represents the request body for sending reminders
 type SendRemindersRequest struct {
-	Emails []string `json:"emails,omitempty"` // If empty, send to all pending signers
+	Emails []string             `json:"emails,omitempty"` // If empty, send to all pending signers
+	Level  models.ReminderLevel `json:"level,omitempty"`  // If empty, selected per signer from the escalation thresholds
 }
 
 // HandleSendReminders handles POST /api/v1/admin/documents/{docId}/reminders
@@ -423,18 +572,25 @@ func (h *Handler) HandleSendReminders(w http.ResponseWriter, r *http.Request) {
 		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
 		return
 	}
+	if req.Level != "" && !req.Level.IsValid() {
+		shared.WriteValidationError(w, "Invalid reminder level", map[string]string{"level": "must be gentle, firm or final"})
+		return
+	}
 
-	// Get document URL from metadata
+	// Get document URL from metadata, or an expiring link for stored files
 	var docURL string
-	if doc, err := h.adminService.GetDocument(ctx, docID); err == nil && doc != nil && doc.URL != "" {
+	if doc, err := h.adminService.GetDocument(ctx, docID); err == nil && doc != nil {
 		docURL = doc.URL
+		if docURL == "" {
+			docURL = h.signedContentURL(doc)
+		}
 	}
 
 	// Get locale from request using i18n helper
 	locale := i18n.GetLangFromRequest(r)
 
 	// Send reminders
-	result, err := h.reminderService.SendReminders(ctx, docID, user.Email, req.Emails, docURL, locale)
+	result, err := h.reminderService.SendRemindersWithLevel(ctx, docID, user.Email, req.Emails, docURL, locale, req.Level)
 	if err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to send reminders", nil)
 		return
@@ -446,6 +602,41 @@ func (h *Handler) HandleSendReminders(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleSendReminderDigests handles POST /api/v1/admin/reminders/digest
+// Each recipient receives one email listing all of their pending documents.
+func (h *Handler) HandleSendReminderDigests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.reminderService == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeInternal, "Reminder service not configured", nil)
+		return
+	}
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	// Same body as per-document reminders: no emails means every recipient with a pending document
+	var req SendRemindersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	result, err := h.reminderService.SendDigests(ctx, req.Emails, user.Email, i18n.GetLangFromRequest(r))
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to send reminder digests", nil)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Reminder digests sent",
+		"result":  result,
+	})
+}
+
 // ReminderLogResponse represents a reminder log entry in API responses
 type ReminderLogResponse struct {
 	ID             int64   `json:"id"`
@@ -497,7 +688,8 @@ func (h *Handler) HandleGetReminderHistory(w http.ResponseWriter, r *http.Reques
 	shared.WriteJSON(w, http.StatusOK, response)
 }
 
-// UpdateDocumentMetadataRequest represents the request body for updating document metadata
+// UpdateDocumentMetadataRequest represents the request body for updating document metadata.
+// Only provided fields are persisted; Version (or an If-Match header) enables optimistic locking.
 type UpdateDocumentMetadataRequest struct {
 	Title             *string `json:"title,omitempty"`
 	URL               *string `json:"url,omitempty"`
@@ -508,9 +700,83 @@ type UpdateDocumentMetadataRequest struct {
 	AllowDownload     *bool   `json:"allowDownload,omitempty"`
 	RequireFullRead   *bool   `json:"requireFullRead,omitempty"`
 	VerifyChecksum    *bool   `json:"verifyChecksum,omitempty"`
+	Version           *int    `json:"version,omitempty"`
+
+	// LandingMessage replaces the localized Markdown variants shown on the signing page;
+	// an empty object clears it
+	LandingMessage models.LandingMessage `json:"landingMessage,omitempty"`
+}
+
+func (req UpdateDocumentMetadataRequest) toPatch() models.DocumentPatch {
+	return models.DocumentPatch{
+		Title:             req.Title,
+		URL:               req.URL,
+		Checksum:          req.Checksum,
+		ChecksumAlgorithm: req.ChecksumAlgorithm,
+		Description:       req.Description,
+		ReadMode:          req.ReadMode,
+		AllowDownload:     req.AllowDownload,
+		RequireFullRead:   req.RequireFullRead,
+		VerifyChecksum:    req.VerifyChecksum,
+		LandingMessage:    req.LandingMessage,
+	}
+}
+
+// toInput builds a creation input; nil fields fall back to repository defaults
+func (req UpdateDocumentMetadataRequest) toInput() models.DocumentInput {
+	input := models.DocumentInput{
+		AllowDownload:   req.AllowDownload,
+		RequireFullRead: req.RequireFullRead,
+		VerifyChecksum:  req.VerifyChecksum,
+		LandingMessage:  req.LandingMessage,
+	}
+	if req.Title != nil {
+		input.Title = *req.Title
+	}
+	if req.URL != nil {
+		input.URL = *req.URL
+	}
+	if req.Checksum != nil {
+		input.Checksum = *req.Checksum
+	}
+	if req.ChecksumAlgorithm != nil {
+		input.ChecksumAlgorithm = *req.ChecksumAlgorithm
+	}
+	if req.Description != nil {
+		input.Description = *req.Description
+	}
+	if req.ReadMode != nil {
+		input.ReadMode = *req.ReadMode
+	}
+	return input
+}
+
+// parseMetadataRequest decodes the request body and resolves the optimistic locking precondition
+func parseMetadataRequest(w http.ResponseWriter, r *http.Request) (*UpdateDocumentMetadataRequest, *int, bool) {
+	var req UpdateDocumentMetadataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return nil, nil, false
+	}
+
+	landingMessage, err := req.LandingMessage.Normalize()
+	if err != nil {
+		shared.WriteValidationError(w, "Invalid landing message", map[string]string{"landingMessage": err.Error()})
+		return nil, nil, false
+	}
+	req.LandingMessage = landingMessage
+
+	expectedVersion, err := shared.ResolveExpectedVersion(r, req.Version)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid version precondition", nil)
+		return nil, nil, false
+	}
+
+	return &req, expectedVersion, true
 }
 
 // HandleUpdateDocumentMetadata handles PUT /api/v1/admin/documents/{docId}/metadata
+// Existing documents are updated with partial semantics; unknown documents are created.
 func (h *Handler) HandleUpdateDocumentMetadata(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	docID := chi.URLParam(r, "docId")
@@ -527,75 +793,88 @@ func (h *Handler) HandleUpdateDocumentMetadata(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// Parse request body
-	var req UpdateDocumentMetadataRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+	req, expectedVersion, ok := parseMetadataRequest(w, r)
+	if !ok {
 		return
 	}
 
-	// Get existing document or create new one
-	doc, err := h.adminService.GetDocument(ctx, docID)
-	if err != nil || doc == nil {
-		// Document doesn't exist, create a new one
-		doc = &models.Document{
-			DocID:     docID,
-			CreatedBy: user.Email,
-		}
+	// Existing document: only persist provided fields so concurrent edits are not clobbered
+	if doc, err := h.adminService.GetDocument(ctx, docID); err == nil && doc != nil {
+		h.patchDocument(w, r, docID, req.toPatch(), expectedVersion)
+		return
 	}
 
-	// Update fields if provided
-	if req.Title != nil {
-		doc.Title = *req.Title
-	}
-	if req.URL != nil {
-		doc.URL = *req.URL
-	}
-	if req.Checksum != nil {
-		doc.Checksum = *req.Checksum
-	}
-	if req.ChecksumAlgorithm != nil {
-		doc.ChecksumAlgorithm = *req.ChecksumAlgorithm
-	}
-	if req.Description != nil {
-		doc.Description = *req.Description
+	// A version precondition cannot match a document that does not exist
+	if expectedVersion != nil {
+		shared.WriteConflict(w, "Document has been deleted or does not exist")
+		return
 	}
-	if req.ReadMode != nil {
-		doc.ReadMode = *req.ReadMode
+
+	doc, err := h.adminService.UpdateDocumentMetadata(ctx, docID, req.toInput(), user.Email)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to update document metadata", nil)
+		return
 	}
-	if req.AllowDownload != nil {
-		doc.AllowDownload = *req.AllowDownload
+
+	shared.SetVersionETag(w, doc.Version)
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"message":  "Document metadata updated successfully",
+		"document": toDocumentResponse(doc),
+	})
+}
+
+// HandlePatchDocumentMetadata handles PATCH /api/v1/admin/documents/{docId}/metadata
+func (h *Handler) HandlePatchDocumentMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	docID := chi.URLParam(r, "docId")
+
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
 	}
-	if req.RequireFullRead != nil {
-		doc.RequireFullRead = *req.RequireFullRead
+
+	if _, ok := shared.GetUserFromContext(ctx); !ok {
+		shared.WriteUnauthorized(w, "")
+		return
 	}
-	if req.VerifyChecksum != nil {
-		doc.VerifyChecksum = *req.VerifyChecksum
+
+	req, expectedVersion, ok := parseMetadataRequest(w, r)
+	if !ok {
+		return
 	}
 
-	// Save document using CreateOrUpdate (preserve storage fields from existing document)
-	input := models.DocumentInput{
-		Title:             doc.Title,
-		URL:               doc.URL,
-		Checksum:          doc.Checksum,
-		ChecksumAlgorithm: doc.ChecksumAlgorithm,
-		Description:       doc.Description,
-		ReadMode:          doc.ReadMode,
-		AllowDownload:     &doc.AllowDownload,
-		RequireFullRead:   &doc.RequireFullRead,
-		VerifyChecksum:    &doc.VerifyChecksum,
-		StorageKey:        doc.StorageKey,
-		StorageProvider:   doc.StorageProvider,
-		FileSize:          doc.FileSize,
-		MimeType:          doc.MimeType,
-		OriginalFilename:  doc.OriginalFilename,
+	patch := req.toPatch()
+	if patch.IsEmpty() {
+		shared.WriteValidationError(w, "No fields to update", nil)
+		return
 	}
-	doc, err = h.adminService.UpdateDocumentMetadata(ctx, docID, input, user.Email)
-	if err != nil {
+
+	h.patchDocument(w, r, docID, patch, expectedVersion)
+}
+
+// patchDocument applies a partial update and maps locking failures to 409 Conflict
+func (h *Handler) patchDocument(w http.ResponseWriter, r *http.Request, docID string, patch models.DocumentPatch, expectedVersion *int) {
+	ctx := r.Context()
+
+	doc, err := h.adminService.PatchDocumentMetadata(ctx, docID, patch, expectedVersion)
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+		return
+	case errors.Is(err, models.ErrVersionConflict):
+		details := map[string]interface{}{}
+		if current, err := h.adminService.GetDocument(ctx, docID); err == nil && current != nil {
+			details["currentVersion"] = current.Version
+		}
+		shared.WriteError(w, http.StatusConflict, shared.ErrCodeConflict, "Document has been modified by another request", details)
+		return
+	case err != nil:
+		logger.Logger.Error("Failed to patch document metadata", "error", err.Error(), "doc_id", docID)
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to update document metadata", nil)
 		return
 	}
 
+	shared.SetVersionETag(w, doc.Version)
 	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"message":  "Document metadata updated successfully",
 		"document": toDocumentResponse(doc),
@@ -611,6 +890,8 @@ type DocumentStatusResponse struct {
 	Stats                *DocumentStatsResponse         `json:"stats"`
 	ReminderStats        *ReminderStatsResponse         `json:"reminderStats,omitempty"`
 	ShareLink            string                         `json:"shareLink"`
+	SignedContentURL     string                         `json:"signedContentUrl,omitempty"` // Expiring download link of the stored file
+	CurrentSignOrder     int                            `json:"currentSignOrder,omitempty"` // Position awaiting signatures in a sequential workflow
 }
 
 // ReminderStatsResponse represents reminder statistics
@@ -630,74 +911,53 @@ func (h *Handler) HandleGetDocumentStatus(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Document, signers, signatures and reminder totals come from a single query
+	status, err := h.adminService.GetDocumentStatus(ctx, docID)
+	if err != nil {
+		logger.Logger.Error("Failed to get document status", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
 	response := &DocumentStatusResponse{
 		DocID:                docID,
-		ExpectedSigners:      []*ExpectedSignerResponse{},
-		UnexpectedSignatures: []*UnexpectedSignatureResponse{},
+		ExpectedSigners:      make([]*ExpectedSignerResponse, 0, len(status.Signers)),
+		UnexpectedSignatures: make([]*UnexpectedSignatureResponse, 0, len(status.UnexpectedSignatures)),
+		Stats:                toStatsResponse(status.Stats),
 		ShareLink:            h.baseURL + "/?doc=" + docID,
+		CurrentSignOrder:     models.CurrentSignOrder(status.Signers),
 	}
 
-	// Get document (optional)
-	if doc, err := h.adminService.GetDocument(ctx, docID); err == nil && doc != nil {
-		response.Document = toDocumentResponse(doc)
+	if status.Document != nil {
+		response.Document = toDocumentResponse(status.Document)
+		response.SignedContentURL = h.signedContentURL(status.Document)
 	}
 
-	// Get expected signers with status
-	expectedEmails := make(map[string]bool)
-	if signers, err := h.adminService.ListExpectedSignersWithStatus(ctx, docID); err == nil {
-		for _, signer := range signers {
-			response.ExpectedSigners = append(response.ExpectedSigners, toExpectedSignerResponse(signer))
-			expectedEmails[signer.Email] = true
-		}
+	for _, signer := range status.Signers {
+		response.ExpectedSigners = append(response.ExpectedSigners, toExpectedSignerResponse(signer))
 	}
 
-	// Get all signatures for this document and find unexpected ones
-	if h.signatureService != nil {
-		if signatures, err := h.signatureService.GetDocumentSignatures(ctx, docID); err == nil {
-			for _, sig := range signatures {
-				// If this signature's email is not in the expected list, it's unexpected
-				if !expectedEmails[sig.UserEmail] {
-					userName := sig.UserName
-					response.UnexpectedSignatures = append(response.UnexpectedSignatures, &UnexpectedSignatureResponse{
-						UserEmail:   sig.UserEmail,
-						UserName:    &userName,
-						SignedAtUTC: sig.SignedAtUTC.Format("2006-01-02T15:04:05Z07:00"),
-					})
-				}
-			}
-		}
+	for _, sig := range status.UnexpectedSignatures {
+		userName := sig.UserName
+		response.UnexpectedSignatures = append(response.UnexpectedSignatures, &UnexpectedSignatureResponse{
+			UserEmail:      sig.UserEmail,
+			UserName:       &userName,
+			SignedAtUTC:    sig.SignedAt.Format("2006-01-02T15:04:05Z07:00"),
+			ApprovalID:     sig.ApprovalID,
+			ApprovalStatus: string(sig.ApprovalStatus),
+		})
 	}
 
-	// Get completion stats
-	if stats, err := h.adminService.GetSignerStats(ctx, docID); err == nil {
-		response.Stats = toStatsResponse(stats)
-	} else {
-		// Default stats if no expected signers
-		response.Stats = &DocumentStatsResponse{
-			DocID:          docID,
-			ExpectedCount:  0,
-			SignedCount:    0,
-			PendingCount:   0,
-			CompletionRate: 0,
+	if reminderStats := status.ReminderStats; reminderStats != nil {
+		var lastSentAt *string
+		if reminderStats.LastSentAt != nil {
+			formatted := reminderStats.LastSentAt.Format("2006-01-02T15:04:05Z07:00")
+			lastSentAt = &formatted
 		}
-	}
-
-	// Get reminder stats if service available
-	if h.reminderService != nil {
-		reminderStats, err := h.reminderService.GetReminderStats(ctx, docID)
-		if err == nil && reminderStats != nil {
-			var lastSentAt *string
-			if reminderStats.LastSentAt != nil {
-				formatted := reminderStats.LastSentAt.Format("2006-01-02T15:04:05Z07:00")
-				lastSentAt = &formatted
-			}
-			response.ReminderStats = &ReminderStatsResponse{
-				TotalSent:    reminderStats.TotalSent,
-				PendingCount: reminderStats.PendingCount,
-				LastSentAt:   lastSentAt,
-			}
-		} else if err != nil {
-			logger.Logger.Debug("Failed to get reminder stats", "doc_id", docID, "error", err.Error())
+		response.ReminderStats = &ReminderStatsResponse{
+			TotalSent:    reminderStats.TotalSent,
+			PendingCount: reminderStats.PendingCount,
+			LastSentAt:   lastSentAt,
 		}
 	}
 
@@ -780,6 +1040,38 @@ func (h *Handler) HandlePreviewCSV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Move the emails refused by the signer email policy to the errors of their line
+	if len(result.Signers) > 0 {
+		emails := make([]string, len(result.Signers))
+		for i, entry := range result.Signers {
+			emails[i] = entry.Email
+		}
+		if rejections := h.checkSignerEmails(ctx, emails); len(rejections) > 0 {
+			rejected := make(map[int]models.SignerEmailRejection, len(rejections))
+			for _, rejection := range rejections {
+				rejected[rejection.Row-1] = rejection
+			}
+			accepted := make([]services.CSVSignerEntry, 0, len(result.Signers)-len(rejections))
+			for i, entry := range result.Signers {
+				rejection, ok := rejected[i]
+				if !ok {
+					accepted = append(accepted, entry)
+					continue
+				}
+				result.Errors = append(result.Errors, services.CSVParseError{
+					LineNumber: entry.LineNumber,
+					Content:    entry.Email,
+					Error:      rejection.Message,
+					Reason:     string(rejection.Reason),
+				})
+			}
+			sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].LineNumber < result.Errors[j].LineNumber })
+			result.Signers = accepted
+			result.ValidCount -= len(rejections)
+			result.InvalidCount += len(rejections)
+		}
+	}
+
 	// Get existing signers for this document to identify duplicates
 	existingEmails := []string{}
 	existingSigners, err := h.adminService.ListExpectedSigners(ctx, docID)
@@ -818,8 +1110,9 @@ type ImportSignersRequest struct {
 
 // ImportSignerEntry represents a single signer to import
 type ImportSignerEntry struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	SignOrder *int   `json:"signOrder,omitempty"`
 }
 
 // ImportSignersResponse represents the response for signer import
@@ -828,6 +1121,8 @@ type ImportSignersResponse struct {
 	Imported int    `json:"imported"`
 	Skipped  int    `json:"skipped"`
 	Total    int    `json:"total"`
+	// Rejected lists the signers refused by the signer email policy, which were not imported
+	Rejected []models.SignerEmailRejection `json:"rejected"`
 }
 
 // HandleImportSigners handles POST /api/v1/admin/documents/{docId}/signers/import
@@ -875,36 +1170,61 @@ func (h *Handler) HandleImportSigners(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Signers refused by the signer email policy are reported, not imported
+	emails := make([]string, len(req.Signers))
+	for i, signer := range req.Signers {
+		emails[i] = strings.TrimSpace(signer.Email)
+	}
+	rejections := h.checkSignerEmails(ctx, emails)
+	if rejections == nil {
+		rejections = []models.SignerEmailRejection{}
+	}
+	rejectedRows := make(map[int]bool, len(rejections))
+	for _, rejection := range rejections {
+		rejectedRows[rejection.Row-1] = true
+	}
+
 	// Count how many will be skipped (already exist)
 	skippedCount := 0
-	for _, signer := range req.Signers {
-		if existingEmailsMap[strings.ToLower(signer.Email)] {
+	for i, signer := range req.Signers {
+		if !rejectedRows[i] && existingEmailsMap[strings.ToLower(signer.Email)] {
 			skippedCount++
 		}
 	}
 
 	// Convert to ContactInfo slice
 	contacts := make([]models.ContactInfo, 0, len(req.Signers))
-	for _, signer := range req.Signers {
+	for i, signer := range req.Signers {
+		if signer.SignOrder != nil && *signer.SignOrder < 1 {
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Sign order must be a positive number", map[string]interface{}{"email": signer.Email})
+			return
+		}
+		if rejectedRows[i] {
+			continue
+		}
 		contacts = append(contacts, models.ContactInfo{
-			Email: strings.ToLower(strings.TrimSpace(signer.Email)),
-			Name:  strings.TrimSpace(signer.Name),
+			Email:     strings.ToLower(strings.TrimSpace(signer.Email)),
+			Name:      strings.TrimSpace(signer.Name),
+			SignOrder: signer.SignOrder,
 		})
 	}
 
 	// Add all signers (repository handles duplicates with ON CONFLICT DO NOTHING)
-	if err := h.adminService.AddExpectedSigners(ctx, docID, contacts, user.Email); err != nil {
-		logger.Logger.Error("Failed to import signers", "error", err.Error(), "doc_id", docID, "count", len(contacts))
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to import signers", nil)
-		return
+	if len(contacts) > 0 {
+		if err := h.adminService.AddExpectedSigners(ctx, docID, contacts, user.Email); err != nil {
+			logger.Logger.Error("Failed to import signers", "error", err.Error(), "doc_id", docID, "count", len(contacts))
+			shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to import signers", nil)
+			return
+		}
 	}
 
-	importedCount := len(req.Signers) - skippedCount
+	importedCount := len(contacts) - skippedCount
 
 	logger.Logger.Info("Signers imported successfully",
 		"doc_id", docID,
 		"imported", importedCount,
 		"skipped", skippedCount,
+		"rejected", len(rejections),
 		"total", len(req.Signers),
 		"imported_by", user.Email)
 
@@ -913,5 +1233,6 @@ func (h *Handler) HandleImportSigners(w http.ResponseWriter, r *http.Request) {
 		Imported: importedCount,
 		Skipped:  skippedCount,
 		Total:    len(req.Signers),
+		Rejected: rejections,
 	})
 }
@@ -89,7 +89,7 @@ func TestAdminHandler_GetDocumentStatus_WithUnexpectedSignatures(t *testing.T) {
 
 	// Create admin handler
 	adminService := services.NewAdminService(docRepo, expectedSignerRepo)
-	handler := admin.NewHandler(adminService, nil, sigService, "https://example.com", 500)
+	handler := admin.NewHandler(adminService, nil, "https://example.com", 500)
 
 	// Create HTTP request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents/"+docID+"/status", nil)
@@ -213,7 +213,7 @@ func TestAdminHandler_GetDocumentStatus_NoExpectedSigners(t *testing.T) {
 
 	// Create admin handler
 	adminService := services.NewAdminService(docRepo, expectedSignerRepo)
-	handler := admin.NewHandler(adminService, nil, sigService, "https://example.com", 500)
+	handler := admin.NewHandler(adminService, nil, "https://example.com", 500)
 
 	// Create HTTP request
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents/"+docID+"/status", nil)
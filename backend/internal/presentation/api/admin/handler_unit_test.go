@@ -28,8 +28,13 @@ type mockAdminService struct {
 	listDocumentsFunc                 func(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	searchDocumentsFunc               func(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
 	countDocumentsFunc                func(ctx context.Context, searchQuery string) (int, error)
+	listDocumentsByCreatorFunc        func(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
+	searchDocumentsByCreatorFunc      func(ctx context.Context, createdBy, query string, limit, offset int) ([]*models.Document, error)
+	countDocumentsByCreatorFunc       func(ctx context.Context, createdBy, searchQuery string) (int, error)
 	updateDocumentMetadataFunc        func(ctx context.Context, docID string, input models.DocumentInput, updatedBy string) (*models.Document, error)
+	patchDocumentMetadataFunc         func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error)
 	deleteDocumentFunc                func(ctx context.Context, docID string) error
+	getDocumentStatusFunc             func(ctx context.Context, docID string) (*models.DocumentStatus, error)
 	listExpectedSignersFunc           func(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
 	listExpectedSignersWithStatusFunc func(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
 	addExpectedSignersFunc            func(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
@@ -65,6 +70,27 @@ func (m *mockAdminService) CountDocuments(ctx context.Context, searchQuery strin
 	return 0, errors.New("not implemented")
 }
 
+func (m *mockAdminService) ListDocumentsByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error) {
+	if m.listDocumentsByCreatorFunc != nil {
+		return m.listDocumentsByCreatorFunc(ctx, createdBy, limit, offset)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockAdminService) SearchDocumentsByCreator(ctx context.Context, createdBy, query string, limit, offset int) ([]*models.Document, error) {
+	if m.searchDocumentsByCreatorFunc != nil {
+		return m.searchDocumentsByCreatorFunc(ctx, createdBy, query, limit, offset)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockAdminService) CountDocumentsByCreator(ctx context.Context, createdBy, searchQuery string) (int, error) {
+	if m.countDocumentsByCreatorFunc != nil {
+		return m.countDocumentsByCreatorFunc(ctx, createdBy, searchQuery)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func (m *mockAdminService) UpdateDocumentMetadata(ctx context.Context, docID string, input models.DocumentInput, updatedBy string) (*models.Document, error) {
 	if m.updateDocumentMetadataFunc != nil {
 		return m.updateDocumentMetadataFunc(ctx, docID, input, updatedBy)
@@ -72,6 +98,13 @@ func (m *mockAdminService) UpdateDocumentMetadata(ctx context.Context, docID str
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockAdminService) PatchDocumentMetadata(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+	if m.patchDocumentMetadataFunc != nil {
+		return m.patchDocumentMetadataFunc(ctx, docID, patch, expectedVersion)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockAdminService) DeleteDocument(ctx context.Context, docID string) error {
 	if m.deleteDocumentFunc != nil {
 		return m.deleteDocumentFunc(ctx, docID)
@@ -79,6 +112,13 @@ func (m *mockAdminService) DeleteDocument(ctx context.Context, docID string) err
 	return errors.New("not implemented")
 }
 
+func (m *mockAdminService) GetDocumentStatus(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+	if m.getDocumentStatusFunc != nil {
+		return m.getDocumentStatusFunc(ctx, docID)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockAdminService) ListExpectedSigners(ctx context.Context, docID string) ([]*models.ExpectedSigner, error) {
 	if m.listExpectedSignersFunc != nil {
 		return m.listExpectedSignersFunc(ctx, docID)
@@ -117,10 +157,12 @@ func (m *mockAdminService) GetSignerStats(ctx context.Context, docID string) (*m
 type mockReminderService struct {
 	sendRemindersFunc      func(ctx context.Context, docID, sentBy string, specificEmails []string, docURL string, locale string) (*models.ReminderSendResult, error)
 	getReminderHistoryFunc func(ctx context.Context, docID string) ([]*models.ReminderLog, error)
-	getReminderStatsFunc   func(ctx context.Context, docID string) (*models.ReminderStats, error)
+	sendDigestsFunc        func(ctx context.Context, emails []string, sentBy, locale string) (*models.ReminderDigestResult, error)
+	sentLevel              models.ReminderLevel
 }
 
-func (m *mockReminderService) SendReminders(ctx context.Context, docID, sentBy string, specificEmails []string, docURL string, locale string) (*models.ReminderSendResult, error) {
+func (m *mockReminderService) SendRemindersWithLevel(ctx context.Context, docID, sentBy string, specificEmails []string, docURL string, locale string, level models.ReminderLevel) (*models.ReminderSendResult, error) {
+	m.sentLevel = level
 	if m.sendRemindersFunc != nil {
 		return m.sendRemindersFunc(ctx, docID, sentBy, specificEmails, docURL, locale)
 	}
@@ -134,20 +176,9 @@ func (m *mockReminderService) GetReminderHistory(ctx context.Context, docID stri
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockReminderService) GetReminderStats(ctx context.Context, docID string) (*models.ReminderStats, error) {
-	if m.getReminderStatsFunc != nil {
-		return m.getReminderStatsFunc(ctx, docID)
-	}
-	return nil, errors.New("not implemented")
-}
-
-type mockSignatureService struct {
-	getDocumentSignaturesFunc func(ctx context.Context, docID string) ([]*models.Signature, error)
-}
-
-func (m *mockSignatureService) GetDocumentSignatures(ctx context.Context, docID string) ([]*models.Signature, error) {
-	if m.getDocumentSignaturesFunc != nil {
-		return m.getDocumentSignaturesFunc(ctx, docID)
+func (m *mockReminderService) SendDigests(ctx context.Context, emails []string, sentBy, locale string) (*models.ReminderDigestResult, error) {
+	if m.sendDigestsFunc != nil {
+		return m.sendDigestsFunc(ctx, emails, sentBy, locale)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -156,8 +187,8 @@ func (m *mockSignatureService) GetDocumentSignatures(ctx context.Context, docID
 // HELPERS
 // ============================================================================
 
-func createTestHandler(adminSvc adminService, reminderSvc reminderService, sigService signatureService) *Handler {
-	return NewHandler(adminSvc, reminderSvc, sigService, "https://test.example.com", 500)
+func createTestHandler(adminSvc adminService, reminderSvc reminderService) *Handler {
+	return NewHandler(adminSvc, reminderSvc, "https://test.example.com", 500)
 }
 
 func createContextWithUser(email string, isAdmin bool) context.Context {
@@ -245,7 +276,7 @@ func TestHandleListDocuments_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents", nil)
 	rec := httptest.NewRecorder()
 
@@ -263,6 +294,67 @@ func TestHandleListDocuments_Success(t *testing.T) {
 	assert.Equal(t, 2, int(response.Meta["total"].(float64)))
 }
 
+func TestHandleListDocuments_Fields(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		listDocumentsFunc: func(ctx context.Context, limit, offset int) ([]*models.Document, error) {
+			return []*models.Document{createTestDocument("doc1"), createTestDocument("doc2")}, nil
+		},
+		countDocumentsFunc: func(ctx context.Context, searchQuery string) (int, error) {
+			return 2, nil
+		},
+	}
+	handler := createTestHandler(adminSvc, nil)
+
+	tests := []struct {
+		name   string
+		fields string
+		keys   []string
+	}{
+		{"title", "title", []string{"docId", "title"}},
+		{"title and dates", "title,createdAt,updatedAt", []string{"docId", "title", "createdAt", "updatedAt"}},
+		{"key only", "docId", []string{"docId"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents?fields="+tt.fields, nil)
+			rec := httptest.NewRecorder()
+
+			handler.HandleListDocuments(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var response struct {
+				Data []map[string]interface{} `json:"data"`
+				Meta map[string]interface{}   `json:"meta"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Data, 2)
+			for _, doc := range response.Data {
+				assert.Len(t, doc, len(tt.keys))
+				for _, key := range tt.keys {
+					assert.Contains(t, doc, key)
+				}
+			}
+			assert.Equal(t, "doc1", response.Data[0]["docId"])
+			assert.Equal(t, 2, int(response.Meta["total"].(float64)))
+		})
+	}
+}
+
+func TestHandleListDocuments_UnknownFields(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler(&mockAdminService{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents?fields=title,secret", nil)
+	rec := httptest.NewRecorder()
+
+	handler.HandleListDocuments(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Unknown fields: secret")
+}
+
 func TestHandleListDocuments_EmptyList(t *testing.T) {
 	t.Parallel()
 
@@ -272,7 +364,7 @@ func TestHandleListDocuments_EmptyList(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents", nil)
 	rec := httptest.NewRecorder()
 
@@ -298,7 +390,7 @@ func TestHandleListDocuments_RepositoryError(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents", nil)
 	rec := httptest.NewRecorder()
 
@@ -307,6 +399,63 @@ func TestHandleListDocuments_RepositoryError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
+func TestHandleListDocuments_OwnerFilter(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		listDocumentsByCreatorFunc: func(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error) {
+			assert.Equal(t, "alice@example.com", createdBy)
+			return []*models.Document{createTestDocument("doc1")}, nil
+		},
+		countDocumentsByCreatorFunc: func(ctx context.Context, createdBy, searchQuery string) (int, error) {
+			assert.Equal(t, "alice@example.com", createdBy)
+			return 1, nil
+		},
+	}
+
+	handler := createTestHandler(adminSvc, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents?owner=alice@example.com", nil)
+	rec := httptest.NewRecorder()
+
+	handler.HandleListDocuments(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Data []DocumentResponse     `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	err := json.Unmarshal(rec.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "alice@example.com", response.Meta["owner"])
+}
+
+func TestHandleListDocuments_OwnerMe(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		searchDocumentsByCreatorFunc: func(ctx context.Context, createdBy, query string, limit, offset int) ([]*models.Document, error) {
+			assert.Equal(t, "admin@example.com", createdBy)
+			assert.Equal(t, "policy", query)
+			return []*models.Document{}, nil
+		},
+		countDocumentsByCreatorFunc: func(ctx context.Context, createdBy, searchQuery string) (int, error) {
+			assert.Equal(t, "policy", searchQuery)
+			return 0, nil
+		},
+	}
+
+	handler := createTestHandler(adminSvc, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents?owner=me&search=policy", nil)
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	handler.HandleListDocuments(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
 // ============================================================================
 // TESTS - HandleGetDocument
 // ============================================================================
@@ -322,7 +471,7 @@ func TestHandleGetDocument_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}", handler.HandleGetDocument)
@@ -352,7 +501,7 @@ func TestHandleGetDocument_NotFound(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}", handler.HandleGetDocument)
@@ -368,7 +517,7 @@ func TestHandleGetDocument_NotFound(t *testing.T) {
 func TestHandleGetDocument_EmptyDocID(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	// Without chi routing context, docId will be empty
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents/", nil)
@@ -411,7 +560,7 @@ func TestHandleGetDocumentWithSigners_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/signers", handler.HandleGetDocumentWithSigners)
@@ -433,6 +582,69 @@ func TestHandleGetDocumentWithSigners_Success(t *testing.T) {
 	assert.NotNil(t, response.Data["stats"])
 }
 
+func TestHandleGetDocumentWithSigners_Fields(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
+			return createTestDocument("doc1"), nil
+		},
+		listExpectedSignersWithStatusFunc: func(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error) {
+			return []*models.ExpectedSignerWithStatus{
+				createTestExpectedSignerWithStatus("doc1", "signer1@example.com", true),
+				createTestExpectedSignerWithStatus("doc1", "signer2@example.com", false),
+			}, nil
+		},
+		getSignerStatsFunc: func(ctx context.Context, docID string) (*models.DocCompletionStats, error) {
+			return &models.DocCompletionStats{DocID: "doc1", ExpectedCount: 2, SignedCount: 1}, nil
+		},
+	}
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/admin/documents/{docId}/signers", handler.HandleGetDocumentWithSigners)
+
+	tests := []struct {
+		name   string
+		fields string
+		keys   []string
+	}{
+		{"status", "hasSigned", []string{"email", "hasSigned"}},
+		{"status and reminders", "name,hasSigned,reminderCount", []string{"email", "name", "hasSigned", "reminderCount"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents/doc1/signers?fields="+tt.fields, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			var response struct {
+				Data struct {
+					Document map[string]interface{}   `json:"document"`
+					Signers  []map[string]interface{} `json:"signers"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			require.Len(t, response.Data.Signers, 2)
+			for _, signer := range response.Data.Signers {
+				assert.Len(t, signer, len(tt.keys))
+				for _, key := range tt.keys {
+					assert.Contains(t, signer, key)
+				}
+			}
+			assert.Equal(t, true, response.Data.Signers[0]["hasSigned"])
+			assert.Contains(t, response.Data.Document, "title", "the document is not projected")
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents/doc1/signers?fields=hasSigned,ssn", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestHandleGetDocumentWithSigners_DocumentNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -442,7 +654,7 @@ func TestHandleGetDocumentWithSigners_DocumentNotFound(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/signers", handler.HandleGetDocumentWithSigners)
@@ -468,7 +680,7 @@ func TestHandleGetDocumentWithSigners_SignersError(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/signers", handler.HandleGetDocumentWithSigners)
@@ -498,7 +710,7 @@ func TestHandleAddExpectedSigner_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/signers", handler.HandleAddExpectedSigner)
@@ -528,7 +740,7 @@ func TestHandleAddExpectedSigner_Success(t *testing.T) {
 func TestHandleAddExpectedSigner_MissingEmail(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/signers", handler.HandleAddExpectedSigner)
@@ -548,10 +760,97 @@ func TestHandleAddExpectedSigner_MissingEmail(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+// blockDomainPolicy refuses the emails of one domain
+type blockDomainPolicy struct {
+	domain string
+}
+
+func (p *blockDomainPolicy) CheckEmails(_ context.Context, emails []string) []models.SignerEmailRejection {
+	rejections := []models.SignerEmailRejection{}
+	for i, email := range emails {
+		if strings.HasSuffix(email, "@"+p.domain) {
+			reason := models.SignerEmailDomainBlocked
+			rejections = append(rejections, models.SignerEmailRejection{Row: i + 1, Email: email, Reason: reason, Message: reason.Message()})
+		}
+	}
+	return rejections
+}
+
+func TestHandleAddExpectedSigner_RefusedByPolicy(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		addExpectedSignersFunc: func(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error {
+			t.Error("a refused signer must not be added")
+			return nil
+		},
+	}
+	handler := createTestHandler(adminSvc, nil)
+	handler.SetSignerEmailPolicy(&blockDomainPolicy{domain: "gmail.com"})
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/documents/{docId}/signers", handler.HandleAddExpectedSigner)
+
+	body, _ := json.Marshal(AddExpectedSignerRequest{Email: "someone@gmail.com"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/documents/doc1/signers", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), string(models.SignerEmailDomainBlocked))
+}
+
+func TestHandleImportSigners_ReportsRefusedSigners(t *testing.T) {
+	t.Parallel()
+
+	var added []models.ContactInfo
+	adminSvc := &mockAdminService{
+		listExpectedSignersFunc: func(ctx context.Context, docID string) ([]*models.ExpectedSigner, error) {
+			return []*models.ExpectedSigner{{Email: "known@corp.com"}}, nil
+		},
+		addExpectedSignersFunc: func(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error {
+			added = contacts
+			return nil
+		},
+	}
+	handler := createTestHandler(adminSvc, nil)
+	handler.SetSignerEmailPolicy(&blockDomainPolicy{domain: "gmail.com"})
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/documents/{docId}/signers/import", handler.HandleImportSigners)
+
+	body, _ := json.Marshal(ImportSignersRequest{Signers: []ImportSignerEntry{
+		{Email: "alice@corp.com"},
+		{Email: "bob@gmail.com"},
+		{Email: "known@corp.com"},
+	}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/documents/doc1/signers/import", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response struct {
+		Data ImportSignersResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Data.Imported)
+	assert.Equal(t, 1, response.Data.Skipped)
+	assert.Equal(t, 3, response.Data.Total)
+	require.Len(t, response.Data.Rejected, 1)
+	assert.Equal(t, 2, response.Data.Rejected[0].Row)
+	assert.Equal(t, models.SignerEmailDomainBlocked, response.Data.Rejected[0].Reason)
+	require.Len(t, added, 2)
+	assert.Equal(t, "alice@corp.com", added[0].Email)
+}
+
 func TestHandleAddExpectedSigner_NoUser(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/signers", handler.HandleAddExpectedSigner)
@@ -573,7 +872,7 @@ func TestHandleAddExpectedSigner_NoUser(t *testing.T) {
 func TestHandleAddExpectedSigner_InvalidJSON(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/signers", handler.HandleAddExpectedSigner)
@@ -602,7 +901,7 @@ func TestHandleRemoveExpectedSigner_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Delete("/api/v1/admin/documents/{docId}/signers/{email}", handler.HandleRemoveExpectedSigner)
@@ -624,7 +923,7 @@ func TestHandleRemoveExpectedSigner_RepositoryError(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Delete("/api/v1/admin/documents/{docId}/signers/{email}", handler.HandleRemoveExpectedSigner)
@@ -640,7 +939,7 @@ func TestHandleRemoveExpectedSigner_RepositoryError(t *testing.T) {
 func TestHandleRemoveExpectedSigner_EmptyParams(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	// Without chi routing context, params will be empty
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/documents//signers/", nil)
@@ -678,7 +977,7 @@ func TestHandleSendReminders_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, reminderSvc, nil)
+	handler := createTestHandler(adminSvc, reminderSvc)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/reminders", handler.HandleSendReminders)
@@ -698,7 +997,7 @@ func TestHandleSendReminders_Success(t *testing.T) {
 func TestHandleSendReminders_ServiceNotAvailable(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/reminders", handler.HandleSendReminders)
@@ -735,7 +1034,7 @@ func TestHandleSendReminders_WithLocale(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, reminderSvc, nil)
+	handler := createTestHandler(adminSvc, reminderSvc)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/reminders", handler.HandleSendReminders)
@@ -775,7 +1074,7 @@ func TestHandleSendReminders_SpecificEmails(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, reminderSvc, nil)
+	handler := createTestHandler(adminSvc, reminderSvc)
 
 	router := chi.NewRouter()
 	router.Post("/api/v1/admin/documents/{docId}/reminders", handler.HandleSendReminders)
@@ -794,6 +1093,101 @@ func TestHandleSendReminders_SpecificEmails(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestHandleSendReminders_Level(t *testing.T) {
+	t.Parallel()
+
+	reminderSvc := &mockReminderService{
+		sendRemindersFunc: func(ctx context.Context, docID, sentBy string, specificEmails []string, docURL string, locale string) (*models.ReminderSendResult, error) {
+			return &models.ReminderSendResult{TotalAttempted: 1, SuccessfullySent: 1}, nil
+		},
+	}
+
+	handler := createTestHandler(&mockAdminService{}, reminderSvc)
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/documents/{docId}/reminders", handler.HandleSendReminders)
+
+	body, _ := json.Marshal(SendRemindersRequest{Level: models.ReminderLevelFinal})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/documents/doc1/reminders", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, models.ReminderLevelFinal, reminderSvc.sentLevel)
+}
+
+func TestHandleSendReminders_InvalidLevel(t *testing.T) {
+	t.Parallel()
+
+	reminderSvc := &mockReminderService{}
+	handler := createTestHandler(&mockAdminService{}, reminderSvc)
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/documents/{docId}/reminders", handler.HandleSendReminders)
+
+	body, _ := json.Marshal(SendRemindersRequest{Level: "urgent"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/documents/doc1/reminders", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, reminderSvc.sentLevel)
+}
+
+// ============================================================================
+// TESTS - HandleSendReminderDigests
+// ============================================================================
+
+func TestHandleSendReminderDigests_Success(t *testing.T) {
+	t.Parallel()
+
+	reminderSvc := &mockReminderService{
+		sendDigestsFunc: func(ctx context.Context, emails []string, sentBy, locale string) (*models.ReminderDigestResult, error) {
+			assert.Equal(t, []string{"bob@example.com"}, emails)
+			assert.Equal(t, "admin@example.com", sentBy)
+			return &models.ReminderDigestResult{TotalRecipients: 1, DigestsQueued: 1, DocumentCount: 3}, nil
+		},
+	}
+
+	handler := createTestHandler(nil, reminderSvc)
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/reminders/digest", handler.HandleSendReminderDigests)
+
+	body, _ := json.Marshal(SendRemindersRequest{Emails: []string{"bob@example.com"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/reminders/digest", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"document_count":3`)
+}
+
+func TestHandleSendReminderDigests_ServiceNotAvailable(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler(nil, nil)
+
+	router := chi.NewRouter()
+	router.Post("/api/v1/admin/reminders/digest", handler.HandleSendReminderDigests)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/reminders/digest", bytes.NewReader([]byte(`{}`)))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
 // ============================================================================
 // TESTS - HandleGetReminderHistory
 // ============================================================================
@@ -814,7 +1208,7 @@ func TestHandleGetReminderHistory_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, reminderSvc, nil)
+	handler := createTestHandler(adminSvc, reminderSvc)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/reminders", handler.HandleGetReminderHistory)
@@ -837,7 +1231,7 @@ func TestHandleGetReminderHistory_Success(t *testing.T) {
 func TestHandleGetReminderHistory_ServiceNotAvailable(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/reminders", handler.HandleGetReminderHistory)
@@ -860,7 +1254,7 @@ func TestHandleGetReminderHistory_EmptyHistory(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, reminderSvc, nil)
+	handler := createTestHandler(adminSvc, reminderSvc)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/reminders", handler.HandleGetReminderHistory)
@@ -894,12 +1288,14 @@ func TestHandleUpdateDocumentMetadata_CreateNew(t *testing.T) {
 		updateDocumentMetadataFunc: func(ctx context.Context, docID string, input models.DocumentInput, createdBy string) (*models.Document, error) {
 			assert.Equal(t, "new-doc", docID)
 			assert.Equal(t, "New Document", input.Title)
+			assert.Nil(t, input.AllowDownload, "unset toggles must fall back to defaults")
+			assert.Nil(t, input.VerifyChecksum, "unset toggles must fall back to defaults")
 			assert.Equal(t, "admin@example.com", createdBy)
 			return createTestDocument(docID), nil
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
@@ -927,14 +1323,17 @@ func TestHandleUpdateDocumentMetadata_UpdateExisting(t *testing.T) {
 		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
 			return doc, nil
 		},
-		updateDocumentMetadataFunc: func(ctx context.Context, docID string, input models.DocumentInput, createdBy string) (*models.Document, error) {
-			assert.Equal(t, "Updated Title", input.Title)
-			doc.Title = input.Title
+		patchDocumentMetadataFunc: func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+			require.NotNil(t, patch.Title)
+			assert.Equal(t, "Updated Title", *patch.Title)
+			assert.Nil(t, patch.AllowDownload, "unset fields must not be persisted")
+			assert.Nil(t, expectedVersion)
+			doc.Title = *patch.Title
 			return doc, nil
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
@@ -961,17 +1360,17 @@ func TestHandleUpdateDocumentMetadata_AllFields(t *testing.T) {
 		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
 			return createTestDocument(docID), nil
 		},
-		updateDocumentMetadataFunc: func(ctx context.Context, docID string, input models.DocumentInput, createdBy string) (*models.Document, error) {
-			assert.Equal(t, "New Title", input.Title)
-			assert.Equal(t, "https://new.example.com/doc.pdf", input.URL)
-			assert.Equal(t, "xyz789", input.Checksum)
-			assert.Equal(t, "SHA-512", input.ChecksumAlgorithm)
-			assert.Equal(t, "New description", input.Description)
+		patchDocumentMetadataFunc: func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+			assert.Equal(t, "New Title", *patch.Title)
+			assert.Equal(t, "https://new.example.com/doc.pdf", *patch.URL)
+			assert.Equal(t, "xyz789", *patch.Checksum)
+			assert.Equal(t, "SHA-512", *patch.ChecksumAlgorithm)
+			assert.Equal(t, "New description", *patch.Description)
 			return createTestDocument(docID), nil
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
@@ -1002,7 +1401,7 @@ func TestHandleUpdateDocumentMetadata_AllFields(t *testing.T) {
 func TestHandleUpdateDocumentMetadata_NoUser(t *testing.T) {
 	t.Parallel()
 
-	handler := createTestHandler(nil, nil, nil)
+	handler := createTestHandler(nil, nil)
 
 	router := chi.NewRouter()
 	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
@@ -1021,64 +1420,255 @@ func TestHandleUpdateDocumentMetadata_NoUser(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestHandleUpdateDocumentMetadata_VersionConflict(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
+			doc := createTestDocument(docID)
+			doc.Version = 5
+			return doc, nil
+		},
+		patchDocumentMetadataFunc: func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+			require.NotNil(t, expectedVersion)
+			assert.Equal(t, 3, *expectedVersion)
+			return nil, models.ErrVersionConflict
+		},
+	}
+
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
+
+	body := []byte(`{"title":"Stale edit"}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/documents/doc1/metadata", bytes.NewReader(body))
+	req.Header.Set("If-Match", `"3"`)
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var response shared.ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, shared.ErrCodeConflict, response.Error.Code)
+	assert.Equal(t, float64(5), response.Error.Details["currentVersion"])
+}
+
+func TestHandleUpdateDocumentMetadata_VersionOnMissingDocument(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
+			return nil, nil
+		},
+	}
+
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
+
+	body := []byte(`{"title":"Edit","version":2}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/documents/gone/metadata", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestHandleUpdateDocumentMetadata_InvalidIfMatch(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler(&mockAdminService{}, nil)
+
+	router := chi.NewRouter()
+	router.Put("/api/v1/admin/documents/{docId}/metadata", handler.HandleUpdateDocumentMetadata)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/documents/doc1/metadata", strings.NewReader(`{"title":"x"}`))
+	req.Header.Set("If-Match", `"not-a-version"`)
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 // ============================================================================
-// TESTS - HandleGetDocumentStatus
+// TESTS - HandlePatchDocumentMetadata
 // ============================================================================
 
-func TestHandleGetDocumentStatus_Complete(t *testing.T) {
+func TestHandlePatchDocumentMetadata_Success(t *testing.T) {
 	t.Parallel()
 
-	doc := createTestDocument("doc1")
-	signers := []*models.ExpectedSignerWithStatus{
-		createTestExpectedSignerWithStatus("doc1", "expected@example.com", true),
-	}
-	stats := &models.DocCompletionStats{
-		DocID:          "doc1",
-		ExpectedCount:  1,
-		SignedCount:    1,
-		PendingCount:   0,
-		CompletionRate: 100.0,
-	}
-	signatures := []*models.Signature{
-		{
-			ID:          1,
-			DocID:       "doc1",
-			UserSub:     "unexpected-sub",
-			UserEmail:   "unexpected@example.com",
-			UserName:    "Unexpected User",
-			SignedAtUTC: time.Now(),
+	adminSvc := &mockAdminService{
+		patchDocumentMetadataFunc: func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+			require.NotNil(t, patch.AllowDownload)
+			assert.False(t, *patch.AllowDownload)
+			assert.Nil(t, patch.Title)
+			require.NotNil(t, expectedVersion)
+			assert.Equal(t, 2, *expectedVersion)
+
+			doc := createTestDocument(docID)
+			doc.AllowDownload = false
+			doc.Version = 3
+			return doc, nil
 		},
 	}
-	lastSent := time.Now()
-	reminderStats := &models.ReminderStats{
-		TotalSent:    5,
-		PendingCount: 0,
-		LastSentAt:   &lastSent,
+
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Patch("/api/v1/admin/documents/{docId}/metadata", handler.HandlePatchDocumentMetadata)
+
+	body := []byte(`{"allowDownload":false,"version":2}`)
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/documents/doc1/metadata", bytes.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+}
+
+func TestHandlePatchDocumentMetadata_NotFound(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		patchDocumentMetadataFunc: func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+			return nil, models.ErrDocumentNotFound
+		},
 	}
 
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Patch("/api/v1/admin/documents/{docId}/metadata", handler.HandlePatchDocumentMetadata)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/documents/missing/metadata", strings.NewReader(`{"title":"x"}`))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHandlePatchDocumentMetadata_EmptyPatch(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler(&mockAdminService{}, nil)
+
+	router := chi.NewRouter()
+	router.Patch("/api/v1/admin/documents/{docId}/metadata", handler.HandlePatchDocumentMetadata)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/documents/doc1/metadata", strings.NewReader(`{}`))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandlePatchDocumentMetadata_LandingMessage(t *testing.T) {
+	t.Parallel()
+
 	adminSvc := &mockAdminService{
-		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
+		patchDocumentMetadataFunc: func(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error) {
+			assert.Equal(t, models.LandingMessage{"fr": "Lisez **tout**", "en": "Read it all"}, patch.LandingMessage)
+			assert.Nil(t, patch.Title)
+
+			doc := createTestDocument(docID)
+			doc.LandingMessage = patch.LandingMessage
 			return doc, nil
 		},
-		listExpectedSignersWithStatusFunc: func(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error) {
-			return signers, nil
+	}
+
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Patch("/api/v1/admin/documents/{docId}/metadata", handler.HandlePatchDocumentMetadata)
+
+	body := `{"landingMessage":{"fr-FR":"  Lisez **tout**  ","en":"Read it all","de":""}}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/documents/doc1/metadata", strings.NewReader(body))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response struct {
+		Data struct {
+			Document DocumentResponse `json:"document"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Read it all", response.Data.Document.LandingMessage["en"])
+}
+
+func TestHandlePatchDocumentMetadata_InvalidLandingMessage(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler(&mockAdminService{}, nil)
+
+	router := chi.NewRouter()
+	router.Patch("/api/v1/admin/documents/{docId}/metadata", handler.HandlePatchDocumentMetadata)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/documents/doc1/metadata", strings.NewReader(`{"landingMessage":{"xx":"text"}}`))
+	req = req.WithContext(createContextWithUser("admin@example.com", true))
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "landingMessage")
+}
+
+// ============================================================================
+// TESTS - HandleGetDocumentStatus
+// ============================================================================
+
+func TestHandleGetDocumentStatus_Complete(t *testing.T) {
+	t.Parallel()
+
+	lastSent := time.Now()
+	status := &models.DocumentStatus{
+		DocID:    "doc1",
+		Document: createTestDocument("doc1"),
+		Signers: []*models.ExpectedSignerWithStatus{
+			createTestExpectedSignerWithStatus("doc1", "expected@example.com", true),
 		},
-		getSignerStatsFunc: func(ctx context.Context, docID string) (*models.DocCompletionStats, error) {
-			return stats, nil
+		UnexpectedSignatures: []*models.UnexpectedSignature{
+			{UserEmail: "unexpected@example.com", UserName: "Unexpected User", SignedAt: time.Now()},
 		},
-	}
-	sigService := &mockSignatureService{
-		getDocumentSignaturesFunc: func(ctx context.Context, docID string) ([]*models.Signature, error) {
-			return signatures, nil
+		Stats: &models.DocCompletionStats{
+			DocID:          "doc1",
+			ExpectedCount:  1,
+			SignedCount:    1,
+			PendingCount:   0,
+			CompletionRate: 100.0,
+		},
+		ReminderStats: &models.ReminderStats{
+			TotalSent:    5,
+			PendingCount: 0,
+			LastSentAt:   &lastSent,
 		},
 	}
-	reminderSvc := &mockReminderService{
-		getReminderStatsFunc: func(ctx context.Context, docID string) (*models.ReminderStats, error) {
-			return reminderStats, nil
+
+	adminSvc := &mockAdminService{
+		getDocumentStatusFunc: func(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+			return status, nil
 		},
 	}
 
-	handler := createTestHandler(adminSvc, reminderSvc, sigService)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/status", handler.HandleGetDocumentStatus)
@@ -1101,7 +1691,9 @@ func TestHandleGetDocumentStatus_Complete(t *testing.T) {
 	assert.Len(t, response.Data.UnexpectedSignatures, 1)
 	assert.Equal(t, "unexpected@example.com", response.Data.UnexpectedSignatures[0].UserEmail)
 	assert.NotNil(t, response.Data.Stats)
-	assert.NotNil(t, response.Data.ReminderStats)
+	require.NotNil(t, response.Data.ReminderStats)
+	assert.Equal(t, 5, response.Data.ReminderStats.TotalSent)
+	assert.NotNil(t, response.Data.ReminderStats.LastSentAt)
 	assert.Contains(t, response.Data.ShareLink, "doc1")
 }
 
@@ -1109,18 +1701,18 @@ func TestHandleGetDocumentStatus_MinimalData(t *testing.T) {
 	t.Parallel()
 
 	adminSvc := &mockAdminService{
-		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
-			return nil, errors.New("not found")
-		},
-		listExpectedSignersWithStatusFunc: func(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error) {
-			return []*models.ExpectedSignerWithStatus{}, nil
-		},
-		getSignerStatsFunc: func(ctx context.Context, docID string) (*models.DocCompletionStats, error) {
-			return nil, errors.New("no stats")
+		getDocumentStatusFunc: func(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+			return &models.DocumentStatus{
+				DocID:                docID,
+				Signers:              []*models.ExpectedSignerWithStatus{},
+				UnexpectedSignatures: []*models.UnexpectedSignature{},
+				Stats:                &models.DocCompletionStats{DocID: docID},
+				ReminderStats:        &models.ReminderStats{},
+			}, nil
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/status", handler.HandleGetDocumentStatus)
@@ -1145,6 +1737,28 @@ func TestHandleGetDocumentStatus_MinimalData(t *testing.T) {
 	assert.Equal(t, 0.0, response.Data.Stats.CompletionRate)
 }
 
+func TestHandleGetDocumentStatus_Error(t *testing.T) {
+	t.Parallel()
+
+	adminSvc := &mockAdminService{
+		getDocumentStatusFunc: func(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	handler := createTestHandler(adminSvc, nil)
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/admin/documents/{docId}/status", handler.HandleGetDocumentStatus)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/documents/doc1/status", nil)
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
 // ============================================================================
 // TESTS - HandleDeleteDocument
 // ============================================================================
@@ -1159,7 +1773,7 @@ func TestHandleDeleteDocument_Success(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Delete("/api/v1/admin/documents/{docId}", handler.HandleDeleteDocument)
@@ -1188,7 +1802,7 @@ func TestHandleDeleteDocument_RepositoryError(t *testing.T) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Delete("/api/v1/admin/documents/{docId}", handler.HandleDeleteDocument)
@@ -1282,7 +1896,7 @@ func BenchmarkHandleListDocuments(b *testing.B) {
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -1293,32 +1907,31 @@ func BenchmarkHandleListDocuments(b *testing.B) {
 }
 
 func BenchmarkHandleGetDocumentStatus(b *testing.B) {
-	doc := createTestDocument("doc1")
-	signers := []*models.ExpectedSignerWithStatus{
-		createTestExpectedSignerWithStatus("doc1", "signer1@example.com", true),
-		createTestExpectedSignerWithStatus("doc1", "signer2@example.com", false),
-	}
-	stats := &models.DocCompletionStats{
-		DocID:          "doc1",
-		ExpectedCount:  2,
-		SignedCount:    1,
-		PendingCount:   1,
-		CompletionRate: 50.0,
+	status := &models.DocumentStatus{
+		DocID:    "doc1",
+		Document: createTestDocument("doc1"),
+		Signers: []*models.ExpectedSignerWithStatus{
+			createTestExpectedSignerWithStatus("doc1", "signer1@example.com", true),
+			createTestExpectedSignerWithStatus("doc1", "signer2@example.com", false),
+		},
+		UnexpectedSignatures: []*models.UnexpectedSignature{},
+		Stats: &models.DocCompletionStats{
+			DocID:          "doc1",
+			ExpectedCount:  2,
+			SignedCount:    1,
+			PendingCount:   1,
+			CompletionRate: 50.0,
+		},
+		ReminderStats: &models.ReminderStats{PendingCount: 1},
 	}
 
 	adminSvc := &mockAdminService{
-		getDocumentFunc: func(ctx context.Context, docID string) (*models.Document, error) {
-			return doc, nil
-		},
-		listExpectedSignersWithStatusFunc: func(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error) {
-			return signers, nil
-		},
-		getSignerStatsFunc: func(ctx context.Context, docID string) (*models.DocCompletionStats, error) {
-			return stats, nil
+		getDocumentStatusFunc: func(ctx context.Context, docID string) (*models.DocumentStatus, error) {
+			return status, nil
 		},
 	}
 
-	handler := createTestHandler(adminSvc, nil, nil)
+	handler := createTestHandler(adminSvc, nil)
 
 	router := chi.NewRouter()
 	router.Get("/api/v1/admin/documents/{docId}/status", handler.HandleGetDocumentStatus)
@@ -1348,3 +1961,56 @@ func BenchmarkToExpectedSignerResponse(b *testing.B) {
 		_ = toExpectedSignerResponse(signer)
 	}
 }
+
+// ============================================================================
+// TESTS - RateLimitsHandler
+// ============================================================================
+
+type mockRateLimitService struct {
+	period time.Duration
+}
+
+func (m *mockRateLimitService) ListViolations(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitViolation, error) {
+	m.period = period
+	return []*models.RateLimitViolation{{ID: 1, Action: models.RateLimitActionSignature, Scope: models.RateLimitScopeIP, IPAddress: "10.0.0.1"}}, nil
+}
+
+func (m *mockRateLimitService) ListOffenders(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitOffender, error) {
+	return []*models.RateLimitOffender{{Scope: models.RateLimitScopeIP, Subject: "10.0.0.1", Violations: 1}}, nil
+}
+
+func TestHandleListRateLimitViolations(t *testing.T) {
+	t.Parallel()
+
+	svc := &mockRateLimitService{}
+	handler := NewRateLimitsHandler(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/rate-limits/violations?hours=48", nil)
+	rec := httptest.NewRecorder()
+	handler.HandleListViolations(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 48*time.Hour, svc.period)
+
+	var response struct {
+		Data RateLimitViolationsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 48, response.Data.Hours)
+	assert.Len(t, response.Data.Violations, 1)
+	assert.Equal(t, "10.0.0.1", response.Data.Offenders[0].Subject)
+}
+
+func TestHandleListRateLimitViolations_InvalidHours(t *testing.T) {
+	t.Parallel()
+
+	handler := NewRateLimitsHandler(&mockRateLimitService{})
+
+	for _, hours := range []string{"0", "abc", "1000"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/rate-limits/violations?hours="+hours, nil)
+		rec := httptest.NewRecorder()
+		handler.HandleListViolations(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "hours=%s", hours)
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultHistoryDays = 90
+	maxHistoryDays     = 3650
+)
+
+// historyService returns the daily completion snapshots of documents
+type historyService interface {
+	GetHistory(ctx context.Context, docID string, days int) ([]*models.CompletionSnapshot, error)
+}
+
+// HistoryHandler exposes the completion history of documents
+type HistoryHandler struct {
+	service historyService
+}
+
+func NewHistoryHandler(service historyService) *HistoryHandler {
+	return &HistoryHandler{service: service}
+}
+
+// HistoryPointResponse is the signing progress of a document on a given day
+type HistoryPointResponse struct {
+	Date           string  `json:"date"` // YYYY-MM-DD
+	ExpectedCount  int     `json:"expectedCount"`
+	SignedCount    int     `json:"signedCount"`
+	PendingCount   int     `json:"pendingCount"`
+	SignatureCount int     `json:"signatureCount"`
+	CompletionRate float64 `json:"completionRate"`
+}
+
+// HistoryResponse is the completion time series of a document, oldest day first
+type HistoryResponse struct {
+	DocID  string                 `json:"docId"`
+	Days   int                    `json:"days"`
+	Points []HistoryPointResponse `json:"points"`
+}
+
+// HandleGetHistory handles GET /api/v1/admin/documents/{docId}/history
+func (h *HistoryHandler) HandleGetHistory(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	days := defaultHistoryDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxHistoryDays {
+			shared.WriteValidationError(w, "days must be between 1 and 3650", nil)
+			return
+		}
+		days = parsed
+	}
+
+	snapshots, err := h.service.GetHistory(r.Context(), docID, days)
+	if err != nil {
+		if errors.Is(err, models.ErrDocumentNotFound) {
+			shared.WriteNotFound(w, "Document")
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+
+	points := make([]HistoryPointResponse, 0, len(snapshots))
+	for _, s := range snapshots {
+		points = append(points, HistoryPointResponse{
+			Date:           s.Date.Format(time.DateOnly),
+			ExpectedCount:  s.ExpectedCount,
+			SignedCount:    s.SignedCount,
+			PendingCount:   s.ExpectedCount - s.SignedCount,
+			SignatureCount: s.SignatureCount,
+			CompletionRate: s.CompletionRate(),
+		})
+	}
+
+	shared.WriteJSON(w, http.StatusOK, HistoryResponse{DocID: docID, Days: days, Points: points})
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// identityMergeService merges duplicate identities of a person
+type identityMergeService interface {
+	Merge(ctx context.Context, input models.IdentityMergeInput, mergedBy string) (*models.IdentityMerge, error)
+	ListMerges(ctx context.Context, limit, offset int) ([]*models.IdentityMerge, error)
+}
+
+// IdentityMergesHandler exposes the identity merge tool to admins
+type IdentityMergesHandler struct {
+	service identityMergeService
+}
+
+func NewIdentityMergesHandler(service identityMergeService) *IdentityMergesHandler {
+	return &IdentityMergesHandler{service: service}
+}
+
+// HandleMerge handles POST /api/v1/admin/users/merge
+func (h *IdentityMergesHandler) HandleMerge(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	var req models.IdentityMergeInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	merge, err := h.service.Merge(ctx, req, user.Email)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidIdentityMerge):
+			shared.WriteValidationError(w, err.Error(), nil)
+		case errors.Is(err, models.ErrIdentityMergeConflict):
+			shared.WriteConflict(w, err.Error())
+		default:
+			logger.Logger.Error("Failed to merge identities", "error", err.Error())
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, merge)
+}
+
+// HandleListMerges handles GET /api/v1/admin/users/merges
+func (h *IdentityMergesHandler) HandleListMerges(w http.ResponseWriter, r *http.Request) {
+	pagination := shared.ParsePaginationParams(r, 50, 200)
+	merges, err := h.service.ListMerges(r.Context(), pagination.PageSize, pagination.Offset)
+	if err != nil {
+		logger.Logger.Error("Failed to list identity merges", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	meta := map[string]interface{}{"total": len(merges), "limit": pagination.PageSize, "offset": pagination.Offset}
+	shared.WriteJSONWithMeta(w, http.StatusOK, merges, meta)
+}
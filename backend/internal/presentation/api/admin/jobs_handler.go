@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// jobService lists the background jobs and retries or cancels them
+type jobService interface {
+	List(ctx context.Context, filter models.JobFilter) ([]*models.Job, error)
+	Get(ctx context.Context, id int64) (*models.Job, error)
+	Counts(ctx context.Context) ([]models.JobCounts, error)
+	Retry(ctx context.Context, id int64) (*models.Job, error)
+	Cancel(ctx context.Context, id int64) (*models.Job, error)
+}
+
+// JobsHandler exposes the shared background job queue to admins
+type JobsHandler struct {
+	service jobService
+}
+
+func NewJobsHandler(service jobService) *JobsHandler {
+	return &JobsHandler{service: service}
+}
+
+// HandleListJobs handles GET /api/v1/admin/jobs?status=failed&type=document.checksum&limit=100
+func (h *JobsHandler) HandleListJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.JobFilter{
+		Status: models.JobStatus(query.Get("status")),
+		Type:   query.Get("type"),
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			shared.WriteValidationError(w, "limit must be a positive number", nil)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	jobs, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	if jobs == nil {
+		jobs = []*models.Job{}
+	}
+	shared.WriteJSON(w, http.StatusOK, jobs)
+}
+
+// HandleGetStats handles GET /api/v1/admin/jobs/stats
+func (h *JobsHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.service.Counts(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to count jobs", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if counts == nil {
+		counts = []models.JobCounts{}
+	}
+	shared.WriteJSON(w, http.StatusOK, counts)
+}
+
+// HandleGetJob handles GET /api/v1/admin/jobs/{id}
+func (h *JobsHandler) HandleGetJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseJobID(w, r)
+	if !ok {
+		return
+	}
+	job, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, job)
+}
+
+// HandleRetryJob handles POST /api/v1/admin/jobs/{id}/retry
+func (h *JobsHandler) HandleRetryJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseJobID(w, r)
+	if !ok {
+		return
+	}
+	job, err := h.service.Retry(r.Context(), id)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, job)
+}
+
+// HandleCancelJob handles POST /api/v1/admin/jobs/{id}/cancel
+func (h *JobsHandler) HandleCancelJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseJobID(w, r)
+	if !ok {
+		return
+	}
+	job, err := h.service.Cancel(r.Context(), id)
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, job)
+}
+
+func parseJobID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid job ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidJob):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrJobNotFound):
+		shared.WriteNotFound(w, "Job")
+	case errors.Is(err, services.ErrJobStateConflict):
+		shared.WriteConflict(w, err.Error())
+	default:
+		logger.Logger.Error("Job operation failed", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// ledgerLagProvider reports how far the external signature ledger is behind
+type ledgerLagProvider interface {
+	Lag(ctx context.Context) (*models.LedgerLag, error)
+}
+
+// LedgerHandler exposes the streaming of signature hashes to the external ledger to admins
+type LedgerHandler struct {
+	ledger ledgerLagProvider
+}
+
+func NewLedgerHandler(ledger ledgerLagProvider) *LedgerHandler {
+	return &LedgerHandler{ledger: ledger}
+}
+
+// HandleGetLag handles GET /api/v1/admin/ledger
+func (h *LedgerHandler) HandleGetLag(w http.ResponseWriter, r *http.Request) {
+	lag, err := h.ledger.Lag(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to measure signature ledger lag", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, lag)
+}
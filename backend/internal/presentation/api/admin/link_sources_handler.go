@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// linkResolverService resolves Confluence, SharePoint and Google Drive links and their tracked metadata
+type linkResolverService interface {
+	Preview(ctx context.Context, rawURL string) (*models.LinkMetadata, error)
+	GetSource(ctx context.Context, docID string) (*models.DocumentLinkSource, error)
+	Refresh(ctx context.Context, docID string) (*models.DocumentLinkSource, error)
+}
+
+// LinkSourcesHandler exposes the metadata of the pages documents link to
+type LinkSourcesHandler struct {
+	service linkResolverService
+}
+
+func NewLinkSourcesHandler(service linkResolverService) *LinkSourcesHandler {
+	return &LinkSourcesHandler{service: service}
+}
+
+// ResolveLinkRequest is the body of link resolution requests
+type ResolveLinkRequest struct {
+	URL string `json:"url"`
+}
+
+// HandleResolve handles POST /api/v1/admin/link-metadata/resolve, used to fill in the document
+// form before the document is created
+func (h *LinkSourcesHandler) HandleResolve(w http.ResponseWriter, r *http.Request) {
+	var req ResolveLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	if req.URL == "" {
+		shared.WriteValidationError(w, "url is required", nil)
+		return
+	}
+
+	meta, err := h.service.Preview(r.Context(), req.URL)
+	if err != nil {
+		writeLinkSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, meta)
+}
+
+// HandleGetSource handles GET /api/v1/admin/documents/{docId}/link-source
+func (h *LinkSourcesHandler) HandleGetSource(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	source, err := h.service.GetSource(r.Context(), docID)
+	if err != nil {
+		writeLinkSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, source)
+}
+
+// HandleRefreshSource handles POST /api/v1/admin/documents/{docId}/link-source/refresh.
+// It also starts tracking documents whose link was changed to a Confluence, SharePoint or Google Drive page.
+func (h *LinkSourcesHandler) HandleRefreshSource(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	// A failed resolution is reported in lastError with a 200, so that it is stored
+	source, err := h.service.Refresh(r.Context(), docID)
+	if err != nil {
+		writeLinkSourceError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, source)
+}
+
+func writeLinkSourceError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrLinkNotSupported):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, services.ErrLinkResolve):
+		shared.WriteError(w, http.StatusBadGateway, shared.ErrCodeServiceUnavailable, err.Error(), nil)
+	case errors.Is(err, models.ErrLinkSourceNotFound):
+		shared.WriteNotFound(w, "Link source")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
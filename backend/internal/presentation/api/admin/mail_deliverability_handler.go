@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	defaultDeliverabilityHours = 7 * 24
+	maxDeliverabilityHours     = 30 * 24
+	maxDeliverabilityDomains   = 50
+	maxDeliveryFailures        = 100
+)
+
+// mailDeliverabilityService reports the outcome of the sends to the SMTP servers
+type mailDeliverabilityService interface {
+	ListDomains(ctx context.Context, period time.Duration, limit int) ([]*models.EmailDomainDeliverability, error)
+	ListCodes(ctx context.Context, period time.Duration) ([]*models.EmailResponseCodeCount, error)
+	ListFailures(ctx context.Context, period time.Duration, limit int) ([]*models.EmailDelivery, error)
+}
+
+// MailDeliverabilityHandler exposes the deliverability report to admins
+type MailDeliverabilityHandler struct {
+	service mailDeliverabilityService
+}
+
+func NewMailDeliverabilityHandler(service mailDeliverabilityService) *MailDeliverabilityHandler {
+	return &MailDeliverabilityHandler{service: service}
+}
+
+// MailDeliverabilityResponse aggregates the sends of a period per recipient domain and reply code,
+// with the most recent failures
+type MailDeliverabilityResponse struct {
+	Hours       int                                 `json:"hours"`
+	Sent        int                                 `json:"sent"`
+	Failed      int                                 `json:"failed"`
+	SuccessRate float64                             `json:"successRate"`
+	Domains     []*models.EmailDomainDeliverability `json:"domains"`
+	Codes       []*models.EmailResponseCodeCount    `json:"codes"`
+	Failures    []*models.EmailDelivery             `json:"failures"`
+}
+
+// HandleGetReport handles GET /api/v1/admin/mail/deliverability
+func (h *MailDeliverabilityHandler) HandleGetReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	hours := defaultDeliverabilityHours
+	if raw := r.URL.Query().Get("hours"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxDeliverabilityHours {
+			shared.WriteValidationError(w, "hours must be between 1 and 720", nil)
+			return
+		}
+		hours = parsed
+	}
+	period := time.Duration(hours) * time.Hour
+
+	domains, err := h.service.ListDomains(ctx, period, maxDeliverabilityDomains)
+	if err != nil {
+		logger.Logger.Error("Failed to aggregate email deliveries", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	codes, err := h.service.ListCodes(ctx, period)
+	if err != nil {
+		logger.Logger.Error("Failed to count email reply codes", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	failures, err := h.service.ListFailures(ctx, period, maxDeliveryFailures)
+	if err != nil {
+		logger.Logger.Error("Failed to list failed email deliveries", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	response := &MailDeliverabilityResponse{
+		Hours:    hours,
+		Domains:  domains,
+		Codes:    codes,
+		Failures: failures,
+	}
+	// A send to several domains counts once: the totals come from the reply codes
+	for _, code := range codes {
+		if code.Status == models.EmailDeliverySent {
+			response.Sent += code.Count
+		} else {
+			response.Failed += code.Count
+		}
+	}
+	if total := response.Sent + response.Failed; total > 0 {
+		response.SuccessRate = float64(response.Sent) / float64(total)
+	}
+	if response.Domains == nil {
+		response.Domains = []*models.EmailDomainDeliverability{}
+	}
+	if response.Codes == nil {
+		response.Codes = []*models.EmailResponseCodeCount{}
+	}
+	if response.Failures == nil {
+		response.Failures = []*models.EmailDelivery{}
+	}
+	shared.WriteJSON(w, http.StatusOK, response)
+}
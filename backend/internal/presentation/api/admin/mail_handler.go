@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// mailHealthProvider reports the health of the SMTP servers
+type mailHealthProvider interface {
+	Health() []models.SMTPServerHealth
+}
+
+// MailHandler exposes the SMTP servers and their failover state to admins
+type MailHandler struct {
+	mail mailHealthProvider
+}
+
+func NewMailHandler(mail mailHealthProvider) *MailHandler {
+	return &MailHandler{mail: mail}
+}
+
+// MailDiagnosticsResponse lists the SMTP servers in configuration order, the main one first
+type MailDiagnosticsResponse struct {
+	Healthy        bool                      `json:"healthy"` // At least one server can be used
+	HealthyServers int                       `json:"healthyServers"`
+	Servers        []models.SMTPServerHealth `json:"servers"`
+}
+
+// HandleGetDiagnostics handles GET /api/v1/admin/mail/diagnostics
+func (h *MailHandler) HandleGetDiagnostics(w http.ResponseWriter, r *http.Request) {
+	servers := h.mail.Health()
+	response := &MailDiagnosticsResponse{Servers: servers}
+	for _, server := range servers {
+		if server.Healthy {
+			response.HealthyServers++
+		}
+	}
+	response.Healthy = response.HealthyServers > 0
+
+	shared.WriteJSON(w, http.StatusOK, response)
+}
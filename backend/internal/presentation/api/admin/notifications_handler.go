@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/websocket"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// notificationPingInterval keeps idle WebSocket connections alive through proxies
+const notificationPingInterval = 30 * time.Second
+
+// notificationService defines notification inbox operations of the current user
+type notificationService interface {
+	List(ctx context.Context, recipient string, unreadOnly bool, limit, offset int) ([]*models.Notification, int, error)
+	UnreadCount(ctx context.Context, recipient string) (int, error)
+	MarkRead(ctx context.Context, recipient string, id int64) error
+	MarkAllRead(ctx context.Context, recipient string) (int64, error)
+	Subscribe(recipient string) (<-chan services.NotificationEvent, func())
+	Done() <-chan struct{}
+}
+
+// NotificationsHandler exposes the notification inbox of the authenticated admin
+type NotificationsHandler struct {
+	service notificationService
+	baseURL string
+}
+
+func NewNotificationsHandler(service notificationService, baseURL string) *NotificationsHandler {
+	return &NotificationsHandler{service: service, baseURL: baseURL}
+}
+
+// HandleList handles GET /api/v1/admin/notifications
+func (h *NotificationsHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	pagination := shared.ParsePaginationParams(r, 20, 100)
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, total, err := h.service.List(ctx, user.Email, unreadOnly, pagination.PageSize, pagination.Offset)
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if notifications == nil {
+		notifications = []*models.Notification{}
+	}
+
+	shared.WritePaginatedJSON(w, notifications, pagination.Page, pagination.PageSize, total)
+}
+
+// HandleUnreadCount handles GET /api/v1/admin/notifications/unread-count
+func (h *NotificationsHandler) HandleUnreadCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	count, err := h.service.UnreadCount(ctx, user.Email)
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]int{"unread": count})
+}
+
+// HandleMarkRead handles POST /api/v1/admin/notifications/{id}/read
+func (h *NotificationsHandler) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid notification ID", nil)
+		return
+	}
+
+	if err := h.service.MarkRead(ctx, user.Email, id); err != nil {
+		if errors.Is(err, models.ErrNotificationNotFound) {
+			shared.WriteNotFound(w, "Notification")
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"id": id, "read": true})
+}
+
+// HandleMarkAllRead handles POST /api/v1/admin/notifications/read-all
+func (h *NotificationsHandler) HandleMarkAllRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	count, err := h.service.MarkAllRead(ctx, user.Email)
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]int64{"updated": count})
+}
+
+// HandleStream handles GET /api/v1/admin/notifications/ws.
+// New notifications are pushed as JSON messages until the client disconnects.
+// On shutdown the connection is closed with status 1012 (service restart).
+func (h *NotificationsHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	conn, err := websocket.Upgrade(w, r, h.baseURL)
+	if err != nil {
+		switch {
+		case errors.Is(err, websocket.ErrNotWebSocket):
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "WebSocket upgrade required", nil)
+		case errors.Is(err, websocket.ErrOriginNotAllowed):
+			shared.WriteForbidden(w, "Origin not allowed")
+		default:
+			logger.Logger.Warn("WebSocket upgrade failed", "error", err.Error())
+		}
+		return
+	}
+	defer conn.Close()
+
+	events, cancel := h.service.Subscribe(user.Email)
+	defer cancel()
+
+	logger.Logger.Debug("Notification stream opened", "user", user.Email)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := conn.ReadLoop(); err != nil {
+			logger.Logger.Debug("Notification stream read ended", "error", err.Error())
+		}
+	}()
+
+	ticker := time.NewTicker(notificationPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-h.service.Done():
+			// Server shutting down: ask the client to reconnect, possibly to another instance
+			_ = conn.CloseWithStatus(websocket.StatusServiceRestart, "server restarting")
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.Ping(); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// eventOutbox reports the relay of the transactional outbox and retries the events given up
+type eventOutbox interface {
+	Lag(ctx context.Context) (*models.OutboxLag, error)
+	RetryDead(ctx context.Context) (int, error)
+}
+
+// OutboxHandler exposes the relay of signature events to webhooks and notifiers to admins
+type OutboxHandler struct {
+	outbox eventOutbox
+}
+
+func NewOutboxHandler(outbox eventOutbox) *OutboxHandler {
+	return &OutboxHandler{outbox: outbox}
+}
+
+// HandleGetLag handles GET /api/v1/admin/outbox
+func (h *OutboxHandler) HandleGetLag(w http.ResponseWriter, r *http.Request) {
+	lag, err := h.outbox.Lag(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to measure event outbox lag", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, lag)
+}
+
+// HandleRetryDead handles POST /api/v1/admin/outbox/retry
+func (h *OutboxHandler) HandleRetryDead(w http.ResponseWriter, r *http.Request) {
+	retried, err := h.outbox.RetryDead(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to retry dead outbox events", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"retried": retried})
+}
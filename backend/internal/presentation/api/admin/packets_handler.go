@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// packetService defines document packet management operations
+type packetService interface {
+	CreatePacket(ctx context.Context, input models.DocumentPacketInput, createdBy string) (*models.DocumentPacket, error)
+	UpdatePacket(ctx context.Context, id int64, input models.DocumentPacketInput) (*models.DocumentPacket, error)
+	DeletePacket(ctx context.Context, id int64) error
+	GetPacket(ctx context.Context, id int64) (*models.DocumentPacket, error)
+	ListPackets(ctx context.Context) ([]*models.DocumentPacket, error)
+	GetStats(ctx context.Context, id int64) (*models.PacketStats, error)
+	SendReminders(ctx context.Context, id int64, sentBy, locale string) (*models.PacketReminderResult, error)
+}
+
+// PacketsHandler groups operations on the packets of documents signed in one session
+type PacketsHandler struct {
+	service packetService
+}
+
+func NewPacketsHandler(service packetService) *PacketsHandler {
+	return &PacketsHandler{service: service}
+}
+
+type PacketRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	DocIDs      []string `json:"docIds"`
+}
+
+func (req PacketRequest) toInput() models.DocumentPacketInput {
+	return models.DocumentPacketInput{
+		Title:       req.Title,
+		Description: req.Description,
+		DocIDs:      req.DocIDs,
+	}
+}
+
+// HandleListPackets handles GET /api/v1/admin/packets
+func (h *PacketsHandler) HandleListPackets(w http.ResponseWriter, r *http.Request) {
+	packets, err := h.service.ListPackets(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if packets == nil {
+		packets = []*models.DocumentPacket{}
+	}
+	shared.WriteJSON(w, http.StatusOK, packets)
+}
+
+// HandleCreatePacket handles POST /api/v1/admin/packets
+func (h *PacketsHandler) HandleCreatePacket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req PacketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	createdBy := ""
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		createdBy = user.Email
+	}
+	packet, err := h.service.CreatePacket(ctx, req.toInput(), createdBy)
+	if err != nil {
+		writePacketError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, packet)
+}
+
+// HandleGetPacket handles GET /api/v1/admin/packets/{id}
+func (h *PacketsHandler) HandleGetPacket(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePacketID(w, r)
+	if !ok {
+		return
+	}
+	packet, err := h.service.GetPacket(r.Context(), id)
+	if err != nil {
+		writePacketError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, packet)
+}
+
+// HandleUpdatePacket handles PUT /api/v1/admin/packets/{id}
+func (h *PacketsHandler) HandleUpdatePacket(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePacketID(w, r)
+	if !ok {
+		return
+	}
+	var req PacketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	packet, err := h.service.UpdatePacket(r.Context(), id, req.toInput())
+	if err != nil {
+		writePacketError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, packet)
+}
+
+// HandleDeletePacket handles DELETE /api/v1/admin/packets/{id}
+func (h *PacketsHandler) HandleDeletePacket(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePacketID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeletePacket(r.Context(), id); err != nil {
+		writePacketError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Document packet deleted"})
+}
+
+// HandleGetStats handles GET /api/v1/admin/packets/{id}/stats
+func (h *PacketsHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	id, ok := parsePacketID(w, r)
+	if !ok {
+		return
+	}
+	stats, err := h.service.GetStats(r.Context(), id)
+	if err != nil {
+		writePacketError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, stats)
+}
+
+// HandleSendReminders handles POST /api/v1/admin/packets/{id}/reminders
+func (h *PacketsHandler) HandleSendReminders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	id, ok := parsePacketID(w, r)
+	if !ok {
+		return
+	}
+	result, err := h.service.SendReminders(ctx, id, user.Email, i18n.GetLangFromRequest(r))
+	if err != nil {
+		if !errors.Is(err, models.ErrPacketNotFound) && !errors.Is(err, services.ErrPacketRemindersDisabled) {
+			logger.Logger.Error("Failed to send packet reminders", "packet_id", id, "error", err.Error())
+		}
+		writePacketError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
+
+func parsePacketID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid packet ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writePacketError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPacket):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrPacketNotFound):
+		shared.WriteNotFound(w, "Document packet")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	case errors.Is(err, services.ErrPacketRemindersDisabled):
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, err.Error(), nil)
+	default:
+		shared.WriteInternalError(w)
+	}
+}
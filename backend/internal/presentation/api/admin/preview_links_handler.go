@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// previewLinkService manages the read-only preview links of documents
+type previewLinkService interface {
+	CreateLink(ctx context.Context, docID string, input models.PreviewLinkInput, createdBy string) (*models.PreviewLink, error)
+	ListLinks(ctx context.Context, docID string) ([]*models.PreviewLink, error)
+	RevokeLink(ctx context.Context, docID string, id int64, revokedBy string) (*models.PreviewLink, error)
+}
+
+// PreviewLinksHandler exposes the expiring links shared with reviewers who have no account
+type PreviewLinksHandler struct {
+	service previewLinkService
+}
+
+func NewPreviewLinksHandler(service previewLinkService) *PreviewLinksHandler {
+	return &PreviewLinksHandler{service: service}
+}
+
+// HandleListPreviewLinks handles GET /api/v1/admin/documents/{docId}/preview-links
+func (h *PreviewLinksHandler) HandleListPreviewLinks(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	links, err := h.service.ListLinks(r.Context(), docID)
+	if err != nil {
+		logger.Logger.Error("Failed to list preview links", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, links)
+}
+
+// HandleCreatePreviewLink handles POST /api/v1/admin/documents/{docId}/preview-links. The URL
+// of the link is returned once and cannot be retrieved afterwards.
+func (h *PreviewLinksHandler) HandleCreatePreviewLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var input models.PreviewLinkInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	link, err := h.service.CreateLink(ctx, chi.URLParam(r, "docId"), input, currentUserEmail(ctx))
+	if err != nil {
+		writePreviewLinkError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	shared.WriteJSON(w, http.StatusCreated, link)
+}
+
+// HandleRevokePreviewLink handles DELETE /api/v1/admin/documents/{docId}/preview-links/{id}
+func (h *PreviewLinksHandler) HandleRevokePreviewLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid preview link ID", nil)
+		return
+	}
+
+	link, err := h.service.RevokeLink(ctx, chi.URLParam(r, "docId"), id, currentUserEmail(ctx))
+	if err != nil {
+		writePreviewLinkError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, link)
+}
+
+func writePreviewLinkError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidPreviewLink):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	case errors.Is(err, models.ErrPreviewLinkNotFound):
+		shared.WriteNotFound(w, "Preview link")
+	default:
+		logger.Logger.Error("Failed to manage preview link", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// quizService defines document quiz authoring and results operations
+type quizService interface {
+	GetQuiz(ctx context.Context, docID string) (*models.DocumentQuiz, error)
+	UpdateQuiz(ctx context.Context, docID string, input models.DocumentQuizInput, updatedBy string) (*models.DocumentQuiz, error)
+	DeleteQuiz(ctx context.Context, docID string) error
+	GetStats(ctx context.Context, docID string) (*models.QuizStats, error)
+}
+
+// QuizHandler exposes the comprehension quizzes of documents
+type QuizHandler struct {
+	service quizService
+}
+
+func NewQuizHandler(service quizService) *QuizHandler {
+	return &QuizHandler{service: service}
+}
+
+// HandleGetQuiz handles GET /api/v1/admin/documents/{docId}/quiz
+func (h *QuizHandler) HandleGetQuiz(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	quiz, err := h.service.GetQuiz(r.Context(), docID)
+	if err != nil {
+		writeQuizError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, quiz)
+}
+
+// HandleUpdateQuiz handles PUT /api/v1/admin/documents/{docId}/quiz
+func (h *QuizHandler) HandleUpdateQuiz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	var input models.DocumentQuizInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	quiz, err := h.service.UpdateQuiz(ctx, docID, input, user.Email)
+	if err != nil {
+		writeQuizError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, quiz)
+}
+
+// HandleDeleteQuiz handles DELETE /api/v1/admin/documents/{docId}/quiz
+func (h *QuizHandler) HandleDeleteQuiz(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	if err := h.service.DeleteQuiz(r.Context(), docID); err != nil {
+		writeQuizError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Quiz deleted"})
+}
+
+// HandleGetResults handles GET /api/v1/admin/documents/{docId}/quiz/results
+func (h *QuizHandler) HandleGetResults(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	stats, err := h.service.GetStats(r.Context(), docID)
+	if err != nil {
+		writeQuizError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, stats)
+}
+
+func writeQuizError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidQuiz):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrQuizNotFound):
+		shared.WriteNotFound(w, "Quiz")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
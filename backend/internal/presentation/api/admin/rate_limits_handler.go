@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	defaultViolationHours = 24
+	maxViolationHours     = 30 * 24
+	maxViolations         = 200
+	maxOffenders          = 20
+)
+
+// rateLimitService lists the requests rejected by rate limits
+type rateLimitService interface {
+	ListViolations(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitViolation, error)
+	ListOffenders(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitOffender, error)
+}
+
+// RateLimitsHandler exposes rate limit violations to admins
+type RateLimitsHandler struct {
+	service rateLimitService
+}
+
+func NewRateLimitsHandler(service rateLimitService) *RateLimitsHandler {
+	return &RateLimitsHandler{service: service}
+}
+
+// RateLimitViolationsResponse lists recent violations and the users and IPs causing most of them
+type RateLimitViolationsResponse struct {
+	Hours      int                          `json:"hours"`
+	Offenders  []*models.RateLimitOffender  `json:"offenders"`
+	Violations []*models.RateLimitViolation `json:"violations"`
+}
+
+// HandleListViolations handles GET /api/v1/admin/rate-limits/violations
+func (h *RateLimitsHandler) HandleListViolations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	hours := defaultViolationHours
+	if raw := r.URL.Query().Get("hours"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxViolationHours {
+			shared.WriteValidationError(w, "hours must be between 1 and 720", nil)
+			return
+		}
+		hours = parsed
+	}
+	period := time.Duration(hours) * time.Hour
+
+	violations, err := h.service.ListViolations(ctx, period, maxViolations)
+	if err != nil {
+		logger.Logger.Error("Failed to list rate limit violations", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	offenders, err := h.service.ListOffenders(ctx, period, maxOffenders)
+	if err != nil {
+		logger.Logger.Error("Failed to list rate limit offenders", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	if violations == nil {
+		violations = []*models.RateLimitViolation{}
+	}
+	if offenders == nil {
+		offenders = []*models.RateLimitOffender{}
+	}
+	shared.WriteJSON(w, http.StatusOK, &RateLimitViolationsResponse{
+		Hours:      hours,
+		Offenders:  offenders,
+		Violations: violations,
+	})
+}
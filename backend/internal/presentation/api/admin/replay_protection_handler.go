@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// replayStatsProvider reports the signature nonces issued and the replays rejected
+type replayStatsProvider interface {
+	Stats() models.ReplayProtectionStats
+}
+
+// ReplayProtectionHandler exposes the signature anti-replay metrics to admins
+type ReplayProtectionHandler struct {
+	stats replayStatsProvider
+}
+
+func NewReplayProtectionHandler(stats replayStatsProvider) *ReplayProtectionHandler {
+	return &ReplayProtectionHandler{stats: stats}
+}
+
+// ReplayProtectionResponse represents the anti-replay metrics of this instance since startup
+type ReplayProtectionResponse struct {
+	Required   bool  `json:"required"`
+	TTLSeconds int64 `json:"ttlSeconds"`
+	Issued     int64 `json:"issued"`
+	Consumed   int64 `json:"consumed"`
+	Replayed   int64 `json:"replayed"`
+	Expired    int64 `json:"expired"`
+	Invalid    int64 `json:"invalid"`
+	Missing    int64 `json:"missing"`
+	Rejected   int64 `json:"rejected"`
+}
+
+// HandleGetStats handles GET /api/v1/admin/replay-protection
+func (h *ReplayProtectionHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	stats := h.stats.Stats()
+	shared.WriteJSON(w, http.StatusOK, ReplayProtectionResponse{
+		Required:   stats.Required,
+		TTLSeconds: int64(stats.TTL.Seconds()),
+		Issued:     stats.Issued,
+		Consumed:   stats.Consumed,
+		Replayed:   stats.Replayed,
+		Expired:    stats.Expired,
+		Invalid:    stats.Invalid,
+		Missing:    stats.Missing,
+		Rejected:   stats.Replayed + stats.Expired + stats.Invalid + stats.Missing,
+	})
+}
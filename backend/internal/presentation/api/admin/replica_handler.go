@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// replicaStatusProvider reports the health of the read replica
+type replicaStatusProvider interface {
+	Status() models.ReplicaStatus
+}
+
+// ReplicaHandler exposes the read replica health to admins
+type ReplicaHandler struct {
+	replica replicaStatusProvider
+}
+
+func NewReplicaHandler(replica replicaStatusProvider) *ReplicaHandler {
+	return &ReplicaHandler{replica: replica}
+}
+
+// ReplicaStatusResponse represents the health of the read replica at its last check
+type ReplicaStatusResponse struct {
+	Healthy    bool    `json:"healthy"`
+	LagSeconds float64 `json:"lagSeconds"`
+	MaxLag     float64 `json:"maxLagSeconds"`
+	CheckedAt  *string `json:"checkedAt,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// HandleGetReplicaStatus handles GET /api/v1/admin/replica
+func (h *ReplicaHandler) HandleGetReplicaStatus(w http.ResponseWriter, r *http.Request) {
+	status := h.replica.Status()
+	response := &ReplicaStatusResponse{
+		Healthy:    status.Healthy,
+		LagSeconds: status.LagSeconds,
+		MaxLag:     status.MaxLag,
+		Error:      status.Error,
+	}
+	if status.CheckedAt != nil {
+		checkedAt := status.CheckedAt.Format("2006-01-02T15:04:05Z07:00")
+		response.CheckedAt = &checkedAt
+	}
+
+	shared.WriteJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// complianceReportsPath is the API path reports are served under
+const complianceReportsPath = "/api/v1/admin/reports/compliance"
+
+// reportService requests, reads and renders compliance reports
+type reportService interface {
+	Request(ctx context.Context, period string, requestedBy string, refresh bool) (*models.ComplianceReport, error)
+	Get(ctx context.Context, id int64) (*models.ComplianceReport, error)
+	Render(report *models.ComplianceReport, format string) ([]byte, error)
+}
+
+// ReportsHandler serves the cross-document compliance reports
+type ReportsHandler struct {
+	service reportService
+}
+
+func NewReportsHandler(service reportService) *ReportsHandler {
+	return &ReportsHandler{service: service}
+}
+
+// ReportResponse is the generation state of a report, with its download links once ready
+type ReportResponse struct {
+	*models.ComplianceReport
+	StatusURL    string            `json:"statusUrl"`
+	DownloadURLs map[string]string `json:"downloadUrls,omitempty"` // By format: json, csv, pdf
+}
+
+func newReportResponse(report *models.ComplianceReport) ReportResponse {
+	resp := ReportResponse{
+		ComplianceReport: report,
+		StatusURL:        fmt.Sprintf("%s/%d", complianceReportsPath, report.ID),
+	}
+	if report.Status == models.ComplianceReportReady {
+		resp.DownloadURLs = make(map[string]string, 3)
+		for _, format := range []string{services.ReportFormatJSON, services.ReportFormatCSV, services.ReportFormatPDF} {
+			resp.DownloadURLs[format] = fmt.Sprintf("%s/%d/download?format=%s", complianceReportsPath, report.ID, format)
+		}
+	}
+	return resp
+}
+
+// writeReportStatus answers 200 once the report is ready and 202 while it is generated
+func writeReportStatus(w http.ResponseWriter, report *models.ComplianceReport) {
+	status := http.StatusAccepted
+	if report.Status == models.ComplianceReportReady || report.Status == models.ComplianceReportFailed {
+		status = http.StatusOK
+	}
+	shared.WriteJSON(w, status, newReportResponse(report))
+}
+
+// HandleRequestReport handles GET /api/v1/admin/reports/compliance?period=2024-Q4, returning the
+// recent report of the period or queuing a new one. refresh=true queues a new one anyway.
+func (h *ReportsHandler) HandleRequestReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		shared.WriteValidationError(w, "period is required", nil)
+		return
+	}
+	refresh, _ := strconv.ParseBool(r.URL.Query().Get("refresh"))
+
+	requestedBy := ""
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		requestedBy = user.Email
+	}
+
+	report, err := h.service.Request(ctx, period, requestedBy, refresh)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidPeriod) {
+			shared.WriteValidationError(w, err.Error(), nil)
+			return
+		}
+		logger.Logger.Error("Failed to request compliance report", "period", period, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	writeReportStatus(w, report)
+}
+
+// HandleGetReport handles GET /api/v1/admin/reports/compliance/{id}
+func (h *ReportsHandler) HandleGetReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := h.getReport(w, r)
+	if !ok {
+		return
+	}
+	writeReportStatus(w, report)
+}
+
+// HandleDownloadReport handles GET /api/v1/admin/reports/compliance/{id}/download?format=pdf
+func (h *ReportsHandler) HandleDownloadReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := h.getReport(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = services.ReportFormatJSON
+	}
+	data, err := h.service.Render(report, format)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidReportFormat):
+			shared.WriteValidationError(w, err.Error(), nil)
+		case errors.Is(err, services.ErrReportNotReady):
+			shared.WriteConflict(w, err.Error())
+		default:
+			logger.Logger.Error("Failed to render compliance report", "report_id", report.ID, "format", format, "error", err.Error())
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+
+	contentType := map[string]string{
+		services.ReportFormatJSON: "application/json",
+		services.ReportFormatCSV:  "text/csv; charset=utf-8",
+		services.ReportFormatPDF:  "application/pdf",
+	}[format]
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="compliance-%s.%s"`, report.Period, format))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func (h *ReportsHandler) getReport(w http.ResponseWriter, r *http.Request) (*models.ComplianceReport, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid report ID", nil)
+		return nil, false
+	}
+	report, err := h.service.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, models.ErrReportNotFound) {
+			shared.WriteNotFound(w, "Report")
+			return nil, false
+		}
+		shared.WriteInternalError(w)
+		return nil, false
+	}
+	return report, true
+}
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// retentionService defines retention policy and archive operations
+type retentionService interface {
+	GetPolicy(ctx context.Context) (*models.RetentionPolicy, error)
+	UpdatePolicy(ctx context.Context, input models.RetentionPolicyInput, updatedBy string) (*models.RetentionPolicy, error)
+	ListArchives(ctx context.Context, limit, offset int) ([]*models.DocumentArchive, error)
+	CountArchives(ctx context.Context) (int, error)
+	GetArchive(ctx context.Context, id int64) (*models.DocumentArchive, error)
+	OpenArchive(ctx context.Context, id int64) (*models.DocumentArchive, io.ReadCloser, error)
+	ArchiveDocument(ctx context.Context, docID, archivedBy string, purgePII bool) (*models.DocumentArchive, error)
+	RestoreArchive(ctx context.Context, id int64, restoredBy string) (*models.DocumentArchive, error)
+}
+
+// RetentionHandler groups operations on retention policies and document archives
+type RetentionHandler struct {
+	service retentionService
+}
+
+func NewRetentionHandler(service retentionService) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+type ArchiveDocumentRequest struct {
+	PurgePII bool `json:"purgePii"`
+}
+
+// HandleGetPolicy handles GET /api/v1/admin/retention
+func (h *RetentionHandler) HandleGetPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.service.GetPolicy(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, policy)
+}
+
+// HandleUpdatePolicy handles PUT /api/v1/admin/retention
+func (h *RetentionHandler) HandleUpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	var input models.RetentionPolicyInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	policy, err := h.service.UpdatePolicy(ctx, input, user.Email)
+	if err != nil {
+		writeRetentionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, policy)
+}
+
+// HandleListArchives handles GET /api/v1/admin/archives
+func (h *RetentionHandler) HandleListArchives(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	pagination := shared.ParsePaginationParams(r, 20, 100)
+
+	archives, err := h.service.ListArchives(ctx, pagination.PageSize, pagination.Offset)
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if archives == nil {
+		archives = []*models.DocumentArchive{}
+	}
+
+	total, err := h.service.CountArchives(ctx)
+	if err != nil {
+		logger.Logger.Warn("Failed to count archives, using result count", "error", err.Error())
+		total = len(archives)
+	}
+
+	shared.WritePaginatedJSON(w, archives, pagination.Page, pagination.PageSize, total)
+}
+
+// HandleGetArchive handles GET /api/v1/admin/archives/{id}
+func (h *RetentionHandler) HandleGetArchive(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseArchiveID(w, r)
+	if !ok {
+		return
+	}
+	archive, err := h.service.GetArchive(r.Context(), id)
+	if err != nil {
+		writeRetentionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, archive)
+}
+
+// HandleDownloadArchive handles GET /api/v1/admin/archives/{id}/download
+func (h *RetentionHandler) HandleDownloadArchive(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseArchiveID(w, r)
+	if !ok {
+		return
+	}
+	archive, reader, err := h.service.OpenArchive(r.Context(), id)
+	if err != nil {
+		writeRetentionError(w, err)
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-archive-%d.json.gz"`, archive.DocID, archive.ID))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, reader); err != nil {
+		logger.Logger.Error("Failed to stream archive", "archive_id", id, "error", err.Error())
+	}
+}
+
+// HandleRestoreArchive handles POST /api/v1/admin/archives/{id}/restore
+func (h *RetentionHandler) HandleRestoreArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	id, ok := parseArchiveID(w, r)
+	if !ok {
+		return
+	}
+
+	archive, err := h.service.RestoreArchive(ctx, id, user.Email)
+	if err != nil {
+		writeRetentionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, archive)
+}
+
+// HandleArchiveDocument handles POST /api/v1/admin/documents/{docId}/archive
+func (h *RetentionHandler) HandleArchiveDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	// Body is optional: archive without purging PII by default
+	var req ArchiveDocumentRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+			return
+		}
+	}
+
+	archive, err := h.service.ArchiveDocument(ctx, docID, user.Email, req.PurgePII)
+	if err != nil {
+		writeRetentionError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, archive)
+}
+
+func parseArchiveID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid archive ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeRetentionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRetentionPolicy):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, services.ErrArchiveStorageDisabled):
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, "Object storage is not configured", nil)
+	case errors.Is(err, services.ErrArchiveAlreadyRestored):
+		shared.WriteConflict(w, "Archive already restored")
+	case errors.Is(err, models.ErrArchiveNotFound):
+		shared.WriteNotFound(w, "Archive")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// roleService defines delegated admin role management operations
+type roleService interface {
+	ListRoles(ctx context.Context) ([]*models.AdminRoleAssignment, error)
+	AssignRole(ctx context.Context, email string, role models.AdminRole, departmentID *int64, grantedBy string) (*models.AdminRoleAssignment, error)
+	RevokeRole(ctx context.Context, email, revokedBy string) error
+}
+
+// RolesHandler groups operations on delegated admin roles
+type RolesHandler struct {
+	service roleService
+}
+
+func NewRolesHandler(service roleService) *RolesHandler {
+	return &RolesHandler{service: service}
+}
+
+type AssignRoleRequest struct {
+	Role         models.AdminRole `json:"role"`
+	DepartmentID *int64           `json:"departmentId,omitempty"` // Required for department-admins
+}
+
+// RoleDefinition describes a role and the permissions it grants
+type RoleDefinition struct {
+	Role        models.AdminRole    `json:"role"`
+	Permissions []models.Permission `json:"permissions"`
+}
+
+// HandleListRoles handles GET /api/v1/admin/roles
+func (h *RolesHandler) HandleListRoles(w http.ResponseWriter, r *http.Request) {
+	assignments, err := h.service.ListRoles(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if assignments == nil {
+		assignments = []*models.AdminRoleAssignment{}
+	}
+	shared.WriteJSON(w, http.StatusOK, assignments)
+}
+
+// HandleListRoleDefinitions handles GET /api/v1/admin/roles/definitions
+func (h *RolesHandler) HandleListRoleDefinitions(w http.ResponseWriter, _ *http.Request) {
+	roles := []models.AdminRole{
+		models.AdminRoleViewer,
+		models.AdminRoleDocumentManager,
+		models.AdminRoleReminderOperator,
+		models.AdminRoleDepartmentAdmin,
+		models.AdminRoleSuperAdmin,
+	}
+	definitions := make([]RoleDefinition, 0, len(roles))
+	for _, role := range roles {
+		definitions = append(definitions, RoleDefinition{Role: role, Permissions: role.Permissions()})
+	}
+	shared.WriteJSON(w, http.StatusOK, definitions)
+}
+
+// HandleAssignRole handles PUT /api/v1/admin/roles/{email}
+func (h *RolesHandler) HandleAssignRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	// Decode URL-encoded email (e.g., al%40bundy.com -> al@bundy.com)
+	email, err := url.QueryUnescape(chi.URLParam(r, "email"))
+	if err != nil || email == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid email format", nil)
+		return
+	}
+
+	var req AssignRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	assignment, err := h.service.AssignRole(ctx, email, req.Role, req.DepartmentID, user.Email)
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, assignment)
+}
+
+// HandleRevokeRole handles DELETE /api/v1/admin/roles/{email}
+func (h *RolesHandler) HandleRevokeRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	// Decode URL-encoded email (e.g., al%40bundy.com -> al@bundy.com)
+	email, err := url.QueryUnescape(chi.URLParam(r, "email"))
+	if err != nil || email == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid email format", nil)
+		return
+	}
+
+	if err := h.service.RevokeRole(ctx, email, user.Email); err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Role revoked"})
+}
+
+func writeRoleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRole):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrRoleNotFound):
+		shared.WriteNotFound(w, "Role assignment")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
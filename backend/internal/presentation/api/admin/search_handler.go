@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// searchService defines full-text search operations
+type searchService interface {
+	Search(ctx context.Context, query, tag string, limit int) ([]*models.SearchResult, error)
+}
+
+// SearchHandler serves the admin search across documents and signers
+type SearchHandler struct {
+	service searchService
+}
+
+func NewSearchHandler(service searchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// HandleSearch handles GET /api/v1/admin/search?q=...&tag=...&limit=20
+func (h *SearchHandler) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		shared.WriteValidationError(w, "Query parameter 'q' is required", nil)
+		return
+	}
+	tag := strings.TrimSpace(r.URL.Query().Get("tag"))
+	pagination := shared.ParsePaginationParams(r, 20, 50)
+
+	results, err := h.service.Search(r.Context(), query, tag, pagination.PageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSearchQuery) {
+			shared.WriteValidationError(w, err.Error(), nil)
+			return
+		}
+		logger.Logger.Error("Search failed", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if results == nil {
+		results = []*models.SearchResult{}
+	}
+
+	meta := map[string]interface{}{"query": query, "total": len(results), "limit": pagination.PageSize}
+	if tag != "" {
+		meta["tag"] = tag
+	}
+	shared.WriteJSONWithMeta(w, http.StatusOK, results, meta)
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	defaultSecurityHours = 24
+	maxSecurityHours     = 90 * 24
+	maxSecurityEvents    = 200
+)
+
+// securityAlertService lists failed authentications and the security events they raised
+type securityAlertService interface {
+	ListEvents(ctx context.Context, period time.Duration, unacknowledgedOnly bool, limit int) ([]*models.SecurityEvent, error)
+	FailureCounts(ctx context.Context, period time.Duration) ([]models.AuthFailureCount, error)
+	Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*models.SecurityEvent, error)
+}
+
+// SecurityHandler exposes suspicious authentication activity to admins
+type SecurityHandler struct {
+	service securityAlertService
+}
+
+func NewSecurityHandler(service securityAlertService) *SecurityHandler {
+	return &SecurityHandler{service: service}
+}
+
+// SecurityEventsResponse lists the failed attempts by kind and the events raised over a period
+type SecurityEventsResponse struct {
+	Hours    int                       `json:"hours"`
+	Failures []models.AuthFailureCount `json:"failures"`
+	Events   []*models.SecurityEvent   `json:"events"`
+}
+
+// HandleListEvents handles GET /api/v1/admin/security/events?hours=24&unacknowledged=true
+func (h *SecurityHandler) HandleListEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	hours := defaultSecurityHours
+	if raw := r.URL.Query().Get("hours"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxSecurityHours {
+			shared.WriteValidationError(w, "hours must be between 1 and 2160", nil)
+			return
+		}
+		hours = parsed
+	}
+	period := time.Duration(hours) * time.Hour
+	unacknowledged := r.URL.Query().Get("unacknowledged") == "true"
+
+	events, err := h.service.ListEvents(ctx, period, unacknowledged, maxSecurityEvents)
+	if err != nil {
+		logger.Logger.Error("Failed to list security events", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	failures, err := h.service.FailureCounts(ctx, period)
+	if err != nil {
+		logger.Logger.Error("Failed to count auth failures", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	if events == nil {
+		events = []*models.SecurityEvent{}
+	}
+	if failures == nil {
+		failures = []models.AuthFailureCount{}
+	}
+	shared.WriteJSON(w, http.StatusOK, &SecurityEventsResponse{
+		Hours:    hours,
+		Failures: failures,
+		Events:   events,
+	})
+}
+
+// HandleAcknowledgeEvent handles POST /api/v1/admin/security/events/{id}/acknowledge
+func (h *SecurityHandler) HandleAcknowledgeEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid event ID", nil)
+		return
+	}
+
+	event, err := h.service.Acknowledge(ctx, id, user.Email)
+	if errors.Is(err, models.ErrSecurityEventNotFound) {
+		shared.WriteNotFound(w, "Security event")
+		return
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to acknowledge security event", "id", id, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, event)
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// sessionCleaner purges expired sessions and reports the rows purged
+type sessionCleaner interface {
+	RunCleanup(ctx context.Context, forced bool) (*models.SessionCleanupRun, error)
+	Stats() models.SessionCleanupStats
+}
+
+// SessionCleanupHandler exposes the session cleanup metrics and lets admins force a run
+type SessionCleanupHandler struct {
+	cleaner sessionCleaner
+}
+
+func NewSessionCleanupHandler(cleaner sessionCleaner) *SessionCleanupHandler {
+	return &SessionCleanupHandler{cleaner: cleaner}
+}
+
+// SessionCleanupRunResponse represents one run of the session cleanup
+type SessionCleanupRunResponse struct {
+	StartedAt     time.Time `json:"startedAt"`
+	DurationMs    int64     `json:"durationMs"`
+	OAuthSessions int64     `json:"oauthSessions"`
+	UserSessions  int64     `json:"userSessions"`
+	Forced        bool      `json:"forced"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// SessionCleanupResponse represents the session cleanup settings and metrics of this instance since startup
+type SessionCleanupResponse struct {
+	IntervalHours       int64                      `json:"intervalHours"`
+	OAuthRetentionDays  int64                      `json:"oauthRetentionDays"`
+	UserRetentionDays   int64                      `json:"userRetentionDays"`
+	Runs                int64                      `json:"runs"`
+	Failures            int64                      `json:"failures"`
+	OAuthSessionsPurged int64                      `json:"oauthSessionsPurged"`
+	UserSessionsPurged  int64                      `json:"userSessionsPurged"`
+	LastRun             *SessionCleanupRunResponse `json:"lastRun,omitempty"`
+}
+
+// HandleGetStats handles GET /api/v1/admin/session-cleanup
+func (h *SessionCleanupHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	stats := h.cleaner.Stats()
+	shared.WriteJSON(w, http.StatusOK, SessionCleanupResponse{
+		IntervalHours:       int64(stats.Interval / time.Hour),
+		OAuthRetentionDays:  int64(stats.OAuthRetention / (24 * time.Hour)),
+		UserRetentionDays:   int64(stats.UserRetention / (24 * time.Hour)),
+		Runs:                stats.Runs,
+		Failures:            stats.Failures,
+		OAuthSessionsPurged: stats.OAuthSessionsTotal,
+		UserSessionsPurged:  stats.UserSessionsTotal,
+		LastRun:             toSessionCleanupRunResponse(stats.LastRun),
+	})
+}
+
+// HandleRun handles POST /api/v1/admin/session-cleanup/run
+func (h *SessionCleanupHandler) HandleRun(w http.ResponseWriter, r *http.Request) {
+	run, err := h.cleaner.RunCleanup(r.Context(), true)
+	if err != nil {
+		logger.Logger.Error("Forced session cleanup failed", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	if admin, ok := shared.GetUserFromContext(r.Context()); ok {
+		logger.Logger.Info("Admin forced a session cleanup", "admin", admin.Email,
+			"oauth_sessions", run.OAuthSessions, "user_sessions", run.UserSessions)
+	}
+	shared.WriteJSON(w, http.StatusOK, toSessionCleanupRunResponse(run))
+}
+
+func toSessionCleanupRunResponse(run *models.SessionCleanupRun) *SessionCleanupRunResponse {
+	if run == nil {
+		return nil
+	}
+	return &SessionCleanupRunResponse{
+		StartedAt:     run.StartedAt,
+		DurationMs:    run.Duration.Milliseconds(),
+		OAuthSessions: run.OAuthSessions,
+		UserSessions:  run.UserSessions,
+		Forced:        run.Forced,
+		Error:         run.Error,
+	}
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// sessionService lists and revokes the server-side sessions of any user
+type sessionService interface {
+	ListSessions(ctx context.Context, email, currentSessionID string) ([]*models.UserSession, error)
+	RevokeSession(ctx context.Context, email string, id int64) error
+	RevokeSessions(ctx context.Context, email, exceptSessionID string) (int64, error)
+}
+
+// SessionsHandler lets admins sign users out of their active sessions
+type SessionsHandler struct {
+	service sessionService
+}
+
+func NewSessionsHandler(service sessionService) *SessionsHandler {
+	return &SessionsHandler{service: service}
+}
+
+// HandleListUserSessions handles GET /api/v1/admin/users/{email}/sessions
+func (h *SessionsHandler) HandleListUserSessions(w http.ResponseWriter, r *http.Request) {
+	email, ok := sessionEmailParam(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.service.ListSessions(r.Context(), email, "")
+	if err != nil {
+		logger.Logger.Error("Failed to list user sessions", "email", email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, sessions)
+}
+
+// HandleRevokeUserSessions handles DELETE /api/v1/admin/users/{email}/sessions
+func (h *SessionsHandler) HandleRevokeUserSessions(w http.ResponseWriter, r *http.Request) {
+	email, ok := sessionEmailParam(w, r)
+	if !ok {
+		return
+	}
+
+	count, err := h.service.RevokeSessions(r.Context(), email, "")
+	if err != nil {
+		logger.Logger.Error("Failed to revoke user sessions", "email", email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	if admin, ok := shared.GetUserFromContext(r.Context()); ok {
+		logger.Logger.Info("Admin revoked user sessions", "admin", admin.Email, "email", email, "count", count)
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Sessions revoked",
+		"revoked": count,
+	})
+}
+
+// HandleRevokeUserSession handles DELETE /api/v1/admin/users/{email}/sessions/{id}
+func (h *SessionsHandler) HandleRevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	email, ok := sessionEmailParam(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid id", nil)
+		return
+	}
+
+	if err := h.service.RevokeSession(r.Context(), email, id); err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			shared.WriteNotFound(w, "Session")
+			return
+		}
+		logger.Logger.Error("Failed to revoke user session", "email", email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Session revoked"})
+}
+
+func sessionEmailParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	// Decode URL-encoded email (e.g., al%40bundy.com -> al@bundy.com)
+	email, err := url.QueryUnescape(chi.URLParam(r, "email"))
+	if err != nil || email == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid email format", nil)
+		return "", false
+	}
+	return email, true
+}
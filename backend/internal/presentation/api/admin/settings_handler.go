@@ -5,9 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -16,20 +20,30 @@ import (
 type configService interface {
 	GetConfig() *models.MutableConfig
 	UpdateSection(ctx context.Context, category models.ConfigCategory, input json.RawMessage, updatedBy string) error
-	TestSMTP(ctx context.Context, cfg models.SMTPConfig) error
+	PreviewSection(category models.ConfigCategory, input json.RawMessage) (*models.ConfigPreview, error)
+	StageSection(ctx context.Context, category models.ConfigCategory, input json.RawMessage, stagedBy string) (*models.PendingConfig, error)
+	PendingChanges(ctx context.Context) (*models.PendingConfig, error)
+	ApplyPending(ctx context.Context, appliedBy string) ([]models.ConfigChange, error)
+	DiscardPending(ctx context.Context, category models.ConfigCategory) error
+	TestSMTP(ctx context.Context, cfg models.SMTPConfig) (string, error)
 	TestS3(ctx context.Context, cfg models.StorageConfig) error
 	TestOIDC(ctx context.Context, cfg models.OIDCConfig) error
 	ResetFromENV(ctx context.Context, updatedBy string) error
+	ExportBundle(passphrase string) (*models.ConfigBundle, error)
+	ImportBundle(ctx context.Context, bundle *models.ConfigBundle, passphrase, updatedBy string) error
+	CheckSecrets(ctx context.Context) (*models.ConfigSecretsStatus, error)
+	RotateSecrets(ctx context.Context, updatedBy string) (*models.ConfigSecretsStatus, error)
 }
 
 // SettingsHandler handles admin settings endpoints
 type SettingsHandler struct {
 	configService configService
+	baseURL       string
 }
 
 // NewSettingsHandler creates a new settings handler
-func NewSettingsHandler(configService configService) *SettingsHandler {
-	return &SettingsHandler{configService: configService}
+func NewSettingsHandler(configService configService, baseURL string) *SettingsHandler {
+	return &SettingsHandler{configService: configService, baseURL: baseURL}
 }
 
 // SettingsResponse represents the full settings response
@@ -39,6 +53,9 @@ type SettingsResponse struct {
 	MagicLink models.MagicLinkConfig `json:"magiclink"`
 	SMTP      SMTPResponse           `json:"smtp"`
 	Storage   StorageResponse        `json:"storage"`
+	Branding  BrandingResponse       `json:"branding"`
+	Reminders models.ReminderConfig  `json:"reminders"`
+	Security  models.SecurityConfig  `json:"security"`
 	UpdatedAt string                 `json:"updated_at"`
 }
 
@@ -63,8 +80,9 @@ type SMTPResponse struct {
 	Port               int    `json:"port"`
 	Username           string `json:"username"`
 	Password           string `json:"password"`
-	TLS                bool   `json:"tls"`
-	StartTLS           bool   `json:"starttls"`
+	Encryption         string `json:"encryption"`
+	TLS                bool   `json:"tls"`      // Former flag, set from the encryption for older clients
+	StartTLS           bool   `json:"starttls"` // Former flag, set from the encryption for older clients
 	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
 	Timeout            string `json:"timeout"`
 	From               string `json:"from"`
@@ -83,6 +101,17 @@ type StorageResponse struct {
 	S3SecretKey string `json:"s3_secret_key,omitempty"`
 	S3Region    string `json:"s3_region,omitempty"`
 	S3UseSSL    bool   `json:"s3_use_ssl"`
+
+	AllowedMimeTypes []string `json:"allowed_mime_types,omitempty"`
+}
+
+// BrandingResponse is BrandingConfig with the logo storage key replaced by its public URL
+type BrandingResponse struct {
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	EmailFooter  string `json:"email_footer,omitempty"`
+	SupportEmail string `json:"support_email,omitempty"`
+	SupportURL   string `json:"support_url,omitempty"`
 }
 
 // HandleGetSettings handles GET /api/v1/admin/settings
@@ -111,8 +140,9 @@ func (h *SettingsHandler) HandleGetSettings(w http.ResponseWriter, r *http.Reque
 			Port:               cfg.SMTP.Port,
 			Username:           cfg.SMTP.Username,
 			Password:           maskSecret(cfg.SMTP.Password),
-			TLS:                cfg.SMTP.TLS,
-			StartTLS:           cfg.SMTP.StartTLS,
+			Encryption:         cfg.SMTP.EncryptionMode(),
+			TLS:                cfg.SMTP.EncryptionMode() == models.SMTPEncryptionImplicit,
+			StartTLS:           cfg.SMTP.EncryptionMode() == models.SMTPEncryptionStartTLS,
 			InsecureSkipVerify: cfg.SMTP.InsecureSkipVerify,
 			Timeout:            cfg.SMTP.Timeout,
 			From:               cfg.SMTP.From,
@@ -129,14 +159,25 @@ func (h *SettingsHandler) HandleGetSettings(w http.ResponseWriter, r *http.Reque
 			S3SecretKey: maskSecret(cfg.Storage.S3SecretKey),
 			S3Region:    cfg.Storage.S3Region,
 			S3UseSSL:    cfg.Storage.S3UseSSL,
+
+			AllowedMimeTypes: cfg.Storage.AllowedMimeTypes,
 		},
+		Branding: BrandingResponse{
+			LogoURL:      cfg.Branding.LogoURL(h.baseURL),
+			PrimaryColor: cfg.Branding.PrimaryColor,
+			EmailFooter:  cfg.Branding.EmailFooter,
+			SupportEmail: cfg.Branding.SupportEmail,
+			SupportURL:   cfg.Branding.SupportURL,
+		},
+		Reminders: cfg.Reminders,
+		Security:  cfg.Security,
 		UpdatedAt: cfg.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	shared.WriteJSON(w, http.StatusOK, response)
 }
 
-// HandleUpdateSection handles PUT /api/v1/admin/settings/{section}
+// HandleUpdateSection handles PUT /api/v1/admin/settings/{section}[?dryRun=true]
 func (h *SettingsHandler) HandleUpdateSection(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	section := chi.URLParam(r, "section")
@@ -159,6 +200,17 @@ func (h *SettingsHandler) HandleUpdateSection(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Validate only: report errors and changes without storing anything
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun")); dryRun {
+		preview, err := h.configService.PreviewSection(category, input)
+		if err != nil {
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, err.Error(), nil)
+			return
+		}
+		shared.WriteJSON(w, http.StatusOK, preview)
+		return
+	}
+
 	if err := h.configService.UpdateSection(ctx, category, input, user.Email); err != nil {
 		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, err.Error(), nil)
 		return
@@ -167,12 +219,101 @@ func (h *SettingsHandler) HandleUpdateSection(w http.ResponseWriter, r *http.Req
 	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Configuration updated"})
 }
 
+// HandleGetPending handles GET /api/v1/admin/settings/pending
+func (h *SettingsHandler) HandleGetPending(w http.ResponseWriter, r *http.Request) {
+	pending, err := h.configService.PendingChanges(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to list pending config changes", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, pending)
+}
+
+// HandleStageSection handles PUT /api/v1/admin/settings/pending/{section}
+func (h *SettingsHandler) HandleStageSection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	section := chi.URLParam(r, "section")
+
+	category, err := parseCategory(section)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid section: "+section, nil)
+		return
+	}
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	var input json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid JSON: "+err.Error(), nil)
+		return
+	}
+
+	pending, err := h.configService.StageSection(ctx, category, input, user.Email)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, err.Error(), nil)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, pending)
+}
+
+// HandleApplyPending handles POST /api/v1/admin/settings/pending/apply
+func (h *SettingsHandler) HandleApplyPending(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	changes, err := h.configService.ApplyPending(ctx, user.Email)
+	if errors.Is(err, services.ErrNoPendingConfig) {
+		shared.WriteConflict(w, err.Error())
+		return
+	}
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, err.Error(), nil)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Configuration updated",
+		"changes": changes,
+	})
+}
+
+// HandleDiscardPending handles DELETE /api/v1/admin/settings/pending[/{section}]
+func (h *SettingsHandler) HandleDiscardPending(w http.ResponseWriter, r *http.Request) {
+	var category models.ConfigCategory
+	if section := chi.URLParam(r, "section"); section != "" {
+		parsed, err := parseCategory(section)
+		if err != nil {
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid section: "+section, nil)
+			return
+		}
+		category = parsed
+	}
+
+	if err := h.configService.DiscardPending(r.Context(), category); err != nil {
+		logger.Logger.Error("Failed to discard pending config changes", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Pending changes discarded"})
+}
+
 // HandleTestConnection handles POST /api/v1/admin/settings/test/{type}
 func (h *SettingsHandler) HandleTestConnection(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	testType := chi.URLParam(r, "type")
 
 	var err error
+	details := map[string]string{"message": "Connection successful"}
 	switch testType {
 	case "smtp":
 		var cfg models.SMTPConfig
@@ -180,7 +321,11 @@ func (h *SettingsHandler) HandleTestConnection(w http.ResponseWriter, r *http.Re
 			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid SMTP config: "+decErr.Error(), nil)
 			return
 		}
-		err = h.configService.TestSMTP(ctx, cfg)
+		var encryption string
+		encryption, err = h.configService.TestSMTP(ctx, cfg)
+		if encryption != "" {
+			details["encryption"] = encryption
+		}
 
 	case "s3":
 		var cfg models.StorageConfig
@@ -208,7 +353,7 @@ func (h *SettingsHandler) HandleTestConnection(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Connection successful"})
+	shared.WriteJSON(w, http.StatusOK, details)
 }
 
 // HandleResetFromENV handles POST /api/v1/admin/settings/reset
@@ -229,6 +374,87 @@ func (h *SettingsHandler) HandleResetFromENV(w http.ResponseWriter, r *http.Requ
 	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Configuration reset from environment"})
 }
 
+// HandleSecretsStatus handles GET /api/v1/admin/settings/secrets
+func (h *SettingsHandler) HandleSecretsStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := h.configService.CheckSecrets(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, status)
+}
+
+// HandleRotateSecrets handles POST /api/v1/admin/settings/secrets/rotate
+func (h *SettingsHandler) HandleRotateSecrets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	status, err := h.configService.RotateSecrets(ctx, user.Email)
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Rotation failed: "+err.Error(), nil)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, status)
+}
+
+// configBundleKeyHeader carries the passphrase encrypting the secrets of a config bundle.
+// A header keeps it out of URLs and access logs.
+const configBundleKeyHeader = "X-Config-Key"
+
+// maxConfigBundleSize limits the size of an imported config bundle
+const maxConfigBundleSize = 1 << 20
+
+// HandleExportConfig handles GET /api/v1/admin/config/export.
+// The bundle is returned as a JSON attachment that can be sent back as-is to HandleImportConfig.
+func (h *SettingsHandler) HandleExportConfig(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.configService.ExportBundle(r.Header.Get(configBundleKeyHeader))
+	if err != nil {
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Export failed: "+err.Error(), nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="ackify-config-%s.json"`, bundle.ExportedAt.Format("20060102-150405")))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(bundle)
+}
+
+// HandleImportConfig handles PUT /api/v1/admin/config/export
+func (h *SettingsHandler) HandleImportConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	var bundle models.ConfigBundle
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxConfigBundleSize)).Decode(&bundle); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid config bundle: "+err.Error(), nil)
+		return
+	}
+
+	if err := h.configService.ImportBundle(ctx, &bundle, r.Header.Get(configBundleKeyHeader), user.Email); err != nil {
+		if errors.Is(err, services.ErrBundleKeyRequired) || errors.Is(err, services.ErrBundleInvalidKey) {
+			shared.WriteError(w, http.StatusUnprocessableEntity, shared.ErrCodeBadRequest, err.Error(), nil)
+			return
+		}
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, err.Error(), nil)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Configuration imported"})
+}
+
 // parseCategory converts a string to a ConfigCategory
 func parseCategory(s string) (models.ConfigCategory, error) {
 	category := models.ConfigCategory(s)
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// shortLinkService manages the short links and QR codes of documents
+type shortLinkService interface {
+	GetOrCreate(ctx context.Context, docID, locale, createdBy string) (*models.ShortLink, error)
+	List(ctx context.Context, docID string) ([]*models.ShortLink, error)
+	Delete(ctx context.Context, docID, code string) error
+	QRCode(ctx context.Context, docID, locale, format, createdBy string) ([]byte, string, error)
+}
+
+// ShortLinksHandler exposes the short links of documents and their QR codes, printed on notices
+type ShortLinksHandler struct {
+	service shortLinkService
+}
+
+func NewShortLinksHandler(service shortLinkService) *ShortLinksHandler {
+	return &ShortLinksHandler{service: service}
+}
+
+// ShortLinkRequest is the body of a short link creation
+type ShortLinkRequest struct {
+	Locale string `json:"locale"`
+}
+
+// HandleListShortLinks handles GET /api/v1/admin/documents/{docId}/short-links
+func (h *ShortLinksHandler) HandleListShortLinks(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	links, err := h.service.List(r.Context(), docID)
+	if err != nil {
+		logger.Logger.Error("Failed to list short links", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, links)
+}
+
+// HandleCreateShortLink handles POST /api/v1/admin/documents/{docId}/short-links, returning
+// the existing link of the locale when there is one
+func (h *ShortLinksHandler) HandleCreateShortLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req ShortLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	link, err := h.service.GetOrCreate(ctx, chi.URLParam(r, "docId"), req.Locale, currentUserEmail(ctx))
+	if err != nil {
+		writeShortLinkError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, link)
+}
+
+// HandleDeleteShortLink handles DELETE /api/v1/admin/documents/{docId}/short-links/{code}
+func (h *ShortLinksHandler) HandleDeleteShortLink(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.Delete(r.Context(), chi.URLParam(r, "docId"), chi.URLParam(r, "code")); err != nil {
+		writeShortLinkError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Short link deleted"})
+}
+
+// HandleGetQRCode handles GET /api/v1/admin/documents/{docId}/qr?locale=fr&format=png, returning
+// the QR code of the short link of the locale, created on first use. The format defaults to svg.
+func (h *ShortLinksHandler) HandleGetQRCode(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	docID := chi.URLParam(r, "docId")
+	query := r.URL.Query()
+
+	data, contentType, err := h.service.QRCode(ctx, docID, query.Get("locale"), query.Get("format"), currentUserEmail(ctx))
+	if err != nil {
+		writeShortLinkError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+func currentUserEmail(ctx context.Context) string {
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		return user.Email
+	}
+	return ""
+}
+
+func writeShortLinkError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	case errors.Is(err, models.ErrShortLinkNotFound):
+		shared.WriteNotFound(w, "Short link")
+	case errors.Is(err, services.ErrInvalidLocale), errors.Is(err, services.ErrInvalidQRFormat):
+		shared.WriteValidationError(w, err.Error(), nil)
+	default:
+		logger.Logger.Error("Failed to manage short link", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
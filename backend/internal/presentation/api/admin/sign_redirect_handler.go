@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// signRedirectService defines per-document redirect after signing operations
+type signRedirectService interface {
+	GetRedirect(ctx context.Context, docID string) (*models.SignRedirect, error)
+	SetRedirect(ctx context.Context, docID, url, updatedBy string) (*models.SignRedirect, error)
+	DeleteRedirect(ctx context.Context, docID string) error
+}
+
+// SignRedirectHandler exposes the URLs signers are sent to after signing documents
+type SignRedirectHandler struct {
+	service signRedirectService
+}
+
+func NewSignRedirectHandler(service signRedirectService) *SignRedirectHandler {
+	return &SignRedirectHandler{service: service}
+}
+
+// SignRedirectRequest sets the redirect of a document
+type SignRedirectRequest struct {
+	URL string `json:"url"`
+}
+
+// HandleGetRedirect handles GET /api/v1/admin/documents/{docId}/sign-redirect
+func (h *SignRedirectHandler) HandleGetRedirect(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	redirect, err := h.service.GetRedirect(r.Context(), docID)
+	if err != nil {
+		writeSignRedirectError(w, err, docID)
+		return
+	}
+	if redirect == nil {
+		shared.WriteNotFound(w, "Sign redirect")
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, redirect)
+}
+
+// HandleSetRedirect handles PUT /api/v1/admin/documents/{docId}/sign-redirect
+func (h *SignRedirectHandler) HandleSetRedirect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+
+	var req SignRedirectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	redirect, err := h.service.SetRedirect(ctx, docID, req.URL, user.Email)
+	if err != nil {
+		writeSignRedirectError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, redirect)
+}
+
+// HandleDeleteRedirect handles DELETE /api/v1/admin/documents/{docId}/sign-redirect
+func (h *SignRedirectHandler) HandleDeleteRedirect(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if err := h.service.DeleteRedirect(r.Context(), docID); err != nil {
+		writeSignRedirectError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Sign redirect deleted"})
+}
+
+func writeSignRedirectError(w http.ResponseWriter, err error, docID string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSignRedirect):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		logger.Logger.Error("Sign redirect operation failed", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
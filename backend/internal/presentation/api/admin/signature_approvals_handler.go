@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// signatureApprovalService reviews the signatures held for approval
+type signatureApprovalService interface {
+	List(ctx context.Context, docID, status string) ([]*models.SignatureApproval, error)
+	Approve(ctx context.Context, id int64, reviewedBy string) (*models.SignatureApproval, error)
+	Reject(ctx context.Context, id int64, reviewedBy string) (*models.SignatureApproval, error)
+}
+
+// SignatureApprovalsHandler exposes the signatures held for approval to admins
+type SignatureApprovalsHandler struct {
+	service signatureApprovalService
+}
+
+func NewSignatureApprovalsHandler(service signatureApprovalService) *SignatureApprovalsHandler {
+	return &SignatureApprovalsHandler{service: service}
+}
+
+// HandleList handles GET /api/v1/admin/signature-approvals?docId=&status=pending|approved|rejected
+func (h *SignatureApprovalsHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	approvals, err := h.service.List(r.Context(), query.Get("docId"), query.Get("status"))
+	if err != nil {
+		writeApprovalError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, approvals)
+}
+
+// HandleApprove handles POST /api/v1/admin/signature-approvals/{id}/approve
+func (h *SignatureApprovalsHandler) HandleApprove(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.Approve, "Held signature approved")
+}
+
+// HandleReject handles POST /api/v1/admin/signature-approvals/{id}/reject
+func (h *SignatureApprovalsHandler) HandleReject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.Reject, "Held signature rejected")
+}
+
+func (h *SignatureApprovalsHandler) review(w http.ResponseWriter, r *http.Request, decide func(context.Context, int64, string) (*models.SignatureApproval, error), message string) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid signature approval ID", nil)
+		return
+	}
+	reviewedBy := ""
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		reviewedBy = user.Email
+	}
+
+	approval, err := decide(ctx, id, reviewedBy)
+	if err != nil {
+		writeApprovalError(w, err)
+		return
+	}
+	logger.Logger.Info(message, "id", id, "doc_id", approval.DocID, "admin", reviewedBy)
+	shared.WriteJSON(w, http.StatusOK, approval)
+}
+
+func writeApprovalError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidApprovalStatus):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrApprovalNotFound):
+		shared.WriteNotFound(w, "Signature approval")
+	case errors.Is(err, models.ErrApprovalReviewed):
+		shared.WriteConflict(w, err.Error())
+	default:
+		logger.Logger.Error("Signature approval request failed", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// signatureArchiveService queues the signature archive of all documents
+type signatureArchiveService interface {
+	Request(ctx context.Context, requestedBy string) (*models.Job, error)
+}
+
+// SignatureArchiveHandler lets admins request the signature archive of all documents
+type SignatureArchiveHandler struct {
+	service signatureArchiveService
+}
+
+func NewSignatureArchiveHandler(service signatureArchiveService) *SignatureArchiveHandler {
+	return &SignatureArchiveHandler{service: service}
+}
+
+// HandleRequest handles POST /api/v1/admin/exports/signatures. The archive is built by a job:
+// its progress and, once succeeded, its download link are read from GET /api/v1/admin/jobs/{id}.
+func (h *SignatureArchiveHandler) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	job, err := h.service.Request(r.Context(), user.Email)
+	if err != nil {
+		logger.Logger.Error("Failed to queue signature archive", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// signatureHookService defines per-document signature validation hook operations
+type signatureHookService interface {
+	GetHook(ctx context.Context, docID string) (*models.SignatureHook, error)
+	SetHook(ctx context.Context, docID string, input models.SignatureHookInput, updatedBy string) (*models.SignatureHook, error)
+	DeleteHook(ctx context.Context, docID string) error
+}
+
+// SignatureHookHandler exposes the validation hooks evaluated before documents are signed
+type SignatureHookHandler struct {
+	service signatureHookService
+}
+
+func NewSignatureHookHandler(service signatureHookService) *SignatureHookHandler {
+	return &SignatureHookHandler{service: service}
+}
+
+// HandleGetHook handles GET /api/v1/admin/documents/{docId}/signature-hook
+func (h *SignatureHookHandler) HandleGetHook(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	hook, err := h.service.GetHook(r.Context(), docID)
+	if err != nil {
+		writeSignatureHookError(w, err, docID)
+		return
+	}
+	if hook == nil {
+		shared.WriteNotFound(w, "Signature hook")
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, hook)
+}
+
+// HandleSetHook handles PUT /api/v1/admin/documents/{docId}/signature-hook
+func (h *SignatureHookHandler) HandleSetHook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	var input models.SignatureHookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	hook, err := h.service.SetHook(ctx, docID, input, user.Email)
+	if err != nil {
+		writeSignatureHookError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, hook)
+}
+
+// HandleDeleteHook handles DELETE /api/v1/admin/documents/{docId}/signature-hook
+func (h *SignatureHookHandler) HandleDeleteHook(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	if err := h.service.DeleteHook(r.Context(), docID); err != nil {
+		writeSignatureHookError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Signature hook deleted"})
+}
+
+func writeSignatureHookError(w http.ResponseWriter, err error, docID string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSignatureHook):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		logger.Logger.Error("Signature hook operation failed", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
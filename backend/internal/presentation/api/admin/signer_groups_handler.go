@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// signerGroupService defines signer group management and directory sync operations
+type signerGroupService interface {
+	Providers() []string
+	CreateGroup(ctx context.Context, input models.SignerGroupInput) (*models.SignerGroup, error)
+	UpdateGroup(ctx context.Context, id int64, input models.SignerGroupInput) (*models.SignerGroup, error)
+	DeleteGroup(ctx context.Context, id int64) error
+	GetGroup(ctx context.Context, id int64) (*models.SignerGroup, error)
+	ListGroups(ctx context.Context) ([]*models.SignerGroup, error)
+	ListMembers(ctx context.Context, id int64) ([]models.SignerGroupMember, error)
+	AddMembers(ctx context.Context, id int64, members []models.SignerGroupMember) error
+	RemoveMember(ctx context.Context, id int64, email string) error
+	SyncGroup(ctx context.Context, id int64, dryRun bool) (*models.DirectorySyncResult, error)
+	AddToDocument(ctx context.Context, docID string, id int64, addedBy string) (int, error)
+}
+
+// SignerGroupsHandler groups operations on signer groups and their directory sync
+type SignerGroupsHandler struct {
+	service signerGroupService
+}
+
+func NewSignerGroupsHandler(service signerGroupService) *SignerGroupsHandler {
+	return &SignerGroupsHandler{service: service}
+}
+
+type SignerGroupRequest struct {
+	Name              string                  `json:"name"`
+	Description       string                  `json:"description"`
+	DirectoryProvider string                  `json:"directoryProvider"`
+	DirectoryGroup    string                  `json:"directoryGroup"`
+	Mapping           models.DirectoryMapping `json:"mapping"`
+	SyncEnabled       bool                    `json:"syncEnabled"`
+}
+
+func (req SignerGroupRequest) toInput() models.SignerGroupInput {
+	return models.SignerGroupInput{
+		Name:              req.Name,
+		Description:       req.Description,
+		DirectoryProvider: req.DirectoryProvider,
+		DirectoryGroup:    req.DirectoryGroup,
+		Mapping:           req.Mapping,
+		SyncEnabled:       req.SyncEnabled,
+	}
+}
+
+type AddGroupMembersRequest struct {
+	Members []models.SignerGroupMember `json:"members"`
+}
+
+// HandleListProviders handles GET /api/v1/admin/signer-groups/providers
+func (h *SignerGroupsHandler) HandleListProviders(w http.ResponseWriter, r *http.Request) {
+	shared.WriteJSON(w, http.StatusOK, map[string][]string{"providers": h.service.Providers()})
+}
+
+// HandleListGroups handles GET /api/v1/admin/signer-groups
+func (h *SignerGroupsHandler) HandleListGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.ListGroups(r.Context())
+	if err != nil {
+		shared.WriteInternalError(w)
+		return
+	}
+	if groups == nil {
+		groups = []*models.SignerGroup{}
+	}
+	shared.WriteJSON(w, http.StatusOK, groups)
+}
+
+// HandleCreateGroup handles POST /api/v1/admin/signer-groups
+func (h *SignerGroupsHandler) HandleCreateGroup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req SignerGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	input := req.toInput()
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		input.CreatedBy = user.Email
+	}
+	group, err := h.service.CreateGroup(ctx, input)
+	if err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, group)
+}
+
+// HandleGetGroup handles GET /api/v1/admin/signer-groups/{id}
+func (h *SignerGroupsHandler) HandleGetGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	group, err := h.service.GetGroup(r.Context(), id)
+	if err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, group)
+}
+
+// HandleUpdateGroup handles PUT /api/v1/admin/signer-groups/{id}
+func (h *SignerGroupsHandler) HandleUpdateGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	var req SignerGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	group, err := h.service.UpdateGroup(r.Context(), id, req.toInput())
+	if err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, group)
+}
+
+// HandleDeleteGroup handles DELETE /api/v1/admin/signer-groups/{id}
+func (h *SignerGroupsHandler) HandleDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteGroup(r.Context(), id); err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Signer group deleted"})
+}
+
+// HandleListMembers handles GET /api/v1/admin/signer-groups/{id}/members
+func (h *SignerGroupsHandler) HandleListMembers(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	members, err := h.service.ListMembers(r.Context(), id)
+	if err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	if members == nil {
+		members = []models.SignerGroupMember{}
+	}
+	shared.WriteJSON(w, http.StatusOK, members)
+}
+
+// HandleAddMembers handles POST /api/v1/admin/signer-groups/{id}/members
+func (h *SignerGroupsHandler) HandleAddMembers(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	var req AddGroupMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Members) == 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "At least one member is required", nil)
+		return
+	}
+	if err := h.service.AddMembers(r.Context(), id, req.Members); err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, map[string]int{"added": len(req.Members)})
+}
+
+// HandleRemoveMember handles DELETE /api/v1/admin/signer-groups/{id}/members/{email}
+func (h *SignerGroupsHandler) HandleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	email, err := url.PathUnescape(chi.URLParam(r, "email"))
+	if err != nil || email == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Email is required", nil)
+		return
+	}
+	if err := h.service.RemoveMember(r.Context(), id, email); err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Member removed"})
+}
+
+// HandleSyncGroup handles POST /api/v1/admin/signer-groups/{id}/sync[?dryRun=true]
+func (h *SignerGroupsHandler) HandleSyncGroup(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	result, err := h.service.SyncGroup(r.Context(), id, dryRun)
+	if err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
+
+// HandleAddToDocument handles POST /api/v1/admin/documents/{docId}/signers/groups/{id}
+func (h *SignerGroupsHandler) HandleAddToDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+	id, ok := parseSignerGroupID(w, r)
+	if !ok {
+		return
+	}
+	count, err := h.service.AddToDocument(ctx, docID, id, user.Email)
+	if err != nil {
+		writeSignerGroupError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "groupId": id, "memberCount": count})
+}
+
+func parseSignerGroupID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid signer group ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeSignerGroupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSignerGroup):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, services.ErrDirectoryGroup), errors.Is(err, models.ErrSignerGroupExists):
+		shared.WriteConflict(w, err.Error())
+	case errors.Is(err, services.ErrDirectorySync):
+		shared.WriteError(w, http.StatusBadGateway, shared.ErrCodeServiceUnavailable, err.Error(), nil)
+	case errors.Is(err, models.ErrSignerGroupNotFound):
+		shared.WriteNotFound(w, "Signer group")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		shared.WriteInternalError(w)
+	}
+}
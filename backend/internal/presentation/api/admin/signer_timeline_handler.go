@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// signerTimelineService returns the events of a signer on a document
+type signerTimelineService interface {
+	GetTimeline(ctx context.Context, docID, email string) (*models.SignerTimeline, error)
+}
+
+// SignerTimelineHandler exposes the per-signer event history of documents
+type SignerTimelineHandler struct {
+	service signerTimelineService
+}
+
+func NewSignerTimelineHandler(service signerTimelineService) *SignerTimelineHandler {
+	return &SignerTimelineHandler{service: service}
+}
+
+// HandleGetTimeline handles GET /api/v1/admin/documents/{docId}/signers/{email}/timeline
+func (h *SignerTimelineHandler) HandleGetTimeline(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	email, err := url.PathUnescape(chi.URLParam(r, "email"))
+	if err != nil || !strings.Contains(email, "@") {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid email address", nil)
+		return
+	}
+
+	timeline, err := h.service.GetTimeline(r.Context(), docID, email)
+	if err != nil {
+		if errors.Is(err, models.ErrDocumentNotFound) {
+			shared.WriteNotFound(w, "Document")
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, timeline)
+}
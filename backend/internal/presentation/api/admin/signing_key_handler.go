@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// keyBackupPassphraseHeader carries the passphrase encrypting a signing key backup.
+// A header keeps it out of URLs and access logs.
+const keyBackupPassphraseHeader = "X-Backup-Passphrase"
+
+// signingKeyService reports the health of the signer key and exports encrypted backups of it
+type signingKeyService interface {
+	Status() *models.SigningKeyStatus
+	Check() *models.SigningKeyStatus
+	ExportBackup(passphrase string) (*crypto.KeyBackup, error)
+}
+
+// SigningKeyHandler exposes the signer key checks and backups to admins
+type SigningKeyHandler struct {
+	service signingKeyService
+}
+
+func NewSigningKeyHandler(service signingKeyService) *SigningKeyHandler {
+	return &SigningKeyHandler{service: service}
+}
+
+// HandleGetStatus handles GET /api/v1/admin/signing-key
+func (h *SigningKeyHandler) HandleGetStatus(w http.ResponseWriter, r *http.Request) {
+	shared.WriteJSON(w, http.StatusOK, h.service.Status())
+}
+
+// HandleCheck handles POST /api/v1/admin/signing-key/check, running the checks immediately
+func (h *SigningKeyHandler) HandleCheck(w http.ResponseWriter, r *http.Request) {
+	shared.WriteJSON(w, http.StatusOK, h.service.Check())
+}
+
+// HandleExportBackup handles POST /api/v1/admin/signing-key/backup.
+// The backup is returned as a JSON attachment, its private key encrypted with the passphrase
+// of the X-Backup-Passphrase header.
+func (h *SigningKeyHandler) HandleExportBackup(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+
+	backup, err := h.service.ExportBackup(r.Header.Get(keyBackupPassphraseHeader))
+	if errors.Is(err, services.ErrKeyBackupPassphraseTooShort) {
+		shared.WriteValidationError(w, err.Error(), nil)
+		return
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to export signing key backup", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	logger.Logger.Info("Signing key backup exported", "by", user.Email, "public_key", backup.PublicKey)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="ackify-signing-key-%s.json"`, backup.CreatedAt.Format("20060102-150405")))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(backup)
+}
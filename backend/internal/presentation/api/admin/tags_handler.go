@@ -0,0 +1,248 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxTagSuggestions bounds the tags returned for autocompletion
+const maxTagSuggestions = 50
+
+// tagService defines document tag and tag policy operations
+type tagService interface {
+	DocumentTags(ctx context.Context, docID string) ([]string, error)
+	SetDocumentTags(ctx context.Context, docID string, tags []string, updatedBy string) ([]string, error)
+	ListTags(ctx context.Context, query string, limit int) ([]models.TagCount, error)
+	RenameTag(ctx context.Context, from, to string) (int, error)
+	DeleteTag(ctx context.Context, tag string) (int, error)
+	Stats(ctx context.Context, tag string) (*models.TagStats, error)
+	ListPolicies(ctx context.Context) ([]*models.TagPolicy, error)
+	SetPolicy(ctx context.Context, policy models.TagPolicy, updatedBy string) (*models.TagPolicy, error)
+	DeletePolicy(ctx context.Context, tag string) error
+}
+
+// TagsHandler groups operations on document tags and tag policies
+type TagsHandler struct {
+	service tagService
+}
+
+func NewTagsHandler(service tagService) *TagsHandler {
+	return &TagsHandler{service: service}
+}
+
+type DocumentTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+type TagPolicyRequest struct {
+	FirmAfterReminders  int  `json:"firmAfterReminders"`
+	FirmAfterDays       int  `json:"firmAfterDays"`
+	FinalAfterReminders int  `json:"finalAfterReminders"`
+	FinalAfterDays      int  `json:"finalAfterDays"`
+	ArchiveAfterDays    *int `json:"archiveAfterDays"`
+}
+
+// HandleGetDocumentTags handles GET /api/v1/admin/documents/{docId}/tags
+func (h *TagsHandler) HandleGetDocumentTags(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+	tags, err := h.service.DocumentTags(r.Context(), docID)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "tags": tags})
+}
+
+// HandleSetDocumentTags handles PUT /api/v1/admin/documents/{docId}/tags, replacing the tags
+// of the document; an empty list removes them all
+func (h *TagsHandler) HandleSetDocumentTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+	var req DocumentTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	tags, err := h.service.SetDocumentTags(ctx, docID, req.Tags, user.Email)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "tags": tags})
+}
+
+// HandleListTags handles GET /api/v1/admin/tags?q=...&limit=20, the tags containing q with
+// their document count, for autocompletion
+func (h *TagsHandler) HandleListTags(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if v, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && v > 0 {
+		limit = min(v, maxTagSuggestions)
+	}
+	tags, err := h.service.ListTags(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	if tags == nil {
+		tags = []models.TagCount{}
+	}
+	shared.WriteJSON(w, http.StatusOK, tags)
+}
+
+// HandleRenameTag handles PUT /api/v1/admin/tags/{tag}, renaming the tag on every document
+func (h *TagsHandler) HandleRenameTag(w http.ResponseWriter, r *http.Request) {
+	tag, ok := parseTag(w, r)
+	if !ok {
+		return
+	}
+	var req RenameTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	count, err := h.service.RenameTag(r.Context(), tag, req.Name)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	name, _ := models.NormalizeTag(req.Name)
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"tag": name, "documents": count})
+}
+
+// HandleDeleteTag handles DELETE /api/v1/admin/tags/{tag}, removing the tag from every document
+func (h *TagsHandler) HandleDeleteTag(w http.ResponseWriter, r *http.Request) {
+	tag, ok := parseTag(w, r)
+	if !ok {
+		return
+	}
+	count, err := h.service.DeleteTag(r.Context(), tag)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"message": "Tag deleted", "documents": count})
+}
+
+// HandleGetStats handles GET /api/v1/admin/tags/{tag}/stats
+func (h *TagsHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
+	tag, ok := parseTag(w, r)
+	if !ok {
+		return
+	}
+	stats, err := h.service.Stats(r.Context(), tag)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, stats)
+}
+
+// HandleListPolicies handles GET /api/v1/admin/tag-policies
+func (h *TagsHandler) HandleListPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.service.ListPolicies(r.Context())
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	if policies == nil {
+		policies = []*models.TagPolicy{}
+	}
+	shared.WriteJSON(w, http.StatusOK, policies)
+}
+
+// HandleSetPolicy handles PUT /api/v1/admin/tag-policies/{tag}
+func (h *TagsHandler) HandleSetPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	tag, ok := parseTag(w, r)
+	if !ok {
+		return
+	}
+	var req TagPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	policy, err := h.service.SetPolicy(ctx, models.TagPolicy{
+		Tag:                 tag,
+		FirmAfterReminders:  req.FirmAfterReminders,
+		FirmAfterDays:       req.FirmAfterDays,
+		FinalAfterReminders: req.FinalAfterReminders,
+		FinalAfterDays:      req.FinalAfterDays,
+		ArchiveAfterDays:    req.ArchiveAfterDays,
+	}, user.Email)
+	if err != nil {
+		writeTagError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, policy)
+}
+
+// HandleDeletePolicy handles DELETE /api/v1/admin/tag-policies/{tag}
+func (h *TagsHandler) HandleDeletePolicy(w http.ResponseWriter, r *http.Request) {
+	tag, ok := parseTag(w, r)
+	if !ok {
+		return
+	}
+	if err := h.service.DeletePolicy(r.Context(), tag); err != nil {
+		writeTagError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Tag policy deleted"})
+}
+
+func parseTag(w http.ResponseWriter, r *http.Request) (string, bool) {
+	tag, err := url.PathUnescape(chi.URLParam(r, "tag"))
+	if err != nil || strings.TrimSpace(tag) == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Tag is required", nil)
+		return "", false
+	}
+	return tag, true
+}
+
+func writeTagError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrInvalidTag), errors.Is(err, services.ErrInvalidTagPolicy):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrTagNotFound):
+		shared.WriteNotFound(w, "Tag")
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	default:
+		logger.Logger.Error("Tag operation failed", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// uploadQuarantineService reviews the uploads held back by the upload policy or the virus scanner
+type uploadQuarantineService interface {
+	List(ctx context.Context, status string) ([]*models.QuarantinedUpload, error)
+	Release(ctx context.Context, id int64, reviewedBy string) (*models.QuarantinedUpload, error)
+	Discard(ctx context.Context, id int64, reviewedBy string) (*models.QuarantinedUpload, error)
+}
+
+// UploadQuarantineHandler exposes the quarantine queue of uploads to admins
+type UploadQuarantineHandler struct {
+	service uploadQuarantineService
+}
+
+func NewUploadQuarantineHandler(service uploadQuarantineService) *UploadQuarantineHandler {
+	return &UploadQuarantineHandler{service: service}
+}
+
+// HandleList handles GET /api/v1/admin/uploads/quarantine?status=pending|released|discarded
+func (h *UploadQuarantineHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	uploads, err := h.service.List(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		writeQuarantineError(w, err)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, uploads)
+}
+
+// HandleRelease handles POST /api/v1/admin/uploads/quarantine/{id}/release
+func (h *UploadQuarantineHandler) HandleRelease(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.Release, "Quarantined upload released")
+}
+
+// HandleDiscard handles POST /api/v1/admin/uploads/quarantine/{id}/discard
+func (h *UploadQuarantineHandler) HandleDiscard(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.service.Discard, "Quarantined upload discarded")
+}
+
+func (h *UploadQuarantineHandler) review(w http.ResponseWriter, r *http.Request, decide func(context.Context, int64, string) (*models.QuarantinedUpload, error), message string) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid quarantined upload ID", nil)
+		return
+	}
+	reviewedBy := ""
+	if user, _ := shared.GetUserFromContext(ctx); user != nil {
+		reviewedBy = user.Email
+	}
+
+	upload, err := decide(ctx, id, reviewedBy)
+	if err != nil {
+		writeQuarantineError(w, err)
+		return
+	}
+	logger.Logger.Info(message, "id", id, "reason", upload.Reason, "admin", reviewedBy)
+	shared.WriteJSON(w, http.StatusOK, upload)
+}
+
+func writeQuarantineError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidQuarantineStatus):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrQuarantineNotFound):
+		shared.WriteNotFound(w, "Quarantined upload")
+	case errors.Is(err, models.ErrQuarantineReviewed):
+		shared.WriteConflict(w, err.Error())
+	default:
+		logger.Logger.Error("Upload quarantine request failed", "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
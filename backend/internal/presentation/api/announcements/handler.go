@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package announcements
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// announcementService defines the published announcement operations
+type announcementService interface {
+	ListActive(ctx context.Context, authenticated, admin bool) ([]*models.Announcement, error)
+}
+
+// Handler serves the banners currently published to the visitor, rendered by the SPA
+type Handler struct {
+	service    announcementService
+	authorizer providers.Authorizer
+}
+
+// NewHandler creates a new announcements handler
+func NewHandler(service announcementService, authorizer providers.Authorizer) *Handler {
+	return &Handler{service: service, authorizer: authorizer}
+}
+
+// AnnouncementResponse represents a published banner
+type AnnouncementResponse struct {
+	ID       int64                       `json:"id"`
+	Severity models.AnnouncementSeverity `json:"severity"`
+	Message  string                      `json:"message"`
+	EndsAt   *string                     `json:"endsAt,omitempty"`
+}
+
+// HandleListAnnouncements handles GET /api/v1/announcements.
+// Must run after OptionalAuth: signed-in users and admins also see the banners targeted at them.
+func (h *Handler) HandleListAnnouncements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, authenticated := shared.GetUserFromContext(ctx)
+	authenticated = authenticated && user != nil
+	admin := authenticated && h.authorizer != nil && h.authorizer.IsAdmin(ctx, user.Email)
+
+	announcements, err := h.service.ListActive(ctx, authenticated, admin)
+	if err != nil {
+		logger.Logger.Error("Failed to list announcements", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	response := make([]*AnnouncementResponse, 0, len(announcements))
+	for _, a := range announcements {
+		item := &AnnouncementResponse{ID: a.ID, Severity: a.Severity, Message: a.Message}
+		if a.EndsAt != nil {
+			endsAt := a.EndsAt.UTC().Format("2006-01-02T15:04:05Z07:00")
+			item.EndsAt = &endsAt
+		}
+		response = append(response, item)
+	}
+
+	shared.WriteJSON(w, http.StatusOK, response)
+}
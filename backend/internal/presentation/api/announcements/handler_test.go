@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package announcements
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/types"
+)
+
+type mockAnnouncementService struct {
+	announcements        []*models.Announcement
+	err                  error
+	authenticated, admin bool
+}
+
+func (m *mockAnnouncementService) ListActive(_ context.Context, authenticated, admin bool) ([]*models.Announcement, error) {
+	m.authenticated, m.admin = authenticated, admin
+	return m.announcements, m.err
+}
+
+type mockAuthorizer struct {
+	admin string
+}
+
+func (m *mockAuthorizer) IsAdmin(_ context.Context, email string) bool {
+	return email == m.admin
+}
+
+func (m *mockAuthorizer) CanCreateDocument(context.Context, string) bool { return true }
+
+func (m *mockAuthorizer) CanManageDocument(context.Context, string, string) bool { return false }
+
+func serve(h *Handler, user *types.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/announcements", nil)
+	if user != nil {
+		req = req.WithContext(context.WithValue(req.Context(), shared.ContextKeyUser, user))
+	}
+	rec := httptest.NewRecorder()
+	h.HandleListAnnouncements(rec, req)
+	return rec
+}
+
+func TestHandler_HandleListAnnouncements(t *testing.T) {
+	t.Parallel()
+
+	endsAt := time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC)
+	service := &mockAnnouncementService{announcements: []*models.Announcement{
+		{ID: 1, Severity: models.AnnouncementWarning, Message: "Signing paused for maintenance Friday", Audience: models.AudienceAll, EndsAt: &endsAt, CreatedBy: "admin@example.com"},
+	}}
+	h := NewHandler(service, &mockAuthorizer{admin: "admin@example.com"})
+
+	rec := serve(h, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, service.authenticated)
+	assert.False(t, service.admin)
+
+	var wrapper struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	require.Len(t, wrapper.Data, 1)
+	assert.Equal(t, "warning", wrapper.Data[0]["severity"])
+	assert.Equal(t, "2026-03-06T18:00:00Z", wrapper.Data[0]["endsAt"])
+	assert.NotContains(t, wrapper.Data[0], "createdBy", "authors are not exposed to visitors")
+
+	serve(h, &types.User{Email: "alice@example.com"})
+	assert.True(t, service.authenticated)
+	assert.False(t, service.admin)
+
+	serve(h, &types.User{Email: "admin@example.com"})
+	assert.True(t, service.admin)
+
+	service.err = errors.New("db down")
+	assert.Equal(t, http.StatusInternalServerError, serve(h, nil).Code)
+}
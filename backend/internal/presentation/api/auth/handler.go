@@ -2,6 +2,7 @@
 package auth
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 	"github.com/btouchard/ackify-ce/backend/pkg/types"
 )
@@ -21,11 +23,35 @@ type middleware interface {
 	GenerateCSRFToken() (string, error)
 }
 
+// localeDetector stores the browser locale of users at first login and returns their preference
+type localeDetector interface {
+	DetectLocale(ctx context.Context, email, detected string) string
+}
+
+// failureRecorder tracks failed authentications for security alerts
+type failureRecorder interface {
+	RecordFailure(ctx context.Context, kind, userEmail, ip string)
+}
+
+// sessionPeeker reads the user and the expiry of their session without recording activity
+type sessionPeeker interface {
+	PeekUser(r *http.Request) (*models.User, *models.SessionExpiry, error)
+}
+
+// identityResolver maps a login to the identity it was merged into
+type identityResolver interface {
+	ResolveIdentity(ctx context.Context, sub, email string) (string, string, error)
+}
+
 // Handler handles authentication API requests using unified AuthProvider
 type Handler struct {
 	authProvider providers.AuthProvider
 	middleware   middleware
 	baseURL      string
+	locales      localeDetector
+	failures     failureRecorder
+	sessions     sessionPeeker
+	identities   identityResolver
 }
 
 // NewHandler creates a new auth handler with unified AuthProvider
@@ -37,6 +63,65 @@ func NewHandler(authProvider providers.AuthProvider, middleware middleware, base
 	}
 }
 
+// SetLocaleDetector enables the per-user locale: logins store the browser locale as the preference
+// of new users and switch the language cookie to the preference of known ones
+func (h *Handler) SetLocaleDetector(locales localeDetector) {
+	h.locales = locales
+}
+
+// SetFailureRecorder tracks failed OAuth callbacks and magic link verifications
+func (h *Handler) SetFailureRecorder(failures failureRecorder) {
+	h.failures = failures
+}
+
+// SetSessionPeeker adds the session expiry to the auth check, which then no longer counts as
+// activity unless called with renew=true
+func (h *Handler) SetSessionPeeker(sessions sessionPeeker) {
+	h.sessions = sessions
+}
+
+// SetIdentityResolver signs the logins of merged identities in as the identity they were merged into
+func (h *Handler) SetIdentityResolver(identities identityResolver) {
+	h.identities = identities
+}
+
+// resolveIdentity replaces the subject and email of a merged identity with those of its target.
+// A resolution failure keeps the login identity rather than failing the login.
+func (h *Handler) resolveIdentity(ctx context.Context, user *types.User) {
+	if h.identities == nil {
+		return
+	}
+	sub, email, err := h.identities.ResolveIdentity(ctx, user.Sub, user.Email)
+	if err != nil {
+		logger.Logger.Error("Failed to resolve merged identity", "sub", user.Sub, "error", err.Error())
+		return
+	}
+	if email != user.Email {
+		logger.Logger.Info("Login resolved to merged identity", "email", user.Email, "target_email", email)
+		if user.Name == user.Email {
+			user.Name = email
+		}
+	}
+	user.Sub, user.Email = sub, email
+}
+
+// recordFailure tracks a failed authentication from the client IP; the user is unknown at this point
+func (h *Handler) recordFailure(r *http.Request, kind string) {
+	if h.failures == nil {
+		return
+	}
+	h.failures.RecordFailure(r.Context(), kind, "", extractIP(r.RemoteAddr))
+}
+
+// applyLocale sets the language cookie to the preferred locale of a user who just logged in
+func (h *Handler) applyLocale(w http.ResponseWriter, r *http.Request, email string) {
+	if h.locales == nil {
+		return
+	}
+	locale := h.locales.DetectLocale(r.Context(), email, i18n.GetLangFromRequest(r))
+	i18n.SetLangCookie(w, locale, strings.HasPrefix(h.baseURL, "https://"))
+}
+
 // HandleGetCSRFToken handles GET /api/v1/csrf
 func (h *Handler) HandleGetCSRFToken(w http.ResponseWriter, r *http.Request) {
 	token, err := h.middleware.GenerateCSRFToken()
@@ -118,12 +203,14 @@ func (h *Handler) HandleOIDCCallback(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		http.Error(w, "OAuth error: "+oauthError, http.StatusBadRequest)
+		h.recordFailure(r, models.AuthFailureOAuthCallback)
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "OAuth error: "+oauthError, nil)
 		return
 	}
 
 	if code == "" {
-		http.Error(w, "Missing authorization code", http.StatusBadRequest)
+		h.recordFailure(r, models.AuthFailureOAuthCallback)
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Missing authorization code", nil)
 		return
 	}
 
@@ -135,7 +222,8 @@ func (h *Handler) HandleOIDCCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if token == "" || !h.authProvider.VerifyOIDCState(w, r, token) {
-		http.Error(w, "Invalid OAuth state", http.StatusBadRequest)
+		h.recordFailure(r, models.AuthFailureOAuthCallback)
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid OAuth state", nil)
 		return
 	}
 
@@ -143,15 +231,18 @@ func (h *Handler) HandleOIDCCallback(w http.ResponseWriter, r *http.Request) {
 	user, nextURL, err := h.authProvider.HandleOIDCCallback(ctx, w, r, code, state)
 	if err != nil {
 		logger.Logger.Error("OIDC callback failed", "error", err.Error())
-		http.Error(w, "Authentication failed: "+err.Error(), http.StatusUnauthorized)
+		h.recordFailure(r, models.AuthFailureOAuthCallback)
+		shared.WriteUnauthorized(w, "Authentication failed")
 		return
 	}
 
+	h.resolveIdentity(ctx, user)
 	if err := h.authProvider.SetCurrentUser(w, r, user); err != nil {
 		logger.Logger.Error("Failed to set user session", "error", err.Error())
-		http.Error(w, "Failed to set user session", http.StatusInternalServerError)
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to set user session", nil)
 		return
 	}
+	h.applyLocale(w, r, user.Email)
 
 	if nextURL == "" {
 		nextURL = "/"
@@ -185,9 +276,19 @@ func (h *Handler) HandleLogout(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// HandleAuthCheck handles GET /api/v1/auth/check
+// HandleAuthCheck handles GET /api/v1/auth/check[?renew=true]
+// With server-side sessions, the response carries the session expiry so that the SPA can prompt
+// before it. Polling does not keep an idle session alive: only renew=true records activity.
 func (h *Handler) HandleAuthCheck(w http.ResponseWriter, r *http.Request) {
-	user, err := h.authProvider.GetCurrentUser(r)
+	var user *types.User
+	var expiry *models.SessionExpiry
+	var err error
+	if h.sessions == nil || r.URL.Query().Get("renew") == "true" {
+		user, err = h.authProvider.GetCurrentUser(r)
+	}
+	if h.sessions != nil && err == nil {
+		user, expiry, err = h.sessions.PeekUser(r)
+	}
 	if err != nil || user == nil {
 		shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
 			"authenticated": false,
@@ -195,14 +296,18 @@ func (h *Handler) HandleAuthCheck(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"authenticated": true,
 		"user": map[string]interface{}{
 			"id":    user.Sub,
 			"email": user.Email,
 			"name":  user.Name,
 		},
-	})
+	}
+	if expiry != nil {
+		response["session"] = expiry
+	}
+	shared.WriteJSON(w, http.StatusOK, response)
 }
 
 // === MagicLink Handlers ===
@@ -271,6 +376,7 @@ func (h *Handler) HandleVerifyMagicLink(w http.ResponseWriter, r *http.Request)
 	result, err := h.authProvider.VerifyMagicLink(ctx, token, ip, userAgent)
 	if err != nil {
 		logger.Logger.Error("Failed to verify magic link", "error", err.Error())
+		h.recordFailure(r, models.AuthFailureMagicLink)
 		http.Redirect(w, r, "/?error=invalid_token", http.StatusFound)
 		return
 	}
@@ -282,11 +388,13 @@ func (h *Handler) HandleVerifyMagicLink(w http.ResponseWriter, r *http.Request)
 		Name:  result.Email,
 	}
 
+	h.resolveIdentity(ctx, user)
 	if err := h.authProvider.SetCurrentUser(w, r, user); err != nil {
 		logger.Logger.Error("Failed to set user session", "error", err.Error())
 		http.Redirect(w, r, "/?error=session_error", http.StatusFound)
 		return
 	}
+	h.applyLocale(w, r, user.Email)
 
 	redirectTo := result.RedirectTo
 	if redirectTo == "" {
@@ -316,6 +424,7 @@ func (h *Handler) HandleVerifyReminderAuthLink(w http.ResponseWriter, r *http.Re
 	result, err := h.authProvider.VerifyReminderAuthToken(ctx, token, ip, userAgent)
 	if err != nil {
 		logger.Logger.Error("Failed to verify reminder auth token", "error", err.Error())
+		h.recordFailure(r, models.AuthFailureMagicLink)
 		http.Redirect(w, r, "/?error=invalid_token", http.StatusFound)
 		return
 	}
@@ -327,11 +436,13 @@ func (h *Handler) HandleVerifyReminderAuthLink(w http.ResponseWriter, r *http.Re
 		Name:  result.Email,
 	}
 
+	h.resolveIdentity(ctx, user)
 	if err := h.authProvider.SetCurrentUser(w, r, user); err != nil {
 		logger.Logger.Error("Failed to set user session", "error", err.Error())
 		http.Redirect(w, r, "/?error=session_error", http.StatusFound)
 		return
 	}
+	h.applyLocale(w, r, user.Email)
 
 	redirectTo := result.RedirectTo
 	if redirectTo == "" && result.DocID != nil {
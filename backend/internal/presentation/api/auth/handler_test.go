@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -83,6 +85,7 @@ type mockAuthProvider struct {
 	oidcEnabled      bool
 	magicLinkEnabled bool
 	logoutURL        string
+	magicLinkErr     error
 }
 
 func newMockAuthProvider() *mockAuthProvider {
@@ -165,6 +168,9 @@ func (m *mockAuthProvider) RequestMagicLink(_ context.Context, _, _, _, _, _ str
 }
 
 func (m *mockAuthProvider) VerifyMagicLink(_ context.Context, _, _, _ string) (*providers.MagicLinkResult, error) {
+	if m.magicLinkErr != nil {
+		return nil, m.magicLinkErr
+	}
 	return &providers.MagicLinkResult{
 		Email:      "test@example.com",
 		RedirectTo: "/",
@@ -335,6 +341,71 @@ func TestHandler_HandleAuthCheck_NotAuthenticated(t *testing.T) {
 	}
 }
 
+// mockSessionPeeker reads a fixed session
+type mockSessionPeeker struct {
+	user   *types.User
+	expiry *models.SessionExpiry
+}
+
+func (m *mockSessionPeeker) PeekUser(_ *http.Request) (*types.User, *models.SessionExpiry, error) {
+	if m.user == nil {
+		return nil, nil, models.ErrUnauthorized
+	}
+	return m.user, m.expiry, nil
+}
+
+func TestHandler_HandleAuthCheck_SessionExpiry(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(2 * time.Minute).UTC().Truncate(time.Second)
+	peeker := &mockSessionPeeker{
+		user:   &types.User{Sub: testUser.Sub, Email: testUser.Email},
+		expiry: &models.SessionExpiry{ExpiresAt: expiresAt, ExpiresIn: 120, WarnBefore: 300, Warning: true},
+	}
+
+	t.Run("polling reads the session without activity", func(t *testing.T) {
+		t.Parallel()
+
+		// The provider has no user: a GetCurrentUser call would sign the user out
+		handler := NewHandler(newMockAuthProvider(), createTestMiddleware(), testBaseURL)
+		handler.SetSessionPeeker(peeker)
+
+		rec := httptest.NewRecorder()
+		handler.HandleAuthCheck(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/check", nil))
+
+		var wrapper struct {
+			Data struct {
+				Authenticated bool                  `json:"authenticated"`
+				Session       *models.SessionExpiry `json:"session"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+		assert.True(t, wrapper.Data.Authenticated)
+		require.NotNil(t, wrapper.Data.Session)
+		assert.True(t, wrapper.Data.Session.ExpiresAt.Equal(expiresAt))
+		assert.Equal(t, 120, wrapper.Data.Session.ExpiresIn)
+		assert.True(t, wrapper.Data.Session.Warning)
+	})
+
+	t.Run("renew goes through the provider", func(t *testing.T) {
+		t.Parallel()
+
+		handler := NewHandler(newMockAuthProvider(), createTestMiddleware(), testBaseURL)
+		handler.SetSessionPeeker(peeker)
+
+		rec := httptest.NewRecorder()
+		handler.HandleAuthCheck(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/check?renew=true", nil))
+
+		var wrapper struct {
+			Data struct {
+				Authenticated bool `json:"authenticated"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+		assert.False(t, wrapper.Data.Authenticated, "the provider has no user")
+	})
+}
+
 func TestHandler_HandleAuthCheck_ResponseFormat(t *testing.T) {
 	t.Parallel()
 
@@ -824,6 +895,181 @@ func TestHandler_HandleStartOIDC_Concurrent(t *testing.T) {
 	assert.Equal(t, 0, errCount, "All concurrent requests should succeed")
 }
 
+// ============================================================================
+// TESTS - Locale detection at login
+// ============================================================================
+
+type mockLocaleDetector struct {
+	detected map[string]string
+}
+
+func (m *mockLocaleDetector) DetectLocale(_ context.Context, email, detected string) string {
+	if locale, ok := m.detected[email]; ok {
+		return locale
+	}
+	m.detected[email] = detected
+	return detected
+}
+
+func TestHandler_HandleVerifyMagicLink_DetectsLocale(t *testing.T) {
+	t.Parallel()
+
+	authProvider := newMockAuthProvider()
+	authProvider.setMagicLinkEnabled(true)
+	detector := &mockLocaleDetector{detected: make(map[string]string)}
+	handler := NewHandler(authProvider, createTestMiddleware(), testBaseURL)
+	handler.SetLocaleDetector(detector)
+
+	login := func(acceptLanguage string) *http.Cookie {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/magic-link/verify?token=abc", nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		rec := httptest.NewRecorder()
+		handler.HandleVerifyMagicLink(rec, req)
+		require.Equal(t, http.StatusFound, rec.Code)
+		for _, c := range rec.Result().Cookies() {
+			if c.Name == "lang" {
+				return c
+			}
+		}
+		return nil
+	}
+
+	cookie := login("fr-FR,fr;q=0.9,en;q=0.8")
+	require.NotNil(t, cookie)
+	assert.Equal(t, "fr", cookie.Value)
+	assert.True(t, cookie.Secure)
+	assert.Equal(t, "fr", detector.detected["test@example.com"])
+
+	// Later logins keep the stored preference
+	cookie = login("de")
+	require.NotNil(t, cookie)
+	assert.Equal(t, "fr", cookie.Value)
+}
+
+// ============================================================================
+// TESTS - Failed authentication tracking
+// ============================================================================
+
+type mockFailureRecorder struct {
+	mu    sync.Mutex
+	kinds []string
+	ips   []string
+}
+
+func (m *mockFailureRecorder) RecordFailure(_ context.Context, kind, _, ip string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.kinds = append(m.kinds, kind)
+	m.ips = append(m.ips, ip)
+}
+
+func TestHandler_HandleOIDCCallback_RecordsFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		query  string
+		record bool
+	}{
+		{"provider error", "?error=access_denied&state=abc", true},
+		{"missing code", "?state=abc", true},
+		{"silent login required", "?error=login_required&state=abc", false},
+		{"success", "?code=xyz&state=abc", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &mockFailureRecorder{}
+			handler := NewHandler(newMockAuthProvider(), createTestMiddleware(), testBaseURL)
+			handler.SetFailureRecorder(recorder)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/callback"+tt.query, nil)
+			req.RemoteAddr = "203.0.113.7:4321"
+			rec := httptest.NewRecorder()
+			handler.HandleOIDCCallback(rec, req)
+
+			if !tt.record {
+				assert.Empty(t, recorder.kinds)
+				return
+			}
+			assert.Equal(t, []string{models.AuthFailureOAuthCallback}, recorder.kinds)
+			assert.Equal(t, []string{"203.0.113.7"}, recorder.ips)
+		})
+	}
+}
+
+func TestHandler_HandleVerifyMagicLink_RecordsFailure(t *testing.T) {
+	t.Parallel()
+
+	authProvider := newMockAuthProvider()
+	authProvider.setMagicLinkEnabled(true)
+	authProvider.magicLinkErr = errors.New("token expired")
+	recorder := &mockFailureRecorder{}
+	handler := NewHandler(authProvider, createTestMiddleware(), testBaseURL)
+	handler.SetFailureRecorder(recorder)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/magic-link/verify?token=abc", nil)
+	req.RemoteAddr = "203.0.113.7:4321"
+	rec := httptest.NewRecorder()
+	handler.HandleVerifyMagicLink(rec, req)
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, []string{models.AuthFailureMagicLink}, recorder.kinds)
+	assert.Equal(t, []string{"203.0.113.7"}, recorder.ips)
+}
+
+// ============================================================================
+// TESTS - Merged identities
+// ============================================================================
+
+type mockIdentityResolver struct {
+	targets map[string][2]string
+	err     error
+}
+
+func (m *mockIdentityResolver) ResolveIdentity(_ context.Context, sub, email string) (string, string, error) {
+	if m.err != nil {
+		return "", "", m.err
+	}
+	if target, ok := m.targets[email]; ok {
+		return target[0], target[1], nil
+	}
+	return sub, email, nil
+}
+
+func TestHandler_HandleVerifyMagicLink_ResolvesMergedIdentity(t *testing.T) {
+	t.Parallel()
+
+	authProvider := newMockAuthProvider()
+	authProvider.setMagicLinkEnabled(true)
+	resolver := &mockIdentityResolver{targets: map[string][2]string{
+		"test@example.com": {"google-123", "alice@example.com"},
+	}}
+	handler := NewHandler(authProvider, createTestMiddleware(), testBaseURL)
+	handler.SetIdentityResolver(resolver)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/magic-link/verify?token=abc", nil)
+	rec := httptest.NewRecorder()
+	handler.HandleVerifyMagicLink(rec, req)
+
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.NotNil(t, authProvider.currentUser)
+	assert.Equal(t, "google-123", authProvider.currentUser.Sub)
+	assert.Equal(t, "alice@example.com", authProvider.currentUser.Email)
+	assert.Equal(t, "alice@example.com", authProvider.currentUser.Name)
+
+	// A resolution failure keeps the login identity
+	resolver.err = errors.New("database unavailable")
+	rec = httptest.NewRecorder()
+	handler.HandleVerifyMagicLink(rec, httptest.NewRequest(http.MethodGet, "/api/v1/auth/magic-link/verify?token=abc", nil))
+
+	require.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "magiclink:test@example.com", authProvider.currentUser.Sub)
+	assert.Equal(t, "test@example.com", authProvider.currentUser.Email)
+}
+
 // ============================================================================
 // BENCHMARKS
 // ============================================================================
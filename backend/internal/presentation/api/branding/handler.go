@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package branding
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// brandingService defines the public branding operations
+type brandingService interface {
+	GetBranding() *models.Branding
+	OpenLogo(ctx context.Context) (io.ReadCloser, int64, string, error)
+}
+
+// Handler handles public branding API requests
+type Handler struct {
+	service brandingService
+}
+
+// NewHandler creates a new branding handler
+func NewHandler(service brandingService) *Handler {
+	return &Handler{service: service}
+}
+
+// HandleGetBranding handles GET /api/v1/branding
+func (h *Handler) HandleGetBranding(w http.ResponseWriter, r *http.Request) {
+	shared.WriteJSON(w, http.StatusOK, h.service.GetBranding())
+}
+
+// HandleGetLogo handles GET /api/v1/branding/logo
+func (h *Handler) HandleGetLogo(w http.ResponseWriter, r *http.Request) {
+	reader, size, contentType, err := h.service.OpenLogo(r.Context())
+	if err != nil {
+		if errors.Is(err, services.ErrLogoNotFound) {
+			shared.WriteNotFound(w, "Logo")
+			return
+		}
+		logger.Logger.Error("Failed to read branding logo", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	defer reader.Close()
+
+	// SVG logos may contain scripts: never let them run when opened directly
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	// The logo URL changes with every upload
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+
+	if _, err := io.Copy(w, reader); err != nil {
+		logger.Logger.Warn("Failed to stream branding logo", "error", err.Error())
+	}
+}
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package csp
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// maxReportSize bounds the body of a report, browsers send a few hundred bytes
+const maxReportSize = 64 << 10
+
+// reportCollector records Content-Security-Policy violations
+type reportCollector interface {
+	Record(report models.CSPViolation)
+}
+
+// Handler receives the violation reports sent by browsers for the report-uri directive
+type Handler struct {
+	collector reportCollector
+	baseURL   string
+}
+
+// NewHandler creates a new CSP report handler.
+// Only reports about pages of baseURL are kept, others are acknowledged and ignored.
+func NewHandler(collector reportCollector, baseURL string) *Handler {
+	return &Handler{collector: collector, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// cspReport is the body sent for the report-uri directive (application/csp-report)
+type cspReport struct {
+	Report struct {
+		DocumentURI        string `json:"document-uri"`
+		BlockedURI         string `json:"blocked-uri"`
+		EffectiveDirective string `json:"effective-directive"`
+		ViolatedDirective  string `json:"violated-directive"`
+		SourceFile         string `json:"source-file"`
+		LineNumber         int    `json:"line-number"`
+		ScriptSample       string `json:"script-sample"`
+		Disposition        string `json:"disposition"`
+	} `json:"csp-report"`
+}
+
+// reportingAPIReport is a report of the Reporting API (application/reports+json)
+type reportingAPIReport struct {
+	Type string `json:"type"`
+	Body struct {
+		DocumentURL        string `json:"documentURL"`
+		BlockedURL         string `json:"blockedURL"`
+		EffectiveDirective string `json:"effectiveDirective"`
+		SourceFile         string `json:"sourceFile"`
+		LineNumber         int    `json:"lineNumber"`
+		Sample             string `json:"sample"`
+		Disposition        string `json:"disposition"`
+	} `json:"body"`
+}
+
+// HandleReport handles POST /api/v1/csp-report
+func (h *Handler) HandleReport(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxReportSize+1))
+	if err != nil || len(body) > maxReportSize {
+		shared.WriteValidationError(w, "Invalid report", nil)
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var violations []models.CSPViolation
+	if mediaType == "application/reports+json" {
+		var reports []reportingAPIReport
+		if err := json.Unmarshal(body, &reports); err != nil {
+			shared.WriteValidationError(w, "Invalid report", nil)
+			return
+		}
+		for _, report := range reports {
+			if report.Type != "csp-violation" {
+				continue
+			}
+			violations = append(violations, models.CSPViolation{
+				Directive:   report.Body.EffectiveDirective,
+				BlockedURI:  report.Body.BlockedURL,
+				DocumentURI: report.Body.DocumentURL,
+				SourceFile:  report.Body.SourceFile,
+				LineNumber:  report.Body.LineNumber,
+				Sample:      report.Body.Sample,
+				Disposition: report.Body.Disposition,
+			})
+		}
+	} else {
+		var report cspReport
+		if err := json.Unmarshal(body, &report); err != nil {
+			shared.WriteValidationError(w, "Invalid report", nil)
+			return
+		}
+		directive := report.Report.EffectiveDirective
+		if directive == "" {
+			// Older browsers only send the violated directive with its sources
+			directive, _, _ = strings.Cut(report.Report.ViolatedDirective, " ")
+		}
+		violations = append(violations, models.CSPViolation{
+			Directive:   directive,
+			BlockedURI:  report.Report.BlockedURI,
+			DocumentURI: report.Report.DocumentURI,
+			SourceFile:  report.Report.SourceFile,
+			LineNumber:  report.Report.LineNumber,
+			Sample:      report.Report.ScriptSample,
+			Disposition: report.Report.Disposition,
+		})
+	}
+
+	for _, violation := range violations {
+		if violation.Directive == "" || !h.isOwnPage(violation.DocumentURI) {
+			continue
+		}
+		h.collector.Record(violation)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isOwnPage reports whether the page that triggered the violation belongs to this instance
+func (h *Handler) isOwnPage(documentURI string) bool {
+	return documentURI == h.baseURL || strings.HasPrefix(documentURI, h.baseURL+"/")
+}
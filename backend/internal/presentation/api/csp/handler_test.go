@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package csp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeCollector struct {
+	reports []models.CSPViolation
+}
+
+func (f *fakeCollector) Record(report models.CSPViolation) {
+	f.reports = append(f.reports, report)
+}
+
+func postReport(h *Handler, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/csp-report", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.HandleReport(rec, req)
+	return rec
+}
+
+func TestHandleReport_ReportURI(t *testing.T) {
+	collector := &fakeCollector{}
+	h := NewHandler(collector, "https://ackify.example.com/")
+
+	rec := postReport(h, "application/csp-report", `{"csp-report": {
+		"document-uri": "https://ackify.example.com/admin",
+		"blocked-uri": "https://fonts.example.com/font.woff2",
+		"violated-directive": "font-src 'self' https://fonts.gstatic.com",
+		"line-number": 12
+	}}`)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, collector.reports, 1)
+	assert.Equal(t, "font-src", collector.reports[0].Directive)
+	assert.Equal(t, "https://fonts.example.com/font.woff2", collector.reports[0].BlockedURI)
+	assert.Equal(t, 12, collector.reports[0].LineNumber)
+}
+
+func TestHandleReport_ReportingAPI(t *testing.T) {
+	collector := &fakeCollector{}
+	h := NewHandler(collector, "https://ackify.example.com")
+
+	rec := postReport(h, "application/reports+json", `[
+		{"type": "csp-violation", "body": {"documentURL": "https://ackify.example.com/", "blockedURL": "wss://ws.example.com", "effectiveDirective": "connect-src"}},
+		{"type": "deprecation", "body": {}}
+	]`)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, collector.reports, 1)
+	assert.Equal(t, "connect-src", collector.reports[0].Directive)
+}
+
+func TestHandleReport_IgnoresOtherSites(t *testing.T) {
+	collector := &fakeCollector{}
+	h := NewHandler(collector, "https://ackify.example.com")
+
+	rec := postReport(h, "application/csp-report", `{"csp-report": {"document-uri": "https://ackify.example.com.evil.test/", "effective-directive": "img-src"}}`)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, collector.reports)
+}
+
+func TestHandleReport_Invalid(t *testing.T) {
+	h := NewHandler(&fakeCollector{}, "https://ackify.example.com")
+
+	assert.Equal(t, http.StatusBadRequest, postReport(h, "application/csp-report", `not json`).Code)
+	assert.Equal(t, http.StatusBadRequest, postReport(h, "application/csp-report", strings.Repeat("a", maxReportSize+1)).Code)
+}
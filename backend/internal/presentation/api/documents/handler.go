@@ -4,16 +4,21 @@ package documents
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/markdown"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 )
@@ -26,7 +31,9 @@ type documentService interface {
 	List(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
 	Count(ctx context.Context, searchQuery string) (int, error)
+	ListPage(ctx context.Context, searchQuery string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Document], error)
 	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	ResolveAlias(ctx context.Context, alias string) (string, error)
 	GetExpectedSignerStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
 	ListExpectedSigners(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
 	ListByCreatedBy(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
@@ -42,12 +49,14 @@ type webhookPublisher interface {
 // signatureService defines the interface for signature operations
 type signatureService interface {
 	GetDocumentSignatures(ctx context.Context, docID string) ([]*models.Signature, error)
+	ListDocumentSignaturesPage(ctx context.Context, docID string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error)
 }
 
 // adminService defines admin-level operations needed for document management
 type adminService interface {
 	GetDocument(ctx context.Context, docID string) (*models.Document, error)
 	UpdateDocumentMetadata(ctx context.Context, docID string, input models.DocumentInput, updatedBy string) (*models.Document, error)
+	PatchDocumentMetadata(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error)
 	DeleteDocument(ctx context.Context, docID string) error
 	ListExpectedSignersWithStatus(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
 	GetSignerStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
@@ -63,6 +72,13 @@ type Handler struct {
 	webhookPublisher webhookPublisher
 	authorizer       providers.Authorizer
 	baseURL          string
+	statusCache      *shared.StatusCache
+	urlSigner        contentURLSigner
+}
+
+// contentURLSigner issues expiring links to the content of stored documents
+type contentURLSigner interface {
+	SignedURL(docID string) string
 }
 
 // NewHandler creates a handler with all dependencies for full functionality
@@ -87,6 +103,26 @@ func (h *Handler) WithAdminService(adminService adminService, baseURL string) *H
 	return h
 }
 
+// WithStatusCache enables caching of public document status responses.
+func (h *Handler) WithStatusCache(cache *shared.StatusCache) *Handler {
+	h.statusCache = cache
+	return h
+}
+
+// WithURLSigner exposes expiring content links of stored documents to the embed page and share links.
+func (h *Handler) WithURLSigner(signer contentURLSigner) *Handler {
+	h.urlSigner = signer
+	return h
+}
+
+// signedContentURL returns an expiring link to the stored file of doc, if any
+func (h *Handler) signedContentURL(doc *models.Document) string {
+	if h.urlSigner == nil || doc == nil || !doc.IsStored() {
+		return ""
+	}
+	return h.urlSigner.SignedURL(doc.DocID)
+}
+
 // DocumentDTO represents a document data transfer object
 type DocumentDTO struct {
 	ID                  string                 `json:"id"`
@@ -155,7 +191,7 @@ func (h *Handler) HandleCreateDocument(w http.ResponseWriter, r *http.Request) {
 		logger.Logger.Warn("Invalid document creation request body",
 			"error", err.Error(),
 			"remote_addr", r.RemoteAddr)
-		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", map[string]interface{}{"error": err.Error()})
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
 		return
 	}
 
@@ -174,6 +210,7 @@ func (h *Handler) HandleCreateDocument(w http.ResponseWriter, r *http.Request) {
 	docRequest := services.CreateDocumentRequest{
 		Reference: req.Reference,
 		Title:     req.Title,
+		CreatedBy: userEmail,
 	}
 
 	doc, err := h.documentService.CreateDocument(ctx, docRequest)
@@ -181,7 +218,7 @@ func (h *Handler) HandleCreateDocument(w http.ResponseWriter, r *http.Request) {
 		logger.Logger.Error("Document creation failed in handler",
 			"reference", req.Reference,
 			"error", err.Error())
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create document", map[string]interface{}{"error": err.Error()})
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create document", nil)
 		return
 	}
 
@@ -198,6 +235,7 @@ func (h *Handler) HandleCreateDocument(w http.ResponseWriter, r *http.Request) {
 			"url":                doc.URL,
 			"checksum":           doc.Checksum,
 			"checksum_algorithm": doc.ChecksumAlgorithm,
+			"content_hash":       doc.ContentHash,
 		})
 	}
 
@@ -213,14 +251,32 @@ func (h *Handler) HandleCreateDocument(w http.ResponseWriter, r *http.Request) {
 }
 
 // HandleListDocuments handles GET /api/v1/documents
+// With a cursor parameter (empty for the first page), the documents are paginated with cursors.
 func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	pagination := shared.ParsePaginationParams(r, 20, 100)
 	searchQuery := r.URL.Query().Get("search")
+	cursorParams, err := shared.ParseCursorParams(r, 20, 100)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid cursor", nil)
+		return
+	}
+	if cursorParams != nil {
+		page, err := h.documentService.ListPage(ctx, searchQuery, cursorParams.Cursor, cursorParams.Limit)
+		if err != nil {
+			logger.Logger.Error("Failed to fetch documents",
+				"search", searchQuery,
+				"error", err.Error())
+			shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch documents", nil)
+			return
+		}
+		shared.WriteCursorPage(w, h.documentDTOs(ctx, page.Items), cursorParams.Limit, page.NextCursor, page.PrevCursor)
+		return
+	}
+
+	pagination := shared.ParsePaginationParams(r, 20, 100)
 
 	var docs []*models.Document
-	var err error
 
 	if searchQuery != "" {
 		// Use search if query is provided
@@ -253,6 +309,11 @@ func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 		totalCount = len(docs)
 	}
 
+	shared.WritePaginatedJSON(w, h.documentDTOs(ctx, docs), pagination.Page, pagination.PageSize, totalCount)
+}
+
+// documentDTOs converts listed documents to DTOs with their signature counts
+func (h *Handler) documentDTOs(ctx context.Context, docs []*models.Document) []DocumentDTO {
 	documents := make([]DocumentDTO, 0, len(docs))
 	for _, doc := range docs {
 		dto := DocumentDTO{
@@ -273,8 +334,7 @@ func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
 
 		documents = append(documents, dto)
 	}
-
-	shared.WritePaginatedJSON(w, documents, pagination.Page, pagination.PageSize, totalCount)
+	return documents
 }
 
 // HandleGetDocument handles GET /api/v1/documents/{docId}
@@ -287,7 +347,45 @@ func (h *Handler) HandleGetDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	doc, err := h.documentService.GetByDocID(ctx, docID)
+	// Embeds poll this endpoint: serve from cache and let clients revalidate
+	w.Header().Set("Cache-Control", "no-cache")
+	if cached, ok := h.statusCache.Get(docID); ok {
+		if shared.CheckNotModified(w, r, cached.ETag, cached.LastModified) {
+			return
+		}
+		shared.WriteStatus(w, cached)
+		return
+	}
+
+	status, err := h.LoadStatus(ctx, docID)
+	if err != nil {
+		logger.Logger.Error("Failed to load document status", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if status == nil {
+		h.redirectAlias(w, r, docID)
+		return
+	}
+	h.statusCache.Set(docID, *status)
+
+	if shared.CheckNotModified(w, r, status.ETag, status.LastModified) {
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, status.Data)
+}
+
+// HandleGetLandingMessage handles GET /api/v1/documents/{docId}/landing-message
+// Returns the message shown on the signing page before the acknowledgement button, in the
+// requested locale (else the display language of the page), rendered to sanitized HTML.
+func (h *Handler) HandleGetLandingMessage(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteValidationError(w, "Document ID is required", nil)
+		return
+	}
+
+	doc, err := h.documentService.GetByDocID(r.Context(), docID)
 	if err != nil {
 		logger.Logger.Error("Failed to get document", "doc_id", docID, "error", err.Error())
 		shared.WriteInternalError(w)
@@ -298,12 +396,59 @@ func (h *Handler) HandleGetDocument(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	signatures, err := h.signatureService.GetDocumentSignatures(ctx, docID)
+	requested := r.URL.Query().Get("locale")
+	if requested == "" {
+		requested = i18n.GetLangFromRequest(r)
+	}
+	locale, text := doc.LandingMessage.Select(requested)
+	if text == "" {
+		shared.WriteNotFound(w, "Landing message")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"docId":    docID,
+		"locale":   locale,
+		"markdown": text,
+		"html":     markdown.ToHTML(text),
+	})
+}
+
+// redirectAlias permanently redirects a request for an alias to the same path with the ID of
+// its document, and answers 404 when docID is not an alias either
+func (h *Handler) redirectAlias(w http.ResponseWriter, r *http.Request, docID string) {
+	target, err := h.documentService.ResolveAlias(r.Context(), docID)
 	if err != nil {
-		logger.Logger.Error("Failed to get signatures", "doc_id", docID, "error", err.Error())
+		logger.Logger.Error("Failed to resolve document alias", "alias", docID, "error", err.Error())
 		shared.WriteInternalError(w)
 		return
 	}
+	if target == "" {
+		shared.WriteError(w, http.StatusNotFound, shared.ErrCodeNotFound, "Document not found", nil)
+		return
+	}
+
+	location := *r.URL
+	location.Path = strings.TrimSuffix(r.URL.Path, docID) + target
+	location.RawPath = ""
+	http.Redirect(w, r, location.String(), http.StatusMovedPermanently)
+}
+
+// LoadStatus computes the public status of a document with its validators; it returns nil
+// when the document does not exist
+func (h *Handler) LoadStatus(ctx context.Context, docID string) (*shared.CachedStatus, error) {
+	doc, err := h.documentService.GetByDocID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if doc == nil {
+		return nil, nil
+	}
+
+	signatures, err := h.signatureService.GetDocumentSignatures(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signatures: %w", err)
+	}
 
 	// Build response
 	response := DocumentDTO{
@@ -320,13 +465,28 @@ func (h *Handler) HandleGetDocument(w http.ResponseWriter, r *http.Request) {
 		response.ExpectedSignerCount = stats.ExpectedCount
 	}
 
-	shared.WriteJSON(w, http.StatusOK, response)
+	// Validators derive from the latest signature and the last document update
+	lastModified := doc.UpdatedAt
+	for _, sig := range signatures {
+		if sig.SignedAtUTC.After(lastModified) {
+			lastModified = sig.SignedAtUTC
+		}
+	}
+	return &shared.CachedStatus{
+		Data: response,
+		ETag: shared.ContentETag(docID,
+			lastModified.UTC().Format(time.RFC3339Nano),
+			strconv.Itoa(response.SignatureCount),
+			strconv.Itoa(response.ExpectedSignerCount)),
+		LastModified: lastModified,
+	}, nil
 }
 
 // HandleGetDocumentSignatures handles GET /api/v1/documents/{docId}/signatures
 // Returns the detailed signature list only for document owner or admin.
 // For authenticated users who are not owner/admin, returns only their own signature (if they signed).
 // Non-authenticated users receive an empty list (the count remains available via DocumentDTO).
+// Owner/admin can page through the list with a cursor parameter (empty for the first page).
 func (h *Handler) HandleGetDocumentSignatures(w http.ResponseWriter, r *http.Request) {
 	docID := chi.URLParam(r, "docId")
 	if docID == "" {
@@ -354,6 +514,31 @@ func (h *Handler) HandleGetDocumentSignatures(w http.ResponseWriter, r *http.Req
 	// Owner/Admin can see all signatures
 	canViewAll := authenticated && user != nil && h.authorizer.CanManageDocument(ctx, user.Email, doc.CreatedBy)
 
+	// Owner/Admin can page through the signatures with cursors
+	if canViewAll {
+		cursorParams, err := shared.ParseCursorParams(r, 50, 200)
+		if err != nil {
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid cursor", nil)
+			return
+		}
+		if cursorParams != nil {
+			page, err := h.signatureService.ListDocumentSignaturesPage(ctx, docID, cursorParams.Cursor, cursorParams.Limit)
+			if err != nil {
+				logger.Logger.Error("Failed to get signatures",
+					"doc_id", docID,
+					"error", err.Error())
+				shared.WriteInternalError(w)
+				return
+			}
+			dtos := make([]SignatureDTO, len(page.Items))
+			for i := range page.Items {
+				dtos[i] = signatureToDTO(page.Items[i])
+			}
+			shared.WriteCursorPage(w, dtos, cursorParams.Limit, page.NextCursor, page.PrevCursor)
+			return
+		}
+	}
+
 	signatures, err := h.signatureService.GetDocumentSignatures(ctx, docID)
 	if err != nil {
 		logger.Logger.Error("Failed to get signatures",
@@ -475,6 +660,7 @@ type FindOrCreateDocumentResponse struct {
 	Title             string `json:"title"`
 	Checksum          string `json:"checksum,omitempty"`
 	ChecksumAlgorithm string `json:"checksumAlgorithm,omitempty"`
+	ContentHash       string `json:"contentHash,omitempty"`
 	Description       string `json:"description,omitempty"`
 	ReadMode          string `json:"readMode"`
 	AllowDownload     bool   `json:"allowDownload"`
@@ -486,6 +672,7 @@ type FindOrCreateDocumentResponse struct {
 	// Storage fields for uploaded documents
 	StorageKey string `json:"storageKey,omitempty"`
 	MimeType   string `json:"mimeType,omitempty"`
+	ContentURL string `json:"contentUrl,omitempty"` // Expiring signed link to the stored file
 }
 
 // HandleFindOrCreateDocument handles GET /api/v1/documents/find-or-create?doc={reference}
@@ -518,7 +705,7 @@ func (h *Handler) HandleFindOrCreateDocument(w http.ResponseWriter, r *http.Requ
 		logger.Logger.Error("Failed to search for document",
 			"reference", ref,
 			"error", err.Error())
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to search for document", map[string]interface{}{"error": err.Error()})
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to search for document", nil)
 		return
 	}
 
@@ -540,6 +727,7 @@ func (h *Handler) HandleFindOrCreateDocument(w http.ResponseWriter, r *http.Requ
 			Title:             existingDoc.Title,
 			Checksum:          existingDoc.Checksum,
 			ChecksumAlgorithm: existingDoc.ChecksumAlgorithm,
+			ContentHash:       existingDoc.ContentHash,
 			Description:       existingDoc.Description,
 			ReadMode:          existingDoc.ReadMode,
 			AllowDownload:     existingDoc.AllowDownload,
@@ -550,6 +738,7 @@ func (h *Handler) HandleFindOrCreateDocument(w http.ResponseWriter, r *http.Requ
 			SignatureCount:    signatureCount,
 			StorageKey:        existingDoc.StorageKey,
 			MimeType:          existingDoc.MimeType,
+			ContentURL:        h.signedContentURL(existingDoc),
 		}
 
 		shared.WriteJSON(w, http.StatusOK, response)
@@ -581,7 +770,7 @@ func (h *Handler) HandleFindOrCreateDocument(w http.ResponseWriter, r *http.Requ
 		logger.Logger.Error("Failed to create document",
 			"reference", ref,
 			"error", err.Error())
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create document", map[string]interface{}{"error": err.Error()})
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create document", nil)
 		return
 	}
 
@@ -597,6 +786,7 @@ func (h *Handler) HandleFindOrCreateDocument(w http.ResponseWriter, r *http.Requ
 		Title:             doc.Title,
 		Checksum:          doc.Checksum,
 		ChecksumAlgorithm: doc.ChecksumAlgorithm,
+		ContentHash:       doc.ContentHash,
 		Description:       doc.Description,
 		ReadMode:          doc.ReadMode,
 		AllowDownload:     doc.AllowDownload,
@@ -607,6 +797,7 @@ func (h *Handler) HandleFindOrCreateDocument(w http.ResponseWriter, r *http.Requ
 		SignatureCount:    0,
 		StorageKey:        doc.StorageKey,
 		MimeType:          doc.MimeType,
+		ContentURL:        h.signedContentURL(doc),
 	}
 
 	shared.WriteJSON(w, http.StatusOK, response)
@@ -649,11 +840,6 @@ func (h *Handler) HandleListMyDocuments(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if !h.authorizer.CanCreateDocument(ctx, user.Email) {
-		shared.WriteForbidden(w, "You don't have permission to manage documents")
-		return
-	}
-
 	pagination := shared.ParsePaginationParams(r, 20, 100)
 	searchQuery := r.URL.Query().Get("search")
 
@@ -733,11 +919,6 @@ func (h *Handler) checkDocumentOwnership(w http.ResponseWriter, r *http.Request)
 		return nil, nil
 	}
 
-	if !h.authorizer.CanCreateDocument(ctx, user.Email) {
-		shared.WriteForbidden(w, "You don't have permission to manage documents")
-		return nil, nil
-	}
-
 	doc, err := h.adminService.GetDocument(ctx, docID)
 	if err != nil || doc == nil {
 		shared.WriteError(w, http.StatusNotFound, shared.ErrCodeNotFound, "Document not found", nil)
@@ -777,6 +958,8 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 		ReminderCount         int     `json:"reminderCount"`
 		DaysSinceAdded        int     `json:"daysSinceAdded"`
 		DaysSinceLastReminder *int    `json:"daysSinceLastReminder,omitempty"`
+		SignOrder             *int    `json:"signOrder,omitempty"`
+		Turn                  string  `json:"turn,omitempty"`
 	}
 
 	type UnexpectedSignatureResponse struct {
@@ -799,6 +982,7 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 		URL               string `json:"url"`
 		Checksum          string `json:"checksum,omitempty"`
 		ChecksumAlgorithm string `json:"checksumAlgorithm,omitempty"`
+		ContentHash       string `json:"contentHash,omitempty"`
 		Description       string `json:"description"`
 		ReadMode          string `json:"readMode"`
 		AllowDownload     bool   `json:"allowDownload"`
@@ -820,6 +1004,8 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 		UnexpectedSignatures []*UnexpectedSignatureResponse `json:"unexpectedSignatures"`
 		Stats                *DocumentStatsResponse         `json:"stats"`
 		ShareLink            string                         `json:"shareLink"`
+		SignedContentURL     string                         `json:"signedContentUrl,omitempty"`
+		CurrentSignOrder     int                            `json:"currentSignOrder,omitempty"`
 	}
 
 	response := &StatusResponse{
@@ -827,12 +1013,14 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 		ExpectedSigners:      []*ExpectedSignerResponse{},
 		UnexpectedSignatures: []*UnexpectedSignatureResponse{},
 		ShareLink:            h.baseURL + "/?doc=" + docID,
+		SignedContentURL:     h.signedContentURL(doc),
 		Document: &DocumentResponse{
 			DocID:             doc.DocID,
 			Title:             doc.Title,
 			URL:               doc.URL,
 			Checksum:          doc.Checksum,
 			ChecksumAlgorithm: doc.ChecksumAlgorithm,
+			ContentHash:       doc.ContentHash,
 			Description:       doc.Description,
 			ReadMode:          doc.ReadMode,
 			AllowDownload:     doc.AllowDownload,
@@ -865,6 +1053,8 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 				ReminderCount:         signer.ReminderCount,
 				DaysSinceAdded:        signer.DaysSinceAdded,
 				DaysSinceLastReminder: signer.DaysSinceLastReminder,
+				SignOrder:             signer.SignOrder,
+				Turn:                  string(signer.Turn),
 			}
 			if signer.SignedAt != nil {
 				signedAt := signer.SignedAt.Format("2006-01-02T15:04:05Z07:00")
@@ -877,6 +1067,7 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 			response.ExpectedSigners = append(response.ExpectedSigners, resp)
 			expectedEmails[signer.Email] = true
 		}
+		response.CurrentSignOrder = models.CurrentSignOrder(signers)
 	}
 
 	// Get all signatures and find unexpected ones
@@ -911,9 +1102,9 @@ func (h *Handler) HandleGetMyDocumentStatus(w http.ResponseWriter, r *http.Reque
 	shared.WriteJSON(w, http.StatusOK, response)
 }
 
-// HandleUpdateMyDocumentMetadata handles PUT /api/v1/users/me/documents/{docId}/metadata
+// HandleUpdateMyDocumentMetadata handles PUT/PATCH /api/v1/users/me/documents/{docId}/metadata
 func (h *Handler) HandleUpdateMyDocumentMetadata(w http.ResponseWriter, r *http.Request) {
-	doc, user := h.checkDocumentOwnership(w, r)
+	doc, _ := h.checkDocumentOwnership(w, r)
 	if doc == nil {
 		return
 	}
@@ -930,63 +1121,47 @@ func (h *Handler) HandleUpdateMyDocumentMetadata(w http.ResponseWriter, r *http.
 		AllowDownload     *bool   `json:"allowDownload,omitempty"`
 		RequireFullRead   *bool   `json:"requireFullRead,omitempty"`
 		VerifyChecksum    *bool   `json:"verifyChecksum,omitempty"`
+		Version           *int    `json:"version,omitempty"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
 		return
 	}
 
-	if req.Title != nil {
-		doc.Title = *req.Title
-	}
-	if req.URL != nil {
-		doc.URL = *req.URL
-	}
-	if req.Checksum != nil {
-		doc.Checksum = *req.Checksum
-	}
-	if req.ChecksumAlgorithm != nil {
-		doc.ChecksumAlgorithm = *req.ChecksumAlgorithm
-	}
-	if req.Description != nil {
-		doc.Description = *req.Description
-	}
-	if req.ReadMode != nil {
-		doc.ReadMode = *req.ReadMode
-	}
-	if req.AllowDownload != nil {
-		doc.AllowDownload = *req.AllowDownload
-	}
-	if req.RequireFullRead != nil {
-		doc.RequireFullRead = *req.RequireFullRead
-	}
-	if req.VerifyChecksum != nil {
-		doc.VerifyChecksum = *req.VerifyChecksum
+	expectedVersion, err := shared.ResolveExpectedVersion(r, req.Version)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid version precondition", nil)
+		return
 	}
 
-	input := models.DocumentInput{
-		Title:             doc.Title,
-		URL:               doc.URL,
-		Checksum:          doc.Checksum,
-		ChecksumAlgorithm: doc.ChecksumAlgorithm,
-		Description:       doc.Description,
-		ReadMode:          doc.ReadMode,
-		AllowDownload:     &doc.AllowDownload,
-		RequireFullRead:   &doc.RequireFullRead,
-		VerifyChecksum:    &doc.VerifyChecksum,
-		StorageKey:        doc.StorageKey,
-		StorageProvider:   doc.StorageProvider,
-		FileSize:          doc.FileSize,
-		MimeType:          doc.MimeType,
-		OriginalFilename:  doc.OriginalFilename,
+	// Only provided fields are persisted so concurrent edits of other fields are preserved
+	patch := models.DocumentPatch{
+		Title:             req.Title,
+		URL:               req.URL,
+		Checksum:          req.Checksum,
+		ChecksumAlgorithm: req.ChecksumAlgorithm,
+		Description:       req.Description,
+		ReadMode:          req.ReadMode,
+		AllowDownload:     req.AllowDownload,
+		RequireFullRead:   req.RequireFullRead,
+		VerifyChecksum:    req.VerifyChecksum,
 	}
 
-	updated, err := h.adminService.UpdateDocumentMetadata(ctx, doc.DocID, input, user.Email)
-	if err != nil {
+	updated, err := h.adminService.PatchDocumentMetadata(ctx, doc.DocID, patch, expectedVersion)
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+		return
+	case errors.Is(err, models.ErrVersionConflict):
+		shared.WriteError(w, http.StatusConflict, shared.ErrCodeConflict, "Document has been modified by another request", nil)
+		return
+	case err != nil:
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to update document metadata", nil)
 		return
 	}
 
+	h.statusCache.Invalidate(doc.DocID)
+	shared.SetVersionETag(w, updated.Version)
 	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"message": "Document metadata updated successfully",
 		"document": map[string]interface{}{
@@ -995,6 +1170,7 @@ func (h *Handler) HandleUpdateMyDocumentMetadata(w http.ResponseWriter, r *http.
 			"url":               updated.URL,
 			"checksum":          updated.Checksum,
 			"checksumAlgorithm": updated.ChecksumAlgorithm,
+			"contentHash":       updated.ContentHash,
 			"description":       updated.Description,
 			"readMode":          updated.ReadMode,
 			"allowDownload":     updated.AllowDownload,
@@ -1003,6 +1179,7 @@ func (h *Handler) HandleUpdateMyDocumentMetadata(w http.ResponseWriter, r *http.
 			"createdAt":         updated.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			"updatedAt":         updated.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 			"createdBy":         updated.CreatedBy,
+			"version":           updated.Version,
 		},
 	})
 }
@@ -1020,6 +1197,7 @@ func (h *Handler) HandleDeleteMyDocument(w http.ResponseWriter, r *http.Request)
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to delete document", nil)
 		return
 	}
+	h.statusCache.Invalidate(doc.DocID)
 
 	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"message": "Document deleted successfully",
@@ -1036,8 +1214,9 @@ func (h *Handler) HandleAddMyExpectedSigner(w http.ResponseWriter, r *http.Reque
 	ctx := r.Context()
 
 	var req struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
+		Email     string `json:"email"`
+		Name      string `json:"name"`
+		SignOrder *int   `json:"signOrder,omitempty"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
@@ -1048,12 +1227,17 @@ func (h *Handler) HandleAddMyExpectedSigner(w http.ResponseWriter, r *http.Reque
 		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Email is required", nil)
 		return
 	}
+	if req.SignOrder != nil && *req.SignOrder < 1 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Sign order must be a positive number", nil)
+		return
+	}
 
-	contacts := []models.ContactInfo{{Email: req.Email, Name: req.Name}}
+	contacts := []models.ContactInfo{{Email: req.Email, Name: req.Name, SignOrder: req.SignOrder}}
 	if err := h.adminService.AddExpectedSigners(ctx, doc.DocID, contacts, user.Email); err != nil {
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to add expected signer", nil)
 		return
 	}
+	h.statusCache.Invalidate(doc.DocID)
 
 	shared.WriteJSON(w, http.StatusCreated, map[string]interface{}{
 		"message": "Expected signer added successfully",
@@ -1088,6 +1272,7 @@ func (h *Handler) HandleRemoveMyExpectedSigner(w http.ResponseWriter, r *http.Re
 		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to remove expected signer", nil)
 		return
 	}
+	h.statusCache.Invalidate(doc.DocID)
 
 	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"message": "Expected signer removed successfully",
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -108,6 +109,8 @@ type mockDocumentService struct {
 	createDocFunc       func(ctx context.Context, req services.CreateDocumentRequest) (*models.Document, error)
 	findOrCreateDocFunc func(ctx context.Context, ref string, createdBy string) (*models.Document, bool, error)
 	findByReferenceFunc func(ctx context.Context, ref string, refType string) (*models.Document, error)
+	getByDocIDFunc      func(ctx context.Context, docID string) (*models.Document, error)
+	aliases             map[string]string
 }
 
 func (m *mockDocumentService) CreateDocument(ctx context.Context, req services.CreateDocumentRequest) (*models.Document, error) {
@@ -143,10 +146,23 @@ func (m *mockDocumentService) Count(_ context.Context, _ string) (int, error) {
 	return 1, nil
 }
 
-func (m *mockDocumentService) GetByDocID(_ context.Context, _ string) (*models.Document, error) {
+func (m *mockDocumentService) ListPage(_ context.Context, _ string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Document], error) {
+	return models.NewKeysetPage([]*models.Document{testDoc}, cursor, limit, func(doc *models.Document) models.PageCursor {
+		return models.PageCursor{At: doc.CreatedAt, Key: doc.DocID}
+	}), nil
+}
+
+func (m *mockDocumentService) GetByDocID(ctx context.Context, docID string) (*models.Document, error) {
+	if m.getByDocIDFunc != nil {
+		return m.getByDocIDFunc(ctx, docID)
+	}
 	return testDoc, nil
 }
 
+func (m *mockDocumentService) ResolveAlias(_ context.Context, alias string) (string, error) {
+	return m.aliases[alias], nil
+}
+
 func (m *mockDocumentService) GetExpectedSignerStats(_ context.Context, _ string) (*models.DocCompletionStats, error) {
 	return &models.DocCompletionStats{}, nil
 }
@@ -179,6 +195,32 @@ func (m *mockSignatureService) GetDocumentSignatures(ctx context.Context, docID
 	return []*models.Signature{testSignature}, nil
 }
 
+func (m *mockSignatureService) ListDocumentSignaturesPage(_ context.Context, _ string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error) {
+	return models.NewKeysetPage([]*models.Signature{testSignature}, cursor, limit, func(sig *models.Signature) models.PageCursor {
+		return models.PageCursor{At: sig.CreatedAt, Key: strconv.FormatInt(sig.ID, 10)}
+	}), nil
+}
+
+// Mock admin service for owner-based management; only the methods used by tests are implemented
+type mockOwnerAdminService struct {
+	adminService
+	docs    map[string]*models.Document
+	deleted []string
+}
+
+func (m *mockOwnerAdminService) GetDocument(_ context.Context, docID string) (*models.Document, error) {
+	doc, ok := m.docs[docID]
+	if !ok {
+		return nil, models.ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+func (m *mockOwnerAdminService) DeleteDocument(_ context.Context, docID string) error {
+	m.deleted = append(m.deleted, docID)
+	return nil
+}
+
 func createTestHandler() *Handler {
 	return &Handler{
 		signatureService: &mockSignatureService{},
@@ -441,6 +483,44 @@ func TestHandler_HandleListDocuments_Success(t *testing.T) {
 	}
 }
 
+func TestHandler_HandleListDocuments_Cursor(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents?cursor=&limit=10", nil)
+	rec := httptest.NewRecorder()
+
+	handler.HandleListDocuments(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var wrapper struct {
+		Data []DocumentDTO          `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	require.Len(t, wrapper.Data, 1)
+	assert.Equal(t, testDoc.DocID, wrapper.Data[0].ID)
+	assert.Equal(t, float64(10), wrapper.Meta["limit"])
+	assert.Equal(t, "", wrapper.Meta["nextCursor"])
+	assert.Equal(t, "", wrapper.Meta["prevCursor"])
+	assert.NotContains(t, wrapper.Meta, "total")
+}
+
+func TestHandler_HandleListDocuments_InvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents?cursor=invalid", nil)
+	rec := httptest.NewRecorder()
+
+	handler.HandleListDocuments(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 // ============================================================================
 // TESTS - HandleGetDocument
 // ============================================================================
@@ -464,6 +544,135 @@ func TestHandler_HandleGetDocument_MissingDocID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestHandler_HandleGetDocument_ConditionalGet(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	handler := createTestHandler().WithStatusCache(shared.NewStatusCache(time.Minute))
+	handler.signatureService = &mockSignatureService{
+		getDocumentSignaturesFunc: func(_ context.Context, _ string) ([]*models.Signature, error) {
+			calls++
+			return []*models.Signature{testSignature}, nil
+		},
+	}
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/test-doc-123", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("docId", "test-doc-123")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		handler.HandleGetDocument(rec, req)
+		return rec
+	}
+
+	first := get("", "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	// Last-Modified follows the latest signature
+	assert.Equal(t, "Mon, 01 Jan 2024 12:30:00 GMT", first.Header().Get("Last-Modified"))
+
+	assert.Equal(t, http.StatusNotModified, get("If-None-Match", etag).Code)
+	assert.Equal(t, http.StatusNotModified, get("If-Modified-Since", "Mon, 01 Jan 2024 12:30:00 GMT").Code)
+	assert.Equal(t, http.StatusOK, get("If-None-Match", `"stale"`).Code)
+	assert.Equal(t, 1, calls, "cached responses must not hit the signature service")
+
+	// A new signature invalidates the cached status
+	handler.statusCache.Invalidate("test-doc-123")
+	get("", "")
+	assert.Equal(t, 2, calls)
+}
+
+func TestHandler_HandleGetDocument_Alias(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+	handler.documentService = &mockDocumentService{
+		getByDocIDFunc: func(_ context.Context, docID string) (*models.Document, error) {
+			if docID == testDoc.DocID {
+				return testDoc, nil
+			}
+			return nil, nil
+		},
+		aliases: map[string]string{"security-policy": testDoc.DocID},
+	}
+
+	get := func(docID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/"+docID+"?embed=1", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("docId", docID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		handler.HandleGetDocument(rec, req)
+		return rec
+	}
+
+	rec := get("security-policy")
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/api/v1/documents/test-doc-123?embed=1", rec.Header().Get("Location"))
+
+	assert.Equal(t, http.StatusNotFound, get("unknown").Code)
+}
+
+func TestHandler_HandleGetLandingMessage(t *testing.T) {
+	t.Parallel()
+
+	doc := *testDoc
+	doc.LandingMessage = models.LandingMessage{
+		"en": "Read the **whole** policy <script>",
+		"fr": "Lisez toute la politique",
+	}
+	handler := createTestHandler()
+	handler.documentService = &mockDocumentService{
+		getByDocIDFunc: func(_ context.Context, docID string) (*models.Document, error) {
+			switch docID {
+			case doc.DocID:
+				return &doc, nil
+			case "no-message":
+				return testDoc, nil
+			}
+			return nil, nil
+		},
+	}
+
+	get := func(docID, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/"+docID+"/landing-message"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("docId", docID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		handler.HandleGetLandingMessage(rec, req)
+		return rec
+	}
+
+	var response struct {
+		Data struct {
+			Locale   string `json:"locale"`
+			Markdown string `json:"markdown"`
+			HTML     string `json:"html"`
+		} `json:"data"`
+	}
+
+	rec := get(doc.DocID, "?locale=de")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "en", response.Data.Locale)
+	assert.Equal(t, "<p>Read the <strong>whole</strong> policy &lt;script&gt;</p>", response.Data.HTML)
+
+	rec = get(doc.DocID, "?locale=fr-FR")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "fr", response.Data.Locale)
+	assert.Equal(t, "Lisez toute la politique", response.Data.Markdown)
+
+	assert.Equal(t, http.StatusNotFound, get("no-message", "").Code)
+	assert.Equal(t, http.StatusNotFound, get("unknown", "").Code)
+}
+
 // ============================================================================
 // TESTS - HandleGetDocumentSignatures
 // ============================================================================
@@ -531,6 +740,49 @@ func TestHandler_HandleFindOrCreateDocument_FindExisting(t *testing.T) {
 	assert.Equal(t, 1, wrapper.Data.SignatureCount, "Should have 1 signature")
 }
 
+type stubURLSigner struct{}
+
+func (stubURLSigner) SignedURL(docID string) string {
+	return "https://example.com/api/v1/documents/" + docID + "/content/signed?expires=1&sig=abc"
+}
+
+func TestHandler_HandleFindOrCreateDocument_StoredDocumentContentURL(t *testing.T) {
+	t.Parallel()
+
+	stored := *testDoc
+	stored.URL = ""
+	stored.StorageKey = "docs/doc.pdf"
+	stored.StorageProvider = "local"
+
+	handler := &Handler{
+		documentService: &mockDocumentService{
+			findByReferenceFunc: func(ctx context.Context, ref string, refType string) (*models.Document, error) {
+				return &stored, nil
+			},
+		},
+		signatureService: &mockSignatureService{
+			getDocumentSignaturesFunc: func(ctx context.Context, docID string) ([]*models.Signature, error) {
+				return nil, nil
+			},
+		},
+		authorizer: newMockAuthorizer([]string{}, false),
+	}
+	handler.WithURLSigner(stubURLSigner{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/find-or-create?doc="+stored.DocID, nil)
+	rec := httptest.NewRecorder()
+
+	handler.HandleFindOrCreateDocument(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var wrapper struct {
+		Data FindOrCreateDocumentResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, stubURLSigner{}.SignedURL(stored.DocID), wrapper.Data.ContentURL)
+}
+
 func TestHandler_HandleFindOrCreateDocument_CreateNew(t *testing.T) {
 	t.Parallel()
 
@@ -914,6 +1166,85 @@ func TestHandler_HandleCreateDocument_AdminOnlyDisabled_AnyUser(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "test-doc-123")
 }
 
+func TestHandler_HandleCreateDocument_RecordsCreator(t *testing.T) {
+	t.Parallel()
+
+	var captured services.CreateDocumentRequest
+	handler := &Handler{
+		signatureService: &services.SignatureService{},
+		documentService: &mockDocumentService{
+			createDocFunc: func(_ context.Context, req services.CreateDocumentRequest) (*models.Document, error) {
+				captured = req
+				return testDoc, nil
+			},
+		},
+		authorizer: newMockAuthorizer([]string{}, false),
+	}
+
+	body, err := json.Marshal(CreateDocumentRequest{Reference: "https://example.com/doc.pdf"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+
+	rec := httptest.NewRecorder()
+	handler.HandleCreateDocument(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, testUser.Email, captured.CreatedBy)
+}
+
+// ============================================================================
+// TESTS - Owner-based document management
+// ============================================================================
+
+func TestHandler_HandleDeleteMyDocument_Ownership(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		userEmail    string
+		expectedCode int
+	}{
+		{"creator can delete own document", "owner@example.com", http.StatusOK},
+		{"admin can delete any document", "admin@example.com", http.StatusOK},
+		{"other user is forbidden", "other@example.com", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			adminSvc := &mockOwnerAdminService{
+				docs: map[string]*models.Document{"doc-1": {DocID: "doc-1", CreatedBy: "owner@example.com"}},
+			}
+			handler := &Handler{
+				adminService: adminSvc,
+				// Ownership must be enough even when creation is restricted to admins
+				authorizer: newMockAuthorizer([]string{"admin@example.com"}, true),
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/documents/doc-1", nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("docId", "doc-1")
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = addUserToContext(ctx, &models.User{Sub: "sub", Email: tt.userEmail})
+			req = req.WithContext(ctx)
+
+			rec := httptest.NewRecorder()
+			handler.HandleDeleteMyDocument(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, []string{"doc-1"}, adminSvc.deleted)
+			} else {
+				assert.Empty(t, adminSvc.deleted)
+			}
+		})
+	}
+}
+
 // ============================================================================
 // BENCHMARKS
 // ============================================================================
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// extensionService manages the deadline extension requests of a document
+type extensionService interface {
+	List(ctx context.Context, docID string, user *models.User) (*models.DeadlineExtensionView, error)
+	Request(ctx context.Context, docID string, user *models.User, deadline time.Time, reason string) (*models.DeadlineExtension, error)
+	Approve(ctx context.Context, docID string, id int64, user *models.User, deadline *time.Time, note string) (*models.DeadlineExtension, error)
+	Deny(ctx context.Context, docID string, id int64, user *models.User, note string) (*models.DeadlineExtension, error)
+}
+
+// Handler serves the deadline extensions signers request and the decisions of the document owner
+type Handler struct {
+	service extensionService
+}
+
+// NewHandler creates a new deadline extensions handler
+func NewHandler(service extensionService) *Handler {
+	return &Handler{service: service}
+}
+
+// RequestBody asks for a later deadline
+type RequestBody struct {
+	Deadline *time.Time `json:"deadline"`
+	Reason   string     `json:"reason"`
+}
+
+// DecisionBody approves or denies a request. Deadline, for approvals only, grants another
+// deadline than the requested one.
+type DecisionBody struct {
+	Deadline *time.Time `json:"deadline,omitempty"`
+	Note     string     `json:"note"`
+}
+
+// HandleList handles GET /api/v1/documents/{docId}/deadline-extensions.
+// The document owner and admins see every request; signers see their own.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+
+	view, err := h.service.List(r.Context(), docID, user)
+	if err != nil {
+		writeExtensionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, view)
+}
+
+// HandleRequest handles POST /api/v1/documents/{docId}/deadline-extensions
+func (h *Handler) HandleRequest(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	var req RequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if req.Deadline == nil {
+		shared.WriteValidationError(w, "deadline is required", nil)
+		return
+	}
+
+	extension, err := h.service.Request(r.Context(), docID, user, *req.Deadline, req.Reason)
+	if err != nil {
+		writeExtensionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, extension)
+}
+
+// HandleApprove handles POST /api/v1/documents/{docId}/deadline-extensions/{id}/approve
+func (h *Handler) HandleApprove(w http.ResponseWriter, r *http.Request) {
+	user, id, req, ok := decisionRequest(w, r)
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+
+	extension, err := h.service.Approve(r.Context(), docID, id, user, req.Deadline, req.Note)
+	if err != nil {
+		writeExtensionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, extension)
+}
+
+// HandleDeny handles POST /api/v1/documents/{docId}/deadline-extensions/{id}/deny
+func (h *Handler) HandleDeny(w http.ResponseWriter, r *http.Request) {
+	user, id, req, ok := decisionRequest(w, r)
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+
+	extension, err := h.service.Deny(r.Context(), docID, id, user, req.Note)
+	if err != nil {
+		writeExtensionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, extension)
+}
+
+// decisionRequest reads the user, the request ID and the optional body of a decision
+func decisionRequest(w http.ResponseWriter, r *http.Request) (*models.User, int64, DecisionBody, bool) {
+	var req DecisionBody
+	user, ok := requireUser(w, r)
+	if !ok {
+		return nil, 0, req, false
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid extension ID", nil)
+		return nil, 0, req, false
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return nil, 0, req, false
+	}
+	return user, id, req, true
+}
+
+func requireUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "")
+		return nil, false
+	}
+	return user, true
+}
+
+func writeExtensionError(w http.ResponseWriter, err error, docID string) {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	case errors.Is(err, models.ErrExtensionNotFound):
+		shared.WriteNotFound(w, "Deadline extension")
+	case errors.Is(err, models.ErrInvalidExtension), errors.Is(err, models.ErrNoDocumentDeadline):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrExtensionForbidden):
+		shared.WriteForbidden(w, err.Error())
+	case errors.Is(err, models.ErrExtensionPending), errors.Is(err, models.ErrExtensionDecided):
+		shared.WriteConflict(w, err.Error())
+	default:
+		logger.Logger.Error("Failed to handle deadline extension", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/types"
+)
+
+type mockExtensionService struct {
+	view *models.DeadlineExtensionView
+	err  error
+
+	deadline *time.Time
+	text     string
+	id       int64
+}
+
+func (m *mockExtensionService) List(_ context.Context, _ string, _ *models.User) (*models.DeadlineExtensionView, error) {
+	return m.view, m.err
+}
+
+func (m *mockExtensionService) Request(_ context.Context, docID string, user *models.User, deadline time.Time, reason string) (*models.DeadlineExtension, error) {
+	m.deadline, m.text = &deadline, reason
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.DeadlineExtension{ID: 1, DocID: docID, SignerEmail: user.Email, Reason: reason,
+		RequestedDeadline: deadline, Status: models.ExtensionStatusPending}, nil
+}
+
+func (m *mockExtensionService) Approve(_ context.Context, docID string, id int64, _ *models.User, deadline *time.Time, note string) (*models.DeadlineExtension, error) {
+	m.deadline, m.text, m.id = deadline, note, id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.DeadlineExtension{ID: id, DocID: docID, Status: models.ExtensionStatusApproved, GrantedDeadline: deadline, DecisionNote: note}, nil
+}
+
+func (m *mockExtensionService) Deny(_ context.Context, docID string, id int64, _ *models.User, note string) (*models.DeadlineExtension, error) {
+	m.text, m.id = note, id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.DeadlineExtension{ID: id, DocID: docID, Status: models.ExtensionStatusDenied, DecisionNote: note}, nil
+}
+
+func serve(handler http.HandlerFunc, method, body string, params map[string]string, user *types.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/documents/doc-1/deadline-extensions", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("docId", "doc-1")
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if user != nil {
+		ctx = context.WithValue(ctx, shared.ContextKeyUser, user)
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req.WithContext(ctx))
+	return rec
+}
+
+var alice = &types.User{Email: "alice@example.com", Name: "Alice"}
+
+func TestHandler_HandleList(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Date(2026, 11, 2, 17, 0, 0, 0, time.UTC)
+	extended := deadline.Add(72 * time.Hour)
+	service := &mockExtensionService{view: &models.DeadlineExtensionView{
+		Deadline: &deadline, EffectiveDeadline: &extended,
+		Extensions: []*models.DeadlineExtension{{ID: 1, DocID: "doc-1", Status: models.ExtensionStatusApproved, GrantedDeadline: &extended}},
+	}}
+	h := NewHandler(service)
+
+	rec := serve(h.HandleList, http.MethodGet, "", nil, alice)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, "2026-11-05T17:00:00Z", wrapper.Data["effectiveDeadline"])
+	assert.Equal(t, false, wrapper.Data["canDecide"])
+	assert.Len(t, wrapper.Data["extensions"], 1)
+
+	rec = serve(h.HandleList, http.MethodGet, "", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandler_HandleRequest(t *testing.T) {
+	t.Parallel()
+
+	service := &mockExtensionService{}
+	h := NewHandler(service)
+
+	rec := serve(h.HandleRequest, http.MethodPost, `{"deadline":"2026-11-05T17:00:00Z","reason":"Travelling"}`, nil, alice)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "Travelling", service.text)
+	assert.True(t, service.deadline.Equal(time.Date(2026, 11, 5, 17, 0, 0, 0, time.UTC)))
+
+	rec = serve(h.HandleRequest, http.MethodPost, `{"reason":"Travelling"}`, nil, alice)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(h.HandleRequest, http.MethodPost, `{"deadline":"next week"}`, nil, alice)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_HandleDecisions(t *testing.T) {
+	t.Parallel()
+
+	service := &mockExtensionService{}
+	h := NewHandler(service)
+
+	rec := serve(h.HandleApprove, http.MethodPost, "", map[string]string{"id": "3"}, alice)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(3), service.id)
+	assert.Nil(t, service.deadline)
+
+	rec = serve(h.HandleApprove, http.MethodPost, `{"deadline":"2026-11-04T17:00:00Z","note":"Two days"}`, map[string]string{"id": "3"}, alice)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, service.deadline)
+	assert.Equal(t, "Two days", service.text)
+
+	rec = serve(h.HandleDeny, http.MethodPost, `{"note":"No"}`, map[string]string{"id": "4"}, alice)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(4), service.id)
+	assert.Contains(t, rec.Body.String(), `"status":"denied"`)
+
+	rec = serve(h.HandleDeny, http.MethodPost, "", map[string]string{"id": "x"}, alice)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"document not found", models.ErrDocumentNotFound, http.StatusNotFound},
+		{"extension not found", models.ErrExtensionNotFound, http.StatusNotFound},
+		{"invalid", models.ErrInvalidExtension, http.StatusBadRequest},
+		{"no deadline", models.ErrNoDocumentDeadline, http.StatusBadRequest},
+		{"forbidden", models.ErrExtensionForbidden, http.StatusForbidden},
+		{"already pending", models.ErrExtensionPending, http.StatusConflict},
+		{"already decided", models.ErrExtensionDecided, http.StatusConflict},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&mockExtensionService{err: tt.err})
+			rec := serve(h.HandleDeny, http.MethodPost, `{"note":"No"}`, map[string]string{"id": "1"}, alice)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
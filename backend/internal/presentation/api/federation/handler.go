@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package federation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/federation"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// maxPushSize bounds the body of a push, a full batch of proofs takes about 1 MiB
+const maxPushSize = 4 << 20
+
+// pushReceiver stores the signed pushes of the peers
+type pushReceiver interface {
+	ReceivePush(ctx context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error)
+}
+
+// Handler receives the pushes of the peers of a federation. It needs no session: each push is
+// authenticated by the signature of its body with the key registered for the peer.
+type Handler struct {
+	hub pushReceiver
+}
+
+// NewHandler creates a new federation push handler
+func NewHandler(hub pushReceiver) *Handler {
+	return &Handler{hub: hub}
+}
+
+// HandlePush handles POST /api/v1/federation/push
+func (h *Handler) HandlePush(w http.ResponseWriter, r *http.Request) {
+	instanceID := r.Header.Get(federation.InstanceHeader)
+	signature := r.Header.Get(federation.SignatureHeader)
+	if instanceID == "" || signature == "" {
+		shared.WriteUnauthorized(w, models.ErrFederationUnauthorized.Error())
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushSize+1))
+	if err != nil || len(body) > maxPushSize {
+		shared.WriteError(w, http.StatusRequestEntityTooLarge, shared.ErrCodeBadRequest, "Push too large", nil)
+		return
+	}
+
+	result, err := h.hub.ReceivePush(r.Context(), instanceID, body, signature)
+	switch {
+	case errors.Is(err, models.ErrFederationUnauthorized):
+		logger.Logger.Warn("Federation push rejected", "instance_id", instanceID, "remote_addr", r.RemoteAddr)
+		shared.WriteUnauthorized(w, err.Error())
+		return
+	case errors.Is(err, models.ErrInvalidFederationPush):
+		shared.WriteValidationError(w, err.Error(), nil)
+		return
+	case err != nil:
+		logger.Logger.Error("Failed to store federation push", "instance_id", instanceID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	logger.Logger.Info("Federation push received", "instance_id", instanceID,
+		"documents", result.Documents, "signatures", result.Signatures, "duplicates", result.Duplicates)
+	shared.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/federation"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeReceiver struct {
+	err         error
+	instanceID  string
+	body        string
+	signature   string
+	invocations int
+}
+
+func (f *fakeReceiver) ReceivePush(_ context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error) {
+	f.invocations++
+	f.instanceID, f.body, f.signature = instanceID, string(body), signature
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.FederationPushResult{Documents: 1, Signatures: 2}, nil
+}
+
+func postPush(h *Handler, instanceID, signature, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, federation.PushPath, strings.NewReader(body))
+	if instanceID != "" {
+		req.Header.Set(federation.InstanceHeader, instanceID)
+	}
+	if signature != "" {
+		req.Header.Set(federation.SignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	h.HandlePush(rec, req)
+	return rec
+}
+
+func TestHandlePush(t *testing.T) {
+	receiver := &fakeReceiver{}
+	rec := postPush(NewHandler(receiver), "acme-fr", "c2ln", `{"instanceId":"acme-fr"}`)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "acme-fr", receiver.instanceID)
+	assert.Equal(t, "c2ln", receiver.signature)
+	assert.Equal(t, `{"instanceId":"acme-fr"}`, receiver.body)
+
+	var reply struct {
+		Data models.FederationPushResult `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reply))
+	assert.Equal(t, 2, reply.Data.Signatures)
+}
+
+func TestHandlePush_Rejected(t *testing.T) {
+	receiver := &fakeReceiver{}
+	h := NewHandler(receiver)
+
+	assert.Equal(t, http.StatusUnauthorized, postPush(h, "", "c2ln", `{}`).Code)
+	assert.Equal(t, http.StatusUnauthorized, postPush(h, "acme-fr", "", `{}`).Code)
+	assert.Zero(t, receiver.invocations, "unsigned pushes must not reach the hub")
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postPush(h, "acme-fr", "c2ln", strings.Repeat("a", maxPushSize+1)).Code)
+
+	receiver.err = models.ErrFederationUnauthorized
+	assert.Equal(t, http.StatusUnauthorized, postPush(h, "acme-fr", "c2ln", `{}`).Code)
+
+	receiver.err = fmt.Errorf("%w: too old", models.ErrInvalidFederationPush)
+	assert.Equal(t, http.StatusBadRequest, postPush(h, "acme-fr", "c2ln", `{}`).Code)
+}
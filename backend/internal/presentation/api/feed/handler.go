@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const atomContentType = "application/atom+xml"
+
+// feedService defines the public feed operations
+type feedService interface {
+	Feed(ctx context.Context) (*models.PublicFeed, error)
+}
+
+// Handler serves the public feed of the documents as JSON or Atom. The feed only holds what the
+// public document endpoints already expose, so portals read it without an account.
+type Handler struct {
+	service feedService
+}
+
+// NewHandler creates a new feed handler
+func NewHandler(service feedService) *Handler {
+	return &Handler{service: service}
+}
+
+// HandleListDocuments handles GET /api/v1/public/documents.
+// Atom is served with format=atom or an Accept header asking for application/atom+xml.
+func (h *Handler) HandleListDocuments(w http.ResponseWriter, r *http.Request) {
+	atom, ok := wantsAtom(r)
+	if !ok {
+		shared.WriteValidationError(w, "Invalid format", map[string]string{"format": "must be json or atom"})
+		return
+	}
+
+	feed, err := h.service.Feed(r.Context())
+	if err != nil {
+		logger.Logger.Error("Failed to build public feed", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	// Signatures change the completion without updating documents: the validator covers the content
+	content, err := json.Marshal(feed)
+	if err != nil {
+		logger.Logger.Error("Failed to encode public feed", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Add("Vary", "Accept")
+	if shared.CheckNotModified(w, r, shared.ContentETag(string(content), fmt.Sprint(atom)), time.Time{}) {
+		return
+	}
+
+	if !atom {
+		shared.WriteJSON(w, http.StatusOK, feed)
+		return
+	}
+
+	body, err := xml.MarshalIndent(atomFeed(feed, r.URL.Path), "", "  ")
+	if err != nil {
+		logger.Logger.Error("Failed to encode public feed", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	w.Header().Set("Content-Type", atomContentType+"; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(body)
+}
+
+// wantsAtom reads the requested format; the format parameter wins over the Accept header
+func wantsAtom(r *http.Request) (atom bool, valid bool) {
+	switch r.URL.Query().Get("format") {
+	case "atom":
+		return true, true
+	case "json":
+		return false, true
+	case "":
+		return strings.Contains(r.Header.Get("Accept"), atomContentType), true
+	}
+	return false, false
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+	Summary   string   `xml:"summary,omitempty"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomFeed converts the feed to Atom; the version, checksum and completion go in the summary
+func atomFeed(feed *models.PublicFeed, path string) atomDocument {
+	updated := feed.UpdatedAt
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	title := feed.Title
+	if title == "" {
+		title = "Documents"
+	}
+
+	doc := atomDocument{
+		ID:      feed.URL,
+		Title:   title,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.URL, Rel: "alternate"},
+			{Href: strings.TrimSuffix(feed.URL, "/") + path + "?format=atom", Rel: "self"},
+		},
+		Entries: make([]atomEntry, 0, len(feed.Documents)),
+	}
+	for _, d := range feed.Documents {
+		doc.Entries = append(doc.Entries, atomEntry{
+			ID:        d.URL,
+			Title:     d.Title,
+			Link:      atomLink{Href: d.URL},
+			Published: d.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   d.UpdatedAt.UTC().Format(time.RFC3339),
+			Summary:   atomSummary(d),
+		})
+	}
+	return doc
+}
+
+func atomSummary(d *models.PublicDocument) string {
+	parts := []string{}
+	if d.Description != "" {
+		parts = append(parts, d.Description)
+	}
+	parts = append(parts, fmt.Sprintf("Version %d", d.Version))
+	if d.Checksum != "" {
+		parts = append(parts, fmt.Sprintf("%s %s", d.ChecksumAlgorithm, d.Checksum))
+	}
+	if d.Completion != nil {
+		parts = append(parts, fmt.Sprintf("%d/%d signed", d.Completion.SignedCount, d.Completion.ExpectedCount))
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockFeedService struct {
+	feed *models.PublicFeed
+	err  error
+}
+
+func (m *mockFeedService) Feed(_ context.Context) (*models.PublicFeed, error) {
+	return m.feed, m.err
+}
+
+func newMockFeed() *mockFeedService {
+	updated := time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)
+	return &mockFeedService{feed: &models.PublicFeed{
+		Title:     "Acme",
+		URL:       "https://sign.acme.com/",
+		UpdatedAt: updated,
+		Documents: []*models.PublicDocument{{
+			DocID: "it-charter", Title: "IT charter", Version: 3, Checksum: "abc123", ChecksumAlgorithm: "SHA-256",
+			URL: "https://sign.acme.com/?doc=it-charter", CreatedAt: updated.Add(-48 * time.Hour), UpdatedAt: updated,
+			Completion: &models.PublicCompletion{ExpectedCount: 4, SignedCount: 3, CompletionRate: 75},
+		}},
+	}}
+}
+
+func serve(h *Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	h.HandleListDocuments(rec, req)
+	return rec
+}
+
+func TestHandler_HandleListDocuments_JSON(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(newMockFeed())
+	rec := serve(h, "/api/v1/public/documents", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+	var body struct {
+		Data models.PublicFeed `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Data.Documents, 1)
+	assert.Equal(t, "abc123", body.Data.Documents[0].Checksum)
+	assert.Equal(t, 3, body.Data.Documents[0].Completion.SignedCount)
+
+	// Clients revalidate with the ETag of the content
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	rec = serve(h, "/api/v1/public/documents", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestHandler_HandleListDocuments_Atom(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(newMockFeed())
+	for _, tc := range []struct {
+		name   string
+		target string
+		header http.Header
+	}{
+		{"format parameter", "/api/v1/public/documents?format=atom", nil},
+		{"accept header", "/api/v1/public/documents", http.Header{"Accept": {"application/atom+xml"}}},
+	} {
+		rec := serve(h, tc.target, tc.header)
+		require.Equal(t, http.StatusOK, rec.Code, tc.name)
+		assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "application/atom+xml"), tc.name)
+
+		var feed atomDocument
+		require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &feed), tc.name)
+		assert.Equal(t, "Acme", feed.Title)
+		assert.Equal(t, "2026-03-03T09:00:00Z", feed.Updated)
+		require.Len(t, feed.Entries, 1)
+		assert.Equal(t, "https://sign.acme.com/?doc=it-charter", feed.Entries[0].Link.Href)
+		assert.Contains(t, feed.Entries[0].Summary, "Version 3")
+		assert.Contains(t, feed.Entries[0].Summary, "3/4 signed")
+	}
+}
+
+func TestHandler_HandleListDocuments_Errors(t *testing.T) {
+	t.Parallel()
+
+	rec := serve(NewHandler(newMockFeed()), "/api/v1/public/documents?format=rss", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(NewHandler(&mockFeedService{err: errors.New("db down")}), "/api/v1/public/documents", nil)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
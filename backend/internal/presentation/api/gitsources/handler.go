@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package gitsources
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxPushSize bounds the body of a push event, large pushes list many commits
+const maxPushSize = 1 << 20
+
+// pushHandler requests the sync of a Git source on a push event
+type pushHandler interface {
+	HandlePush(ctx context.Context, id int64, push services.GitPush) (bool, error)
+}
+
+// Handler receives the push webhooks of GitHub, GitLab and Gitea.
+// Requests are authenticated by the webhook secret of the source, not by a session.
+type Handler struct {
+	service pushHandler
+}
+
+// NewHandler creates a new Git push webhook handler
+func NewHandler(service pushHandler) *Handler {
+	return &Handler{service: service}
+}
+
+// HandlePush handles POST /api/v1/git-sources/{id}/webhook
+func (h *Handler) HandlePush(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteNotFound(w, "Git source")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushSize+1))
+	if err != nil || len(body) > maxPushSize {
+		shared.WriteValidationError(w, "Invalid push event", nil)
+		return
+	}
+
+	signature := r.Header.Get("X-Hub-Signature-256")
+	if signature == "" {
+		signature = r.Header.Get("X-Gitea-Signature")
+	}
+	requested, err := h.service.HandlePush(r.Context(), id, services.GitPush{
+		Body:      body,
+		Signature: signature,
+		Token:     r.Header.Get("X-Gitlab-Token"),
+	})
+	switch {
+	case errors.Is(err, services.ErrInvalidGitWebhook):
+		shared.WriteUnauthorized(w, "Invalid webhook signature")
+		return
+	case errors.Is(err, models.ErrGitSourceNotFound):
+		shared.WriteNotFound(w, "Git source")
+		return
+	case err != nil:
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusAccepted, map[string]bool{"requested": requested})
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package gitsources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockPushHandler struct {
+	requested bool
+	err       error
+	lastID    int64
+	lastPush  services.GitPush
+}
+
+func (m *mockPushHandler) HandlePush(_ context.Context, id int64, push services.GitPush) (bool, error) {
+	m.lastID = id
+	m.lastPush = push
+	return m.requested, m.err
+}
+
+func serve(h *Handler, target string, headers map[string]string) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	r.Post("/git-sources/{id}/webhook", h.HandlePush)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(`{"ref":"refs/heads/main"}`))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_HandlePush(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GitHub signature", func(t *testing.T) {
+		t.Parallel()
+		service := &mockPushHandler{requested: true}
+		rec := serve(NewHandler(service), "/git-sources/4/webhook", map[string]string{"X-Hub-Signature-256": "sha256=abc"})
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.JSONEq(t, `{"data":{"requested":true}}`, rec.Body.String())
+		assert.Equal(t, int64(4), service.lastID)
+		assert.Equal(t, "sha256=abc", service.lastPush.Signature)
+		assert.Equal(t, `{"ref":"refs/heads/main"}`, string(service.lastPush.Body))
+	})
+
+	t.Run("Gitea signature and GitLab token", func(t *testing.T) {
+		t.Parallel()
+		service := &mockPushHandler{}
+		serve(NewHandler(service), "/git-sources/4/webhook", map[string]string{"X-Gitea-Signature": "abc"})
+		assert.Equal(t, "abc", service.lastPush.Signature)
+
+		serve(NewHandler(service), "/git-sources/4/webhook", map[string]string{"X-Gitlab-Token": "s3cret"})
+		assert.Equal(t, "s3cret", service.lastPush.Token)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+		rec := serve(NewHandler(&mockPushHandler{err: services.ErrInvalidGitWebhook}), "/git-sources/4/webhook", nil)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		t.Parallel()
+		rec := serve(NewHandler(&mockPushHandler{err: models.ErrGitSourceNotFound}), "/git-sources/4/webhook", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+
+		rec = serve(NewHandler(&mockPushHandler{}), "/git-sources/abc/webhook", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
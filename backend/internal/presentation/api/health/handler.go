@@ -9,13 +9,21 @@ import (
 )
 
 // Handler handles health check requests
-type Handler struct{}
+type Handler struct {
+	draining func() bool
+}
 
 // NewHandler creates a new health handler
 func NewHandler() *Handler {
 	return &Handler{}
 }
 
+// SetDraining registers a check reporting whether the server is shutting down.
+// While it returns true the health endpoint answers 503 so load balancers stop routing traffic.
+func (h *Handler) SetDraining(draining func() bool) {
+	h.draining = draining
+}
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
 	Status    string    `json:"status"`
@@ -24,6 +32,14 @@ type HealthResponse struct {
 
 // HandleHealth handles GET /api/v1/health
 func (h *Handler) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	if h.draining != nil && h.draining() {
+		shared.WriteJSON(w, http.StatusServiceUnavailable, HealthResponse{
+			Status:    "draining",
+			Timestamp: time.Now(),
+		})
+		return
+	}
+
 	response := HealthResponse{
 		Status:    "ok",
 		Timestamp: time.Now(),
@@ -71,3 +71,26 @@ func TestHandler_HandleHealth(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_HandleHealth_Draining(t *testing.T) {
+	t.Parallel()
+
+	draining := false
+	handler := NewHandler()
+	handler.SetDraining(func() bool { return draining })
+
+	rec := httptest.NewRecorder()
+	handler.HandleHealth(rec, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	draining = true
+	rec = httptest.NewRecorder()
+	handler.HandleHealth(rec, httptest.NewRequest(http.MethodGet, "/api/v1/health", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var wrapper struct {
+		Data HealthResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, "draining", wrapper.Data.Status)
+}
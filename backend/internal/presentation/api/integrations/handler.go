@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// contextKeyAPIKey is the context key for the authenticated API key
+const contextKeyAPIKey shared.ContextKey = "api_key"
+
+// apiKeyHeader is an alternative to the Authorization header for clients that cannot set bearer tokens
+const apiKeyHeader = "X-API-Key"
+
+// maxRequestBytes bounds the size of an integration request body
+const maxRequestBytes = 1 << 20
+
+// apiKeyAuthenticator validates API key secrets
+type apiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (*models.APIKey, error)
+}
+
+// integrationService defines delegated document operations
+type integrationService interface {
+	CreateDocument(ctx context.Context, req services.IntegrationDocumentRequest, key *models.APIKey) (*services.IntegrationDocumentResult, error)
+	GetDocumentStatus(ctx context.Context, docID string) (*services.IntegrationDocumentResult, error)
+}
+
+// Handler serves the integration API used by external systems
+type Handler struct {
+	keys    apiKeyAuthenticator
+	service integrationService
+}
+
+func NewHandler(keys apiKeyAuthenticator, service integrationService) *Handler {
+	return &Handler{keys: keys, service: service}
+}
+
+// RequireAPIKey middleware authenticates the request with an API key
+// given as "Authorization: Bearer <key>" or in the X-API-Key header
+func (h *Handler) RequireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret := r.Header.Get(apiKeyHeader)
+		if auth := r.Header.Get("Authorization"); secret == "" && strings.HasPrefix(auth, "Bearer ") {
+			secret = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+		}
+		if secret == "" {
+			shared.WriteUnauthorized(w, "API key required")
+			return
+		}
+
+		key, err := h.keys.Authenticate(r.Context(), secret)
+		if err != nil {
+			if !errors.Is(err, services.ErrInvalidAPIKey) {
+				logger.Logger.Error("API key authentication failed", "error", err.Error())
+				shared.WriteInternalError(w)
+				return
+			}
+			shared.WriteUnauthorized(w, "Invalid API key")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), contextKeyAPIKey, key)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireScope middleware ensures the API key grants a permission. Must run after RequireAPIKey.
+func RequireScope(scope models.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, ok := GetAPIKeyFromContext(r.Context())
+			if !ok {
+				shared.WriteUnauthorized(w, "API key required")
+				return
+			}
+			if !key.HasScope(scope) {
+				shared.WriteForbidden(w, "Missing scope: "+string(scope))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// GetAPIKeyFromContext retrieves the API key from the request context
+func GetAPIKeyFromContext(ctx context.Context) (*models.APIKey, bool) {
+	key, ok := ctx.Value(contextKeyAPIKey).(*models.APIKey)
+	return key, ok
+}
+
+// HandleCreateDocument handles POST /api/v1/integrations/documents
+func (h *Handler) HandleCreateDocument(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	key, ok := GetAPIKeyFromContext(ctx)
+	if !ok {
+		shared.WriteUnauthorized(w, "API key required")
+		return
+	}
+
+	var req services.IntegrationDocumentRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	// Signers and webhooks need their own scope on top of documents:write
+	if len(req.Signers) > 0 && !key.HasScope(models.PermissionSignersManage) {
+		shared.WriteForbidden(w, "Missing scope: "+string(models.PermissionSignersManage))
+		return
+	}
+	if req.Webhook != nil && !key.HasScope(models.PermissionWebhooksManage) {
+		shared.WriteForbidden(w, "Missing scope: "+string(models.PermissionWebhooksManage))
+		return
+	}
+
+	result, err := h.service.CreateDocument(ctx, req, key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidIntegrationRequest) {
+			shared.WriteValidationError(w, err.Error(), nil)
+			return
+		}
+		logger.Logger.Error("Integration document creation failed", "api_key_id", key.ID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Created {
+		status = http.StatusCreated
+	}
+	shared.WriteJSON(w, status, result)
+}
+
+// HandleGetDocument handles GET /api/v1/integrations/documents/{docId}
+func (h *Handler) HandleGetDocument(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	result, err := h.service.GetDocumentStatus(r.Context(), docID)
+	if err != nil {
+		if errors.Is(err, models.ErrDocumentNotFound) {
+			shared.WriteNotFound(w, "Document")
+			return
+		}
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package integrations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeAuthenticator struct {
+	key *models.APIKey
+}
+
+func (f *fakeAuthenticator) Authenticate(_ context.Context, secret string) (*models.APIKey, error) {
+	if secret != "ack_valid" {
+		return nil, services.ErrInvalidAPIKey
+	}
+	return f.key, nil
+}
+
+type fakeIntegrationService struct {
+	calls int
+}
+
+func (f *fakeIntegrationService) CreateDocument(_ context.Context, req services.IntegrationDocumentRequest, _ *models.APIKey) (*services.IntegrationDocumentResult, error) {
+	f.calls++
+	return &services.IntegrationDocumentResult{DocID: "abc", URL: req.URL, Created: true}, nil
+}
+
+func (f *fakeIntegrationService) GetDocumentStatus(_ context.Context, docID string) (*services.IntegrationDocumentResult, error) {
+	return nil, models.ErrDocumentNotFound
+}
+
+func TestHandler_CreateDocument_Auth(t *testing.T) {
+	key := &models.APIKey{ID: 1, Name: "DMS", Scopes: []models.Permission{models.PermissionDocumentsWrite}}
+	service := &fakeIntegrationService{}
+	h := NewHandler(&fakeAuthenticator{key: key}, service)
+	handler := h.RequireAPIKey(RequireScope(models.PermissionDocumentsWrite)(http.HandlerFunc(h.HandleCreateDocument)))
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		body       string
+		wantStatus int
+	}{
+		{"missing key", "", "", `{"url":"https://dms.example.com/a.pdf"}`, http.StatusUnauthorized},
+		{"invalid key", "Authorization", "Bearer ack_invalid", `{"url":"https://dms.example.com/a.pdf"}`, http.StatusUnauthorized},
+		{"bearer key", "Authorization", "Bearer ack_valid", `{"url":"https://dms.example.com/a.pdf"}`, http.StatusCreated},
+		{"header key", apiKeyHeader, "ack_valid", `{"url":"https://dms.example.com/a.pdf"}`, http.StatusCreated},
+		{"signers without scope", apiKeyHeader, "ack_valid", `{"url":"https://dms.example.com/a.pdf","signers":[{"email":"a@example.com"}]}`, http.StatusForbidden},
+		{"webhook without scope", apiKeyHeader, "ack_valid", `{"url":"https://dms.example.com/a.pdf","webhook":{"url":"https://dms.example.com/h","secret":"s"}}`, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/integrations/documents", strings.NewReader(tt.body))
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+	if service.calls != 2 {
+		t.Errorf("expected 2 service calls, got %d", service.calls)
+	}
+}
+
+func TestRequireScope_Forbidden(t *testing.T) {
+	key := &models.APIKey{ID: 1, Scopes: []models.Permission{models.PermissionDocumentsWrite}}
+	h := NewHandler(&fakeAuthenticator{key: key}, &fakeIntegrationService{})
+	handler := h.RequireAPIKey(RequireScope(models.PermissionDocumentsRead)(http.HandlerFunc(h.HandleGetDocument)))
+
+	req := httptest.NewRequest(http.MethodGet, "/integrations/documents/abc", nil)
+	req.Header.Set("Authorization", "Bearer ack_valid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package limits
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// routeLimiter is an in-memory per-IP limit of a route group
+type routeLimiter interface {
+	Quota(ip string) *models.RateLimitQuota
+}
+
+// actionQuotas reports the database-backed limits of protected actions, such as signing
+type actionQuotas interface {
+	Quotas(ctx context.Context, userEmail, ip string) ([]*models.RateLimitQuota, error)
+}
+
+// Handler publishes the request quotas applying to the caller, so that integrators can self-throttle
+type Handler struct {
+	routes  []routeLimiter
+	actions actionQuotas
+}
+
+// NewHandler creates a new limits handler for the route group limiters
+func NewHandler(routes ...routeLimiter) *Handler {
+	return &Handler{routes: routes}
+}
+
+// SetActionQuotas adds the limits of protected actions to the quota document
+func (h *Handler) SetActionQuotas(actions actionQuotas) {
+	h.actions = actions
+}
+
+// LimitsResponse is the quota document of the caller
+type LimitsResponse struct {
+	// Routes are counted per client IP over all the requests of a route group
+	Routes []*models.RateLimitQuota `json:"routes"`
+	// Actions are counted per user and per client IP within the tenant
+	Actions []*models.RateLimitQuota `json:"actions"`
+}
+
+// HandleGetLimits handles GET /api/v1/limits
+func (h *Handler) HandleGetLimits(w http.ResponseWriter, r *http.Request) {
+	ip := shared.RemoteIP(r)
+
+	response := LimitsResponse{
+		Routes:  make([]*models.RateLimitQuota, 0, len(h.routes)),
+		Actions: []*models.RateLimitQuota{},
+	}
+	for _, route := range h.routes {
+		response.Routes = append(response.Routes, route.Quota(ip))
+	}
+
+	if h.actions != nil {
+		var userEmail string
+		if user, ok := shared.GetUserFromContext(r.Context()); ok {
+			userEmail = user.Email
+		}
+		actions, err := h.actions.Quotas(r.Context(), userEmail, ip)
+		if err != nil {
+			logger.Logger.Error("Failed to read action quotas", "error", err.Error())
+			shared.WriteInternalError(w)
+			return
+		}
+		response.Actions = actions
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	shared.WriteJSON(w, http.StatusOK, response)
+}
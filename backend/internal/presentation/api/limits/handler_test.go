@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package limits
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeActionQuotas struct {
+	userEmail string
+	ip        string
+}
+
+func (f *fakeActionQuotas) Quotas(_ context.Context, userEmail, ip string) ([]*models.RateLimitQuota, error) {
+	f.userEmail, f.ip = userEmail, ip
+	return []*models.RateLimitQuota{{Name: models.RateLimitActionSignature, Scope: models.RateLimitScopeUser, Limit: 5, WindowSeconds: 3600, Remaining: 4}}, nil
+}
+
+func TestHandler_HandleGetLimits(t *testing.T) {
+	t.Parallel()
+
+	general := shared.NewRateLimit(100, time.Minute)
+	auth := shared.NewRateLimit(5, time.Minute).WithName("auth")
+	actions := &fakeActionQuotas{}
+	handler := NewHandler(general, auth)
+	handler.SetActionQuotas(actions)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/limits", nil)
+	req.RemoteAddr = "203.0.113.7:4242"
+	req = req.WithContext(context.WithValue(req.Context(), shared.ContextKeyUser, &models.User{Email: "dev@example.com"}))
+	rec := httptest.NewRecorder()
+
+	general.Middleware(http.HandlerFunc(handler.HandleGetLimits)).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var response struct {
+		Data LimitsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	require.Len(t, response.Data.Routes, 2)
+	assert.Equal(t, "general", response.Data.Routes[0].Name)
+	assert.Equal(t, 99, response.Data.Routes[0].Remaining, "the request itself is counted")
+	assert.Equal(t, "auth", response.Data.Routes[1].Name)
+	assert.Equal(t, 5, response.Data.Routes[1].Remaining)
+
+	require.Len(t, response.Data.Actions, 1)
+	assert.Equal(t, 4, response.Data.Actions[0].Remaining)
+	assert.Equal(t, "dev@example.com", actions.userEmail)
+	assert.Equal(t, "203.0.113.7", actions.ip)
+	assert.Equal(t, "99", rec.Header().Get("X-RateLimit-Remaining"))
+}
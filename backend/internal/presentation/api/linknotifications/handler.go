@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linknotifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// maxNotificationSize bounds the body of a notification batch
+const maxNotificationSize = 1 << 20
+
+// notificationHandler marks the documents whose linked file changed
+type notificationHandler interface {
+	HandleNotification(ctx context.Context, provider, subscriptionID, clientState string) error
+}
+
+// Handler receives the change notifications of Microsoft Graph and Google Drive.
+// Requests are authenticated by the client state of the subscription, not by a session.
+type Handler struct {
+	service notificationHandler
+}
+
+// NewHandler creates a new link change notification handler
+func NewHandler(service notificationHandler) *Handler {
+	return &Handler{service: service}
+}
+
+// graphNotifications is a batch of Microsoft Graph change notifications
+type graphNotifications struct {
+	Value []struct {
+		SubscriptionID string `json:"subscriptionId"`
+		ClientState    string `json:"clientState"`
+	} `json:"value"`
+}
+
+// HandleSharePoint handles POST /api/v1/link-notifications/sharepoint
+func (h *Handler) HandleSharePoint(w http.ResponseWriter, r *http.Request) {
+	// Graph validates the endpoint when subscribing: the token must be echoed as plain text
+	if token := r.URL.Query().Get("validationToken"); token != "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, token)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxNotificationSize+1))
+	var batch graphNotifications
+	if err != nil || len(body) > maxNotificationSize || json.Unmarshal(body, &batch) != nil {
+		shared.WriteValidationError(w, "Invalid change notification", nil)
+		return
+	}
+
+	accepted, rejected := 0, 0
+	for _, notification := range batch.Value {
+		err := h.service.HandleNotification(r.Context(), models.LinkProviderSharePoint, notification.SubscriptionID, notification.ClientState)
+		switch {
+		case errors.Is(err, services.ErrInvalidLinkNotification):
+			rejected++
+		case errors.Is(err, models.ErrSubscriptionNotFound):
+			// Subscription replaced or of a deleted document, it expires on its own
+		case err != nil:
+			logger.Logger.Error("Failed to handle link change notification", "provider", models.LinkProviderSharePoint, "error", err.Error())
+			shared.WriteInternalError(w)
+			return
+		default:
+			accepted++
+		}
+	}
+	if rejected > 0 && accepted == 0 {
+		shared.WriteUnauthorized(w, "Invalid client state")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// HandleGoogleDrive handles POST /api/v1/link-notifications/google-drive
+func (h *Handler) HandleGoogleDrive(w http.ResponseWriter, r *http.Request) {
+	channelID := r.Header.Get("X-Goog-Channel-ID")
+	if channelID == "" {
+		shared.WriteValidationError(w, "Invalid change notification", nil)
+		return
+	}
+	// Drive confirms each new channel with a sync message, which reports no change
+	if r.Header.Get("X-Goog-Resource-State") == "sync" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	err := h.service.HandleNotification(r.Context(), models.LinkProviderGoogleDrive, channelID, r.Header.Get("X-Goog-Channel-Token"))
+	switch {
+	case errors.Is(err, services.ErrInvalidLinkNotification):
+		shared.WriteUnauthorized(w, "Invalid channel token")
+		return
+	case errors.Is(err, models.ErrSubscriptionNotFound):
+		shared.WriteNotFound(w, "Link subscription")
+		return
+	case err != nil:
+		logger.Logger.Error("Failed to handle link change notification", "provider", models.LinkProviderGoogleDrive, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
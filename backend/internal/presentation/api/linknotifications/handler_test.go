@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package linknotifications
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockNotificationHandler struct {
+	errs     map[string]error
+	received []string
+}
+
+func (m *mockNotificationHandler) HandleNotification(_ context.Context, provider, subscriptionID, clientState string) error {
+	m.received = append(m.received, provider+"/"+subscriptionID+"/"+clientState)
+	return m.errs[subscriptionID]
+}
+
+func TestHandler_HandleSharePoint(t *testing.T) {
+	t.Parallel()
+
+	t.Run("validation token echoed", func(t *testing.T) {
+		t.Parallel()
+		service := &mockNotificationHandler{}
+		req := httptest.NewRequest(http.MethodPost, "/link-notifications/sharepoint?validationToken=abc%20123", nil)
+		rec := httptest.NewRecorder()
+		NewHandler(service).HandleSharePoint(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "abc 123", rec.Body.String())
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+		assert.Empty(t, service.received)
+	})
+
+	t.Run("batch of notifications", func(t *testing.T) {
+		t.Parallel()
+		service := &mockNotificationHandler{errs: map[string]error{"stale": models.ErrSubscriptionNotFound}}
+		body := `{"value":[{"subscriptionId":"sub-1","clientState":"s1"},{"subscriptionId":"stale","clientState":"s2"}]}`
+		rec := httptest.NewRecorder()
+		NewHandler(service).HandleSharePoint(rec, httptest.NewRequest(http.MethodPost, "/link-notifications/sharepoint", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, []string{"sharepoint/sub-1/s1", "sharepoint/stale/s2"}, service.received)
+	})
+
+	t.Run("invalid client state", func(t *testing.T) {
+		t.Parallel()
+		service := &mockNotificationHandler{errs: map[string]error{"sub-1": services.ErrInvalidLinkNotification}}
+		body := `{"value":[{"subscriptionId":"sub-1","clientState":"forged"}]}`
+		rec := httptest.NewRecorder()
+		NewHandler(service).HandleSharePoint(rec, httptest.NewRequest(http.MethodPost, "/link-notifications/sharepoint", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		NewHandler(&mockNotificationHandler{}).HandleSharePoint(rec, httptest.NewRequest(http.MethodPost, "/link-notifications/sharepoint", strings.NewReader("{")))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_HandleGoogleDrive(t *testing.T) {
+	t.Parallel()
+
+	notify := func(service *mockNotificationHandler, state string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/link-notifications/google-drive", nil)
+		req.Header.Set("X-Goog-Channel-ID", "channel-1")
+		req.Header.Set("X-Goog-Channel-Token", "token")
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		NewHandler(service).HandleGoogleDrive(rec, req)
+		return rec
+	}
+
+	t.Run("sync message", func(t *testing.T) {
+		t.Parallel()
+		service := &mockNotificationHandler{}
+		assert.Equal(t, http.StatusOK, notify(service, "sync").Code)
+		assert.Empty(t, service.received)
+	})
+
+	t.Run("change", func(t *testing.T) {
+		t.Parallel()
+		service := &mockNotificationHandler{}
+		assert.Equal(t, http.StatusOK, notify(service, "update").Code)
+		assert.Equal(t, []string{"google-drive/channel-1/token"}, service.received)
+	})
+
+	t.Run("invalid token and unknown channel", func(t *testing.T) {
+		t.Parallel()
+		rec := notify(&mockNotificationHandler{errs: map[string]error{"channel-1": services.ErrInvalidLinkNotification}}, "update")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		rec = notify(&mockNotificationHandler{errs: map[string]error{"channel-1": models.ErrSubscriptionNotFound}}, "update")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		t.Parallel()
+		rec := httptest.NewRecorder()
+		NewHandler(&mockNotificationHandler{}).HandleGoogleDrive(rec, httptest.NewRequest(http.MethodPost, "/link-notifications/google-drive", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
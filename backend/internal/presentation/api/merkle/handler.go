@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package merkle
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+const (
+	defaultRootsLimit = 20
+	maxRootsLimit     = 100
+)
+
+// merkleService defines the public Merkle root operations
+type merkleService interface {
+	ListRoots(ctx context.Context, limit int) ([]*models.MerkleRoot, error)
+	GetProof(ctx context.Context, signatureID int64) (*models.MerkleProof, error)
+}
+
+// Handler serves published Merkle roots and signature inclusion proofs.
+// Proofs only expose hashes, so they are public and can be checked by third parties.
+type Handler struct {
+	service merkleService
+}
+
+// NewHandler creates a new Merkle handler
+func NewHandler(service merkleService) *Handler {
+	return &Handler{service: service}
+}
+
+// HandleListRoots handles GET /api/v1/merkle/roots
+func (h *Handler) HandleListRoots(w http.ResponseWriter, r *http.Request) {
+	limit := defaultRootsLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxRootsLimit {
+			shared.WriteValidationError(w, "Invalid limit", map[string]string{"limit": "must be between 1 and 100"})
+			return
+		}
+		limit = parsed
+	}
+
+	roots, err := h.service.ListRoots(r.Context(), limit)
+	if err != nil {
+		logger.Logger.Error("Failed to list merkle roots", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if roots == nil {
+		roots = []*models.MerkleRoot{}
+	}
+	shared.WriteJSON(w, http.StatusOK, roots)
+}
+
+// HandleGetProof handles GET /api/v1/merkle/proofs/{signatureId}
+func (h *Handler) HandleGetProof(w http.ResponseWriter, r *http.Request) {
+	signatureID, err := strconv.ParseInt(chi.URLParam(r, "signatureId"), 10, 64)
+	if err != nil || signatureID <= 0 {
+		shared.WriteValidationError(w, "Invalid signature ID", map[string]string{"signatureId": "must be a positive integer"})
+		return
+	}
+
+	proof, err := h.service.GetProof(r.Context(), signatureID)
+	if err != nil {
+		logger.Logger.Error("Failed to build merkle proof", "signature_id", signatureID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if proof == nil {
+		// Unknown signature, or created since the latest root
+		shared.WriteNotFound(w, "Merkle proof")
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, proof)
+}
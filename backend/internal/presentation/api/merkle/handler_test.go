@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package merkle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockMerkleService struct {
+	roots     []*models.MerkleRoot
+	proofs    map[int64]*models.MerkleProof
+	err       error
+	lastLimit int
+}
+
+func (m *mockMerkleService) ListRoots(_ context.Context, limit int) ([]*models.MerkleRoot, error) {
+	m.lastLimit = limit
+	return m.roots, m.err
+}
+
+func (m *mockMerkleService) GetProof(_ context.Context, signatureID int64) (*models.MerkleProof, error) {
+	return m.proofs[signatureID], m.err
+}
+
+func serve(h *Handler, target string) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	r.Get("/merkle/roots", h.HandleListRoots)
+	r.Get("/merkle/proofs/{signatureId}", h.HandleGetProof)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestHandler_HandleGetProof(t *testing.T) {
+	t.Parallel()
+
+	service := &mockMerkleService{proofs: map[int64]*models.MerkleProof{
+		3: {SignatureID: 3, LeafIndex: 2, Root: &models.MerkleRoot{ID: 1, RootHash: "root"}, RecordMatches: true},
+	}}
+	h := NewHandler(service)
+
+	rec := serve(h, "/merkle/proofs/3")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Data models.MerkleProof `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, 2, body.Data.LeafIndex)
+	assert.Equal(t, "root", body.Data.Root.RootHash)
+
+	assert.Equal(t, http.StatusNotFound, serve(h, "/merkle/proofs/4").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, "/merkle/proofs/abc").Code)
+
+	service.err = errors.New("db down")
+	assert.Equal(t, http.StatusInternalServerError, serve(h, "/merkle/proofs/3").Code)
+}
+
+func TestHandler_HandleListRoots(t *testing.T) {
+	t.Parallel()
+
+	service := &mockMerkleService{}
+	h := NewHandler(service)
+
+	rec := serve(h, "/merkle/roots")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data":[]}`, rec.Body.String())
+	assert.Equal(t, defaultRootsLimit, service.lastLimit)
+
+	serve(h, "/merkle/roots?limit=5")
+	assert.Equal(t, 5, service.lastLimit)
+
+	assert.Equal(t, http.StatusBadRequest, serve(h, "/merkle/roots?limit=500").Code)
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package packets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// packetService defines the signer side of document packets
+type packetService interface {
+	GetSignerView(ctx context.Context, id int64, user *models.User) (*models.PacketSignerView, error)
+}
+
+// Handler serves the packets signers go through one document after the other
+type Handler struct {
+	service packetService
+}
+
+// NewHandler creates a new packets handler
+func NewHandler(service packetService) *Handler {
+	return &Handler{service: service}
+}
+
+// HandleGetPacket handles GET /api/v1/packets/{id}.
+// Lists the documents of the packet with the signing status of the current user and the next
+// document to sign; documents are signed one by one through POST /api/v1/signatures.
+func (h *Handler) HandleGetPacket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid packet ID", nil)
+		return
+	}
+
+	view, err := h.service.GetSignerView(ctx, id, user)
+	if errors.Is(err, models.ErrPacketNotFound) {
+		shared.WriteNotFound(w, "Document packet")
+		return
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to get document packet", "packet_id", id, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, view)
+}
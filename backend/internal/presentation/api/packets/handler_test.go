@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package packets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/types"
+)
+
+type mockPacketService struct {
+	view *models.PacketSignerView
+	err  error
+	user *models.User
+}
+
+func (m *mockPacketService) GetSignerView(_ context.Context, _ int64, user *models.User) (*models.PacketSignerView, error) {
+	m.user = user
+	return m.view, m.err
+}
+
+func serve(h *Handler, id string, user *types.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/packets/"+id, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if user != nil {
+		ctx = context.WithValue(ctx, shared.ContextKeyUser, user)
+	}
+	rec := httptest.NewRecorder()
+	h.HandleGetPacket(rec, req.WithContext(ctx))
+	return rec
+}
+
+func TestHandler_HandleGetPacket(t *testing.T) {
+	t.Parallel()
+
+	service := &mockPacketService{view: &models.PacketSignerView{
+		ID:    1,
+		Title: "Onboarding",
+		Documents: []*models.PacketSignerDocument{
+			{DocID: "doc-a", Title: "Code of conduct", Signed: true},
+			{DocID: "doc-b", Title: "IT charter"},
+		},
+		SignedCount: 1,
+		NextDocID:   "doc-b",
+	}}
+	h := NewHandler(service)
+
+	rec := serve(h, "1", &types.User{Email: "alice@example.com"})
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "alice@example.com", service.user.Email)
+
+	var wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, "doc-b", wrapper.Data["nextDocId"])
+	assert.Equal(t, float64(1), wrapper.Data["signedCount"])
+	assert.Len(t, wrapper.Data["documents"], 2)
+}
+
+func TestHandler_HandleGetPacket_Errors(t *testing.T) {
+	t.Parallel()
+
+	user := &types.User{Email: "alice@example.com"}
+
+	rec := serve(NewHandler(&mockPacketService{}), "1", nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(NewHandler(&mockPacketService{}), "abc", user)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(NewHandler(&mockPacketService{err: models.ErrPacketNotFound}), "1", user)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(NewHandler(&mockPacketService{err: errors.New("db down")}), "1", user)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
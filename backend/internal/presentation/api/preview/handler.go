@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package preview
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// previewService resolves preview tokens to the status of their document
+type previewService interface {
+	GetPreview(ctx context.Context, token string) (*models.DocumentPreview, error)
+}
+
+// Handler serves the read-only document status shared with reviewers who have no account
+type Handler struct {
+	service previewService
+}
+
+// NewHandler creates a new preview handler
+func NewHandler(service previewService) *Handler {
+	return &Handler{service: service}
+}
+
+// HandleGetPreview handles GET /api/v1/preview/{token}.
+// Unknown, revoked and expired tokens all answer 404, without telling them apart.
+func (h *Handler) HandleGetPreview(w http.ResponseWriter, r *http.Request) {
+	preview, err := h.service.GetPreview(r.Context(), chi.URLParam(r, "token"))
+	if errors.Is(err, models.ErrPreviewLinkNotFound) {
+		shared.WriteNotFound(w, "Preview link")
+		return
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to get document preview", "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	shared.WriteJSON(w, http.StatusOK, preview)
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockPreviewService struct {
+	preview *models.DocumentPreview
+	err     error
+	token   string
+}
+
+func (m *mockPreviewService) GetPreview(_ context.Context, token string) (*models.DocumentPreview, error) {
+	m.token = token
+	return m.preview, m.err
+}
+
+func serve(h *Handler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/preview/"+token, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("token", token)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+	h.HandleGetPreview(rec, req)
+	return rec
+}
+
+func TestHandler_HandleGetPreview(t *testing.T) {
+	t.Parallel()
+
+	service := &mockPreviewService{preview: &models.DocumentPreview{
+		DocID: "policy",
+		Title: "Privacy policy",
+		Stats: &models.DocCompletionStats{DocID: "policy", ExpectedCount: 2, SignedCount: 1, PendingCount: 1, CompletionRate: 50},
+	}}
+	h := NewHandler(service)
+
+	rec := serve(h, "secret-token")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "secret-token", service.token)
+	assert.Equal(t, "private, no-store", rec.Header().Get("Cache-Control"))
+
+	var wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, "Privacy policy", wrapper.Data["title"])
+	assert.NotContains(t, wrapper.Data, "signers", "signers are listed only when the link includes them")
+}
+
+func TestHandler_HandleGetPreview_Errors(t *testing.T) {
+	t.Parallel()
+
+	rec := serve(NewHandler(&mockPreviewService{err: models.ErrPreviewLinkNotFound}), "expired")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(NewHandler(&mockPreviewService{err: errors.New("db down")}), "token")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
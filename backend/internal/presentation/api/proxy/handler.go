@@ -114,7 +114,7 @@ func (h *Handler) HandleProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get client IP
-	clientIP := getClientIP(r)
+	clientIP := shared.RemoteIP(r)
 
 	// Check rate limit
 	rateLimitResult := h.rateLimiter.Check(clientIP, docID)
@@ -345,26 +345,6 @@ func getRequestID(ctx context.Context) string {
 	return ""
 }
 
-func getClientIP(r *http.Request) string {
-	// Check X-Forwarded-For header
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		ips := strings.Split(xff, ",")
-		return strings.TrimSpace(ips[0])
-	}
-
-	// Check X-Real-IP header
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
-	}
-
-	// Fall back to RemoteAddr
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr
-	}
-	return host
-}
-
 func (h *Handler) isValidURL(u *url.URL) bool {
 	// Must be HTTP or HTTPS
 	if u.Scheme != "http" && u.Scheme != "https" {
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package questions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// questionService manages the question threads of documents
+type questionService interface {
+	List(ctx context.Context, docID string, user *models.User) ([]*models.DocumentQuestion, error)
+	Ask(ctx context.Context, docID string, user *models.User, body string) (*models.DocumentQuestion, error)
+	Reply(ctx context.Context, docID string, questionID int64, user *models.User, body string) (*models.DocumentQuestionReply, error)
+	SetStatus(ctx context.Context, docID string, questionID int64, user *models.User, status models.QuestionStatus) error
+	SetHidden(ctx context.Context, docID string, questionID int64, user *models.User, hidden bool) error
+	SetReplyHidden(ctx context.Context, docID string, questionID, replyID int64, user *models.User, hidden bool) error
+}
+
+// Handler serves the questions signers ask about a document and the replies of its owner
+type Handler struct {
+	service questionService
+}
+
+// NewHandler creates a new questions handler
+func NewHandler(service questionService) *Handler {
+	return &Handler{service: service}
+}
+
+// MessageRequest is the text of a question or a reply
+type MessageRequest struct {
+	Body string `json:"body"`
+}
+
+// StatusRequest moves a question to another status
+type StatusRequest struct {
+	Status models.QuestionStatus `json:"status"`
+}
+
+// HiddenRequest hides a question or a reply from other signers, or shows it again
+type HiddenRequest struct {
+	Hidden bool `json:"hidden"`
+}
+
+// HandleList handles GET /api/v1/documents/{docId}/questions.
+// The document owner and admins see every question; signers see the questions not hidden by
+// moderation, and only their own email address.
+func (h *Handler) HandleList(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+
+	questions, err := h.service.List(r.Context(), docID, user)
+	if err != nil {
+		writeQuestionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, questions)
+}
+
+// HandleAsk handles POST /api/v1/documents/{docId}/questions
+func (h *Handler) HandleAsk(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	var req MessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	question, err := h.service.Ask(r.Context(), docID, user, req.Body)
+	if err != nil {
+		writeQuestionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, question)
+}
+
+// HandleReply handles POST /api/v1/documents/{docId}/questions/{id}/replies
+func (h *Handler) HandleReply(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	var req MessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	reply, err := h.service.Reply(r.Context(), docID, id, user, req.Body)
+	if err != nil {
+		writeQuestionError(w, err, docID)
+		return
+	}
+	shared.WriteJSON(w, http.StatusCreated, reply)
+}
+
+// HandleSetStatus handles PUT /api/v1/documents/{docId}/questions/{id}/status
+func (h *Handler) HandleSetStatus(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	var req StatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.SetStatus(r.Context(), docID, id, user, req.Status); err != nil {
+		writeQuestionError(w, err, docID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleSetHidden handles PUT /api/v1/documents/{docId}/questions/{id}/hidden
+func (h *Handler) HandleSetHidden(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	var req HiddenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.SetHidden(r.Context(), docID, id, user, req.Hidden); err != nil {
+		writeQuestionError(w, err, docID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleSetReplyHidden handles PUT /api/v1/documents/{docId}/questions/{id}/replies/{replyId}/hidden
+func (h *Handler) HandleSetReplyHidden(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+	id, ok := parseID(w, r, "id")
+	if !ok {
+		return
+	}
+	replyID, ok := parseID(w, r, "replyId")
+	if !ok {
+		return
+	}
+	docID := chi.URLParam(r, "docId")
+	var req HiddenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	if err := h.service.SetReplyHidden(r.Context(), docID, id, replyID, user, req.Hidden); err != nil {
+		writeQuestionError(w, err, docID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func requireUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "")
+		return nil, false
+	}
+	return user, true
+}
+
+func parseID(w http.ResponseWriter, r *http.Request, param string) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid question ID", nil)
+		return 0, false
+	}
+	return id, true
+}
+
+func writeQuestionError(w http.ResponseWriter, err error, docID string) {
+	switch {
+	case errors.Is(err, models.ErrDocumentNotFound):
+		shared.WriteNotFound(w, "Document")
+	case errors.Is(err, models.ErrQuestionNotFound):
+		shared.WriteNotFound(w, "Question")
+	case errors.Is(err, models.ErrInvalidQuestion), errors.Is(err, models.ErrInvalidQuestionStatus):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrQuestionForbidden):
+		shared.WriteForbidden(w, err.Error())
+	case errors.Is(err, models.ErrQuestionClosed):
+		shared.WriteConflict(w, err.Error())
+	default:
+		logger.Logger.Error("Failed to handle document question", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
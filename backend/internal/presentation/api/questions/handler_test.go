@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package questions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/types"
+)
+
+type mockQuestionService struct {
+	questions []*models.DocumentQuestion
+	err       error
+
+	body   string
+	status models.QuestionStatus
+	hidden bool
+	ids    []int64
+}
+
+func (m *mockQuestionService) List(_ context.Context, _ string, _ *models.User) ([]*models.DocumentQuestion, error) {
+	return m.questions, m.err
+}
+
+func (m *mockQuestionService) Ask(_ context.Context, docID string, user *models.User, body string) (*models.DocumentQuestion, error) {
+	m.body = body
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.DocumentQuestion{ID: 1, DocID: docID, AuthorEmail: user.Email, Body: body, Status: models.QuestionStatusOpen}, nil
+}
+
+func (m *mockQuestionService) Reply(_ context.Context, _ string, questionID int64, _ *models.User, body string) (*models.DocumentQuestionReply, error) {
+	m.body, m.ids = body, []int64{questionID}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.DocumentQuestionReply{ID: 2, QuestionID: questionID, Body: body}, nil
+}
+
+func (m *mockQuestionService) SetStatus(_ context.Context, _ string, questionID int64, _ *models.User, status models.QuestionStatus) error {
+	m.status, m.ids = status, []int64{questionID}
+	return m.err
+}
+
+func (m *mockQuestionService) SetHidden(_ context.Context, _ string, questionID int64, _ *models.User, hidden bool) error {
+	m.hidden, m.ids = hidden, []int64{questionID}
+	return m.err
+}
+
+func (m *mockQuestionService) SetReplyHidden(_ context.Context, _ string, questionID, replyID int64, _ *models.User, hidden bool) error {
+	m.hidden, m.ids = hidden, []int64{questionID, replyID}
+	return m.err
+}
+
+func serve(handler http.HandlerFunc, method, body string, params map[string]string, user *types.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/documents/doc-1/questions", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("docId", "doc-1")
+	for k, v := range params {
+		rctx.URLParams.Add(k, v)
+	}
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if user != nil {
+		ctx = context.WithValue(ctx, shared.ContextKeyUser, user)
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req.WithContext(ctx))
+	return rec
+}
+
+var alice = &types.User{Email: "alice@example.com", Name: "Alice"}
+
+func TestHandler_HandleList(t *testing.T) {
+	t.Parallel()
+
+	service := &mockQuestionService{questions: []*models.DocumentQuestion{
+		{ID: 1, DocID: "doc-1", Body: "Question?", Status: models.QuestionStatusAnswered,
+			Replies: []*models.DocumentQuestionReply{{ID: 2, QuestionID: 1, Body: "Answer.", FromOwner: true}}},
+	}}
+	h := NewHandler(service)
+
+	rec := serve(h.HandleList, http.MethodGet, "", nil, alice)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var wrapper struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	require.Len(t, wrapper.Data, 1)
+	assert.Equal(t, "answered", wrapper.Data[0]["status"])
+	assert.Len(t, wrapper.Data[0]["replies"], 1)
+
+	rec = serve(h.HandleList, http.MethodGet, "", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandler_HandleAskAndReply(t *testing.T) {
+	t.Parallel()
+
+	service := &mockQuestionService{}
+	h := NewHandler(service)
+
+	rec := serve(h.HandleAsk, http.MethodPost, `{"body":"Question?"}`, nil, alice)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "Question?", service.body)
+
+	rec = serve(h.HandleReply, http.MethodPost, `{"body":"Thanks"}`, map[string]string{"id": "7"}, alice)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, []int64{7}, service.ids)
+
+	rec = serve(h.HandleReply, http.MethodPost, `{"body":"Thanks"}`, map[string]string{"id": "x"}, alice)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(h.HandleAsk, http.MethodPost, `{`, nil, alice)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_HandleModeration(t *testing.T) {
+	t.Parallel()
+
+	service := &mockQuestionService{}
+	h := NewHandler(service)
+
+	rec := serve(h.HandleSetStatus, http.MethodPut, `{"status":"resolved"}`, map[string]string{"id": "3"}, alice)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, models.QuestionStatusResolved, service.status)
+
+	rec = serve(h.HandleSetHidden, http.MethodPut, `{"hidden":true}`, map[string]string{"id": "3"}, alice)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.True(t, service.hidden)
+
+	rec = serve(h.HandleSetReplyHidden, http.MethodPut, `{"hidden":false}`, map[string]string{"id": "3", "replyId": "4"}, alice)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.False(t, service.hidden)
+	assert.Equal(t, []int64{3, 4}, service.ids)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"document not found", models.ErrDocumentNotFound, http.StatusNotFound},
+		{"question not found", models.ErrQuestionNotFound, http.StatusNotFound},
+		{"invalid text", models.ErrInvalidQuestion, http.StatusBadRequest},
+		{"invalid status", models.ErrInvalidQuestionStatus, http.StatusBadRequest},
+		{"forbidden", models.ErrQuestionForbidden, http.StatusForbidden},
+		{"closed", models.ErrQuestionClosed, http.StatusConflict},
+		{"internal", errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&mockQuestionService{err: tt.err})
+			rec := serve(h.HandleReply, http.MethodPost, `{"body":"Hello"}`, map[string]string{"id": "1"}, alice)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
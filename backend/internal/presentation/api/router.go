@@ -5,8 +5,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -14,21 +16,42 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
 	apiAdmin "github.com/btouchard/ackify-ce/backend/internal/presentation/api/admin"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/announcements"
 	apiAuth "github.com/btouchard/ackify-ce/backend/internal/presentation/api/auth"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/branding"
 	apiConfig "github.com/btouchard/ackify-ce/backend/internal/presentation/api/config"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/csp"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/documents"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/extensions"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/federation"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/feed"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/gitsources"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/health"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/integrations"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/limits"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/linknotifications"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/merkle"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/packets"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/preview"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/proxy"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/questions"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/signatures"
 	apiStorage "github.com/btouchard/ackify-ce/backend/internal/presentation/api/storage"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/users"
+	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 	"github.com/btouchard/ackify-ce/backend/pkg/storage"
 )
 
+// statusCacheTTL bounds how long public document status responses are served from memory.
+// Signatures invalidate the cache immediately; other changes appear within this delay.
+const statusCacheTTL = 30 * time.Second
+
 // magicLinkService defines magic link authentication operations
 type magicLinkService interface {
 	RequestMagicLink(ctx context.Context, email, redirectTo, ip, userAgent, locale string) error
@@ -43,6 +66,27 @@ type signatureService interface {
 	GetSignatureStatus(ctx context.Context, docID string, user *models.User) (*models.SignatureStatus, error)
 	GetSignatureByDocAndUser(ctx context.Context, docID string, user *models.User) (*models.Signature, error)
 	GetUserSignatures(ctx context.Context, user *models.User) ([]*models.Signature, error)
+	ListUserSignaturesPage(ctx context.Context, user *models.User, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error)
+	ListDocumentSignaturesPage(ctx context.Context, docID string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error)
+}
+
+// sessionCleaner purges expired sessions on demand and reports the rows purged
+type sessionCleaner interface {
+	RunCleanup(ctx context.Context, forced bool) (*models.SessionCleanupRun, error)
+	Stats() models.SessionCleanupStats
+}
+
+// signatureNonceService issues the single-use nonces of signature requests and reports rejected replays
+type signatureNonceService interface {
+	Issue(ctx context.Context, docID string, user *models.User) (*models.SignatureNonce, error)
+	Consume(ctx context.Context, nonce, docID string, user *models.User) error
+	Stats() models.ReplayProtectionStats
+}
+
+// signatureIdempotencyService reserves the Idempotency-Key of signature requests and stores their response
+type signatureIdempotencyService interface {
+	Begin(ctx context.Context, key string, user *models.User, body []byte) (*models.SignatureIdempotencyKey, error)
+	Complete(ctx context.Context, key string, user *models.User, statusCode int, response []byte) error
 }
 
 // documentService defines document operations
@@ -53,7 +97,9 @@ type documentService interface {
 	List(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	Search(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
 	Count(ctx context.Context, searchQuery string) (int, error)
+	ListPage(ctx context.Context, searchQuery string, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Document], error)
 	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	ResolveAlias(ctx context.Context, alias string) (string, error)
 	GetExpectedSignerStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
 	ListExpectedSigners(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
 	ListByCreatedBy(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
@@ -63,9 +109,10 @@ type documentService interface {
 
 // reminderService defines reminder operations
 type reminderService interface {
-	SendReminders(ctx context.Context, docID, sentBy string, specificEmails []string, docURL, locale string) (*models.ReminderSendResult, error)
+	SendRemindersWithLevel(ctx context.Context, docID, sentBy string, specificEmails []string, docURL, locale string, level models.ReminderLevel) (*models.ReminderSendResult, error)
 	GetReminderHistory(ctx context.Context, docID string) ([]*models.ReminderLog, error)
 	GetReminderStats(ctx context.Context, docID string) (*models.ReminderStats, error)
+	SendDigests(ctx context.Context, emails []string, sentBy, locale string) (*models.ReminderDigestResult, error)
 }
 
 // webhookPublisher defines webhook publish operations
@@ -79,8 +126,13 @@ type adminService interface {
 	ListDocuments(ctx context.Context, limit, offset int) ([]*models.Document, error)
 	SearchDocuments(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
 	CountDocuments(ctx context.Context, searchQuery string) (int, error)
+	ListDocumentsByCreator(ctx context.Context, createdBy string, limit, offset int) ([]*models.Document, error)
+	SearchDocumentsByCreator(ctx context.Context, createdBy, query string, limit, offset int) ([]*models.Document, error)
+	CountDocumentsByCreator(ctx context.Context, createdBy, searchQuery string) (int, error)
 	UpdateDocumentMetadata(ctx context.Context, docID string, input models.DocumentInput, updatedBy string) (*models.Document, error)
+	PatchDocumentMetadata(ctx context.Context, docID string, patch models.DocumentPatch, expectedVersion *int) (*models.Document, error)
 	DeleteDocument(ctx context.Context, docID string) error
+	GetDocumentStatus(ctx context.Context, docID string) (*models.DocumentStatus, error)
 	ListExpectedSigners(ctx context.Context, docID string) ([]*models.ExpectedSigner, error)
 	ListExpectedSignersWithStatus(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
 	AddExpectedSigners(ctx context.Context, docID string, contacts []models.ContactInfo, addedBy string) error
@@ -99,14 +151,501 @@ type webhookService interface {
 	ListDeliveries(ctx context.Context, webhookID int64, limit, offset int) ([]*models.WebhookDelivery, error)
 }
 
+// campaignService defines recurring campaign management operations
+type campaignService interface {
+	CreateCampaign(ctx context.Context, input models.CampaignInput) (*models.Campaign, error)
+	UpdateCampaign(ctx context.Context, id int64, input models.CampaignInput) (*models.Campaign, error)
+	DeleteCampaign(ctx context.Context, id int64) error
+	GetCampaignByID(ctx context.Context, id int64) (*models.Campaign, error)
+	ListCampaigns(ctx context.Context, limit, offset int) ([]*models.Campaign, error)
+	ListCampaignRuns(ctx context.Context, id int64, limit, offset int) ([]*models.CampaignRun, error)
+	TriggerCampaign(ctx context.Context, id int64, triggeredBy, locale string) (*models.CampaignRun, error)
+}
+
+// retentionService defines retention policy and document archive operations
+type retentionService interface {
+	GetPolicy(ctx context.Context) (*models.RetentionPolicy, error)
+	UpdatePolicy(ctx context.Context, input models.RetentionPolicyInput, updatedBy string) (*models.RetentionPolicy, error)
+	ListArchives(ctx context.Context, limit, offset int) ([]*models.DocumentArchive, error)
+	CountArchives(ctx context.Context) (int, error)
+	GetArchive(ctx context.Context, id int64) (*models.DocumentArchive, error)
+	OpenArchive(ctx context.Context, id int64) (*models.DocumentArchive, io.ReadCloser, error)
+	ArchiveDocument(ctx context.Context, docID, archivedBy string, purgePII bool) (*models.DocumentArchive, error)
+	RestoreArchive(ctx context.Context, id int64, restoredBy string) (*models.DocumentArchive, error)
+}
+
+// completionService defines completion notification operations
+type completionService interface {
+	GetSettings(ctx context.Context, docID string) (*models.CompletionSettings, error)
+	UpdateSettings(ctx context.Context, docID string, input models.CompletionSettingsInput, updatedBy string) (*models.CompletionSettings, error)
+	OnSignature(ctx context.Context, docID string) error
+}
+
+// historyService defines document completion history operations
+type historyService interface {
+	GetHistory(ctx context.Context, docID string, days int) ([]*models.CompletionSnapshot, error)
+}
+
+// reportService defines compliance report operations
+type reportService interface {
+	Request(ctx context.Context, period string, requestedBy string, refresh bool) (*models.ComplianceReport, error)
+	Get(ctx context.Context, id int64) (*models.ComplianceReport, error)
+	Render(report *models.ComplianceReport, format string) ([]byte, error)
+}
+
+// signerTimelineService defines the per-signer event history of a document
+type signerTimelineService interface {
+	GetTimeline(ctx context.Context, docID, email string) (*models.SignerTimeline, error)
+}
+
+// shortLinkService defines the short links and QR codes of documents
+type shortLinkService interface {
+	GetOrCreate(ctx context.Context, docID, locale, createdBy string) (*models.ShortLink, error)
+	List(ctx context.Context, docID string) ([]*models.ShortLink, error)
+	Delete(ctx context.Context, docID, code string) error
+	QRCode(ctx context.Context, docID, locale, format, createdBy string) ([]byte, string, error)
+}
+
+// documentAliasService defines the alternative IDs of documents
+type documentAliasService interface {
+	ListAliases(ctx context.Context, docID string) ([]*models.DocumentAlias, error)
+	AddAlias(ctx context.Context, docID, alias, createdBy string) (*models.DocumentAlias, error)
+	RemoveAlias(ctx context.Context, docID, alias string) error
+}
+
+// signerEmailPolicy defines the check of expected signer emails against the tenant policy
+type signerEmailPolicy interface {
+	CheckEmails(ctx context.Context, emails []string) []models.SignerEmailRejection
+}
+
+// checksumInspector defines the server-side checksum computation of document URLs
+type checksumInspector interface {
+	InspectURL(ctx context.Context, url string) (*checksum.Inspection, error)
+}
+
+// signerGroupService defines signer group and directory sync operations
+type signerGroupService interface {
+	Providers() []string
+	CreateGroup(ctx context.Context, input models.SignerGroupInput) (*models.SignerGroup, error)
+	UpdateGroup(ctx context.Context, id int64, input models.SignerGroupInput) (*models.SignerGroup, error)
+	DeleteGroup(ctx context.Context, id int64) error
+	GetGroup(ctx context.Context, id int64) (*models.SignerGroup, error)
+	ListGroups(ctx context.Context) ([]*models.SignerGroup, error)
+	ListMembers(ctx context.Context, id int64) ([]models.SignerGroupMember, error)
+	AddMembers(ctx context.Context, id int64, members []models.SignerGroupMember) error
+	RemoveMember(ctx context.Context, id int64, email string) error
+	SyncGroup(ctx context.Context, id int64, dryRun bool) (*models.DirectorySyncResult, error)
+	AddToDocument(ctx context.Context, docID string, id int64, addedBy string) (int, error)
+}
+
+// announcementService defines the management and publication of announcement banners
+type announcementService interface {
+	CreateAnnouncement(ctx context.Context, input models.AnnouncementInput) (*models.Announcement, error)
+	UpdateAnnouncement(ctx context.Context, id int64, input models.AnnouncementInput) (*models.Announcement, error)
+	DeleteAnnouncement(ctx context.Context, id int64) error
+	GetAnnouncement(ctx context.Context, id int64) (*models.Announcement, error)
+	ListAnnouncements(ctx context.Context) ([]*models.Announcement, error)
+	ListActive(ctx context.Context, authenticated, admin bool) ([]*models.Announcement, error)
+}
+
+// consentService defines the consent catalog and the consent text shown to signers
+type consentService interface {
+	ListCatalog(ctx context.Context) ([]*models.ConsentText, error)
+	PublishVersion(ctx context.Context, input models.ConsentVersionInput) ([]*models.ConsentText, error)
+	GetDocumentConsent(ctx context.Context, docID string) (*models.DocumentConsent, error)
+	SetDocumentVersion(ctx context.Context, docID string, version *int, setBy string) (*models.DocumentConsent, error)
+	Resolve(ctx context.Context, docID, locale string) (*models.ConsentText, error)
+}
+
+// emailSuppressionService defines management of the addresses no email is sent to
+type emailSuppressionService interface {
+	ListSuppressions(ctx context.Context, search string) ([]*models.EmailSuppression, error)
+	AddSuppression(ctx context.Context, entry models.EmailSuppression) (*models.EmailSuppression, error)
+	RemoveSuppression(ctx context.Context, email string) error
+}
+
+// gitImportService defines Git source management, import and push webhook operations
+type gitImportService interface {
+	CreateSource(ctx context.Context, input models.GitSourceInput) (*models.GitSource, error)
+	UpdateSource(ctx context.Context, id int64, input models.GitSourceInput) (*models.GitSource, error)
+	DeleteSource(ctx context.Context, id int64) error
+	GetSource(ctx context.Context, id int64) (*models.GitSource, error)
+	ListSources(ctx context.Context) ([]*models.GitSource, error)
+	ListDocuments(ctx context.Context, id int64) ([]*models.GitSourceDocument, error)
+	SyncSource(ctx context.Context, id int64) (*models.GitSyncResult, error)
+	HandlePush(ctx context.Context, id int64, push services.GitPush) (bool, error)
+}
+
+// linkResolverService defines Confluence, SharePoint and Google Drive link resolution operations
+type linkResolverService interface {
+	Preview(ctx context.Context, rawURL string) (*models.LinkMetadata, error)
+	GetSource(ctx context.Context, docID string) (*models.DocumentLinkSource, error)
+	Refresh(ctx context.Context, docID string) (*models.DocumentLinkSource, error)
+	HandleNotification(ctx context.Context, provider, subscriptionID, clientState string) error
+}
+
+// exportService defines signed export bundle operations
+type exportService interface {
+	ExportDocumentSignatures(ctx context.Context, docID string) (*crypto.ExportBundle, error)
+	VerifyBundle(data []byte) (*crypto.BundleVerification, error)
+}
+
+// notificationService defines notification inbox operations
+type notificationService interface {
+	List(ctx context.Context, recipient string, unreadOnly bool, limit, offset int) ([]*models.Notification, int, error)
+	UnreadCount(ctx context.Context, recipient string) (int, error)
+	MarkRead(ctx context.Context, recipient string, id int64) error
+	MarkAllRead(ctx context.Context, recipient string) (int64, error)
+	Subscribe(recipient string) (<-chan services.NotificationEvent, func())
+	Done() <-chan struct{}
+	OnSignature(ctx context.Context, docID, signerEmail, signerName string) error
+}
+
+// roleService defines delegated admin role management operations
+type roleService interface {
+	ListRoles(ctx context.Context) ([]*models.AdminRoleAssignment, error)
+	AssignRole(ctx context.Context, email string, role models.AdminRole, departmentID *int64, grantedBy string) (*models.AdminRoleAssignment, error)
+	RevokeRole(ctx context.Context, email, revokedBy string) error
+}
+
+// rateLimitService lists the requests rejected by rate limits
+type rateLimitService interface {
+	ListViolations(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitViolation, error)
+	ListOffenders(ctx context.Context, period time.Duration, limit int) ([]*models.RateLimitOffender, error)
+	Quotas(ctx context.Context, userEmail, ip string) ([]*models.RateLimitQuota, error)
+}
+
+// securityAlertService tracks failed authentications and lists the security events they raise
+type securityAlertService interface {
+	RecordFailure(ctx context.Context, kind, userEmail, ip string)
+	ListEvents(ctx context.Context, period time.Duration, unacknowledgedOnly bool, limit int) ([]*models.SecurityEvent, error)
+	FailureCounts(ctx context.Context, period time.Duration) ([]models.AuthFailureCount, error)
+	Acknowledge(ctx context.Context, id int64, acknowledgedBy string) (*models.SecurityEvent, error)
+}
+
+// cspReportService collects the Content-Security-Policy violations reported by browsers
+type cspReportService interface {
+	Record(report models.CSPViolation)
+	List(limit int) []*models.CSPViolation
+}
+
+// merkleService serves Merkle roots over signatures and inclusion proofs
+type merkleService interface {
+	ListRoots(ctx context.Context, limit int) ([]*models.MerkleRoot, error)
+	GetProof(ctx context.Context, signatureID int64) (*models.MerkleProof, error)
+}
+
+// publicFeedService builds the public feed of the documents
+type publicFeedService interface {
+	Feed(ctx context.Context) (*models.PublicFeed, error)
+}
+
+// emailMatchingService defines email alias and matching rule management operations
+type emailMatchingService interface {
+	ListAliases(ctx context.Context) ([]*models.EmailAlias, error)
+	AddAlias(ctx context.Context, email, alias, createdBy string) (*models.EmailAlias, error)
+	RemoveAlias(ctx context.Context, id int64, removedBy string) error
+	GetRules(ctx context.Context) (*models.EmailMatchingRules, error)
+	UpdateRules(ctx context.Context, rules models.EmailMatchingRules, updatedBy string) (*models.EmailMatchingRules, error)
+}
+
 // configService defines configuration management operations
 type configService interface {
 	GetConfig() *models.MutableConfig
 	UpdateSection(ctx context.Context, category models.ConfigCategory, input json.RawMessage, updatedBy string) error
-	TestSMTP(ctx context.Context, cfg models.SMTPConfig) error
+	PreviewSection(category models.ConfigCategory, input json.RawMessage) (*models.ConfigPreview, error)
+	StageSection(ctx context.Context, category models.ConfigCategory, input json.RawMessage, stagedBy string) (*models.PendingConfig, error)
+	PendingChanges(ctx context.Context) (*models.PendingConfig, error)
+	ApplyPending(ctx context.Context, appliedBy string) ([]models.ConfigChange, error)
+	DiscardPending(ctx context.Context, category models.ConfigCategory) error
+	TestSMTP(ctx context.Context, cfg models.SMTPConfig) (string, error)
 	TestS3(ctx context.Context, cfg models.StorageConfig) error
 	TestOIDC(ctx context.Context, cfg models.OIDCConfig) error
 	ResetFromENV(ctx context.Context, updatedBy string) error
+	ExportBundle(passphrase string) (*models.ConfigBundle, error)
+	ImportBundle(ctx context.Context, bundle *models.ConfigBundle, passphrase, updatedBy string) error
+	CheckSecrets(ctx context.Context) (*models.ConfigSecretsStatus, error)
+	RotateSecrets(ctx context.Context, updatedBy string) (*models.ConfigSecretsStatus, error)
+}
+
+// apiKeyService defines integration API key operations
+type apiKeyService interface {
+	CreateKey(ctx context.Context, name string, scopes []models.Permission, createdBy string) (*models.APIKey, string, error)
+	Authenticate(ctx context.Context, secret string) (*models.APIKey, error)
+	ListKeys(ctx context.Context) ([]*models.APIKey, error)
+	RevokeKey(ctx context.Context, id int64, revokedBy string) error
+}
+
+// integrationService defines delegated document operations for external systems
+type integrationService interface {
+	CreateDocument(ctx context.Context, req services.IntegrationDocumentRequest, key *models.APIKey) (*services.IntegrationDocumentResult, error)
+	GetDocumentStatus(ctx context.Context, docID string) (*services.IntegrationDocumentResult, error)
+}
+
+// searchService defines full-text search operations
+type searchService interface {
+	Search(ctx context.Context, query, tag string, limit int) ([]*models.SearchResult, error)
+}
+
+// signingOrderService notifies the next signers of sequential workflows
+type signingOrderService interface {
+	OnSignature(ctx context.Context, docID, signerEmail, locale string) error
+}
+
+// quizService manages document comprehension quizzes
+type quizService interface {
+	GetQuiz(ctx context.Context, docID string) (*models.DocumentQuiz, error)
+	GetSignerQuiz(ctx context.Context, docID string) (*models.SignerQuiz, error)
+	UpdateQuiz(ctx context.Context, docID string, input models.DocumentQuizInput, updatedBy string) (*models.DocumentQuiz, error)
+	DeleteQuiz(ctx context.Context, docID string) error
+	GetStats(ctx context.Context, docID string) (*models.QuizStats, error)
+}
+
+// signatureHookService manages and evaluates the validation hooks called before signing
+type signatureHookService interface {
+	GetHook(ctx context.Context, docID string) (*models.SignatureHook, error)
+	SetHook(ctx context.Context, docID string, input models.SignatureHookInput, updatedBy string) (*models.SignatureHook, error)
+	DeleteHook(ctx context.Context, docID string) error
+}
+
+// signRedirectService manages the URLs signers are sent to after signing
+type signRedirectService interface {
+	GetRedirect(ctx context.Context, docID string) (*models.SignRedirect, error)
+	SetRedirect(ctx context.Context, docID, url, updatedBy string) (*models.SignRedirect, error)
+	DeleteRedirect(ctx context.Context, docID string) error
+	ResolveRedirect(ctx context.Context, docID string) (string, error)
+}
+
+// packetService manages document packets and serves them to their signers
+type packetService interface {
+	CreatePacket(ctx context.Context, input models.DocumentPacketInput, createdBy string) (*models.DocumentPacket, error)
+	UpdatePacket(ctx context.Context, id int64, input models.DocumentPacketInput) (*models.DocumentPacket, error)
+	DeletePacket(ctx context.Context, id int64) error
+	GetPacket(ctx context.Context, id int64) (*models.DocumentPacket, error)
+	ListPackets(ctx context.Context) ([]*models.DocumentPacket, error)
+	GetStats(ctx context.Context, id int64) (*models.PacketStats, error)
+	SendReminders(ctx context.Context, id int64, sentBy, locale string) (*models.PacketReminderResult, error)
+	GetSignerView(ctx context.Context, id int64, user *models.User) (*models.PacketSignerView, error)
+}
+
+// directoryCredentialService manages the delegated access to the directories signer groups are synced from
+type directoryCredentialService interface {
+	List(ctx context.Context) ([]*models.DirectoryCredential, error)
+	StartAuthorization(ctx context.Context, provider string) (string, error)
+	CompleteAuthorization(ctx context.Context, state, code, connectedBy string) (*models.DirectoryCredential, error)
+	Refresh(ctx context.Context, provider string) (*models.DirectoryCredential, error)
+	Disconnect(ctx context.Context, provider string) error
+}
+
+// documentQuestionService manages the question threads between signers and document owners
+type documentQuestionService interface {
+	List(ctx context.Context, docID string, user *models.User) ([]*models.DocumentQuestion, error)
+	Ask(ctx context.Context, docID string, user *models.User, body string) (*models.DocumentQuestion, error)
+	Reply(ctx context.Context, docID string, questionID int64, user *models.User, body string) (*models.DocumentQuestionReply, error)
+	SetStatus(ctx context.Context, docID string, questionID int64, user *models.User, status models.QuestionStatus) error
+	SetHidden(ctx context.Context, docID string, questionID int64, user *models.User, hidden bool) error
+	SetReplyHidden(ctx context.Context, docID string, questionID, replyID int64, user *models.User, hidden bool) error
+}
+
+// deadlineExtensionService manages the deadline extensions signers request and owners decide
+type deadlineExtensionService interface {
+	List(ctx context.Context, docID string, user *models.User) (*models.DeadlineExtensionView, error)
+	Request(ctx context.Context, docID string, user *models.User, deadline time.Time, reason string) (*models.DeadlineExtension, error)
+	Approve(ctx context.Context, docID string, id int64, user *models.User, deadline *time.Time, note string) (*models.DeadlineExtension, error)
+	Deny(ctx context.Context, docID string, id int64, user *models.User, note string) (*models.DeadlineExtension, error)
+}
+
+// jobService lists the background jobs of the shared queue and retries or cancels them
+type jobService interface {
+	List(ctx context.Context, filter models.JobFilter) ([]*models.Job, error)
+	Get(ctx context.Context, id int64) (*models.Job, error)
+	Counts(ctx context.Context) ([]models.JobCounts, error)
+	Retry(ctx context.Context, id int64) (*models.Job, error)
+	Cancel(ctx context.Context, id int64) (*models.Job, error)
+}
+
+// signingKeyService checks the signer key and exports encrypted backups of it
+type signingKeyService interface {
+	Status() *models.SigningKeyStatus
+	Check() *models.SigningKeyStatus
+	ExportBackup(passphrase string) (*crypto.KeyBackup, error)
+}
+
+// previewLinkService manages the read-only preview links of documents and resolves their tokens
+type previewLinkService interface {
+	CreateLink(ctx context.Context, docID string, input models.PreviewLinkInput, createdBy string) (*models.PreviewLink, error)
+	ListLinks(ctx context.Context, docID string) ([]*models.PreviewLink, error)
+	RevokeLink(ctx context.Context, docID string, id int64, revokedBy string) (*models.PreviewLink, error)
+	GetPreview(ctx context.Context, token string) (*models.DocumentPreview, error)
+}
+
+// externalSignerService verifies external signers and manages the per-document toggle
+type externalSignerService interface {
+	GetSettings(ctx context.Context, docID string) (*models.ExternalSigning, error)
+	SetEnabled(ctx context.Context, docID string, enabled bool, updatedBy string) (*models.ExternalSigning, error)
+	RequestCode(ctx context.Context, docID, email, ip, locale string) error
+	VerifyCode(ctx context.Context, docID, email, code string) (*models.User, error)
+	ConsumeCode(ctx context.Context, docID, email string) error
+}
+
+// sessionManager lists and revokes server-side user sessions
+type sessionManager interface {
+	CurrentSessionID(r *http.Request) string
+	ListSessions(ctx context.Context, email, currentSessionID string) ([]*models.UserSession, error)
+	RevokeSession(ctx context.Context, email string, id int64) error
+	RevokeSessions(ctx context.Context, email, exceptSessionID string) (int64, error)
+}
+
+// sessionPeeker reads the session expiry reported by the auth check
+type sessionPeeker interface {
+	PeekUser(r *http.Request) (*models.User, *models.SessionExpiry, error)
+}
+
+// cacheStatsProvider reports the activity of an in-memory cache
+type cacheStatsProvider interface {
+	Stats() models.CacheStats
+}
+
+// signerStatusNotifier reports the documents whose signatures changed on any instance
+type signerStatusNotifier interface {
+	SetChangeHook(fn func(docID string))
+}
+
+// mailHealthProvider reports the health of the SMTP servers mail fails over between
+type mailHealthProvider interface {
+	Health() []models.SMTPServerHealth
+}
+
+// mailDeliverabilityService reports the outcome of the sends to the SMTP servers per recipient domain
+type mailDeliverabilityService interface {
+	ListDomains(ctx context.Context, period time.Duration, limit int) ([]*models.EmailDomainDeliverability, error)
+	ListCodes(ctx context.Context, period time.Duration) ([]*models.EmailResponseCodeCount, error)
+	ListFailures(ctx context.Context, period time.Duration, limit int) ([]*models.EmailDelivery, error)
+}
+
+// readReplica serves heavy admin reads while it is healthy
+type readReplica interface {
+	DB() *sql.DB
+	Status() models.ReplicaStatus
+}
+
+// ledgerService reports the streaming of signature hashes to the external ledger
+type ledgerService interface {
+	Lag(ctx context.Context) (*models.LedgerLag, error)
+}
+
+// eventOutboxService records signature events in the transactional outbox and reports its relay
+type eventOutboxService interface {
+	Publish(ctx context.Context, eventType string, payload map[string]interface{}) error
+	Lag(ctx context.Context) (*models.OutboxLag, error)
+	RetryDead(ctx context.Context) (int, error)
+}
+
+// federationHubService receives the pushes of the peers of a federation and manages the peers
+type federationHubService interface {
+	RegisterPeer(ctx context.Context, instanceID, name, publicKey, createdBy string) (*models.FederationPeer, error)
+	ListPeers(ctx context.Context) ([]*models.FederationPeer, error)
+	DeletePeer(ctx context.Context, id int64) error
+	ListDocuments(ctx context.Context, instanceID string) ([]*models.FederatedDocument, error)
+	ReceivePush(ctx context.Context, instanceID string, body []byte, signature string) (*models.FederationPushResult, error)
+}
+
+// federationClientService pushes the signatures of this instance to its federation hub
+type federationClientService interface {
+	PushPending(ctx context.Context) (int, error)
+	Status(ctx context.Context) (*models.FederationStatus, error)
+}
+
+// uploadQuarantineService checks uploads against the tenant policy and the virus scanner and
+// reviews the uploads it held back
+type uploadQuarantineService interface {
+	Policy() (int64, []string)
+	Inspect(ctx context.Context, r io.Reader, size int64, mimeType string) (models.QuarantineReason, string)
+	Quarantine(ctx context.Context, r io.Reader, upload *models.QuarantinedUpload) error
+	List(ctx context.Context, status string) ([]*models.QuarantinedUpload, error)
+	Release(ctx context.Context, id int64, reviewedBy string) (*models.QuarantinedUpload, error)
+	Discard(ctx context.Context, id int64, reviewedBy string) (*models.QuarantinedUpload, error)
+}
+
+// signatureApprovalService reviews the signatures held for approval
+type signatureApprovalService interface {
+	List(ctx context.Context, docID, status string) ([]*models.SignatureApproval, error)
+	Approve(ctx context.Context, id int64, reviewedBy string) (*models.SignatureApproval, error)
+	Reject(ctx context.Context, id int64, reviewedBy string) (*models.SignatureApproval, error)
+}
+
+// identityMergeService merges duplicate identities of a person
+type identityMergeService interface {
+	Merge(ctx context.Context, input models.IdentityMergeInput, mergedBy string) (*models.IdentityMerge, error)
+	ListMerges(ctx context.Context, limit, offset int) ([]*models.IdentityMerge, error)
+	ResolveIdentity(ctx context.Context, sub, email string) (string, string, error)
+}
+
+// signatureArchiveService builds the signature archive of all documents and serves it
+type signatureArchiveService interface {
+	Request(ctx context.Context, requestedBy string) (*models.Job, error)
+	Open(ctx context.Context, archiveID, expires, signature string) (io.ReadCloser, int64, error)
+}
+
+// accessResendService sends fresh magic links or codes to signers who lost access
+type accessResendService interface {
+	Resend(ctx context.Context, docID string, emails []string, method, sentBy, ip, locale string) (*models.AccessResendResult, error)
+}
+
+// brandingService defines branding and logo operations
+type brandingService interface {
+	GetBranding() *models.Branding
+	OpenLogo(ctx context.Context) (io.ReadCloser, int64, string, error)
+	UploadLogo(ctx context.Context, data []byte, contentType, updatedBy string) error
+	DeleteLogo(ctx context.Context, updatedBy string) error
+}
+
+// departmentService defines department, member and document assignment operations
+type departmentService interface {
+	ListDepartments(ctx context.Context) ([]*models.Department, error)
+	GetDepartment(ctx context.Context, id int64) (*models.Department, error)
+	CreateDepartment(ctx context.Context, input models.DepartmentInput) (*models.Department, error)
+	UpdateDepartment(ctx context.Context, id int64, input models.DepartmentInput) (*models.Department, error)
+	DeleteDepartment(ctx context.Context, id int64) error
+	ListMembers(ctx context.Context, id int64) ([]models.DepartmentMember, error)
+	AddMembers(ctx context.Context, id int64, members []models.DepartmentMember, addedBy string) error
+	RemoveMember(ctx context.Context, id int64, email string) error
+	SetDocumentDepartment(ctx context.Context, docID string, departmentID *int64) error
+	ListDocuments(ctx context.Context, id int64) ([]*models.DepartmentDocument, error)
+	Stats(ctx context.Context, id int64) (*models.DepartmentStats, error)
+	AddToDocument(ctx context.Context, docID string, id int64, addedBy string) (int, error)
+}
+
+// tagService defines document tag, tag policy and tag-filtered document list operations
+type tagService interface {
+	DocumentTags(ctx context.Context, docID string) ([]string, error)
+	SetDocumentTags(ctx context.Context, docID string, tags []string, updatedBy string) ([]string, error)
+	ListTags(ctx context.Context, query string, limit int) ([]models.TagCount, error)
+	RenameTag(ctx context.Context, from, to string) (int, error)
+	DeleteTag(ctx context.Context, tag string) (int, error)
+	Stats(ctx context.Context, tag string) (*models.TagStats, error)
+	ListDocuments(ctx context.Context, filter models.DocumentFilter, limit, offset int) ([]*models.Document, error)
+	CountDocuments(ctx context.Context, filter models.DocumentFilter) (int, error)
+	ListPolicies(ctx context.Context) ([]*models.TagPolicy, error)
+	SetPolicy(ctx context.Context, policy models.TagPolicy, updatedBy string) (*models.TagPolicy, error)
+	DeletePolicy(ctx context.Context, tag string) error
+}
+
+// userPreferenceService stores the preferred locale and the timezone of users
+type userPreferenceService interface {
+	GetPreferences(ctx context.Context, email string) (*models.UserPreferences, error)
+	UpdateLocale(ctx context.Context, email, locale string) (*models.UserPreferences, error)
+	UpdateTimezone(ctx context.Context, email, timezone string) (*models.UserPreferences, error)
+	DetectLocale(ctx context.Context, email, detected string) string
+	DefaultLocale() string
+}
+
+// certificateService issues the PDF certificates of signatures and verifies them
+type certificateService interface {
+	Issue(ctx context.Context, sig *models.Signature) error
+	URL(signatureID int64) string
+	Open(ctx context.Context, signatureID int64, email string) (io.ReadCloser, int64, error)
+	Verify(ctx context.Context, signatureID int64, recordHash string) (*models.CertificateVerification, error)
 }
 
 // RouterConfig holds configuration for the API router
@@ -121,17 +660,134 @@ type RouterConfig struct {
 	Authorizer   providers.Authorizer   // Required for authorization decisions
 
 	// Services
-	SignatureService signatureService
-	DocumentService  documentService
-	AdminService     adminService
-	ReminderService  reminderService
-	WebhookService   webhookService
-	WebhookPublisher webhookPublisher
-	ConfigService    configService
+	SignatureService    signatureService
+	DocumentService     documentService
+	AdminService        adminService
+	ReminderService     reminderService
+	WebhookService      webhookService
+	WebhookPublisher    webhookPublisher
+	ConfigService       configService
+	CampaignService     campaignService
+	RoleService         roleService
+	RetentionService    retentionService
+	CompletionService   completionService
+	HistoryService      historyService
+	SignerGroupService  signerGroupService
+	GitImportService    gitImportService
+	LinkResolverService linkResolverService
+	NotificationService notificationService
+	APIKeyService       apiKeyService
+	IntegrationService  integrationService
+	SearchService       searchService
+	SigningOrderService signingOrderService
+	QuizService         quizService
+	BrandingService     brandingService
+	// EmailMatchingService manages email aliases and matching rules
+	EmailMatchingService emailMatchingService
+	// RateLimitService exposes signature and magic link rate limit violations
+	RateLimitService rateLimitService
+	// SecurityAlertService is optional, it tracks failed OAuth callbacks, magic link
+	// verifications and CSRF checks and exposes the resulting security events
+	SecurityAlertService securityAlertService
+	// MerkleService publishes Merkle roots and signature inclusion proofs
+	MerkleService merkleService
+	// PublicFeedService is optional, set when the public feed of the documents is enabled
+	PublicFeedService publicFeedService
+	// CSPReportService collects Content-Security-Policy violation reports
+	CSPReportService cspReportService
+	// ExternalSignerService is optional, set when email is configured
+	ExternalSignerService externalSignerService
+	// SessionManager is optional, set when sessions are stored server-side
+	SessionManager sessionManager
+	// SessionPeeker is optional, set when sessions are stored server-side: the auth check then
+	// reports when the session expires
+	SessionPeeker sessionPeeker
+	// SignerStatusCache is optional, its metrics are exposed to admins
+	SignerStatusCache cacheStatsProvider
+	// SignerStatusNotifier is optional, it refreshes the status snapshots of hot documents
+	// when another instance records a signature
+	SignerStatusNotifier signerStatusNotifier
+	// NonceService rejects replayed signature requests
+	NonceService signatureNonceService
+	// SessionCleaner is optional, set when OAuth sessions are stored server-side
+	SessionCleaner sessionCleaner
+	// IdempotencyService replays the response of signature requests retried with an Idempotency-Key
+	IdempotencyService signatureIdempotencyService
+	// ExportService signs the manifests of signature exports
+	ExportService exportService
+	// DepartmentService manages the departments department-admins are scoped to
+	DepartmentService departmentService
+	// TagService manages document tags and the reminder and retention policies of tags
+	TagService tagService
+	// SignerEmailPolicy refuses the expected signers outside the domains allowed by the tenant
+	SignerEmailPolicy signerEmailPolicy
+	// UserPreferenceService stores the locale of users, detected at first login
+	UserPreferenceService userPreferenceService
+	// CertificateService is optional, set when object storage is configured
+	CertificateService certificateService
+	// SignerTimelineService gathers the events of one signer on one document
+	SignerTimelineService signerTimelineService
+	// ReportService generates cross-document compliance reports in the background
+	ReportService reportService
+	// ShortLinkService manages the /s/{code} links and QR codes printed on notices
+	ShortLinkService shortLinkService
+	// DocumentAliasService manages the alternative IDs redirected to documents
+	DocumentAliasService documentAliasService
+	// ChecksumInspector computes the checksums of document URLs to prefill their metadata
+	ChecksumInspector checksumInspector
+	// ReadReplica is optional, set when a read replica DSN is configured
+	ReadReplica readReplica
+	// MailServers is optional, set when mail is sent through SMTP
+	MailServers mailHealthProvider
+	// MailDeliverability is optional, set with MailServers to report the outcome of the sends
+	MailDeliverability mailDeliverabilityService
+	// LedgerService is optional, set when an external signature ledger is configured
+	LedgerService ledgerService
+	// EventOutbox is optional, set when signature events are relayed through the transactional outbox
+	EventOutbox eventOutboxService
+	// FederationHub is optional, set when this instance accepts pushes of federation peers
+	FederationHub federationHubService
+	// FederationClient is optional, set when this instance pushes to a federation hub
+	FederationClient federationClientService
+	// AnnouncementService manages the banners admins publish to users
+	AnnouncementService announcementService
+	// EmailSuppressionService manages the addresses no email is sent to
+	EmailSuppressionService emailSuppressionService
+	// ConsentService manages the versioned consent texts recorded with signatures
+	ConsentService consentService
+	// AccessResendService sends fresh magic links or codes to signers who lost access
+	AccessResendService accessResendService
+	// SignatureHookService manages the per-document HTTP hooks that can reject signatures
+	SignatureHookService signatureHookService
+	// SignRedirectService manages the per-document redirects after signing
+	SignRedirectService signRedirectService
+	// PacketService manages the packets of documents signed in one session
+	PacketService packetService
+	// DocumentQuestionService manages the questions signers ask document owners
+	DocumentQuestionService documentQuestionService
+	// DeadlineExtensionService manages the deadline extensions of signers
+	DeadlineExtensionService deadlineExtensionService
+	// PreviewLinkService manages the expiring status links shared with reviewers without an account
+	PreviewLinkService previewLinkService
+	// JobService exposes the background job queue to admins
+	JobService jobService
+	// SigningKeyService checks the signer key and exports encrypted backups of it
+	SigningKeyService signingKeyService
+	// DirectoryCredentialService keeps the refresh tokens of directories read with delegated access
+	DirectoryCredentialService directoryCredentialService
+	// IdentityMergeService merges duplicate identities and resolves the logins of merged ones
+	IdentityMergeService identityMergeService
+	// SignatureApprovalService reviews the signatures of unexpected signers held for approval
+	SignatureApprovals signatureApprovalService
 
 	// Storage
-	StorageProvider  storage.Provider // Optional, for document file storage
-	StorageMaxSizeMB int64            // Maximum upload size in MB
+	StorageProvider  storage.Provider   // Optional, for document file storage
+	StorageMaxSizeMB int64              // Maximum upload size in MB
+	StorageURLSigner *storage.URLSigner // Optional, issues expiring signed links to stored files
+	// UploadQuarantine is optional, set with the storage to enforce the tenant upload policy
+	UploadQuarantine uploadQuarantineService
+	// SignatureArchives is optional, set with the storage to export the signatures of all documents
+	SignatureArchives signatureArchiveService
 
 	// Configuration
 	BaseURL           string
@@ -139,6 +795,13 @@ type RouterConfig struct {
 	DocumentRateLimit int // Document creation rate limit (requests per minute), default: 10
 	GeneralRateLimit  int // General API rate limit (requests per minute), default: 100
 	ImportMaxSigners  int // Maximum signers per CSV import, default: 500
+
+	// StatusSnapshotDebounce enables status snapshots of hot documents, refreshed at most once
+	// per interval on signatures (0 disables)
+	StatusSnapshotDebounce time.Duration
+
+	// Draining reports whether the server is shutting down (optional, health returns 503 while true)
+	Draining func() bool
 }
 
 // NewRouter creates and configures the API v1 router
@@ -147,6 +810,12 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 
 	// Initialize middleware with providers
 	apiMiddleware := shared.NewMiddleware(cfg.AuthProvider, cfg.BaseURL, cfg.Authorizer)
+	if cfg.SecurityAlertService != nil {
+		apiMiddleware.SetFailureRecorder(cfg.SecurityAlertService)
+	}
+	if cfg.ConfigService != nil {
+		apiMiddleware.SetCORSConfigProvider(cfg.ConfigService)
+	}
 
 	// Rate limiters with configurable limits
 	authLimit := cfg.AuthRateLimit
@@ -162,14 +831,15 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		generalLimit = 100 // Default: 100 requests per minute general
 	}
 
-	authRateLimit := shared.NewRateLimit(authLimit, time.Minute)
-	documentRateLimit := shared.NewRateLimit(documentLimit, time.Minute)
+	authRateLimit := shared.NewRateLimit(authLimit, time.Minute).WithName("auth")
+	documentRateLimit := shared.NewRateLimit(documentLimit, time.Minute).WithName("documents")
 	generalRateLimit := shared.NewRateLimit(generalLimit, time.Minute)
 
 	// Global middleware
 	r.Use(middleware.RequestID)
 	r.Use(shared.AddRequestIDToContext)
-	r.Use(middleware.RealIP)
+	r.Use(shared.ProblemDetails)
+	// RemoteAddr already holds the client address resolved by shared.RealIP in the server
 	r.Use(shared.RequestLogger)
 	r.Use(middleware.Recoverer)
 	r.Use(shared.SecurityHeaders)
@@ -185,16 +855,86 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 
 	// Initialize handlers
 	healthHandler := health.NewHandler()
+	if cfg.Draining != nil {
+		healthHandler.SetDraining(cfg.Draining)
+	}
 	configHandler := apiConfig.NewHandler(cfg.ConfigService)
 	authHandler := apiAuth.NewHandler(cfg.AuthProvider, apiMiddleware, cfg.BaseURL)
+	if cfg.UserPreferenceService != nil {
+		authHandler.SetLocaleDetector(cfg.UserPreferenceService)
+	}
+	if cfg.SecurityAlertService != nil {
+		authHandler.SetFailureRecorder(cfg.SecurityAlertService)
+	}
+	if cfg.SessionPeeker != nil {
+		authHandler.SetSessionPeeker(cfg.SessionPeeker)
+	}
+	if cfg.IdentityMergeService != nil {
+		authHandler.SetIdentityResolver(cfg.IdentityMergeService)
+	}
 	usersHandler := users.NewHandler(cfg.Authorizer)
+	if cfg.SessionManager != nil {
+		usersHandler.SetSessionManager(cfg.SessionManager)
+	}
+	if cfg.UserPreferenceService != nil {
+		usersHandler.SetPreferenceService(cfg.UserPreferenceService, strings.HasPrefix(cfg.BaseURL, "https://"))
+	}
+	statusCache := shared.NewStatusCache(statusCacheTTL)
 	documentsHandler := documents.NewHandler(
 		cfg.SignatureService,
 		cfg.DocumentService,
 		cfg.WebhookPublisher,
 		cfg.Authorizer,
-	).WithAdminService(cfg.AdminService, cfg.BaseURL)
+	).WithAdminService(cfg.AdminService, cfg.BaseURL).WithStatusCache(statusCache)
+	if cfg.StatusSnapshotDebounce > 0 && cfg.DB != nil && cfg.TenantProvider != nil {
+		statusCache.EnableSnapshots(func(ctx context.Context, docID string) (*shared.CachedStatus, error) {
+			var status *shared.CachedStatus
+			err := tenant.WithTenantContextFromProvider(ctx, cfg.DB, cfg.TenantProvider, func(txCtx context.Context) error {
+				var err error
+				status, err = documentsHandler.LoadStatus(txCtx, docID)
+				return err
+			})
+			return status, err
+		}, cfg.StatusSnapshotDebounce)
+		if cfg.SignerStatusNotifier != nil {
+			cfg.SignerStatusNotifier.SetChangeHook(statusCache.Invalidate)
+		}
+	}
 	signaturesHandler := signatures.NewHandler(cfg.SignatureService, cfg.AdminService, cfg.WebhookPublisher)
+	signaturesHandler.SetStatusCache(statusCache)
+	if cfg.EventOutbox != nil {
+		signaturesHandler.SetEventOutbox(cfg.EventOutbox)
+	}
+	if cfg.CompletionService != nil {
+		signaturesHandler.SetCompletionNotifier(cfg.CompletionService)
+	}
+	if cfg.NotificationService != nil {
+		signaturesHandler.SetNotificationInbox(cfg.NotificationService)
+	}
+	if cfg.SigningOrderService != nil {
+		signaturesHandler.SetSigningOrderNotifier(cfg.SigningOrderService)
+	}
+	if cfg.QuizService != nil {
+		signaturesHandler.SetQuizReader(cfg.QuizService)
+	}
+	if cfg.ExternalSignerService != nil {
+		signaturesHandler.SetExternalSigner(cfg.ExternalSignerService)
+	}
+	if cfg.ConsentService != nil {
+		signaturesHandler.SetConsentReader(cfg.ConsentService)
+	}
+	if cfg.SignRedirectService != nil {
+		signaturesHandler.SetSignRedirects(cfg.SignRedirectService)
+	}
+	if cfg.IdempotencyService != nil {
+		signaturesHandler.SetIdempotencyStore(cfg.IdempotencyService)
+	}
+	if cfg.NonceService != nil {
+		signaturesHandler.SetNonceStore(cfg.NonceService)
+	}
+	if cfg.CertificateService != nil {
+		signaturesHandler.SetCertificateIssuer(cfg.CertificateService)
+	}
 	proxyHandler := proxy.NewHandler(cfg.DocumentService)
 
 	// Storage handler (optional - only if storage is configured)
@@ -203,15 +943,94 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		maxSizeMB = 50 // Default: 50 MB
 	}
 	storageHandler := apiStorage.NewHandler(cfg.StorageProvider, cfg.DocumentService, maxSizeMB)
+	if cfg.StorageURLSigner != nil {
+		storageHandler.SetURLVerifier(cfg.StorageURLSigner)
+		documentsHandler.WithURLSigner(cfg.StorageURLSigner)
+	}
+	if cfg.UploadQuarantine != nil {
+		storageHandler.SetQuarantine(cfg.UploadQuarantine)
+	}
+	if cfg.SignatureArchives != nil {
+		storageHandler.SetSignatureArchives(cfg.SignatureArchives)
+	}
 
 	// Public routes
 	r.Group(func(r chi.Router) {
 		// Health check
 		r.Get("/health", healthHandler.HandleHealth)
 
+		// Request quotas of the caller, so that integrators can self-throttle
+		limitsHandler := limits.NewHandler(generalRateLimit, authRateLimit, documentRateLimit)
+		if cfg.RateLimitService != nil {
+			limitsHandler.SetActionQuotas(cfg.RateLimitService)
+		}
+		r.With(apiMiddleware.OptionalAuth).Get("/limits", limitsHandler.HandleGetLimits)
+
 		// Public configuration (smtpEnabled, storageEnabled, auth methods)
 		r.Get("/config", configHandler.HandleGetConfig)
 
+		// Organization branding read by the SPA at boot and by email clients
+		if cfg.BrandingService != nil {
+			brandingHandler := branding.NewHandler(cfg.BrandingService)
+			r.Get("/branding", brandingHandler.HandleGetBranding)
+			r.Get("/branding/logo", brandingHandler.HandleGetLogo)
+		}
+
+		// Merkle roots over signatures and inclusion proofs, verifiable by third parties
+		if cfg.MerkleService != nil {
+			merkleHandler := merkle.NewHandler(cfg.MerkleService)
+			r.Get("/merkle/roots", merkleHandler.HandleListRoots)
+			r.Get("/merkle/proofs/{signatureId}", merkleHandler.HandleGetProof)
+		}
+
+		// Feed of the documents read by intranet portals, as JSON or Atom
+		if cfg.PublicFeedService != nil {
+			feedHandler := feed.NewHandler(cfg.PublicFeedService)
+			r.Get("/public/documents", feedHandler.HandleListDocuments)
+		}
+
+		// Verification link of the QR code printed on signature certificates
+		if cfg.CertificateService != nil {
+			r.Get("/certificates/{id}/verify", signaturesHandler.HandleVerifyCertificate)
+		}
+
+		// Banners published by admins, rendered by the SPA (signed-in users also see theirs)
+		if cfg.AnnouncementService != nil {
+			announcementsHandler := announcements.NewHandler(cfg.AnnouncementService, cfg.Authorizer)
+			r.With(apiMiddleware.OptionalAuth).Get("/announcements", announcementsHandler.HandleListAnnouncements)
+		}
+
+		// Read-only document status shared with reviewers without an account (authenticated by the token)
+		if cfg.PreviewLinkService != nil {
+			previewHandler := preview.NewHandler(cfg.PreviewLinkService)
+			r.Get("/preview/{token}", previewHandler.HandleGetPreview)
+		}
+
+		// Content-Security-Policy violations reported by browsers (no CSRF token: sent by the browser itself)
+		if cfg.CSPReportService != nil {
+			cspHandler := csp.NewHandler(cfg.CSPReportService, cfg.BaseURL)
+			r.Post("/csp-report", cspHandler.HandleReport)
+		}
+
+		// Pushes of federation peers (no session nor CSRF: authenticated by the signature of the body)
+		if cfg.FederationHub != nil {
+			federationHandler := federation.NewHandler(cfg.FederationHub)
+			r.With(documentRateLimit.Middleware).Post("/federation/push", federationHandler.HandlePush)
+		}
+
+		// Push webhooks of the Git hosts (no session nor CSRF: authenticated by the source secret)
+		if cfg.GitImportService != nil {
+			gitSourcesHandler := gitsources.NewHandler(cfg.GitImportService)
+			r.With(documentRateLimit.Middleware).Post("/git-sources/{id}/webhook", gitSourcesHandler.HandlePush)
+		}
+
+		// Change notifications of SharePoint and Google Drive (no session nor CSRF: authenticated by the client state)
+		if cfg.LinkResolverService != nil {
+			linkNotificationsHandler := linknotifications.NewHandler(cfg.LinkResolverService)
+			r.With(documentRateLimit.Middleware).Post("/link-notifications/sharepoint", linkNotificationsHandler.HandleSharePoint)
+			r.With(documentRateLimit.Middleware).Post("/link-notifications/google-drive", linkNotificationsHandler.HandleGoogleDrive)
+		}
+
 		// CSRF token
 		r.Get("/csrf", authHandler.HandleGetCSRFToken)
 
@@ -243,8 +1062,11 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		r.Route("/documents", func(r chi.Router) {
 			// Document creation (with CSRF and stricter rate limiting)
 			r.Group(func(r chi.Router) {
+				r.Use(apiMiddleware.OptionalAuth)
 				r.Use(apiMiddleware.CSRFProtect)
 				r.Use(documentRateLimit.Middleware)
+				// Documents created by department-admins belong to their department
+				r.Use(apiMiddleware.ScopeDepartments)
 				r.Post("/", documentsHandler.HandleCreateDocument)
 			})
 
@@ -252,6 +1074,12 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			r.Get("/", documentsHandler.HandleListDocuments)
 			r.Get("/{docId}", documentsHandler.HandleGetDocument)
 
+			// Consent text to display next to the sign button
+			r.Get("/{docId}/consent", signaturesHandler.HandleGetDocumentConsent)
+
+			// Message shown on the signing page before the acknowledgement button
+			r.Get("/{docId}/landing-message", documentsHandler.HandleGetLandingMessage)
+
 			// Signatures and expected-signers: detailed list restricted to owner/admin
 			r.Group(func(r chi.Router) {
 				r.Use(apiMiddleware.OptionalAuth)
@@ -259,6 +1087,18 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 				r.Get("/{docId}/expected-signers", documentsHandler.HandleGetExpectedSigners)
 			})
 
+			// Signing by email-verified signers outside the identity provider
+			r.Get("/{docId}/external", signaturesHandler.HandleGetExternalSigning)
+			r.Get("/{docId}/external/quiz", signaturesHandler.HandleGetExternalQuiz)
+			if cfg.ExternalSignerService != nil {
+				r.Group(func(r chi.Router) {
+					r.Use(apiMiddleware.CSRFProtect)
+					r.Use(authRateLimit.Middleware)
+					r.Post("/{docId}/external/request-code", signaturesHandler.HandleRequestExternalCode)
+					r.Post("/{docId}/external/sign", signaturesHandler.HandleCreateExternalSignature)
+				})
+			}
+
 			// Find or create document by reference (public for embed support, but with optional auth)
 			r.Group(func(r chi.Router) {
 				r.Use(apiMiddleware.OptionalAuth)
@@ -268,8 +1108,28 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 
 		// Storage configuration endpoint (public, tells frontend if storage is enabled)
 		r.Get("/storage/config", storageHandler.HandleStorageConfig)
+
+		// Document content through an expiring signed link (email links, embed page)
+		if cfg.StorageURLSigner != nil {
+			r.Get("/documents/{docId}/content/signed", storageHandler.HandleSignedContent)
+		}
+
+		// Signature archive of all documents through its expiring signed link
+		if cfg.SignatureArchives != nil {
+			r.Get("/exports/signatures/{archiveId}", storageHandler.HandleSignatureArchive)
+		}
 	})
 
+	// Integration API for external systems (API key auth, no session nor CSRF)
+	if cfg.APIKeyService != nil && cfg.IntegrationService != nil {
+		integrationsHandler := integrations.NewHandler(cfg.APIKeyService, cfg.IntegrationService)
+		r.Route("/integrations", func(r chi.Router) {
+			r.Use(integrationsHandler.RequireAPIKey)
+			r.With(integrations.RequireScope(models.PermissionDocumentsWrite), documentRateLimit.Middleware).Post("/documents", integrationsHandler.HandleCreateDocument)
+			r.With(integrations.RequireScope(models.PermissionDocumentsRead)).Get("/documents/{docId}", integrationsHandler.HandleGetDocument)
+		})
+	}
+
 	// Authenticated routes
 	r.Group(func(r chi.Router) {
 		r.Use(apiMiddleware.RequireAuth)
@@ -280,9 +1140,23 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 			r.Get("/me", usersHandler.HandleGetCurrentUser)
 			r.Get("/me/documents", documentsHandler.HandleListMyDocuments)
 
+			// Preferred locale of the current user
+			if cfg.UserPreferenceService != nil {
+				r.Get("/me/preferences", usersHandler.HandleGetMyPreferences)
+				r.Put("/me/preferences", usersHandler.HandleUpdateMyPreferences)
+			}
+
+			// Active sessions of the current user
+			if cfg.SessionManager != nil {
+				r.Get("/me/sessions", usersHandler.HandleListMySessions)
+				r.Delete("/me/sessions", usersHandler.HandleRevokeMyOtherSessions)
+				r.Delete("/me/sessions/{id}", usersHandler.HandleRevokeMySession)
+			}
+
 			// Owner-based document management (user can manage docs they created)
 			r.Get("/me/documents/{docId}/status", documentsHandler.HandleGetMyDocumentStatus)
 			r.Put("/me/documents/{docId}/metadata", documentsHandler.HandleUpdateMyDocumentMetadata)
+			r.Patch("/me/documents/{docId}/metadata", documentsHandler.HandleUpdateMyDocumentMetadata)
 			r.Delete("/me/documents/{docId}", documentsHandler.HandleDeleteMyDocument)
 
 			// Expected signers management (owner can manage signers for their documents)
@@ -294,11 +1168,48 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		r.Route("/signatures", func(r chi.Router) {
 			r.Get("/", signaturesHandler.HandleGetUserSignatures)
 			r.Post("/", signaturesHandler.HandleCreateSignature)
+			r.Post("/nonce", signaturesHandler.HandleIssueNonce)
+			if cfg.CertificateService != nil {
+				r.Get("/{id}/certificate", signaturesHandler.HandleGetCertificate)
+			}
 		})
 
+		// Document packets signed one document after the other
+		if cfg.PacketService != nil {
+			packetsHandler := packets.NewHandler(cfg.PacketService)
+			r.Get("/packets/{id}", packetsHandler.HandleGetPacket)
+		}
+
 		// Document signature status (authenticated)
 		r.Get("/documents/{docId}/signatures/status", signaturesHandler.HandleGetSignatureStatus)
 
+		// Document quiz to answer before signing (authenticated, without answers)
+		r.Get("/documents/{docId}/quiz", signaturesHandler.HandleGetDocumentQuiz)
+
+		// Questions signers ask the document owner, with replies and moderation
+		if cfg.DocumentQuestionService != nil {
+			questionsHandler := questions.NewHandler(cfg.DocumentQuestionService)
+			r.Route("/documents/{docId}/questions", func(r chi.Router) {
+				r.Get("/", questionsHandler.HandleList)
+				r.Post("/", questionsHandler.HandleAsk)
+				r.Post("/{id}/replies", questionsHandler.HandleReply)
+				r.Put("/{id}/status", questionsHandler.HandleSetStatus)
+				r.Put("/{id}/hidden", questionsHandler.HandleSetHidden)
+				r.Put("/{id}/replies/{replyId}/hidden", questionsHandler.HandleSetReplyHidden)
+			})
+		}
+
+		// Deadline extensions signers request and the document owner approves or denies
+		if cfg.DeadlineExtensionService != nil {
+			extensionsHandler := extensions.NewHandler(cfg.DeadlineExtensionService)
+			r.Route("/documents/{docId}/deadline-extensions", func(r chi.Router) {
+				r.Get("/", extensionsHandler.HandleList)
+				r.Post("/", extensionsHandler.HandleRequest)
+				r.Post("/{id}/approve", extensionsHandler.HandleApprove)
+				r.Post("/{id}/deny", extensionsHandler.HandleDeny)
+			})
+		}
+
 		// Document content (authenticated - serves stored files)
 		r.Get("/documents/{docId}/content", storageHandler.HandleContent)
 
@@ -315,6 +1226,14 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 	r.Group(func(r chi.Router) {
 		r.Use(apiMiddleware.RequireAdmin)
 		r.Use(apiMiddleware.CSRFProtect)
+		// Department-admins only see the documents of their department subtree
+		r.Use(apiMiddleware.ScopeDepartments)
+
+		// Dashboards (lists, statuses, statistics, exports) read from the replica when configured
+		replicaReads := func(next http.Handler) http.Handler { return next }
+		if cfg.ReadReplica != nil && cfg.TenantProvider != nil {
+			replicaReads = shared.NewReadReplicaMiddleware(cfg.ReadReplica, cfg.TenantProvider).Handler
+		}
 
 		// Configure import max signers with default
 		importMaxSigners := cfg.ImportMaxSigners
@@ -323,38 +1242,278 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 		}
 
 		// Initialize admin handler
-		adminHandler := apiAdmin.NewHandler(cfg.AdminService, cfg.ReminderService, cfg.SignatureService, cfg.BaseURL, importMaxSigners)
+		adminHandler := apiAdmin.NewHandler(cfg.AdminService, cfg.ReminderService, cfg.BaseURL, importMaxSigners)
+		if cfg.StorageURLSigner != nil {
+			adminHandler.SetURLSigner(cfg.StorageURLSigner)
+		}
+		if cfg.TagService != nil {
+			adminHandler.SetDocumentFilter(cfg.TagService)
+		}
+		if cfg.SignerEmailPolicy != nil {
+			adminHandler.SetSignerEmailPolicy(cfg.SignerEmailPolicy)
+		}
 		webhooksHandler := apiAdmin.NewWebhooksHandler(cfg.WebhookService)
+		campaignsHandler := apiAdmin.NewCampaignsHandler(cfg.CampaignService)
+
+		var retentionHandler *apiAdmin.RetentionHandler
+		if cfg.RetentionService != nil {
+			retentionHandler = apiAdmin.NewRetentionHandler(cfg.RetentionService)
+		}
+
+		var accessLinksHandler *apiAdmin.AccessLinksHandler
+		if cfg.AccessResendService != nil {
+			accessLinksHandler = apiAdmin.NewAccessLinksHandler(cfg.AccessResendService)
+		}
+
+		var completionHandler *apiAdmin.CompletionHandler
+		if cfg.CompletionService != nil {
+			completionHandler = apiAdmin.NewCompletionHandler(cfg.CompletionService)
+		}
+
+		var historyHandler *apiAdmin.HistoryHandler
+		if cfg.HistoryService != nil {
+			historyHandler = apiAdmin.NewHistoryHandler(cfg.HistoryService)
+		}
+
+		var signerTimelineHandler *apiAdmin.SignerTimelineHandler
+		if cfg.SignerTimelineService != nil {
+			signerTimelineHandler = apiAdmin.NewSignerTimelineHandler(cfg.SignerTimelineService)
+		}
+
+		var previewLinksHandler *apiAdmin.PreviewLinksHandler
+		if cfg.PreviewLinkService != nil {
+			previewLinksHandler = apiAdmin.NewPreviewLinksHandler(cfg.PreviewLinkService)
+		}
+		var shortLinksHandler *apiAdmin.ShortLinksHandler
+		if cfg.ShortLinkService != nil {
+			shortLinksHandler = apiAdmin.NewShortLinksHandler(cfg.ShortLinkService)
+		}
+
+		var documentAliasesHandler *apiAdmin.DocumentAliasesHandler
+		if cfg.DocumentAliasService != nil {
+			documentAliasesHandler = apiAdmin.NewDocumentAliasesHandler(cfg.DocumentAliasService)
+		}
+
+		var documentChecksumHandler *apiAdmin.DocumentChecksumHandler
+		if cfg.ChecksumInspector != nil {
+			documentChecksumHandler = apiAdmin.NewDocumentChecksumHandler(cfg.ChecksumInspector)
+		}
+
+		var signerGroupsHandler *apiAdmin.SignerGroupsHandler
+		if cfg.SignerGroupService != nil {
+			signerGroupsHandler = apiAdmin.NewSignerGroupsHandler(cfg.SignerGroupService)
+		}
+
+		var gitSourcesHandler *apiAdmin.GitSourcesHandler
+		if cfg.GitImportService != nil {
+			gitSourcesHandler = apiAdmin.NewGitSourcesHandler(cfg.GitImportService)
+		}
+
+		var linkSourcesHandler *apiAdmin.LinkSourcesHandler
+		if cfg.LinkResolverService != nil {
+			linkSourcesHandler = apiAdmin.NewLinkSourcesHandler(cfg.LinkResolverService)
+		}
+
+		var exportsHandler *apiAdmin.ExportsHandler
+		if cfg.ExportService != nil {
+			exportsHandler = apiAdmin.NewExportsHandler(cfg.ExportService)
+		}
+
+		var departmentsHandler *apiAdmin.DepartmentsHandler
+		if cfg.DepartmentService != nil {
+			departmentsHandler = apiAdmin.NewDepartmentsHandler(cfg.DepartmentService)
+		}
+
+		var tagsHandler *apiAdmin.TagsHandler
+		if cfg.TagService != nil {
+			tagsHandler = apiAdmin.NewTagsHandler(cfg.TagService)
+		}
+
+		var quizHandler *apiAdmin.QuizHandler
+		if cfg.QuizService != nil {
+			quizHandler = apiAdmin.NewQuizHandler(cfg.QuizService)
+		}
+
+		var signatureHookHandler *apiAdmin.SignatureHookHandler
+		if cfg.SignatureHookService != nil {
+			signatureHookHandler = apiAdmin.NewSignatureHookHandler(cfg.SignatureHookService)
+		}
+
+		var signRedirectHandler *apiAdmin.SignRedirectHandler
+		if cfg.SignRedirectService != nil {
+			signRedirectHandler = apiAdmin.NewSignRedirectHandler(cfg.SignRedirectService)
+		}
+
+		var externalSigningHandler *apiAdmin.ExternalSigningHandler
+		if cfg.ExternalSignerService != nil {
+			externalSigningHandler = apiAdmin.NewExternalSigningHandler(cfg.ExternalSignerService)
+		}
+
+		var consentHandler *apiAdmin.ConsentHandler
+		if cfg.ConsentService != nil {
+			consentHandler = apiAdmin.NewConsentHandler(cfg.ConsentService)
+		}
+
+		// Per-operation permission checks for delegated admin roles
+		can := apiMiddleware.RequirePermission
 
 		r.Route("/admin", func(r chi.Router) {
+			// ?nocache=1 reads fresh data, to debug suspected stale caches
+			r.Use(shared.CacheBypass)
+
 			// Document management
 			r.Route("/documents", func(r chi.Router) {
-				r.Get("/", adminHandler.HandleListDocuments)
-				r.Get("/{docId}", adminHandler.HandleGetDocument)
-				r.Get("/{docId}/signers", adminHandler.HandleGetDocumentWithSigners)
-				r.Get("/{docId}/status", adminHandler.HandleGetDocumentStatus)
+				r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/", adminHandler.HandleListDocuments)
+				if documentChecksumHandler != nil {
+					r.With(can(models.PermissionDocumentsWrite)).Post("/checksum", documentChecksumHandler.HandleComputeChecksum)
+				}
+				r.With(can(models.PermissionDocumentsRead)).Get("/{docId}", adminHandler.HandleGetDocument)
+				r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{docId}/signers", adminHandler.HandleGetDocumentWithSigners)
+				r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{docId}/status", adminHandler.HandleGetDocumentStatus)
 
 				// Document metadata
-				r.Put("/{docId}/metadata", adminHandler.HandleUpdateDocumentMetadata)
+				r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/metadata", adminHandler.HandleUpdateDocumentMetadata)
+				r.With(can(models.PermissionDocumentsWrite)).Patch("/{docId}/metadata", adminHandler.HandlePatchDocumentMetadata)
 
 				// Document deletion
-				r.Delete("/{docId}", adminHandler.HandleDeleteDocument)
+				r.With(can(models.PermissionDocumentsWrite)).Delete("/{docId}", adminHandler.HandleDeleteDocument)
 
 				// Expected signers management
-				r.Post("/{docId}/signers", adminHandler.HandleAddExpectedSigner)
-				r.Delete("/{docId}/signers/{email}", adminHandler.HandleRemoveExpectedSigner)
+				r.With(can(models.PermissionSignersManage)).Post("/{docId}/signers", adminHandler.HandleAddExpectedSigner)
+				r.With(can(models.PermissionSignersManage)).Delete("/{docId}/signers/{email}", adminHandler.HandleRemoveExpectedSigner)
 
 				// CSV import for expected signers
-				r.Post("/{docId}/signers/preview-csv", adminHandler.HandlePreviewCSV)
-				r.Post("/{docId}/signers/import", adminHandler.HandleImportSigners)
+				r.With(can(models.PermissionSignersManage)).Post("/{docId}/signers/preview-csv", adminHandler.HandlePreviewCSV)
+				r.With(can(models.PermissionSignersManage)).Post("/{docId}/signers/import", adminHandler.HandleImportSigners)
+
+				// Expected signers from a signer group
+				if signerGroupsHandler != nil {
+					r.With(can(models.PermissionSignersManage)).Post("/{docId}/signers/groups/{id}", signerGroupsHandler.HandleAddToDocument)
+				}
+
+				// Expected signers from the members of a department subtree, and department of the document
+				if departmentsHandler != nil {
+					r.With(can(models.PermissionSignersManage)).Post("/{docId}/signers/departments/{id}", departmentsHandler.HandleAddToDocument)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/department", departmentsHandler.HandleSetDocumentDepartment)
+				}
+
+				// Document tags
+				if tagsHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/tags", tagsHandler.HandleGetDocumentTags)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/tags", tagsHandler.HandleSetDocumentTags)
+				}
 
 				// Reminder management
-				r.Post("/{docId}/reminders", adminHandler.HandleSendReminders)
-				r.Get("/{docId}/reminders", adminHandler.HandleGetReminderHistory)
+				r.With(can(models.PermissionRemindersSend)).Post("/{docId}/reminders", adminHandler.HandleSendReminders)
+				r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/reminders", adminHandler.HandleGetReminderHistory)
+
+				// Fresh magic links or codes for signers who lost access
+				if accessLinksHandler != nil {
+					r.With(can(models.PermissionRemindersSend)).Post("/{docId}/access-links", accessLinksHandler.HandleResendAccess)
+				}
+
+				// Manual archiving
+				if retentionHandler != nil {
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{docId}/archive", retentionHandler.HandleArchiveDocument)
+				}
+
+				// Completion notification settings
+				if completionHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/notifications", completionHandler.HandleGetSettings)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/notifications", completionHandler.HandleUpdateSettings)
+				}
+
+				// Completion history
+				if historyHandler != nil {
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{docId}/history", historyHandler.HandleGetHistory)
+				}
+
+				// Everything recorded about one signer, for support investigations
+				if signerTimelineHandler != nil {
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{docId}/signers/{email}/timeline", signerTimelineHandler.HandleGetTimeline)
+				}
+
+				// Short links and QR codes printed on notices
+				if shortLinksHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/short-links", shortLinksHandler.HandleListShortLinks)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{docId}/short-links", shortLinksHandler.HandleCreateShortLink)
+					r.With(can(models.PermissionDocumentsWrite)).Delete("/{docId}/short-links/{code}", shortLinksHandler.HandleDeleteShortLink)
+					r.With(can(models.PermissionDocumentsWrite)).Get("/{docId}/qr", shortLinksHandler.HandleGetQRCode)
+				}
+
+				// Alternative IDs redirected to the document
+				if documentAliasesHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/aliases", documentAliasesHandler.HandleListAliases)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{docId}/aliases", documentAliasesHandler.HandleAddAlias)
+					r.With(can(models.PermissionDocumentsWrite)).Delete("/{docId}/aliases/{alias}", documentAliasesHandler.HandleRemoveAlias)
+				}
+
+				// Expiring read-only status links for reviewers without an account
+				if previewLinksHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/preview-links", previewLinksHandler.HandleListPreviewLinks)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{docId}/preview-links", previewLinksHandler.HandleCreatePreviewLink)
+					r.With(can(models.PermissionDocumentsWrite)).Delete("/{docId}/preview-links/{id}", previewLinksHandler.HandleRevokePreviewLink)
+				}
+
+				// Confluence and SharePoint page the document links to
+				if linkSourcesHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/link-source", linkSourcesHandler.HandleGetSource)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{docId}/link-source/refresh", linkSourcesHandler.HandleRefreshSource)
+				}
+
+				// Signed export bundle of the signatures
+				if exportsHandler != nil {
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{docId}/signatures/export", exportsHandler.HandleExportSignatures)
+				}
+
+				// Comprehension quiz
+				if quizHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/quiz", quizHandler.HandleGetQuiz)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/quiz", quizHandler.HandleUpdateQuiz)
+					r.With(can(models.PermissionDocumentsWrite)).Delete("/{docId}/quiz", quizHandler.HandleDeleteQuiz)
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/quiz/results", quizHandler.HandleGetResults)
+				}
+
+				// Validation hook called before signing. Hooks send signer identities to
+				// external URLs, only settings managers configure them
+				if signatureHookHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/signature-hook", signatureHookHandler.HandleGetHook)
+					r.With(can(models.PermissionSettingsManage)).Put("/{docId}/signature-hook", signatureHookHandler.HandleSetHook)
+					r.With(can(models.PermissionSettingsManage)).Delete("/{docId}/signature-hook", signatureHookHandler.HandleDeleteHook)
+				}
+
+				// Redirect after signing, restricted to the allowed origins
+				if signRedirectHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/sign-redirect", signRedirectHandler.HandleGetRedirect)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/sign-redirect", signRedirectHandler.HandleSetRedirect)
+					r.With(can(models.PermissionDocumentsWrite)).Delete("/{docId}/sign-redirect", signRedirectHandler.HandleDeleteRedirect)
+				}
+
+				// External signers
+				if externalSigningHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/external-signing", externalSigningHandler.HandleGetExternalSigning)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/external-signing", externalSigningHandler.HandleUpdateExternalSigning)
+				}
+
+				// Consent version signers are shown
+				if consentHandler != nil {
+					r.With(can(models.PermissionDocumentsRead)).Get("/{docId}/consent", consentHandler.HandleGetDocumentConsent)
+					r.With(can(models.PermissionDocumentsWrite)).Put("/{docId}/consent", consentHandler.HandleUpdateDocumentConsent)
+				}
 			})
 
+			// Full-text search across documents and signers
+			if cfg.SearchService != nil {
+				searchHandler := apiAdmin.NewSearchHandler(cfg.SearchService)
+				r.With(can(models.PermissionDocumentsRead)).Get("/search", searchHandler.HandleSearch)
+			}
+
+			// Reminder digests across documents
+			r.With(can(models.PermissionRemindersSend)).Post("/reminders/digest", adminHandler.HandleSendReminderDigests)
+
 			// Webhooks management
 			r.Route("/webhooks", func(r chi.Router) {
+				r.Use(can(models.PermissionWebhooksManage))
 				r.Get("/", webhooksHandler.HandleListWebhooks)
 				r.Post("/", webhooksHandler.HandleCreateWebhook)
 				r.Get("/{id}", webhooksHandler.HandleGetWebhook)
@@ -364,14 +1523,425 @@ func NewRouter(cfg RouterConfig) *chi.Mux {
 				r.Get("/{id}/deliveries", webhooksHandler.HandleListDeliveries)
 			})
 
+			// Signer groups, maintained by hand or synced from a directory
+			if signerGroupsHandler != nil {
+				r.Route("/signer-groups", func(r chi.Router) {
+					r.Use(can(models.PermissionSignersManage), shared.RequireTenantWide)
+					r.Get("/", signerGroupsHandler.HandleListGroups)
+					r.Post("/", signerGroupsHandler.HandleCreateGroup)
+					r.Get("/providers", signerGroupsHandler.HandleListProviders)
+					r.Get("/{id}", signerGroupsHandler.HandleGetGroup)
+					r.Put("/{id}", signerGroupsHandler.HandleUpdateGroup)
+					r.Delete("/{id}", signerGroupsHandler.HandleDeleteGroup)
+					r.Get("/{id}/members", signerGroupsHandler.HandleListMembers)
+					r.Post("/{id}/members", signerGroupsHandler.HandleAddMembers)
+					r.Delete("/{id}/members/{email}", signerGroupsHandler.HandleRemoveMember)
+					r.Post("/{id}/sync", signerGroupsHandler.HandleSyncGroup)
+				})
+			}
+
+			// Announcement banners
+			if cfg.AnnouncementService != nil {
+				announcementsHandler := apiAdmin.NewAnnouncementsHandler(cfg.AnnouncementService)
+				r.Route("/announcements", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage), shared.RequireTenantWide)
+					r.Get("/", announcementsHandler.HandleListAnnouncements)
+					r.Post("/", announcementsHandler.HandleCreateAnnouncement)
+					r.Get("/{id}", announcementsHandler.HandleGetAnnouncement)
+					r.Put("/{id}", announcementsHandler.HandleUpdateAnnouncement)
+					r.Delete("/{id}", announcementsHandler.HandleDeleteAnnouncement)
+				})
+			}
+
+			// Document packets
+			if cfg.PacketService != nil {
+				packetsHandler := apiAdmin.NewPacketsHandler(cfg.PacketService)
+				r.Route("/packets", func(r chi.Router) {
+					r.Use(can(models.PermissionDocumentsWrite), shared.RequireTenantWide)
+					r.Get("/", packetsHandler.HandleListPackets)
+					r.Post("/", packetsHandler.HandleCreatePacket)
+					r.Get("/{id}", packetsHandler.HandleGetPacket)
+					r.Put("/{id}", packetsHandler.HandleUpdatePacket)
+					r.Delete("/{id}", packetsHandler.HandleDeletePacket)
+					r.Get("/{id}/stats", packetsHandler.HandleGetStats)
+					r.With(can(models.PermissionSignersManage)).Post("/{id}/reminders", packetsHandler.HandleSendReminders)
+				})
+			}
+
+			// Background job queue
+			if cfg.JobService != nil {
+				jobsHandler := apiAdmin.NewJobsHandler(cfg.JobService)
+				r.Route("/jobs", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage), shared.RequireTenantWide)
+					r.Get("/", jobsHandler.HandleListJobs)
+					r.Get("/stats", jobsHandler.HandleGetStats)
+					r.Get("/{id}", jobsHandler.HandleGetJob)
+					r.Post("/{id}/retry", jobsHandler.HandleRetryJob)
+					r.Post("/{id}/cancel", jobsHandler.HandleCancelJob)
+				})
+			}
+
+			// Consent catalog
+			if consentHandler != nil {
+				r.Route("/consent", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage), shared.RequireTenantWide)
+					r.Get("/", consentHandler.HandleListCatalog)
+					r.Post("/", consentHandler.HandlePublishVersion)
+				})
+			}
+
+			// Email suppression list
+			if cfg.EmailSuppressionService != nil {
+				suppressionsHandler := apiAdmin.NewEmailSuppressionsHandler(cfg.EmailSuppressionService)
+				r.Route("/email-suppressions", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage), shared.RequireTenantWide)
+					r.Get("/", suppressionsHandler.HandleListSuppressions)
+					r.Post("/", suppressionsHandler.HandleAddSuppression)
+					r.Delete("/{email}", suppressionsHandler.HandleRemoveSuppression)
+				})
+			}
+
+			// Department tree, with the members, documents and completion of each subtree
+			if departmentsHandler != nil {
+				r.Route("/departments", func(r chi.Router) {
+					r.With(can(models.PermissionDocumentsRead)).Get("/", departmentsHandler.HandleListDepartments)
+					r.With(can(models.PermissionRolesManage)).Post("/", departmentsHandler.HandleCreateDepartment)
+					r.With(can(models.PermissionDocumentsRead)).Get("/{id}", departmentsHandler.HandleGetDepartment)
+					r.With(can(models.PermissionRolesManage)).Put("/{id}", departmentsHandler.HandleUpdateDepartment)
+					r.With(can(models.PermissionRolesManage)).Delete("/{id}", departmentsHandler.HandleDeleteDepartment)
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{id}/members", departmentsHandler.HandleListMembers)
+					r.With(can(models.PermissionSignersManage)).Post("/{id}/members", departmentsHandler.HandleAddMembers)
+					r.With(can(models.PermissionSignersManage)).Delete("/{id}/members/{email}", departmentsHandler.HandleRemoveMember)
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{id}/documents", departmentsHandler.HandleListDocuments)
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{id}/documents/export", departmentsHandler.HandleExportDocuments)
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{id}/stats", departmentsHandler.HandleGetStats)
+				})
+			}
+
+			// Tags with autocompletion and completion per tag; renaming and deleting a tag affects
+			// every document of the tenant, as do the reminder and retention policies of tags
+			if tagsHandler != nil {
+				r.Route("/tags", func(r chi.Router) {
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/", tagsHandler.HandleListTags)
+					r.With(can(models.PermissionDocumentsRead), replicaReads).Get("/{tag}/stats", tagsHandler.HandleGetStats)
+					r.With(can(models.PermissionDocumentsWrite), shared.RequireTenantWide).Put("/{tag}", tagsHandler.HandleRenameTag)
+					r.With(can(models.PermissionDocumentsWrite), shared.RequireTenantWide).Delete("/{tag}", tagsHandler.HandleDeleteTag)
+				})
+				r.Route("/tag-policies", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage), shared.RequireTenantWide)
+					r.Get("/", tagsHandler.HandleListPolicies)
+					r.Put("/{tag}", tagsHandler.HandleSetPolicy)
+					r.Delete("/{tag}", tagsHandler.HandleDeletePolicy)
+				})
+			}
+
+			// Confluence and SharePoint link metadata, to fill in new documents
+			if linkSourcesHandler != nil {
+				r.With(can(models.PermissionDocumentsWrite)).Post("/link-metadata/resolve", linkSourcesHandler.HandleResolve)
+			}
+
+			// Cross-document compliance reports, generated in the background
+			if cfg.ReportService != nil {
+				reportsHandler := apiAdmin.NewReportsHandler(cfg.ReportService)
+				r.Route("/reports/compliance", func(r chi.Router) {
+					r.Use(can(models.PermissionDocumentsRead), shared.RequireTenantWide)
+					r.Get("/", reportsHandler.HandleRequestReport)
+					r.Get("/{id}", reportsHandler.HandleGetReport)
+					r.Get("/{id}/download", reportsHandler.HandleDownloadReport)
+				})
+			}
+
+			// Verification of signed export bundles
+			if exportsHandler != nil {
+				r.With(can(models.PermissionDocumentsRead)).Post("/exports/verify", exportsHandler.HandleVerifyBundle)
+			}
+
+			// Signature archive of all documents, built by a job followed through the jobs API
+			if cfg.SignatureArchives != nil {
+				archiveHandler := apiAdmin.NewSignatureArchiveHandler(cfg.SignatureArchives)
+				r.With(can(models.PermissionSettingsManage), shared.RequireTenantWide).Post("/exports/signatures", archiveHandler.HandleRequest)
+			}
+
+			// Git repositories documents are imported from
+			if gitSourcesHandler != nil {
+				r.Route("/git-sources", func(r chi.Router) {
+					r.Use(can(models.PermissionDocumentsWrite), shared.RequireTenantWide)
+					r.Get("/", gitSourcesHandler.HandleListSources)
+					r.Post("/", gitSourcesHandler.HandleCreateSource)
+					r.Get("/{id}", gitSourcesHandler.HandleGetSource)
+					r.Put("/{id}", gitSourcesHandler.HandleUpdateSource)
+					r.Delete("/{id}", gitSourcesHandler.HandleDeleteSource)
+					r.Get("/{id}/documents", gitSourcesHandler.HandleListDocuments)
+					r.Post("/{id}/sync", gitSourcesHandler.HandleSyncSource)
+				})
+			}
+
+			// Recurring acknowledgement campaigns
+			r.Route("/campaigns", func(r chi.Router) {
+				r.Use(can(models.PermissionCampaignsManage))
+				r.Get("/", campaignsHandler.HandleListCampaigns)
+				r.Post("/", campaignsHandler.HandleCreateCampaign)
+				r.Get("/{id}", campaignsHandler.HandleGetCampaign)
+				r.Put("/{id}", campaignsHandler.HandleUpdateCampaign)
+				r.Delete("/{id}", campaignsHandler.HandleDeleteCampaign)
+				r.Get("/{id}/runs", campaignsHandler.HandleListCampaignRuns)
+				r.Post("/{id}/runs", campaignsHandler.HandleTriggerCampaign)
+			})
+
+			// Retention policy and document archives
+			if retentionHandler != nil {
+				r.Route("/retention", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", retentionHandler.HandleGetPolicy)
+					r.Put("/", retentionHandler.HandleUpdatePolicy)
+				})
+				r.Route("/archives", func(r chi.Router) {
+					r.Use(shared.RequireTenantWide)
+					r.With(can(models.PermissionDocumentsRead)).Get("/", retentionHandler.HandleListArchives)
+					r.With(can(models.PermissionDocumentsRead)).Get("/{id}", retentionHandler.HandleGetArchive)
+					r.With(can(models.PermissionDocumentsRead)).Get("/{id}/download", retentionHandler.HandleDownloadArchive)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{id}/restore", retentionHandler.HandleRestoreArchive)
+				})
+			}
+
+			// Notification inbox of the current user (no permission: each user only sees their own)
+			if cfg.NotificationService != nil {
+				notificationsHandler := apiAdmin.NewNotificationsHandler(cfg.NotificationService, cfg.BaseURL)
+				r.Route("/notifications", func(r chi.Router) {
+					r.Get("/", notificationsHandler.HandleList)
+					r.Get("/unread-count", notificationsHandler.HandleUnreadCount)
+					r.Get("/ws", notificationsHandler.HandleStream)
+					r.Post("/read-all", notificationsHandler.HandleMarkAllRead)
+					r.Post("/{id}/read", notificationsHandler.HandleMarkRead)
+				})
+			}
+
+			// Delegated admin roles
+			if cfg.RoleService != nil {
+				rolesHandler := apiAdmin.NewRolesHandler(cfg.RoleService)
+				r.Route("/roles", func(r chi.Router) {
+					r.Use(can(models.PermissionRolesManage))
+					r.Get("/", rolesHandler.HandleListRoles)
+					r.Get("/definitions", rolesHandler.HandleListRoleDefinitions)
+					r.Put("/{email}", rolesHandler.HandleAssignRole)
+					r.Delete("/{email}", rolesHandler.HandleRevokeRole)
+				})
+			}
+
+			// Email aliases and matching rules used to match signatures to expected signers
+			if cfg.EmailMatchingService != nil {
+				emailMatchingHandler := apiAdmin.NewEmailMatchingHandler(cfg.EmailMatchingService)
+				r.Route("/email-aliases", func(r chi.Router) {
+					r.Use(can(models.PermissionSignersManage), shared.RequireTenantWide)
+					r.Get("/", emailMatchingHandler.HandleListEmailAliases)
+					r.Post("/", emailMatchingHandler.HandleCreateEmailAlias)
+					r.Delete("/{id}", emailMatchingHandler.HandleDeleteEmailAlias)
+				})
+				r.Route("/email-matching", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", emailMatchingHandler.HandleGetEmailMatchingRules)
+					r.Put("/", emailMatchingHandler.HandleUpdateEmailMatchingRules)
+				})
+			}
+
+			// Requests rejected by signature and magic link rate limits
+			if cfg.RateLimitService != nil {
+				rateLimitsHandler := apiAdmin.NewRateLimitsHandler(cfg.RateLimitService)
+				r.With(can(models.PermissionSettingsManage)).Get("/rate-limits/violations", rateLimitsHandler.HandleListViolations)
+			}
+
+			// Repeated failed authentications by user or IP
+			if cfg.SecurityAlertService != nil {
+				securityHandler := apiAdmin.NewSecurityHandler(cfg.SecurityAlertService)
+				r.Route("/security/events", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", securityHandler.HandleListEvents)
+					r.Post("/{id}/acknowledge", securityHandler.HandleAcknowledgeEvent)
+				})
+			}
+
+			// Content-Security-Policy violations, to adjust the policy sources
+			if cfg.CSPReportService != nil {
+				cspReportsHandler := apiAdmin.NewCSPReportsHandler(cfg.CSPReportService)
+				r.With(can(models.PermissionSettingsManage)).Get("/csp-reports", cspReportsHandler.HandleListViolations)
+			}
+
+			// Active sessions of any user
+			if cfg.SessionManager != nil {
+				sessionsHandler := apiAdmin.NewSessionsHandler(cfg.SessionManager)
+				r.Route("/users/{email}/sessions", func(r chi.Router) {
+					r.Use(can(models.PermissionRolesManage))
+					r.Get("/", sessionsHandler.HandleListUserSessions)
+					r.Delete("/", sessionsHandler.HandleRevokeUserSessions)
+					r.Delete("/{id}", sessionsHandler.HandleRevokeUserSession)
+				})
+			}
+
+			// Duplicate identities merged into one
+			if cfg.IdentityMergeService != nil {
+				identityMergesHandler := apiAdmin.NewIdentityMergesHandler(cfg.IdentityMergeService)
+				r.Route("/users", func(r chi.Router) {
+					r.Use(can(models.PermissionRolesManage), shared.RequireTenantWide)
+					r.Post("/merge", identityMergesHandler.HandleMerge)
+					r.Get("/merges", identityMergesHandler.HandleListMerges)
+				})
+			}
+
+			// Server-side cache metrics
+			if cfg.SignerStatusCache != nil {
+				cacheHandler := apiAdmin.NewCacheHandler(cfg.SignerStatusCache)
+				r.With(can(models.PermissionSettingsManage)).Get("/cache", cacheHandler.HandleGetCacheStats)
+			}
+
+			// Read replica health
+			if cfg.ReadReplica != nil {
+				replicaHandler := apiAdmin.NewReplicaHandler(cfg.ReadReplica)
+				r.With(can(models.PermissionSettingsManage)).Get("/replica", replicaHandler.HandleGetReplicaStatus)
+			}
+
+			// SMTP servers health
+			if cfg.MailServers != nil {
+				mailHandler := apiAdmin.NewMailHandler(cfg.MailServers)
+				r.With(can(models.PermissionSettingsManage)).Get("/mail/diagnostics", mailHandler.HandleGetDiagnostics)
+			}
+			if cfg.MailDeliverability != nil {
+				deliverabilityHandler := apiAdmin.NewMailDeliverabilityHandler(cfg.MailDeliverability)
+				r.With(can(models.PermissionSettingsManage)).Get("/mail/deliverability", deliverabilityHandler.HandleGetReport)
+			}
+
+			// External signature ledger lag
+			if cfg.LedgerService != nil {
+				ledgerHandler := apiAdmin.NewLedgerHandler(cfg.LedgerService)
+				r.With(can(models.PermissionSettingsManage)).Get("/ledger", ledgerHandler.HandleGetLag)
+			}
+
+			// Transactional outbox of signature events
+			if cfg.EventOutbox != nil {
+				outboxHandler := apiAdmin.NewOutboxHandler(cfg.EventOutbox)
+				r.Route("/outbox", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", outboxHandler.HandleGetLag)
+					r.Post("/retry", outboxHandler.HandleRetryDead)
+				})
+			}
+
+			// Uploads held back by the upload policy or the virus scanner
+			if cfg.UploadQuarantine != nil {
+				quarantineHandler := apiAdmin.NewUploadQuarantineHandler(cfg.UploadQuarantine)
+				r.Route("/uploads/quarantine", func(r chi.Router) {
+					r.Use(shared.RequireTenantWide)
+					r.With(can(models.PermissionDocumentsRead)).Get("/", quarantineHandler.HandleList)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{id}/release", quarantineHandler.HandleRelease)
+					r.With(can(models.PermissionDocumentsWrite)).Post("/{id}/discard", quarantineHandler.HandleDiscard)
+				})
+			}
+
+			// Signatures of unexpected signers held for approval
+			if cfg.SignatureApprovals != nil {
+				approvalsHandler := apiAdmin.NewSignatureApprovalsHandler(cfg.SignatureApprovals)
+				r.Route("/signature-approvals", func(r chi.Router) {
+					r.Use(shared.RequireTenantWide)
+					r.With(can(models.PermissionDocumentsRead)).Get("/", approvalsHandler.HandleList)
+					r.With(can(models.PermissionSignersManage)).Post("/{id}/approve", approvalsHandler.HandleApprove)
+					r.With(can(models.PermissionSignersManage)).Post("/{id}/reject", approvalsHandler.HandleReject)
+				})
+			}
+
+			// Federation: peers and documents of a hub, pushes of a peer
+			if cfg.FederationHub != nil || cfg.FederationClient != nil {
+				federationHandler := apiAdmin.NewFederationHandler(cfg.FederationHub, cfg.FederationClient)
+				r.Route("/federation", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					if cfg.FederationHub != nil {
+						r.Get("/peers", federationHandler.HandleListPeers)
+						r.Post("/peers", federationHandler.HandleCreatePeer)
+						r.Delete("/peers/{id}", federationHandler.HandleDeletePeer)
+						r.Get("/documents", federationHandler.HandleListDocuments)
+					}
+					if cfg.FederationClient != nil {
+						r.Get("/status", federationHandler.HandleGetStatus)
+						r.Post("/push", federationHandler.HandlePush)
+					}
+				})
+			}
+
+			// Signer key checks and encrypted backups
+			if cfg.SigningKeyService != nil {
+				signingKeyHandler := apiAdmin.NewSigningKeyHandler(cfg.SigningKeyService)
+				r.Route("/signing-key", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", signingKeyHandler.HandleGetStatus)
+					r.Post("/check", signingKeyHandler.HandleCheck)
+					r.Post("/backup", signingKeyHandler.HandleExportBackup)
+				})
+			}
+
+			// Signature anti-replay metrics
+			if cfg.NonceService != nil {
+				replayHandler := apiAdmin.NewReplayProtectionHandler(cfg.NonceService)
+				r.With(can(models.PermissionSettingsManage)).Get("/replay-protection", replayHandler.HandleGetStats)
+			}
+
+			// Expired session cleanup metrics and forced runs
+			if cfg.SessionCleaner != nil {
+				cleanupHandler := apiAdmin.NewSessionCleanupHandler(cfg.SessionCleaner)
+				r.Route("/session-cleanup", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", cleanupHandler.HandleGetStats)
+					r.Post("/run", cleanupHandler.HandleRun)
+				})
+			}
+
+			// Delegated access to the directories signer groups are synced from
+			if cfg.DirectoryCredentialService != nil {
+				directoryHandler := apiAdmin.NewDirectoryCredentialsHandler(cfg.DirectoryCredentialService, cfg.BaseURL)
+				r.Route("/integrations/directory", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", directoryHandler.HandleList)
+					r.Get("/callback", directoryHandler.HandleCallback)
+					r.Post("/{provider}/connect", directoryHandler.HandleConnect)
+					r.Post("/{provider}/refresh", directoryHandler.HandleRefresh)
+					r.Delete("/{provider}", directoryHandler.HandleDisconnect)
+				})
+			}
+
+			// Integration API keys
+			if cfg.APIKeyService != nil {
+				apiKeysHandler := apiAdmin.NewAPIKeysHandler(cfg.APIKeyService)
+				r.Route("/api-keys", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/", apiKeysHandler.HandleListAPIKeys)
+					r.Post("/", apiKeysHandler.HandleCreateAPIKey)
+					r.Delete("/{id}", apiKeysHandler.HandleRevokeAPIKey)
+				})
+			}
+
 			// Settings management (configuration)
 			if cfg.ConfigService != nil {
-				settingsHandler := apiAdmin.NewSettingsHandler(cfg.ConfigService)
+				settingsHandler := apiAdmin.NewSettingsHandler(cfg.ConfigService, cfg.BaseURL)
 				r.Route("/settings", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
 					r.Get("/", settingsHandler.HandleGetSettings)
 					r.Put("/{section}", settingsHandler.HandleUpdateSection)
+					r.Get("/pending", settingsHandler.HandleGetPending)
+					r.Put("/pending/{section}", settingsHandler.HandleStageSection)
+					r.Post("/pending/apply", settingsHandler.HandleApplyPending)
+					r.Delete("/pending", settingsHandler.HandleDiscardPending)
+					r.Delete("/pending/{section}", settingsHandler.HandleDiscardPending)
 					r.Post("/test/{type}", settingsHandler.HandleTestConnection)
 					r.Post("/reset", settingsHandler.HandleResetFromENV)
+					r.Get("/secrets", settingsHandler.HandleSecretsStatus)
+					r.Post("/secrets/rotate", settingsHandler.HandleRotateSecrets)
+					if cfg.BrandingService != nil {
+						brandingHandler := apiAdmin.NewBrandingHandler(cfg.BrandingService)
+						r.Put("/branding/logo", brandingHandler.HandleUploadLogo)
+						r.Delete("/branding/logo", brandingHandler.HandleDeleteLogo)
+					}
+				})
+				r.Route("/config", func(r chi.Router) {
+					r.Use(can(models.PermissionSettingsManage))
+					r.Get("/export", settingsHandler.HandleExportConfig)
+					r.Put("/export", settingsHandler.HandleImportConfig)
 				})
 			}
 		})
@@ -398,17 +1968,13 @@ func serveOpenAPISpec(w http.ResponseWriter, r *http.Request) {
 	// Parse YAML and convert to JSON
 	var spec map[string]interface{}
 	if err := yaml.Unmarshal(yamlData, &spec); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"error":"Failed to parse OpenAPI spec"}`))
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to parse OpenAPI spec", nil)
 		return
 	}
 
 	jsonData, err := json.MarshalIndent(spec, "", "  ")
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(`{"error":"Failed to convert OpenAPI spec to JSON"}`))
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to convert OpenAPI spec to JSON", nil)
 		return
 	}
 
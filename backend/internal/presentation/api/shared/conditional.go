@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// maxStatusCacheEntries bounds the memory used by a StatusCache
+const maxStatusCacheEntries = 1000
+
+// ContentETag builds a strong ETag from the values a response is derived from
+func ContentETag(parts ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return `"` + hex.EncodeToString(h[:16]) + `"`
+}
+
+// CheckNotModified sets the ETag and Last-Modified validators and answers conditional GETs.
+// It returns true when a 304 Not Modified was written and the handler must stop.
+// If-None-Match takes precedence over If-Modified-Since (RFC 9110, section 13.2.2).
+// A zero lastModified disables the Last-Modified validator.
+func CheckNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" || !etagMatches(inm, etag) {
+			return false
+		}
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ims)
+		// HTTP dates have a one second resolution
+		if err != nil || lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+
+	return false
+}
+
+// CacheBypass makes the reads of a request skip server-side caches when the
+// nocache query parameter is set (e.g. ?nocache=1), to debug suspected stale data
+func CacheBypass(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.ToLower(r.URL.Query().Get("nocache")) {
+		case "", "0", "false":
+			next.ServeHTTP(w, r)
+		default:
+			next.ServeHTTP(w, r.WithContext(dbctx.WithoutCache(r.Context())))
+		}
+	})
+}
+
+// etagMatches applies the weak comparison used by If-None-Match
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// CachedStatus is a computed status response with its validators
+type CachedStatus struct {
+	Data         interface{}
+	ETag         string
+	LastModified time.Time
+	// Body is the encoded response of a snapshot, written as is
+	Body []byte
+}
+
+type statusCacheEntry struct {
+	status    CachedStatus
+	expiresAt time.Time
+	reads     int
+	// hot entries are snapshots: refreshed on changes instead of expiring
+	hot         bool
+	refreshedAt time.Time
+}
+
+// StatusCache is a small in-memory TTL cache of public document status responses, keyed by document ID.
+// It absorbs the polling of embeds; entries are invalidated when a document receives a signature.
+// With snapshots enabled, very hot documents are kept as encoded snapshots instead (see EnableSnapshots).
+type StatusCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	load       StatusLoader
+	debounce   time.Duration
+	refreshing map[string]*time.Timer
+
+	mu      sync.Mutex
+	entries map[string]statusCacheEntry
+}
+
+// NewStatusCache creates a status cache; a zero ttl disables caching
+func NewStatusCache(ttl time.Duration) *StatusCache {
+	return &StatusCache{
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    make(map[string]statusCacheEntry),
+		refreshing: make(map[string]*time.Timer),
+	}
+}
+
+// Get returns the cached status of a document if it has not expired
+func (c *StatusCache) Get(docID string) (CachedStatus, bool) {
+	if c == nil || c.ttl <= 0 {
+		return CachedStatus{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[docID]
+	if !ok {
+		return CachedStatus{}, false
+	}
+	now := c.now()
+	if !now.Before(entry.expiresAt) {
+		delete(c.entries, docID)
+		return CachedStatus{}, false
+	}
+
+	entry.reads++
+	switch {
+	case entry.hot:
+		entry.expiresAt = now.Add(statusSnapshotIdle)
+		if now.Sub(entry.refreshedAt) >= statusSnapshotMaxAge {
+			c.scheduleRefresh(docID)
+		}
+	case c.load != nil && entry.reads >= hotStatusReads:
+		entry.hot = true
+		entry.status = materializeStatus(entry.status)
+		entry.expiresAt = now.Add(statusSnapshotIdle)
+		entry.refreshedAt = now
+		logger.Logger.Debug("Document status promoted to a snapshot", "doc_id", docID)
+	}
+	c.entries[docID] = entry
+	return entry.status, true
+}
+
+// Set stores the status of a document
+func (c *StatusCache) Set(docID string, status CachedStatus) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= maxStatusCacheEntries {
+		for key, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		// Still full: drop an arbitrary entry rather than grow without bound
+		for key := range c.entries {
+			if len(c.entries) < maxStatusCacheEntries {
+				break
+			}
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[docID] = statusCacheEntry{status: status, expiresAt: now.Add(c.ttl)}
+}
+
+// Invalidate drops the cached status of a document. The snapshot of a hot document is kept
+// and refreshed once the debounce interval has elapsed.
+func (c *StatusCache) Invalidate(docID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[docID]; ok && entry.hot {
+		c.scheduleRefresh(docID)
+		return
+	}
+	delete(c.entries, docID)
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	t.Parallel()
+
+	lastModified := time.Date(2025, 6, 1, 10, 0, 0, 500, time.UTC)
+	etag := ContentETag("doc", "3")
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		expected bool
+	}{
+		{name: "no validator", expected: false},
+		{name: "matching etag", header: "If-None-Match", value: etag, expected: true},
+		{name: "weak matching etag", header: "If-None-Match", value: "W/" + etag, expected: true},
+		{name: "etag in list", header: "If-None-Match", value: `"other", ` + etag, expected: true},
+		{name: "wildcard", header: "If-None-Match", value: "*", expected: true},
+		{name: "different etag", header: "If-None-Match", value: `"other"`, expected: false},
+		{name: "not modified since", header: "If-Modified-Since", value: "Sun, 01 Jun 2025 10:00:00 GMT", expected: true},
+		{name: "modified since", header: "If-Modified-Since", value: "Sun, 01 Jun 2025 09:59:59 GMT", expected: false},
+		{name: "invalid date", header: "If-Modified-Since", value: "yesterday", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+
+			assert.Equal(t, tt.expected, CheckNotModified(rec, req, etag, lastModified))
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			assert.Equal(t, "Sun, 01 Jun 2025 10:00:00 GMT", rec.Header().Get("Last-Modified"))
+			if tt.expected {
+				assert.Equal(t, http.StatusNotModified, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCheckNotModified_IfNoneMatchTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	req.Header.Set("If-Modified-Since", "Sun, 01 Jun 2025 10:00:00 GMT")
+
+	assert.False(t, CheckNotModified(httptest.NewRecorder(), req, `"current"`, time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)))
+}
+
+func TestStatusCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	cache := NewStatusCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+
+	_, ok := cache.Get("doc")
+	assert.False(t, ok)
+
+	cache.Set("doc", CachedStatus{Data: 1, ETag: `"a"`})
+	status, ok := cache.Get("doc")
+	assert.True(t, ok)
+	assert.Equal(t, `"a"`, status.ETag)
+
+	cache.Invalidate("doc")
+	_, ok = cache.Get("doc")
+	assert.False(t, ok)
+
+	cache.Set("doc", CachedStatus{Data: 2})
+	now = now.Add(30 * time.Second)
+	_, ok = cache.Get("doc")
+	assert.False(t, ok, "entry must expire after the TTL")
+
+	// A nil or disabled cache never stores anything
+	var disabled *StatusCache
+	disabled.Set("doc", CachedStatus{})
+	disabled.Invalidate("doc")
+	_, ok = disabled.Get("doc")
+	assert.False(t, ok)
+}
+
+func TestStatusCache_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	cache := NewStatusCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+
+	var mu sync.Mutex
+	loads := 0
+	refreshed := make(chan struct{}, 10)
+	cache.EnableSnapshots(func(_ context.Context, docID string) (*CachedStatus, error) {
+		mu.Lock()
+		loads++
+		mu.Unlock()
+		defer func() { refreshed <- struct{}{} }()
+		return &CachedStatus{Data: map[string]int{"signatureCount": 2}, ETag: `"b"`}, nil
+	}, 20*time.Millisecond)
+
+	cache.Set("doc", CachedStatus{Data: map[string]int{"signatureCount": 1}, ETag: `"a"`})
+	var status CachedStatus
+	for i := 0; i < hotStatusReads; i++ {
+		status, _ = cache.Get("doc")
+	}
+	assert.JSONEq(t, `{"data":{"signatureCount":1}}`, string(status.Body), "hot documents are served from an encoded snapshot")
+
+	// Snapshots outlive the TTL while they are read
+	now = now.Add(time.Minute)
+	_, ok := cache.Get("doc")
+	assert.True(t, ok)
+
+	// A burst of signatures keeps the snapshot and refreshes it once
+	for i := 0; i < 5; i++ {
+		cache.Invalidate("doc")
+	}
+	status, ok = cache.Get("doc")
+	assert.True(t, ok)
+	assert.Equal(t, `"a"`, status.ETag)
+	select {
+	case <-refreshed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("snapshot not refreshed")
+	}
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, 1, loads)
+	mu.Unlock()
+	status, _ = cache.Get("doc")
+	assert.Equal(t, `"b"`, status.ETag)
+	assert.JSONEq(t, `{"data":{"signatureCount":2}}`, string(status.Body))
+
+	rec := httptest.NewRecorder()
+	WriteStatus(rec, status)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"data":{"signatureCount":2}}`, rec.Body.String())
+
+	// Unread snapshots are dropped
+	now = now.Add(statusSnapshotIdle)
+	_, ok = cache.Get("doc")
+	assert.False(t, ok)
+}
+
+func TestCacheBypass(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query  string
+		bypass bool
+	}{
+		{"", false},
+		{"?nocache=0", false},
+		{"?nocache=false", false},
+		{"?nocache=1", true},
+		{"?nocache=true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+
+			var bypassed bool
+			handler := CacheBypass(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				bypassed = dbctx.CacheBypassed(r.Context())
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/admin/documents/doc"+tt.query, nil))
+
+			assert.Equal(t, tt.bypass, bypassed)
+		})
+	}
+}
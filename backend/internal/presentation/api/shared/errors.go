@@ -32,14 +32,36 @@ type ErrorResponse struct {
 
 // ErrorDetail contains error details
 type ErrorDetail struct {
-	Code    ErrorCode              `json:"code"`
-	Message string                 `json:"message"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Code      ErrorCode              `json:"code"`
+	Message   string                 `json:"message"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
 }
 
+// WriteError writes the error as problem+json when the client asked for it (see ProblemDetails),
+// and in the {"error": {...}} envelope otherwise
 func WriteError(w http.ResponseWriter, statusCode int, code ErrorCode, message string, details map[string]interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	writeError(w, statusCode, code, message, details, nil)
+}
+
+func WriteValidationError(w http.ResponseWriter, message string, fieldErrors map[string]string) {
+	writeError(w, http.StatusBadRequest, ErrCodeValidation, message, nil, fieldErrors)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code ErrorCode, message string, details map[string]interface{}, fieldErrors map[string]string) {
+	format := negotiatedFormat(w)
+	if format != nil && format.problem {
+		writeProblem(w, format, newProblem(statusCode, code, message, details, fieldErrors))
+		return
+	}
+
+	// The envelope keeps field errors under details.fields
+	if fieldErrors != nil {
+		if details == nil {
+			details = make(map[string]interface{})
+		}
+		details["fields"] = fieldErrors
+	}
 
 	response := ErrorResponse{
 		Error: ErrorDetail{
@@ -48,18 +70,15 @@ func WriteError(w http.ResponseWriter, statusCode int, code ErrorCode, message s
 			Details: details,
 		},
 	}
+	if format != nil {
+		response.Error.RequestID = format.requestID
+	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(response)
 }
 
-func WriteValidationError(w http.ResponseWriter, message string, fieldErrors map[string]string) {
-	details := make(map[string]interface{})
-	if fieldErrors != nil {
-		details["fields"] = fieldErrors
-	}
-	WriteError(w, http.StatusBadRequest, ErrCodeValidation, message, details)
-}
-
 func WriteUnauthorized(w http.ResponseWriter, message string) {
 	if message == "" {
 		message = "Authentication required"
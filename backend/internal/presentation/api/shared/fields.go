@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldSet holds the JSON fields requested with the fields= query parameter (sparse fieldsets).
+// A nil FieldSet selects every field.
+type FieldSet map[string]bool
+
+// ParseFields reads the comma-separated fields= parameter, checked against the JSON fields of
+// model, a struct or a pointer to one. The key fields are always included, so that clients can
+// tell items apart. It returns nil without parameter, and the unknown fields as validation
+// errors.
+func ParseFields(r *http.Request, model any, keys ...string) (FieldSet, map[string]string) {
+	raw := strings.TrimSpace(r.URL.Query().Get("fields"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(model))
+	fields := FieldSet{}
+	unknown := map[string]string{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			unknown[name] = "unknown field"
+			continue
+		}
+		fields[name] = true
+	}
+	if len(unknown) > 0 {
+		return nil, unknown
+	}
+	for _, key := range keys {
+		fields[key] = true
+	}
+	return fields, nil
+}
+
+// FieldsError is the message of a validation error listing unknown fields
+func FieldsError(unknown map[string]string) string {
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("Unknown fields: %s", strings.Join(names, ", "))
+}
+
+// Project reduces each item to the fields of the set. Fields omitted by their item (omitempty)
+// stay omitted. Without fields, the items are returned unchanged.
+func Project[T any](items []T, fields FieldSet) (any, error) {
+	if fields == nil {
+		return items, nil
+	}
+
+	projected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode item: %w", err)
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(encoded, &all); err != nil {
+			return nil, fmt.Errorf("failed to project item: %w", err)
+		}
+		kept := make(map[string]json.RawMessage, len(fields))
+		for name, value := range all {
+			if fields[name] {
+				kept[name] = value
+			}
+		}
+		projected = append(projected, kept)
+	}
+	return projected, nil
+}
+
+// jsonFieldNames returns the names under which the fields of a struct are encoded
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
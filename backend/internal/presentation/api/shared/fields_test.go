@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsTestItem struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Notes    *string `json:"notes,omitempty"`
+	Internal string  `json:"-"`
+	Count    int
+}
+
+func TestParseFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		fields  FieldSet
+		unknown []string
+	}{
+		{"no parameter", "", nil, nil},
+		{"blank parameter", "?fields=", nil, nil},
+		{"selected fields with key", "?fields=title", FieldSet{"id": true, "title": true}, nil},
+		{"spaces and empty names", "?fields=%20title%20,,notes", FieldSet{"id": true, "title": true, "notes": true}, nil},
+		{"untagged field", "?fields=Count", FieldSet{"id": true, "Count": true}, nil},
+		{"unknown fields", "?fields=title,size,owner", nil, []string{"owner", "size"}},
+		{"ignored field", "?fields=Internal", nil, []string{"Internal"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			req := httptest.NewRequest("GET", "/items"+tt.query, nil)
+			fields, unknown := ParseFields(req, &fieldsTestItem{}, "id")
+			assert.Equal(t, tt.fields, fields)
+			if tt.unknown == nil {
+				assert.Nil(t, unknown)
+				return
+			}
+			require.Len(t, unknown, len(tt.unknown))
+			for _, name := range tt.unknown {
+				assert.Equal(t, "unknown field", unknown[name])
+			}
+		})
+	}
+
+	assert.Equal(t, "Unknown fields: owner, size", FieldsError(map[string]string{"size": "unknown field", "owner": "unknown field"}))
+}
+
+func TestProject(t *testing.T) {
+	t.Parallel()
+
+	notes := "reviewed"
+	items := []*fieldsTestItem{
+		{ID: "a", Title: "Policy", Notes: &notes, Count: 2},
+		{ID: "b", Title: "Charter", Count: 1},
+	}
+
+	all, err := Project(items, nil)
+	require.NoError(t, err)
+	assert.Equal(t, items, all, "items are unchanged without fields")
+
+	projected, err := Project(items, FieldSet{"id": true, "notes": true})
+	require.NoError(t, err)
+	encoded, err := json.Marshal(projected)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"a","notes":"reviewed"},{"id":"b"}]`, string(encoded))
+
+	empty, err := Project([]*fieldsTestItem{}, FieldSet{"id": true})
+	require.NoError(t, err)
+	encoded, _ = json.Marshal(empty)
+	assert.Equal(t, "[]", string(encoded))
+}
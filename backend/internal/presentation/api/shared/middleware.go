@@ -5,12 +5,17 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 	"github.com/btouchard/ackify-ce/backend/pkg/types"
 )
@@ -29,12 +34,24 @@ const (
 	CSRFTokenCookie = "csrf_token"
 )
 
+// failureRecorder tracks failed CSRF checks for security alerts
+type failureRecorder interface {
+	RecordFailure(ctx context.Context, kind, userEmail, ip string)
+}
+
+// corsConfigProvider returns the current configuration, holding the CORS policies of the admin settings
+type corsConfigProvider interface {
+	GetConfig() *models.MutableConfig
+}
+
 // Middleware represents API middleware
 type Middleware struct {
 	authProvider providers.AuthProvider
 	csrfTokens   *sync.Map
 	baseURL      string
 	authorizer   providers.Authorizer
+	failures     failureRecorder
+	corsConfig   corsConfigProvider
 }
 
 // NewMiddleware creates a new middleware instance
@@ -47,18 +64,45 @@ func NewMiddleware(authProvider providers.AuthProvider, baseURL string, authoriz
 	}
 }
 
-// CORS middleware for handling cross-origin requests
+// SetFailureRecorder tracks the requests rejected by CSRFProtect
+func (m *Middleware) SetFailureRecorder(failures failureRecorder) {
+	m.failures = failures
+}
+
+// SetCORSConfigProvider allows the origins of the CORS policies of the admin settings, read on
+// each request so that changes apply without restart
+func (m *Middleware) SetCORSConfigProvider(provider corsConfigProvider) {
+	m.corsConfig = provider
+}
+
+const (
+	// devOrigin is the Vite dev server, always allowed with credentials
+	devOrigin = "http://localhost:5173"
+	// adminPathPrefix selects the admin CORS policy
+	adminPathPrefix = "/api/v1/admin"
+
+	corsAllMethods    = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsPublicMethods = "GET, HEAD, OPTIONS"
+	corsHeaders       = "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-CSRF-Token, If-Match, Idempotency-Key"
+	corsExposeHeaders = "X-CSRF-Token, ETag, Idempotent-Replayed, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, X-RateLimit-Policy"
+)
+
+// CORS middleware for handling cross-origin requests. Besides the dev server, origins are
+// allowed by the admin policy on the admin API, with credentials, and by the public policy
+// elsewhere, without credentials.
 func (m *Middleware) CORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
 
 		// In development, allow localhost:5173 (Vite dev server)
-		if origin == "http://localhost:5173" {
+		if origin == devOrigin {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, Authorization, X-CSRF-Token")
-			w.Header().Set("Access-Control-Expose-Headers", "X-CSRF-Token")
+			w.Header().Set("Access-Control-Allow-Methods", corsAllMethods)
+			w.Header().Set("Access-Control-Allow-Headers", corsHeaders)
+			w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		} else if origin != "" && m.corsConfig != nil {
+			m.applyCORSPolicy(w, r, origin)
 		}
 
 		// Handle preflight requests
@@ -71,6 +115,45 @@ func (m *Middleware) CORS(next http.Handler) http.Handler {
 	})
 }
 
+// applyCORSPolicy sets the CORS headers of the policy matching the path when it allows the origin
+func (m *Middleware) applyCORSPolicy(w http.ResponseWriter, r *http.Request, origin string) {
+	current := m.corsConfig.GetConfig()
+	if current == nil {
+		return
+	}
+
+	admin := strings.HasPrefix(r.URL.Path, adminPathPrefix)
+	policy, methods := current.Security.CORS.Public, corsPublicMethods
+	if admin {
+		policy, methods = current.Security.CORS.Admin, corsAllMethods
+	}
+
+	// The answer depends on the origin even when it is refused
+	w.Header().Add("Vary", "Origin")
+	if !policy.AllowsOrigin(origin) {
+		return
+	}
+
+	headers := corsHeaders
+	if len(policy.AllowedMethods) > 0 {
+		methods = strings.ToUpper(strings.Join(policy.AllowedMethods, ", "))
+	}
+	if len(policy.AllowedHeaders) > 0 {
+		headers = strings.Join(policy.AllowedHeaders, ", ")
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if admin {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+	w.Header().Set("Access-Control-Allow-Methods", methods)
+	w.Header().Set("Access-Control-Allow-Headers", headers)
+	w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+	if policy.MaxAgeSeconds > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(policy.MaxAgeSeconds))
+	}
+}
+
 // RequireAuth middleware ensures user is authenticated
 func (m *Middleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -158,6 +241,112 @@ func (m *Middleware) RequireAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// RequirePermission middleware ensures the admin user holds a delegated permission.
+// Must run after RequireAdmin. Authorizers without role support grant every permission to admins.
+func (m *Middleware) RequirePermission(permission models.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			permAuthorizer, ok := m.authorizer.(providers.PermissionAuthorizer)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, ok := GetUserFromContext(r.Context())
+			if !ok || user == nil {
+				WriteUnauthorized(w, "Authentication required")
+				return
+			}
+
+			if !permAuthorizer.HasPermission(r.Context(), user.Email, permission) {
+				logger.Logger.Warn("admin_permission_denied",
+					"request_id", getRequestID(r.Context()),
+					"user_email", user.Email,
+					"permission", permission,
+					"path", r.URL.Path)
+				WriteForbidden(w, "Missing permission: "+string(permission))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ScopeDepartments limits the requests of department-admins to their department subtree.
+// Must run after RequireAdmin or OptionalAuth, within the RLS transaction: the subtree is set
+// as app.department_ids for the RLS policies and stored in the request context.
+// Requests that cannot be scoped are denied rather than served unscoped.
+func (m *Middleware) ScopeDepartments(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deptAuthorizer, ok := m.authorizer.(providers.DepartmentAuthorizer)
+		user, authenticated := GetUserFromContext(r.Context())
+		if !ok || !authenticated || user == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		requestID := getRequestID(r.Context())
+		departmentIDs, err := deptAuthorizer.DepartmentScope(r.Context(), user.Email)
+		if err != nil {
+			logger.Logger.Error("department_scope_failed",
+				"request_id", requestID,
+				"user_email", user.Email,
+				"error", err.Error())
+			WriteForbidden(w, "Department scope unavailable")
+			return
+		}
+		if departmentIDs == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tx := dbctx.TxFromContext(r.Context())
+		if tx == nil {
+			logger.Logger.Error("department_scope_without_transaction",
+				"request_id", requestID,
+				"user_email", user.Email)
+			WriteInternalError(w)
+			return
+		}
+		if _, err := tx.ExecContext(r.Context(), "SELECT set_config('app.department_ids', $1, true)", departmentArray(departmentIDs)); err != nil {
+			logger.Logger.Error("department_scope_set_failed",
+				"request_id", requestID,
+				"user_email", user.Email,
+				"error", err.Error())
+			WriteInternalError(w)
+			return
+		}
+
+		logger.Logger.Debug("department_scope_set",
+			"request_id", requestID,
+			"user_email", user.Email,
+			"departments", departmentIDs)
+		next.ServeHTTP(w, r.WithContext(dbctx.WithDepartmentScope(r.Context(), departmentIDs)))
+	})
+}
+
+// RequireTenantWide denies department-admins the features that are not scoped to departments.
+// Must run after ScopeDepartments.
+func RequireTenantWide(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if dbctx.DepartmentScope(r.Context()) != nil {
+			WriteForbidden(w, "Not available to department admins")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// departmentArray formats department IDs as a Postgres array literal
+func departmentArray(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
 // GenerateCSRFToken generates a new CSRF token
 func (m *Middleware) GenerateCSRFToken() (string, error) {
 	b := make([]byte, 32)
@@ -214,6 +403,7 @@ func (m *Middleware) CSRFProtect(next http.Handler) http.Handler {
 		}
 
 		if !m.ValidateCSRFToken(token) {
+			m.recordCSRFFailure(r)
 			WriteError(w, http.StatusForbidden, ErrCodeCSRFInvalid, "Invalid or missing CSRF token", nil)
 			return
 		}
@@ -233,6 +423,18 @@ func (m *Middleware) cleanExpiredTokens() {
 	})
 }
 
+// recordCSRFFailure tracks a rejected request, by user when an earlier middleware authenticated it
+func (m *Middleware) recordCSRFFailure(r *http.Request) {
+	if m.failures == nil {
+		return
+	}
+	userEmail := ""
+	if user, ok := GetUserFromContext(r.Context()); ok {
+		userEmail = user.NormalizedEmail()
+	}
+	m.failures.RecordFailure(r.Context(), models.AuthFailureCSRF, userEmail, RemoteIP(r))
+}
+
 // GetUserFromContext retrieves the user from the request context
 func GetUserFromContext(ctx context.Context) (*types.User, bool) {
 	user, ok := ctx.Value(ContextKeyUser).(*types.User)
@@ -271,6 +473,7 @@ type RateLimit struct {
 	attempts *sync.Map
 	limit    int
 	window   time.Duration
+	name     string
 }
 
 // NewRateLimit creates a new rate limiter
@@ -279,44 +482,91 @@ func NewRateLimit(limit int, window time.Duration) *RateLimit {
 		attempts: &sync.Map{},
 		limit:    limit,
 		window:   window,
+		name:     "general",
 	}
 }
 
+// WithName names the route group the limiter protects, in the X-RateLimit-Policy header and the quota document
+func (rl *RateLimit) WithName(name string) *RateLimit {
+	rl.name = name
+	return rl
+}
+
+// Quota returns the limit of an IP and how much of it is left, without counting a request
+func (rl *RateLimit) Quota(ip string) *models.RateLimitQuota {
+	var valid []time.Time
+	if val, ok := rl.attempts.Load(ip); ok {
+		valid = rl.recent(val.([]time.Time), time.Now())
+	}
+	return rl.quota(valid, time.Now())
+}
+
+// recent filters out the attempts older than the window
+func (rl *RateLimit) recent(attempts []time.Time, now time.Time) []time.Time {
+	var valid []time.Time
+	for _, t := range attempts {
+		if now.Sub(t) < rl.window {
+			valid = append(valid, t)
+		}
+	}
+	return valid
+}
+
+// quota describes the limit given the attempts still in the window, oldest first
+func (rl *RateLimit) quota(valid []time.Time, now time.Time) *models.RateLimitQuota {
+	quota := &models.RateLimitQuota{
+		Name:          rl.name,
+		Scope:         models.RateLimitScopeIP,
+		Limit:         rl.limit,
+		WindowSeconds: int(rl.window.Seconds()),
+		Remaining:     max(rl.limit-len(valid), 0),
+	}
+	if len(valid) > 0 {
+		quota.ResetSeconds = int(math.Ceil(valid[0].Add(rl.window).Sub(now).Seconds()))
+	}
+	return quota
+}
+
+// writeRateLimitHeaders publishes the quota in the X-RateLimit-* headers. When several limiters
+// apply to a route, the headers describe the one with the fewest remaining requests.
+func writeRateLimitHeaders(w http.ResponseWriter, quota *models.RateLimitQuota) {
+	if current := w.Header().Get("X-RateLimit-Remaining"); current != "" {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= quota.Remaining {
+			return
+		}
+	}
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(quota.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(quota.Remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.Itoa(quota.ResetSeconds))
+	w.Header().Set("X-RateLimit-Policy", fmt.Sprintf("%s;q=%d;w=%d", quota.Name, quota.Limit, quota.WindowSeconds))
+}
+
 // RateLimitMiddleware creates a rate limiting middleware
 func (rl *RateLimit) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Use IP address as identifier
-		ip := r.RemoteAddr
-		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-			ip = strings.Split(forwarded, ",")[0]
-		}
+		// Use the client IP resolved by RealIP; forwarding headers are not trusted here
+		ip := RemoteIP(r)
 
 		now := time.Now()
 
 		// Check current attempts
+		var valid []time.Time
 		if val, ok := rl.attempts.Load(ip); ok {
-			attempts := val.([]time.Time)
-
-			// Filter out old attempts
-			var valid []time.Time
-			for _, t := range attempts {
-				if now.Sub(t) < rl.window {
-					valid = append(valid, t)
-				}
-			}
+			valid = rl.recent(val.([]time.Time), now)
 
 			if len(valid) >= rl.limit {
+				quota := rl.quota(valid, now)
+				writeRateLimitHeaders(w, quota)
+				w.Header().Set("Retry-After", strconv.Itoa(quota.ResetSeconds))
 				WriteError(w, http.StatusTooManyRequests, ErrCodeRateLimited, "Rate limit exceeded", map[string]interface{}{
 					"retryAfter": rl.window.Seconds(),
 				})
 				return
 			}
-
-			valid = append(valid, now)
-			rl.attempts.Store(ip, valid)
-		} else {
-			rl.attempts.Store(ip, []time.Time{now})
 		}
+		valid = append(valid, now)
+		rl.attempts.Store(ip, valid)
+		writeRateLimitHeaders(w, rl.quota(valid, now))
 
 		next.ServeHTTP(w, r)
 	})
@@ -3,6 +3,7 @@ package shared
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 	"github.com/btouchard/ackify-ce/backend/pkg/types"
@@ -274,6 +276,59 @@ func TestMiddleware_CORS(t *testing.T) {
 	}
 }
 
+type staticCORSConfig struct {
+	cfg *models.MutableConfig
+}
+
+func (s *staticCORSConfig) GetConfig() *models.MutableConfig {
+	return s.cfg
+}
+
+func TestMiddleware_CORS_ConfiguredPolicies(t *testing.T) {
+	t.Parallel()
+
+	m, _ := createTestMiddleware([]string{})
+	provider := &staticCORSConfig{cfg: &models.MutableConfig{Security: models.SecurityConfig{CORS: models.CORSConfig{
+		Public: models.CORSPolicy{AllowedOrigins: []string{"*"}, MaxAgeSeconds: 600},
+		Admin:  models.CORSPolicy{AllowedOrigins: []string{"https://console.example.com"}, AllowedMethods: []string{"get", "post"}},
+	}}}}
+	m.SetCORSConfigProvider(provider)
+	handler := m.CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method, path, origin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Public endpoints: any origin, read methods, no credentials
+	rec := serve(http.MethodOptions, "/api/v1/documents/policy-2025", "https://portal.example.org")
+	assert.Equal(t, "https://portal.example.org", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, HEAD, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+
+	// Admin API: listed origins only, with credentials
+	rec = serve(http.MethodGet, "/api/v1/admin/documents", "https://console.example.com")
+	assert.Equal(t, "https://console.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Max-Age"))
+
+	rec = serve(http.MethodGet, "/api/v1/admin/documents", "https://portal.example.org")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+
+	// Changes of the settings apply to the next request
+	provider.cfg = &models.MutableConfig{}
+	rec = serve(http.MethodGet, "/api/v1/documents/policy-2025", "https://portal.example.org")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
 // ============================================================================
 // TESTS - RequireAuth Middleware
 // ============================================================================
@@ -521,6 +576,167 @@ func TestMiddleware_RequireAdmin_Forbidden(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec2.Code)
 }
 
+// ============================================================================
+// TESTS - RequirePermission Middleware
+// ============================================================================
+
+// mockPermissionAuthorizer is a test implementation of providers.PermissionAuthorizer
+type mockPermissionAuthorizer struct {
+	*mockAuthorizer
+	roles map[string]models.AdminRole
+}
+
+func (m *mockPermissionAuthorizer) HasPermission(_ context.Context, email string, permission models.Permission) bool {
+	return m.roles[strings.ToLower(email)].HasPermission(permission)
+}
+
+func (m *mockPermissionAuthorizer) Permissions(_ context.Context, email string) []models.Permission {
+	return m.roles[strings.ToLower(email)].Permissions()
+}
+
+func TestMiddleware_RequirePermission(t *testing.T) {
+	t.Parallel()
+
+	authorizer := &mockPermissionAuthorizer{
+		mockAuthorizer: newMockAuthorizer([]string{"viewer@example.com", "manager@example.com"}, false),
+		roles: map[string]models.AdminRole{
+			"viewer@example.com":  models.AdminRoleViewer,
+			"manager@example.com": models.AdminRoleDocumentManager,
+		},
+	}
+	m := NewMiddleware(newMockAuthProvider(), testBaseURL, authorizer)
+
+	tests := []struct {
+		name           string
+		email          string
+		permission     models.Permission
+		expectedStatus int
+	}{
+		{"viewer can read", "viewer@example.com", models.PermissionDocumentsRead, http.StatusOK},
+		{"viewer cannot write", "viewer@example.com", models.PermissionDocumentsWrite, http.StatusForbidden},
+		{"manager can write", "Manager@Example.com", models.PermissionDocumentsWrite, http.StatusOK},
+		{"manager cannot manage roles", "manager@example.com", models.PermissionRolesManage, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := m.RequirePermission(tt.permission)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/test", nil)
+			req = req.WithContext(context.WithValue(req.Context(), ContextKeyUser, &types.User{Email: tt.email}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestMiddleware_RequirePermission_LegacyAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	m, _ := createTestMiddleware([]string{"admin@example.com"})
+
+	nextCalled := false
+	handler := m.RequirePermission(models.PermissionRolesManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/test", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.True(t, nextCalled, "Authorizers without role support should not restrict admins")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// ============================================================================
+// TESTS - ScopeDepartments Middleware
+// ============================================================================
+
+// mockDepartmentAuthorizer is a test implementation of providers.DepartmentAuthorizer
+type mockDepartmentAuthorizer struct {
+	*mockAuthorizer
+	scopes map[string][]int64
+	err    error
+}
+
+func (m *mockDepartmentAuthorizer) DepartmentScope(_ context.Context, email string) ([]int64, error) {
+	return m.scopes[strings.ToLower(email)], m.err
+}
+
+func TestMiddleware_ScopeDepartments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		err            error
+		email          string
+		expectedStatus int
+	}{
+		{"unscoped admin", nil, "admin@example.com", http.StatusOK},
+		{"scoped admin without transaction", nil, "dept@example.com", http.StatusInternalServerError},
+		{"scope lookup failure", fmt.Errorf("db down"), "dept@example.com", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			authorizer := &mockDepartmentAuthorizer{
+				mockAuthorizer: newMockAuthorizer([]string{"admin@example.com", "dept@example.com"}, false),
+				scopes:         map[string][]int64{"dept@example.com": {3, 7}},
+				err:            tt.err,
+			}
+			m := NewMiddleware(newMockAuthProvider(), testBaseURL, authorizer)
+
+			nextCalled := false
+			handler := m.ScopeDepartments(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/test", nil)
+			req = req.WithContext(context.WithValue(req.Context(), ContextKeyUser, &types.User{Email: tt.email}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedStatus == http.StatusOK, nextCalled)
+		})
+	}
+}
+
+func TestDepartmentArray(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "{3,7,12}", departmentArray([]int64{3, 7, 12}))
+	assert.Equal(t, "{}", departmentArray(nil))
+}
+
+func TestRequireTenantWide(t *testing.T) {
+	t.Parallel()
+
+	handler := RequireTenantWide(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/signer-groups", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/signer-groups", nil)
+	req = req.WithContext(dbctx.WithDepartmentScope(req.Context(), []int64{1}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
 // ============================================================================
 // TESTS - CSRF Token Generation & Validation
 // ============================================================================
@@ -758,6 +974,51 @@ func TestMiddleware_CSRFProtect_InvalidToken(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
+type recordedFailure struct {
+	kind      string
+	userEmail string
+	ip        string
+}
+
+type mockFailureRecorder struct {
+	failures []recordedFailure
+}
+
+func (m *mockFailureRecorder) RecordFailure(_ context.Context, kind, userEmail, ip string) {
+	m.failures = append(m.failures, recordedFailure{kind: kind, userEmail: userEmail, ip: ip})
+}
+
+func TestMiddleware_CSRFProtect_RecordsFailures(t *testing.T) {
+	t.Parallel()
+
+	m, _ := createTestMiddleware([]string{})
+	recorder := &mockFailureRecorder{}
+	m.SetFailureRecorder(recorder)
+
+	handler := m.CSRFProtect(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("data"))
+	req.RemoteAddr = "203.0.113.7:4321"
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyUser, &types.User{Email: "Alice@Example.com"}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, []recordedFailure{{kind: models.AuthFailureCSRF, userEmail: "alice@example.com", ip: "203.0.113.7"}}, recorder.failures)
+
+	// Valid tokens and safe methods are not tracked
+	token, err := m.GenerateCSRFToken()
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("data"))
+	req.Header.Set(CSRFTokenHeader, token)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.Len(t, recorder.failures, 1)
+}
+
 // ============================================================================
 // TESTS - SecurityHeaders Middleware
 // ============================================================================
@@ -936,7 +1197,46 @@ func TestRateLimit_Middleware_DifferentIPs(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code, "Different IP should not be rate limited")
 }
 
-func TestRateLimit_Middleware_XForwardedFor(t *testing.T) {
+func TestRateLimit_Middleware_Headers(t *testing.T) {
+	t.Parallel()
+
+	general := NewRateLimit(100, time.Minute)
+	auth := NewRateLimit(2, time.Minute).WithName("auth")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := general.Middleware(auth.Middleware(next))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "192.168.1.1:1234"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// The headers describe the limiter closest to exhaustion
+	rec := send()
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", rec.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, "auth;q=2;w=60", rec.Header().Get("X-RateLimit-Policy"))
+
+	send()
+	rec = send()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	quota := general.Quota("192.168.1.1")
+	assert.Equal(t, "general", quota.Name)
+	assert.Equal(t, 97, quota.Remaining)
+	assert.Equal(t, 100, general.Quota("192.168.1.2").Remaining)
+	assert.Equal(t, 0, general.Quota("192.168.1.2").ResetSeconds)
+}
+
+func TestRateLimit_Middleware_IgnoresXForwardedFor(t *testing.T) {
 	t.Parallel()
 
 	rl := NewRateLimit(2, 1*time.Minute)
@@ -947,21 +1247,21 @@ func TestRateLimit_Middleware_XForwardedFor(t *testing.T) {
 
 	handler := rl.Middleware(next)
 
-	// Make 2 requests with X-Forwarded-For
+	// Make 2 requests with a different spoofed X-Forwarded-For each time
 	for i := 0; i < 2; i++ {
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
 		req.RemoteAddr = "192.168.1.99:1234"
-		req.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("10.0.0.%d", i))
 		rec := httptest.NewRecorder()
 
 		handler.ServeHTTP(rec, req)
 		assert.Equal(t, http.StatusOK, rec.Code)
 	}
 
-	// 3rd request should be limited (using first IP from X-Forwarded-For)
+	// 3rd request should be limited: the client IP comes from RemoteAddr (resolved by RealIP)
 	req := httptest.NewRequest(http.MethodGet, "/test", nil)
-	req.RemoteAddr = "192.168.1.99:1234"
-	req.Header.Set("X-Forwarded-For", "10.0.0.1, 10.0.0.2")
+	req.RemoteAddr = "192.168.1.99:5678"
+	req.Header.Set("X-Forwarded-For", "10.0.0.42")
 	rec := httptest.NewRecorder()
 
 	handler.ServeHTTP(rec, req)
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// ErrCodeNetworkForbidden is returned when the client network is not allowed by a network policy
+const ErrCodeNetworkForbidden ErrorCode = "NETWORK_FORBIDDEN"
+
+// Reasons reported when a network policy blocks a request
+const (
+	NetworkBlockDenied     = "denied"
+	NetworkBlockNotAllowed = "not_allowed"
+)
+
+// NetworkPolicyConfig configures a NetworkPolicy
+type NetworkPolicyConfig struct {
+	Scope       string         // Name reported in responses and logs, e.g. "admin" or "app"
+	PathPrefix  string         // Only requests under this path are checked ("" checks every request)
+	ExemptPaths []string       // Paths never checked, e.g. the health endpoint
+	Allow       []netip.Prefix // Empty allows every network not denied
+	Deny        []netip.Prefix // Checked first
+
+	// OnBlocked is called for every blocked request (optional, for audit logging)
+	OnBlocked func(r *http.Request, scope string, clientIP netip.Addr, reason string)
+}
+
+// NetworkPolicy restricts requests to client IPs matching CIDR allow/deny lists
+type NetworkPolicy struct {
+	cfg NetworkPolicyConfig
+}
+
+// NewNetworkPolicy creates a network policy
+func NewNetworkPolicy(cfg NetworkPolicyConfig) *NetworkPolicy {
+	return &NetworkPolicy{cfg: cfg}
+}
+
+// Enabled reports whether the policy has any allow or deny rule
+func (p *NetworkPolicy) Enabled() bool {
+	return len(p.cfg.Allow) > 0 || len(p.cfg.Deny) > 0
+}
+
+// Check returns the reason why ip is blocked, or "" when it is allowed
+func (p *NetworkPolicy) Check(ip netip.Addr) string {
+	if !ip.IsValid() {
+		if len(p.cfg.Allow) > 0 {
+			return NetworkBlockNotAllowed
+		}
+		return ""
+	}
+	if containsAddr(p.cfg.Deny, ip) {
+		return NetworkBlockDenied
+	}
+	if len(p.cfg.Allow) > 0 && !containsAddr(p.cfg.Allow, ip) {
+		return NetworkBlockNotAllowed
+	}
+	return ""
+}
+
+// Middleware rejects requests from blocked client networks with 403
+func (p *NetworkPolicy) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !p.applies(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// RemoteAddr holds the client address resolved by RealIP
+		ip := parseAddr(r.RemoteAddr)
+		reason := p.Check(ip)
+		if reason == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		logger.Logger.Warn("Request blocked by network policy",
+			"scope", p.cfg.Scope,
+			"client_ip", ip.String(),
+			"reason", reason,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"remote_addr", r.RemoteAddr)
+		if p.cfg.OnBlocked != nil {
+			p.cfg.OnBlocked(r, p.cfg.Scope, ip, reason)
+		}
+
+		WriteError(w, http.StatusForbidden, ErrCodeNetworkForbidden, "Access from your network is not allowed", map[string]interface{}{
+			"scope":    p.cfg.Scope,
+			"clientIp": ip.String(),
+		})
+	})
+}
+
+func (p *NetworkPolicy) applies(path string) bool {
+	if !p.Enabled() {
+		return false
+	}
+	for _, exempt := range p.cfg.ExemptPaths {
+		if path == exempt {
+			return false
+		}
+	}
+	if p.cfg.PathPrefix == "" {
+		return true
+	}
+	return path == p.cfg.PathPrefix || strings.HasPrefix(path, strings.TrimSuffix(p.cfg.PathPrefix, "/")+"/")
+}
+
+func containsAddr(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
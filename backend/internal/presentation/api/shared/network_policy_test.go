@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustPrefixes(t *testing.T, values ...string) []netip.Prefix {
+	t.Helper()
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		p, err := netip.ParsePrefix(v)
+		require.NoError(t, err)
+		prefixes = append(prefixes, p)
+	}
+	return prefixes
+}
+
+func TestNetworkPolicy_Middleware(t *testing.T) {
+	var blocked []string
+	policy := NewNetworkPolicy(NetworkPolicyConfig{
+		Scope:      "admin",
+		PathPrefix: "/api/v1/admin",
+		Allow:      mustPrefixes(t, "192.168.0.0/16"),
+		Deny:       mustPrefixes(t, "192.168.66.0/24"),
+		OnBlocked: func(_ *http.Request, scope string, ip netip.Addr, reason string) {
+			blocked = append(blocked, scope+" "+ip.String()+" "+reason)
+		},
+	})
+	handler := policy.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		wantStatus int
+	}{
+		{"allowed network", "/api/v1/admin/documents", "192.168.1.10:1234", http.StatusOK},
+		{"address resolved by RealIP", "/api/v1/admin/documents", "192.168.1.10", http.StatusOK},
+		{"outside allow list", "/api/v1/admin/documents", "203.0.113.7:1234", http.StatusForbidden},
+		{"deny wins over allow", "/api/v1/admin", "192.168.66.5:1234", http.StatusForbidden},
+		{"other paths unaffected", "/api/v1/documents", "203.0.113.7:1234", http.StatusOK},
+		{"prefix must match a path segment", "/api/v1/administrators", "203.0.113.7:1234", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+
+	assert.Equal(t, []string{
+		"admin 203.0.113.7 not_allowed",
+		"admin 192.168.66.5 denied",
+	}, blocked)
+}
+
+func TestNetworkPolicy_ForbiddenResponse(t *testing.T) {
+	policy := NewNetworkPolicy(NetworkPolicyConfig{
+		Scope:       "app",
+		ExemptPaths: []string{"/api/v1/health"},
+		Deny:        mustPrefixes(t, "203.0.113.0/24"),
+	})
+	handler := policy.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/health", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code, "exempt paths are never blocked")
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:1234"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	var body ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, ErrCodeNetworkForbidden, body.Error.Code)
+	assert.Equal(t, "app", body.Error.Details["scope"])
+	assert.Equal(t, "203.0.113.7", body.Error.Details["clientIp"])
+}
+
+func TestNetworkPolicy_Disabled(t *testing.T) {
+	policy := NewNetworkPolicy(NetworkPolicyConfig{Scope: "app"})
+	assert.False(t, policy.Enabled())
+	assert.Equal(t, "", policy.Check(netip.MustParseAddr("203.0.113.7")))
+}
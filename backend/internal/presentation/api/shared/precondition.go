@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPrecondition is returned when an If-Match header cannot be parsed as a resource version
+var ErrInvalidPrecondition = errors.New("invalid If-Match header")
+
+// VersionETag formats a resource version as a strong ETag value
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// SetVersionETag exposes the resource version through the ETag response header
+func SetVersionETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", VersionETag(version))
+}
+
+// ParseIfMatchVersion extracts the expected resource version from the If-Match header.
+// Returns nil when no precondition applies (header absent or "*").
+func ParseIfMatchVersion(r *http.Request) (*int, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	// Only a single entity tag is meaningful for version-based locking
+	if strings.Contains(header, ",") {
+		return nil, ErrInvalidPrecondition
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	tag = strings.Trim(tag, `"`)
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version < 1 {
+		return nil, ErrInvalidPrecondition
+	}
+	return &version, nil
+}
+
+// ResolveExpectedVersion combines the If-Match header with an optional version field from the request body.
+// The header takes precedence; a mismatch between both is reported as an invalid precondition.
+func ResolveExpectedVersion(r *http.Request, bodyVersion *int) (*int, error) {
+	headerVersion, err := ParseIfMatchVersion(r)
+	if err != nil {
+		return nil, err
+	}
+	if headerVersion != nil && bodyVersion != nil && *headerVersion != *bodyVersion {
+		return nil, ErrInvalidPrecondition
+	}
+	if headerVersion != nil {
+		return headerVersion, nil
+	}
+	return bodyVersion, nil
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIfMatchVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		header   string
+		expected *int
+		wantErr  bool
+	}{
+		{name: "absent", header: "", expected: nil},
+		{name: "wildcard", header: "*", expected: nil},
+		{name: "strong etag", header: `"3"`, expected: intPtr(3)},
+		{name: "weak etag", header: `W/"7"`, expected: intPtr(7)},
+		{name: "bare number", header: "12", expected: intPtr(12)},
+		{name: "not a number", header: `"abc"`, wantErr: true},
+		{name: "zero", header: `"0"`, wantErr: true},
+		{name: "multiple tags", header: `"1", "2"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPatch, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("If-Match", tt.header)
+			}
+
+			version, err := ParseIfMatchVersion(req)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPrecondition)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
+
+func TestResolveExpectedVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("body only", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		version, err := ResolveExpectedVersion(req, intPtr(4))
+		require.NoError(t, err)
+		assert.Equal(t, 4, *version)
+	})
+
+	t.Run("header and body agree", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		req.Header.Set("If-Match", `"4"`)
+		version, err := ResolveExpectedVersion(req, intPtr(4))
+		require.NoError(t, err)
+		assert.Equal(t, 4, *version)
+	})
+
+	t.Run("header and body disagree", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/", nil)
+		req.Header.Set("If-Match", `"4"`)
+		_, err := ResolveExpectedVersion(req, intPtr(5))
+		assert.ErrorIs(t, err, ErrInvalidPrecondition)
+	})
+}
+
+func TestSetVersionETag(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	SetVersionETag(rec, 9)
+	assert.Equal(t, `"9"`, rec.Header().Get("ETag"))
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response, extended with the machine-readable code,
+// field-level validation errors and the request ID used to correlate logs
+type Problem struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      ErrorCode              `json:"code"`
+	RequestID string                 `json:"requestId,omitempty"`
+	Errors    []FieldError           `json:"errors,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// FieldError describes why a single request field was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// errorFormatWriter carries what ProblemDetails negotiated to the error writers,
+// which only receive the http.ResponseWriter
+type errorFormatWriter struct {
+	http.ResponseWriter
+	problem   bool
+	requestID string
+	instance  string
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController (e.g. WebSocket hijacking)
+func (w *errorFormatWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ProblemDetails middleware echoes the request ID in X-Request-ID and lets clients opt in to
+// problem+json errors through the Accept header. Other clients keep the {"error": {...}} envelope.
+// Must run after AddRequestIDToContext.
+func ProblemDetails(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := getRequestID(r.Context())
+		if requestID != "" {
+			w.Header().Set("X-Request-ID", requestID)
+		}
+
+		next.ServeHTTP(&errorFormatWriter{
+			ResponseWriter: w,
+			problem:        acceptsProblem(r.Header.Get("Accept")),
+			requestID:      requestID,
+			instance:       r.URL.Path,
+		}, r)
+	})
+}
+
+// acceptsProblem reports whether the Accept header explicitly lists problem+json
+func acceptsProblem(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		return params["q"] != "0" && params["q"] != "0.0"
+	}
+	return false
+}
+
+// negotiatedFormat finds the writer installed by ProblemDetails through the wrappers of later middlewares
+func negotiatedFormat(w http.ResponseWriter) *errorFormatWriter {
+	for w != nil {
+		if fw, ok := w.(*errorFormatWriter); ok {
+			return fw
+		}
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil
+		}
+		w = u.Unwrap()
+	}
+	return nil
+}
+
+func newProblem(statusCode int, code ErrorCode, message string, details map[string]interface{}, fields map[string]string) Problem {
+	p := Problem{
+		Type:    "about:blank",
+		Title:   http.StatusText(statusCode),
+		Status:  statusCode,
+		Detail:  message,
+		Code:    code,
+		Details: details,
+	}
+
+	if len(fields) > 0 {
+		p.Errors = make([]FieldError, 0, len(fields))
+		for field, msg := range fields {
+			p.Errors = append(p.Errors, FieldError{Field: field, Message: msg})
+		}
+		sort.Slice(p.Errors, func(i, j int) bool { return p.Errors[i].Field < p.Errors[j].Field })
+	}
+
+	return p
+}
+
+func writeProblem(w http.ResponseWriter, format *errorFormatWriter, p Problem) {
+	p.RequestID = format.requestID
+	p.Instance = format.instance
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
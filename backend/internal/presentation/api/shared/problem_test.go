@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveWithProblemDetails(accept string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/documents", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), ContextKeyRequestID, "req-42"))
+
+	w := httptest.NewRecorder()
+	ProblemDetails(handler).ServeHTTP(w, req)
+	return w
+}
+
+func TestWriteError_ProblemJSON(t *testing.T) {
+	t.Parallel()
+
+	w := serveWithProblemDetails("application/problem+json", func(w http.ResponseWriter, r *http.Request) {
+		WriteValidationError(w, "Invalid input", map[string]string{
+			"url":   "Invalid URL",
+			"email": "Invalid email format",
+		})
+	})
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Expected Content-Type %s, got %s", ProblemContentType, ct)
+	}
+	if id := w.Header().Get("X-Request-ID"); id != "req-42" {
+		t.Errorf("Expected X-Request-ID req-42, got %q", id)
+	}
+
+	var p Problem
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if p.Type != "about:blank" || p.Title != "Bad Request" || p.Status != http.StatusBadRequest {
+		t.Errorf("Unexpected problem header fields: %+v", p)
+	}
+	if p.Code != ErrCodeValidation || p.Detail != "Invalid input" {
+		t.Errorf("Expected code %s and detail 'Invalid input', got %s and %q", ErrCodeValidation, p.Code, p.Detail)
+	}
+	if p.RequestID != "req-42" || p.Instance != "/api/v1/documents" {
+		t.Errorf("Expected request ID and instance, got %q and %q", p.RequestID, p.Instance)
+	}
+	if len(p.Errors) != 2 || p.Errors[0].Field != "email" || p.Errors[1].Field != "url" {
+		t.Errorf("Expected field errors sorted by field, got %+v", p.Errors)
+	}
+	if p.Details != nil {
+		t.Errorf("Expected no details, got %v", p.Details)
+	}
+}
+
+func TestWriteError_LegacyEnvelope(t *testing.T) {
+	t.Parallel()
+
+	for _, accept := range []string{"", "application/json", "*/*", "application/problem+json;q=0"} {
+		t.Run(accept, func(t *testing.T) {
+			t.Parallel()
+
+			w := serveWithProblemDetails(accept, func(w http.ResponseWriter, r *http.Request) {
+				WriteValidationError(w, "Invalid input", map[string]string{"email": "Invalid email format"})
+			})
+
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected Content-Type application/json, got %s", ct)
+			}
+
+			var response ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Error.Code != ErrCodeValidation || response.Error.RequestID != "req-42" {
+				t.Errorf("Unexpected error: %+v", response.Error)
+			}
+			if _, ok := response.Error.Details["fields"]; !ok {
+				t.Errorf("Expected field errors under details.fields, got %v", response.Error.Details)
+			}
+		})
+	}
+}
+
+func TestWriteError_ProblemThroughWrappedWriter(t *testing.T) {
+	t.Parallel()
+
+	w := serveWithProblemDetails("application/json, application/problem+json", func(w http.ResponseWriter, r *http.Request) {
+		// Later middlewares wrap the writer, as RequestLogger and the RLS middleware do
+		WriteNotFound(wrapResponseWriter(w), "Document")
+	})
+
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Fatalf("Expected Content-Type %s, got %s", ProblemContentType, ct)
+	}
+
+	var p Problem
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if p.Status != http.StatusNotFound || p.Code != ErrCodeNotFound || p.Detail != "Document not found" {
+		t.Errorf("Unexpected problem: %+v", p)
+	}
+}
+
+func TestAcceptsProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"*/*", false},
+		{"application/problem+json", true},
+		{"application/json;q=0.9, application/problem+json", true},
+		{"application/problem+json; q=0", false},
+		{"not a media type;;", false},
+	}
+
+	for _, tt := range tests {
+		if got := acceptsProblem(tt.accept); got != tt.want {
+			t.Errorf("acceptsProblem(%q) = %v, want %v", tt.accept, got, tt.want)
+		}
+	}
+}
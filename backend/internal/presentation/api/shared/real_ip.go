@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// RealIP replaces r.RemoteAddr with the client address resolved by ClientIP, so that rate
+// limiting, network policies and logs see the client behind trusted reverse proxies.
+// It must run once, before any middleware reading RemoteAddr.
+func RealIP(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := ClientIP(r, trustedProxies); ip.IsValid() {
+				r.RemoteAddr = ip.String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientIP returns the address of the client that sent the request.
+// Forwarding headers are only honored when the direct peer is a trusted proxy. The RFC 7239
+// Forwarded header is preferred, then X-Forwarded-For, then X-Real-IP. Hops are read from
+// right to left, skipping trusted proxies, so a client cannot spoof its address by
+// prepending entries.
+func ClientIP(r *http.Request, trustedProxies []netip.Prefix) netip.Addr {
+	ip := parseAddr(r.RemoteAddr)
+	if !ip.IsValid() || !containsAddr(trustedProxies, ip) {
+		return ip
+	}
+
+	var hops []string
+	switch {
+	case len(r.Header.Values("Forwarded")) > 0:
+		hops = forwardedFor(r.Header.Values("Forwarded"))
+	case len(r.Header.Values("X-Forwarded-For")) > 0:
+		hops = strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	case r.Header.Get("X-Real-IP") != "":
+		hops = []string{r.Header.Get("X-Real-IP")}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := parseAddr(strings.TrimSpace(hops[i]))
+		if !hop.IsValid() {
+			break
+		}
+		ip = hop
+		if !containsAddr(trustedProxies, hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// forwardedFor returns the "for" parameter of each element of RFC 7239 Forwarded headers.
+// Elements without one, or with an obfuscated identifier ("unknown", "_hidden"), yield an
+// empty hop, which stops the walk in ClientIP.
+func forwardedFor(values []string) []string {
+	var hops []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			hop := ""
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					hop = strings.Trim(strings.TrimSpace(val), `"`)
+					break
+				}
+			}
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// RemoteIP returns the host part of r.RemoteAddr
+func RemoteIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// parseAddr parses "ip", "ip:port", "[ipv6]" or "[ipv6]:port"
+func parseAddr(value string) netip.Addr {
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		value = host
+	}
+	addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]"))
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap()
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := mustPrefixes(t, "10.0.0.0/8")
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{"direct client", "203.0.113.7:4321", nil, "203.0.113.7"},
+		{"untrusted peer header ignored", "203.0.113.7:4321", map[string][]string{"X-Forwarded-For": {"198.51.100.1"}}, "203.0.113.7"},
+		{"untrusted peer forwarded ignored", "203.0.113.7:4321", map[string][]string{"Forwarded": {"for=198.51.100.1"}}, "203.0.113.7"},
+		{"trusted proxy", "10.0.0.2:80", map[string][]string{"X-Forwarded-For": {"198.51.100.1"}}, "198.51.100.1"},
+		{"spoofed entries before real client", "10.0.0.2:80", map[string][]string{"X-Forwarded-For": {"1.2.3.4, 198.51.100.1"}}, "198.51.100.1"},
+		{"chain of trusted proxies", "10.0.0.2:80", map[string][]string{"X-Forwarded-For": {"198.51.100.1, 10.0.0.9"}}, "198.51.100.1"},
+		{"multiple headers", "10.0.0.2:80", map[string][]string{"X-Forwarded-For": {"1.2.3.4", "198.51.100.1"}}, "198.51.100.1"},
+		{"garbage stops the walk", "10.0.0.2:80", map[string][]string{"X-Forwarded-For": {"198.51.100.1, not-an-ip"}}, "10.0.0.2"},
+		{"only trusted hops", "10.0.0.2:80", map[string][]string{"X-Forwarded-For": {"10.0.0.3"}}, "10.0.0.3"},
+		{"x-real-ip", "10.0.0.2:80", map[string][]string{"X-Real-Ip": {"198.51.100.1"}}, "198.51.100.1"},
+		{"forwarded", "10.0.0.2:80", map[string][]string{"Forwarded": {"for=192.0.2.60;proto=http;by=203.0.113.43"}}, "192.0.2.60"},
+		{"forwarded ipv6 with port", "10.0.0.2:80", map[string][]string{"Forwarded": {`For="[2001:db8:cafe::17]:4711"`}}, "2001:db8:cafe::17"},
+		{"forwarded list", "10.0.0.2:80", map[string][]string{"Forwarded": {"for=1.2.3.4, for=198.51.100.1;proto=https, for=10.0.0.9"}}, "198.51.100.1"},
+		{"forwarded preferred over x-forwarded-for", "10.0.0.2:80", map[string][]string{
+			"Forwarded":       {"for=192.0.2.60"},
+			"X-Forwarded-For": {"198.51.100.1"},
+		}, "192.0.2.60"},
+		{"forwarded obfuscated stops the walk", "10.0.0.2:80", map[string][]string{"Forwarded": {"for=192.0.2.60, for=_hidden"}}, "10.0.0.2"},
+		{"ipv6 peer", "[2001:db8::1]:443", nil, "2001:db8::1"},
+		{"ipv4-mapped peer", "[::ffff:203.0.113.7]:443", nil, "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, values := range tt.headers {
+				for _, v := range values {
+					req.Header.Add(key, v)
+				}
+			}
+			assert.Equal(t, tt.want, ClientIP(req, trusted).String())
+		})
+	}
+}
+
+func TestRealIP(t *testing.T) {
+	var seen string
+	handler := RealIP(mustPrefixes(t, "10.0.0.1/32"))(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seen = r.RemoteAddr
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:80"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "198.51.100.1", seen)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:4321"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "203.0.113.7", seen, "forwarding headers from untrusted peers are ignored")
+}
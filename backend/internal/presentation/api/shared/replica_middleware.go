@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// readReplica returns the replica connection while it is healthy, nil otherwise
+type readReplica interface {
+	DB() *sql.DB
+}
+
+// ReadReplicaMiddleware routes the heavy reads of a request (lists, statuses, statistics,
+// exports) to the read replica, keeping admin dashboards off the primary. Writes and other
+// reads keep using the RLS transaction on the primary.
+type ReadReplicaMiddleware struct {
+	replica readReplica
+	tenants providers.TenantProvider
+}
+
+// NewReadReplicaMiddleware creates a new read replica middleware.
+func NewReadReplicaMiddleware(replica readReplica, tenants providers.TenantProvider) *ReadReplicaMiddleware {
+	return &ReadReplicaMiddleware{
+		replica: replica,
+		tenants: tenants,
+	}
+}
+
+// Handler opens a read-only transaction on the replica with the same tenant and department
+// scope as the request transaction, so RLS policies apply identically. Must run after
+// ScopeDepartments. Requests are served from the primary when the replica is unhealthy
+// or unreachable.
+func (m *ReadReplicaMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		db := m.replica.DB()
+		if db == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		requestID := getRequestID(ctx)
+
+		tenantID, err := m.tenants.CurrentTenant(ctx)
+		if err != nil {
+			logger.Logger.Warn("read_replica: failed to get tenant, using primary",
+				"request_id", requestID,
+				"error", err.Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			logger.Logger.Warn("read_replica: failed to begin transaction, using primary",
+				"request_id", requestID,
+				"error", err.Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantID.String()); err != nil {
+			logger.Logger.Warn("read_replica: failed to set tenant context, using primary",
+				"request_id", requestID,
+				"error", err.Error())
+			next.ServeHTTP(w, r)
+			return
+		}
+		if departmentIDs := dbctx.DepartmentScope(ctx); departmentIDs != nil {
+			if _, err := tx.ExecContext(ctx, "SELECT set_config('app.department_ids', $1, true)", departmentArray(departmentIDs)); err != nil {
+				logger.Logger.Warn("read_replica: failed to set department scope, using primary",
+					"request_id", requestID,
+					"error", err.Error())
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(dbctx.WithReadTx(ctx, tx)))
+	})
+}
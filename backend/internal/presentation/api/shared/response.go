@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
 // Response represents a standardized API response
@@ -82,6 +84,40 @@ func (p *PaginationParams) Validate(maxPageSize int) {
 	p.Offset = (p.Page - 1) * p.PageSize
 }
 
+// CursorParams represents the query parameters of lists paginated with cursors
+type CursorParams struct {
+	Cursor *models.PageCursor // nil for the first page
+	Limit  int
+}
+
+// ParseCursorParams parses the cursor and limit query parameters. It returns nil when the
+// request has no cursor parameter, i.e. asks for offset pagination; an empty cursor asks
+// for the first page.
+func ParseCursorParams(r *http.Request, defaultLimit, maxLimit int) (*CursorParams, error) {
+	query := r.URL.Query()
+	if !query.Has("cursor") {
+		return nil, nil
+	}
+
+	params := NewPaginationParams(1, defaultLimit, maxLimit)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
+			params.PageSize = limit
+		}
+	}
+	params.Validate(maxLimit)
+
+	cursorParams := &CursorParams{Limit: params.PageSize}
+	if raw := query.Get("cursor"); raw != "" {
+		cursor, err := models.ParsePageCursor(raw)
+		if err != nil {
+			return nil, err
+		}
+		cursorParams.Cursor = cursor
+	}
+	return cursorParams, nil
+}
+
 func WriteJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -120,3 +156,15 @@ func WritePaginatedJSON(w http.ResponseWriter, data interface{}, page, limit, to
 
 	WriteJSONWithMeta(w, http.StatusOK, data, meta)
 }
+
+// WriteCursorPage writes a page of a list paginated with cursors. The cursors are empty at
+// the ends of the list.
+func WriteCursorPage(w http.ResponseWriter, data interface{}, limit int, nextCursor, prevCursor string) {
+	meta := map[string]interface{}{
+		"limit":      limit,
+		"nextCursor": nextCursor,
+		"prevCursor": prevCursor,
+	}
+
+	WriteJSONWithMeta(w, http.StatusOK, data, meta)
+}
@@ -6,6 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
 func TestWriteJSON(t *testing.T) {
@@ -222,3 +225,72 @@ func TestWritePaginatedJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCursorParams(t *testing.T) {
+	t.Parallel()
+
+	cursor := models.PageCursor{At: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Key: "42"}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectNil     bool
+		expectCursor  bool
+		expectedLimit int
+		expectErr     bool
+	}{
+		{name: "No cursor parameter", query: "page=2", expectNil: true},
+		{name: "Empty cursor", query: "cursor=", expectedLimit: 20},
+		{name: "Cursor with limit", query: "cursor=" + cursor.Encode() + "&limit=5", expectCursor: true, expectedLimit: 5},
+		{name: "Limit above max", query: "cursor=&limit=500", expectedLimit: 100},
+		{name: "Invalid cursor", query: "cursor=invalid", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest(http.MethodGet, "/items?"+tt.query, nil)
+
+			params, err := ParseCursorParams(r, 20, 100)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expectNil {
+				if params != nil {
+					t.Errorf("Expected nil params, got %+v", params)
+				}
+				return
+			}
+			if params.Limit != tt.expectedLimit {
+				t.Errorf("Expected limit %d, got %d", tt.expectedLimit, params.Limit)
+			}
+			if (params.Cursor != nil) != tt.expectCursor {
+				t.Errorf("Expected cursor set: %v, got %+v", tt.expectCursor, params.Cursor)
+			}
+		})
+	}
+}
+
+func TestWriteCursorPage(t *testing.T) {
+	t.Parallel()
+	w := httptest.NewRecorder()
+
+	WriteCursorPage(w, []string{"item1"}, 10, "next", "")
+
+	var response Response
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Meta["nextCursor"] != "next" || response.Meta["prevCursor"] != "" || response.Meta["limit"] != float64(10) {
+		t.Errorf("Unexpected meta: %v", response.Meta)
+	}
+	if _, ok := response.Meta["total"]; ok {
+		t.Error("Expected no total in cursor pages")
+	}
+}
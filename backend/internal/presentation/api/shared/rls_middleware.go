@@ -2,7 +2,9 @@
 package shared
 
 import (
+	"bufio"
 	"database/sql"
+	"net"
 	"net/http"
 
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/dbctx"
@@ -80,7 +82,7 @@ func (m *RLSMiddleware) Handler(next http.Handler) http.Handler {
 		ctxWithTx := dbctx.WithTx(ctx, tx)
 
 		// Wrap response writer to capture status code
-		wrapped := &statusCapturingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		wrapped := &statusCapturingResponseWriter{ResponseWriter: w, status: http.StatusOK, tx: tx, requestID: requestID}
 
 		// Handle panics - rollback on panic
 		defer func() {
@@ -96,6 +98,11 @@ func (m *RLSMiddleware) Handler(next http.Handler) http.Handler {
 		// Call next handler with transaction context
 		next.ServeHTTP(wrapped, r.WithContext(ctxWithTx))
 
+		// Hijacked connections (WebSocket) committed the transaction when taken over
+		if wrapped.hijacked {
+			return
+		}
+
 		// Commit or rollback based on response status
 		if wrapped.status >= 200 && wrapped.status < 400 {
 			if err := tx.Commit(); err != nil {
@@ -129,6 +136,33 @@ type statusCapturingResponseWriter struct {
 	http.ResponseWriter
 	status      int
 	wroteHeader bool
+
+	tx        *sql.Tx
+	requestID string
+	hijacked  bool
+}
+
+// Hijack takes over the connection (WebSocket upgrades) and commits the transaction:
+// the connection outlives the request and must not keep a transaction open.
+// Database work done after the hijack must open its own tenant context.
+func (w *statusCapturingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.hijacked = true
+	if err := w.tx.Commit(); err != nil {
+		logger.Logger.Error("rls_middleware: failed to commit transaction before hijack",
+			"request_id", w.requestID,
+			"error", err.Error())
+	}
+	return conn, rw, nil
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *statusCapturingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
 }
 
 func (w *statusCapturingResponseWriter) WriteHeader(code int) {
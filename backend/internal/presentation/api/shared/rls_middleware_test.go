@@ -78,6 +78,23 @@ func TestStatusCapturingResponseWriter_Write(t *testing.T) {
 	}
 }
 
+func TestStatusCapturingResponseWriter_HijackUnsupported(t *testing.T) {
+	rr := httptest.NewRecorder()
+	wrapped := &statusCapturingResponseWriter{ResponseWriter: rr, status: http.StatusOK}
+
+	if wrapped.Unwrap() != rr {
+		t.Error("Unwrap should return the underlying writer")
+	}
+
+	// httptest.ResponseRecorder cannot be hijacked: the transaction must stay managed by the middleware
+	if _, _, err := wrapped.Hijack(); err == nil {
+		t.Error("Expected hijack error for a recorder")
+	}
+	if wrapped.hijacked {
+		t.Error("hijacked should stay false when the hijack fails")
+	}
+}
+
 func TestRLSMiddleware_TenantError(t *testing.T) {
 	provider := &mockTenantProvider{err: sql.ErrNoRows}
 	m := NewRLSMiddleware(nil, provider)
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+const (
+	// hotStatusReads is the number of reads of a cached status, within its TTL, that promotes
+	// the document to a snapshot
+	hotStatusReads = 100
+	// statusSnapshotIdle drops the snapshot of a document that is no longer read
+	statusSnapshotIdle = 10 * time.Minute
+	// statusSnapshotMaxAge refreshes snapshots whose changes may have been missed, e.g. when
+	// signatures recorded by another instance are not notified
+	statusSnapshotMaxAge = 5 * time.Minute
+	// statusRefreshTimeout bounds the computation of a snapshot
+	statusRefreshTimeout = 10 * time.Second
+)
+
+// StatusLoader computes the status of a document outside of any request; a nil status means
+// the document no longer exists
+type StatusLoader func(ctx context.Context, docID string) (*CachedStatus, error)
+
+// EnableSnapshots keeps the status of very hot documents, such as the ones embedded in intranet
+// homepages, as an encoded snapshot served from memory. Snapshots do not expire while they are
+// read: signatures refresh them in the background with load, at most once per debounce interval,
+// so that a burst of signatures costs a single computation. A zero debounce disables snapshots.
+func (c *StatusCache) EnableSnapshots(load StatusLoader, debounce time.Duration) {
+	if c == nil || c.ttl <= 0 || debounce <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	c.load = load
+	c.debounce = debounce
+	c.mu.Unlock()
+}
+
+// scheduleRefresh refreshes a snapshot after the debounce interval unless a refresh is already
+// pending; the caller holds c.mu
+func (c *StatusCache) scheduleRefresh(docID string) {
+	if _, pending := c.refreshing[docID]; pending {
+		return
+	}
+	c.refreshing[docID] = time.AfterFunc(c.debounce, func() { c.refresh(docID) })
+}
+
+func (c *StatusCache) refresh(docID string) {
+	// Changes from now on schedule another refresh
+	c.mu.Lock()
+	delete(c.refreshing, docID)
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusRefreshTimeout)
+	defer cancel()
+	status, err := c.load(ctx, docID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[docID]
+	if !ok || !entry.hot {
+		return
+	}
+	if err != nil || status == nil {
+		// Let the next read compute the status rather than serve a stale snapshot
+		if err != nil {
+			logger.Logger.Warn("Failed to refresh document status snapshot", "doc_id", docID, "error", err.Error())
+		}
+		delete(c.entries, docID)
+		return
+	}
+	entry.status = materializeStatus(*status)
+	entry.refreshedAt = c.now()
+	c.entries[docID] = entry
+}
+
+// materializeStatus encodes the response of a snapshot once for all its reads
+func materializeStatus(status CachedStatus) CachedStatus {
+	body, err := json.Marshal(Response{Data: status.Data})
+	if err != nil {
+		return status
+	}
+	status.Body = append(body, '\n')
+	return status
+}
+
+// WriteStatus writes a cached status response, from its encoded body for snapshots
+func WriteStatus(w http.ResponseWriter, status CachedStatus) {
+	if status.Body == nil {
+		WriteJSON(w, http.StatusOK, status.Data)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(status.Body)
+}
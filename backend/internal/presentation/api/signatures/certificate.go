@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signatures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// certificateIssuer renders the PDF certificate of signatures and verifies them
+type certificateIssuer interface {
+	Issue(ctx context.Context, sig *models.Signature) error
+	URL(signatureID int64) string
+	Open(ctx context.Context, signatureID int64, email string) (io.ReadCloser, int64, error)
+	Verify(ctx context.Context, signatureID int64, recordHash string) (*models.CertificateVerification, error)
+}
+
+// SetCertificateIssuer issues a PDF certificate after each signature and links it from responses
+func (h *Handler) SetCertificateIssuer(certificates certificateIssuer) {
+	h.certificates = certificates
+}
+
+// issueCertificate stores the certificate of a new signature; a failure does not fail the
+// signature since the certificate is issued again on first download
+func (h *Handler) issueCertificate(ctx context.Context, sig *models.Signature) {
+	if h.certificates == nil {
+		return
+	}
+	if err := h.certificates.Issue(ctx, sig); err != nil {
+		logger.Logger.Warn("Failed to issue signature certificate", "signature_id", sig.ID, "error", err.Error())
+	}
+}
+
+// certificateURL returns the certificate link of a signature when the current user signed it
+func (h *Handler) certificateURL(ctx context.Context, sig *models.Signature) *string {
+	if h.certificates == nil {
+		return nil
+	}
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil || !strings.EqualFold(user.Email, sig.UserEmail) {
+		return nil
+	}
+	link := h.certificates.URL(sig.ID)
+	return &link
+}
+
+// HandleGetCertificate handles GET /api/v1/signatures/{id}/certificate
+func (h *Handler) HandleGetCertificate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+	id, ok := parseSignatureID(w, r)
+	if !ok {
+		return
+	}
+
+	reader, size, err := h.certificates.Open(ctx, id, user.Email)
+	if err != nil {
+		writeCertificateError(w, err, id)
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="certificate-%d.pdf"`, id))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, reader); err != nil {
+		logger.Logger.Error("Failed to stream certificate", "signature_id", id, "error", err.Error())
+	}
+}
+
+// HandleVerifyCertificate handles GET /api/v1/certificates/{id}/verify, the public link
+// of the certificate QR code
+func (h *Handler) HandleVerifyCertificate(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseSignatureID(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.certificates.Verify(r.Context(), id, r.URL.Query().Get("hash"))
+	if err != nil {
+		writeCertificateError(w, err, id)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, result)
+}
+
+func parseSignatureID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		shared.WriteValidationError(w, "Invalid signature ID", map[string]string{"id": "must be a positive integer"})
+		return 0, false
+	}
+	return id, true
+}
+
+func writeCertificateError(w http.ResponseWriter, err error, id int64) {
+	if errors.Is(err, models.ErrCertificateNotFound) {
+		shared.WriteNotFound(w, "Certificate")
+		return
+	}
+	logger.Logger.Error("Certificate request failed", "signature_id", id, "error", err.Error())
+	shared.WriteInternalError(w)
+}
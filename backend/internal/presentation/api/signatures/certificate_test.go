@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockCertificateIssuer struct {
+	issued []int64
+	err    error
+}
+
+func (m *mockCertificateIssuer) Issue(_ context.Context, sig *models.Signature) error {
+	m.issued = append(m.issued, sig.ID)
+	return m.err
+}
+
+func (m *mockCertificateIssuer) URL(signatureID int64) string {
+	return fmt.Sprintf("https://sign.example.com/api/v1/signatures/%d/certificate", signatureID)
+}
+
+func (m *mockCertificateIssuer) Open(_ context.Context, signatureID int64, email string) (io.ReadCloser, int64, error) {
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	if signatureID != testSignature.ID || email != testSignature.UserEmail {
+		return nil, 0, models.ErrCertificateNotFound
+	}
+	return io.NopCloser(strings.NewReader("%PDF-1.4")), 8, nil
+}
+
+func (m *mockCertificateIssuer) Verify(_ context.Context, signatureID int64, recordHash string) (*models.CertificateVerification, error) {
+	if signatureID != testSignature.ID {
+		return nil, models.ErrCertificateNotFound
+	}
+	return &models.CertificateVerification{SignatureID: signatureID, Valid: recordHash == "good"}, nil
+}
+
+func serveCertificate(h *Handler, path string, user *models.User) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	r.Get("/signatures/{id}/certificate", h.HandleGetCertificate)
+	r.Get("/certificates/{id}/verify", h.HandleVerifyCertificate)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if user != nil {
+		req = req.WithContext(addUserToContext(req.Context(), user))
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_HandleCreateSignature_IssuesCertificate(t *testing.T) {
+	t.Parallel()
+
+	certificates := &mockCertificateIssuer{err: errors.New("storage down")}
+	handler := &Handler{signatureService: &mockSignatureService{}}
+	handler.SetCertificateIssuer(certificates)
+
+	body, _ := json.Marshal(CreateSignatureRequest{DocID: testSignature.DocID})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+	handler.HandleCreateSignature(rec, req)
+
+	// A storage failure does not fail the signature
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, []int64{testSignature.ID}, certificates.issued)
+
+	var wrapper struct {
+		Data SignatureResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	require.NotNil(t, wrapper.Data.CertificateURL)
+	assert.Equal(t, "https://sign.example.com/api/v1/signatures/1/certificate", *wrapper.Data.CertificateURL)
+}
+
+func TestHandler_toSignatureResponse_CertificateURL(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{}
+	ctx := addUserToContext(context.Background(), testUser)
+	assert.Nil(t, handler.toSignatureResponse(ctx, testSignature).CertificateURL, "no link when certificates are disabled")
+
+	handler.SetCertificateIssuer(&mockCertificateIssuer{})
+	assert.NotNil(t, handler.toSignatureResponse(ctx, testSignature).CertificateURL)
+
+	other := addUserToContext(context.Background(), &models.User{Sub: "other", Email: "other@example.com"})
+	assert.Nil(t, handler.toSignatureResponse(other, testSignature).CertificateURL, "no link on other users' signatures")
+}
+
+func TestHandler_HandleGetCertificate(t *testing.T) {
+	t.Parallel()
+
+	certificates := &mockCertificateIssuer{}
+	handler := &Handler{}
+	handler.SetCertificateIssuer(certificates)
+
+	rec := serveCertificate(handler, "/signatures/1/certificate", testUser)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "certificate-1.pdf")
+	assert.Equal(t, "%PDF-1.4", rec.Body.String())
+
+	other := &models.User{Sub: "other", Email: "other@example.com"}
+	assert.Equal(t, http.StatusNotFound, serveCertificate(handler, "/signatures/1/certificate", other).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveCertificate(handler, "/signatures/1/certificate", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serveCertificate(handler, "/signatures/abc/certificate", testUser).Code)
+
+	certificates.err = errors.New("storage down")
+	assert.Equal(t, http.StatusInternalServerError, serveCertificate(handler, "/signatures/1/certificate", testUser).Code)
+}
+
+func TestHandler_HandleVerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{}
+	handler.SetCertificateIssuer(&mockCertificateIssuer{})
+
+	rec := serveCertificate(handler, "/certificates/1/verify?hash=good", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var wrapper struct {
+		Data models.CertificateVerification `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.True(t, wrapper.Data.Valid)
+
+	rec = serveCertificate(handler, "/certificates/1/verify?hash=bad", nil)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.False(t, wrapper.Data.Valid)
+
+	assert.Equal(t, http.StatusNotFound, serveCertificate(handler, "/certificates/2/verify?hash=good", nil).Code)
+}
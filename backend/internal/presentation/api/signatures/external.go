@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signatures
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// ExternalCodeRequest is the body of an external signer verification code request
+type ExternalCodeRequest struct {
+	Email string `json:"email"`
+}
+
+// ExternalSignatureRequest is the body of an external signature
+type ExternalSignatureRequest struct {
+	Email       string             `json:"email"`
+	Code        string             `json:"code"`
+	Name        string             `json:"name,omitempty"`
+	Referer     *string            `json:"referer,omitempty"`
+	QuizAnswers models.QuizAnswers `json:"quizAnswers,omitempty"`
+	// Display language and consent version of the signing page
+	Locale         string `json:"locale,omitempty"`
+	ConsentVersion *int   `json:"consentVersion,omitempty"`
+}
+
+// HandleGetExternalSigning handles GET /api/v1/documents/{docId}/external
+func (h *Handler) HandleGetExternalSigning(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if h.external == nil {
+		shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "enabled": false})
+		return
+	}
+
+	settings, err := h.external.GetSettings(r.Context(), docID)
+	if err != nil {
+		if errors.Is(err, models.ErrDocumentNotFound) {
+			shared.WriteNotFound(w, "Document")
+			return
+		}
+		writeExternalError(w, err, docID)
+		return
+	}
+
+	// Only the toggle is public, not who enabled it
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{"docId": docID, "enabled": settings.Enabled})
+}
+
+// HandleGetExternalQuiz handles GET /api/v1/documents/{docId}/external/quiz.
+// It serves the document quiz without a session, only for documents open to external signers.
+func (h *Handler) HandleGetExternalQuiz(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if h.external == nil {
+		shared.WriteNotFound(w, "Quiz")
+		return
+	}
+
+	settings, err := h.external.GetSettings(r.Context(), docID)
+	if err != nil {
+		if errors.Is(err, models.ErrDocumentNotFound) {
+			shared.WriteNotFound(w, "Document")
+			return
+		}
+		writeExternalError(w, err, docID)
+		return
+	}
+	if !settings.Enabled {
+		writeExternalError(w, models.ErrExternalSigningClosed, docID)
+		return
+	}
+
+	h.HandleGetDocumentQuiz(w, r)
+}
+
+// HandleRequestExternalCode handles POST /api/v1/documents/{docId}/external/request-code
+func (h *Handler) HandleRequestExternalCode(w http.ResponseWriter, r *http.Request) {
+	if h.external == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, "External signing not enabled", nil)
+		return
+	}
+
+	docID := chi.URLParam(r, "docId")
+	var req ExternalCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if req.Email == "" {
+		shared.WriteValidationError(w, "Email is required", nil)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.external.RequestCode(ctx, docID, req.Email, shared.RemoteIP(r), i18n.GetLang(ctx)); err != nil {
+		writeExternalError(w, err, docID)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "A verification code has been sent",
+	})
+}
+
+// HandleCreateExternalSignature handles POST /api/v1/documents/{docId}/external/sign
+func (h *Handler) HandleCreateExternalSignature(w http.ResponseWriter, r *http.Request) {
+	if h.external == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, "External signing not enabled", nil)
+		return
+	}
+
+	docID := chi.URLParam(r, "docId")
+	var req ExternalSignatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if req.Email == "" || req.Code == "" {
+		shared.WriteValidationError(w, "Email and code are required", nil)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.external.VerifyCode(ctx, docID, req.Email, req.Code)
+	if err != nil {
+		writeExternalError(w, err, docID)
+		return
+	}
+	user.Name = req.Name
+
+	sigRequest := &models.SignatureRequest{
+		DocID:       docID,
+		User:        user,
+		Referer:     req.Referer,
+		QuizAnswers: req.QuizAnswers,
+		IPAddress:   shared.RemoteIP(r),
+		AuthMethod:  models.AuthMethodEmailCode,
+		// The signing page language, falling back to the language the request is served in
+		Locale:         displayLocale(r, req.Locale),
+		ConsentVersion: req.ConsentVersion,
+	}
+	if err := h.signatureService.CreateSignature(ctx, sigRequest); err != nil {
+		writeCreateSignatureError(w, err, docID)
+		return
+	}
+
+	if err := h.external.ConsumeCode(ctx, docID, user.Email); err != nil {
+		logger.Logger.Warn("Failed to invalidate external signer code", "doc_id", docID, "error", err.Error())
+	}
+
+	h.onSignatureCreated(r, docID, user)
+
+	signature, err := h.signatureService.GetSignatureByDocAndUser(ctx, docID, user)
+	if err != nil {
+		shared.WriteJSON(w, http.StatusCreated, map[string]interface{}{
+			"message": "Signature created successfully",
+			"docId":   docID,
+		})
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusCreated, h.toSignatureResponse(ctx, signature))
+}
+
+func writeExternalError(w http.ResponseWriter, err error, docID string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSignerEmail):
+		shared.WriteValidationError(w, err.Error(), nil)
+	case errors.Is(err, models.ErrDomainNotAllowed):
+		shared.WriteError(w, http.StatusForbidden, "DOMAIN_NOT_ALLOWED", "This email domain is not allowed to sign", nil)
+	case errors.Is(err, models.ErrExternalSigningClosed):
+		shared.WriteError(w, http.StatusForbidden, "EXTERNAL_SIGNING_CLOSED", "This document does not accept external signers", map[string]interface{}{
+			"docId": docID,
+		})
+	case errors.Is(err, services.ErrInvalidVerificationCode):
+		shared.WriteError(w, http.StatusUnauthorized, "INVALID_CODE", "Invalid or expired verification code", nil)
+	case errors.Is(err, models.ErrEmailSuppressed):
+		shared.WriteError(w, http.StatusUnprocessableEntity, "EMAIL_SUPPRESSED", "This email address does not receive messages from us", nil)
+	default:
+		logger.Logger.Error("External signing failed", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+	}
+}
@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signatures_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/signatures"
+	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// codeCatcher keeps the verification code of the last email sent
+type codeCatcher struct {
+	code string
+}
+
+func (c *codeCatcher) Send(_ context.Context, msg email.Message) error {
+	c.code, _ = msg.Data["Code"].(string)
+	return nil
+}
+
+func TestExternalSignature_LockoutSurvivesRolledBackRequests(t *testing.T) {
+	testDB := database.SetupTestDB(t)
+	ctx := context.Background()
+	docID := "external-lockout"
+	signerEmail := "bob@partner.com"
+
+	docRepo := database.NewDocumentRepository(testDB.DB, testDB.TenantProvider)
+	sigRepo := database.NewSignatureRepository(testDB.DB, testDB.TenantProvider)
+	externalRepo := database.NewExternalSignerRepository(testDB.DB, testDB.TenantProvider)
+
+	_, err := docRepo.CreateOrUpdate(ctx, docID, models.DocumentInput{Title: "Partner agreement"}, "admin@example.com")
+	require.NoError(t, err)
+	require.NoError(t, externalRepo.Enable(ctx, docID, "admin@example.com"))
+
+	mailer := &codeCatcher{}
+	external := services.NewExternalSignerService(externalRepo, docRepo, mailer, nil, nil, nil)
+	require.NoError(t, external.RequestCode(ctx, docID, signerEmail, "192.0.2.10", "en"))
+	require.NotEmpty(t, mailer.code)
+
+	signer, err := crypto.NewEd25519Signer()
+	require.NoError(t, err)
+	handler := signatures.NewHandler(services.NewSignatureService(sigRepo, docRepo, signer), nil, nil)
+	handler.SetExternalSigner(external)
+	// Refused requests are rolled back by the RLS middleware, as in the router
+	sign := shared.NewRLSMiddleware(testDB.DB, testDB.TenantProvider).Handler(http.HandlerFunc(handler.HandleCreateExternalSignature))
+
+	post := func(code string) int {
+		body := `{"email":"` + signerEmail + `","code":"` + code + `","name":"Bob"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/documents/"+docID+"/external/sign", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("docId", docID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		sign.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	wrongCode := "000000"
+	if mailer.code == wrongCode {
+		wrongCode = "111111"
+	}
+	const maxAttempts = 5
+	for i := 0; i < maxAttempts; i++ {
+		require.Equal(t, http.StatusUnauthorized, post(wrongCode), "attempt %d", i+1)
+	}
+
+	var attempts int
+	err = testDB.DB.QueryRow(`SELECT attempts FROM external_signer_codes WHERE doc_id = $1 AND email = $2`, docID, signerEmail).Scan(&attempts)
+	require.NoError(t, err)
+	assert.Equal(t, maxAttempts, attempts, "failed attempts must be committed despite the rollback of the requests")
+
+	assert.Equal(t, http.StatusUnauthorized, post(mailer.code), "the correct code must be refused once the attempts are exhausted")
+	var signed int
+	require.NoError(t, testDB.DB.QueryRow(`SELECT COUNT(*) FROM signatures WHERE doc_id = $1`, docID).Scan(&signed))
+	assert.Zero(t, signed)
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockExternalSigner struct {
+	enabled   bool
+	verified  *models.User
+	verifyErr error
+	consumed  []string
+}
+
+func (m *mockExternalSigner) GetSettings(_ context.Context, docID string) (*models.ExternalSigning, error) {
+	return &models.ExternalSigning{DocID: docID, Enabled: m.enabled, EnabledBy: "admin@example.com"}, nil
+}
+
+func (m *mockExternalSigner) RequestCode(_ context.Context, _, _, _, _ string) error {
+	if !m.enabled {
+		return models.ErrExternalSigningClosed
+	}
+	return nil
+}
+
+func (m *mockExternalSigner) VerifyCode(_ context.Context, _, _, _ string) (*models.User, error) {
+	if m.verifyErr != nil {
+		return nil, m.verifyErr
+	}
+	user := *m.verified
+	return &user, nil
+}
+
+func (m *mockExternalSigner) ConsumeCode(_ context.Context, _, email string) error {
+	m.consumed = append(m.consumed, email)
+	return nil
+}
+
+func externalRequest(method, path, docID, body string) *http.Request {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("docId", docID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandler_HandleCreateExternalSignature_Success(t *testing.T) {
+	t.Parallel()
+
+	var recorded *models.SignatureRequest
+	sigService := &mockSignatureService{
+		createSignatureFunc: func(_ context.Context, request *models.SignatureRequest) error {
+			recorded = request
+			return nil
+		},
+		getSignatureByDocAndUserFunc: func(_ context.Context, docID string, user *models.User) (*models.Signature, error) {
+			return &models.Signature{DocID: docID, UserSub: user.Sub, UserEmail: user.Email, AuthMethod: models.AuthMethodEmailCode}, nil
+		},
+	}
+	external := &mockExternalSigner{enabled: true, verified: &models.User{Sub: "external:bob@partner.com", Email: "bob@partner.com"}}
+	handler := &Handler{signatureService: sigService, external: external}
+
+	rec := httptest.NewRecorder()
+	handler.HandleCreateExternalSignature(rec, externalRequest(http.MethodPost, "/api/v1/documents/doc1/external/sign", "doc1",
+		`{"email":"bob@partner.com","code":"123456","name":"Bob"}`))
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.NotNil(t, recorded)
+	assert.Equal(t, models.AuthMethodEmailCode, recorded.AuthMethod)
+	assert.Equal(t, "external:bob@partner.com", recorded.User.Sub)
+	assert.Equal(t, "Bob", recorded.User.Name)
+	assert.Equal(t, []string{"bob@partner.com"}, external.consumed)
+	assert.Contains(t, rec.Body.String(), `"authMethod":"email_code"`)
+}
+
+func TestHandler_HandleCreateExternalSignature_InvalidCode(t *testing.T) {
+	t.Parallel()
+
+	sigService := &mockSignatureService{
+		createSignatureFunc: func(_ context.Context, _ *models.SignatureRequest) error {
+			t.Fatal("no signature expected with an invalid code")
+			return nil
+		},
+	}
+	external := &mockExternalSigner{enabled: true, verifyErr: services.ErrInvalidVerificationCode}
+	handler := &Handler{signatureService: sigService, external: external}
+
+	rec := httptest.NewRecorder()
+	handler.HandleCreateExternalSignature(rec, externalRequest(http.MethodPost, "/api/v1/documents/doc1/external/sign", "doc1",
+		`{"email":"bob@partner.com","code":"000000"}`))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_CODE")
+	assert.Empty(t, external.consumed)
+}
+
+func TestHandler_HandleCreateExternalSignature_KeepsCodeWhenSignatureRefused(t *testing.T) {
+	t.Parallel()
+
+	sigService := &mockSignatureService{
+		createSignatureFunc: func(_ context.Context, _ *models.SignatureRequest) error {
+			return models.ErrQuizFailed
+		},
+	}
+	external := &mockExternalSigner{enabled: true, verified: &models.User{Sub: "external:bob@partner.com", Email: "bob@partner.com"}}
+	handler := &Handler{signatureService: sigService, external: external}
+
+	rec := httptest.NewRecorder()
+	handler.HandleCreateExternalSignature(rec, externalRequest(http.MethodPost, "/api/v1/documents/doc1/external/sign", "doc1",
+		`{"email":"bob@partner.com","code":"123456"}`))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Empty(t, external.consumed)
+}
+
+func TestHandler_HandleRequestExternalCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		external *mockExternalSigner
+		body     string
+		wantCode int
+	}{
+		{name: "sent", external: &mockExternalSigner{enabled: true}, body: `{"email":"bob@partner.com"}`, wantCode: http.StatusOK},
+		{name: "document closed", external: &mockExternalSigner{}, body: `{"email":"bob@partner.com"}`, wantCode: http.StatusForbidden},
+		{name: "missing email", external: &mockExternalSigner{enabled: true}, body: `{}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &Handler{signatureService: &mockSignatureService{}, external: tt.external}
+			rec := httptest.NewRecorder()
+			handler.HandleRequestExternalCode(rec, externalRequest(http.MethodPost, "/api/v1/documents/doc1/external/request-code", "doc1", tt.body))
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestHandler_HandleGetExternalSigning_HidesEnabledBy(t *testing.T) {
+	t.Parallel()
+
+	handler := &Handler{signatureService: &mockSignatureService{}, external: &mockExternalSigner{enabled: true}}
+	rec := httptest.NewRecorder()
+	handler.HandleGetExternalSigning(rec, externalRequest(http.MethodGet, "/api/v1/documents/doc1/external", "doc1", ""))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"enabled":true`)
+	assert.NotContains(t, rec.Body.String(), "admin@example.com")
+}
@@ -4,11 +4,15 @@ package signatures
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 
 	"time"
 
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -20,6 +24,7 @@ type signatureService interface {
 	GetSignatureByDocAndUser(ctx context.Context, docID string, user *models.User) (*models.Signature, error)
 	GetDocumentSignatures(ctx context.Context, docID string) ([]*models.Signature, error)
 	GetUserSignatures(ctx context.Context, user *models.User) ([]*models.Signature, error)
+	ListUserSignaturesPage(ctx context.Context, user *models.User, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error)
 }
 
 // adminService defines minimal admin operations needed for stats
@@ -32,11 +37,78 @@ type webhookPublisher interface {
 	Publish(ctx context.Context, eventType string, payload map[string]interface{}) error
 }
 
+// completionNotifier notifies document creators about signing milestones
+type completionNotifier interface {
+	OnSignature(ctx context.Context, docID string) error
+}
+
+// signatureInbox records new signatures in the document owner's notification inbox
+type signatureInbox interface {
+	OnSignature(ctx context.Context, docID, signerEmail, signerName string) error
+}
+
+// signingOrderNotifier notifies the next signers of a sequential workflow
+type signingOrderNotifier interface {
+	OnSignature(ctx context.Context, docID, signerEmail, locale string) error
+}
+
+// signerQuizReader returns the quiz signers must pass, without its answers
+type signerQuizReader interface {
+	GetSignerQuiz(ctx context.Context, docID string) (*models.SignerQuiz, error)
+}
+
+// externalSigner verifies the email of signers outside the identity provider
+type externalSigner interface {
+	GetSettings(ctx context.Context, docID string) (*models.ExternalSigning, error)
+	RequestCode(ctx context.Context, docID, email, ip, locale string) error
+	VerifyCode(ctx context.Context, docID, email, code string) (*models.User, error)
+	ConsumeCode(ctx context.Context, docID, email string) error
+}
+
+// statusInvalidator drops cached document status responses
+type statusInvalidator interface {
+	Invalidate(docID string)
+}
+
+// consentReader returns the consent text displayed next to the sign button
+type consentReader interface {
+	Resolve(ctx context.Context, docID, locale string) (*models.ConsentText, error)
+}
+
+// signRedirectResolver returns the URL signers of a document are sent to after signing
+type signRedirectResolver interface {
+	ResolveRedirect(ctx context.Context, docID string) (string, error)
+}
+
+// nonceStore issues and consumes the single-use nonces rejecting replayed signature requests
+type nonceStore interface {
+	Issue(ctx context.Context, docID string, user *models.User) (*models.SignatureNonce, error)
+	Consume(ctx context.Context, nonce, docID string, user *models.User) error
+}
+
+// idempotencyStore reserves the Idempotency-Key of signature requests and stores their response
+type idempotencyStore interface {
+	Begin(ctx context.Context, key string, user *models.User, body []byte) (*models.SignatureIdempotencyKey, error)
+	Complete(ctx context.Context, key string, user *models.User, statusCode int, response []byte) error
+}
+
 // Handler handles signature-related requests
 type Handler struct {
 	signatureService signatureService
 	adminService     adminService
 	webhookPublisher webhookPublisher
+	outbox           webhookPublisher
+	notifier         completionNotifier
+	inbox            signatureInbox
+	signingOrder     signingOrderNotifier
+	quiz             signerQuizReader
+	external         externalSigner
+	statusCache      statusInvalidator
+	nonces           nonceStore
+	idempotency      idempotencyStore
+	certificates     certificateIssuer
+	consent          consentReader
+	redirects        signRedirectResolver
 }
 
 // NewHandler constructor to inject admin service and webhook publisher
@@ -44,10 +116,84 @@ func NewHandler(signatureService signatureService, adminSvc adminService, publis
 	return &Handler{signatureService: signatureService, adminService: adminSvc, webhookPublisher: publisher}
 }
 
+// SetEventOutbox records the signature events in the transactional outbox, with the signature,
+// instead of publishing them directly. The outbox relay then delivers them to the webhooks and
+// notifiers, so the completion notifier and inbox are not called by the handler anymore.
+func (h *Handler) SetEventOutbox(outbox webhookPublisher) {
+	h.outbox = outbox
+}
+
+// SetCompletionNotifier enables completion notifications to document creators
+func (h *Handler) SetCompletionNotifier(notifier completionNotifier) {
+	h.notifier = notifier
+}
+
+// SetNotificationInbox enables inbox notifications to document owners
+func (h *Handler) SetNotificationInbox(inbox signatureInbox) {
+	h.inbox = inbox
+}
+
+// SetSigningOrderNotifier enables notification of the next signers in sequential workflows
+func (h *Handler) SetSigningOrderNotifier(notifier signingOrderNotifier) {
+	h.signingOrder = notifier
+}
+
+// SetQuizReader exposes document quizzes to signers
+func (h *Handler) SetQuizReader(quiz signerQuizReader) {
+	h.quiz = quiz
+}
+
+// SetExternalSigner enables signing by email-verified signers outside the identity provider
+func (h *Handler) SetExternalSigner(external externalSigner) {
+	h.external = external
+}
+
+// SetStatusCache invalidates cached document status when a signature is recorded
+func (h *Handler) SetStatusCache(cache statusInvalidator) {
+	h.statusCache = cache
+}
+
+// SetNonceStore rejects signature requests replaying a nonce
+func (h *Handler) SetNonceStore(nonces nonceStore) {
+	h.nonces = nonces
+}
+
+// SetIdempotencyStore lets clients retry signature requests with an Idempotency-Key header
+func (h *Handler) SetIdempotencyStore(idempotency idempotencyStore) {
+	h.idempotency = idempotency
+}
+
+// SetConsentReader exposes the consent text of documents to signers
+func (h *Handler) SetConsentReader(consent consentReader) {
+	h.consent = consent
+}
+
+// SetSignRedirects returns the redirect configured for the document with new signatures
+func (h *Handler) SetSignRedirects(redirects signRedirectResolver) {
+	h.redirects = redirects
+}
+
 // CreateSignatureRequest represents the request body for creating a signature
 type CreateSignatureRequest struct {
-	DocID   string  `json:"docId"`
-	Referer *string `json:"referer,omitempty"`
+	DocID       string             `json:"docId"`
+	Referer     *string            `json:"referer,omitempty"`
+	QuizAnswers models.QuizAnswers `json:"quizAnswers,omitempty"` // Question ID -> chosen option index
+	Nonce       string             `json:"nonce,omitempty"`       // Single-use nonce from POST /signatures/nonce
+	// Display language and consent version of the signing page, see GET /documents/{docId}/consent
+	Locale         string `json:"locale,omitempty"`
+	ConsentVersion *int   `json:"consentVersion,omitempty"`
+}
+
+// IssueNonceRequest represents the request body for issuing a signature nonce
+type IssueNonceRequest struct {
+	DocID string `json:"docId"`
+}
+
+// NonceResponse represents an issued signature nonce
+type NonceResponse struct {
+	Nonce     string `json:"nonce"`
+	DocID     string `json:"docId"`
+	ExpiresAt string `json:"expiresAt"`
 }
 
 // SignatureResponse represents a signature in API responses
@@ -66,9 +212,20 @@ type SignatureResponse struct {
 	PrevHash     *string            `json:"prevHash,omitempty"`
 	ServiceInfo  *ServiceInfoResult `json:"serviceInfo,omitempty"`
 	DocDeletedAt *string            `json:"docDeletedAt,omitempty"`
+	QuizScore    *int               `json:"quizScore,omitempty"`
+	AuthMethod   string             `json:"authMethod,omitempty"`
+	// Display language and consent shown to the signer
+	Locale         string `json:"locale,omitempty"`
+	ConsentVersion *int   `json:"consentVersion,omitempty"`
+	ConsentLocale  string `json:"consentLocale,omitempty"`
+	ConsentText    string `json:"consentText,omitempty"`
 	// Document metadata
 	DocTitle *string `json:"docTitle,omitempty"`
 	DocUrl   *string `json:"docUrl,omitempty"`
+	// Download link of the PDF certificate, only on the current user's signatures
+	CertificateURL *string `json:"certificateUrl,omitempty"`
+	// URL to send the signer to, only on signature creation
+	RedirectURL string `json:"redirectUrl,omitempty"`
 }
 
 // ServiceInfoResult represents service detection information
@@ -81,10 +238,11 @@ type ServiceInfoResult struct {
 
 // SignatureStatusResponse represents the signature status for a document
 type SignatureStatusResponse struct {
-	DocID     string  `json:"docId"`
-	UserEmail string  `json:"userEmail"`
-	IsSigned  bool    `json:"isSigned"`
-	SignedAt  *string `json:"signedAt,omitempty"`
+	DocID         string  `json:"docId"`
+	UserEmail     string  `json:"userEmail"`
+	IsSigned      bool    `json:"isSigned"`
+	SignedAt      *string `json:"signedAt,omitempty"`
+	PendingBefore int     `json:"pendingBefore,omitempty"` // Signers who must sign first in a sequential workflow
 }
 
 // HandleCreateSignature handles POST /api/v1/signatures
@@ -98,8 +256,9 @@ func (h *Handler) HandleCreateSignature(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req CreateSignatureRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", map[string]interface{}{"error": err.Error()})
+	body, err := io.ReadAll(r.Body)
+	if err != nil || json.Unmarshal(body, &req) != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
 		return
 	}
 
@@ -108,71 +267,332 @@ func (h *Handler) HandleCreateSignature(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// A retry of a request that created the signature gets the original response, before the nonce
+	// it carries is refused as replayed
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if idempotencyKey != "" && h.idempotency != nil {
+		stored, err := h.idempotency.Begin(ctx, idempotencyKey, user, body)
+		if err != nil {
+			writeCreateSignatureError(w, err, req.DocID)
+			return
+		}
+		if stored != nil {
+			w.Header().Set("Idempotent-Replayed", "true")
+			shared.WriteJSON(w, stored.StatusCode, stored.Response)
+			return
+		}
+	}
+
+	// The nonce is consumed in the request transaction, so it stays usable when the signature fails
+	if h.nonces != nil {
+		if err := h.nonces.Consume(ctx, req.Nonce, req.DocID, user); err != nil {
+			writeCreateSignatureError(w, err, req.DocID)
+			return
+		}
+	}
+
 	sigRequest := &models.SignatureRequest{
-		DocID:   req.DocID,
-		User:    user,
-		Referer: req.Referer,
+		DocID:       req.DocID,
+		User:        user,
+		Referer:     req.Referer,
+		QuizAnswers: req.QuizAnswers,
+		IPAddress:   shared.RemoteIP(r),
+		Nonce:       req.Nonce,
+		// The signing page language, falling back to the language the request is served in
+		Locale:         displayLocale(r, req.Locale),
+		ConsentVersion: req.ConsentVersion,
 	}
 
-	err := h.signatureService.CreateSignature(ctx, sigRequest)
+	if err := h.signatureService.CreateSignature(ctx, sigRequest); err != nil {
+		writeCreateSignatureError(w, err, req.DocID)
+		return
+	}
+
+	if err := h.onSignatureCreated(r, req.DocID, user); err != nil {
+		// The error rolls the signature back with its events
+		logger.Logger.Error("Failed to record signature events", "doc_id", req.DocID, "error", err.Error())
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create signature", nil)
+		return
+	}
+
+	var response interface{}
+	signature, err := h.signatureService.GetSignatureByDocAndUser(ctx, req.DocID, user)
 	if err != nil {
-		if err == models.ErrSignatureAlreadyExists {
-			shared.WriteConflict(w, "You have already signed this document")
-			return
+		response = map[string]interface{}{
+			"message": "Signature created successfully",
+			"docId":   req.DocID,
 		}
+	} else {
+		h.issueCertificate(ctx, signature)
+		sigResponse := h.toSignatureResponse(ctx, signature)
+		sigResponse.RedirectURL = h.resolveRedirect(ctx, req.DocID)
+		response = sigResponse
+	}
 
-		if err == models.ErrInvalidDocument {
-			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid document", nil)
+	if idempotencyKey != "" && h.idempotency != nil {
+		if err := h.storeIdempotentResponse(ctx, idempotencyKey, user, response); err != nil {
+			// Failing the request rolls the signature back, so that a retry creates it again
+			logger.Logger.Error("Failed to store idempotent signature response", "doc_id", req.DocID, "error", err.Error())
+			shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create signature", nil)
 			return
 		}
+	}
 
-		if err == models.ErrDocumentModified {
-			shared.WriteError(w, http.StatusConflict, "DOCUMENT_MODIFIED", "The document has been modified since it was created. Please verify the current version before signing.", map[string]interface{}{
-				"docId": req.DocID,
-			})
-			return
+	shared.WriteJSON(w, http.StatusCreated, response)
+}
+
+// resolveRedirect returns the redirect after signing the document, if any. The signature is
+// recorded at this point, a failure only loses the redirect.
+func (h *Handler) resolveRedirect(ctx context.Context, docID string) string {
+	if h.redirects == nil {
+		return ""
+	}
+	redirect, err := h.redirects.ResolveRedirect(ctx, docID)
+	if err != nil {
+		logger.Logger.Warn("Failed to resolve sign redirect", "doc_id", docID, "error", err.Error())
+		return ""
+	}
+	return redirect
+}
+
+// storeIdempotentResponse stores the response of a signature creation under its Idempotency-Key
+func (h *Handler) storeIdempotentResponse(ctx context.Context, key string, user *models.User, response interface{}) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return h.idempotency.Complete(ctx, key, user, http.StatusCreated, data)
+}
+
+// HandleIssueNonce handles POST /api/v1/signatures/nonce
+func (h *Handler) HandleIssueNonce(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, ok := shared.GetUserFromContext(ctx)
+	if !ok || user == nil {
+		shared.WriteUnauthorized(w, "Authentication required")
+		return
+	}
+	if h.nonces == nil {
+		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, "Signature nonces not enabled", nil)
+		return
+	}
+
+	var req IssueNonceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+	if req.DocID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+
+	nonce, err := h.nonces.Issue(ctx, req.DocID, user)
+	if err != nil {
+		logger.Logger.Error("Failed to issue signature nonce", "doc_id", req.DocID, "error", err.Error())
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to issue nonce", nil)
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusCreated, NonceResponse{
+		Nonce:     nonce.Nonce,
+		DocID:     nonce.DocID,
+		ExpiresAt: nonce.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+// writeCreateSignatureError maps signature creation errors to API responses
+func writeCreateSignatureError(w http.ResponseWriter, err error, docID string) {
+	if err == models.ErrSignatureAlreadyExists {
+		shared.WriteConflict(w, "You have already signed this document")
+		return
+	}
+
+	if err == models.ErrInvalidDocument {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid document", nil)
+		return
+	}
+
+	if err == models.ErrNotSignerTurn {
+		shared.WriteError(w, http.StatusConflict, "NOT_SIGNER_TURN", "Previous signers must sign this document first", map[string]interface{}{
+			"docId": docID,
+		})
+		return
+	}
+
+	if err == models.ErrQuizAnswersRequired {
+		shared.WriteError(w, http.StatusBadRequest, "QUIZ_REQUIRED", "This document requires answering its quiz before signing", map[string]interface{}{
+			"docId": docID,
+		})
+		return
+	}
+
+	if err == models.ErrQuizFailed {
+		shared.WriteError(w, http.StatusUnprocessableEntity, "QUIZ_FAILED", "Your quiz answers do not reach the required score", map[string]interface{}{
+			"docId": docID,
+		})
+		return
+	}
+
+	if err == models.ErrNonceRequired {
+		shared.WriteError(w, http.StatusBadRequest, "NONCE_REQUIRED", "A signature nonce is required, request one before signing", nil)
+		return
+	}
+
+	if err == models.ErrNonceInvalid || err == models.ErrNonceExpired {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_NONCE", "The signature nonce is invalid or expired, request a new one", nil)
+		return
+	}
+
+	if err == models.ErrNonceReplayed {
+		shared.WriteError(w, http.StatusConflict, "NONCE_REPLAYED", "This signature request has already been submitted", nil)
+		return
+	}
+
+	if err == models.ErrIdempotencyKeyInvalid {
+		shared.WriteError(w, http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY", "The Idempotency-Key header must be 1 to 255 printable ASCII characters", nil)
+		return
+	}
+
+	if err == models.ErrIdempotencyKeyReused {
+		shared.WriteError(w, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "This Idempotency-Key was already used with a different request", nil)
+		return
+	}
+
+	if err == models.ErrIdempotencyKeyPending {
+		shared.WriteError(w, http.StatusConflict, "IDEMPOTENCY_KEY_IN_PROGRESS", "A request with this Idempotency-Key is still in progress, retry later", nil)
+		return
+	}
+
+	if err == models.ErrRateLimited {
+		shared.WriteError(w, http.StatusTooManyRequests, shared.ErrCodeRateLimited, "Too many signatures, please try again later", nil)
+		return
+	}
+
+	if err == models.ErrConsentOutdated {
+		shared.WriteError(w, http.StatusConflict, "CONSENT_OUTDATED", "The consent text has changed, please review it before signing", map[string]interface{}{
+			"docId": docID,
+		})
+		return
+	}
+
+	var rejected *models.SignatureRejectedError
+	if errors.As(err, &rejected) {
+		message := rejected.Reason
+		if message == "" {
+			message = "You are not allowed to sign this document yet"
 		}
+		shared.WriteError(w, http.StatusUnprocessableEntity, "SIGNATURE_REJECTED", message, map[string]interface{}{
+			"docId": docID,
+		})
+		return
+	}
 
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create signature", map[string]interface{}{"error": err.Error()})
+	if err == models.ErrSignatureHookFailed {
+		shared.WriteError(w, http.StatusServiceUnavailable, "SIGNATURE_VALIDATION_UNAVAILABLE", "The signature could not be validated, please try again later", map[string]interface{}{
+			"docId": docID,
+		})
 		return
 	}
 
-	// Publish signature.created webhook
-	if h.webhookPublisher != nil {
-		_ = h.webhookPublisher.Publish(ctx, "signature.created", map[string]interface{}{
-			"doc_id":     req.DocID,
-			"user_email": user.Email,
-			"user_name":  user.Name,
+	if err == models.ErrDocumentModified {
+		shared.WriteError(w, http.StatusConflict, "DOCUMENT_MODIFIED", "The document has been modified since it was created. Please verify the current version before signing.", map[string]interface{}{
+			"docId": docID,
 		})
+		return
+	}
+
+	logger.Logger.Error("Signature creation failed in handler", "doc_id", docID, "error", err.Error())
+	shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to create signature", nil)
+}
+
+// onSignatureCreated runs the side effects of a new signature: cache invalidation,
+// webhooks and notifications. It only fails when the events cannot be recorded in the outbox.
+func (h *Handler) onSignatureCreated(r *http.Request, docID string, user *models.User) error {
+	ctx := r.Context()
+
+	if h.statusCache != nil {
+		h.statusCache.Invalidate(docID)
+	}
+
+	if h.outbox != nil {
+		for _, event := range h.signatureEvents(ctx, docID, user) {
+			if err := h.outbox.Publish(ctx, event.eventType, event.payload); err != nil {
+				return err
+			}
+		}
+	} else {
+		h.publishSignatureEvents(ctx, docID, user)
+	}
+
+	if h.signingOrder != nil {
+		if err := h.signingOrder.OnSignature(ctx, docID, user.Email, i18n.GetLangFromRequest(r)); err != nil {
+			logger.Logger.Warn("Failed to notify next signers", "doc_id", docID, "error", err.Error())
+		}
 	}
+	return nil
+}
+
+type signatureEvent struct {
+	eventType string
+	payload   map[string]interface{}
+}
 
-	// If expected signers completed -> publish document.completed
-	if h.adminService != nil && h.webhookPublisher != nil {
-		if stats, err := h.adminService.GetSignerStats(ctx, req.DocID); err == nil {
+// signatureEvents returns signature.created and, once expected signers completed, document.completed
+func (h *Handler) signatureEvents(ctx context.Context, docID string, user *models.User) []signatureEvent {
+	events := []signatureEvent{{
+		eventType: "signature.created",
+		payload: map[string]interface{}{
+			"doc_id":     docID,
+			"user_email": user.Email,
+			"user_name":  user.Name,
+		},
+	}}
+
+	if h.adminService != nil {
+		if stats, err := h.adminService.GetSignerStats(ctx, docID); err == nil {
 			if stats.ExpectedCount > 0 && stats.PendingCount == 0 {
-				_ = h.webhookPublisher.Publish(ctx, "document.completed", map[string]interface{}{
-					"doc_id":         req.DocID,
-					"completed_at":   time.Now().UTC().Format("2006-01-02T15:04:05Z07:00"),
-					"expected_count": stats.ExpectedCount,
-					"signed_count":   stats.SignedCount,
+				events = append(events, signatureEvent{
+					eventType: "document.completed",
+					payload: map[string]interface{}{
+						"doc_id":         docID,
+						"completed_at":   time.Now().UTC().Format("2006-01-02T15:04:05Z07:00"),
+						"expected_count": stats.ExpectedCount,
+						"signed_count":   stats.SignedCount,
+					},
 				})
 			}
 		}
 	}
+	return events
+}
 
-	signature, err := h.signatureService.GetSignatureByDocAndUser(ctx, req.DocID, user)
-	if err != nil {
-		shared.WriteJSON(w, http.StatusCreated, map[string]interface{}{
-			"message": "Signature created successfully",
-			"docId":   req.DocID,
-		})
-		return
+// publishSignatureEvents publishes the signature events to the webhooks and notifies the document
+// owner directly, when no outbox is configured
+func (h *Handler) publishSignatureEvents(ctx context.Context, docID string, user *models.User) {
+	if h.webhookPublisher != nil {
+		for _, event := range h.signatureEvents(ctx, docID, user) {
+			_ = h.webhookPublisher.Publish(ctx, event.eventType, event.payload)
+		}
+	}
+
+	// Notify the document creator when a completion trigger is reached
+	if h.notifier != nil {
+		if err := h.notifier.OnSignature(ctx, docID); err != nil {
+			logger.Logger.Warn("Failed to send completion notification", "doc_id", docID, "error", err.Error())
+		}
 	}
 
-	shared.WriteJSON(w, http.StatusCreated, h.toSignatureResponse(ctx, signature))
+	if h.inbox != nil {
+		if err := h.inbox.OnSignature(ctx, docID, user.Email, user.Name); err != nil {
+			logger.Logger.Warn("Failed to record signature notification", "doc_id", docID, "error", err.Error())
+		}
+	}
 }
 
 // HandleGetUserSignatures handles GET /api/v1/signatures
+// With a cursor parameter (empty for the first page), the signatures are paginated with cursors.
 func (h *Handler) HandleGetUserSignatures(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -182,9 +602,30 @@ func (h *Handler) HandleGetUserSignatures(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	cursorParams, err := shared.ParseCursorParams(r, 50, 200)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid cursor", nil)
+		return
+	}
+	if cursorParams != nil {
+		page, err := h.signatureService.ListUserSignaturesPage(ctx, user, cursorParams.Cursor, cursorParams.Limit)
+		if err != nil {
+			logger.Logger.Error("Failed to fetch signatures", "error", err.Error())
+			shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signatures", nil)
+			return
+		}
+		response := make([]*SignatureResponse, 0, len(page.Items))
+		for _, sig := range page.Items {
+			response = append(response, h.toSignatureResponse(ctx, sig))
+		}
+		shared.WriteCursorPage(w, response, cursorParams.Limit, page.NextCursor, page.PrevCursor)
+		return
+	}
+
 	signatures, err := h.signatureService.GetUserSignatures(ctx, user)
 	if err != nil {
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signatures", map[string]interface{}{"error": err.Error()})
+		logger.Logger.Error("Failed to fetch signatures", "error", err.Error())
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signatures", nil)
 		return
 	}
 
@@ -208,7 +649,8 @@ func (h *Handler) HandleGetDocumentSignatures(w http.ResponseWriter, r *http.Req
 
 	signatures, err := h.signatureService.GetDocumentSignatures(ctx, docID)
 	if err != nil {
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signatures", map[string]interface{}{"error": err.Error()})
+		logger.Logger.Error("Failed to fetch signatures", "error", err.Error())
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signatures", nil)
 		return
 	}
 
@@ -238,14 +680,16 @@ func (h *Handler) HandleGetSignatureStatus(w http.ResponseWriter, r *http.Reques
 
 	status, err := h.signatureService.GetSignatureStatus(ctx, docID, user)
 	if err != nil {
-		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signature status", map[string]interface{}{"error": err.Error()})
+		logger.Logger.Error("Failed to fetch signature status", "error", err.Error())
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch signature status", nil)
 		return
 	}
 
 	response := SignatureStatusResponse{
-		DocID:     status.DocID,
-		UserEmail: status.UserEmail,
-		IsSigned:  status.IsSigned,
+		DocID:         status.DocID,
+		UserEmail:     status.UserEmail,
+		IsSigned:      status.IsSigned,
+		PendingBefore: status.PendingBefore,
 	}
 
 	if status.SignedAt != nil {
@@ -256,6 +700,69 @@ func (h *Handler) HandleGetSignatureStatus(w http.ResponseWriter, r *http.Reques
 	shared.WriteJSON(w, http.StatusOK, response)
 }
 
+// HandleGetDocumentQuiz handles GET /api/v1/documents/{docId}/quiz
+// The correct answers are never returned to signers.
+func (h *Handler) HandleGetDocumentQuiz(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if docID == "" {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Document ID is required", nil)
+		return
+	}
+	if h.quiz == nil {
+		shared.WriteNotFound(w, "Quiz")
+		return
+	}
+
+	quiz, err := h.quiz.GetSignerQuiz(r.Context(), docID)
+	if err != nil {
+		logger.Logger.Error("Failed to fetch signer quiz", "error", err.Error())
+		shared.WriteError(w, http.StatusInternalServerError, shared.ErrCodeInternal, "Failed to fetch quiz", nil)
+		return
+	}
+	if quiz == nil {
+		shared.WriteNotFound(w, "Quiz")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, quiz)
+}
+
+// HandleGetDocumentConsent handles GET /api/v1/documents/{docId}/consent
+// It returns the consent text to display next to the sign button, in the requested locale when available.
+func (h *Handler) HandleGetDocumentConsent(w http.ResponseWriter, r *http.Request) {
+	docID := chi.URLParam(r, "docId")
+	if h.consent == nil {
+		shared.WriteNotFound(w, "Consent")
+		return
+	}
+
+	consent, err := h.consent.Resolve(r.Context(), docID, displayLocale(r, r.URL.Query().Get("locale")))
+	if err != nil {
+		logger.Logger.Error("Failed to resolve consent text", "doc_id", docID, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	if consent == nil {
+		shared.WriteNotFound(w, "Consent")
+		return
+	}
+
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"docId":   docID,
+		"version": consent.Version,
+		"locale":  consent.Locale,
+		"text":    consent.Text,
+	})
+}
+
+// displayLocale returns the language the signing page was displayed in
+func displayLocale(r *http.Request, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return i18n.GetLangFromRequest(r)
+}
+
 // toSignatureResponse converts a domain signature to API response format
 func (h *Handler) toSignatureResponse(ctx context.Context, sig *models.Signature) *SignatureResponse {
 	response := &SignatureResponse{
@@ -271,6 +778,13 @@ func (h *Handler) toSignatureResponse(ctx context.Context, sig *models.Signature
 		CreatedAt:   sig.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		Referer:     sig.Referer,
 		PrevHash:    sig.PrevHash,
+		QuizScore:   sig.QuizScore,
+		AuthMethod:  sig.AuthMethod,
+		Locale:      sig.Locale,
+		// Consent evidence
+		ConsentVersion: sig.ConsentVersion,
+		ConsentLocale:  sig.ConsentLocale,
+		ConsentText:    sig.ConsentText,
 	}
 
 	// Add doc_deleted_at if document was deleted
@@ -296,6 +810,7 @@ func (h *Handler) toSignatureResponse(ctx context.Context, sig *models.Signature
 	if sig.DocURL != "" {
 		response.DocUrl = &sig.DocURL
 	}
+	response.CertificateURL = h.certificateURL(ctx, sig)
 
 	return response
 }
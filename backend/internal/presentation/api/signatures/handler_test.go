@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -115,6 +117,31 @@ func (m *mockSignatureService) GetUserSignatures(ctx context.Context, user *mode
 	return []*models.Signature{testSignature}, nil
 }
 
+func (m *mockSignatureService) ListUserSignaturesPage(_ context.Context, _ *models.User, cursor *models.PageCursor, limit int) (*models.KeysetPage[*models.Signature], error) {
+	return models.NewKeysetPage([]*models.Signature{testSignature}, cursor, limit, func(sig *models.Signature) models.PageCursor {
+		return models.PageCursor{At: sig.CreatedAt, Key: strconv.FormatInt(sig.ID, 10)}
+	}), nil
+}
+
+type mockCompletionNotifier struct {
+	docIDs []string
+	err    error
+}
+
+func (m *mockCompletionNotifier) OnSignature(ctx context.Context, docID string) error {
+	m.docIDs = append(m.docIDs, docID)
+	return m.err
+}
+
+type mockSignatureInbox struct {
+	signers []string
+}
+
+func (m *mockSignatureInbox) OnSignature(ctx context.Context, docID, signerEmail, signerName string) error {
+	m.signers = append(m.signers, docID+":"+signerEmail)
+	return nil
+}
+
 func createTestHandler() *Handler {
 	return &Handler{
 		signatureService: &mockSignatureService{},
@@ -242,6 +269,144 @@ func TestHandler_HandleCreateSignature_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestHandler_HandleCreateSignature_NotifiesCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		notifierErr error
+	}{
+		{name: "notification sent", notifierErr: nil},
+		{name: "notification failure does not fail signature", notifierErr: fmt.Errorf("queue unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			notifier := &mockCompletionNotifier{err: tt.notifierErr}
+			handler := createTestHandler()
+			handler.SetCompletionNotifier(notifier)
+
+			body, err := json.Marshal(CreateSignatureRequest{DocID: "test-doc-123"})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(addUserToContext(req.Context(), testUser))
+			rec := httptest.NewRecorder()
+
+			handler.HandleCreateSignature(rec, req)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, []string{"test-doc-123"}, notifier.docIDs)
+		})
+	}
+}
+
+func TestHandler_HandleCreateSignature_RecordsInboxNotification(t *testing.T) {
+	t.Parallel()
+
+	inbox := &mockSignatureInbox{}
+	handler := createTestHandler()
+	handler.SetNotificationInbox(inbox)
+
+	body, err := json.Marshal(CreateSignatureRequest{DocID: "test-doc-123"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+
+	handler.HandleCreateSignature(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, []string{"test-doc-123:user@example.com"}, inbox.signers)
+}
+
+// mockEventOutbox records the published event types
+type mockEventOutbox struct {
+	events []string
+	err    error
+}
+
+func (m *mockEventOutbox) Publish(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	m.events = append(m.events, eventType)
+	return m.err
+}
+
+func TestHandler_HandleCreateSignature_RecordsOutboxEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		outboxErr    error
+		expectedCode int
+	}{
+		{name: "events recorded", outboxErr: nil, expectedCode: http.StatusCreated},
+		{name: "outbox failure fails signature", outboxErr: fmt.Errorf("database unavailable"), expectedCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outbox := &mockEventOutbox{err: tt.outboxErr}
+			notifier := &mockCompletionNotifier{}
+			handler := createTestHandler()
+			handler.SetEventOutbox(outbox)
+			handler.SetCompletionNotifier(notifier)
+
+			body, err := json.Marshal(CreateSignatureRequest{DocID: "test-doc-123"})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(addUserToContext(req.Context(), testUser))
+			rec := httptest.NewRecorder()
+
+			handler.HandleCreateSignature(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, []string{"signature.created"}, outbox.events)
+			// Notifications are relayed from the outbox
+			assert.Empty(t, notifier.docIDs)
+		})
+	}
+}
+
+// mockSignRedirects returns a fixed redirect per document
+type mockSignRedirects map[string]string
+
+func (m mockSignRedirects) ResolveRedirect(_ context.Context, docID string) (string, error) {
+	return m[docID], nil
+}
+
+func TestHandler_HandleCreateSignature_ReturnsRedirect(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+	handler.SetSignRedirects(mockSignRedirects{"test-doc-123": "https://intranet.example.com/policies"})
+
+	body, err := json.Marshal(CreateSignatureRequest{DocID: "test-doc-123"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+
+	handler.HandleCreateSignature(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var wrapper struct {
+		Data SignatureResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, "https://intranet.example.com/policies", wrapper.Data.RedirectURL)
+}
+
 func TestHandler_HandleCreateSignature_ValidationErrors(t *testing.T) {
 	t.Parallel()
 
@@ -317,6 +482,48 @@ func TestHandler_HandleCreateSignature_ServiceErrors(t *testing.T) {
 			expectedStatus: http.StatusConflict,
 			expectedMsg:    "The document has been modified since it was created",
 		},
+		{
+			name:           "not the signer's turn",
+			serviceError:   models.ErrNotSignerTurn,
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "Previous signers must sign this document first",
+		},
+		{
+			name:           "quiz answers missing",
+			serviceError:   models.ErrQuizAnswersRequired,
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "This document requires answering its quiz before signing",
+		},
+		{
+			name:           "quiz failed",
+			serviceError:   models.ErrQuizFailed,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedMsg:    "Your quiz answers do not reach the required score",
+		},
+		{
+			name:           "consent outdated",
+			serviceError:   models.ErrConsentOutdated,
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "The consent text has changed, please review it before signing",
+		},
+		{
+			name:           "rejected by validation hook",
+			serviceError:   &models.SignatureRejectedError{Reason: "Complete the security training first"},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedMsg:    "Complete the security training first",
+		},
+		{
+			name:           "validation hook unavailable",
+			serviceError:   models.ErrSignatureHookFailed,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedMsg:    "The signature could not be validated, please try again later",
+		},
+		{
+			name:           "rate limited",
+			serviceError:   models.ErrRateLimited,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedMsg:    "Too many signatures, please try again later",
+		},
 		{
 			name:           "generic error",
 			serviceError:   fmt.Errorf("database error"),
@@ -364,6 +571,178 @@ func TestHandler_HandleCreateSignature_ServiceErrors(t *testing.T) {
 	}
 }
 
+// mockNonceStore accepts each issued nonce once
+type mockNonceStore struct {
+	mu     sync.Mutex
+	issued map[string]bool
+}
+
+func (m *mockNonceStore) Issue(ctx context.Context, docID string, user *models.User) (*models.SignatureNonce, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nonce := fmt.Sprintf("nonce-%d", len(m.issued)+1)
+	m.issued[nonce] = false
+	return &models.SignatureNonce{Nonce: nonce, DocID: docID, ExpiresAt: time.Now().Add(5 * time.Minute)}, nil
+}
+
+func (m *mockNonceStore) Consume(ctx context.Context, nonce, docID string, user *models.User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if nonce == "" {
+		return models.ErrNonceRequired
+	}
+	used, ok := m.issued[nonce]
+	if !ok {
+		return models.ErrNonceInvalid
+	}
+	if used {
+		return models.ErrNonceReplayed
+	}
+	m.issued[nonce] = true
+	return nil
+}
+
+func postSignature(handler *Handler, reqBody CreateSignatureRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+	handler.HandleCreateSignature(rec, req)
+	return rec
+}
+
+func TestHandler_HandleCreateSignature_RejectsReplayedNonce(t *testing.T) {
+	t.Parallel()
+
+	var recorded []string
+	handler := &Handler{
+		signatureService: &mockSignatureService{
+			createSignatureFunc: func(ctx context.Context, request *models.SignatureRequest) error {
+				recorded = append(recorded, request.Nonce)
+				return nil
+			},
+		},
+	}
+	handler.SetNonceStore(&mockNonceStore{issued: map[string]bool{}})
+
+	body, err := json.Marshal(IssueNonceRequest{DocID: "test-doc-123"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures/nonce", bytes.NewReader(body))
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+	handler.HandleIssueNonce(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var wrapper struct {
+		Data NonceResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, "test-doc-123", wrapper.Data.DocID)
+	assert.NotEmpty(t, wrapper.Data.ExpiresAt)
+
+	first := postSignature(handler, CreateSignatureRequest{DocID: "test-doc-123", Nonce: wrapper.Data.Nonce})
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, []string{wrapper.Data.Nonce}, recorded, "expected the nonce recorded with the signature")
+
+	replay := postSignature(handler, CreateSignatureRequest{DocID: "test-doc-123", Nonce: wrapper.Data.Nonce})
+	assert.Equal(t, http.StatusConflict, replay.Code)
+	assert.Contains(t, replay.Body.String(), "NONCE_REPLAYED")
+
+	unknown := postSignature(handler, CreateSignatureRequest{DocID: "test-doc-123", Nonce: "forged"})
+	assert.Equal(t, http.StatusBadRequest, unknown.Code)
+	assert.Contains(t, unknown.Body.String(), "INVALID_NONCE")
+
+	missing := postSignature(handler, CreateSignatureRequest{DocID: "test-doc-123"})
+	assert.Equal(t, http.StatusBadRequest, missing.Code)
+	assert.Contains(t, missing.Body.String(), "NONCE_REQUIRED")
+
+	assert.Len(t, recorded, 1, "rejected requests must not reach the signature service")
+}
+
+// mockIdempotencyStore keeps the keys of signature requests in memory
+type mockIdempotencyStore struct {
+	keys map[string]*models.SignatureIdempotencyKey
+}
+
+func (m *mockIdempotencyStore) Begin(ctx context.Context, key string, user *models.User, body []byte) (*models.SignatureIdempotencyKey, error) {
+	stored, ok := m.keys[key]
+	if !ok {
+		m.keys[key] = &models.SignatureIdempotencyKey{Key: key, UserSub: user.Sub, Fingerprint: string(body)}
+		return nil, nil
+	}
+	if stored.Fingerprint != string(body) {
+		return nil, models.ErrIdempotencyKeyReused
+	}
+	return stored, nil
+}
+
+func (m *mockIdempotencyStore) Complete(ctx context.Context, key string, user *models.User, statusCode int, response []byte) error {
+	m.keys[key].StatusCode = statusCode
+	m.keys[key].Response = response
+	return nil
+}
+
+func postSignatureWithKey(handler *Handler, reqBody CreateSignatureRequest, key string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(reqBody)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+	handler.HandleCreateSignature(rec, req)
+	return rec
+}
+
+func TestHandler_HandleCreateSignature_IdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	created := 0
+	handler := &Handler{
+		signatureService: &mockSignatureService{
+			createSignatureFunc: func(ctx context.Context, request *models.SignatureRequest) error {
+				created++
+				if created > 1 {
+					return models.ErrSignatureAlreadyExists
+				}
+				return nil
+			},
+		},
+	}
+	handler.SetIdempotencyStore(&mockIdempotencyStore{keys: map[string]*models.SignatureIdempotencyKey{}})
+
+	first := postSignatureWithKey(handler, CreateSignatureRequest{DocID: "test-doc-123"}, "retry-1")
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get("Idempotent-Replayed"))
+
+	retry := postSignatureWithKey(handler, CreateSignatureRequest{DocID: "test-doc-123"}, "retry-1")
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get("Idempotent-Replayed"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 1, created, "a retry must not reach the signature service")
+
+	reused := postSignatureWithKey(handler, CreateSignatureRequest{DocID: "other-doc"}, "retry-1")
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+	assert.Contains(t, reused.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+
+	// Without key, the retry is refused as before
+	unkeyed := postSignature(handler, CreateSignatureRequest{DocID: "test-doc-123"})
+	assert.Equal(t, http.StatusConflict, unkeyed.Code)
+}
+
+func TestHandler_HandleIssueNonce_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+	handler.SetNonceStore(&mockNonceStore{issued: map[string]bool{}})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures/nonce", bytes.NewReader([]byte(`{"docId":"test-doc-123"}`)))
+	rec := httptest.NewRecorder()
+	handler.HandleIssueNonce(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
 // ============================================================================
 // TESTS - HandleGetUserSignatures
 // ============================================================================
@@ -402,6 +781,46 @@ func TestHandler_HandleGetUserSignatures_Success(t *testing.T) {
 	assert.Equal(t, testSignature.DocID, wrapper.Data[0].DocID)
 }
 
+func TestHandler_HandleGetUserSignatures_Cursor(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+
+	cursor := models.PageCursor{At: testSignature.CreatedAt.Add(time.Hour), Key: "99"}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/signatures?cursor="+cursor.Encode(), nil)
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+
+	handler.HandleGetUserSignatures(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var wrapper struct {
+		Data []*SignatureResponse   `json:"data"`
+		Meta map[string]interface{} `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	require.Len(t, wrapper.Data, 1)
+	assert.Equal(t, testSignature.ID, wrapper.Data[0].ID)
+	assert.Equal(t, float64(50), wrapper.Meta["limit"])
+	assert.NotEmpty(t, wrapper.Meta["prevCursor"])
+	assert.Equal(t, "", wrapper.Meta["nextCursor"])
+}
+
+func TestHandler_HandleGetUserSignatures_InvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	handler := createTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/signatures?cursor=invalid", nil)
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+
+	handler.HandleGetUserSignatures(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestHandler_HandleGetUserSignatures_Unauthorized(t *testing.T) {
 	t.Parallel()
 
@@ -897,3 +1316,67 @@ func Benchmark_toSignatureResponse(b *testing.B) {
 		handler.toSignatureResponse(ctx, testSignature)
 	}
 }
+
+type mockConsentReader struct {
+	locales []string
+}
+
+func (m *mockConsentReader) Resolve(_ context.Context, docID, locale string) (*models.ConsentText, error) {
+	m.locales = append(m.locales, locale)
+	if docID != "test-doc-123" {
+		return nil, nil
+	}
+	return &models.ConsentText{Version: 2, Locale: "fr", Text: "J'ai lu ce document"}, nil
+}
+
+func TestHandler_HandleGetDocumentConsent(t *testing.T) {
+	consent := &mockConsentReader{}
+	handler := createTestHandler()
+	handler.SetConsentReader(consent)
+
+	get := func(docID, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/"+docID+"/consent"+query, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("docId", docID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		handler.HandleGetDocumentConsent(rec, req)
+		return rec
+	}
+
+	rec := get("test-doc-123", "?locale=fr-FR")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var wrapper struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &wrapper))
+	assert.Equal(t, float64(2), wrapper.Data["version"])
+	assert.Equal(t, "J'ai lu ce document", wrapper.Data["text"])
+	assert.Equal(t, "fr-FR", consent.locales[0])
+
+	assert.Equal(t, http.StatusNotFound, get("no-catalog", "").Code)
+}
+
+func TestHandler_HandleCreateSignature_PassesDisplayLanguageAndConsent(t *testing.T) {
+	var got *models.SignatureRequest
+	handler := &Handler{signatureService: &mockSignatureService{
+		createSignatureFunc: func(_ context.Context, request *models.SignatureRequest) error {
+			got = request
+			return nil
+		},
+	}}
+
+	body, _ := json.Marshal(map[string]interface{}{"docId": "test-doc-123", "consentVersion": 2})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/signatures", bytes.NewReader(body))
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9")
+	req = req.WithContext(addUserToContext(req.Context(), testUser))
+	rec := httptest.NewRecorder()
+
+	handler.HandleCreateSignature(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.NotNil(t, got)
+	assert.Equal(t, "de", got.Locale)
+	require.NotNil(t, got.ConsentVersion)
+	assert.Equal(t, 2, *got.ConsentVersion)
+}
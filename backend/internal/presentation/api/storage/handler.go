@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -93,10 +94,31 @@ type documentService interface {
 	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
 }
 
+// urlVerifier checks the signature and expiry of a signed content link
+type urlVerifier interface {
+	Verify(docID, expires, signature string) error
+}
+
+// uploadQuarantine holds back the uploads breaking the tenant upload policy or flagged by the
+// virus scanner
+type uploadQuarantine interface {
+	Policy() (int64, []string)
+	Inspect(ctx context.Context, r io.Reader, size int64, mimeType string) (models.QuarantineReason, string)
+	Quarantine(ctx context.Context, r io.Reader, upload *models.QuarantinedUpload) error
+}
+
+// signatureArchives serves the signature archives of all documents through their expiring links
+type signatureArchives interface {
+	Open(ctx context.Context, archiveID, expires, signature string) (io.ReadCloser, int64, error)
+}
+
 type Handler struct {
 	provider   storage.Provider
 	docService documentService
 	maxSizeMB  int64
+	signer     urlVerifier
+	quarantine uploadQuarantine
+	archives   signatureArchives
 }
 
 func NewHandler(provider storage.Provider, docService documentService, maxSizeMB int64) *Handler {
@@ -107,6 +129,21 @@ func NewHandler(provider storage.Provider, docService documentService, maxSizeMB
 	}
 }
 
+// SetURLVerifier enables the public signed content endpoint
+func (h *Handler) SetURLVerifier(signer urlVerifier) {
+	h.signer = signer
+}
+
+// SetQuarantine enables the tenant upload policy and the virus scanning of uploads
+func (h *Handler) SetQuarantine(quarantine uploadQuarantine) {
+	h.quarantine = quarantine
+}
+
+// SetSignatureArchives enables the download of the signature archives of all documents
+func (h *Handler) SetSignatureArchives(archives signatureArchives) {
+	h.archives = archives
+}
+
 func (h *Handler) IsEnabled() bool {
 	return h.provider != nil
 }
@@ -124,6 +161,14 @@ type UploadResponse struct {
 	IsNew             bool      `json:"is_new"`
 }
 
+// QuarantineResponse tells the uploader that the file awaits an admin review
+type QuarantineResponse struct {
+	QuarantineID int64                   `json:"quarantine_id"`
+	Reason       models.QuarantineReason `json:"reason"`
+	Status       models.QuarantineStatus `json:"status"`
+	Message      string                  `json:"message"`
+}
+
 func (h *Handler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -204,6 +249,44 @@ func (h *Handler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	// Generate storage key (unique per upload)
 	storageKey := generateStorageKey(header.Filename)
 
+	// Hold back uploads breaking the tenant policy or flagged by the virus scanner
+	if h.quarantine != nil {
+		reason, detail := h.quarantine.Inspect(ctx, file, header.Size, contentType)
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			shared.WriteInternalError(w)
+			return
+		}
+		if reason != "" {
+			upload := &models.QuarantinedUpload{
+				StorageKey:       storageKey,
+				StorageProvider:  h.provider.Type(),
+				OriginalFilename: header.Filename,
+				Title:            title,
+				MimeType:         contentType,
+				FileSize:         header.Size,
+				ReadMode:         readMode,
+				AllowDownload:    allowDownload,
+				RequireFullRead:  requireFullRead,
+				VerifyChecksum:   verifyChecksum,
+				Reason:           reason,
+				Detail:           detail,
+				UploadedBy:       user.Email,
+			}
+			if err := h.quarantine.Quarantine(ctx, file, upload); err != nil {
+				logger.Logger.Error("Failed to quarantine upload", "error", err.Error(), "filename", header.Filename)
+				shared.WriteInternalError(w)
+				return
+			}
+			shared.WriteJSON(w, http.StatusAccepted, QuarantineResponse{
+				QuarantineID: upload.ID,
+				Reason:       upload.Reason,
+				Status:       upload.Status,
+				Message:      "File is held for review by an administrator",
+			})
+			return
+		}
+	}
+
 	// Calculate checksum while reading
 	hasher := sha256.New()
 	teeReader := io.TeeReader(file, hasher)
@@ -267,9 +350,75 @@ func (h *Handler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) HandleContent(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	h.serveContent(w, r, chi.URLParam(r, "docId"))
+}
+
+// HandleSignedContent handles GET /api/v1/documents/{docId}/content/signed?expires=...&sig=...
+// It serves the stored file without a session as long as the link signature is valid and not expired
+func (h *Handler) HandleSignedContent(w http.ResponseWriter, r *http.Request) {
 	docID := chi.URLParam(r, "docId")
 
+	if h.signer == nil {
+		shared.WriteNotFound(w, "Document content")
+		return
+	}
+
+	query := r.URL.Query()
+	if err := h.signer.Verify(docID, query.Get("expires"), query.Get("sig")); err != nil {
+		if errors.Is(err, storage.ErrSignedURLExpired) {
+			shared.WriteError(w, http.StatusForbidden, shared.ErrCodeForbidden, "Link has expired", nil)
+			return
+		}
+		shared.WriteError(w, http.StatusForbidden, shared.ErrCodeForbidden, "Invalid link signature", nil)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	h.serveContent(w, r, docID)
+}
+
+// HandleSignatureArchive handles GET /api/v1/exports/signatures/{archiveId}?expires=...&sig=...
+// It serves the archive without a session as long as the link signature is valid and not expired
+func (h *Handler) HandleSignatureArchive(w http.ResponseWriter, r *http.Request) {
+	archiveID := chi.URLParam(r, "archiveId")
+
+	if h.archives == nil {
+		shared.WriteNotFound(w, "Signature archive")
+		return
+	}
+
+	query := r.URL.Query()
+	reader, size, err := h.archives.Open(r.Context(), archiveID, query.Get("expires"), query.Get("sig"))
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrSignedURLExpired):
+			shared.WriteError(w, http.StatusForbidden, shared.ErrCodeForbidden, "Link has expired", nil)
+		case errors.Is(err, storage.ErrSignedURLInvalid):
+			shared.WriteError(w, http.StatusForbidden, shared.ErrCodeForbidden, "Invalid link signature", nil)
+		case errors.Is(err, models.ErrSignatureArchiveGone):
+			shared.WriteNotFound(w, "Signature archive")
+		default:
+			logger.Logger.Error("Failed to open signature archive", "archive_id", archiveID, "error", err.Error())
+			shared.WriteInternalError(w)
+		}
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="signatures-%s.zip"`, archiveID))
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, reader); err != nil {
+		logger.Logger.Warn("Failed to send signature archive", "archive_id", archiveID, "error", err.Error())
+	}
+}
+
+func (h *Handler) serveContent(w http.ResponseWriter, r *http.Request, docID string) {
+	ctx := r.Context()
+
 	if h.provider == nil {
 		shared.WriteError(w, http.StatusServiceUnavailable, shared.ErrCodeServiceUnavailable, "Storage is not configured", nil)
 		return
@@ -365,6 +514,17 @@ func (h *Handler) HandleStorageConfig(w http.ResponseWriter, r *http.Request) {
 	if h.IsEnabled() {
 		response["provider"] = h.provider.Type()
 		response["allowed_types"] = getAllowedMIMETypes()
+
+		// Advertise the tenant upload policy, uploads beyond it are quarantined
+		if h.quarantine != nil {
+			maxSizeMB, allowedTypes := h.quarantine.Policy()
+			if maxSizeMB > 0 && maxSizeMB < h.maxSizeMB {
+				response["max_size_mb"] = maxSizeMB
+			}
+			if len(allowedTypes) > 0 {
+				response["allowed_types"] = allowedTypes
+			}
+		}
 	}
 
 	shared.WriteJSON(w, http.StatusOK, response)
@@ -5,12 +5,16 @@ import (
 	"net/http"
 
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 )
 
 // Handler handles user API requests
 type Handler struct {
-	authorizer providers.Authorizer
+	authorizer    providers.Authorizer
+	sessions      sessionManager
+	preferences   preferenceService
+	secureCookies bool
 }
 
 // NewHandler creates a new users handler
@@ -27,6 +31,9 @@ type UserDTO struct {
 	Name    string `json:"name"`
 	Picture string `json:"picture,omitempty"`
 	IsAdmin bool   `json:"isAdmin"`
+
+	// Permissions lists delegated admin permissions when the authorizer supports roles
+	Permissions []models.Permission `json:"permissions,omitempty"`
 }
 
 // HandleGetCurrentUser handles GET /api/v1/users/me
@@ -44,6 +51,9 @@ func (h *Handler) HandleGetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		Picture: user.Picture,
 		IsAdmin: h.authorizer.IsAdmin(r.Context(), user.Email),
 	}
+	if permAuthorizer, ok := h.authorizer.(providers.PermissionAuthorizer); ok && userDTO.IsAdmin {
+		userDTO.Permissions = permAuthorizer.Permissions(r.Context(), user.Email)
+	}
 
 	shared.WriteJSON(w, http.StatusOK, userDTO)
 }
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// preferenceService reads and updates the preferences of the current user
+type preferenceService interface {
+	GetPreferences(ctx context.Context, email string) (*models.UserPreferences, error)
+	UpdateLocale(ctx context.Context, email, locale string) (*models.UserPreferences, error)
+	UpdateTimezone(ctx context.Context, email, timezone string) (*models.UserPreferences, error)
+	DefaultLocale() string
+}
+
+// SetPreferenceService enables the preference endpoints. secureCookies marks the language
+// cookie set on update as Secure.
+func (h *Handler) SetPreferenceService(preferences preferenceService, secureCookies bool) {
+	h.preferences = preferences
+	h.secureCookies = secureCookies
+}
+
+// PreferencesResponse represents the preferences of the current user
+type PreferencesResponse struct {
+	Locale           string   `json:"locale"`   // Empty until detected at first login or set by the user
+	Timezone         string   `json:"timezone"` // Empty to use the timezone of the organization
+	DefaultLocale    string   `json:"defaultLocale"`
+	SupportedLocales []string `json:"supportedLocales"`
+}
+
+// UpdatePreferencesRequest represents the request body of PUT /api/v1/users/me/preferences
+type UpdatePreferencesRequest struct {
+	Locale   string  `json:"locale,omitempty"`
+	Timezone *string `json:"timezone,omitempty"` // IANA name, empty to clear
+}
+
+func (h *Handler) toPreferencesResponse(prefs *models.UserPreferences) PreferencesResponse {
+	return PreferencesResponse{
+		Locale:           prefs.Locale,
+		Timezone:         prefs.Timezone,
+		DefaultLocale:    h.preferences.DefaultLocale(),
+		SupportedLocales: models.SupportedLocales,
+	}
+}
+
+// HandleGetMyPreferences handles GET /api/v1/users/me/preferences
+func (h *Handler) HandleGetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	prefs, err := h.preferences.GetPreferences(r.Context(), user.Email)
+	if err != nil {
+		logger.Logger.Error("Failed to get user preferences", "email", user.Email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, h.toPreferencesResponse(prefs))
+}
+
+// HandleUpdateMyPreferences handles PUT /api/v1/users/me/preferences.
+// The language cookie is switched to the new locale, so that API messages follow it. A request
+// with only a timezone keeps the locale.
+func (h *Handler) HandleUpdateMyPreferences(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid request body", nil)
+		return
+	}
+
+	var prefs *models.UserPreferences
+	var err error
+	if req.Timezone != nil {
+		prefs, err = h.preferences.UpdateTimezone(r.Context(), user.Email, *req.Timezone)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidTimezone) {
+				shared.WriteValidationError(w, "Unknown timezone", map[string]string{"timezone": "must be an IANA timezone such as Europe/Paris"})
+				return
+			}
+			logger.Logger.Error("Failed to update user preferences", "email", user.Email, "error", err.Error())
+			shared.WriteInternalError(w)
+			return
+		}
+	}
+
+	if req.Locale != "" || req.Timezone == nil {
+		prefs, err = h.preferences.UpdateLocale(r.Context(), user.Email, req.Locale)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidLocale) {
+				shared.WriteValidationError(w, "Unsupported locale", map[string]string{"locale": "must be one of " + strings.Join(models.SupportedLocales, ", ")})
+				return
+			}
+			logger.Logger.Error("Failed to update user preferences", "email", user.Email, "error", err.Error())
+			shared.WriteInternalError(w)
+			return
+		}
+		i18n.SetLangCookie(w, prefs.Locale, h.secureCookies)
+	}
+
+	shared.WriteJSON(w, http.StatusOK, h.toPreferencesResponse(prefs))
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package users
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockPreferenceService struct {
+	locales   map[string]string
+	timezones map[string]string
+}
+
+func (m *mockPreferenceService) GetPreferences(_ context.Context, email string) (*models.UserPreferences, error) {
+	return &models.UserPreferences{Email: email, Locale: m.locales[email], Timezone: m.timezones[email]}, nil
+}
+
+func (m *mockPreferenceService) UpdateTimezone(_ context.Context, email, timezone string) (*models.UserPreferences, error) {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, services.ErrInvalidTimezone
+	}
+	m.timezones[email] = timezone
+	return &models.UserPreferences{Email: email, Locale: m.locales[email], Timezone: timezone}, nil
+}
+
+func (m *mockPreferenceService) UpdateLocale(_ context.Context, email, locale string) (*models.UserPreferences, error) {
+	locale, ok := models.NormalizeLocale(locale)
+	if !ok {
+		return nil, services.ErrInvalidLocale
+	}
+	m.locales[email] = locale
+	return &models.UserPreferences{Email: email, Locale: locale}, nil
+}
+
+func (m *mockPreferenceService) DefaultLocale() string {
+	return "en"
+}
+
+func newPreferencesHandler(service *mockPreferenceService) *Handler {
+	handler := NewHandler(newMockAuthorizer(nil))
+	handler.SetPreferenceService(service, true)
+	return handler
+}
+
+func preferencesRequest(method, body string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/users/me/preferences", strings.NewReader(body))
+	return req.WithContext(addUserToContext(req.Context(), testUserRegular))
+}
+
+func TestHandler_HandleGetMyPreferences(t *testing.T) {
+	t.Parallel()
+
+	service := &mockPreferenceService{locales: map[string]string{testUserRegular.Email: "fr"}}
+	rec := httptest.NewRecorder()
+	newPreferencesHandler(service).HandleGetMyPreferences(rec, preferencesRequest(http.MethodGet, ""))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"locale":"fr"`)
+	assert.Contains(t, rec.Body.String(), `"defaultLocale":"en"`)
+}
+
+func TestHandler_HandleUpdateMyPreferences(t *testing.T) {
+	t.Parallel()
+
+	service := &mockPreferenceService{locales: make(map[string]string)}
+	handler := newPreferencesHandler(service)
+
+	rec := httptest.NewRecorder()
+	handler.HandleUpdateMyPreferences(rec, preferencesRequest(http.MethodPut, `{"locale": "de-DE"}`))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "de", service.locales[testUserRegular.Email])
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "lang", cookies[0].Name)
+	assert.Equal(t, "de", cookies[0].Value)
+	assert.True(t, cookies[0].Secure)
+
+	rec = httptest.NewRecorder()
+	handler.HandleUpdateMyPreferences(rec, preferencesRequest(http.MethodPut, `{"locale": "pt"}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Result().Cookies())
+}
+
+func TestHandler_HandleUpdateMyPreferences_Timezone(t *testing.T) {
+	t.Parallel()
+
+	service := &mockPreferenceService{locales: map[string]string{testUserRegular.Email: "fr"}, timezones: make(map[string]string)}
+	handler := newPreferencesHandler(service)
+
+	rec := httptest.NewRecorder()
+	handler.HandleUpdateMyPreferences(rec, preferencesRequest(http.MethodPut, `{"timezone": "Asia/Tokyo"}`))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"timezone":"Asia/Tokyo"`)
+	assert.Contains(t, rec.Body.String(), `"locale":"fr"`)
+	assert.Empty(t, rec.Result().Cookies(), "a timezone update keeps the language cookie")
+
+	rec = httptest.NewRecorder()
+	handler.HandleUpdateMyPreferences(rec, preferencesRequest(http.MethodPut, `{"timezone": "Europe/Atlantis"}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "Asia/Tokyo", service.timezones[testUserRegular.Email])
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package users
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// sessionManager lists and revokes the server-side sessions of a user
+type sessionManager interface {
+	CurrentSessionID(r *http.Request) string
+	ListSessions(ctx context.Context, email, currentSessionID string) ([]*models.UserSession, error)
+	RevokeSession(ctx context.Context, email string, id int64) error
+	RevokeSessions(ctx context.Context, email, exceptSessionID string) (int64, error)
+}
+
+// SetSessionManager enables the session management endpoints
+func (h *Handler) SetSessionManager(sessions sessionManager) {
+	h.sessions = sessions
+}
+
+// HandleListMySessions handles GET /api/v1/users/me/sessions
+func (h *Handler) HandleListMySessions(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	sessions, err := h.sessions.ListSessions(r.Context(), user.Email, h.sessions.CurrentSessionID(r))
+	if err != nil {
+		logger.Logger.Error("Failed to list sessions", "email", user.Email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, sessions)
+}
+
+// HandleRevokeMyOtherSessions handles DELETE /api/v1/users/me/sessions.
+// Every session except the current one is revoked.
+func (h *Handler) HandleRevokeMyOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	count, err := h.sessions.RevokeSessions(r.Context(), user.Email, h.sessions.CurrentSessionID(r))
+	if err != nil {
+		logger.Logger.Error("Failed to revoke sessions", "email", user.Email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Other sessions revoked",
+		"revoked": count,
+	})
+}
+
+// HandleRevokeMySession handles DELETE /api/v1/users/me/sessions/{id}
+func (h *Handler) HandleRevokeMySession(w http.ResponseWriter, r *http.Request) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok {
+		shared.WriteUnauthorized(w, "")
+		return
+	}
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid id", nil)
+		return
+	}
+
+	if err := h.sessions.RevokeSession(r.Context(), user.Email, id); err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			shared.WriteNotFound(w, "Session")
+			return
+		}
+		logger.Logger.Error("Failed to revoke session", "email", user.Email, "error", err.Error())
+		shared.WriteInternalError(w)
+		return
+	}
+	shared.WriteJSON(w, http.StatusOK, map[string]string{"message": "Session revoked"})
+}
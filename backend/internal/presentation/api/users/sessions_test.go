@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package users
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type mockSessionManager struct {
+	sessions     []*models.UserSession
+	revokedEmail string
+	revokedID    int64
+	keptSession  string
+}
+
+func (m *mockSessionManager) CurrentSessionID(_ *http.Request) string {
+	return "current-sid"
+}
+
+func (m *mockSessionManager) ListSessions(_ context.Context, _, currentSessionID string) ([]*models.UserSession, error) {
+	for _, s := range m.sessions {
+		s.Current = s.SessionID == currentSessionID
+	}
+	return m.sessions, nil
+}
+
+func (m *mockSessionManager) RevokeSession(_ context.Context, email string, id int64) error {
+	for _, s := range m.sessions {
+		if s.ID == id {
+			m.revokedEmail, m.revokedID = email, id
+			return nil
+		}
+	}
+	return models.ErrSessionNotFound
+}
+
+func (m *mockSessionManager) RevokeSessions(_ context.Context, email, exceptSessionID string) (int64, error) {
+	m.revokedEmail, m.keptSession = email, exceptSessionID
+	return int64(len(m.sessions) - 1), nil
+}
+
+func newSessionsHandler(manager *mockSessionManager) *Handler {
+	handler := NewHandler(newMockAuthorizer(nil))
+	handler.SetSessionManager(manager)
+	return handler
+}
+
+func sessionsRequest(method, id string) *http.Request {
+	req := httptest.NewRequest(method, "/api/v1/users/me/sessions", nil)
+	rctx := chi.NewRouteContext()
+	if id != "" {
+		rctx.URLParams.Add("id", id)
+	}
+	ctx := context.WithValue(addUserToContext(req.Context(), testUserRegular), chi.RouteCtxKey, rctx)
+	return req.WithContext(ctx)
+}
+
+func TestHandler_HandleListMySessions(t *testing.T) {
+	t.Parallel()
+
+	manager := &mockSessionManager{sessions: []*models.UserSession{
+		{ID: 1, SessionID: "current-sid", IPAddress: "203.0.113.7", UserAgent: "Firefox"},
+		{ID: 2, SessionID: "other-sid", IPAddress: "198.51.100.1", UserAgent: "Safari"},
+	}}
+	rec := httptest.NewRecorder()
+	newSessionsHandler(manager).HandleListMySessions(rec, sessionsRequest(http.MethodGet, ""))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `"current":true`)
+	assert.Contains(t, body, `"userAgent":"Safari"`)
+	assert.NotContains(t, body, "current-sid", "the cookie session ID must never be exposed")
+}
+
+func TestHandler_HandleRevokeMyOtherSessions_KeepsCurrent(t *testing.T) {
+	t.Parallel()
+
+	manager := &mockSessionManager{sessions: []*models.UserSession{{ID: 1}, {ID: 2}, {ID: 3}}}
+	rec := httptest.NewRecorder()
+	newSessionsHandler(manager).HandleRevokeMyOtherSessions(rec, sessionsRequest(http.MethodDelete, ""))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, testUserRegular.Email, manager.revokedEmail)
+	assert.Equal(t, "current-sid", manager.keptSession)
+	assert.Contains(t, rec.Body.String(), `"revoked":2`)
+}
+
+func TestHandler_HandleRevokeMySession(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		id       string
+		wantCode int
+	}{
+		{name: "revoked", id: "2", wantCode: http.StatusOK},
+		{name: "unknown session", id: "42", wantCode: http.StatusNotFound},
+		{name: "invalid id", id: "abc", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &mockSessionManager{sessions: []*models.UserSession{{ID: 1}, {ID: 2}}}
+			rec := httptest.NewRecorder()
+			newSessionsHandler(manager).HandleRevokeMySession(rec, sessionsRequest(http.MethodDelete, tt.id))
+			assert.Equal(t, tt.wantCode, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// CSPReportPath receives the violations reported by browsers (report-uri directive)
+const CSPReportPath = "/api/v1/csp-report"
+
+// defaultCSPSources are always allowed: the SPA itself, the CDNs it loads and OAuth provider avatars
+var defaultCSPSources = models.CSPSources{
+	ScriptSrc:  []string{"'self'", "'unsafe-inline'", "https://cdn.tailwindcss.com"},
+	StyleSrc:   []string{"'self'", "'unsafe-inline'", "https://cdn.tailwindcss.com", "https://fonts.googleapis.com"},
+	ConnectSrc: []string{"'self'"},
+	ImgSrc: []string{"'self'", "data:", "https://cdn.simpleicons.org", "https://*.googleusercontent.com",
+		"https://avatars.githubusercontent.com", "https://secure.gravatar.com", "https://gitlab.com"},
+	FontSrc: []string{"'self'", "https://fonts.gstatic.com"},
+}
+
+// cspConfigProvider returns the current configuration, holding the sources added by admins
+type cspConfigProvider interface {
+	GetConfig() *models.MutableConfig
+}
+
+// cspPolicies caches the policies built for one configuration
+type cspPolicies struct {
+	config *models.MutableConfig
+	page   string
+	embed  string
+}
+
+// CSPBuilder builds the Content-Security-Policy of the frontend pages from the built-in sources,
+// the sources set by environment variables and those added from the admin settings
+type CSPBuilder struct {
+	sources             models.CSPSources
+	embedFrameAncestors []string
+	reportURI           string
+	configProvider      cspConfigProvider
+	cache               atomic.Pointer[cspPolicies]
+}
+
+// NewCSPBuilder creates a builder extending the built-in sources with the environment ones
+func NewCSPBuilder(cfg config.CSPConfig) *CSPBuilder {
+	embedFrameAncestors := cfg.EmbedFrameAncestors
+	if len(embedFrameAncestors) == 0 {
+		embedFrameAncestors = []string{"*"}
+	}
+	return &CSPBuilder{
+		sources:             mergeCSPSources(defaultCSPSources, cfg.Sources),
+		embedFrameAncestors: embedFrameAncestors,
+		reportURI:           CSPReportPath,
+	}
+}
+
+// SetConfigProvider adds the sources of the admin settings, read again when the configuration changes
+func (b *CSPBuilder) SetConfigProvider(provider cspConfigProvider) {
+	b.configProvider = provider
+}
+
+// Policy returns the policy of the frontend pages, or of the embed pages which may be framed
+// by the origins of the admin settings, else by those of the environment
+func (b *CSPBuilder) Policy(embed bool) string {
+	var current *models.MutableConfig
+	if b.configProvider != nil {
+		current = b.configProvider.GetConfig()
+	}
+
+	policies := b.cache.Load()
+	if policies == nil || policies.config != current {
+		sources := b.sources
+		embedFrameAncestors := b.embedFrameAncestors
+		if current != nil {
+			sources = mergeCSPSources(sources, current.Security.CSP)
+			if len(current.Security.EmbedFrameAncestors) > 0 {
+				embedFrameAncestors = current.Security.EmbedFrameAncestors
+			}
+		}
+		policies = &cspPolicies{
+			config: current,
+			page:   b.build(sources, []string{"'self'"}),
+			embed:  b.build(sources, embedFrameAncestors),
+		}
+		b.cache.Store(policies)
+	}
+
+	if embed {
+		return policies.embed
+	}
+	return policies.page
+}
+
+func (b *CSPBuilder) build(sources models.CSPSources, frameAncestors []string) string {
+	directives := []string{
+		"default-src 'self'",
+		"style-src " + strings.Join(sources.StyleSrc, " "),
+		"font-src " + strings.Join(sources.FontSrc, " "),
+		"script-src " + strings.Join(sources.ScriptSrc, " "),
+		"img-src " + strings.Join(sources.ImgSrc, " "),
+		"connect-src " + strings.Join(sources.ConnectSrc, " "),
+		"frame-ancestors " + strings.Join(frameAncestors, " "),
+	}
+	if b.reportURI != "" {
+		directives = append(directives, "report-uri "+b.reportURI)
+	}
+	return strings.Join(directives, "; ")
+}
+
+// mergeCSPSources appends the extra sources to the base ones, without duplicates
+func mergeCSPSources(base, extra models.CSPSources) models.CSPSources {
+	return models.CSPSources{
+		ScriptSrc:  appendUnique(base.ScriptSrc, extra.ScriptSrc),
+		StyleSrc:   appendUnique(base.StyleSrc, extra.StyleSrc),
+		ConnectSrc: appendUnique(base.ConnectSrc, extra.ConnectSrc),
+		ImgSrc:     appendUnique(base.ImgSrc, extra.ImgSrc),
+		FontSrc:    appendUnique(base.FontSrc, extra.FontSrc),
+	}
+}
+
+func appendUnique(base, extra []string) []string {
+	merged := make([]string, 0, len(base)+len(extra))
+	seen := make(map[string]bool, len(base)+len(extra))
+	for _, list := range [][]string{base, extra} {
+		for _, source := range list {
+			if !seen[source] {
+				seen[source] = true
+				merged = append(merged, source)
+			}
+		}
+	}
+	return merged
+}
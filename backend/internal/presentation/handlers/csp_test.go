@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeCSPConfig struct {
+	cfg *models.MutableConfig
+}
+
+func (f *fakeCSPConfig) GetConfig() *models.MutableConfig { return f.cfg }
+
+func TestCSPBuilder_Defaults(t *testing.T) {
+	t.Parallel()
+
+	builder := NewCSPBuilder(config.CSPConfig{})
+
+	page := builder.Policy(false)
+	assert.Contains(t, page, "default-src 'self'")
+	assert.Contains(t, page, "connect-src 'self';")
+	assert.Contains(t, page, "frame-ancestors 'self'")
+	assert.Contains(t, page, "report-uri "+CSPReportPath)
+	assert.Contains(t, builder.Policy(true), "frame-ancestors *")
+}
+
+func TestCSPBuilder_EnvironmentAndSettings(t *testing.T) {
+	t.Parallel()
+
+	builder := NewCSPBuilder(config.CSPConfig{
+		Sources: models.CSPSources{
+			FontSrc:    []string{"https://fonts.example.com", "'self'"},
+			ConnectSrc: []string{"wss://ackify.example.com"},
+		},
+		EmbedFrameAncestors: []string{"https://intranet.example.com"},
+	})
+	provider := &fakeCSPConfig{cfg: &models.MutableConfig{}}
+	builder.SetConfigProvider(provider)
+
+	page := builder.Policy(false)
+	assert.Contains(t, page, "font-src 'self' https://fonts.gstatic.com https://fonts.example.com;", "duplicates are removed")
+	assert.Contains(t, page, "connect-src 'self' wss://ackify.example.com;")
+	assert.Contains(t, builder.Policy(true), "frame-ancestors https://intranet.example.com;")
+
+	// A configuration change from the admin settings is applied to the next requests
+	provider.cfg = &models.MutableConfig{Security: models.SecurityConfig{
+		CSP: models.CSPSources{ImgSrc: []string{"https://cdn.example.com"}},
+	}}
+	assert.Contains(t, builder.Policy(false), "https://gitlab.com https://cdn.example.com;")
+	assert.Contains(t, builder.Policy(false), "wss://ackify.example.com", "environment sources are kept")
+}
+
+func TestSecureHeadersWithCSP(t *testing.T) {
+	t.Parallel()
+
+	builder := NewCSPBuilder(config.CSPConfig{Sources: models.CSPSources{StyleSrc: []string{"https://fonts.example.com"}}})
+	handler := SecureHeadersWithCSP(builder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/documents", nil))
+
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.Equal(t, builder.Policy(false), rec.Header().Get("Content-Security-Policy"))
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "https://fonts.example.com")
+}
+
+func TestCSPBuilder_EmbedFrameAncestorsFromSettings(t *testing.T) {
+	t.Parallel()
+
+	builder := NewCSPBuilder(config.CSPConfig{EmbedFrameAncestors: []string{"https://wiki.example.com"}})
+	provider := &fakeCSPConfig{cfg: &models.MutableConfig{Security: models.SecurityConfig{
+		EmbedFrameAncestors: []string{"'self'", "https://intranet.example.com"},
+	}}}
+	builder.SetConfigProvider(provider)
+
+	embed := builder.Policy(true)
+	assert.Contains(t, embed, "frame-ancestors 'self' https://intranet.example.com;")
+	assert.NotContains(t, embed, "wiki.example.com", "the settings list replaces the environment one")
+	assert.Contains(t, builder.Policy(false), "frame-ancestors 'self';", "other pages stay same-origin")
+
+	// Clearing the settings list falls back to the environment
+	provider.cfg = &models.MutableConfig{}
+	assert.Contains(t, builder.Policy(true), "frame-ancestors https://wiki.example.com;")
+}
+
+func TestSecureHeaders_EmbedPolicyRoutes(t *testing.T) {
+	t.Parallel()
+
+	builder := NewCSPBuilder(config.CSPConfig{EmbedFrameAncestors: []string{"https://intranet.example.com"}})
+	handler := SecureHeadersWithCSP(builder)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		path  string
+		embed bool
+	}{
+		{"/embed", true},
+		{"/embed/doc123", true},
+		{"/oembed", true},
+		{"/embedded", false},
+		{"/admin", false},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if tt.embed {
+			assert.Empty(t, rec.Header().Get("X-Frame-Options"), tt.path)
+			assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "frame-ancestors https://intranet.example.com", tt.path)
+		} else {
+			assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"), tt.path)
+			assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "frame-ancestors 'self'", tt.path)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// devPageCSP allows the plain pages of the development routes: no scripts, forms posting to the app
+const devPageCSP = "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'"
+
+// devCodeTTL bounds how long an authorization code of the fake OAuth provider can be exchanged
+const devCodeTTL = time.Minute
+
+// devUser is an identity offered by the fake OAuth provider
+type devUser struct {
+	Email string
+	Name  string
+}
+
+// devUsers are the identities listed on the user picker, any other address can be typed in
+var devUsers = []devUser{
+	{Email: config.DevAdminEmail, Name: "Ada Admin"},
+	{Email: "alice@ackify.local", Name: "Alice Martin"},
+	{Email: "bob@ackify.local", Name: "Bob Durand"},
+}
+
+type devGrant struct {
+	user          devUser
+	redirectURI   string
+	codeChallenge string
+	expiresAt     time.Time
+}
+
+// DevOAuthProvider is the fake OAuth provider of the development mode. Its authorization page
+// lets the developer pick who to sign in as, without password.
+type DevOAuthProvider struct {
+	clientID     string
+	clientSecret string
+	baseURL      string
+
+	mu     sync.Mutex
+	codes  map[string]devGrant
+	tokens map[string]devUser
+}
+
+// NewDevOAuthProvider creates the fake provider accepting the given client
+func NewDevOAuthProvider(clientID, clientSecret, baseURL string) *DevOAuthProvider {
+	return &DevOAuthProvider{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		codes:        make(map[string]devGrant),
+		tokens:       make(map[string]devUser),
+	}
+}
+
+// devMailbox lists the emails caught in development mode
+type devMailbox interface {
+	Messages() []email.MailboxMessage
+	Message(id string) (email.MailboxMessage, bool)
+	Clear()
+}
+
+// DevRoutes returns the routes mounted under /dev in development mode: the fake OAuth provider
+// and, when emails are caught, the mailbox
+func DevRoutes(provider *DevOAuthProvider, mailbox devMailbox) http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "no-store")
+			w.Header().Set("X-Robots-Tag", "noindex")
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	r.Get("/oauth/authorize", provider.HandleAuthorize)
+	r.Post("/oauth/authorize", provider.HandleAuthorizeSubmit)
+	r.Post("/oauth/token", provider.HandleToken)
+	r.Get("/oauth/userinfo", provider.HandleUserInfo)
+
+	if mailbox != nil {
+		r.Get("/mailbox", HandleDevMailbox(mailbox))
+		r.Delete("/mailbox", HandleDevMailboxClear(mailbox))
+		r.Get("/mailbox/{id}", HandleDevMailboxMessage(mailbox))
+	}
+	return r
+}
+
+var devPickerTemplate = template.Must(template.New("picker").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Ackify dev sign-in</title></head>
+<body style="font-family: sans-serif; max-width: 32rem; margin: 3rem auto;">
+<h1>Sign in as&hellip;</h1>
+<p>Development mode: pick any identity, no password is asked.</p>
+{{range .Users}}
+<form method="post" action="/dev/oauth/authorize">
+{{template "hidden" $}}
+<input type="hidden" name="email" value="{{.Email}}">
+<input type="hidden" name="name" value="{{.Name}}">
+<p><button type="submit">{{.Name}} &lt;{{.Email}}&gt;</button></p>
+</form>
+{{end}}
+<h2>Someone else</h2>
+<form method="post" action="/dev/oauth/authorize">
+{{template "hidden" .}}
+<p><label>Email <input type="email" name="email" required></label></p>
+<p><label>Name <input type="text" name="name"></label></p>
+<p><button type="submit">Sign in</button></p>
+</form>
+</body>
+</html>
+{{define "hidden"}}<input type="hidden" name="redirect_uri" value="{{.RedirectURI}}">
+<input type="hidden" name="state" value="{{.State}}">
+<input type="hidden" name="code_challenge" value="{{.CodeChallenge}}">{{end}}`))
+
+// HandleAuthorize handles GET /dev/oauth/authorize and renders the user picker
+func (p *DevOAuthProvider) HandleAuthorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != p.clientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	if !p.validRedirectURI(q.Get("redirect_uri")) {
+		http.Error(w, "redirect_uri must point to this application", http.StatusBadRequest)
+		return
+	}
+	if method := q.Get("code_challenge_method"); q.Get("code_challenge") != "" && method != "S256" {
+		http.Error(w, "unsupported code_challenge_method", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", devPageCSP)
+	_ = devPickerTemplate.Execute(w, map[string]any{
+		"Users":         devUsers,
+		"RedirectURI":   q.Get("redirect_uri"),
+		"State":         q.Get("state"),
+		"CodeChallenge": q.Get("code_challenge"),
+	})
+}
+
+// HandleAuthorizeSubmit handles POST /dev/oauth/authorize: it issues a code for the picked user
+// and sends the browser back to the application
+func (p *DevOAuthProvider) HandleAuthorizeSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI := r.PostForm.Get("redirect_uri")
+	if !p.validRedirectURI(redirectURI) {
+		http.Error(w, "redirect_uri must point to this application", http.StatusBadRequest)
+		return
+	}
+	userEmail := strings.ToLower(strings.TrimSpace(r.PostForm.Get("email")))
+	if !strings.Contains(userEmail, "@") {
+		http.Error(w, "an email is required", http.StatusBadRequest)
+		return
+	}
+	name := strings.TrimSpace(r.PostForm.Get("name"))
+	if name == "" {
+		name = userEmail[:strings.Index(userEmail, "@")]
+	}
+
+	code := devRandomToken()
+	p.mu.Lock()
+	p.codes[code] = devGrant{
+		user:          devUser{Email: userEmail, Name: name},
+		redirectURI:   redirectURI,
+		codeChallenge: r.PostForm.Get("code_challenge"),
+		expiresAt:     time.Now().Add(devCodeTTL),
+	}
+	p.mu.Unlock()
+
+	logger.Logger.Info("Dev OAuth sign-in", "email", userEmail)
+
+	target, _ := url.Parse(redirectURI)
+	query := target.Query()
+	query.Set("code", code)
+	query.Set("state", r.PostForm.Get("state"))
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// HandleToken handles POST /dev/oauth/token, exchanging a code for an access token
+func (p *DevOAuthProvider) HandleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeDevOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) != 1 {
+		writeDevOAuthError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeDevOAuthError(w, http.StatusBadRequest, "unsupported_grant_type")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	grant, found := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !found || time.Now().After(grant.expiresAt) || grant.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeDevOAuthError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+	if grant.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != grant.codeChallenge {
+			writeDevOAuthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+	}
+
+	token := devRandomToken()
+	p.mu.Lock()
+	p.tokens[token] = grant.user
+	p.mu.Unlock()
+
+	writeDevOAuthJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// HandleUserInfo handles GET /dev/oauth/userinfo for the holder of an access token
+func (p *DevOAuthProvider) HandleUserInfo(w http.ResponseWriter, r *http.Request) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found {
+		writeDevOAuthError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	p.mu.Lock()
+	user, found := p.tokens[token]
+	p.mu.Unlock()
+	if !found {
+		writeDevOAuthError(w, http.StatusUnauthorized, "invalid_token")
+		return
+	}
+
+	writeDevOAuthJSON(w, http.StatusOK, map[string]any{
+		"sub":            "dev|" + user.Email,
+		"email":          user.Email,
+		"email_verified": true,
+		"name":           user.Name,
+	})
+}
+
+// validRedirectURI only lets the provider send codes back to the application itself
+func (p *DevOAuthProvider) validRedirectURI(redirectURI string) bool {
+	return strings.HasPrefix(redirectURI, p.baseURL+"/")
+}
+
+func devRandomToken() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeDevOAuthError(w http.ResponseWriter, status int, code string) {
+	writeDevOAuthJSON(w, status, map[string]string{"error": code})
+}
+
+// writeDevOAuthJSON writes a bare JSON body, as OAuth clients expect it (no API envelope)
+func writeDevOAuthJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+var devMailboxTemplate = template.Must(template.New("mailbox").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>Ackify dev mailbox</title></head>
+<body style="font-family: sans-serif; margin: 2rem;">
+<h1>Dev mailbox</h1>
+<p>Emails caught in development mode, most recent first. Also available as JSON with <code>Accept: application/json</code>.</p>
+{{if .}}
+<table cellpadding="6">
+<tr><th align="left">Sent</th><th align="left">To</th><th align="left">Subject</th><th align="left">Template</th><th></th></tr>
+{{range .}}
+<tr>
+<td>{{.SentAt.Format "15:04:05"}}</td>
+<td>{{range $i, $to := .To}}{{if $i}}, {{end}}{{$to}}{{end}}</td>
+<td><a href="/dev/mailbox/{{.ID}}">{{.Subject}}</a></td>
+<td>{{.Template}}</td>
+<td><a href="/dev/mailbox/{{.ID}}?format=text">text</a></td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p>No email yet.</p>
+{{end}}
+</body>
+</html>`))
+
+// HandleDevMailbox handles GET /dev/mailbox, listing the caught emails as a page or as JSON
+func HandleDevMailbox(mailbox devMailbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		messages := mailbox.Messages()
+		if strings.Contains(r.Header.Get("Accept"), "application/json") || r.URL.Query().Get("format") == "json" {
+			shared.WriteJSON(w, http.StatusOK, messages)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", devPageCSP)
+		_ = devMailboxTemplate.Execute(w, messages)
+	}
+}
+
+// HandleDevMailboxClear handles DELETE /dev/mailbox
+func HandleDevMailboxClear(mailbox devMailbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mailbox.Clear()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleDevMailboxMessage handles GET /dev/mailbox/{id}, showing an email as it would be received.
+// The HTML body is sandboxed, its links still open the application.
+func HandleDevMailboxMessage(mailbox devMailbox) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		msg, found := mailbox.Message(chi.URLParam(r, "id"))
+		if !found {
+			shared.WriteNotFound(w, "Email")
+			return
+		}
+
+		switch r.URL.Query().Get("format") {
+		case "json":
+			shared.WriteJSON(w, http.StatusOK, msg)
+		case "text":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte(msg.Text))
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; img-src * data:; sandbox allow-popups allow-top-navigation-by-user-activation")
+			_, _ = w.Write([]byte(msg.HTML))
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const devTestCallback = "http://localhost:8080/api/v1/auth/callback"
+
+type fakeDevMailbox struct {
+	messages []email.MailboxMessage
+}
+
+func (f *fakeDevMailbox) Messages() []email.MailboxMessage { return f.messages }
+
+func (f *fakeDevMailbox) Message(id string) (email.MailboxMessage, bool) {
+	for _, msg := range f.messages {
+		if msg.ID == id {
+			return msg, true
+		}
+	}
+	return email.MailboxMessage{}, false
+}
+
+func (f *fakeDevMailbox) Clear() { f.messages = nil }
+
+func serveDev(handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func devForm(method, path string, form url.Values) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestDevOAuthProvider_Flow(t *testing.T) {
+	routes := DevRoutes(NewDevOAuthProvider("client", "secret", "http://localhost:8080"), nil)
+
+	verifier := "a-long-enough-pkce-code-verifier-for-the-test"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	query := url.Values{
+		"client_id":             {"client"},
+		"redirect_uri":          {devTestCallback},
+		"state":                 {"state-1"},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+	rec := serveDev(routes, httptest.NewRequest(http.MethodGet, "/oauth/authorize?"+query.Encode(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "alice@ackify.local")
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "form-action 'self'")
+
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/authorize", url.Values{
+		"redirect_uri":   {devTestCallback},
+		"state":          {"state-1"},
+		"code_challenge": {challenge},
+		"email":          {"Alice@Ackify.local"},
+		"name":           {"Alice"},
+	}))
+	require.Equal(t, http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", location.Query().Get("state"))
+	code := location.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	exchange := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {devTestCallback},
+		"code_verifier": {verifier},
+		"client_id":     {"client"},
+		"client_secret": {"secret"},
+	}
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/token", exchange))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &token))
+	require.NotEmpty(t, token.AccessToken)
+
+	// A code is single use
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/token", exchange))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	rec = serveDev(routes, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var info map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	assert.Equal(t, "alice@ackify.local", info["email"])
+	assert.Equal(t, "Alice", info["name"])
+	assert.Equal(t, "dev|alice@ackify.local", info["sub"])
+}
+
+func TestDevOAuthProvider_Rejections(t *testing.T) {
+	provider := NewDevOAuthProvider("client", "secret", "http://localhost:8080")
+	routes := DevRoutes(provider, nil)
+
+	rec := serveDev(routes, httptest.NewRequest(http.MethodGet, "/oauth/authorize?client_id=other&redirect_uri="+url.QueryEscape(devTestCallback), nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/authorize", url.Values{
+		"redirect_uri": {"https://evil.example.com/callback"},
+		"email":        {"alice@ackify.local"},
+	}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/authorize", url.Values{
+		"redirect_uri":   {devTestCallback},
+		"email":          {"bob@ackify.local"},
+		"code_challenge": {"expected-challenge"},
+	}))
+	require.Equal(t, http.StatusFound, rec.Code)
+	location, _ := url.Parse(rec.Header().Get("Location"))
+
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {location.Query().Get("code")},
+		"redirect_uri":  {devTestCallback},
+		"code_verifier": {"wrong-verifier"},
+		"client_id":     {"client"},
+		"client_secret": {"secret"},
+	}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveDev(routes, devForm(http.MethodPost, "/oauth/token", url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {"client"},
+		"client_secret": {"wrong"},
+	}))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/oauth/userinfo", nil)
+	req.Header.Set("Authorization", "Bearer unknown")
+	assert.Equal(t, http.StatusUnauthorized, serveDev(routes, req).Code)
+}
+
+func TestDevMailbox(t *testing.T) {
+	mailbox := &fakeDevMailbox{messages: []email.MailboxMessage{
+		{ID: "1", To: []string{"alice@ackify.local"}, Subject: "Sign <this>", HTML: "<p>Hello</p>", Text: "Hello"},
+	}}
+	routes := DevRoutes(NewDevOAuthProvider("client", "secret", "http://localhost:8080"), mailbox)
+
+	rec := serveDev(routes, httptest.NewRequest(http.MethodGet, "/mailbox", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Sign &lt;this&gt;")
+	assert.Contains(t, rec.Body.String(), "/dev/mailbox/1")
+
+	req := httptest.NewRequest(http.MethodGet, "/mailbox", nil)
+	req.Header.Set("Accept", "application/json")
+	rec = serveDev(routes, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":"1"`)
+
+	rec = serveDev(routes, httptest.NewRequest(http.MethodGet, "/mailbox/1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "<p>Hello</p>", rec.Body.String())
+	assert.Contains(t, rec.Header().Get("Content-Security-Policy"), "sandbox")
+
+	rec = serveDev(routes, httptest.NewRequest(http.MethodGet, "/mailbox/1?format=text", nil))
+	assert.Equal(t, "Hello", rec.Body.String())
+
+	rec = serveDev(routes, httptest.NewRequest(http.MethodGet, "/mailbox/2", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serveDev(routes, httptest.NewRequest(http.MethodDelete, "/mailbox", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, mailbox.messages)
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// documentAliasResolver returns the ID of the document an alias leads to, or an empty string
+type documentAliasResolver interface {
+	ResolveAlias(ctx context.Context, alias string) (string, error)
+}
+
+// RedirectDocumentAliases permanently redirects the pages opened with an alias in their doc
+// parameter (/?doc=security-policy) to the same page with the ID of the document, so links
+// keep working once a document gets a new address. Other requests go to next unchanged.
+// withTenant sets the tenant context the alias is looked up in.
+func RedirectDocumentAliases(resolver documentAliasResolver, withTenant func(http.Handler) http.Handler, next http.Handler) http.HandlerFunc {
+	redirect := withTenant(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		alias := query.Get("doc")
+		docID, err := resolver.ResolveAlias(r.Context(), alias)
+		if err != nil {
+			// The page still opens, the frontend reports the unknown document
+			logger.Logger.Warn("Failed to resolve document alias", "alias", alias, "error", err.Error())
+		}
+		if docID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		query.Set("doc", docID)
+		location := *r.URL
+		location.RawQuery = query.Encode()
+		http.Redirect(w, r, location.String(), http.StatusMovedPermanently)
+	}))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Query().Get("doc") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		redirect.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDocumentAliasResolver struct {
+	aliases map[string]string
+	lookups int
+}
+
+func (f *fakeDocumentAliasResolver) ResolveAlias(_ context.Context, alias string) (string, error) {
+	f.lookups++
+	return f.aliases[alias], nil
+}
+
+func TestRedirectDocumentAliases(t *testing.T) {
+	resolver := &fakeDocumentAliasResolver{aliases: map[string]string{"security-policy": "abc123"}}
+	tenantContexts := 0
+	withTenant := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantContexts++
+			next.ServeHTTP(w, r)
+		})
+	}
+	spa := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := RedirectDocumentAliases(resolver, withTenant, spa)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec
+	}
+
+	rec := serve(http.MethodGet, "/?doc=security-policy&lang=fr")
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/?doc=abc123&lang=fr", rec.Header().Get("Location"))
+
+	// Document IDs and unknown references open the page
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/?doc=abc123").Code)
+	assert.Equal(t, 2, resolver.lookups)
+
+	// Pages and assets without doc parameter are served without lookup
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/assets/app.js").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodHead, "/?doc=security-policy").Code)
+	assert.Equal(t, 2, resolver.lookups)
+	assert.Equal(t, 2, tenantContexts)
+}
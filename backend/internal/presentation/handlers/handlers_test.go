@@ -108,7 +108,7 @@ func TestHandleOEmbed_Success(t *testing.T) {
 	t.Parallel()
 
 	baseURL := "https://example.com"
-	handler := HandleOEmbed(baseURL)
+	handler := HandleOEmbed(baseURL, nil)
 
 	tests := []struct {
 		name     string
@@ -164,10 +164,60 @@ func TestHandleOEmbed_Success(t *testing.T) {
 	}
 }
 
+func TestHandleOEmbed_Branding(t *testing.T) {
+	t.Parallel()
+
+	handler := HandleOEmbed("https://example.com", func() *models.Branding {
+		return &models.Branding{Organisation: "Acme", PrimaryColor: "#0F766E"}
+	})
+	req := httptest.NewRequest(http.MethodGet, "/oembed?url="+url.QueryEscape("https://example.com/?doc=doc123"), nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	var response OEmbedResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.ProviderName != "Acme" {
+		t.Errorf("Expected provider 'Acme', got %s", response.ProviderName)
+	}
+	if !strings.Contains(response.HTML, "#0F766E") {
+		t.Error("Expected HTML to use the primary color")
+	}
+}
+
+func TestHandleOEmbed_ConditionalGet(t *testing.T) {
+	t.Parallel()
+
+	handler := HandleOEmbed("https://example.com", nil)
+	target := "/oembed?url=" + url.QueryEscape("https://example.com/?doc=doc123")
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag header")
+	}
+	if rec.Header().Get("Cache-Control") != "public, max-age=3600" {
+		t.Errorf("Unexpected Cache-Control %q", rec.Header().Get("Cache-Control"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("Expected 304, got %d", rec.Code)
+	}
+	if rec.Body.Len() != 0 {
+		t.Error("Expected an empty body for 304")
+	}
+}
+
 func TestHandleOEmbed_MissingURLParam(t *testing.T) {
 	t.Parallel()
 
-	handler := HandleOEmbed("https://example.com")
+	handler := HandleOEmbed("https://example.com", nil)
 	req := httptest.NewRequest(http.MethodGet, "/oembed", nil)
 	rec := httptest.NewRecorder()
 
@@ -181,7 +231,7 @@ func TestHandleOEmbed_MissingURLParam(t *testing.T) {
 func TestHandleOEmbed_InvalidURL(t *testing.T) {
 	t.Parallel()
 
-	handler := HandleOEmbed("https://example.com")
+	handler := HandleOEmbed("https://example.com", nil)
 	req := httptest.NewRequest(http.MethodGet, "/oembed?url=:::invalid", nil)
 	rec := httptest.NewRecorder()
 
@@ -195,7 +245,7 @@ func TestHandleOEmbed_InvalidURL(t *testing.T) {
 func TestHandleOEmbed_MissingDocParam(t *testing.T) {
 	t.Parallel()
 
-	handler := HandleOEmbed("https://example.com")
+	handler := HandleOEmbed("https://example.com", nil)
 	req := httptest.NewRequest(http.MethodGet, "/oembed?url="+url.QueryEscape("https://example.com/"), nil)
 	rec := httptest.NewRecorder()
 
@@ -211,7 +261,7 @@ func TestHandleOEmbed_MissingDocParam(t *testing.T) {
 // ============================================================================
 
 func BenchmarkHandleOEmbed(b *testing.B) {
-	handler := HandleOEmbed("https://example.com")
+	handler := HandleOEmbed("https://example.com", nil)
 	reqURL := url.QueryEscape("https://example.com/?doc=test123")
 
 	b.ResetTimer()
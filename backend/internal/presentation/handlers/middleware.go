@@ -6,43 +6,39 @@ import (
 	"strings"
 	"time"
 
+	"github.com/btouchard/ackify-ce/backend/pkg/config"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 )
 
-// SecureHeaders enforces baseline security headers (CSP, XFO, etc.)
+// SecureHeaders enforces baseline security headers (CSP, XFO, etc.) with the built-in CSP
 func SecureHeaders(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("X-Content-Type-Options", "nosniff")
-		w.Header().Set("Referrer-Policy", "no-referrer")
+	return SecureHeadersWithCSP(NewCSPBuilder(config.CSPConfig{}))(next)
+}
 
-		isEmbedRoute := strings.HasPrefix(r.URL.Path, "/embed/") || strings.HasPrefix(r.URL.Path, "/embed")
+// SecureHeadersWithCSP enforces baseline security headers with the CSP of the builder
+func SecureHeadersWithCSP(csp *CSPBuilder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.Header().Set("Referrer-Policy", "no-referrer")
 
-		// OAuth provider avatar domains
-		imgSrc := "img-src 'self' data: https://cdn.simpleicons.org https://*.googleusercontent.com https://avatars.githubusercontent.com https://secure.gravatar.com https://gitlab.com"
+			if isEmbedRoute(r.URL.Path) {
+				// X-Frame-Options cannot list origins: framing is only controlled by frame-ancestors
+				w.Header().Set("Content-Security-Policy", csp.Policy(true))
+			} else {
+				w.Header().Set("X-Frame-Options", "DENY")
+				w.Header().Set("Content-Security-Policy", csp.Policy(false))
+			}
 
-		if isEmbedRoute {
-			w.Header().Set("Content-Security-Policy",
-				"default-src 'self'; "+
-					"style-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com https://fonts.googleapis.com; "+
-					"font-src 'self' https://fonts.gstatic.com; "+
-					"script-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com; "+
-					imgSrc+"; "+
-					"connect-src 'self'; "+
-					"frame-ancestors *")
-		} else {
-			w.Header().Set("X-Frame-Options", "DENY")
-			w.Header().Set("Content-Security-Policy",
-				"default-src 'self'; "+
-					"style-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com https://fonts.googleapis.com; "+
-					"font-src 'self' https://fonts.gstatic.com; "+
-					"script-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com; "+
-					imgSrc+"; "+
-					"connect-src 'self'; "+
-					"frame-ancestors 'self'")
-		}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
 
-		next.ServeHTTP(w, r)
-	})
+// isEmbedRoute reports whether the path is an embed page, meant to be framed by other sites,
+// or the oEmbed endpoint describing it
+func isEmbedRoute(path string) bool {
+	return path == "/embed" || strings.HasPrefix(path, "/embed/") || path == "/oembed"
 }
 
 func RequestLogger(next http.Handler) http.Handler {
@@ -68,3 +64,8 @@ func (sr *statusRecorder) WriteHeader(code int) {
 	sr.status = code
 	sr.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap exposes the underlying writer to http.ResponseController (e.g. WebSocket hijacking)
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
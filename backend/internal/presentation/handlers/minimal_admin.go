@@ -0,0 +1,562 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+	"github.com/btouchard/ackify-ce/backend/pkg/types"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// MinimalAdminPath is where the server-rendered admin fallback is mounted
+	MinimalAdminPath = "/minimal"
+
+	// minimalAdminPageCSP allows the server-rendered admin pages: no scripts, forms posting to the app
+	minimalAdminPageCSP = "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'"
+
+	// minimalAdminPageSize is the number of documents per page of the document list
+	minimalAdminPageSize = 50
+
+	// minimalAdminCSRFField is the form field holding the CSRF token of the pages
+	minimalAdminCSRFField = "csrf_token"
+)
+
+// minimalAdminAuth signs admins in and out of the minimal UI
+type minimalAdminAuth interface {
+	IsOIDCEnabled() bool
+	StartOIDC(w http.ResponseWriter, r *http.Request, nextURL string) string
+	IsMagicLinkEnabled() bool
+	RequestMagicLink(ctx context.Context, email, redirectTo, ip, userAgent, locale string) error
+	Logout(w http.ResponseWriter, r *http.Request)
+}
+
+// minimalAdminAuthorizer tells admins apart; authorizers implementing providers.PermissionAuthorizer
+// also restrict the pages to the permission of the matching API endpoint
+type minimalAdminAuthorizer interface {
+	IsAdmin(ctx context.Context, userEmail string) bool
+}
+
+// minimalAdminDocuments lists the documents and the status of their expected signers
+type minimalAdminDocuments interface {
+	GetDocument(ctx context.Context, docID string) (*models.Document, error)
+	ListDocuments(ctx context.Context, limit, offset int) ([]*models.Document, error)
+	SearchDocuments(ctx context.Context, query string, limit, offset int) ([]*models.Document, error)
+	CountDocuments(ctx context.Context, searchQuery string) (int, error)
+	ListExpectedSignersWithStatus(ctx context.Context, docID string) ([]*models.ExpectedSignerWithStatus, error)
+	GetSignerStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
+}
+
+// minimalAdminReminders sends reminders to pending signers
+type minimalAdminReminders interface {
+	SendRemindersWithLevel(ctx context.Context, docID, sentBy string, specificEmails []string, docURL, locale string, level models.ReminderLevel) (*models.ReminderSendResult, error)
+}
+
+// minimalAdminCSRF issues and checks the tokens embedded in the forms of the pages
+type minimalAdminCSRF interface {
+	GenerateCSRFToken() (string, error)
+	ValidateCSRFToken(token string) bool
+}
+
+var minimalAdminTemplates = template.Must(template.New("minimal").Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{.Title}} - Ackify</title>
+<style>body{margin:0 auto;max-width:64rem;padding:1rem;font-family:system-ui,sans-serif;line-height:1.5;color:#1a1a1a}a{color:#0b57d0}table{border-collapse:collapse;width:100%}th,td{text-align:left;padding:.35rem .5rem;border-bottom:1px solid #ddd}header{display:flex;justify-content:space-between;align-items:center;border-bottom:2px solid #0b57d0;margin-bottom:1rem}.notice{padding:.5rem;background:#e8f0fe}.error{padding:.5rem;background:#fce8e6}form.inline{display:inline}</style>
+</head>
+<body>
+<header>
+<p><a href="{{.Base}}/">Ackify admin</a></p>
+{{- if .User}}
+<form class="inline" method="post" action="{{.Base}}/logout">
+<input type="hidden" name="csrf_token" value="{{.CSRF}}">
+{{.User}} <button type="submit">Sign out</button>
+</form>
+{{- end}}
+</header>
+<main>
+{{- if .Notice}}
+<p class="notice" role="status">{{.Notice}}</p>
+{{- end}}
+{{- if .Error}}
+<p class="error" role="alert">{{.Error}}</p>
+{{- end}}
+{{end}}
+
+{{define "footer"}}</main>
+</body>
+</html>
+{{end}}
+
+{{define "message"}}{{template "header" .}}
+<h1>{{.Title}}</h1>
+{{- if .User}}
+<p><a href="{{.Base}}/">Back to documents</a></p>
+{{- end}}
+{{template "footer" .}}{{end}}
+
+{{define "login"}}{{template "header" .}}
+<h1>{{.Title}}</h1>
+{{- if .OIDC}}
+<p><a href="{{.Base}}/login">Sign in with your organisation account</a></p>
+{{- end}}
+{{- if .MagicLink}}
+<form method="post" action="{{.Base}}/login">
+<input type="hidden" name="csrf_token" value="{{.CSRF}}">
+<p><label>Email <input type="email" name="email" required autocomplete="email"></label>
+<button type="submit">Email me a sign-in link</button></p>
+</form>
+{{- end}}
+{{- if not (or .OIDC .MagicLink)}}
+<p>No sign-in method is enabled.</p>
+{{- end}}
+{{template "footer" .}}{{end}}
+
+{{define "documents"}}{{template "header" .}}
+<h1>{{.Title}}</h1>
+<form method="get" action="{{.Base}}/">
+<p><label>Search <input type="search" name="q" value="{{.Query}}"></label> <button type="submit">Search</button></p>
+</form>
+<p>{{.Total}} document(s)</p>
+<table>
+<thead><tr><th scope="col">Document</th><th scope="col">Created by</th><th scope="col">Created</th></tr></thead>
+<tbody>
+{{- range .Documents}}
+<tr><td><a href="{{$.Base}}/documents/{{.DocID}}">{{if .Title}}{{.Title}}{{else}}{{.DocID}}{{end}}</a></td><td>{{.CreatedBy}}</td><td>{{.CreatedAt.UTC.Format "2006-01-02"}}</td></tr>
+{{- else}}
+<tr><td colspan="3">No documents.</td></tr>
+{{- end}}
+</tbody>
+</table>
+<p>
+{{- if .PrevURL}}<a href="{{.PrevURL}}" rel="prev">Previous</a> {{end}}
+{{- if .NextURL}}<a href="{{.NextURL}}" rel="next">Next</a>{{end}}
+</p>
+{{template "footer" .}}{{end}}
+
+{{define "document"}}{{template "header" .}}
+<p><a href="{{.Base}}/">Back to documents</a></p>
+<h1>{{.Title}}</h1>
+<dl>
+<dt>Document ID</dt><dd>{{.Document.DocID}}</dd>
+{{- if .Document.URL}}
+<dt>URL</dt><dd><a href="{{.Document.URL}}" rel="noopener noreferrer">{{.Document.URL}}</a></dd>
+{{- end}}
+<dt>Signed</dt><dd>{{.Stats.SignedCount}} of {{.Stats.ExpectedCount}} expected signers ({{printf "%.0f" .Stats.CompletionRate}}%)</dd>
+</dl>
+<h2>Expected signers</h2>
+<form method="post" action="{{.Base}}/documents/{{.Document.DocID}}/reminders">
+<input type="hidden" name="csrf_token" value="{{.CSRF}}">
+<table>
+<thead><tr><th scope="col">Remind</th><th scope="col">Signer</th><th scope="col">Status</th><th scope="col">Reminders</th></tr></thead>
+<tbody>
+{{- range .Signers}}
+<tr>
+<td>{{if not .HasSigned}}<input type="checkbox" name="email" value="{{.Email}}" aria-label="Remind {{.Email}}">{{end}}</td>
+<td>{{if .Name}}{{.Name}} &lt;{{.Email}}&gt;{{else}}{{.Email}}{{end}}</td>
+<td>{{if .HasSigned}}Signed{{if .SignedAt}} on {{.SignedAt.UTC.Format "2006-01-02 15:04"}} UTC{{end}}{{else}}Pending{{end}}</td>
+<td>{{.ReminderCount}}{{if .LastReminderSent}}, last on {{.LastReminderSent.UTC.Format "2006-01-02"}}{{end}}</td>
+</tr>
+{{- else}}
+<tr><td colspan="4">No expected signers.</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- if .CanRemind}}
+{{- if .Stats.PendingCount}}
+<p><button type="submit">Remind selected signers</button> <button type="submit" name="all" value="1">Remind all pending signers</button></p>
+{{- end}}
+{{- end}}
+</form>
+{{template "footer" .}}{{end}}
+`))
+
+// minimalPage holds the values shared by every page of the minimal UI
+type minimalPage struct {
+	Base   string
+	Title  string
+	User   string
+	CSRF   string
+	Notice string
+	Error  string
+}
+
+// MinimalAdmin serves a server-rendered admin fallback for deployments without the frontend
+// bundle: documents, the status of their expected signers, and reminders. Pages have no script
+// and reuse the session, the admin permissions and the tenant transaction of the API.
+type MinimalAdmin struct {
+	auth       minimalAdminAuth
+	authorizer minimalAdminAuthorizer
+	documents  minimalAdminDocuments
+	reminders  minimalAdminReminders
+	csrf       minimalAdminCSRF
+	urlSigner  contentURLSigner
+}
+
+// contentURLSigner issues expiring links to the content of stored documents
+type contentURLSigner interface {
+	SignedURL(docID string) string
+}
+
+// NewMinimalAdmin creates the minimal admin UI
+func NewMinimalAdmin(auth minimalAdminAuth, authorizer minimalAdminAuthorizer, documents minimalAdminDocuments, reminders minimalAdminReminders, csrf minimalAdminCSRF) *MinimalAdmin {
+	return &MinimalAdmin{
+		auth:       auth,
+		authorizer: authorizer,
+		documents:  documents,
+		reminders:  reminders,
+		csrf:       csrf,
+	}
+}
+
+// SetURLSigner enables signed content links for stored documents in reminders
+func (h *MinimalAdmin) SetURLSigner(signer contentURLSigner) {
+	h.urlSigner = signer
+}
+
+// MinimalAdminRoutes returns the routes mounted under /minimal. middlewares run first, in order;
+// they must open the tenant transaction and put the session user in the context.
+// loginRateLimit limits the sign-in link requests.
+func MinimalAdminRoutes(h *MinimalAdmin, loginRateLimit func(http.Handler) http.Handler, middlewares ...func(http.Handler) http.Handler) http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "no-store")
+			w.Header().Set("X-Robots-Tag", "noindex")
+			w.Header().Set("Content-Security-Policy", minimalAdminPageCSP)
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Use(middlewares...)
+
+	r.With(loginRateLimit).Get("/login", h.HandleLogin)
+	r.With(loginRateLimit).Post("/login", h.HandleRequestLink)
+	r.Post("/logout", h.HandleLogout)
+	r.Get("/", h.HandleDocuments)
+	r.Get("/documents/{docId}", h.HandleDocument)
+	r.Post("/documents/{docId}/reminders", h.HandleSendReminders)
+	return r
+}
+
+// HandleLogin handles GET /minimal/login: it sends the browser to the OIDC provider
+func (h *MinimalAdmin) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	if !h.auth.IsOIDCEnabled() {
+		h.renderLogin(w, http.StatusOK, "", "")
+		return
+	}
+	authURL := h.auth.StartOIDC(w, r, MinimalAdminPath+"/")
+	if authURL == "" {
+		h.renderLogin(w, http.StatusInternalServerError, "", "Sign-in could not be started.")
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// HandleRequestLink handles POST /minimal/login: it emails a sign-in link back to the minimal UI
+func (h *MinimalAdmin) HandleRequestLink(w http.ResponseWriter, r *http.Request) {
+	if !h.validForm(w, r) {
+		return
+	}
+	if !h.auth.IsMagicLinkEnabled() {
+		h.renderLogin(w, http.StatusServiceUnavailable, "", "Sign-in links are not enabled.")
+		return
+	}
+	email := strings.TrimSpace(r.PostFormValue("email"))
+	if email == "" {
+		h.renderLogin(w, http.StatusBadRequest, "", "Email is required.")
+		return
+	}
+
+	err := h.auth.RequestMagicLink(r.Context(), email, MinimalAdminPath+"/", shared.RemoteIP(r), r.UserAgent(), i18n.GetLangFromRequest(r))
+	if err != nil {
+		// Don't reveal if email exists or not
+		logger.Logger.Error("Failed to request magic link", "error", err.Error())
+	}
+	h.renderLogin(w, http.StatusOK, "If the email exists, a sign-in link has been sent.", "")
+}
+
+// HandleLogout handles POST /minimal/logout
+func (h *MinimalAdmin) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	if !h.validForm(w, r) {
+		return
+	}
+	h.auth.Logout(w, r)
+	http.Redirect(w, r, MinimalAdminPath+"/", http.StatusSeeOther)
+}
+
+// HandleDocuments handles GET /minimal/, the list of documents with an optional search
+func (h *MinimalAdmin) HandleDocuments(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.requireAdmin(w, r, models.PermissionDocumentsRead)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	offset := (page - 1) * minimalAdminPageSize
+
+	var documents []*models.Document
+	var err error
+	if query != "" {
+		documents, err = h.documents.SearchDocuments(ctx, query, minimalAdminPageSize, offset)
+	} else {
+		documents, err = h.documents.ListDocuments(ctx, minimalAdminPageSize, offset)
+	}
+	if err != nil {
+		logger.Logger.Error("Failed to list documents", "error", err.Error(), "search", query)
+		h.renderMessage(w, user, http.StatusInternalServerError, "Documents could not be listed")
+		return
+	}
+	total, err := h.documents.CountDocuments(ctx, query)
+	if err != nil {
+		logger.Logger.Warn("Failed to count documents, using result count", "error", err.Error(), "search", query)
+		total = offset + len(documents)
+	}
+
+	pageURL := func(page int) string {
+		values := url.Values{"page": {strconv.Itoa(page)}}
+		if query != "" {
+			values.Set("q", query)
+		}
+		return MinimalAdminPath + "/?" + values.Encode()
+	}
+	data := struct {
+		minimalPage
+		Query     string
+		Total     int
+		Documents []*models.Document
+		PrevURL   string
+		NextURL   string
+	}{
+		minimalPage: h.page(user, "Documents"),
+		Query:       query,
+		Total:       total,
+		Documents:   documents,
+	}
+	if page > 1 {
+		data.PrevURL = pageURL(page - 1)
+	}
+	if offset+len(documents) < total {
+		data.NextURL = pageURL(page + 1)
+	}
+	h.render(w, http.StatusOK, "documents", data)
+}
+
+// HandleDocument handles GET /minimal/documents/{docId}, the status of the expected signers
+func (h *MinimalAdmin) HandleDocument(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.requireAdmin(w, r, models.PermissionDocumentsRead)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+	docID := chi.URLParam(r, "docId")
+
+	doc, err := h.documents.GetDocument(ctx, docID)
+	if err != nil || doc == nil {
+		h.renderMessage(w, user, http.StatusNotFound, "Document not found")
+		return
+	}
+	signers, err := h.documents.ListExpectedSignersWithStatus(ctx, docID)
+	if err != nil {
+		logger.Logger.Error("Failed to get signers", "doc_id", docID, "error", err.Error())
+		h.renderMessage(w, user, http.StatusInternalServerError, "Signers could not be listed")
+		return
+	}
+	stats, err := h.documents.GetSignerStats(ctx, docID)
+	if err != nil {
+		logger.Logger.Error("Failed to get signer stats", "doc_id", docID, "error", err.Error())
+		h.renderMessage(w, user, http.StatusInternalServerError, "Signers could not be listed")
+		return
+	}
+
+	title := doc.Title
+	if title == "" {
+		title = doc.DocID
+	}
+	data := struct {
+		minimalPage
+		Document  *models.Document
+		Signers   []*models.ExpectedSignerWithStatus
+		Stats     *models.DocCompletionStats
+		CanRemind bool
+	}{
+		minimalPage: h.page(user, title),
+		Document:    doc,
+		Signers:     signers,
+		Stats:       stats,
+		CanRemind:   h.reminders != nil && h.can(ctx, user, models.PermissionRemindersSend),
+	}
+	data.Notice = reminderNotice(r.URL.Query())
+	h.render(w, http.StatusOK, "document", data)
+}
+
+// HandleSendReminders handles POST /minimal/documents/{docId}/reminders: the checked signers are
+// reminded, or all pending signers with the "all" button
+func (h *MinimalAdmin) HandleSendReminders(w http.ResponseWriter, r *http.Request) {
+	user, ok := h.requireAdmin(w, r, models.PermissionRemindersSend)
+	if !ok || !h.validForm(w, r) {
+		return
+	}
+	ctx := r.Context()
+	docID := chi.URLParam(r, "docId")
+
+	if h.reminders == nil {
+		h.renderMessage(w, user, http.StatusServiceUnavailable, "Reminder service not configured")
+		return
+	}
+	doc, err := h.documents.GetDocument(ctx, docID)
+	if err != nil || doc == nil {
+		h.renderMessage(w, user, http.StatusNotFound, "Document not found")
+		return
+	}
+
+	var emails []string
+	if r.PostFormValue("all") == "" {
+		emails = r.PostForm["email"]
+		if len(emails) == 0 {
+			h.renderMessage(w, user, http.StatusBadRequest, "Select the signers to remind")
+			return
+		}
+	}
+
+	docURL := doc.URL
+	if docURL == "" && h.urlSigner != nil && doc.IsStored() {
+		docURL = h.urlSigner.SignedURL(doc.DocID)
+	}
+	result, err := h.reminders.SendRemindersWithLevel(ctx, docID, user.Email, emails, docURL, i18n.GetLangFromRequest(r), "")
+	if err != nil {
+		logger.Logger.Error("Failed to send reminders", "doc_id", docID, "error", err.Error())
+		h.renderMessage(w, user, http.StatusInternalServerError, "Failed to send reminders")
+		return
+	}
+
+	values := url.Values{
+		"sent":     {strconv.Itoa(result.SuccessfullySent)},
+		"deferred": {strconv.Itoa(result.Deferred)},
+		"failed":   {strconv.Itoa(result.Failed)},
+		"skipped":  {strconv.Itoa(len(result.Skipped))},
+	}
+	http.Redirect(w, r, MinimalAdminPath+"/documents/"+url.PathEscape(docID)+"?"+values.Encode(), http.StatusSeeOther)
+}
+
+// reminderNotice describes the outcome of the reminders sent before the redirect
+func reminderNotice(query url.Values) string {
+	if !query.Has("sent") {
+		return ""
+	}
+	count := func(name string) int {
+		n, _ := strconv.Atoi(query.Get(name))
+		return n
+	}
+	notice := strconv.Itoa(count("sent")) + " reminder(s) sent"
+	if n := count("deferred"); n > 0 {
+		notice += ", " + strconv.Itoa(n) + " deferred to working hours"
+	}
+	if n := count("skipped"); n > 0 {
+		notice += ", " + strconv.Itoa(n) + " skipped"
+	}
+	if n := count("failed"); n > 0 {
+		notice += ", " + strconv.Itoa(n) + " failed"
+	}
+	return notice + "."
+}
+
+// requireAdmin returns the signed-in admin holding permission. Visitors without session get the
+// sign-in page, other users a 403.
+func (h *MinimalAdmin) requireAdmin(w http.ResponseWriter, r *http.Request, permission models.Permission) (*types.User, bool) {
+	user, ok := shared.GetUserFromContext(r.Context())
+	if !ok || user == nil {
+		h.renderLogin(w, http.StatusUnauthorized, "", "")
+		return nil, false
+	}
+	if !h.authorizer.IsAdmin(r.Context(), user.Email) {
+		logger.Logger.Warn("admin_access_denied", "user_email", user.Email, "path", r.URL.Path)
+		h.renderMessage(w, user, http.StatusForbidden, "Admin access required")
+		return nil, false
+	}
+	if !h.can(r.Context(), user, permission) {
+		logger.Logger.Warn("admin_permission_denied", "user_email", user.Email, "permission", permission, "path", r.URL.Path)
+		h.renderMessage(w, user, http.StatusForbidden, "Missing permission: "+string(permission))
+		return nil, false
+	}
+	return user, true
+}
+
+// can reports whether the admin holds permission; authorizers without role support grant every
+// permission to admins
+func (h *MinimalAdmin) can(ctx context.Context, user *types.User, permission models.Permission) bool {
+	permAuthorizer, ok := h.authorizer.(providers.PermissionAuthorizer)
+	return !ok || permAuthorizer.HasPermission(ctx, user.Email, permission)
+}
+
+// validForm parses a posted form and checks its CSRF token
+func (h *MinimalAdmin) validForm(w http.ResponseWriter, r *http.Request) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return false
+	}
+	if !h.csrf.ValidateCSRFToken(r.PostFormValue(minimalAdminCSRFField)) {
+		http.Error(w, "Invalid or missing CSRF token, reload the page", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// page returns the common values of a page, with a fresh CSRF token for its forms
+func (h *MinimalAdmin) page(user *types.User, title string) minimalPage {
+	page := minimalPage{Base: MinimalAdminPath, Title: title}
+	if user != nil {
+		page.User = user.Email
+	}
+	token, err := h.csrf.GenerateCSRFToken()
+	if err != nil {
+		logger.Logger.Error("Failed to generate CSRF token", "error", err.Error())
+	}
+	page.CSRF = token
+	return page
+}
+
+func (h *MinimalAdmin) renderLogin(w http.ResponseWriter, status int, notice, errMessage string) {
+	data := struct {
+		minimalPage
+		OIDC      bool
+		MagicLink bool
+	}{
+		minimalPage: h.page(nil, "Sign in"),
+		OIDC:        h.auth.IsOIDCEnabled(),
+		MagicLink:   h.auth.IsMagicLinkEnabled(),
+	}
+	data.Notice, data.Error = notice, errMessage
+	h.render(w, status, "login", data)
+}
+
+func (h *MinimalAdmin) renderMessage(w http.ResponseWriter, user *types.User, status int, message string) {
+	h.render(w, status, "message", h.page(user, message))
+}
+
+func (h *MinimalAdmin) render(w http.ResponseWriter, status int, name string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := minimalAdminTemplates.ExecuteTemplate(w, name, data); err != nil {
+		logger.Logger.Error("Failed to render minimal admin page", "page", name, "error", err.Error())
+	}
+}
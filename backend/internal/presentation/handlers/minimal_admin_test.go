@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMinimalAuth struct {
+	magicLinks []string
+}
+
+func (f *fakeMinimalAuth) IsOIDCEnabled() bool { return true }
+
+func (f *fakeMinimalAuth) StartOIDC(_ http.ResponseWriter, _ *http.Request, nextURL string) string {
+	return "https://idp.example.com/authorize?next=" + url.QueryEscape(nextURL)
+}
+
+func (f *fakeMinimalAuth) IsMagicLinkEnabled() bool { return true }
+
+func (f *fakeMinimalAuth) RequestMagicLink(_ context.Context, email, redirectTo, _, _, _ string) error {
+	f.magicLinks = append(f.magicLinks, email+" "+redirectTo)
+	return nil
+}
+
+func (f *fakeMinimalAuth) Logout(http.ResponseWriter, *http.Request) {}
+
+type fakeMinimalAuthorizer map[string]bool
+
+func (f fakeMinimalAuthorizer) IsAdmin(_ context.Context, email string) bool { return f[email] }
+
+type fakeMinimalDocuments struct {
+	docs    []*models.Document
+	signers []*models.ExpectedSignerWithStatus
+}
+
+func (f *fakeMinimalDocuments) GetDocument(_ context.Context, docID string) (*models.Document, error) {
+	for _, doc := range f.docs {
+		if doc.DocID == docID {
+			return doc, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeMinimalDocuments) ListDocuments(_ context.Context, limit, offset int) ([]*models.Document, error) {
+	if offset >= len(f.docs) {
+		return nil, nil
+	}
+	return f.docs[offset:min(offset+limit, len(f.docs))], nil
+}
+
+func (f *fakeMinimalDocuments) SearchDocuments(_ context.Context, query string, _, _ int) ([]*models.Document, error) {
+	var docs []*models.Document
+	for _, doc := range f.docs {
+		if strings.Contains(doc.Title, query) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func (f *fakeMinimalDocuments) CountDocuments(ctx context.Context, query string) (int, error) {
+	docs, _ := f.SearchDocuments(ctx, query, 0, 0)
+	return len(docs), nil
+}
+
+func (f *fakeMinimalDocuments) ListExpectedSignersWithStatus(context.Context, string) ([]*models.ExpectedSignerWithStatus, error) {
+	return f.signers, nil
+}
+
+func (f *fakeMinimalDocuments) GetSignerStats(_ context.Context, docID string) (*models.DocCompletionStats, error) {
+	stats := &models.DocCompletionStats{DocID: docID, ExpectedCount: len(f.signers)}
+	for _, signer := range f.signers {
+		if signer.HasSigned {
+			stats.SignedCount++
+		}
+	}
+	stats.PendingCount = stats.ExpectedCount - stats.SignedCount
+	stats.CompletionRate = 100 * float64(stats.SignedCount) / float64(stats.ExpectedCount)
+	return stats, nil
+}
+
+type fakeMinimalReminders struct {
+	sentBy string
+	emails []string
+	calls  int
+}
+
+func (f *fakeMinimalReminders) SendRemindersWithLevel(_ context.Context, _, sentBy string, emails []string, _, _ string, _ models.ReminderLevel) (*models.ReminderSendResult, error) {
+	f.calls++
+	f.sentBy, f.emails = sentBy, emails
+	return &models.ReminderSendResult{TotalAttempted: 1, SuccessfullySent: 1}, nil
+}
+
+type fakeMinimalCSRF struct{}
+
+func (fakeMinimalCSRF) GenerateCSRFToken() (string, error) { return "token", nil }
+
+func (fakeMinimalCSRF) ValidateCSRFToken(token string) bool { return token == "token" }
+
+func newTestMinimalAdmin() (http.Handler, *fakeMinimalAuth, *fakeMinimalReminders) {
+	auth := &fakeMinimalAuth{}
+	documents := &fakeMinimalDocuments{
+		docs: []*models.Document{
+			{DocID: "policy", Title: "Security Policy", CreatedBy: "admin@example.com", CreatedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+			{DocID: "charter", Title: "IT Charter", CreatedBy: "admin@example.com", CreatedAt: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)},
+		},
+		signers: []*models.ExpectedSignerWithStatus{
+			{ExpectedSigner: models.ExpectedSigner{DocID: "policy", Email: "alice@example.com", Name: "Alice"}, HasSigned: true},
+			{ExpectedSigner: models.ExpectedSigner{DocID: "policy", Email: "bob@example.com"}},
+		},
+	}
+	reminders := &fakeMinimalReminders{}
+	handler := NewMinimalAdmin(auth, fakeMinimalAuthorizer{"admin@example.com": true}, documents, reminders, fakeMinimalCSRF{})
+
+	// Stands for the session middleware: the X-User header names the signed-in user
+	session := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if email := r.Header.Get("X-User"); email != "" {
+				r = r.WithContext(context.WithValue(r.Context(), shared.ContextKeyUser, &types.User{Email: email}))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	noLimit := func(next http.Handler) http.Handler { return next }
+
+	mux := http.NewServeMux()
+	mux.Handle(MinimalAdminPath+"/", http.StripPrefix(MinimalAdminPath, MinimalAdminRoutes(handler, noLimit, session)))
+	return mux, auth, reminders
+}
+
+func minimalRequest(method, target, user string, form url.Values) *http.Request {
+	var req *http.Request
+	if form != nil {
+		req = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req = httptest.NewRequest(method, target, nil)
+	}
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+	return req
+}
+
+func TestMinimalAdmin_RequiresAdmin(t *testing.T) {
+	router, _, _ := newTestMinimalAdmin()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/", "", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Sign in with your organisation account")
+	assert.Equal(t, minimalAdminPageCSP, rec.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/", "alice@example.com", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Admin access required")
+}
+
+func TestMinimalAdmin_Login(t *testing.T) {
+	router, auth, _ := newTestMinimalAdmin()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/login", "", nil))
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://idp.example.com/authorize?next=%2Fminimal%2F", rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodPost, "/minimal/login", "", url.Values{"email": {"admin@example.com"}}))
+	assert.Equal(t, http.StatusForbidden, rec.Code, "the CSRF token is required")
+	assert.Empty(t, auth.magicLinks)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodPost, "/minimal/login", "", url.Values{"email": {"admin@example.com"}, "csrf_token": {"token"}}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"admin@example.com /minimal/"}, auth.magicLinks)
+}
+
+func TestMinimalAdmin_Documents(t *testing.T) {
+	router, _, _ := newTestMinimalAdmin()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/", "admin@example.com", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `<a href="/minimal/documents/policy">Security Policy</a>`)
+	assert.Contains(t, body, "IT Charter")
+	assert.Contains(t, body, "2 document(s)")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/?q=Charter", "admin@example.com", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "Security Policy")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/documents/policy?sent=1&failed=0", "admin@example.com", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body = rec.Body.String()
+	assert.Contains(t, body, "1 of 2 expected signers (50%)")
+	assert.Contains(t, body, `name="email" value="bob@example.com"`)
+	assert.NotContains(t, body, `value="alice@example.com"`, "signers who signed cannot be reminded")
+	assert.Contains(t, body, "1 reminder(s) sent.")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodGet, "/minimal/documents/unknown", "admin@example.com", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMinimalAdmin_SendReminders(t *testing.T) {
+	router, _, reminders := newTestMinimalAdmin()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodPost, "/minimal/documents/policy/reminders", "admin@example.com",
+		url.Values{"email": {"bob@example.com"}, "csrf_token": {"forged"}}))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Zero(t, reminders.calls)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodPost, "/minimal/documents/policy/reminders", "admin@example.com",
+		url.Values{"email": {"bob@example.com"}, "csrf_token": {"token"}}))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/minimal/documents/policy?deferred=0&failed=0&sent=1&skipped=0", rec.Header().Get("Location"))
+	assert.Equal(t, "admin@example.com", reminders.sentBy)
+	assert.Equal(t, []string{"bob@example.com"}, reminders.emails)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodPost, "/minimal/documents/policy/reminders", "admin@example.com",
+		url.Values{"all": {"1"}, "csrf_token": {"token"}}))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Nil(t, reminders.emails, "all pending signers are reminded")
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, minimalRequest(http.MethodPost, "/minimal/documents/policy/reminders", "admin@example.com",
+		url.Values{"csrf_token": {"token"}}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, 2, reminders.calls)
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
 // OEmbedResponse represents the oEmbed JSON response format
@@ -23,14 +26,15 @@ type OEmbedResponse struct {
 }
 
 // HandleOEmbed handles GET /oembed?url=<document_url>
-// Returns oEmbed JSON for embedding Ackify signature widgets in external platforms
-func HandleOEmbed(baseURL string) http.HandlerFunc {
+// Returns oEmbed JSON for embedding Ackify signature widgets in external platforms.
+// branding is optional; when set, the organization name and primary color brand the embed.
+func HandleOEmbed(baseURL string, branding func() *models.Branding) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		urlParam := r.URL.Query().Get("url")
 		if urlParam == "" {
 			logger.Logger.Warn("oEmbed request missing url parameter",
 				"remote_addr", r.RemoteAddr)
-			http.Error(w, "Missing 'url' parameter", http.StatusBadRequest)
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Missing 'url' parameter", nil)
 			return
 		}
 
@@ -40,7 +44,7 @@ func HandleOEmbed(baseURL string) http.HandlerFunc {
 				"url", urlParam,
 				"error", err.Error(),
 				"remote_addr", r.RemoteAddr)
-			http.Error(w, "Invalid 'url' parameter", http.StatusBadRequest)
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "Invalid 'url' parameter", nil)
 			return
 		}
 
@@ -53,7 +57,7 @@ func HandleOEmbed(baseURL string) http.HandlerFunc {
 			logger.Logger.Warn("oEmbed request missing doc parameter in url",
 				"url", urlParam,
 				"remote_addr", r.RemoteAddr)
-			http.Error(w, "URL must contain 'doc' parameter", http.StatusBadRequest)
+			shared.WriteError(w, http.StatusBadRequest, shared.ErrCodeBadRequest, "URL must contain 'doc' parameter", nil)
 			return
 		}
 
@@ -64,26 +68,53 @@ func HandleOEmbed(baseURL string) http.HandlerFunc {
 			embedURL += "&referrer=" + url.QueryEscape(referrer)
 		}
 
-		iframeHTML := `<iframe src="` + embedURL + `" width="100%" height="200" frameborder="0" style="border: 1px solid #ddd; border-radius: 6px;" allowtransparency="true"></iframe>`
+		providerName := "Ackify"
+		borderColor := "#ddd"
+		if branding != nil {
+			if b := branding(); b != nil {
+				if b.Organisation != "" {
+					providerName = b.Organisation
+				}
+				if b.PrimaryColor != "" {
+					borderColor = b.PrimaryColor
+				}
+			}
+		}
+
+		iframeHTML := `<iframe src="` + embedURL + `" width="100%" height="200" frameborder="0" style="border: 1px solid ` + borderColor + `; border-radius: 6px;" allowtransparency="true"></iframe>`
 
 		response := OEmbedResponse{
 			Type:         "rich",
 			Version:      "1.0",
 			Title:        "Document " + docID + " - Confirmations de lecture",
-			ProviderName: "Ackify",
+			ProviderName: providerName,
 			ProviderURL:  baseURL,
 			HTML:         iframeHTML,
 			Height:       200,
 		}
 
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		body, err := json.Marshal(response)
+		if err != nil {
+			logger.Logger.Error("Failed to encode oEmbed response",
+				"doc_id", docID,
+				"error", err.Error())
+			shared.WriteInternalError(w)
+			return
+		}
+
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			logger.Logger.Error("Failed to encode oEmbed response",
+		// The embed code only depends on the request and the branding: let consumers cache it and revalidate cheaply
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		if shared.CheckNotModified(w, r, shared.ContentETag(string(body)), time.Time{}) {
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := w.Write(append(body, '\n')); err != nil {
+			logger.Logger.Warn("Failed to write oEmbed response",
 				"doc_id", docID,
 				"error", err.Error())
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
 
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// shortLinkResolver counts a scan of a short link and returns the signing page it leads to
+type shortLinkResolver interface {
+	Resolve(ctx context.Context, code string) (*models.ShortLink, string, error)
+}
+
+// HandleShortLink handles GET /s/{code}, the short link printed as a QR code on notices.
+// It counts the scan and redirects to the signing page of the document, in the language of the
+// link when it has one.
+func HandleShortLink(resolver shortLinkResolver, baseURL string) http.HandlerFunc {
+	secureCookies := strings.HasPrefix(baseURL, "https://")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := chi.URLParam(r, "code")
+		link, target, err := resolver.Resolve(r.Context(), code)
+		if err != nil {
+			if errors.Is(err, models.ErrShortLinkNotFound) {
+				shared.WriteNotFound(w, "Short link")
+				return
+			}
+			logger.Logger.Error("Failed to resolve short link", "code", code, "error", err.Error())
+			shared.WriteInternalError(w)
+			return
+		}
+
+		if link.Locale != "" {
+			i18n.SetLangCookie(w, link.Locale, secureCookies)
+		}
+		w.Header().Set("Cache-Control", "no-store")
+		http.Redirect(w, r, target, http.StatusFound)
+	}
+}
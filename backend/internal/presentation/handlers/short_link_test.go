@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeShortLinkResolver struct {
+	links map[string]*models.ShortLink
+	err   error
+}
+
+func (f *fakeShortLinkResolver) Resolve(_ context.Context, code string) (*models.ShortLink, string, error) {
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	link, ok := f.links[code]
+	if !ok {
+		return nil, "", models.ErrShortLinkNotFound
+	}
+	target := "https://sign.example.com/?doc=" + link.DocID
+	if link.Locale != "" {
+		target += "&lang=" + link.Locale
+	}
+	return link, target, nil
+}
+
+func serveShortLink(resolver shortLinkResolver, path string) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Get("/s/{code}", HandleShortLink(resolver, "https://sign.example.com"))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestHandleShortLink_Redirect(t *testing.T) {
+	resolver := &fakeShortLinkResolver{links: map[string]*models.ShortLink{
+		"Abcd1234": {Code: "Abcd1234", DocID: "doc1"},
+		"Frfr1234": {Code: "Frfr1234", DocID: "doc1", Locale: "fr"},
+	}}
+
+	rec := serveShortLink(resolver, "/s/Abcd1234")
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://sign.example.com/?doc=doc1", rec.Header().Get("Location"))
+	assert.Empty(t, rec.Result().Cookies())
+
+	rec = serveShortLink(resolver, "/s/Frfr1234")
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://sign.example.com/?doc=doc1&lang=fr", rec.Header().Get("Location"))
+	cookies := rec.Result().Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, "lang", cookies[0].Name)
+		assert.Equal(t, "fr", cookies[0].Value)
+		assert.True(t, cookies[0].Secure)
+	}
+}
+
+func TestHandleShortLink_NotFound(t *testing.T) {
+	rec := serveShortLink(&fakeShortLinkResolver{}, "/s/unknown")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serveShortLink(&fakeShortLinkResolver{err: errors.New("db down")}, "/s/Abcd1234")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
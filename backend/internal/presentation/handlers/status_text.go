@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+// statusDocuments reads a document and the completion of its expected signers
+type statusDocuments interface {
+	GetByDocID(ctx context.Context, docID string) (*models.Document, error)
+	GetExpectedSignerStats(ctx context.Context, docID string) (*models.DocCompletionStats, error)
+}
+
+// statusSignatures lists the signatures of a document
+type statusSignatures interface {
+	GetDocumentSignatures(ctx context.Context, docID string) ([]*models.Signature, error)
+}
+
+// statusTranslator translates the status sentences
+type statusTranslator interface {
+	T(lang, key string) string
+}
+
+// accessibleStatusPage is a page without script, meant to be framed by portals that must meet
+// accessibility requirements: the status is announced by screen readers as a live region.
+var accessibleStatusPage = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Label}}</title>
+<style>body{margin:0;padding:1rem;font-family:system-ui,sans-serif;font-size:1rem;line-height:1.5;color:#1a1a1a;background:#fff}a{color:#0b57d0}a:focus{outline:3px solid #0b57d0;outline-offset:2px}</style>
+</head>
+<body>
+<main aria-label="{{.Label}}">
+<p role="status" aria-live="polite" aria-atomic="true">{{.Sentence}}</p>
+{{- if .SignURL}}
+<p><a href="{{.SignURL}}" target="_blank" rel="noopener">{{.Link}}</a></p>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// StatusTextHandler serves the acknowledgement status of a document as a sentence in plain
+// language ("7 of 10 people have acknowledged …"), for screen readers and accessible embeds
+type StatusTextHandler struct {
+	documents  statusDocuments
+	signatures statusSignatures
+	i18n       statusTranslator
+	baseURL    string
+	now        func() time.Time
+}
+
+func NewStatusTextHandler(documents statusDocuments, signatures statusSignatures, translator statusTranslator, baseURL string) *StatusTextHandler {
+	return &StatusTextHandler{
+		documents:  documents,
+		signatures: signatures,
+		i18n:       translator,
+		baseURL:    baseURL,
+		now:        time.Now,
+	}
+}
+
+// HandleText handles GET /status.txt?doc=<id>&lang=<lang>
+func (h *StatusTextHandler) HandleText(w http.ResponseWriter, r *http.Request) {
+	docID, lang := statusParams(r)
+	if docID == "" {
+		writeStatusText(w, http.StatusBadRequest, lang, "Missing 'doc' parameter.")
+		return
+	}
+
+	sentence, found, err := h.sentence(r.Context(), docID, lang)
+	if err != nil {
+		logger.Logger.Error("Failed to build status text", "doc_id", docID, "error", err.Error())
+		writeStatusText(w, http.StatusInternalServerError, lang, "Internal error.")
+		return
+	}
+	if !found {
+		writeStatusText(w, http.StatusNotFound, lang, h.i18n.T(lang, "status.text.not_found"))
+		return
+	}
+	writeStatusText(w, http.StatusOK, lang, sentence)
+}
+
+// HandleEmbed handles GET /embed/status?doc=<id>&lang=<lang>, the accessible variant of the
+// embed: the same sentence as /status.txt in a page without script, with a link to sign
+func (h *StatusTextHandler) HandleEmbed(w http.ResponseWriter, r *http.Request) {
+	docID, lang := statusParams(r)
+	if docID == "" {
+		writeStatusText(w, http.StatusBadRequest, lang, "Missing 'doc' parameter.")
+		return
+	}
+
+	sentence, found, err := h.sentence(r.Context(), docID, lang)
+	if err != nil {
+		logger.Logger.Error("Failed to build status text", "doc_id", docID, "error", err.Error())
+		writeStatusText(w, http.StatusInternalServerError, lang, "Internal error.")
+		return
+	}
+
+	status := http.StatusOK
+	signURL := h.baseURL + "/?doc=" + url.QueryEscape(docID) + "&lang=" + lang
+	if !found {
+		status = http.StatusNotFound
+		sentence, signURL = h.i18n.T(lang, "status.text.not_found"), ""
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	err = accessibleStatusPage.Execute(w, map[string]string{
+		"Lang":     lang,
+		"Label":    h.i18n.T(lang, "status.embed.label"),
+		"Sentence": sentence,
+		"SignURL":  signURL,
+		"Link":     h.i18n.T(lang, "status.embed.link"),
+	})
+	if err != nil {
+		logger.Logger.Error("Failed to render accessible status page", "doc_id", docID, "error", err.Error())
+	}
+}
+
+// sentence describes the status of a document in lang; found is false when it does not exist.
+// Documents with expected signers count the expected signers who signed, others all signatures.
+func (h *StatusTextHandler) sentence(ctx context.Context, docID, lang string) (string, bool, error) {
+	doc, err := h.documents.GetByDocID(ctx, docID)
+	if err != nil {
+		return "", false, err
+	}
+	if doc == nil {
+		return "", false, nil
+	}
+
+	title := doc.Title
+	if title == "" {
+		title = doc.DocID
+	}
+	values := map[string]string{
+		"Title": title,
+		"Date":  h.now().UTC().Format(h.i18n.T(lang, "status.text.date_layout")),
+	}
+
+	if stats, err := h.documents.GetExpectedSignerStats(ctx, docID); err == nil && stats.ExpectedCount > 0 {
+		values["Signed"] = strconv.Itoa(stats.SignedCount)
+		values["Expected"] = strconv.Itoa(stats.ExpectedCount)
+		return interpolate(h.i18n.T(lang, "status.text.progress"), values), true, nil
+	}
+
+	signatures, err := h.signatures.GetDocumentSignatures(ctx, docID)
+	if err != nil {
+		return "", false, err
+	}
+	key := "status.text.count_other"
+	switch len(signatures) {
+	case 0:
+		key = "status.text.count_none"
+	case 1:
+		key = "status.text.count_one"
+	}
+	values["Count"] = strconv.Itoa(len(signatures))
+	return interpolate(h.i18n.T(lang, key), values), true, nil
+}
+
+// statusParams returns the document of the request, "doc" or "ref" like the embed page, and
+// its language
+func statusParams(r *http.Request) (string, string) {
+	docID := r.URL.Query().Get("doc")
+	if docID == "" {
+		docID = r.URL.Query().Get("ref")
+	}
+	return docID, i18n.GetLangFromQueryOrRequest(r)
+}
+
+func writeStatusText(w http.ResponseWriter, status int, lang, text string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Language", lang)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(text + "\n"))
+}
+
+// interpolate replaces the {{.Name}} placeholders of a translation in a single pass, so that
+// a title holding a placeholder is kept as is
+func interpolate(text string, values map[string]string) string {
+	pairs := make([]string, 0, 2*len(values))
+	for name, value := range values {
+		pairs = append(pairs, "{{."+name+"}}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
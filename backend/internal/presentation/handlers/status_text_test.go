@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStatusDocuments struct {
+	docs  map[string]*models.Document
+	stats map[string]*models.DocCompletionStats
+}
+
+func (f *fakeStatusDocuments) GetByDocID(_ context.Context, docID string) (*models.Document, error) {
+	return f.docs[docID], nil
+}
+
+func (f *fakeStatusDocuments) GetExpectedSignerStats(_ context.Context, docID string) (*models.DocCompletionStats, error) {
+	if stats, ok := f.stats[docID]; ok {
+		return stats, nil
+	}
+	return &models.DocCompletionStats{DocID: docID}, nil
+}
+
+type fakeStatusSignatures map[string]int
+
+func (f fakeStatusSignatures) GetDocumentSignatures(_ context.Context, docID string) ([]*models.Signature, error) {
+	return make([]*models.Signature, f[docID]), nil
+}
+
+type fakeStatusTranslator map[string]map[string]string
+
+func (f fakeStatusTranslator) T(lang, key string) string {
+	if value, ok := f[lang][key]; ok {
+		return value
+	}
+	return f["en"][key]
+}
+
+func newTestStatusTextHandler() *StatusTextHandler {
+	documents := &fakeStatusDocuments{
+		docs: map[string]*models.Document{
+			"policy":  {DocID: "policy", Title: "Security Policy v3"},
+			"charter": {DocID: "charter", Title: "IT Charter"},
+			"notice":  {DocID: "notice"},
+		},
+		stats: map[string]*models.DocCompletionStats{
+			"policy": {DocID: "policy", ExpectedCount: 10, SignedCount: 7},
+		},
+	}
+	translator := fakeStatusTranslator{
+		"en": {
+			"status.text.progress":    "{{.Signed}} of {{.Expected}} people have acknowledged \"{{.Title}}\" as of {{.Date}}.",
+			"status.text.count_none":  "No one has acknowledged \"{{.Title}}\" as of {{.Date}}.",
+			"status.text.count_one":   "1 person has acknowledged \"{{.Title}}\" as of {{.Date}}.",
+			"status.text.count_other": "{{.Count}} people have acknowledged \"{{.Title}}\" as of {{.Date}}.",
+			"status.text.date_layout": "January 2, 2006 at 15:04 MST",
+			"status.text.not_found":   "Document not found.",
+			"status.embed.label":      "Acknowledgement status",
+			"status.embed.link":       "Read and acknowledge the document",
+		},
+		"fr": {
+			"status.text.progress":    "{{.Signed}} personnes sur {{.Expected}} ont pris connaissance de « {{.Title}} » au {{.Date}}.",
+			"status.text.date_layout": "02/01/2006 à 15:04 MST",
+		},
+	}
+	handler := NewStatusTextHandler(documents, fakeStatusSignatures{"charter": 3}, translator, "https://sign.example.com")
+	handler.now = func() time.Time { return time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC) }
+	return handler
+}
+
+func TestStatusTextHandler_HandleText(t *testing.T) {
+	handler := newTestStatusTextHandler()
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/status.txt?doc=policy", http.StatusOK, "7 of 10 people have acknowledged \"Security Policy v3\" as of March 4, 2026 at 09:30 UTC.\n"},
+		{"/status.txt?doc=policy&lang=fr", http.StatusOK, "7 personnes sur 10 ont pris connaissance de « Security Policy v3 » au 04/03/2026 à 09:30 UTC.\n"},
+		{"/status.txt?ref=charter", http.StatusOK, "3 people have acknowledged \"IT Charter\" as of March 4, 2026 at 09:30 UTC.\n"},
+		{"/status.txt?doc=notice", http.StatusOK, "No one has acknowledged \"notice\" as of March 4, 2026 at 09:30 UTC.\n"},
+		{"/status.txt?doc=missing", http.StatusNotFound, "Document not found.\n"},
+		{"/status.txt", http.StatusBadRequest, "Missing 'doc' parameter.\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.HandleText(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		assert.Equal(t, tt.status, rec.Code, tt.path)
+		assert.Equal(t, tt.body, rec.Body.String(), tt.path)
+		assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"), tt.path)
+	}
+}
+
+func TestStatusTextHandler_HandleEmbed(t *testing.T) {
+	handler := newTestStatusTextHandler()
+
+	rec := httptest.NewRecorder()
+	handler.HandleEmbed(rec, httptest.NewRequest(http.MethodGet, "/embed/status?doc=policy&lang=fr", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "fr", rec.Header().Get("Content-Language"))
+	body := rec.Body.String()
+	assert.Contains(t, body, `<html lang="fr">`)
+	assert.Contains(t, body, `<p role="status" aria-live="polite" aria-atomic="true">7 personnes sur 10 ont pris connaissance de « Security Policy v3 » au 04/03/2026 à 09:30 UTC.</p>`)
+	assert.Contains(t, body, `href="https://sign.example.com/?doc=policy&amp;lang=fr"`)
+	assert.NotContains(t, body, "<script")
+
+	rec = httptest.NewRecorder()
+	handler.HandleEmbed(rec, httptest.NewRequest(http.MethodGet, "/embed/status?doc=missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Document not found.")
+	assert.NotContains(t, rec.Body.String(), "<a href")
+}
@@ -0,0 +1,12 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- Restore original updated_at trigger function
+CREATE OR REPLACE FUNCTION update_documents_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE documents DROP COLUMN IF EXISTS version;
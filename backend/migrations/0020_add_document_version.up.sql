@@ -0,0 +1,17 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- Add optimistic locking version to documents
+-- The version is incremented on every update so clients can detect concurrent edits
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN documents.version IS 'Optimistic locking version (incremented on each update)';
+
+-- Replace updated_at trigger function to also bump the version
+CREATE OR REPLACE FUNCTION update_documents_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = now();
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,82 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Recurring Acknowledgement Campaigns
+-- ============================================================================
+-- Campaigns periodically re-issue a source document (yearly or quarterly):
+-- each run clones the document under a new doc_id, copies the expected
+-- signers (so everybody starts unsigned again) and notifies them.
+-- ============================================================================
+
+-- Step 1: Campaign definitions
+CREATE TABLE campaigns (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    title TEXT NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    schedule TEXT NOT NULL CHECK (schedule IN ('yearly', 'quarterly')),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMPTZ NOT NULL,
+    last_run_at TIMESTAMPTZ,
+    created_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE campaigns IS 'Recurring acknowledgement campaigns re-issuing a source document on a schedule';
+COMMENT ON COLUMN campaigns.doc_id IS 'Source document cloned on each run';
+COMMENT ON COLUMN campaigns.schedule IS 'Recurrence: yearly or quarterly';
+COMMENT ON COLUMN campaigns.next_run_at IS 'Next time the scheduler will start a run';
+
+CREATE INDEX idx_campaigns_due ON campaigns(next_run_at) WHERE active = TRUE;
+CREATE INDEX idx_campaigns_tenant_id ON campaigns(tenant_id);
+
+-- Step 2: Campaign run history
+CREATE TABLE campaign_runs (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    campaign_id BIGINT NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    doc_id TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    triggered_by TEXT,
+    expected_count INT NOT NULL DEFAULT 0,
+    notified_count INT NOT NULL DEFAULT 0
+);
+
+COMMENT ON TABLE campaign_runs IS 'History of campaign runs, one cloned document per run';
+COMMENT ON COLUMN campaign_runs.doc_id IS 'Document created for this run';
+COMMENT ON COLUMN campaign_runs.triggered_by IS 'Email of the admin who triggered the run, NULL for scheduled runs';
+
+CREATE INDEX idx_campaign_runs_campaign ON campaign_runs(campaign_id, started_at DESC);
+CREATE INDEX idx_campaign_runs_tenant_id ON campaign_runs(tenant_id);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_campaigns_tenant_id_immutable
+    BEFORE UPDATE ON campaigns
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_campaign_runs_tenant_id_immutable
+    BEFORE UPDATE ON campaign_runs
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE campaigns ENABLE ROW LEVEL SECURITY;
+ALTER TABLE campaigns FORCE ROW LEVEL SECURITY;
+ALTER TABLE campaign_runs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE campaign_runs FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_campaigns ON campaigns;
+CREATE POLICY tenant_isolation_campaigns ON campaigns
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_campaign_runs ON campaign_runs;
+CREATE POLICY tenant_isolation_campaign_runs ON campaign_runs
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON campaigns TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE campaigns_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON campaign_runs TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE campaign_runs_id_seq TO ackify_app;
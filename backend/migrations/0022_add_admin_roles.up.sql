@@ -0,0 +1,43 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Delegated Admin Roles
+-- ============================================================================
+-- Admins listed in ACKIFY_ADMIN_EMAILS remain super-admins. This table lets
+-- them delegate narrower roles (viewer, document-manager, reminder-operator,
+-- super-admin) to other users, per tenant.
+-- ============================================================================
+
+-- Step 1: Create admin_roles table
+CREATE TABLE admin_roles (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('viewer', 'document-manager', 'reminder-operator', 'super-admin')),
+    granted_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, email)
+);
+
+COMMENT ON TABLE admin_roles IS 'Delegated admin roles assigned to users';
+COMMENT ON COLUMN admin_roles.email IS 'Lower-cased email of the user holding the role';
+COMMENT ON COLUMN admin_roles.granted_by IS 'Email of the admin who granted the role';
+
+-- Step 2: Add tenant_id immutability trigger
+CREATE TRIGGER tr_admin_roles_tenant_id_immutable
+    BEFORE UPDATE ON admin_roles
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE admin_roles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE admin_roles FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_admin_roles ON admin_roles;
+CREATE POLICY tenant_isolation_admin_roles ON admin_roles
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON admin_roles TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE admin_roles_id_seq TO ackify_app;
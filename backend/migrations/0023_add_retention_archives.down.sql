@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TABLE IF EXISTS document_archives;
+DROP TABLE IF EXISTS retention_policies;
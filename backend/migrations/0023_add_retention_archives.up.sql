@@ -0,0 +1,81 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Retention Policies and Document Archives
+-- ============================================================================
+-- Once a document is fully signed and the retention delay has elapsed, it is
+-- exported (gzip-compressed JSON) to object storage, soft-deleted and, if
+-- the policy says so, stripped of signer PII. Archives can be browsed,
+-- downloaded and restored.
+-- ============================================================================
+
+-- Step 1: One retention policy per tenant
+CREATE TABLE retention_policies (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL UNIQUE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    archive_after_days INT NOT NULL DEFAULT 365 CHECK (archive_after_days > 0),
+    purge_pii BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE retention_policies IS 'Per-tenant rules for archiving completed documents';
+COMMENT ON COLUMN retention_policies.archive_after_days IS 'Days after the last expected signature before the document is archived';
+COMMENT ON COLUMN retention_policies.purge_pii IS 'Remove signer emails and names from the database once archived';
+
+-- Step 2: Archive records
+CREATE TABLE document_archives (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    storage_key TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    signature_count INT NOT NULL DEFAULT 0,
+    pii_purged BOOLEAN NOT NULL DEFAULT FALSE,
+    archived_by TEXT NOT NULL,
+    archived_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    restored_by TEXT,
+    restored_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE document_archives IS 'Compressed exports of completed documents stored in object storage';
+COMMENT ON COLUMN document_archives.storage_key IS 'Object storage key of the gzip-compressed JSON export';
+COMMENT ON COLUMN document_archives.archived_by IS 'Email of the admin, or "retention-policy" for automatic archiving';
+
+CREATE INDEX idx_document_archives_doc_id ON document_archives(doc_id);
+CREATE INDEX idx_document_archives_archived_at ON document_archives(archived_at DESC);
+CREATE INDEX idx_document_archives_tenant_id ON document_archives(tenant_id);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_retention_policies_tenant_id_immutable
+    BEFORE UPDATE ON retention_policies
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_document_archives_tenant_id_immutable
+    BEFORE UPDATE ON document_archives
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE retention_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE retention_policies FORCE ROW LEVEL SECURITY;
+ALTER TABLE document_archives ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_archives FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_retention_policies ON retention_policies;
+CREATE POLICY tenant_isolation_retention_policies ON retention_policies
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_document_archives ON document_archives;
+CREATE POLICY tenant_isolation_document_archives ON document_archives
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON retention_policies TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE retention_policies_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_archives TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_archives_id_seq TO ackify_app;
@@ -0,0 +1,56 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Completion Notifications
+-- ============================================================================
+-- Per-document settings controlling when the document creator receives a
+-- completion summary (100% signed, threshold reached, deadline reached),
+-- and whether webhooks/Slack are notified too. The *_notified_at columns
+-- ensure each notification is sent only once.
+-- ============================================================================
+
+-- Step 1: Create document_completion_settings table
+CREATE TABLE document_completion_settings (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    notify_on_complete BOOLEAN NOT NULL DEFAULT TRUE,
+    threshold_percent INT CHECK (threshold_percent BETWEEN 1 AND 99),
+    deadline TIMESTAMPTZ,
+    notify_webhook BOOLEAN NOT NULL DEFAULT FALSE,
+    slack_webhook_url TEXT,
+    completed_notified_at TIMESTAMPTZ,
+    threshold_notified_at TIMESTAMPTZ,
+    deadline_notified_at TIMESTAMPTZ,
+    updated_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_completion_settings IS 'Per-document completion notification triggers for the document creator';
+COMMENT ON COLUMN document_completion_settings.threshold_percent IS 'Notify once this completion percentage is reached (NULL disables)';
+COMMENT ON COLUMN document_completion_settings.deadline IS 'Notify with the current status when this date is reached (NULL disables)';
+COMMENT ON COLUMN document_completion_settings.slack_webhook_url IS 'Optional Slack incoming webhook receiving the summary';
+
+CREATE INDEX idx_document_completion_settings_deadline ON document_completion_settings(deadline)
+    WHERE deadline IS NOT NULL AND deadline_notified_at IS NULL;
+CREATE INDEX idx_document_completion_settings_tenant_id ON document_completion_settings(tenant_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_completion_settings_tenant_id_immutable
+    BEFORE UPDATE ON document_completion_settings
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_completion_settings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_completion_settings FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_completion_settings ON document_completion_settings;
+CREATE POLICY tenant_isolation_document_completion_settings ON document_completion_settings
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_completion_settings TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_completion_settings_id_seq TO ackify_app;
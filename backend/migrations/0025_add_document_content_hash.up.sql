@@ -0,0 +1,8 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- Add a hash of the extracted text of URL documents (PDF/HTML)
+-- Re-serializing a document (new metadata, recompression) changes the byte checksum
+-- but not the content hash, so integrity checks can tell immaterial changes apart
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS content_hash TEXT;
+
+COMMENT ON COLUMN documents.content_hash IS 'SHA-256 of the normalized extracted text, tied to the byte checksum';
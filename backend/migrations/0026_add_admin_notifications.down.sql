@@ -0,0 +1,3 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TABLE IF EXISTS admin_notifications;
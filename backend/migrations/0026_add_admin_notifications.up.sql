@@ -0,0 +1,49 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Admin Notifications
+-- ============================================================================
+-- Per-user notification inbox (new signature on my documents, reminder email
+-- bounced, deadline approaching). New entries are also pushed live over
+-- WebSocket; read_at tracks the read/unread state.
+-- ============================================================================
+
+-- Step 1: Create admin_notifications table
+CREATE TABLE admin_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    recipient_email TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('signature_received', 'email_bounced', 'deadline_approaching')),
+    doc_id TEXT,
+    data JSONB NOT NULL DEFAULT '{}',
+    dedup_key TEXT,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE admin_notifications IS 'Notification inbox of document owners and admins';
+COMMENT ON COLUMN admin_notifications.dedup_key IS 'Optional key ensuring an event is stored only once per recipient';
+
+CREATE INDEX idx_admin_notifications_recipient ON admin_notifications(tenant_id, recipient_email, created_at DESC);
+CREATE INDEX idx_admin_notifications_unread ON admin_notifications(tenant_id, recipient_email)
+    WHERE read_at IS NULL;
+CREATE UNIQUE INDEX idx_admin_notifications_dedup ON admin_notifications(tenant_id, recipient_email, dedup_key)
+    WHERE dedup_key IS NOT NULL;
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_admin_notifications_tenant_id_immutable
+    BEFORE UPDATE ON admin_notifications
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE admin_notifications ENABLE ROW LEVEL SECURITY;
+ALTER TABLE admin_notifications FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_admin_notifications ON admin_notifications;
+CREATE POLICY tenant_isolation_admin_notifications ON admin_notifications
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON admin_notifications TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE admin_notifications_id_seq TO ackify_app;
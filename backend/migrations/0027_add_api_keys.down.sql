@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP INDEX IF EXISTS idx_webhooks_doc_id;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS doc_id;
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,57 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add API Keys
+-- ============================================================================
+-- API keys authenticate external systems (DMS, intranet) on the integration
+-- API. Only a SHA-256 hash of the key is stored; scopes reuse admin
+-- permissions. Webhooks can be scoped to a single document so an integration
+-- receives the status events of the documents it created.
+-- ============================================================================
+
+-- Step 1: Create api_keys table
+CREATE TABLE api_keys (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    key_prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT ARRAY[]::TEXT[],
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE api_keys IS 'API keys of external integrations';
+COMMENT ON COLUMN api_keys.key_prefix IS 'First characters of the key, displayed to identify it';
+COMMENT ON COLUMN api_keys.key_hash IS 'Hex SHA-256 of the key; the key itself is only shown once';
+COMMENT ON COLUMN api_keys.scopes IS 'Admin permissions granted to the key';
+
+CREATE UNIQUE INDEX idx_api_keys_hash ON api_keys(key_hash);
+CREATE INDEX idx_api_keys_tenant ON api_keys(tenant_id, created_at DESC);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_api_keys_tenant_id_immutable
+    BEFORE UPDATE ON api_keys
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE api_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE api_keys FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_api_keys ON api_keys;
+CREATE POLICY tenant_isolation_api_keys ON api_keys
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON api_keys TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE api_keys_id_seq TO ackify_app;
+
+-- Step 5: Document-scoped webhooks
+ALTER TABLE webhooks ADD COLUMN doc_id TEXT;
+
+COMMENT ON COLUMN webhooks.doc_id IS 'When set, only events of this document are delivered';
+
+CREATE INDEX idx_webhooks_doc_id ON webhooks(doc_id) WHERE doc_id IS NOT NULL;
@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP INDEX IF EXISTS idx_signatures_search;
+DROP INDEX IF EXISTS idx_expected_signers_search;
+DROP INDEX IF EXISTS idx_documents_search;
@@ -0,0 +1,28 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Full-Text Search Indexes
+-- ============================================================================
+-- GIN indexes over tsvector expressions for the admin search. The 'simple'
+-- configuration is used because documents are written in any language: no
+-- stemming, only lowercasing. Separators of URLs and emails are replaced by
+-- spaces so that each part can be searched on its own.
+-- The expressions must stay identical to the ones used by SearchRepository.
+-- ============================================================================
+
+-- Documents: title (A), description (B), identifiers (C)
+CREATE INDEX idx_documents_search ON documents USING GIN ((
+    setweight(to_tsvector('simple', title), 'A') ||
+    setweight(to_tsvector('simple', description), 'B') ||
+    setweight(to_tsvector('simple', doc_id || ' ' || translate(url, '/:.-_?=&#', '         ') || ' ' || coalesce(original_filename, '')), 'C')
+)) WHERE deleted_at IS NULL;
+
+-- Expected signers: name and email
+CREATE INDEX idx_expected_signers_search ON expected_signers USING GIN ((
+    to_tsvector('simple', name || ' ' || translate(email, '@.-_+', '     '))
+));
+
+-- Signatures: signer name and email
+CREATE INDEX idx_signatures_search ON signatures USING GIN ((
+    to_tsvector('simple', coalesce(user_name, '') || ' ' || translate(user_email, '@.-_+', '     '))
+));
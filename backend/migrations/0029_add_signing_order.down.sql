@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP INDEX IF EXISTS idx_expected_signers_sign_order;
+ALTER TABLE expected_signers DROP COLUMN IF EXISTS sign_order;
@@ -0,0 +1,17 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signing Order
+-- ============================================================================
+-- Expected signers can be given a position in a sequential workflow
+-- (employee -> manager -> HR). A signer may only sign once every signer with
+-- a lower position has signed; signers sharing a position sign in parallel.
+-- Signers without a position are not constrained.
+-- ============================================================================
+
+ALTER TABLE expected_signers
+    ADD COLUMN sign_order INT CHECK (sign_order > 0);
+
+COMMENT ON COLUMN expected_signers.sign_order IS 'Position in the signing sequence; NULL means the signer can sign at any time';
+
+CREATE INDEX idx_expected_signers_sign_order ON expected_signers(doc_id, sign_order) WHERE sign_order IS NOT NULL;
@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE signatures DROP COLUMN IF EXISTS quiz_answers;
+ALTER TABLE signatures DROP COLUMN IF EXISTS quiz_score;
+
+DROP TABLE IF EXISTS document_quizzes;
@@ -0,0 +1,55 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Quizzes
+-- ============================================================================
+-- A document can carry a comprehension quiz (multiple-choice questions stored
+-- as JSONB and a pass threshold). Signers submit their answers with the
+-- signature; the signature is refused below the threshold, otherwise the
+-- score and answers are recorded on the signature row.
+-- ============================================================================
+
+-- Step 1: Create document_quizzes table
+CREATE TABLE document_quizzes (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    questions JSONB NOT NULL DEFAULT '[]',
+    pass_threshold INT NOT NULL DEFAULT 100 CHECK (pass_threshold BETWEEN 1 AND 100),
+    updated_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_quizzes IS 'Comprehension quiz signers must pass before signing a document';
+COMMENT ON COLUMN document_quizzes.questions IS 'Array of {id, text, options, answer} where answer is the index of the correct option';
+COMMENT ON COLUMN document_quizzes.pass_threshold IS 'Minimum score in percent required to sign';
+
+CREATE INDEX idx_document_quizzes_tenant_id ON document_quizzes(tenant_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_quizzes_tenant_id_immutable
+    BEFORE UPDATE ON document_quizzes
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_quizzes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_quizzes FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_quizzes ON document_quizzes;
+CREATE POLICY tenant_isolation_document_quizzes ON document_quizzes
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_quizzes TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_quizzes_id_seq TO ackify_app;
+
+-- Step 5: Quiz outcome recorded with the signature
+ALTER TABLE signatures
+    ADD COLUMN quiz_score INT CHECK (quiz_score BETWEEN 0 AND 100),
+    ADD COLUMN quiz_answers JSONB;
+
+COMMENT ON COLUMN signatures.quiz_score IS 'Quiz score in percent at signing time; NULL when the document had no quiz';
+COMMENT ON COLUMN signatures.quiz_answers IS 'Quiz answers submitted with the signature, keyed by question id';
@@ -0,0 +1,9 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DELETE FROM tenant_config WHERE category = 'branding';
+
+ALTER TABLE tenant_config DROP CONSTRAINT IF EXISTS tenant_config_category_check;
+ALTER TABLE tenant_config ADD CONSTRAINT tenant_config_category_check
+    CHECK (category IN ('general', 'oidc', 'magiclink', 'smtp', 'storage'));
+
+COMMENT ON COLUMN tenant_config.category IS 'Configuration category: general, oidc, magiclink, smtp, storage';
@@ -0,0 +1,15 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Branding Configuration Category
+-- ============================================================================
+-- Branding settings (logo, primary color, email footer, support contact) are
+-- stored as a new tenant_config category. The logo itself lives in the
+-- document storage backend; the config only keeps its storage key.
+-- ============================================================================
+
+ALTER TABLE tenant_config DROP CONSTRAINT IF EXISTS tenant_config_category_check;
+ALTER TABLE tenant_config ADD CONSTRAINT tenant_config_category_check
+    CHECK (category IN ('general', 'oidc', 'magiclink', 'smtp', 'storage', 'branding'));
+
+COMMENT ON COLUMN tenant_config.category IS 'Configuration category: general, oidc, magiclink, smtp, storage, branding';
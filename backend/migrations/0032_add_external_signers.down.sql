@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE signatures DROP COLUMN IF EXISTS auth_method;
+
+DROP TABLE IF EXISTS external_signer_codes;
+DROP TABLE IF EXISTS document_external_signing;
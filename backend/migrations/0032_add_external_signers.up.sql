@@ -0,0 +1,84 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add External Signers
+-- ============================================================================
+-- Documents can be opened to signers outside the identity provider. Such a
+-- signer proves ownership of an email address with a one-time code sent by
+-- email; the signature records the verified email and the 'email_code'
+-- authentication method.
+-- ============================================================================
+
+-- Step 1: Per-document opt-in (a row means external signers are accepted)
+CREATE TABLE document_external_signing (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    enabled_by TEXT,
+    enabled_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_external_signing IS 'Documents that accept signers verified by an email code';
+
+CREATE INDEX idx_document_external_signing_tenant_id ON document_external_signing(tenant_id);
+
+-- Step 2: One-time verification codes
+CREATE TABLE external_signer_codes (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    code_hash TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_by_ip TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE external_signer_codes IS 'Email verification codes sent to external signers';
+COMMENT ON COLUMN external_signer_codes.code_hash IS 'SHA-256 of the code; the code itself is never stored';
+
+CREATE INDEX idx_external_signer_codes_tenant_id ON external_signer_codes(tenant_id);
+CREATE INDEX idx_external_signer_codes_doc_email ON external_signer_codes(doc_id, email, created_at DESC);
+CREATE INDEX idx_external_signer_codes_expires_at ON external_signer_codes(expires_at);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_document_external_signing_tenant_id_immutable
+    BEFORE UPDATE ON document_external_signing
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_external_signer_codes_tenant_id_immutable
+    BEFORE UPDATE ON external_signer_codes
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE document_external_signing ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_external_signing FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_external_signing ON document_external_signing;
+CREATE POLICY tenant_isolation_document_external_signing ON document_external_signing
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE external_signer_codes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE external_signer_codes FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_external_signer_codes ON external_signer_codes;
+CREATE POLICY tenant_isolation_external_signer_codes ON external_signer_codes
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_external_signing TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_external_signing_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON external_signer_codes TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE external_signer_codes_id_seq TO ackify_app;
+
+-- Step 6: Authentication method recorded with the signature
+ALTER TABLE signatures
+    ADD COLUMN auth_method TEXT NOT NULL DEFAULT 'session'
+    CHECK (auth_method IN ('session', 'email_code'));
+
+COMMENT ON COLUMN signatures.auth_method IS 'How the signer was authenticated: session (OAuth or magic link) or email_code (external signer)';
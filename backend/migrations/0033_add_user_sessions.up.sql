@@ -0,0 +1,51 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add User Sessions
+-- ============================================================================
+-- Browser sessions are tracked server-side. The session cookie only carries
+-- an opaque session ID; the user identity is read from this table, so that a
+-- session can be listed and revoked before its cookie expires.
+-- ============================================================================
+
+-- Step 1: Create user_sessions table
+CREATE TABLE user_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    session_id TEXT NOT NULL UNIQUE,
+    user_sub TEXT NOT NULL,
+    user_email TEXT NOT NULL,
+    user_name TEXT NOT NULL DEFAULT '',
+    user_picture TEXT NOT NULL DEFAULT '',
+    ip_address TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE user_sessions IS 'Server-side browser sessions, revocable before cookie expiry';
+COMMENT ON COLUMN user_sessions.session_id IS 'Opaque identifier stored in the session cookie; never exposed by the API';
+
+CREATE INDEX idx_user_sessions_tenant_id ON user_sessions(tenant_id);
+CREATE INDEX idx_user_sessions_user_email ON user_sessions(user_email);
+CREATE INDEX idx_user_sessions_expires_at ON user_sessions(expires_at);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_user_sessions_tenant_id_immutable
+    BEFORE UPDATE ON user_sessions
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE user_sessions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_sessions FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_user_sessions ON user_sessions;
+CREATE POLICY tenant_isolation_user_sessions ON user_sessions
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON user_sessions TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE user_sessions_id_seq TO ackify_app;
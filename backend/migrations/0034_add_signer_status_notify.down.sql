@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TRIGGER IF EXISTS tr_reminder_logs_notify_status ON reminder_logs;
+DROP TRIGGER IF EXISTS tr_signatures_notify_status ON signatures;
+DROP TRIGGER IF EXISTS tr_expected_signers_notify_status ON expected_signers;
+DROP FUNCTION IF EXISTS notify_signer_status_change();
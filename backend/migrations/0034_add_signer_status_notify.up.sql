@@ -0,0 +1,37 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Notify Signer Status Changes
+-- ============================================================================
+-- Application instances cache the expected signer status of documents.
+-- Every change to expected signers, signatures or reminder logs publishes
+-- '<tenant_id>:<doc_id>' on the ackify_signer_status channel so that all
+-- instances drop the stale entry. Notifications are delivered on commit and
+-- identical ones are merged within a transaction, so bulk imports send one
+-- notification per document.
+-- ============================================================================
+
+CREATE OR REPLACE FUNCTION notify_signer_status_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM pg_notify('ackify_signer_status', NEW.tenant_id::text || ':' || NEW.doc_id);
+    END IF;
+    IF TG_OP = 'DELETE' OR (TG_OP = 'UPDATE' AND OLD.doc_id IS DISTINCT FROM NEW.doc_id) THEN
+        PERFORM pg_notify('ackify_signer_status', OLD.tenant_id::text || ':' || OLD.doc_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_expected_signers_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON expected_signers
+    FOR EACH ROW EXECUTE FUNCTION notify_signer_status_change();
+
+CREATE TRIGGER tr_signatures_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON signatures
+    FOR EACH ROW EXECUTE FUNCTION notify_signer_status_change();
+
+CREATE TRIGGER tr_reminder_logs_notify_status
+    AFTER INSERT OR UPDATE OR DELETE ON reminder_logs
+    FOR EACH ROW EXECUTE FUNCTION notify_signer_status_change();
@@ -0,0 +1,15 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TRIGGER IF EXISTS tr_signatures_match_key ON signatures;
+DROP TRIGGER IF EXISTS tr_expected_signers_match_key ON expected_signers;
+DROP FUNCTION IF EXISTS set_signature_match_key();
+DROP FUNCTION IF EXISTS set_expected_signer_match_key();
+
+DROP INDEX IF EXISTS idx_signatures_match_key;
+DROP INDEX IF EXISTS idx_expected_signers_match_key;
+ALTER TABLE signatures DROP COLUMN IF EXISTS match_key;
+ALTER TABLE expected_signers DROP COLUMN IF EXISTS match_key;
+
+DROP FUNCTION IF EXISTS email_match_key(UUID, TEXT);
+DROP TABLE IF EXISTS email_matching_rules;
+DROP TABLE IF EXISTS email_aliases;
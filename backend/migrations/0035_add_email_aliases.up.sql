@@ -0,0 +1,163 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Email Aliases and Matching Rules
+-- ============================================================================
+-- Signatures were matched to expected signers by exact email. A person may
+-- sign with firstname.lastname@corp.com while HR imported f.lastname@corp.com.
+-- Expected signers and signatures now carry a match_key computed by
+-- email_match_key(): aliases resolve to the person's primary address, then
+-- the tenant's normalization rules apply (case, plus-addressing, Gmail dots).
+-- Matching joins compare match keys instead of raw addresses.
+-- ============================================================================
+
+-- Step 1: Alternate addresses of a person
+CREATE TABLE email_aliases (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    alias TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_by TEXT NOT NULL,
+    UNIQUE (tenant_id, alias)
+);
+
+COMMENT ON TABLE email_aliases IS 'Alternate addresses matched as the primary email (both stored lowercase)';
+
+CREATE INDEX idx_email_aliases_tenant_email ON email_aliases(tenant_id, email);
+
+-- Step 2: Normalization rules of a tenant (defaults apply without a row)
+CREATE TABLE email_matching_rules (
+    tenant_id UUID PRIMARY KEY,
+    ignore_case BOOLEAN NOT NULL DEFAULT true,
+    strip_plus_tag BOOLEAN NOT NULL DEFAULT false,
+    ignore_gmail_dots BOOLEAN NOT NULL DEFAULT false,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by TEXT NOT NULL DEFAULT ''
+);
+
+COMMENT ON TABLE email_matching_rules IS 'Email normalization applied when matching signatures to expected signers';
+
+-- Step 3: Match key function
+CREATE OR REPLACE FUNCTION email_match_key(p_tenant_id UUID, p_email TEXT)
+RETURNS TEXT AS $$
+DECLARE
+    v_email TEXT := btrim(p_email);
+    v_primary TEXT;
+    v_ignore_case BOOLEAN := true;
+    v_strip_plus_tag BOOLEAN := false;
+    v_ignore_gmail_dots BOOLEAN := false;
+    v_local TEXT;
+    v_domain TEXT;
+BEGIN
+    IF v_email IS NULL OR v_email = '' THEN
+        RETURN v_email;
+    END IF;
+
+    SELECT email INTO v_primary FROM email_aliases
+    WHERE tenant_id = p_tenant_id AND alias = lower(v_email);
+    IF FOUND THEN
+        v_email := v_primary;
+    END IF;
+
+    SELECT ignore_case, strip_plus_tag, ignore_gmail_dots
+    INTO v_ignore_case, v_strip_plus_tag, v_ignore_gmail_dots
+    FROM email_matching_rules WHERE tenant_id = p_tenant_id;
+    IF NOT FOUND THEN
+        v_ignore_case := true;
+        v_strip_plus_tag := false;
+        v_ignore_gmail_dots := false;
+    END IF;
+
+    IF position('@' IN v_email) = 0 THEN
+        RETURN CASE WHEN v_ignore_case THEN lower(v_email) ELSE v_email END;
+    END IF;
+
+    v_local := substring(v_email FROM '^(.*)@[^@]*$');
+    v_domain := lower(substring(v_email FROM '@([^@]*)$'));
+
+    IF v_ignore_case THEN
+        v_local := lower(v_local);
+    END IF;
+    IF v_strip_plus_tag THEN
+        v_local := split_part(v_local, '+', 1);
+    END IF;
+    IF v_ignore_gmail_dots AND v_domain IN ('gmail.com', 'googlemail.com') THEN
+        v_local := replace(v_local, '.', '');
+        v_domain := 'gmail.com';
+    END IF;
+
+    RETURN v_local || '@' || v_domain;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+COMMENT ON FUNCTION email_match_key(UUID, TEXT) IS 'Key under which an address is matched: alias resolution, then the tenant normalization rules';
+
+-- Step 4: Match keys of expected signers and signatures, kept up to date on insert
+ALTER TABLE expected_signers ADD COLUMN match_key TEXT;
+ALTER TABLE signatures ADD COLUMN match_key TEXT;
+
+CREATE OR REPLACE FUNCTION set_expected_signer_match_key()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.match_key := email_match_key(NEW.tenant_id, NEW.email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION set_signature_match_key()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.match_key := email_match_key(NEW.tenant_id, NEW.user_email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tr_expected_signers_match_key
+    BEFORE INSERT OR UPDATE OF email ON expected_signers
+    FOR EACH ROW EXECUTE FUNCTION set_expected_signer_match_key();
+
+CREATE TRIGGER tr_signatures_match_key
+    BEFORE INSERT OR UPDATE OF user_email ON signatures
+    FOR EACH ROW EXECUTE FUNCTION set_signature_match_key();
+
+-- Backfill existing rows; FORCE is lifted so that a non-superuser table owner sees every tenant
+ALTER TABLE expected_signers NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE signatures NO FORCE ROW LEVEL SECURITY;
+UPDATE expected_signers SET match_key = email_match_key(tenant_id, email);
+UPDATE signatures SET match_key = email_match_key(tenant_id, user_email);
+ALTER TABLE expected_signers FORCE ROW LEVEL SECURITY;
+ALTER TABLE signatures FORCE ROW LEVEL SECURITY;
+
+CREATE INDEX idx_expected_signers_match_key ON expected_signers(tenant_id, doc_id, match_key);
+CREATE INDEX idx_signatures_match_key ON signatures(tenant_id, doc_id, match_key);
+
+-- Step 5: tenant_id immutability triggers
+CREATE TRIGGER tr_email_aliases_tenant_id_immutable
+    BEFORE UPDATE ON email_aliases
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_email_matching_rules_tenant_id_immutable
+    BEFORE UPDATE ON email_matching_rules
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 6: Enable Row Level Security
+ALTER TABLE email_aliases ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_aliases FORCE ROW LEVEL SECURITY;
+ALTER TABLE email_matching_rules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_matching_rules FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_email_aliases ON email_aliases;
+CREATE POLICY tenant_isolation_email_aliases ON email_aliases
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_email_matching_rules ON email_matching_rules;
+CREATE POLICY tenant_isolation_email_matching_rules ON email_matching_rules
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 7: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON email_aliases TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE email_aliases_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON email_matching_rules TO ackify_app;
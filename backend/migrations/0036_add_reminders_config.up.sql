@@ -0,0 +1,15 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Reminders Configuration Category
+-- ============================================================================
+-- Reminder escalation settings (thresholds selecting the gentle, firm or final
+-- reminder template) are stored as a new tenant_config category. A missing
+-- row means the built-in thresholds apply.
+-- ============================================================================
+
+ALTER TABLE tenant_config DROP CONSTRAINT IF EXISTS tenant_config_category_check;
+ALTER TABLE tenant_config ADD CONSTRAINT tenant_config_category_check
+    CHECK (category IN ('general', 'oidc', 'magiclink', 'smtp', 'storage', 'branding', 'reminders'));
+
+COMMENT ON COLUMN tenant_config.category IS 'Configuration category: general, oidc, magiclink, smtp, storage, branding, reminders';
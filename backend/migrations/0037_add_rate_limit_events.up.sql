@@ -0,0 +1,50 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Rate Limit Events
+-- ============================================================================
+-- Signature creations are counted per user and per client IP over a sliding
+-- window, so that limits hold across instances and restarts. Rejected
+-- requests (blocked = true) are kept longer and listed to admins to detect
+-- scripted abuse; magic link rejections are recorded there too.
+-- ============================================================================
+
+-- Step 1: Create rate_limit_events table
+CREATE TABLE rate_limit_events (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    action TEXT NOT NULL,
+    user_email TEXT,
+    ip_address TEXT,
+    blocked BOOLEAN NOT NULL DEFAULT false,
+    scope TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT rate_limit_events_scope_check CHECK (scope IN ('user', 'ip')),
+    CONSTRAINT rate_limit_events_blocked_scope_check CHECK (blocked = (scope IS NOT NULL))
+);
+
+COMMENT ON TABLE rate_limit_events IS 'Rate-limited requests: accepted ones feed sliding window counters, blocked ones are violations';
+COMMENT ON COLUMN rate_limit_events.scope IS 'Limit that rejected a blocked request: user or ip';
+
+CREATE INDEX idx_rate_limit_events_tenant_id ON rate_limit_events(tenant_id);
+CREATE INDEX idx_rate_limit_events_user ON rate_limit_events(action, user_email, created_at) WHERE NOT blocked;
+CREATE INDEX idx_rate_limit_events_ip ON rate_limit_events(action, ip_address, created_at) WHERE NOT blocked;
+CREATE INDEX idx_rate_limit_events_violations ON rate_limit_events(created_at DESC) WHERE blocked;
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_rate_limit_events_tenant_id_immutable
+    BEFORE UPDATE ON rate_limit_events
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE rate_limit_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE rate_limit_events FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_rate_limit_events ON rate_limit_events;
+CREATE POLICY tenant_isolation_rate_limit_events ON rate_limit_events
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, DELETE ON rate_limit_events TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE rate_limit_events_id_seq TO ackify_app;
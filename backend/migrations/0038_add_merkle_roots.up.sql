@@ -0,0 +1,73 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Merkle Roots
+-- ============================================================================
+-- A periodic job computes a Merkle root over the signatures created since the
+-- previous root and may publish it to an external anchor, so that anyone can
+-- check later that signatures were not altered. The leaves keep the record
+-- hash of each signature at computation time to serve inclusion proofs.
+-- ============================================================================
+
+-- Step 1: Create merkle_roots table
+CREATE TABLE merkle_roots (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    root_hash TEXT NOT NULL,
+    first_signature_id BIGINT NOT NULL,
+    last_signature_id BIGINT NOT NULL,
+    leaf_count INT NOT NULL CHECK (leaf_count > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    anchored_at TIMESTAMPTZ,
+    anchor_ref TEXT,
+    anchor_error TEXT,
+    CONSTRAINT merkle_roots_range_check CHECK (first_signature_id <= last_signature_id)
+);
+
+COMMENT ON TABLE merkle_roots IS 'Merkle roots over consecutive batches of signatures, optionally published to an external anchor';
+COMMENT ON COLUMN merkle_roots.anchor_ref IS 'Location of the published root (storage key, git commit, HTTPS response reference)';
+
+CREATE INDEX idx_merkle_roots_tenant_id ON merkle_roots(tenant_id);
+CREATE INDEX idx_merkle_roots_last_signature ON merkle_roots(tenant_id, last_signature_id DESC);
+CREATE INDEX idx_merkle_roots_unanchored ON merkle_roots(created_at) WHERE anchored_at IS NULL;
+
+-- Step 2: Create merkle_leaves table
+CREATE TABLE merkle_leaves (
+    root_id BIGINT NOT NULL REFERENCES merkle_roots(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    signature_id BIGINT NOT NULL,
+    leaf_index INT NOT NULL,
+    record_hash TEXT NOT NULL,
+    PRIMARY KEY (root_id, leaf_index)
+);
+
+COMMENT ON COLUMN merkle_leaves.record_hash IS 'Record hash of the signature when the root was computed';
+
+CREATE INDEX idx_merkle_leaves_tenant_id ON merkle_leaves(tenant_id);
+CREATE UNIQUE INDEX idx_merkle_leaves_signature ON merkle_leaves(tenant_id, signature_id);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_merkle_roots_tenant_id_immutable
+    BEFORE UPDATE ON merkle_roots
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE merkle_roots ENABLE ROW LEVEL SECURITY;
+ALTER TABLE merkle_roots FORCE ROW LEVEL SECURITY;
+ALTER TABLE merkle_leaves ENABLE ROW LEVEL SECURITY;
+ALTER TABLE merkle_leaves FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_merkle_roots ON merkle_roots;
+CREATE POLICY tenant_isolation_merkle_roots ON merkle_roots
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_merkle_leaves ON merkle_leaves;
+CREATE POLICY tenant_isolation_merkle_leaves ON merkle_leaves
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role (leaves are never modified)
+GRANT SELECT, INSERT, UPDATE ON merkle_roots TO ackify_app;
+GRANT SELECT, INSERT ON merkle_leaves TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE merkle_roots_id_seq TO ackify_app;
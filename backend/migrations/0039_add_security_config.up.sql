@@ -0,0 +1,15 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Security Configuration Category
+-- ============================================================================
+-- Content-Security-Policy sources added by admins (script, style, connect,
+-- image and font sources) are stored as a new tenant_config category. A
+-- missing row means only the built-in and environment sources apply.
+-- ============================================================================
+
+ALTER TABLE tenant_config DROP CONSTRAINT IF EXISTS tenant_config_category_check;
+ALTER TABLE tenant_config ADD CONSTRAINT tenant_config_category_check
+    CHECK (category IN ('general', 'oidc', 'magiclink', 'smtp', 'storage', 'branding', 'reminders', 'security'));
+
+COMMENT ON COLUMN tenant_config.category IS 'Configuration category: general, oidc, magiclink, smtp, storage, branding, reminders, security';
@@ -0,0 +1,47 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Completion Snapshots
+-- ============================================================================
+-- A daily job records the signing progress of each document (expected
+-- signers, signed expected signers, total signatures) so that admins can chart
+-- the completion rate over time instead of only seeing the current value.
+-- ============================================================================
+
+-- Step 1: Create document_completion_snapshots table
+CREATE TABLE document_completion_snapshots (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL,
+    snapshot_date DATE NOT NULL,
+    expected_count INT NOT NULL DEFAULT 0,
+    signed_count INT NOT NULL DEFAULT 0,
+    signature_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT document_completion_snapshots_unique UNIQUE (tenant_id, doc_id, snapshot_date)
+);
+
+COMMENT ON TABLE document_completion_snapshots IS 'Daily signing progress of each document, to chart completion over time';
+COMMENT ON COLUMN document_completion_snapshots.signed_count IS 'Expected signers who had signed on snapshot_date';
+COMMENT ON COLUMN document_completion_snapshots.signature_count IS 'All signatures of the document, expected or not';
+
+CREATE INDEX idx_document_completion_snapshots_tenant_id ON document_completion_snapshots(tenant_id);
+CREATE INDEX idx_document_completion_snapshots_date ON document_completion_snapshots(snapshot_date);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_completion_snapshots_tenant_id_immutable
+    BEFORE UPDATE ON document_completion_snapshots
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_completion_snapshots ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_completion_snapshots FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_completion_snapshots ON document_completion_snapshots;
+CREATE POLICY tenant_isolation_document_completion_snapshots ON document_completion_snapshots
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role (snapshots are never modified, old ones are purged)
+GRANT SELECT, INSERT, DELETE ON document_completion_snapshots TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_completion_snapshots_id_seq TO ackify_app;
@@ -0,0 +1,78 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signer Groups
+-- ============================================================================
+-- Signer groups are reusable lists of signers that can be added to documents.
+-- A group is either maintained by hand or synced on a schedule from a
+-- directory group (Google Workspace, Microsoft Entra ID, GitLab), so that
+-- lists such as "all employees" stay current without CSV uploads.
+-- ============================================================================
+
+-- Step 1: Create signer_groups table
+CREATE TABLE signer_groups (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    directory_provider TEXT,
+    directory_group TEXT,
+    mapping JSONB NOT NULL DEFAULT '{}'::jsonb,
+    sync_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    last_synced_at TIMESTAMPTZ,
+    last_sync_error TEXT,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT signer_groups_name_unique UNIQUE (tenant_id, name),
+    CONSTRAINT signer_groups_provider_check CHECK (directory_provider IN ('google', 'microsoft', 'gitlab')),
+    CONSTRAINT signer_groups_directory_check CHECK ((directory_provider IS NULL) = (directory_group IS NULL))
+);
+
+COMMENT ON TABLE signer_groups IS 'Reusable lists of signers, maintained by hand or synced from a directory group';
+COMMENT ON COLUMN signer_groups.directory_group IS 'Group email (Google), object ID (Microsoft) or full path (GitLab)';
+COMMENT ON COLUMN signer_groups.mapping IS 'How directory members become signers: attributes, email domain, filters';
+
+CREATE INDEX idx_signer_groups_tenant_id ON signer_groups(tenant_id);
+
+-- Step 2: Create signer_group_members table
+CREATE TABLE signer_group_members (
+    group_id BIGINT NOT NULL REFERENCES signer_groups(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (group_id, email)
+);
+
+CREATE INDEX idx_signer_group_members_tenant_id ON signer_group_members(tenant_id);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_signer_groups_tenant_id_immutable
+    BEFORE UPDATE ON signer_groups
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_signer_group_members_tenant_id_immutable
+    BEFORE UPDATE ON signer_group_members
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE signer_groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signer_groups FORCE ROW LEVEL SECURITY;
+ALTER TABLE signer_group_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signer_group_members FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_signer_groups ON signer_groups;
+CREATE POLICY tenant_isolation_signer_groups ON signer_groups
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_signer_group_members ON signer_group_members;
+CREATE POLICY tenant_isolation_signer_group_members ON signer_group_members
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON signer_groups TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON signer_group_members TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE signer_groups_id_seq TO ackify_app;
@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TABLE IF EXISTS git_source_documents;
+DROP TABLE IF EXISTS git_sources;
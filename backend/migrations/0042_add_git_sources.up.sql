@@ -0,0 +1,85 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Git Sources
+-- ============================================================================
+-- Git sources import Markdown and PDF policies kept in a Git repository
+-- (docs-as-code). Each matching file becomes an Ackify document that is
+-- updated, with a new checksum and version, when the file changes in the
+-- repository. Path rules decide which files are imported and whether a
+-- change asks signers to acknowledge the document again.
+-- ============================================================================
+
+-- Step 1: Create git_sources table
+CREATE TABLE git_sources (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    repo_url TEXT NOT NULL,
+    branch TEXT NOT NULL DEFAULT 'main',
+    rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+    webhook_secret TEXT NOT NULL DEFAULT '',
+    sync_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sync_requested_at TIMESTAMPTZ,
+    last_commit TEXT NOT NULL DEFAULT '',
+    last_synced_at TIMESTAMPTZ,
+    last_sync_error TEXT,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT git_sources_name_unique UNIQUE (tenant_id, name)
+);
+
+COMMENT ON TABLE git_sources IS 'Git repositories whose Markdown and PDF files are imported as documents';
+COMMENT ON COLUMN git_sources.rules IS 'Path patterns of the imported files and their options';
+COMMENT ON COLUMN git_sources.sync_requested_at IS 'Set by a push webhook, cleared by the next sync';
+
+CREATE INDEX idx_git_sources_tenant_id ON git_sources(tenant_id);
+
+-- Step 2: Create git_source_documents table
+CREATE TABLE git_source_documents (
+    source_id BIGINT NOT NULL REFERENCES git_sources(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    path TEXT NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    checksum TEXT NOT NULL,
+    commit_sha TEXT NOT NULL,
+    reacknowledge_since TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (source_id, path)
+);
+
+COMMENT ON COLUMN git_source_documents.reacknowledge_since IS 'When a change of the file asked signers to acknowledge the document again';
+
+CREATE INDEX idx_git_source_documents_tenant_id ON git_source_documents(tenant_id);
+CREATE INDEX idx_git_source_documents_doc_id ON git_source_documents(doc_id);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_git_sources_tenant_id_immutable
+    BEFORE UPDATE ON git_sources
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_git_source_documents_tenant_id_immutable
+    BEFORE UPDATE ON git_source_documents
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE git_sources ENABLE ROW LEVEL SECURITY;
+ALTER TABLE git_sources FORCE ROW LEVEL SECURITY;
+ALTER TABLE git_source_documents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE git_source_documents FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_git_sources ON git_sources;
+CREATE POLICY tenant_isolation_git_sources ON git_sources
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_git_source_documents ON git_source_documents;
+CREATE POLICY tenant_isolation_git_source_documents ON git_source_documents
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON git_sources TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON git_source_documents TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE git_sources_id_seq TO ackify_app;
@@ -0,0 +1,9 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DELETE FROM admin_notifications WHERE type = 'source_changed';
+
+ALTER TABLE admin_notifications DROP CONSTRAINT IF EXISTS admin_notifications_type_check;
+ALTER TABLE admin_notifications ADD CONSTRAINT admin_notifications_type_check
+    CHECK (type IN ('signature_received', 'email_bounced', 'deadline_approaching'));
+
+DROP TABLE IF EXISTS document_link_sources;
@@ -0,0 +1,58 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Link Sources
+-- ============================================================================
+-- A document whose URL is a Confluence page or a SharePoint file is tracked
+-- through the API of its host: the page title, latest version and content
+-- hash are resolved when the document is created and checked periodically,
+-- and a change of the source page is recorded and announced to the owner.
+-- ============================================================================
+
+-- Step 1: Create document_link_sources table
+CREATE TABLE document_link_sources (
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    version TEXT NOT NULL DEFAULT '',
+    content_hash TEXT NOT NULL DEFAULT '',
+    modified_at TIMESTAMPTZ,
+    previous_version TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMPTZ,
+    last_checked_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_link_sources IS 'Metadata of the Confluence pages and SharePoint files documents link to';
+COMMENT ON COLUMN document_link_sources.content_hash IS 'Hash of the page content as reported or computed from the host API';
+COMMENT ON COLUMN document_link_sources.changed_at IS 'Last time the source page changed after the document was created';
+
+CREATE INDEX idx_document_link_sources_tenant_id ON document_link_sources(tenant_id);
+CREATE INDEX idx_document_link_sources_checked ON document_link_sources(last_checked_at);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_link_sources_tenant_id_immutable
+    BEFORE UPDATE ON document_link_sources
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_link_sources ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_link_sources FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_link_sources ON document_link_sources;
+CREATE POLICY tenant_isolation_document_link_sources ON document_link_sources
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_link_sources TO ackify_app;
+
+-- Step 5: Allow the source_changed notification type
+ALTER TABLE admin_notifications DROP CONSTRAINT IF EXISTS admin_notifications_type_check;
+ALTER TABLE admin_notifications ADD CONSTRAINT admin_notifications_type_check
+    CHECK (type IN ('signature_received', 'email_bounced', 'deadline_approaching', 'source_changed'));
@@ -0,0 +1,44 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signature Nonces
+-- ============================================================================
+-- Signers fetch a single-use nonce before signing. The nonce is bound to the
+-- user and the document, expires after a few minutes and is consumed by the
+-- signature creation, so that a replayed request is rejected.
+-- ============================================================================
+
+-- Step 1: Create signature_nonces table
+CREATE TABLE signature_nonces (
+    tenant_id UUID NOT NULL,
+    nonce TEXT NOT NULL,
+    user_sub TEXT NOT NULL,
+    doc_id TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, nonce)
+);
+
+COMMENT ON TABLE signature_nonces IS 'Single-use nonces issued to signers to reject replayed signature requests';
+COMMENT ON COLUMN signature_nonces.used_at IS 'Set when a signature consumed the nonce; used nonces are kept until they expire';
+
+CREATE INDEX idx_signature_nonces_tenant_id ON signature_nonces(tenant_id);
+CREATE INDEX idx_signature_nonces_expires_at ON signature_nonces(expires_at);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_signature_nonces_tenant_id_immutable
+    BEFORE UPDATE ON signature_nonces
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE signature_nonces ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signature_nonces FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_signature_nonces ON signature_nonces;
+CREATE POLICY tenant_isolation_signature_nonces ON signature_nonces
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON signature_nonces TO ackify_app;
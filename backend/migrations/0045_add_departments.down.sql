@@ -0,0 +1,21 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP POLICY IF EXISTS department_scope_reminder_logs ON reminder_logs;
+DROP POLICY IF EXISTS department_scope_signatures ON signatures;
+DROP POLICY IF EXISTS department_scope_expected_signers ON expected_signers;
+DROP POLICY IF EXISTS department_scope_documents ON documents;
+
+DELETE FROM admin_roles WHERE role = 'department-admin';
+ALTER TABLE admin_roles DROP CONSTRAINT IF EXISTS admin_roles_department_check;
+ALTER TABLE admin_roles DROP CONSTRAINT IF EXISTS admin_roles_role_check;
+ALTER TABLE admin_roles ADD CONSTRAINT admin_roles_role_check
+    CHECK (role IN ('viewer', 'document-manager', 'reminder-operator', 'super-admin'));
+ALTER TABLE admin_roles DROP COLUMN IF EXISTS department_id;
+
+DROP INDEX IF EXISTS idx_documents_department_id;
+ALTER TABLE documents DROP COLUMN IF EXISTS department_id;
+
+DROP TABLE IF EXISTS department_members;
+DROP TABLE IF EXISTS departments;
+
+DROP FUNCTION IF EXISTS current_department_scope();
@@ -0,0 +1,122 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Departments
+-- ============================================================================
+-- Departments form a tree per tenant. Documents and signers belong to a
+-- department, and department-admins only see and manage the documents of
+-- their department subtree.
+--
+-- Scoping is enforced by restrictive RLS policies: the API sets
+-- app.department_ids to the subtree of a department-admin for the request
+-- transaction. When the setting is empty (other admins, public endpoints,
+-- background workers), the policies do not restrict anything.
+-- ============================================================================
+
+-- Step 1: Create departments table
+CREATE TABLE departments (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    parent_id BIGINT REFERENCES departments(id) ON DELETE RESTRICT,
+    name TEXT NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT departments_parent_check CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+COMMENT ON TABLE departments IS 'Organization units documents and signers belong to';
+COMMENT ON COLUMN departments.parent_id IS 'Parent department, NULL for top-level departments';
+
+CREATE INDEX idx_departments_tenant_id ON departments(tenant_id);
+CREATE INDEX idx_departments_parent_id ON departments(parent_id);
+CREATE UNIQUE INDEX idx_departments_name_unique ON departments(tenant_id, COALESCE(parent_id, 0), lower(name));
+
+-- Step 2: Create department_members table
+CREATE TABLE department_members (
+    department_id BIGINT NOT NULL REFERENCES departments(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    added_by TEXT NOT NULL DEFAULT '',
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (department_id, email)
+);
+
+COMMENT ON TABLE department_members IS 'Signers belonging to a department';
+
+CREATE INDEX idx_department_members_tenant_id ON department_members(tenant_id);
+CREATE INDEX idx_department_members_email ON department_members(tenant_id, email);
+
+-- Step 3: Attach documents and admin roles to departments
+ALTER TABLE documents ADD COLUMN department_id BIGINT REFERENCES departments(id) ON DELETE SET NULL;
+CREATE INDEX idx_documents_department_id ON documents(department_id) WHERE department_id IS NOT NULL;
+
+COMMENT ON COLUMN documents.department_id IS 'Department the document belongs to, NULL when not assigned';
+
+-- Deleting a department must not silently turn its admins into unscoped admins
+ALTER TABLE admin_roles ADD COLUMN department_id BIGINT REFERENCES departments(id) ON DELETE RESTRICT;
+ALTER TABLE admin_roles DROP CONSTRAINT IF EXISTS admin_roles_role_check;
+ALTER TABLE admin_roles ADD CONSTRAINT admin_roles_role_check
+    CHECK (role IN ('viewer', 'document-manager', 'reminder-operator', 'department-admin', 'super-admin'));
+ALTER TABLE admin_roles ADD CONSTRAINT admin_roles_department_check
+    CHECK ((role = 'department-admin') = (department_id IS NOT NULL));
+
+COMMENT ON COLUMN admin_roles.department_id IS 'Department a department-admin manages, with its subtree';
+
+-- Step 4: Department scope of the current transaction
+CREATE OR REPLACE FUNCTION current_department_scope() RETURNS BIGINT[] AS $$
+    SELECT NULLIF(current_setting('app.department_ids', true), '')::BIGINT[];
+$$ LANGUAGE sql STABLE;
+
+COMMENT ON FUNCTION current_department_scope() IS 'Returns the department IDs a department-admin may access (app.department_ids). Returns NULL when the request is not scoped.';
+
+-- Step 5: tenant_id immutability triggers
+CREATE TRIGGER tr_departments_tenant_id_immutable
+    BEFORE UPDATE ON departments
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_department_members_tenant_id_immutable
+    BEFORE UPDATE ON department_members
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 6: Enable Row Level Security
+ALTER TABLE departments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE departments FORCE ROW LEVEL SECURITY;
+ALTER TABLE department_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE department_members FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_departments ON departments;
+CREATE POLICY tenant_isolation_departments ON departments
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_department_members ON department_members;
+CREATE POLICY tenant_isolation_department_members ON department_members
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 7: Department scoping, combined with the tenant policies (AS RESTRICTIVE)
+CREATE POLICY department_scope_departments ON departments AS RESTRICTIVE
+    USING (current_department_scope() IS NULL OR id = ANY(current_department_scope()));
+
+CREATE POLICY department_scope_department_members ON department_members AS RESTRICTIVE
+    USING (current_department_scope() IS NULL OR department_id = ANY(current_department_scope()));
+
+CREATE POLICY department_scope_documents ON documents AS RESTRICTIVE
+    USING (current_department_scope() IS NULL OR department_id = ANY(current_department_scope()));
+
+-- Signers, signatures and reminders follow the visibility of their document
+CREATE POLICY department_scope_expected_signers ON expected_signers AS RESTRICTIVE
+    USING (current_department_scope() IS NULL OR doc_id IN (SELECT doc_id FROM documents));
+
+CREATE POLICY department_scope_signatures ON signatures AS RESTRICTIVE
+    USING (current_department_scope() IS NULL OR doc_id IN (SELECT doc_id FROM documents));
+
+CREATE POLICY department_scope_reminder_logs ON reminder_logs AS RESTRICTIVE
+    USING (current_department_scope() IS NULL OR doc_id IN (SELECT doc_id FROM documents));
+
+-- Step 8: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON departments TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON department_members TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE departments_id_seq TO ackify_app;
@@ -0,0 +1,41 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add User Preferences
+-- ============================================================================
+-- Stores the preferred locale of each user. It is inferred from the browser
+-- language at first login, can be changed from /api/v1/users/me/preferences,
+-- and selects the language of the emails sent to the user.
+-- ============================================================================
+
+-- Step 1: Create user_preferences table
+CREATE TABLE user_preferences (
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, email)
+);
+
+COMMENT ON TABLE user_preferences IS 'Per-user preferences, keyed by lowercased email';
+COMMENT ON COLUMN user_preferences.locale IS 'Preferred locale (en, fr, it, de, es) for emails and API messages';
+
+CREATE INDEX idx_user_preferences_tenant_id ON user_preferences(tenant_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_user_preferences_tenant_id_immutable
+    BEFORE UPDATE ON user_preferences
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE user_preferences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_preferences FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_user_preferences ON user_preferences;
+CREATE POLICY tenant_isolation_user_preferences ON user_preferences
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON user_preferences TO ackify_app;
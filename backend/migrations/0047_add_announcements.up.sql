@@ -0,0 +1,52 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Announcements
+-- ============================================================================
+-- Announcements are banners admins publish to the users of their tenant, for
+-- example "signing paused for maintenance Friday". Each banner has a severity,
+-- an optional publication window and a target audience, and is served to the
+-- SPA by /api/v1/announcements while it is active.
+-- ============================================================================
+
+-- Step 1: Create announcements table
+CREATE TABLE announcements (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    severity TEXT NOT NULL DEFAULT 'info',
+    message TEXT NOT NULL,
+    audience TEXT NOT NULL DEFAULT 'all',
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT announcements_severity_check CHECK (severity IN ('info', 'warning', 'critical')),
+    CONSTRAINT announcements_audience_check CHECK (audience IN ('all', 'authenticated', 'admins')),
+    CONSTRAINT announcements_window_check CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+COMMENT ON TABLE announcements IS 'Banners shown by the SPA to the users of a tenant';
+COMMENT ON COLUMN announcements.audience IS 'Who sees the banner: all visitors, signed-in users or admins';
+COMMENT ON COLUMN announcements.starts_at IS 'Start of the publication window, published immediately when NULL';
+COMMENT ON COLUMN announcements.ends_at IS 'End of the publication window, published until deleted when NULL';
+
+CREATE INDEX idx_announcements_tenant_id ON announcements(tenant_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_announcements_tenant_id_immutable
+    BEFORE UPDATE ON announcements
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE announcements ENABLE ROW LEVEL SECURITY;
+ALTER TABLE announcements FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_announcements ON announcements;
+CREATE POLICY tenant_isolation_announcements ON announcements
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON announcements TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE announcements_id_seq TO ackify_app;
@@ -0,0 +1,47 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Email Suppressions
+-- ============================================================================
+-- Addresses that must no longer receive email: hard bounces recorded by the
+-- email worker, spam complaints and manual opt-outs entered by admins. The
+-- list is consulted before every send (reminders, magic links, verification
+-- codes) to protect the sender reputation from repeatedly mailing dead
+-- addresses.
+-- ============================================================================
+
+-- Step 1: Create email_suppressions table
+CREATE TABLE email_suppressions (
+    tenant_id UUID NOT NULL,
+    email TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, email),
+    CONSTRAINT email_suppressions_reason_check CHECK (reason IN ('hard_bounce', 'complaint', 'manual'))
+);
+
+COMMENT ON TABLE email_suppressions IS 'Addresses no email is sent to, keyed by lowercased email';
+COMMENT ON COLUMN email_suppressions.detail IS 'Mail server response of a bounce, or the note of the admin';
+COMMENT ON COLUMN email_suppressions.created_by IS 'Admin email, empty when recorded from a bounce';
+
+CREATE INDEX idx_email_suppressions_tenant_id ON email_suppressions(tenant_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_email_suppressions_tenant_id_immutable
+    BEFORE UPDATE ON email_suppressions
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE email_suppressions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_suppressions FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_email_suppressions ON email_suppressions;
+CREATE POLICY tenant_isolation_email_suppressions ON email_suppressions
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON email_suppressions TO ackify_app;
@@ -0,0 +1,10 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE signatures
+    DROP COLUMN IF EXISTS consent_text,
+    DROP COLUMN IF EXISTS consent_locale,
+    DROP COLUMN IF EXISTS consent_version,
+    DROP COLUMN IF EXISTS locale;
+
+DROP TABLE IF EXISTS document_consent_versions;
+DROP TABLE IF EXISTS consent_texts;
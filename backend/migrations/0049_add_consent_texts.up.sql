@@ -0,0 +1,81 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Consent Texts
+-- ============================================================================
+-- The consent sentence shown next to the sign button is versioned per tenant,
+-- with one text per language. The latest version applies unless a document is
+-- pinned to another one. Each signature records the language the signer saw
+-- the page in, and the exact consent version, language and text displayed, so
+-- that exports hold the evidence of what was agreed to.
+-- ============================================================================
+
+-- Step 1: Consent catalog (versions are immutable once published)
+CREATE TABLE consent_texts (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    version INT NOT NULL CHECK (version > 0),
+    locale TEXT NOT NULL,
+    text TEXT NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, version, locale)
+);
+
+COMMENT ON TABLE consent_texts IS 'Versioned consent sentences shown to signers, one row per version and language';
+
+CREATE INDEX idx_consent_texts_tenant_id ON consent_texts(tenant_id);
+
+-- Step 2: Per-document consent version (a row pins the document to a version)
+CREATE TABLE document_consent_versions (
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    version INT NOT NULL CHECK (version > 0),
+    set_by TEXT NOT NULL DEFAULT '',
+    set_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_consent_versions IS 'Documents signed with a consent version other than the latest';
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_consent_texts_tenant_id_immutable
+    BEFORE UPDATE ON consent_texts
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_document_consent_versions_tenant_id_immutable
+    BEFORE UPDATE ON document_consent_versions
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE consent_texts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE consent_texts FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_consent_texts ON consent_texts;
+CREATE POLICY tenant_isolation_consent_texts ON consent_texts
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE document_consent_versions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_consent_versions FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_consent_versions ON document_consent_versions;
+CREATE POLICY tenant_isolation_document_consent_versions ON document_consent_versions
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON consent_texts TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE consent_texts_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_consent_versions TO ackify_app;
+
+-- Step 6: Display language and consent recorded with the signature
+ALTER TABLE signatures
+    ADD COLUMN locale TEXT,
+    ADD COLUMN consent_version INT,
+    ADD COLUMN consent_locale TEXT,
+    ADD COLUMN consent_text TEXT;
+
+COMMENT ON COLUMN signatures.locale IS 'Language the signing page was displayed in';
+COMMENT ON COLUMN signatures.consent_version IS 'Consent catalog version shown to the signer, NULL without catalog';
+COMMENT ON COLUMN signatures.consent_text IS 'Exact consent sentence shown to the signer';
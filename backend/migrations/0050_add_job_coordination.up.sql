@@ -0,0 +1,49 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Job Coordination
+-- ============================================================================
+-- With several replicas, every instance starts the same schedulers (reminder
+-- digests, completion snapshots, link checks, ...) and would run their work
+-- once per replica. Instances now compete for a lease: only the holder runs
+-- the scheduled jobs, renews the lease while alive, and another instance takes
+-- over once it expires. Each run is recorded for troubleshooting.
+--
+-- Both tables coordinate instances rather than hold tenant data, so they have
+-- no tenant_id and no RLS policy.
+-- ============================================================================
+
+-- Step 1: Create job_leases table
+CREATE TABLE job_leases (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    acquired_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    renewed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+COMMENT ON TABLE job_leases IS 'Leader leases of the instances running scheduled jobs';
+COMMENT ON COLUMN job_leases.holder IS 'Instance ID of the current leader';
+COMMENT ON COLUMN job_leases.expires_at IS 'Once past, another instance may take the lease over';
+
+-- Step 2: Create job_runs table
+CREATE TABLE job_runs (
+    id BIGSERIAL PRIMARY KEY,
+    job TEXT NOT NULL,
+    holder TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'running',
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ,
+    CONSTRAINT job_runs_status_check CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+COMMENT ON TABLE job_runs IS 'Runs of scheduled jobs with the instance that executed them';
+COMMENT ON COLUMN job_runs.status IS 'failed when the run panicked, running when the instance stopped mid-run';
+
+CREATE INDEX idx_job_runs_started_at ON job_runs(started_at DESC);
+CREATE INDEX idx_job_runs_job ON job_runs(job, started_at DESC);
+
+-- Step 3: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON job_leases TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON job_runs TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE job_runs_id_seq TO ackify_app;
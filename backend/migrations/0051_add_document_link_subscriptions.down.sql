@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE document_link_sources DROP COLUMN IF EXISTS notified_at;
+
+DROP TABLE IF EXISTS document_link_subscriptions;
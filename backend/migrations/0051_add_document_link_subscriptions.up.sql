@@ -0,0 +1,56 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Link Subscriptions
+-- ============================================================================
+-- SharePoint and Google Drive push a notification when a file changes, so a
+-- document linking to one is checked as soon as its source is edited instead
+-- of at the next periodic check. Each tracked file gets a Microsoft Graph
+-- subscription or a Drive channel, renewed before it expires; notifications
+-- are authenticated by the client state of the subscription and request a
+-- check of the source by the link check worker.
+-- ============================================================================
+
+-- Step 1: Create document_link_subscriptions table
+CREATE TABLE document_link_subscriptions (
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    subscription_id TEXT NOT NULL,
+    resource_id TEXT NOT NULL DEFAULT '',
+    client_state TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_link_subscriptions IS 'Change notification subscriptions of the SharePoint and Google Drive files documents link to';
+COMMENT ON COLUMN document_link_subscriptions.resource_id IS 'Drive resource watched by the channel, needed to stop it';
+COMMENT ON COLUMN document_link_subscriptions.client_state IS 'Secret sent back by the host with each notification';
+
+CREATE INDEX idx_document_link_subscriptions_tenant_id ON document_link_subscriptions(tenant_id);
+CREATE UNIQUE INDEX idx_document_link_subscriptions_subscription ON document_link_subscriptions(provider, subscription_id);
+CREATE INDEX idx_document_link_subscriptions_expires ON document_link_subscriptions(expires_at);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_link_subscriptions_tenant_id_immutable
+    BEFORE UPDATE ON document_link_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_link_subscriptions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_link_subscriptions FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_link_subscriptions ON document_link_subscriptions;
+CREATE POLICY tenant_isolation_document_link_subscriptions ON document_link_subscriptions
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_link_subscriptions TO ackify_app;
+
+-- Step 5: Change notifications received since the last check
+ALTER TABLE document_link_sources ADD COLUMN notified_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN document_link_sources.notified_at IS 'Change notified by the host since the last check';
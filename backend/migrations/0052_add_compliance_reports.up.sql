@@ -0,0 +1,55 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Compliance Reports
+-- ============================================================================
+-- Cross-document compliance reports over a year, quarter or month: per document
+-- the expected, signed, late and outstanding signers, per user the documents
+-- left to sign. Aggregating every document takes a while on large tenants, so
+-- a report is requested, generated by a background worker and downloaded once
+-- ready as JSON, CSV or PDF, all rendered from the stored data.
+-- ============================================================================
+
+-- Step 1: Create compliance_reports table
+CREATE TABLE compliance_reports (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    period TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    data JSONB,
+    requested_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT compliance_reports_status_check CHECK (status IN ('pending', 'processing', 'ready', 'failed'))
+);
+
+COMMENT ON TABLE compliance_reports IS 'Cross-document signing reports, generated in the background';
+COMMENT ON COLUMN compliance_reports.period IS 'Year (2024), quarter (2024-Q4) or month (2024-11) covered';
+COMMENT ON COLUMN compliance_reports.period_end IS 'Exclusive end of the period; the report reflects the state at that date';
+COMMENT ON COLUMN compliance_reports.data IS 'Report content, set once ready';
+
+CREATE INDEX idx_compliance_reports_tenant_id ON compliance_reports(tenant_id);
+CREATE INDEX idx_compliance_reports_period ON compliance_reports(period, created_at DESC);
+CREATE INDEX idx_compliance_reports_pending ON compliance_reports(created_at) WHERE status IN ('pending', 'processing');
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_compliance_reports_tenant_id_immutable
+    BEFORE UPDATE ON compliance_reports
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE compliance_reports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE compliance_reports FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_compliance_reports ON compliance_reports;
+CREATE POLICY tenant_isolation_compliance_reports ON compliance_reports
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON compliance_reports TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE compliance_reports_id_seq TO ackify_app;
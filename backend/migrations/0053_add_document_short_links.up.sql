@@ -0,0 +1,49 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Short Links
+-- ============================================================================
+-- Short links (/s/{code}) to the signing page of a document, printed as QR
+-- codes on notices so that workers without a mailbox reach the document by
+-- scanning it. A document has one link per locale; a link with a locale opens
+-- the signing page in that language. Scans are counted per link.
+-- ============================================================================
+
+-- Step 1: Create document_short_links table
+CREATE TABLE document_short_links (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    code TEXT NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    locale TEXT NOT NULL DEFAULT '',
+    scan_count BIGINT NOT NULL DEFAULT 0,
+    last_scanned_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, doc_id, locale)
+);
+
+COMMENT ON TABLE document_short_links IS 'Short links to the signing page of documents, printed as QR codes';
+COMMENT ON COLUMN document_short_links.code IS 'Random code of the /s/{code} link, unique across tenants';
+COMMENT ON COLUMN document_short_links.locale IS 'Language the signing page opens in (empty: the browser language)';
+
+CREATE INDEX idx_document_short_links_tenant_id ON document_short_links(tenant_id);
+CREATE UNIQUE INDEX idx_document_short_links_code ON document_short_links(code);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_short_links_tenant_id_immutable
+    BEFORE UPDATE ON document_short_links
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_short_links ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_short_links FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_short_links ON document_short_links;
+CREATE POLICY tenant_isolation_document_short_links ON document_short_links
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_short_links TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_short_links_id_seq TO ackify_app;
@@ -0,0 +1,48 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signature Idempotency Keys
+-- ============================================================================
+-- Clients send an Idempotency-Key header with POST /api/v1/signatures to retry
+-- it safely. The key is stored with a fingerprint of the request and the
+-- response of the signature creation, so that a retry of the same request
+-- gets the original 201 response instead of a 409. Keys expire after a
+-- configurable retention window.
+-- ============================================================================
+
+-- Step 1: Create signature_idempotency_keys table
+CREATE TABLE signature_idempotency_keys (
+    tenant_id UUID NOT NULL,
+    user_sub TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    response JSONB,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, user_sub, idempotency_key)
+);
+
+COMMENT ON TABLE signature_idempotency_keys IS 'Idempotency keys of signature requests with the response to replay on retries';
+COMMENT ON COLUMN signature_idempotency_keys.fingerprint IS 'SHA-256 of the request body; a key reused with another body is rejected';
+COMMENT ON COLUMN signature_idempotency_keys.status_code IS 'Status of the stored response, 0 while the request is in progress';
+
+CREATE INDEX idx_signature_idempotency_keys_tenant_id ON signature_idempotency_keys(tenant_id);
+CREATE INDEX idx_signature_idempotency_keys_expires_at ON signature_idempotency_keys(expires_at);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_signature_idempotency_keys_tenant_id_immutable
+    BEFORE UPDATE ON signature_idempotency_keys
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE signature_idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signature_idempotency_keys FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_signature_idempotency_keys ON signature_idempotency_keys;
+CREATE POLICY tenant_isolation_signature_idempotency_keys ON signature_idempotency_keys
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON signature_idempotency_keys TO ackify_app;
@@ -0,0 +1,44 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signature Ledger Entries
+-- ============================================================================
+-- The record hash of each new signature can be streamed to an external
+-- append-only ledger (a separate PostgreSQL database or a Kafka topic), kept
+-- by another team for separation of duties on evidence storage. This table
+-- records what was streamed and where, so that the streaming lag can be
+-- monitored and the signatures reconciled with the ledger.
+-- ============================================================================
+
+-- Step 1: Create signature_ledger_entries table
+CREATE TABLE signature_ledger_entries (
+    tenant_id UUID NOT NULL,
+    signature_id BIGINT NOT NULL,
+    record_hash TEXT NOT NULL,
+    ledger_ref TEXT NOT NULL,
+    streamed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, signature_id)
+);
+
+COMMENT ON TABLE signature_ledger_entries IS 'Signature record hashes streamed to the external append-only ledger';
+COMMENT ON COLUMN signature_ledger_entries.record_hash IS 'Record hash of the signature when it was streamed';
+COMMENT ON COLUMN signature_ledger_entries.ledger_ref IS 'Location of the entry in the ledger (row ID, topic partition and offset)';
+
+CREATE INDEX idx_signature_ledger_entries_streamed_at ON signature_ledger_entries(tenant_id, streamed_at DESC);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_signature_ledger_entries_tenant_id_immutable
+    BEFORE UPDATE ON signature_ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE signature_ledger_entries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signature_ledger_entries FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_signature_ledger_entries ON signature_ledger_entries;
+CREATE POLICY tenant_isolation_signature_ledger_entries ON signature_ledger_entries
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role (entries are never modified)
+GRANT SELECT, INSERT ON signature_ledger_entries TO ackify_app;
@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE user_preferences
+    DROP COLUMN IF EXISTS timezone;
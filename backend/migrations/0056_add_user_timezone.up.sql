@@ -0,0 +1,16 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add User Timezone
+-- ============================================================================
+-- Stores the timezone of each user with their preferences. When the tenant
+-- enables working hours for reminders, a reminder queued outside the business
+-- hours of its recipient, in their timezone or else the tenant one, is
+-- delivered when their next working hours start.
+-- ============================================================================
+
+-- Step 1: Timezone of the user, empty when unknown
+ALTER TABLE user_preferences
+    ADD COLUMN timezone TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN user_preferences.timezone IS 'IANA timezone of the user for reminder working hours, empty to use the tenant one';
@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TABLE IF EXISTS tag_policies;
+
+DROP TABLE IF EXISTS document_tags;
@@ -0,0 +1,74 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Tags
+-- ============================================================================
+-- Documents carry free-form tags, normalized to lowercase, used to filter the
+-- document list, the search and the statistics. A policy attached to a tag
+-- tightens the reminder escalation of the documents carrying it and changes
+-- how long they are kept before the retention job archives them.
+-- ============================================================================
+
+-- Step 1: Create document_tags table
+CREATE TABLE document_tags (
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, doc_id, tag)
+);
+
+COMMENT ON TABLE document_tags IS 'Free-form tags of documents';
+COMMENT ON COLUMN document_tags.tag IS 'Lowercase tag, whitespace collapsed';
+
+CREATE INDEX idx_document_tags_tenant_tag ON document_tags(tenant_id, tag);
+CREATE INDEX idx_document_tags_doc_id ON document_tags(doc_id);
+
+-- Step 2: Create tag_policies table
+CREATE TABLE tag_policies (
+    tenant_id UUID NOT NULL,
+    tag TEXT NOT NULL,
+    firm_after_reminders INT NOT NULL DEFAULT 0,
+    firm_after_days INT NOT NULL DEFAULT 0,
+    final_after_reminders INT NOT NULL DEFAULT 0,
+    final_after_days INT NOT NULL DEFAULT 0,
+    archive_after_days INT,
+    updated_by TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, tag)
+);
+
+COMMENT ON TABLE tag_policies IS 'Reminder and retention policies inherited by the documents carrying a tag';
+COMMENT ON COLUMN tag_policies.firm_after_reminders IS 'Escalation threshold, 0 keeps the tenant setting';
+COMMENT ON COLUMN tag_policies.archive_after_days IS 'Days after completion before archiving, NULL keeps the tenant retention policy';
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_document_tags_tenant_id_immutable
+    BEFORE UPDATE ON document_tags
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_tag_policies_tenant_id_immutable
+    BEFORE UPDATE ON tag_policies
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE document_tags ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_tags FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_tags ON document_tags;
+CREATE POLICY tenant_isolation_document_tags ON document_tags
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE tag_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tag_policies FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_tag_policies ON tag_policies;
+CREATE POLICY tenant_isolation_tag_policies ON tag_policies
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_tags TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON tag_policies TO ackify_app;
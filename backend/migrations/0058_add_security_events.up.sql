@@ -0,0 +1,85 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Security Events
+-- ============================================================================
+-- Failed OAuth callbacks, invalid magic links and CSRF failures are recorded
+-- per client IP and user. When a user or IP exceeds the alert threshold within
+-- the window, a security event is raised: it is listed to admins and sent to
+-- the configured alert channels (alerted_at records the delivery).
+-- ============================================================================
+
+-- Step 1: Create auth_failures table
+CREATE TABLE auth_failures (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    kind TEXT NOT NULL,
+    user_email TEXT,
+    ip_address TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT auth_failures_kind_check CHECK (kind IN ('oauth_callback', 'magic_link', 'csrf'))
+);
+
+COMMENT ON TABLE auth_failures IS 'Failed authentication attempts, counted per user and IP over the alert window';
+
+CREATE INDEX idx_auth_failures_tenant_id ON auth_failures(tenant_id);
+CREATE INDEX idx_auth_failures_ip ON auth_failures(kind, ip_address, created_at);
+CREATE INDEX idx_auth_failures_user ON auth_failures(kind, user_email, created_at) WHERE user_email IS NOT NULL;
+CREATE INDEX idx_auth_failures_created_at ON auth_failures(created_at);
+
+-- Step 2: Create security_events table
+CREATE TABLE security_events (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    kind TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    failures INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    alerted_at TIMESTAMPTZ,
+    acknowledged_at TIMESTAMPTZ,
+    acknowledged_by TEXT,
+    CONSTRAINT security_events_kind_check CHECK (kind IN ('oauth_callback', 'magic_link', 'csrf')),
+    CONSTRAINT security_events_scope_check CHECK (scope IN ('user', 'ip'))
+);
+
+COMMENT ON TABLE security_events IS 'Users or IPs that exceeded the failed authentication threshold';
+COMMENT ON COLUMN security_events.failures IS 'Failures counted within the window when the event was raised';
+COMMENT ON COLUMN security_events.alerted_at IS 'When the alert channels were notified, NULL while pending';
+
+CREATE INDEX idx_security_events_tenant_id ON security_events(tenant_id);
+CREATE INDEX idx_security_events_subject ON security_events(kind, scope, subject, created_at DESC);
+CREATE INDEX idx_security_events_created_at ON security_events(created_at DESC);
+CREATE INDEX idx_security_events_pending_alerts ON security_events(created_at) WHERE alerted_at IS NULL;
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_auth_failures_tenant_id_immutable
+    BEFORE UPDATE ON auth_failures
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_security_events_tenant_id_immutable
+    BEFORE UPDATE ON security_events
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE auth_failures ENABLE ROW LEVEL SECURITY;
+ALTER TABLE auth_failures FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_auth_failures ON auth_failures;
+CREATE POLICY tenant_isolation_auth_failures ON auth_failures
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE security_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE security_events FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_security_events ON security_events;
+CREATE POLICY tenant_isolation_security_events ON security_events
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, DELETE ON auth_failures TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE auth_failures_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON security_events TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE security_events_id_seq TO ackify_app;
@@ -0,0 +1,49 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signature Validation Hooks
+-- ============================================================================
+-- Per-document HTTP callout evaluated before a signature is created. The hook
+-- can reject the signature based on external state (e.g. a training completed
+-- in an LMS). The failure policy decides whether signing is allowed (open) or
+-- refused (closed) when the hook times out or answers an error.
+-- ============================================================================
+
+-- Step 1: Create signature_hooks table
+CREATE TABLE signature_hooks (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT,
+    timeout_ms INT NOT NULL DEFAULT 3000 CHECK (timeout_ms BETWEEN 100 AND 10000),
+    failure_policy TEXT NOT NULL DEFAULT 'closed' CHECK (failure_policy IN ('open', 'closed')),
+    updated_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE signature_hooks IS 'Per-document HTTP validation hooks called before a signature is created';
+COMMENT ON COLUMN signature_hooks.secret IS 'Optional HMAC secret signing the hook requests (X-Ackify-Signature)';
+COMMENT ON COLUMN signature_hooks.failure_policy IS 'open: allow signing when the hook fails, closed: refuse it';
+
+CREATE INDEX idx_signature_hooks_tenant_id ON signature_hooks(tenant_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_signature_hooks_tenant_id_immutable
+    BEFORE UPDATE ON signature_hooks
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE signature_hooks ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signature_hooks FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_signature_hooks ON signature_hooks;
+CREATE POLICY tenant_isolation_signature_hooks ON signature_hooks
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON signature_hooks TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE signature_hooks_id_seq TO ackify_app;
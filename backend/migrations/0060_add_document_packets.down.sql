@@ -0,0 +1,4 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TABLE IF EXISTS document_packet_items;
+DROP TABLE IF EXISTS document_packets;
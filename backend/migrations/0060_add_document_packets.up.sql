@@ -0,0 +1,73 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Packets
+-- ============================================================================
+-- A packet bundles documents signed together (e.g. an onboarding pack of
+-- policies). Signers get one link listing every document of the packet and
+-- sign them one after the other; completion and reminders are tracked at the
+-- packet level. Documents keep their own expected signers and signatures.
+-- ============================================================================
+
+-- Step 1: Create document_packets table
+CREATE TABLE document_packets (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE document_packets IS 'Bundles of documents signed in a single flow';
+
+CREATE INDEX idx_document_packets_tenant_id ON document_packets(tenant_id);
+
+-- Step 2: Create document_packet_items table
+CREATE TABLE document_packet_items (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    packet_id BIGINT NOT NULL REFERENCES document_packets(id) ON DELETE CASCADE,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    UNIQUE (packet_id, doc_id)
+);
+
+COMMENT ON TABLE document_packet_items IS 'Documents of a packet, in signing order';
+COMMENT ON COLUMN document_packet_items.position IS 'Order in which signers are presented the document, starting at 1';
+
+CREATE INDEX idx_document_packet_items_packet ON document_packet_items(packet_id, position);
+CREATE INDEX idx_document_packet_items_doc_id ON document_packet_items(doc_id);
+CREATE INDEX idx_document_packet_items_tenant_id ON document_packet_items(tenant_id);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_document_packets_tenant_id_immutable
+    BEFORE UPDATE ON document_packets
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_document_packet_items_tenant_id_immutable
+    BEFORE UPDATE ON document_packet_items
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE document_packets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_packets FORCE ROW LEVEL SECURITY;
+ALTER TABLE document_packet_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_packet_items FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_packets ON document_packets;
+CREATE POLICY tenant_isolation_document_packets ON document_packets
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_document_packet_items ON document_packet_items;
+CREATE POLICY tenant_isolation_document_packet_items ON document_packet_items
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_packets TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_packets_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_packet_items TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_packet_items_id_seq TO ackify_app;
@@ -0,0 +1,52 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Preview Links
+-- ============================================================================
+-- Read-only links to the status of a document (metadata and completion
+-- stats), shared with auditors or external counsel who have no account. Only
+-- the hash of the token is stored. Links expire and can be revoked; signer
+-- names and emails are shown only when the link allows it.
+-- ============================================================================
+
+-- Step 1: Create document_preview_links table
+CREATE TABLE document_preview_links (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    include_signers BOOLEAN NOT NULL DEFAULT false,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    view_count BIGINT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE document_preview_links IS 'Expiring read-only links to the status of documents, for reviewers without an account';
+COMMENT ON COLUMN document_preview_links.token_hash IS 'Hex SHA-256 of the token, the token itself is never stored';
+COMMENT ON COLUMN document_preview_links.include_signers IS 'Whether the preview lists signer names and emails';
+
+CREATE INDEX idx_document_preview_links_tenant_id ON document_preview_links(tenant_id);
+CREATE INDEX idx_document_preview_links_doc_id ON document_preview_links(tenant_id, doc_id);
+CREATE UNIQUE INDEX idx_document_preview_links_token_hash ON document_preview_links(token_hash);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_preview_links_tenant_id_immutable
+    BEFORE UPDATE ON document_preview_links
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_preview_links ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_preview_links FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_preview_links ON document_preview_links;
+CREATE POLICY tenant_isolation_document_preview_links ON document_preview_links
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_preview_links TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_preview_links_id_seq TO ackify_app;
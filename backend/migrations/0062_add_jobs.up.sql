@@ -0,0 +1,64 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Jobs
+-- ============================================================================
+-- A common queue for background work. Each job has a type, the handler of
+-- which decodes its JSON payload. Instances claim queued jobs with
+-- FOR UPDATE SKIP LOCKED, so every instance runs jobs without running the same
+-- one twice. Failed runs are retried with a backoff until max_attempts; jobs
+-- left running by a lost instance are requeued once their lock is stale.
+-- ============================================================================
+
+-- Step 1: Create jobs table
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    type TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'queued',
+    priority INT NOT NULL DEFAULT 0,
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 3,
+    run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_by TEXT,
+    locked_at TIMESTAMPTZ,
+    last_error TEXT,
+    dedup_key TEXT,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    CONSTRAINT jobs_status_check CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+    CONSTRAINT jobs_max_attempts_check CHECK (max_attempts >= 1)
+);
+
+COMMENT ON TABLE jobs IS 'Background jobs claimed by the instances with SKIP LOCKED';
+COMMENT ON COLUMN jobs.run_at IS 'The job is not claimed before, also holds the next retry';
+COMMENT ON COLUMN jobs.locked_by IS 'Instance ID running the job';
+COMMENT ON COLUMN jobs.dedup_key IS 'At most one queued or running job per type and key';
+
+CREATE INDEX idx_jobs_tenant_id ON jobs(tenant_id);
+CREATE INDEX idx_jobs_queued ON jobs(type, priority DESC, run_at) WHERE status = 'queued';
+CREATE INDEX idx_jobs_running ON jobs(locked_at) WHERE status = 'running';
+CREATE INDEX idx_jobs_created_at ON jobs(created_at DESC);
+CREATE UNIQUE INDEX idx_jobs_dedup ON jobs(tenant_id, type, dedup_key)
+    WHERE dedup_key IS NOT NULL AND status IN ('queued', 'running');
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_jobs_tenant_id_immutable
+    BEFORE UPDATE ON jobs
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE jobs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE jobs FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_jobs ON jobs;
+CREATE POLICY tenant_isolation_jobs ON jobs
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON jobs TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE jobs_id_seq TO ackify_app;
@@ -0,0 +1,42 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Pending Configuration Changes
+-- ============================================================================
+-- Configuration sections staged by admins without being applied. Staged
+-- sections are validated together and applied at once, so that changes
+-- depending on each other (enabling MagicLink and configuring SMTP) never
+-- leave a half-applied configuration. One staged section per category.
+-- ============================================================================
+
+-- Step 1: Create tenant_config_pending table
+CREATE TABLE tenant_config_pending (
+    tenant_id UUID NOT NULL,
+    category TEXT NOT NULL CHECK (category IN ('general', 'oidc', 'magiclink', 'smtp', 'storage', 'branding', 'reminders', 'security')),
+    config JSONB NOT NULL DEFAULT '{}',
+    secrets_encrypted BYTEA,
+    staged_by TEXT NOT NULL DEFAULT '',
+    staged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, category)
+);
+
+COMMENT ON TABLE tenant_config_pending IS 'Configuration sections staged by admins, applied together to tenant_config';
+COMMENT ON COLUMN tenant_config_pending.config IS 'JSONB configuration data (secrets excluded)';
+COMMENT ON COLUMN tenant_config_pending.secrets_encrypted IS 'AES-256-GCM encrypted secrets blob, NULL keeps the current secrets';
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_tenant_config_pending_tenant_id_immutable
+    BEFORE UPDATE ON tenant_config_pending
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE tenant_config_pending ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tenant_config_pending FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_tenant_config_pending ON tenant_config_pending;
+CREATE POLICY tenant_isolation_tenant_config_pending ON tenant_config_pending
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON tenant_config_pending TO ackify_app;
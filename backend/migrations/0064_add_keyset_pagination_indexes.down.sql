@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP INDEX IF EXISTS idx_signatures_user_keyset;
+DROP INDEX IF EXISTS idx_signatures_doc_keyset;
+DROP INDEX IF EXISTS idx_documents_keyset;
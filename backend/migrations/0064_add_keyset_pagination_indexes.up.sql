@@ -0,0 +1,21 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Keyset Pagination Indexes
+-- ============================================================================
+-- Cursor-paginated lists of documents and signatures are ordered newest first
+-- by creation time, then by key to break ties, and filter rows with a row
+-- comparison on the same columns. These indexes serve that comparison at any
+-- depth; B-tree indexes are scanned backward as well, so the same index serves
+-- the previous pages (oldest first).
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_documents_keyset
+    ON documents(tenant_id, created_at, doc_id)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_signatures_doc_keyset
+    ON signatures(tenant_id, doc_id, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_signatures_user_keyset
+    ON signatures(tenant_id, LOWER(user_email), created_at, id);
@@ -0,0 +1,43 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Aliases
+-- ============================================================================
+-- Alternative IDs of documents. The ID of a document cannot change once it is
+-- signed, since signatures are bound to it; an alias gives a document a new
+-- address while links using the alias are redirected to the document.
+-- Aliases share the namespace of document IDs: an alias never matches the ID
+-- of a document.
+-- ============================================================================
+
+-- Step 1: Create document_aliases table
+CREATE TABLE document_aliases (
+    alias TEXT PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE document_aliases IS 'Alternative IDs of documents, redirected to the document ID';
+COMMENT ON COLUMN document_aliases.alias IS 'Alternative ID, unique across tenants like document IDs';
+
+CREATE INDEX idx_document_aliases_tenant_id ON document_aliases(tenant_id);
+CREATE INDEX idx_document_aliases_doc_id ON document_aliases(doc_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_aliases_tenant_id_immutable
+    BEFORE UPDATE ON document_aliases
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_aliases ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_aliases FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_aliases ON document_aliases;
+CREATE POLICY tenant_isolation_document_aliases ON document_aliases
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_aliases TO ackify_app;
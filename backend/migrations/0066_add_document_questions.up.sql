@@ -0,0 +1,82 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Questions
+-- ============================================================================
+-- Questions signers ask about a document before signing it, and the replies
+-- of the document owner and the asker. A question is open until the owner
+-- replies, then answered; the asker or the owner resolves it, the owner can
+-- close it. The owner and admins can hide a question or a reply, hidden ones
+-- are only shown to them and to their author.
+-- ============================================================================
+
+-- Step 1: Create document_questions table
+CREATE TABLE document_questions (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    author_email TEXT NOT NULL,
+    author_name TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'answered', 'resolved', 'closed')),
+    hidden_at TIMESTAMPTZ,
+    hidden_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE document_questions IS 'Questions signers ask about documents, answered by the document owner';
+COMMENT ON COLUMN document_questions.status IS 'open, answered (the owner replied last), resolved or closed';
+COMMENT ON COLUMN document_questions.hidden_at IS 'Set when the owner or an admin hides the question from other signers';
+
+CREATE INDEX idx_document_questions_tenant_id ON document_questions(tenant_id);
+CREATE INDEX idx_document_questions_doc_id ON document_questions(doc_id, created_at);
+
+-- Step 2: Create document_question_replies table
+CREATE TABLE document_question_replies (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    question_id BIGINT NOT NULL REFERENCES document_questions(id) ON DELETE CASCADE,
+    author_email TEXT NOT NULL,
+    author_name TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    from_owner BOOLEAN NOT NULL DEFAULT false,
+    hidden_at TIMESTAMPTZ,
+    hidden_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE document_question_replies IS 'Replies in the threads of document questions';
+COMMENT ON COLUMN document_question_replies.from_owner IS 'Posted by the document owner or an admin rather than the asker';
+
+CREATE INDEX idx_document_question_replies_tenant_id ON document_question_replies(tenant_id);
+CREATE INDEX idx_document_question_replies_question_id ON document_question_replies(question_id, created_at);
+
+-- Step 3: tenant_id immutability triggers
+CREATE TRIGGER tr_document_questions_tenant_id_immutable
+    BEFORE UPDATE ON document_questions
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_document_question_replies_tenant_id_immutable
+    BEFORE UPDATE ON document_question_replies
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 4: Enable Row Level Security
+ALTER TABLE document_questions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_questions FORCE ROW LEVEL SECURITY;
+ALTER TABLE document_question_replies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_question_replies FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_questions ON document_questions;
+CREATE POLICY tenant_isolation_document_questions ON document_questions
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_document_question_replies ON document_question_replies;
+CREATE POLICY tenant_isolation_document_question_replies ON document_question_replies
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 5: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_questions, document_question_replies TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE document_questions_id_seq, document_question_replies_id_seq TO ackify_app;
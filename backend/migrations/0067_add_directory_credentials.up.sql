@@ -0,0 +1,56 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Directory Credentials
+-- ============================================================================
+-- Delegated access to the directories signer groups are synced from. An admin
+-- consents once with the OAuth authorization code flow; the refresh token is
+-- stored encrypted with the settings encryption key and exchanged for short
+-- lived access tokens. A refresh token rejected by the provider marks the
+-- credential revoked until an admin consents again.
+-- ============================================================================
+
+-- Step 1: Create directory_credentials table
+CREATE TABLE directory_credentials (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    provider TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'error', 'revoked')),
+    refresh_token_encrypted BYTEA,
+    oauth_state TEXT,
+    oauth_state_expires_at TIMESTAMPTZ,
+    connected_by TEXT NOT NULL DEFAULT '',
+    connected_at TIMESTAMPTZ,
+    last_refreshed_at TIMESTAMPTZ,
+    token_expires_at TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, provider)
+);
+
+COMMENT ON TABLE directory_credentials IS 'Delegated OAuth access to the directories signer groups are synced from';
+COMMENT ON COLUMN directory_credentials.refresh_token_encrypted IS 'Refresh token encrypted with AES-256-GCM and the settings encryption key';
+COMMENT ON COLUMN directory_credentials.oauth_state IS 'State of the consent in progress, single use';
+
+CREATE INDEX idx_directory_credentials_tenant_id ON directory_credentials(tenant_id);
+CREATE UNIQUE INDEX idx_directory_credentials_oauth_state ON directory_credentials(oauth_state) WHERE oauth_state IS NOT NULL;
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_directory_credentials_tenant_id_immutable
+    BEFORE UPDATE ON directory_credentials
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE directory_credentials ENABLE ROW LEVEL SECURITY;
+ALTER TABLE directory_credentials FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_directory_credentials ON directory_credentials;
+CREATE POLICY tenant_isolation_directory_credentials ON directory_credentials
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON directory_credentials TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE directory_credentials_id_seq TO ackify_app;
@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP INDEX IF EXISTS idx_user_sessions_tenant_revoked_at;
+DROP INDEX IF EXISTS idx_user_sessions_tenant_expires_at;
+DROP INDEX IF EXISTS idx_oauth_sessions_tenant_updated_at;
@@ -0,0 +1,16 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Session Cleanup Indexes
+-- ============================================================================
+-- The session cleanup deletes OAuth sessions not refreshed within the
+-- retention, and user sessions expired or revoked before it. These indexes
+-- let the cleanup find them without scanning the tables of the tenant.
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_oauth_sessions_tenant_updated_at ON oauth_sessions(tenant_id, updated_at);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_tenant_expires_at ON user_sessions(tenant_id, expires_at);
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_tenant_revoked_at ON user_sessions(tenant_id, revoked_at)
+    WHERE revoked_at IS NOT NULL;
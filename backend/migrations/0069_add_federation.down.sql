@@ -0,0 +1,6 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+DROP TABLE IF EXISTS federation_signatures;
+DROP TABLE IF EXISTS federation_documents;
+DROP TABLE IF EXISTS federation_peers;
+DROP TABLE IF EXISTS federation_pushes;
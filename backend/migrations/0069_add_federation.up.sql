@@ -0,0 +1,144 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Federation
+-- ============================================================================
+-- Companies running an Ackify instance per subsidiary can federate them: each
+-- peer periodically pushes the completion of its documents and the proofs of
+-- its signatures to a central instance, the hub. Pushes are signed with the
+-- signing key of the peer, registered at the hub.
+--
+-- Peer side, federation_pushes records the pushes accepted by the hub; the
+-- last signature pushed is the cursor of the next push.
+-- Hub side, documents and signatures are keyed by peer and by their ID at the
+-- peer, so that documents of different peers never collide and a push retried
+-- after a failure stores nothing twice.
+-- ============================================================================
+
+-- Step 1: Create federation_pushes table (peer side)
+CREATE TABLE federation_pushes (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    last_signature_id BIGINT NOT NULL,
+    documents INTEGER NOT NULL DEFAULT 0,
+    signatures INTEGER NOT NULL DEFAULT 0,
+    pushed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE federation_pushes IS 'Pushes of signature proofs accepted by the federation hub';
+COMMENT ON COLUMN federation_pushes.last_signature_id IS 'Highest signature ID of the push, cursor of the next push';
+
+CREATE INDEX idx_federation_pushes_cursor ON federation_pushes(tenant_id, last_signature_id DESC);
+
+-- Step 2: Create federation_peers table (hub side)
+CREATE TABLE federation_peers (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    instance_id TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    public_key TEXT NOT NULL,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_push_at TIMESTAMPTZ,
+    UNIQUE (tenant_id, instance_id)
+);
+
+COMMENT ON TABLE federation_peers IS 'Instances allowed to push signature proofs to this hub';
+COMMENT ON COLUMN federation_peers.public_key IS 'Base64 Ed25519 signing key of the peer, verifying its pushes';
+
+-- Step 3: Create federation_documents table (hub side)
+CREATE TABLE federation_documents (
+    tenant_id UUID NOT NULL,
+    peer_id BIGINT NOT NULL REFERENCES federation_peers(id) ON DELETE CASCADE,
+    doc_id TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    checksum TEXT NOT NULL DEFAULT '',
+    expected_count INTEGER NOT NULL DEFAULT 0,
+    signed_count INTEGER NOT NULL DEFAULT 0,
+    signature_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (peer_id, doc_id)
+);
+
+COMMENT ON TABLE federation_documents IS 'Completion of the documents of the peers, as last pushed';
+COMMENT ON COLUMN federation_documents.doc_id IS 'Document ID at the peer';
+
+CREATE INDEX idx_federation_documents_tenant_id ON federation_documents(tenant_id);
+
+-- Step 4: Create federation_signatures table (hub side)
+CREATE TABLE federation_signatures (
+    tenant_id UUID NOT NULL,
+    peer_id BIGINT NOT NULL REFERENCES federation_peers(id) ON DELETE CASCADE,
+    signature_id BIGINT NOT NULL,
+    doc_id TEXT NOT NULL,
+    user_email TEXT NOT NULL,
+    user_name TEXT NOT NULL DEFAULT '',
+    signed_at TIMESTAMPTZ NOT NULL,
+    doc_checksum TEXT NOT NULL DEFAULT '',
+    payload_hash TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    nonce TEXT NOT NULL,
+    prev_hash TEXT,
+    record_hash TEXT NOT NULL,
+    hash_version INTEGER NOT NULL DEFAULT 1,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (peer_id, signature_id)
+);
+
+COMMENT ON TABLE federation_signatures IS 'Proofs of the signatures of the peers, as recorded by the peers';
+COMMENT ON COLUMN federation_signatures.signature_id IS 'Signature ID at the peer';
+
+CREATE INDEX idx_federation_signatures_doc ON federation_signatures(tenant_id, peer_id, doc_id);
+
+-- Step 5: tenant_id immutability triggers
+CREATE TRIGGER tr_federation_pushes_tenant_id_immutable
+    BEFORE UPDATE ON federation_pushes
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_federation_peers_tenant_id_immutable
+    BEFORE UPDATE ON federation_peers
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+CREATE TRIGGER tr_federation_documents_tenant_id_immutable
+    BEFORE UPDATE ON federation_documents
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 6: Enable Row Level Security
+ALTER TABLE federation_pushes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE federation_pushes FORCE ROW LEVEL SECURITY;
+ALTER TABLE federation_peers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE federation_peers FORCE ROW LEVEL SECURITY;
+ALTER TABLE federation_documents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE federation_documents FORCE ROW LEVEL SECURITY;
+ALTER TABLE federation_signatures ENABLE ROW LEVEL SECURITY;
+ALTER TABLE federation_signatures FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_federation_pushes ON federation_pushes;
+CREATE POLICY tenant_isolation_federation_pushes ON federation_pushes
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_federation_peers ON federation_peers;
+CREATE POLICY tenant_isolation_federation_peers ON federation_peers
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_federation_documents ON federation_documents;
+CREATE POLICY tenant_isolation_federation_documents ON federation_documents
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+DROP POLICY IF EXISTS tenant_isolation_federation_signatures ON federation_signatures;
+CREATE POLICY tenant_isolation_federation_signatures ON federation_signatures
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 7: Grant permissions to ackify_app role (received proofs are never modified)
+GRANT SELECT, INSERT ON federation_pushes TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE federation_pushes_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON federation_peers TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE federation_peers_id_seq TO ackify_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON federation_documents TO ackify_app;
+GRANT SELECT, INSERT, DELETE ON federation_signatures TO ackify_app;
@@ -0,0 +1,40 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Sign Redirects
+-- ============================================================================
+-- Per-document URL the signer is sent to after signing (e.g. back to the
+-- intranet page or a thank-you survey). The URL must belong to an origin of
+-- ACKIFY_SIGN_REDIRECT_ALLOWED_ORIGINS, checked when it is set and again when
+-- it is returned by the sign API.
+-- ============================================================================
+
+-- Step 1: Create document_sign_redirects table
+CREATE TABLE document_sign_redirects (
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL REFERENCES documents(doc_id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    updated_by TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, doc_id)
+);
+
+COMMENT ON TABLE document_sign_redirects IS 'Per-document URL signers are redirected to after signing';
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_document_sign_redirects_tenant_id_immutable
+    BEFORE UPDATE ON document_sign_redirects
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE document_sign_redirects ENABLE ROW LEVEL SECURITY;
+ALTER TABLE document_sign_redirects FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_document_sign_redirects ON document_sign_redirects;
+CREATE POLICY tenant_isolation_document_sign_redirects ON document_sign_redirects
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON document_sign_redirects TO ackify_app;
@@ -0,0 +1,60 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Upload Quarantine
+-- ============================================================================
+-- Uploaded files that break the upload policy of the tenant (size, MIME type)
+-- or that the virus scanner flags are kept aside instead of becoming a
+-- document. An admin reviews them: releasing one creates the document as the
+-- uploader asked, discarding one deletes the file. The row is kept with the
+-- decision as a record of the review.
+-- ============================================================================
+
+-- Step 1: Create upload_quarantine table
+CREATE TABLE upload_quarantine (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    storage_key TEXT NOT NULL,
+    storage_provider TEXT NOT NULL,
+    original_filename TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    mime_type TEXT NOT NULL,
+    file_size BIGINT NOT NULL,
+    checksum TEXT NOT NULL,
+    read_mode TEXT NOT NULL DEFAULT 'integrated',
+    allow_download BOOLEAN NOT NULL DEFAULT false,
+    require_full_read BOOLEAN NOT NULL DEFAULT false,
+    verify_checksum BOOLEAN NOT NULL DEFAULT true,
+    reason TEXT NOT NULL CHECK (reason IN ('too_large', 'type_not_allowed', 'infected', 'scan_failed')),
+    detail TEXT NOT NULL DEFAULT '',
+    uploaded_by TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'released', 'discarded')),
+    reviewed_by TEXT,
+    reviewed_at TIMESTAMPTZ,
+    doc_id TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE upload_quarantine IS 'Uploaded files held for admin review instead of becoming documents';
+COMMENT ON COLUMN upload_quarantine.detail IS 'Signature name reported by the virus scanner, or the scan error';
+COMMENT ON COLUMN upload_quarantine.doc_id IS 'Document created when the file was released';
+
+CREATE INDEX idx_upload_quarantine_status ON upload_quarantine(tenant_id, status, created_at DESC);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_upload_quarantine_tenant_id_immutable
+    BEFORE UPDATE ON upload_quarantine
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE upload_quarantine ENABLE ROW LEVEL SECURITY;
+ALTER TABLE upload_quarantine FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_upload_quarantine ON upload_quarantine;
+CREATE POLICY tenant_isolation_upload_quarantine ON upload_quarantine
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON upload_quarantine TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE upload_quarantine_id_seq TO ackify_app;
@@ -0,0 +1,53 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Event Outbox
+-- ============================================================================
+-- Domain events (signature.created, document.completed, ...) are written to
+-- the outbox in the transaction of the state change they report, so that an
+-- event exists if and only if the change was committed. A relay worker then
+-- hands each event to the webhooks and notifiers, in its own transaction,
+-- and marks it delivered: delivery is at least once, even across crashes.
+--
+-- Events failing repeatedly are retried with backoff, then marked dead until
+-- an admin retries them. Delivered events are purged after a week.
+-- ============================================================================
+
+-- Step 1: Create event_outbox table
+CREATE TABLE event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    event_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE event_outbox IS 'Domain events written with their state change, relayed to webhooks and notifiers';
+COMMENT ON COLUMN event_outbox.event_id IS 'Stable ID of the event, sent with every webhook delivery so receivers can deduplicate';
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox(tenant_id, next_attempt_at, id) WHERE status = 'pending';
+CREATE INDEX idx_event_outbox_delivered ON event_outbox(tenant_id, delivered_at) WHERE status = 'delivered';
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_event_outbox_tenant_id_immutable
+    BEFORE UPDATE ON event_outbox
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE event_outbox ENABLE ROW LEVEL SECURITY;
+ALTER TABLE event_outbox FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_event_outbox ON event_outbox;
+CREATE POLICY tenant_isolation_event_outbox ON event_outbox
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON event_outbox TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE event_outbox_id_seq TO ackify_app;
@@ -0,0 +1,5 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS result;
+ALTER TABLE jobs DROP COLUMN IF EXISTS progress_total;
+ALTER TABLE jobs DROP COLUMN IF EXISTS progress_done;
@@ -0,0 +1,19 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Job Progress
+-- ============================================================================
+-- Long jobs, such as the signature archive of all documents, report how many
+-- of their items are done while they run and keep a result once they
+-- succeed (e.g. the download link of the archive), both shown by the jobs
+-- API. Progress is written in a transaction of its own, so it is visible
+-- while the run is still in progress.
+-- ============================================================================
+
+ALTER TABLE jobs ADD COLUMN progress_done INT NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN progress_total INT NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN result JSONB;
+
+COMMENT ON COLUMN jobs.progress_done IS 'Items done by the current run, out of progress_total';
+COMMENT ON COLUMN jobs.progress_total IS 'Items of the current run, 0 when the job does not report progress';
+COMMENT ON COLUMN jobs.result IS 'Outcome of a succeeded job, decoded by the API clients of its type';
@@ -0,0 +1,52 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Identity Merges
+-- ============================================================================
+-- A person signing in with different methods (Google, then a magic link) gets
+-- several identities, splitting their signature history. An admin merges a
+-- source identity into a target one: the source email becomes an email alias
+-- of the target, ownership references move to the target, and later logins
+-- with the source subjects or email resolve to the target identity.
+-- Signatures are signed records and keep their original identity.
+-- ============================================================================
+
+-- Step 1: Create identity_merges table
+CREATE TABLE identity_merges (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    source_email TEXT NOT NULL,
+    source_subs TEXT[] NOT NULL DEFAULT '{}',
+    target_email TEXT NOT NULL,
+    target_sub TEXT NOT NULL DEFAULT '',
+    summary JSONB NOT NULL DEFAULT '{}',
+    merged_by TEXT NOT NULL,
+    merged_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (tenant_id, source_email)
+);
+
+COMMENT ON TABLE identity_merges IS 'Identities merged into another one, resolved at login';
+COMMENT ON COLUMN identity_merges.source_subs IS 'Subjects of the source identity, mapped to target_sub at login';
+COMMENT ON COLUMN identity_merges.target_sub IS 'Subject of the target identity, empty when it never signed in (logins keep their subject)';
+COMMENT ON COLUMN identity_merges.summary IS 'Counts of the references moved to the target identity';
+
+CREATE INDEX idx_identity_merges_source_subs ON identity_merges USING GIN (source_subs);
+CREATE INDEX idx_identity_merges_target_email ON identity_merges(tenant_id, target_email);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_identity_merges_tenant_id_immutable
+    BEFORE UPDATE ON identity_merges
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE identity_merges ENABLE ROW LEVEL SECURITY;
+ALTER TABLE identity_merges FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_identity_merges ON identity_merges;
+CREATE POLICY tenant_isolation_identity_merges ON identity_merges
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON identity_merges TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE identity_merges_id_seq TO ackify_app;
@@ -0,0 +1,54 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Signature Approvals
+-- ============================================================================
+-- With the approve_unexpected_signatures policy of the tenant, a signature from
+-- a user missing from the expected signers of a document is held for review.
+-- Held signatures stay recorded (they are signed records) but do not count in
+-- the signature lists and counts until an admin approves them. A rejected
+-- signature stays excluded. The row keeps the decision as a record.
+-- ============================================================================
+
+-- Step 1: Create signature_approvals table
+CREATE TABLE signature_approvals (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    signature_id BIGINT NOT NULL REFERENCES signatures(id) ON DELETE CASCADE,
+    doc_id TEXT NOT NULL,
+    user_email TEXT NOT NULL,
+    user_name TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by TEXT,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (signature_id)
+);
+
+COMMENT ON TABLE signature_approvals IS 'Signatures of unexpected users held for admin approval';
+
+CREATE INDEX idx_signature_approvals_status ON signature_approvals(tenant_id, status, created_at DESC);
+CREATE INDEX idx_signature_approvals_doc_id ON signature_approvals(tenant_id, doc_id);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_signature_approvals_tenant_id_immutable
+    BEFORE UPDATE ON signature_approvals
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE signature_approvals ENABLE ROW LEVEL SECURITY;
+ALTER TABLE signature_approvals FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_signature_approvals ON signature_approvals;
+CREATE POLICY tenant_isolation_signature_approvals ON signature_approvals
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON signature_approvals TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE signature_approvals_id_seq TO ackify_app;
+
+-- Step 5: Allow the signature_approval_required notification type
+ALTER TABLE admin_notifications DROP CONSTRAINT IF EXISTS admin_notifications_type_check;
+ALTER TABLE admin_notifications ADD CONSTRAINT admin_notifications_type_check
+    CHECK (type IN ('signature_received', 'email_bounced', 'deadline_approaching', 'source_changed', 'signature_approval_required'));
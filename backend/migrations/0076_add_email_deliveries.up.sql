@@ -0,0 +1,49 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Email Deliveries
+-- ============================================================================
+-- Each send to the SMTP servers is recorded with the response code, the
+-- latency, the Message-ID and the domains of its recipients. Admins aggregate
+-- them per domain to spot systematic delivery failures, e.g. a corporate relay
+-- rejecting every message. Deliveries are kept 30 days.
+-- ============================================================================
+
+-- Step 1: Create email_deliveries table
+CREATE TABLE email_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    message_id TEXT NOT NULL,
+    template TEXT NOT NULL DEFAULT '',
+    recipient_domains TEXT[] NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL CHECK (status IN ('sent', 'failed')),
+    smtp_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    server TEXT NOT NULL DEFAULT '',
+    latency_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE email_deliveries IS 'Outcome of each send to the SMTP servers, for the deliverability report';
+COMMENT ON COLUMN email_deliveries.smtp_code IS 'SMTP reply code of the last command, 0 when no server answered';
+COMMENT ON COLUMN email_deliveries.server IS 'Last SMTP server tried';
+
+CREATE INDEX idx_email_deliveries_created_at ON email_deliveries(tenant_id, created_at DESC);
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_email_deliveries_tenant_id_immutable
+    BEFORE UPDATE ON email_deliveries
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE email_deliveries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE email_deliveries FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_email_deliveries ON email_deliveries;
+CREATE POLICY tenant_isolation_email_deliveries ON email_deliveries
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, DELETE ON email_deliveries TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE email_deliveries_id_seq TO ackify_app;
@@ -0,0 +1,26 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add TLS Certificates
+-- ============================================================================
+-- With ACKIFY_TLS_AUTO, the server obtains its HTTPS certificate from an ACME
+-- CA such as Let's Encrypt and renews it before expiry. The ACME account key
+-- and the certificates are kept here unless a cache directory is configured,
+-- so that restarts reuse them instead of hitting the CA rate limits.
+--
+-- Certificates belong to the instance rather than to a tenant, so the table
+-- has no tenant_id and no RLS policy.
+-- ============================================================================
+
+-- Step 1: Create tls_certificates table
+CREATE TABLE tls_certificates (
+    name TEXT PRIMARY KEY,
+    data BYTEA NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE tls_certificates IS 'ACME account key and certificates of the automatic TLS';
+COMMENT ON COLUMN tls_certificates.data IS 'PEM encoded private key, followed by the certificate chain for certificates';
+
+-- Step 2: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE, DELETE ON tls_certificates TO ackify_app;
@@ -0,0 +1,3 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+ALTER TABLE documents DROP COLUMN IF EXISTS landing_message;
@@ -0,0 +1,13 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Document Landing Message
+-- ============================================================================
+-- Admins can show a message on the signing page of a document, before the
+-- acknowledgement button: instructions, or the legal context of the
+-- acknowledgement. The message is Markdown, with one variant per locale.
+-- ============================================================================
+
+ALTER TABLE documents ADD COLUMN landing_message JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+COMMENT ON COLUMN documents.landing_message IS 'Markdown shown on the signing page, keyed by locale (en, fr, ...)';
@@ -0,0 +1,56 @@
+-- SPDX-License-Identifier: AGPL-3.0-or-later
+
+-- ============================================================================
+-- Migration: Add Deadline Extensions
+-- ============================================================================
+-- An expected signer can ask for more time than the document deadline, with a
+-- reason. The document owner approves the request, possibly with another
+-- deadline, or denies it. The deadline of a signer is the later of the
+-- document deadline and their last approved extension; the document deadline
+-- itself is unchanged. Rows are never deleted with their decision, so that the
+-- table keeps the trail of the requests and who decided them.
+-- ============================================================================
+
+-- Step 1: Create deadline_extensions table
+CREATE TABLE deadline_extensions (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    doc_id TEXT NOT NULL,
+    signer_email TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    requested_deadline TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'denied')),
+    granted_deadline TIMESTAMPTZ,
+    decided_by TEXT,
+    decided_at TIMESTAMPTZ,
+    decision_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (status <> 'approved' OR granted_deadline IS NOT NULL)
+);
+
+COMMENT ON TABLE deadline_extensions IS 'Deadline extensions requested by expected signers and the decisions of document owners';
+COMMENT ON COLUMN deadline_extensions.granted_deadline IS 'Deadline of the signer once approved, the requested one unless the owner chose another';
+
+CREATE INDEX idx_deadline_extensions_doc_id ON deadline_extensions(tenant_id, doc_id, created_at DESC);
+
+-- A signer has at most one request awaiting a decision per document
+CREATE UNIQUE INDEX idx_deadline_extensions_pending ON deadline_extensions(tenant_id, doc_id, signer_email)
+    WHERE status = 'pending';
+
+-- Step 2: tenant_id immutability trigger
+CREATE TRIGGER tr_deadline_extensions_tenant_id_immutable
+    BEFORE UPDATE ON deadline_extensions
+    FOR EACH ROW EXECUTE FUNCTION prevent_tenant_id_modification();
+
+-- Step 3: Enable Row Level Security
+ALTER TABLE deadline_extensions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE deadline_extensions FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS tenant_isolation_deadline_extensions ON deadline_extensions;
+CREATE POLICY tenant_isolation_deadline_extensions ON deadline_extensions
+    USING (tenant_id = current_tenant_id())
+    WITH CHECK (tenant_id = current_tenant_id());
+
+-- Step 4: Grant permissions to ackify_app role
+GRANT SELECT, INSERT, UPDATE ON deadline_extensions TO ackify_app;
+GRANT USAGE, SELECT ON SEQUENCE deadline_extensions_id_seq TO ackify_app;
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package certificate renders the sign-off certificate of a signature as a single page PDF,
+// with a QR code linking to its public verification, and tabular reports over several pages.
+package certificate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Data holds the facts printed on a certificate
+type Data struct {
+	Organisation string
+	DocID        string
+	DocTitle     string
+	DocChecksum  string
+	SignerName   string
+	SignerEmail  string
+	SignedAt     time.Time
+	SignatureID  int64
+	PayloadHash  string
+	RecordHash   string
+	Signature    string
+	VerifyURL    string
+}
+
+// Layout, in points
+const (
+	marginLeft  = 56.0
+	valueLeft   = 190.0
+	lineHeight  = 14.0
+	qrWidth     = 120.0
+	maxTitleRow = 4
+)
+
+// Characters per line in the value column, from the average Helvetica width and the fixed Courier width
+const (
+	valueChars     = 62
+	monospaceChars = 64
+	urlChars       = 72
+)
+
+// Render returns the PDF certificate
+func Render(d Data) ([]byte, error) {
+	code, err := EncodeQR(d.VerifyURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode verification link: %w", err)
+	}
+
+	var p page
+	y := pageHeight - 80
+
+	p.gray(0.1)
+	p.text(marginLeft, y, fontBold, 22, "Certificate of Acknowledgement")
+	if d.Organisation != "" {
+		y -= 20
+		p.text(marginLeft, y, fontRegular, 12, "Issued by "+d.Organisation)
+	}
+	y -= 16
+	p.line(marginLeft, y, pageWidth-marginLeft, y, 1)
+
+	y -= 28
+	for _, row := range wrap("This certificate attests that the signer below confirmed reading the document. "+
+		"The signature is recorded in a tamper-evident ledger and can be checked with the link or QR code below.", 90, 0) {
+		p.text(marginLeft, y, fontRegular, 10, row)
+		y -= lineHeight
+	}
+
+	signer := d.SignerEmail
+	if d.SignerName != "" {
+		signer = d.SignerName + " <" + d.SignerEmail + ">"
+	}
+	checksum := d.DocChecksum
+	if checksum == "" {
+		checksum = "-"
+	}
+
+	fields := []struct {
+		label     string
+		value     string
+		monospace bool
+		maxRows   int
+	}{
+		{"Document", d.DocTitle, false, maxTitleRow},
+		{"Document ID", d.DocID, true, 0},
+		{"Document checksum", checksum, true, 0},
+		{"Signer", signer, false, 0},
+		{"Signed at", d.SignedAt.UTC().Format("2006-01-02 15:04:05 UTC"), false, 0},
+		{"Signature ID", strconv.FormatInt(d.SignatureID, 10), true, 0},
+		{"Payload hash", d.PayloadHash, true, 0},
+		{"Record hash", d.RecordHash, true, 0},
+		{"Ed25519 signature", d.Signature, true, 0},
+	}
+
+	y -= 14
+	for _, field := range fields {
+		p.text(marginLeft, y, fontBold, 10, field.label)
+		f, size, width := fontRegular, 11.0, valueChars
+		if field.monospace {
+			f, size, width = fontMonospace, 9, monospaceChars
+		}
+		for _, row := range wrap(field.value, width, field.maxRows) {
+			p.text(valueLeft, y, f, size, row)
+			y -= lineHeight
+		}
+		y -= 6
+	}
+
+	y -= 10
+	p.line(marginLeft, y, pageWidth-marginLeft, y, 0.5)
+	y -= 24
+
+	p.gray(0)
+	p.qr(code, marginLeft, y, qrWidth/float64(code.Size))
+	p.gray(0.1)
+	p.text(valueLeft+6, y-14, fontBold, 11, "Scan to verify this certificate")
+	rowY := y - 32
+	for _, row := range wrap(d.VerifyURL, urlChars, 0) {
+		p.text(valueLeft+6, rowY, fontMonospace, 8, row)
+		rowY -= 11
+	}
+
+	p.text(marginLeft, 40, fontRegular, 8, "Generated by Ackify - Proof of Read")
+
+	return p.encode("Certificate - "+d.DocTitle, d.SignedAt)
+}
+
+// wrap splits s in rows of at most width characters, breaking at spaces when possible.
+// When maxRows is positive, extra rows are dropped and the last one ends with an ellipsis.
+func wrap(s string, width, maxRows int) []string {
+	var rows []string
+	for _, word := range strings.Fields(s) {
+		runes := []rune(word)
+		for len(runes) > 0 {
+			last := len(rows) - 1
+			if last >= 0 && len([]rune(rows[last]))+1+len(runes) <= width {
+				rows[last] += " " + string(runes)
+				break
+			}
+			n := min(len(runes), width)
+			rows = append(rows, string(runes[:n]))
+			runes = runes[n:]
+		}
+	}
+	if maxRows > 0 && len(rows) > maxRows {
+		rows = rows[:maxRows]
+		last := []rune(rows[maxRows-1])
+		rows[maxRows-1] = string(last[:min(len(last), width-3)]) + "..."
+	}
+	if len(rows) == 0 {
+		rows = []string{""}
+	}
+	return rows
+}
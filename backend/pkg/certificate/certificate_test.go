@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
+)
+
+func testData() Data {
+	return Data{
+		Organisation: "Acme",
+		DocID:        "policy-2026",
+		DocTitle:     "Security policy (2026)",
+		DocChecksum:  strings.Repeat("c", 64),
+		SignerName:   "Alice",
+		SignerEmail:  "alice@example.com",
+		SignedAt:     time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		SignatureID:  42,
+		PayloadHash:  strings.Repeat("a", 64),
+		RecordHash:   strings.Repeat("b", 64),
+		Signature:    strings.Repeat("s", 88),
+		VerifyURL:    "https://sign.example.com/api/v1/certificates/42/verify?hash=" + strings.Repeat("b", 64),
+	}
+}
+
+func TestRender(t *testing.T) {
+	pdf, err := Render(testData())
+	if err != nil {
+		t.Fatalf("Render err: %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("not a PDF file")
+	}
+
+	text := checksum.ExtractText("application/pdf", pdf)
+	for _, want := range []string{
+		"Certificate of Acknowledgement",
+		"Security policy (2026)",
+		"Alice <alice@example.com>",
+		"2026-03-01 09:30:00 UTC",
+		strings.Repeat("a", 64),
+		strings.Repeat("c", 64),
+		"Scan to verify this certificate",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the certificate text", want)
+		}
+	}
+
+	// Every cross-reference entry points at its object
+	start, err := strconv.Atoi(string(regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(pdf)[1]))
+	if err != nil || !bytes.HasPrefix(pdf[start:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	for i, m := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf, -1) {
+		offset, _ := strconv.Atoi(string(m[1]))
+		if !bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Errorf("xref entry %d points at the wrong offset", i+1)
+		}
+	}
+
+	again, _ := Render(testData())
+	if !bytes.Equal(pdf, again) {
+		t.Error("expected a reproducible rendering")
+	}
+}
+
+func TestRender_LinkTooLong(t *testing.T) {
+	d := testData()
+	d.VerifyURL = "https://sign.example.com/" + strings.Repeat("x", 300)
+	if _, err := Render(d); err == nil {
+		t.Error("expected an error for a link that does not fit in a QR code")
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	tests := map[string]string{
+		"plain":      "plain",
+		"a (b) \\ c": `a \(b\) \\ c`,
+		"Clémence":   `Cl\351mence`,
+		"€ 5":        `\200 5`,
+		"日本":         "??",
+	}
+	for in, want := range tests {
+		if got := escapeText(in); got != want {
+			t.Errorf("escapeText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if got := wrap("one two three", 7, 0); strings.Join(got, "|") != "one two|three" {
+		t.Errorf("unexpected rows %q", got)
+	}
+	if got := wrap(strings.Repeat("x", 10), 4, 0); strings.Join(got, "|") != "xxxx|xxxx|xx" {
+		t.Errorf("expected long words to be split, got %q", got)
+	}
+	if got := wrap("aa bb cc dd", 5, 1); len(got) != 1 || got[0] != "aa..." {
+		t.Errorf("expected truncation, got %q", got)
+	}
+	if got := wrap("", 5, 0); len(got) != 1 || got[0] != "" {
+		t.Errorf("expected a single empty row, got %q", got)
+	}
+}
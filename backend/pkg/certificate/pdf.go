@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page size in points
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+)
+
+// Standard PDF fonts, available in every reader without embedding
+type font string
+
+const (
+	fontRegular   font = "F1"
+	fontBold      font = "F2"
+	fontMonospace font = "F3"
+)
+
+var fontNames = map[font]string{
+	fontRegular:   "Helvetica",
+	fontBold:      "Helvetica-Bold",
+	fontMonospace: "Courier",
+}
+
+// page is a single PDF page built from content stream operators
+type page struct {
+	content bytes.Buffer
+}
+
+func (p *page) text(x, y float64, f font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", f, num(size), num(x), num(y), escapeText(s))
+}
+
+func (p *page) rect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(y), num(w), num(h))
+}
+
+func (p *page) line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+func (p *page) gray(level float64) {
+	fmt.Fprintf(&p.content, "%s g %s G\n", num(level), num(level))
+}
+
+// qr draws the QR code with its top left corner at (x, y), with a 4 module quiet zone
+// left to the caller
+func (p *page) qr(code *QRCode, x, y, moduleSize float64) {
+	for row := 0; row < code.Size; row++ {
+		for col := 0; col < code.Size; col++ {
+			if code.Modules[row][col] {
+				p.rect(x+float64(col)*moduleSize, y-float64(row+1)*moduleSize, moduleSize, moduleSize)
+			}
+		}
+	}
+}
+
+// encode serializes the page as a complete PDF file with a compressed content stream
+func (p *page) encode(title string, created time.Time) ([]byte, error) {
+	return encodePages([]*page{p}, title, created)
+}
+
+// encodePages serializes pages as a complete PDF file, each with a compressed content stream.
+// Objects are the catalog, the page tree and the fonts, then each page followed by its content.
+func encodePages(pages []*page, title string, created time.Time) ([]byte, error) {
+	fonts := ""
+	for _, f := range []font{fontRegular, fontBold, fontMonospace} {
+		fonts += fmt.Sprintf("/%s %d 0 R ", f, fontObject(f))
+	}
+
+	firstPage := fontObject(fontMonospace) + 1
+	kids := ""
+	for i := range pages {
+		kids += fmt.Sprintf("%d 0 R ", firstPage+2*i)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.TrimSpace(kids), len(pages)),
+	}
+	for _, f := range []font{fontRegular, fontBold, fontMonospace} {
+		objects = append(objects, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", fontNames[f]))
+	}
+	for i, p := range pages {
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(p.content.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+				num(pageWidth), num(pageHeight), fonts, firstPage+2*i+1),
+			fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.Bytes()),
+		)
+	}
+	objects = append(objects,
+		fmt.Sprintf("<< /Title (%s) /Producer (Ackify) /CreationDate (D:%s) >>", escapeText(title), created.UTC().Format("20060102150405Z")),
+	)
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, len(objects), xref)
+	return out.Bytes(), nil
+}
+
+// fontObject returns the object number of a font: fonts follow the catalog and the page tree
+func fontObject(f font) int {
+	switch f {
+	case fontBold:
+		return 4
+	case fontMonospace:
+		return 5
+	default:
+		return 3
+	}
+}
+
+// escapeText converts s to the WinAnsi encoding of the standard fonts and escapes it for a
+// literal string. Characters outside the encoding are replaced with '?'.
+func escapeText(s string) string {
+	var b bytes.Buffer
+	encoder := charmap.Windows1252.NewEncoder()
+	for _, r := range s {
+		encoded, err := encoder.Bytes([]byte(string(r)))
+		if err != nil || len(encoded) != 1 {
+			encoded = []byte{'?'}
+		}
+		switch c := encoded[0]; {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x7F:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// num formats a coordinate with at most two decimals
+func num(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
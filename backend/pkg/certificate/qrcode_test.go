@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQRReedSolomon_KnownVector(t *testing.T) {
+	// "HELLO WORLD" as version 1-M, from the ISO/IEC 18004 worked example
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	got := qrReedSolomonRemainder(data, qrReedSolomonDivisor(len(want)))
+	if !bytes.Equal(got, want) {
+		t.Errorf("unexpected error correction codewords %v, want %v", got, want)
+	}
+}
+
+func TestQRDataCodewords(t *testing.T) {
+	// Data capacities of level M from the specification
+	want := map[int]int{1: 16, 2: 28, 3: 44, 4: 64, 5: 86, 6: 108, 7: 124, 8: 154, 9: 182, 10: 216}
+	for version, codewords := range want {
+		if got := qrDataCodewords(version); got != codewords {
+			t.Errorf("version %d: got %d data codewords, want %d", version, got, codewords)
+		}
+	}
+}
+
+func TestEncodeQR_ReadsBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		version int
+	}{
+		{"short link", "https://sign.example.com/v/42", 3},
+		{"verification link", "https://sign.example.com/api/v1/certificates/123/verify?hash=" + strings.Repeat("ab", 32), 8},
+		{"long link", "https://sign.example.com/" + strings.Repeat("x", 160), 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qr, err := EncodeQR(tt.text)
+			if err != nil {
+				t.Fatalf("EncodeQR err: %v", err)
+			}
+			if qr.Size != tt.version*4+17 {
+				t.Fatalf("expected version %d, got size %d", tt.version, qr.Size)
+			}
+
+			mask := readFormatMask(t, qr)
+			if tt.version >= 7 {
+				checkVersionBits(t, qr, tt.version)
+			}
+
+			codewords := readCodewords(qr, tt.version, mask)
+			if len(codewords) != qrRawDataModules(tt.version)/8 {
+				t.Fatalf("read %d codewords", len(codewords))
+			}
+			data := deinterleave(t, codewords, tt.version)
+
+			// Byte mode header, count and payload
+			if data[0]>>4 != 0x4 {
+				t.Fatalf("unexpected mode %x", data[0]>>4)
+			}
+			var bits qrBitBuffer
+			for _, b := range data {
+				bits.append(int(b), 8)
+			}
+			count := readBits(bits[4:], qrCountBits(tt.version))
+			offset := 4 + qrCountBits(tt.version)
+			payload := make([]byte, count)
+			for i := range payload {
+				payload[i] = byte(readBits(bits[offset+8*i:], 8))
+			}
+			if string(payload) != tt.text {
+				t.Errorf("read back %q, want %q", payload, tt.text)
+			}
+		})
+	}
+}
+
+func TestEncodeQR_TooLong(t *testing.T) {
+	if _, err := EncodeQR(strings.Repeat("x", 214)); !errors.Is(err, ErrQRTooLong) {
+		t.Errorf("expected ErrQRTooLong, got %v", err)
+	}
+}
+
+// readFormatMask decodes both copies of the format information and returns the mask
+func readFormatMask(t *testing.T, qr *QRCode) int {
+	t.Helper()
+	m := qr.Modules
+	first, second := 0, 0
+	bitsFirst := []bool{m[0][8], m[1][8], m[2][8], m[3][8], m[4][8], m[5][8], m[7][8], m[8][8], m[8][7], m[8][5], m[8][4], m[8][3], m[8][2], m[8][1], m[8][0]}
+	for i, dark := range bitsFirst {
+		if dark {
+			first |= 1 << i
+		}
+	}
+	for i := 0; i < 8; i++ {
+		if m[8][qr.Size-1-i] {
+			second |= 1 << i
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if m[qr.Size-15+i][8] {
+			second |= 1 << i
+		}
+	}
+	if first != second {
+		t.Fatalf("format copies differ: %015b vs %015b", first, second)
+	}
+	if !m[qr.Size-8][8] {
+		t.Fatal("dark module missing")
+	}
+
+	// Format strings of level M for each mask, from the specification
+	formats := []int{0x5412, 0x5125, 0x5E7C, 0x5B4B, 0x45F9, 0x40CE, 0x4F97, 0x4AA0}
+	for mask, format := range formats {
+		if first == format {
+			return mask
+		}
+	}
+	t.Fatalf("invalid format information %015b", first)
+	return 0
+}
+
+// checkVersionBits compares both copies of the version information with the specification
+func checkVersionBits(t *testing.T, qr *QRCode, version int) {
+	t.Helper()
+	want := map[int]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3}[version]
+	for i := 0; i < 18; i++ {
+		a, b := qr.Size-11+i%3, i/3
+		dark := (want>>i)&1 != 0
+		if qr.Modules[b][a] != dark || qr.Modules[a][b] != dark {
+			t.Fatalf("version information mismatch at bit %d", i)
+		}
+	}
+}
+
+// readCodewords reads the data area in zigzag order and removes the mask
+func readCodewords(qr *QRCode, version, mask int) []byte {
+	ref := newQRCode(version)
+	ref.drawFunctionPatterns()
+
+	var bits qrBitBuffer
+	for right := qr.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < qr.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = qr.Size - 1 - vert
+				}
+				if ref.isFunction[y][x] {
+					continue
+				}
+				dark := qr.Modules[y][x] != qrMaskBit(mask, x, y)
+				bits = append(bits, dark)
+			}
+		}
+	}
+
+	out := make([]byte, len(bits)/8)
+	for i := range out {
+		out[i] = byte(readBits(bits[8*i:], 8))
+	}
+	return out
+}
+
+// deinterleave splits the codewords back in blocks, checks their error correction and
+// returns the data codewords
+func deinterleave(t *testing.T, codewords []byte, version int) []byte {
+	t.Helper()
+	numBlocks := qrNumBlocks[version]
+	eccLen := qrECCPerBlock[version]
+	numShortBlocks := numBlocks - len(codewords)%numBlocks
+	shortDataLen := len(codewords)/numBlocks - eccLen
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortDataLen+1; i++ {
+		for j := range blocks {
+			if i == shortDataLen && j < numShortBlocks {
+				continue
+			}
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+	eccs := make([][]byte, numBlocks)
+	for i := 0; i < eccLen; i++ {
+		for j := range eccs {
+			eccs[j] = append(eccs[j], codewords[k])
+			k++
+		}
+	}
+
+	divisor := qrReedSolomonDivisor(eccLen)
+	var data []byte
+	for j, block := range blocks {
+		if !bytes.Equal(qrReedSolomonRemainder(block, divisor), eccs[j]) {
+			t.Fatalf("block %d: error correction mismatch", j)
+		}
+		data = append(data, block...)
+	}
+	return data
+}
+
+func readBits(bits qrBitBuffer, n int) int {
+	v := 0
+	for _, bit := range bits[:n] {
+		v <<= 1
+		if bit {
+			v |= 1
+		}
+	}
+	return v
+}
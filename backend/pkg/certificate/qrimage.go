@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// qrQuietZone is the light border, in modules, scanners need around a QR code
+const qrQuietZone = 4
+
+// SVG returns the QR code as an SVG image of moduleSize pixels per module, quiet zone included
+func (qr *QRCode) SVG(moduleSize int) []byte {
+	side := (qr.Size + 2*qrQuietZone) * moduleSize
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		side, side, qr.Size+2*qrQuietZone, qr.Size+2*qrQuietZone)
+	b.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			if qr.Modules[y][x] {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+qrQuietZone, y+qrQuietZone)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.Bytes()
+}
+
+// PNG returns the QR code as a black and white PNG image of moduleSize pixels per module,
+// quiet zone included
+func (qr *QRCode) PNG(moduleSize int) ([]byte, error) {
+	side := (qr.Size + 2*qrQuietZone) * moduleSize
+	img := image.NewPaletted(image.Rect(0, 0, side, side), color.Palette{color.White, color.Black})
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			if !qr.Modules[y][x] {
+				continue
+			}
+			left, top := (x+qrQuietZone)*moduleSize, (y+qrQuietZone)*moduleSize
+			for py := top; py < top+moduleSize; py++ {
+				for px := left; px < left+moduleSize; px++ {
+					img.SetColorIndex(px, py, 1)
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"bytes"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestQRCode_PNG(t *testing.T) {
+	qr, err := EncodeQR("https://sign.example.com/s/Ab3dEf7h")
+	if err != nil {
+		t.Fatalf("EncodeQR err: %v", err)
+	}
+	data, err := qr.PNG(4)
+	if err != nil {
+		t.Fatalf("PNG err: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+
+	side := (qr.Size + 2*qrQuietZone) * 4
+	if img.Bounds().Dx() != side || img.Bounds().Dy() != side {
+		t.Fatalf("expected a %dpx image, got %v", side, img.Bounds())
+	}
+	dark := func(x, y int) bool {
+		r, _, _, _ := img.At(x, y).RGBA()
+		return r == 0
+	}
+	if dark(0, 0) {
+		t.Error("expected a light quiet zone")
+	}
+	// Every module is drawn at its place, the top left finder pattern first
+	for y := 0; y < qr.Size; y++ {
+		for x := 0; x < qr.Size; x++ {
+			if got := dark((x+qrQuietZone)*4+2, (y+qrQuietZone)*4+2); got != qr.Modules[y][x] {
+				t.Fatalf("module (%d, %d): expected dark=%v", x, y, qr.Modules[y][x])
+			}
+		}
+	}
+}
+
+func TestQRCode_SVG(t *testing.T) {
+	qr, err := EncodeQR("https://sign.example.com/s/Ab3dEf7h")
+	if err != nil {
+		t.Fatalf("EncodeQR err: %v", err)
+	}
+	svg := string(qr.SVG(8))
+
+	side := (qr.Size + 2*qrQuietZone) * 8
+	if !strings.HasPrefix(svg, "<svg ") || !strings.Contains(svg, `width="`+strconv.Itoa(side)+`"`) {
+		t.Fatalf("unexpected SVG header: %.120s", svg)
+	}
+	dark := 0
+	for _, row := range qr.Modules {
+		for _, m := range row {
+			if m {
+				dark++
+			}
+		}
+	}
+	if got := strings.Count(svg, "h1v1h-1z"); got != dark {
+		t.Errorf("expected %d dark modules, got %d", dark, got)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"fmt"
+	"time"
+)
+
+// Report is a tabular report, rendered over as many pages as its tables need
+type Report struct {
+	Organisation string
+	Title        string
+	Summary      []string // Lines printed under the title
+	GeneratedAt  time.Time
+	Tables       []Table
+}
+
+// Table is a titled table whose header is repeated on each page it spans
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    [][]string
+}
+
+// Column is a table column; cells longer than its width are cut with an ellipsis
+type Column struct {
+	Header string
+	Width  int // Characters
+}
+
+// Report layout, in points
+const (
+	reportMargin     = 40.0
+	reportBottom     = 56.0
+	reportRowHeight  = 12.0
+	reportCellSize   = 8.0
+	reportCharWidth  = 4.3 // Average Helvetica width at reportCellSize
+	reportTableSpace = 24.0
+)
+
+// reportWriter lays the report out, starting a page whenever the current one is full
+type reportWriter struct {
+	pages []*page
+	p     *page
+	y     float64
+}
+
+func (w *reportWriter) newPage() {
+	w.p = &page{}
+	w.p.gray(0.1)
+	w.pages = append(w.pages, w.p)
+	w.y = pageHeight - reportMargin - 16
+}
+
+// reserve starts a new page unless height fits above the footer, and reports whether it did
+func (w *reportWriter) reserve(height float64) bool {
+	if w.y-height >= reportBottom {
+		return false
+	}
+	w.newPage()
+	return true
+}
+
+func (w *reportWriter) row(columns []Column, cells []string, f font) {
+	x := reportMargin
+	for i, column := range columns {
+		if i < len(cells) && cells[i] != "" {
+			if rows := wrap(cells[i], column.Width-1, 1); len(rows) > 0 {
+				w.p.text(x, w.y, f, reportCellSize, rows[0])
+			}
+		}
+		x += float64(column.Width) * reportCharWidth
+	}
+	w.y -= reportRowHeight
+}
+
+func (w *reportWriter) header(t Table) {
+	w.row(t.Columns, headers(t.Columns), fontBold)
+	w.p.line(reportMargin, w.y+reportRowHeight-3, pageWidth-reportMargin, w.y+reportRowHeight-3, 0.5)
+}
+
+func headers(columns []Column) []string {
+	cells := make([]string, len(columns))
+	for i, column := range columns {
+		cells[i] = column.Header
+	}
+	return cells
+}
+
+// RenderReport returns the PDF of a report, with the page number in the footer of each page
+func RenderReport(r Report) ([]byte, error) {
+	w := &reportWriter{}
+	w.newPage()
+
+	w.p.text(reportMargin, w.y, fontBold, 18, r.Title)
+	w.y -= 18
+	if r.Organisation != "" {
+		w.p.text(reportMargin, w.y, fontRegular, 11, r.Organisation)
+		w.y -= 16
+	}
+	for _, line := range r.Summary {
+		w.p.text(reportMargin, w.y, fontRegular, 10, line)
+		w.y -= lineHeight
+	}
+	w.y -= 4
+	w.p.line(reportMargin, w.y, pageWidth-reportMargin, w.y, 1)
+	w.y -= reportTableSpace
+
+	for _, t := range r.Tables {
+		// Keep the title with the header and first row
+		w.reserve(3 * reportRowHeight)
+		w.p.text(reportMargin, w.y, fontBold, 12, t.Title)
+		w.y -= 18
+		w.header(t)
+		if len(t.Rows) == 0 {
+			w.p.text(reportMargin, w.y, fontRegular, reportCellSize, "None")
+			w.y -= reportRowHeight
+		}
+		for _, cells := range t.Rows {
+			if w.reserve(reportRowHeight) {
+				w.p.text(reportMargin, w.y, fontBold, 10, t.Title+" (continued)")
+				w.y -= 16
+				w.header(t)
+			}
+			w.row(t.Columns, cells, fontRegular)
+		}
+		w.y -= reportTableSpace
+	}
+
+	generated := "Generated by Ackify on " + r.GeneratedAt.UTC().Format("2006-01-02 15:04 UTC")
+	for i, p := range w.pages {
+		p.text(reportMargin, 32, fontRegular, 8, generated)
+		p.text(pageWidth-reportMargin-60, 32, fontRegular, 8, fmt.Sprintf("Page %d of %d", i+1, len(w.pages)))
+	}
+
+	return encodePages(w.pages, r.Title, r.GeneratedAt)
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/checksum"
+)
+
+func testReport(rows int) Report {
+	table := Table{
+		Title:   "Documents",
+		Columns: []Column{{Header: "Document", Width: 40}, {Header: "Expected", Width: 10}},
+	}
+	for i := 0; i < rows; i++ {
+		table.Rows = append(table.Rows, []string{fmt.Sprintf("doc-%03d", i), "3"})
+	}
+	return Report{
+		Organisation: "Acme",
+		Title:        "Compliance report 2024-Q4",
+		Summary:      []string{"Period: 2024-10-01 to 2024-12-31"},
+		GeneratedAt:  time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC),
+		Tables:       []Table{table, {Title: "Outstanding signers", Columns: []Column{{Header: "Email", Width: 40}}}},
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	pdf, err := RenderReport(testReport(3))
+	if err != nil {
+		t.Fatalf("RenderReport err: %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.Contains(pdf, []byte("/Count 1 ")) {
+		t.Fatal("expected a single page PDF")
+	}
+
+	text := checksum.ExtractText("application/pdf", pdf)
+	for _, want := range []string{"Compliance report 2024-Q4", "Acme", "doc-002", "Outstanding signers", "None", "Page 1 of 1"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the report text", want)
+		}
+	}
+}
+
+func TestRenderReport_Pages(t *testing.T) {
+	pdf, err := RenderReport(testReport(150))
+	if err != nil {
+		t.Fatalf("RenderReport err: %v", err)
+	}
+	if !bytes.Contains(pdf, []byte("/Count 3 ")) {
+		t.Fatal("expected the rows to span 3 pages")
+	}
+
+	text := checksum.ExtractText("application/pdf", pdf)
+	for _, want := range []string{"doc-000", "doc-149", "Documents (continued)", "Page 3 of 3"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in the report text", want)
+		}
+	}
+}
+
+func TestReportRow_CutsLongCells(t *testing.T) {
+	w := &reportWriter{}
+	w.newPage()
+	w.row([]Column{{Header: "Title", Width: 10}}, []string{strings.Repeat("x", 30)}, fontRegular)
+	if strings.Contains(w.p.content.String(), strings.Repeat("x", 10)) {
+		t.Error("expected the cell to be cut to the column width")
+	}
+}
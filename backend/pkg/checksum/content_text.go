@@ -0,0 +1,366 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package checksum
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxInflatedStreamBytes bounds the size of a single decompressed PDF stream
+const maxInflatedStreamBytes = 32 * 1024 * 1024
+
+var (
+	htmlSkippedBlockRe = regexp.MustCompile(`(?is)<(script|style|noscript|template)\b[^>]*>.*?</(script|style|noscript|template)\s*>`)
+	htmlCommentRe      = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlTagRe          = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// isTextExtractable reports whether canonical text can be extracted from the content type
+func isTextExtractable(contentType string) bool {
+	switch baseContentType(contentType) {
+	case "application/pdf", "text/html", "application/xhtml+xml":
+		return true
+	}
+	return false
+}
+
+// ComputeContentHash extracts the canonical text of a PDF or HTML document and returns
+// the SHA-256 of the normalized text. Returns an empty string when the content type is
+// not supported or no text could be extracted.
+func ComputeContentHash(contentType string, data []byte) string {
+	text := ExtractText(contentType, data)
+	if text == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// ExtractText returns the normalized text content of a PDF or HTML document.
+// When the content type is empty, the format is detected from the payload.
+func ExtractText(contentType string, data []byte) string {
+	base := baseContentType(contentType)
+	if base == "" {
+		switch {
+		case bytes.HasPrefix(data, []byte("%PDF-")):
+			base = "application/pdf"
+		case looksLikeHTML(data):
+			base = "text/html"
+		}
+	}
+
+	switch base {
+	case "application/pdf":
+		return normalizeText(extractPDFText(data))
+	case "text/html", "application/xhtml+xml":
+		return normalizeText(extractHTMLText(data))
+	}
+	return ""
+}
+
+// normalizeText applies Unicode NFC normalization and collapses whitespace runs
+// so that layout-only differences do not change the content hash
+func normalizeText(text string) string {
+	return strings.Join(strings.FieldsFunc(norm.NFC.String(text), unicode.IsSpace), " ")
+}
+
+func baseContentType(contentType string) string {
+	return strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+}
+
+func looksLikeHTML(data []byte) bool {
+	head := bytes.ToLower(bytes.TrimSpace(data[:min(len(data), 512)]))
+	return bytes.HasPrefix(head, []byte("<!doctype html")) || bytes.HasPrefix(head, []byte("<html"))
+}
+
+// extractHTMLText strips markup, scripts and styles and decodes entities
+func extractHTMLText(data []byte) string {
+	text := htmlCommentRe.ReplaceAllString(string(data), " ")
+	text = htmlSkippedBlockRe.ReplaceAllString(text, " ")
+	text = htmlTagRe.ReplaceAllString(text, " ")
+	return html.UnescapeString(text)
+}
+
+// extractPDFText collects the strings shown by text operators in the PDF content streams.
+// Only unfiltered and FlateDecode streams are inspected; image and font streams never
+// contain text operators. Strings are taken as encoded in the file, which is stable
+// across re-serializations that keep the fonts.
+func extractPDFText(data []byte) string {
+	var out strings.Builder
+	pos := 0
+	for {
+		start := indexStreamKeyword(data, pos)
+		if start < 0 {
+			break
+		}
+		bodyStart := start + len("stream")
+		if bodyStart < len(data) && data[bodyStart] == '\r' {
+			bodyStart++
+		}
+		if bodyStart < len(data) && data[bodyStart] == '\n' {
+			bodyStart++
+		}
+		end := bytes.Index(data[bodyStart:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		body := data[bodyStart : bodyStart+end]
+		pos = bodyStart + end + len("endstream")
+
+		dict := streamDictionary(data[:start])
+		if bytes.Contains(dict, []byte("/Subtype/Image")) || bytes.Contains(dict, []byte("/Subtype /Image")) {
+			continue
+		}
+		content, ok := decodePDFStream(dict, body)
+		if !ok {
+			continue
+		}
+		out.WriteString(extractContentStreamText(content))
+		out.WriteByte(' ')
+	}
+	return out.String()
+}
+
+// indexStreamKeyword finds the next "stream" keyword that is not part of "endstream"
+func indexStreamKeyword(data []byte, from int) int {
+	for from < len(data) {
+		i := bytes.Index(data[from:], []byte("stream"))
+		if i < 0 {
+			return -1
+		}
+		i += from
+		if i < 3 || !bytes.Equal(data[i-3:i], []byte("end")) {
+			return i
+		}
+		from = i + len("stream")
+	}
+	return -1
+}
+
+// streamDictionary returns the dictionary that precedes a stream keyword
+func streamDictionary(before []byte) []byte {
+	i := bytes.LastIndex(before, []byte("obj"))
+	if i < 0 {
+		return nil
+	}
+	return before[i:]
+}
+
+// streamFilters returns the /Filter value of a stream dictionary without whitespace
+func streamFilters(dict []byte) string {
+	i := bytes.Index(dict, []byte("/Filter"))
+	if i < 0 {
+		return ""
+	}
+	rest := bytes.TrimLeft(dict[i+len("/Filter"):], " \r\n\t")
+	var value []byte
+	if bytes.HasPrefix(rest, []byte("[")) {
+		end := bytes.IndexByte(rest, ']')
+		if end < 0 {
+			return "?"
+		}
+		value = rest[1:end]
+	} else if bytes.HasPrefix(rest, []byte("/")) {
+		end := 1
+		for end < len(rest) && !isPDFWhitespace(rest[end]) && !isPDFDelimiter(rest[end]) {
+			end++
+		}
+		value = rest[:end]
+	} else {
+		return "?"
+	}
+	return strings.Join(strings.Fields(string(value)), "")
+}
+
+func decodePDFStream(dict, body []byte) ([]byte, bool) {
+	switch streamFilters(dict) {
+	case "":
+		return body, true
+	case "/FlateDecode":
+	default:
+		// Chained or unsupported filters (DCT, LZW, ASCII85...)
+		return nil, false
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, false
+	}
+	defer zr.Close()
+	inflated, err := io.ReadAll(io.LimitReader(zr, maxInflatedStreamBytes))
+	if err != nil && len(inflated) == 0 {
+		return nil, false
+	}
+	return inflated, true
+}
+
+// extractContentStreamText tokenizes a content stream and keeps the operands of
+// the Tj, TJ, ' and " operators inside BT/ET blocks
+func extractContentStreamText(content []byte) string {
+	var out strings.Builder
+	var operands []string
+	inText := false
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case isPDFWhitespace(c):
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			s, next := readLiteralString(content, i)
+			operands = append(operands, s)
+			i = next
+		case c == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2
+		case c == '>' && i+1 < len(content) && content[i+1] == '>':
+			i += 2
+		case c == '<':
+			s, next := readHexString(content, i)
+			operands = append(operands, s)
+			i = next
+		case c == '[' || c == ']':
+			i++
+		default:
+			start := i
+			for i < len(content) && !isPDFWhitespace(content[i]) && !isPDFDelimiter(content[i]) {
+				i++
+			}
+			if i == start {
+				i++
+				continue
+			}
+			switch token := string(content[start:i]); token {
+			case "BT":
+				inText = true
+				operands = operands[:0]
+			case "ET":
+				inText = false
+				out.WriteByte(' ')
+				operands = operands[:0]
+			case "Tj", "TJ", "'", "\"":
+				if inText {
+					for _, s := range operands {
+						out.WriteString(s)
+					}
+				}
+				operands = operands[:0]
+			case "Td", "TD", "T*", "Tm":
+				if inText {
+					out.WriteByte(' ')
+				}
+				operands = operands[:0]
+			default:
+				if !isPDFNumber(token) {
+					operands = operands[:0]
+				}
+			}
+		}
+	}
+	return out.String()
+}
+
+func readLiteralString(content []byte, i int) (string, int) {
+	var sb strings.Builder
+	depth := 0
+	for i < len(content) {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			switch e := content[i]; e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'b', 'f':
+				// Backspace and form feed carry no text
+			case '\r', '\n':
+				// Line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					for n := 0; n < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; n++ {
+						v = v*8 + int(content[i]-'0')
+						i++
+					}
+					sb.WriteByte(byte(v))
+					continue
+				}
+				sb.WriteByte(e)
+			}
+			i++
+		case c == '(':
+			if depth > 0 {
+				sb.WriteByte(c)
+			}
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+			if depth == 0 {
+				return sb.String(), i
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String(), i
+}
+
+func readHexString(content []byte, i int) (string, int) {
+	end := bytes.IndexByte(content[i:], '>')
+	if end < 0 {
+		return "", len(content)
+	}
+	digits := make([]byte, 0, end)
+	for _, c := range content[i+1 : i+end] {
+		if !isPDFWhitespace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	decoded := make([]byte, len(digits)/2)
+	if _, err := hex.Decode(decoded, digits); err != nil {
+		return "", i + end + 1
+	}
+	return string(decoded), i + end + 1
+}
+
+func isPDFWhitespace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func isPDFNumber(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, c := range token {
+		if (c < '0' || c > '9') && c != '.' && c != '-' && c != '+' {
+			return false
+		}
+	}
+	return true
+}
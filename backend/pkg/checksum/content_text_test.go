@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package checksum
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// buildTestPDF assembles a minimal PDF with one content stream and the given Info title
+func buildTestPDF(t *testing.T, content, title string, compress bool) []byte {
+	t.Helper()
+
+	stream := []byte(content)
+	filter := ""
+	if compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		if _, err := zw.Write(stream); err != nil {
+			t.Fatalf("Failed to compress stream: %v", err)
+		}
+		zw.Close()
+		stream = buf.Bytes()
+		filter = " /Filter /FlateDecode"
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	pdf.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	pdf.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+	pdf.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>\nendobj\n")
+	fmt.Fprintf(&pdf, "4 0 obj\n<< /Length %d%s >>\nstream\n", len(stream), filter)
+	pdf.Write(stream)
+	pdf.WriteString("\nendstream\nendobj\n")
+	fmt.Fprintf(&pdf, "5 0 obj\n<< /Title (%s) >>\nendobj\n", title)
+	pdf.WriteString("trailer\n<< /Root 1 0 R /Info 5 0 R >>\n%%EOF\n")
+	return pdf.Bytes()
+}
+
+func TestExtractText_PDF(t *testing.T) {
+	content := "BT /F1 12 Tf 72 712 Td (Hello, \\(PDF\\) World!) Tj 0 -14 Td [(Sec) -250 (ond)] TJ ET"
+
+	tests := []struct {
+		name     string
+		compress bool
+	}{
+		{"uncompressed stream", false},
+		{"flate stream", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := ExtractText("application/pdf", buildTestPDF(t, content, "Policy", tt.compress))
+			if text != "Hello, (PDF) World! Second" {
+				t.Errorf("Unexpected text %q", text)
+			}
+		})
+	}
+}
+
+func TestExtractText_PDFHexStrings(t *testing.T) {
+	pdf := buildTestPDF(t, "BT /F1 12 Tf <48656C6C6F> Tj ET", "", false)
+
+	if text := ExtractText("application/pdf", pdf); text != "Hello" {
+		t.Errorf("Expected Hello, got %q", text)
+	}
+}
+
+func TestExtractText_HTML(t *testing.T) {
+	page := `<!DOCTYPE html>
+<html><head><title>Policy</title><style>body { color: red; }</style>
+<script>var tracking = "ignored";</script></head>
+<body><!-- build 42 --><h1>Security   Policy</h1>
+<p>Caf&eacute; &amp; tea</p></body></html>`
+
+	text := ExtractText("text/html; charset=utf-8", []byte(page))
+	if text != "Policy Security Policy Café & tea" {
+		t.Errorf("Unexpected text %q", text)
+	}
+}
+
+func TestExtractText_Unsupported(t *testing.T) {
+	if text := ExtractText("image/png", []byte("\x89PNG")); text != "" {
+		t.Errorf("Expected no text for images, got %q", text)
+	}
+}
+
+func TestExtractText_SniffsFormat(t *testing.T) {
+	pdf := buildTestPDF(t, "BT (Sniffed) Tj ET", "", true)
+
+	if text := ExtractText("", pdf); text != "Sniffed" {
+		t.Errorf("Expected PDF to be sniffed, got %q", text)
+	}
+	if text := ExtractText("", []byte("<html><body>Page</body></html>")); text != "Page" {
+		t.Errorf("Expected HTML to be sniffed, got %q", text)
+	}
+}
+
+func TestComputeContentHash_IgnoresReserialization(t *testing.T) {
+	content := "BT /F1 12 Tf 72 712 Td (Terms of service) Tj ET"
+	original := buildTestPDF(t, content, "Draft", false)
+	resaved := buildTestPDF(t, content, "Final version", true)
+
+	if bytes.Equal(original, resaved) {
+		t.Fatal("Expected different bytes for test setup")
+	}
+
+	originalHash := ComputeContentHash("application/pdf", original)
+	if originalHash == "" {
+		t.Fatal("Expected content hash, got empty string")
+	}
+	if resavedHash := ComputeContentHash("application/pdf", resaved); resavedHash != originalHash {
+		t.Errorf("Expected identical content hash, got %s and %s", originalHash, resavedHash)
+	}
+
+	modified := buildTestPDF(t, "BT /F1 12 Tf 72 712 Td (Terms of services) Tj ET", "Draft", false)
+	if ComputeContentHash("application/pdf", modified) == originalHash {
+		t.Error("Expected content hash to change when text changes")
+	}
+}
+
+func TestComputeContentHash_NoText(t *testing.T) {
+	if hash := ComputeContentHash("application/pdf", []byte("%PDF-1.4\n%%EOF\n")); hash != "" {
+		t.Errorf("Expected empty hash for PDF without text, got %s", hash)
+	}
+}
+
+func TestComputeRemoteChecksum_ContentHash(t *testing.T) {
+	pdf := buildTestPDF(t, "BT (Remote document) Tj ET", "Remote", true)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdf)))
+		if r.Method == "GET" {
+			w.Write(pdf)
+		}
+	}))
+	defer server.Close()
+
+	opts := DefaultOptions()
+	opts.SkipSSRFCheck = true
+	opts.InsecureSkipVerify = true
+	result, err := ComputeRemoteChecksum(context.Background(), server.URL, opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result == nil {
+		t.Fatal("Expected result, got nil")
+	}
+
+	if expected := ComputeContentHash("application/pdf", pdf); result.ContentHashHex != expected {
+		t.Errorf("Expected content hash %s, got %s", expected, result.ContentHashHex)
+	}
+}
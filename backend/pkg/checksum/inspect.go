@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package checksum
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+// Errors returned by Inspect, so that callers can tell a rejected URL from an unreachable one
+var (
+	ErrURLNotAllowed          = errors.New("URL must use HTTPS and point to a public host")
+	ErrContentTypeNotAllowed  = errors.New("content type not allowed")
+	ErrTooLarge               = errors.New("file exceeds the maximum size")
+	ErrFetchFailed            = errors.New("failed to fetch the file")
+	errPrivateAddressRejected = errors.New("connection to a private address rejected")
+)
+
+// Inspection describes a remote file fetched by Inspect
+type Inspection struct {
+	URL         string `json:"url"`
+	SHA256      string `json:"sha256"`
+	SHA512      string `json:"sha512"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// Inspect downloads a remote file with a single streamed GET and computes its SHA-256 and SHA-512.
+// Unlike ComputeRemoteChecksum it reports why a file was rejected. Besides the host check, every
+// connection is checked at dial time, so a host resolving to a private address after the check
+// (DNS rebinding) or a redirect to one is rejected as well.
+func Inspect(ctx context.Context, urlStr string, opts ComputeOptions) (*Inspection, error) {
+	urlStr = strings.TrimSpace(urlStr)
+	if !isValidURL(urlStr) {
+		return nil, ErrURLNotAllowed
+	}
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil || parsedURL.Hostname() == "" {
+		return nil, ErrURLNotAllowed
+	}
+	if !opts.SkipSSRFCheck && isBlockedHost(parsedURL.Hostname()) {
+		logger.Logger.Warn("Checksum: SSRF protection - blocked internal/private host", "host", parsedURL.Hostname())
+		return nil, ErrURLNotAllowed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, ErrURLNotAllowed
+	}
+	req.Header.Set("User-Agent", "Ackify-Checksum/1.0")
+
+	resp, err := newInspectClient(opts).Do(req)
+	if err != nil {
+		if errors.Is(err, ErrURLNotAllowed) || errors.Is(err, errPrivateAddressRejected) {
+			logger.Logger.Warn("Checksum: SSRF protection - blocked connection", "url", urlStr, "error", err.Error())
+			return nil, ErrURLNotAllowed
+		}
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: HTTP status %d", ErrFetchFailed, resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" || !isAllowedContentType(contentType, opts.AllowedContentType) {
+		return nil, fmt.Errorf("%w: %q", ErrContentTypeNotAllowed, contentType)
+	}
+	if resp.ContentLength > opts.MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	sha256Hasher, sha512Hasher := sha256.New(), sha512.New()
+	written, err := io.Copy(io.MultiWriter(sha256Hasher, sha512Hasher), io.LimitReader(resp.Body, opts.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFetchFailed, err)
+	}
+	if written > opts.MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	logger.Logger.Info("Checksum: Remote file inspected", "url", urlStr, "content_type", contentType, "bytes", written)
+	return &Inspection{
+		URL:         resp.Request.URL.String(),
+		SHA256:      hex.EncodeToString(sha256Hasher.Sum(nil)),
+		SHA512:      hex.EncodeToString(sha512Hasher.Sum(nil)),
+		ContentType: strings.TrimSpace(strings.Split(contentType, ";")[0]),
+		Size:        written,
+	}, nil
+}
+
+// newInspectClient returns an HTTP client refusing redirects to non HTTPS URLs and connections
+// to private addresses
+func newInspectClient(opts ComputeOptions) *http.Client {
+	dialer := &net.Dialer{Timeout: time.Duration(opts.TimeoutMs) * time.Millisecond}
+	if !opts.SkipSSRFCheck {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+				return fmt.Errorf("%w: %s", errPrivateAddressRejected, host)
+			}
+			return nil
+		}
+	}
+
+	transport := &http.Transport{
+		Proxy:               nil, // A proxy would dial on our behalf and bypass the address check
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: time.Duration(opts.TimeoutMs) * time.Millisecond,
+	}
+	if opts.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &http.Client{
+		Timeout:   time.Duration(opts.TimeoutMs) * time.Millisecond,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= opts.MaxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+			if !isValidURL(req.URL.String()) {
+				return ErrURLNotAllowed
+			}
+			if !opts.SkipSSRFCheck && isBlockedHost(req.URL.Hostname()) {
+				return fmt.Errorf("%w: redirect to %s", ErrURLNotAllowed, req.URL.Hostname())
+			}
+			return nil
+		},
+	}
+}
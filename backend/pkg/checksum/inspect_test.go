@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package checksum
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testInspectOptions() ComputeOptions {
+	opts := DefaultOptions()
+	opts.SkipSSRFCheck = true      // For testing with httptest
+	opts.InsecureSkipVerify = true // Accept self-signed certs
+	return opts
+}
+
+func TestInspect_Success(t *testing.T) {
+	content := "Hello, World!"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf; charset=binary")
+		w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	inspection, err := Inspect(context.Background(), server.URL, testInspectOptions())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if inspection.SHA256 != "dffd6021bb2bd5b0af676290809ec3a53191dd81c7f70a4b28688a362182986f" {
+		t.Errorf("Unexpected SHA-256 %s", inspection.SHA256)
+	}
+	if !strings.HasPrefix(inspection.SHA512, "374d794a95cdcfd8b35993185fef9ba368f160d8daf432d08ba9f1ed1e5abe6c") {
+		t.Errorf("Unexpected SHA-512 %s", inspection.SHA512)
+	}
+	if inspection.ContentType != "application/pdf" || inspection.Size != int64(len(content)) {
+		t.Errorf("Unexpected inspection %+v", inspection)
+	}
+}
+
+func TestInspect_Rejections(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case "/large":
+			// No Content-Length: the limit applies while streaming
+			w.Header().Set("Content-Type", "application/pdf")
+			w.(http.Flusher).Flush()
+			w.Write(make([]byte, 2048))
+		case "/redirect":
+			http.Redirect(w, r, "http://example.com/file.pdf", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	opts := testInspectOptions()
+	opts.MaxBytes = 1024
+
+	tests := []struct {
+		name string
+		url  string
+		want error
+	}{
+		{"not HTTPS", "http://example.com/file.pdf", ErrURLNotAllowed},
+		{"content type", server.URL + "/page", ErrContentTypeNotAllowed},
+		{"too large", server.URL + "/large", ErrTooLarge},
+		{"redirect to HTTP", server.URL + "/redirect", ErrURLNotAllowed},
+		{"HTTP error", server.URL + "/missing", ErrFetchFailed},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Inspect(context.Background(), tc.url, opts); !errors.Is(err, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestInspect_BlocksPrivateAddresses(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF"))
+	}))
+	defer server.Close()
+
+	opts := testInspectOptions()
+	opts.SkipSSRFCheck = false
+	if _, err := Inspect(context.Background(), server.URL, opts); !errors.Is(err, ErrURLNotAllowed) {
+		t.Errorf("Expected ErrURLNotAllowed for a loopback server, got %v", err)
+	}
+
+	// The dial-time check also applies when the host check is passed
+	client := newInspectClient(opts)
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("Expected the connection to a loopback address to be rejected")
+	}
+	if !errors.Is(err, errPrivateAddressRejected) {
+		t.Errorf("Expected errPrivateAddressRejected, got %v", err)
+	}
+}
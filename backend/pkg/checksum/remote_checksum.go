@@ -2,6 +2,7 @@
 package checksum
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/tls"
@@ -21,6 +22,8 @@ import (
 type Result struct {
 	ChecksumHex string
 	Algorithm   string
+	// ContentHashHex is the SHA-256 of the normalized extracted text (PDF/HTML only)
+	ContentHashHex string
 }
 
 // ComputeOptions configures the remote checksum computation behavior
@@ -156,7 +159,7 @@ func ComputeRemoteChecksum(ctx context.Context, urlStr string, opts ComputeOptio
 	}
 
 	// Compute SHA-256 with size limit
-	return computeHashWithLimit(getResp.Body, opts.MaxBytes, urlStr)
+	return computeHashWithLimit(getResp.Body, opts.MaxBytes, urlStr, getResp.Header.Get("Content-Type"))
 }
 
 // computeWithStreamedGET performs a GET request and computes checksum with hard size limit
@@ -186,15 +189,23 @@ func computeWithStreamedGET(ctx context.Context, client *http.Client, urlStr str
 		return nil, nil
 	}
 
-	return computeHashWithLimit(getResp.Body, opts.MaxBytes, urlStr)
+	return computeHashWithLimit(getResp.Body, opts.MaxBytes, urlStr, contentType)
 }
 
 // computeHashWithLimit computes SHA-256 hash with a hard size limit
-func computeHashWithLimit(reader io.Reader, maxBytes int64, urlStr string) (*Result, error) {
+// PDF and HTML bodies are also buffered to compute the content hash of their extracted text
+func computeHashWithLimit(reader io.Reader, maxBytes int64, urlStr string, contentType string) (*Result, error) {
 	hasher := sha256.New()
 	limitedReader := io.LimitReader(reader, maxBytes+1) // +1 to detect overflow
 
-	written, err := io.Copy(hasher, limitedReader)
+	var body *bytes.Buffer
+	var sink io.Writer = hasher
+	if contentType == "" || isTextExtractable(contentType) {
+		body = &bytes.Buffer{}
+		sink = io.MultiWriter(hasher, body)
+	}
+
+	written, err := io.Copy(sink, limitedReader)
 	if err != nil {
 		logger.Logger.Warn("Checksum: Failed to read stream", "url", urlStr, "error", err.Error())
 		return nil, nil
@@ -208,10 +219,18 @@ func computeHashWithLimit(reader io.Reader, maxBytes int64, urlStr string) (*Res
 	checksumHex := hex.EncodeToString(hasher.Sum(nil))
 	logger.Logger.Info("Checksum: Successfully computed", "url", urlStr, "checksum", checksumHex, "bytes", written)
 
-	return &Result{
+	result := &Result{
 		ChecksumHex: checksumHex,
 		Algorithm:   "SHA-256",
-	}, nil
+	}
+	if body != nil {
+		result.ContentHashHex = ComputeContentHash(contentType, body.Bytes())
+		if result.ContentHashHex != "" {
+			logger.Logger.Debug("Checksum: Content hash computed", "url", urlStr, "content_hash", result.ContentHashHex)
+		}
+	}
+
+	return result, nil
 }
 
 // isValidURL checks if the URL uses HTTPS scheme
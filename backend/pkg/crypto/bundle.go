@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Export bundles are zip archives holding the exported files with a manifest listing their
+// SHA-256, the Ed25519 signature of the manifest and, optionally, an RFC 3161 time-stamp of it.
+// Recipients can prove that the files were not altered since the export without the database.
+const (
+	BundleManifestName  = "manifest.json"
+	BundleSignatureName = "manifest.sig"
+	BundleTimestampName = "manifest.tsr"
+
+	bundleVersion   = 1
+	bundleAlgorithm = "ed25519"
+
+	// maxBundleEntrySize bounds each decompressed entry read while verifying
+	maxBundleEntrySize = 256 << 20
+)
+
+// ErrBundleInvalid is returned for archives that are not export bundles
+var ErrBundleInvalid = errors.New("invalid export bundle")
+
+// BundleFile is a file of an export bundle
+type BundleFile struct {
+	Name string
+	Data []byte
+}
+
+// ExportManifest describes the files of an export bundle
+type ExportManifest struct {
+	Version   int            `json:"version"`
+	Kind      string         `json:"kind"`
+	Subject   string         `json:"subject,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	Algorithm string         `json:"algorithm"`
+	PublicKey string         `json:"publicKey"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// ManifestFile is the manifest entry of a file
+type ManifestFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// NewExportManifest hashes the files of a bundle of the given kind (e.g. "signatures") about a
+// subject (e.g. a document ID), to be signed with the key of publicKey
+func NewExportManifest(kind, subject, publicKey string, files []BundleFile, createdAt time.Time) *ExportManifest {
+	manifest := &ExportManifest{
+		Version:   bundleVersion,
+		Kind:      kind,
+		Subject:   subject,
+		CreatedAt: createdAt.UTC(),
+		Algorithm: bundleAlgorithm,
+		PublicKey: publicKey,
+		Files:     make([]ManifestFile, 0, len(files)),
+	}
+	for _, file := range files {
+		sum := sha256.Sum256(file.Data)
+		manifest.Files = append(manifest.Files, ManifestFile{Name: file.Name, SHA256: hex.EncodeToString(sum[:]), Size: int64(len(file.Data))})
+	}
+	return manifest
+}
+
+// Encode returns the manifest bytes that are signed and stored in the bundle
+func (m *ExportManifest) Encode() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// ExportBundle is a signed export, ready to be written
+type ExportBundle struct {
+	Files     []BundleFile
+	Manifest  []byte
+	Signature string // base64 Ed25519 signature of Manifest
+	Timestamp []byte // DER RFC 3161 response for the SHA-256 of Manifest, optional
+}
+
+// Write writes the bundle as a zip archive
+func (b *ExportBundle) Write(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	entries := append([]BundleFile{}, b.Files...)
+	entries = append(entries, BundleFile{Name: BundleManifestName, Data: b.Manifest}, BundleFile{Name: BundleSignatureName, Data: []byte(b.Signature + "\n")})
+	if len(b.Timestamp) > 0 {
+		entries = append(entries, BundleFile{Name: BundleTimestampName, Data: b.Timestamp})
+	}
+
+	for _, entry := range entries {
+		f, err := zw.Create(entry.Name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to bundle: %w", entry.Name, err)
+		}
+		if _, err := f.Write(entry.Data); err != nil {
+			return fmt.Errorf("failed to write %s to bundle: %w", entry.Name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// BundleVerification is the outcome of a bundle verification
+type BundleVerification struct {
+	// Valid is set when the signature, every file and the time-stamp, if any, are valid, and
+	// the manifest was signed with the trusted key when one was given
+	Valid          bool               `json:"valid"`
+	SignatureValid bool               `json:"signatureValid"`
+	KeyTrusted     bool               `json:"keyTrusted"`
+	Manifest       *ExportManifest    `json:"manifest"`
+	Files          []FileVerification `json:"files"`
+	Timestamp      *TimestampInfo     `json:"timestamp,omitempty"`
+	Errors         []string           `json:"errors,omitempty"`
+}
+
+// FileVerification is the outcome of the check of one file
+type FileVerification struct {
+	Name  string `json:"name"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// VerifyExportBundle checks a bundle. With a trustedKey, the manifest must be signed with it;
+// without, the key embedded in the manifest is used, which only proves the files match what
+// that key signed. ErrBundleInvalid is returned when the archive has no readable manifest.
+func VerifyExportBundle(data []byte, trustedKey string) (*BundleVerification, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBundleInvalid, err)
+	}
+	entries := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		content, err := readBundleEntry(f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrBundleInvalid, err)
+		}
+		entries[f.Name] = content
+	}
+
+	manifestData, ok := entries[BundleManifestName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is missing", ErrBundleInvalid, BundleManifestName)
+	}
+	var manifest ExportManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBundleInvalid, err)
+	}
+
+	result := &BundleVerification{Manifest: &manifest, Files: []FileVerification{}}
+	if manifest.Algorithm != bundleAlgorithm {
+		result.Errors = append(result.Errors, fmt.Sprintf("unsupported algorithm %q", manifest.Algorithm))
+	}
+
+	publicKey := manifest.PublicKey
+	if trustedKey != "" {
+		result.KeyTrusted = manifest.PublicKey == trustedKey
+		if !result.KeyTrusted {
+			result.Errors = append(result.Errors, "manifest was not signed with the trusted key")
+		}
+		publicKey = trustedKey
+	}
+	signature := strings.TrimSpace(string(entries[BundleSignatureName]))
+	result.SignatureValid = VerifyEd25519(publicKey, manifestData, signature)
+	if !result.SignatureValid {
+		result.Errors = append(result.Errors, "manifest signature is invalid")
+	}
+
+	listed := make(map[string]bool, len(manifest.Files))
+	for _, file := range manifest.Files {
+		listed[file.Name] = true
+		check := FileVerification{Name: file.Name}
+		content, ok := entries[file.Name]
+		sum := sha256.Sum256(content)
+		switch {
+		case !ok:
+			check.Error = "file is missing"
+		case hex.EncodeToString(sum[:]) != file.SHA256 || int64(len(content)) != file.Size:
+			check.Error = "file was modified"
+		default:
+			check.Valid = true
+		}
+		if !check.Valid {
+			result.Errors = append(result.Errors, file.Name+": "+check.Error)
+		}
+		result.Files = append(result.Files, check)
+	}
+
+	// Files added to the archive are not covered by the signature
+	var extra []string
+	for name := range entries {
+		if !listed[name] && name != BundleManifestName && name != BundleSignatureName && name != BundleTimestampName {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		result.Files = append(result.Files, FileVerification{Name: name, Error: "file is not in the manifest"})
+		result.Errors = append(result.Errors, name+": file is not in the manifest")
+	}
+
+	if tsr, ok := entries[BundleTimestampName]; ok {
+		digest := sha256.Sum256(manifestData)
+		info, err := ParseTimestampResponse(tsr, digest[:])
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		} else {
+			result.Timestamp = info
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result, nil
+}
+
+func readBundleEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	content, err := io.ReadAll(io.LimitReader(rc, maxBundleEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxBundleEntrySize {
+		return nil, fmt.Errorf("%s is too large", f.Name)
+	}
+	return content, nil
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBundle(t *testing.T, signer *Ed25519Signer, withTimestamp bool) []byte {
+	t.Helper()
+
+	files := []BundleFile{
+		{Name: "signatures.json", Data: []byte(`[{"id":1}]`)},
+		{Name: "signatures.csv", Data: []byte("id\n1\n")},
+	}
+	manifest, err := NewExportManifest("signatures", "doc1", signer.GetPublicKey(), files, time.Now()).Encode()
+	require.NoError(t, err)
+
+	bundle := &ExportBundle{Files: files, Manifest: manifest, Signature: signer.Sign(manifest)}
+	if withTimestamp {
+		digest := sha256.Sum256(manifest)
+		bundle.Timestamp = fakeTimestampResponse(t, digest[:], time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, bundle.Write(&buf))
+	return buf.Bytes()
+}
+
+// rewriteBundle copies a bundle, replacing or adding entries
+func rewriteBundle(t *testing.T, data []byte, changes map[string][]byte) []byte {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		content, ok := changes[f.Name]
+		if ok {
+			delete(changes, f.Name)
+		} else {
+			rc, err := f.Open()
+			require.NoError(t, err)
+			content, err = io.ReadAll(rc)
+			require.NoError(t, err)
+			_ = rc.Close()
+		}
+		w, err := zw.Create(f.Name)
+		require.NoError(t, err)
+		_, _ = w.Write(content)
+	}
+	for name, content := range changes {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, _ = w.Write(content)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestVerifyExportBundle(t *testing.T) {
+	signer, err := NewEd25519Signer()
+	require.NoError(t, err)
+
+	t.Run("valid bundle with the trusted key", func(t *testing.T) {
+		result, err := VerifyExportBundle(newTestBundle(t, signer, true), signer.GetPublicKey())
+		require.NoError(t, err)
+		assert.True(t, result.Valid, result.Errors)
+		assert.True(t, result.SignatureValid)
+		assert.True(t, result.KeyTrusted)
+		assert.Len(t, result.Files, 2)
+		require.NotNil(t, result.Timestamp)
+		assert.Equal(t, "42", result.Timestamp.SerialNumber)
+		assert.Equal(t, "doc1", result.Manifest.Subject)
+	})
+
+	t.Run("embedded key without a trusted key", func(t *testing.T) {
+		result, err := VerifyExportBundle(newTestBundle(t, signer, false), "")
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.False(t, result.KeyTrusted)
+		assert.Nil(t, result.Timestamp)
+	})
+
+	t.Run("other key", func(t *testing.T) {
+		other, err := NewEd25519Signer()
+		require.NoError(t, err)
+		result, err := VerifyExportBundle(newTestBundle(t, signer, false), other.GetPublicKey())
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.False(t, result.KeyTrusted)
+		assert.False(t, result.SignatureValid)
+	})
+
+	t.Run("modified file", func(t *testing.T) {
+		data := rewriteBundle(t, newTestBundle(t, signer, false), map[string][]byte{"signatures.csv": []byte("id\n2\n")})
+		result, err := VerifyExportBundle(data, signer.GetPublicKey())
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.True(t, result.SignatureValid)
+		assert.Equal(t, "file was modified", result.Files[1].Error)
+	})
+
+	t.Run("modified manifest", func(t *testing.T) {
+		files := []BundleFile{{Name: "signatures.json", Data: []byte(`[]`)}}
+		manifest, err := NewExportManifest("signatures", "doc1", signer.GetPublicKey(), files, time.Now()).Encode()
+		require.NoError(t, err)
+		data := rewriteBundle(t, newTestBundle(t, signer, false), map[string][]byte{BundleManifestName: manifest, "signatures.json": []byte(`[]`)})
+		result, err := VerifyExportBundle(data, "")
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.False(t, result.SignatureValid)
+	})
+
+	t.Run("added file", func(t *testing.T) {
+		data := rewriteBundle(t, newTestBundle(t, signer, false), map[string][]byte{"extra.json": []byte(`{}`)})
+		result, err := VerifyExportBundle(data, signer.GetPublicKey())
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		assert.Equal(t, "extra.json", result.Files[2].Name)
+	})
+
+	t.Run("not a bundle", func(t *testing.T) {
+		_, err := VerifyExportBundle([]byte("not a zip"), "")
+		assert.ErrorIs(t, err, ErrBundleInvalid)
+
+		var buf bytes.Buffer
+		require.NoError(t, (&ExportBundle{}).Write(&buf))
+		data := rewriteBundle(t, buf.Bytes(), map[string][]byte{BundleManifestName: []byte("{")})
+		_, err = VerifyExportBundle(data, "")
+		assert.ErrorIs(t, err, ErrBundleInvalid)
+	})
+}
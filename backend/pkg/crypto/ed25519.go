@@ -2,11 +2,13 @@
 package crypto
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -16,23 +18,39 @@ import (
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
 
+// Sources of the signer key
+const (
+	KeySourceEnv       = "env"
+	KeySourceFile      = "file"
+	KeySourceEphemeral = "ephemeral"
+)
+
+var (
+	// ErrKeyMismatch is returned when the public half of a private key is not derived from its seed:
+	// signatures made with such a key never verify
+	ErrKeyMismatch = errors.New("ed25519 public key does not match the private key seed")
+	// ErrKeySelfTest is returned when the signer key fails to sign and verify the test vector
+	ErrKeySelfTest = errors.New("ed25519 key failed to sign and verify the test vector")
+)
+
+// keyTestVector is signed and verified by SelfTest
+var keyTestVector = []byte("ackify ed25519 key self-test")
+
 // Ed25519Signer provides cryptographic signature operations using Ed25519 elliptic curve algorithm
 type Ed25519Signer struct {
 	privateKey ed25519.PrivateKey
 	publicKey  ed25519.PublicKey
+	source     string
+	keyFile    string // set when the key is read from ACKIFY_ED25519_PRIVATE_KEY_FILE
 }
 
 // NewEd25519Signer initializes signer with persistent or ephemeral keypair from environment
 func NewEd25519Signer() (*Ed25519Signer, error) {
-	privKey, pubKey, err := loadOrGenerateKeys()
+	signer, err := loadOrGenerateKeys()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load or generate keys: %w", err)
 	}
-
-	return &Ed25519Signer{
-		privateKey: privKey,
-		publicKey:  pubKey,
-	}, nil
+	return signer, nil
 }
 
 // CreateSignature generates SHA-256 payload hash and Ed25519 signature for non-repudiation proof
@@ -55,6 +73,46 @@ func (s *Ed25519Signer) GetPublicKey() string {
 	return base64.StdEncoding.EncodeToString(s.publicKey)
 }
 
+// Sign signs an arbitrary message, such as an export manifest, and returns the base64 signature
+func (s *Ed25519Signer) Sign(message []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(s.privateKey, message))
+}
+
+// KeySource tells where the key comes from: KeySourceEnv, KeySourceFile or KeySourceEphemeral
+func (s *Ed25519Signer) KeySource() string {
+	return s.source
+}
+
+// KeyFile returns the path of the key file, empty unless the key is read from a file
+func (s *Ed25519Signer) KeyFile() string {
+	return s.keyFile
+}
+
+// SelfTest checks that the key signs a test vector into a signature that verifies with its public key
+func (s *Ed25519Signer) SelfTest() error {
+	derived := ed25519.NewKeyFromSeed(s.privateKey.Seed()).Public().(ed25519.PublicKey)
+	if !bytes.Equal(derived, s.publicKey) {
+		return ErrKeyMismatch
+	}
+	if !VerifyEd25519(s.GetPublicKey(), keyTestVector, s.Sign(keyTestVector)) {
+		return ErrKeySelfTest
+	}
+	return nil
+}
+
+// VerifyEd25519 checks a base64 signature of message against a base64 public key
+func VerifyEd25519(publicKey string, message []byte, signature string) bool {
+	keyBytes, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(keyBytes) != ed25519.PublicKeySize {
+		return false
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(sigBytes) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(keyBytes), message, sigBytes)
+}
+
 func canonicalPayload(docID string, user *models.User, timestamp time.Time, nonce string, docChecksum string) []byte {
 	payload := fmt.Sprintf(
 		"doc_id=%s\nuser_sub=%s\nuser_email=%s\nsigned_at=%s\nnonce=%s\n",
@@ -73,27 +131,54 @@ func canonicalPayload(docID string, user *models.User, timestamp time.Time, nonc
 	return []byte(payload)
 }
 
-func loadOrGenerateKeys() (ed25519.PrivateKey, ed25519.PublicKey, error) {
+func loadOrGenerateKeys() (*Ed25519Signer, error) {
 	b64Key := strings.TrimSpace(os.Getenv("ACKIFY_ED25519_PRIVATE_KEY"))
+	source, variable := KeySourceEnv, "ACKIFY_ED25519_PRIVATE_KEY"
+	keyFile := ""
+
+	if b64Key == "" {
+		if keyFile = strings.TrimSpace(os.Getenv("ACKIFY_ED25519_PRIVATE_KEY_FILE")); keyFile != "" {
+			data, err := os.ReadFile(keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read ACKIFY_ED25519_PRIVATE_KEY_FILE: %w", err)
+			}
+			b64Key = strings.TrimSpace(string(data))
+			source, variable = KeySourceFile, "ACKIFY_ED25519_PRIVATE_KEY_FILE"
+		}
+	}
 
 	if b64Key != "" {
-		keyBytes, err := base64.StdEncoding.DecodeString(b64Key)
-		if err != nil || len(keyBytes) != ed25519.PrivateKeySize {
-			return nil, nil, fmt.Errorf("invalid ACKIFY_ED25519_PRIVATE_KEY: %v", err)
+		privateKey, err := ParseEd25519PrivateKey(b64Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", variable, err)
 		}
 
-		privateKey := ed25519.PrivateKey(keyBytes)
-		publicKey := privateKey.Public().(ed25519.PublicKey)
-
-		return privateKey, publicKey, nil
+		return &Ed25519Signer{
+			privateKey: privateKey,
+			publicKey:  privateKey.Public().(ed25519.PublicKey),
+			source:     source,
+			keyFile:    keyFile,
+		}, nil
 	}
 
 	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate keys: %w", err)
+		return nil, fmt.Errorf("failed to generate keys: %w", err)
 	}
 
 	logger.Logger.Warn("Ed25519 private key not set, signatures will be different on restart")
 
-	return privateKey, publicKey, nil
+	return &Ed25519Signer{privateKey: privateKey, publicKey: publicKey, source: KeySourceEphemeral}, nil
+}
+
+// ParseEd25519PrivateKey decodes a base64 Ed25519 private key (seed followed by public key)
+func ParseEd25519PrivateKey(b64Key string) (ed25519.PrivateKey, error) {
+	keyBytes, err := base64.StdEncoding.DecodeString(b64Key)
+	if err != nil {
+		return nil, err
+	}
+	if len(keyBytes) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("expected %d bytes, got %d", ed25519.PrivateKeySize, len(keyBytes))
+	}
+	return ed25519.PrivateKey(keyBytes), nil
 }
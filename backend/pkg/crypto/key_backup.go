@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Key backups hold the signer private key encrypted with AES-256-GCM under a key derived from a
+// passphrase with PBKDF2-SHA256. The public key stays readable to tell which key a backup holds.
+const (
+	keyBackupVersion    = 1
+	keyBackupKDF        = "pbkdf2-sha256"
+	keyBackupIterations = 600000
+	keyBackupSaltSize   = 16
+)
+
+var (
+	// ErrKeyBackupInvalid is returned for backups that are not signer key backups
+	ErrKeyBackupInvalid = errors.New("invalid signing key backup")
+	// ErrKeyBackupPassphrase is returned when the passphrase does not decrypt the backup
+	ErrKeyBackupPassphrase = errors.New("failed to decrypt signing key backup: invalid passphrase")
+	// ErrKeyFileTooOpen is returned for key files readable or writable by other users than their owner
+	ErrKeyFileTooOpen = errors.New("key file permissions are too open")
+)
+
+// KeyBackup is an encrypted backup of the signer private key
+type KeyBackup struct {
+	Version      int       `json:"version"`
+	Algorithm    string    `json:"algorithm"`
+	PublicKey    string    `json:"publicKey"`
+	KDF          string    `json:"kdf"`
+	Iterations   int       `json:"iterations"`
+	Salt         []byte    `json:"salt"`
+	EncryptedKey []byte    `json:"encryptedKey"` // nonce + ciphertext + tag of the base64 private key
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// ExportBackup encrypts the private key with a key derived from passphrase
+func (s *Ed25519Signer) ExportBackup(passphrase string) (*KeyBackup, error) {
+	salt := make([]byte, keyBackupSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, keyBackupIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive backup key: %w", err)
+	}
+	encrypted, err := EncryptToken(base64.StdEncoding.EncodeToString(s.privateKey), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt private key: %w", err)
+	}
+
+	return &KeyBackup{
+		Version:      keyBackupVersion,
+		Algorithm:    bundleAlgorithm,
+		PublicKey:    s.GetPublicKey(),
+		KDF:          keyBackupKDF,
+		Iterations:   keyBackupIterations,
+		Salt:         salt,
+		EncryptedKey: encrypted,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// OpenKeyBackup decrypts a backup with its passphrase and returns the base64 private key, the value
+// of ACKIFY_ED25519_PRIVATE_KEY restoring it
+func OpenKeyBackup(backup *KeyBackup, passphrase string) (string, error) {
+	if backup.Version != keyBackupVersion || backup.Algorithm != bundleAlgorithm || backup.KDF != keyBackupKDF ||
+		backup.Iterations < 1 || len(backup.Salt) == 0 || len(backup.EncryptedKey) == 0 {
+		return "", ErrKeyBackupInvalid
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, backup.Salt, backup.Iterations, 32)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive backup key: %w", err)
+	}
+	b64Key, err := DecryptToken(backup.EncryptedKey, key)
+	if err != nil {
+		return "", ErrKeyBackupPassphrase
+	}
+
+	privateKey, err := ParseEd25519PrivateKey(b64Key)
+	if err != nil {
+		return "", ErrKeyBackupInvalid
+	}
+	publicKey := base64.StdEncoding.EncodeToString(privateKey.Public().(ed25519.PublicKey))
+	if publicKey != backup.PublicKey {
+		return "", ErrKeyBackupInvalid
+	}
+	return b64Key, nil
+}
+
+// CheckKeyFilePermissions returns ErrKeyFileTooOpen when other users than the owner have any
+// permission on the key file
+func CheckKeyFilePermissions(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat key file: %w", err)
+	}
+	if mode := info.Mode().Perm(); mode&0o077 != 0 {
+		return fmt.Errorf("%w: %s has mode %04o, expected 0600 or 0400", ErrKeyFileTooOpen, path, mode)
+	}
+	return nil
+}
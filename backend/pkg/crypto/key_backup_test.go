@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) *Ed25519Signer {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &Ed25519Signer{privateKey: privateKey, publicKey: publicKey, source: KeySourceEnv}
+}
+
+func TestEd25519Signer_SelfTest(t *testing.T) {
+	t.Run("valid key", func(t *testing.T) {
+		assert.NoError(t, newTestSigner(t).SelfTest())
+	})
+
+	t.Run("public key not derived from the seed", func(t *testing.T) {
+		// 64 random bytes, as produced by openssl rand: the second half is not the public key
+		keyBytes := make([]byte, ed25519.PrivateKeySize)
+		_, err := rand.Read(keyBytes)
+		require.NoError(t, err)
+		privateKey := ed25519.PrivateKey(keyBytes)
+		signer := &Ed25519Signer{privateKey: privateKey, publicKey: privateKey.Public().(ed25519.PublicKey)}
+
+		assert.ErrorIs(t, signer.SelfTest(), ErrKeyMismatch)
+	})
+}
+
+func TestNewEd25519Signer_KeyFile(t *testing.T) {
+	signer := newTestSigner(t)
+	path := filepath.Join(t.TempDir(), "ed25519.key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(signer.privateKey)+"\n"), 0o600))
+
+	t.Setenv("ACKIFY_ED25519_PRIVATE_KEY", "")
+	t.Setenv("ACKIFY_ED25519_PRIVATE_KEY_FILE", path)
+
+	loaded, err := NewEd25519Signer()
+	require.NoError(t, err)
+	assert.Equal(t, signer.GetPublicKey(), loaded.GetPublicKey())
+	assert.Equal(t, KeySourceFile, loaded.KeySource())
+	assert.Equal(t, path, loaded.KeyFile())
+
+	t.Setenv("ACKIFY_ED25519_PRIVATE_KEY_FILE", filepath.Join(t.TempDir(), "missing.key"))
+	_, err = NewEd25519Signer()
+	assert.Error(t, err)
+}
+
+func TestCheckKeyFilePermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ed25519.key")
+	require.NoError(t, os.WriteFile(path, []byte("key"), 0o600))
+	assert.NoError(t, CheckKeyFilePermissions(path))
+
+	require.NoError(t, os.Chmod(path, 0o644))
+	assert.ErrorIs(t, CheckKeyFilePermissions(path), ErrKeyFileTooOpen)
+
+	assert.Error(t, CheckKeyFilePermissions(filepath.Join(t.TempDir(), "missing.key")))
+}
+
+func TestKeyBackup_RoundTrip(t *testing.T) {
+	signer := newTestSigner(t)
+
+	backup, err := signer.ExportBackup("correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, signer.GetPublicKey(), backup.PublicKey)
+	assert.NotContains(t, string(backup.EncryptedKey), base64.StdEncoding.EncodeToString(signer.privateKey))
+
+	b64Key, err := OpenKeyBackup(backup, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(signer.privateKey), b64Key)
+
+	_, err = OpenKeyBackup(backup, "wrong passphrase")
+	assert.ErrorIs(t, err, ErrKeyBackupPassphrase)
+
+	backup.Version = 2
+	_, err = OpenKeyBackup(backup, "correct horse battery staple")
+	assert.ErrorIs(t, err, ErrKeyBackupInvalid)
+}
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Domain separation prefixes (RFC 6962) so that a leaf can never be presented as an inner node
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// MerkleStep is one level of an inclusion proof: the sibling hash and its side
+type MerkleStep struct {
+	Sibling []byte
+	Left    bool // Sibling is the left operand of the parent hash
+}
+
+// MerkleLeafHash hashes a leaf value, such as a signature record hash
+func MerkleLeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleLeafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func merkleNodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{merkleNodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// merkleParents hashes a level pairwise; an odd last node is promoted unchanged
+func merkleParents(level [][]byte) [][]byte {
+	parents := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			parents = append(parents, level[i])
+			continue
+		}
+		parents = append(parents, merkleNodeHash(level[i], level[i+1]))
+	}
+	return parents
+}
+
+// MerkleRoot computes the root of a tree over leaf hashes, in order
+func MerkleRoot(leaves [][]byte) ([]byte, error) {
+	if len(leaves) == 0 {
+		return nil, fmt.Errorf("merkle tree requires at least one leaf")
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = merkleParents(level)
+	}
+	return level[0], nil
+}
+
+// MerkleProof returns the inclusion path of the leaf at index, from the leaf up to the root
+func MerkleProof(leaves [][]byte, index int) ([]MerkleStep, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, fmt.Errorf("leaf index %d out of range", index)
+	}
+	var steps []MerkleStep
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			steps = append(steps, MerkleStep{Sibling: level[sibling], Left: sibling < index})
+		}
+		level = merkleParents(level)
+		index /= 2
+	}
+	return steps, nil
+}
+
+// VerifyMerkleProof checks that the leaf hash and the inclusion path lead to the root
+func VerifyMerkleProof(leaf []byte, steps []MerkleStep, root []byte) bool {
+	current := leaf
+	for _, step := range steps {
+		if step.Left {
+			current = merkleNodeHash(step.Sibling, current)
+		} else {
+			current = merkleNodeHash(current, step.Sibling)
+		}
+	}
+	return bytes.Equal(current, root)
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMerkleLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = MerkleLeafHash([]byte(fmt.Sprintf("signature-%d", i)))
+	}
+	return leaves
+}
+
+func TestMerkleRoot(t *testing.T) {
+	t.Run("single leaf is the root", func(t *testing.T) {
+		leaves := testMerkleLeaves(1)
+		root, err := MerkleRoot(leaves)
+		require.NoError(t, err)
+		assert.Equal(t, leaves[0], root)
+	})
+
+	t.Run("two leaves", func(t *testing.T) {
+		leaves := testMerkleLeaves(2)
+		root, err := MerkleRoot(leaves)
+		require.NoError(t, err)
+		assert.Equal(t, merkleNodeHash(leaves[0], leaves[1]), root)
+	})
+
+	t.Run("odd leaf is promoted", func(t *testing.T) {
+		leaves := testMerkleLeaves(3)
+		root, err := MerkleRoot(leaves)
+		require.NoError(t, err)
+		assert.Equal(t, merkleNodeHash(merkleNodeHash(leaves[0], leaves[1]), leaves[2]), root)
+	})
+
+	t.Run("order matters", func(t *testing.T) {
+		leaves := testMerkleLeaves(2)
+		root, _ := MerkleRoot(leaves)
+		swapped, _ := MerkleRoot([][]byte{leaves[1], leaves[0]})
+		assert.NotEqual(t, root, swapped)
+	})
+
+	t.Run("no leaves", func(t *testing.T) {
+		_, err := MerkleRoot(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestMerkleProof(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 8, 13} {
+		leaves := testMerkleLeaves(n)
+		root, err := MerkleRoot(leaves)
+		require.NoError(t, err)
+
+		for i := range leaves {
+			steps, err := MerkleProof(leaves, i)
+			require.NoError(t, err)
+			assert.True(t, VerifyMerkleProof(leaves[i], steps, root), "leaf %d of %d", i, n)
+
+			other := MerkleLeafHash([]byte("forged"))
+			assert.False(t, VerifyMerkleProof(other, steps, root), "forged leaf %d of %d", i, n)
+		}
+	}
+
+	t.Run("index out of range", func(t *testing.T) {
+		_, err := MerkleProof(testMerkleLeaves(2), 2)
+		assert.Error(t, err)
+	})
+}
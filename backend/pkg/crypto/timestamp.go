@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// RFC 3161 time-stamp protocol, limited to what export manifests need: building a request for a
+// SHA-256 digest and reading the time and the digest back from the response. The TSA signature
+// of the token is not checked here; it can be checked with `openssl ts -verify` and the TSA
+// certificate.
+
+var (
+	oidSHA256     = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidTSTInfo    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+)
+
+// ErrTimestampInvalid is returned for time-stamp responses that cannot be read or do not match
+var ErrTimestampInvalid = errors.New("invalid time-stamp response")
+
+// TimestampInfo is what a time-stamp token attests
+type TimestampInfo struct {
+	GenTime      time.Time `json:"genTime"`
+	SerialNumber string    `json:"serialNumber"`
+	Policy       string    `json:"policy"`
+}
+
+type tsMessageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type tsRequest struct {
+	Version        int
+	MessageImprint tsMessageImprint
+	Nonce          *big.Int `asn1:"optional"`
+	CertReq        bool     `asn1:"optional"`
+}
+
+type tsResponse struct {
+	Status         asn1.RawValue
+	TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+type tsContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+type tsSignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	EncapContentInfo tsEncapContentInfo
+}
+
+type tsEncapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,tag:0"`
+}
+
+type tsTSTInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint tsMessageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time `asn1:"generalized"`
+}
+
+func sha256Identifier() pkix.AlgorithmIdentifier {
+	return pkix.AlgorithmIdentifier{Algorithm: oidSHA256, Parameters: asn1.NullRawValue}
+}
+
+// NewTimestampRequest builds the DER time-stamp request of a SHA-256 digest, asking for the TSA
+// certificate in the response
+func NewTimestampRequest(digest []byte) ([]byte, error) {
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate time-stamp nonce: %w", err)
+	}
+	return asn1.Marshal(tsRequest{
+		Version: 1,
+		MessageImprint: tsMessageImprint{
+			HashAlgorithm: sha256Identifier(),
+			HashedMessage: digest,
+		},
+		Nonce:   nonce,
+		CertReq: true,
+	})
+}
+
+// ParseTimestampResponse reads a DER time-stamp response and checks that it was granted for the
+// SHA-256 digest
+func ParseTimestampResponse(der, digest []byte) (*TimestampInfo, error) {
+	var resp tsResponse
+	if _, err := asn1.Unmarshal(der, &resp); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+	var status int
+	if _, err := asn1.Unmarshal(resp.Status.Bytes, &status); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+	// 0 granted, 1 granted with modifications
+	if status > 1 {
+		return nil, fmt.Errorf("%w: request rejected with status %d", ErrTimestampInvalid, status)
+	}
+	if len(resp.TimeStampToken.FullBytes) == 0 {
+		return nil, fmt.Errorf("%w: no time-stamp token", ErrTimestampInvalid)
+	}
+
+	var content tsContentInfo
+	if _, err := asn1.Unmarshal(resp.TimeStampToken.FullBytes, &content); err != nil || !content.ContentType.Equal(oidSignedData) {
+		return nil, fmt.Errorf("%w: token is not a signed data", ErrTimestampInvalid)
+	}
+	var signed tsSignedData
+	if _, err := asn1.Unmarshal(content.Content.Bytes, &signed); err != nil || !signed.EncapContentInfo.EContentType.Equal(oidTSTInfo) {
+		return nil, fmt.Errorf("%w: token does not hold a TSTInfo", ErrTimestampInvalid)
+	}
+	var info tsTSTInfo
+	if _, err := asn1.Unmarshal(signed.EncapContentInfo.EContent, &info); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTimestampInvalid, err)
+	}
+
+	if !info.MessageImprint.HashAlgorithm.Algorithm.Equal(oidSHA256) || !bytes.Equal(info.MessageImprint.HashedMessage, digest) {
+		return nil, fmt.Errorf("%w: token does not cover the manifest", ErrTimestampInvalid)
+	}
+
+	result := &TimestampInfo{GenTime: info.GenTime.UTC(), Policy: info.Policy.String()}
+	if info.SerialNumber != nil {
+		result.SerialNumber = info.SerialNumber.String()
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTimestampResponse builds a granted response for digest, with the certificates and signer
+// infos of a real token replaced by placeholders
+func fakeTimestampResponse(t *testing.T, digest []byte, genTime time.Time) []byte {
+	t.Helper()
+
+	tstInfo, err := asn1.Marshal(tsTSTInfo{
+		Version:        1,
+		Policy:         asn1.ObjectIdentifier{1, 2, 3, 4},
+		MessageImprint: tsMessageImprint{HashAlgorithm: sha256Identifier(), HashedMessage: digest},
+		SerialNumber:   big.NewInt(42),
+		GenTime:        genTime,
+	})
+	require.NoError(t, err)
+
+	signed, err := asn1.Marshal(struct {
+		Version          int
+		DigestAlgorithms asn1.RawValue
+		EncapContentInfo tsEncapContentInfo
+		Certificates     asn1.RawValue `asn1:"tag:0"`
+		SignerInfos      asn1.RawValue
+	}{
+		Version:          3,
+		DigestAlgorithms: asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: []byte{}},
+		EncapContentInfo: tsEncapContentInfo{EContentType: oidTSTInfo, EContent: tstInfo},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: []byte{}},
+		SignerInfos:      asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: []byte{}},
+	})
+	require.NoError(t, err)
+
+	token, err := asn1.Marshal(tsContentInfo{ContentType: oidSignedData, Content: asn1.RawValue{FullBytes: mustExplicit(t, signed)}})
+	require.NoError(t, err)
+
+	status, err := asn1.Marshal(struct{ Status int }{Status: 0})
+	require.NoError(t, err)
+
+	resp, err := asn1.Marshal(tsResponse{Status: asn1.RawValue{FullBytes: status}, TimeStampToken: asn1.RawValue{FullBytes: token}})
+	require.NoError(t, err)
+	return resp
+}
+
+func mustExplicit(t *testing.T, inner []byte) []byte {
+	t.Helper()
+	wrapped, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: inner})
+	require.NoError(t, err)
+	return wrapped
+}
+
+func TestNewTimestampRequest(t *testing.T) {
+	digest := sha256.Sum256([]byte("manifest"))
+	der, err := NewTimestampRequest(digest[:])
+	require.NoError(t, err)
+
+	var req tsRequest
+	_, err = asn1.Unmarshal(der, &req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, req.Version)
+	assert.True(t, req.MessageImprint.HashAlgorithm.Algorithm.Equal(oidSHA256))
+	assert.Equal(t, digest[:], req.MessageImprint.HashedMessage)
+	assert.True(t, req.CertReq)
+	assert.NotNil(t, req.Nonce)
+}
+
+func TestParseTimestampResponse(t *testing.T) {
+	digest := sha256.Sum256([]byte("manifest"))
+	genTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	resp := fakeTimestampResponse(t, digest[:], genTime)
+
+	info, err := ParseTimestampResponse(resp, digest[:])
+	require.NoError(t, err)
+	assert.True(t, info.GenTime.Equal(genTime))
+	assert.Equal(t, "42", info.SerialNumber)
+	assert.Equal(t, "1.2.3.4", info.Policy)
+
+	other := sha256.Sum256([]byte("other manifest"))
+	_, err = ParseTimestampResponse(resp, other[:])
+	assert.ErrorIs(t, err, ErrTimestampInvalid)
+
+	_, err = ParseTimestampResponse([]byte("not DER"), digest[:])
+	assert.ErrorIs(t, err, ErrTimestampInvalid)
+}
+
+func TestParseTimestampResponse_Rejected(t *testing.T) {
+	status, err := asn1.Marshal(struct{ Status int }{Status: 2})
+	require.NoError(t, err)
+	resp, err := asn1.Marshal(struct{ Status asn1.RawValue }{Status: asn1.RawValue{FullBytes: status}})
+	require.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("manifest"))
+	_, err = ParseTimestampResponse(resp, digest[:])
+	assert.ErrorIs(t, err, ErrTimestampInvalid)
+}
@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+// Package markdown renders the small Markdown subset of admin-authored messages to HTML
+// that is safe to insert in a page: raw HTML is always escaped and links are limited to
+// http, https and mailto.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	headingRe     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	bulletItemRe  = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedItemRe = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	quoteRe       = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	fenceRe       = regexp.MustCompile("^\\s{0,3}```")
+)
+
+// allowedSchemes are the link schemes kept by the renderer, other links are rendered as text
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// ToHTML renders src. Supported: paragraphs, headings, bullet and numbered lists, block
+// quotes, fenced code blocks, code spans, strong and emphasis, and links.
+func ToHTML(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	var b strings.Builder
+	renderBlocks(&b, strings.Split(src, "\n"))
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func renderBlocks(b *strings.Builder, lines []string) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderInline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+
+		case fenceRe.MatchString(line):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !fenceRe.MatchString(lines[i]); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case headingRe.MatchString(line):
+			flush()
+			m := headingRe.FindStringSubmatch(line)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+
+		case quoteRe.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && quoteRe.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quoteRe.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+
+		case bulletItemRe.MatchString(line), orderedItemRe.MatchString(line):
+			flush()
+			itemRe, tag := bulletItemRe, "ul"
+			if !bulletItemRe.MatchString(line) {
+				itemRe, tag = orderedItemRe, "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for i < len(lines) && itemRe.MatchString(lines[i]) {
+				item := []string{itemRe.FindStringSubmatch(lines[i])[1]}
+				// Indented lines continue the item
+				for i++; i < len(lines) && isContinuation(lines[i]); i++ {
+					item = append(item, strings.TrimSpace(lines[i]))
+				}
+				b.WriteString("<li>" + renderInline(strings.Join(item, "\n")) + "</li>\n")
+			}
+			i--
+			b.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flush()
+}
+
+func isContinuation(line string) bool {
+	return strings.TrimSpace(line) != "" && (strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t")) &&
+		!bulletItemRe.MatchString(line) && !orderedItemRe.MatchString(line)
+}
+
+// renderInline renders the spans of a block, escaping everything else
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				b.WriteString("<code>" + html.EscapeString(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(s[i:], "**") || strings.HasPrefix(s[i:], "__"):
+			delim := s[i : i+2]
+			if end := strings.Index(s[i+2:], delim); end > 0 && opens(s, i, 2) {
+				b.WriteString("<strong>" + renderInline(s[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case c == '*' || c == '_':
+			if end := strings.IndexByte(s[i+1:], c); end > 0 && opens(s, i, 1) {
+				b.WriteString("<em>" + renderInline(s[i+1:i+1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case c == '[':
+			if text, href, n, ok := parseLink(s[i:]); ok {
+				if safeURL(href) {
+					b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="noopener noreferrer nofollow" target="_blank">` +
+						renderInline(text) + "</a>")
+				} else {
+					b.WriteString(renderInline(text))
+				}
+				i += n
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(html.EscapeString(s[i : i+size]))
+		i += size
+	}
+	return b.String()
+}
+
+// opens reports whether the delimiter of width n at i opens a span: it is not inside a word
+// and is followed by a non-space, so that snake_case and "2 * 3" stay text
+func opens(s string, i, n int) bool {
+	if i+n >= len(s) || s[i+n] == ' ' {
+		return false
+	}
+	if i > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsLetter(prev) || unicode.IsDigit(prev) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseLink parses "[text](url)" at the start of s and returns its length
+func parseLink(s string) (text, href string, n int, ok bool) {
+	closeText := strings.Index(s, "](")
+	if closeText < 1 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(s[closeText+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	text = s[1:closeText]
+	href = strings.TrimSpace(s[closeText+2 : closeText+2+closeURL])
+	return text, href, closeText + 3 + closeURL, true
+}
+
+func safeURL(href string) bool {
+	if href == "" || strings.ContainsAny(href, " \n\t") {
+		return false
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	return allowedSchemes[strings.ToLower(u.Scheme)]
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package markdown
+
+import "testing"
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"paragraphs", "First line\nsame paragraph\n\nSecond", "<p>First line\nsame paragraph</p>\n<p>Second</p>"},
+		{"heading", "## Before you sign ##", "<h2>Before you sign</h2>"},
+		{"emphasis", "**Read** the *whole* document, not `part_of` it", "<p><strong>Read</strong> the <em>whole</em> document, not <code>part_of</code> it</p>"},
+		{"intraword underscores", "snake_case_name and 2 * 3 * 4", "<p>snake_case_name and 2 * 3 * 4</p>"},
+		{"bullet list", "- one\n- two\n  continued\n\nafter", "<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n</ul>\n<p>after</p>"},
+		{"ordered list", "1. first\n2) second", "<ol>\n<li>first</li>\n<li>second</li>\n</ol>"},
+		{"quote", "> **Note**\n> quoted", "<blockquote>\n<p><strong>Note</strong>\nquoted</p>\n</blockquote>"},
+		{"code block", "```\n<b>x</b>\n```", "<pre><code>&lt;b&gt;x&lt;/b&gt;</code></pre>"},
+		{"link", "See [the policy](https://example.com/p?a=1&b=2)", `<p>See <a href="https://example.com/p?a=1&amp;b=2" rel="noopener noreferrer nofollow" target="_blank">the policy</a></p>`},
+		{"mailto link", "[Legal](mailto:legal@example.com)", `<p><a href="mailto:legal@example.com" rel="noopener noreferrer nofollow" target="_blank">Legal</a></p>`},
+		{"escaped", `\*not emphasis\*`, "<p>*not emphasis*</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToHTML(tt.src); got != tt.want {
+				t.Errorf("ToHTML(%q) =\n%q\nwant\n%q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToHTML_Sanitizes(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`<script>alert(1)</script>`, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{`<img src=x onerror="alert(1)">`, "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>"},
+		{`[click](javascript:alert(1))`, "<p>click)</p>"},
+		{`[click](JavaScript:alert(1))`, "<p>click)</p>"},
+		{`[click](data:text/html,hi)`, "<p>click</p>"},
+		{`[click](/relative)`, "<p>click</p>"},
+		{`[x](https://a.example/" onmouseover="alert(1))`, "<p>x)</p>"},
+		{`[x](https://a.example/"onmouseover="alert(1))`, `<p><a href="https://a.example/&#34;onmouseover=&#34;alert(1" rel="noopener noreferrer nofollow" target="_blank">x</a>)</p>`},
+		{`**<b>bold</b>**`, "<p><strong>&lt;b&gt;bold&lt;/b&gt;</strong></p>"},
+	}
+	for _, tt := range tests {
+		if got := ToHTML(tt.src); got != tt.want {
+			t.Errorf("ToHTML(%q) =\n%q\nwant\n%q", tt.src, got, tt.want)
+		}
+	}
+}
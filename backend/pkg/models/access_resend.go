@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// How fresh access is sent to signers who lost it
+const (
+	AccessResendMagicLink = "magic_link" // Login link opening the signing page, valid 24 hours
+	AccessResendCode      = "code"       // One-time code of the external signing page
+)
+
+// Outcome of an access resend for one recipient
+const (
+	AccessResendQueued      = "queued"       // Magic link queued for sending
+	AccessResendSent        = "sent"         // Code sent
+	AccessResendThrottled   = "throttled"    // An email was sent to the recipient too recently
+	AccessResendSigned      = "signed"       // The recipient already signed the document
+	AccessResendWaiting     = "waiting"      // The turn of the ordered recipient has not come yet
+	AccessResendNotExpected = "not_expected" // The recipient is not an expected signer of the document
+	AccessResendFailed      = "failed"
+)
+
+// AccessResendRecipient is the outcome of an access resend for one recipient
+type AccessResendRecipient struct {
+	Email      string     `json:"email"`
+	Status     string     `json:"status"`
+	RetryAfter *time.Time `json:"retryAfter,omitempty"` // When a throttled recipient can be sent access again
+	Error      string     `json:"error,omitempty"`
+}
+
+// AccessResendResult summarizes an access resend to the signers of a document
+type AccessResendResult struct {
+	Method     string                  `json:"method"`
+	Sent       int                     `json:"sent"` // Links queued or codes sent
+	Skipped    int                     `json:"skipped"`
+	Failed     int                     `json:"failed"`
+	Recipients []AccessResendRecipient `json:"recipients"`
+}
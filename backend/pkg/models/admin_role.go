@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Permission is a single admin capability checked per operation
+type Permission string
+
+const (
+	PermissionDocumentsRead   Permission = "documents:read"
+	PermissionDocumentsWrite  Permission = "documents:write"
+	PermissionSignersManage   Permission = "signers:manage"
+	PermissionRemindersSend   Permission = "reminders:send"
+	PermissionCampaignsManage Permission = "campaigns:manage"
+	PermissionWebhooksManage  Permission = "webhooks:manage"
+	PermissionSettingsManage  Permission = "settings:manage"
+	PermissionRolesManage     Permission = "roles:manage"
+)
+
+// AdminRole groups permissions delegated to an admin user
+type AdminRole string
+
+const (
+	AdminRoleViewer           AdminRole = "viewer"
+	AdminRoleDocumentManager  AdminRole = "document-manager"
+	AdminRoleReminderOperator AdminRole = "reminder-operator"
+	AdminRoleDepartmentAdmin  AdminRole = "department-admin"
+	AdminRoleSuperAdmin       AdminRole = "super-admin"
+)
+
+var adminRolePermissions = map[AdminRole][]Permission{
+	AdminRoleViewer: {
+		PermissionDocumentsRead,
+	},
+	AdminRoleDocumentManager: {
+		PermissionDocumentsRead,
+		PermissionDocumentsWrite,
+		PermissionSignersManage,
+		PermissionCampaignsManage,
+	},
+	AdminRoleReminderOperator: {
+		PermissionDocumentsRead,
+		PermissionRemindersSend,
+	},
+	AdminRoleDepartmentAdmin: {
+		PermissionDocumentsRead,
+		PermissionDocumentsWrite,
+		PermissionSignersManage,
+		PermissionRemindersSend,
+	},
+	AdminRoleSuperAdmin: {
+		PermissionDocumentsRead,
+		PermissionDocumentsWrite,
+		PermissionSignersManage,
+		PermissionRemindersSend,
+		PermissionCampaignsManage,
+		PermissionWebhooksManage,
+		PermissionSettingsManage,
+		PermissionRolesManage,
+	},
+}
+
+// IsValid reports whether the role is a known role
+func (r AdminRole) IsValid() bool {
+	_, ok := adminRolePermissions[r]
+	return ok
+}
+
+// Permissions returns the permissions granted by the role
+func (r AdminRole) Permissions() []Permission {
+	return append([]Permission(nil), adminRolePermissions[r]...)
+}
+
+// HasPermission reports whether the role grants the permission
+func (r AdminRole) HasPermission(permission Permission) bool {
+	for _, p := range adminRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDepartmentScoped reports whether the role is limited to a department subtree
+func (r AdminRole) IsDepartmentScoped() bool {
+	return r == AdminRoleDepartmentAdmin
+}
+
+// AdminRoleAssignment links a user email to a delegated admin role
+type AdminRoleAssignment struct {
+	ID           int64     `json:"id"`
+	TenantID     uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Email        string    `json:"email"`
+	Role         AdminRole `json:"role"`
+	DepartmentID *int64    `json:"departmentId,omitempty"` // Set for department-admins only
+	GrantedBy    string    `json:"grantedBy,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
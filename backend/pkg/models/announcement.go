@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnnouncementSeverity selects how prominently the SPA renders a banner
+type AnnouncementSeverity string
+
+const (
+	AnnouncementInfo     AnnouncementSeverity = "info"
+	AnnouncementWarning  AnnouncementSeverity = "warning"
+	AnnouncementCritical AnnouncementSeverity = "critical"
+)
+
+// IsValid reports whether the severity is supported
+func (s AnnouncementSeverity) IsValid() bool {
+	return s == AnnouncementInfo || s == AnnouncementWarning || s == AnnouncementCritical
+}
+
+// AnnouncementAudience selects who sees a banner
+type AnnouncementAudience string
+
+const (
+	AudienceAll           AnnouncementAudience = "all"           // Every visitor, signed in or not
+	AudienceAuthenticated AnnouncementAudience = "authenticated" // Signed-in users
+	AudienceAdmins        AnnouncementAudience = "admins"        // Admins only
+)
+
+// IsValid reports whether the audience is supported
+func (a AnnouncementAudience) IsValid() bool {
+	return a == AudienceAll || a == AudienceAuthenticated || a == AudienceAdmins
+}
+
+// Announcement is a banner published by admins to the users of their tenant
+type Announcement struct {
+	ID        int64                `json:"id"`
+	TenantID  uuid.UUID            `json:"-"`
+	Severity  AnnouncementSeverity `json:"severity"`
+	Message   string               `json:"message"`
+	Audience  AnnouncementAudience `json:"audience"`
+	StartsAt  *time.Time           `json:"startsAt,omitempty"` // Published immediately when nil
+	EndsAt    *time.Time           `json:"endsAt,omitempty"`   // Published until deleted when nil
+	CreatedBy string               `json:"createdBy,omitempty"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+// IsActive reports whether the announcement is within its publication window at the given time
+func (a *Announcement) IsActive(at time.Time) bool {
+	if a.StartsAt != nil && at.Before(*a.StartsAt) {
+		return false
+	}
+	return a.EndsAt == nil || at.Before(*a.EndsAt)
+}
+
+// VisibleTo reports whether the audience of the announcement includes the viewer
+func (a *Announcement) VisibleTo(authenticated, admin bool) bool {
+	switch a.Audience {
+	case AudienceAdmins:
+		return admin
+	case AudienceAuthenticated:
+		return authenticated
+	default:
+		return true
+	}
+}
+
+type AnnouncementInput struct {
+	Severity  AnnouncementSeverity
+	Message   string
+	Audience  AnnouncementAudience
+	StartsAt  *time.Time
+	EndsAt    *time.Time
+	CreatedBy string
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey authenticates an external integration. Its scopes are admin permissions;
+// the key itself is never stored, only its hash.
+type APIKey struct {
+	ID         int64        `json:"id"`
+	TenantID   uuid.UUID    `json:"-"`
+	Name       string       `json:"name"`
+	KeyPrefix  string       `json:"keyPrefix"`
+	Scopes     []Permission `json:"scopes"`
+	CreatedBy  string       `json:"createdBy"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	LastUsedAt *time.Time   `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time   `json:"revokedAt,omitempty"`
+}
+
+// HasScope reports whether the key grants the permission
+func (k *APIKey) HasScope(permission Permission) bool {
+	for _, s := range k.Scopes {
+		if s == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRevoked reports whether the key can no longer be used
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+// CacheStats reports the activity of an in-memory cache since startup
+type CacheStats struct {
+	Name          string  `json:"name"`
+	Enabled       bool    `json:"enabled"`
+	Listening     bool    `json:"listening"` // Cross-instance invalidation is connected
+	Entries       int     `json:"entries"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	Bypasses      int64   `json:"bypasses"`
+	Invalidations int64   `json:"invalidations"`
+	HitRate       float64 `json:"hit_rate"` // Percentage 0-100 of cached reads
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignSchedule is the recurrence of a campaign
+type CampaignSchedule string
+
+const (
+	CampaignScheduleYearly    CampaignSchedule = "yearly"
+	CampaignScheduleQuarterly CampaignSchedule = "quarterly"
+)
+
+// IsValid reports whether the schedule is supported
+func (s CampaignSchedule) IsValid() bool {
+	return s == CampaignScheduleYearly || s == CampaignScheduleQuarterly
+}
+
+// Next returns the run following t for this schedule
+func (s CampaignSchedule) Next(t time.Time) time.Time {
+	if s == CampaignScheduleQuarterly {
+		return t.AddDate(0, 3, 0)
+	}
+	return t.AddDate(1, 0, 0)
+}
+
+// Campaign periodically re-issues a source document to its expected signers
+type Campaign struct {
+	ID        int64            `json:"id"`
+	TenantID  uuid.UUID        `json:"tenant_id" db:"tenant_id"`
+	Title     string           `json:"title"`
+	DocID     string           `json:"docId"`
+	Schedule  CampaignSchedule `json:"schedule"`
+	Active    bool             `json:"active"`
+	NextRunAt time.Time        `json:"nextRunAt"`
+	LastRunAt *time.Time       `json:"lastRunAt,omitempty"`
+	CreatedBy string           `json:"createdBy,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+type CampaignInput struct {
+	Title     string           `json:"title"`
+	DocID     string           `json:"docId"`
+	Schedule  CampaignSchedule `json:"schedule"`
+	Active    bool             `json:"active"`
+	NextRunAt time.Time        `json:"nextRunAt"`
+	CreatedBy string           `json:"createdBy,omitempty"`
+}
+
+// CampaignRun records one occurrence of a campaign and its completion
+type CampaignRun struct {
+	ID             int64     `json:"id"`
+	TenantID       uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	CampaignID     int64     `json:"campaignId"`
+	DocID          string    `json:"docId"`
+	StartedAt      time.Time `json:"startedAt"`
+	TriggeredBy    string    `json:"triggeredBy,omitempty"`
+	ExpectedCount  int       `json:"expectedCount"`
+	NotifiedCount  int       `json:"notifiedCount"`
+	SignedCount    int       `json:"signedCount"`
+	CompletionRate float64   `json:"completionRate"`
+}
+
+type CampaignRunInput struct {
+	CampaignID    int64
+	DocID         string
+	TriggeredBy   string
+	ExpectedCount int
+	NotifiedCount int
+}
@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCertificateNotFound = errors.New("certificate not found")
+
+// CertificateVerification is the public outcome of checking a signature certificate.
+// Details are only returned when the record hash printed on the certificate matches.
+type CertificateVerification struct {
+	SignatureID int64      `json:"signatureId"`
+	Valid       bool       `json:"valid"`
+	DocID       string     `json:"docId,omitempty"`
+	DocTitle    string     `json:"docTitle,omitempty"`
+	SignerName  string     `json:"signerName,omitempty"`
+	SignedAt    *time.Time `json:"signedAt,omitempty"`
+	DocDeleted  bool       `json:"docDeleted,omitempty"`
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CompletionTrigger is the condition that fires a completion notification
+type CompletionTrigger string
+
+const (
+	CompletionTriggerCompleted CompletionTrigger = "completed"
+	CompletionTriggerThreshold CompletionTrigger = "threshold"
+	CompletionTriggerDeadline  CompletionTrigger = "deadline"
+)
+
+// CompletionSettings configures when the document creator is notified about signing progress.
+// Documents without stored settings notify the creator by email on completion only.
+type CompletionSettings struct {
+	TenantID         uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	DocID            string     `json:"docId"`
+	NotifyOnComplete bool       `json:"notifyOnComplete"`
+	ThresholdPercent *int       `json:"thresholdPercent,omitempty"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	NotifyWebhook    bool       `json:"notifyWebhook"`
+	SlackWebhookURL  string     `json:"slackWebhookUrl,omitempty"`
+
+	CompletedNotifiedAt *time.Time `json:"completedNotifiedAt,omitempty"`
+	ThresholdNotifiedAt *time.Time `json:"thresholdNotifiedAt,omitempty"`
+	DeadlineNotifiedAt  *time.Time `json:"deadlineNotifiedAt,omitempty"`
+
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NotifiedAt returns when the notification for trigger was sent, or nil
+func (s *CompletionSettings) NotifiedAt(trigger CompletionTrigger) *time.Time {
+	switch trigger {
+	case CompletionTriggerCompleted:
+		return s.CompletedNotifiedAt
+	case CompletionTriggerThreshold:
+		return s.ThresholdNotifiedAt
+	case CompletionTriggerDeadline:
+		return s.DeadlineNotifiedAt
+	}
+	return nil
+}
+
+type CompletionSettingsInput struct {
+	NotifyOnComplete bool       `json:"notifyOnComplete"`
+	ThresholdPercent *int       `json:"thresholdPercent,omitempty"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	NotifyWebhook    bool       `json:"notifyWebhook"`
+	SlackWebhookURL  string     `json:"slackWebhookUrl,omitempty"`
+}
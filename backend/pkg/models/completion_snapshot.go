@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CompletionSnapshot is the signing progress of a document recorded on a given day
+type CompletionSnapshot struct {
+	ID             int64
+	TenantID       uuid.UUID
+	DocID          string
+	Date           time.Time // Day of the snapshot (UTC midnight)
+	ExpectedCount  int       // Expected signers
+	SignedCount    int       // Expected signers who had signed
+	SignatureCount int       // All signatures, expected or not
+	CreatedAt      time.Time
+}
+
+// CompletionRate returns the percentage (0-100) of expected signers who had signed
+func (s *CompletionSnapshot) CompletionRate() float64 {
+	if s.ExpectedCount == 0 {
+		return 0
+	}
+	return float64(s.SignedCount) / float64(s.ExpectedCount) * 100
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ComplianceReportStatus is the generation state of a compliance report
+type ComplianceReportStatus string
+
+const (
+	ComplianceReportPending    ComplianceReportStatus = "pending"
+	ComplianceReportProcessing ComplianceReportStatus = "processing"
+	ComplianceReportReady      ComplianceReportStatus = "ready"
+	ComplianceReportFailed     ComplianceReportStatus = "failed"
+)
+
+// ComplianceReport is a cross-document signing report over a period, generated in the background
+type ComplianceReport struct {
+	ID          int64                  `json:"id"`
+	Period      string                 `json:"period"`
+	PeriodStart time.Time              `json:"periodStart"`
+	PeriodEnd   time.Time              `json:"periodEnd"` // Exclusive
+	Status      ComplianceReportStatus `json:"status"`
+	Error       string                 `json:"error,omitempty"`
+	RequestedBy string                 `json:"requestedBy"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	StartedAt   *time.Time             `json:"startedAt,omitempty"`
+	CompletedAt *time.Time             `json:"completedAt,omitempty"`
+
+	Data *ComplianceReportData `json:"-"` // Set once ready
+}
+
+// ComplianceReportData is the content of a compliance report, as of the end of its period
+type ComplianceReportData struct {
+	Period      string                  `json:"period"`
+	PeriodStart time.Time               `json:"periodStart"`
+	PeriodEnd   time.Time               `json:"periodEnd"`
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Totals      ComplianceTotals        `json:"totals"`
+	Documents   []ComplianceDocumentRow `json:"documents"`
+	Users       []ComplianceUserRow     `json:"users"`
+}
+
+// ComplianceTotals sums the document rows of a report
+type ComplianceTotals struct {
+	Documents   int `json:"documents"`
+	Expected    int `json:"expected"`
+	Signed      int `json:"signed"`
+	Late        int `json:"late"`
+	Outstanding int `json:"outstanding"`
+}
+
+// ComplianceDocumentRow is the signing progress of one document at the end of the period
+type ComplianceDocumentRow struct {
+	DocID          string     `json:"docId"`
+	Title          string     `json:"title"`
+	Deadline       *time.Time `json:"deadline,omitempty"`
+	Expected       int        `json:"expected"`       // Expected signers added before the end of the period
+	Signed         int        `json:"signed"`         // Of them, signed before the end of the period
+	SignedInPeriod int        `json:"signedInPeriod"` // Of them, signed during the period
+	Late           int        `json:"late"`           // Of them, signed after the deadline
+	Outstanding    int        `json:"outstanding"`    // Not signed at the end of the period
+}
+
+// CompletionRate returns the percentage (0-100) of expected signers who had signed
+func (r ComplianceDocumentRow) CompletionRate() float64 {
+	if r.Expected == 0 {
+		return 0
+	}
+	return float64(r.Signed) / float64(r.Expected) * 100
+}
+
+// ComplianceUserRow lists the documents an expected signer had not signed at the end of the period
+type ComplianceUserRow struct {
+	Email       string   `json:"email"`
+	Name        string   `json:"name,omitempty"`
+	Outstanding int      `json:"outstanding"`
+	DocIDs      []string `json:"docIds"`
+}
+
+// ReportPeriod is the calendar year, quarter or month a compliance report covers
+type ReportPeriod struct {
+	Name  string    // 2024, 2024-Q4 or 2024-11
+	Start time.Time // UTC
+	End   time.Time // UTC, exclusive
+}
+
+// ParseReportPeriod parses a period written 2024, 2024-Q4 or 2024-11, case-insensitively
+func ParseReportPeriod(period string) (ReportPeriod, error) {
+	name := strings.ToUpper(strings.TrimSpace(period))
+	yearPart, rest, hasRest := strings.Cut(name, "-")
+	year, err := strconv.Atoi(yearPart)
+	if err != nil || len(yearPart) != 4 || year < 2000 {
+		return ReportPeriod{}, ErrInvalidPeriod
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	switch {
+	case !hasRest:
+		return ReportPeriod{Name: name, Start: start, End: start.AddDate(1, 0, 0)}, nil
+	case len(rest) == 2 && rest[0] == 'Q' && rest[1] >= '1' && rest[1] <= '4':
+		start = start.AddDate(0, int(rest[1]-'1')*3, 0)
+		return ReportPeriod{Name: name, Start: start, End: start.AddDate(0, 3, 0)}, nil
+	case len(rest) == 2:
+		month, err := strconv.Atoi(rest)
+		if err != nil || month < 1 || month > 12 {
+			return ReportPeriod{}, ErrInvalidPeriod
+		}
+		start = start.AddDate(0, month-1, 0)
+		return ReportPeriod{Name: name, Start: start, End: start.AddDate(0, 1, 0)}, nil
+	}
+	return ReportPeriod{}, ErrInvalidPeriod
+}
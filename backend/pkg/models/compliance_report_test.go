@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseReportPeriod(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		period string
+		want   ReportPeriod
+	}{
+		{"2024", ReportPeriod{Name: "2024", Start: day(2024, 1, 1), End: day(2025, 1, 1)}},
+		{"2024-Q1", ReportPeriod{Name: "2024-Q1", Start: day(2024, 1, 1), End: day(2024, 4, 1)}},
+		{" 2024-q4 ", ReportPeriod{Name: "2024-Q4", Start: day(2024, 10, 1), End: day(2025, 1, 1)}},
+		{"2024-02", ReportPeriod{Name: "2024-02", Start: day(2024, 2, 1), End: day(2024, 3, 1)}},
+		{"2024-12", ReportPeriod{Name: "2024-12", Start: day(2024, 12, 1), End: day(2025, 1, 1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			got, err := ParseReportPeriod(tt.period)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Name != tt.want.Name || !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	for _, period := range []string{"", "24", "2024-Q5", "2024-Q0", "2024-13", "2024-1", "2024-H1", "1999", "2024-11-01"} {
+		if _, err := ParseReportPeriod(period); !errors.Is(err, ErrInvalidPeriod) {
+			t.Errorf("%q: expected ErrInvalidPeriod, got %v", period, err)
+		}
+	}
+}
+
+func TestComplianceDocumentRow_CompletionRate(t *testing.T) {
+	if rate := (ComplianceDocumentRow{Expected: 4, Signed: 3}).CompletionRate(); rate != 75 {
+		t.Errorf("expected 75, got %v", rate)
+	}
+	if rate := (ComplianceDocumentRow{}).CompletionRate(); rate != 0 {
+		t.Errorf("expected 0, got %v", rate)
+	}
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+)
+
+// ConsentText is the consent sentence of a catalog version in one language
+type ConsentText struct {
+	Version   int       `json:"version"`
+	Locale    string    `json:"locale"`
+	Text      string    `json:"text"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ConsentVersionInput publishes a new consent version with its text per locale
+type ConsentVersionInput struct {
+	Texts     map[string]string
+	CreatedBy string
+}
+
+// DocumentConsent reports the consent version a document is signed with
+type DocumentConsent struct {
+	DocID string `json:"docId"`
+	// Version is 0 while the tenant has no consent catalog
+	Version int        `json:"version"`
+	Pinned  bool       `json:"pinned"`
+	SetBy   string     `json:"setBy,omitempty"`
+	SetAt   *time.Time `json:"setAt,omitempty"`
+}
+
+// SelectConsentText picks the text of a version shown for a display locale: the same
+// language, else English, else the first text
+func SelectConsentText(texts []*ConsentText, locale string) *ConsentText {
+	if len(texts) == 0 {
+		return nil
+	}
+	locale, _ = NormalizeLocale(locale)
+	var english *ConsentText
+	for _, t := range texts {
+		if t.Locale == locale {
+			return t
+		}
+		if t.Locale == "en" {
+			english = t
+		}
+	}
+	if english != nil {
+		return english
+	}
+	return texts[0]
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "testing"
+
+func TestSelectConsentText(t *testing.T) {
+	texts := []*ConsentText{
+		{Version: 2, Locale: "de", Text: "Ich bestätige"},
+		{Version: 2, Locale: "en", Text: "I confirm"},
+		{Version: 2, Locale: "fr", Text: "Je confirme"},
+	}
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"fr-FR", "fr"},
+		{"de", "de"},
+		{"es", "en"},
+		{"", "en"},
+	}
+	for _, tt := range tests {
+		if got := SelectConsentText(texts, tt.locale); got == nil || got.Locale != tt.want {
+			t.Errorf("SelectConsentText(%q) = %+v, want locale %q", tt.locale, got, tt.want)
+		}
+	}
+
+	if got := SelectConsentText(texts[:1], "fr"); got == nil || got.Locale != "de" {
+		t.Errorf("expected the first text without English, got %+v", got)
+	}
+	if SelectConsentText(nil, "fr") != nil {
+		t.Error("expected nil without texts")
+	}
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxCORSMaxAge bounds how long browsers may cache a preflight response, in seconds
+const MaxCORSMaxAge = 86400
+
+// corsMethods are the methods a policy may allow
+var corsMethods = map[string]bool{
+	"GET": true, "HEAD": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "OPTIONS": true,
+}
+
+var (
+	corsOrigin = regexp.MustCompile(`^https?://(?:\*\.)?[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*(?::[0-9]{1,5})?$`)
+	corsHeader = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
+// CORSConfig holds the cross-origin policies of the API. The admin policy applies to the admin
+// API and sends credentials, so that the session cookie is used; the public one applies to the
+// other endpoints, such as the document status read by embeds and portals, without credentials.
+type CORSConfig struct {
+	Public CORSPolicy `json:"public"`
+	Admin  CORSPolicy `json:"admin"`
+}
+
+// Validate checks both policies; the admin policy cannot allow any origin
+func (c *CORSConfig) Validate() error {
+	if err := c.Public.Validate(); err != nil {
+		return fmt.Errorf("public: %w", err)
+	}
+	if err := c.Admin.Validate(); err != nil {
+		return fmt.Errorf("admin: %w", err)
+	}
+	for _, origin := range c.Admin.AllowedOrigins {
+		if origin == "*" {
+			return fmt.Errorf("admin: \"*\" cannot be allowed with credentials")
+		}
+	}
+	return nil
+}
+
+// CORSPolicy allows cross-origin requests from the listed origins. Empty methods and headers
+// keep the defaults of the API; a zero max age lets browsers use their own.
+type CORSPolicy struct {
+	AllowedOrigins []string `json:"allowed_origins,omitempty"` // Origins such as https://intranet.example.com, *.example.com subdomains or *
+	AllowedMethods []string `json:"allowed_methods,omitempty"`
+	AllowedHeaders []string `json:"allowed_headers,omitempty"`
+	MaxAgeSeconds  int      `json:"max_age_seconds,omitempty"`
+}
+
+// Validate checks the origins, methods, headers and max age of the policy
+func (p *CORSPolicy) Validate() error {
+	for _, origin := range p.AllowedOrigins {
+		if origin != "*" && !corsOrigin.MatchString(origin) {
+			return fmt.Errorf("invalid origin %q", origin)
+		}
+	}
+	for _, method := range p.AllowedMethods {
+		if !corsMethods[strings.ToUpper(method)] {
+			return fmt.Errorf("invalid method %q", method)
+		}
+	}
+	for _, header := range p.AllowedHeaders {
+		if !corsHeader.MatchString(header) {
+			return fmt.Errorf("invalid header %q", header)
+		}
+	}
+	if p.MaxAgeSeconds < 0 || p.MaxAgeSeconds > MaxCORSMaxAge {
+		return fmt.Errorf("max_age_seconds must be between 0 and %d", MaxCORSMaxAge)
+	}
+	return nil
+}
+
+// AllowsOrigin reports whether the policy allows the origin of a request. Origins compare
+// case-insensitively; https://*.example.com matches the subdomains of example.com only.
+func (p *CORSPolicy) AllowsOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	origin = strings.ToLower(origin)
+	for _, allowed := range p.AllowedOrigins {
+		allowed = strings.ToLower(allowed)
+		switch {
+		case allowed == "*", allowed == origin:
+			return true
+		case strings.Contains(allowed, "://*."):
+			scheme, suffix, _ := strings.Cut(allowed, "*")
+			host := strings.TrimPrefix(origin, scheme)
+			if strings.HasPrefix(origin, scheme) && strings.HasSuffix(host, suffix) && len(host) > len(suffix) &&
+				!strings.ContainsAny(strings.TrimSuffix(host, suffix), ":/") {
+				return true
+			}
+		}
+	}
+	return false
+}
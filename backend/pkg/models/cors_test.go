@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCORSPolicy_AllowsOrigin(t *testing.T) {
+	policy := CORSPolicy{AllowedOrigins: []string{"https://intranet.example.com", "https://*.portal.example.com"}}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://intranet.example.com", true},
+		{"https://Intranet.Example.com", true},
+		{"http://intranet.example.com", false},
+		{"https://intranet.example.com:8443", false},
+		{"https://hr.portal.example.com", true},
+		{"https://a.b.portal.example.com", true},
+		{"https://portal.example.com", false},
+		{"https://evilportal.example.com", false},
+		{"https://hr.portal.example.com.evil.com", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := policy.AllowsOrigin(tt.origin); got != tt.want {
+			t.Errorf("AllowsOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+
+	anyOrigin := CORSPolicy{AllowedOrigins: []string{"*"}}
+	if !anyOrigin.AllowsOrigin("https://anything.example.org") {
+		t.Error("expected * to allow any origin")
+	}
+}
+
+func TestCORSConfig_Validate(t *testing.T) {
+	valid := CORSConfig{
+		Public: CORSPolicy{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET", "head"}, MaxAgeSeconds: 600},
+		Admin:  CORSPolicy{AllowedOrigins: []string{"https://*.example.com"}, AllowedHeaders: []string{"X-CSRF-Token"}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  CORSConfig
+		want string
+	}{
+		{"origin with path", CORSConfig{Public: CORSPolicy{AllowedOrigins: []string{"https://example.com/app"}}}, "public: invalid origin"},
+		{"origin without scheme", CORSConfig{Admin: CORSPolicy{AllowedOrigins: []string{"example.com"}}}, "admin: invalid origin"},
+		{"unknown method", CORSConfig{Public: CORSPolicy{AllowedMethods: []string{"TRACE"}}}, "invalid method"},
+		{"invalid header", CORSConfig{Public: CORSPolicy{AllowedHeaders: []string{"X-Token: 1"}}}, "invalid header"},
+		{"max age", CORSConfig{Admin: CORSPolicy{MaxAgeSeconds: MaxCORSMaxAge + 1}}, "max_age_seconds"},
+		{"any origin with credentials", CORSConfig{Admin: CORSPolicy{AllowedOrigins: []string{"*"}}}, "credentials"},
+	}
+	for _, tt := range tests {
+		err := tt.cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() = %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+	security := SecurityConfig{CORS: CORSConfig{Admin: CORSPolicy{AllowedOrigins: []string{"*"}}}}
+	if err := security.Validate(); err == nil || !strings.HasPrefix(err.Error(), "cors.admin:") {
+		t.Errorf("expected the security settings to reject the CORS policy, got %v", err)
+	}
+}
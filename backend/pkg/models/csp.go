@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CSPSources are sources added to the built-in Content-Security-Policy of the frontend,
+// e.g. an external font host or the websocket origin of a reverse proxy
+type CSPSources struct {
+	ScriptSrc  []string `json:"script_src,omitempty"`
+	StyleSrc   []string `json:"style_src,omitempty"`
+	ConnectSrc []string `json:"connect_src,omitempty"`
+	ImgSrc     []string `json:"img_src,omitempty"`
+	FontSrc    []string `json:"font_src,omitempty"`
+}
+
+// Validate checks that every source is a valid CSP source expression
+func (s *CSPSources) Validate() error {
+	lists := []struct {
+		directive string
+		sources   []string
+	}{
+		{"script-src", s.ScriptSrc},
+		{"style-src", s.StyleSrc},
+		{"connect-src", s.ConnectSrc},
+		{"img-src", s.ImgSrc},
+		{"font-src", s.FontSrc},
+	}
+	for _, list := range lists {
+		for _, source := range list.sources {
+			if err := ValidateCSPSource(source); err != nil {
+				return fmt.Errorf("%s: %w", list.directive, err)
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	cspKeywords = map[string]bool{
+		"'self'":             true,
+		"'unsafe-inline'":    true,
+		"'unsafe-eval'":      true,
+		"'wasm-unsafe-eval'": true,
+		"'unsafe-hashes'":    true,
+		"'strict-dynamic'":   true,
+		"'report-sample'":    true,
+	}
+	cspSchemeSource = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:$`)
+	cspHashSource   = regexp.MustCompile(`^'sha(?:256|384|512)-[A-Za-z0-9+/_-]+={0,2}'$`)
+	cspHostSource   = regexp.MustCompile(`^(?:[a-zA-Z][a-zA-Z0-9+.-]*://)?(?:\*|(?:\*\.)?[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*)(?::(?:[0-9]{1,5}|\*))?(?:/[^\s;,']*)?$`)
+)
+
+// ValidateCSPSource checks a single source expression: a keyword such as 'self', a hash,
+// a scheme (https:, wss:, data:) or a host with optional scheme, port and path.
+// Nonces are refused since the policy is static.
+func ValidateCSPSource(source string) error {
+	switch {
+	case cspKeywords[strings.ToLower(source)]:
+		return nil
+	case cspHashSource.MatchString(source), cspSchemeSource.MatchString(source), cspHostSource.MatchString(source):
+		return nil
+	}
+	return fmt.Errorf("invalid source %q", source)
+}
+
+// ParseCSPSources parses a list of sources separated by spaces or commas
+func ParseCSPSources(raw string) ([]string, error) {
+	sources := splitCSPList(raw)
+	for _, source := range sources {
+		if err := ValidateCSPSource(source); err != nil {
+			return nil, err
+		}
+	}
+	return sources, nil
+}
+
+var cspOrigin = regexp.MustCompile(`^https?://(?:\*\.)?[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*(?::[0-9]{1,5})?/?$`)
+
+// ValidateFrameAncestors checks a list of origins allowed to frame a page: 'self' or origins
+// such as https://intranet.example.com (subdomain wildcards allowed). 'none' must be alone.
+func ValidateFrameAncestors(ancestors []string) error {
+	for _, ancestor := range ancestors {
+		switch {
+		case strings.EqualFold(ancestor, "'none'"):
+			if len(ancestors) > 1 {
+				return fmt.Errorf("'none' cannot be combined with other origins")
+			}
+		case strings.EqualFold(ancestor, "'self'"), cspOrigin.MatchString(ancestor):
+		default:
+			return fmt.Errorf("invalid origin %q", ancestor)
+		}
+	}
+	return nil
+}
+
+// ParseFrameAncestors parses a list of origins separated by spaces or commas
+func ParseFrameAncestors(raw string) ([]string, error) {
+	ancestors := splitCSPList(raw)
+	if err := ValidateFrameAncestors(ancestors); err != nil {
+		return nil, err
+	}
+	return ancestors, nil
+}
+
+func splitCSPList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
+// CSPViolation aggregates the Content-Security-Policy violations reported by browsers
+// for the same directive, blocked resource and page
+type CSPViolation struct {
+	Directive   string    `json:"directive"`
+	BlockedURI  string    `json:"blockedUri"`
+	DocumentURI string    `json:"documentUri"`
+	SourceFile  string    `json:"sourceFile,omitempty"`
+	LineNumber  int       `json:"lineNumber,omitempty"`
+	Sample      string    `json:"sample,omitempty"`
+	Disposition string    `json:"disposition,omitempty"` // "enforce" or "report"
+	Count       int       `json:"count"`
+	FirstSeenAt time.Time `json:"firstSeenAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+}
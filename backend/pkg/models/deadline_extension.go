@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxExtensionReasonLength bounds the reason of an extension request and the note of a decision
+	MaxExtensionReasonLength = 1000
+
+	// MaxExtensionDuration bounds how far in the future an extended deadline may be
+	MaxExtensionDuration = 365 * 24 * time.Hour
+)
+
+// ExtensionStatus is the state of a deadline extension request
+type ExtensionStatus string
+
+const (
+	ExtensionStatusPending  ExtensionStatus = "pending"
+	ExtensionStatusApproved ExtensionStatus = "approved"
+	ExtensionStatusDenied   ExtensionStatus = "denied"
+)
+
+// IsValid reports whether s is a known extension status
+func (s ExtensionStatus) IsValid() bool {
+	switch s {
+	case ExtensionStatusPending, ExtensionStatusApproved, ExtensionStatusDenied:
+		return true
+	}
+	return false
+}
+
+// DeadlineExtension is the request of an expected signer for more time to sign a document, and
+// the decision of the document owner on it. Rows are kept as the trail of the decisions.
+type DeadlineExtension struct {
+	ID                int64           `json:"id"`
+	DocID             string          `json:"docId"`
+	SignerEmail       string          `json:"signerEmail"`
+	Reason            string          `json:"reason"`
+	RequestedDeadline time.Time       `json:"requestedDeadline"`
+	Status            ExtensionStatus `json:"status"`
+	GrantedDeadline   *time.Time      `json:"grantedDeadline,omitempty"` // Set on approval, the requested one unless the owner chose another
+	DecidedBy         *string         `json:"decidedBy,omitempty"`
+	DecidedAt         *time.Time      `json:"decidedAt,omitempty"`
+	DecisionNote      string          `json:"decisionNote,omitempty"`
+	CreatedAt         time.Time       `json:"createdAt"`
+}
+
+// DeadlineExtensionView is the deadline of a document as seen by a user, with the extension
+// requests they may see: all of them for the document owner, their own ones otherwise
+type DeadlineExtensionView struct {
+	Deadline          *time.Time           `json:"deadline,omitempty"`          // Deadline of the document
+	EffectiveDeadline *time.Time           `json:"effectiveDeadline,omitempty"` // Deadline of the user, with their approved extension
+	CanDecide         bool                 `json:"canDecide"`
+	Extensions        []*DeadlineExtension `json:"extensions"`
+}
+
+// NormalizeExtensionText trims the reason of a request or the note of a decision. It returns
+// ErrInvalidExtension for texts longer than MaxExtensionReasonLength, and for empty
+// texts when required.
+func NormalizeExtensionText(text string, required bool) (string, error) {
+	text = strings.TrimSpace(text)
+	if (required && text == "") || utf8.RuneCountInString(text) > MaxExtensionReasonLength {
+		return "", ErrInvalidExtension
+	}
+	return text, nil
+}
+
+// SignerExtensions is the extension situation of one signer of a document
+type SignerExtensions struct {
+	Pending  *DeadlineExtension // Request awaiting a decision
+	Approved *DeadlineExtension // Last approved request
+}
+
+// GroupExtensions groups the extension requests of a document by signer email, lowercased
+func GroupExtensions(extensions []*DeadlineExtension) map[string]*SignerExtensions {
+	grouped := make(map[string]*SignerExtensions)
+	for _, e := range extensions {
+		email := strings.ToLower(e.SignerEmail)
+		signer := grouped[email]
+		if signer == nil {
+			signer = &SignerExtensions{}
+			grouped[email] = signer
+		}
+		switch e.Status {
+		case ExtensionStatusPending:
+			signer.Pending = e
+		case ExtensionStatusApproved:
+			if signer.Approved == nil || decidedAfter(e, signer.Approved) {
+				signer.Approved = e
+			}
+		}
+	}
+	return grouped
+}
+
+func decidedAfter(a, b *DeadlineExtension) bool {
+	if a.DecidedAt == nil || b.DecidedAt == nil {
+		return a.ID > b.ID
+	}
+	return a.DecidedAt.After(*b.DecidedAt) || (a.DecidedAt.Equal(*b.DecidedAt) && a.ID > b.ID)
+}
+
+// EffectiveDeadline returns the deadline of the signer: the later of the document deadline and
+// the last approved extension. Without a document deadline, there is none.
+func (s *SignerExtensions) EffectiveDeadline(documentDeadline *time.Time) *time.Time {
+	if documentDeadline == nil {
+		return nil
+	}
+	if s == nil || s.Approved == nil || s.Approved.GrantedDeadline == nil || !s.Approved.GrantedDeadline.After(*documentDeadline) {
+		return documentDeadline
+	}
+	return s.Approved.GrantedDeadline
+}
+
+// RunningExtension returns the approved extension still running at now, nil when the signer has
+// none or it has passed
+func (s *SignerExtensions) RunningExtension(now time.Time) *DeadlineExtension {
+	if s == nil || s.Approved == nil || s.Approved.GrantedDeadline == nil || !s.Approved.GrantedDeadline.After(now) {
+		return nil
+	}
+	return s.Approved
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeExtensionText(t *testing.T) {
+	got, err := NormalizeExtensionText("  On leave until the 15th\n", true)
+	if err != nil || got != "On leave until the 15th" {
+		t.Errorf("NormalizeExtensionText = %q, %v", got, err)
+	}
+	if got, err := NormalizeExtensionText(" ", false); err != nil || got != "" {
+		t.Errorf("expected an optional empty text to be accepted, got %q, %v", got, err)
+	}
+
+	for _, input := range []string{"", " \n", strings.Repeat("a", MaxExtensionReasonLength+1)} {
+		if _, err := NormalizeExtensionText(input, true); !errors.Is(err, ErrInvalidExtension) {
+			t.Errorf("NormalizeExtensionText(%.20q): expected ErrInvalidExtension, got %v", input, err)
+		}
+	}
+}
+
+func TestGroupExtensions(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time {
+		d := now.AddDate(0, 0, days)
+		return &d
+	}
+	extensions := []*DeadlineExtension{
+		{ID: 1, SignerEmail: "Alice@example.com", Status: ExtensionStatusApproved, GrantedDeadline: at(10), DecidedAt: at(-5)},
+		{ID: 2, SignerEmail: "alice@example.com", Status: ExtensionStatusApproved, GrantedDeadline: at(3), DecidedAt: at(-1)},
+		{ID: 3, SignerEmail: "alice@example.com", Status: ExtensionStatusPending},
+		{ID: 4, SignerEmail: "bob@example.com", Status: ExtensionStatusDenied, DecidedAt: at(-1)},
+	}
+
+	grouped := GroupExtensions(extensions)
+	alice := grouped["alice@example.com"]
+	if alice == nil || alice.Pending == nil || alice.Pending.ID != 3 {
+		t.Fatalf("expected the pending request of alice, got %+v", alice)
+	}
+	if alice.Approved == nil || alice.Approved.ID != 2 {
+		t.Errorf("expected the last approved request to win, got %+v", alice.Approved)
+	}
+	if bob := grouped["bob@example.com"]; bob == nil || bob.Pending != nil || bob.Approved != nil {
+		t.Errorf("expected a denied request to grant nothing, got %+v", bob)
+	}
+
+	if got := alice.EffectiveDeadline(at(1)); !got.Equal(*at(3)) {
+		t.Errorf("expected the extended deadline, got %v", got)
+	}
+	if got := alice.EffectiveDeadline(at(7)); !got.Equal(*at(7)) {
+		t.Errorf("expected a later document deadline to win, got %v", got)
+	}
+	if alice.EffectiveDeadline(nil) != nil {
+		t.Error("expected no deadline without a document deadline")
+	}
+	var none *SignerExtensions
+	if got := none.EffectiveDeadline(at(1)); !got.Equal(*at(1)) {
+		t.Errorf("expected the document deadline without extension, got %v", got)
+	}
+
+	if alice.RunningExtension(now) == nil {
+		t.Error("expected the extension to be running")
+	}
+	if alice.RunningExtension(*at(4)) != nil {
+		t.Error("expected a passed extension not to be running")
+	}
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Department is an organization unit of a tenant. Departments form a tree: the admins of a
+// department manage the documents of the department and of all its sub-departments.
+type Department struct {
+	ID            int64     `json:"id"`
+	TenantID      uuid.UUID `json:"-"`
+	ParentID      *int64    `json:"parentId,omitempty"` // Nil for top-level departments
+	Name          string    `json:"name"`
+	MemberCount   int       `json:"memberCount"`
+	DocumentCount int       `json:"documentCount"`
+	CreatedBy     string    `json:"createdBy,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type DepartmentInput struct {
+	Name      string
+	ParentID  *int64
+	CreatedBy string
+}
+
+// DepartmentMember is a signer belonging to a department
+type DepartmentMember struct {
+	Email   string    `json:"email"`
+	Name    string    `json:"name,omitempty"`
+	AddedBy string    `json:"addedBy,omitempty"`
+	AddedAt time.Time `json:"addedAt,omitempty"`
+}
+
+// DepartmentStats aggregates the completion of the documents of a department subtree
+type DepartmentStats struct {
+	DepartmentID   int64   `json:"departmentId"`
+	Departments    int     `json:"departments"` // The department and its sub-departments
+	Documents      int     `json:"documents"`
+	Members        int     `json:"members"`
+	ExpectedCount  int     `json:"expectedCount"`
+	SignedCount    int     `json:"signedCount"`
+	PendingCount   int     `json:"pendingCount"`
+	CompletionRate float64 `json:"completionRate"`
+}
+
+// DepartmentDocument is a document of a department subtree with its completion
+type DepartmentDocument struct {
+	DocID          string    `json:"docId"`
+	Title          string    `json:"title"`
+	DepartmentID   int64     `json:"departmentId"`
+	DepartmentName string    `json:"departmentName"`
+	ExpectedCount  int       `json:"expectedCount"`
+	SignedCount    int       `json:"signedCount"`
+	CompletionRate float64   `json:"completionRate"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
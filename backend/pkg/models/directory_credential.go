@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// DirectoryCredentialStatus is the state of the delegated access to a directory
+type DirectoryCredentialStatus string
+
+const (
+	DirectoryCredentialDisconnected DirectoryCredentialStatus = "disconnected" // No admin consented yet
+	DirectoryCredentialPending      DirectoryCredentialStatus = "pending"      // Consent started, no token received yet
+	DirectoryCredentialActive       DirectoryCredentialStatus = "active"       // The last refresh succeeded
+	DirectoryCredentialError        DirectoryCredentialStatus = "error"        // The last refresh failed, retried on next use
+	DirectoryCredentialRevoked      DirectoryCredentialStatus = "revoked"      // The provider rejected the refresh token: consent again
+)
+
+// DirectoryCredential is the delegated access to a directory an admin consented to. The refresh
+// token is stored encrypted with the settings encryption key and never leaves the server.
+type DirectoryCredential struct {
+	Provider        string                    `json:"provider"`
+	Status          DirectoryCredentialStatus `json:"status"`
+	ConnectedBy     string                    `json:"connectedBy,omitempty"`
+	ConnectedAt     *time.Time                `json:"connectedAt,omitempty"`
+	LastRefreshedAt *time.Time                `json:"lastRefreshedAt,omitempty"`
+	TokenExpiresAt  *time.Time                `json:"tokenExpiresAt,omitempty"` // Expiry of the last access token
+	LastError       string                    `json:"lastError,omitempty"`
+	RevokedAt       *time.Time                `json:"revokedAt,omitempty"`
+	UpdatedAt       *time.Time                `json:"updatedAt,omitempty"`
+	RefreshToken    []byte                    `json:"-"` // Encrypted
+}
+
+// DirectoryToken is the token set returned by a directory provider on consent or refresh
+type DirectoryToken struct {
+	AccessToken  string
+	RefreshToken string    // Empty when the provider keeps the previous refresh token
+	Expiry       time.Time // Zero when the provider gives no lifetime
+}
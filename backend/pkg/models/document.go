@@ -15,6 +15,7 @@ type Document struct {
 	URL               string     `json:"url" db:"url"`
 	Checksum          string     `json:"checksum" db:"checksum"`
 	ChecksumAlgorithm string     `json:"checksum_algorithm" db:"checksum_algorithm"`
+	ContentHash       string     `json:"content_hash,omitempty" db:"content_hash"`
 	Description       string     `json:"description" db:"description"`
 	ReadMode          string     `json:"read_mode" db:"read_mode"`
 	AllowDownload     bool       `json:"allow_download" db:"allow_download"`
@@ -24,6 +25,11 @@ type Document struct {
 	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
 	CreatedBy         string     `json:"created_by" db:"created_by"`
 	DeletedAt         *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	Version           int        `json:"version" db:"version"`
+	DepartmentID      *int64     `json:"department_id,omitempty" db:"department_id"`
+
+	// LandingMessage is shown on the signing page before the acknowledgement button
+	LandingMessage LandingMessage `json:"landing_message,omitempty" db:"landing_message"`
 
 	// Storage fields for uploaded files
 	StorageKey       string `json:"storage_key,omitempty" db:"storage_key"`
@@ -39,12 +45,15 @@ type DocumentInput struct {
 	URL               string `json:"url"`
 	Checksum          string `json:"checksum"`
 	ChecksumAlgorithm string `json:"checksum_algorithm"`
+	ContentHash       string `json:"content_hash,omitempty"`
 	Description       string `json:"description"`
 	ReadMode          string `json:"read_mode"`
 	AllowDownload     *bool  `json:"allow_download"`
 	RequireFullRead   *bool  `json:"require_full_read"`
 	VerifyChecksum    *bool  `json:"verify_checksum"`
 
+	LandingMessage LandingMessage `json:"landing_message,omitempty"`
+
 	// Storage fields for uploaded files
 	StorageKey       string `json:"storage_key,omitempty"`
 	StorageProvider  string `json:"storage_provider,omitempty"`
@@ -53,6 +62,28 @@ type DocumentInput struct {
 	OriginalFilename string `json:"original_filename,omitempty"`
 }
 
+// DocumentPatch represents a partial metadata update: only non-nil fields are persisted
+type DocumentPatch struct {
+	Title             *string `json:"title,omitempty"`
+	URL               *string `json:"url,omitempty"`
+	Checksum          *string `json:"checksum,omitempty"`
+	ChecksumAlgorithm *string `json:"checksum_algorithm,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	ReadMode          *string `json:"read_mode,omitempty"`
+	AllowDownload     *bool   `json:"allow_download,omitempty"`
+	RequireFullRead   *bool   `json:"require_full_read,omitempty"`
+	VerifyChecksum    *bool   `json:"verify_checksum,omitempty"`
+	// LandingMessage replaces every variant when non-nil; an empty map clears the message
+	LandingMessage LandingMessage `json:"landing_message,omitempty"`
+}
+
+// IsEmpty returns true if the patch does not modify any field
+func (p DocumentPatch) IsEmpty() bool {
+	return p.Title == nil && p.URL == nil && p.Checksum == nil && p.ChecksumAlgorithm == nil &&
+		p.Description == nil && p.ReadMode == nil && p.AllowDownload == nil &&
+		p.RequireFullRead == nil && p.VerifyChecksum == nil && p.LandingMessage == nil
+}
+
 // IsStored returns true if the document has an uploaded file
 func (d *Document) IsStored() bool {
 	return d.StorageKey != "" && d.StorageProvider != ""
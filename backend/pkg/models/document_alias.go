@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxDocumentAliasLength bounds the length of a document alias
+const MaxDocumentAliasLength = 128
+
+// DocumentAlias is an alternative ID of a document. Links using it are redirected to the document,
+// so a document keeps its old links when it gets a new, friendlier address.
+type DocumentAlias struct {
+	Alias     string    `json:"alias"`
+	DocID     string    `json:"docId"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NormalizeDocumentAlias trims an alias and checks it only holds characters safe in URLs.
+// It returns ErrInvalidDocumentAlias for empty or too long aliases and other characters.
+func NormalizeDocumentAlias(alias string) (string, error) {
+	alias = strings.TrimSpace(alias)
+	if alias == "" || len(alias) > MaxDocumentAliasLength {
+		return "", ErrInvalidDocumentAlias
+	}
+	for _, r := range alias {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return "", ErrInvalidDocumentAlias
+		}
+	}
+	return alias, nil
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDocumentAlias(t *testing.T) {
+	tests := map[string]string{
+		"security-policy":     "security-policy",
+		"  HR_2026.handbook ": "HR_2026.handbook",
+	}
+	for input, want := range tests {
+		got, err := NormalizeDocumentAlias(input)
+		if err != nil || got != want {
+			t.Errorf("NormalizeDocumentAlias(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "  ", "with space", "a/b", "politique-sécurité", strings.Repeat("a", MaxDocumentAliasLength+1)} {
+		if _, err := NormalizeDocumentAlias(input); !errors.Is(err, ErrInvalidDocumentAlias) {
+			t.Errorf("NormalizeDocumentAlias(%q): expected ErrInvalidDocumentAlias, got %v", input, err)
+		}
+	}
+}
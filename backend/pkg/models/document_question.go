@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxQuestionLength bounds the text of a question or a reply, in characters
+const MaxQuestionLength = 2000
+
+// QuestionStatus is the state of a question signers ask about a document
+type QuestionStatus string
+
+const (
+	QuestionStatusOpen     QuestionStatus = "open"     // Waiting for an answer of the document owner
+	QuestionStatusAnswered QuestionStatus = "answered" // The owner replied last
+	QuestionStatusResolved QuestionStatus = "resolved" // The asker or the owner considers it answered
+	QuestionStatusClosed   QuestionStatus = "closed"   // Closed by the owner without resolution
+)
+
+// IsValid reports whether s is a known question status
+func (s QuestionStatus) IsValid() bool {
+	switch s {
+	case QuestionStatusOpen, QuestionStatusAnswered, QuestionStatusResolved, QuestionStatusClosed:
+		return true
+	}
+	return false
+}
+
+// AcceptsReplies reports whether the thread of a question is still open to replies
+func (s QuestionStatus) AcceptsReplies() bool {
+	return s == QuestionStatusOpen || s == QuestionStatusAnswered
+}
+
+// DocumentQuestion is a question a signer asks about a document, with its replies.
+// Hidden questions and replies are only shown to the document owner, admins and their author.
+type DocumentQuestion struct {
+	ID          int64                    `json:"id"`
+	DocID       string                   `json:"docId"`
+	AuthorEmail string                   `json:"authorEmail,omitempty"` // Only shown to the owner, admins and the author
+	AuthorName  string                   `json:"authorName"`
+	Body        string                   `json:"body"`
+	Status      QuestionStatus           `json:"status"`
+	HiddenAt    *time.Time               `json:"hiddenAt,omitempty"`
+	HiddenBy    string                   `json:"hiddenBy,omitempty"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	UpdatedAt   time.Time                `json:"updatedAt"`
+	Replies     []*DocumentQuestionReply `json:"replies"`
+}
+
+// DocumentQuestionReply is a message in the thread of a question, by the owner or the asker
+type DocumentQuestionReply struct {
+	ID          int64      `json:"id"`
+	QuestionID  int64      `json:"questionId"`
+	AuthorEmail string     `json:"authorEmail,omitempty"`
+	AuthorName  string     `json:"authorName"`
+	Body        string     `json:"body"`
+	FromOwner   bool       `json:"fromOwner"` // Posted by the document owner or an admin
+	HiddenAt    *time.Time `json:"hiddenAt,omitempty"`
+	HiddenBy    string     `json:"hiddenBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// NormalizeQuestionBody trims the text of a question or a reply. It returns ErrInvalidQuestion
+// for empty texts and texts longer than MaxQuestionLength.
+func NormalizeQuestionBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > MaxQuestionLength {
+		return "", ErrInvalidQuestion
+	}
+	return body, nil
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeQuestionBody(t *testing.T) {
+	got, err := NormalizeQuestionBody("  Does this apply to contractors?\n")
+	if err != nil || got != "Does this apply to contractors?" {
+		t.Errorf("NormalizeQuestionBody = %q, %v", got, err)
+	}
+	if _, err := NormalizeQuestionBody(strings.Repeat("é", MaxQuestionLength)); err != nil {
+		t.Errorf("expected %d characters to be accepted, got %v", MaxQuestionLength, err)
+	}
+
+	for _, input := range []string{"", " \n\t", strings.Repeat("a", MaxQuestionLength+1)} {
+		if _, err := NormalizeQuestionBody(input); !errors.Is(err, ErrInvalidQuestion) {
+			t.Errorf("NormalizeQuestionBody(%.20q): expected ErrInvalidQuestion, got %v", input, err)
+		}
+	}
+}
+
+func TestQuestionStatus(t *testing.T) {
+	for _, status := range []QuestionStatus{QuestionStatusOpen, QuestionStatusAnswered, QuestionStatusResolved, QuestionStatusClosed} {
+		if !status.IsValid() {
+			t.Errorf("expected %q to be valid", status)
+		}
+	}
+	if QuestionStatus("pending").IsValid() {
+		t.Error("expected an unknown status to be invalid")
+	}
+	if !QuestionStatusAnswered.AcceptsReplies() || QuestionStatusResolved.AcceptsReplies() || QuestionStatusClosed.AcceptsReplies() {
+		t.Error("expected only open and answered questions to accept replies")
+	}
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// UnexpectedSignature is a signature from a user who is not in the expected signers list
+type UnexpectedSignature struct {
+	UserEmail string    `json:"user_email"`
+	UserName  string    `json:"user_name"`
+	SignedAt  time.Time `json:"signed_at"`
+	// Set when the signature was held for approval
+	ApprovalID     int64          `json:"approval_id"`
+	ApprovalStatus ApprovalStatus `json:"approval_status"`
+}
+
+// DocumentStatus gathers everything the admin status page shows about a document
+type DocumentStatus struct {
+	DocID                string
+	Document             *Document // nil when the document has no metadata
+	Signers              []*ExpectedSignerWithStatus
+	UnexpectedSignatures []*UnexpectedSignature
+	Stats                *DocCompletionStats
+	ReminderStats        *ReminderStats
+}
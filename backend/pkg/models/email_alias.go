@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailAlias is an alternate address matched as the primary email of a person,
+// e.g. the address returned by the identity provider when HR imported another one
+type EmailAlias struct {
+	ID        int64     `json:"id"`
+	TenantID  uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Email     string    `json:"email"`
+	Alias     string    `json:"alias"`
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// EmailMatchingRules are the normalizations applied to addresses before matching
+// signatures to expected signers
+type EmailMatchingRules struct {
+	// IgnoreCase compares the local part case-insensitively (domains always are)
+	IgnoreCase bool `json:"ignoreCase"`
+	// StripPlusTag ignores plus-addressing tags (jane+hr@corp.com matches jane@corp.com)
+	StripPlusTag bool `json:"stripPlusTag"`
+	// IgnoreGmailDots ignores dots in gmail.com and googlemail.com local parts
+	IgnoreGmailDots bool       `json:"ignoreGmailDots"`
+	UpdatedBy       string     `json:"updatedBy,omitempty"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+}
+
+// DefaultEmailMatchingRules returns the rules applied when a tenant has not configured any
+func DefaultEmailMatchingRules() *EmailMatchingRules {
+	return &EmailMatchingRules{IgnoreCase: true}
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of a send to the SMTP servers
+const (
+	EmailDeliverySent   = "sent"
+	EmailDeliveryFailed = "failed"
+)
+
+// EmailDelivery is the outcome of one send to the SMTP servers
+type EmailDelivery struct {
+	ID               int64     `json:"id"`
+	TenantID         uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	MessageID        string    `json:"messageId"`
+	Template         string    `json:"template"`
+	RecipientDomains []string  `json:"recipientDomains"`
+	Status           string    `json:"status"`
+	SMTPCode         int       `json:"smtpCode"` // 0 when no server answered
+	Error            string    `json:"error,omitempty"`
+	Server           string    `json:"server"`
+	LatencyMs        int64     `json:"latencyMs"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// EmailDomainDeliverability aggregates the sends to one recipient domain
+type EmailDomainDeliverability struct {
+	Domain          string     `json:"domain"`
+	Sent            int        `json:"sent"`
+	Failed          int        `json:"failed"`
+	SuccessRate     float64    `json:"successRate"` // Between 0 and 1
+	AvgLatencyMs    int64      `json:"avgLatencyMs"`
+	LastFailureCode int        `json:"lastFailureCode,omitempty"`
+	LastFailureAt   *time.Time `json:"lastFailureAt,omitempty"`
+}
+
+// EmailResponseCodeCount counts the sends ending with an SMTP reply code
+type EmailResponseCodeCount struct {
+	Status string `json:"status"`
+	Code   int    `json:"code"`
+	Count  int    `json:"count"`
+}
+
+// EmailDomains returns the distinct lowercase domains of the given addresses, in order
+func EmailDomains(addresses ...[]string) []string {
+	domains := []string{}
+	seen := map[string]bool{}
+	for _, list := range addresses {
+		for _, address := range list {
+			at := strings.LastIndex(address, "@")
+			if at < 0 {
+				continue
+			}
+			domain := strings.ToLower(strings.TrimRight(strings.TrimSpace(address[at+1:]), ">"))
+			if domain == "" || seen[domain] {
+				continue
+			}
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
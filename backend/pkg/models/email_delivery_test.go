@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEmailDomains(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses [][]string
+		want      []string
+	}{
+		{
+			name:      "distinct lowercase domains in order",
+			addresses: [][]string{{"alice@Example.com", "bob@corp.example.org"}, {"carol@example.com"}},
+			want:      []string{"example.com", "corp.example.org"},
+		},
+		{
+			name:      "bracketed address",
+			addresses: [][]string{{"Alice <alice@gmail.com>"}},
+			want:      []string{"gmail.com"},
+		},
+		{
+			name:      "addresses without domain are skipped",
+			addresses: [][]string{{"alice", "bob@"}, nil},
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EmailDomains(tt.addresses...); !slices.Equal(got, tt.want) {
+				t.Errorf("EmailDomains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
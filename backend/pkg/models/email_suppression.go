@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// SuppressionReason tells why an address no longer receives email
+type SuppressionReason string
+
+const (
+	SuppressionHardBounce SuppressionReason = "hard_bounce" // Rejected permanently by the mail server
+	SuppressionComplaint  SuppressionReason = "complaint"   // Reported as spam by the recipient
+	SuppressionManual     SuppressionReason = "manual"      // Opted out, entered by an admin
+)
+
+// IsValid reports whether the reason is supported
+func (r SuppressionReason) IsValid() bool {
+	return r == SuppressionHardBounce || r == SuppressionComplaint || r == SuppressionManual
+}
+
+// EmailSuppression is an address no email is sent to
+type EmailSuppression struct {
+	Email     string            `json:"email"`
+	Reason    SuppressionReason `json:"reason"`
+	Detail    string            `json:"detail,omitempty"`    // Mail server response or admin note
+	CreatedBy string            `json:"createdBy,omitempty"` // Empty when recorded from a bounce
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
@@ -13,4 +13,83 @@ var (
 	ErrDomainNotAllowed       = errors.New("domain not allowed")
 	ErrDocumentModified       = errors.New("document has been modified since creation")
 	ErrDocumentNotFound       = errors.New("document not found")
+	ErrVersionConflict        = errors.New("resource has been modified concurrently")
+	ErrCampaignNotFound       = errors.New("campaign not found")
+	ErrRoleNotFound           = errors.New("role assignment not found")
+	ErrArchiveNotFound        = errors.New("archive not found")
+	ErrNotificationNotFound   = errors.New("notification not found")
+	ErrAPIKeyNotFound         = errors.New("api key not found")
+	ErrNotSignerTurn          = errors.New("previous signers have not signed yet")
+	ErrQuizNotFound           = errors.New("quiz not found")
+	ErrQuizAnswersRequired    = errors.New("quiz answers are required")
+	ErrQuizFailed             = errors.New("quiz score is below the pass threshold")
+	ErrExternalSigningClosed  = errors.New("document does not accept external signers")
+	ErrSessionNotFound        = errors.New("session not found")
+	ErrEmailAliasNotFound     = errors.New("email alias not found")
+	ErrEmailAliasExists       = errors.New("email alias already exists")
+	ErrRateLimited            = errors.New("rate limit exceeded")
+	ErrSignerGroupNotFound    = errors.New("signer group not found")
+	ErrSignerGroupExists      = errors.New("a signer group with this name already exists")
+	ErrGitSourceNotFound      = errors.New("git source not found")
+	ErrGitSourceExists        = errors.New("a git source with this name already exists")
+	ErrLinkSourceNotFound     = errors.New("document link source not found")
+	ErrSubscriptionNotFound   = errors.New("document link subscription not found")
+	ErrNonceNotFound          = errors.New("signature nonce not found")
+	ErrNonceRequired          = errors.New("a signature nonce is required")
+	ErrNonceInvalid           = errors.New("signature nonce is unknown or issued for another signature")
+	ErrNonceExpired           = errors.New("signature nonce has expired")
+	ErrNonceReplayed          = errors.New("signature nonce has already been used")
+	ErrIdempotencyKeyInvalid  = errors.New("idempotency key must be 1 to 255 printable ASCII characters")
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyPending  = errors.New("a request with this idempotency key is still in progress")
+	ErrDepartmentNotFound     = errors.New("department not found")
+	ErrDepartmentExists       = errors.New("a department with this name already exists under the same parent")
+	ErrDepartmentInUse        = errors.New("department has sub-departments or admins")
+	ErrOutOfDepartmentScope   = errors.New("outside of the departments you manage")
+	ErrAnnouncementNotFound   = errors.New("announcement not found")
+	ErrSuppressionNotFound    = errors.New("email suppression not found")
+	ErrReportNotFound         = errors.New("compliance report not found")
+	ErrShortLinkNotFound      = errors.New("short link not found")
+	ErrInvalidPeriod          = errors.New("period must be YYYY, YYYY-Qn or YYYY-MM")
+	ErrEmailSuppressed        = errors.New("recipient is on the email suppression list")
+	ErrConsentOutdated        = errors.New("consent text changed since it was displayed")
+	ErrInvalidTag             = errors.New("invalid tag")
+	ErrTagNotFound            = errors.New("tag not found")
+	ErrSecurityEventNotFound  = errors.New("security event not found")
+	ErrSignatureRejected      = errors.New("signature rejected by the validation hook")
+	ErrSignatureHookFailed    = errors.New("signature validation hook unavailable")
+	ErrPacketNotFound         = errors.New("document packet not found")
+	ErrPreviewLinkNotFound    = errors.New("preview link not found")
+	ErrJobNotFound            = errors.New("job not found")
+	ErrInvalidCursor          = errors.New("invalid pagination cursor")
+	ErrInvalidDocumentAlias   = errors.New("document alias must be 1 to 128 letters, digits, dots, dashes or underscores")
+	ErrDocumentAliasTaken     = errors.New("document alias is already used by a document or another alias")
+	ErrDocumentAliasNotFound  = errors.New("document alias not found")
+	ErrQuestionNotFound       = errors.New("document question not found")
+	ErrInvalidQuestion        = errors.New("question and reply text must be 1 to 2000 characters")
+	ErrQuestionClosed         = errors.New("document question is resolved or closed")
+	ErrQuestionForbidden      = errors.New("only the document owner can moderate or reopen questions")
+	ErrInvalidQuestionStatus  = errors.New("question status must be open, answered, resolved or closed")
+	ErrDirectoryNotDelegated  = errors.New("directory provider is not configured for delegated access")
+	ErrDirectoryNotConnected  = errors.New("directory is not connected, an admin must grant access first")
+	ErrDirectoryTokenRevoked  = errors.New("directory access was revoked or expired, an admin must grant access again")
+	ErrInvalidDirectoryGrant  = errors.New("directory authorization is invalid or expired")
+	ErrFederationPeerNotFound = errors.New("federation peer not found")
+	ErrFederationPeerExists   = errors.New("a federation peer with this instance ID already exists")
+	ErrInvalidFederationPeer  = errors.New("federation peer needs an instance ID of lowercase letters, digits and dashes and an Ed25519 public key")
+	ErrFederationUnauthorized = errors.New("federation push is not signed by a registered peer")
+	ErrInvalidFederationPush  = errors.New("invalid federation push")
+	ErrQuarantineNotFound     = errors.New("quarantined upload not found")
+	ErrQuarantineReviewed     = errors.New("quarantined upload was already released or discarded")
+	ErrSignatureArchiveGone   = errors.New("signature archive expired or not found")
+	ErrInvalidIdentityMerge   = errors.New("invalid identity merge")
+	ErrIdentityMergeConflict  = errors.New("identity is already merged or aliased elsewhere")
+	ErrApprovalNotFound       = errors.New("signature approval not found")
+	ErrApprovalReviewed       = errors.New("signature was already approved or rejected")
+	ErrExtensionNotFound      = errors.New("deadline extension request not found")
+	ErrExtensionDecided       = errors.New("deadline extension request was already approved or denied")
+	ErrExtensionPending       = errors.New("a deadline extension request is already awaiting a decision")
+	ErrExtensionForbidden     = errors.New("only pending expected signers request extensions and only the document owner decides them")
+	ErrNoDocumentDeadline     = errors.New("document has no deadline to extend")
+	ErrInvalidExtension       = errors.New("extension needs a reason of at most 1000 characters and a deadline later than the current one, within a year")
 )
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus is the relay state of an outbox event
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "pending"
+	OutboxStatusDelivered OutboxStatus = "delivered"
+	OutboxStatusDead      OutboxStatus = "dead" // Gave up after too many attempts, until retried by an admin
+)
+
+// OutboxEvent is a domain event written in the transaction of the change it reports, waiting to be
+// relayed to the webhooks and notifiers
+type OutboxEvent struct {
+	ID            int64                  `json:"id"`
+	TenantID      uuid.UUID              `json:"-"`
+	EventID       string                 `json:"eventId"`
+	EventType     string                 `json:"eventType"`
+	Payload       map[string]interface{} `json:"payload"`
+	Status        OutboxStatus           `json:"status"`
+	Attempts      int                    `json:"attempts"`
+	LastError     *string                `json:"lastError,omitempty"`
+	NextAttemptAt time.Time              `json:"nextAttemptAt"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	DeliveredAt   *time.Time             `json:"deliveredAt,omitempty"`
+}
+
+// OutboxLag reports how far the relay of outbox events is behind
+type OutboxLag struct {
+	PendingCount    int        `json:"pendingCount"`
+	DeadCount       int        `json:"deadCount"`
+	OldestPendingAt *time.Time `json:"oldestPendingAt,omitempty"`
+	LastDeliveredAt *time.Time `json:"lastDeliveredAt,omitempty"`
+	LagSeconds      float64    `json:"lagSeconds"` // Age of the oldest event not delivered yet
+	MaxLagSeconds   float64    `json:"maxLagSeconds"`
+	Healthy         bool       `json:"healthy"` // Within the max lag and without dead events
+}
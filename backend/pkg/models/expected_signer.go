@@ -17,6 +17,8 @@ type ExpectedSigner struct {
 	AddedAt  time.Time `json:"added_at" db:"added_at"`
 	AddedBy  string    `json:"added_by" db:"added_by"`
 	Notes    *string   `json:"notes,omitempty" db:"notes"`
+	// SignOrder is the position in a sequential workflow; nil when the signer is not ordered
+	SignOrder *int `json:"sign_order,omitempty" db:"sign_order"`
 }
 
 // ExpectedSignerWithStatus combines expected signer info with signature status
@@ -29,6 +31,17 @@ type ExpectedSignerWithStatus struct {
 	ReminderCount         int        `json:"reminder_count"`
 	DaysSinceAdded        int        `json:"days_since_added"`
 	DaysSinceLastReminder *int       `json:"days_since_last_reminder,omitempty"`
+	// Turn is set by ApplySigningTurns for ordered signers
+	Turn SigningTurn `json:"turn,omitempty"`
+}
+
+// PendingDocument is a document still awaiting the confirmation of an expected signer
+type PendingDocument struct {
+	DocID         string    `json:"doc_id"`
+	Title         string    `json:"title"`
+	URL           string    `json:"url"`
+	RecipientName string    `json:"recipient_name"`
+	AddedAt       time.Time `json:"added_at"`
 }
 
 // DocCompletionStats provides completion statistics for a document
@@ -42,6 +55,7 @@ type DocCompletionStats struct {
 
 // ContactInfo represents a contact with optional name and email
 type ContactInfo struct {
-	Name  string
-	Email string
+	Name      string
+	Email     string
+	SignOrder *int // Optional position in the signing sequence
 }
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Authentication methods recorded with a signature
+const (
+	// AuthMethodSession is a signer authenticated by an OAuth or magic link session
+	AuthMethodSession = "session"
+	// AuthMethodEmailCode is an external signer who verified their email with a one-time code
+	AuthMethodEmailCode = "email_code"
+)
+
+// ExternalSignerSubPrefix prefixes the user subject of external signers
+const ExternalSignerSubPrefix = "external:"
+
+// ExternalSigning reports whether a document accepts external signers
+type ExternalSigning struct {
+	DocID     string     `json:"docId"`
+	Enabled   bool       `json:"enabled"`
+	EnabledBy string     `json:"enabledBy,omitempty"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty"`
+}
+
+// ExternalSignerCode is a one-time code sent to an external signer
+type ExternalSignerCode struct {
+	ID          int64      `json:"id" db:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	DocID       string     `json:"doc_id" db:"doc_id"`
+	Email       string     `json:"email" db:"email"`
+	CodeHash    string     `json:"-" db:"code_hash"`
+	Attempts    int        `json:"attempts" db:"attempts"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt      *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedByIP string     `json:"created_by_ip" db:"created_by_ip"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsUsable reports whether the code can still be redeemed
+func (c *ExternalSignerCode) IsUsable(maxAttempts int) bool {
+	return c.UsedAt == nil && c.Attempts < maxAttempts && time.Now().Before(c.ExpiresAt)
+}
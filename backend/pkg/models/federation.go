@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bounds of a federation push, the hub rejects larger ones
+const (
+	MaxFederationPushDocuments  = 1000
+	MaxFederationPushSignatures = 1000
+)
+
+var federationInstanceID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// ValidFederationInstanceID reports whether id can identify an instance at the hub: 1 to 63
+// lowercase letters, digits and dashes, not starting with a dash
+func ValidFederationInstanceID(id string) bool {
+	return federationInstanceID.MatchString(id)
+}
+
+// FederatedDocumentID is the identity of a peer document at the hub. Instance IDs are unique at
+// the hub and cannot hold a slash, so documents of different peers never collide.
+func FederatedDocumentID(instanceID, docID string) string {
+	return instanceID + "/" + docID
+}
+
+// FederationPush is what a peer sends to the hub: the completion of the documents it signed since
+// its last push and the proofs of these signatures. The body is signed with the signing key of
+// the peer, registered at the hub.
+type FederationPush struct {
+	InstanceID string                `json:"instanceId"`
+	SentAt     time.Time             `json:"sentAt"`
+	Documents  []FederationDocument  `json:"documents"`
+	Signatures []FederationSignature `json:"signatures"`
+}
+
+// FederationDocument is the completion summary of a peer document
+type FederationDocument struct {
+	DocID          string    `json:"docId"`
+	Title          string    `json:"title,omitempty"`
+	URL            string    `json:"url,omitempty"`
+	Checksum       string    `json:"checksum,omitempty"`
+	ExpectedCount  int       `json:"expectedCount"`  // Expected signers, 0 when none
+	SignedCount    int       `json:"signedCount"`    // Expected signers who signed
+	SignatureCount int       `json:"signatureCount"` // All signatures
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// FederationSignature is the proof of a peer signature, as recorded by the peer
+type FederationSignature struct {
+	ID          int64     `json:"id"` // ID at the peer
+	DocID       string    `json:"docId"`
+	UserEmail   string    `json:"userEmail"`
+	UserName    string    `json:"userName,omitempty"`
+	SignedAt    time.Time `json:"signedAt"`
+	DocChecksum string    `json:"docChecksum,omitempty"`
+	PayloadHash string    `json:"payloadHash"`
+	Signature   string    `json:"signature"`
+	Nonce       string    `json:"nonce"`
+	PrevHash    *string   `json:"prevHash,omitempty"`
+	RecordHash  string    `json:"recordHash"`
+	HashVersion int       `json:"hashVersion"`
+}
+
+// Validate checks a push received from instanceID
+func (p *FederationPush) Validate(instanceID string) error {
+	if p.InstanceID != instanceID {
+		return fmt.Errorf("%w: instance ID does not match the signing peer", ErrInvalidFederationPush)
+	}
+	if len(p.Documents) > MaxFederationPushDocuments || len(p.Signatures) > MaxFederationPushSignatures {
+		return fmt.Errorf("%w: at most %d documents and %d signatures per push", ErrInvalidFederationPush,
+			MaxFederationPushDocuments, MaxFederationPushSignatures)
+	}
+	for _, doc := range p.Documents {
+		if doc.DocID == "" || doc.ExpectedCount < 0 || doc.SignedCount < 0 || doc.SignatureCount < 0 {
+			return fmt.Errorf("%w: document without ID or with negative counts", ErrInvalidFederationPush)
+		}
+	}
+	for _, sig := range p.Signatures {
+		if sig.ID <= 0 || sig.DocID == "" || sig.RecordHash == "" || sig.Signature == "" {
+			return fmt.Errorf("%w: signature %d lacks its ID, document or proof", ErrInvalidFederationPush, sig.ID)
+		}
+	}
+	return nil
+}
+
+// FederationPushResult reports what the hub stored from a push. Signatures already received are
+// counted as duplicates, so that a push retried after a failure is harmless.
+type FederationPushResult struct {
+	Documents  int `json:"documents"`
+	Signatures int `json:"signatures"`
+	Duplicates int `json:"duplicates"`
+}
+
+// FederationPeer is an instance allowed to push to the hub, identified by its instance ID and
+// authenticated by the public signing key it signs its pushes with
+type FederationPeer struct {
+	ID         int64      `json:"id"`
+	TenantID   uuid.UUID  `json:"-"`
+	InstanceID string     `json:"instanceId"`
+	Name       string     `json:"name"`
+	PublicKey  string     `json:"publicKey"` // Base64 Ed25519 public key
+	CreatedBy  string     `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastPushAt *time.Time `json:"lastPushAt,omitempty"`
+	Documents  int        `json:"documents"`
+	Signatures int        `json:"signatures"`
+}
+
+// FederatedDocument is a peer document as known by the hub
+type FederatedDocument struct {
+	FederatedID    string    `json:"federatedId"` // FederatedDocumentID of the document
+	InstanceID     string    `json:"instanceId"`
+	PeerName       string    `json:"peerName"`
+	DocID          string    `json:"docId"` // ID at the peer
+	Title          string    `json:"title,omitempty"`
+	URL            string    `json:"url,omitempty"`
+	Checksum       string    `json:"checksum,omitempty"`
+	ExpectedCount  int       `json:"expectedCount"`
+	SignedCount    int       `json:"signedCount"`
+	SignatureCount int       `json:"signatureCount"`
+	UpdatedAt      time.Time `json:"updatedAt"`  // At the peer
+	ReceivedAt     time.Time `json:"receivedAt"` // At the hub
+}
+
+// FederationStatus reports the pushes of this instance to the hub
+type FederationStatus struct {
+	HubURL          string     `json:"hubUrl"`
+	InstanceID      string     `json:"instanceId"`
+	PublicKey       string     `json:"publicKey"` // To register this instance at the hub
+	LastSignatureID int64      `json:"lastSignatureId"`
+	LastPushAt      *time.Time `json:"lastPushAt,omitempty"`
+	PendingCount    int        `json:"pendingCount"`
+	LastError       string     `json:"lastError,omitempty"`
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidFederationInstanceID(t *testing.T) {
+	for _, id := range []string{"acme-fr", "a", "subsidiary-2", strings.Repeat("a", 63)} {
+		if !ValidFederationInstanceID(id) {
+			t.Errorf("ValidFederationInstanceID(%q) = false, want true", id)
+		}
+	}
+	for _, id := range []string{"", "-acme", "Acme", "acme/fr", "acme fr", strings.Repeat("a", 64)} {
+		if ValidFederationInstanceID(id) {
+			t.Errorf("ValidFederationInstanceID(%q) = true, want false", id)
+		}
+	}
+}
+
+func TestFederatedDocumentID(t *testing.T) {
+	// The same local ID at two peers maps to two documents at the hub
+	if FederatedDocumentID("acme-fr", "policy") == FederatedDocumentID("acme-de", "policy") {
+		t.Error("documents of different peers must not collide")
+	}
+	if got := FederatedDocumentID("acme-fr", "policy"); got != "acme-fr/policy" {
+		t.Errorf("FederatedDocumentID = %q, want acme-fr/policy", got)
+	}
+}
+
+func TestFederationPush_Validate(t *testing.T) {
+	valid := func() *FederationPush {
+		return &FederationPush{
+			InstanceID: "acme-fr",
+			Documents:  []FederationDocument{{DocID: "policy", ExpectedCount: 2, SignedCount: 1, SignatureCount: 1}},
+			Signatures: []FederationSignature{{ID: 7, DocID: "policy", RecordHash: "hash", Signature: "sig"}},
+		}
+	}
+	if err := valid().Validate("acme-fr"); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	tests := map[string]func(p *FederationPush){
+		"other instance":   func(p *FederationPush) { p.InstanceID = "acme-de" },
+		"document id":      func(p *FederationPush) { p.Documents[0].DocID = "" },
+		"negative count":   func(p *FederationPush) { p.Documents[0].SignedCount = -1 },
+		"signature id":     func(p *FederationPush) { p.Signatures[0].ID = 0 },
+		"missing proof":    func(p *FederationPush) { p.Signatures[0].Signature = "" },
+		"too many entries": func(p *FederationPush) { p.Signatures = make([]FederationSignature, MaxFederationPushSignatures+1) },
+	}
+	for name, mutate := range tests {
+		push := valid()
+		mutate(push)
+		if err := push.Validate("acme-fr"); !errors.Is(err, ErrInvalidFederationPush) {
+			t.Errorf("%s: expected ErrInvalidFederationPush, got %v", name, err)
+		}
+	}
+}
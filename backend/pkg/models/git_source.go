@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GitPathRule selects repository files to import and how their changes are handled
+type GitPathRule struct {
+	Pattern         string `json:"pattern"`                   // Slash-separated glob, "**" matches any number of directories
+	Reacknowledge   bool   `json:"reacknowledge,omitempty"`   // A change of the file asks signers to acknowledge it again
+	RequireFullRead bool   `json:"requireFullRead,omitempty"` // Applied to the documents created from the files
+}
+
+// Matches reports whether the slash-separated file path matches the rule pattern
+func (r GitPathRule) Matches(filePath string) bool {
+	return matchGlob(strings.Split(r.Pattern, "/"), strings.Split(filePath, "/"))
+}
+
+// IsValid reports whether the rule pattern is a well-formed glob
+func (r GitPathRule) IsValid() bool {
+	if r.Pattern == "" || strings.HasPrefix(r.Pattern, "/") {
+		return false
+	}
+	for _, segment := range strings.Split(r.Pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func matchGlob(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			// "**" consumes zero or more directories
+			for i := 0; i <= len(segments); i++ {
+				if matchGlob(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// GitSource is a Git repository whose Markdown and PDF files are imported as documents
+type GitSource struct {
+	ID              int64         `json:"id"`
+	TenantID        uuid.UUID     `json:"-"`
+	Name            string        `json:"name"`
+	RepoURL         string        `json:"repoUrl"`
+	Branch          string        `json:"branch"`
+	Rules           []GitPathRule `json:"rules"`
+	WebhookSecret   string        `json:"-"`
+	SyncEnabled     bool          `json:"syncEnabled"` // Polled on schedule and on push webhooks, else only on demand
+	SyncRequestedAt *time.Time    `json:"syncRequestedAt,omitempty"`
+	LastCommit      string        `json:"lastCommit,omitempty"`
+	LastSyncedAt    *time.Time    `json:"lastSyncedAt,omitempty"`
+	LastSyncError   string        `json:"lastSyncError,omitempty"`
+	CreatedBy       string        `json:"createdBy,omitempty"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+// MatchRule returns the first rule matching the file path, nil when the file is not imported
+func (s *GitSource) MatchRule(filePath string) *GitPathRule {
+	for i := range s.Rules {
+		if s.Rules[i].Matches(filePath) {
+			return &s.Rules[i]
+		}
+	}
+	return nil
+}
+
+// RedactedRepoURL hides the password or token of the repository URL
+func (s *GitSource) RedactedRepoURL() string {
+	u, err := url.Parse(s.RepoURL)
+	if err != nil || u.User == nil {
+		return s.RepoURL
+	}
+	return u.Redacted()
+}
+
+type GitSourceInput struct {
+	Name          string
+	RepoURL       string
+	Branch        string
+	Rules         []GitPathRule
+	WebhookSecret string
+	SyncEnabled   bool
+	CreatedBy     string
+}
+
+// GitSourceDocument links a repository file to the document imported from it
+type GitSourceDocument struct {
+	SourceID           int64      `json:"sourceId"`
+	Path               string     `json:"path"`
+	DocID              string     `json:"docId"`
+	Checksum           string     `json:"checksum"`
+	CommitSHA          string     `json:"commit"`
+	ReacknowledgeSince *time.Time `json:"reacknowledgeSince,omitempty"`
+	OutdatedSignatures int        `json:"outdatedSignatures"` // Signatures made on a previous content of the file
+	UpdatedAt          time.Time  `json:"updatedAt"`
+}
+
+// GitSyncChange is a repository file imported by a sync
+type GitSyncChange struct {
+	Path          string `json:"path"`
+	DocID         string `json:"docId"`
+	Version       int    `json:"version"`
+	Reacknowledge bool   `json:"reacknowledge,omitempty"`
+}
+
+// GitSyncResult describes what a sync of a Git source imported
+type GitSyncResult struct {
+	SourceID  int64           `json:"sourceId"`
+	Commit    string          `json:"commit"`
+	Created   []GitSyncChange `json:"created"`
+	Updated   []GitSyncChange `json:"updated"`
+	Missing   []string        `json:"missing"` // Imported files no longer in the repository, their documents are kept
+	Unchanged int             `json:"unchanged"`
+	Skipped   []string        `json:"skipped"` // Matching files that are not Markdown or PDF, or too large
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdentityMergeInput names the identity to merge and the identity it is merged into. The source
+// and target emails are equal to merge the subjects of one email, e.g. a Google account and
+// magic links; TargetSub then picks the subject to keep.
+type IdentityMergeInput struct {
+	SourceEmail string `json:"sourceEmail"`
+	TargetEmail string `json:"targetEmail"`
+	TargetSub   string `json:"targetSub,omitempty"`
+}
+
+// IdentityMergeSummary counts the references moved from the source identity to the target one
+type IdentityMergeSummary struct {
+	AliasCreated     bool  `json:"aliasCreated"` // The source email became an alias of the target email
+	Documents        int64 `json:"documents"`    // Documents owned
+	Questions        int64 `json:"questions"`    // Questions and replies authored
+	Notifications    int64 `json:"notifications"`
+	Preferences      int64 `json:"preferences"` // Moved when the target had none
+	Roles            int64 `json:"roles"`       // Admin role moved when the target had none
+	RolesDropped     int64 `json:"rolesDropped"`
+	Memberships      int64 `json:"memberships"` // Signer group and department memberships
+	SessionsRevoked  int64 `json:"sessionsRevoked"`
+	MergesRetargeted int64 `json:"mergesRetargeted"` // Earlier merges into the source now pointing to the target
+}
+
+// IdentityMerge records a source identity merged into a target one. Logins with a subject of
+// SourceSubs or with SourceEmail resolve to the target identity.
+type IdentityMerge struct {
+	ID          int64                `json:"id"`
+	TenantID    uuid.UUID            `json:"-"`
+	SourceEmail string               `json:"sourceEmail"`
+	SourceSubs  []string             `json:"sourceSubs"`
+	TargetEmail string               `json:"targetEmail"`
+	TargetSub   string               `json:"targetSub,omitempty"`
+	Summary     IdentityMergeSummary `json:"summary"`
+	MergedBy    string               `json:"mergedBy"`
+	MergedAt    time.Time            `json:"mergedAt"`
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobStatus is the state of a background job
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// IsValid reports whether the status is a known job status
+func (s JobStatus) IsValid() bool {
+	switch s {
+	case JobQueued, JobRunning, JobSucceeded, JobFailed, JobCancelled:
+		return true
+	}
+	return false
+}
+
+// DefaultJobMaxAttempts is the number of runs of a job before it is failed, when none is given
+const DefaultJobMaxAttempts = 3
+
+// Job is a unit of background work of a given type, claimed by one instance at a time.
+// Its payload is the JSON the handler of the type decodes.
+type Job struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      JobStatus       `json:"status"`
+	Priority    int             `json:"priority"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	RunAt       time.Time       `json:"runAt"` // Not run before; the next retry while queued
+	LockedBy    string          `json:"lockedBy,omitempty"`
+	LockedAt    *time.Time      `json:"lockedAt,omitempty"`
+	LastError   string          `json:"lastError,omitempty"`
+	DedupKey    string          `json:"dedupKey,omitempty"`
+	CreatedBy   string          `json:"createdBy,omitempty"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	StartedAt   *time.Time      `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time      `json:"finishedAt,omitempty"`
+	Progress    *JobProgress    `json:"progress,omitempty"` // nil when the job does not report progress
+	Result      json.RawMessage `json:"result,omitempty"`   // Set by the handler of a succeeded job
+}
+
+// JobProgress counts the items done by the current run of a job
+type JobProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// JobInput describes a job to enqueue. Payload is encoded as JSON.
+type JobInput struct {
+	Type        string
+	Payload     interface{}
+	Priority    int        // Higher runs first
+	RunAt       *time.Time // nil: as soon as possible
+	MaxAttempts int        // 0: DefaultJobMaxAttempts
+	// DedupKey, when set, returns the queued or running job of the same type and key
+	// instead of enqueuing another one
+	DedupKey  string
+	CreatedBy string
+}
+
+// JobFilter selects the jobs listed to admins
+type JobFilter struct {
+	Status JobStatus // Empty: any
+	Type   string    // Empty: any
+	Limit  int
+}
+
+// JobCounts counts the jobs of a type by status
+type JobCounts struct {
+	Type      string `json:"type"`
+	Queued    int    `json:"queued"`
+	Running   int    `json:"running"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	Cancelled int    `json:"cancelled"`
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxLandingMessageLength is the maximum length of a landing message, in characters per locale
+const MaxLandingMessageLength = 10000
+
+// LandingMessage is the Markdown shown on the signing page of a document before the
+// acknowledgement button, per locale, e.g. instructions or legal context
+type LandingMessage map[string]string
+
+// Normalize checks the locales and lengths of the variants and returns them keyed by their
+// base language, trimmed. Empty variants are dropped, so that an empty message clears it.
+func (m LandingMessage) Normalize() (LandingMessage, error) {
+	if m == nil {
+		return nil, nil
+	}
+	normalized := make(LandingMessage, len(m))
+	for tag, text := range m {
+		locale, ok := NormalizeLocale(tag)
+		if !ok {
+			return nil, fmt.Errorf("unsupported locale %q", tag)
+		}
+		if _, dup := normalized[locale]; dup {
+			return nil, fmt.Errorf("locale %q is given twice", locale)
+		}
+		text = strings.TrimSpace(text)
+		if utf8.RuneCountInString(text) > MaxLandingMessageLength {
+			return nil, fmt.Errorf("message for %q must be at most %d characters", locale, MaxLandingMessageLength)
+		}
+		if text != "" {
+			normalized[locale] = text
+		}
+	}
+	return normalized, nil
+}
+
+// Select picks the variant shown for a display locale: the same language, else English, else
+// the first locale in alphabetical order. It returns an empty locale when there is no message.
+func (m LandingMessage) Select(locale string) (string, string) {
+	if len(m) == 0 {
+		return "", ""
+	}
+	locale, _ = NormalizeLocale(locale)
+	if text, ok := m[locale]; ok {
+		return locale, text
+	}
+	if text, ok := m["en"]; ok {
+		return "en", text
+	}
+	locales := make([]string, 0, len(m))
+	for l := range m {
+		locales = append(locales, l)
+	}
+	sort.Strings(locales)
+	return locales[0], m[locales[0]]
+}
+
+// Value implements driver.Valuer; a nil message is NULL
+func (m LandingMessage) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return json.Marshal(map[string]string(m))
+}
+
+// Scan implements sql.Scanner
+func (m *LandingMessage) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported landing message type %T", value)
+	}
+	return json.Unmarshal(data, (*map[string]string)(m))
+}
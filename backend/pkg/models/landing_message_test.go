@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLandingMessage_Normalize(t *testing.T) {
+	got, err := LandingMessage{"fr-FR": "  Bonjour  ", "en": "Hello", "de": "   "}.Normalize()
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if len(got) != 2 || got["fr"] != "Bonjour" || got["en"] != "Hello" {
+		t.Errorf("unexpected normalized message %v", got)
+	}
+
+	if got, err := (LandingMessage(nil)).Normalize(); err != nil || got != nil {
+		t.Errorf("expected nil for a nil message, got %v, %v", got, err)
+	}
+	if got, err := (LandingMessage{}).Normalize(); err != nil || got == nil || len(got) != 0 {
+		t.Errorf("expected an empty message to stay empty, got %v, %v", got, err)
+	}
+
+	invalid := []LandingMessage{
+		{"xx": "text"},
+		{"fr": "a", "fr-CA": "b"},
+		{"en": strings.Repeat("a", MaxLandingMessageLength+1)},
+	}
+	for _, m := range invalid {
+		if _, err := m.Normalize(); err == nil {
+			t.Errorf("expected an error for %v", m)
+		}
+	}
+}
+
+func TestLandingMessage_Select(t *testing.T) {
+	m := LandingMessage{"de": "Hallo", "en": "Hello", "fr": "Bonjour"}
+
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"fr-FR", "fr"},
+		{"de", "de"},
+		{"es", "en"},
+		{"", "en"},
+	}
+	for _, tt := range tests {
+		if got, text := m.Select(tt.locale); got != tt.want || text != m[tt.want] {
+			t.Errorf("Select(%q) = %q, %q, want locale %q", tt.locale, got, text, tt.want)
+		}
+	}
+
+	if got, _ := (LandingMessage{"fr": "Bonjour", "de": "Hallo"}).Select("es"); got != "de" {
+		t.Errorf("expected the first locale without English, got %q", got)
+	}
+	if got, text := (LandingMessage{}).Select("fr"); got != "" || text != "" {
+		t.Errorf("expected no message, got %q, %q", got, text)
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// External append-only ledgers signature hashes can be streamed to
+const (
+	LedgerPostgres = "postgres" // Table of a separate PostgreSQL database
+	LedgerKafka    = "kafka"    // Kafka topic, through a Kafka REST proxy
+)
+
+// Kinds of discrepancies found when reconciling the ledger with the signatures
+const (
+	LedgerSignatureDeleted  = "signature_deleted"  // The streamed signature no longer exists
+	LedgerSignatureModified = "signature_modified" // The signature no longer hashes to the streamed hash
+	LedgerEntryMissing      = "ledger_missing"     // The external ledger has no entry for the signature
+	LedgerEntryMismatch     = "ledger_mismatch"    // The external ledger holds another hash for the signature
+)
+
+// LedgerEntry is the record hash of a signature streamed to the external ledger
+type LedgerEntry struct {
+	TenantID    uuid.UUID `json:"tenantId"`
+	SignatureID int64     `json:"signatureId"`
+	DocID       string    `json:"docId"`
+	RecordHash  string    `json:"recordHash"` // Base64 record hash of the signature when streamed
+	SignedAt    time.Time `json:"signedAt"`
+	LedgerRef   string    `json:"-"` // Where the entry was written in the ledger
+	StreamedAt  time.Time `json:"-"`
+}
+
+// LedgerLag reports how far the external ledger is behind the signatures
+type LedgerLag struct {
+	Ledger              string     `json:"ledger"`
+	LastSignatureID     int64      `json:"lastSignatureId"` // Last signature streamed
+	LastStreamedAt      *time.Time `json:"lastStreamedAt,omitempty"`
+	PendingCount        int        `json:"pendingCount"`
+	OldestPendingAt     *time.Time `json:"oldestPendingAt,omitempty"`
+	LagSeconds          float64    `json:"lagSeconds"` // Age of the oldest signature not streamed yet
+	MaxLagSeconds       float64    `json:"maxLagSeconds"`
+	Healthy             bool       `json:"healthy"`
+	ReconciliationReady bool       `json:"reconciliationReady"` // The ledger can be read back for reconciliation
+}
+
+// LedgerDiscrepancy is a streamed signature that does not match the signatures or the ledger
+type LedgerDiscrepancy struct {
+	SignatureID int64  `json:"signatureId"`
+	Kind        string `json:"kind"`
+	Expected    string `json:"expected"` // Record hash streamed
+	Actual      string `json:"actual,omitempty"`
+}
+
+// LedgerReconciliation is the result of a reconciliation run
+type LedgerReconciliation struct {
+	Checked       int                 `json:"checked"`
+	LedgerChecked bool                `json:"ledgerChecked"` // False when the ledger cannot be read back
+	Discrepancies []LedgerDiscrepancy `json:"discrepancies"`
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LinkProviderConfluence  = "confluence"   // Confluence Cloud or Data Center page, through the REST API
+	LinkProviderSharePoint  = "sharepoint"   // SharePoint page or file, through Microsoft Graph
+	LinkProviderGoogleDrive = "google-drive" // Google Docs, Sheets, Slides or Drive file, through the Drive API
+)
+
+// LinkMetadata is what the API of a document host reports about a page
+type LinkMetadata struct {
+	Provider    string     `json:"provider"`
+	Title       string     `json:"title"`
+	Version     string     `json:"version"`
+	ContentHash string     `json:"contentHash"`
+	ModifiedAt  *time.Time `json:"modifiedAt,omitempty"`
+}
+
+// Differs reports whether the page changed between two resolutions
+func (m *LinkMetadata) Differs(other *LinkMetadata) bool {
+	return m.Version != other.Version || m.ContentHash != other.ContentHash
+}
+
+// DocumentLinkSource is the tracked metadata of the Confluence page, SharePoint or Google Drive file a document links to
+type DocumentLinkSource struct {
+	TenantID uuid.UUID `json:"-"`
+	DocID    string    `json:"docId"`
+	URL      string    `json:"url"`
+	LinkMetadata
+	PreviousVersion string     `json:"previousVersion,omitempty"`
+	ChangedAt       *time.Time `json:"changedAt,omitempty"` // Last change of the page after the document was created
+	LastCheckedAt   time.Time  `json:"lastCheckedAt"`
+	NotifiedAt      *time.Time `json:"notifiedAt,omitempty"` // Change notified by the host since the last check
+	LastError       string     `json:"lastError,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// LinkSubscription is a subscription to the change notifications the host pushes for the file a
+// document links to: a Microsoft Graph subscription or a Google Drive channel
+type LinkSubscription struct {
+	TenantID       uuid.UUID `json:"-"`
+	DocID          string    `json:"docId"`
+	Provider       string    `json:"provider"`
+	SubscriptionID string    `json:"subscriptionId"`
+	ResourceID     string    `json:"-"` // Drive resource watched by the channel, needed to stop it
+	ClientState    string    `json:"-"` // Secret sent back with each notification
+	ExpiresAt      time.Time `json:"expiresAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Anchor types a Merkle root can be published to
+const (
+	MerkleAnchorStorage = "storage" // JSON file in the document storage (local or S3)
+	MerkleAnchorGit     = "git"     // Commit in a local clone pushed to its remote
+	MerkleAnchorHTTPS   = "https"   // POST to an HTTPS endpoint
+)
+
+// MerkleRoot is the root of a Merkle tree over a batch of consecutive signatures.
+// Leaves are the record hashes of the signatures from FirstSignatureID to LastSignatureID, by ID.
+type MerkleRoot struct {
+	ID               int64      `json:"id"`
+	TenantID         uuid.UUID  `json:"-"`
+	RootHash         string     `json:"rootHash"` // Base64 SHA-256
+	FirstSignatureID int64      `json:"firstSignatureId"`
+	LastSignatureID  int64      `json:"lastSignatureId"`
+	LeafCount        int        `json:"leafCount"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	AnchoredAt       *time.Time `json:"anchoredAt,omitempty"`
+	AnchorRef        string     `json:"anchorRef,omitempty"` // Where the root was published
+	AnchorError      string     `json:"-"`
+}
+
+// MerkleLeaf is a signature record hash included in a Merkle root
+type MerkleLeaf struct {
+	RootID      int64
+	SignatureID int64
+	LeafIndex   int
+	RecordHash  string // Base64 record hash of the signature when the root was computed
+}
+
+// MerkleProofStep is a sibling hash on the path from a leaf to the root
+type MerkleProofStep struct {
+	Hash     string `json:"hash"`     // Base64
+	Position string `json:"position"` // "left" or "right" of the running hash
+}
+
+// MerkleProof proves that a signature is included in a published Merkle root.
+// The leaf hash is SHA-256(0x00 || record hash), inner nodes SHA-256(0x01 || left || right).
+type MerkleProof struct {
+	SignatureID int64             `json:"signatureId"`
+	RecordHash  string            `json:"recordHash"`
+	LeafHash    string            `json:"leafHash"`
+	LeafIndex   int               `json:"leafIndex"`
+	Path        []MerkleProofStep `json:"path"`
+	Root        *MerkleRoot       `json:"root"`
+	// RecordMatches is false when the signature no longer hashes to the included record hash
+	RecordMatches bool `json:"recordMatches"`
+}
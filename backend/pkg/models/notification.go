@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType identifies the event behind an inbox notification
+type NotificationType string
+
+const (
+	NotificationTypeSignatureReceived   NotificationType = "signature_received"
+	NotificationTypeEmailBounced        NotificationType = "email_bounced"
+	NotificationTypeDeadlineApproaching NotificationType = "deadline_approaching"
+	NotificationTypeSourceChanged       NotificationType = "source_changed"
+	NotificationTypeApprovalRequired    NotificationType = "signature_approval_required"
+)
+
+// Notification is an entry of a user's notification inbox (admin notifications center)
+type Notification struct {
+	ID        int64                  `json:"id"`
+	TenantID  uuid.UUID              `json:"-"`
+	Recipient string                 `json:"-"`
+	Type      NotificationType       `json:"type"`
+	DocID     string                 `json:"docId,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	ReadAt    *time.Time             `json:"readAt,omitempty"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+// NotificationInput represents a notification to store in an inbox.
+// DedupKey, when set, ensures the same event is only stored once per recipient.
+type NotificationInput struct {
+	Recipient string
+	Type      NotificationType
+	DocID     string
+	Data      map[string]interface{}
+	DedupKey  string
+}
@@ -21,3 +21,26 @@ type OAuthSession struct {
 	UserAgent             string
 	IPAddress             string
 }
+
+// SessionCleanupRun reports the rows purged by one run of the session cleanup
+type SessionCleanupRun struct {
+	StartedAt     time.Time
+	Duration      time.Duration
+	OAuthSessions int64 // OAuth sessions not refreshed within the retention
+	UserSessions  int64 // User sessions expired or revoked before the retention
+	Forced        bool  // Run requested by an admin
+	Error         string
+}
+
+// SessionCleanupStats reports the session cleanup settings of this instance and the rows it
+// purged since startup
+type SessionCleanupStats struct {
+	Interval           time.Duration
+	OAuthRetention     time.Duration
+	UserRetention      time.Duration
+	Runs               int64
+	Failures           int64
+	OAuthSessionsTotal int64
+	UserSessionsTotal  int64
+	LastRun            *SessionCleanupRun
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentPacket bundles documents signed in a single flow, such as an onboarding pack.
+// DocIDs are listed in the order signers are presented them.
+type DocumentPacket struct {
+	ID          int64     `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	DocIDs      []string  `json:"docIds"`
+	CreatedBy   string    `json:"createdBy,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type DocumentPacketInput struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	DocIDs      []string `json:"docIds"`
+}
+
+// PacketDocumentStats is the signing progress of one document of a packet
+type PacketDocumentStats struct {
+	DocID          string  `json:"docId"`
+	Title          string  `json:"title"`
+	ExpectedCount  int     `json:"expectedCount"`
+	SignedCount    int     `json:"signedCount"`
+	CompletionRate float64 `json:"completionRate"`
+}
+
+// PacketStats is the signing progress of a packet. A signer has completed the packet once every
+// document they are expected on is signed.
+type PacketStats struct {
+	PacketID        int64                  `json:"packetId"`
+	SignerCount     int                    `json:"signerCount"`
+	CompletedCount  int                    `json:"completedCount"`
+	InProgressCount int                    `json:"inProgressCount"`
+	NotStartedCount int                    `json:"notStartedCount"`
+	CompletionRate  float64                `json:"completionRate"`
+	Documents       []*PacketDocumentStats `json:"documents"`
+}
+
+// PacketSignerDocument is a document of a packet with the signing status of the current user
+type PacketSignerDocument struct {
+	DocID    string     `json:"docId"`
+	Title    string     `json:"title"`
+	URL      string     `json:"url,omitempty"`
+	Signed   bool       `json:"signed"`
+	SignedAt *time.Time `json:"signedAt,omitempty"`
+}
+
+// PacketSignerView lists the documents of a packet for one signer, NextDocID being the first
+// document left to sign
+type PacketSignerView struct {
+	ID          int64                   `json:"id"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description,omitempty"`
+	Documents   []*PacketSignerDocument `json:"documents"`
+	SignedCount int                     `json:"signedCount"`
+	NextDocID   string                  `json:"nextDocId,omitempty"`
+	Completed   bool                    `json:"completed"`
+}
+
+// PacketPendingSigner is an expected signer with documents of the packet left to sign
+type PacketPendingSigner struct {
+	Email  string
+	Name   string
+	DocIDs []string
+}
+
+// PacketReminderResult summarizes the reminders sent for a packet
+type PacketReminderResult struct {
+	TotalRecipients int      `json:"totalRecipients"`
+	RemindersQueued int      `json:"remindersQueued"`
+	Failed          int      `json:"failed"`
+	Errors          []string `json:"errors,omitempty"`
+}
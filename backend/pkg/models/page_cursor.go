@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// PageCursor is a position in a list ordered newest first by time, then by a unique key
+// breaking ties. Lists paginated with cursors (keyset pagination) stay fast at any depth
+// and never skip or repeat rows inserted while they are traversed.
+type PageCursor struct {
+	At  time.Time `json:"t"`
+	Key string    `json:"k"` // Document ID, or the decimal ID of numeric rows
+	// Backward selects the rows before the position (newer), for the previous page
+	Backward bool `json:"b,omitempty"`
+}
+
+// Encode returns the opaque form of the cursor handed to clients
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParsePageCursor decodes a cursor returned by Encode
+func ParsePageCursor(raw string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.At.IsZero() || cursor.Key == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// KeysetPage is a page of a list paginated with cursors. NextCursor and PrevCursor are
+// empty at the end and at the start of the list.
+type KeysetPage[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+}
+
+// NewKeysetPage builds a page from up to limit+1 rows fetched after cursor (nil for the first
+// page), in the order of the query: newest first, or oldest first when the cursor is backward.
+// The extra row only tells that more rows follow. position returns the cursor of a row.
+func NewKeysetPage[T any](rows []T, cursor *PageCursor, limit int, position func(T) PageCursor) *KeysetPage[T] {
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	page := &KeysetPage[T]{Items: rows}
+	if len(rows) == 0 {
+		// Past either end: the cursor position leads back into the list
+		if cursor != nil {
+			back := *cursor
+			back.Backward = !cursor.Backward
+			if backward {
+				page.NextCursor = back.Encode()
+			} else {
+				page.PrevCursor = back.Encode()
+			}
+		}
+		return page
+	}
+
+	first, last := position(rows[0]), position(rows[len(rows)-1])
+	first.Backward, last.Backward = true, false
+	if (!backward && more) || backward {
+		page.NextCursor = last.Encode()
+	}
+	if (backward && more) || (!backward && cursor != nil) {
+		page.PrevCursor = first.Encode()
+	}
+	return page
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPageCursor_EncodeParse(t *testing.T) {
+	cursor := PageCursor{At: time.Date(2025, 3, 1, 12, 0, 0, 123456000, time.UTC), Key: "42", Backward: true}
+
+	parsed, err := ParsePageCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("ParsePageCursor() error = %v", err)
+	}
+	if !parsed.At.Equal(cursor.At) || parsed.Key != cursor.Key || !parsed.Backward {
+		t.Errorf("ParsePageCursor() = %+v, want %+v", parsed, cursor)
+	}
+}
+
+func TestParsePageCursor_Invalid(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"not base64", "!!!"},
+		{"not json", encode("cursor")},
+		{"no time", encode(`{"k":"42"}`)},
+		{"no key", encode(`{"t":"2025-03-01T12:00:00Z"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePageCursor(tt.raw); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("ParsePageCursor() error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestNewKeysetPage(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	position := func(id int) PageCursor {
+		return PageCursor{At: base.Add(time.Duration(id) * time.Minute), Key: strconv.Itoa(id)}
+	}
+	forward := position(5)
+	backward := position(5)
+	backward.Backward = true
+
+	tests := []struct {
+		name     string
+		rows     []int
+		cursor   *PageCursor
+		want     []int
+		wantNext bool
+		wantPrev bool
+	}{
+		{"first page with more", []int{9, 8, 7}, nil, []int{9, 8}, true, false},
+		{"single page", []int{9, 8}, nil, []int{9, 8}, false, false},
+		{"forward with more", []int{4, 3, 2}, &forward, []int{4, 3}, true, true},
+		{"forward last page", []int{4}, &forward, []int{4}, false, true},
+		{"backward with more", []int{6, 7, 8}, &backward, []int{7, 6}, true, true},
+		{"backward first page", []int{6}, &backward, []int{6}, true, false},
+		{"past the end", nil, &forward, nil, false, true},
+		{"before the start", nil, &backward, nil, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewKeysetPage(tt.rows, tt.cursor, 2, position)
+
+			if len(page.Items) != len(tt.want) {
+				t.Fatalf("Items = %v, want %v", page.Items, tt.want)
+			}
+			for i := range tt.want {
+				if page.Items[i] != tt.want[i] {
+					t.Fatalf("Items = %v, want %v", page.Items, tt.want)
+				}
+			}
+			if (page.NextCursor != "") != tt.wantNext {
+				t.Errorf("NextCursor = %q, want set: %v", page.NextCursor, tt.wantNext)
+			}
+			if (page.PrevCursor != "") != tt.wantPrev {
+				t.Errorf("PrevCursor = %q, want set: %v", page.PrevCursor, tt.wantPrev)
+			}
+		})
+	}
+
+	page := NewKeysetPage([]int{4, 3, 2}, &forward, 2, position)
+	next, _ := ParsePageCursor(page.NextCursor)
+	prev, _ := ParsePageCursor(page.PrevCursor)
+	if next.Key != "3" || next.Backward || prev.Key != "4" || !prev.Backward {
+		t.Errorf("unexpected cursors: next=%+v prev=%+v", next, prev)
+	}
+}
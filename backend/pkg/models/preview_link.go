@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// PreviewLink is an expiring read-only link to the status of a document, shared with
+// reviewers who have no account. Only the hash of its token is stored.
+type PreviewLink struct {
+	ID             int64      `json:"id"`
+	DocID          string     `json:"docId"`
+	Label          string     `json:"label,omitempty"`
+	IncludeSigners bool       `json:"includeSigners"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+	ViewCount      int64      `json:"viewCount"`
+	LastViewedAt   *time.Time `json:"lastViewedAt,omitempty"`
+	CreatedBy      string     `json:"createdBy"`
+	CreatedAt      time.Time  `json:"createdAt"`
+
+	URL string `json:"url,omitempty"` // Preview URL with the token, set by the service on creation only
+}
+
+// IsActive reports whether the link can still be opened at now
+func (l *PreviewLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
+
+// PreviewLinkInput is the content of a preview link creation
+type PreviewLinkInput struct {
+	Label          string `json:"label"`
+	IncludeSigners bool   `json:"includeSigners"`
+	ExpiresInHours int    `json:"expiresInHours"`
+}
+
+// PreviewSigner is an expected signer listed by a preview link allowing it
+type PreviewSigner struct {
+	Email    string     `json:"email"`
+	Name     string     `json:"name,omitempty"`
+	Signed   bool       `json:"signed"`
+	SignedAt *time.Time `json:"signedAt,omitempty"`
+}
+
+// DocumentPreview is the read-only status of a document served by a preview link
+type DocumentPreview struct {
+	DocID       string              `json:"docId"`
+	Title       string              `json:"title"`
+	Description string              `json:"description,omitempty"`
+	URL         string              `json:"url,omitempty"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	Stats       *DocCompletionStats `json:"stats"`
+	Signers     []*PreviewSigner    `json:"signers,omitempty"` // Only when the link includes signers
+	ExpiresAt   time.Time           `json:"expiresAt"`
+}
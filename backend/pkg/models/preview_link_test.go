@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviewLink_IsActive(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		link PreviewLink
+		want bool
+	}{
+		{"active", PreviewLink{ExpiresAt: now.Add(time.Hour)}, true},
+		{"expired", PreviewLink{ExpiresAt: now.Add(-time.Second)}, false},
+		{"expires now", PreviewLink{ExpiresAt: now}, false},
+		{"revoked", PreviewLink{ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
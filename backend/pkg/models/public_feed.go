@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// PublicFeed lists the current documents of an organisation for intranet portals
+type PublicFeed struct {
+	Title     string            `json:"title"`
+	URL       string            `json:"url"`
+	UpdatedAt time.Time         `json:"updatedAt"` // Latest update of the listed documents
+	Documents []*PublicDocument `json:"documents"`
+}
+
+// PublicDocument is a document listed in the public feed
+type PublicDocument struct {
+	DocID             string            `json:"docId"`
+	Title             string            `json:"title"`
+	Description       string            `json:"description,omitempty"`
+	Version           int               `json:"version"`
+	Checksum          string            `json:"checksum,omitempty"`
+	ChecksumAlgorithm string            `json:"checksumAlgorithm,omitempty"`
+	URL               string            `json:"url"` // Page where the document is read and signed
+	CreatedAt         time.Time         `json:"createdAt"`
+	UpdatedAt         time.Time         `json:"updatedAt"`
+	Completion        *PublicCompletion `json:"completion,omitempty"` // Nil when hidden or without expected signers
+}
+
+// PublicCompletion is the progress of the expected signers of a document
+type PublicCompletion struct {
+	ExpectedCount  int     `json:"expectedCount"`
+	SignedCount    int     `json:"signedCount"`
+	CompletionRate float64 `json:"completionRate"` // Percentage 0-100
+}
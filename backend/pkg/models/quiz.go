@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuizQuestion is a multiple-choice question signers answer before signing
+type QuizQuestion struct {
+	ID      string   `json:"id"`
+	Text    string   `json:"text"`
+	Options []string `json:"options"`
+	Answer  int      `json:"answer"` // Index of the correct option
+}
+
+// QuizAnswers maps question IDs to the index of the chosen option
+type QuizAnswers map[string]int
+
+// DocumentQuiz is a comprehension check signers must pass before signing a document
+type DocumentQuiz struct {
+	TenantID      uuid.UUID      `json:"tenant_id" db:"tenant_id"`
+	DocID         string         `json:"docId"`
+	Questions     []QuizQuestion `json:"questions"`
+	PassThreshold int            `json:"passThreshold"` // Minimum score in percent
+	UpdatedBy     string         `json:"updatedBy,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+type DocumentQuizInput struct {
+	Questions     []QuizQuestion `json:"questions"`
+	PassThreshold int            `json:"passThreshold"`
+}
+
+// Score returns the percentage of questions answered correctly
+func (q *DocumentQuiz) Score(answers QuizAnswers) int {
+	if len(q.Questions) == 0 {
+		return 100
+	}
+	correct := 0
+	for _, question := range q.Questions {
+		if choice, ok := answers[question.ID]; ok && choice == question.Answer {
+			correct++
+		}
+	}
+	return correct * 100 / len(q.Questions)
+}
+
+// SignerQuiz is the quiz shown to signers, without the correct answers
+type SignerQuiz struct {
+	DocID         string               `json:"docId"`
+	Questions     []SignerQuizQuestion `json:"questions"`
+	PassThreshold int                  `json:"passThreshold"`
+}
+
+type SignerQuizQuestion struct {
+	ID      string   `json:"id"`
+	Text    string   `json:"text"`
+	Options []string `json:"options"`
+}
+
+// ForSigner strips the correct answers from the quiz
+func (q *DocumentQuiz) ForSigner() *SignerQuiz {
+	questions := make([]SignerQuizQuestion, 0, len(q.Questions))
+	for _, question := range q.Questions {
+		questions = append(questions, SignerQuizQuestion{ID: question.ID, Text: question.Text, Options: question.Options})
+	}
+	return &SignerQuiz{DocID: q.DocID, Questions: questions, PassThreshold: q.PassThreshold}
+}
+
+// QuizResult is the quiz outcome recorded with a signature
+type QuizResult struct {
+	UserEmail string      `json:"userEmail"`
+	Score     int         `json:"score"`
+	Answers   QuizAnswers `json:"answers"`
+	SignedAt  time.Time   `json:"signedAt"`
+}
+
+// QuizStats aggregates the quiz results of a document's signers
+type QuizStats struct {
+	DocID         string              `json:"docId"`
+	PassThreshold int                 `json:"passThreshold"`
+	ResultCount   int                 `json:"resultCount"`
+	AverageScore  float64             `json:"averageScore"`
+	Questions     []QuizQuestionStats `json:"questions"`
+}
+
+// QuizQuestionStats counts how signers answered a question
+type QuizQuestionStats struct {
+	ID           string  `json:"id"`
+	Text         string  `json:"text"`
+	CorrectCount int     `json:"correctCount"`
+	CorrectRate  float64 `json:"correctRate"`  // Percentage of signers who chose the correct option
+	OptionCounts []int   `json:"optionCounts"` // Number of signers who chose each option
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Actions protected by a rate limit
+const (
+	RateLimitActionSignature = "signature"
+	RateLimitActionMagicLink = "magic_link"
+)
+
+// Scopes of a rate limit: the limit applies per user email or per client IP
+const (
+	RateLimitScopeUser = "user"
+	RateLimitScopeIP   = "ip"
+)
+
+// RateLimitViolation is a request rejected because a rate limit was reached
+type RateLimitViolation struct {
+	ID        int64     `json:"id"`
+	TenantID  uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Action    string    `json:"action"`
+	Scope     string    `json:"scope"`
+	UserEmail string    `json:"userEmail,omitempty"`
+	IPAddress string    `json:"ipAddress,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RateLimitOffender aggregates the violations of one user or IP, to spot scripted abuse
+type RateLimitOffender struct {
+	Scope      string    `json:"scope"`
+	Subject    string    `json:"subject"`
+	Violations int       `json:"violations"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+}
+
+// RateLimitQuota is a limit applying to the caller and how much of it is left, published so that
+// integrators can throttle themselves before being rejected
+type RateLimitQuota struct {
+	Name          string `json:"name"` // Route group or protected action
+	Scope         string `json:"scope"`
+	Limit         int    `json:"limit"`
+	WindowSeconds int    `json:"windowSeconds"`
+	Remaining     int    `json:"remaining"`
+	// ResetSeconds is the delay before a request is freed, 0 when nothing is used
+	ResetSeconds int `json:"resetSeconds"`
+}
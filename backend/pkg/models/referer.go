@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RefererPolicy selects what is kept of the referer recorded with each signature,
+// which may otherwise leak internal URLs
+type RefererPolicy string
+
+const (
+	RefererPolicyKeep   RefererPolicy = "keep"
+	RefererPolicyOrigin RefererPolicy = "origin" // scheme and host only
+	RefererPolicyHash   RefererPolicy = "hash"   // SHA-256, still comparable between signatures
+	RefererPolicyDrop   RefererPolicy = "drop"
+)
+
+// refererHashPrefix marks hashed referers, so sanitizing them again leaves them unchanged
+const refererHashPrefix = "sha256:"
+
+// serviceRefererPattern matches the service names (google-docs, notion, ...) sent by
+// integrations instead of a URL; they reveal nothing and are kept by every policy but drop
+var serviceRefererPattern = regexp.MustCompile(`^[a-z0-9-]{1,64}$`)
+
+// IsValid reports whether the policy is known; empty means keep
+func (p RefererPolicy) IsValid() bool {
+	switch p {
+	case "", RefererPolicyKeep, RefererPolicyOrigin, RefererPolicyHash, RefererPolicyDrop:
+		return true
+	}
+	return false
+}
+
+// Apply returns the referer to persist under the policy
+func (p RefererPolicy) Apply(referer *string) *string {
+	if referer == nil {
+		return nil
+	}
+	value := strings.TrimSpace(*referer)
+	if value == "" || p == RefererPolicyDrop {
+		return nil
+	}
+	if p != RefererPolicyOrigin && p != RefererPolicyHash {
+		return referer
+	}
+	if serviceRefererPattern.MatchString(value) || strings.HasPrefix(value, refererHashPrefix) {
+		return &value
+	}
+
+	if p == RefererPolicyHash {
+		sum := sha256.Sum256([]byte(value))
+		hashed := refererHashPrefix + hex.EncodeToString(sum[:])
+		return &hashed
+	}
+
+	// Anything that does not parse as an absolute URL is dropped rather than kept whole
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil
+	}
+	origin := u.Scheme + "://" + u.Host
+	return &origin
+}
+
+// SignatureReferer is the referer recorded with a signature
+type SignatureReferer struct {
+	ID      int64
+	Referer string
+}
+
+// RefererSanitizeResult reports a retroactive sanitization of recorded referers
+type RefererSanitizeResult struct {
+	Policy       RefererPolicy `json:"policy"`
+	Scanned      int           `json:"scanned"`
+	Changed      int           `json:"changed"`
+	DryRun       bool          `json:"dryRun"`
+	ChainRebuilt bool          `json:"chainRebuilt"`
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestRefererPolicy_Apply(t *testing.T) {
+	internal := "https://intranet.corp.local/hr/policies?id=42#top"
+
+	tests := []struct {
+		name    string
+		policy  RefererPolicy
+		referer *string
+		want    *string
+	}{
+		{"nil referer", RefererPolicyOrigin, nil, nil},
+		{"blank referer", RefererPolicyKeep, strPtr("  "), nil},
+		{"keep", RefererPolicyKeep, strPtr(internal), strPtr(internal)},
+		{"empty policy keeps", "", strPtr(internal), strPtr(internal)},
+		{"origin", RefererPolicyOrigin, strPtr(internal), strPtr("https://intranet.corp.local")},
+		{"origin drops relative", RefererPolicyOrigin, strPtr("/hr/policies"), nil},
+		{"origin keeps service names", RefererPolicyOrigin, strPtr("google-docs"), strPtr("google-docs")},
+		{"drop", RefererPolicyDrop, strPtr("google-docs"), nil},
+		{"hash keeps service names", RefererPolicyHash, strPtr("notion"), strPtr("notion")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Apply(tt.referer)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("Apply() = %v, want %v", deref(got), deref(tt.want))
+			}
+		})
+	}
+}
+
+func TestRefererPolicy_ApplyHash(t *testing.T) {
+	first := RefererPolicyHash.Apply(strPtr("https://intranet.corp.local/a"))
+	if first == nil || !strings.HasPrefix(*first, "sha256:") || strings.Contains(*first, "intranet") {
+		t.Fatalf("unexpected hash %v", deref(first))
+	}
+	if again := RefererPolicyHash.Apply(strPtr("https://intranet.corp.local/a")); *again != *first {
+		t.Errorf("hash should be stable, got %s and %s", *first, *again)
+	}
+	if rehashed := RefererPolicyHash.Apply(first); *rehashed != *first {
+		t.Errorf("hashing a hash should leave it unchanged, got %s", *rehashed)
+	}
+}
+
+func TestRefererPolicy_IsValid(t *testing.T) {
+	for _, p := range []RefererPolicy{"", RefererPolicyKeep, RefererPolicyOrigin, RefererPolicyHash, RefererPolicyDrop} {
+		if !p.IsValid() {
+			t.Errorf("%q should be valid", p)
+		}
+	}
+	if RefererPolicy("truncate").IsValid() {
+		t.Error("unknown policy should be invalid")
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
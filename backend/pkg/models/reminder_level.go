@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// ReminderLevel is the escalation level of a reminder, each level using its own email template
+type ReminderLevel string
+
+const (
+	ReminderLevelGentle ReminderLevel = "gentle"
+	ReminderLevelFirm   ReminderLevel = "firm"
+	ReminderLevelFinal  ReminderLevel = "final"
+)
+
+// Default escalation thresholds, used when the reminder configuration leaves them at zero
+const (
+	DefaultFirmAfterReminders  = 2
+	DefaultFirmAfterDays       = 14
+	DefaultFinalAfterReminders = 4
+	DefaultFinalAfterDays      = 30
+)
+
+// MaxReminderMinIntervalHours bounds the minimum interval between reminders to a recipient (30 days)
+const MaxReminderMinIntervalHours = 720
+
+var reminderLevelTemplates = map[ReminderLevel]string{
+	ReminderLevelGentle: "signature_reminder",
+	ReminderLevelFirm:   "signature_reminder_firm",
+	ReminderLevelFinal:  "signature_reminder_final",
+}
+
+// IsValid reports whether the level is a known level
+func (l ReminderLevel) IsValid() bool {
+	_, ok := reminderLevelTemplates[l]
+	return ok
+}
+
+// Template returns the email template of the level, also recorded as template_used in the reminder log
+func (l ReminderLevel) Template() string {
+	if template, ok := reminderLevelTemplates[l]; ok {
+		return template
+	}
+	return reminderLevelTemplates[ReminderLevelGentle]
+}
+
+// SubjectKey returns the i18n key of the email subject of the level
+func (l ReminderLevel) SubjectKey() string {
+	switch l {
+	case ReminderLevelFirm:
+		return "email.reminder_firm.subject"
+	case ReminderLevelFinal:
+		return "email.reminder_final.subject"
+	default:
+		return "email.reminder.subject"
+	}
+}
+
+// Thresholds returns the escalation thresholds with defaults applied to unset values:
+// reminders already sent and days since the signer was added from which each level applies
+func (c ReminderConfig) Thresholds() (firmReminders, firmDays, finalReminders, finalDays int) {
+	firmReminders, firmDays = c.FirmAfterReminders, c.FirmAfterDays
+	finalReminders, finalDays = c.FinalAfterReminders, c.FinalAfterDays
+	if firmReminders == 0 {
+		firmReminders = DefaultFirmAfterReminders
+	}
+	if firmDays == 0 {
+		firmDays = DefaultFirmAfterDays
+	}
+	if finalReminders == 0 {
+		finalReminders = DefaultFinalAfterReminders
+	}
+	if finalDays == 0 {
+		finalDays = DefaultFinalAfterDays
+	}
+	return firmReminders, firmDays, finalReminders, finalDays
+}
+
+// LevelFor selects the level of the next reminder of a signer from the reminders already sent
+// and the days the signer has been pending. Either threshold is enough to escalate.
+func (c ReminderConfig) LevelFor(remindersSent, daysPending int) ReminderLevel {
+	if c.DisableEscalation {
+		return ReminderLevelGentle
+	}
+	firmReminders, firmDays, finalReminders, finalDays := c.Thresholds()
+	switch {
+	case remindersSent >= finalReminders || daysPending >= finalDays:
+		return ReminderLevelFinal
+	case remindersSent >= firmReminders || daysPending >= firmDays:
+		return ReminderLevelFirm
+	default:
+		return ReminderLevelGentle
+	}
+}
+
+// MinInterval returns the minimum time between two reminders of a document to the same
+// recipient, zero without limit
+func (c ReminderConfig) MinInterval() time.Duration {
+	return time.Duration(c.MinIntervalHours) * time.Hour
+}
+
+// NextReminderAllowed returns when a recipient last reminded at last may be reminded again,
+// and whether that is already the case at now
+func (c ReminderConfig) NextReminderAllowed(last *time.Time, now time.Time) (time.Time, bool) {
+	if c.MinIntervalHours <= 0 || last == nil {
+		return now, true
+	}
+	next := last.Add(c.MinInterval())
+	return next, !next.After(now)
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminderConfig_LevelFor(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        ReminderConfig
+		remindersSent int
+		daysPending   int
+		want          ReminderLevel
+	}{
+		{name: "first reminder", want: ReminderLevelGentle},
+		{name: "default firm by count", remindersSent: 2, want: ReminderLevelFirm},
+		{name: "default firm by days", remindersSent: 1, daysPending: 14, want: ReminderLevelFirm},
+		{name: "default final by count", remindersSent: 4, want: ReminderLevelFinal},
+		{name: "default final by days", daysPending: 30, want: ReminderLevelFinal},
+		{
+			name:          "custom thresholds",
+			config:        ReminderConfig{FirmAfterReminders: 1, FinalAfterReminders: 2, FirmAfterDays: 3, FinalAfterDays: 7},
+			remindersSent: 1,
+			want:          ReminderLevelFirm,
+		},
+		{
+			name:        "custom final by days",
+			config:      ReminderConfig{FirmAfterDays: 3, FinalAfterDays: 7},
+			daysPending: 7,
+			want:        ReminderLevelFinal,
+		},
+		{
+			name:          "escalation disabled",
+			config:        ReminderConfig{DisableEscalation: true},
+			remindersSent: 10,
+			daysPending:   90,
+			want:          ReminderLevelGentle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.LevelFor(tt.remindersSent, tt.daysPending); got != tt.want {
+				t.Errorf("LevelFor(%d, %d) = %q, want %q", tt.remindersSent, tt.daysPending, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderLevel_Template(t *testing.T) {
+	tests := map[ReminderLevel]string{
+		ReminderLevelGentle: "signature_reminder",
+		ReminderLevelFirm:   "signature_reminder_firm",
+		ReminderLevelFinal:  "signature_reminder_final",
+		"unknown":           "signature_reminder",
+	}
+	for level, want := range tests {
+		if got := level.Template(); got != want {
+			t.Errorf("%q.Template() = %q, want %q", level, got, want)
+		}
+	}
+	if ReminderLevel("unknown").IsValid() {
+		t.Error("expected unknown level to be invalid")
+	}
+}
+
+func TestReminderConfig_NextReminderAllowed(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-2 * time.Hour)
+	old := now.Add(-30 * time.Hour)
+
+	if _, ok := (ReminderConfig{}).NextReminderAllowed(&recent, now); !ok {
+		t.Error("expected no limit without a minimum interval")
+	}
+	cfg := ReminderConfig{MinIntervalHours: 24}
+	if _, ok := cfg.NextReminderAllowed(nil, now); !ok {
+		t.Error("expected a recipient never reminded to be allowed")
+	}
+	if _, ok := cfg.NextReminderAllowed(&old, now); !ok {
+		t.Error("expected a recipient reminded before the interval to be allowed")
+	}
+	next, ok := cfg.NextReminderAllowed(&recent, now)
+	if ok || !next.Equal(recent.Add(24*time.Hour)) {
+		t.Errorf("expected the next reminder 24h after the last one, got %s, %v", next, ok)
+	}
+}
@@ -27,10 +27,40 @@ type ReminderStats struct {
 	PendingCount int        `json:"pending_count"`
 }
 
-// ReminderSendResult represents the result of a bulk reminder send operation
+// ReminderSendResult represents the result of a bulk reminder send operation.
+// Attempted reminders are either sent, failed or skipped.
 type ReminderSendResult struct {
-	TotalAttempted   int      `json:"total_attempted"`
-	SuccessfullySent int      `json:"successfully_sent"`
-	Failed           int      `json:"failed"`
-	Errors           []string `json:"errors,omitempty"`
+	TotalAttempted   int            `json:"total_attempted"`
+	SuccessfullySent int            `json:"successfully_sent"`
+	Deferred         int            `json:"deferred,omitempty"` // Queued until the working hours of the recipient
+	Failed           int            `json:"failed"`
+	Skipped          []ReminderSkip `json:"skipped,omitempty"`
+	Errors           []string       `json:"errors,omitempty"`
+}
+
+// ReminderSkipReason tells why a reminder was not sent to a pending signer
+type ReminderSkipReason string
+
+const (
+	// ReminderSkipMinInterval marks a recipient reminded of the document within the minimum interval
+	ReminderSkipMinInterval ReminderSkipReason = "min_interval"
+	// ReminderSkipExtensionPending marks a signer whose deadline extension request awaits a decision
+	ReminderSkipExtensionPending ReminderSkipReason = "extension_pending"
+)
+
+// ReminderSkip is a pending signer left out of a reminder send
+type ReminderSkip struct {
+	Email         string             `json:"email"`
+	Reason        ReminderSkipReason `json:"reason"`
+	NextAllowedAt *time.Time         `json:"next_allowed_at,omitempty"`
+}
+
+// ReminderDigestResult summarizes a digest reminder run
+type ReminderDigestResult struct {
+	TotalRecipients int      `json:"total_recipients"`
+	DigestsQueued   int      `json:"digests_queued"`
+	DocumentCount   int      `json:"document_count"`
+	Deferred        int      `json:"deferred,omitempty"` // Queued until the working hours of the recipient
+	Failed          int      `json:"failed"`
+	Errors          []string `json:"errors,omitempty"`
 }
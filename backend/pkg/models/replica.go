@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// ReplicaStatus reports the health of the read replica at its last check
+type ReplicaStatus struct {
+	Healthy    bool       `json:"healthy"`     // Heavy reads are served by the replica
+	LagSeconds float64    `json:"lag_seconds"` // Replication lag measured at the last check
+	MaxLag     float64    `json:"max_lag"`     // Lag in seconds above which reads fall back to the primary
+	CheckedAt  *time.Time `json:"checked_at,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
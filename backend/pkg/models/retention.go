@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionPolicy controls automatic archiving of completed documents for a tenant
+type RetentionPolicy struct {
+	TenantID         uuid.UUID `json:"tenant_id" db:"tenant_id"`
+	Enabled          bool      `json:"enabled"`
+	ArchiveAfterDays int       `json:"archiveAfterDays"`
+	PurgePII         bool      `json:"purgePii"`
+	UpdatedBy        string    `json:"updatedBy,omitempty"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+type RetentionPolicyInput struct {
+	Enabled          bool `json:"enabled"`
+	ArchiveAfterDays int  `json:"archiveAfterDays"`
+	PurgePII         bool `json:"purgePii"`
+}
+
+// DocumentArchive is a compressed export of a completed document stored in object storage
+type DocumentArchive struct {
+	ID             int64      `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	DocID          string     `json:"docId"`
+	Title          string     `json:"title"`
+	StorageKey     string     `json:"-"`
+	SizeBytes      int64      `json:"sizeBytes"`
+	SignatureCount int        `json:"signatureCount"`
+	PIIPurged      bool       `json:"piiPurged"`
+	ArchivedBy     string     `json:"archivedBy"`
+	ArchivedAt     time.Time  `json:"archivedAt"`
+	RestoredBy     string     `json:"restoredBy,omitempty"`
+	RestoredAt     *time.Time `json:"restoredAt,omitempty"`
+}
+
+// IsRestored reports whether the archived document has been brought back
+func (a *DocumentArchive) IsRestored() bool {
+	return a.RestoredAt != nil
+}
+
+type DocumentArchiveInput struct {
+	DocID          string
+	Title          string
+	StorageKey     string
+	SizeBytes      int64
+	SignatureCount int
+	PIIPurged      bool
+	ArchivedBy     string
+}
+
+// ArchiveExport is the payload written (gzip-compressed JSON) for each archived document
+type ArchiveExport struct {
+	FormatVersion   int               `json:"formatVersion"`
+	ArchivedAt      time.Time         `json:"archivedAt"`
+	Document        *Document         `json:"document"`
+	ExpectedSigners []*ExpectedSigner `json:"expectedSigners"`
+	Signatures      []*Signature      `json:"signatures"`
+}
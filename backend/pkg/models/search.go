@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+// DocumentSearchHit is a document matching a full-text search
+type DocumentSearchHit struct {
+	DocID            string
+	Title            string
+	Description      string
+	URL              string
+	OriginalFilename string
+	Rank             float64
+}
+
+// SignerSearchHit is an expected signer or a signer matching a full-text search
+type SignerSearchHit struct {
+	DocID    string
+	DocTitle string
+	Email    string
+	Name     string
+	Signed   bool
+	Rank     float64
+}
+
+// SearchMatch is a highlighted excerpt of a matching document field.
+// Highlight is HTML: text is escaped and matches are wrapped in <mark>.
+type SearchMatch struct {
+	Field     string `json:"field"` // title, description, url, filename
+	Highlight string `json:"highlight"`
+}
+
+// SearchSignerMatch is a matching signer of a document
+type SearchSignerMatch struct {
+	Email     string `json:"email"`
+	Name      string `json:"name,omitempty"`
+	Signed    bool   `json:"signed"`
+	Highlight string `json:"highlight"`
+}
+
+// SearchResult groups the matches of one document
+type SearchResult struct {
+	DocID   string              `json:"docId"`
+	Title   string              `json:"title"`
+	Rank    float64             `json:"rank"`
+	Matches []SearchMatch       `json:"matches"`
+	Signers []SearchSignerMatch `json:"signers"`
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of failed authentication attempts tracked for security alerts
+const (
+	AuthFailureOAuthCallback = "oauth_callback" // OAuth callback with an error, an invalid state or a rejected code
+	AuthFailureMagicLink     = "magic_link"     // Invalid, expired or already used magic link
+	AuthFailureCSRF          = "csrf"           // State-changing request without a valid CSRF token
+)
+
+// SecurityEvent is raised when a user or IP exceeds the failed authentication threshold.
+// Scope is RateLimitScopeUser or RateLimitScopeIP, Subject the email or IP address.
+type SecurityEvent struct {
+	ID             int64      `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id" db:"tenant_id"`
+	Kind           string     `json:"kind"`
+	Scope          string     `json:"scope"`
+	Subject        string     `json:"subject"`
+	Failures       int        `json:"failures"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	AlertedAt      *time.Time `json:"alertedAt,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	AcknowledgedBy string     `json:"acknowledgedBy,omitempty"`
+}
+
+// AuthFailureCount is the number of failed attempts of one kind over a period
+type AuthFailureCount struct {
+	Kind     string `json:"kind"`
+	Failures int    `json:"failures"`
+	IPs      int    `json:"ips"`
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// ShortLink is a short /s/{code} link to the signing page of a document, printed as a QR code
+// on notices. A document has at most one link per locale; an empty locale keeps the browser language.
+type ShortLink struct {
+	ID            int64      `json:"id"`
+	Code          string     `json:"code"`
+	DocID         string     `json:"docId"`
+	Locale        string     `json:"locale,omitempty"`
+	ScanCount     int64      `json:"scanCount"`
+	LastScannedAt *time.Time `json:"lastScannedAt,omitempty"`
+	CreatedBy     string     `json:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt"`
+
+	URL string `json:"url"` // Public short URL, set by the service
+}
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignRedirect is the URL a signer is sent to after signing the document
+type SignRedirect struct {
+	TenantID  uuid.UUID `json:"-"`
+	DocID     string    `json:"docId"`
+	URL       string    `json:"url"`
+	UpdatedBy string    `json:"updatedBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ParseRedirectOrigins parses the origins sign redirects may point to (comma or space separated),
+// such as https://intranet.example.com or https://*.example.com. "*" is refused: any origin
+// would make Ackify an open redirect.
+func ParseRedirectOrigins(raw string) ([]string, error) {
+	origins := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	for i, origin := range origins {
+		origin = strings.TrimSuffix(origin, "/")
+		if !corsOrigin.MatchString(origin) {
+			return nil, fmt.Errorf("invalid origin %q", origin)
+		}
+		origins[i] = origin
+	}
+	return origins, nil
+}
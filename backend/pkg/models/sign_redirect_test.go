@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "testing"
+
+func TestParseRedirectOrigins(t *testing.T) {
+	origins, err := ParseRedirectOrigins("https://intranet.example.com/, https://*.example.org http://localhost:8080")
+	if err != nil {
+		t.Fatalf("ParseRedirectOrigins() error: %v", err)
+	}
+	want := []string{"https://intranet.example.com", "https://*.example.org", "http://localhost:8080"}
+	if len(origins) != len(want) {
+		t.Fatalf("origins = %v, want %v", origins, want)
+	}
+	for i := range want {
+		if origins[i] != want[i] {
+			t.Errorf("origins[%d] = %q, want %q", i, origins[i], want[i])
+		}
+	}
+
+	for _, raw := range []string{"*", "https://example.com/survey", "intranet.example.com", "ftp://example.com"} {
+		if _, err := ParseRedirectOrigins(raw); err == nil {
+			t.Errorf("ParseRedirectOrigins(%q) should fail", raw)
+		}
+	}
+}
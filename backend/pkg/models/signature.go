@@ -29,6 +29,17 @@ type Signature struct {
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	HashVersion  int        `json:"hash_version" db:"hash_version"`
 	DocDeletedAt *time.Time `json:"doc_deleted_at,omitempty" db:"doc_deleted_at"`
+	// Comprehension quiz outcome, set when the document has a quiz
+	QuizScore   *int        `json:"quiz_score,omitempty" db:"quiz_score"`
+	QuizAnswers QuizAnswers `json:"quiz_answers,omitempty" db:"quiz_answers"`
+	// AuthMethod records how the signer was authenticated (AuthMethodSession or AuthMethodEmailCode)
+	AuthMethod string `json:"auth_method" db:"auth_method"`
+	// Locale is the language the signing page was displayed in
+	Locale string `json:"locale,omitempty" db:"locale"`
+	// Consent shown next to the sign button, set when the tenant has a consent catalog
+	ConsentVersion *int   `json:"consent_version,omitempty" db:"consent_version"`
+	ConsentLocale  string `json:"consent_locale,omitempty" db:"consent_locale"`
+	ConsentText    string `json:"consent_text,omitempty" db:"consent_text"`
 	// Document metadata enriched from LEFT JOIN (not stored in signatures table)
 	DocTitle string `json:"doc_title,omitempty"`
 	DocURL   string `json:"doc_url,omitempty"`
@@ -42,9 +53,20 @@ func (s *Signature) GetServiceInfo() *services.ServiceInfo {
 }
 
 type SignatureRequest struct {
-	DocID   string
-	User    *User
-	Referer *string
+	DocID       string
+	User        *User
+	Referer     *string
+	QuizAnswers QuizAnswers
+	// AuthMethod defaults to AuthMethodSession when empty
+	AuthMethod string
+	// IPAddress is the client IP, used by rate limiting
+	IPAddress string
+	// Nonce is the consumed anti-replay nonce, recorded as the signature nonce; one is generated when empty
+	Nonce string
+	// Locale is the display language of the signing page
+	Locale string
+	// ConsentVersion is the consent version the page displayed, checked against the current one when set
+	ConsentVersion *int
 }
 
 type SignatureStatus struct {
@@ -52,6 +74,8 @@ type SignatureStatus struct {
 	UserEmail string
 	IsSigned  bool
 	SignedAt  *time.Time
+	// PendingBefore counts the ordered signers who must sign before this user
+	PendingBefore int
 }
 
 // ComputeRecordHash computes the hash of the signature record for blockchain integrity
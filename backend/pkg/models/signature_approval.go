@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApprovalStatus is the review state of a held signature
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+)
+
+// SignatureApproval is the signature of a user missing from the expected signers of a document,
+// held for admin review. Until approved, it does not count in the signature lists and counts.
+type SignatureApproval struct {
+	ID          int64          `json:"id"`
+	TenantID    uuid.UUID      `json:"-"`
+	SignatureID int64          `json:"signatureId"`
+	DocID       string         `json:"docId"`
+	UserEmail   string         `json:"userEmail"`
+	UserName    string         `json:"userName,omitempty"`
+	Status      ApprovalStatus `json:"status"`
+	ReviewedBy  *string        `json:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time     `json:"reviewedAt,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
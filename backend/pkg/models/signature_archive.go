@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// SignatureArchive is the result of a signature archive job: a signed export bundle of the
+// signatures of every document, kept in object storage until ExpiresAt
+type SignatureArchive struct {
+	ArchiveID   string    `json:"archiveId"`
+	Documents   int       `json:"documents"`
+	Signatures  int       `json:"signatures"`
+	Size        int64     `json:"size"`
+	SHA256      string    `json:"sha256"`
+	DownloadURL string    `json:"downloadUrl"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
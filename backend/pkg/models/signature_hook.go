@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignatureHookFailurePolicy decides the outcome of a signature when its hook cannot be evaluated
+type SignatureHookFailurePolicy string
+
+const (
+	// SignatureHookFailOpen allows the signature when the hook times out or fails
+	SignatureHookFailOpen SignatureHookFailurePolicy = "open"
+	// SignatureHookFailClosed refuses the signature when the hook times out or fails
+	SignatureHookFailClosed SignatureHookFailurePolicy = "closed"
+)
+
+// SignatureHook is an HTTP callout evaluated before a signature of the document is created.
+// The secret is never returned, HasSecret tells whether requests are signed.
+type SignatureHook struct {
+	TenantID      uuid.UUID                  `json:"tenant_id" db:"tenant_id"`
+	DocID         string                     `json:"docId"`
+	URL           string                     `json:"url"`
+	Secret        string                     `json:"-"`
+	HasSecret     bool                       `json:"hasSecret"`
+	TimeoutMs     int                        `json:"timeoutMs"`
+	FailurePolicy SignatureHookFailurePolicy `json:"failurePolicy"`
+	UpdatedBy     string                     `json:"updatedBy,omitempty"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	UpdatedAt     time.Time                  `json:"updatedAt"`
+}
+
+// SignatureHookInput configures the hook of a document. An empty secret keeps the stored one.
+type SignatureHookInput struct {
+	URL           string                     `json:"url"`
+	Secret        string                     `json:"secret,omitempty"`
+	TimeoutMs     int                        `json:"timeoutMs,omitempty"`
+	FailurePolicy SignatureHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// SignatureHookDecision is the answer of a hook to a signature attempt
+type SignatureHookDecision struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SignatureRejectedError is returned when a validation hook refuses a signature.
+// It matches ErrSignatureRejected with errors.Is.
+type SignatureRejectedError struct {
+	Reason string
+}
+
+func (e *SignatureRejectedError) Error() string {
+	if e.Reason == "" {
+		return ErrSignatureRejected.Error()
+	}
+	return ErrSignatureRejected.Error() + ": " + e.Reason
+}
+
+func (e *SignatureRejectedError) Is(target error) bool {
+	return target == ErrSignatureRejected
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSignatureRejectedError(t *testing.T) {
+	err := fmt.Errorf("hook: %w", &SignatureRejectedError{Reason: "training not completed"})
+
+	if !errors.Is(err, ErrSignatureRejected) {
+		t.Error("expected the rejection to match ErrSignatureRejected")
+	}
+	var rejected *SignatureRejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != "training not completed" {
+		t.Errorf("expected the reason to be kept, got %+v", rejected)
+	}
+	if got := (&SignatureRejectedError{}).Error(); got != ErrSignatureRejected.Error() {
+		t.Errorf("unexpected message without reason: %q", got)
+	}
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SignatureIdempotencyKey is the Idempotency-Key of a signature request, stored with the response
+// to replay when the client retries the same request
+type SignatureIdempotencyKey struct {
+	Key         string
+	UserSub     string
+	Fingerprint string          // SHA-256 of the request body
+	StatusCode  int             // 0 while the request is in progress
+	Response    json.RawMessage // Data of the response
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignatureNonce is a single-use nonce a signer fetches before signing a document
+type SignatureNonce struct {
+	TenantID  uuid.UUID  `json:"-"`
+	Nonce     string     `json:"nonce"`
+	UserSub   string     `json:"-"`
+	DocID     string     `json:"docId"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"-"`
+}
+
+// ReplayProtectionStats reports the nonces issued and the signature requests rejected since startup
+type ReplayProtectionStats struct {
+	Required bool          `json:"required"`
+	TTL      time.Duration `json:"-"`
+	Issued   int64         `json:"issued"`
+	Consumed int64         `json:"consumed"`
+	Replayed int64         `json:"replayed"` // Nonce already used by a signature
+	Expired  int64         `json:"expired"`
+	Invalid  int64         `json:"invalid"` // Unknown nonce, or issued for another user or document
+	Missing  int64         `json:"missing"` // Request without nonce while nonces are required
+}
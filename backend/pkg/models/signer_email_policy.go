@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// SignerEmailReason is why an expected signer email was rejected by the tenant policy
+type SignerEmailReason string
+
+const (
+	SignerEmailInvalid          SignerEmailReason = "invalid_email"
+	SignerEmailDomainNotAllowed SignerEmailReason = "domain_not_allowed"
+	SignerEmailDomainBlocked    SignerEmailReason = "domain_blocked"
+	SignerEmailNoMX             SignerEmailReason = "no_mx"
+)
+
+// Message returns a sentence explaining the rejection, shown in the import report
+func (r SignerEmailReason) Message() string {
+	switch r {
+	case SignerEmailInvalid:
+		return "Invalid email address"
+	case SignerEmailDomainNotAllowed:
+		return "Email domain is not in the allowed domains"
+	case SignerEmailDomainBlocked:
+		return "Email domain is blocked"
+	case SignerEmailNoMX:
+		return "Email domain does not receive mail (no MX record)"
+	}
+	return string(r)
+}
+
+// SignerEmailRejection is an expected signer email refused by the tenant policy
+type SignerEmailRejection struct {
+	Row     int               `json:"row,omitempty"` // 1-based position in the import, 0 for a single add
+	Email   string            `json:"email"`
+	Reason  SignerEmailReason `json:"reason"`
+	Message string            `json:"message"`
+}
+
+// SignerEmailPolicy restricts the emails admins can add as expected signers.
+// A domain entry also matches its subdomains.
+type SignerEmailPolicy struct {
+	// AllowedDomains only accepts these domains; empty accepts every domain not blocked
+	AllowedDomains []string `json:"allowed_domains,omitempty"`
+	BlockedDomains []string `json:"blocked_domains,omitempty"`
+	// RequireMX rejects the domains without MX record, usually typos (gmial.com)
+	RequireMX bool `json:"require_mx"`
+}
+
+var domainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// Validate checks the domain lists
+func (p *SignerEmailPolicy) Validate() error {
+	for _, domain := range append(append([]string{}, p.AllowedDomains...), p.BlockedDomains...) {
+		if !domainPattern.MatchString(domain) {
+			return fmt.Errorf("signer_emails: %q is not a lowercase domain name", domain)
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true when the policy accepts every valid email
+func (p *SignerEmailPolicy) IsEmpty() bool {
+	return len(p.AllowedDomains) == 0 && len(p.BlockedDomains) == 0 && !p.RequireMX
+}
+
+// CheckDomain returns why an email is refused by the domain lists, or an empty reason.
+// It also returns the domain of the email, for the MX check.
+func (p *SignerEmailPolicy) CheckDomain(email string) (string, SignerEmailReason) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return "", SignerEmailInvalid
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	domain := strings.ToLower(addr.Address[at+1:])
+	if !domainPattern.MatchString(domain) {
+		return "", SignerEmailInvalid
+	}
+
+	if matchesDomain(domain, p.BlockedDomains) {
+		return domain, SignerEmailDomainBlocked
+	}
+	if len(p.AllowedDomains) > 0 && !matchesDomain(domain, p.AllowedDomains) {
+		return domain, SignerEmailDomainNotAllowed
+	}
+	return domain, ""
+}
+
+// matchesDomain returns true if domain is one of the entries or a subdomain of one
+func matchesDomain(domain string, entries []string) bool {
+	for _, entry := range entries {
+		if domain == entry || strings.HasSuffix(domain, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
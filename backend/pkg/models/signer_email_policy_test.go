@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "testing"
+
+func TestSignerEmailPolicy_CheckDomain(t *testing.T) {
+	policy := SignerEmailPolicy{
+		AllowedDomains: []string{"corp.com", "partner.org"},
+		BlockedDomains: []string{"contractors.corp.com"},
+	}
+
+	tests := []struct {
+		email  string
+		domain string
+		reason SignerEmailReason
+	}{
+		{"alice@corp.com", "corp.com", ""},
+		{"Bob <bob@EU.Corp.com>", "eu.corp.com", ""},
+		{"carol@partner.org", "partner.org", ""},
+		{"dave@contractors.corp.com", "contractors.corp.com", SignerEmailDomainBlocked},
+		{"eve@gmail.com", "gmail.com", SignerEmailDomainNotAllowed},
+		{"mallory@notcorp.com", "notcorp.com", SignerEmailDomainNotAllowed},
+		{"not-an-email", "", SignerEmailInvalid},
+		{"frank@localhost", "", SignerEmailInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			domain, reason := policy.CheckDomain(tt.email)
+			if domain != tt.domain || reason != tt.reason {
+				t.Errorf("CheckDomain(%q) = %q, %q; want %q, %q", tt.email, domain, reason, tt.domain, tt.reason)
+			}
+		})
+	}
+
+	empty := SignerEmailPolicy{}
+	if !empty.IsEmpty() {
+		t.Error("expected an empty policy")
+	}
+	if _, reason := empty.CheckDomain("eve@gmail.com"); reason != "" {
+		t.Errorf("expected an empty policy to accept every domain, got %q", reason)
+	}
+}
+
+func TestSignerEmailPolicy_Validate(t *testing.T) {
+	valid := SignerEmailPolicy{AllowedDomains: []string{"corp.com"}, BlockedDomains: []string{"mail.ru"}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, domain := range []string{"Corp.com", "@corp.com", "corp", "*.corp.com", ""} {
+		policy := SignerEmailPolicy{BlockedDomains: []string{domain}}
+		if err := policy.Validate(); err == nil {
+			t.Errorf("expected %q to be rejected", domain)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Directories signer groups can be synced from
+const (
+	DirectoryProviderGoogle    = "google"    // Google Workspace group, through the Admin SDK
+	DirectoryProviderMicrosoft = "microsoft" // Microsoft Entra ID group, through Microsoft Graph
+	DirectoryProviderGitLab    = "gitlab"    // GitLab group members, including inherited ones
+)
+
+// directoryDefaultAttributes are the email and name attributes used when the mapping leaves them empty
+var directoryDefaultAttributes = map[string][2]string{
+	DirectoryProviderGoogle:    {"email", ""},
+	DirectoryProviderMicrosoft: {"mail", "displayName"},
+	DirectoryProviderGitLab:    {"email", "name"},
+}
+
+// IsValidDirectoryProvider reports whether the directory provider is supported
+func IsValidDirectoryProvider(provider string) bool {
+	_, ok := directoryDefaultAttributes[provider]
+	return ok
+}
+
+// DirectoryMapping describes how directory members become signers
+type DirectoryMapping struct {
+	EmailAttribute  string   `json:"emailAttribute,omitempty"`  // Attribute holding the email, provider default when empty
+	NameAttribute   string   `json:"nameAttribute,omitempty"`   // Attribute holding the display name, provider default when empty
+	EmailDomain     string   `json:"emailDomain,omitempty"`     // Appended to values without "@", e.g. GitLab usernames
+	AllowedDomains  []string `json:"allowedDomains,omitempty"`  // Only keep emails of these domains (empty keeps all)
+	IncludeInactive bool     `json:"includeInactive,omitempty"` // Keep suspended, disabled or blocked accounts
+}
+
+// WithDefaults fills the empty attributes with the defaults of the provider
+func (m DirectoryMapping) WithDefaults(provider string) DirectoryMapping {
+	defaults := directoryDefaultAttributes[provider]
+	if m.EmailAttribute == "" {
+		m.EmailAttribute = defaults[0]
+	}
+	if m.NameAttribute == "" {
+		m.NameAttribute = defaults[1]
+	}
+	return m
+}
+
+// DirectoryEntry is a member returned by a directory, with its raw attributes
+type DirectoryEntry struct {
+	Attributes map[string]string
+	Active     bool
+}
+
+// SignerGroup is a reusable list of signers, maintained by hand or synced from a directory group
+type SignerGroup struct {
+	ID                int64            `json:"id"`
+	TenantID          uuid.UUID        `json:"-"`
+	Name              string           `json:"name"`
+	Description       string           `json:"description,omitempty"`
+	DirectoryProvider string           `json:"directoryProvider,omitempty"` // Empty for groups maintained by hand
+	DirectoryGroup    string           `json:"directoryGroup,omitempty"`    // Group email, object ID or path in the directory
+	Mapping           DirectoryMapping `json:"mapping"`
+	SyncEnabled       bool             `json:"syncEnabled"` // Synced on schedule, else only on demand
+	MemberCount       int              `json:"memberCount"`
+	LastSyncedAt      *time.Time       `json:"lastSyncedAt,omitempty"`
+	LastSyncError     string           `json:"lastSyncError,omitempty"`
+	CreatedBy         string           `json:"createdBy,omitempty"`
+	CreatedAt         time.Time        `json:"createdAt"`
+	UpdatedAt         time.Time        `json:"updatedAt"`
+}
+
+// IsDirectory reports whether the members of the group come from a directory
+func (g *SignerGroup) IsDirectory() bool {
+	return g.DirectoryProvider != ""
+}
+
+type SignerGroupInput struct {
+	Name              string
+	Description       string
+	DirectoryProvider string
+	DirectoryGroup    string
+	Mapping           DirectoryMapping
+	SyncEnabled       bool
+	CreatedBy         string
+}
+
+// SignerGroupMember is a signer of a group
+type SignerGroupMember struct {
+	Email   string    `json:"email"`
+	Name    string    `json:"name,omitempty"`
+	AddedAt time.Time `json:"addedAt,omitempty"`
+}
+
+// DirectorySyncResult is the difference between a group and its directory group,
+// applied unless the sync is a dry run
+type DirectorySyncResult struct {
+	GroupID   int64               `json:"groupId"`
+	DryRun    bool                `json:"dryRun"`
+	Added     []SignerGroupMember `json:"added"`
+	Updated   []SignerGroupMember `json:"updated"` // Members whose name changed
+	Removed   []SignerGroupMember `json:"removed"`
+	Unchanged int                 `json:"unchanged"`
+	Skipped   int                 `json:"skipped"` // Directory members without a usable email, inactive or outside the allowed domains
+}
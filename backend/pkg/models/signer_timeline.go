@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// SignerEventType is the kind of an event of a signer on a document
+type SignerEventType string
+
+const (
+	SignerEventAdded            SignerEventType = "added"             // Added to the expected signers
+	SignerEventReminderQueued   SignerEventType = "reminder_queued"   // Reminder waiting in the email queue
+	SignerEventReminderSent     SignerEventType = "reminder_sent"     // Reminder handed to the mail server
+	SignerEventReminderFailed   SignerEventType = "reminder_failed"   // Reminder rejected by the mail server
+	SignerEventReminderBounced  SignerEventType = "reminder_bounced"  // Reminder bounced back
+	SignerEventLinkOpened       SignerEventType = "link_opened"       // Sign-in link of a reminder opened
+	SignerEventCodeSent         SignerEventType = "code_sent"         // Verification code sent to an external signer
+	SignerEventCodeVerified     SignerEventType = "code_verified"     // Verification code entered by an external signer
+	SignerEventChecksumVerified SignerEventType = "checksum_verified" // Document checksum verified by the signer
+	SignerEventSigned           SignerEventType = "signed"
+	SignerEventSuppressed       SignerEventType = "suppressed" // Address suppressed after a bounce, a complaint or by an admin
+)
+
+// SignerTimelineEvent is one event of a signer on a document
+type SignerTimelineEvent struct {
+	Type   SignerEventType `json:"type"`
+	At     time.Time       `json:"at"`
+	Actor  string          `json:"actor,omitempty"`  // Admin behind the event, when not the signer
+	Detail string          `json:"detail,omitempty"` // Template, status, error or reason of the event
+}
+
+// SignerTimeline is everything recorded about one signer on one document, oldest event first
+type SignerTimeline struct {
+	DocID    string                `json:"docId"`
+	Email    string                `json:"email"`
+	Expected bool                  `json:"expected"`
+	Signed   bool                  `json:"signed"`
+	Events   []SignerTimelineEvent `json:"events"`
+}
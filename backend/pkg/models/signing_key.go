@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// SigningKeyStatus is the result of the last check of the Ed25519 signer key
+type SigningKeyStatus struct {
+	Healthy   bool      `json:"healthy"` // The key signs and verifies the test vector
+	PublicKey string    `json:"publicKey"`
+	Source    string    `json:"source"` // "env", "file" or "ephemeral"
+	KeyFile   string    `json:"keyFile,omitempty"`
+	Errors    []string  `json:"errors"`
+	Warnings  []string  `json:"warnings"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
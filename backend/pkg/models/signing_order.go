@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+// SigningTurn describes where an ordered signer stands in a sequential workflow
+type SigningTurn string
+
+const (
+	SigningTurnSigned  SigningTurn = "signed"
+	SigningTurnCurrent SigningTurn = "current"
+	SigningTurnWaiting SigningTurn = "waiting"
+)
+
+// CurrentSignOrder returns the lowest position still awaiting a signature, or 0 when
+// every ordered signer has signed or the document has no ordered signers
+func CurrentSignOrder(signers []*ExpectedSignerWithStatus) int {
+	current := 0
+	for _, signer := range signers {
+		if signer.SignOrder == nil || signer.HasSigned {
+			continue
+		}
+		if current == 0 || *signer.SignOrder < current {
+			current = *signer.SignOrder
+		}
+	}
+	return current
+}
+
+// ApplySigningTurns sets the Turn of each ordered signer. Signers sharing the current
+// position may sign in parallel; signers without a position are left untouched.
+func ApplySigningTurns(signers []*ExpectedSignerWithStatus) {
+	current := CurrentSignOrder(signers)
+	for _, signer := range signers {
+		switch {
+		case signer.SignOrder == nil:
+			signer.Turn = ""
+		case signer.HasSigned:
+			signer.Turn = SigningTurnSigned
+		case *signer.SignOrder == current:
+			signer.Turn = SigningTurnCurrent
+		default:
+			signer.Turn = SigningTurnWaiting
+		}
+	}
+}
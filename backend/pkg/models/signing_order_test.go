@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "testing"
+
+func orderedSigner(email string, order int, signed bool) *ExpectedSignerWithStatus {
+	s := &ExpectedSignerWithStatus{HasSigned: signed}
+	s.Email = email
+	if order > 0 {
+		s.SignOrder = &order
+	}
+	return s
+}
+
+func TestApplySigningTurns(t *testing.T) {
+	signers := []*ExpectedSignerWithStatus{
+		orderedSigner("employee@example.com", 1, true),
+		orderedSigner("manager@example.com", 2, false),
+		orderedSigner("deputy@example.com", 2, false),
+		orderedSigner("hr@example.com", 3, false),
+		orderedSigner("observer@example.com", 0, false),
+	}
+
+	ApplySigningTurns(signers)
+
+	expected := map[string]SigningTurn{
+		"employee@example.com": SigningTurnSigned,
+		"manager@example.com":  SigningTurnCurrent,
+		"deputy@example.com":   SigningTurnCurrent,
+		"hr@example.com":       SigningTurnWaiting,
+		"observer@example.com": "",
+	}
+	for _, s := range signers {
+		if s.Turn != expected[s.Email] {
+			t.Errorf("%s: expected turn %q, got %q", s.Email, expected[s.Email], s.Turn)
+		}
+	}
+	if got := CurrentSignOrder(signers); got != 2 {
+		t.Errorf("expected current order 2, got %d", got)
+	}
+}
+
+func TestCurrentSignOrder_Unordered(t *testing.T) {
+	signers := []*ExpectedSignerWithStatus{
+		orderedSigner("a@example.com", 0, false),
+		orderedSigner("b@example.com", 1, true),
+	}
+	if got := CurrentSignOrder(signers); got != 0 {
+		t.Errorf("expected 0 when no ordered signer is pending, got %d", got)
+	}
+}
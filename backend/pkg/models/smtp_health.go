@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "time"
+
+// SMTPServerHealth is the state of an SMTP server the mail is sent through
+type SMTPServerHealth struct {
+	Host                string     `json:"host"`
+	Port                int        `json:"port"`
+	Priority            int        `json:"priority"`
+	Weight              int        `json:"weight"`
+	Encryption          string     `json:"encryption"`
+	DetectedEncryption  string     `json:"detectedEncryption,omitempty"` // In auto mode, once a connection succeeded
+	Healthy             bool       `json:"healthy"`
+	UnhealthyUntil      *time.Time `json:"unhealthyUntil,omitempty"` // End of the cooldown of a failing server
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastError           string     `json:"lastError,omitempty"`
+	LastErrorAt         *time.Time `json:"lastErrorAt,omitempty"`
+	LastSuccessAt       *time.Time `json:"lastSuccessAt,omitempty"`
+}
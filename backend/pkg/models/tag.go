@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTagLength bounds the length of a tag, in characters
+	MaxTagLength = 50
+	// MaxDocumentTags bounds the number of tags of a document
+	MaxDocumentTags = 20
+)
+
+// NormalizeTag lowercases a tag, trims it and collapses its inner whitespace to single spaces.
+// It returns ErrInvalidTag for empty tags, tags that are too long or contain control characters.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", ErrInvalidTag
+	}
+	for _, r := range tag {
+		if unicode.IsControl(r) {
+			return "", ErrInvalidTag
+		}
+	}
+	return tag, nil
+}
+
+// TagCount is a tag with the number of active documents carrying it
+type TagCount struct {
+	Tag       string `json:"tag"`
+	Documents int    `json:"documents"`
+}
+
+// TagStats aggregates the completion of the documents carrying a tag
+type TagStats struct {
+	Tag            string  `json:"tag"`
+	Documents      int     `json:"documents"`
+	ExpectedCount  int     `json:"expectedCount"`
+	SignedCount    int     `json:"signedCount"`
+	PendingCount   int     `json:"pendingCount"`
+	CompletionRate float64 `json:"completionRate"`
+}
+
+// DocumentFilter narrows the admin document list. Empty fields do not filter.
+type DocumentFilter struct {
+	Tag    string
+	Owner  string
+	Search string
+}
+
+// TagPolicy is the reminder and retention policy inherited by the documents carrying a tag.
+// Zero reminder thresholds keep the tenant setting.
+type TagPolicy struct {
+	Tag                 string    `json:"tag"`
+	FirmAfterReminders  int       `json:"firmAfterReminders"`
+	FirmAfterDays       int       `json:"firmAfterDays"`
+	FinalAfterReminders int       `json:"finalAfterReminders"`
+	FinalAfterDays      int       `json:"finalAfterDays"`
+	ArchiveAfterDays    *int      `json:"archiveAfterDays,omitempty"` // Nil keeps the tenant retention policy
+	UpdatedBy           string    `json:"updatedBy,omitempty"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+}
+
+// HasReminders reports whether the policy sets any reminder threshold
+func (p TagPolicy) HasReminders() bool {
+	return p.FirmAfterReminders > 0 || p.FirmAfterDays > 0 || p.FinalAfterReminders > 0 || p.FinalAfterDays > 0
+}
+
+// WithTagPolicies returns the reminder settings of a document carrying tags with policies: each
+// threshold is the strictest of the tenant one and those of the policies. A policy setting a
+// threshold re-enables escalation when the tenant disabled it.
+func (c ReminderConfig) WithTagPolicies(policies []TagPolicy) ReminderConfig {
+	strictest := func(current, policy int) int {
+		if policy > 0 && policy < current {
+			return policy
+		}
+		return current
+	}
+
+	out := c
+	out.FirmAfterReminders, out.FirmAfterDays, out.FinalAfterReminders, out.FinalAfterDays = c.Thresholds()
+	tightened := false
+	for _, p := range policies {
+		if !p.HasReminders() {
+			continue
+		}
+		tightened = true
+		out.FirmAfterReminders = strictest(out.FirmAfterReminders, p.FirmAfterReminders)
+		out.FirmAfterDays = strictest(out.FirmAfterDays, p.FirmAfterDays)
+		out.FinalAfterReminders = strictest(out.FinalAfterReminders, p.FinalAfterReminders)
+		out.FinalAfterDays = strictest(out.FinalAfterDays, p.FinalAfterDays)
+	}
+	if !tightened {
+		return c
+	}
+	out.DisableEscalation = false
+	return out
+}
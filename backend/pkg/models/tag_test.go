@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := map[string]string{
+		"Security":           "security",
+		"  Legal   Review  ": "legal review",
+		"RGPD\t2026":         "rgpd 2026",
+	}
+	for input, want := range tests {
+		got, err := NormalizeTag(input)
+		if err != nil || got != want {
+			t.Errorf("NormalizeTag(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "   ", strings.Repeat("a", MaxTagLength+1), "bad\u0000tag"} {
+		if _, err := NormalizeTag(input); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("NormalizeTag(%q): expected ErrInvalidTag, got %v", input, err)
+		}
+	}
+}
+
+func TestReminderConfig_WithTagPolicies(t *testing.T) {
+	base := ReminderConfig{FirmAfterDays: 10, DisableEscalation: true}
+
+	if got := base.WithTagPolicies(nil); !reflect.DeepEqual(got, base) {
+		t.Errorf("expected the tenant settings without policies, got %+v", got)
+	}
+	if got := base.WithTagPolicies([]TagPolicy{{Tag: "archive-only"}}); !reflect.DeepEqual(got, base) {
+		t.Errorf("expected the tenant settings with retention-only policies, got %+v", got)
+	}
+
+	got := base.WithTagPolicies([]TagPolicy{
+		{Tag: "security", FirmAfterReminders: 1, FirmAfterDays: 20, FinalAfterDays: 7},
+		{Tag: "legal", FirmAfterDays: 3, FinalAfterReminders: 3},
+	})
+	want := ReminderConfig{FirmAfterReminders: 1, FirmAfterDays: 3, FinalAfterReminders: 3, FinalAfterDays: 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the strictest thresholds %+v, got %+v", want, got)
+	}
+	if level := got.LevelFor(1, 0); level != ReminderLevelFirm {
+		t.Errorf("expected escalation re-enabled by the policy, got %q", level)
+	}
+}
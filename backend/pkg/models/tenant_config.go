@@ -3,6 +3,9 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +20,9 @@ const (
 	ConfigCategoryMagicLink ConfigCategory = "magiclink"
 	ConfigCategorySMTP      ConfigCategory = "smtp"
 	ConfigCategoryStorage   ConfigCategory = "storage"
+	ConfigCategoryBranding  ConfigCategory = "branding"
+	ConfigCategoryReminders ConfigCategory = "reminders"
+	ConfigCategorySecurity  ConfigCategory = "security"
 )
 
 // AllConfigCategories returns all valid configuration categories
@@ -27,6 +33,9 @@ func AllConfigCategories() []ConfigCategory {
 		ConfigCategoryMagicLink,
 		ConfigCategorySMTP,
 		ConfigCategoryStorage,
+		ConfigCategoryBranding,
+		ConfigCategoryReminders,
+		ConfigCategorySecurity,
 	}
 }
 
@@ -34,7 +43,7 @@ func AllConfigCategories() []ConfigCategory {
 func (c ConfigCategory) IsValid() bool {
 	switch c {
 	case ConfigCategoryGeneral, ConfigCategoryOIDC, ConfigCategoryMagicLink,
-		ConfigCategorySMTP, ConfigCategoryStorage:
+		ConfigCategorySMTP, ConfigCategoryStorage, ConfigCategoryBranding, ConfigCategoryReminders, ConfigCategorySecurity:
 		return true
 	}
 	return false
@@ -57,6 +66,12 @@ type TenantConfig struct {
 type GeneralConfig struct {
 	Organisation       string `json:"organisation"`
 	OnlyAdminCanCreate bool   `json:"only_admin_can_create"`
+	DefaultLocale      string `json:"default_locale,omitempty"` // Locale of users without a preference, ACKIFY_MAIL_DEFAULT_LOCALE when empty
+	// ApproveUnexpectedSignatures holds the signatures of users missing from the expected signers
+	// of a document until an admin approves them
+	ApproveUnexpectedSignatures bool `json:"approve_unexpected_signatures"`
+	// SignerEmails restricts the emails added as expected signers
+	SignerEmails SignerEmailPolicy `json:"signer_emails"`
 }
 
 // OIDCConfig holds OIDC/OAuth2 authentication settings
@@ -84,14 +99,23 @@ type MagicLinkConfig struct {
 	Enabled bool `json:"enabled"`
 }
 
+// Encryption modes of an SMTP connection
+const (
+	SMTPEncryptionAuto     = "auto"     // implicit TLS or STARTTLS, detected by probing the server
+	SMTPEncryptionNone     = "none"     // plain text, local relays only
+	SMTPEncryptionStartTLS = "starttls" // plain connection upgraded with STARTTLS (typically port 587)
+	SMTPEncryptionImplicit = "implicit" // TLS from the first byte (typically port 465)
+)
+
 // SMTPConfig holds SMTP email settings
 type SMTPConfig struct {
 	Host               string `json:"host"`
 	Port               int    `json:"port"`
 	Username           string `json:"username"`
 	Password           string `json:"password,omitempty"`
-	TLS                bool   `json:"tls"`
-	StartTLS           bool   `json:"starttls"`
+	Encryption         string `json:"encryption,omitempty"`
+	TLS                bool   `json:"tls"`      // Former flag, only read when Encryption is empty
+	StartTLS           bool   `json:"starttls"` // Former flag, only read when Encryption is empty
 	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
 	Timeout            string `json:"timeout"`
 	From               string `json:"from"`
@@ -109,6 +133,39 @@ func (c *SMTPConfig) IsConfigured() bool {
 	return c.Host != "" && c.From != ""
 }
 
+// EncryptionMode returns the encryption of the connection, derived from the former flags when unset
+func (c *SMTPConfig) EncryptionMode() string {
+	if c.Encryption != "" {
+		return c.Encryption
+	}
+	return LegacySMTPEncryption(c.TLS, c.StartTLS)
+}
+
+// ParseSMTPEncryption validates an encryption mode, empty meaning auto
+func ParseSMTPEncryption(value string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(value)); mode {
+	case "":
+		return SMTPEncryptionAuto, nil
+	case SMTPEncryptionAuto, SMTPEncryptionNone, SMTPEncryptionStartTLS, SMTPEncryptionImplicit:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown SMTP encryption %q (expected none, starttls, implicit or auto)", value)
+	}
+}
+
+// LegacySMTPEncryption maps the former TLS and STARTTLS flags to an encryption mode.
+// TLS wins when both are set, as it always did.
+func LegacySMTPEncryption(tls, startTLS bool) string {
+	switch {
+	case tls:
+		return SMTPEncryptionImplicit
+	case startTLS:
+		return SMTPEncryptionStartTLS
+	default:
+		return SMTPEncryptionNone
+	}
+}
+
 // StorageConfig holds document storage settings
 type StorageConfig struct {
 	Type        string `json:"type"` // "", "local", "s3"
@@ -120,6 +177,10 @@ type StorageConfig struct {
 	S3SecretKey string `json:"s3_secret_key,omitempty"`
 	S3Region    string `json:"s3_region,omitempty"`
 	S3UseSSL    bool   `json:"s3_use_ssl"`
+
+	// Upload policy: uploads above MaxSizeMB or of another type are quarantined for review.
+	// An empty list allows every type supported by the storage.
+	AllowedMimeTypes []string `json:"allowed_mime_types,omitempty"`
 }
 
 // StorageSecrets holds the secret fields for Storage config
@@ -132,6 +193,86 @@ func (c *StorageConfig) IsEnabled() bool {
 	return c.Type == "local" || c.Type == "s3"
 }
 
+// BrandingConfig holds the organization branding shown in the frontend and emails
+type BrandingConfig struct {
+	LogoKey         string `json:"logo_key,omitempty"` // Storage key of the uploaded logo, managed by the logo endpoints
+	LogoContentType string `json:"logo_content_type,omitempty"`
+	PrimaryColor    string `json:"primary_color,omitempty"` // Hex color, e.g. #4F46E5
+	EmailFooter     string `json:"email_footer,omitempty"`
+	SupportEmail    string `json:"support_email,omitempty"`
+	SupportURL      string `json:"support_url,omitempty"`
+}
+
+// HasLogo returns true if a logo has been uploaded
+func (c *BrandingConfig) HasLogo() bool {
+	return c.LogoKey != ""
+}
+
+// LogoURL returns the public URL of the logo, or an empty string without logo.
+// The version parameter changes with every upload so clients never show a stale logo.
+func (c *BrandingConfig) LogoURL(baseURL string) string {
+	if !c.HasLogo() {
+		return ""
+	}
+	version := c.LogoKey[strings.LastIndex(c.LogoKey, "/")+1:]
+	return baseURL + "/api/v1/branding/logo?v=" + url.QueryEscape(version)
+}
+
+// ReminderConfig selects the reminder template variant (gentle, firm, final notice) from the
+// escalation of each pending signer. Zero thresholds keep the defaults.
+type ReminderConfig struct {
+	// DisableEscalation always sends the gentle variant unless another one is requested
+	DisableEscalation   bool `json:"disable_escalation"`
+	FirmAfterReminders  int  `json:"firm_after_reminders,omitempty"`
+	FirmAfterDays       int  `json:"firm_after_days,omitempty"`
+	FinalAfterReminders int  `json:"final_after_reminders,omitempty"`
+	FinalAfterDays      int  `json:"final_after_days,omitempty"`
+	// WorkingHours defers reminders queued outside the business hours of their recipient
+	WorkingHours WorkingHours `json:"working_hours"`
+	// MinIntervalHours skips recipients reminded of the same document more recently (0: no limit)
+	MinIntervalHours int `json:"min_interval_hours,omitempty"`
+}
+
+// SecurityConfig holds the Content-Security-Policy sources added by admins to the built-in
+// ones and to those set by environment variables
+type SecurityConfig struct {
+	CSP CSPSources `json:"csp"`
+	// EmbedFrameAncestors are the origins allowed to frame the embed pages.
+	// When set, they replace the list of the environment (default: any origin).
+	EmbedFrameAncestors []string `json:"embed_frame_ancestors,omitempty"`
+	// RefererPolicy selects what is recorded of the referer of new signatures (default: keep)
+	RefererPolicy RefererPolicy `json:"referer_policy,omitempty"`
+	// CORS allows browsers on other origins to call the API, applied without restart
+	CORS CORSConfig `json:"cors"`
+}
+
+// Validate checks the CSP sources, the embed origins, the referer policy and the CORS policies
+func (c *SecurityConfig) Validate() error {
+	if err := c.CSP.Validate(); err != nil {
+		return err
+	}
+	if err := ValidateFrameAncestors(c.EmbedFrameAncestors); err != nil {
+		return fmt.Errorf("embed_frame_ancestors: %w", err)
+	}
+	if !c.RefererPolicy.IsValid() {
+		return fmt.Errorf("referer_policy: must be keep, origin, hash or drop")
+	}
+	if err := c.CORS.Validate(); err != nil {
+		return fmt.Errorf("cors.%w", err)
+	}
+	return nil
+}
+
+// Branding is the public branding served to the frontend at boot
+type Branding struct {
+	Organisation string `json:"organisation"`
+	LogoURL      string `json:"logoUrl,omitempty"`
+	PrimaryColor string `json:"primaryColor,omitempty"`
+	EmailFooter  string `json:"emailFooter,omitempty"`
+	SupportEmail string `json:"supportEmail,omitempty"`
+	SupportURL   string `json:"supportUrl,omitempty"`
+}
+
 // MutableConfig combines all mutable configuration sections
 type MutableConfig struct {
 	General   GeneralConfig   `json:"general"`
@@ -139,6 +280,9 @@ type MutableConfig struct {
 	MagicLink MagicLinkConfig `json:"magiclink"`
 	SMTP      SMTPConfig      `json:"smtp"`
 	Storage   StorageConfig   `json:"storage"`
+	Branding  BrandingConfig  `json:"branding"`
+	Reminders ReminderConfig  `json:"reminders"`
+	Security  SecurityConfig  `json:"security"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
@@ -162,6 +306,74 @@ func (c *MutableConfig) MagicLinkRequiresSMTP() bool {
 	return c.SMTP.IsConfigured()
 }
 
+// ConfigSecretsStatus reports with which key the stored secrets of each section are encrypted
+type ConfigSecretsStatus struct {
+	Current       []ConfigCategory `json:"current"`
+	Stale         []ConfigCategory `json:"stale"`             // Encrypted with a previous key
+	Undecryptable []ConfigCategory `json:"undecryptable"`     // Encrypted with an unknown key
+	Rotated       []ConfigCategory `json:"rotated,omitempty"` // Re-encrypted with the current key
+}
+
+// ConfigChange is a field of the effective configuration changed by an update.
+// Secrets are masked: a changed secret is reported with the mask on both sides.
+type ConfigChange struct {
+	Category ConfigCategory `json:"category"`
+	Field    string         `json:"field"` // JSON name, dotted for nested fields (working_hours.start)
+	Old      any            `json:"old"`
+	New      any            `json:"new"`
+}
+
+// ConfigPreview is the outcome of validating configuration changes without applying them
+type ConfigPreview struct {
+	Valid   bool           `json:"valid"`
+	Errors  []string       `json:"errors"`
+	Changes []ConfigChange `json:"changes"`
+}
+
+// PendingConfigSection is a configuration section staged to be applied with the others
+type PendingConfigSection struct {
+	Category         ConfigCategory  `json:"category"`
+	Config           json.RawMessage `json:"-"` // Secrets excluded, as in TenantConfig
+	SecretsEncrypted []byte          `json:"-"`
+	StagedBy         string          `json:"staged_by"`
+	StagedAt         time.Time       `json:"staged_at"`
+}
+
+// PendingConfig lists the staged sections and what applying them would change
+type PendingConfig struct {
+	Sections []*PendingConfigSection `json:"sections"`
+	Preview  ConfigPreview           `json:"preview"`
+}
+
+// ConfigBundleVersion is the format version of exported configuration bundles
+const ConfigBundleVersion = 1
+
+// Secret handling modes of a configuration bundle
+const (
+	BundleSecretsRedacted  = "redacted"
+	BundleSecretsEncrypted = "encrypted"
+)
+
+// ConfigBundle is a portable export of all configuration sections, used to copy
+// a configuration between instances or to restore it.
+// Secrets are either masked (redacted) or stored in EncryptedSecrets under a key
+// derived from a passphrase chosen at export time.
+type ConfigBundle struct {
+	Version          int             `json:"version"`
+	ExportedAt       time.Time       `json:"exported_at"`
+	Secrets          string          `json:"secrets"`
+	General          GeneralConfig   `json:"general"`
+	OIDC             OIDCConfig      `json:"oidc"`
+	MagicLink        MagicLinkConfig `json:"magiclink"`
+	SMTP             SMTPConfig      `json:"smtp"`
+	Storage          StorageConfig   `json:"storage"`
+	Branding         BrandingConfig  `json:"branding"`
+	Reminders        ReminderConfig  `json:"reminders"`
+	Security         SecurityConfig  `json:"security"`
+	EncryptedSecrets []byte          `json:"encrypted_secrets,omitempty"` // AES-256-GCM sealed ConfigSecrets
+	KeySalt          []byte          `json:"key_salt,omitempty"`          // PBKDF2 salt of the passphrase
+}
+
 // SecretMask is the value returned for masked secrets
 const SecretMask = "********"
 
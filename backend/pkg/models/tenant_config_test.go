@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "testing"
+
+func TestParseSMTPEncryption(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", SMTPEncryptionAuto, false},
+		{"auto", SMTPEncryptionAuto, false},
+		{" STARTTLS ", SMTPEncryptionStartTLS, false},
+		{"implicit", SMTPEncryptionImplicit, false},
+		{"none", SMTPEncryptionNone, false},
+		{"ssl", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSMTPEncryption(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSMTPEncryption(%q) = %q, %v; expected %q (error: %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSMTPConfig_EncryptionMode(t *testing.T) {
+	tests := []struct {
+		cfg  SMTPConfig
+		want string
+	}{
+		{SMTPConfig{Encryption: SMTPEncryptionAuto, TLS: true}, SMTPEncryptionAuto},
+		{SMTPConfig{TLS: true, StartTLS: true}, SMTPEncryptionImplicit},
+		{SMTPConfig{StartTLS: true}, SMTPEncryptionStartTLS},
+		{SMTPConfig{}, SMTPEncryptionNone},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cfg.EncryptionMode(); got != tt.want {
+			t.Errorf("EncryptionMode() of %+v = %q, expected %q", tt.cfg, got, tt.want)
+		}
+	}
+}
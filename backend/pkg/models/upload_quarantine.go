@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuarantineReason is why an upload was quarantined instead of becoming a document
+type QuarantineReason string
+
+const (
+	QuarantineReasonTooLarge       QuarantineReason = "too_large"        // Above the tenant size limit
+	QuarantineReasonTypeNotAllowed QuarantineReason = "type_not_allowed" // Outside the tenant MIME types
+	QuarantineReasonInfected       QuarantineReason = "infected"         // Flagged by the virus scanner
+	QuarantineReasonScanFailed     QuarantineReason = "scan_failed"      // The virus scanner could not tell
+)
+
+// QuarantineStatus is the review state of a quarantined upload
+type QuarantineStatus string
+
+const (
+	QuarantineStatusPending   QuarantineStatus = "pending"
+	QuarantineStatusReleased  QuarantineStatus = "released"
+	QuarantineStatusDiscarded QuarantineStatus = "discarded"
+)
+
+// QuarantinedUpload is an uploaded file held for admin review, with the document options the
+// uploader asked for so that releasing it creates the document they expected
+type QuarantinedUpload struct {
+	ID               int64            `json:"id"`
+	TenantID         uuid.UUID        `json:"-"`
+	StorageKey       string           `json:"-"`
+	StorageProvider  string           `json:"storageProvider"`
+	OriginalFilename string           `json:"originalFilename"`
+	Title            string           `json:"title"`
+	MimeType         string           `json:"mimeType"`
+	FileSize         int64            `json:"fileSize"`
+	Checksum         string           `json:"checksum"` // SHA-256
+	ReadMode         string           `json:"readMode"`
+	AllowDownload    bool             `json:"allowDownload"`
+	RequireFullRead  bool             `json:"requireFullRead"`
+	VerifyChecksum   bool             `json:"verifyChecksum"`
+	Reason           QuarantineReason `json:"reason"`
+	Detail           string           `json:"detail,omitempty"` // Virus name or scan error
+	UploadedBy       string           `json:"uploadedBy"`
+	Status           QuarantineStatus `json:"status"`
+	ReviewedBy       *string          `json:"reviewedBy,omitempty"`
+	ReviewedAt       *time.Time       `json:"reviewedAt,omitempty"`
+	DocID            *string          `json:"docId,omitempty"` // Document created on release
+	CreatedAt        time.Time        `json:"createdAt"`
+}
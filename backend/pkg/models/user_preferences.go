@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// SupportedLocales lists the locales emails and API messages are translated to
+var SupportedLocales = []string{"en", "fr", "it", "de", "es"}
+
+// UserPreferences holds the preferences of a user
+type UserPreferences struct {
+	Email     string    `json:"email"`
+	Locale    string    `json:"locale"`   // Empty until detected at first login or set by the user
+	Timezone  string    `json:"timezone"` // IANA name, empty to use the timezone of the tenant
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NormalizeLocale reduces a language tag to its base language (fr-FR -> fr) and reports
+// whether it is supported
+func NormalizeLocale(tag string) (string, bool) {
+	locale := strings.ToLower(strings.TrimSpace(tag))
+	if idx := strings.IndexAny(locale, "-_"); idx > 0 {
+		locale = locale[:idx]
+	}
+	return locale, slices.Contains(SupportedLocales, locale)
+}
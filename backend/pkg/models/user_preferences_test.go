@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import "testing"
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		tag           string
+		want          string
+		wantSupported bool
+	}{
+		{"fr", "fr", true},
+		{"fr-FR", "fr", true},
+		{" DE_ch ", "de", true},
+		{"pt-BR", "pt", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, supported := NormalizeLocale(tt.tag)
+			if got != tt.want || supported != tt.wantSupported {
+				t.Errorf("NormalizeLocale(%q) = %q, %v; want %q, %v", tt.tag, got, supported, tt.want, tt.wantSupported)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSession is a server-side browser session. SessionID is the opaque value
+// stored in the session cookie and is never serialized.
+type UserSession struct {
+	ID          int64      `json:"id"`
+	TenantID    uuid.UUID  `json:"-"`
+	SessionID   string     `json:"-"`
+	UserSub     string     `json:"-"`
+	UserEmail   string     `json:"email"`
+	UserName    string     `json:"-"`
+	UserPicture string     `json:"-"`
+	IPAddress   string     `json:"ipAddress"`
+	UserAgent   string     `json:"userAgent"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastSeenAt  time.Time  `json:"lastSeenAt"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RevokedAt   *time.Time `json:"-"`
+	Current     bool       `json:"current"`
+}
+
+// IsActive reports whether the session can still authenticate requests
+func (s *UserSession) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// User returns the identity attached to the session
+func (s *UserSession) User() *User {
+	return &User{Sub: s.UserSub, Email: s.UserEmail, Name: s.UserName, Picture: s.UserPicture}
+}
+
+// SessionExpiry tells the SPA when the current session ends, so that it can prompt the user
+// before. ExpiresAt is the earliest of the idle and absolute deadlines; ExpiresIn counts from
+// the server clock, which the SPA should prefer over its own.
+type SessionExpiry struct {
+	ExpiresAt         time.Time  `json:"expiresAt"`
+	IdleExpiresAt     *time.Time `json:"idleExpiresAt,omitempty"`
+	AbsoluteExpiresAt time.Time  `json:"absoluteExpiresAt"`
+	ExpiresIn         int        `json:"expiresInSeconds"`
+	WarnBefore        int        `json:"warnBeforeSeconds"`
+	Warning           bool       `json:"warning"`
+}
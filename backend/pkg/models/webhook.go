@@ -23,6 +23,7 @@ type Webhook struct {
 	UpdatedAt       time.Time         `json:"updatedAt"`
 	LastDeliveredAt *time.Time        `json:"lastDeliveredAt,omitempty"`
 	FailureCount    int               `json:"failureCount"`
+	DocID           *string           `json:"docId,omitempty"` // Only events of this document are delivered
 }
 
 type WebhookInput struct {
@@ -34,6 +35,7 @@ type WebhookInput struct {
 	Headers     map[string]string `json:"headers,omitempty"`
 	Description string            `json:"description,omitempty"`
 	CreatedBy   string            `json:"createdBy,omitempty"`
+	DocID       *string           `json:"docId,omitempty"`
 }
 
 // NullRawMessage mirrors Null handling used elsewhere for JSONB columns
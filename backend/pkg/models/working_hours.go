@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Default business hours, used when the working hours configuration leaves them unset
+const (
+	DefaultWorkingHoursStart = "09:00"
+	DefaultWorkingHoursEnd   = "18:00"
+)
+
+// DefaultWorkingDays are Monday to Friday
+var DefaultWorkingDays = []int{1, 2, 3, 4, 5}
+
+// WorkingHours are the business hours of a tenant. When enabled, reminders are only delivered
+// during the business hours of their recipient, in the recipient timezone or else the tenant one.
+type WorkingHours struct {
+	Enabled bool `json:"enabled"`
+	// Timezone is the IANA name of the timezone of recipients without one (default UTC)
+	Timezone string `json:"timezone,omitempty"`
+	// Days are the working days, 0 for Sunday to 6 for Saturday (default Monday to Friday)
+	Days []int `json:"days,omitempty"`
+	// Start and End bound the working hours of each day, as HH:MM; End is excluded
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// Validate checks the timezone, the days and that the working hours do not span midnight
+func (w WorkingHours) Validate() error {
+	if w.Timezone != "" {
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", w.Timezone)
+		}
+	}
+	for _, day := range w.Days {
+		if day < 0 || day > 6 {
+			return errors.New("working days must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	}
+	start, end, err := w.bounds()
+	if err != nil {
+		return err
+	}
+	if start >= end {
+		return errors.New("working hours must end after they start, on the same day")
+	}
+	return nil
+}
+
+// NextWindow returns t when it falls within working hours in timezone, else the start of the
+// next working hours. An empty or unknown timezone uses the tenant one.
+func (w WorkingHours) NextWindow(t time.Time, timezone string) time.Time {
+	start, end, err := w.bounds()
+	if err != nil || start >= end {
+		return t
+	}
+	days := w.Days
+	if len(days) == 0 {
+		days = DefaultWorkingDays
+	}
+	working := make(map[time.Weekday]bool, len(days))
+	for _, day := range days {
+		working[time.Weekday(day)] = true
+	}
+
+	local := t.In(w.location(timezone))
+	for i := 0; i < 8; i++ {
+		date := local.AddDate(0, 0, i)
+		if !working[date.Weekday()] {
+			continue
+		}
+		opens := clockOn(date, start)
+		if t.Before(opens) {
+			return opens
+		}
+		if t.Before(clockOn(date, end)) {
+			return t
+		}
+	}
+	return t
+}
+
+// clockOn returns the wall clock time of day on the date of day, so that DST changes keep it
+func clockOn(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+// location returns the timezone of a recipient, falling back to the tenant timezone and UTC
+func (w WorkingHours) location(timezone string) *time.Location {
+	for _, name := range []string{timezone, w.Timezone} {
+		if name == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// bounds returns the start and end of the working hours as offsets from midnight
+func (w WorkingHours) bounds() (time.Duration, time.Duration, error) {
+	start, err := parseClock(w.Start, DefaultWorkingHoursStart)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start of working hours: %w", err)
+	}
+	end, err := parseClock(w.End, DefaultWorkingHoursEnd)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end of working hours: %w", err)
+	}
+	return start, end, nil
+}
+
+// parseClock parses an HH:MM time of day, 24:00 being the end of the day
+func parseClock(value, fallback string) (time.Duration, error) {
+	if value == "" {
+		value = fallback
+	}
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
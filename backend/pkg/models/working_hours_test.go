@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkingHours_NextWindow(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+	hours := WorkingHours{Enabled: true, Timezone: "Europe/Paris"}
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		timezone string
+		want     time.Time
+	}{
+		{
+			name: "within working hours",
+			at:   time.Date(2026, 10, 14, 10, 30, 0, 0, paris), // Wednesday
+			want: time.Date(2026, 10, 14, 10, 30, 0, 0, paris),
+		},
+		{
+			name: "early morning waits for the opening",
+			at:   time.Date(2026, 10, 14, 6, 0, 0, 0, paris),
+			want: time.Date(2026, 10, 14, 9, 0, 0, 0, paris),
+		},
+		{
+			name: "evening waits for the next day",
+			at:   time.Date(2026, 10, 14, 18, 0, 0, 0, paris),
+			want: time.Date(2026, 10, 15, 9, 0, 0, 0, paris),
+		},
+		{
+			name: "friday evening waits for monday",
+			at:   time.Date(2026, 10, 16, 20, 0, 0, 0, paris),
+			want: time.Date(2026, 10, 19, 9, 0, 0, 0, paris),
+		},
+		{
+			name:     "recipient timezone",
+			at:       time.Date(2026, 10, 14, 10, 0, 0, 0, paris), // 17:00 in Tokyo
+			timezone: "Asia/Tokyo",
+			want:     time.Date(2026, 10, 14, 10, 0, 0, 0, paris),
+		},
+		{
+			name:     "recipient timezone after hours",
+			at:       time.Date(2026, 10, 14, 12, 0, 0, 0, paris), // 19:00 in Tokyo
+			timezone: "Asia/Tokyo",
+			want:     time.Date(2026, 10, 15, 2, 0, 0, 0, paris), // 09:00 in Tokyo
+		},
+		{
+			name:     "unknown recipient timezone falls back to the tenant one",
+			at:       time.Date(2026, 10, 14, 6, 0, 0, 0, paris),
+			timezone: "Mars/Olympus",
+			want:     time.Date(2026, 10, 14, 9, 0, 0, 0, paris),
+		},
+		{
+			name: "DST change keeps the wall clock",
+			at:   time.Date(2026, 10, 24, 12, 0, 0, 0, paris), // Saturday, before the switch on Sunday
+			want: time.Date(2026, 10, 26, 9, 0, 0, 0, paris),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hours.NextWindow(tt.at, tt.timezone); !got.Equal(tt.want) {
+				t.Errorf("expected %s, got %s", tt.want, got.In(paris))
+			}
+		})
+	}
+
+	custom := WorkingHours{Enabled: true, Days: []int{6}, Start: "08:30", End: "12:00"}
+	at := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	if got, want := custom.NextWindow(at, ""), time.Date(2026, 10, 17, 8, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected the next saturday morning in UTC, got %s", got)
+	}
+}
+
+func TestWorkingHours_Validate(t *testing.T) {
+	valid := []WorkingHours{
+		{},
+		{Timezone: "America/New_York", Days: []int{0, 6}, Start: "00:00", End: "24:00"},
+	}
+	for _, w := range valid {
+		if err := w.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", w, err)
+		}
+	}
+
+	invalid := []WorkingHours{
+		{Timezone: "Nowhere/City"},
+		{Days: []int{7}},
+		{Start: "9h"},
+		{Start: "18:00", End: "09:00"},
+	}
+	for _, w := range invalid {
+		if err := w.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", w)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/types"
 	"github.com/google/uuid"
 )
@@ -84,7 +85,7 @@ type MagicLinkResult struct {
 }
 
 // Authorizer defines the interface for authorization decisions.
-// CE: SimpleAuthorizer based on admin email list.
+// CE: RoleAuthorizer based on admin email list and delegated admin roles.
 // SaaS: RBACAuthorizer with roles and permissions.
 type Authorizer interface {
 	// IsAdmin returns true if the user is an administrator.
@@ -98,6 +99,26 @@ type Authorizer interface {
 	CanManageDocument(ctx context.Context, userEmail, docCreatedBy string) bool
 }
 
+// PermissionAuthorizer is an optional extension of Authorizer for delegated admin roles.
+// When the configured Authorizer implements it, admin operations are checked per permission;
+// otherwise IsAdmin grants every admin operation.
+type PermissionAuthorizer interface {
+	// HasPermission returns true if the user holds the given admin permission.
+	HasPermission(ctx context.Context, userEmail string, permission models.Permission) bool
+
+	// Permissions returns all admin permissions held by the user.
+	Permissions(ctx context.Context, userEmail string) []models.Permission
+}
+
+// DepartmentAuthorizer is an optional extension of Authorizer for department-scoped admins.
+// When the configured Authorizer implements it, the admin API is limited to the departments
+// returned for the user.
+type DepartmentAuthorizer interface {
+	// DepartmentScope returns the department of the admin followed by its sub-departments,
+	// or nil when the admin is not limited to departments.
+	DepartmentScope(ctx context.Context, userEmail string) ([]int64, error)
+}
+
 // === Legacy interfaces for backward compatibility ===
 // These will be removed in a future version.
 
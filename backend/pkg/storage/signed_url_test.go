@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package storage
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseSignedURL(t *testing.T, raw string) (string, string, string) {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("invalid URL %q: %v", raw, err)
+	}
+	docID := strings.TrimSuffix(strings.TrimPrefix(u.Path, "/api/v1/documents/"), "/content/signed")
+	return docID, u.Query().Get("expires"), u.Query().Get("sig")
+}
+
+func TestURLSigner_RoundTrip(t *testing.T) {
+	signer := NewURLSigner([]byte("secret"), "https://ackify.example.com", time.Hour)
+
+	raw := signer.SignedURL("doc-123")
+	if !strings.HasPrefix(raw, "https://ackify.example.com/api/v1/documents/doc-123/content/signed?") {
+		t.Fatalf("unexpected URL %q", raw)
+	}
+
+	docID, expires, sig := parseSignedURL(t, raw)
+	if err := signer.Verify(docID, expires, sig); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+}
+
+func TestURLSigner_Rejects(t *testing.T) {
+	signer := NewURLSigner([]byte("secret"), "https://ackify.example.com", time.Hour)
+	_, expires, sig := parseSignedURL(t, signer.SignedURL("doc-123"))
+
+	tests := []struct {
+		name    string
+		docID   string
+		expires string
+		sig     string
+	}{
+		{"other document", "doc-456", expires, sig},
+		{"extended expiry", "doc-123", "9999999999", sig},
+		{"malformed expiry", "doc-123", "soon", sig},
+		{"missing signature", "doc-123", expires, ""},
+		{"tampered signature", "doc-123", expires, strings.Repeat("0", len(sig))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := signer.Verify(tt.docID, tt.expires, tt.sig); !errors.Is(err, ErrSignedURLInvalid) {
+				t.Errorf("expected ErrSignedURLInvalid, got %v", err)
+			}
+		})
+	}
+
+	other := NewURLSigner([]byte("other-secret"), "https://ackify.example.com", time.Hour)
+	if err := other.Verify("doc-123", expires, sig); !errors.Is(err, ErrSignedURLInvalid) {
+		t.Errorf("expected ErrSignedURLInvalid with another secret, got %v", err)
+	}
+}
+
+func TestURLSigner_Expired(t *testing.T) {
+	signer := NewURLSigner([]byte("secret"), "https://ackify.example.com", time.Hour)
+	docID, expires, sig := parseSignedURL(t, signer.SignedURL("doc-123"))
+
+	signer.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if err := signer.Verify(docID, expires, sig); !errors.Is(err, ErrSignedURLExpired) {
+		t.Errorf("expected ErrSignedURLExpired, got %v", err)
+	}
+}
+
+func TestURLSigner_ArchiveLinks(t *testing.T) {
+	signer := NewURLSigner([]byte("secret"), "https://ackify.example.com", time.Hour)
+
+	raw, expiresAt := signer.SignedArchiveURL("a1b2c3", 24*time.Hour)
+	if !strings.HasPrefix(raw, "https://ackify.example.com/api/v1/exports/signatures/a1b2c3?") {
+		t.Fatalf("unexpected URL %q", raw)
+	}
+	u, _ := url.Parse(raw)
+	expires, sig := u.Query().Get("expires"), u.Query().Get("sig")
+	if expires != strconv.FormatInt(expiresAt.Unix(), 10) {
+		t.Errorf("expected the link to expire at %v, got %s", expiresAt, expires)
+	}
+	if err := signer.VerifyArchive("a1b2c3", expires, sig); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+
+	// Archive and document links are not interchangeable
+	if err := signer.Verify("a1b2c3", expires, sig); !errors.Is(err, ErrSignedURLInvalid) {
+		t.Errorf("expected an archive signature to be refused for a document, got %v", err)
+	}
+	_, docExpires, docSig := parseSignedURL(t, signer.SignedURL("a1b2c3"))
+	if err := signer.VerifyArchive("a1b2c3", docExpires, docSig); !errors.Is(err, ErrSignedURLInvalid) {
+		t.Errorf("expected a document signature to be refused for an archive, got %v", err)
+	}
+
+	signer.now = func() time.Time { return expiresAt }
+	if err := signer.VerifyArchive("a1b2c3", expires, sig); !errors.Is(err, ErrSignedURLExpired) {
+		t.Errorf("expected ErrSignedURLExpired, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package web
+
+import (
+	"context"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// serviceAuditRecorder adapts the AuditLogger capability to internal services,
+// which only know the action, the resource and the actor.
+type serviceAuditRecorder struct {
+	audit   AuditLogger
+	tenants providers.TenantProvider
+}
+
+func newServiceAuditRecorder(audit AuditLogger, tenants providers.TenantProvider) *serviceAuditRecorder {
+	return &serviceAuditRecorder{audit: audit, tenants: tenants}
+}
+
+func (r *serviceAuditRecorder) Record(ctx context.Context, action, resource, resourceID, actor string, details map[string]any) {
+	event := AuditEvent{
+		Timestamp:  time.Now().UTC(),
+		UserEmail:  actor,
+		Action:     action,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Details:    details,
+	}
+	if tenantID, err := r.tenants.CurrentTenant(ctx); err == nil {
+		event.TenantID = tenantID.String()
+	}
+	if err := r.audit.Log(ctx, event); err != nil {
+		logger.Logger.Warn("Failed to record audit event", "action", action, "resource_id", resourceID, "error", err.Error())
+	}
+}
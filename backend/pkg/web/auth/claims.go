@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ClaimValues returns the string values of a token claim.
+// The claim may be a dotted path into nested objects, e.g. "realm_access.roles" for Keycloak
+// realm roles or "resource_access.ackify.roles" for client roles. A claim whose name itself
+// contains dots (namespaced claims) is matched before the path is split.
+func ClaimValues(claims map[string]interface{}, path string) []string {
+	value, ok := lookupClaim(claims, path)
+	if !ok {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// HasClaimValue reports whether the claim holds one of the expected values.
+// Keycloak full group paths ("/ackify-admins") also match the bare group name.
+func HasClaimValue(claims map[string]interface{}, path string, expected []string) bool {
+	if path == "" || len(expected) == 0 {
+		return false
+	}
+	for _, value := range ClaimValues(claims, path) {
+		for _, want := range expected {
+			if value == want || strings.TrimPrefix(value, "/") == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lookupClaim(claims map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := claims[path]; ok {
+		return value, true
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		nested, ok := claims[path[:i]].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if value, ok := lookupClaim(nested, path[i+1:]); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// decodeIDTokenClaims reads the payload of an ID token received from the token endpoint.
+// The token comes straight from the provider over TLS, so its signature is not checked here.
+func decodeIDTokenClaims(idToken string) (map[string]interface{}, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed id_token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode id_token payload: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse id_token payload: %w", err)
+	}
+	return claims, nil
+}
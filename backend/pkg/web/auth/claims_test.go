@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeClaims(t *testing.T, raw string) map[string]interface{} {
+	t.Helper()
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(raw), &claims))
+	return claims
+}
+
+func TestHasClaimValue(t *testing.T) {
+	keycloak := decodeClaims(t, `{
+		"sub": "f:1234",
+		"email": "alice@example.com",
+		"groups": ["/ackify-admins", "/staff/editors"],
+		"realm_access": {"roles": ["offline_access", "ackify-admin"]},
+		"resource_access": {"ackify": {"roles": ["manager"]}}
+	}`)
+	authentik := decodeClaims(t, `{
+		"sub": "9f2c",
+		"email": "bob@example.com",
+		"groups": ["authentik Admins", "ackify-admins"],
+		"https://example.com/roles": "admin"
+	}`)
+
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		claim  string
+		values []string
+		want   bool
+	}{
+		{"keycloak group full path", keycloak, "groups", []string{"/ackify-admins"}, true},
+		{"keycloak group name", keycloak, "groups", []string{"ackify-admins"}, true},
+		{"keycloak nested group needs full path", keycloak, "groups", []string{"editors"}, false},
+		{"keycloak realm role", keycloak, "realm_access.roles", []string{"ackify-admin"}, true},
+		{"keycloak client role", keycloak, "resource_access.ackify.roles", []string{"manager"}, true},
+		{"keycloak missing role", keycloak, "realm_access.roles", []string{"admin"}, false},
+		{"authentik group", authentik, "groups", []string{"ackify-admins"}, true},
+		{"authentik group with spaces", authentik, "groups", []string{"authentik Admins"}, true},
+		{"namespaced string claim", authentik, "https://example.com/roles", []string{"admin"}, true},
+		{"values are case sensitive", authentik, "groups", []string{"Ackify-Admins"}, false},
+		{"missing claim", authentik, "roles", []string{"admin"}, false},
+		{"no claim configured", authentik, "", []string{"ackify-admins"}, false},
+		{"no values configured", authentik, "groups", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, HasClaimValue(tt.claims, tt.claim, tt.values))
+		})
+	}
+}
+
+func TestDecodeIDTokenClaims(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1","groups":["ackify-admins"]}`))
+	claims, err := decodeIDTokenClaims("header." + payload + ".signature")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ackify-admins"}, ClaimValues(claims, "groups"))
+
+	_, err = decodeIDTokenClaims("not-a-jwt")
+	assert.Error(t, err)
+}
@@ -33,12 +33,22 @@ type magicLinkService interface {
 	CreateReminderAuthToken(ctx context.Context, email, docID string) (string, error)
 }
 
+type claimRoleSyncer interface {
+	SyncClaimRole(ctx context.Context, email string, isAdmin bool) error
+}
+
 // ProviderConfig holds a configuration for creating a Provider.
 type ProviderConfig struct {
 	ConfigProvider   configProvider
 	SessionService   *infraAuth.SessionService
 	MagicLinkService magicLinkService
 	BaseURL          string
+
+	// AdminClaim and AdminValues grant the admin role to OIDC users whose token claim
+	// (e.g. "groups") holds one of the values. ClaimRoles stores the resulting role.
+	AdminClaim  string
+	AdminValues []string
+	ClaimRoles  claimRoleSyncer
 }
 
 // Provider implements providers.AuthProvider with dynamic config.
@@ -49,6 +59,9 @@ type Provider struct {
 	sessionService   *infraAuth.SessionService
 	magicLinkService magicLinkService
 	baseURL          string
+	adminClaim       string
+	adminValues      []string
+	claimRoles       claimRoleSyncer
 
 	// Cache for oauth2.Config to avoid recreating on every request
 	// Invalidated when config changes
@@ -64,6 +77,9 @@ func NewAuthProvider(cfg ProviderConfig) *Provider {
 		sessionService:   cfg.SessionService,
 		magicLinkService: cfg.MagicLinkService,
 		baseURL:          cfg.BaseURL,
+		adminClaim:       cfg.AdminClaim,
+		adminValues:      cfg.AdminValues,
+		claimRoles:       cfg.ClaimRoles,
 	}
 }
 
@@ -231,7 +247,7 @@ func (p *Provider) HandleOIDCCallback(ctx context.Context, w http.ResponseWriter
 	}
 	defer resp.Body.Close()
 
-	user, err := p.parseUserInfo(resp)
+	user, userInfo, err := p.parseUserInfo(resp)
 	if err != nil {
 		return nil, nextURL, fmt.Errorf("failed to parse user info: %w", err)
 	}
@@ -240,6 +256,8 @@ func (p *Provider) HandleOIDCCallback(ctx context.Context, w http.ResponseWriter
 		return nil, nextURL, models.ErrDomainNotAllowed
 	}
 
+	p.syncClaimRole(ctx, token, user, userInfo)
+
 	// Store refresh token if available
 	if token.RefreshToken != "" && p.sessionService != nil {
 		if err := p.sessionService.StoreRefreshToken(ctx, w, r, token, user); err != nil {
@@ -377,15 +395,42 @@ func (p *Provider) configMatches(cfg models.OIDCConfig) bool {
 		p.cachedOIDCCfg.TokenURL == cfg.TokenURL
 }
 
-func (p *Provider) parseUserInfo(resp *http.Response) (*types.User, error) {
+// syncClaimRole grants or revokes the admin role from the configured OIDC claim.
+// Userinfo claims take precedence over ID token claims; some providers only put roles in the latter.
+func (p *Provider) syncClaimRole(ctx context.Context, token *oauth2.Token, user *types.User, userInfo map[string]interface{}) {
+	if p.adminClaim == "" || p.claimRoles == nil {
+		return
+	}
+
+	claims := make(map[string]interface{})
+	if idToken, ok := token.Extra("id_token").(string); ok && idToken != "" {
+		idClaims, err := decodeIDTokenClaims(idToken)
+		if err != nil {
+			logger.Logger.Warn("Failed to read id_token claims", "error", err.Error())
+		}
+		for k, v := range idClaims {
+			claims[k] = v
+		}
+	}
+	for k, v := range userInfo {
+		claims[k] = v
+	}
+
+	isAdmin := HasClaimValue(claims, p.adminClaim, p.adminValues)
+	if err := p.claimRoles.SyncClaimRole(ctx, user.Email, isAdmin); err != nil {
+		logger.Logger.Error("Failed to sync admin role from OIDC claim", "email", user.Email, "error", err.Error())
+	}
+}
+
+func (p *Provider) parseUserInfo(resp *http.Response) (*types.User, map[string]interface{}, error) {
 	var rawUser map[string]interface{}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if err := json.Unmarshal(body, &rawUser); err != nil {
-		return nil, fmt.Errorf("failed to decode user info: %w", err)
+		return nil, nil, fmt.Errorf("failed to decode user info: %w", err)
 	}
 
 	user := &types.User{}
@@ -396,7 +441,7 @@ func (p *Provider) parseUserInfo(resp *http.Response) (*types.User, error) {
 	} else if id, ok := rawUser["id"]; ok {
 		user.Sub = fmt.Sprintf("%v", id)
 	} else {
-		return nil, fmt.Errorf("missing user ID in response")
+		return nil, nil, fmt.Errorf("missing user ID in response")
 	}
 
 	// Extract email
@@ -407,7 +452,7 @@ func (p *Provider) parseUserInfo(resp *http.Response) (*types.User, error) {
 	} else if upn, ok := rawUser["userPrincipalName"].(string); ok && upn != "" {
 		user.Email = upn
 	} else {
-		return nil, fmt.Errorf("missing email in user info response")
+		return nil, nil, fmt.Errorf("missing email in user info response")
 	}
 
 	// Extract name
@@ -428,7 +473,7 @@ func (p *Provider) parseUserInfo(resp *http.Response) (*types.User, error) {
 		user.Picture = photo
 	}
 
-	return user, nil
+	return user, rawUser, nil
 }
 
 func subtleConstantTimeCompare(a, b string) bool {
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+	"github.com/btouchard/ackify-ce/backend/pkg/providers"
+)
+
+// RoleRepository provides access to delegated admin roles.
+type RoleRepository interface {
+	GetByEmail(ctx context.Context, email string) (*models.AdminRoleAssignment, error)
+}
+
+// DepartmentTree resolves the sub-departments of a department.
+type DepartmentTree interface {
+	SubtreeIDs(ctx context.Context, id int64) ([]int64, error)
+}
+
+// RoleAuthorizer extends SimpleAuthorizer with delegated admin roles stored in database.
+// Emails listed in the admin email list are always super-admins.
+type RoleAuthorizer struct {
+	*SimpleAuthorizer
+	roles       RoleRepository
+	departments DepartmentTree
+}
+
+// NewRoleAuthorizer creates a new role-based authorizer.
+func NewRoleAuthorizer(adminEmails []string, configProvider ConfigProvider, roles RoleRepository) *RoleAuthorizer {
+	return &RoleAuthorizer{
+		SimpleAuthorizer: NewSimpleAuthorizer(adminEmails, configProvider),
+		roles:            roles,
+	}
+}
+
+// SetDepartments enables the department scope of department-admins.
+func (a *RoleAuthorizer) SetDepartments(departments DepartmentTree) {
+	a.departments = departments
+}
+
+// Role returns the admin role held by the user, or an empty role if none.
+func (a *RoleAuthorizer) Role(ctx context.Context, userEmail string) models.AdminRole {
+	assignment, err := a.assignment(ctx, userEmail)
+	if err != nil {
+		logger.Logger.Error("Failed to get admin role", "email", userEmail, "error", err.Error())
+		return ""
+	}
+	if assignment == nil {
+		return ""
+	}
+	return assignment.Role
+}
+
+// assignment returns the role assignment of the user, super-admin for the admin email list
+func (a *RoleAuthorizer) assignment(ctx context.Context, userEmail string) (*models.AdminRoleAssignment, error) {
+	if a.SimpleAuthorizer.IsAdmin(ctx, userEmail) {
+		return &models.AdminRoleAssignment{Email: userEmail, Role: models.AdminRoleSuperAdmin}, nil
+	}
+	if userEmail == "" || a.roles == nil {
+		return nil, nil
+	}
+	return a.roles.GetByEmail(ctx, userEmail)
+}
+
+// IsAdmin implements providers.Authorizer.
+// Any delegated role grants access to the admin area; operations are then checked per permission.
+func (a *RoleAuthorizer) IsAdmin(ctx context.Context, userEmail string) bool {
+	return a.Role(ctx, userEmail).IsValid()
+}
+
+// CanCreateDocument implements providers.Authorizer.
+func (a *RoleAuthorizer) CanCreateDocument(ctx context.Context, userEmail string) bool {
+	cfg := a.configProvider.GetConfig()
+	if !cfg.General.OnlyAdminCanCreate {
+		return true
+	}
+	return a.HasPermission(ctx, userEmail, models.PermissionDocumentsWrite)
+}
+
+// CanManageDocument implements providers.Authorizer.
+// Department-admins manage the documents of their departments through the admin API only:
+// outside of it, they are limited to the documents they created.
+func (a *RoleAuthorizer) CanManageDocument(ctx context.Context, userEmail, docCreatedBy string) bool {
+	role := a.Role(ctx, userEmail)
+	if role.HasPermission(models.PermissionDocumentsWrite) && !role.IsDepartmentScoped() {
+		return true
+	}
+	normalized := strings.ToLower(strings.TrimSpace(userEmail))
+	normalizedCreator := strings.ToLower(strings.TrimSpace(docCreatedBy))
+	return normalized != "" && normalized == normalizedCreator
+}
+
+// HasPermission implements providers.PermissionAuthorizer.
+func (a *RoleAuthorizer) HasPermission(ctx context.Context, userEmail string, permission models.Permission) bool {
+	return a.Role(ctx, userEmail).HasPermission(permission)
+}
+
+// Permissions implements providers.PermissionAuthorizer.
+func (a *RoleAuthorizer) Permissions(ctx context.Context, userEmail string) []models.Permission {
+	return a.Role(ctx, userEmail).Permissions()
+}
+
+// DepartmentScope implements providers.DepartmentAuthorizer.
+// Errors must deny access: a department-admin is never left unscoped.
+func (a *RoleAuthorizer) DepartmentScope(ctx context.Context, userEmail string) ([]int64, error) {
+	assignment, err := a.assignment(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil || !assignment.Role.IsDepartmentScoped() {
+		return nil, nil
+	}
+	if assignment.DepartmentID == nil {
+		return nil, errors.New("department-admin without department")
+	}
+	if a.departments == nil {
+		return nil, errors.New("departments are not enabled")
+	}
+	return a.departments.SubtreeIDs(ctx, *assignment.DepartmentID)
+}
+
+// Compile-time interface checks.
+var (
+	_ providers.Authorizer           = (*RoleAuthorizer)(nil)
+	_ providers.PermissionAuthorizer = (*RoleAuthorizer)(nil)
+	_ providers.DepartmentAuthorizer = (*RoleAuthorizer)(nil)
+)
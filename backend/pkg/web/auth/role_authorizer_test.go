@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
+)
+
+type fakeConfigProvider struct {
+	onlyAdminCanCreate bool
+}
+
+func (f *fakeConfigProvider) GetConfig() *models.MutableConfig {
+	cfg := &models.MutableConfig{}
+	cfg.General.OnlyAdminCanCreate = f.onlyAdminCanCreate
+	return cfg
+}
+
+type fakeRoleRepository struct {
+	roles       map[string]models.AdminRole
+	departments map[string]int64
+	err         error
+}
+
+func (f *fakeRoleRepository) GetByEmail(_ context.Context, email string) (*models.AdminRoleAssignment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	role, ok := f.roles[email]
+	if !ok {
+		return nil, nil
+	}
+	assignment := &models.AdminRoleAssignment{Email: email, Role: role}
+	if id, ok := f.departments[email]; ok {
+		assignment.DepartmentID = &id
+	}
+	return assignment, nil
+}
+
+type fakeDepartmentTree map[int64][]int64
+
+func (f fakeDepartmentTree) SubtreeIDs(_ context.Context, id int64) ([]int64, error) {
+	ids, ok := f[id]
+	if !ok {
+		return nil, models.ErrDepartmentNotFound
+	}
+	return ids, nil
+}
+
+func TestRoleAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRoleRepository{roles: map[string]models.AdminRole{
+		"viewer@example.com":   models.AdminRoleViewer,
+		"operator@example.com": models.AdminRoleReminderOperator,
+		"manager@example.com":  models.AdminRoleDocumentManager,
+	}}
+	a := NewRoleAuthorizer([]string{"Admin@Example.com"}, &fakeConfigProvider{onlyAdminCanCreate: true}, repo)
+	ctx := context.Background()
+
+	t.Run("env admins are super-admins", func(t *testing.T) {
+		assert.Equal(t, models.AdminRoleSuperAdmin, a.Role(ctx, "admin@example.com"))
+		assert.True(t, a.HasPermission(ctx, "admin@example.com", models.PermissionRolesManage))
+	})
+
+	t.Run("delegated roles grant admin access", func(t *testing.T) {
+		assert.True(t, a.IsAdmin(ctx, "viewer@example.com"))
+		assert.False(t, a.IsAdmin(ctx, "user@example.com"))
+	})
+
+	t.Run("permissions follow the role", func(t *testing.T) {
+		assert.True(t, a.HasPermission(ctx, "operator@example.com", models.PermissionRemindersSend))
+		assert.False(t, a.HasPermission(ctx, "operator@example.com", models.PermissionDocumentsWrite))
+		assert.False(t, a.HasPermission(ctx, "viewer@example.com", models.PermissionSignersManage))
+		assert.Empty(t, a.Permissions(ctx, "user@example.com"))
+	})
+
+	t.Run("document creation and management require write permission", func(t *testing.T) {
+		assert.True(t, a.CanCreateDocument(ctx, "manager@example.com"))
+		assert.False(t, a.CanCreateDocument(ctx, "viewer@example.com"))
+		assert.True(t, a.CanManageDocument(ctx, "manager@example.com", "someone@example.com"))
+		assert.False(t, a.CanManageDocument(ctx, "viewer@example.com", "someone@example.com"))
+		assert.True(t, a.CanManageDocument(ctx, "viewer@example.com", "Viewer@example.com"))
+	})
+}
+
+func TestRoleAuthorizer_RepositoryError(t *testing.T) {
+	t.Parallel()
+
+	a := NewRoleAuthorizer([]string{"admin@example.com"}, &fakeConfigProvider{}, &fakeRoleRepository{err: errors.New("db down")})
+	ctx := context.Background()
+
+	assert.False(t, a.IsAdmin(ctx, "viewer@example.com"))
+	assert.True(t, a.IsAdmin(ctx, "admin@example.com"), "env admins must not depend on the database")
+}
+
+func TestRoleAuthorizer_DepartmentScope(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRoleRepository{
+		roles: map[string]models.AdminRole{
+			"manager@example.com": models.AdminRoleDocumentManager,
+			"sales@example.com":   models.AdminRoleDepartmentAdmin,
+			"orphan@example.com":  models.AdminRoleDepartmentAdmin,
+		},
+		departments: map[string]int64{"sales@example.com": 1, "orphan@example.com": 9},
+	}
+	a := NewRoleAuthorizer([]string{"admin@example.com"}, &fakeConfigProvider{}, repo)
+	a.SetDepartments(fakeDepartmentTree{1: {1, 2, 3}})
+	ctx := context.Background()
+
+	scope, err := a.DepartmentScope(ctx, "sales@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, scope)
+
+	for _, email := range []string{"admin@example.com", "manager@example.com", "user@example.com"} {
+		scope, err := a.DepartmentScope(ctx, email)
+		assert.NoError(t, err)
+		assert.Nil(t, scope, email)
+	}
+
+	_, err = a.DepartmentScope(ctx, "orphan@example.com")
+	assert.Error(t, err, "a department-admin without a department must not be left unscoped")
+
+	assert.False(t, a.CanManageDocument(ctx, "sales@example.com", "someone@example.com"))
+	assert.True(t, a.CanManageDocument(ctx, "sales@example.com", "sales@example.com"))
+}
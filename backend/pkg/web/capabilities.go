@@ -27,6 +27,16 @@ type ConfigProvider interface {
 	GetConfig() *models.MutableConfig
 }
 
+// SecretSource provides ACKIFY_* secrets read from an external store, such as Vault.
+// The OAuth client secret, SMTP password and S3 secret key override the stored settings.
+type SecretSource interface {
+	// Secrets returns the secrets by environment variable name.
+	Secrets() map[string]string
+
+	// OnChange registers a function called with the secrets when they change.
+	OnChange(fn func(secrets map[string]string))
+}
+
 // QuotaEnforcer defines the interface for quota management.
 // CE: NoLimitQuotaEnforcer (no limits).
 // SaaS: PlanBasedQuotaEnforcer (limits based on subscription plan).
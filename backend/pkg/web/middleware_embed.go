@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
 	"github.com/btouchard/ackify-ce/backend/pkg/models"
 )
@@ -39,7 +40,7 @@ func EmbedDocumentMiddleware(
 			}
 
 			// Check rate limit
-			ip := getClientIP(r)
+			ip := shared.RemoteIP(r)
 			if !rateLimiter.Allow(ip) {
 				logger.Logger.Warn("Embed rate limit exceeded",
 					"ip", ip,
@@ -137,23 +138,3 @@ func (rl *embedRateLimiter) Allow(ip string) bool {
 
 	return true
 }
-
-func getClientIP(r *http.Request) string {
-	// Try X-Forwarded-For first (for proxies)
-	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-		ips := strings.Split(forwarded, ",")
-		return strings.TrimSpace(ips[0])
-	}
-
-	// Try X-Real-IP
-	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-		return realIP
-	}
-
-	// Fallback to RemoteAddr
-	ip := r.RemoteAddr
-	if idx := strings.LastIndex(ip, ":"); idx != -1 {
-		ip = ip[:idx]
-	}
-	return ip
-}
@@ -7,27 +7,45 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"github.com/btouchard/ackify-ce/backend/pkg/config"
 	"github.com/btouchard/ackify-ce/backend/pkg/providers"
 	"github.com/go-chi/chi/v5"
+	"golang.org/x/crypto/acme/autocert"
 
 	"github.com/btouchard/ackify-ce/backend/internal/application/services"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/acme"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/anchor"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/antivirus"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/auth"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/database"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/directory"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/email"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/federation"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/gitrepo"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/i18n"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/ledger"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/linkresolver"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/signaturehook"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/slack"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/tenant"
+	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/timestamp"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/webhook"
 	"github.com/btouchard/ackify-ce/backend/internal/infrastructure/workers"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/api"
+	"github.com/btouchard/ackify-ce/backend/internal/presentation/api/shared"
 	"github.com/btouchard/ackify-ce/backend/internal/presentation/handlers"
 	"github.com/btouchard/ackify-ce/backend/pkg/crypto"
 	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+	"github.com/btouchard/ackify-ce/backend/pkg/models"
 	"github.com/btouchard/ackify-ce/backend/pkg/storage"
 	webauth "github.com/btouchard/ackify-ce/backend/pkg/web/auth"
 
@@ -35,15 +53,48 @@ import (
 )
 
 type Server struct {
-	httpServer      *http.Server
-	db              *sql.DB
-	router          *chi.Mux
-	emailSender     email.Sender
-	emailWorker     *email.Worker
-	webhookWorker   *webhook.Worker
-	sessionWorker   *auth.SessionWorker
-	magicLinkWorker *workers.MagicLinkCleanupWorker
-	baseURL         string
+	httpServer       *http.Server
+	db               *sql.DB
+	router           *chi.Mux
+	emailSender      email.Sender
+	emailWorker      *email.Worker
+	webhookWorker    *webhook.Worker
+	sessionWorker    *auth.SessionWorker
+	magicLinkWorker  *workers.MagicLinkCleanupWorker
+	campaignWorker   *workers.CampaignSchedulerWorker
+	retentionWorker  *workers.RetentionWorker
+	completionWorker *workers.CompletionDeadlineWorker
+	securityWorker   *workers.SecurityAlertWorker
+	notifyWorker     *workers.NotificationDeadlineWorker
+	digestWorker     *workers.ReminderDigestWorker
+	merkleWorker     *workers.MerkleWorker
+	ledgerWorker     *workers.LedgerWorker
+	outboxWorker     *workers.OutboxRelayWorker
+	federationWorker *workers.FederationWorker
+	snapshotWorker   *workers.CompletionSnapshotWorker
+	reportWorker     *workers.ComplianceReportWorker
+	directoryWorker  *workers.DirectorySyncWorker
+	gitImportWorker  *workers.GitImportWorker
+	linkCheckWorker  *workers.LinkCheckWorker
+	signingKeyWorker *workers.SigningKeyWorker
+	jobRunner        *workers.JobRunner
+	coordinator      *workers.Coordinator
+	signerCache      *database.SignerStatusCache
+	replica          *database.Replica
+	ledger           io.Closer
+	baseURL          string
+
+	// Automatic TLS: certificate manager and plain HTTP listener redirecting to HTTPS
+	tlsManager     *acme.Manager
+	redirectServer *http.Server
+
+	// Graceful shutdown and reload
+	draining       *atomic.Bool
+	drainDelay     time.Duration
+	notifyService  *services.NotificationCenterService
+	configService  *services.ConfigService
+	i18nService    *i18n.I18n
+	tenantProvider providers.TenantProvider
 
 	// Capability providers
 	authProvider  AuthProvider
@@ -54,7 +105,7 @@ type Server struct {
 
 // ServerBuilder allows dependency injection for extensibility.
 // DB and TenantProvider are REQUIRED.
-// AuthProvider and Authorizer have sensible CE defaults (AuthProvider, SimpleAuthorizer).
+// AuthProvider and Authorizer have sensible CE defaults (AuthProvider, RoleAuthorizer).
 // QuotaEnforcer and AuditLogger have sensible CE defaults (NoLimit, LogOnly).
 // All technical services (I18n, Email, MagicLink, Reminder, Config) are created internally.
 type ServerBuilder struct {
@@ -65,6 +116,7 @@ type ServerBuilder struct {
 	// Core infrastructure (required)
 	db             *sql.DB
 	tenantProvider providers.TenantProvider
+	secretSource   SecretSource
 
 	// Capability providers (all have CE defaults)
 	authProvider  AuthProvider
@@ -74,20 +126,85 @@ type ServerBuilder struct {
 
 	// Internal infrastructure (created by Build)
 	signer          *crypto.Ed25519Signer
+	signingKeySvc   *services.SigningKeyService
 	i18nService     *i18n.I18n
 	emailSender     email.Sender
 	emailRenderer   *email.Renderer
+	devMailbox      *email.Mailbox
+	smtpSender      *email.SMTPSender
 	storageProvider storage.Provider
+	urlSigner       *storage.URLSigner
 	sessionService  *auth.SessionService
 
 	// Internal services (created by Build)
-	magicLinkService *services.MagicLinkService
-	signatureService *services.SignatureService
-	documentService  *services.DocumentService
-	adminService     *services.AdminService
-	webhookService   *services.WebhookService
-	reminderService  *services.ReminderAsyncService
-	configService    *services.ConfigService
+	magicLinkService  *services.MagicLinkService
+	signatureService  *services.SignatureService
+	documentService   *services.DocumentService
+	adminService      *services.AdminService
+	webhookService    *services.WebhookService
+	reminderService   *services.ReminderAsyncService
+	accessResendSvc   *services.AccessResendService
+	campaignService   *services.CampaignService
+	retentionService  *services.RetentionService
+	completionService *services.CompletionNotificationService
+	historyService    *services.CompletionHistoryService
+	signerTimelineSvc *services.SignerTimelineService
+	reportService     *services.ComplianceReportService
+	shortLinkService  *services.ShortLinkService
+	previewLinkSvc    *services.PreviewLinkService
+	signerGroupSvc    *services.SignerGroupService
+	gitImportSvc      *services.GitImportService
+	publicFeedSvc     *services.PublicFeedService
+	linkResolverSvc   *services.LinkResolverService
+	notifyService     *services.NotificationCenterService
+	roleService       *services.AdminRoleService
+	configService     *services.ConfigService
+	apiKeyService     *services.APIKeyService
+	integrationSvc    *services.IntegrationService
+	searchService     *services.SearchService
+	signingOrderSvc   *services.SigningOrderService
+	quizService       *services.QuizService
+	brandingService   *services.BrandingService
+	certificateSvc    *services.CertificateService
+	externalSigners   *services.ExternalSignerService
+	emailMatchingSvc  *services.EmailMatchingService
+	identityMergeSvc  *services.IdentityMergeService
+	deliverabilitySvc *services.EmailDeliverabilityService
+	approvalSvc       *services.SignatureApprovalService
+	rateLimitService  *services.RateLimitService
+	securityAlertSvc  *services.SecurityAlertService
+	nonceService      *services.SignatureNonceService
+	idempotencySvc    *services.SignatureIdempotencyService
+	exportService     *services.ExportService
+	departmentSvc     *services.DepartmentService
+	tagSvc            *services.TagService
+	preferenceSvc     *services.UserPreferenceService
+	announcementSvc   *services.AnnouncementService
+	suppressionSvc    *services.EmailSuppressionService
+	consentSvc        *services.ConsentService
+	signatureHookSvc  *services.SignatureHookService
+	signRedirectSvc   *services.SignRedirectService
+	uploadQuarantine  *services.UploadQuarantineService
+	signatureArchives *services.SignatureArchiveService
+	packetSvc         *services.PacketService
+	questionSvc       *services.DocumentQuestionService
+	extensionSvc      *services.DeadlineExtensionService
+	directoryCreds    *services.DirectoryCredentialService
+	sessionWorker     *auth.SessionWorker
+	jobSvc            *services.JobService
+	merkleService     *services.MerkleService
+	ledgerService     *services.SignatureLedgerService
+	ledgerConn        io.Closer
+	eventOutbox       *services.EventOutboxService
+	federationHub     *services.FederationHubService
+	federationClient  *services.FederationClientService
+	cspReports        *services.CSPReportService
+	signerCache       *database.SignerStatusCache
+	replica           *database.Replica
+	coordinator       *workers.Coordinator
+
+	// Set during graceful shutdown, reported by the health endpoint
+	draining *atomic.Bool
 }
 
 func NewServerBuilder(cfg *config.Config, frontend embed.FS, version string) *ServerBuilder {
@@ -95,6 +212,7 @@ func NewServerBuilder(cfg *config.Config, frontend embed.FS, version string) *Se
 		cfg:      cfg,
 		frontend: frontend,
 		version:  version,
+		draining: &atomic.Bool{},
 	}
 }
 
@@ -110,6 +228,12 @@ func (b *ServerBuilder) WithTenantProvider(tp providers.TenantProvider) *ServerB
 	return b
 }
 
+// WithSecretSource injects a source of secrets overriding the stored settings (optional).
+func (b *ServerBuilder) WithSecretSource(source SecretSource) *ServerBuilder {
+	b.secretSource = source
+	return b
+}
+
 // WithAuthProvider injects an authentication provider (REQUIRED).
 func (b *ServerBuilder) WithAuthProvider(provider AuthProvider) *ServerBuilder {
 	b.authProvider = provider
@@ -146,18 +270,51 @@ func (b *ServerBuilder) Build(ctx context.Context) (*Server, error) {
 
 	repos := b.createRepositories()
 
+	// Every email, queued or sent directly, skips the suppressed addresses
+	if b.emailSender != nil {
+		b.emailSender = email.NewSuppressingSender(b.emailSender, repos.emailSuppression)
+	}
+	b.suppressionSvc = services.NewEmailSuppressionService(repos.emailSuppression)
+	// Sends to the SMTP servers are recorded for the deliverability report
+	if b.smtpSender != nil {
+		b.deliverabilitySvc = services.NewEmailDeliverabilityService(repos.emailDelivery)
+		b.smtpSender.SetDeliveryRecorder(b.deliverabilitySvc)
+	}
+
 	// Initialize services that depend on repos
 	if err := b.initializeConfigService(ctx, repos); err != nil {
 		return nil, err
 	}
+	b.initializeRateLimitService(repos)
+	b.preferenceSvc = services.NewUserPreferenceService(repos.userPreference, b.configService, b.cfg.Mail.DefaultLocale)
 	b.initializeMagicLinkService(repos)
 	b.initializeSessionService(repos)
+	b.announcementSvc = services.NewAnnouncementService(repos.announcement)
+	b.roleService = services.NewAdminRoleService(repos.adminRole)
+	b.roleService.SetDepartments(repos.department)
 
 	// Now we can set default providers (they depend on services above)
-	b.setDefaultProviders()
+	b.setDefaultProviders(repos)
 
 	b.initializeCoreServices(repos)
 	b.initializeReminderService(repos)
+	b.initializeCampaignService(repos)
+	b.initializeRetentionService(repos)
+	if err := b.initializeMerkleService(repos); err != nil {
+		return nil, err
+	}
+	if err := b.initializeLedgerService(repos); err != nil {
+		return nil, err
+	}
+	b.initializeFederationServices(repos)
+	if err := b.initializeSignerGroupService(repos); err != nil {
+		return nil, err
+	}
+	b.initializeGitImportService(repos)
+	if err := b.initializeUploadQuarantineService(repos); err != nil {
+		return nil, err
+	}
+	b.initializePublicFeedService(repos)
 
 	if err := b.initializeTelemetry(ctx); err != nil {
 		return nil, err
@@ -168,39 +325,122 @@ func (b *ServerBuilder) Build(ctx context.Context) (*Server, error) {
 		return nil, err
 	}
 
+	b.initializeNotificationCenter(repos)
+	b.initializeSignatureApprovalService(repos)
+	if err := b.initializeLinkResolverService(repos, whPublisher); err != nil {
+		return nil, err
+	}
+
 	emailWorker, err := b.initializeEmailWorker(ctx, repos, whPublisher)
 	if err != nil {
 		return nil, err
 	}
 
+	b.initializeCompletionService(repos, whPublisher)
+	b.initializeSecurityAlertService(repos, whPublisher)
+	b.initializeEventOutbox(repos, whPublisher)
+	b.historyService = services.NewCompletionHistoryService(repos.snapshot, repos.document, b.cfg.App.CompletionHistoryDays)
+	b.signerTimelineSvc = services.NewSignerTimelineService(repos.signerTimeline, repos.document)
+	b.reportService = services.NewComplianceReportService(repos.complianceReport)
+	b.reportService.SetConfig(b.configService)
+	b.shortLinkService = services.NewShortLinkService(repos.shortLink, repos.document, b.cfg.App.BaseURL)
+	b.previewLinkSvc = services.NewPreviewLinkService(repos.previewLink, repos.document, b.cfg.App.BaseURL)
+
+	b.initializeJobCoordinator(ctx, repos)
 	magicLinkWorker := b.initializeMagicLinkCleanupWorker(ctx)
+	campaignWorker := b.initializeCampaignSchedulerWorker(ctx)
+	retentionWorker := b.initializeRetentionWorker(ctx)
+	completionWorker := b.initializeCompletionDeadlineWorker(ctx)
+	securityWorker := b.initializeSecurityAlertWorker(ctx)
+	notifyWorker := b.initializeNotificationDeadlineWorker(ctx)
+	digestWorker := b.initializeReminderDigestWorker(ctx)
+	merkleWorker := b.initializeMerkleWorker(ctx)
+	ledgerWorker := b.initializeLedgerWorker(ctx)
+	outboxWorker := b.initializeOutboxRelayWorker(ctx)
+	federationWorker := b.initializeFederationWorker(ctx)
+	snapshotWorker := b.initializeCompletionSnapshotWorker(ctx)
+	reportWorker := b.initializeComplianceReportWorker(ctx)
+	directoryWorker := b.initializeDirectorySyncWorker(ctx)
+	gitImportWorker := b.initializeGitImportWorker(ctx)
+	linkCheckWorker := b.initializeLinkCheckWorker(ctx)
+	signingKeyWorker := b.initializeSigningKeyWorker(ctx)
+	jobRunner := b.initializeJobRunner(ctx, repos)
 
 	sessionWorker, err := b.initializeSessionWorker(ctx, repos)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := b.signerCache.Start(b.cfg.Database.DSN); err != nil {
+		return nil, fmt.Errorf("failed to start signer status cache: %w", err)
+	}
+	if err := b.initializeReadReplica(ctx); err != nil {
+		return nil, err
+	}
+
+	b.cspReports = services.NewCSPReportService()
 	router := b.buildRouter(repos, whPublisher)
 
+	// CSP sources come from the environment and from the admin settings
+	cspBuilder := handlers.NewCSPBuilder(b.cfg.CSP)
+	cspBuilder.SetConfigProvider(b.configService)
+
 	httpServer := &http.Server{
 		Addr:    b.cfg.Server.ListenAddr,
-		Handler: handlers.RequestLogger(handlers.SecureHeaders(router)),
+		Handler: handlers.RequestLogger(handlers.SecureHeadersWithCSP(cspBuilder)(router)),
+	}
+	if b.notifyService != nil {
+		// Close open notification streams so Shutdown does not wait on hijacked connections
+		httpServer.RegisterOnShutdown(b.notifyService.Shutdown)
+	}
+	tlsManager, redirectServer, err := b.initializeAutoTLS(httpServer)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Server{
-		httpServer:      httpServer,
-		db:              b.db,
-		router:          router,
-		emailSender:     b.emailSender,
-		emailWorker:     emailWorker,
-		webhookWorker:   whWorker,
-		sessionWorker:   sessionWorker,
-		magicLinkWorker: magicLinkWorker,
-		baseURL:         b.cfg.App.BaseURL,
-		authProvider:    b.authProvider,
-		authorizer:      b.authorizer,
-		quotaEnforcer:   b.quotaEnforcer,
-		auditLogger:     b.auditLogger,
+		httpServer:       httpServer,
+		db:               b.db,
+		router:           router,
+		emailSender:      b.emailSender,
+		emailWorker:      emailWorker,
+		webhookWorker:    whWorker,
+		sessionWorker:    sessionWorker,
+		magicLinkWorker:  magicLinkWorker,
+		campaignWorker:   campaignWorker,
+		retentionWorker:  retentionWorker,
+		completionWorker: completionWorker,
+		securityWorker:   securityWorker,
+		notifyWorker:     notifyWorker,
+		digestWorker:     digestWorker,
+		merkleWorker:     merkleWorker,
+		ledgerWorker:     ledgerWorker,
+		outboxWorker:     outboxWorker,
+		federationWorker: federationWorker,
+		snapshotWorker:   snapshotWorker,
+		reportWorker:     reportWorker,
+		directoryWorker:  directoryWorker,
+		gitImportWorker:  gitImportWorker,
+		linkCheckWorker:  linkCheckWorker,
+		signingKeyWorker: signingKeyWorker,
+		jobRunner:        jobRunner,
+		coordinator:      b.coordinator,
+		signerCache:      b.signerCache,
+		replica:          b.replica,
+		ledger:           b.ledgerConn,
+		baseURL:          b.cfg.App.BaseURL,
+		tlsManager:       tlsManager,
+		redirectServer:   redirectServer,
+		draining:         b.draining,
+		drainDelay:       b.cfg.Server.DrainDelay,
+		notifyService:    b.notifyService,
+		configService:    b.configService,
+		i18nService:      b.i18nService,
+		tenantProvider:   b.tenantProvider,
+		authProvider:     b.authProvider,
+		authorizer:       b.authorizer,
+		quotaEnforcer:    b.quotaEnforcer,
+		auditLogger:      b.auditLogger,
 	}, nil
 }
 
@@ -216,17 +456,22 @@ func (b *ServerBuilder) validateProviders() error {
 
 // setDefaultProviders sets default implementations for optional providers.
 // Must be called AFTER initializeConfigService, initializeMagicLinkService, and initializeSessionService.
-func (b *ServerBuilder) setDefaultProviders() {
+func (b *ServerBuilder) setDefaultProviders(repos *repositories) {
 	if b.authProvider == nil {
 		b.authProvider = webauth.NewAuthProvider(webauth.ProviderConfig{
 			ConfigProvider:   b.configService,
 			SessionService:   b.sessionService,
 			MagicLinkService: b.magicLinkService,
 			BaseURL:          b.cfg.App.BaseURL,
+			AdminClaim:       b.cfg.OAuth.AdminClaim,
+			AdminValues:      b.cfg.OAuth.AdminValues,
+			ClaimRoles:       b.roleService,
 		})
 	}
 	if b.authorizer == nil {
-		b.authorizer = webauth.NewSimpleAuthorizer(b.cfg.App.AdminEmails, b.configService)
+		roleAuthorizer := webauth.NewRoleAuthorizer(b.cfg.App.AdminEmails, b.configService, repos.adminRole)
+		roleAuthorizer.SetDepartments(repos.department)
+		b.authorizer = roleAuthorizer
 	}
 	if b.quotaEnforcer == nil {
 		b.quotaEnforcer = NewNoLimitQuotaEnforcer()
@@ -244,6 +489,9 @@ func (b *ServerBuilder) initializeInfrastructure() error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize signer: %w", err)
 	}
+	// The startup check logs an unusable key or an unprotected key file, it does not block startup
+	b.signingKeySvc = services.NewSigningKeyService(b.signer)
+	b.signingKeySvc.Check()
 
 	// I18n
 	b.i18nService, err = i18n.NewI18n(getLocalesDir())
@@ -262,7 +510,13 @@ func (b *ServerBuilder) initializeInfrastructure() error {
 			b.cfg.Mail.DefaultLocale,
 			b.i18nService,
 		)
-		b.emailSender = email.NewSMTPSender(b.cfg.Mail, b.emailRenderer)
+		if b.cfg.Dev.Enabled && b.cfg.Mail.Host == config.DevMailboxHost {
+			b.devMailbox = email.NewMailbox(b.emailRenderer)
+			b.emailSender = b.devMailbox
+		} else {
+			b.smtpSender = email.NewSMTPSender(b.cfg.Mail, b.emailRenderer)
+			b.emailSender = b.smtpSender
+		}
 	}
 
 	// Storage
@@ -274,6 +528,12 @@ func (b *ServerBuilder) initializeInfrastructure() error {
 		b.storageProvider = provider
 		if provider != nil {
 			logger.Logger.Info("Storage provider initialized", "type", provider.Type())
+
+			secret := b.cfg.Storage.SignedURLSecret
+			if len(secret) == 0 {
+				secret = b.cfg.OAuth.CookieSecret
+			}
+			b.urlSigner = storage.NewURLSigner(secret, b.cfg.App.BaseURL, b.cfg.Storage.SignedURLTTL)
 		}
 	}
 
@@ -282,30 +542,124 @@ func (b *ServerBuilder) initializeInfrastructure() error {
 
 // repositories holds all repository instances.
 type repositories struct {
-	signature       *database.SignatureRepository
-	document        *database.DocumentRepository
-	expectedSigner  *database.ExpectedSignerRepository
-	reminder        *database.ReminderRepository
-	emailQueue      *database.EmailQueueRepository
-	webhook         *database.WebhookRepository
-	webhookDelivery *database.WebhookDeliveryRepository
-	oauthSession    *database.OAuthSessionRepository
-	config          *database.ConfigRepository
-	magicLink       services.MagicLinkRepository
+	signature        *database.SignatureRepository
+	document         *database.DocumentRepository
+	expectedSigner   *database.ExpectedSignerRepository
+	reminder         *database.ReminderRepository
+	emailQueue       *database.EmailQueueRepository
+	webhook          *database.WebhookRepository
+	webhookDelivery  *database.WebhookDeliveryRepository
+	campaign         *database.CampaignRepository
+	retention        *database.RetentionRepository
+	completion       *database.CompletionSettingsRepository
+	notification     *database.NotificationRepository
+	adminRole        *database.AdminRoleRepository
+	apiKey           *database.APIKeyRepository
+	search           *database.SearchRepository
+	quiz             *database.QuizRepository
+	externalSigner   *database.ExternalSignerRepository
+	emailMatching    *database.EmailMatchingRepository
+	identityMerge    *database.IdentityMergeRepository
+	approval         *database.SignatureApprovalRepository
+	rateLimit        *database.RateLimitRepository
+	securityEvent    *database.SecurityEventRepository
+	signatureNonce   *database.SignatureNonceRepository
+	idempotencyKey   *database.SignatureIdempotencyRepository
+	merkle           *database.MerkleRepository
+	signatureLedger  *database.SignatureLedgerRepository
+	eventOutbox      *database.EventOutboxRepository
+	federation       *database.FederationRepository
+	snapshot         *database.CompletionSnapshotRepository
+	signerTimeline   *database.SignerTimelineRepository
+	complianceReport *database.ComplianceReportRepository
+	shortLink        *database.ShortLinkRepository
+	documentAlias    *database.DocumentAliasRepository
+	previewLink      *database.PreviewLinkRepository
+	signerGroup      *database.SignerGroupRepository
+	department       *database.DepartmentRepository
+	tag              *database.TagRepository
+	userPreference   *database.UserPreferenceRepository
+	announcement     *database.AnnouncementRepository
+	emailSuppression *database.EmailSuppressionRepository
+	emailDelivery    *database.EmailDeliveryRepository
+	consent          *database.ConsentRepository
+	signatureHook    *database.SignatureHookRepository
+	signRedirect     *database.SignRedirectRepository
+	uploadQuarantine *database.UploadQuarantineRepository
+	packet           *database.PacketRepository
+	question         *database.DocumentQuestionRepository
+	extension        *database.DeadlineExtensionRepository
+	directoryCred    *database.DirectoryCredentialRepository
+	job              *database.JobRepository
+	jobCoordination  *database.JobCoordinationRepository
+	gitSource        *database.GitSourceRepository
+	linkSource       *database.LinkSourceRepository
+	linkSubscription *database.LinkSubscriptionRepository
+	oauthSession     *database.OAuthSessionRepository
+	userSession      *database.UserSessionRepository
+	config           *database.ConfigRepository
+	magicLink        services.MagicLinkRepository
 }
 
 func (b *ServerBuilder) createRepositories() *repositories {
 	return &repositories{
-		signature:       database.NewSignatureRepository(b.db, b.tenantProvider),
-		document:        database.NewDocumentRepository(b.db, b.tenantProvider),
-		expectedSigner:  database.NewExpectedSignerRepository(b.db, b.tenantProvider),
-		reminder:        database.NewReminderRepository(b.db, b.tenantProvider),
-		emailQueue:      database.NewEmailQueueRepository(b.db, b.tenantProvider),
-		webhook:         database.NewWebhookRepository(b.db, b.tenantProvider),
-		webhookDelivery: database.NewWebhookDeliveryRepository(b.db, b.tenantProvider),
-		oauthSession:    database.NewOAuthSessionRepository(b.db, b.tenantProvider),
-		config:          database.NewConfigRepository(b.db, b.tenantProvider),
-		magicLink:       database.NewMagicLinkRepository(b.db),
+		signature:        database.NewSignatureRepository(b.db, b.tenantProvider),
+		document:         database.NewDocumentRepository(b.db, b.tenantProvider),
+		expectedSigner:   database.NewExpectedSignerRepository(b.db, b.tenantProvider),
+		reminder:         database.NewReminderRepository(b.db, b.tenantProvider),
+		emailQueue:       database.NewEmailQueueRepository(b.db, b.tenantProvider),
+		webhook:          database.NewWebhookRepository(b.db, b.tenantProvider),
+		webhookDelivery:  database.NewWebhookDeliveryRepository(b.db, b.tenantProvider),
+		campaign:         database.NewCampaignRepository(b.db, b.tenantProvider),
+		retention:        database.NewRetentionRepository(b.db, b.tenantProvider),
+		completion:       database.NewCompletionSettingsRepository(b.db, b.tenantProvider),
+		notification:     database.NewNotificationRepository(b.db, b.tenantProvider),
+		adminRole:        database.NewAdminRoleRepository(b.db, b.tenantProvider),
+		apiKey:           database.NewAPIKeyRepository(b.db, b.tenantProvider),
+		search:           database.NewSearchRepository(b.db, b.tenantProvider),
+		quiz:             database.NewQuizRepository(b.db, b.tenantProvider),
+		externalSigner:   database.NewExternalSignerRepository(b.db, b.tenantProvider),
+		emailMatching:    database.NewEmailMatchingRepository(b.db, b.tenantProvider),
+		identityMerge:    database.NewIdentityMergeRepository(b.db, b.tenantProvider),
+		approval:         database.NewSignatureApprovalRepository(b.db, b.tenantProvider),
+		rateLimit:        database.NewRateLimitRepository(b.db, b.tenantProvider),
+		securityEvent:    database.NewSecurityEventRepository(b.db, b.tenantProvider),
+		signatureNonce:   database.NewSignatureNonceRepository(b.db, b.tenantProvider),
+		idempotencyKey:   database.NewSignatureIdempotencyRepository(b.db, b.tenantProvider),
+		merkle:           database.NewMerkleRepository(b.db, b.tenantProvider),
+		signatureLedger:  database.NewSignatureLedgerRepository(b.db, b.tenantProvider),
+		eventOutbox:      database.NewEventOutboxRepository(b.db, b.tenantProvider),
+		federation:       database.NewFederationRepository(b.db, b.tenantProvider),
+		snapshot:         database.NewCompletionSnapshotRepository(b.db, b.tenantProvider),
+		signerTimeline:   database.NewSignerTimelineRepository(b.db),
+		complianceReport: database.NewComplianceReportRepository(b.db, b.tenantProvider),
+		shortLink:        database.NewShortLinkRepository(b.db, b.tenantProvider),
+		documentAlias:    database.NewDocumentAliasRepository(b.db, b.tenantProvider),
+		previewLink:      database.NewPreviewLinkRepository(b.db, b.tenantProvider),
+		signerGroup:      database.NewSignerGroupRepository(b.db, b.tenantProvider),
+		department:       database.NewDepartmentRepository(b.db, b.tenantProvider),
+		tag:              database.NewTagRepository(b.db, b.tenantProvider),
+		userPreference:   database.NewUserPreferenceRepository(b.db, b.tenantProvider),
+		announcement:     database.NewAnnouncementRepository(b.db, b.tenantProvider),
+		emailSuppression: database.NewEmailSuppressionRepository(b.db, b.tenantProvider),
+		emailDelivery:    database.NewEmailDeliveryRepository(b.db, b.tenantProvider),
+		consent:          database.NewConsentRepository(b.db, b.tenantProvider),
+		signatureHook:    database.NewSignatureHookRepository(b.db, b.tenantProvider),
+		signRedirect:     database.NewSignRedirectRepository(b.db, b.tenantProvider),
+		uploadQuarantine: database.NewUploadQuarantineRepository(b.db, b.tenantProvider),
+		packet:           database.NewPacketRepository(b.db, b.tenantProvider),
+		question:         database.NewDocumentQuestionRepository(b.db, b.tenantProvider),
+		extension:        database.NewDeadlineExtensionRepository(b.db, b.tenantProvider),
+		directoryCred:    database.NewDirectoryCredentialRepository(b.db, b.tenantProvider),
+		job:              database.NewJobRepository(b.db, b.tenantProvider),
+		jobCoordination:  database.NewJobCoordinationRepository(b.db),
+		gitSource:        database.NewGitSourceRepository(b.db, b.tenantProvider),
+		linkSource:       database.NewLinkSourceRepository(b.db, b.tenantProvider),
+		linkSubscription: database.NewLinkSubscriptionRepository(b.db, b.tenantProvider),
+		oauthSession:     database.NewOAuthSessionRepository(b.db, b.tenantProvider),
+		userSession:      database.NewUserSessionRepository(b.db, b.tenantProvider),
+		config:           database.NewConfigRepository(b.db, b.tenantProvider),
+		magicLink:        database.NewMagicLinkRepository(b.db),
 	}
 }
 
@@ -361,6 +715,10 @@ func (b *ServerBuilder) initializeEmailWorker(ctx context.Context, repos *reposi
 	if whPublisher != nil {
 		emailWorker.SetPublisher(whPublisher)
 	}
+	if b.notifyService != nil {
+		emailWorker.SetBounceNotifier(b.notifyService)
+	}
+	emailWorker.SetBounceRecorder(b.suppressionSvc)
 
 	if err := emailWorker.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start email worker: %w", err)
@@ -372,14 +730,65 @@ func (b *ServerBuilder) initializeEmailWorker(ctx context.Context, repos *reposi
 func (b *ServerBuilder) initializeCoreServices(repos *repositories) {
 	b.signatureService = services.NewSignatureService(repos.signature, repos.document, b.signer)
 	b.signatureService.SetChecksumConfig(&b.cfg.Checksum)
+	b.signatureService.SetSigningOrder(repos.expectedSigner)
+	b.quizService = services.NewQuizService(repos.quiz, repos.document)
+	b.signatureService.SetQuizGrader(b.quizService)
+	b.signatureService.SetRateLimiter(b.rateLimitService)
+	b.consentSvc = services.NewConsentService(repos.consent, repos.document)
+	b.signatureService.SetConsentResolver(b.consentSvc)
+	b.signatureService.SetPrivacyConfig(b.configService)
+	b.signatureHookSvc = services.NewSignatureHookService(repos.signatureHook, repos.document, signaturehook.NewClient(nil))
+	b.signatureService.SetValidationHook(b.signatureHookSvc)
+	b.signRedirectSvc = services.NewSignRedirectService(repos.signRedirect, repos.document, b.cfg.App.BaseURL, b.cfg.App.SignRedirectOrigins)
+	b.packetSvc = services.NewPacketService(repos.packet, repos.document)
+	b.questionSvc = services.NewDocumentQuestionService(repos.question, repos.document, b.authorizer)
+	b.extensionSvc = services.NewDeadlineExtensionService(repos.extension, repos.document, repos.completion, repos.expectedSigner,
+		b.authorizer, newServiceAuditRecorder(b.auditLogger, b.tenantProvider))
+	b.exportService = services.NewExportService(repos.document, repos.signature, repos.reminder, b.signer)
+	if b.cfg.Export.TSAURL != "" {
+		b.exportService.SetTimestamper(timestamp.NewClient(b.cfg.Export.TSAURL, nil))
+	}
+	b.departmentSvc = services.NewDepartmentService(repos.department, repos.document, repos.expectedSigner)
+	b.tagSvc = services.NewTagService(repos.tag, repos.document)
+	b.brandingService = services.NewBrandingService(b.configService, b.storageProvider, b.cfg.App.BaseURL)
+	// Signature certificates are kept in object storage; without storage, they are disabled
+	if b.storageProvider != nil {
+		b.certificateSvc = services.NewCertificateService(repos.signature, b.storageProvider, b.cfg.App.BaseURL)
+		b.certificateSvc.SetConfig(b.configService)
+	}
+	if b.emailRenderer != nil {
+		b.emailRenderer.SetBranding(b.brandingService.GetBranding)
+	}
+	if b.emailSender != nil {
+		b.externalSigners = services.NewExternalSignerService(repos.externalSigner, repos.document, b.emailSender, b.i18nService,
+			b.cfg.Auth.ExternalSignersAllowedDomains, b.cfg.Auth.ExternalSignersDeniedDomains)
+	}
 	b.documentService = services.NewDocumentService(repos.document, repos.expectedSigner, &b.cfg.Checksum)
-	b.adminService = services.NewAdminService(repos.document, repos.expectedSigner)
+	b.jobSvc = services.NewJobService(repos.job)
+	b.documentService.SetChecksumRetries(b.jobSvc, repos.document)
+	// Signature archives of all documents are kept in object storage until they expire
+	if b.storageProvider != nil {
+		b.signatureArchives = services.NewSignatureArchiveService(repos.document, repos.signature, b.exportService,
+			b.storageProvider, b.urlSigner, b.jobSvc, b.cfg.Export.ArchiveTTL)
+	}
+	b.documentService.SetIDGeneration(b.cfg.DocIDs)
+	b.documentService.SetAliases(repos.documentAlias)
+	// Only admin pages read through the cache: signing order, reminders and completion checks need fresh data
+	b.signerCache = database.NewSignerStatusCache(repos.expectedSigner, b.cfg.Database.SignerStatusCacheTTL)
+	b.adminService = services.NewAdminService(repos.document, b.signerCache)
 	b.webhookService = services.NewWebhookService(repos.webhook, repos.webhookDelivery)
+	b.apiKeyService = services.NewAPIKeyService(repos.apiKey)
+	b.searchService = services.NewSearchService(repos.search)
+	b.emailMatchingSvc = services.NewEmailMatchingService(repos.emailMatching)
+	b.identityMergeSvc = services.NewIdentityMergeService(repos.identityMerge, b.emailMatchingSvc,
+		newServiceAuditRecorder(b.auditLogger, b.tenantProvider))
+	b.integrationSvc = services.NewIntegrationService(b.documentService, b.adminService, b.webhookService, b.cfg.App.BaseURL)
 }
 
 func (b *ServerBuilder) initializeConfigService(ctx context.Context, repos *repositories) error {
 	encryptionKey := b.cfg.OAuth.CookieSecret
 	b.configService = services.NewConfigService(repos.config, b.cfg, encryptionKey)
+	b.configService.SetPreviousKeys(b.cfg.OAuth.PreviousCookieSecrets)
 
 	// Initialize config from DB or ENV
 	err := tenant.WithTenantContextFromProvider(ctx, b.db, b.tenantProvider, func(txCtx context.Context) error {
@@ -388,9 +797,33 @@ func (b *ServerBuilder) initializeConfigService(ctx context.Context, repos *repo
 	if err != nil {
 		logger.Logger.Warn("Failed to initialize config service, using ENV config", "error", err)
 	}
+
+	if b.secretSource != nil {
+		applySecrets := func(secrets map[string]string) {
+			b.configService.SetSecretOverrides(models.ConfigSecrets{
+				OIDCClientSecret: secrets["ACKIFY_OAUTH_CLIENT_SECRET"],
+				SMTPPassword:     secrets["ACKIFY_MAIL_PASSWORD"],
+				S3SecretKey:      secrets["ACKIFY_STORAGE_S3_SECRET_KEY"],
+			})
+		}
+		applySecrets(b.secretSource.Secrets())
+		b.secretSource.OnChange(applySecrets)
+	}
 	return nil
 }
 
+// initializeRateLimitService creates the database-backed limits and anti-replay nonces of signature creation.
+func (b *ServerBuilder) initializeRateLimitService(repos *repositories) {
+	b.rateLimitService = services.NewRateLimitService(repos.rateLimit, map[string]services.RateLimitRule{
+		models.RateLimitActionSignature: {
+			PerUser: b.cfg.Auth.SignRateLimitUser,
+			PerIP:   b.cfg.Auth.SignRateLimitIP,
+		},
+	})
+	b.nonceService = services.NewSignatureNonceService(repos.signatureNonce, b.cfg.Auth.SignNonceTTL, b.cfg.Auth.SignNonceRequired)
+	b.idempotencySvc = services.NewSignatureIdempotencyService(repos.idempotencyKey, b.cfg.Auth.SignIdempotencyTTL)
+}
+
 // initializeMagicLinkService creates the magic link service.
 func (b *ServerBuilder) initializeMagicLinkService(repos *repositories) {
 	b.magicLinkService = services.NewMagicLinkService(services.MagicLinkServiceConfig{
@@ -401,7 +834,9 @@ func (b *ServerBuilder) initializeMagicLinkService(repos *repositories) {
 		AppName:           b.cfg.App.Organisation,
 		RateLimitPerEmail: b.cfg.Auth.MagicLinkRateLimitEmail,
 		RateLimitPerIP:    b.cfg.Auth.MagicLinkRateLimitIP,
+		Violations:        b.rateLimitService,
 	})
+	b.magicLinkService.SetLocaleResolver(b.preferenceSvc)
 }
 
 // initializeSessionService creates the session service for auth.
@@ -410,16 +845,113 @@ func (b *ServerBuilder) initializeSessionService(repos *repositories) {
 		CookieSecret:  b.cfg.OAuth.CookieSecret,
 		SecureCookies: b.cfg.App.SecureCookies,
 		SessionRepo:   repos.oauthSession,
+		UserSessions:  repos.userSession,
+		IdleTimeout:   b.cfg.Auth.SessionIdleTimeout,
+		MaxLifetime:   b.cfg.Auth.SessionMaxLifetime,
+		ExpiryWarning: b.cfg.Auth.SessionExpiryWarning,
 	})
 }
 
+// initializeJobCoordinator starts the election of the replica running the scheduled jobs.
+func (b *ServerBuilder) initializeJobCoordinator(ctx context.Context, repos *repositories) {
+	if !b.cfg.Server.LeaderElection {
+		return
+	}
+	b.coordinator = workers.NewCoordinator(repos.jobCoordination, b.instanceID(), b.cfg.Server.LeaseTTL)
+	go b.coordinator.Start(ctx)
+}
+
+// instanceID identifies this replica in the scheduler lease and the locks of queued jobs.
+func (b *ServerBuilder) instanceID() string {
+	if b.cfg.Server.InstanceID != "" {
+		return b.cfg.Server.InstanceID
+	}
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// initializeJobRunner starts the runner of the shared job queue and registers the handlers of each job type.
+// Every replica runs one: queued jobs are spread among them.
+func (b *ServerBuilder) initializeJobRunner(ctx context.Context, repos *repositories) *workers.JobRunner {
+	jobRunner := workers.NewJobRunner(repos.job, b.instanceID(), 2*time.Second, b.db, b.tenantProvider)
+	jobRunner.Register(services.ChecksumJobType, workers.TypedJob(b.documentService.RunChecksumJob), workers.JobOptions{
+		Concurrency: 2,
+		Timeout:     time.Minute,
+		BaseDelay:   time.Minute,
+		MaxDelay:    15 * time.Minute,
+	})
+	if b.signatureArchives != nil {
+		jobRunner.Register(services.SignatureArchiveJobType, workers.ReportingJob(b.signatureArchives.RunArchiveJob), workers.JobOptions{
+			Timeout:   30 * time.Minute,
+			BaseDelay: 5 * time.Minute,
+		})
+		jobRunner.Register(services.SignatureArchiveCleanupJobType, workers.TypedJob(b.signatureArchives.RunCleanupJob), workers.JobOptions{})
+	}
+	go jobRunner.Start(ctx)
+	return jobRunner
+}
+
 // initializeMagicLinkCleanupWorker starts the cleanup worker for expired magic link tokens.
 func (b *ServerBuilder) initializeMagicLinkCleanupWorker(ctx context.Context) *workers.MagicLinkCleanupWorker {
 	magicLinkWorker := workers.NewMagicLinkCleanupWorker(b.magicLinkService, 1*time.Hour, b.db, b.tenantProvider)
+	magicLinkWorker.SetRateLimitCleaner(b.rateLimitService)
+	magicLinkWorker.SetNonceCleaner(b.nonceService)
+	magicLinkWorker.SetIdempotencyKeyCleaner(b.idempotencySvc)
+	magicLinkWorker.SetSecurityEventCleaner(b.securityAlertSvc)
+	if b.deliverabilitySvc != nil {
+		magicLinkWorker.SetEmailDeliveryCleaner(b.deliverabilitySvc)
+	}
+	magicLinkWorker.SetCoordinator(b.coordinator)
 	go magicLinkWorker.Start(ctx)
 	return magicLinkWorker
 }
 
+// initializeReadReplica connects to the optional read replica and checks its lag periodically.
+func (b *ServerBuilder) initializeReadReplica(ctx context.Context) error {
+	if b.cfg.Database.ReplicaDSN == "" {
+		return nil
+	}
+	replica, err := database.OpenReplica(ctx, b.cfg.Database.ReplicaDSN, b.cfg.Database.ReplicaMaxLag)
+	if err != nil {
+		return err
+	}
+	b.replica = replica
+	go replica.Start(ctx, 15*time.Second)
+	return nil
+}
+
+// initializeAutoTLS serves HTTPS with certificates obtained from the ACME CA when
+// ACKIFY_TLS_AUTO is set. The certificates are stored in the database unless a cache directory
+// is configured; stored certificates are served right away.
+func (b *ServerBuilder) initializeAutoTLS(httpServer *http.Server) (*acme.Manager, *http.Server, error) {
+	if !b.cfg.TLS.Auto {
+		return nil, nil, nil
+	}
+
+	cache := acme.NewDatabaseCache(database.NewTLSCertificateRepository(b.db))
+	if b.cfg.TLS.CacheDir != "" {
+		cache = autocert.DirCache(b.cfg.TLS.CacheDir)
+	}
+	manager := acme.NewManager(cache, b.cfg.TLS)
+
+	httpServer.Addr = b.cfg.TLS.Addr
+	httpServer.TLSConfig = manager.TLSConfig()
+
+	var redirectServer *http.Server
+	if b.cfg.TLS.HTTPAddr != "" {
+		redirectServer = &http.Server{
+			Addr:              b.cfg.TLS.HTTPAddr,
+			Handler:           manager.HTTPHandler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+	logger.Logger.Info("Automatic TLS enabled",
+		"domains", b.cfg.TLS.Domains,
+		"challenge", b.cfg.TLS.Challenge,
+		"directory", b.cfg.TLS.DirectoryURL)
+	return manager, redirectServer, nil
+}
+
 func (b *ServerBuilder) initializeReminderService(repos *repositories) {
 	b.reminderService = services.NewReminderAsyncService(
 		repos.expectedSigner,
@@ -429,6 +961,438 @@ func (b *ServerBuilder) initializeReminderService(repos *repositories) {
 		b.i18nService,
 		b.cfg.App.BaseURL,
 	)
+	b.reminderService.SetConfigStore(b.configService)
+	b.reminderService.SetLocaleResolver(b.preferenceSvc)
+	b.reminderService.SetTimezoneResolver(b.preferenceSvc)
+	b.reminderService.SetReminderPolicies(b.tagSvc)
+	b.reminderService.SetDeadlineExtensions(b.extensionSvc)
+	b.signingOrderSvc = services.NewSigningOrderService(repos.expectedSigner, repos.document, b.reminderService)
+
+	b.accessResendSvc = services.NewAccessResendService(
+		repos.expectedSigner,
+		repos.reminder,
+		repos.document,
+		repos.emailQueue,
+		b.magicLinkService,
+		b.i18nService,
+		b.cfg.App.BaseURL,
+	)
+	b.accessResendSvc.SetLocaleResolver(b.preferenceSvc)
+	if b.externalSigners != nil {
+		b.accessResendSvc.SetCodeSender(b.externalSigners)
+	}
+
+	b.packetSvc.SetReminders(repos.emailQueue, b.magicLinkService, repos.reminder, b.i18nService, b.cfg.App.BaseURL)
+	b.packetSvc.SetLocaleResolver(b.preferenceSvc)
+
+	b.questionSvc.SetNotifications(repos.emailQueue, b.i18nService, b.cfg.App.BaseURL, b.cfg.Mail.DefaultLocale)
+	b.questionSvc.SetLocaleResolver(b.preferenceSvc)
+}
+
+func (b *ServerBuilder) initializeCampaignService(repos *repositories) {
+	b.campaignService = services.NewCampaignService(
+		repos.campaign,
+		repos.document,
+		repos.expectedSigner,
+		b.reminderService,
+	)
+}
+
+// initializeCampaignSchedulerWorker starts the worker running due recurring campaigns.
+func (b *ServerBuilder) initializeCampaignSchedulerWorker(ctx context.Context) *workers.CampaignSchedulerWorker {
+	campaignWorker := workers.NewCampaignSchedulerWorker(b.campaignService, 15*time.Minute, b.db, b.tenantProvider)
+	campaignWorker.SetCoordinator(b.coordinator)
+	go campaignWorker.Start(ctx)
+	return campaignWorker
+}
+
+// initializeRetentionService creates the retention service.
+// Archives are written to the configured object storage; without storage, archiving is disabled.
+func (b *ServerBuilder) initializeRetentionService(repos *repositories) {
+	b.retentionService = services.NewRetentionService(
+		repos.retention,
+		repos.document,
+		repos.expectedSigner,
+		repos.signature,
+		b.storageProvider,
+		newServiceAuditRecorder(b.auditLogger, b.tenantProvider),
+	)
+}
+
+// initializeRetentionWorker starts the worker archiving completed documents.
+func (b *ServerBuilder) initializeRetentionWorker(ctx context.Context) *workers.RetentionWorker {
+	retentionWorker := workers.NewRetentionWorker(b.retentionService, 1*time.Hour, b.db, b.tenantProvider)
+	retentionWorker.SetCoordinator(b.coordinator)
+	go retentionWorker.Start(ctx)
+	return retentionWorker
+}
+
+// initializeMerkleService creates the service computing Merkle roots over signatures.
+// Roots are published to the configured anchor, if any.
+func (b *ServerBuilder) initializeMerkleService(repos *repositories) error {
+	b.merkleService = services.NewMerkleService(repos.merkle, repos.signature)
+
+	publisher, err := anchor.NewPublisher(b.cfg.Merkle, b.storageProvider)
+	if err != nil {
+		return fmt.Errorf("failed to initialize merkle anchor: %w", err)
+	}
+	if publisher != nil {
+		b.merkleService.SetAnchor(publisher)
+		logger.Logger.Info("Merkle roots anchor configured", "type", b.cfg.Merkle.AnchorType)
+	}
+	return nil
+}
+
+// initializeMerkleWorker starts the worker computing and publishing Merkle roots.
+func (b *ServerBuilder) initializeMerkleWorker(ctx context.Context) *workers.MerkleWorker {
+	if !b.cfg.Merkle.Enabled {
+		return nil
+	}
+
+	merkleWorker := workers.NewMerkleWorker(b.merkleService, b.cfg.Merkle.Interval, b.db, b.tenantProvider)
+	merkleWorker.SetCoordinator(b.coordinator)
+	go merkleWorker.Start(ctx)
+	return merkleWorker
+}
+
+// initializeLedgerService creates the service streaming signature hashes to the external ledger,
+// when one is configured.
+func (b *ServerBuilder) initializeLedgerService(repos *repositories) error {
+	writer, err := ledger.New(b.cfg.Ledger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize signature ledger: %w", err)
+	}
+	if writer == nil {
+		return nil
+	}
+
+	b.ledgerService = services.NewSignatureLedgerService(repos.signatureLedger, repos.signature, writer, b.cfg.Ledger.Type, b.cfg.Ledger.MaxLag)
+	if closer, ok := writer.(io.Closer); ok {
+		b.ledgerConn = closer
+	}
+	logger.Logger.Info("Signature ledger configured", "type", b.cfg.Ledger.Type)
+	return nil
+}
+
+// initializeOutboxRelayWorker starts the worker relaying the events of the transactional outbox.
+func (b *ServerBuilder) initializeOutboxRelayWorker(ctx context.Context) *workers.OutboxRelayWorker {
+	if b.eventOutbox == nil {
+		return nil
+	}
+
+	outboxWorker := workers.NewOutboxRelayWorker(b.eventOutbox, b.cfg.Outbox.Interval, b.db, b.tenantProvider)
+	outboxWorker.SetCoordinator(b.coordinator)
+	go outboxWorker.Start(ctx)
+	return outboxWorker
+}
+
+// initializeLedgerWorker starts the worker streaming signature hashes to the external ledger.
+func (b *ServerBuilder) initializeLedgerWorker(ctx context.Context) *workers.LedgerWorker {
+	if b.ledgerService == nil {
+		return nil
+	}
+
+	ledgerWorker := workers.NewLedgerWorker(b.ledgerService, b.cfg.Ledger.Interval, b.db, b.tenantProvider)
+	ledgerWorker.SetCoordinator(b.coordinator)
+	go ledgerWorker.Start(ctx)
+	return ledgerWorker
+}
+
+// initializeFederationServices creates the federation hub service when this instance accepts
+// pushes, and the client service when it pushes to a hub.
+func (b *ServerBuilder) initializeFederationServices(repos *repositories) {
+	if b.cfg.Federation.HubEnabled {
+		b.federationHub = services.NewFederationHubService(repos.federation)
+		logger.Logger.Info("Federation hub enabled")
+	}
+	if b.cfg.Federation.HubURL != "" {
+		hub := federation.NewClient(b.cfg.Federation.HubURL, nil)
+		b.federationClient = services.NewFederationClientService(repos.federation, repos.signature, repos.document, repos.expectedSigner,
+			hub, b.signer, b.cfg.Federation.HubURL, b.cfg.Federation.InstanceID)
+		logger.Logger.Info("Federation client configured", "hub_url", b.cfg.Federation.HubURL, "instance_id", b.cfg.Federation.InstanceID)
+	}
+}
+
+// initializeFederationWorker starts the worker pushing signature proofs to the federation hub.
+func (b *ServerBuilder) initializeFederationWorker(ctx context.Context) *workers.FederationWorker {
+	if b.federationClient == nil {
+		return nil
+	}
+
+	federationWorker := workers.NewFederationWorker(b.federationClient, b.cfg.Federation.Interval, b.db, b.tenantProvider)
+	federationWorker.SetCoordinator(b.coordinator)
+	go federationWorker.Start(ctx)
+	return federationWorker
+}
+
+// initializeSignerGroupService creates the signer group service with the directory connectors
+// whose credentials are configured. Directories read with delegated access get their tokens from
+// the refresh tokens admins grant, stored encrypted with the settings key.
+func (b *ServerBuilder) initializeSignerGroupService(repos *repositories) error {
+	b.signerGroupSvc = services.NewSignerGroupService(repos.signerGroup, repos.document, repos.expectedSigner)
+
+	var tokens directory.TokenSource
+	if b.cfg.Directory.HasDelegatedAccess() {
+		b.directoryCreds = services.NewDirectoryCredentialService(
+			repos.directoryCred,
+			directory.NewDelegatedOAuth(b.cfg.Directory, b.cfg.App.BaseURL),
+			b.configService,
+			b.cfg.Directory.TokenRefreshSkew,
+		)
+		tokens = b.directoryCreds
+	}
+
+	connectors, err := directory.NewConnectors(b.cfg.Directory, tokens)
+	if err != nil {
+		return fmt.Errorf("failed to initialize directory connectors: %w", err)
+	}
+	for provider, connector := range connectors {
+		b.signerGroupSvc.SetConnector(provider, connector)
+		logger.Logger.Info("Directory connector configured", "provider", provider)
+	}
+	return nil
+}
+
+// initializeDirectorySyncWorker starts the worker refreshing signer groups from their directory,
+// only when a directory connector is configured.
+func (b *ServerBuilder) initializeDirectorySyncWorker(ctx context.Context) *workers.DirectorySyncWorker {
+	if len(b.signerGroupSvc.Providers()) == 0 {
+		return nil
+	}
+
+	directoryWorker := workers.NewDirectorySyncWorker(b.signerGroupSvc, b.cfg.Directory.SyncInterval, b.db, b.tenantProvider)
+	directoryWorker.SetCoordinator(b.coordinator)
+	if b.directoryCreds != nil {
+		directoryWorker.SetCredentials(b.directoryCreds)
+	}
+	go directoryWorker.Start(ctx)
+	return directoryWorker
+}
+
+// initializePublicFeedService creates the public feed of the documents, only when enabled
+func (b *ServerBuilder) initializePublicFeedService(repos *repositories) {
+	if !b.cfg.PublicFeed.Enabled {
+		return
+	}
+
+	b.publicFeedSvc = services.NewPublicFeedService(repos.document, repos.expectedSigner, b.configService, b.cfg.App.BaseURL, b.cfg.PublicFeed.Limit)
+	b.publicFeedSvc.SetHideCompletion(b.cfg.PublicFeed.HideCompletion)
+	logger.Logger.Info("Public document feed enabled", "limit", b.cfg.PublicFeed.Limit, "hide_completion", b.cfg.PublicFeed.HideCompletion)
+}
+
+// initializeGitImportService creates the service importing documents from Git repositories,
+// only when Git import is enabled: imported files are stored in the configured object storage.
+func (b *ServerBuilder) initializeGitImportService(repos *repositories) {
+	if !b.cfg.GitImport.Enabled || b.storageProvider == nil {
+		return
+	}
+
+	checkout := gitrepo.NewCheckout(b.cfg.GitImport.WorkDir)
+	b.gitImportSvc = services.NewGitImportService(repos.gitSource, checkout, b.documentService, repos.document, b.storageProvider, b.cfg.Storage.MaxSizeMB)
+	logger.Logger.Info("Git import enabled", "work_dir", b.cfg.GitImport.WorkDir)
+}
+
+// initializeUploadQuarantineService creates the service holding back uploads that break the tenant
+// upload policy or that clamd flags, only when storage is configured.
+func (b *ServerBuilder) initializeUploadQuarantineService(repos *repositories) error {
+	if b.storageProvider == nil {
+		return nil
+	}
+
+	b.uploadQuarantine = services.NewUploadQuarantineService(
+		repos.uploadQuarantine,
+		b.documentService,
+		b.storageProvider,
+		b.configService,
+		newServiceAuditRecorder(b.auditLogger, b.tenantProvider),
+	)
+	if b.cfg.Storage.ClamAVAddress != "" {
+		scanner, err := antivirus.NewClamdClient(b.cfg.Storage.ClamAVAddress, b.cfg.Storage.ClamAVTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to initialize virus scanner: %w", err)
+		}
+		b.uploadQuarantine.SetScanner(scanner)
+		logger.Logger.Info("Upload virus scanning enabled", "clamd", b.cfg.Storage.ClamAVAddress)
+	}
+	return nil
+}
+
+// initializeGitImportWorker starts the worker syncing Git sources, only when Git import is enabled.
+func (b *ServerBuilder) initializeGitImportWorker(ctx context.Context) *workers.GitImportWorker {
+	if b.gitImportSvc == nil {
+		return nil
+	}
+
+	gitImportWorker := workers.NewGitImportWorker(b.gitImportSvc, b.cfg.GitImport.PollInterval, b.db, b.tenantProvider)
+	gitImportWorker.SetCoordinator(b.coordinator)
+	go gitImportWorker.Start(ctx)
+	return gitImportWorker
+}
+
+// initializeLinkResolverService creates the service resolving Confluence, SharePoint and Google Drive
+// document links, only when one of them is configured. It is created after the notification center and
+// the webhook system so page changes can be reported.
+func (b *ServerBuilder) initializeLinkResolverService(repos *repositories, whPublisher *services.WebhookPublisher) error {
+	resolvers, err := linkresolver.NewResolvers(b.cfg.LinkResolvers)
+	if err != nil {
+		return fmt.Errorf("failed to initialize link resolvers: %w", err)
+	}
+	if len(resolvers) == 0 {
+		return nil
+	}
+
+	b.linkResolverSvc = services.NewLinkResolverService(repos.linkSource, repos.document, b.notifyService, whPublisher)
+	for provider, resolver := range resolvers {
+		b.linkResolverSvc.SetResolver(provider, resolver)
+		logger.Logger.Info("Link resolver configured", "provider", provider)
+	}
+	b.linkResolverSvc.SetSignatureReader(repos.signature)
+	if b.cfg.LinkResolvers.PushNotifications {
+		b.linkResolverSvc.EnablePushNotifications(repos.linkSubscription, b.cfg.App.BaseURL+"/api/v1/link-notifications")
+		logger.Logger.Info("Link change notifications enabled")
+	}
+	b.documentService.SetLinkTracker(b.linkResolverSvc)
+	return nil
+}
+
+// initializeLinkCheckWorker starts the worker watching linked pages for changes, only when a link resolver is configured.
+func (b *ServerBuilder) initializeLinkCheckWorker(ctx context.Context) *workers.LinkCheckWorker {
+	if b.linkResolverSvc == nil {
+		return nil
+	}
+
+	linkCheckWorker := workers.NewLinkCheckWorker(b.linkResolverSvc, b.cfg.LinkResolvers.CheckInterval, b.db, b.tenantProvider)
+	linkCheckWorker.SetCoordinator(b.coordinator)
+	go linkCheckWorker.Start(ctx)
+	return linkCheckWorker
+}
+
+// initializeCompletionService creates the service notifying document creators of signing milestones.
+// It is created after the webhook system so threshold and deadline events can be published.
+func (b *ServerBuilder) initializeCompletionService(repos *repositories, whPublisher *services.WebhookPublisher) {
+	b.completionService = services.NewCompletionNotificationService(
+		repos.completion,
+		repos.document,
+		repos.expectedSigner,
+		repos.emailQueue,
+		whPublisher,
+		slack.NewClient(nil),
+		b.i18nService,
+		b.cfg.App.BaseURL,
+		b.cfg.Mail.DefaultLocale,
+	)
+	b.completionService.SetDeadlineExtensions(b.extensionSvc)
+}
+
+// initializeEventOutbox creates the transactional outbox of signature events when enabled. Its relay
+// delivers them to the webhooks and to the completion and inbox notifications.
+func (b *ServerBuilder) initializeEventOutbox(repos *repositories, whPublisher *services.WebhookPublisher) {
+	if !b.cfg.Outbox.Enabled {
+		return
+	}
+	b.eventOutbox = services.NewEventOutboxService(repos.eventOutbox, b.cfg.Outbox.MaxLag)
+	b.eventOutbox.Subscribe(whPublisher)
+	b.eventOutbox.Subscribe(services.NewSignatureEventNotifier(b.completionService, b.notifyService))
+}
+
+// initializeSecurityAlertService creates the service tracking failed authentications.
+// Its events are alerted by email and Slack when configured, and by webhook.
+func (b *ServerBuilder) initializeSecurityAlertService(repos *repositories, whPublisher *services.WebhookPublisher) {
+	b.securityAlertSvc = services.NewSecurityAlertService(repos.securityEvent, b.cfg.Auth.SecurityAlertThreshold, b.cfg.Auth.SecurityAlertWindow)
+	if len(b.cfg.Auth.SecurityAlertEmails) > 0 && b.emailSender != nil {
+		b.securityAlertSvc.SetEmailAlerts(repos.emailQueue, b.cfg.Auth.SecurityAlertEmails, b.i18nService, b.cfg.Mail.DefaultLocale, b.cfg.App.BaseURL)
+	}
+	if b.cfg.Auth.SecurityAlertSlackWebhookURL != "" {
+		b.securityAlertSvc.SetSlackAlerts(slack.NewClient(nil), b.cfg.Auth.SecurityAlertSlackWebhookURL)
+	}
+	if whPublisher != nil {
+		b.securityAlertSvc.SetPublisher(whPublisher)
+	}
+}
+
+// initializeSigningKeyWorker starts the worker checking the signer key periodically, unless disabled.
+func (b *ServerBuilder) initializeSigningKeyWorker(ctx context.Context) *workers.SigningKeyWorker {
+	if b.cfg.SigningKey.CheckInterval == 0 {
+		return nil
+	}
+
+	signingKeyWorker := workers.NewSigningKeyWorker(b.signingKeySvc, b.cfg.SigningKey.CheckInterval)
+	go signingKeyWorker.Start(ctx)
+	return signingKeyWorker
+}
+
+// initializeSecurityAlertWorker starts the worker sending security alerts, only when they are enabled.
+func (b *ServerBuilder) initializeSecurityAlertWorker(ctx context.Context) *workers.SecurityAlertWorker {
+	if !b.securityAlertSvc.AlertsEnabled() {
+		return nil
+	}
+
+	securityWorker := workers.NewSecurityAlertWorker(b.securityAlertSvc, time.Minute, b.db, b.tenantProvider)
+	securityWorker.SetCoordinator(b.coordinator)
+	go securityWorker.Start(ctx)
+	return securityWorker
+}
+
+// initializeCompletionDeadlineWorker starts the worker notifying creators when document deadlines are reached.
+func (b *ServerBuilder) initializeCompletionDeadlineWorker(ctx context.Context) *workers.CompletionDeadlineWorker {
+	completionWorker := workers.NewCompletionDeadlineWorker(b.completionService, 15*time.Minute, b.db, b.tenantProvider)
+	completionWorker.SetCoordinator(b.coordinator)
+	go completionWorker.Start(ctx)
+	return completionWorker
+}
+
+// initializeCompletionSnapshotWorker starts the worker recording the daily completion of documents.
+func (b *ServerBuilder) initializeCompletionSnapshotWorker(ctx context.Context) *workers.CompletionSnapshotWorker {
+	if b.cfg.App.CompletionHistoryDays == 0 {
+		return nil
+	}
+
+	snapshotWorker := workers.NewCompletionSnapshotWorker(b.historyService, time.Hour, b.db, b.tenantProvider)
+	snapshotWorker.SetCoordinator(b.coordinator)
+	go snapshotWorker.Start(ctx)
+	return snapshotWorker
+}
+
+// initializeComplianceReportWorker starts the worker generating the requested compliance reports.
+func (b *ServerBuilder) initializeComplianceReportWorker(ctx context.Context) *workers.ComplianceReportWorker {
+	reportWorker := workers.NewComplianceReportWorker(b.reportService, 10*time.Second, b.db, b.tenantProvider)
+	go reportWorker.Start(ctx)
+	return reportWorker
+}
+
+// initializeNotificationCenter creates the inbox service notifying document owners in the admin UI.
+// It is created before the email worker so reminder bounces reach the inbox.
+func (b *ServerBuilder) initializeNotificationCenter(repos *repositories) {
+	b.notifyService = services.NewNotificationCenterService(repos.notification, repos.document, repos.completion)
+}
+
+// initializeSignatureApprovalService holds the signatures of unexpected signers for approval when the
+// tenant policy requires it. It is created after the notification center, which asks owners to review them.
+func (b *ServerBuilder) initializeSignatureApprovalService(repos *repositories) {
+	b.approvalSvc = services.NewSignatureApprovalService(repos.approval, b.configService, b.notifyService,
+		newServiceAuditRecorder(b.auditLogger, b.tenantProvider))
+	b.signatureService.SetApprovals(b.approvalSvc)
+}
+
+// initializeNotificationDeadlineWorker starts the worker warning owners of approaching document deadlines.
+func (b *ServerBuilder) initializeNotificationDeadlineWorker(ctx context.Context) *workers.NotificationDeadlineWorker {
+	notifyWorker := workers.NewNotificationDeadlineWorker(b.notifyService, time.Hour, b.db, b.tenantProvider)
+	notifyWorker.SetCoordinator(b.coordinator)
+	go notifyWorker.Start(ctx)
+	return notifyWorker
+}
+
+// initializeReminderDigestWorker starts the scheduled reminder digests when enabled and mail is configured.
+func (b *ServerBuilder) initializeReminderDigestWorker(ctx context.Context) *workers.ReminderDigestWorker {
+	if b.cfg.App.ReminderDigestIntervalDays <= 0 || b.cfg.Mail.Host == "" {
+		return nil
+	}
+
+	digestInterval := time.Duration(b.cfg.App.ReminderDigestIntervalDays) * 24 * time.Hour
+	digestWorker := workers.NewReminderDigestWorker(b.reminderService, digestInterval, b.cfg.Mail.DefaultLocale, time.Hour, b.db, b.tenantProvider)
+	digestWorker.SetCoordinator(b.coordinator)
+	go digestWorker.Start(ctx)
+	return digestWorker
 }
 
 func (b *ServerBuilder) initializeSessionWorker(ctx context.Context, repos *repositories) (*auth.SessionWorker, error) {
@@ -436,17 +1400,42 @@ func (b *ServerBuilder) initializeSessionWorker(ctx context.Context, repos *repo
 		return nil, nil
 	}
 
-	workerConfig := auth.DefaultSessionWorkerConfig()
+	workerConfig := auth.SessionWorkerConfig{
+		CleanupInterval:      b.cfg.Auth.SessionCleanupInterval,
+		CleanupAge:           b.cfg.Auth.SessionRetention,
+		UserSessionRetention: b.cfg.Auth.UserSessionRetention,
+	}
 	sessionWorker := auth.NewSessionWorker(repos.oauthSession, workerConfig, ctx, b.db, b.tenantProvider)
+	sessionWorker.SetUserSessionRepository(repos.userSession)
 	if err := sessionWorker.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start OAuth session worker: %w", err)
 	}
 
+	b.sessionWorker = sessionWorker
 	return sessionWorker, nil
 }
 
 func (b *ServerBuilder) buildRouter(repos *repositories, whPublisher *services.WebhookPublisher) *chi.Mux {
 	router := chi.NewRouter()
+
+	// Resolve the client address once, honoring forwarding headers from trusted proxies only
+	router.Use(shared.RealIP(b.cfg.Network.TrustedProxies))
+
+	router.Use(shared.NewNetworkPolicy(shared.NetworkPolicyConfig{
+		Scope:       "app",
+		ExemptPaths: []string{"/api/v1/health"},
+		Allow:       b.cfg.Network.Allow,
+		Deny:        b.cfg.Network.Deny,
+		OnBlocked:   b.auditBlockedRequest,
+	}).Middleware)
+	router.Use(shared.NewNetworkPolicy(shared.NetworkPolicyConfig{
+		Scope:      "admin",
+		PathPrefix: "/api/v1/admin",
+		Allow:      b.cfg.Network.AdminAllow,
+		Deny:       b.cfg.Network.AdminDeny,
+		OnBlocked:  b.auditBlockedRequest,
+	}).Middleware)
+
 	router.Use(i18n.Middleware(b.i18nService))
 	router.Use(EmbedDocumentMiddleware(b.documentService, whPublisher))
 
@@ -457,17 +1446,62 @@ func (b *ServerBuilder) buildRouter(repos *repositories, whPublisher *services.W
 		TenantProvider: b.tenantProvider,
 
 		// Capability providers (TenantProvider handles OIDC + MagicLink dynamically)
-		AuthProvider:     b.authProvider,
-		Authorizer:       b.authorizer,
-		SignatureService: b.signatureService,
-		DocumentService:  b.documentService,
-		AdminService:     b.adminService,
-		ReminderService:  b.reminderService,
-		WebhookService:   b.webhookService,
-		WebhookPublisher: whPublisher,
-		StorageProvider:  b.storageProvider,
-		StorageMaxSizeMB: b.cfg.Storage.MaxSizeMB,
-		BaseURL:          b.cfg.App.BaseURL,
+		AuthProvider:             b.authProvider,
+		Authorizer:               b.authorizer,
+		SignatureService:         b.signatureService,
+		DocumentService:          b.documentService,
+		AdminService:             b.adminService,
+		ReminderService:          b.reminderService,
+		AccessResendService:      b.accessResendSvc,
+		WebhookService:           b.webhookService,
+		WebhookPublisher:         whPublisher,
+		CampaignService:          b.campaignService,
+		RoleService:              b.roleService,
+		RetentionService:         b.retentionService,
+		CompletionService:        b.completionService,
+		HistoryService:           b.historyService,
+		SignerTimelineService:    b.signerTimelineSvc,
+		ReportService:            b.reportService,
+		ShortLinkService:         b.shortLinkService,
+		DocumentAliasService:     b.documentService,
+		ChecksumInspector:        b.documentService,
+		PreviewLinkService:       b.previewLinkSvc,
+		SignerGroupService:       b.signerGroupSvc,
+		NotificationService:      b.notifyService,
+		APIKeyService:            b.apiKeyService,
+		IntegrationService:       b.integrationSvc,
+		SearchService:            b.searchService,
+		SigningOrderService:      b.signingOrderSvc,
+		QuizService:              b.quizService,
+		BrandingService:          b.brandingService,
+		EmailMatchingService:     b.emailMatchingSvc,
+		IdentityMergeService:     b.identityMergeSvc,
+		SignatureApprovals:       b.approvalSvc,
+		RateLimitService:         b.rateLimitService,
+		SecurityAlertService:     b.securityAlertSvc,
+		NonceService:             b.nonceService,
+		IdempotencyService:       b.idempotencySvc,
+		ExportService:            b.exportService,
+		DepartmentService:        b.departmentSvc,
+		TagService:               b.tagSvc,
+		SignerEmailPolicy:        services.NewSignerEmailPolicyService(b.configService),
+		UserPreferenceService:    b.preferenceSvc,
+		AnnouncementService:      b.announcementSvc,
+		EmailSuppressionService:  b.suppressionSvc,
+		ConsentService:           b.consentSvc,
+		SignatureHookService:     b.signatureHookSvc,
+		SignRedirectService:      b.signRedirectSvc,
+		PacketService:            b.packetSvc,
+		DocumentQuestionService:  b.questionSvc,
+		DeadlineExtensionService: b.extensionSvc,
+		JobService:               b.jobSvc,
+		SigningKeyService:        b.signingKeySvc,
+		MerkleService:            b.merkleService,
+		CSPReportService:         b.cspReports,
+		StorageProvider:          b.storageProvider,
+		StorageMaxSizeMB:         b.cfg.Storage.MaxSizeMB,
+		StorageURLSigner:         b.urlSigner,
+		BaseURL:                  b.cfg.App.BaseURL,
 
 		// Rate limiting
 		AuthRateLimit:     b.cfg.App.AuthRateLimit,
@@ -477,28 +1511,252 @@ func (b *ServerBuilder) buildRouter(repos *repositories, whPublisher *services.W
 
 		// Config service for dynamic settings
 		ConfigService: b.configService,
+
+		SignerStatusCache:      b.signerCache,
+		SignerStatusNotifier:   b.signerCache,
+		StatusSnapshotDebounce: b.cfg.App.StatusSnapshotDebounce,
+
+		Draining: b.draining.Load,
+	}
+	if b.externalSigners != nil {
+		apiConfig.ExternalSignerService = b.externalSigners
+	}
+	if b.sessionService != nil {
+		apiConfig.SessionManager = b.sessionService
+		apiConfig.SessionPeeker = b.sessionService
+	}
+	if b.gitImportSvc != nil {
+		apiConfig.GitImportService = b.gitImportSvc
+	}
+	if b.uploadQuarantine != nil {
+		apiConfig.UploadQuarantine = b.uploadQuarantine
+	}
+	if b.signatureArchives != nil {
+		apiConfig.SignatureArchives = b.signatureArchives
+	}
+	if b.linkResolverSvc != nil {
+		apiConfig.LinkResolverService = b.linkResolverSvc
+	}
+	if b.certificateSvc != nil {
+		apiConfig.CertificateService = b.certificateSvc
+	}
+	if b.replica != nil {
+		apiConfig.ReadReplica = b.replica
+	}
+	if b.ledgerService != nil {
+		apiConfig.LedgerService = b.ledgerService
+	}
+	if b.eventOutbox != nil {
+		apiConfig.EventOutbox = b.eventOutbox
+	}
+	if b.federationHub != nil {
+		apiConfig.FederationHub = b.federationHub
+	}
+	if b.federationClient != nil {
+		apiConfig.FederationClient = b.federationClient
+	}
+	if b.smtpSender != nil {
+		apiConfig.MailServers = b.smtpSender
+		apiConfig.MailDeliverability = b.deliverabilitySvc
+	}
+	if b.directoryCreds != nil {
+		apiConfig.DirectoryCredentialService = b.directoryCreds
+	}
+	if b.publicFeedSvc != nil {
+		apiConfig.PublicFeedService = b.publicFeedSvc
+	}
+	if b.sessionWorker != nil {
+		apiConfig.SessionCleaner = b.sessionWorker
 	}
 	apiRouter := api.NewRouter(apiConfig)
 	router.Mount("/api/v1", apiRouter)
 
-	router.Get("/oembed", handlers.HandleOEmbed(b.cfg.App.BaseURL))
-	router.NotFound(EmbedFolder(b.frontend, "web/dist", b.cfg.App.BaseURL, b.version, repos.signature))
+	router.Get("/oembed", handlers.HandleOEmbed(b.cfg.App.BaseURL, b.brandingService.GetBranding))
+	router.With(shared.NewRLSMiddleware(b.db, b.tenantProvider).Handler).
+		Get("/s/{code}", handlers.HandleShortLink(b.shortLinkService, b.cfg.App.BaseURL))
+
+	// Plain-language status for screen readers, and the accessible embed showing it
+	statusText := handlers.NewStatusTextHandler(b.documentService, b.signatureService, b.i18nService, b.cfg.App.BaseURL)
+	router.With(shared.NewRLSMiddleware(b.db, b.tenantProvider).Handler).Get("/status.txt", statusText.HandleText)
+	router.With(shared.NewRLSMiddleware(b.db, b.tenantProvider).Handler).Get("/embed/status", statusText.HandleEmbed)
+	if b.cfg.App.MinimalUI {
+		b.mountMinimalUI(router)
+	}
+	if b.cfg.Dev.Enabled {
+		b.mountDevRoutes(router)
+	}
+	router.NotFound(handlers.RedirectDocumentAliases(b.documentService, shared.NewRLSMiddleware(b.db, b.tenantProvider).Handler,
+		EmbedFolder(b.frontend, "web/dist", b.cfg.App.BaseURL, b.version, repos.signature)))
 
 	return router
 }
 
+// auditBlockedRequest records requests rejected by a network policy
+func (b *ServerBuilder) auditBlockedRequest(r *http.Request, scope string, clientIP netip.Addr, reason string) {
+	event := AuditEvent{
+		Timestamp:  time.Now().UTC(),
+		Action:     AuditActionNetworkBlocked,
+		Resource:   "network_policy",
+		ResourceID: scope,
+		IPAddress:  clientIP.String(),
+		UserAgent:  r.UserAgent(),
+		Details: map[string]any{
+			"reason": reason,
+			"method": r.Method,
+			"path":   r.URL.Path,
+		},
+	}
+	if err := b.auditLogger.Log(r.Context(), event); err != nil {
+		logger.Logger.Warn("Failed to record audit event", "action", AuditActionNetworkBlocked, "error", err.Error())
+	}
+}
+
 // === Server Methods ===
 
 func (s *Server) Start() error {
-	return s.httpServer.ListenAndServe()
+	if s.tlsManager == nil {
+		return s.httpServer.ListenAndServe()
+	}
+
+	// The listeners are bound before ordering, as the CA connects to them to validate the challenges
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	if s.redirectServer != nil {
+		redirectListener, err := net.Listen("tcp", s.redirectServer.Addr)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		go func() {
+			if err := s.redirectServer.Serve(redirectListener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Logger.Error("HTTP redirect listener failed", "addr", s.redirectServer.Addr, "error", err.Error())
+			}
+		}()
+	}
+	s.tlsManager.Start()
+	return s.httpServer.ServeTLS(listener, "", "")
 }
 
+// Shutdown drains the server: the health endpoint reports 503 for the configured drain delay,
+// in-flight requests are allowed to finish, then background workers stop and release the
+// queue items they had not started so another instance can pick them up.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.draining.Store(true)
+	if s.drainDelay > 0 {
+		logger.Logger.Info("Draining before shutdown", "delay", s.drainDelay)
+		select {
+		case <-time.After(s.drainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	// Stop accepting connections and wait for in-flight requests
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		logger.Logger.Warn("HTTP server did not shut down cleanly", "error", err)
+	}
+	if s.redirectServer != nil {
+		if err := s.redirectServer.Shutdown(ctx); err != nil {
+			logger.Logger.Warn("HTTP redirect listener did not shut down cleanly", "error", err)
+		}
+	}
+
 	// Stop Magic Link cleanup worker if it exists
 	if s.magicLinkWorker != nil {
 		s.magicLinkWorker.Stop()
 	}
 
+	// Stop campaign scheduler worker if it exists
+	if s.campaignWorker != nil {
+		s.campaignWorker.Stop()
+	}
+
+	// Stop retention worker if it exists
+	if s.retentionWorker != nil {
+		s.retentionWorker.Stop()
+	}
+
+	// Stop merkle worker if it exists
+	if s.merkleWorker != nil {
+		s.merkleWorker.Stop()
+	}
+
+	// Stop ledger worker if it exists
+	if s.ledgerWorker != nil {
+		s.ledgerWorker.Stop()
+	}
+
+	// Stop outbox relay worker if it exists
+	if s.outboxWorker != nil {
+		s.outboxWorker.Stop()
+	}
+
+	// Stop federation worker if it exists
+	if s.federationWorker != nil {
+		s.federationWorker.Stop()
+	}
+
+	// Stop directory sync worker if it exists
+	if s.directoryWorker != nil {
+		s.directoryWorker.Stop()
+	}
+
+	// Stop git import worker if it exists
+	if s.gitImportWorker != nil {
+		s.gitImportWorker.Stop()
+	}
+
+	// Stop link check worker if it exists
+	if s.linkCheckWorker != nil {
+		s.linkCheckWorker.Stop()
+	}
+
+	// Stop job runner if it exists
+	if s.jobRunner != nil {
+		s.jobRunner.Stop()
+	}
+
+	// Stop completion snapshot worker if it exists
+	if s.snapshotWorker != nil {
+		s.snapshotWorker.Stop()
+	}
+
+	// Stop compliance report worker if it exists
+	if s.reportWorker != nil {
+		s.reportWorker.Stop()
+	}
+
+	// Stop completion deadline worker if it exists
+	if s.completionWorker != nil {
+		s.completionWorker.Stop()
+	}
+
+	// Stop security alert worker if it exists
+	if s.securityWorker != nil {
+		s.securityWorker.Stop()
+	}
+
+	// Stop signing key worker if it exists
+	if s.signingKeyWorker != nil {
+		s.signingKeyWorker.Stop()
+	}
+
+	// Stop notification deadline worker if it exists
+	if s.notifyWorker != nil {
+		s.notifyWorker.Stop()
+	}
+
+	// Stop reminder digest worker if it exists
+	if s.digestWorker != nil {
+		s.digestWorker.Stop()
+	}
+
+	// Release the scheduler lease once no scheduled job runs anymore
+	if s.coordinator != nil {
+		s.coordinator.Stop()
+	}
+
 	// Stop OAuth session worker if it exists
 	if s.sessionWorker != nil {
 		if err := s.sessionWorker.Stop(); err != nil {
@@ -520,9 +1778,25 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	// Shutdown HTTP server
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		return err
+	// Close the signer status cache listener connection
+	if s.signerCache != nil {
+		if err := s.signerCache.Stop(); err != nil {
+			logger.Logger.Warn("Failed to stop signer status cache", "error", err)
+		}
+	}
+
+	// Close the read replica connection
+	if s.replica != nil {
+		if err := s.replica.Close(); err != nil {
+			logger.Logger.Warn("Failed to close read replica", "error", err)
+		}
+	}
+
+	// Close the ledger database connection
+	if s.ledger != nil {
+		if err := s.ledger.Close(); err != nil {
+			logger.Logger.Warn("Failed to close signature ledger", "error", err)
+		}
 	}
 
 	// Close database connection
@@ -532,6 +1806,28 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// Reload re-reads the settings stored in the database and the translation files
+// without restarting the process. Email templates are read from disk on each render.
+func (s *Server) Reload(ctx context.Context) error {
+	if s.configService != nil {
+		err := tenant.WithTenantContextFromProvider(ctx, s.db, s.tenantProvider, func(txCtx context.Context) error {
+			return s.configService.Reload(txCtx)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reload configuration: %w", err)
+		}
+	}
+
+	if s.i18nService != nil {
+		if err := s.i18nService.Reload(); err != nil {
+			return fmt.Errorf("failed to reload translations: %w", err)
+		}
+	}
+
+	logger.Logger.Info("Server reloaded")
+	return nil
+}
+
 func (s *Server) GetAddr() string {
 	return s.httpServer.Addr
 }
@@ -597,6 +1893,41 @@ func getTemplatesDir() string {
 	return "templates"
 }
 
+// mountDevRoutes serves the fake OAuth provider and the mailbox of the development mode under /dev
+func (b *ServerBuilder) mountDevRoutes(router chi.Router) {
+	logger.Logger.Warn("DEVELOPMENT MODE: anyone can sign in as any user, never expose this instance",
+		"sign_in", b.cfg.App.BaseURL+"/dev/oauth/authorize",
+		"mailbox", b.cfg.App.BaseURL+"/dev/mailbox")
+
+	provider := handlers.NewDevOAuthProvider(b.cfg.OAuth.ClientID, b.cfg.OAuth.ClientSecret, b.cfg.App.BaseURL)
+	if b.devMailbox != nil {
+		router.Mount("/dev", handlers.DevRoutes(provider, b.devMailbox))
+	} else {
+		router.Mount("/dev", handlers.DevRoutes(provider, nil))
+	}
+}
+
+// mountMinimalUI serves the server-rendered admin fallback under /minimal, with the session,
+// permissions and tenant transaction of the API
+func (b *ServerBuilder) mountMinimalUI(router chi.Router) {
+	uiMiddleware := shared.NewMiddleware(b.authProvider, b.cfg.App.BaseURL, b.authorizer)
+	minimalAdmin := handlers.NewMinimalAdmin(b.authProvider, b.authorizer, b.adminService, b.reminderService, uiMiddleware)
+	if b.urlSigner != nil {
+		minimalAdmin.SetURLSigner(b.urlSigner)
+	}
+
+	authLimit := b.cfg.App.AuthRateLimit
+	if authLimit == 0 {
+		authLimit = 5 // Same default as the API auth endpoints
+	}
+	loginRateLimit := shared.NewRateLimit(authLimit, time.Minute).WithName("auth")
+	router.Mount(handlers.MinimalAdminPath, handlers.MinimalAdminRoutes(minimalAdmin, loginRateLimit.Middleware,
+		shared.NewRLSMiddleware(b.db, b.tenantProvider).Handler,
+		uiMiddleware.OptionalAuth,
+		uiMiddleware.ScopeDepartments))
+	logger.Logger.Info("Minimal admin UI enabled", "url", b.cfg.App.BaseURL+handlers.MinimalAdminPath+"/")
+}
+
 func getLocalesDir() string {
 	if envDir := os.Getenv("ACKIFY_LOCALES_DIR"); envDir != "" {
 		return envDir
@@ -26,61 +26,42 @@ type SignatureRepository interface {
 // with SPA fallback support (serves index.html for non-existent routes).
 // Only BASE_URL and VERSION are injected - other config is loaded via /api/v1/config.
 func EmbedFolder(fsEmbed embed.FS, targetPath string, baseURL string, version string, signatureRepo SignatureRepository) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		fsys, err := fs.Sub(fsEmbed, targetPath)
-		if err != nil {
-			logger.Logger.Error("Failed to load embedded files",
-				"target_path", targetPath,
-				"error", err.Error())
+	fsys, err := fs.Sub(fsEmbed, targetPath)
+	if err != nil {
+		logger.Logger.Error("Failed to load embedded files",
+			"target_path", targetPath,
+			"error", err.Error())
+		return func(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Failed to load embedded files", http.StatusInternalServerError)
-			return
 		}
+	}
+	files := newStaticFileHandler(fsys)
 
+	return func(w http.ResponseWriter, r *http.Request) {
 		urlPath := r.URL.Path
+		cleanPath := strings.TrimPrefix(path.Clean(urlPath), "/")
 
-		cleanPath := path.Clean(urlPath)
-		shouldServeIndex := false
-
-		if cleanPath == "/" {
-			cleanPath = "index.html"
-			shouldServeIndex = true
-		} else {
-			cleanPath = cleanPath[1:]
-		}
-
-		file, err := fsys.Open(cleanPath)
-		if err != nil {
-			logger.Logger.Debug("SPA fallback: file not found, serving index.html",
-				"requested_path", urlPath,
-				"clean_path", cleanPath)
-			cleanPath = "index.html"
-			shouldServeIndex = true
-			file, err = fsys.Open(cleanPath)
-			if err != nil {
+		if cleanPath == "" || cleanPath == "index.html" || !files.exists(cleanPath) {
+			if cleanPath != "" && cleanPath != "index.html" {
+				logger.Logger.Debug("SPA fallback: file not found, serving index.html",
+					"requested_path", urlPath,
+					"clean_path", cleanPath)
+			}
+			index := files.load("index.html")
+			if index == nil {
 				http.Error(w, "index.html not found", http.StatusInternalServerError)
 				return
 			}
-		}
-		defer file.Close()
-
-		if shouldServeIndex || strings.HasSuffix(cleanPath, "index.html") {
-			serveIndexTemplate(w, r, file, baseURL, version, signatureRepo)
+			serveIndexTemplate(w, r, index.content, baseURL, version, signatureRepo)
 			return
 		}
 
-		fileServer := http.FileServer(http.FS(fsys))
-		fileServer.ServeHTTP(w, r)
+		files.serve(w, r, cleanPath)
 	}
 }
 
-func serveIndexTemplate(w http.ResponseWriter, r *http.Request, file fs.File, baseURL string, version string, signatureRepo SignatureRepository) {
-	content, err := io.ReadAll(file)
-	if err != nil {
-		logger.Logger.Error("Failed to read index.html", "error", err.Error())
-		http.Error(w, "Failed to read index.html", http.StatusInternalServerError)
-		return
-	}
-
+// serveIndexTemplate renders index.html, which is never cached: it references the current hashed assets
+func serveIndexTemplate(w http.ResponseWriter, r *http.Request, content []byte, baseURL string, version string, signatureRepo SignatureRepository) {
 	processedContent := strings.ReplaceAll(string(content), "__ACKIFY_BASE_URL__", baseURL)
 	processedContent = strings.ReplaceAll(processedContent, "__ACKIFY_VERSION__", version)
 
@@ -88,6 +69,7 @@ func serveIndexTemplate(w http.ResponseWriter, r *http.Request, file fs.File, ba
 	processedContent = strings.ReplaceAll(processedContent, "__META_TAGS__", metaTags)
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", cacheControlRevalidate)
 	w.WriteHeader(http.StatusOK)
 
 	if _, err := io.Copy(w, bytes.NewBufferString(processedContent)); err != nil {
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btouchard/ackify-ce/backend/pkg/logger"
+)
+
+const (
+	// hashedAssetsDir holds the bundler output whose file names embed a content hash
+	hashedAssetsDir = "assets/"
+
+	cacheControlImmutable  = "public, max-age=31536000, immutable"
+	cacheControlRevalidate = "no-cache"
+)
+
+// precompressedEncodings lists the encodings produced at build time, in order of preference
+var precompressedEncodings = []struct {
+	name string // Content-Encoding value
+	ext  string // Suffix of the precompressed file
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// staticFile is an embedded file kept in memory with its validator
+type staticFile struct {
+	content []byte
+	etag    string
+}
+
+// staticFileHandler serves the embedded frontend files.
+// Files precompressed at build time (.br, .gz) are served when the client accepts them,
+// hashed assets are cached for a year and other files are revalidated on each use.
+type staticFileHandler struct {
+	fsys  fs.FS
+	mu    sync.RWMutex
+	files map[string]*staticFile // Embedded files never change: loaded once
+}
+
+func newStaticFileHandler(fsys fs.FS) *staticFileHandler {
+	return &staticFileHandler{fsys: fsys, files: make(map[string]*staticFile)}
+}
+
+// exists reports whether name is a regular file of the embedded filesystem
+func (h *staticFileHandler) exists(name string) bool {
+	info, err := fs.Stat(h.fsys, name)
+	return err == nil && !info.IsDir()
+}
+
+// load returns the content of a file, or nil when it does not exist
+func (h *staticFileHandler) load(name string) *staticFile {
+	h.mu.RLock()
+	file, ok := h.files[name]
+	h.mu.RUnlock()
+	if ok {
+		return file
+	}
+
+	if !h.exists(name) {
+		return nil
+	}
+	content, err := fs.ReadFile(h.fsys, name)
+	if err != nil {
+		logger.Logger.Error("Failed to read embedded file", "name", name, "error", err.Error())
+		return nil
+	}
+	sum := sha256.Sum256(content)
+	file = &staticFile{content: content, etag: `"` + base64.RawURLEncoding.EncodeToString(sum[:12]) + `"`}
+
+	h.mu.Lock()
+	h.files[name] = file
+	h.mu.Unlock()
+	return file
+}
+
+// serve writes the file name (relative to the filesystem root), which must exist
+func (h *staticFileHandler) serve(w http.ResponseWriter, r *http.Request, name string) {
+	header := w.Header()
+	if strings.HasPrefix(name, hashedAssetsDir) {
+		header.Set("Cache-Control", cacheControlImmutable)
+	} else {
+		header.Set("Cache-Control", cacheControlRevalidate)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	file := h.load(name)
+	varies := false
+	for _, enc := range precompressedEncodings {
+		compressed := h.load(name + enc.ext)
+		if compressed == nil {
+			continue
+		}
+		varies = true
+		if acceptsEncoding(r.Header.Get("Accept-Encoding"), enc.name) {
+			header.Set("Content-Encoding", enc.name)
+			file = compressed
+			break
+		}
+	}
+	if varies {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	if file == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	header.Set("Content-Type", contentType)
+	header.Set("ETag", file.etag)
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(file.content))
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header allows the encoding.
+// An explicit q=0 refuses it; "*" accepts any encoding not listed.
+func acceptsEncoding(header, encoding string) bool {
+	wildcard := false
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		accepted := qualityOf(params) > 0
+		switch name {
+		case encoding:
+			return accepted
+		case "*":
+			wildcard = accepted
+		}
+	}
+	return wildcard
+}
+
+// qualityOf parses the q parameter of an Accept-Encoding entry, 1 when absent
+func qualityOf(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.TrimSpace(key) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
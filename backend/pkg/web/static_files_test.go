@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStaticFS() fstest.MapFS {
+	return fstest.MapFS{
+		"index.html":              {Data: []byte("<html>__ACKIFY_VERSION__</html>")},
+		"favicon.ico":             {Data: []byte("icon")},
+		"assets/app-3f9a1c.js":    {Data: []byte("console.log('app')")},
+		"assets/app-3f9a1c.js.br": {Data: []byte("brotli")},
+		"assets/app-3f9a1c.js.gz": {Data: []byte("gzip")},
+		"assets/app-3f9a1c.css":   {Data: []byte("body{}")},
+	}
+}
+
+func serveStatic(h *staticFileHandler, name, acceptEncoding string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/"+name, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.serve(rec, req, name)
+	return rec
+}
+
+func TestStaticFileHandler_Precompressed(t *testing.T) {
+	t.Parallel()
+	h := newStaticFileHandler(testStaticFS())
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		wantEncoding   string
+		wantBody       string
+	}{
+		{"brotli preferred", "gzip, deflate, br", "br", "brotli"},
+		{"gzip only", "gzip", "gzip", "gzip"},
+		{"brotli refused", "br;q=0, gzip", "gzip", "gzip"},
+		{"wildcard", "*", "br", "brotli"},
+		{"identity", "", "", "console.log('app')"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveStatic(h, "assets/app-3f9a1c.js", tt.acceptEncoding)
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantEncoding, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Contains(t, rec.Header().Get("Content-Type"), "javascript")
+		})
+	}
+}
+
+func TestStaticFileHandler_CacheControl(t *testing.T) {
+	t.Parallel()
+	h := newStaticFileHandler(testStaticFS())
+
+	rec := serveStatic(h, "assets/app-3f9a1c.css", "br")
+	assert.Equal(t, cacheControlImmutable, rec.Header().Get("Cache-Control"))
+	assert.Empty(t, rec.Header().Get("Content-Encoding"), "no precompressed variant")
+	assert.Empty(t, rec.Header().Get("Vary"))
+
+	rec = serveStatic(h, "favicon.ico", "")
+	assert.Equal(t, cacheControlRevalidate, rec.Header().Get("Cache-Control"))
+
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	rec = serveStatic(h, "favicon.ico", "", "If-None-Match", etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestAcceptsEncoding(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, acceptsEncoding("gzip, br", "br"))
+	assert.True(t, acceptsEncoding("BR;q=0.5", "br"))
+	assert.False(t, acceptsEncoding("br;q=0", "br"))
+	assert.False(t, acceptsEncoding("*;q=0", "gzip"))
+	assert.False(t, acceptsEncoding("gzip;q=0, *", "gzip"))
+	assert.False(t, acceptsEncoding("", "gzip"))
+}
@@ -86,17 +86,30 @@ type AuditEvent struct {
 
 // AuditAction constants for common audit events.
 const (
-	AuditActionLogin           = "auth.login"
-	AuditActionLogout          = "auth.logout"
-	AuditActionDocumentCreate  = "document.create"
-	AuditActionDocumentUpdate  = "document.update"
-	AuditActionDocumentDelete  = "document.delete"
-	AuditActionSignatureCreate = "signature.create"
-	AuditActionReminderSend    = "reminder.send"
-	AuditActionWebhookCreate   = "webhook.create"
-	AuditActionWebhookUpdate   = "webhook.update"
-	AuditActionWebhookDelete   = "webhook.delete"
-	AuditActionSignerAdd       = "signer.add"
-	AuditActionSignerRemove    = "signer.remove"
-	AuditActionAdminAccess     = "admin.access"
+	AuditActionLogin            = "auth.login"
+	AuditActionLogout           = "auth.logout"
+	AuditActionDocumentCreate   = "document.create"
+	AuditActionDocumentUpdate   = "document.update"
+	AuditActionDocumentDelete   = "document.delete"
+	AuditActionSignatureCreate  = "signature.create"
+	AuditActionReminderSend     = "reminder.send"
+	AuditActionWebhookCreate    = "webhook.create"
+	AuditActionWebhookUpdate    = "webhook.update"
+	AuditActionWebhookDelete    = "webhook.delete"
+	AuditActionSignerAdd        = "signer.add"
+	AuditActionSignerRemove     = "signer.remove"
+	AuditActionAdminAccess      = "admin.access"
+	AuditActionDocumentArchive  = "document.archive"
+	AuditActionDocumentRestore  = "document.restore"
+	AuditActionDocumentPurge    = "document.purge_pii"
+	AuditActionNetworkBlocked   = "network.blocked"
+	AuditActionUploadQuarantine = "upload.quarantine"
+	AuditActionUploadRelease    = "upload.release"
+	AuditActionUploadDiscard    = "upload.discard"
+	AuditActionIdentityMerge    = "identity.merge"
+	AuditActionSignatureApprove = "signature.approve"
+	AuditActionSignatureReject  = "signature.reject"
+	AuditActionExtensionRequest = "deadline_extension.request"
+	AuditActionExtensionApprove = "deadline_extension.approve"
+	AuditActionExtensionDeny    = "deadline_extension.deny"
 )
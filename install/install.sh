@@ -504,8 +504,19 @@ if [ "$UPDATE_MODE" = true ] && is_smtp_configured; then
     MAIL_PASSWORD=$(get_env "ACKIFY_MAIL_PASSWORD")
     MAIL_FROM=$(get_env "ACKIFY_MAIL_FROM")
     MAIL_FROM_NAME=$(get_env "ACKIFY_MAIL_FROM_NAME")
-    MAIL_TLS=$(get_env "ACKIFY_MAIL_TLS")
-    MAIL_STARTTLS=$(get_env "ACKIFY_MAIL_STARTTLS")
+    MAIL_ENCRYPTION=$(get_env "ACKIFY_MAIL_ENCRYPTION")
+    if [ -z "$MAIL_ENCRYPTION" ]; then
+        # Convert the deprecated TLS/STARTTLS flags
+        if [ "$(get_env "ACKIFY_MAIL_TLS")" = "true" ]; then
+            MAIL_ENCRYPTION="implicit"
+        elif [ "$(get_env "ACKIFY_MAIL_TLS")" = "false" ] && [ "$(get_env "ACKIFY_MAIL_STARTTLS")" = "false" ]; then
+            MAIL_ENCRYPTION="none"
+        elif [ "$(get_env "ACKIFY_MAIL_TLS")" = "false" ]; then
+            MAIL_ENCRYPTION="starttls"
+        else
+            MAIL_ENCRYPTION="auto"
+        fi
+    fi
 
     # Check MagicLink status
     if [ "$(get_env "ACKIFY_AUTH_MAGICLINK_ENABLED")" = "true" ]; then
@@ -536,17 +547,8 @@ elif [ "$UPDATE_MODE" = true ]; then
         MAIL_FROM=$(prompt_input "From Email Address" "noreply@${APP_DOMAIN}")
         MAIL_FROM_NAME=$(prompt_input "From Name" "$APP_ORGANISATION")
 
-        # Auto-configure TLS based on port
-        if [ "$MAIL_PORT" = "465" ]; then
-            MAIL_TLS="true"
-            MAIL_STARTTLS="false"
-        elif [ "$MAIL_PORT" = "587" ]; then
-            MAIL_TLS="false"
-            MAIL_STARTTLS="true"
-        else
-            MAIL_TLS="false"
-            MAIL_STARTTLS="true"
-        fi
+        # Encryption is detected from the server (implicit TLS or STARTTLS)
+        MAIL_ENCRYPTION="auto"
 
         print_success "SMTP configuration added"
 
@@ -584,26 +586,18 @@ else
         MAIL_FROM_NAME=$(prompt_input "From Name" "$APP_ORGANISATION")
 
         echo ""
-        # Auto-configure TLS based on port
+        # Configure encryption based on port
         if [ "$MAIL_PORT" = "465" ]; then
-            print_info "Port 465 detected - using TLS (implicit SSL)"
-            MAIL_TLS="true"
-            MAIL_STARTTLS="false"
+            print_info "Port 465 detected - using implicit TLS"
+            MAIL_ENCRYPTION="implicit"
         elif [ "$MAIL_PORT" = "587" ]; then
-            print_info "Port 587 detected - using STARTTLS (explicit TLS)"
-            MAIL_TLS="false"
-            MAIL_STARTTLS="true"
+            print_info "Port 587 detected - using STARTTLS"
+            MAIL_ENCRYPTION="starttls"
         else
-            print_warning "Non-standard port detected, please configure TLS manually"
-            if prompt_yes_no "Use TLS (implicit SSL, typically port 465)?" "n"; then
-                MAIL_TLS="true"
-                MAIL_STARTTLS="false"
-            elif prompt_yes_no "Use STARTTLS (explicit TLS, typically port 587)?" "y"; then
-                MAIL_TLS="false"
-                MAIL_STARTTLS="true"
-            else
-                MAIL_TLS="false"
-                MAIL_STARTTLS="false"
+            print_info "Non-standard port - encryption detected from the server"
+            MAIL_ENCRYPTION="auto"
+            if ! prompt_yes_no "Does the server use TLS (implicit TLS or STARTTLS)?" "y"; then
+                MAIL_ENCRYPTION="none"
             fi
         fi
 
@@ -878,6 +872,12 @@ else
 fi
 echo ""
 
+# Keep the trusted proxies of an existing installation
+TRUSTED_PROXIES="127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7"
+if [ "$UPDATE_MODE" = true ] && has_env "ACKIFY_TRUSTED_PROXIES"; then
+    TRUSTED_PROXIES=$(get_env "ACKIFY_TRUSTED_PROXIES")
+fi
+
 cat > .env <<EOF
 # ==========================================
 # Ackify Community Edition Configuration
@@ -907,6 +907,8 @@ ACKIFY_ED25519_PRIVATE_KEY=${ED25519_KEY}
 # ==========================================
 ACKIFY_LISTEN_ADDR=:8080
 ACKIFY_LOG_LEVEL=info
+# Reverse proxies trusted for the client IP (loopback and private networks by default)
+ACKIFY_TRUSTED_PROXIES=${TRUSTED_PROXIES}
 
 EOF
 
@@ -960,8 +962,7 @@ ACKIFY_MAIL_USERNAME=${MAIL_USERNAME}
 ACKIFY_MAIL_PASSWORD=${MAIL_PASSWORD}
 ACKIFY_MAIL_FROM=${MAIL_FROM}
 ACKIFY_MAIL_FROM_NAME=${MAIL_FROM_NAME}
-ACKIFY_MAIL_TLS=${MAIL_TLS}
-ACKIFY_MAIL_STARTTLS=${MAIL_STARTTLS}
+ACKIFY_MAIL_ENCRYPTION=${MAIL_ENCRYPTION}
 ACKIFY_MAIL_TIMEOUT=10s
 ACKIFY_MAIL_TEMPLATE_DIR=templates
 ACKIFY_MAIL_DEFAULT_LOCALE=en
@@ -0,0 +1,62 @@
+#!/usr/bin/env node
+
+/**
+ * Precompress the built assets with Brotli and gzip
+ * The backend serves dist/<file>.br or dist/<file>.gz when the client accepts it.
+ * index.html is skipped: it is rendered by the backend on each request.
+ */
+
+import { readdirSync, readFileSync, statSync, writeFileSync } from 'fs';
+import { fileURLToPath } from 'url';
+import { dirname, extname, join, relative } from 'path';
+import { brotliCompressSync, constants, gzipSync } from 'zlib';
+
+const __filename = fileURLToPath(import.meta.url);
+const __dirname = dirname(__filename);
+
+const distDir = join(__dirname, '../dist');
+const compressible = new Set(['.js', '.mjs', '.css', '.html', '.svg', '.json', '.txt', '.xml', '.map', '.wasm', '.ico']);
+const minSize = 1024;
+
+function walk(dir) {
+  return readdirSync(dir).flatMap((name) => {
+    const path = join(dir, name);
+    return statSync(path).isDirectory() ? walk(path) : [path];
+  });
+}
+
+let count = 0;
+let before = 0;
+let after = 0;
+
+for (const file of walk(distDir)) {
+  const name = relative(distDir, file);
+  if (name === 'index.html' || !compressible.has(extname(file))) {
+    continue;
+  }
+  const content = readFileSync(file);
+  if (content.length < minSize) {
+    continue;
+  }
+
+  const brotli = brotliCompressSync(content, {
+    params: {
+      [constants.BROTLI_PARAM_QUALITY]: constants.BROTLI_MAX_QUALITY,
+      [constants.BROTLI_PARAM_SIZE_HINT]: content.length,
+    },
+  });
+  const gzip = gzipSync(content, { level: 9 });
+
+  // Only keep variants that are actually smaller
+  if (brotli.length < content.length) {
+    writeFileSync(`${file}.br`, brotli);
+  }
+  if (gzip.length < content.length) {
+    writeFileSync(`${file}.gz`, gzip);
+  }
+  count++;
+  before += content.length;
+  after += Math.min(brotli.length, content.length);
+}
+
+console.log(`Precompressed ${count} files: ${(before / 1024).toFixed(0)} KiB -> ${(after / 1024).toFixed(0)} KiB (brotli)`);